@@ -0,0 +1,56 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+
+	"github.com/MainfluxLabs/mainflux/audit"
+	"github.com/go-kit/kit/endpoint"
+)
+
+func listRecordsEndpoint(svc audit.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listRecordsReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		pm := audit.PageMetadata{
+			Offset:   req.offset,
+			Limit:    req.limit,
+			Actor:    req.actor,
+			EntityID: req.entityID,
+			From:     req.from,
+			To:       req.to,
+		}
+		page, err := svc.ListRecords(ctx, req.token, pm)
+		if err != nil {
+			return nil, err
+		}
+
+		res := recordsPageRes{
+			Total:   page.Total,
+			Offset:  page.Offset,
+			Limit:   page.Limit,
+			Records: []recordRes{},
+		}
+		for _, r := range page.Records {
+			res.Records = append(res.Records, recordRes{
+				ID:           r.ID,
+				Actor:        r.Actor,
+				Service:      r.Service,
+				Operation:    r.Operation,
+				Path:         r.Path,
+				EntityID:     r.EntityID,
+				StatusCode:   r.StatusCode,
+				RequestHash:  r.RequestHash,
+				ResponseHash: r.ResponseHash,
+				CreatedAt:    r.CreatedAt,
+			})
+		}
+
+		return res, nil
+	}
+}
@@ -0,0 +1,55 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !test
+
+package api
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/audit"
+	log "github.com/MainfluxLabs/mainflux/logger"
+)
+
+var _ audit.Service = (*loggingMiddleware)(nil)
+
+type loggingMiddleware struct {
+	logger log.Logger
+	svc    audit.Service
+}
+
+// LoggingMiddleware adds logging facilities to the core service.
+func LoggingMiddleware(svc audit.Service, logger log.Logger) audit.Service {
+	return &loggingMiddleware{logger, svc}
+}
+
+func (lm *loggingMiddleware) SaveRecord(ctx context.Context, r audit.Record) (err error) {
+	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "save_record", "latency", time.Since(begin).String())
+		message := fmt.Sprintf("Method save_record for %s %s took %s to complete", r.Operation, r.Path, time.Since(begin))
+		if err != nil {
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		l.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.SaveRecord(ctx, r)
+}
+
+func (lm *loggingMiddleware) ListRecords(ctx context.Context, token string, pm audit.PageMetadata) (page audit.RecordsPage, err error) {
+	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "list_records", "latency", time.Since(begin).String())
+		message := fmt.Sprintf("Method list_records for token %s took %s to complete", token, time.Since(begin))
+		if err != nil {
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		l.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ListRecords(ctx, token, pm)
+}
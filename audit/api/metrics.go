@@ -0,0 +1,49 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !test
+
+package api
+
+import (
+	"context"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/audit"
+	"github.com/go-kit/kit/metrics"
+)
+
+var _ audit.Service = (*metricsMiddleware)(nil)
+
+type metricsMiddleware struct {
+	counter metrics.Counter
+	latency metrics.Histogram
+	svc     audit.Service
+}
+
+// MetricsMiddleware instruments core service by tracking request count and latency.
+func MetricsMiddleware(svc audit.Service, counter metrics.Counter, latency metrics.Histogram) audit.Service {
+	return &metricsMiddleware{
+		counter: counter,
+		latency: latency,
+		svc:     svc,
+	}
+}
+
+func (ms *metricsMiddleware) SaveRecord(ctx context.Context, r audit.Record) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "save_record").Add(1)
+		ms.latency.With("method", "save_record").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.SaveRecord(ctx, r)
+}
+
+func (ms *metricsMiddleware) ListRecords(ctx context.Context, token string, pm audit.PageMetadata) (audit.RecordsPage, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "list_records").Add(1)
+		ms.latency.With("method", "list_records").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ListRecords(ctx, token, pm)
+}
@@ -0,0 +1,38 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/internal/apiutil"
+)
+
+const maxLimitSize = 100
+
+type listRecordsReq struct {
+	token    string
+	actor    string
+	entityID string
+	from     time.Time
+	to       time.Time
+	offset   uint64
+	limit    uint64
+}
+
+func (req listRecordsReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	if req.limit > maxLimitSize {
+		return apiutil.ErrLimitSize
+	}
+
+	if !req.from.IsZero() && !req.to.IsZero() && req.to.Before(req.from) {
+		return apiutil.ErrInvalidQueryParams
+	}
+
+	return nil
+}
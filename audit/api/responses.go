@@ -0,0 +1,45 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux"
+)
+
+var _ mainflux.Response = (*recordsPageRes)(nil)
+
+type recordRes struct {
+	ID           string    `json:"id"`
+	Actor        string    `json:"actor,omitempty"`
+	Service      string    `json:"service"`
+	Operation    string    `json:"operation"`
+	Path         string    `json:"path"`
+	EntityID     string    `json:"entity_id,omitempty"`
+	StatusCode   int       `json:"status_code"`
+	RequestHash  string    `json:"request_hash"`
+	ResponseHash string    `json:"response_hash"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+type recordsPageRes struct {
+	Total   uint64      `json:"total"`
+	Offset  uint64      `json:"offset"`
+	Limit   uint64      `json:"limit"`
+	Records []recordRes `json:"records"`
+}
+
+func (res recordsPageRes) Code() int {
+	return http.StatusOK
+}
+
+func (res recordsPageRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res recordsPageRes) Empty() bool {
+	return false
+}
@@ -0,0 +1,153 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux"
+	"github.com/MainfluxLabs/mainflux/audit"
+	"github.com/MainfluxLabs/mainflux/internal/apiutil"
+	"github.com/MainfluxLabs/mainflux/logger"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	kitot "github.com/go-kit/kit/tracing/opentracing"
+	kithttp "github.com/go-kit/kit/transport/http"
+	"github.com/go-zoo/bone"
+	opentracing "github.com/opentracing/opentracing-go"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const (
+	contentType = "application/json"
+	offsetKey   = "offset"
+	limitKey    = "limit"
+	actorKey    = "actor"
+	entityKey   = "entity"
+	fromKey     = "from"
+	toKey       = "to"
+	defOffset   = 0
+	defLimit    = 10
+)
+
+// MakeHandler returns a HTTP handler for API endpoints.
+func MakeHandler(svc audit.Service, tracer opentracing.Tracer, logger logger.Logger, checks ...mainflux.HealthCheck) http.Handler {
+	opts := []kithttp.ServerOption{
+		kithttp.ServerErrorEncoder(apiutil.LoggingErrorEncoder(logger, encodeError)),
+	}
+
+	r := bone.New()
+
+	r.Get("/records", kithttp.NewServer(
+		kitot.TraceServer(tracer, "list_records")(listRecordsEndpoint(svc)),
+		decodeListRecords,
+		encodeResponse,
+		opts...,
+	))
+
+	r.GetFunc("/health", mainflux.Health("audit", checks...))
+	r.Handle("/metrics", promhttp.Handler())
+	r.Handle("/log-level", mainflux.LogLevel(logger))
+
+	return apiutil.RequestIDMiddleware(apiutil.LocaleMiddleware(r))
+}
+
+func decodeListRecords(_ context.Context, r *http.Request) (interface{}, error) {
+	offset, err := apiutil.ReadUintQuery(r, offsetKey, defOffset)
+	if err != nil {
+		return nil, err
+	}
+
+	limit, err := apiutil.ReadLimitQuery(r, limitKey, defLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	actor, err := apiutil.ReadStringQuery(r, actorKey, "")
+	if err != nil {
+		return nil, err
+	}
+
+	entityID, err := apiutil.ReadStringQuery(r, entityKey, "")
+	if err != nil {
+		return nil, err
+	}
+
+	from, err := readTimeQuery(r, fromKey)
+	if err != nil {
+		return nil, err
+	}
+
+	to, err := readTimeQuery(r, toKey)
+	if err != nil {
+		return nil, err
+	}
+
+	req := listRecordsReq{
+		token:    apiutil.ExtractBearerToken(r),
+		actor:    actor,
+		entityID: entityID,
+		from:     from,
+		to:       to,
+		offset:   offset,
+		limit:    limit,
+	}
+
+	return req, nil
+}
+
+// readTimeQuery reads the RFC3339 formatted time from the query parameter.
+func readTimeQuery(r *http.Request, key string) (time.Time, error) {
+	val, err := apiutil.ReadStringQuery(r, key, "")
+	if err != nil || val == "" {
+		return time.Time{}, err
+	}
+
+	t, err := time.Parse(time.RFC3339, val)
+	if err != nil {
+		return time.Time{}, errors.Wrap(apiutil.ErrInvalidQueryParams, err)
+	}
+
+	return t, nil
+}
+
+func encodeResponse(_ context.Context, w http.ResponseWriter, response interface{}) error {
+	if ar, ok := response.(mainflux.Response); ok {
+		for k, v := range ar.Headers() {
+			w.Header().Set(k, v)
+		}
+		w.Header().Set("Content-Type", contentType)
+		w.WriteHeader(ar.Code())
+
+		if ar.Empty() {
+			return nil
+		}
+	}
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+func encodeError(ctx context.Context, err error, w http.ResponseWriter) {
+	switch {
+	case err == apiutil.ErrLimitSize,
+		errors.Contains(err, apiutil.ErrInvalidQueryParams):
+		w.WriteHeader(http.StatusBadRequest)
+	case errors.Contains(err, errors.ErrAuthentication),
+		err == apiutil.ErrBearerToken:
+		w.WriteHeader(http.StatusUnauthorized)
+	case errors.Contains(err, errors.ErrAuthorization):
+		w.WriteHeader(http.StatusForbidden)
+	default:
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+
+	if errorVal, ok := err.(errors.Error); ok {
+		w.Header().Set("Content-Type", contentType)
+		if err := json.NewEncoder(w).Encode(apiutil.NewErrorRes(ctx, errorVal)); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}
+}
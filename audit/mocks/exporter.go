@@ -0,0 +1,31 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"sync"
+
+	"github.com/MainfluxLabs/mainflux/audit"
+)
+
+var _ audit.Exporter = (*Exporter)(nil)
+
+// Exporter is an audit exporter mock recording exported records.
+type Exporter struct {
+	mu      sync.Mutex
+	Records []audit.Record
+}
+
+// NewExporter returns a recording audit exporter mock.
+func NewExporter() *Exporter {
+	return &Exporter{}
+}
+
+func (e *Exporter) Export(r audit.Record) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.Records = append(e.Records, r)
+	return nil
+}
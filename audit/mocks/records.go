@@ -0,0 +1,75 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/MainfluxLabs/mainflux/audit"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+)
+
+var _ audit.RecordRepository = (*recordRepositoryMock)(nil)
+
+type recordRepositoryMock struct {
+	mu      sync.Mutex
+	records map[string]audit.Record
+}
+
+// NewRecordRepository returns a new audit record repository mock.
+func NewRecordRepository() audit.RecordRepository {
+	return &recordRepositoryMock{
+		records: make(map[string]audit.Record),
+	}
+}
+
+func (rrm *recordRepositoryMock) Save(_ context.Context, r audit.Record) error {
+	rrm.mu.Lock()
+	defer rrm.mu.Unlock()
+
+	if _, ok := rrm.records[r.ID]; ok {
+		return errors.ErrConflict
+	}
+
+	rrm.records[r.ID] = r
+	return nil
+}
+
+func (rrm *recordRepositoryMock) RetrieveAll(_ context.Context, pm audit.PageMetadata) (audit.RecordsPage, error) {
+	rrm.mu.Lock()
+	defer rrm.mu.Unlock()
+
+	var records []audit.Record
+	for _, r := range rrm.records {
+		switch {
+		case pm.Actor != "" && r.Actor != pm.Actor,
+			pm.EntityID != "" && r.EntityID != pm.EntityID,
+			!pm.From.IsZero() && r.CreatedAt.Before(pm.From),
+			!pm.To.IsZero() && r.CreatedAt.After(pm.To):
+			continue
+		}
+		records = append(records, r)
+	}
+
+	sort.Slice(records, func(i, j int) bool {
+		return records[i].CreatedAt.After(records[j].CreatedAt)
+	})
+
+	pm.Total = uint64(len(records))
+	start := pm.Offset
+	if start > pm.Total {
+		start = pm.Total
+	}
+	end := start + pm.Limit
+	if pm.Limit == 0 || end > pm.Total {
+		end = pm.Total
+	}
+
+	return audit.RecordsPage{
+		PageMetadata: pm,
+		Records:      records[start:end],
+	}, nil
+}
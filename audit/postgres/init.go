@@ -0,0 +1,77 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"fmt"
+
+	_ "github.com/jackc/pgx/v5/stdlib" // required for SQL access
+	"github.com/jmoiron/sqlx"
+	migrate "github.com/rubenv/sql-migrate"
+)
+
+// Config defines the options that are used when connecting to a PostgreSQL instance
+type Config struct {
+	Host        string
+	Port        string
+	User        string
+	Pass        string
+	Name        string
+	SSLMode     string
+	SSLCert     string
+	SSLKey      string
+	SSLRootCert string
+}
+
+// Connect creates a connection to the PostgreSQL instance and applies any
+// unapplied database migrations. A non-nil error is returned to indicate
+// failure.
+func Connect(cfg Config) (*sqlx.DB, error) {
+	url := fmt.Sprintf("host=%s port=%s user=%s dbname=%s password=%s sslmode=%s sslcert=%s sslkey=%s sslrootcert=%s", cfg.Host, cfg.Port, cfg.User, cfg.Name, cfg.Pass, cfg.SSLMode, cfg.SSLCert, cfg.SSLKey, cfg.SSLRootCert)
+
+	db, err := sqlx.Open("pgx", url)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := migrateDB(db); err != nil {
+		return nil, err
+	}
+
+	return db, nil
+}
+
+func migrateDB(db *sqlx.DB) error {
+	migrations := &migrate.MemoryMigrationSource{
+		Migrations: []*migrate.Migration{
+			{
+				Id: "audit_1",
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS audit_records (
+                        id            VARCHAR(254) PRIMARY KEY,
+                        actor         VARCHAR(254),
+                        service       VARCHAR(254) NOT NULL,
+                        operation     VARCHAR(16) NOT NULL,
+                        path          TEXT NOT NULL,
+                        entity_id     VARCHAR(254),
+                        status_code   INTEGER NOT NULL,
+                        request_hash  CHAR(64) NOT NULL,
+                        response_hash CHAR(64) NOT NULL,
+                        created_at    TIMESTAMPTZ NOT NULL
+                    )`,
+					`CREATE INDEX IF NOT EXISTS audit_records_actor_idx ON audit_records (actor, created_at)`,
+					`CREATE INDEX IF NOT EXISTS audit_records_entity_idx ON audit_records (entity_id, created_at)`,
+					`CREATE OR REPLACE RULE audit_records_no_update AS ON UPDATE TO audit_records DO INSTEAD NOTHING`,
+					`CREATE OR REPLACE RULE audit_records_no_delete AS ON DELETE TO audit_records DO INSTEAD NOTHING`,
+				},
+				Down: []string{
+					"DROP TABLE IF EXISTS audit_records",
+				},
+			},
+		},
+	}
+
+	_, err := migrate.Exec(db.DB, "postgres", migrations, migrate.Up)
+	return err
+}
@@ -0,0 +1,162 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/audit"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+var _ audit.RecordRepository = (*recordRepository)(nil)
+
+type recordRepository struct {
+	db Database
+}
+
+// New instantiates a PostgreSQL implementation of audit record repository.
+func New(db Database) audit.RecordRepository {
+	return &recordRepository{db: db}
+}
+
+func (rr recordRepository) Save(ctx context.Context, r audit.Record) error {
+	q := `INSERT INTO audit_records (id, actor, service, operation, path, entity_id, status_code, request_hash, response_hash, created_at)
+		VALUES (:id, :actor, :service, :operation, :path, :entity_id, :status_code, :request_hash, :response_hash, :created_at)`
+
+	if _, err := rr.db.NamedExecContext(ctx, q, toDBRecord(r)); err != nil {
+		if pgErr, ok := err.(*pgconn.PgError); ok && pgErr.Code == pgerrcode.UniqueViolation {
+			return errors.Wrap(errors.ErrConflict, err)
+		}
+		return errors.Wrap(errors.ErrCreateEntity, err)
+	}
+
+	return nil
+}
+
+func (rr recordRepository) RetrieveAll(ctx context.Context, pm audit.PageMetadata) (audit.RecordsPage, error) {
+	var conds []string
+	params := map[string]interface{}{
+		"limit":  pm.Limit,
+		"offset": pm.Offset,
+	}
+
+	if pm.Actor != "" {
+		conds = append(conds, "actor = :actor")
+		params["actor"] = pm.Actor
+	}
+	if pm.EntityID != "" {
+		conds = append(conds, "entity_id = :entity_id")
+		params["entity_id"] = pm.EntityID
+	}
+	if !pm.From.IsZero() {
+		conds = append(conds, "created_at >= :from")
+		params["from"] = pm.From
+	}
+	if !pm.To.IsZero() {
+		conds = append(conds, "created_at <= :to")
+		params["to"] = pm.To
+	}
+
+	var where string
+	if len(conds) > 0 {
+		where = fmt.Sprintf("WHERE %s", strings.Join(conds, " AND "))
+	}
+
+	q := fmt.Sprintf(`SELECT id, actor, service, operation, path, entity_id, status_code, request_hash, response_hash, created_at
+		FROM audit_records %s ORDER BY created_at DESC LIMIT :limit OFFSET :offset`, where)
+
+	rows, err := rr.db.NamedQueryContext(ctx, q, params)
+	if err != nil {
+		return audit.RecordsPage{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+	defer rows.Close()
+
+	var records []audit.Record
+	for rows.Next() {
+		var dbr dbRecord
+		if err := rows.StructScan(&dbr); err != nil {
+			return audit.RecordsPage{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+		}
+		records = append(records, toRecord(dbr))
+	}
+
+	cq := fmt.Sprintf(`SELECT COUNT(*) FROM audit_records %s`, where)
+	total, err := total(ctx, rr.db, cq, params)
+	if err != nil {
+		return audit.RecordsPage{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+
+	pm.Total = total
+	return audit.RecordsPage{
+		PageMetadata: pm,
+		Records:      records,
+	}, nil
+}
+
+func total(ctx context.Context, db Database, query string, params interface{}) (uint64, error) {
+	rows, err := db.NamedQueryContext(ctx, query, params)
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	var total uint64
+	if rows.Next() {
+		if err := rows.Scan(&total); err != nil {
+			return 0, err
+		}
+	}
+
+	return total, nil
+}
+
+type dbRecord struct {
+	ID           string         `db:"id"`
+	Actor        sql.NullString `db:"actor"`
+	Service      string         `db:"service"`
+	Operation    string         `db:"operation"`
+	Path         string         `db:"path"`
+	EntityID     sql.NullString `db:"entity_id"`
+	StatusCode   int            `db:"status_code"`
+	RequestHash  string         `db:"request_hash"`
+	ResponseHash string         `db:"response_hash"`
+	CreatedAt    time.Time      `db:"created_at"`
+}
+
+func toDBRecord(r audit.Record) dbRecord {
+	return dbRecord{
+		ID:           r.ID,
+		Actor:        sql.NullString{String: r.Actor, Valid: r.Actor != ""},
+		Service:      r.Service,
+		Operation:    r.Operation,
+		Path:         r.Path,
+		EntityID:     sql.NullString{String: r.EntityID, Valid: r.EntityID != ""},
+		StatusCode:   r.StatusCode,
+		RequestHash:  r.RequestHash,
+		ResponseHash: r.ResponseHash,
+		CreatedAt:    r.CreatedAt,
+	}
+}
+
+func toRecord(r dbRecord) audit.Record {
+	return audit.Record{
+		ID:           r.ID,
+		Actor:        r.Actor.String,
+		Service:      r.Service,
+		Operation:    r.Operation,
+		Path:         r.Path,
+		EntityID:     r.EntityID.String,
+		StatusCode:   r.StatusCode,
+		RequestHash:  r.RequestHash,
+		ResponseHash: r.ResponseHash,
+		CreatedAt:    r.CreatedAt,
+	}
+}
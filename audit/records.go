@@ -0,0 +1,58 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package audit
+
+import (
+	"context"
+	"time"
+)
+
+// Record represents a single mutating API call. Request and response
+// payloads are not stored, only their SHA-256 hashes.
+type Record struct {
+	ID           string
+	Actor        string
+	Service      string
+	Operation    string
+	Path         string
+	EntityID     string
+	StatusCode   int
+	RequestHash  string
+	ResponseHash string
+	CreatedAt    time.Time
+}
+
+// PageMetadata contains page metadata and the filters applied to the page.
+type PageMetadata struct {
+	Total    uint64
+	Offset   uint64
+	Limit    uint64
+	Actor    string
+	EntityID string
+	From     time.Time
+	To       time.Time
+}
+
+// RecordsPage contains page related metadata as well as list of records
+// that belong to this page.
+type RecordsPage struct {
+	PageMetadata
+	Records []Record
+}
+
+// RecordRepository specifies an append-only audit record persistence API.
+type RecordRepository interface {
+	// Save persists the record.
+	Save(ctx context.Context, r Record) error
+
+	// RetrieveAll retrieves the subset of records matching the page filters,
+	// ordered from the newest to the oldest.
+	RetrieveAll(ctx context.Context, pm PageMetadata) (RecordsPage, error)
+}
+
+// Exporter specifies an API for forwarding records to an external system.
+type Exporter interface {
+	// Export forwards the record.
+	Export(r Record) error
+}
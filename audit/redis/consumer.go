@@ -0,0 +1,141 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package redis
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/audit"
+	"github.com/MainfluxLabs/mainflux/logger"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/go-redis/redis/v8"
+)
+
+const (
+	group  = "mainflux.audit"
+	exists = "BUSYGROUP Consumer Group name already exists"
+
+	// pending reads the entries delivered to the consumer but not
+	// acknowledged, and latest reads the entries never delivered.
+	pending = "0"
+	latest  = ">"
+
+	// Failed reads and saves of the records are retried with the
+	// exponential backoff.
+	minBackoff = 100 * time.Millisecond
+	maxBackoff = 5 * time.Second
+)
+
+// Subscriber represents the audit record event source.
+type Subscriber interface {
+	// Subscribe consumes audit records until the context is canceled.
+	Subscribe(ctx context.Context) error
+}
+
+type eventStore struct {
+	svc      audit.Service
+	client   *redis.Client
+	consumer string
+	logger   logger.Logger
+}
+
+// NewEventStore returns new event store instance.
+func NewEventStore(svc audit.Service, client *redis.Client, consumer string, log logger.Logger) Subscriber {
+	return eventStore{
+		svc:      svc,
+		client:   client,
+		consumer: consumer,
+		logger:   log,
+	}
+}
+
+// Subscribe first saves the records delivered to the consumer and not
+// acknowledged before, e.g. by the previous run of the service, and then
+// the new ones. A record is acknowledged only once it is stored, so records
+// failing to be saved are read again from the pending entries.
+func (es eventStore) Subscribe(ctx context.Context) error {
+	err := es.client.XGroupCreateMkStream(ctx, streamID, group, "$").Err()
+	if err != nil && err.Error() != exists {
+		return err
+	}
+
+	lastID := pending
+	backoff := minBackoff
+	for {
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		streams, err := es.client.XReadGroup(ctx, &redis.XReadGroupArgs{
+			Group:    group,
+			Consumer: es.consumer,
+			Streams:  []string{streamID, lastID},
+			Count:    100,
+		}).Result()
+		if ctx.Err() != nil {
+			return nil
+		}
+		if err != nil && err != redis.Nil {
+			es.logger.Warn(fmt.Sprintf("Failed to read audit records: %s", err))
+			backoff = es.wait(ctx, backoff)
+			continue
+		}
+
+		var msgs []redis.XMessage
+		if len(streams) > 0 {
+			msgs = streams[0].Messages
+		}
+		if lastID == pending && len(msgs) == 0 {
+			lastID = latest
+			continue
+		}
+
+		if err := es.save(ctx, msgs); err != nil {
+			es.logger.Warn(fmt.Sprintf("Failed to save audit record: %s", err))
+			lastID = pending
+			backoff = es.wait(ctx, backoff)
+			continue
+		}
+		backoff = minBackoff
+	}
+}
+
+// save stores and acknowledges the records in order, stopping at the first
+// record failing to be stored.
+func (es eventStore) save(ctx context.Context, msgs []redis.XMessage) error {
+	for _, msg := range msgs {
+		err := es.svc.SaveRecord(ctx, decodeRecord(msg.Values))
+		switch {
+		case err == nil:
+		case errors.Contains(err, audit.ErrExport):
+			// The record is stored, so it's not saved again.
+			es.logger.Warn(fmt.Sprintf("Failed to export audit record: %s", err))
+		default:
+			return err
+		}
+
+		if err := es.client.XAck(ctx, streamID, group, msg.ID).Err(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// wait sleeps for the backoff duration, unless the context is canceled, and
+// returns the next backoff duration.
+func (es eventStore) wait(ctx context.Context, backoff time.Duration) time.Duration {
+	select {
+	case <-time.After(backoff):
+	case <-ctx.Done():
+	}
+
+	if backoff *= 2; backoff > maxBackoff {
+		backoff = maxBackoff
+	}
+
+	return backoff
+}
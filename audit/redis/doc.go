@@ -0,0 +1,7 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package redis contains the audit record producer, which services use to
+// record their mutating API calls to the Redis stream, and the consumer the
+// audit service uses to persist them.
+package redis
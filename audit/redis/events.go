@@ -0,0 +1,67 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package redis
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/audit"
+)
+
+type recordEvent struct {
+	actor        string
+	service      string
+	operation    string
+	path         string
+	entityID     string
+	statusCode   int
+	requestHash  string
+	responseHash string
+	createdAt    time.Time
+}
+
+func (re recordEvent) encode() map[string]interface{} {
+	return map[string]interface{}{
+		"actor":         re.actor,
+		"service":       re.service,
+		"operation":     re.operation,
+		"path":          re.path,
+		"entity_id":     re.entityID,
+		"status_code":   re.statusCode,
+		"request_hash":  re.requestHash,
+		"response_hash": re.responseHash,
+		"created_at":    re.createdAt.UnixNano(),
+	}
+}
+
+func decodeRecord(event map[string]interface{}) audit.Record {
+	code, _ := strconv.Atoi(read(event, "status_code", "0"))
+	created, err := strconv.ParseInt(read(event, "created_at", "0"), 10, 64)
+	createdAt := time.Unix(0, created).UTC()
+	if err != nil || created == 0 {
+		createdAt = time.Now().UTC()
+	}
+
+	return audit.Record{
+		Actor:        read(event, "actor", ""),
+		Service:      read(event, "service", ""),
+		Operation:    read(event, "operation", ""),
+		Path:         read(event, "path", ""),
+		EntityID:     read(event, "entity_id", ""),
+		StatusCode:   code,
+		RequestHash:  read(event, "request_hash", ""),
+		ResponseHash: read(event, "response_hash", ""),
+		CreatedAt:    createdAt,
+	}
+}
+
+func read(event map[string]interface{}, key, def string) string {
+	val, ok := event[key].(string)
+	if !ok {
+		return def
+	}
+
+	return val
+}
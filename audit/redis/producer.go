@@ -0,0 +1,167 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package redis
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux"
+	"github.com/MainfluxLabs/mainflux/internal/apiutil"
+	"github.com/MainfluxLabs/mainflux/logger"
+	"github.com/go-redis/redis/v8"
+	"google.golang.org/grpc"
+)
+
+const (
+	streamID  = "mainflux.audit"
+	streamLen = 10000
+)
+
+// Identifier resolves the users performing the requests. It is implemented
+// by the auth service client.
+type Identifier interface {
+	Identify(ctx context.Context, token *mainflux.Token, opts ...grpc.CallOption) (*mainflux.UserIdentity, error)
+}
+
+type auditHandler struct {
+	next    http.Handler
+	service string
+	auth    Identifier
+	client  *redis.Client
+	hashKey []byte
+	logger  logger.Logger
+}
+
+// NewHandler wraps the service HTTP handler and records every mutating
+// request (POST, PUT, PATCH and DELETE) to the audit stream. The request and
+// response payloads are recorded as HMAC-SHA256 hashes keyed with the hash
+// key, since payloads such as login or password change requests carry
+// credentials. The payloads are not hashed if the hash key is empty.
+func NewHandler(next http.Handler, service string, auth Identifier, client *redis.Client, hashKey string, logger logger.Logger) http.Handler {
+	return &auditHandler{
+		next:    next,
+		service: service,
+		auth:    auth,
+		client:  client,
+		hashKey: []byte(hashKey),
+		logger:  logger,
+	}
+}
+
+func (ah *auditHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+	default:
+		ah.next.ServeHTTP(w, r)
+		return
+	}
+
+	reqHash, resHash := ah.newHash(), ah.newHash()
+	if r.Body != nil && reqHash != nil {
+		r.Body = readCloser{io.TeeReader(r.Body, reqHash), r.Body}
+	}
+
+	rw := &responseWriter{ResponseWriter: w, hash: resHash, status: http.StatusOK}
+	ah.next.ServeHTTP(rw, r)
+
+	event := recordEvent{
+		actor:        ah.actor(r),
+		service:      ah.service,
+		operation:    r.Method,
+		path:         r.URL.Path,
+		entityID:     entityID(r.URL.Path, rw.Header().Get("Location")),
+		statusCode:   rw.status,
+		requestHash:  sum(reqHash),
+		responseHash: sum(resHash),
+		createdAt:    time.Now().UTC(),
+	}
+	record := &redis.XAddArgs{
+		Stream:       streamID,
+		MaxLenApprox: streamLen,
+		Values:       event.encode(),
+	}
+	if err := ah.client.XAdd(r.Context(), record).Err(); err != nil {
+		ah.logger.Warn(fmt.Sprintf("Failed to record audit event: %s", err))
+	}
+}
+
+// newHash returns the keyed payload hash, or nil if the hash key is not set.
+func (ah *auditHandler) newHash() hash.Hash {
+	if len(ah.hashKey) == 0 {
+		return nil
+	}
+
+	return hmac.New(sha256.New, ah.hashKey)
+}
+
+func sum(h hash.Hash) string {
+	if h == nil {
+		return ""
+	}
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// actor resolves the user performing the request. Requests authenticated
+// using thing keys or failing authentication are recorded without an actor.
+func (ah *auditHandler) actor(r *http.Request) string {
+	token := apiutil.ExtractBearerToken(r)
+	if token == "" {
+		return ""
+	}
+
+	res, err := ah.auth.Identify(r.Context(), &mainflux.Token{Value: token})
+	if err != nil {
+		return ""
+	}
+
+	return res.GetId()
+}
+
+// entityID extracts the affected entity ID from the request path, or from
+// the Location header of the response for entities created by the request.
+func entityID(path, location string) string {
+	if location != "" {
+		path = location
+	}
+
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	if len(parts) < 2 {
+		return ""
+	}
+
+	return parts[1]
+}
+
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
+
+type responseWriter struct {
+	http.ResponseWriter
+	hash   hash.Hash
+	status int
+}
+
+func (rw *responseWriter) WriteHeader(code int) {
+	rw.status = code
+	rw.ResponseWriter.WriteHeader(code)
+}
+
+func (rw *responseWriter) Write(b []byte) (int, error) {
+	if rw.hash != nil {
+		rw.hash.Write(b)
+	}
+	return rw.ResponseWriter.Write(b)
+}
@@ -0,0 +1,84 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package audit
+
+import (
+	"context"
+
+	"github.com/MainfluxLabs/mainflux"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+)
+
+const rootSubject = "root"
+
+// ErrExport indicates failure to forward the record to the exporter.
+var ErrExport = errors.New("failed to export audit record")
+
+// Service specifies an API that must be fullfiled by the domain service
+// implementation, and all of its decorators (e.g. logging & metrics).
+type Service interface {
+	// SaveRecord stores the audit record and forwards it to the exporter,
+	// if one is configured.
+	SaveRecord(ctx context.Context, r Record) error
+
+	// ListRecords retrieves the audit records matching the page filters.
+	// Only the root admin is allowed to read the audit log.
+	ListRecords(ctx context.Context, token string, pm PageMetadata) (RecordsPage, error)
+}
+
+var _ Service = (*auditService)(nil)
+
+type auditService struct {
+	auth       mainflux.AuthServiceClient
+	records    RecordRepository
+	idProvider mainflux.IDProvider
+	exporter   Exporter
+}
+
+// New instantiates the audit service implementation. The exporter is
+// optional and may be nil.
+func New(auth mainflux.AuthServiceClient, records RecordRepository, idp mainflux.IDProvider, exporter Exporter) Service {
+	return &auditService{
+		auth:       auth,
+		records:    records,
+		idProvider: idp,
+		exporter:   exporter,
+	}
+}
+
+func (as *auditService) SaveRecord(ctx context.Context, r Record) error {
+	if r.ID == "" {
+		id, err := as.idProvider.ID()
+		if err != nil {
+			return err
+		}
+		r.ID = id
+	}
+
+	if err := as.records.Save(ctx, r); err != nil {
+		return err
+	}
+
+	if as.exporter == nil {
+		return nil
+	}
+
+	if err := as.exporter.Export(r); err != nil {
+		return errors.Wrap(ErrExport, err)
+	}
+
+	return nil
+}
+
+func (as *auditService) ListRecords(ctx context.Context, token string, pm PageMetadata) (RecordsPage, error) {
+	req := &mainflux.AuthorizeReq{
+		Token:   token,
+		Subject: rootSubject,
+	}
+	if _, err := as.auth.Authorize(ctx, req); err != nil {
+		return RecordsPage{}, errors.Wrap(errors.ErrAuthorization, err)
+	}
+
+	return as.records.RetrieveAll(ctx, pm)
+}
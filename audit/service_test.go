@@ -0,0 +1,133 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package audit_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/audit"
+	auditmocks "github.com/MainfluxLabs/mainflux/audit/mocks"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/mocks"
+	"github.com/MainfluxLabs/mainflux/pkg/uuid"
+	"github.com/MainfluxLabs/mainflux/users"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	adminEmail = "admin@example.com"
+	userEmail  = "user@example.com"
+	password   = "password"
+	adminID    = "admin-id"
+	userID     = "user-id"
+	thingID    = "thing-id"
+)
+
+var usersList = []users.User{
+	{ID: adminID, Email: adminEmail, Password: password},
+	{ID: userID, Email: userEmail, Password: password},
+}
+
+func newService(exporter audit.Exporter) audit.Service {
+	auth := mocks.NewAuthService(adminID, usersList)
+	return audit.New(auth, auditmocks.NewRecordRepository(), uuid.NewMock(), exporter)
+}
+
+func TestSaveRecord(t *testing.T) {
+	exporter := auditmocks.NewExporter()
+	svc := newService(exporter)
+
+	r := audit.Record{
+		Actor:      userID,
+		Service:    "things",
+		Operation:  "POST",
+		Path:       "/things",
+		EntityID:   thingID,
+		StatusCode: 201,
+		CreatedAt:  time.Now(),
+	}
+
+	err := svc.SaveRecord(context.Background(), r)
+	require.Nil(t, err, fmt.Sprintf("unexpected error saving record: %s", err))
+	require.Len(t, exporter.Records, 1)
+	assert.NotEmpty(t, exporter.Records[0].ID, "expected record ID to be generated")
+	assert.Equal(t, thingID, exporter.Records[0].EntityID, fmt.Sprintf("expected entity %s got %s", thingID, exporter.Records[0].EntityID))
+}
+
+func TestListRecords(t *testing.T) {
+	svc := newService(nil)
+
+	now := time.Now()
+	n := 10
+	for i := 0; i < n; i++ {
+		r := audit.Record{
+			Actor:     userID,
+			Service:   "things",
+			Operation: "PUT",
+			Path:      fmt.Sprintf("/things/%d", i),
+			EntityID:  fmt.Sprintf("%d", i),
+			CreatedAt: now.Add(time.Duration(i-n) * time.Minute),
+		}
+		if i%2 == 0 {
+			r.Actor = adminID
+		}
+		err := svc.SaveRecord(context.Background(), r)
+		require.Nil(t, err, fmt.Sprintf("unexpected error saving record: %s", err))
+	}
+
+	cases := []struct {
+		desc  string
+		token string
+		pm    audit.PageMetadata
+		size  int
+		err   error
+	}{
+		{
+			desc:  "list all records",
+			token: adminEmail,
+			pm:    audit.PageMetadata{Limit: 100},
+			size:  n,
+		},
+		{
+			desc:  "list records filtered by actor",
+			token: adminEmail,
+			pm:    audit.PageMetadata{Limit: 100, Actor: userID},
+			size:  n / 2,
+		},
+		{
+			desc:  "list records filtered by entity",
+			token: adminEmail,
+			pm:    audit.PageMetadata{Limit: 100, EntityID: "3"},
+			size:  1,
+		},
+		{
+			desc:  "list records filtered by time range",
+			token: adminEmail,
+			pm:    audit.PageMetadata{Limit: 100, From: now.Add(-5 * time.Minute), To: now},
+			size:  5,
+		},
+		{
+			desc:  "list records as non-admin user",
+			token: userEmail,
+			pm:    audit.PageMetadata{Limit: 100},
+			err:   errors.ErrAuthorization,
+		},
+		{
+			desc:  "list records with invalid token",
+			token: "invalid",
+			pm:    audit.PageMetadata{Limit: 100},
+			err:   errors.ErrAuthorization,
+		},
+	}
+
+	for _, tc := range cases {
+		page, err := svc.ListRecords(context.Background(), tc.token, tc.pm)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected error %s got %s\n", tc.desc, tc.err, err))
+		assert.Len(t, page.Records, tc.size, fmt.Sprintf("%s: expected %d records got %d\n", tc.desc, tc.size, len(page.Records)))
+	}
+}
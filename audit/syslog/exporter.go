@@ -0,0 +1,68 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package syslog contains the exporter forwarding audit records to syslog.
+package syslog
+
+import (
+	"encoding/json"
+	"log/syslog"
+
+	"github.com/MainfluxLabs/mainflux/audit"
+)
+
+const (
+	tag        = "mainflux-audit"
+	timeFormat = "2006-01-02T15:04:05.000Z07:00"
+)
+
+var _ audit.Exporter = (*exporter)(nil)
+
+type exporter struct {
+	writer *syslog.Writer
+}
+
+// New returns an exporter writing records as JSON to the syslog daemon at
+// the given address. An empty network and address connect to the local
+// syslog daemon.
+func New(network, address string) (audit.Exporter, error) {
+	w, err := syslog.Dial(network, address, syslog.LOG_INFO|syslog.LOG_AUTH, tag)
+	if err != nil {
+		return nil, err
+	}
+
+	return &exporter{writer: w}, nil
+}
+
+func (e *exporter) Export(r audit.Record) error {
+	data, err := json.Marshal(record{
+		ID:           r.ID,
+		Actor:        r.Actor,
+		Service:      r.Service,
+		Operation:    r.Operation,
+		Path:         r.Path,
+		EntityID:     r.EntityID,
+		StatusCode:   r.StatusCode,
+		RequestHash:  r.RequestHash,
+		ResponseHash: r.ResponseHash,
+		CreatedAt:    r.CreatedAt.Format(timeFormat),
+	})
+	if err != nil {
+		return err
+	}
+
+	return e.writer.Info(string(data))
+}
+
+type record struct {
+	ID           string `json:"id"`
+	Actor        string `json:"actor,omitempty"`
+	Service      string `json:"service"`
+	Operation    string `json:"operation"`
+	Path         string `json:"path"`
+	EntityID     string `json:"entity_id,omitempty"`
+	StatusCode   int    `json:"status_code"`
+	RequestHash  string `json:"request_hash"`
+	ResponseHash string `json:"response_hash"`
+	CreatedAt    string `json:"created_at"`
+}
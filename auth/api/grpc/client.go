@@ -23,14 +23,17 @@ const (
 var _ mainflux.AuthServiceClient = (*grpcClient)(nil)
 
 type grpcClient struct {
-	issue      endpoint.Endpoint
-	identify   endpoint.Endpoint
-	authorize  endpoint.Endpoint
-	addPolicy  endpoint.Endpoint
-	assign     endpoint.Endpoint
-	members    endpoint.Endpoint
-	assignRole endpoint.Endpoint
-	timeout    time.Duration
+	issue        endpoint.Endpoint
+	identify     endpoint.Endpoint
+	authorize    endpoint.Endpoint
+	addPolicy    endpoint.Endpoint
+	claim        endpoint.Endpoint
+	assign       endpoint.Endpoint
+	members      endpoint.Endpoint
+	assignRole   endpoint.Endpoint
+	retrieveRole endpoint.Endpoint
+	memberships  endpoint.Endpoint
+	timeout      time.Duration
 }
 
 // NewClient returns new gRPC client instance.
@@ -68,6 +71,14 @@ func NewClient(tracer opentracing.Tracer, conn *grpc.ClientConn, timeout time.Du
 			decodeEmptyResponse,
 			empty.Empty{},
 		).Endpoint()),
+		claim: kitot.TraceClient(tracer, "claim_ownership")(kitgrpc.NewClient(
+			conn,
+			svcName,
+			"ClaimOwnership",
+			encodeClaimOwnershipRequest,
+			decodeEmptyResponse,
+			empty.Empty{},
+		).Endpoint()),
 		assign: kitot.TraceClient(tracer, "assign")(kitgrpc.NewClient(
 			conn,
 			svcName,
@@ -92,6 +103,22 @@ func NewClient(tracer opentracing.Tracer, conn *grpc.ClientConn, timeout time.Du
 			decodeEmptyResponse,
 			empty.Empty{},
 		).Endpoint()),
+		retrieveRole: kitot.TraceClient(tracer, "retrieve_role")(kitgrpc.NewClient(
+			conn,
+			svcName,
+			"RetrieveRole",
+			encodeRetrieveRoleRequest,
+			decodeRetrieveRoleResponse,
+			mainflux.RetrieveRoleRes{},
+		).Endpoint()),
+		memberships: kitot.TraceClient(tracer, "retrieve_memberships")(kitgrpc.NewClient(
+			conn,
+			svcName,
+			"RetrieveMemberships",
+			encodeMembershipsRequest,
+			decodeMembershipsResponse,
+			mainflux.MembershipsRes{},
+		).Endpoint()),
 
 		timeout: timeout,
 	}
@@ -101,7 +128,15 @@ func (client grpcClient) Issue(ctx context.Context, req *mainflux.IssueReq, _ ..
 	ctx, close := context.WithTimeout(ctx, client.timeout)
 	defer close()
 
-	res, err := client.issue(ctx, issueReq{id: req.GetId(), email: req.GetEmail(), keyType: req.Type})
+	issue := issueReq{
+		id:        req.GetId(),
+		email:     req.GetEmail(),
+		keyType:   req.Type,
+		sessionID: req.GetSessionId(),
+		ip:        req.GetIp(),
+		userAgent: req.GetUserAgent(),
+	}
+	res, err := client.issue(ctx, issue)
 	if err != nil {
 		return nil, err
 	}
@@ -112,7 +147,14 @@ func (client grpcClient) Issue(ctx context.Context, req *mainflux.IssueReq, _ ..
 
 func encodeIssueRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
 	req := grpcReq.(issueReq)
-	return &mainflux.IssueReq{Id: req.id, Email: req.email, Type: req.keyType}, nil
+	return &mainflux.IssueReq{
+		Id:        req.id,
+		Email:     req.email,
+		Type:      req.keyType,
+		SessionId: req.sessionID,
+		Ip:        req.ip,
+		UserAgent: req.userAgent,
+	}, nil
 }
 
 func decodeIssueResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
@@ -170,7 +212,7 @@ func (client grpcClient) AddPolicy(ctx context.Context, req *mainflux.PolicyReq,
 	ctx, close := context.WithTimeout(ctx, client.timeout)
 	defer close()
 
-	res, err := client.addPolicy(ctx, policyReq{Token: req.GetToken(), Object: req.GetObject(), Policy: req.GetPolicy()})
+	res, err := client.addPolicy(ctx, policyReq{Token: req.GetToken(), Subject: req.GetSubject(), Object: req.GetObject(), Policy: req.GetPolicy(), MemberID: req.GetMemberID()})
 	if err != nil {
 		return nil, err
 	}
@@ -182,9 +224,33 @@ func (client grpcClient) AddPolicy(ctx context.Context, req *mainflux.PolicyReq,
 func encodeAddPolicyRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
 	req := grpcReq.(policyReq)
 	return &mainflux.PolicyReq{
-		Token:  req.Token,
-		Object: req.Object,
-		Policy: req.Policy,
+		Token:    req.Token,
+		Subject:  req.Subject,
+		Object:   req.Object,
+		Policy:   req.Policy,
+		MemberID: req.MemberID,
+	}, nil
+}
+
+func (client grpcClient) ClaimOwnership(ctx context.Context, req *mainflux.OwnershipReq, _ ...grpc.CallOption) (r *empty.Empty, err error) {
+	ctx, close := context.WithTimeout(ctx, client.timeout)
+	defer close()
+
+	res, err := client.claim(ctx, ownershipReq{subject: req.GetSubject(), object: req.GetObject(), ownerID: req.GetOwnerID()})
+	if err != nil {
+		return nil, err
+	}
+
+	er := res.(emptyRes)
+	return &empty.Empty{}, er.err
+}
+
+func encodeClaimOwnershipRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(ownershipReq)
+	return &mainflux.OwnershipReq{
+		Subject: req.subject,
+		Object:  req.object,
+		OwnerID: req.ownerID,
 	}, nil
 }
 
@@ -234,6 +300,52 @@ func encodeAssignRoleRequest(_ context.Context, grpcReq interface{}) (interface{
 	}, nil
 }
 
+func (client grpcClient) RetrieveRole(ctx context.Context, req *mainflux.RetrieveRoleReq, _ ...grpc.CallOption) (*mainflux.RetrieveRoleRes, error) {
+	ctx, close := context.WithTimeout(ctx, client.timeout)
+	defer close()
+
+	res, err := client.retrieveRole(ctx, retrieveRoleReq{id: req.GetId()})
+	if err != nil {
+		return &mainflux.RetrieveRoleRes{}, err
+	}
+
+	rr := res.(retrieveRoleRes)
+	return &mainflux.RetrieveRoleRes{Role: rr.role}, nil
+}
+
+func encodeRetrieveRoleRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(retrieveRoleReq)
+	return &mainflux.RetrieveRoleReq{Id: req.id}, nil
+}
+
+func decodeRetrieveRoleResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	res := grpcRes.(*mainflux.RetrieveRoleRes)
+	return retrieveRoleRes{role: res.GetRole()}, nil
+}
+
+func (client grpcClient) RetrieveMemberships(ctx context.Context, req *mainflux.MembershipsReq, _ ...grpc.CallOption) (*mainflux.MembershipsRes, error) {
+	ctx, close := context.WithTimeout(ctx, client.timeout)
+	defer close()
+
+	res, err := client.memberships(ctx, membershipsReq{id: req.GetId()})
+	if err != nil {
+		return &mainflux.MembershipsRes{}, err
+	}
+
+	mr := res.(membershipsRes)
+	return &mainflux.MembershipsRes{OrgIDs: mr.orgIDs}, nil
+}
+
+func encodeMembershipsRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(membershipsReq)
+	return &mainflux.MembershipsReq{Id: req.id}, nil
+}
+
+func decodeMembershipsResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	res := grpcRes.(*mainflux.MembershipsRes)
+	return membershipsRes{orgIDs: res.GetOrgIDs()}, nil
+}
+
 func encodeMembersRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
 	req := grpcReq.(membersReq)
 	return &mainflux.MembersReq{
@@ -19,10 +19,26 @@ func issueEndpoint(svc auth.Service) endpoint.Endpoint {
 		}
 
 		key := auth.Key{
-			Type:     req.keyType,
-			Subject:  req.email,
-			IssuerID: req.id,
-			IssuedAt: time.Now().UTC(),
+			Type:      req.keyType,
+			Subject:   req.email,
+			IssuerID:  req.id,
+			IssuedAt:  time.Now().UTC(),
+			SessionID: req.sessionID,
+		}
+
+		// The refresh key issued on login opens the session.
+		if req.keyType == auth.RefreshKey && req.sessionID != "" {
+			s := auth.Session{
+				ID:        req.sessionID,
+				IP:        req.ip,
+				UserAgent: req.userAgent,
+			}
+			_, secret, err := svc.OpenSession(ctx, s, key)
+			if err != nil {
+				return issueRes{}, err
+			}
+
+			return issueRes{secret}, nil
 		}
 
 		_, secret, err := svc.Issue(ctx, "", key)
@@ -94,6 +110,40 @@ func assignRoleEndpoint(svc auth.Service) endpoint.Endpoint {
 	}
 }
 
+func retrieveRoleEndpoint(svc auth.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(retrieveRoleReq)
+
+		if err := req.validate(); err != nil {
+			return retrieveRoleRes{}, err
+		}
+
+		role, err := svc.RetrieveRole(ctx, req.id)
+		if err != nil {
+			return retrieveRoleRes{}, err
+		}
+
+		return retrieveRoleRes{role: role}, nil
+	}
+}
+
+func membershipsEndpoint(svc auth.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(membershipsReq)
+
+		if err := req.validate(); err != nil {
+			return membershipsRes{}, err
+		}
+
+		orgIDs, err := svc.RetrieveMemberships(ctx, req.id)
+		if err != nil {
+			return membershipsRes{}, err
+		}
+
+		return membershipsRes{orgIDs: orgIDs}, nil
+	}
+}
+
 func addPolicyEndpoint(svc auth.Service) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req := request.(policyReq)
@@ -101,7 +151,22 @@ func addPolicyEndpoint(svc auth.Service) endpoint.Endpoint {
 			return emptyRes{}, err
 		}
 
-		if err := svc.AddPolicy(ctx, req.Token, req.Object, req.Policy); err != nil {
+		if err := svc.AddPolicy(ctx, req.Token, req.MemberID, req.Subject, req.Object, req.Policy); err != nil {
+			return emptyRes{}, err
+		}
+
+		return emptyRes{}, nil
+	}
+}
+
+func claimOwnershipEndpoint(svc auth.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(ownershipReq)
+		if err := req.validate(); err != nil {
+			return emptyRes{}, err
+		}
+
+		if err := svc.ClaimOwnership(ctx, req.ownerID, req.subject, req.object); err != nil {
 			return emptyRes{}, err
 		}
 
@@ -122,7 +187,7 @@ func assignEndpoint(svc auth.Service) endpoint.Endpoint {
 			return emptyRes{}, err
 		}
 
-		if err := svc.AssignMembersByIDs(ctx, req.token, req.memberID, req.groupID); err != nil {
+		if err := svc.AssignMembersByIDs(ctx, req.token, req.groupID, req.memberID); err != nil {
 			return emptyRes{}, err
 		}
 
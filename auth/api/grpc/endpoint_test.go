@@ -33,9 +33,10 @@ const (
 	numOfThings = 5
 	numOfUsers  = 5
 
-	authoritiesObj = "authorities"
-	memberRelation = "member"
-	loginDuration  = 30 * time.Minute
+	authoritiesObj  = "authorities"
+	memberRelation  = "member"
+	loginDuration   = 30 * time.Minute
+	refreshDuration = 24 * time.Hour
 )
 
 var svc auth.Service
@@ -45,7 +46,7 @@ func newService() auth.Service {
 	idProvider := uuid.NewMock()
 	t := jwt.New(secret)
 
-	return auth.New(nil, nil, nil, repo, nil, idProvider, t, loginDuration)
+	return auth.New(nil, nil, nil, repo, mocks.NewRevocationRepository(), mocks.NewSessionRepository(), nil, nil, nil, idProvider, t, loginDuration, refreshDuration)
 }
 
 func startGRPCServer(svc auth.Service, port int) {
@@ -84,6 +85,14 @@ func TestIssue(t *testing.T) {
 			err:   nil,
 			code:  codes.OK,
 		},
+		{
+			desc:  "issue refresh key",
+			id:    id,
+			email: email,
+			kind:  auth.RefreshKey,
+			err:   nil,
+			code:  codes.OK,
+		},
 		{
 			desc:  "issue API key unauthenticated",
 			id:    id,
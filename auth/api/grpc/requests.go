@@ -27,9 +27,12 @@ func (req identityReq) validate() error {
 }
 
 type issueReq struct {
-	id      string
-	email   string
-	keyType uint32
+	id        string
+	email     string
+	keyType   uint32
+	sessionID string
+	ip        string
+	userAgent string
 }
 
 func (req issueReq) validate() error {
@@ -38,7 +41,8 @@ func (req issueReq) validate() error {
 	}
 	if req.keyType != auth.LoginKey &&
 		req.keyType != auth.APIKey &&
-		req.keyType != auth.RecoveryKey {
+		req.keyType != auth.RecoveryKey &&
+		req.keyType != auth.RefreshKey {
 		return apiutil.ErrInvalidAuthKey
 	}
 
@@ -94,7 +98,35 @@ func (req authReq) validate() error {
 		return apiutil.ErrBearerToken
 	}
 
-	if req.Subject != auth.RootSubject && req.Subject != auth.GroupSubject {
+	switch req.Subject {
+	case auth.RootSubject, auth.GroupSubject:
+	case auth.ThingSubject, auth.ChannelSubject:
+		if req.Object == "" {
+			return apiutil.ErrMissingObject
+		}
+	default:
+		return apiutil.ErrInvalidSubject
+	}
+
+	return nil
+}
+
+type ownershipReq struct {
+	subject string
+	object  string
+	ownerID string
+}
+
+func (req ownershipReq) validate() error {
+	if req.ownerID == "" {
+		return apiutil.ErrMissingID
+	}
+
+	if req.object == "" {
+		return apiutil.ErrMissingObject
+	}
+
+	if req.subject != auth.ThingSubject && req.subject != auth.ChannelSubject {
 		return apiutil.ErrInvalidSubject
 	}
 
@@ -102,10 +134,11 @@ func (req authReq) validate() error {
 }
 
 type policyReq struct {
-	Token   string
-	Policy  string
-	Subject string
-	Object  string
+	Token    string
+	Policy   string
+	Subject  string
+	Object   string
+	MemberID string
 }
 
 func (req policyReq) validate() error {
@@ -117,12 +150,44 @@ func (req policyReq) validate() error {
 		return apiutil.ErrMissingObject
 	}
 
-	if req.Subject != auth.RootSubject && req.Subject != auth.GroupSubject {
+	switch req.Subject {
+	case auth.RootSubject, auth.GroupSubject:
+		if req.Policy != auth.RPolicy && req.Policy != auth.RwPolicy && req.Policy != "" {
+			return apiutil.ErrInvalidPolicy
+		}
+	case auth.ThingSubject, auth.ChannelSubject:
+		if req.MemberID == "" {
+			return apiutil.ErrMissingID
+		}
+		if req.Policy != auth.OwnerRelation && req.Policy != auth.EditorRelation && req.Policy != auth.ViewerRelation {
+			return apiutil.ErrInvalidPolicy
+		}
+	default:
 		return apiutil.ErrInvalidSubject
 	}
 
-	if req.Policy != auth.RPolicy && req.Policy != auth.RwPolicy && req.Policy != "" {
-		return apiutil.ErrInvalidPolicy
+	return nil
+}
+
+type retrieveRoleReq struct {
+	id string
+}
+
+func (req retrieveRoleReq) validate() error {
+	if req.id == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return nil
+}
+
+type membershipsReq struct {
+	id string
+}
+
+func (req membershipsReq) validate() error {
+	if req.id == "" {
+		return apiutil.ErrMissingID
 	}
 
 	return nil
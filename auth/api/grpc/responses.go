@@ -19,6 +19,14 @@ type membersRes struct {
 	groupType string
 	members   []string
 }
+type retrieveRoleRes struct {
+	role string
+}
+
+type membershipsRes struct {
+	orgIDs []string
+}
+
 type emptyRes struct {
 	err error
 }
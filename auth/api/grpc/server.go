@@ -21,13 +21,16 @@ import (
 var _ mainflux.AuthServiceServer = (*grpcServer)(nil)
 
 type grpcServer struct {
-	issue      kitgrpc.Handler
-	identify   kitgrpc.Handler
-	authorize  kitgrpc.Handler
-	addPolicy  kitgrpc.Handler
-	assign     kitgrpc.Handler
-	members    kitgrpc.Handler
-	assignRole kitgrpc.Handler
+	issue        kitgrpc.Handler
+	identify     kitgrpc.Handler
+	authorize    kitgrpc.Handler
+	addPolicy    kitgrpc.Handler
+	claim        kitgrpc.Handler
+	assign       kitgrpc.Handler
+	members      kitgrpc.Handler
+	assignRole   kitgrpc.Handler
+	retrieveRole kitgrpc.Handler
+	memberships  kitgrpc.Handler
 }
 
 // NewServer returns new AuthServiceServer instance.
@@ -53,6 +56,11 @@ func NewServer(tracer opentracing.Tracer, svc auth.Service) mainflux.AuthService
 			decodeAddPolicyRequest,
 			encodeEmptyResponse,
 		),
+		claim: kitgrpc.NewServer(
+			kitot.TraceServer(tracer, "claim_ownership")(claimOwnershipEndpoint(svc)),
+			decodeClaimOwnershipRequest,
+			encodeEmptyResponse,
+		),
 		assign: kitgrpc.NewServer(
 			kitot.TraceServer(tracer, "assign")(assignEndpoint(svc)),
 			decodeAssignRequest,
@@ -68,6 +76,16 @@ func NewServer(tracer opentracing.Tracer, svc auth.Service) mainflux.AuthService
 			decodeAssignRoleRequest,
 			encodeEmptyResponse,
 		),
+		retrieveRole: kitgrpc.NewServer(
+			kitot.TraceServer(tracer, "retrieve_role")(retrieveRoleEndpoint(svc)),
+			decodeRetrieveRoleRequest,
+			encodeRetrieveRoleResponse,
+		),
+		memberships: kitgrpc.NewServer(
+			kitot.TraceServer(tracer, "retrieve_memberships")(membershipsEndpoint(svc)),
+			decodeMembershipsRequest,
+			encodeMembershipsResponse,
+		),
 	}
 }
 
@@ -103,6 +121,14 @@ func (s *grpcServer) AddPolicy(ctx context.Context, req *mainflux.PolicyReq) (*e
 	return res.(*empty.Empty), nil
 }
 
+func (s *grpcServer) ClaimOwnership(ctx context.Context, req *mainflux.OwnershipReq) (*empty.Empty, error) {
+	_, res, err := s.claim.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+	return res.(*empty.Empty), nil
+}
+
 func (s *grpcServer) Assign(ctx context.Context, token *mainflux.Assignment) (*empty.Empty, error) {
 	_, res, err := s.assign.ServeGRPC(ctx, token)
 	if err != nil {
@@ -132,9 +158,52 @@ func decodeAssignRoleRequest(_ context.Context, grpcReq interface{}) (interface{
 	return assignRoleReq{ID: req.GetId(), Role: req.GetRole()}, nil
 }
 
+func (s *grpcServer) RetrieveRole(ctx context.Context, req *mainflux.RetrieveRoleReq) (*mainflux.RetrieveRoleRes, error) {
+	_, res, err := s.retrieveRole.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+	return res.(*mainflux.RetrieveRoleRes), nil
+}
+
+func decodeRetrieveRoleRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*mainflux.RetrieveRoleReq)
+	return retrieveRoleReq{id: req.GetId()}, nil
+}
+
+func encodeRetrieveRoleResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	res := grpcRes.(retrieveRoleRes)
+	return &mainflux.RetrieveRoleRes{Role: res.role}, nil
+}
+
+func (s *grpcServer) RetrieveMemberships(ctx context.Context, req *mainflux.MembershipsReq) (*mainflux.MembershipsRes, error) {
+	_, res, err := s.memberships.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+	return res.(*mainflux.MembershipsRes), nil
+}
+
+func decodeMembershipsRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*mainflux.MembershipsReq)
+	return membershipsReq{id: req.GetId()}, nil
+}
+
+func encodeMembershipsResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	res := grpcRes.(membershipsRes)
+	return &mainflux.MembershipsRes{OrgIDs: res.orgIDs}, nil
+}
+
 func decodeIssueRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
 	req := grpcReq.(*mainflux.IssueReq)
-	return issueReq{id: req.GetId(), email: req.GetEmail(), keyType: req.GetType()}, nil
+	return issueReq{
+		id:        req.GetId(),
+		email:     req.GetEmail(),
+		keyType:   req.GetType(),
+		sessionID: req.GetSessionId(),
+		ip:        req.GetIp(),
+		userAgent: req.GetUserAgent(),
+	}, nil
 }
 
 func encodeIssueResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
@@ -164,7 +233,12 @@ func decodeAssignRequest(_ context.Context, grpcReq interface{}) (interface{}, e
 
 func decodeAddPolicyRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
 	req := grpcReq.(*mainflux.PolicyReq)
-	return policyReq{Token: req.GetToken(), Object: req.GetObject(), Policy: req.GetPolicy()}, nil
+	return policyReq{Token: req.GetToken(), Subject: req.GetSubject(), Object: req.GetObject(), Policy: req.GetPolicy(), MemberID: req.GetMemberID()}, nil
+}
+
+func decodeClaimOwnershipRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*mainflux.OwnershipReq)
+	return ownershipReq{subject: req.GetSubject(), object: req.GetObject(), ownerID: req.GetOwnerID()}, nil
 }
 
 func decodeMembersRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
@@ -200,6 +274,8 @@ func encodeError(err error) error {
 	case errors.Contains(err, apiutil.ErrMalformedEntity),
 		err == apiutil.ErrInvalidAuthKey,
 		err == apiutil.ErrMissingID,
+		err == apiutil.ErrMissingObject,
+		err == apiutil.ErrInvalidSubject,
 		err == apiutil.ErrMissingMemberType:
 		return status.Error(codes.InvalidArgument, err.Error())
 	case errors.Contains(err, errors.ErrAuthentication),
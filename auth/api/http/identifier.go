@@ -0,0 +1,33 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"context"
+
+	"github.com/MainfluxLabs/mainflux"
+	"github.com/MainfluxLabs/mainflux/auth"
+	"google.golang.org/grpc"
+)
+
+// Identifier identifies the users authenticated by the tokens using the
+// service itself. It records the actors of the audited auth requests.
+type Identifier struct {
+	svc auth.Service
+}
+
+// NewIdentifier returns the identifier backed by the service.
+func NewIdentifier(svc auth.Service) Identifier {
+	return Identifier{svc: svc}
+}
+
+// Identify returns the identity of the user authenticated by the token.
+func (i Identifier) Identify(ctx context.Context, token *mainflux.Token, _ ...grpc.CallOption) (*mainflux.UserIdentity, error) {
+	id, err := i.svc.Identify(ctx, token.GetValue())
+	if err != nil {
+		return nil, err
+	}
+
+	return &mainflux.UserIdentity{Id: id.ID, Email: id.Email}, nil
+}
@@ -7,8 +7,8 @@ import (
 	"context"
 	"time"
 
-	"github.com/go-kit/kit/endpoint"
 	"github.com/MainfluxLabs/mainflux/auth"
+	"github.com/go-kit/kit/endpoint"
 )
 
 func issueEndpoint(svc auth.Service) endpoint.Endpoint {
@@ -90,3 +90,76 @@ func revokeEndpoint(svc auth.Service) endpoint.Endpoint {
 		return revokeKeyRes{}, nil
 	}
 }
+
+func refreshEndpoint(svc auth.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(refreshReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		access, refresh, err := svc.Refresh(ctx, req.RefreshToken)
+		if err != nil {
+			return nil, err
+		}
+
+		return tokenRes{Token: access, RefreshToken: refresh}, nil
+	}
+}
+
+func revokeAllEndpoint(svc auth.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(revokeAllReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		if err := svc.RevokeAll(ctx, req.token); err != nil {
+			return nil, err
+		}
+
+		return revokeKeyRes{}, nil
+	}
+}
+
+func listSessionsEndpoint(svc auth.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listSessionsReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		sessions, err := svc.ListSessions(ctx, req.token, req.userID)
+		if err != nil {
+			return nil, err
+		}
+
+		res := sessionsRes{Sessions: []sessionRes{}}
+		for _, s := range sessions {
+			res.Sessions = append(res.Sessions, sessionRes{
+				ID:        s.ID,
+				IP:        s.IP,
+				UserAgent: s.UserAgent,
+				IssuedAt:  s.IssuedAt,
+				ExpiresAt: s.ExpiresAt,
+			})
+		}
+
+		return res, nil
+	}
+}
+
+func revokeSessionEndpoint(svc auth.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(sessionReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		if err := svc.RevokeSession(ctx, req.token, req.userID, req.id); err != nil {
+			return nil, err
+		}
+
+		return revokeKeyRes{}, nil
+	}
+}
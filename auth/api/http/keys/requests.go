@@ -46,3 +46,62 @@ func (req keyReq) validate() error {
 	}
 	return nil
 }
+
+type refreshReq struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+func (req refreshReq) validate() error {
+	if req.RefreshToken == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	return nil
+}
+
+type revokeAllReq struct {
+	token string
+}
+
+func (req revokeAllReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	return nil
+}
+
+type listSessionsReq struct {
+	token  string
+	userID string
+}
+
+func (req listSessionsReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	if req.userID == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return nil
+}
+
+type sessionReq struct {
+	token  string
+	userID string
+	id     string
+}
+
+func (req sessionReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	if req.userID == "" || req.id == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return nil
+}
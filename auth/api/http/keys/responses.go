@@ -13,6 +13,8 @@ import (
 var (
 	_ mainflux.Response = (*issueKeyRes)(nil)
 	_ mainflux.Response = (*revokeKeyRes)(nil)
+	_ mainflux.Response = (*tokenRes)(nil)
+	_ mainflux.Response = (*sessionsRes)(nil)
 )
 
 type issueKeyRes struct {
@@ -69,3 +71,44 @@ func (res revokeKeyRes) Headers() map[string]string {
 func (res revokeKeyRes) Empty() bool {
 	return true
 }
+
+type tokenRes struct {
+	Token        string `json:"token,omitempty"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+}
+
+func (res tokenRes) Code() int {
+	return http.StatusCreated
+}
+
+func (res tokenRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res tokenRes) Empty() bool {
+	return res.Token == ""
+}
+
+type sessionRes struct {
+	ID        string    `json:"id"`
+	IP        string    `json:"ip,omitempty"`
+	UserAgent string    `json:"user_agent,omitempty"`
+	IssuedAt  time.Time `json:"issued_at"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+type sessionsRes struct {
+	Sessions []sessionRes `json:"sessions"`
+}
+
+func (res sessionsRes) Code() int {
+	return http.StatusOK
+}
+
+func (res sessionsRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res sessionsRes) Empty() bool {
+	return false
+}
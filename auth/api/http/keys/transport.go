@@ -48,6 +48,34 @@ func MakeHandler(svc auth.Service, mux *bone.Mux, tracer opentracing.Tracer, log
 		opts...,
 	))
 
+	mux.Post("/tokens/refresh", kithttp.NewServer(
+		kitot.TraceServer(tracer, "refresh")(refreshEndpoint(svc)),
+		decodeRefresh,
+		encodeResponse,
+		opts...,
+	))
+
+	mux.Post("/tokens/revoke", kithttp.NewServer(
+		kitot.TraceServer(tracer, "revoke_all")(revokeAllEndpoint(svc)),
+		decodeRevokeAll,
+		encodeResponse,
+		opts...,
+	))
+
+	mux.Get("/users/:id/sessions", kithttp.NewServer(
+		kitot.TraceServer(tracer, "list_sessions")(listSessionsEndpoint(svc)),
+		decodeListSessions,
+		encodeResponse,
+		opts...,
+	))
+
+	mux.Delete("/users/:id/sessions/:sessionID", kithttp.NewServer(
+		kitot.TraceServer(tracer, "revoke_session")(revokeSessionEndpoint(svc)),
+		decodeSessionReq,
+		encodeResponse,
+		opts...,
+	))
+
 	return mux
 }
 
@@ -72,6 +100,41 @@ func decodeKeyReq(_ context.Context, r *http.Request) (interface{}, error) {
 	return req, nil
 }
 
+func decodeRefresh(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), contentType) {
+		return nil, apiutil.ErrUnsupportedContentType
+	}
+
+	var req refreshReq
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(apiutil.ErrMalformedEntity, err)
+	}
+
+	return req, nil
+}
+
+func decodeRevokeAll(_ context.Context, r *http.Request) (interface{}, error) {
+	req := revokeAllReq{token: apiutil.ExtractBearerToken(r)}
+	return req, nil
+}
+
+func decodeListSessions(_ context.Context, r *http.Request) (interface{}, error) {
+	req := listSessionsReq{
+		token:  apiutil.ExtractBearerToken(r),
+		userID: bone.GetValue(r, "id"),
+	}
+	return req, nil
+}
+
+func decodeSessionReq(_ context.Context, r *http.Request) (interface{}, error) {
+	req := sessionReq{
+		token:  apiutil.ExtractBearerToken(r),
+		userID: bone.GetValue(r, "id"),
+		id:     bone.GetValue(r, "sessionID"),
+	}
+	return req, nil
+}
+
 func encodeResponse(_ context.Context, w http.ResponseWriter, response interface{}) error {
 	w.Header().Set("Content-Type", contentType)
 
@@ -90,7 +153,7 @@ func encodeResponse(_ context.Context, w http.ResponseWriter, response interface
 	return json.NewEncoder(w).Encode(response)
 }
 
-func encodeError(_ context.Context, err error, w http.ResponseWriter) {
+func encodeError(ctx context.Context, err error, w http.ResponseWriter) {
 	switch {
 	case errors.Contains(err, apiutil.ErrMalformedEntity),
 		err == apiutil.ErrMissingID,
@@ -99,6 +162,8 @@ func encodeError(_ context.Context, err error, w http.ResponseWriter) {
 	case errors.Contains(err, errors.ErrAuthentication),
 		err == apiutil.ErrBearerToken:
 		w.WriteHeader(http.StatusUnauthorized)
+	case errors.Contains(err, errors.ErrAuthorization):
+		w.WriteHeader(http.StatusForbidden)
 	case errors.Contains(err, errors.ErrNotFound):
 		w.WriteHeader(http.StatusNotFound)
 	case errors.Contains(err, errors.ErrConflict):
@@ -111,7 +176,7 @@ func encodeError(_ context.Context, err error, w http.ResponseWriter) {
 
 	if errorVal, ok := err.(errors.Error); ok {
 		w.Header().Set("Content-Type", contentType)
-		if err := json.NewEncoder(w).Encode(apiutil.ErrorRes{Err: errorVal.Msg()}); err != nil {
+		if err := json.NewEncoder(w).Encode(apiutil.NewErrorRes(ctx, errorVal)); err != nil {
 			w.WriteHeader(http.StatusInternalServerError)
 		}
 	}
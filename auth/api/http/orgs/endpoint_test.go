@@ -28,23 +28,24 @@ import (
 )
 
 const (
-	secret        = "secret"
-	contentType   = "application/json"
-	id            = "123e4567-e89b-12d3-a456-000000000022"
-	adminID       = "adminID"
-	editorID      = "editorID"
-	viewerID      = "viewerID"
-	groupID       = "groupID"
-	groupID2      = "groupID2"
-	email         = "user@example.com"
-	adminEmail    = "admin@example.com"
-	editorEmail   = "editor@example.com"
-	viewerEmail   = "viewer@example.com"
-	wrongValue    = "wrong_value"
-	name          = "testName"
-	description   = "testDesc"
-	n             = 10
-	loginDuration = 30 * time.Minute
+	secret          = "secret"
+	contentType     = "application/json"
+	id              = "123e4567-e89b-12d3-a456-000000000022"
+	adminID         = "adminID"
+	editorID        = "editorID"
+	viewerID        = "viewerID"
+	groupID         = "groupID"
+	groupID2        = "groupID2"
+	email           = "user@example.com"
+	adminEmail      = "admin@example.com"
+	editorEmail     = "editor@example.com"
+	viewerEmail     = "viewer@example.com"
+	wrongValue      = "wrong_value"
+	name            = "testName"
+	description     = "testDesc"
+	n               = 10
+	loginDuration   = 30 * time.Minute
+	refreshDuration = 24 * time.Hour
 )
 
 var (
@@ -92,12 +93,13 @@ func (tr testRequest) make() (*http.Response, error) {
 func newService() auth.Service {
 	orgsRepo := mocks.NewOrgRepository()
 	rolesRepo := mocks.NewRolesRepository()
+	policiesRepo := mocks.NewPoliciesRepository()
 	idProvider := uuid.NewMock()
 	t := jwt.New(secret)
 	uc := mocks.NewUsersService(usersByIDs, usersByEmails)
 	tc := thmocks.NewThingsServiceClient(nil, groups)
 
-	return auth.New(orgsRepo, tc, uc, nil, rolesRepo, idProvider, t, loginDuration)
+	return auth.New(orgsRepo, tc, uc, nil, mocks.NewRevocationRepository(), mocks.NewSessionRepository(), rolesRepo, policiesRepo, mocks.NewTeamRepository(), idProvider, t, loginDuration, refreshDuration)
 }
 
 func newServer(svc auth.Service) *httptest.Server {
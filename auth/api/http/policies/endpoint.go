@@ -0,0 +1,65 @@
+package policies
+
+import (
+	"context"
+
+	"github.com/MainfluxLabs/mainflux/auth"
+	"github.com/go-kit/kit/endpoint"
+)
+
+func shareEndpoint(svc auth.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(shareReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		inv := auth.ObjectInvitation{
+			Emails: req.Emails,
+			OrgID:  req.OrgID,
+			Policy: req.Policy,
+		}
+
+		if err := svc.ShareObject(ctx, req.token, req.subject, req.object, inv); err != nil {
+			return nil, err
+		}
+
+		return shareRes{}, nil
+	}
+}
+
+func listPoliciesEndpoint(svc auth.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(objectReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		ps, err := svc.ListObjectPolicies(ctx, req.token, req.subject, req.object)
+		if err != nil {
+			return nil, err
+		}
+
+		res := listPoliciesRes{Policies: []policyRes{}}
+		for _, p := range ps {
+			res.Policies = append(res.Policies, policyRes{MemberID: p.MemberID, MemberType: p.MemberType, Relation: p.Relation})
+		}
+
+		return res, nil
+	}
+}
+
+func unshareEndpoint(svc auth.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(unshareReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		if err := svc.UnshareObject(ctx, req.token, req.subject, req.object, req.MemberIDs...); err != nil {
+			return nil, err
+		}
+
+		return unshareRes{}, nil
+	}
+}
@@ -0,0 +1,80 @@
+package policies
+
+import (
+	"github.com/MainfluxLabs/mainflux/auth"
+	"github.com/MainfluxLabs/mainflux/internal/apiutil"
+)
+
+type objectReq struct {
+	token   string
+	subject string
+	object  string
+}
+
+func (req objectReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	if req.subject != auth.ThingSubject && req.subject != auth.ChannelSubject {
+		return apiutil.ErrInvalidSubject
+	}
+
+	if req.object == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return nil
+}
+
+type shareReq struct {
+	objectReq
+	Emails []string `json:"emails,omitempty"`
+	OrgID  string   `json:"org_id,omitempty"`
+	Policy string   `json:"policy"`
+}
+
+func (req shareReq) validate() error {
+	if err := req.objectReq.validate(); err != nil {
+		return err
+	}
+
+	if len(req.Emails) == 0 && req.OrgID == "" {
+		return apiutil.ErrEmptyList
+	}
+
+	for _, email := range req.Emails {
+		if email == "" {
+			return apiutil.ErrMissingID
+		}
+	}
+
+	if req.Policy != auth.RPolicy && req.Policy != auth.RwPolicy {
+		return apiutil.ErrInvalidPolicy
+	}
+
+	return nil
+}
+
+type unshareReq struct {
+	objectReq
+	MemberIDs []string `json:"member_ids"`
+}
+
+func (req unshareReq) validate() error {
+	if err := req.objectReq.validate(); err != nil {
+		return err
+	}
+
+	if len(req.MemberIDs) == 0 {
+		return apiutil.ErrEmptyList
+	}
+
+	for _, id := range req.MemberIDs {
+		if id == "" {
+			return apiutil.ErrMissingID
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,63 @@
+package policies
+
+import (
+	"net/http"
+
+	"github.com/MainfluxLabs/mainflux"
+)
+
+var (
+	_ mainflux.Response = (*shareRes)(nil)
+	_ mainflux.Response = (*unshareRes)(nil)
+	_ mainflux.Response = (*listPoliciesRes)(nil)
+)
+
+type shareRes struct{}
+
+func (res shareRes) Code() int {
+	return http.StatusCreated
+}
+
+func (res shareRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res shareRes) Empty() bool {
+	return true
+}
+
+type unshareRes struct{}
+
+func (res unshareRes) Code() int {
+	return http.StatusNoContent
+}
+
+func (res unshareRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res unshareRes) Empty() bool {
+	return true
+}
+
+type policyRes struct {
+	MemberID   string `json:"member_id"`
+	MemberType string `json:"member_type"`
+	Relation   string `json:"relation"`
+}
+
+type listPoliciesRes struct {
+	Policies []policyRes `json:"policies"`
+}
+
+func (res listPoliciesRes) Code() int {
+	return http.StatusOK
+}
+
+func (res listPoliciesRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res listPoliciesRes) Empty() bool {
+	return false
+}
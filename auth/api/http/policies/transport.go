@@ -0,0 +1,150 @@
+package policies
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/MainfluxLabs/mainflux"
+	"github.com/MainfluxLabs/mainflux/auth"
+	"github.com/MainfluxLabs/mainflux/internal/apiutil"
+	"github.com/MainfluxLabs/mainflux/logger"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	kitot "github.com/go-kit/kit/tracing/opentracing"
+	kithttp "github.com/go-kit/kit/transport/http"
+	"github.com/go-zoo/bone"
+	"github.com/opentracing/opentracing-go"
+)
+
+const (
+	contentType = "application/json"
+	subjectKey  = "subject"
+	objectKey   = "objectID"
+)
+
+// MakeHandler returns a HTTP handler for API endpoints.
+func MakeHandler(svc auth.Service, mux *bone.Mux, tracer opentracing.Tracer, logger logger.Logger) *bone.Mux {
+	opts := []kithttp.ServerOption{
+		kithttp.ServerErrorEncoder(apiutil.LoggingErrorEncoder(logger, encodeError)),
+	}
+
+	mux.Post("/policies/:subject/:objectID", kithttp.NewServer(
+		kitot.TraceServer(tracer, "share_object")(shareEndpoint(svc)),
+		decodeShareRequest,
+		encodeResponse,
+		opts...,
+	))
+
+	mux.Get("/policies/:subject/:objectID", kithttp.NewServer(
+		kitot.TraceServer(tracer, "list_object_policies")(listPoliciesEndpoint(svc)),
+		decodeObjectRequest,
+		encodeResponse,
+		opts...,
+	))
+
+	mux.Patch("/policies/:subject/:objectID", kithttp.NewServer(
+		kitot.TraceServer(tracer, "unshare_object")(unshareEndpoint(svc)),
+		decodeUnshareRequest,
+		encodeResponse,
+		opts...,
+	))
+
+	return mux
+}
+
+func decodeObjectRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	req := objectReq{
+		token:   apiutil.ExtractBearerToken(r),
+		subject: bone.GetValue(r, subjectKey),
+		object:  bone.GetValue(r, objectKey),
+	}
+
+	return req, nil
+}
+
+func decodeShareRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), contentType) {
+		return nil, apiutil.ErrUnsupportedContentType
+	}
+
+	req := shareReq{
+		objectReq: objectReq{
+			token:   apiutil.ExtractBearerToken(r),
+			subject: bone.GetValue(r, subjectKey),
+			object:  bone.GetValue(r, objectKey),
+		},
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(apiutil.ErrMalformedEntity, err)
+	}
+
+	return req, nil
+}
+
+func decodeUnshareRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), contentType) {
+		return nil, apiutil.ErrUnsupportedContentType
+	}
+
+	req := unshareReq{
+		objectReq: objectReq{
+			token:   apiutil.ExtractBearerToken(r),
+			subject: bone.GetValue(r, subjectKey),
+			object:  bone.GetValue(r, objectKey),
+		},
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(apiutil.ErrMalformedEntity, err)
+	}
+
+	return req, nil
+}
+
+func encodeResponse(_ context.Context, w http.ResponseWriter, response interface{}) error {
+	w.Header().Set("Content-Type", contentType)
+
+	if ar, ok := response.(mainflux.Response); ok {
+		for k, v := range ar.Headers() {
+			w.Header().Set(k, v)
+		}
+
+		w.WriteHeader(ar.Code())
+
+		if ar.Empty() {
+			return nil
+		}
+	}
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+func encodeError(ctx context.Context, err error, w http.ResponseWriter) {
+	switch {
+	case errors.Contains(err, apiutil.ErrMalformedEntity),
+		err == apiutil.ErrMissingID,
+		err == apiutil.ErrEmptyList,
+		err == apiutil.ErrInvalidSubject,
+		err == apiutil.ErrInvalidPolicy,
+		errors.Contains(err, auth.ErrInvalidPolicy):
+		w.WriteHeader(http.StatusBadRequest)
+	case errors.Contains(err, errors.ErrAuthentication),
+		err == apiutil.ErrBearerToken:
+		w.WriteHeader(http.StatusUnauthorized)
+	case errors.Contains(err, errors.ErrNotFound):
+		w.WriteHeader(http.StatusNotFound)
+	case errors.Contains(err, errors.ErrAuthorization):
+		w.WriteHeader(http.StatusForbidden)
+	case errors.Contains(err, apiutil.ErrUnsupportedContentType):
+		w.WriteHeader(http.StatusUnsupportedMediaType)
+	default:
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+
+	if errorVal, ok := err.(errors.Error); ok {
+		w.Header().Set("Content-Type", contentType)
+		if err := json.NewEncoder(w).Encode(apiutil.NewErrorRes(ctx, errorVal)); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}
+}
@@ -0,0 +1,242 @@
+package teams
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/MainfluxLabs/mainflux/auth"
+	"github.com/go-kit/kit/endpoint"
+)
+
+func createTeamEndpoint(svc auth.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(createTeamReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		team := auth.Team{
+			OrgID:       req.orgID,
+			Name:        req.Name,
+			Description: req.Description,
+		}
+
+		team, err := svc.CreateTeam(ctx, req.token, team)
+		if err != nil {
+			return nil, err
+		}
+
+		return teamRes{id: team.ID, created: true}, nil
+	}
+}
+
+func viewTeamEndpoint(svc auth.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(teamReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		team, err := svc.ViewTeam(ctx, req.token, req.id)
+		if err != nil {
+			return nil, err
+		}
+
+		return toViewTeamRes(team), nil
+	}
+}
+
+func listTeamsEndpoint(svc auth.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		pm := auth.PageMetadata{
+			Offset: req.offset,
+			Limit:  req.limit,
+		}
+
+		page, err := svc.ListTeams(ctx, req.token, req.id, pm)
+		if err != nil {
+			return nil, err
+		}
+
+		res := teamsPageRes{
+			pageRes: pageRes{
+				Total:  page.Total,
+				Offset: page.Offset,
+				Limit:  page.Limit,
+			},
+			Teams: []viewTeamRes{},
+		}
+		for _, t := range page.Teams {
+			res.Teams = append(res.Teams, toViewTeamRes(t))
+		}
+
+		return res, nil
+	}
+}
+
+func updateTeamEndpoint(svc auth.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(updateTeamReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		team := auth.Team{
+			ID:          req.id,
+			Name:        req.Name,
+			Description: req.Description,
+		}
+
+		if err := svc.UpdateTeam(ctx, req.token, team); err != nil {
+			return nil, err
+		}
+
+		return teamRes{created: false}, nil
+	}
+}
+
+func removeTeamEndpoint(svc auth.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(teamReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		if err := svc.RemoveTeam(ctx, req.token, req.id); err != nil {
+			return nil, err
+		}
+
+		return emptyRes{code: http.StatusNoContent}, nil
+	}
+}
+
+func assignMembersEndpoint(svc auth.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(membersReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		if err := svc.AssignTeamMembers(ctx, req.token, req.id, req.MemberIDs...); err != nil {
+			return nil, err
+		}
+
+		return emptyRes{code: http.StatusOK}, nil
+	}
+}
+
+func unassignMembersEndpoint(svc auth.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(membersReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		if err := svc.UnassignTeamMembers(ctx, req.token, req.id, req.MemberIDs...); err != nil {
+			return nil, err
+		}
+
+		return emptyRes{code: http.StatusNoContent}, nil
+	}
+}
+
+func listMembersEndpoint(svc auth.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		pm := auth.PageMetadata{
+			Offset: req.offset,
+			Limit:  req.limit,
+		}
+
+		page, err := svc.ListTeamMembers(ctx, req.token, req.id, pm)
+		if err != nil {
+			return nil, err
+		}
+
+		res := membersPageRes{
+			pageRes: pageRes{
+				Total:  page.Total,
+				Offset: page.Offset,
+				Limit:  page.Limit,
+			},
+			Members: []memberRes{},
+		}
+		for _, m := range page.Members {
+			res.Members = append(res.Members, memberRes{ID: m.ID, Email: m.Email})
+		}
+
+		return res, nil
+	}
+}
+
+func grantAccessEndpoint(svc auth.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(grantReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		if err := svc.GrantTeamAccess(ctx, req.token, req.id, req.GroupID, req.Policy); err != nil {
+			return nil, err
+		}
+
+		return emptyRes{code: http.StatusCreated}, nil
+	}
+}
+
+func revokeAccessEndpoint(svc auth.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(revokeReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		if err := svc.RevokeTeamAccess(ctx, req.token, req.id, req.groupID); err != nil {
+			return nil, err
+		}
+
+		return emptyRes{code: http.StatusNoContent}, nil
+	}
+}
+
+func listPoliciesEndpoint(svc auth.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(teamReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		tps, err := svc.ListTeamPolicies(ctx, req.token, req.id)
+		if err != nil {
+			return nil, err
+		}
+
+		res := policiesRes{Policies: []policyRes{}}
+		for _, tp := range tps {
+			res.Policies = append(res.Policies, policyRes{GroupID: tp.GroupID, Policy: tp.Policy})
+		}
+
+		return res, nil
+	}
+}
+
+func toViewTeamRes(t auth.Team) viewTeamRes {
+	return viewTeamRes{
+		ID:          t.ID,
+		OrgID:       t.OrgID,
+		OwnerID:     t.OwnerID,
+		Name:        t.Name,
+		Description: t.Description,
+		CreatedAt:   t.CreatedAt,
+		UpdatedAt:   t.UpdatedAt,
+	}
+}
@@ -0,0 +1,166 @@
+package teams
+
+import (
+	"github.com/MainfluxLabs/mainflux/auth"
+	"github.com/MainfluxLabs/mainflux/internal/apiutil"
+)
+
+const (
+	maxNameSize = 254
+	maxLimit    = 100
+)
+
+type createTeamReq struct {
+	token       string
+	orgID       string
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+}
+
+func (req createTeamReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	if req.orgID == "" {
+		return apiutil.ErrMissingID
+	}
+
+	if len(req.Name) > maxNameSize || req.Name == "" {
+		return apiutil.ErrNameSize
+	}
+
+	return nil
+}
+
+type updateTeamReq struct {
+	token       string
+	id          string
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+}
+
+func (req updateTeamReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	if req.id == "" {
+		return apiutil.ErrMissingID
+	}
+
+	if len(req.Name) > maxNameSize || req.Name == "" {
+		return apiutil.ErrNameSize
+	}
+
+	return nil
+}
+
+type teamReq struct {
+	token string
+	id    string
+}
+
+func (req teamReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	if req.id == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return nil
+}
+
+type listReq struct {
+	token  string
+	id     string
+	offset uint64
+	limit  uint64
+}
+
+func (req listReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	if req.id == "" {
+		return apiutil.ErrMissingID
+	}
+
+	if req.limit > maxLimit || req.limit == 0 {
+		return apiutil.ErrLimitSize
+	}
+
+	return nil
+}
+
+type membersReq struct {
+	token     string
+	id        string
+	MemberIDs []string `json:"member_ids"`
+}
+
+func (req membersReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	if req.id == "" {
+		return apiutil.ErrMissingID
+	}
+
+	if len(req.MemberIDs) == 0 {
+		return apiutil.ErrEmptyList
+	}
+
+	for _, id := range req.MemberIDs {
+		if id == "" {
+			return apiutil.ErrMissingID
+		}
+	}
+
+	return nil
+}
+
+type grantReq struct {
+	token   string
+	id      string
+	GroupID string `json:"group_id"`
+	Policy  string `json:"policy"`
+}
+
+func (req grantReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	if req.id == "" || req.GroupID == "" {
+		return apiutil.ErrMissingID
+	}
+
+	if req.Policy != auth.RPolicy && req.Policy != auth.RwPolicy {
+		return apiutil.ErrInvalidPolicy
+	}
+
+	return nil
+}
+
+type revokeReq struct {
+	token   string
+	id      string
+	groupID string
+}
+
+func (req revokeReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	if req.id == "" || req.groupID == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return nil
+}
@@ -0,0 +1,149 @@
+package teams
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux"
+)
+
+var (
+	_ mainflux.Response = (*teamRes)(nil)
+	_ mainflux.Response = (*viewTeamRes)(nil)
+	_ mainflux.Response = (*teamsPageRes)(nil)
+	_ mainflux.Response = (*membersPageRes)(nil)
+	_ mainflux.Response = (*policiesRes)(nil)
+	_ mainflux.Response = (*emptyRes)(nil)
+)
+
+type teamRes struct {
+	id      string
+	created bool
+}
+
+func (res teamRes) Code() int {
+	if res.created {
+		return http.StatusCreated
+	}
+
+	return http.StatusOK
+}
+
+func (res teamRes) Headers() map[string]string {
+	if res.created {
+		return map[string]string{
+			"Location": fmt.Sprintf("/teams/%s", res.id),
+		}
+	}
+
+	return map[string]string{}
+}
+
+func (res teamRes) Empty() bool {
+	return true
+}
+
+type viewTeamRes struct {
+	ID          string    `json:"id"`
+	OrgID       string    `json:"org_id"`
+	OwnerID     string    `json:"owner_id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+func (res viewTeamRes) Code() int {
+	return http.StatusOK
+}
+
+func (res viewTeamRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res viewTeamRes) Empty() bool {
+	return false
+}
+
+type pageRes struct {
+	Total  uint64 `json:"total"`
+	Offset uint64 `json:"offset"`
+	Limit  uint64 `json:"limit"`
+}
+
+type teamsPageRes struct {
+	pageRes
+	Teams []viewTeamRes `json:"teams"`
+}
+
+func (res teamsPageRes) Code() int {
+	return http.StatusOK
+}
+
+func (res teamsPageRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res teamsPageRes) Empty() bool {
+	return false
+}
+
+type memberRes struct {
+	ID    string `json:"id"`
+	Email string `json:"email"`
+}
+
+type membersPageRes struct {
+	pageRes
+	Members []memberRes `json:"members"`
+}
+
+func (res membersPageRes) Code() int {
+	return http.StatusOK
+}
+
+func (res membersPageRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res membersPageRes) Empty() bool {
+	return false
+}
+
+type policyRes struct {
+	GroupID string `json:"group_id"`
+	Policy  string `json:"policy"`
+}
+
+type policiesRes struct {
+	Policies []policyRes `json:"policies"`
+}
+
+func (res policiesRes) Code() int {
+	return http.StatusOK
+}
+
+func (res policiesRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res policiesRes) Empty() bool {
+	return false
+}
+
+type emptyRes struct {
+	code int
+}
+
+func (res emptyRes) Code() int {
+	return res.code
+}
+
+func (res emptyRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res emptyRes) Empty() bool {
+	return true
+}
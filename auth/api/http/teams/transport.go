@@ -0,0 +1,274 @@
+package teams
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/MainfluxLabs/mainflux"
+	"github.com/MainfluxLabs/mainflux/auth"
+	"github.com/MainfluxLabs/mainflux/internal/apiutil"
+	"github.com/MainfluxLabs/mainflux/logger"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	kitot "github.com/go-kit/kit/tracing/opentracing"
+	kithttp "github.com/go-kit/kit/transport/http"
+	"github.com/go-zoo/bone"
+	"github.com/opentracing/opentracing-go"
+)
+
+const (
+	contentType = "application/json"
+	offsetKey   = "offset"
+	limitKey    = "limit"
+	defOffset   = 0
+	defLimit    = 10
+	orgIDKey    = "orgID"
+	teamIDKey   = "teamID"
+	groupIDKey  = "groupID"
+)
+
+// MakeHandler returns a HTTP handler for API endpoints.
+func MakeHandler(svc auth.Service, mux *bone.Mux, tracer opentracing.Tracer, logger logger.Logger) *bone.Mux {
+	opts := []kithttp.ServerOption{
+		kithttp.ServerErrorEncoder(apiutil.LoggingErrorEncoder(logger, encodeError)),
+	}
+
+	mux.Post("/orgs/:orgID/teams", kithttp.NewServer(
+		kitot.TraceServer(tracer, "create_team")(createTeamEndpoint(svc)),
+		decodeCreateTeamRequest,
+		encodeResponse,
+		opts...,
+	))
+
+	mux.Get("/orgs/:orgID/teams", kithttp.NewServer(
+		kitot.TraceServer(tracer, "list_teams")(listTeamsEndpoint(svc)),
+		decodeListRequest(orgIDKey),
+		encodeResponse,
+		opts...,
+	))
+
+	mux.Get("/teams/:teamID", kithttp.NewServer(
+		kitot.TraceServer(tracer, "view_team")(viewTeamEndpoint(svc)),
+		decodeTeamRequest,
+		encodeResponse,
+		opts...,
+	))
+
+	mux.Put("/teams/:teamID", kithttp.NewServer(
+		kitot.TraceServer(tracer, "update_team")(updateTeamEndpoint(svc)),
+		decodeUpdateTeamRequest,
+		encodeResponse,
+		opts...,
+	))
+
+	mux.Delete("/teams/:teamID", kithttp.NewServer(
+		kitot.TraceServer(tracer, "remove_team")(removeTeamEndpoint(svc)),
+		decodeTeamRequest,
+		encodeResponse,
+		opts...,
+	))
+
+	mux.Post("/teams/:teamID/members", kithttp.NewServer(
+		kitot.TraceServer(tracer, "assign_team_members")(assignMembersEndpoint(svc)),
+		decodeMembersRequest,
+		encodeResponse,
+		opts...,
+	))
+
+	mux.Delete("/teams/:teamID/members", kithttp.NewServer(
+		kitot.TraceServer(tracer, "unassign_team_members")(unassignMembersEndpoint(svc)),
+		decodeMembersRequest,
+		encodeResponse,
+		opts...,
+	))
+
+	mux.Get("/teams/:teamID/members", kithttp.NewServer(
+		kitot.TraceServer(tracer, "list_team_members")(listMembersEndpoint(svc)),
+		decodeListRequest(teamIDKey),
+		encodeResponse,
+		opts...,
+	))
+
+	mux.Post("/teams/:teamID/groups", kithttp.NewServer(
+		kitot.TraceServer(tracer, "grant_team_access")(grantAccessEndpoint(svc)),
+		decodeGrantRequest,
+		encodeResponse,
+		opts...,
+	))
+
+	mux.Get("/teams/:teamID/groups", kithttp.NewServer(
+		kitot.TraceServer(tracer, "list_team_policies")(listPoliciesEndpoint(svc)),
+		decodeTeamRequest,
+		encodeResponse,
+		opts...,
+	))
+
+	mux.Delete("/teams/:teamID/groups/:groupID", kithttp.NewServer(
+		kitot.TraceServer(tracer, "revoke_team_access")(revokeAccessEndpoint(svc)),
+		decodeRevokeRequest,
+		encodeResponse,
+		opts...,
+	))
+
+	return mux
+}
+
+func decodeCreateTeamRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), contentType) {
+		return nil, apiutil.ErrUnsupportedContentType
+	}
+
+	req := createTeamReq{
+		token: apiutil.ExtractBearerToken(r),
+		orgID: bone.GetValue(r, orgIDKey),
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(apiutil.ErrMalformedEntity, err)
+	}
+
+	return req, nil
+}
+
+func decodeUpdateTeamRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), contentType) {
+		return nil, apiutil.ErrUnsupportedContentType
+	}
+
+	req := updateTeamReq{
+		token: apiutil.ExtractBearerToken(r),
+		id:    bone.GetValue(r, teamIDKey),
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(apiutil.ErrMalformedEntity, err)
+	}
+
+	return req, nil
+}
+
+func decodeTeamRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	req := teamReq{
+		token: apiutil.ExtractBearerToken(r),
+		id:    bone.GetValue(r, teamIDKey),
+	}
+
+	return req, nil
+}
+
+func decodeListRequest(idKey string) kithttp.DecodeRequestFunc {
+	return func(_ context.Context, r *http.Request) (interface{}, error) {
+		o, err := apiutil.ReadUintQuery(r, offsetKey, defOffset)
+		if err != nil {
+			return nil, err
+		}
+
+		l, err := apiutil.ReadUintQuery(r, limitKey, defLimit)
+		if err != nil {
+			return nil, err
+		}
+
+		req := listReq{
+			token:  apiutil.ExtractBearerToken(r),
+			id:     bone.GetValue(r, idKey),
+			offset: o,
+			limit:  l,
+		}
+
+		return req, nil
+	}
+}
+
+func decodeMembersRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), contentType) {
+		return nil, apiutil.ErrUnsupportedContentType
+	}
+
+	req := membersReq{
+		token: apiutil.ExtractBearerToken(r),
+		id:    bone.GetValue(r, teamIDKey),
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(apiutil.ErrMalformedEntity, err)
+	}
+
+	return req, nil
+}
+
+func decodeGrantRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), contentType) {
+		return nil, apiutil.ErrUnsupportedContentType
+	}
+
+	req := grantReq{
+		token: apiutil.ExtractBearerToken(r),
+		id:    bone.GetValue(r, teamIDKey),
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(apiutil.ErrMalformedEntity, err)
+	}
+
+	return req, nil
+}
+
+func decodeRevokeRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	req := revokeReq{
+		token:   apiutil.ExtractBearerToken(r),
+		id:      bone.GetValue(r, teamIDKey),
+		groupID: bone.GetValue(r, groupIDKey),
+	}
+
+	return req, nil
+}
+
+func encodeResponse(_ context.Context, w http.ResponseWriter, response interface{}) error {
+	w.Header().Set("Content-Type", contentType)
+
+	if ar, ok := response.(mainflux.Response); ok {
+		for k, v := range ar.Headers() {
+			w.Header().Set(k, v)
+		}
+
+		w.WriteHeader(ar.Code())
+
+		if ar.Empty() {
+			return nil
+		}
+	}
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+func encodeError(ctx context.Context, err error, w http.ResponseWriter) {
+	switch {
+	case errors.Contains(err, apiutil.ErrMalformedEntity),
+		err == apiutil.ErrMissingID,
+		err == apiutil.ErrEmptyList,
+		err == apiutil.ErrNameSize,
+		err == apiutil.ErrLimitSize,
+		err == apiutil.ErrInvalidPolicy,
+		errors.Contains(err, apiutil.ErrInvalidQueryParams),
+		errors.Contains(err, auth.ErrInvalidPolicy),
+		errors.Contains(err, auth.ErrNotOrgMember):
+		w.WriteHeader(http.StatusBadRequest)
+	case errors.Contains(err, errors.ErrAuthentication),
+		err == apiutil.ErrBearerToken:
+		w.WriteHeader(http.StatusUnauthorized)
+	case errors.Contains(err, errors.ErrNotFound):
+		w.WriteHeader(http.StatusNotFound)
+	case errors.Contains(err, errors.ErrConflict):
+		w.WriteHeader(http.StatusConflict)
+	case errors.Contains(err, errors.ErrAuthorization):
+		w.WriteHeader(http.StatusForbidden)
+	case errors.Contains(err, apiutil.ErrUnsupportedContentType):
+		w.WriteHeader(http.StatusUnsupportedMediaType)
+	default:
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+
+	if errorVal, ok := err.(errors.Error); ok {
+		w.Header().Set("Content-Type", contentType)
+		if err := json.NewEncoder(w).Encode(apiutil.NewErrorRes(ctx, errorVal)); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}
+}
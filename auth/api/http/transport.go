@@ -9,6 +9,9 @@ import (
 	"github.com/MainfluxLabs/mainflux/auth"
 	"github.com/MainfluxLabs/mainflux/auth/api/http/keys"
 	"github.com/MainfluxLabs/mainflux/auth/api/http/orgs"
+	"github.com/MainfluxLabs/mainflux/auth/api/http/policies"
+	"github.com/MainfluxLabs/mainflux/auth/api/http/teams"
+	"github.com/MainfluxLabs/mainflux/internal/apiutil"
 	"github.com/MainfluxLabs/mainflux/logger"
 	"github.com/go-zoo/bone"
 	"github.com/opentracing/opentracing-go"
@@ -16,11 +19,14 @@ import (
 )
 
 // MakeHandler returns a HTTP handler for API endpoints.
-func MakeHandler(svc auth.Service, tracer opentracing.Tracer, logger logger.Logger) http.Handler {
+func MakeHandler(svc auth.Service, tracer opentracing.Tracer, logger logger.Logger, checks ...mainflux.HealthCheck) http.Handler {
 	mux := bone.New()
 	mux = orgs.MakeHandler(svc, mux, tracer, logger)
 	mux = keys.MakeHandler(svc, mux, tracer, logger)
-	mux.GetFunc("/health", mainflux.Health("auth"))
+	mux = policies.MakeHandler(svc, mux, tracer, logger)
+	mux = teams.MakeHandler(svc, mux, tracer, logger)
+	mux.GetFunc("/health", mainflux.Health("auth", checks...))
 	mux.Handle("/metrics", promhttp.Handler())
-	return mux
+	mux.Handle("/log-level", mainflux.LogLevel(logger))
+	return apiutil.RequestIDMiddleware(apiutil.LocaleMiddleware(mux))
 }
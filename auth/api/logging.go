@@ -28,16 +28,17 @@ func LoggingMiddleware(svc auth.Service, logger log.Logger) auth.Service {
 
 func (lm *loggingMiddleware) Issue(ctx context.Context, token string, newKey auth.Key) (key auth.Key, secret string, err error) {
 	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "issue", "latency", time.Since(begin).String())
 		d := "infinite duration"
 		if !key.ExpiresAt.IsZero() {
 			d = fmt.Sprintf("the key with expiration date %v", key.ExpiresAt)
 		}
 		message := fmt.Sprintf("Method issue for %s took %s to complete", d, time.Since(begin))
 		if err != nil {
-			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
 			return
 		}
-		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+		l.Info(fmt.Sprintf("%s without errors.", message))
 	}(time.Now())
 
 	return lm.svc.Issue(ctx, token, newKey)
@@ -45,25 +46,97 @@ func (lm *loggingMiddleware) Issue(ctx context.Context, token string, newKey aut
 
 func (lm *loggingMiddleware) Revoke(ctx context.Context, token, id string) (err error) {
 	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "revoke", "latency", time.Since(begin).String())
 		message := fmt.Sprintf("Method revoke for key %s took %s to complete", id, time.Since(begin))
 		if err != nil {
-			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
 			return
 		}
-		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+		l.Info(fmt.Sprintf("%s without errors.", message))
 	}(time.Now())
 
 	return lm.svc.Revoke(ctx, token, id)
 }
 
+func (lm *loggingMiddleware) Refresh(ctx context.Context, token string) (access, refresh string, err error) {
+	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "refresh", "latency", time.Since(begin).String())
+		message := fmt.Sprintf("Method refresh took %s to complete", time.Since(begin))
+		if err != nil {
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		l.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.Refresh(ctx, token)
+}
+
+func (lm *loggingMiddleware) RevokeAll(ctx context.Context, token string) (err error) {
+	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "revoke_all", "latency", time.Since(begin).String())
+		message := fmt.Sprintf("Method revoke_all took %s to complete", time.Since(begin))
+		if err != nil {
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		l.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.RevokeAll(ctx, token)
+}
+
+func (lm *loggingMiddleware) OpenSession(ctx context.Context, s auth.Session, key auth.Key) (k auth.Key, secret string, err error) {
+	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "open_session", "latency", time.Since(begin).String())
+		message := fmt.Sprintf("Method open_session for session %s took %s to complete", s.ID, time.Since(begin))
+		if err != nil {
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		l.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.OpenSession(ctx, s, key)
+}
+
+func (lm *loggingMiddleware) ListSessions(ctx context.Context, token, userID string) (s []auth.Session, err error) {
+	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "list_sessions", "latency", time.Since(begin).String())
+		message := fmt.Sprintf("Method list_sessions for user %s took %s to complete", userID, time.Since(begin))
+		if err != nil {
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		l.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ListSessions(ctx, token, userID)
+}
+
+func (lm *loggingMiddleware) RevokeSession(ctx context.Context, token, userID, id string) (err error) {
+	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "revoke_session", "latency", time.Since(begin).String())
+		message := fmt.Sprintf("Method revoke_session for session %s took %s to complete", id, time.Since(begin))
+		if err != nil {
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		l.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.RevokeSession(ctx, token, userID, id)
+}
+
 func (lm *loggingMiddleware) RetrieveKey(ctx context.Context, token, id string) (key auth.Key, err error) {
 	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "retrieve", "latency", time.Since(begin).String())
 		message := fmt.Sprintf("Method retrieve for key %s took %s to complete", id, time.Since(begin))
 		if err != nil {
-			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
 			return
 		}
-		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+		l.Info(fmt.Sprintf("%s without errors.", message))
 	}(time.Now())
 
 	return lm.svc.RetrieveKey(ctx, token, id)
@@ -71,12 +144,13 @@ func (lm *loggingMiddleware) RetrieveKey(ctx context.Context, token, id string)
 
 func (lm *loggingMiddleware) Identify(ctx context.Context, key string) (id auth.Identity, err error) {
 	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "identify", "latency", time.Since(begin).String())
 		message := fmt.Sprintf("Method identify took %s to complete", time.Since(begin))
 		if err != nil {
-			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
 			return
 		}
-		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+		l.Info(fmt.Sprintf("%s without errors.", message))
 	}(time.Now())
 
 	return lm.svc.Identify(ctx, key)
@@ -84,24 +158,26 @@ func (lm *loggingMiddleware) Identify(ctx context.Context, key string) (id auth.
 
 func (lm *loggingMiddleware) Authorize(ctx context.Context, ar auth.AuthzReq) (err error) {
 	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "authorize", "latency", time.Since(begin).String())
 		message := fmt.Sprintf("Method authorize took %s to complete", time.Since(begin))
 		if err != nil {
-			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
 			return
 		}
-		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+		l.Info(fmt.Sprintf("%s without errors.", message))
 	}(time.Now())
 	return lm.svc.Authorize(ctx, ar)
 }
 
 func (lm *loggingMiddleware) CreateOrg(ctx context.Context, token string, org auth.Org) (o auth.Org, err error) {
 	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "create_org", "latency", time.Since(begin).String())
 		message := fmt.Sprintf("Method create_org for token %s and name %s took %s to complete", token, org.Name, time.Since(begin))
 		if err != nil {
-			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
 			return
 		}
-		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+		l.Info(fmt.Sprintf("%s without errors.", message))
 	}(time.Now())
 
 	return lm.svc.CreateOrg(ctx, token, org)
@@ -109,12 +185,13 @@ func (lm *loggingMiddleware) CreateOrg(ctx context.Context, token string, org au
 
 func (lm *loggingMiddleware) UpdateOrg(ctx context.Context, token string, org auth.Org) (o auth.Org, err error) {
 	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "update_org", "latency", time.Since(begin).String())
 		message := fmt.Sprintf("Method update_org for token %s and name %s took %s to complete", token, org.Name, time.Since(begin))
 		if err != nil {
-			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
 			return
 		}
-		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+		l.Info(fmt.Sprintf("%s without errors.", message))
 	}(time.Now())
 
 	return lm.svc.UpdateOrg(ctx, token, org)
@@ -122,12 +199,13 @@ func (lm *loggingMiddleware) UpdateOrg(ctx context.Context, token string, org au
 
 func (lm *loggingMiddleware) RemoveOrg(ctx context.Context, token string, id string) (err error) {
 	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "remove_org", "latency", time.Since(begin).String())
 		message := fmt.Sprintf("Method remove_org for token %s and id %s took %s to complete", token, id, time.Since(begin))
 		if err != nil {
-			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
 			return
 		}
-		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+		l.Info(fmt.Sprintf("%s without errors.", message))
 	}(time.Now())
 
 	return lm.svc.RemoveOrg(ctx, token, id)
@@ -135,12 +213,13 @@ func (lm *loggingMiddleware) RemoveOrg(ctx context.Context, token string, id str
 
 func (lm *loggingMiddleware) ViewOrg(ctx context.Context, token, id string) (o auth.Org, err error) {
 	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "view_org", "latency", time.Since(begin).String())
 		message := fmt.Sprintf("Method view_org for token %s and id %s took %s to complete", token, id, time.Since(begin))
 		if err != nil {
-			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
 			return
 		}
-		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+		l.Info(fmt.Sprintf("%s without errors.", message))
 	}(time.Now())
 
 	return lm.svc.ViewOrg(ctx, token, id)
@@ -148,12 +227,13 @@ func (lm *loggingMiddleware) ViewOrg(ctx context.Context, token, id string) (o a
 
 func (lm *loggingMiddleware) ListOrgs(ctx context.Context, token string, admin bool, pm auth.PageMetadata) (gp auth.OrgsPage, err error) {
 	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "list_orgs", "latency", time.Since(begin).String())
 		message := fmt.Sprintf("Method list_orgs for token %s took %s to complete", token, time.Since(begin))
 		if err != nil {
-			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
 			return
 		}
-		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+		l.Info(fmt.Sprintf("%s without errors.", message))
 	}(time.Now())
 
 	return lm.svc.ListOrgs(ctx, token, admin, pm)
@@ -161,12 +241,13 @@ func (lm *loggingMiddleware) ListOrgs(ctx context.Context, token string, admin b
 
 func (lm *loggingMiddleware) ViewMember(ctx context.Context, token, orgID, memberID string) (m auth.Member, err error) {
 	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "view_member", "latency", time.Since(begin).String())
 		message := fmt.Sprintf("Method view_member for token %s and org id %s and member id %s took %s to complete", token, orgID, memberID, time.Since(begin))
 		if err != nil {
-			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
 			return
 		}
-		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+		l.Info(fmt.Sprintf("%s without errors.", message))
 	}(time.Now())
 
 	return lm.svc.ViewMember(ctx, token, orgID, memberID)
@@ -174,25 +255,41 @@ func (lm *loggingMiddleware) ViewMember(ctx context.Context, token, orgID, membe
 
 func (lm *loggingMiddleware) ListOrgMembers(ctx context.Context, token, orgID string, pm auth.PageMetadata) (op auth.MembersPage, err error) {
 	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "list_org_members", "latency", time.Since(begin).String())
 		message := fmt.Sprintf("Method list_org_members for token %s and org id %s took %s to complete", token, orgID, time.Since(begin))
 		if err != nil {
-			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
 			return
 		}
-		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+		l.Info(fmt.Sprintf("%s without errors.", message))
 	}(time.Now())
 
 	return lm.svc.ListOrgMembers(ctx, token, orgID, pm)
 }
 
+func (lm *loggingMiddleware) RetrieveMemberships(ctx context.Context, memberID string) (orgIDs []string, err error) {
+	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "retrieve_memberships", "latency", time.Since(begin).String())
+		message := fmt.Sprintf("Method retrieve_memberships for member id %s took %s to complete", memberID, time.Since(begin))
+		if err != nil {
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		l.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.RetrieveMemberships(ctx, memberID)
+}
+
 func (lm *loggingMiddleware) ListOrgMemberships(ctx context.Context, token, memberID string, pm auth.PageMetadata) (op auth.OrgsPage, err error) {
 	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "list_org_memberships", "latency", time.Since(begin).String())
 		message := fmt.Sprintf("Method list_org_memberships for token %s and member id %s took %s to complete", token, memberID, time.Since(begin))
 		if err != nil {
-			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
 			return
 		}
-		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+		l.Info(fmt.Sprintf("%s without errors.", message))
 	}(time.Now())
 
 	return lm.svc.ListOrgMemberships(ctx, token, memberID, pm)
@@ -200,12 +297,13 @@ func (lm *loggingMiddleware) ListOrgMemberships(ctx context.Context, token, memb
 
 func (lm *loggingMiddleware) AssignMembersByIDs(ctx context.Context, token, orgID string, memberIDs ...string) (err error) {
 	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "assign_members_by_ids", "latency", time.Since(begin).String())
 		message := fmt.Sprintf("Method assign_members_by_ids for token %s , member ids %s and org id %s took %s to complete", token, memberIDs, orgID, time.Since(begin))
 		if err != nil {
-			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
 			return
 		}
-		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+		l.Info(fmt.Sprintf("%s without errors.", message))
 	}(time.Now())
 
 	return lm.svc.AssignMembersByIDs(ctx, token, orgID, memberIDs...)
@@ -213,12 +311,13 @@ func (lm *loggingMiddleware) AssignMembersByIDs(ctx context.Context, token, orgI
 
 func (lm *loggingMiddleware) AssignMembers(ctx context.Context, token, orgID string, members ...auth.Member) (err error) {
 	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "assign_members", "latency", time.Since(begin).String())
 		message := fmt.Sprintf("Method assign_members for token %s , members %s and org id %s took %s to complete", token, members, orgID, time.Since(begin))
 		if err != nil {
-			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
 			return
 		}
-		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+		l.Info(fmt.Sprintf("%s without errors.", message))
 	}(time.Now())
 
 	return lm.svc.AssignMembers(ctx, token, orgID, members...)
@@ -226,12 +325,13 @@ func (lm *loggingMiddleware) AssignMembers(ctx context.Context, token, orgID str
 
 func (lm *loggingMiddleware) UnassignMembersByIDs(ctx context.Context, token string, orgID string, memberIDs ...string) (err error) {
 	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "unassign_members_by_ids", "latency", time.Since(begin).String())
 		message := fmt.Sprintf("Method unassign_members_by_ids for token %s , member ids %s and org id %s took %s to complete", token, memberIDs, orgID, time.Since(begin))
 		if err != nil {
-			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
 			return
 		}
-		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+		l.Info(fmt.Sprintf("%s without errors.", message))
 	}(time.Now())
 
 	return lm.svc.UnassignMembersByIDs(ctx, token, orgID, memberIDs...)
@@ -239,12 +339,13 @@ func (lm *loggingMiddleware) UnassignMembersByIDs(ctx context.Context, token str
 
 func (lm *loggingMiddleware) UnassignMembers(ctx context.Context, token string, orgID string, memberIDs ...string) (err error) {
 	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "unassign_members", "latency", time.Since(begin).String())
 		message := fmt.Sprintf("Method unassign_members for token %s , member ids %s and org id %s took %s to complete", token, memberIDs, orgID, time.Since(begin))
 		if err != nil {
-			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
 			return
 		}
-		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+		l.Info(fmt.Sprintf("%s without errors.", message))
 	}(time.Now())
 
 	return lm.svc.UnassignMembers(ctx, token, orgID, memberIDs...)
@@ -252,12 +353,13 @@ func (lm *loggingMiddleware) UnassignMembers(ctx context.Context, token string,
 
 func (lm *loggingMiddleware) UpdateMembers(ctx context.Context, token, orgID string, members ...auth.Member) (err error) {
 	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "update_members", "latency", time.Since(begin).String())
 		message := fmt.Sprintf("Method update_members for token %s , members %s and org id %s took %s to complete", token, members, orgID, time.Since(begin))
 		if err != nil {
-			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
 			return
 		}
-		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+		l.Info(fmt.Sprintf("%s without errors.", message))
 	}(time.Now())
 
 	return lm.svc.UpdateMembers(ctx, token, orgID, members...)
@@ -265,12 +367,13 @@ func (lm *loggingMiddleware) UpdateMembers(ctx context.Context, token, orgID str
 
 func (lm *loggingMiddleware) AssignGroups(ctx context.Context, token, orgID string, groupIDs ...string) (err error) {
 	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "assign_groups", "latency", time.Since(begin).String())
 		message := fmt.Sprintf("Method assign_groups for token %s , group ids %s and org id %s took %s to complete", token, groupIDs, orgID, time.Since(begin))
 		if err != nil {
-			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
 			return
 		}
-		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+		l.Info(fmt.Sprintf("%s without errors.", message))
 	}(time.Now())
 
 	return lm.svc.AssignGroups(ctx, token, orgID, groupIDs...)
@@ -278,12 +381,13 @@ func (lm *loggingMiddleware) AssignGroups(ctx context.Context, token, orgID stri
 
 func (lm *loggingMiddleware) UnassignGroups(ctx context.Context, token string, orgID string, groupIDs ...string) (err error) {
 	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "unassign_groups", "latency", time.Since(begin).String())
 		message := fmt.Sprintf("Method unassign_groups for token %s, group ids %s and org id %s took %s to complete", token, groupIDs, orgID, time.Since(begin))
 		if err != nil {
-			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
 			return
 		}
-		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+		l.Info(fmt.Sprintf("%s without errors.", message))
 	}(time.Now())
 
 	return lm.svc.UnassignGroups(ctx, token, orgID, groupIDs...)
@@ -291,38 +395,251 @@ func (lm *loggingMiddleware) UnassignGroups(ctx context.Context, token string, o
 
 func (lm *loggingMiddleware) ListOrgGroups(ctx context.Context, token, orgID string, pm auth.PageMetadata) (op auth.GroupsPage, err error) {
 	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "list_org_groups", "latency", time.Since(begin).String())
 		message := fmt.Sprintf("Method list_org_groups for token %s and org id %s took %s to complete", token, orgID, time.Since(begin))
 		if err != nil {
-			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
 			return
 		}
-		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+		l.Info(fmt.Sprintf("%s without errors.", message))
 	}(time.Now())
 
 	return lm.svc.ListOrgGroups(ctx, token, orgID, pm)
 }
 
-func (lm *loggingMiddleware) AddPolicy(ctx context.Context, token, groupID, policy string) (err error) {
+func (lm *loggingMiddleware) AddPolicy(ctx context.Context, token, memberID, subject, object, policy string) (err error) {
+	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "add_policy", "latency", time.Since(begin).String())
+		message := fmt.Sprintf("Method add_policy for %s %s took %s to complete", subject, object, time.Since(begin))
+		if err != nil {
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		l.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.AddPolicy(ctx, token, memberID, subject, object, policy)
+}
+
+func (lm *loggingMiddleware) ClaimOwnership(ctx context.Context, ownerID, subject, object string) (err error) {
+	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "claim_ownership", "latency", time.Since(begin).String())
+		message := fmt.Sprintf("Method claim_ownership for %s %s took %s to complete", subject, object, time.Since(begin))
+		if err != nil {
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		l.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ClaimOwnership(ctx, ownerID, subject, object)
+}
+
+func (lm *loggingMiddleware) ShareObject(ctx context.Context, token, subject, object string, inv auth.ObjectInvitation) (err error) {
+	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "share_object", "latency", time.Since(begin).String())
+		message := fmt.Sprintf("Method share_object for %s %s took %s to complete", subject, object, time.Since(begin))
+		if err != nil {
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		l.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ShareObject(ctx, token, subject, object, inv)
+}
+
+func (lm *loggingMiddleware) ListObjectPolicies(ctx context.Context, token, subject, object string) (ps []auth.Policy, err error) {
+	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "list_object_policies", "latency", time.Since(begin).String())
+		message := fmt.Sprintf("Method list_object_policies for %s %s took %s to complete", subject, object, time.Since(begin))
+		if err != nil {
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		l.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ListObjectPolicies(ctx, token, subject, object)
+}
+
+func (lm *loggingMiddleware) UnshareObject(ctx context.Context, token, subject, object string, memberIDs ...string) (err error) {
+	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "unshare_object", "latency", time.Since(begin).String())
+		message := fmt.Sprintf("Method unshare_object for %s %s took %s to complete", subject, object, time.Since(begin))
+		if err != nil {
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		l.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.UnshareObject(ctx, token, subject, object, memberIDs...)
+}
+
+func (lm *loggingMiddleware) CreateTeam(ctx context.Context, token string, team auth.Team) (t auth.Team, err error) {
 	defer func(begin time.Time) {
-		message := fmt.Sprintf("Method add_policy for token %s and took %s to complete", token, time.Since(begin))
+		l := lm.logger.WithContext(ctx).With("method", "create_team", "latency", time.Since(begin).String())
+		message := fmt.Sprintf("Method create_team in org %s took %s to complete", team.OrgID, time.Since(begin))
 		if err != nil {
-			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
 			return
 		}
-		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+		l.Info(fmt.Sprintf("%s without errors.", message))
 	}(time.Now())
 
-	return lm.svc.AddPolicy(ctx, token, groupID, policy)
+	return lm.svc.CreateTeam(ctx, token, team)
+}
+
+func (lm *loggingMiddleware) ViewTeam(ctx context.Context, token, id string) (t auth.Team, err error) {
+	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "view_team", "latency", time.Since(begin).String())
+		message := fmt.Sprintf("Method view_team for id %s took %s to complete", id, time.Since(begin))
+		if err != nil {
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		l.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ViewTeam(ctx, token, id)
+}
+
+func (lm *loggingMiddleware) ListTeams(ctx context.Context, token, orgID string, pm auth.PageMetadata) (tp auth.TeamsPage, err error) {
+	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "list_teams", "latency", time.Since(begin).String())
+		message := fmt.Sprintf("Method list_teams for org %s took %s to complete", orgID, time.Since(begin))
+		if err != nil {
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		l.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ListTeams(ctx, token, orgID, pm)
+}
+
+func (lm *loggingMiddleware) UpdateTeam(ctx context.Context, token string, team auth.Team) (err error) {
+	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "update_team", "latency", time.Since(begin).String())
+		message := fmt.Sprintf("Method update_team for id %s took %s to complete", team.ID, time.Since(begin))
+		if err != nil {
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		l.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.UpdateTeam(ctx, token, team)
+}
+
+func (lm *loggingMiddleware) RemoveTeam(ctx context.Context, token, id string) (err error) {
+	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "remove_team", "latency", time.Since(begin).String())
+		message := fmt.Sprintf("Method remove_team for id %s took %s to complete", id, time.Since(begin))
+		if err != nil {
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		l.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.RemoveTeam(ctx, token, id)
+}
+
+func (lm *loggingMiddleware) AssignTeamMembers(ctx context.Context, token, teamID string, memberIDs ...string) (err error) {
+	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "assign_team_members", "latency", time.Since(begin).String())
+		message := fmt.Sprintf("Method assign_team_members for team %s took %s to complete", teamID, time.Since(begin))
+		if err != nil {
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		l.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.AssignTeamMembers(ctx, token, teamID, memberIDs...)
+}
+
+func (lm *loggingMiddleware) UnassignTeamMembers(ctx context.Context, token, teamID string, memberIDs ...string) (err error) {
+	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "unassign_team_members", "latency", time.Since(begin).String())
+		message := fmt.Sprintf("Method unassign_team_members for team %s took %s to complete", teamID, time.Since(begin))
+		if err != nil {
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		l.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.UnassignTeamMembers(ctx, token, teamID, memberIDs...)
+}
+
+func (lm *loggingMiddleware) ListTeamMembers(ctx context.Context, token, teamID string, pm auth.PageMetadata) (mp auth.MembersPage, err error) {
+	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "list_team_members", "latency", time.Since(begin).String())
+		message := fmt.Sprintf("Method list_team_members for team %s took %s to complete", teamID, time.Since(begin))
+		if err != nil {
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		l.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ListTeamMembers(ctx, token, teamID, pm)
+}
+
+func (lm *loggingMiddleware) GrantTeamAccess(ctx context.Context, token, teamID, groupID, policy string) (err error) {
+	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "grant_team_access", "latency", time.Since(begin).String())
+		message := fmt.Sprintf("Method grant_team_access for team %s and group %s took %s to complete", teamID, groupID, time.Since(begin))
+		if err != nil {
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		l.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.GrantTeamAccess(ctx, token, teamID, groupID, policy)
+}
+
+func (lm *loggingMiddleware) RevokeTeamAccess(ctx context.Context, token, teamID, groupID string) (err error) {
+	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "revoke_team_access", "latency", time.Since(begin).String())
+		message := fmt.Sprintf("Method revoke_team_access for team %s and group %s took %s to complete", teamID, groupID, time.Since(begin))
+		if err != nil {
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		l.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.RevokeTeamAccess(ctx, token, teamID, groupID)
+}
+
+func (lm *loggingMiddleware) ListTeamPolicies(ctx context.Context, token, teamID string) (tps []auth.TeamPolicy, err error) {
+	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "list_team_policies", "latency", time.Since(begin).String())
+		message := fmt.Sprintf("Method list_team_policies for team %s took %s to complete", teamID, time.Since(begin))
+		if err != nil {
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		l.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ListTeamPolicies(ctx, token, teamID)
 }
 
 func (lm *loggingMiddleware) Backup(ctx context.Context, token string) (backup auth.Backup, err error) {
 	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "backup", "latency", time.Since(begin).String())
 		message := fmt.Sprintf("Method backup for token %s took %s to complete", token, time.Since(begin))
 		if err != nil {
-			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
 			return
 		}
-		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+		l.Info(fmt.Sprintf("%s without errors.", message))
 	}(time.Now())
 
 	return lm.svc.Backup(ctx, token)
@@ -330,12 +647,13 @@ func (lm *loggingMiddleware) Backup(ctx context.Context, token string) (backup a
 
 func (lm *loggingMiddleware) Restore(ctx context.Context, token string, backup auth.Backup) (err error) {
 	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "restore", "latency", time.Since(begin).String())
 		message := fmt.Sprintf("Method restore for token %s took %s to complete", token, time.Since(begin))
 		if err != nil {
-			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
 			return
 		}
-		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+		l.Info(fmt.Sprintf("%s without errors.", message))
 	}(time.Now())
 
 	return lm.svc.Restore(ctx, token, backup)
@@ -343,9 +661,10 @@ func (lm *loggingMiddleware) Restore(ctx context.Context, token string, backup a
 
 func (lm *loggingMiddleware) AssignRole(ctx context.Context, id, role string) (err error) {
 	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "assign_role", "latency", time.Since(begin).String())
 		message := fmt.Sprintf("Method assign_role for id %s and role %s took %s to complete", id, role, time.Since(begin))
 		if err != nil {
-			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
 			return
 		}
 	}(time.Now())
@@ -353,11 +672,26 @@ func (lm *loggingMiddleware) AssignRole(ctx context.Context, id, role string) (e
 	return lm.svc.AssignRole(ctx, id, role)
 }
 
+func (lm *loggingMiddleware) RetrieveRole(ctx context.Context, id string) (role string, err error) {
+	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "retrieve_role", "latency", time.Since(begin).String())
+		message := fmt.Sprintf("Method retrieve_role for id %s took %s to complete", id, time.Since(begin))
+		if err != nil {
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		l.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.RetrieveRole(ctx, id)
+}
+
 func (lm *loggingMiddleware) CreatePolicies(ctx context.Context, token, groupID string, giByEmails ...auth.GroupInvitationByEmail) (err error) {
 	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "create_policies", "latency", time.Since(begin).String())
 		message := fmt.Sprintf("Method create_policies for token %s and group id %s took %s to complete", token, groupID, time.Since(begin))
 		if err != nil {
-			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
 			return
 		}
 	}(time.Now())
@@ -367,11 +701,12 @@ func (lm *loggingMiddleware) CreatePolicies(ctx context.Context, token, groupID
 
 func (lm *loggingMiddleware) ListMembersPolicies(ctx context.Context, token, groupID string, pm auth.PageMetadata) (mpp auth.GroupMembersPoliciesPage, err error) {
 	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "list_members_policies", "latency", time.Since(begin).String())
 		message := fmt.Sprintf("Method list_members_policies for token %s and group id %s took %s to complete", token, groupID, time.Since(begin))
 		if err != nil {
-			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
 		}
-		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+		l.Info(fmt.Sprintf("%s without errors.", message))
 	}(time.Now())
 
 	return lm.svc.ListMembersPolicies(ctx, token, groupID, pm)
@@ -379,9 +714,10 @@ func (lm *loggingMiddleware) ListMembersPolicies(ctx context.Context, token, gro
 
 func (lm *loggingMiddleware) UpdatePolicies(ctx context.Context, token, groupID string, giByEmails ...auth.GroupInvitationByEmail) (err error) {
 	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "update_policies", "latency", time.Since(begin).String())
 		message := fmt.Sprintf("Method update_policies for token %s and group id %s took %s to complete", token, groupID, time.Since(begin))
 		if err != nil {
-			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
 			return
 		}
 	}(time.Now())
@@ -391,9 +727,10 @@ func (lm *loggingMiddleware) UpdatePolicies(ctx context.Context, token, groupID
 
 func (lm *loggingMiddleware) RemovePolicies(ctx context.Context, token, groupID string, memberIDs ...string) (err error) {
 	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "remove_policies", "latency", time.Since(begin).String())
 		message := fmt.Sprintf("Method remove_policies for token %s and group id %s took %s to complete", token, groupID, time.Since(begin))
 		if err != nil {
-			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
 			return
 		}
 	}(time.Now())
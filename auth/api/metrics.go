@@ -48,6 +48,51 @@ func (ms *metricsMiddleware) Revoke(ctx context.Context, token, id string) error
 	return ms.svc.Revoke(ctx, token, id)
 }
 
+func (ms *metricsMiddleware) Refresh(ctx context.Context, token string) (string, string, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "refresh").Add(1)
+		ms.latency.With("method", "refresh").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.Refresh(ctx, token)
+}
+
+func (ms *metricsMiddleware) RevokeAll(ctx context.Context, token string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "revoke_all").Add(1)
+		ms.latency.With("method", "revoke_all").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.RevokeAll(ctx, token)
+}
+
+func (ms *metricsMiddleware) OpenSession(ctx context.Context, s auth.Session, key auth.Key) (auth.Key, string, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "open_session").Add(1)
+		ms.latency.With("method", "open_session").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.OpenSession(ctx, s, key)
+}
+
+func (ms *metricsMiddleware) ListSessions(ctx context.Context, token, userID string) ([]auth.Session, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "list_sessions").Add(1)
+		ms.latency.With("method", "list_sessions").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ListSessions(ctx, token, userID)
+}
+
+func (ms *metricsMiddleware) RevokeSession(ctx context.Context, token, userID, id string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "revoke_session").Add(1)
+		ms.latency.With("method", "revoke_session").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.RevokeSession(ctx, token, userID, id)
+}
+
 func (ms *metricsMiddleware) RetrieveKey(ctx context.Context, token, id string) (auth.Key, error) {
 	defer func(begin time.Time) {
 		ms.counter.With("method", "retrieve_key").Add(1)
@@ -116,6 +161,15 @@ func (ms *metricsMiddleware) ListOrgs(ctx context.Context, token string, admin b
 	return ms.svc.ListOrgs(ctx, token, admin, pm)
 }
 
+func (ms *metricsMiddleware) RetrieveMemberships(ctx context.Context, memberID string) ([]string, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "retrieve_memberships").Add(1)
+		ms.latency.With("method", "retrieve_memberships").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.RetrieveMemberships(ctx, memberID)
+}
+
 func (ms *metricsMiddleware) ListOrgMemberships(ctx context.Context, token, memberID string, pm auth.PageMetadata) (auth.OrgsPage, error) {
 	defer func(begin time.Time) {
 		ms.counter.With("method", "list_org_memberships").Add(1)
@@ -215,13 +269,148 @@ func (ms *metricsMiddleware) ListOrgGroups(ctx context.Context, token, groupID s
 	return ms.svc.ListOrgGroups(ctx, token, groupID, pm)
 }
 
-func (ms *metricsMiddleware) AddPolicy(ctx context.Context, token, groupID, policy string) error {
+func (ms *metricsMiddleware) AddPolicy(ctx context.Context, token, memberID, subject, object, policy string) error {
 	defer func(begin time.Time) {
 		ms.counter.With("method", "add_policy").Add(1)
 		ms.latency.With("method", "add_policy").Observe(time.Since(begin).Seconds())
 	}(time.Now())
 
-	return ms.svc.AddPolicy(ctx, token, groupID, policy)
+	return ms.svc.AddPolicy(ctx, token, memberID, subject, object, policy)
+}
+
+func (ms *metricsMiddleware) ClaimOwnership(ctx context.Context, ownerID, subject, object string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "claim_ownership").Add(1)
+		ms.latency.With("method", "claim_ownership").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ClaimOwnership(ctx, ownerID, subject, object)
+}
+
+func (ms *metricsMiddleware) ShareObject(ctx context.Context, token, subject, object string, inv auth.ObjectInvitation) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "share_object").Add(1)
+		ms.latency.With("method", "share_object").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ShareObject(ctx, token, subject, object, inv)
+}
+
+func (ms *metricsMiddleware) ListObjectPolicies(ctx context.Context, token, subject, object string) ([]auth.Policy, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "list_object_policies").Add(1)
+		ms.latency.With("method", "list_object_policies").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ListObjectPolicies(ctx, token, subject, object)
+}
+
+func (ms *metricsMiddleware) UnshareObject(ctx context.Context, token, subject, object string, memberIDs ...string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "unshare_object").Add(1)
+		ms.latency.With("method", "unshare_object").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.UnshareObject(ctx, token, subject, object, memberIDs...)
+}
+
+func (ms *metricsMiddleware) CreateTeam(ctx context.Context, token string, team auth.Team) (auth.Team, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "create_team").Add(1)
+		ms.latency.With("method", "create_team").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.CreateTeam(ctx, token, team)
+}
+
+func (ms *metricsMiddleware) ViewTeam(ctx context.Context, token, id string) (auth.Team, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "view_team").Add(1)
+		ms.latency.With("method", "view_team").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ViewTeam(ctx, token, id)
+}
+
+func (ms *metricsMiddleware) ListTeams(ctx context.Context, token, orgID string, pm auth.PageMetadata) (auth.TeamsPage, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "list_teams").Add(1)
+		ms.latency.With("method", "list_teams").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ListTeams(ctx, token, orgID, pm)
+}
+
+func (ms *metricsMiddleware) UpdateTeam(ctx context.Context, token string, team auth.Team) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "update_team").Add(1)
+		ms.latency.With("method", "update_team").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.UpdateTeam(ctx, token, team)
+}
+
+func (ms *metricsMiddleware) RemoveTeam(ctx context.Context, token, id string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "remove_team").Add(1)
+		ms.latency.With("method", "remove_team").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.RemoveTeam(ctx, token, id)
+}
+
+func (ms *metricsMiddleware) AssignTeamMembers(ctx context.Context, token, teamID string, memberIDs ...string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "assign_team_members").Add(1)
+		ms.latency.With("method", "assign_team_members").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.AssignTeamMembers(ctx, token, teamID, memberIDs...)
+}
+
+func (ms *metricsMiddleware) UnassignTeamMembers(ctx context.Context, token, teamID string, memberIDs ...string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "unassign_team_members").Add(1)
+		ms.latency.With("method", "unassign_team_members").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.UnassignTeamMembers(ctx, token, teamID, memberIDs...)
+}
+
+func (ms *metricsMiddleware) ListTeamMembers(ctx context.Context, token, teamID string, pm auth.PageMetadata) (auth.MembersPage, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "list_team_members").Add(1)
+		ms.latency.With("method", "list_team_members").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ListTeamMembers(ctx, token, teamID, pm)
+}
+
+func (ms *metricsMiddleware) GrantTeamAccess(ctx context.Context, token, teamID, groupID, policy string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "grant_team_access").Add(1)
+		ms.latency.With("method", "grant_team_access").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.GrantTeamAccess(ctx, token, teamID, groupID, policy)
+}
+
+func (ms *metricsMiddleware) RevokeTeamAccess(ctx context.Context, token, teamID, groupID string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "revoke_team_access").Add(1)
+		ms.latency.With("method", "revoke_team_access").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.RevokeTeamAccess(ctx, token, teamID, groupID)
+}
+
+func (ms *metricsMiddleware) ListTeamPolicies(ctx context.Context, token, teamID string) ([]auth.TeamPolicy, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "list_team_policies").Add(1)
+		ms.latency.With("method", "list_team_policies").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ListTeamPolicies(ctx, token, teamID)
 }
 
 func (ms *metricsMiddleware) Backup(ctx context.Context, token string) (auth.Backup, error) {
@@ -251,6 +440,15 @@ func (ms *metricsMiddleware) AssignRole(ctx context.Context, id, role string) er
 	return ms.svc.AssignRole(ctx, id, role)
 }
 
+func (ms *metricsMiddleware) RetrieveRole(ctx context.Context, id string) (string, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "retrieve_role").Add(1)
+		ms.latency.With("method", "retrieve_role").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.RetrieveRole(ctx, id)
+}
+
 func (ms *metricsMiddleware) CreatePolicies(ctx context.Context, token, groupID string, gis ...auth.GroupInvitationByEmail) error {
 	defer func(begin time.Time) {
 		ms.counter.With("method", "create_policies").Add(1)
@@ -15,12 +15,16 @@ const issuerName = "mainflux.auth"
 
 type claims struct {
 	jwt.StandardClaims
-	IssuerID string  `json:"issuer_id,omitempty"`
-	Type     *uint32 `json:"type,omitempty"`
+	IssuerID  string  `json:"issuer_id,omitempty"`
+	Type      *uint32 `json:"type,omitempty"`
+	SessionID string  `json:"session_id,omitempty"`
+	// IssuedAtNano keeps the issue time with the precision of nanoseconds,
+	// so the keys issued right before their revocation are revoked as well.
+	IssuedAtNano int64 `json:"iat_ns,omitempty"`
 }
 
 func (c claims) Valid() error {
-	if c.Type == nil || *c.Type > auth.APIKey || c.Issuer != issuerName {
+	if c.Type == nil || *c.Type > auth.RefreshKey || c.Issuer != issuerName {
 		return errors.ErrMalformedEntity
 	}
 
@@ -43,8 +47,10 @@ func (svc tokenizer) Issue(key auth.Key) (string, error) {
 			Subject:  key.Subject,
 			IssuedAt: key.IssuedAt.UTC().Unix(),
 		},
-		IssuerID: key.IssuerID,
-		Type:     &key.Type,
+		IssuerID:     key.IssuerID,
+		Type:         &key.Type,
+		SessionID:    key.SessionID,
+		IssuedAtNano: key.IssuedAt.UTC().UnixNano(),
 	}
 
 	if !key.ExpiresAt.IsZero() {
@@ -83,10 +89,14 @@ func (svc tokenizer) Parse(token string) (auth.Key, error) {
 
 func (c claims) toKey() auth.Key {
 	key := auth.Key{
-		ID:       c.Id,
-		IssuerID: c.IssuerID,
-		Subject:  c.Subject,
-		IssuedAt: time.Unix(c.IssuedAt, 0).UTC(),
+		ID:        c.Id,
+		IssuerID:  c.IssuerID,
+		Subject:   c.Subject,
+		IssuedAt:  time.Unix(c.IssuedAt, 0).UTC(),
+		SessionID: c.SessionID,
+	}
+	if c.IssuedAtNano != 0 {
+		key.IssuedAt = time.Unix(0, c.IssuedAtNano).UTC()
 	}
 	if c.ExpiresAt != 0 {
 		key.ExpiresAt = time.Unix(c.ExpiresAt, 0).UTC()
@@ -28,6 +28,8 @@ const (
 	RecoveryKey
 	// APIKey enables the one to act on behalf of the user.
 	APIKey
+	// RefreshKey is used to obtain new login keys without the user credentials.
+	RefreshKey
 )
 
 // Key represents API key.
@@ -38,6 +40,9 @@ type Key struct {
 	Subject   string
 	IssuedAt  time.Time
 	ExpiresAt time.Time
+	// SessionID identifies the session the login or refresh key
+	// belongs to. It is empty for keys issued outside of a session.
+	SessionID string
 }
 
 // Identity contains ID and Email.
@@ -66,3 +71,19 @@ type KeyRepository interface {
 	// Remove removes Key with provided ID.
 	Remove(context.Context, string, string) error
 }
+
+// RevocationRepository specifies the revoked keys persistence API.
+type RevocationRepository interface {
+	// Revoke revokes the key with the provided ID until it expires. It
+	// reports whether the key is revoked by this call, which is false if
+	// the key is already revoked. Concurrent calls revoke the key once.
+	Revoke(ctx context.Context, id string, expiresAt time.Time) (bool, error)
+
+	// RevokeAll revokes all keys of the user issued before the provided time.
+	// The time is kept with the precision of seconds, as the key issue time.
+	RevokeAll(ctx context.Context, userID string, until time.Time) error
+
+	// RevokedUntil returns the time until which all keys of the user are
+	// revoked. Zero time is returned if the user keys are not revoked.
+	RevokedUntil(ctx context.Context, userID string) (time.Time, error)
+}
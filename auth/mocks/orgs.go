@@ -17,6 +17,7 @@ type orgRepositoryMock struct {
 	orgs            map[string]auth.Org
 	members         map[string]auth.Member
 	groups          map[string]auth.Group
+	groupOrgs       map[string]string
 	membersPolicies map[string]auth.GroupInvitationByID
 }
 
@@ -26,6 +27,7 @@ func NewOrgRepository() auth.OrgRepository {
 		orgs:            make(map[string]auth.Org),
 		members:         make(map[string]auth.Member),
 		groups:          make(map[string]auth.Group),
+		groupOrgs:       make(map[string]string),
 		membersPolicies: make(map[string]auth.GroupInvitationByID),
 	}
 }
@@ -234,6 +236,7 @@ func (orm *orgRepositoryMock) AssignGroups(ctx context.Context, grs ...auth.Grou
 		orm.groups[gr.GroupID] = auth.Group{
 			ID: gr.GroupID,
 		}
+		orm.groupOrgs[gr.GroupID] = gr.OrgID
 	}
 
 	return nil
@@ -248,6 +251,7 @@ func (orm *orgRepositoryMock) UnassignGroups(ctx context.Context, orgID string,
 			return errors.ErrNotFound
 		}
 		delete(orm.groups, groupID)
+		delete(orm.groupOrgs, groupID)
 	}
 
 	return nil
@@ -285,12 +289,12 @@ func (orm *orgRepositoryMock) RetrieveByGroupID(ctx context.Context, groupID str
 	orm.mu.Lock()
 	defer orm.mu.Unlock()
 
-	org, ok := orm.groups[groupID]
+	orgID, ok := orm.groupOrgs[groupID]
 	if !ok {
 		return auth.Org{}, errors.ErrNotFound
 	}
 
-	return orm.orgs[org.ID], nil
+	return orm.orgs[orgID], nil
 }
 
 func (orm *orgRepositoryMock) RetrieveAll(ctx context.Context) ([]auth.Org, error) {
@@ -386,7 +390,10 @@ func (orm *orgRepositoryMock) SavePolicies(ctx context.Context, groupID string,
 }
 
 func (orm *orgRepositoryMock) RetrievePolicy(ctx context.Context, gp auth.GroupsPolicy) (string, error) {
-	panic("not implemented")
+	orm.mu.Lock()
+	defer orm.mu.Unlock()
+
+	return orm.membersPolicies[gp.MemberID].Policy, nil
 }
 
 func (orm *orgRepositoryMock) RetrievePolicies(ctx context.Context, groupID string, pm auth.PageMetadata) (auth.GroupMembersPoliciesPage, error) {
@@ -0,0 +1,88 @@
+package mocks
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/MainfluxLabs/mainflux/auth"
+)
+
+var _ auth.PoliciesRepository = (*policiesRepositoryMock)(nil)
+
+type policiesRepositoryMock struct {
+	mu       sync.Mutex
+	policies map[string]auth.Policy
+}
+
+// NewPoliciesRepository returns mock of policies repository.
+func NewPoliciesRepository() auth.PoliciesRepository {
+	return &policiesRepositoryMock{
+		policies: make(map[string]auth.Policy),
+	}
+}
+
+func (prm *policiesRepositoryMock) SavePolicies(ctx context.Context, policies ...auth.Policy) error {
+	prm.mu.Lock()
+	defer prm.mu.Unlock()
+
+	for _, p := range policies {
+		prm.policies[policyKey(p.MemberID, p.ObjectType, p.ObjectID)] = p
+	}
+
+	return nil
+}
+
+func (prm *policiesRepositoryMock) RetrieveRelation(ctx context.Context, memberID, objectType, objectID string) (string, error) {
+	prm.mu.Lock()
+	defer prm.mu.Unlock()
+
+	return prm.policies[policyKey(memberID, objectType, objectID)].Relation, nil
+}
+
+func (prm *policiesRepositoryMock) RetrieveByObject(ctx context.Context, objectType, objectID string) ([]auth.Policy, error) {
+	prm.mu.Lock()
+	defer prm.mu.Unlock()
+
+	var policies []auth.Policy
+	for _, p := range prm.policies {
+		if p.ObjectType == objectType && p.ObjectID == objectID {
+			policies = append(policies, p)
+		}
+	}
+
+	sort.SliceStable(policies, func(i, j int) bool {
+		return policies[i].MemberID < policies[j].MemberID
+	})
+
+	return policies, nil
+}
+
+func (prm *policiesRepositoryMock) RetrieveOrgPolicies(ctx context.Context, objectType, objectID string) ([]auth.Policy, error) {
+	prm.mu.Lock()
+	defer prm.mu.Unlock()
+
+	var policies []auth.Policy
+	for _, p := range prm.policies {
+		if p.MemberType == auth.OrgMember && p.ObjectType == objectType && p.ObjectID == objectID {
+			policies = append(policies, p)
+		}
+	}
+
+	return policies, nil
+}
+
+func (prm *policiesRepositoryMock) RemovePolicies(ctx context.Context, objectType, objectID string, memberIDs ...string) error {
+	prm.mu.Lock()
+	defer prm.mu.Unlock()
+
+	for _, memberID := range memberIDs {
+		delete(prm.policies, policyKey(memberID, objectType, objectID))
+	}
+
+	return nil
+}
+
+func policyKey(memberID, objectType, objectID string) string {
+	return memberID + ":" + objectType + ":" + objectID
+}
@@ -0,0 +1,54 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/auth"
+)
+
+var _ auth.RevocationRepository = (*revocationRepositoryMock)(nil)
+
+type revocationRepositoryMock struct {
+	mu    sync.Mutex
+	keys  map[string]time.Time
+	users map[string]time.Time
+}
+
+// NewRevocationRepository creates in-memory revoked keys repository.
+func NewRevocationRepository() auth.RevocationRepository {
+	return &revocationRepositoryMock{
+		keys:  make(map[string]time.Time),
+		users: make(map[string]time.Time),
+	}
+}
+
+func (rrm *revocationRepositoryMock) Revoke(ctx context.Context, id string, expiresAt time.Time) (bool, error) {
+	rrm.mu.Lock()
+	defer rrm.mu.Unlock()
+
+	if exp, ok := rrm.keys[id]; ok && exp.After(time.Now()) {
+		return false, nil
+	}
+	rrm.keys[id] = expiresAt
+	return true, nil
+}
+
+func (rrm *revocationRepositoryMock) RevokeAll(ctx context.Context, userID string, until time.Time) error {
+	rrm.mu.Lock()
+	defer rrm.mu.Unlock()
+
+	rrm.users[userID] = until
+	return nil
+}
+
+func (rrm *revocationRepositoryMock) RevokedUntil(ctx context.Context, userID string) (time.Time, error) {
+	rrm.mu.Lock()
+	defer rrm.mu.Unlock()
+
+	return rrm.users[userID], nil
+}
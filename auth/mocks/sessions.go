@@ -0,0 +1,91 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/auth"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+)
+
+var _ auth.SessionRepository = (*sessionRepositoryMock)(nil)
+
+type sessionRepositoryMock struct {
+	mu       sync.Mutex
+	sessions map[string]map[string]auth.Session
+}
+
+// NewSessionRepository creates in-memory sessions repository.
+func NewSessionRepository() auth.SessionRepository {
+	return &sessionRepositoryMock{
+		sessions: make(map[string]map[string]auth.Session),
+	}
+}
+
+func (srm *sessionRepositoryMock) Save(ctx context.Context, s auth.Session) error {
+	srm.mu.Lock()
+	defer srm.mu.Unlock()
+
+	if _, ok := srm.sessions[s.UserID]; !ok {
+		srm.sessions[s.UserID] = make(map[string]auth.Session)
+	}
+	srm.sessions[s.UserID][s.ID] = s
+
+	return nil
+}
+
+func (srm *sessionRepositoryMock) Retrieve(ctx context.Context, userID, id string) (auth.Session, error) {
+	srm.mu.Lock()
+	defer srm.mu.Unlock()
+
+	s, ok := srm.sessions[userID][id]
+	if !ok || s.ExpiresAt.Before(time.Now()) {
+		return auth.Session{}, errors.ErrNotFound
+	}
+
+	return s, nil
+}
+
+func (srm *sessionRepositoryMock) RetrieveAll(ctx context.Context, userID string) ([]auth.Session, error) {
+	srm.mu.Lock()
+	defer srm.mu.Unlock()
+
+	var sessions []auth.Session
+	for _, s := range srm.sessions[userID] {
+		if s.ExpiresAt.After(time.Now()) {
+			sessions = append(sessions, s)
+		}
+	}
+
+	sort.SliceStable(sessions, func(i, j int) bool {
+		return sessions[i].IssuedAt.Before(sessions[j].IssuedAt)
+	})
+
+	return sessions, nil
+}
+
+func (srm *sessionRepositoryMock) Remove(ctx context.Context, userID, id string) error {
+	srm.mu.Lock()
+	defer srm.mu.Unlock()
+
+	if _, ok := srm.sessions[userID][id]; !ok {
+		return errors.ErrNotFound
+	}
+	delete(srm.sessions[userID], id)
+
+	return nil
+}
+
+func (srm *sessionRepositoryMock) RemoveAll(ctx context.Context, userID string) error {
+	srm.mu.Lock()
+	defer srm.mu.Unlock()
+
+	delete(srm.sessions, userID)
+
+	return nil
+}
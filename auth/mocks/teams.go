@@ -0,0 +1,228 @@
+package mocks
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/MainfluxLabs/mainflux/auth"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+)
+
+var _ auth.TeamRepository = (*teamRepositoryMock)(nil)
+
+type teamRepositoryMock struct {
+	mu       sync.Mutex
+	teams    map[string]auth.Team
+	members  map[string]map[string]bool
+	policies map[string]map[string]string
+}
+
+// NewTeamRepository returns mock of team repository.
+func NewTeamRepository() auth.TeamRepository {
+	return &teamRepositoryMock{
+		teams:    make(map[string]auth.Team),
+		members:  make(map[string]map[string]bool),
+		policies: make(map[string]map[string]string),
+	}
+}
+
+func (trm *teamRepositoryMock) Save(ctx context.Context, team auth.Team) error {
+	trm.mu.Lock()
+	defer trm.mu.Unlock()
+
+	if _, ok := trm.teams[team.ID]; ok {
+		return errors.ErrConflict
+	}
+
+	trm.teams[team.ID] = team
+	trm.members[team.ID] = make(map[string]bool)
+	trm.policies[team.ID] = make(map[string]string)
+
+	return nil
+}
+
+func (trm *teamRepositoryMock) Update(ctx context.Context, team auth.Team) error {
+	trm.mu.Lock()
+	defer trm.mu.Unlock()
+
+	if _, ok := trm.teams[team.ID]; !ok {
+		return errors.ErrNotFound
+	}
+
+	trm.teams[team.ID] = team
+
+	return nil
+}
+
+func (trm *teamRepositoryMock) Remove(ctx context.Context, id string) error {
+	trm.mu.Lock()
+	defer trm.mu.Unlock()
+
+	delete(trm.teams, id)
+	delete(trm.members, id)
+	delete(trm.policies, id)
+
+	return nil
+}
+
+func (trm *teamRepositoryMock) RetrieveByID(ctx context.Context, id string) (auth.Team, error) {
+	trm.mu.Lock()
+	defer trm.mu.Unlock()
+
+	team, ok := trm.teams[id]
+	if !ok {
+		return auth.Team{}, errors.ErrNotFound
+	}
+
+	return team, nil
+}
+
+func (trm *teamRepositoryMock) RetrieveByOrg(ctx context.Context, orgID string, pm auth.PageMetadata) (auth.TeamsPage, error) {
+	trm.mu.Lock()
+	defer trm.mu.Unlock()
+
+	var teams []auth.Team
+	for _, t := range trm.teams {
+		if t.OrgID == orgID {
+			teams = append(teams, t)
+		}
+	}
+
+	sort.SliceStable(teams, func(i, j int) bool {
+		return teams[i].ID < teams[j].ID
+	})
+	start, end := pageBounds(len(teams), pm)
+
+	page := auth.TeamsPage{
+		PageMetadata: auth.PageMetadata{
+			Total:  uint64(len(teams)),
+			Offset: pm.Offset,
+			Limit:  pm.Limit,
+		},
+		Teams: teams[start:end],
+	}
+
+	return page, nil
+}
+
+func (trm *teamRepositoryMock) AssignMembers(ctx context.Context, teamID string, memberIDs ...string) error {
+	trm.mu.Lock()
+	defer trm.mu.Unlock()
+
+	members, ok := trm.members[teamID]
+	if !ok {
+		return errors.ErrNotFound
+	}
+
+	for _, id := range memberIDs {
+		members[id] = true
+	}
+
+	return nil
+}
+
+func (trm *teamRepositoryMock) UnassignMembers(ctx context.Context, teamID string, memberIDs ...string) error {
+	trm.mu.Lock()
+	defer trm.mu.Unlock()
+
+	for _, id := range memberIDs {
+		delete(trm.members[teamID], id)
+	}
+
+	return nil
+}
+
+func (trm *teamRepositoryMock) RetrieveMembers(ctx context.Context, teamID string, pm auth.PageMetadata) (auth.TeamMembersPage, error) {
+	trm.mu.Lock()
+	defer trm.mu.Unlock()
+
+	var ids []string
+	for id := range trm.members[teamID] {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	start, end := pageBounds(len(ids), pm)
+
+	page := auth.TeamMembersPage{
+		PageMetadata: auth.PageMetadata{
+			Total:  uint64(len(ids)),
+			Offset: pm.Offset,
+			Limit:  pm.Limit,
+		},
+		MemberIDs: ids[start:end],
+	}
+
+	return page, nil
+}
+
+func (trm *teamRepositoryMock) SavePolicy(ctx context.Context, tp auth.TeamPolicy) error {
+	trm.mu.Lock()
+	defer trm.mu.Unlock()
+
+	policies, ok := trm.policies[tp.TeamID]
+	if !ok {
+		return errors.ErrNotFound
+	}
+
+	policies[tp.GroupID] = tp.Policy
+
+	return nil
+}
+
+func (trm *teamRepositoryMock) RemovePolicy(ctx context.Context, teamID, groupID string) error {
+	trm.mu.Lock()
+	defer trm.mu.Unlock()
+
+	delete(trm.policies[teamID], groupID)
+
+	return nil
+}
+
+func (trm *teamRepositoryMock) RetrievePolicies(ctx context.Context, teamID string) ([]auth.TeamPolicy, error) {
+	trm.mu.Lock()
+	defer trm.mu.Unlock()
+
+	var tps []auth.TeamPolicy
+	for groupID, policy := range trm.policies[teamID] {
+		tps = append(tps, auth.TeamPolicy{TeamID: teamID, GroupID: groupID, Policy: policy})
+	}
+
+	sort.SliceStable(tps, func(i, j int) bool {
+		return tps[i].GroupID < tps[j].GroupID
+	})
+
+	return tps, nil
+}
+
+func (trm *teamRepositoryMock) RetrieveMemberPolicies(ctx context.Context, memberID, groupID string) ([]string, error) {
+	trm.mu.Lock()
+	defer trm.mu.Unlock()
+
+	var policies []string
+	for teamID, members := range trm.members {
+		if !members[memberID] {
+			continue
+		}
+
+		if policy, ok := trm.policies[teamID][groupID]; ok {
+			policies = append(policies, policy)
+		}
+	}
+
+	return policies, nil
+}
+
+func pageBounds(total int, pm auth.PageMetadata) (uint64, uint64) {
+	n := uint64(total)
+	if pm.Offset >= n {
+		return n, n
+	}
+
+	end := pm.Offset + pm.Limit
+	if pm.Limit == 0 || end > n {
+		end = n
+	}
+
+	return pm.Offset, end
+}
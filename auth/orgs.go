@@ -167,6 +167,11 @@ type Orgs interface {
 	// ListOrgMemberships retrieves all orgs for member that is identified with memberID belongs to.
 	ListOrgMemberships(ctx context.Context, token, memberID string, pm PageMetadata) (OrgsPage, error)
 
+	// RetrieveMemberships retrieves IDs of all orgs the member identified by
+	// memberID belongs to. It's used by the services which have no token of
+	// the member, e.g. before the member logs in.
+	RetrieveMemberships(ctx context.Context, memberID string) ([]string, error)
+
 	// RemoveOrg removes the org identified with the provided ID.
 	RemoveOrg(ctx context.Context, token, id string) error
 
@@ -179,7 +184,8 @@ type Orgs interface {
 	// UpdateMembers updates members role in an org.
 	UpdateMembers(ctx context.Context, token, orgID string, members ...Member) error
 
-	// AssignMembersByIDs adds members with memberIDs into the org identified by orgID.
+	// AssignMembersByIDs adds members with memberIDs into the org identified by orgID,
+	// with the viewer role.
 	AssignMembersByIDs(ctx context.Context, token, orgID string, memberIDs ...string) error
 
 	// UnassignMembersByIDs removes members with memberIDs from org identified by orgID.
@@ -0,0 +1,97 @@
+package auth
+
+import (
+	"context"
+
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+)
+
+// ErrInvalidPolicy indicates that the shared policy is not supported.
+var ErrInvalidPolicy = errors.New("invalid policy")
+
+const (
+	// ThingSubject is the subject used to authorize access to things.
+	ThingSubject = "thing"
+	// ChannelSubject is the subject used to authorize access to channels.
+	ChannelSubject = "channel"
+
+	// OwnerRelation grants full access to the object, including sharing it.
+	OwnerRelation = "owner"
+	// EditorRelation grants read and write access to the object.
+	EditorRelation = "editor"
+	// ViewerRelation grants read only access to the object.
+	ViewerRelation = "viewer"
+
+	// UserMember is the type of the policy granted to the user.
+	UserMember = "user"
+	// OrgMember is the type of the policy granted to all members of the org.
+	OrgMember = "org"
+)
+
+// ObjectInvitation represents a request to share an object either with
+// the users identified by emails or with all members of an org.
+type ObjectInvitation struct {
+	Emails []string
+	OrgID  string
+	Policy string
+}
+
+// Policy represents a relation between a user, or all members of an org,
+// and an object.
+type Policy struct {
+	MemberID   string
+	MemberType string
+	ObjectType string
+	ObjectID   string
+	Relation   string
+}
+
+// Policies specifies an API for sharing things and channels with other users.
+type Policies interface {
+	// ShareObject grants the users of the invitation access to the object
+	// of the given subject. The object shared with the org is accessible to
+	// its current and future members. Only the owner of the object can share it.
+	ShareObject(ctx context.Context, token, subject, object string, inv ObjectInvitation) error
+
+	// ListObjectPolicies retrieves policies of the object of the given subject.
+	ListObjectPolicies(ctx context.Context, token, subject, object string) ([]Policy, error)
+
+	// UnshareObject revokes access of the members to the object of the given subject.
+	UnshareObject(ctx context.Context, token, subject, object string, memberIDs ...string) error
+}
+
+// PoliciesRepository specifies object policies persistence API.
+type PoliciesRepository interface {
+	// SavePolicies saves object policies. Existing policies of the
+	// same member and object are overwritten.
+	SavePolicies(ctx context.Context, policies ...Policy) error
+
+	// RetrieveRelation retrieves the relation of the member to the object.
+	// Empty string is returned if the member has no relation to the object.
+	RetrieveRelation(ctx context.Context, memberID, objectType, objectID string) (string, error)
+
+	// RetrieveByObject retrieves all policies of the object.
+	RetrieveByObject(ctx context.Context, objectType, objectID string) ([]Policy, error)
+
+	// RetrieveOrgPolicies retrieves the policies of the object granted to orgs.
+	RetrieveOrgPolicies(ctx context.Context, objectType, objectID string) ([]Policy, error)
+
+	// RemovePolicies removes policies of the members to the object.
+	RemovePolicies(ctx context.Context, objectType, objectID string, memberIDs ...string) error
+}
+
+// toRelation maps read and read_write policies to object relations.
+func toRelation(policy string) (string, error) {
+	switch policy {
+	case RPolicy:
+		return ViewerRelation, nil
+	case RwPolicy:
+		return EditorRelation, nil
+	default:
+		return "", ErrInvalidPolicy
+	}
+}
+
+func isObjectSubject(subject string) bool {
+	return subject == ThingSubject || subject == ChannelSubject
+}
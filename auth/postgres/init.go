@@ -138,6 +138,69 @@ func migrateDB(db *sqlx.DB) error {
 					`ALTER TABLE group_policies ADD CONSTRAINT group_policies_pkey PRIMARY KEY (group_id, member_id)`,
 				},
 			},
+			{
+				Id: "auth_7",
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS object_policies (
+							member_id   UUID NOT NULL,
+							object_type VARCHAR(15) NOT NULL,
+							object_id   VARCHAR(254) NOT NULL,
+							relation    VARCHAR(15) NOT NULL,
+							PRIMARY KEY (member_id, object_type, object_id)
+						 )`,
+					`CREATE INDEX IF NOT EXISTS object_policies_object_idx ON object_policies (object_type, object_id)`,
+				},
+				Down: []string{
+					`DROP TABLE IF EXISTS object_policies`,
+				},
+			},
+			{
+				Id: "auth_8",
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS teams (
+							id          UUID UNIQUE NOT NULL,
+							org_id      UUID NOT NULL,
+							owner_id    UUID NOT NULL,
+							name        VARCHAR(254) NOT NULL,
+							description VARCHAR(1024),
+							created_at  TIMESTAMPTZ,
+							updated_at  TIMESTAMPTZ,
+							FOREIGN KEY (org_id) REFERENCES orgs (id) ON DELETE CASCADE,
+							PRIMARY KEY (id)
+						 )`,
+					`CREATE TABLE IF NOT EXISTS team_members (
+							team_id     UUID NOT NULL,
+							member_id   UUID NOT NULL,
+							FOREIGN KEY (team_id) REFERENCES teams (id) ON DELETE CASCADE,
+							PRIMARY KEY (team_id, member_id)
+						 )`,
+					`CREATE TABLE IF NOT EXISTS team_policies (
+							team_id     UUID NOT NULL,
+							group_id    UUID NOT NULL,
+							policy      VARCHAR(15) NOT NULL,
+							FOREIGN KEY (team_id) REFERENCES teams (id) ON DELETE CASCADE,
+							FOREIGN KEY (group_id) REFERENCES group_relations (group_id) ON DELETE CASCADE,
+							PRIMARY KEY (team_id, group_id)
+						 )`,
+					`CREATE INDEX IF NOT EXISTS team_members_member_idx ON team_members (member_id)`,
+				},
+				Down: []string{
+					`DROP TABLE IF EXISTS team_policies`,
+					`DROP TABLE IF EXISTS team_members`,
+					`DROP TABLE IF EXISTS teams`,
+				},
+			},
+			{
+				Id: "auth_9",
+				Up: []string{
+					`ALTER TABLE object_policies ADD COLUMN IF NOT EXISTS member_type VARCHAR(15) NOT NULL DEFAULT 'user'`,
+					`CREATE INDEX IF NOT EXISTS object_policies_member_type_idx ON object_policies (object_type, object_id, member_type)`,
+				},
+				Down: []string{
+					`DROP INDEX IF EXISTS object_policies_member_type_idx`,
+					`ALTER TABLE object_policies DROP COLUMN IF EXISTS member_type`,
+				},
+			},
 		},
 	}
 
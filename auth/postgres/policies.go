@@ -0,0 +1,151 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/MainfluxLabs/mainflux/auth"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+)
+
+var _ auth.PoliciesRepository = (*policiesRepository)(nil)
+
+type policiesRepository struct {
+	db Database
+}
+
+// NewPoliciesRepo instantiates a PostgreSQL implementation of policies repository.
+func NewPoliciesRepo(db Database) auth.PoliciesRepository {
+	return &policiesRepository{
+		db: db,
+	}
+}
+
+func (pr policiesRepository) SavePolicies(ctx context.Context, policies ...auth.Policy) error {
+	tx, err := pr.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(errors.ErrCreateEntity, err)
+	}
+
+	q := `INSERT INTO object_policies (member_id, member_type, object_type, object_id, relation)
+		VALUES (:member_id, :member_type, :object_type, :object_id, :relation)
+		ON CONFLICT (member_id, object_type, object_id) DO UPDATE SET relation = :relation;`
+
+	for _, p := range policies {
+		if _, err := tx.NamedExecContext(ctx, q, toDBPolicy(p)); err != nil {
+			tx.Rollback()
+			return errors.Wrap(errors.ErrCreateEntity, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.Wrap(errors.ErrCreateEntity, err)
+	}
+
+	return nil
+}
+
+func (pr policiesRepository) RetrieveRelation(ctx context.Context, memberID, objectType, objectID string) (string, error) {
+	q := `SELECT relation FROM object_policies
+		WHERE member_id = :member_id AND object_type = :object_type AND object_id = :object_id;`
+
+	dbp := dbPolicy{MemberID: memberID, ObjectType: objectType, ObjectID: objectID}
+
+	rows, err := pr.db.NamedQueryContext(ctx, q, dbp)
+	if err != nil {
+		return "", errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+	defer rows.Close()
+
+	var relation string
+	for rows.Next() {
+		if err := rows.Scan(&relation); err != nil {
+			return "", errors.Wrap(errors.ErrRetrieveEntity, err)
+		}
+	}
+
+	return relation, nil
+}
+
+func (pr policiesRepository) RetrieveByObject(ctx context.Context, objectType, objectID string) ([]auth.Policy, error) {
+	q := `SELECT member_id, member_type, object_type, object_id, relation FROM object_policies
+		WHERE object_type = :object_type AND object_id = :object_id ORDER BY member_id;`
+
+	dbp := dbPolicy{ObjectType: objectType, ObjectID: objectID}
+
+	return pr.retrieve(ctx, q, dbp)
+}
+
+func (pr policiesRepository) RetrieveOrgPolicies(ctx context.Context, objectType, objectID string) ([]auth.Policy, error) {
+	q := `SELECT member_id, member_type, object_type, object_id, relation FROM object_policies
+		WHERE object_type = :object_type AND object_id = :object_id AND member_type = :member_type;`
+
+	dbp := dbPolicy{MemberType: auth.OrgMember, ObjectType: objectType, ObjectID: objectID}
+
+	return pr.retrieve(ctx, q, dbp)
+}
+
+func (pr policiesRepository) RemovePolicies(ctx context.Context, objectType, objectID string, memberIDs ...string) error {
+	q := `DELETE FROM object_policies
+		WHERE member_id = :member_id AND object_type = :object_type AND object_id = :object_id;`
+
+	for _, memberID := range memberIDs {
+		dbp := dbPolicy{MemberID: memberID, ObjectType: objectType, ObjectID: objectID}
+		if _, err := pr.db.NamedExecContext(ctx, q, dbp); err != nil {
+			return errors.Wrap(errors.ErrRemoveEntity, err)
+		}
+	}
+
+	return nil
+}
+
+func (pr policiesRepository) retrieve(ctx context.Context, query string, params dbPolicy) ([]auth.Policy, error) {
+	rows, err := pr.db.NamedQueryContext(ctx, query, params)
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+	defer rows.Close()
+
+	var policies []auth.Policy
+	for rows.Next() {
+		var dbp dbPolicy
+		if err := rows.StructScan(&dbp); err != nil {
+			return nil, errors.Wrap(errors.ErrRetrieveEntity, err)
+		}
+		policies = append(policies, toPolicy(dbp))
+	}
+
+	return policies, nil
+}
+
+type dbPolicy struct {
+	MemberID   string `db:"member_id"`
+	MemberType string `db:"member_type"`
+	ObjectType string `db:"object_type"`
+	ObjectID   string `db:"object_id"`
+	Relation   string `db:"relation"`
+}
+
+func toDBPolicy(p auth.Policy) dbPolicy {
+	memberType := p.MemberType
+	if memberType == "" {
+		memberType = auth.UserMember
+	}
+
+	return dbPolicy{
+		MemberID:   p.MemberID,
+		MemberType: memberType,
+		ObjectType: p.ObjectType,
+		ObjectID:   p.ObjectID,
+		Relation:   p.Relation,
+	}
+}
+
+func toPolicy(dbp dbPolicy) auth.Policy {
+	return auth.Policy{
+		MemberID:   dbp.MemberID,
+		MemberType: dbp.MemberType,
+		ObjectType: dbp.ObjectType,
+		ObjectID:   dbp.ObjectID,
+		Relation:   dbp.Relation,
+	}
+}
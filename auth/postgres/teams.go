@@ -0,0 +1,358 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/auth"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+var _ auth.TeamRepository = (*teamRepository)(nil)
+
+type teamRepository struct {
+	db Database
+}
+
+// NewTeamRepo instantiates a PostgreSQL implementation of team repository.
+func NewTeamRepo(db Database) auth.TeamRepository {
+	return &teamRepository{
+		db: db,
+	}
+}
+
+func (tr teamRepository) Save(ctx context.Context, team auth.Team) error {
+	q := `INSERT INTO teams (id, org_id, owner_id, name, description, created_at, updated_at)
+		VALUES (:id, :org_id, :owner_id, :name, :description, :created_at, :updated_at);`
+
+	if _, err := tr.db.NamedExecContext(ctx, q, toDBTeam(team)); err != nil {
+		pgErr, ok := err.(*pgconn.PgError)
+		if ok {
+			switch pgErr.Code {
+			case pgerrcode.InvalidTextRepresentation, pgerrcode.StringDataRightTruncationDataException:
+				return errors.Wrap(errors.ErrMalformedEntity, err)
+			case pgerrcode.UniqueViolation:
+				return errors.Wrap(errors.ErrConflict, err)
+			}
+		}
+
+		return errors.Wrap(errors.ErrCreateEntity, err)
+	}
+
+	return nil
+}
+
+func (tr teamRepository) Update(ctx context.Context, team auth.Team) error {
+	q := `UPDATE teams SET name = :name, description = :description, updated_at = :updated_at WHERE id = :id;`
+
+	res, err := tr.db.NamedExecContext(ctx, q, toDBTeam(team))
+	if err != nil {
+		return errors.Wrap(errors.ErrUpdateEntity, err)
+	}
+
+	cnt, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(errors.ErrUpdateEntity, err)
+	}
+
+	if cnt != 1 {
+		return errors.ErrNotFound
+	}
+
+	return nil
+}
+
+func (tr teamRepository) Remove(ctx context.Context, id string) error {
+	q := `DELETE FROM teams WHERE id = :id;`
+
+	if _, err := tr.db.NamedExecContext(ctx, q, dbTeam{ID: id}); err != nil {
+		return errors.Wrap(errors.ErrRemoveEntity, err)
+	}
+
+	return nil
+}
+
+func (tr teamRepository) RetrieveByID(ctx context.Context, id string) (auth.Team, error) {
+	q := `SELECT id, org_id, owner_id, name, description, created_at, updated_at FROM teams WHERE id = $1;`
+
+	var dbt dbTeam
+	if err := tr.db.QueryRowxContext(ctx, q, id).StructScan(&dbt); err != nil {
+		pgErr, ok := err.(*pgconn.PgError)
+		if err == sql.ErrNoRows || ok && pgerrcode.InvalidTextRepresentation == pgErr.Code {
+			return auth.Team{}, errors.Wrap(errors.ErrNotFound, err)
+		}
+
+		return auth.Team{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+
+	return toTeam(dbt), nil
+}
+
+func (tr teamRepository) RetrieveByOrg(ctx context.Context, orgID string, pm auth.PageMetadata) (auth.TeamsPage, error) {
+	q := `SELECT id, org_id, owner_id, name, description, created_at, updated_at FROM teams
+		WHERE org_id = :org_id ORDER BY name, id LIMIT :limit OFFSET :offset;`
+
+	params := map[string]interface{}{
+		"org_id": orgID,
+		"limit":  pm.Limit,
+		"offset": pm.Offset,
+	}
+
+	rows, err := tr.db.NamedQueryContext(ctx, q, params)
+	if err != nil {
+		return auth.TeamsPage{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+	defer rows.Close()
+
+	var teams []auth.Team
+	for rows.Next() {
+		var dbt dbTeam
+		if err := rows.StructScan(&dbt); err != nil {
+			return auth.TeamsPage{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+		}
+		teams = append(teams, toTeam(dbt))
+	}
+
+	cq := `SELECT COUNT(*) FROM teams WHERE org_id = :org_id;`
+
+	total, err := total(ctx, tr.db, cq, params)
+	if err != nil {
+		return auth.TeamsPage{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+
+	page := auth.TeamsPage{
+		Teams: teams,
+		PageMetadata: auth.PageMetadata{
+			Total:  total,
+			Offset: pm.Offset,
+			Limit:  pm.Limit,
+		},
+	}
+
+	return page, nil
+}
+
+func (tr teamRepository) AssignMembers(ctx context.Context, teamID string, memberIDs ...string) error {
+	tx, err := tr.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(errors.ErrCreateEntity, err)
+	}
+
+	q := `INSERT INTO team_members (team_id, member_id) VALUES (:team_id, :member_id)
+		ON CONFLICT (team_id, member_id) DO NOTHING;`
+
+	for _, memberID := range memberIDs {
+		dbtm := dbTeamMember{TeamID: teamID, MemberID: memberID}
+		if _, err := tx.NamedExecContext(ctx, q, dbtm); err != nil {
+			tx.Rollback()
+			pgErr, ok := err.(*pgconn.PgError)
+			if ok && pgErr.Code == pgerrcode.ForeignKeyViolation {
+				return errors.Wrap(errors.ErrNotFound, err)
+			}
+
+			return errors.Wrap(errors.ErrCreateEntity, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.Wrap(errors.ErrCreateEntity, err)
+	}
+
+	return nil
+}
+
+func (tr teamRepository) UnassignMembers(ctx context.Context, teamID string, memberIDs ...string) error {
+	q := `DELETE FROM team_members WHERE team_id = :team_id AND member_id = :member_id;`
+
+	for _, memberID := range memberIDs {
+		dbtm := dbTeamMember{TeamID: teamID, MemberID: memberID}
+		if _, err := tr.db.NamedExecContext(ctx, q, dbtm); err != nil {
+			return errors.Wrap(errors.ErrRemoveEntity, err)
+		}
+	}
+
+	return nil
+}
+
+func (tr teamRepository) RetrieveMembers(ctx context.Context, teamID string, pm auth.PageMetadata) (auth.TeamMembersPage, error) {
+	q := `SELECT member_id FROM team_members WHERE team_id = :team_id
+		ORDER BY member_id LIMIT :limit OFFSET :offset;`
+
+	params := map[string]interface{}{
+		"team_id": teamID,
+		"limit":   pm.Limit,
+		"offset":  pm.Offset,
+	}
+
+	rows, err := tr.db.NamedQueryContext(ctx, q, params)
+	if err != nil {
+		return auth.TeamMembersPage{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+	defer rows.Close()
+
+	var memberIDs []string
+	for rows.Next() {
+		var memberID string
+		if err := rows.Scan(&memberID); err != nil {
+			return auth.TeamMembersPage{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+		}
+		memberIDs = append(memberIDs, memberID)
+	}
+
+	cq := `SELECT COUNT(*) FROM team_members WHERE team_id = :team_id;`
+
+	total, err := total(ctx, tr.db, cq, params)
+	if err != nil {
+		return auth.TeamMembersPage{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+
+	page := auth.TeamMembersPage{
+		MemberIDs: memberIDs,
+		PageMetadata: auth.PageMetadata{
+			Total:  total,
+			Offset: pm.Offset,
+			Limit:  pm.Limit,
+		},
+	}
+
+	return page, nil
+}
+
+func (tr teamRepository) SavePolicy(ctx context.Context, tp auth.TeamPolicy) error {
+	q := `INSERT INTO team_policies (team_id, group_id, policy) VALUES (:team_id, :group_id, :policy)
+		ON CONFLICT (team_id, group_id) DO UPDATE SET policy = :policy;`
+
+	if _, err := tr.db.NamedExecContext(ctx, q, toDBTeamPolicy(tp)); err != nil {
+		pgErr, ok := err.(*pgconn.PgError)
+		if ok && pgErr.Code == pgerrcode.ForeignKeyViolation {
+			return errors.Wrap(errors.ErrNotFound, err)
+		}
+
+		return errors.Wrap(errors.ErrCreateEntity, err)
+	}
+
+	return nil
+}
+
+func (tr teamRepository) RemovePolicy(ctx context.Context, teamID, groupID string) error {
+	q := `DELETE FROM team_policies WHERE team_id = :team_id AND group_id = :group_id;`
+
+	if _, err := tr.db.NamedExecContext(ctx, q, dbTeamPolicy{TeamID: teamID, GroupID: groupID}); err != nil {
+		return errors.Wrap(errors.ErrRemoveEntity, err)
+	}
+
+	return nil
+}
+
+func (tr teamRepository) RetrievePolicies(ctx context.Context, teamID string) ([]auth.TeamPolicy, error) {
+	q := `SELECT team_id, group_id, policy FROM team_policies WHERE team_id = :team_id ORDER BY group_id;`
+
+	rows, err := tr.db.NamedQueryContext(ctx, q, dbTeamPolicy{TeamID: teamID})
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+	defer rows.Close()
+
+	var tps []auth.TeamPolicy
+	for rows.Next() {
+		var dbtp dbTeamPolicy
+		if err := rows.StructScan(&dbtp); err != nil {
+			return nil, errors.Wrap(errors.ErrRetrieveEntity, err)
+		}
+		tps = append(tps, toTeamPolicy(dbtp))
+	}
+
+	return tps, nil
+}
+
+func (tr teamRepository) RetrieveMemberPolicies(ctx context.Context, memberID, groupID string) ([]string, error) {
+	q := `SELECT tp.policy FROM team_policies tp
+		JOIN team_members tm ON tm.team_id = tp.team_id
+		WHERE tm.member_id = :member_id AND tp.group_id = :group_id;`
+
+	params := map[string]interface{}{
+		"member_id": memberID,
+		"group_id":  groupID,
+	}
+
+	rows, err := tr.db.NamedQueryContext(ctx, q, params)
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+	defer rows.Close()
+
+	var policies []string
+	for rows.Next() {
+		var policy string
+		if err := rows.Scan(&policy); err != nil {
+			return nil, errors.Wrap(errors.ErrRetrieveEntity, err)
+		}
+		policies = append(policies, policy)
+	}
+
+	return policies, nil
+}
+
+type dbTeam struct {
+	ID          string         `db:"id"`
+	OrgID       string         `db:"org_id"`
+	OwnerID     string         `db:"owner_id"`
+	Name        string         `db:"name"`
+	Description sql.NullString `db:"description"`
+	CreatedAt   time.Time      `db:"created_at"`
+	UpdatedAt   time.Time      `db:"updated_at"`
+}
+
+func toDBTeam(t auth.Team) dbTeam {
+	return dbTeam{
+		ID:          t.ID,
+		OrgID:       t.OrgID,
+		OwnerID:     t.OwnerID,
+		Name:        t.Name,
+		Description: sql.NullString{String: t.Description, Valid: t.Description != ""},
+		CreatedAt:   t.CreatedAt,
+		UpdatedAt:   t.UpdatedAt,
+	}
+}
+
+func toTeam(dbt dbTeam) auth.Team {
+	return auth.Team{
+		ID:          dbt.ID,
+		OrgID:       dbt.OrgID,
+		OwnerID:     dbt.OwnerID,
+		Name:        dbt.Name,
+		Description: dbt.Description.String,
+		CreatedAt:   dbt.CreatedAt,
+		UpdatedAt:   dbt.UpdatedAt,
+	}
+}
+
+type dbTeamMember struct {
+	TeamID   string `db:"team_id"`
+	MemberID string `db:"member_id"`
+}
+
+type dbTeamPolicy struct {
+	TeamID  string `db:"team_id"`
+	GroupID string `db:"group_id"`
+	Policy  string `db:"policy"`
+}
+
+func toDBTeamPolicy(tp auth.TeamPolicy) dbTeamPolicy {
+	return dbTeamPolicy{
+		TeamID:  tp.TeamID,
+		GroupID: tp.GroupID,
+		Policy:  tp.Policy,
+	}
+}
+
+func toTeamPolicy(dbtp dbTeamPolicy) auth.TeamPolicy {
+	return auth.TeamPolicy{
+		TeamID:  dbtp.TeamID,
+		GroupID: dbtp.GroupID,
+		Policy:  dbtp.Policy,
+	}
+}
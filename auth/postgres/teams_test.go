@@ -0,0 +1,120 @@
+package postgres_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/auth"
+	"github.com/MainfluxLabs/mainflux/auth/postgres"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const teamName = "team"
+
+func TestSaveTeam(t *testing.T) {
+	dbMiddleware := postgres.NewDatabase(db)
+	orgRepo := postgres.NewOrgRepo(dbMiddleware)
+	repo := postgres.NewTeamRepo(dbMiddleware)
+
+	org := createOrg(t, orgRepo)
+
+	id, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	team := auth.Team{ID: id, OrgID: org.ID, OwnerID: org.OwnerID, Name: teamName, CreatedAt: time.Now(), UpdatedAt: time.Now()}
+
+	cases := []struct {
+		desc string
+		team auth.Team
+		err  error
+	}{
+		{
+			desc: "save team",
+			team: team,
+			err:  nil,
+		},
+		{
+			desc: "save existing team",
+			team: team,
+			err:  errors.ErrConflict,
+		},
+		{
+			desc: "save team with invalid id",
+			team: auth.Team{ID: invalidID, OrgID: org.ID, OwnerID: org.OwnerID, Name: teamName},
+			err:  errors.ErrMalformedEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		err := repo.Save(context.Background(), tc.team)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestRetrieveMemberPolicies(t *testing.T) {
+	dbMiddleware := postgres.NewDatabase(db)
+	orgRepo := postgres.NewOrgRepo(dbMiddleware)
+	repo := postgres.NewTeamRepo(dbMiddleware)
+
+	org := createOrg(t, orgRepo)
+
+	groupID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	err = orgRepo.AssignGroups(context.Background(), auth.GroupRelation{GroupID: groupID, OrgID: org.ID, CreatedAt: time.Now(), UpdatedAt: time.Now()})
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	teamID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	err = repo.Save(context.Background(), auth.Team{ID: teamID, OrgID: org.ID, OwnerID: org.OwnerID, Name: teamName})
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	memberID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	otherID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	err = repo.AssignMembers(context.Background(), teamID, memberID)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	err = repo.SavePolicy(context.Background(), auth.TeamPolicy{TeamID: teamID, GroupID: groupID, Policy: auth.RwPolicy})
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	cases := []struct {
+		desc     string
+		memberID string
+		policies []string
+	}{
+		{
+			desc:     "retrieve policies of team member",
+			memberID: memberID,
+			policies: []string{auth.RwPolicy},
+		},
+		{
+			desc:     "retrieve policies of non-team member",
+			memberID: otherID,
+			policies: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		policies, err := repo.RetrieveMemberPolicies(context.Background(), tc.memberID, groupID)
+		assert.Nil(t, err, fmt.Sprintf("%s: got unexpected error: %s\n", tc.desc, err))
+		assert.Equal(t, tc.policies, policies, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.policies, policies))
+	}
+}
+
+func createOrg(t *testing.T, repo auth.OrgRepository) auth.Org {
+	id, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	ownerID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	org := auth.Org{ID: id, OwnerID: ownerID, Name: orgName, Description: orgDesc, CreatedAt: time.Now(), UpdatedAt: time.Now()}
+	err = repo.Save(context.Background(), org)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	return org
+}
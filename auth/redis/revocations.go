@@ -0,0 +1,88 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package redis
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/auth"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/go-redis/redis/v8"
+)
+
+const (
+	keyPrefix        = "revoked_key"
+	userPrefix       = "revoked_user"
+	minRevocationTTL = time.Second
+)
+
+var _ auth.RevocationRepository = (*revocationRepository)(nil)
+
+type revocationRepository struct {
+	client *redis.Client
+	ttl    time.Duration
+}
+
+// NewRevocationRepository returns redis revoked keys repository. Revocations
+// of all user keys are kept for the provided TTL, which has to be at least
+// as long as the longest lived revoked key.
+func NewRevocationRepository(client *redis.Client, ttl time.Duration) auth.RevocationRepository {
+	return &revocationRepository{
+		client: client,
+		ttl:    ttl,
+	}
+}
+
+func (rr *revocationRepository) Revoke(ctx context.Context, id string, expiresAt time.Time) (bool, error) {
+	// Keys expiring meanwhile are kept revoked briefly, so that the
+	// concurrent revocations of the key are still detected.
+	ttl := time.Until(expiresAt)
+	if ttl < minRevocationTTL {
+		ttl = minRevocationTTL
+	}
+
+	k := fmt.Sprintf("%s:%s", keyPrefix, id)
+	ok, err := rr.client.SetNX(ctx, k, true, ttl).Result()
+	if err != nil {
+		return false, errors.Wrap(errors.ErrCreateEntity, err)
+	}
+
+	return ok, nil
+}
+
+func (rr *revocationRepository) RevokeAll(ctx context.Context, userID string, until time.Time) error {
+	k := fmt.Sprintf("%s:%s", userPrefix, userID)
+	if err := rr.client.Set(ctx, k, until.UTC().Format(time.RFC3339Nano), rr.ttl).Err(); err != nil {
+		return errors.Wrap(errors.ErrCreateEntity, err)
+	}
+
+	return nil
+}
+
+func (rr *revocationRepository) RevokedUntil(ctx context.Context, userID string) (time.Time, error) {
+	k := fmt.Sprintf("%s:%s", userPrefix, userID)
+	val, err := rr.client.Get(ctx, k).Result()
+	// Redis returns Nil Reply when key does not exist.
+	if err == redis.Nil {
+		return time.Time{}, nil
+	}
+	if err != nil {
+		return time.Time{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+
+	if until, err := time.Parse(time.RFC3339Nano, val); err == nil {
+		return until.UTC(), nil
+	}
+
+	// Revocations stored by the previous versions are kept in seconds.
+	secs, err := strconv.ParseInt(val, 10, 64)
+	if err != nil {
+		return time.Time{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+
+	return time.Unix(secs, 0).UTC(), nil
+}
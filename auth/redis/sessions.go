@@ -0,0 +1,148 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/auth"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/go-redis/redis/v8"
+)
+
+const (
+	sessionPrefix     = "session"
+	userSessionPrefix = "user_sessions"
+)
+
+var _ auth.SessionRepository = (*sessionRepository)(nil)
+
+type sessionRepository struct {
+	client *redis.Client
+	ttl    time.Duration
+}
+
+// NewSessionRepository returns redis sessions repository. Every session
+// expires together with its refresh key, while the index of the user
+// sessions is kept for the provided TTL since the last login or refresh.
+func NewSessionRepository(client *redis.Client, ttl time.Duration) auth.SessionRepository {
+	return &sessionRepository{
+		client: client,
+		ttl:    ttl,
+	}
+}
+
+func (sr *sessionRepository) Save(ctx context.Context, s auth.Session) error {
+	ttl := time.Until(s.ExpiresAt)
+	if ttl <= 0 {
+		return nil
+	}
+
+	data, err := json.Marshal(s)
+	if err != nil {
+		return errors.Wrap(errors.ErrCreateEntity, err)
+	}
+
+	idx := fmt.Sprintf("%s:%s", userSessionPrefix, s.UserID)
+	pipe := sr.client.TxPipeline()
+	pipe.Set(ctx, sessionKey(s.UserID, s.ID), data, ttl)
+	pipe.SAdd(ctx, idx, s.ID)
+	pipe.Expire(ctx, idx, sr.ttl)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return errors.Wrap(errors.ErrCreateEntity, err)
+	}
+
+	return nil
+}
+
+func (sr *sessionRepository) Retrieve(ctx context.Context, userID, id string) (auth.Session, error) {
+	data, err := sr.client.Get(ctx, sessionKey(userID, id)).Bytes()
+	// Redis returns Nil Reply when key does not exist.
+	if err == redis.Nil {
+		return auth.Session{}, errors.ErrNotFound
+	}
+	if err != nil {
+		return auth.Session{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+
+	var s auth.Session
+	if err := json.Unmarshal(data, &s); err != nil {
+		return auth.Session{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+
+	return s, nil
+}
+
+func (sr *sessionRepository) RetrieveAll(ctx context.Context, userID string) ([]auth.Session, error) {
+	idx := fmt.Sprintf("%s:%s", userSessionPrefix, userID)
+	ids, err := sr.client.SMembers(ctx, idx).Result()
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+
+	var sessions []auth.Session
+	for _, id := range ids {
+		s, err := sr.Retrieve(ctx, userID, id)
+		if errors.Contains(err, errors.ErrNotFound) {
+			// Expired sessions are dropped from the index lazily.
+			if err := sr.client.SRem(ctx, idx, id).Err(); err != nil {
+				return nil, errors.Wrap(errors.ErrRetrieveEntity, err)
+			}
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, s)
+	}
+
+	sort.SliceStable(sessions, func(i, j int) bool {
+		return sessions[i].IssuedAt.Before(sessions[j].IssuedAt)
+	})
+
+	return sessions, nil
+}
+
+func (sr *sessionRepository) Remove(ctx context.Context, userID, id string) error {
+	n, err := sr.client.Del(ctx, sessionKey(userID, id)).Result()
+	if err != nil {
+		return errors.Wrap(errors.ErrRemoveEntity, err)
+	}
+	if n == 0 {
+		return errors.ErrNotFound
+	}
+
+	idx := fmt.Sprintf("%s:%s", userSessionPrefix, userID)
+	if err := sr.client.SRem(ctx, idx, id).Err(); err != nil {
+		return errors.Wrap(errors.ErrRemoveEntity, err)
+	}
+
+	return nil
+}
+
+func (sr *sessionRepository) RemoveAll(ctx context.Context, userID string) error {
+	idx := fmt.Sprintf("%s:%s", userSessionPrefix, userID)
+	ids, err := sr.client.SMembers(ctx, idx).Result()
+	if err != nil {
+		return errors.Wrap(errors.ErrRemoveEntity, err)
+	}
+
+	keys := []string{idx}
+	for _, id := range ids {
+		keys = append(keys, sessionKey(userID, id))
+	}
+	if err := sr.client.Del(ctx, keys...).Err(); err != nil {
+		return errors.Wrap(errors.ErrRemoveEntity, err)
+	}
+
+	return nil
+}
+
+func sessionKey(userID, id string) string {
+	return fmt.Sprintf("%s:%s:%s", sessionPrefix, userID, id)
+}
@@ -13,6 +13,7 @@ import (
 
 const (
 	recoveryDuration = 5 * time.Minute
+	membersPageLimit = 100
 	ViewerRole       = "viewer"
 	AdminRole        = "admin"
 	OwnerRole        = "owner"
@@ -37,12 +38,21 @@ var (
 	errRevoke         = errors.New("failed to remove key")
 	errRetrieve       = errors.New("failed to retrieve key data")
 	errIdentify       = errors.New("failed to validate token")
+	errRefresh        = errors.New("failed to refresh token")
+	errRevokedKey     = errors.New("use of revoked key")
+	errEndedSession   = errors.New("use of key of ended session")
 	errUnknownSubject = errors.New("unknown subject")
+	errOwnerRelation  = errors.New("owner relation can't be changed")
+	errUnknownEmail   = errors.New("user with the email doesn't exist")
 )
 
 type Roles interface {
 	// AssignRole assigns a role to a user.
 	AssignRole(ctx context.Context, id, role string) error
+
+	// RetrieveRole retrieves the role of the user, or empty string if the
+	// user has no role assigned.
+	RetrieveRole(ctx context.Context, id string) (string, error)
 }
 
 // Authn specifies an API that must be fullfiled by the domain service
@@ -61,6 +71,28 @@ type Authn interface {
 	// ID, that is issued by the user identified by the provided key.
 	RetrieveKey(ctx context.Context, token, id string) (Key, error)
 
+	// Refresh issues a new login key and refresh key given the valid
+	// refresh token, returning their token values. The provided refresh
+	// token is revoked, so that it can be used only once.
+	Refresh(ctx context.Context, token string) (string, string, error)
+
+	// RevokeAll revokes all login and refresh keys of the user identified
+	// by the provided key, ending all sessions of the user.
+	RevokeAll(ctx context.Context, token string) error
+
+	// OpenSession records a new session of the user with the provided
+	// client details, issuing the refresh key of the session and
+	// returning its token value alongside.
+	OpenSession(ctx context.Context, s Session, key Key) (Key, string, error)
+
+	// ListSessions retrieves the active sessions of the user with the
+	// provided ID. Only the user and the root admin can list them.
+	ListSessions(ctx context.Context, token, userID string) ([]Session, error)
+
+	// RevokeSession ends the session of the user, invalidating all login
+	// and refresh keys issued for it.
+	RevokeSession(ctx context.Context, token, userID, id string) error
+
 	// Identify validates token token. If token is valid, content
 	// is returned. If token is invalid, or invocation failed for some
 	// other reason, non-nil error value is returned in response.
@@ -79,7 +111,17 @@ type AuthzReq struct {
 // functionalities through `auth` to perform authorization.
 type Authz interface {
 	Authorize(ctx context.Context, ar AuthzReq) error
-	AddPolicy(ctx context.Context, token, groupID, policy string) error
+	// AddPolicy adds a policy to the object of the given subject. Thing and
+	// channel policies grant the relation to the member, and can only be
+	// added by the owner of the object or by the admin. The relation of the
+	// owner can't be changed. Policies of other subjects are added to the
+	// identified user, and the member ID is ignored.
+	AddPolicy(ctx context.Context, token, memberID, subject, object, policy string) error
+
+	// ClaimOwnership makes the user the owner of the thing or channel which
+	// has no policies yet. It's used by the things service on creating the
+	// object, and it's not exposed to the users.
+	ClaimOwnership(ctx context.Context, ownerID, subject, object string) error
 }
 
 // Service specifies an API that must be fulfilled by the domain service
@@ -91,32 +133,44 @@ type Service interface {
 	Authz
 	Roles
 	Orgs
+	Policies
+	Teams
 }
 
 var _ Service = (*service)(nil)
 
 type service struct {
-	orgs          OrgRepository
-	users         mainflux.UsersServiceClient
-	things        mainflux.ThingsServiceClient
-	keys          KeyRepository
-	roles         RolesRepository
-	idProvider    mainflux.IDProvider
-	tokenizer     Tokenizer
-	loginDuration time.Duration
+	orgs            OrgRepository
+	users           mainflux.UsersServiceClient
+	things          mainflux.ThingsServiceClient
+	keys            KeyRepository
+	revocations     RevocationRepository
+	sessions        SessionRepository
+	roles           RolesRepository
+	policies        PoliciesRepository
+	teams           TeamRepository
+	idProvider      mainflux.IDProvider
+	tokenizer       Tokenizer
+	loginDuration   time.Duration
+	refreshDuration time.Duration
 }
 
 // New instantiates the auth service implementation.
-func New(orgs OrgRepository, tc mainflux.ThingsServiceClient, uc mainflux.UsersServiceClient, keys KeyRepository, roles RolesRepository, idp mainflux.IDProvider, tokenizer Tokenizer, duration time.Duration) Service {
+func New(orgs OrgRepository, tc mainflux.ThingsServiceClient, uc mainflux.UsersServiceClient, keys KeyRepository, revocations RevocationRepository, sessions SessionRepository, roles RolesRepository, policies PoliciesRepository, teams TeamRepository, idp mainflux.IDProvider, tokenizer Tokenizer, loginDuration, refreshDuration time.Duration) Service {
 	return &service{
-		tokenizer:     tokenizer,
-		things:        tc,
-		orgs:          orgs,
-		users:         uc,
-		keys:          keys,
-		roles:         roles,
-		idProvider:    idp,
-		loginDuration: duration,
+		tokenizer:       tokenizer,
+		things:          tc,
+		orgs:            orgs,
+		users:           uc,
+		keys:            keys,
+		revocations:     revocations,
+		sessions:        sessions,
+		roles:           roles,
+		policies:        policies,
+		teams:           teams,
+		idProvider:      idp,
+		loginDuration:   loginDuration,
+		refreshDuration: refreshDuration,
 	}
 }
 
@@ -129,13 +183,155 @@ func (svc service) Issue(ctx context.Context, token string, key Key) (Key, strin
 		return svc.userKey(ctx, token, key)
 	case RecoveryKey:
 		return svc.tmpKey(recoveryDuration, key)
+	case RefreshKey:
+		return svc.refreshKey(key)
 	default:
 		return svc.tmpKey(svc.loginDuration, key)
 	}
 }
 
+func (svc service) Refresh(ctx context.Context, token string) (string, string, error) {
+	key, err := svc.tokenizer.Parse(token)
+	if err != nil {
+		return "", "", errors.Wrap(errors.ErrAuthentication, err)
+	}
+	if key.Type != RefreshKey || key.ID == "" || key.IssuerID == "" {
+		return "", "", errors.Wrap(errRefresh, errors.ErrAuthentication)
+	}
+
+	if err := svc.checkRevoked(ctx, key); err != nil {
+		return "", "", errors.Wrap(errRefresh, err)
+	}
+
+	// The refresh key is revoked atomically, so only one of the concurrent
+	// refreshes with the same key rotates it, while others are reuses.
+	rotated, err := svc.revocations.Revoke(ctx, key.ID, key.ExpiresAt)
+	if err != nil {
+		return "", "", errors.Wrap(errRefresh, err)
+	}
+	if !rotated {
+		// Reuse of the rotated refresh token indicates that the token
+		// leaked, so all sessions of the user are ended.
+		if err := svc.revocations.RevokeAll(ctx, key.IssuerID, revokedUntil()); err != nil {
+			return "", "", errors.Wrap(errRefresh, err)
+		}
+		if err := svc.sessions.RemoveAll(ctx, key.IssuerID); err != nil {
+			return "", "", errors.Wrap(errRefresh, err)
+		}
+		return "", "", errors.Wrap(errors.ErrAuthentication, errRevokedKey)
+	}
+
+	now := time.Now().UTC()
+	loginKey := Key{
+		Type:      LoginKey,
+		IssuerID:  key.IssuerID,
+		Subject:   key.Subject,
+		IssuedAt:  now,
+		SessionID: key.SessionID,
+	}
+	_, access, err := svc.tmpKey(svc.loginDuration, loginKey)
+	if err != nil {
+		return "", "", errors.Wrap(errRefresh, err)
+	}
+
+	refreshKey := Key{
+		Type:      RefreshKey,
+		IssuerID:  key.IssuerID,
+		Subject:   key.Subject,
+		IssuedAt:  now,
+		SessionID: key.SessionID,
+	}
+	refreshKey, refresh, err := svc.refreshKey(refreshKey)
+	if err != nil {
+		return "", "", errors.Wrap(errRefresh, err)
+	}
+
+	if key.SessionID != "" {
+		s, err := svc.sessions.Retrieve(ctx, key.IssuerID, key.SessionID)
+		if err != nil {
+			return "", "", errors.Wrap(errRefresh, err)
+		}
+		// The session lasts as long as its latest refresh key.
+		s.ExpiresAt = refreshKey.ExpiresAt
+		if err := svc.sessions.Save(ctx, s); err != nil {
+			return "", "", errors.Wrap(errRefresh, err)
+		}
+	}
+
+	return access, refresh, nil
+}
+
+func (svc service) RevokeAll(ctx context.Context, token string) error {
+	issuerID, _, err := svc.login(ctx, token)
+	if err != nil {
+		return errors.Wrap(errRevoke, err)
+	}
+	if err := svc.revocations.RevokeAll(ctx, issuerID, revokedUntil()); err != nil {
+		return errors.Wrap(errRevoke, err)
+	}
+	if err := svc.sessions.RemoveAll(ctx, issuerID); err != nil {
+		return errors.Wrap(errRevoke, err)
+	}
+
+	return nil
+}
+
+func (svc service) OpenSession(ctx context.Context, s Session, key Key) (Key, string, error) {
+	if key.IssuedAt.IsZero() {
+		return Key{}, "", ErrInvalidKeyIssuedAt
+	}
+	if key.Type != RefreshKey || s.ID == "" {
+		return Key{}, "", errors.ErrMalformedEntity
+	}
+
+	key.SessionID = s.ID
+	key, secret, err := svc.refreshKey(key)
+	if err != nil {
+		return Key{}, "", err
+	}
+
+	s.UserID = key.IssuerID
+	s.IssuedAt = key.IssuedAt
+	s.ExpiresAt = key.ExpiresAt
+	if err := svc.sessions.Save(ctx, s); err != nil {
+		return Key{}, "", errors.Wrap(errIssueTmp, err)
+	}
+
+	return key, secret, nil
+}
+
+func (svc service) ListSessions(ctx context.Context, token, userID string) ([]Session, error) {
+	if err := svc.canManageSessions(ctx, token, userID); err != nil {
+		return nil, err
+	}
+
+	return svc.sessions.RetrieveAll(ctx, userID)
+}
+
+func (svc service) RevokeSession(ctx context.Context, token, userID, id string) error {
+	if err := svc.canManageSessions(ctx, token, userID); err != nil {
+		return err
+	}
+
+	return svc.sessions.Remove(ctx, userID, id)
+}
+
+// canManageSessions returns an error if the identified user is neither
+// the owner of the sessions nor the root admin.
+func (svc service) canManageSessions(ctx context.Context, token, userID string) error {
+	user, err := svc.Identify(ctx, token)
+	if err != nil {
+		return err
+	}
+	if user.ID == userID {
+		return nil
+	}
+
+	return svc.isAdmin(ctx, user.ID)
+}
+
 func (svc service) Revoke(ctx context.Context, token, id string) error {
-	issuerID, _, err := svc.login(token)
+	issuerID, _, err := svc.login(ctx, token)
 	if err != nil {
 		return errors.Wrap(errRevoke, err)
 	}
@@ -146,7 +342,7 @@ func (svc service) Revoke(ctx context.Context, token, id string) error {
 }
 
 func (svc service) RetrieveKey(ctx context.Context, token, id string) (Key, error) {
-	issuerID, _, err := svc.login(token)
+	issuerID, _, err := svc.login(ctx, token)
 	if err != nil {
 		return Key{}, errors.Wrap(errRetrieve, err)
 	}
@@ -169,6 +365,8 @@ func (svc service) Authorize(ctx context.Context, ar AuthzReq) error {
 		return svc.isAdmin(ctx, user.ID)
 	case GroupSubject:
 		return svc.canAccessGroup(ctx, user.ID, ar.Object, ar.Action)
+	case ThingSubject, ChannelSubject:
+		return svc.canAccessObject(ctx, user.ID, ar.Subject, ar.Object, ar.Action)
 	default:
 		return errUnknownSubject
 	}
@@ -184,8 +382,18 @@ func (svc service) tmpKey(duration time.Duration, key Key) (Key, string, error)
 	return key, secret, nil
 }
 
+func (svc service) refreshKey(key Key) (Key, string, error) {
+	id, err := svc.idProvider.ID()
+	if err != nil {
+		return Key{}, "", errors.Wrap(errIssueTmp, err)
+	}
+	key.ID = id
+
+	return svc.tmpKey(svc.refreshDuration, key)
+}
+
 func (svc service) userKey(ctx context.Context, token string, key Key) (Key, string, error) {
-	id, sub, err := svc.login(token)
+	id, sub, err := svc.login(ctx, token)
 	if err != nil {
 		return Key{}, "", errors.Wrap(errIssueUser, err)
 	}
@@ -213,7 +421,7 @@ func (svc service) userKey(ctx context.Context, token string, key Key) (Key, str
 	return key, secret, nil
 }
 
-func (svc service) login(token string) (string, string, error) {
+func (svc service) login(ctx context.Context, token string) (string, string, error) {
 	key, err := svc.tokenizer.Parse(token)
 	if err != nil {
 		return "", "", err
@@ -222,10 +430,46 @@ func (svc service) login(token string) (string, string, error) {
 	if key.Type != LoginKey || key.IssuerID == "" {
 		return "", "", errors.ErrAuthentication
 	}
+	if err := svc.checkRevoked(ctx, key); err != nil {
+		return "", "", err
+	}
 
 	return key.IssuerID, key.Subject, nil
 }
 
+// revokedUntil returns the time until which the keys are revoked when all
+// keys of the user are revoked. Key issue times are kept with the precision
+// of nanoseconds, so the keys issued earlier within the same second are
+// revoked, while the keys issued right after the revocation stay valid.
+func revokedUntil() time.Time {
+	return time.Now().UTC()
+}
+
+// checkRevoked returns an error if the key is issued before all keys of
+// its issuer were revoked, or if the session of the key has ended.
+func (svc service) checkRevoked(ctx context.Context, key Key) error {
+	until, err := svc.revocations.RevokedUntil(ctx, key.IssuerID)
+	if err != nil {
+		return errors.Wrap(errRetrieve, err)
+	}
+	if !until.IsZero() && key.IssuedAt.Before(until) {
+		return errors.Wrap(errors.ErrAuthentication, errRevokedKey)
+	}
+
+	if key.SessionID == "" {
+		return nil
+	}
+	_, err = svc.sessions.Retrieve(ctx, key.IssuerID, key.SessionID)
+	switch {
+	case errors.Contains(err, errors.ErrNotFound):
+		return errors.Wrap(errors.ErrAuthentication, errEndedSession)
+	case err != nil:
+		return errors.Wrap(errRetrieve, err)
+	}
+
+	return nil
+}
+
 func getTimestmap() time.Time {
 	return time.Now().UTC().Round(time.Millisecond)
 }
@@ -378,11 +622,25 @@ func (svc service) AssignMembersByIDs(ctx context.Context, token, orgID string,
 		return err
 	}
 
-	if err := svc.canEditMembers(ctx, orgID, user.ID, memberIDs...); err != nil {
+	if err := svc.canEditOrg(ctx, orgID, user.ID); err != nil {
 		return err
 	}
 
-	if err := svc.orgs.AssignMembers(ctx, MemberRelation{}); err != nil {
+	timestamp := getTimestmap()
+	var mrs []MemberRelation
+	for _, memberID := range memberIDs {
+		mr := MemberRelation{
+			OrgID:     orgID,
+			MemberID:  memberID,
+			Role:      ViewerRole,
+			UpdatedAt: timestamp,
+			CreatedAt: timestamp,
+		}
+
+		mrs = append(mrs, mr)
+	}
+
+	if err := svc.orgs.AssignMembers(ctx, mrs...); err != nil {
 		return err
 	}
 
@@ -742,6 +1000,25 @@ func (svc service) ListOrgMemberships(ctx context.Context, token string, memberI
 	return svc.orgs.RetrieveMemberships(ctx, memberID, pm)
 }
 
+func (svc service) RetrieveMemberships(ctx context.Context, memberID string) ([]string, error) {
+	pm := PageMetadata{Limit: membersPageLimit}
+
+	var orgIDs []string
+	for {
+		op, err := svc.orgs.RetrieveMemberships(ctx, memberID, pm)
+		if err != nil {
+			return nil, err
+		}
+		for _, org := range op.Orgs {
+			orgIDs = append(orgIDs, org.ID)
+		}
+		if uint64(len(op.Orgs)) < pm.Limit {
+			return orgIDs, nil
+		}
+		pm.Offset += pm.Limit
+	}
+}
+
 func (svc service) CreatePolicies(ctx context.Context, token, groupID string, giByEmails ...GroupInvitationByEmail) error {
 	user, err := svc.Identify(ctx, token)
 	if err != nil {
@@ -909,12 +1186,7 @@ func (svc service) RemovePolicies(ctx context.Context, token, groupID string, me
 }
 
 func (svc service) canAccessGroup(ctx context.Context, userID, Object, action string) error {
-	gp := GroupsPolicy{
-		MemberID: userID,
-		GroupID:  Object,
-	}
-
-	policy, err := svc.orgs.RetrievePolicy(ctx, gp)
+	policy, err := svc.groupPolicy(ctx, userID, Object)
 	if err != nil {
 		return err
 	}
@@ -945,24 +1217,526 @@ func (svc service) canAccessGroup(ctx context.Context, userID, Object, action st
 	return nil
 }
 
-func (svc service) AddPolicy(ctx context.Context, token, groupID, policy string) error {
+// groupPolicy returns the policy of the member to the group, granted either
+// to the member directly or to any of the member's teams. The read_write
+// policy takes precedence over the read policy.
+func (svc service) groupPolicy(ctx context.Context, memberID, groupID string) (string, error) {
+	gp := GroupsPolicy{
+		MemberID: memberID,
+		GroupID:  groupID,
+	}
+
+	policy, err := svc.orgs.RetrievePolicy(ctx, gp)
+	if err != nil {
+		return "", err
+	}
+
+	if policy == RwPolicy {
+		return policy, nil
+	}
+
+	tps, err := svc.teams.RetrieveMemberPolicies(ctx, memberID, groupID)
+	if err != nil {
+		return "", err
+	}
+
+	for _, tp := range tps {
+		switch tp {
+		case RwPolicy:
+			return tp, nil
+		case RPolicy:
+			policy = tp
+		}
+	}
+
+	return policy, nil
+}
+
+func (svc service) canAccessObject(ctx context.Context, userID, subject, object, action string) error {
+	if err := svc.isAdmin(ctx, userID); err == nil {
+		return nil
+	}
+
+	relation, err := svc.objectRelation(ctx, userID, subject, object)
+	if err != nil {
+		return err
+	}
+
+	switch relation {
+	case OwnerRelation, EditorRelation:
+		return nil
+	case ViewerRelation:
+		if action == WriteAction {
+			return errors.ErrAuthorization
+		}
+		return nil
+	default:
+		return errors.ErrAuthorization
+	}
+}
+
+// objectRelation returns the relation of the user to the object, granted
+// either to the user directly or to any of the orgs the user is a member of.
+// The owner and editor relations take precedence over the viewer relation.
+func (svc service) objectRelation(ctx context.Context, userID, subject, object string) (string, error) {
+	relation, err := svc.policies.RetrieveRelation(ctx, userID, subject, object)
+	if err != nil {
+		return "", err
+	}
+
+	if relation == OwnerRelation || relation == EditorRelation {
+		return relation, nil
+	}
+
+	ps, err := svc.policies.RetrieveOrgPolicies(ctx, subject, object)
+	if err != nil {
+		return "", err
+	}
+
+	for _, p := range ps {
+		if _, err := svc.orgs.RetrieveRole(ctx, userID, p.MemberID); err != nil {
+			if errors.Contains(err, errors.ErrNotFound) {
+				continue
+			}
+			return "", err
+		}
+
+		switch p.Relation {
+		case EditorRelation:
+			return p.Relation, nil
+		case ViewerRelation:
+			relation = p.Relation
+		}
+	}
+
+	return relation, nil
+}
+
+func (svc service) AddPolicy(ctx context.Context, token, memberID, subject, object, policy string) error {
 	user, err := svc.Identify(ctx, token)
 	if err != nil {
 		return err
 	}
 
+	if isObjectSubject(subject) {
+		if err := svc.isObjectOwner(ctx, user.ID, subject, object); err != nil {
+			return err
+		}
+
+		relation, err := svc.policies.RetrieveRelation(ctx, memberID, subject, object)
+		if err != nil {
+			return err
+		}
+		if relation == OwnerRelation {
+			return errors.Wrap(errors.ErrAuthorization, errOwnerRelation)
+		}
+
+		p := Policy{
+			MemberID:   memberID,
+			MemberType: UserMember,
+			ObjectType: subject,
+			ObjectID:   object,
+			Relation:   policy,
+		}
+
+		return svc.policies.SavePolicies(ctx, p)
+	}
+
 	giByIDs := GroupInvitationByID{
 		MemberID: user.ID,
 		Policy:   policy,
 	}
 
-	if err := svc.orgs.SavePolicies(ctx, groupID, giByIDs); err != nil {
+	if err := svc.orgs.SavePolicies(ctx, object, giByIDs); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (svc service) ClaimOwnership(ctx context.Context, ownerID, subject, object string) error {
+	if !isObjectSubject(subject) {
+		return ErrInvalidPolicy
+	}
+
+	ps, err := svc.policies.RetrieveByObject(ctx, subject, object)
+	if err != nil {
+		return err
+	}
+
+	// Claiming the object again is a no-op, so that the owners of the
+	// existing objects can be migrated repeatedly.
+	for _, p := range ps {
+		if p.MemberID == ownerID && p.Relation == OwnerRelation {
+			return nil
+		}
+	}
+
+	if len(ps) > 0 {
+		return errors.ErrAuthorization
+	}
+
+	p := Policy{
+		MemberID:   ownerID,
+		MemberType: UserMember,
+		ObjectType: subject,
+		ObjectID:   object,
+		Relation:   OwnerRelation,
+	}
+
+	return svc.policies.SavePolicies(ctx, p)
+}
+
+func (svc service) ShareObject(ctx context.Context, token, subject, object string, inv ObjectInvitation) error {
+	user, err := svc.Identify(ctx, token)
+	if err != nil {
+		return err
+	}
+
+	if err := svc.isObjectOwner(ctx, user.ID, subject, object); err != nil {
+		return err
+	}
+
+	relation, err := toRelation(inv.Policy)
+	if err != nil {
+		return err
+	}
+
+	memberIDs, err := svc.invitedMembers(ctx, inv)
+	if err != nil {
+		return err
+	}
+
+	var ps []Policy
+	for _, memberID := range memberIDs {
+		// The owner relation must not be downgraded by sharing.
+		if memberID == user.ID {
+			continue
+		}
+
+		ps = append(ps, Policy{
+			MemberID:   memberID,
+			MemberType: UserMember,
+			ObjectType: subject,
+			ObjectID:   object,
+			Relation:   relation,
+		})
+	}
+
+	// The policy is granted to the org itself rather than to its current
+	// members, so that the members joining the org later are granted as well.
+	if inv.OrgID != "" {
+		if err := svc.canAccessOrg(ctx, inv.OrgID, user); err != nil {
+			return err
+		}
+
+		ps = append(ps, Policy{
+			MemberID:   inv.OrgID,
+			MemberType: OrgMember,
+			ObjectType: subject,
+			ObjectID:   object,
+			Relation:   relation,
+		})
+	}
+
+	if len(ps) == 0 {
+		return nil
+	}
+
+	return svc.policies.SavePolicies(ctx, ps...)
+}
+
+func (svc service) ListObjectPolicies(ctx context.Context, token, subject, object string) ([]Policy, error) {
+	user, err := svc.Identify(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := svc.isObjectOwner(ctx, user.ID, subject, object); err != nil {
+		return nil, err
+	}
+
+	return svc.policies.RetrieveByObject(ctx, subject, object)
+}
+
+func (svc service) UnshareObject(ctx context.Context, token, subject, object string, memberIDs ...string) error {
+	user, err := svc.Identify(ctx, token)
+	if err != nil {
+		return err
+	}
+
+	if err := svc.isObjectOwner(ctx, user.ID, subject, object); err != nil {
+		return err
+	}
+
+	var ids []string
+	for _, id := range memberIDs {
+		if id != user.ID {
+			ids = append(ids, id)
+		}
+	}
+
+	return svc.policies.RemovePolicies(ctx, subject, object, ids...)
+}
+
+func (svc service) isObjectOwner(ctx context.Context, userID, subject, object string) error {
+	if err := svc.isAdmin(ctx, userID); err == nil {
+		return nil
+	}
+
+	relation, err := svc.policies.RetrieveRelation(ctx, userID, subject, object)
+	if err != nil {
 		return err
 	}
 
+	if relation != OwnerRelation {
+		return errors.ErrAuthorization
+	}
+
 	return nil
 }
 
+// invitedMembers resolves the IDs of the users identified by the emails of
+// the invitation. ErrNotFound is returned if any of the users doesn't exist.
+func (svc service) invitedMembers(ctx context.Context, inv ObjectInvitation) ([]string, error) {
+	if len(inv.Emails) == 0 {
+		return nil, nil
+	}
+
+	usr, err := svc.users.GetUsersByEmails(ctx, &mainflux.UsersByEmailsReq{Emails: inv.Emails})
+	if err != nil {
+		return nil, err
+	}
+
+	found := map[string]bool{}
+	var memberIDs []string
+	for _, u := range usr.Users {
+		found[u.Email] = true
+		memberIDs = append(memberIDs, u.Id)
+	}
+
+	for _, email := range inv.Emails {
+		if !found[email] {
+			return nil, errors.Wrap(errors.ErrNotFound, errUnknownEmail)
+		}
+	}
+
+	return memberIDs, nil
+}
+
+func (svc service) CreateTeam(ctx context.Context, token string, t Team) (Team, error) {
+	user, err := svc.Identify(ctx, token)
+	if err != nil {
+		return Team{}, err
+	}
+
+	if err := svc.canEditOrg(ctx, t.OrgID, user.ID); err != nil {
+		return Team{}, err
+	}
+
+	id, err := svc.idProvider.ID()
+	if err != nil {
+		return Team{}, err
+	}
+
+	timestamp := getTimestmap()
+
+	team := Team{
+		ID:          id,
+		OrgID:       t.OrgID,
+		OwnerID:     user.ID,
+		Name:        t.Name,
+		Description: t.Description,
+		CreatedAt:   timestamp,
+		UpdatedAt:   timestamp,
+	}
+
+	if err := svc.teams.Save(ctx, team); err != nil {
+		return Team{}, err
+	}
+
+	return team, nil
+}
+
+func (svc service) ViewTeam(ctx context.Context, token, id string) (Team, error) {
+	user, err := svc.Identify(ctx, token)
+	if err != nil {
+		return Team{}, err
+	}
+
+	team, err := svc.teams.RetrieveByID(ctx, id)
+	if err != nil {
+		return Team{}, err
+	}
+
+	if err := svc.canAccessOrg(ctx, team.OrgID, user); err != nil {
+		return Team{}, err
+	}
+
+	return team, nil
+}
+
+func (svc service) ListTeams(ctx context.Context, token, orgID string, pm PageMetadata) (TeamsPage, error) {
+	user, err := svc.Identify(ctx, token)
+	if err != nil {
+		return TeamsPage{}, err
+	}
+
+	if err := svc.canAccessOrg(ctx, orgID, user); err != nil {
+		return TeamsPage{}, err
+	}
+
+	return svc.teams.RetrieveByOrg(ctx, orgID, pm)
+}
+
+func (svc service) UpdateTeam(ctx context.Context, token string, t Team) error {
+	team, err := svc.editableTeam(ctx, token, t.ID)
+	if err != nil {
+		return err
+	}
+
+	team.Name = t.Name
+	team.Description = t.Description
+	team.UpdatedAt = getTimestmap()
+
+	return svc.teams.Update(ctx, team)
+}
+
+func (svc service) RemoveTeam(ctx context.Context, token, id string) error {
+	if _, err := svc.editableTeam(ctx, token, id); err != nil {
+		return err
+	}
+
+	return svc.teams.Remove(ctx, id)
+}
+
+func (svc service) AssignTeamMembers(ctx context.Context, token, teamID string, memberIDs ...string) error {
+	team, err := svc.editableTeam(ctx, token, teamID)
+	if err != nil {
+		return err
+	}
+
+	for _, memberID := range memberIDs {
+		role, err := svc.orgs.RetrieveRole(ctx, memberID, team.OrgID)
+		if err != nil && !errors.Contains(err, errors.ErrNotFound) {
+			return err
+		}
+
+		if role == "" {
+			return ErrNotOrgMember
+		}
+	}
+
+	return svc.teams.AssignMembers(ctx, teamID, memberIDs...)
+}
+
+func (svc service) UnassignTeamMembers(ctx context.Context, token, teamID string, memberIDs ...string) error {
+	if _, err := svc.editableTeam(ctx, token, teamID); err != nil {
+		return err
+	}
+
+	return svc.teams.UnassignMembers(ctx, teamID, memberIDs...)
+}
+
+func (svc service) ListTeamMembers(ctx context.Context, token, teamID string, pm PageMetadata) (MembersPage, error) {
+	team, err := svc.ViewTeam(ctx, token, teamID)
+	if err != nil {
+		return MembersPage{}, err
+	}
+
+	tmp, err := svc.teams.RetrieveMembers(ctx, team.ID, pm)
+	if err != nil {
+		return MembersPage{}, errors.Wrap(ErrFailedToRetrieveMembers, err)
+	}
+
+	var members []Member
+	if len(tmp.MemberIDs) > 0 {
+		page, err := svc.users.GetUsersByIDs(ctx, &mainflux.UsersByIDsReq{Ids: tmp.MemberIDs})
+		if err != nil {
+			return MembersPage{}, err
+		}
+
+		for _, user := range page.Users {
+			members = append(members, Member{ID: user.Id, Email: user.GetEmail()})
+		}
+	}
+
+	mp := MembersPage{
+		Members: members,
+		PageMetadata: PageMetadata{
+			Total:  tmp.Total,
+			Offset: tmp.Offset,
+			Limit:  tmp.Limit,
+		},
+	}
+
+	return mp, nil
+}
+
+func (svc service) GrantTeamAccess(ctx context.Context, token, teamID, groupID, policy string) error {
+	if policy != RPolicy && policy != RwPolicy {
+		return ErrInvalidPolicy
+	}
+
+	team, err := svc.editableTeam(ctx, token, teamID)
+	if err != nil {
+		return err
+	}
+
+	org, err := svc.orgs.RetrieveByGroupID(ctx, groupID)
+	if err != nil {
+		return err
+	}
+
+	if org.ID != team.OrgID {
+		return errors.ErrAuthorization
+	}
+
+	tp := TeamPolicy{
+		TeamID:  teamID,
+		GroupID: groupID,
+		Policy:  policy,
+	}
+
+	return svc.teams.SavePolicy(ctx, tp)
+}
+
+func (svc service) RevokeTeamAccess(ctx context.Context, token, teamID, groupID string) error {
+	if _, err := svc.editableTeam(ctx, token, teamID); err != nil {
+		return err
+	}
+
+	return svc.teams.RemovePolicy(ctx, teamID, groupID)
+}
+
+func (svc service) ListTeamPolicies(ctx context.Context, token, teamID string) ([]TeamPolicy, error) {
+	team, err := svc.ViewTeam(ctx, token, teamID)
+	if err != nil {
+		return nil, err
+	}
+
+	return svc.teams.RetrievePolicies(ctx, team.ID)
+}
+
+// editableTeam retrieves the team if the user can edit the team's org.
+func (svc service) editableTeam(ctx context.Context, token, teamID string) (Team, error) {
+	user, err := svc.Identify(ctx, token)
+	if err != nil {
+		return Team{}, err
+	}
+
+	team, err := svc.teams.RetrieveByID(ctx, teamID)
+	if err != nil {
+		return Team{}, err
+	}
+
+	if err := svc.canEditOrg(ctx, team.OrgID, user.ID); err != nil {
+		return Team{}, err
+	}
+
+	return team, nil
+}
+
 func (svc service) Backup(ctx context.Context, token string) (Backup, error) {
 	user, err := svc.Identify(ctx, token)
 	if err != nil {
@@ -1030,6 +1804,10 @@ func (svc service) AssignRole(ctx context.Context, id, role string) error {
 	return nil
 }
 
+func (svc service) RetrieveRole(ctx context.Context, id string) (string, error) {
+	return svc.roles.RetrieveRole(ctx, id)
+}
+
 func (svc service) isAdmin(ctx context.Context, id string) error {
 	role, err := svc.roles.RetrieveRole(ctx, id)
 	if err != nil {
@@ -1129,7 +1907,15 @@ func (svc service) identify(ctx context.Context, token string) (Identity, error)
 	}
 
 	switch key.Type {
-	case RecoveryKey, LoginKey:
+	case LoginKey:
+		if err := svc.checkRevoked(ctx, key); err != nil {
+			return Identity{}, err
+		}
+		return Identity{ID: key.IssuerID, Email: key.Subject}, nil
+	case RecoveryKey:
+		if err := svc.checkRevoked(ctx, key); err != nil {
+			return Identity{}, err
+		}
 		return Identity{ID: key.IssuerID, Email: key.Subject}, nil
 	case APIKey:
 		_, err := svc.keys.Retrieve(context.TODO(), key.IssuerID, key.ID)
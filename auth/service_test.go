@@ -6,6 +6,7 @@ package auth_test
 import (
 	"context"
 	"fmt"
+	"sync"
 	"testing"
 	"time"
 
@@ -40,7 +41,8 @@ const (
 	invalid         = "invalid"
 	n               = 10
 
-	loginDuration = 30 * time.Minute
+	loginDuration   = 30 * time.Minute
+	refreshDuration = 24 * time.Hour
 )
 
 var (
@@ -56,10 +58,11 @@ func newService() auth.Service {
 	idMockProvider := uuid.NewMock()
 	orgRepo := mocks.NewOrgRepository()
 	roleRepo := mocks.NewRolesRepository()
+	policiesRepo := mocks.NewPoliciesRepository()
 	uc := mocks.NewUsersService(usersByIDs, usersByEmails)
 	tc := thmocks.NewThingsServiceClient(nil, createGroups())
 	t := jwt.New(secret)
-	return auth.New(orgRepo, tc, uc, keyRepo, roleRepo, idMockProvider, t, loginDuration)
+	return auth.New(orgRepo, tc, uc, keyRepo, mocks.NewRevocationRepository(), mocks.NewSessionRepository(), roleRepo, policiesRepo, mocks.NewTeamRepository(), idMockProvider, t, loginDuration, refreshDuration)
 }
 
 func createGroups() map[string]things.Group {
@@ -337,6 +340,304 @@ func TestIdentify(t *testing.T) {
 	}
 }
 
+func TestRefresh(t *testing.T) {
+	svc := newService()
+
+	_, loginSecret, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.LoginKey, IssuedAt: time.Now(), IssuerID: id, Subject: email})
+	assert.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
+
+	_, refreshSecret, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.RefreshKey, IssuedAt: time.Now(), IssuerID: id, Subject: email})
+	assert.Nil(t, err, fmt.Sprintf("Issuing refresh key expected to succeed: %s", err))
+
+	_, err = svc.Identify(context.Background(), refreshSecret)
+	assert.True(t, errors.Contains(err, errors.ErrAuthentication), fmt.Sprintf("identify refresh key: expected %s got %s\n", errors.ErrAuthentication, err))
+
+	access, refresh, err := svc.Refresh(context.Background(), refreshSecret)
+	assert.Nil(t, err, fmt.Sprintf("Refreshing token expected to succeed: %s", err))
+
+	idt, err := svc.Identify(context.Background(), access)
+	assert.Nil(t, err, fmt.Sprintf("Identifying refreshed login key expected to succeed: %s", err))
+	assert.Equal(t, auth.Identity{ID: id, Email: email}, idt, fmt.Sprintf("expected %v got %v", auth.Identity{ID: id, Email: email}, idt))
+
+	cases := []struct {
+		desc  string
+		token string
+		err   error
+	}{
+		{
+			desc:  "refresh with login key",
+			token: loginSecret,
+			err:   errors.ErrAuthentication,
+		},
+		{
+			desc:  "refresh with invalid token",
+			token: "invalid",
+			err:   errors.ErrAuthentication,
+		},
+		{
+			desc:  "refresh with used refresh key",
+			token: refreshSecret,
+			err:   errors.ErrAuthentication,
+		},
+		{
+			desc:  "refresh with rotated refresh key after reuse of used refresh key",
+			token: refresh,
+			err:   errors.ErrAuthentication,
+		},
+	}
+
+	for _, tc := range cases {
+		_, _, err := svc.Refresh(context.Background(), tc.token)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+
+	_, err = svc.Identify(context.Background(), access)
+	assert.True(t, errors.Contains(err, errors.ErrAuthentication), fmt.Sprintf("identify login key after reuse of used refresh key: expected %s got %s\n", errors.ErrAuthentication, err))
+}
+
+func TestRefreshConcurrent(t *testing.T) {
+	svc := newService()
+
+	_, refreshSecret, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.RefreshKey, IssuedAt: time.Now(), IssuerID: id, Subject: email})
+	require.Nil(t, err, fmt.Sprintf("Issuing refresh key expected to succeed: %s", err))
+
+	const refreshes = 10
+	var wg sync.WaitGroup
+	errs := make(chan error, refreshes)
+	for i := 0; i < refreshes; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, _, err := svc.Refresh(context.Background(), refreshSecret)
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	var rotated int
+	for err := range errs {
+		if err == nil {
+			rotated++
+			continue
+		}
+		assert.True(t, errors.Contains(err, errors.ErrAuthentication), fmt.Sprintf("concurrent refresh: expected %s got %s\n", errors.ErrAuthentication, err))
+	}
+	assert.Equal(t, 1, rotated, fmt.Sprintf("expected refresh key to be rotated once got %d times", rotated))
+}
+
+func TestRevokeAll(t *testing.T) {
+	svc := newService()
+
+	issuedAt := time.Now().Add(-time.Second)
+
+	_, loginSecret, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.LoginKey, IssuedAt: issuedAt, IssuerID: id, Subject: email})
+	assert.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
+
+	_, refreshSecret, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.RefreshKey, IssuedAt: issuedAt, IssuerID: id, Subject: email})
+	assert.Nil(t, err, fmt.Sprintf("Issuing refresh key expected to succeed: %s", err))
+
+	_, recoverySecret, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.RecoveryKey, IssuedAt: issuedAt, IssuerID: id, Subject: email})
+	assert.Nil(t, err, fmt.Sprintf("Issuing recovery key expected to succeed: %s", err))
+
+	_, apiSecret, err := svc.Issue(context.Background(), loginSecret, auth.Key{Type: auth.APIKey, IssuedAt: time.Now(), IssuerID: id, Subject: email})
+	assert.Nil(t, err, fmt.Sprintf("Issuing API key expected to succeed: %s", err))
+
+	// The keys issued within the same second as the revocation are revoked
+	// as well.
+	_, sameSecondLoginSecret, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.LoginKey, IssuedAt: time.Now(), IssuerID: id, Subject: email})
+	assert.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
+
+	_, sameSecondRefreshSecret, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.RefreshKey, IssuedAt: time.Now(), IssuerID: id, Subject: email})
+	assert.Nil(t, err, fmt.Sprintf("Issuing refresh key expected to succeed: %s", err))
+
+	err = svc.RevokeAll(context.Background(), "invalid")
+	assert.True(t, errors.Contains(err, errors.ErrAuthentication), fmt.Sprintf("revoke all with invalid token: expected %s got %s\n", errors.ErrAuthentication, err))
+
+	err = svc.RevokeAll(context.Background(), loginSecret)
+	assert.Nil(t, err, fmt.Sprintf("Revoking all keys expected to succeed: %s", err))
+
+	_, newLoginSecret, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.LoginKey, IssuedAt: time.Now(), IssuerID: id, Subject: email})
+	assert.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
+
+	cases := []struct {
+		desc string
+		key  string
+		err  error
+	}{
+		{
+			desc: "identify revoked login key",
+			key:  loginSecret,
+			err:  errors.ErrAuthentication,
+		},
+		{
+			desc: "identify revoked recovery key",
+			key:  recoverySecret,
+			err:  errors.ErrAuthentication,
+		},
+		{
+			desc: "identify login key issued within the second of revocation",
+			key:  sameSecondLoginSecret,
+			err:  errors.ErrAuthentication,
+		},
+		{
+			desc: "identify login key issued right after revocation",
+			key:  newLoginSecret,
+			err:  nil,
+		},
+		{
+			desc: "identify API key",
+			key:  apiSecret,
+			err:  nil,
+		},
+	}
+
+	for _, tc := range cases {
+		_, err := svc.Identify(context.Background(), tc.key)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+
+	_, _, err = svc.Refresh(context.Background(), refreshSecret)
+	assert.True(t, errors.Contains(err, errors.ErrAuthentication), fmt.Sprintf("refresh with revoked refresh key: expected %s got %s\n", errors.ErrAuthentication, err))
+
+	_, _, err = svc.Refresh(context.Background(), sameSecondRefreshSecret)
+	assert.True(t, errors.Contains(err, errors.ErrAuthentication), fmt.Sprintf("refresh with refresh key issued within the second of revocation: expected %s got %s\n", errors.ErrAuthentication, err))
+}
+
+func openSession(t *testing.T, svc auth.Service, userID, userEmail string) (string, string, string) {
+	sessionID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	_, loginSecret, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.LoginKey, IssuedAt: time.Now(), IssuerID: userID, Subject: userEmail, SessionID: sessionID})
+	require.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
+
+	s := auth.Session{ID: sessionID, IP: "10.0.0.1", UserAgent: "Mozilla/5.0"}
+	_, refreshSecret, err := svc.OpenSession(context.Background(), s, auth.Key{Type: auth.RefreshKey, IssuedAt: time.Now(), IssuerID: userID, Subject: userEmail})
+	require.Nil(t, err, fmt.Sprintf("Opening session expected to succeed: %s", err))
+
+	return sessionID, loginSecret, refreshSecret
+}
+
+func TestListSessions(t *testing.T) {
+	svc := newService()
+
+	err := svc.AssignRole(context.Background(), rootAdminID, auth.RoleRootAdmin)
+	require.Nil(t, err, fmt.Sprintf("Assigning role expected to succeed: %s", err))
+
+	sessionID, loginSecret, _ := openSession(t, svc, id, email)
+	_, viewerSecret, _ := openSession(t, svc, viewerID, viewerEmail)
+	_, adminSecret, _ := openSession(t, svc, rootAdminID, adminEmail)
+	openSession(t, svc, id, email)
+
+	cases := []struct {
+		desc   string
+		token  string
+		userID string
+		size   int
+		err    error
+	}{
+		{
+			desc:   "list own sessions",
+			token:  loginSecret,
+			userID: id,
+			size:   2,
+			err:    nil,
+		},
+		{
+			desc:   "list sessions of other user as root admin",
+			token:  adminSecret,
+			userID: id,
+			size:   2,
+			err:    nil,
+		},
+		{
+			desc:   "list sessions of other user",
+			token:  viewerSecret,
+			userID: id,
+			size:   0,
+			err:    errors.ErrAuthorization,
+		},
+		{
+			desc:   "list sessions with invalid token",
+			token:  invalid,
+			userID: id,
+			size:   0,
+			err:    errors.ErrAuthentication,
+		},
+	}
+
+	for _, tc := range cases {
+		sessions, err := svc.ListSessions(context.Background(), tc.token, tc.userID)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.size, len(sessions), fmt.Sprintf("%s: expected %d got %d\n", tc.desc, tc.size, len(sessions)))
+	}
+
+	sessions, err := svc.ListSessions(context.Background(), loginSecret, id)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.Equal(t, sessionID, sessions[0].ID, fmt.Sprintf("expected %s got %s\n", sessionID, sessions[0].ID))
+	assert.Equal(t, "10.0.0.1", sessions[0].IP, fmt.Sprintf("expected %s got %s\n", "10.0.0.1", sessions[0].IP))
+	assert.Equal(t, "Mozilla/5.0", sessions[0].UserAgent, fmt.Sprintf("expected %s got %s\n", "Mozilla/5.0", sessions[0].UserAgent))
+}
+
+func TestRevokeSession(t *testing.T) {
+	svc := newService()
+
+	sessionID, loginSecret, refreshSecret := openSession(t, svc, id, email)
+	_, otherSecret, _ := openSession(t, svc, id, email)
+	_, viewerSecret, _ := openSession(t, svc, viewerID, viewerEmail)
+
+	cases := []struct {
+		desc   string
+		token  string
+		userID string
+		id     string
+		err    error
+	}{
+		{
+			desc:   "revoke session of other user",
+			token:  viewerSecret,
+			userID: id,
+			id:     sessionID,
+			err:    errors.ErrAuthorization,
+		},
+		{
+			desc:   "revoke session with invalid token",
+			token:  invalid,
+			userID: id,
+			id:     sessionID,
+			err:    errors.ErrAuthentication,
+		},
+		{
+			desc:   "revoke own session from other session",
+			token:  otherSecret,
+			userID: id,
+			id:     sessionID,
+			err:    nil,
+		},
+		{
+			desc:   "revoke already revoked session",
+			token:  otherSecret,
+			userID: id,
+			id:     sessionID,
+			err:    errors.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		err := svc.RevokeSession(context.Background(), tc.token, tc.userID, tc.id)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+
+	_, err := svc.Identify(context.Background(), loginSecret)
+	assert.True(t, errors.Contains(err, errors.ErrAuthentication), fmt.Sprintf("identify login key of revoked session: expected %s got %s\n", errors.ErrAuthentication, err))
+
+	_, _, err = svc.Refresh(context.Background(), refreshSecret)
+	assert.True(t, errors.Contains(err, errors.ErrAuthentication), fmt.Sprintf("refresh key of revoked session: expected %s got %s\n", errors.ErrAuthentication, err))
+
+	_, err = svc.Identify(context.Background(), otherSecret)
+	assert.Nil(t, err, fmt.Sprintf("identify login key of other session: expected to succeed: %s", err))
+}
+
 func TestAuthorize(t *testing.T) {
 	svc := newService()
 
@@ -351,6 +652,320 @@ func TestAuthorize(t *testing.T) {
 	require.Nil(t, err, fmt.Sprintf("authorizing initial %v authz request expected to succeed: %s", pr, err))
 }
 
+func TestClaimOwnership(t *testing.T) {
+	svc := newService()
+
+	cases := []struct {
+		desc    string
+		ownerID string
+		subject string
+		object  string
+		err     error
+	}{
+		{
+			desc:    "claim thing ownership",
+			ownerID: ownerID,
+			subject: auth.ThingSubject,
+			object:  id,
+			err:     nil,
+		},
+		{
+			desc:    "claim ownership of thing owned by the same user",
+			ownerID: ownerID,
+			subject: auth.ThingSubject,
+			object:  id,
+			err:     nil,
+		},
+		{
+			desc:    "claim ownership of thing owned by other user",
+			ownerID: viewerID,
+			subject: auth.ThingSubject,
+			object:  id,
+			err:     errors.ErrAuthorization,
+		},
+		{
+			desc:    "claim ownership of org",
+			ownerID: ownerID,
+			subject: auth.GroupSubject,
+			object:  id,
+			err:     auth.ErrInvalidPolicy,
+		},
+	}
+
+	for _, tc := range cases {
+		err := svc.ClaimOwnership(context.Background(), tc.ownerID, tc.subject, tc.object)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestAddPolicy(t *testing.T) {
+	svc := newService()
+
+	_, ownerToken, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.LoginKey, IssuedAt: time.Now(), IssuerID: ownerID, Subject: ownerEmail})
+	require.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
+
+	_, viewerToken, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.LoginKey, IssuedAt: time.Now(), IssuerID: viewerID, Subject: viewerEmail})
+	require.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
+
+	_, adminToken, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.LoginKey, IssuedAt: time.Now(), IssuerID: adminID, Subject: adminEmail})
+	require.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
+
+	err = svc.AssignRole(context.Background(), adminID, auth.RoleAdmin)
+	require.Nil(t, err, fmt.Sprintf("saving role expected to succeed: %s", err))
+
+	err = svc.ClaimOwnership(context.Background(), ownerID, auth.ThingSubject, id)
+	require.Nil(t, err, fmt.Sprintf("claiming ownership expected to succeed: %s", err))
+
+	cases := []struct {
+		desc     string
+		token    string
+		memberID string
+		subject  string
+		object   string
+		policy   string
+		err      error
+	}{
+		{
+			desc:     "add thing policy as owner",
+			token:    ownerToken,
+			memberID: viewerID,
+			subject:  auth.ThingSubject,
+			object:   id,
+			policy:   auth.EditorRelation,
+			err:      nil,
+		},
+		{
+			desc:     "add thing policy as admin",
+			token:    adminToken,
+			memberID: viewerID,
+			subject:  auth.ThingSubject,
+			object:   id,
+			policy:   auth.ViewerRelation,
+			err:      nil,
+		},
+		{
+			desc:     "change owner relation as owner",
+			token:    ownerToken,
+			memberID: ownerID,
+			subject:  auth.ThingSubject,
+			object:   id,
+			policy:   auth.ViewerRelation,
+			err:      errors.ErrAuthorization,
+		},
+		{
+			desc:     "add thing policy as non-owner",
+			token:    viewerToken,
+			memberID: viewerID,
+			subject:  auth.ThingSubject,
+			object:   id,
+			policy:   auth.OwnerRelation,
+			err:      errors.ErrAuthorization,
+		},
+		{
+			desc:     "add policy of channel without policies",
+			token:    viewerToken,
+			memberID: viewerID,
+			subject:  auth.ChannelSubject,
+			object:   id,
+			policy:   auth.OwnerRelation,
+			err:      errors.ErrAuthorization,
+		},
+		{
+			desc:     "add channel policy with invalid token",
+			token:    invalid,
+			memberID: viewerID,
+			subject:  auth.ChannelSubject,
+			object:   id,
+			policy:   auth.OwnerRelation,
+			err:      errors.ErrAuthentication,
+		},
+	}
+
+	for _, tc := range cases {
+		err := svc.AddPolicy(context.Background(), tc.token, tc.memberID, tc.subject, tc.object, tc.policy)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+
+	err = svc.Authorize(context.Background(), auth.AuthzReq{Token: viewerToken, Subject: auth.ThingSubject, Object: id, Action: auth.ReadAction})
+	assert.Nil(t, err, fmt.Sprintf("authorize member granted the policy to read thing: expected no error got %s\n", err))
+
+	err = svc.Authorize(context.Background(), auth.AuthzReq{Token: viewerToken, Subject: auth.ThingSubject, Object: id, Action: auth.WriteAction})
+	assert.True(t, errors.Contains(err, errors.ErrAuthorization), fmt.Sprintf("authorize viewer to write thing: expected %s got %s\n", errors.ErrAuthorization, err))
+
+	err = svc.Authorize(context.Background(), auth.AuthzReq{Token: ownerToken, Subject: auth.ThingSubject, Object: id, Action: auth.WriteAction})
+	assert.Nil(t, err, fmt.Sprintf("authorize owner to write thing: expected no error got %s\n", err))
+}
+
+func TestAuthorizeObject(t *testing.T) {
+	svc := newService()
+
+	_, ownerToken, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.LoginKey, IssuedAt: time.Now(), IssuerID: ownerID, Subject: ownerEmail})
+	require.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
+
+	_, viewerToken, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.LoginKey, IssuedAt: time.Now(), IssuerID: viewerID, Subject: viewerEmail})
+	require.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
+
+	err = svc.ClaimOwnership(context.Background(), ownerID, auth.ChannelSubject, id)
+	require.Nil(t, err, fmt.Sprintf("claiming ownership expected to succeed: %s", err))
+
+	cases := []struct {
+		desc string
+		req  auth.AuthzReq
+		err  error
+	}{
+		{
+			desc: "authorize owner to write channel",
+			req:  auth.AuthzReq{Token: ownerToken, Subject: auth.ChannelSubject, Object: id, Action: auth.WriteAction},
+			err:  nil,
+		},
+		{
+			desc: "authorize user without policy to read channel",
+			req:  auth.AuthzReq{Token: viewerToken, Subject: auth.ChannelSubject, Object: id, Action: auth.ReadAction},
+			err:  errors.ErrAuthorization,
+		},
+		{
+			desc: "authorize owner to read thing with the channel ID",
+			req:  auth.AuthzReq{Token: ownerToken, Subject: auth.ThingSubject, Object: id, Action: auth.ReadAction},
+			err:  errors.ErrAuthorization,
+		},
+	}
+
+	for _, tc := range cases {
+		err := svc.Authorize(context.Background(), tc.req)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestShareObject(t *testing.T) {
+	svc := newService()
+
+	_, ownerToken, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.LoginKey, IssuedAt: time.Now(), IssuerID: ownerID, Subject: ownerEmail})
+	require.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
+
+	_, viewerToken, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.LoginKey, IssuedAt: time.Now(), IssuerID: viewerID, Subject: viewerEmail})
+	require.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
+
+	err = svc.ClaimOwnership(context.Background(), ownerID, auth.ThingSubject, id)
+	require.Nil(t, err, fmt.Sprintf("claiming ownership expected to succeed: %s", err))
+
+	cases := []struct {
+		desc  string
+		token string
+		inv   auth.ObjectInvitation
+		err   error
+	}{
+		{
+			desc:  "share thing with read policy",
+			token: ownerToken,
+			inv:   auth.ObjectInvitation{Emails: []string{viewerEmail}, Policy: auth.RPolicy},
+			err:   nil,
+		},
+		{
+			desc:  "share thing with invalid policy",
+			token: ownerToken,
+			inv:   auth.ObjectInvitation{Emails: []string{editorEmail}, Policy: invalid},
+			err:   auth.ErrInvalidPolicy,
+		},
+		{
+			desc:  "share thing with non-existing user",
+			token: ownerToken,
+			inv:   auth.ObjectInvitation{Emails: []string{editorEmail, "unknown@example.com"}, Policy: auth.RwPolicy},
+			err:   errors.ErrNotFound,
+		},
+		{
+			desc:  "share thing as non-owner",
+			token: viewerToken,
+			inv:   auth.ObjectInvitation{Emails: []string{editorEmail}, Policy: auth.RwPolicy},
+			err:   errors.ErrAuthorization,
+		},
+		{
+			desc:  "share thing with invalid token",
+			token: invalid,
+			inv:   auth.ObjectInvitation{Emails: []string{editorEmail}, Policy: auth.RwPolicy},
+			err:   errors.ErrAuthentication,
+		},
+	}
+
+	for _, tc := range cases {
+		err := svc.ShareObject(context.Background(), tc.token, auth.ThingSubject, id, tc.inv)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+
+	err = svc.Authorize(context.Background(), auth.AuthzReq{Token: viewerToken, Subject: auth.ThingSubject, Object: id, Action: auth.ReadAction})
+	assert.Nil(t, err, fmt.Sprintf("authorizing shared thing read expected to succeed: %s", err))
+
+	err = svc.Authorize(context.Background(), auth.AuthzReq{Token: viewerToken, Subject: auth.ThingSubject, Object: id, Action: auth.WriteAction})
+	assert.True(t, errors.Contains(err, errors.ErrAuthorization), fmt.Sprintf("authorizing shared thing write: expected %s got %s\n", errors.ErrAuthorization, err))
+
+	_, editorToken, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.LoginKey, IssuedAt: time.Now(), IssuerID: editorID, Subject: editorEmail})
+	require.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
+
+	err = svc.Authorize(context.Background(), auth.AuthzReq{Token: editorToken, Subject: auth.ThingSubject, Object: id, Action: auth.ReadAction})
+	assert.True(t, errors.Contains(err, errors.ErrAuthorization), fmt.Sprintf("authorizing thing shared with non-existing user: expected %s got %s\n", errors.ErrAuthorization, err))
+
+	// The thing shared with the org is accessible to the members joining
+	// the org after it was shared.
+	or, err := svc.CreateOrg(context.Background(), ownerToken, org)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	err = svc.ShareObject(context.Background(), ownerToken, auth.ThingSubject, id, auth.ObjectInvitation{OrgID: or.ID, Policy: auth.RwPolicy})
+	require.Nil(t, err, fmt.Sprintf("sharing thing with org expected to succeed: %s", err))
+
+	err = svc.AssignMembers(context.Background(), ownerToken, or.ID, auth.Member{Email: editorEmail, Role: auth.EditorRole})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	err = svc.Authorize(context.Background(), auth.AuthzReq{Token: editorToken, Subject: auth.ThingSubject, Object: id, Action: auth.WriteAction})
+	assert.Nil(t, err, fmt.Sprintf("authorizing thing shared with org write expected to succeed: %s", err))
+
+	err = svc.Authorize(context.Background(), auth.AuthzReq{Token: viewerToken, Subject: auth.ThingSubject, Object: id, Action: auth.WriteAction})
+	assert.True(t, errors.Contains(err, errors.ErrAuthorization), fmt.Sprintf("authorizing thing shared with org write as non-member: expected %s got %s\n", errors.ErrAuthorization, err))
+}
+
+func TestUnshareObject(t *testing.T) {
+	svc := newService()
+
+	_, ownerToken, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.LoginKey, IssuedAt: time.Now(), IssuerID: ownerID, Subject: ownerEmail})
+	require.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
+
+	_, viewerToken, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.LoginKey, IssuedAt: time.Now(), IssuerID: viewerID, Subject: viewerEmail})
+	require.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
+
+	err = svc.ClaimOwnership(context.Background(), ownerID, auth.ChannelSubject, id)
+	require.Nil(t, err, fmt.Sprintf("claiming ownership expected to succeed: %s", err))
+
+	err = svc.ShareObject(context.Background(), ownerToken, auth.ChannelSubject, id, auth.ObjectInvitation{Emails: []string{viewerEmail}, Policy: auth.RPolicy})
+	require.Nil(t, err, fmt.Sprintf("sharing channel expected to succeed: %s", err))
+
+	cases := []struct {
+		desc      string
+		token     string
+		memberIDs []string
+		err       error
+	}{
+		{
+			desc:      "unshare channel as non-owner",
+			token:     viewerToken,
+			memberIDs: []string{viewerID},
+			err:       errors.ErrAuthorization,
+		},
+		{
+			desc:      "unshare channel",
+			token:     ownerToken,
+			memberIDs: []string{viewerID, ownerID},
+			err:       nil,
+		},
+	}
+
+	for _, tc := range cases {
+		err := svc.UnshareObject(context.Background(), tc.token, auth.ChannelSubject, id, tc.memberIDs...)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+
+	ps, err := svc.ListObjectPolicies(context.Background(), ownerToken, auth.ChannelSubject, id)
+	require.Nil(t, err, fmt.Sprintf("listing policies expected to succeed: %s", err))
+	assert.Equal(t, []auth.Policy{{MemberID: ownerID, MemberType: auth.UserMember, ObjectType: auth.ChannelSubject, ObjectID: id, Relation: auth.OwnerRelation}}, ps, "expected only the owner policy to remain")
+}
+
 func TestCreateOrg(t *testing.T) {
 	svc := newService()
 
@@ -864,6 +1479,72 @@ func TestAssignMembers(t *testing.T) {
 	}
 }
 
+func TestAssignMembersByIDs(t *testing.T) {
+	svc := newService()
+
+	_, ownerToken, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.LoginKey, IssuedAt: time.Now(), IssuerID: ownerID, Subject: ownerEmail})
+	assert.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
+	_, viewerToken, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.LoginKey, IssuedAt: time.Now(), IssuerID: viewerID, Subject: viewerEmail})
+	assert.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
+	_, adminToken, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.LoginKey, IssuedAt: time.Now(), IssuerID: adminID, Subject: adminEmail})
+	assert.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
+
+	or, err := svc.CreateOrg(context.Background(), ownerToken, org)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	err = svc.AssignMembers(context.Background(), ownerToken, or.ID, members...)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	cases := []struct {
+		desc      string
+		token     string
+		orgID     string
+		memberIDs []string
+		err       error
+	}{
+		{
+			desc:      "assign members by ids to org as owner",
+			token:     ownerToken,
+			orgID:     or.ID,
+			memberIDs: []string{"member1"},
+			err:       nil,
+		},
+		{
+			desc:      "assign members by ids to org as admin",
+			token:     adminToken,
+			orgID:     or.ID,
+			memberIDs: []string{"member2", "member3"},
+			err:       nil,
+		},
+		{
+			desc:      "assign members by ids to org as viewer",
+			token:     viewerToken,
+			orgID:     or.ID,
+			memberIDs: []string{"member4"},
+			err:       errors.ErrAuthorization,
+		},
+		{
+			desc:      "assign members by ids with wrong credentials",
+			token:     invalid,
+			orgID:     or.ID,
+			memberIDs: []string{"member4"},
+			err:       errors.ErrAuthentication,
+		},
+		{
+			desc:      "assign members by ids to non-existing org",
+			token:     ownerToken,
+			orgID:     invalid,
+			memberIDs: []string{"member4"},
+			err:       errors.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		err := svc.AssignMembersByIDs(context.Background(), tc.token, tc.orgID, tc.memberIDs...)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
 func TestUnAssignMembers(t *testing.T) {
 	svc := newService()
 
@@ -1547,6 +2228,46 @@ func TestListOrgGroups(t *testing.T) {
 	}
 }
 
+func TestRetrieveMemberships(t *testing.T) {
+	svc := newService()
+
+	_, ownerToken, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.LoginKey, IssuedAt: time.Now(), IssuerID: ownerID, Subject: ownerEmail})
+	require.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
+
+	var orgIDs []string
+	for i := 0; i < n; i++ {
+		or, err := svc.CreateOrg(context.Background(), ownerToken, auth.Org{Name: fmt.Sprintf("org-%d", i)})
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+		orgIDs = append(orgIDs, or.ID)
+
+		err = svc.AssignMembers(context.Background(), ownerToken, or.ID, members...)
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	}
+
+	cases := []struct {
+		desc     string
+		memberID string
+		orgIDs   []string
+	}{
+		{
+			desc:     "retrieve memberships of org member",
+			memberID: viewerID,
+			orgIDs:   orgIDs,
+		},
+		{
+			desc:     "retrieve memberships of non-member",
+			memberID: "unknownID",
+			orgIDs:   nil,
+		},
+	}
+
+	for _, tc := range cases {
+		ids, err := svc.RetrieveMemberships(context.Background(), tc.memberID)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s\n", tc.desc, err))
+		assert.ElementsMatch(t, tc.orgIDs, ids, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.orgIDs, ids))
+	}
+}
+
 func TestListOrgMemberships(t *testing.T) {
 	svc := newService()
 
@@ -1855,3 +2576,206 @@ func TestRestore(t *testing.T) {
 		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s expected %s got %s\n", tc.desc, tc.err, err))
 	}
 }
+
+func TestCreateTeam(t *testing.T) {
+	svc := newService()
+
+	_, ownerToken, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.LoginKey, IssuedAt: time.Now(), IssuerID: ownerID, Subject: ownerEmail})
+	require.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
+	_, adminToken, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.LoginKey, IssuedAt: time.Now(), IssuerID: adminID, Subject: adminEmail})
+	require.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
+	_, editorToken, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.LoginKey, IssuedAt: time.Now(), IssuerID: editorID, Subject: editorEmail})
+	require.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
+
+	or, err := svc.CreateOrg(context.Background(), ownerToken, org)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	err = svc.AssignMembers(context.Background(), ownerToken, or.ID, members...)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	cases := []struct {
+		desc  string
+		token string
+		team  auth.Team
+		err   error
+	}{
+		{
+			desc:  "create team as org owner",
+			token: ownerToken,
+			team:  auth.Team{OrgID: or.ID, Name: name},
+			err:   nil,
+		},
+		{
+			desc:  "create team as org admin",
+			token: adminToken,
+			team:  auth.Team{OrgID: or.ID, Name: name},
+			err:   nil,
+		},
+		{
+			desc:  "create team as org editor",
+			token: editorToken,
+			team:  auth.Team{OrgID: or.ID, Name: name},
+			err:   errors.ErrAuthorization,
+		},
+		{
+			desc:  "create team with invalid token",
+			token: invalid,
+			team:  auth.Team{OrgID: or.ID, Name: name},
+			err:   errors.ErrAuthentication,
+		},
+	}
+
+	for _, tc := range cases {
+		team, err := svc.CreateTeam(context.Background(), tc.token, tc.team)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if err == nil {
+			assert.NotEmpty(t, team.ID, fmt.Sprintf("%s: expected team ID to be set", tc.desc))
+		}
+	}
+}
+
+func TestAssignTeamMembers(t *testing.T) {
+	svc := newService()
+
+	_, ownerToken, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.LoginKey, IssuedAt: time.Now(), IssuerID: ownerID, Subject: ownerEmail})
+	require.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
+	_, viewerToken, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.LoginKey, IssuedAt: time.Now(), IssuerID: viewerID, Subject: viewerEmail})
+	require.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
+
+	or, err := svc.CreateOrg(context.Background(), ownerToken, org)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	err = svc.AssignMembers(context.Background(), ownerToken, or.ID, members...)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	team, err := svc.CreateTeam(context.Background(), ownerToken, auth.Team{OrgID: or.ID, Name: name})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	cases := []struct {
+		desc      string
+		token     string
+		teamID    string
+		memberIDs []string
+		err       error
+	}{
+		{
+			desc:      "assign org members to team",
+			token:     ownerToken,
+			teamID:    team.ID,
+			memberIDs: []string{editorID, viewerID},
+			err:       nil,
+		},
+		{
+			desc:      "assign non-org member to team",
+			token:     ownerToken,
+			teamID:    team.ID,
+			memberIDs: []string{id},
+			err:       auth.ErrNotOrgMember,
+		},
+		{
+			desc:      "assign members to team as org viewer",
+			token:     viewerToken,
+			teamID:    team.ID,
+			memberIDs: []string{editorID},
+			err:       errors.ErrAuthorization,
+		},
+		{
+			desc:      "assign members to non-existing team",
+			token:     ownerToken,
+			teamID:    invalid,
+			memberIDs: []string{editorID},
+			err:       errors.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		err := svc.AssignTeamMembers(context.Background(), tc.token, tc.teamID, tc.memberIDs...)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+
+	mp, err := svc.ListTeamMembers(context.Background(), viewerToken, team.ID, auth.PageMetadata{Limit: n})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	assert.Equal(t, uint64(2), mp.Total, fmt.Sprintf("expected 2 team members got %d", mp.Total))
+}
+
+func TestGrantTeamAccess(t *testing.T) {
+	svc := newService()
+
+	_, ownerToken, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.LoginKey, IssuedAt: time.Now(), IssuerID: ownerID, Subject: ownerEmail})
+	require.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
+	_, viewerToken, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.LoginKey, IssuedAt: time.Now(), IssuerID: viewerID, Subject: viewerEmail})
+	require.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
+
+	or, err := svc.CreateOrg(context.Background(), ownerToken, org)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	err = svc.AssignMembers(context.Background(), ownerToken, or.ID, members...)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	groupID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	err = svc.AssignGroups(context.Background(), ownerToken, or.ID, groupID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	team, err := svc.CreateTeam(context.Background(), ownerToken, auth.Team{OrgID: or.ID, Name: name})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	cases := []struct {
+		desc    string
+		token   string
+		groupID string
+		policy  string
+		err     error
+	}{
+		{
+			desc:    "grant team read access to group",
+			token:   ownerToken,
+			groupID: groupID,
+			policy:  auth.RPolicy,
+			err:     nil,
+		},
+		{
+			desc:    "grant team access with invalid policy",
+			token:   ownerToken,
+			groupID: groupID,
+			policy:  invalid,
+			err:     auth.ErrInvalidPolicy,
+		},
+		{
+			desc:    "grant team access to group of another org",
+			token:   ownerToken,
+			groupID: invalid,
+			policy:  auth.RPolicy,
+			err:     errors.ErrNotFound,
+		},
+		{
+			desc:    "grant team access as org viewer",
+			token:   viewerToken,
+			groupID: groupID,
+			policy:  auth.RPolicy,
+			err:     errors.ErrAuthorization,
+		},
+	}
+
+	for _, tc := range cases {
+		err := svc.GrantTeamAccess(context.Background(), tc.token, team.ID, tc.groupID, tc.policy)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+
+	ar := auth.AuthzReq{Token: viewerToken, Subject: auth.GroupSubject, Object: groupID, Action: auth.ReadAction}
+	err = svc.Authorize(context.Background(), ar)
+	assert.True(t, errors.Contains(err, errors.ErrAuthorization), fmt.Sprintf("authorizing non-team member: expected %s got %s\n", errors.ErrAuthorization, err))
+
+	err = svc.AssignTeamMembers(context.Background(), ownerToken, team.ID, viewerID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	err = svc.Authorize(context.Background(), ar)
+	assert.Nil(t, err, fmt.Sprintf("authorizing team member: expected to succeed: %s\n", err))
+
+	err = svc.UnassignTeamMembers(context.Background(), ownerToken, team.ID, viewerID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	err = svc.Authorize(context.Background(), ar)
+	assert.True(t, errors.Contains(err, errors.ErrAuthorization), fmt.Sprintf("authorizing removed team member: expected %s got %s\n", errors.ErrAuthorization, err))
+}
@@ -0,0 +1,40 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package auth
+
+import (
+	"context"
+	"time"
+)
+
+// Session represents a login of the user on a single device. The session
+// lasts as long as its refresh key keeps being refreshed, and removing it
+// invalidates all login and refresh keys issued for it.
+type Session struct {
+	ID        string
+	UserID    string
+	IP        string
+	UserAgent string
+	IssuedAt  time.Time
+	ExpiresAt time.Time
+}
+
+// SessionRepository specifies the active sessions persistence API.
+type SessionRepository interface {
+	// Save persists the session until it expires, overwriting the
+	// existing session with the same ID.
+	Save(ctx context.Context, s Session) error
+
+	// Retrieve retrieves the session of the user by its ID.
+	Retrieve(ctx context.Context, userID, id string) (Session, error)
+
+	// RetrieveAll retrieves all active sessions of the user.
+	RetrieveAll(ctx context.Context, userID string) ([]Session, error)
+
+	// Remove removes the session of the user with the provided ID.
+	Remove(ctx context.Context, userID, id string) error
+
+	// RemoveAll removes all sessions of the user.
+	RemoveAll(ctx context.Context, userID string) error
+}
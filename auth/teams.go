@@ -0,0 +1,125 @@
+package auth
+
+import (
+	"context"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+)
+
+// ErrNotOrgMember indicates that the user assigned to a team is not a member
+// of the team's org.
+var ErrNotOrgMember = errors.New("user is not a member of the org")
+
+// Team represents a group of org members that can be granted access to
+// things groups as a whole.
+type Team struct {
+	ID          string
+	OrgID       string
+	OwnerID     string
+	Name        string
+	Description string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// TeamsPage contains page related metadata as well as list of teams that
+// belong to this page.
+type TeamsPage struct {
+	PageMetadata
+	Teams []Team
+}
+
+// TeamMembersPage contains page related metadata as well as list of IDs of
+// the team members that belong to this page.
+type TeamMembersPage struct {
+	PageMetadata
+	MemberIDs []string
+}
+
+// TeamPolicy represents the policy of a team to a things group, which
+// applies to all members of the team.
+type TeamPolicy struct {
+	TeamID  string
+	GroupID string
+	Policy  string
+}
+
+// Teams specifies an API for managing teams and their access to things groups.
+type Teams interface {
+	// CreateTeam creates a new team in the org.
+	CreateTeam(ctx context.Context, token string, team Team) (Team, error)
+
+	// ViewTeam retrieves the team identified by the provided ID.
+	ViewTeam(ctx context.Context, token, id string) (Team, error)
+
+	// ListTeams retrieves the teams of the org.
+	ListTeams(ctx context.Context, token, orgID string, pm PageMetadata) (TeamsPage, error)
+
+	// UpdateTeam updates the team identified by the provided ID.
+	UpdateTeam(ctx context.Context, token string, team Team) error
+
+	// RemoveTeam removes the team identified by the provided ID, revoking
+	// the access its members gained through the team.
+	RemoveTeam(ctx context.Context, token, id string) error
+
+	// AssignTeamMembers adds the org members to the team.
+	AssignTeamMembers(ctx context.Context, token, teamID string, memberIDs ...string) error
+
+	// UnassignTeamMembers removes the members from the team.
+	UnassignTeamMembers(ctx context.Context, token, teamID string, memberIDs ...string) error
+
+	// ListTeamMembers retrieves the members of the team.
+	ListTeamMembers(ctx context.Context, token, teamID string, pm PageMetadata) (MembersPage, error)
+
+	// GrantTeamAccess grants all members of the team the policy to the
+	// things group, which has to be assigned to the team's org.
+	GrantTeamAccess(ctx context.Context, token, teamID, groupID, policy string) error
+
+	// RevokeTeamAccess revokes the policy of the team to the things group.
+	RevokeTeamAccess(ctx context.Context, token, teamID, groupID string) error
+
+	// ListTeamPolicies retrieves the policies of the team to things groups.
+	ListTeamPolicies(ctx context.Context, token, teamID string) ([]TeamPolicy, error)
+}
+
+// TeamRepository specifies a team persistence API.
+type TeamRepository interface {
+	// Save persists the team.
+	Save(ctx context.Context, team Team) error
+
+	// Update updates the team name and description.
+	Update(ctx context.Context, team Team) error
+
+	// Remove removes the team along with its members and policies.
+	Remove(ctx context.Context, id string) error
+
+	// RetrieveByID retrieves the team by its ID.
+	RetrieveByID(ctx context.Context, id string) (Team, error)
+
+	// RetrieveByOrg retrieves the subset of teams of the org.
+	RetrieveByOrg(ctx context.Context, orgID string, pm PageMetadata) (TeamsPage, error)
+
+	// AssignMembers adds the members to the team.
+	AssignMembers(ctx context.Context, teamID string, memberIDs ...string) error
+
+	// UnassignMembers removes the members from the team.
+	UnassignMembers(ctx context.Context, teamID string, memberIDs ...string) error
+
+	// RetrieveMembers retrieves the subset of members of the team.
+	RetrieveMembers(ctx context.Context, teamID string, pm PageMetadata) (TeamMembersPage, error)
+
+	// SavePolicy saves the team policy. The existing policy of the team to
+	// the same group is overwritten.
+	SavePolicy(ctx context.Context, tp TeamPolicy) error
+
+	// RemovePolicy removes the policy of the team to the group.
+	RemovePolicy(ctx context.Context, teamID, groupID string) error
+
+	// RetrievePolicies retrieves the policies of the team.
+	RetrievePolicies(ctx context.Context, teamID string) ([]TeamPolicy, error)
+
+	// RetrieveMemberPolicies retrieves the policies to the group of all
+	// teams the member belongs to.
+	RetrieveMemberPolicies(ctx context.Context, memberID, groupID string) ([]string, error)
+}
@@ -0,0 +1,71 @@
+package tracing
+
+import (
+	"context"
+
+	"github.com/MainfluxLabs/mainflux/auth"
+	opentracing "github.com/opentracing/opentracing-go"
+)
+
+const (
+	saveObjectPolicies   = "save_object_policies"
+	retrieveRelation     = "retrieve_relation"
+	retrieveByObject     = "retrieve_by_object"
+	retrieveOrgPolicies  = "retrieve_org_policies"
+	removeObjectPolicies = "remove_object_policies"
+)
+
+var _ auth.PoliciesRepository = (*policiesRepositoryMiddleware)(nil)
+
+type policiesRepositoryMiddleware struct {
+	tracer opentracing.Tracer
+	repo   auth.PoliciesRepository
+}
+
+// PoliciesRepositoryMiddleware tracks request and their latency, and adds spans to context.
+func PoliciesRepositoryMiddleware(tracer opentracing.Tracer, pr auth.PoliciesRepository) auth.PoliciesRepository {
+	return policiesRepositoryMiddleware{
+		tracer: tracer,
+		repo:   pr,
+	}
+}
+
+func (prm policiesRepositoryMiddleware) SavePolicies(ctx context.Context, policies ...auth.Policy) error {
+	span := createSpan(ctx, prm.tracer, saveObjectPolicies)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return prm.repo.SavePolicies(ctx, policies...)
+}
+
+func (prm policiesRepositoryMiddleware) RetrieveRelation(ctx context.Context, memberID, objectType, objectID string) (string, error) {
+	span := createSpan(ctx, prm.tracer, retrieveRelation)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return prm.repo.RetrieveRelation(ctx, memberID, objectType, objectID)
+}
+
+func (prm policiesRepositoryMiddleware) RetrieveByObject(ctx context.Context, objectType, objectID string) ([]auth.Policy, error) {
+	span := createSpan(ctx, prm.tracer, retrieveByObject)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return prm.repo.RetrieveByObject(ctx, objectType, objectID)
+}
+
+func (prm policiesRepositoryMiddleware) RetrieveOrgPolicies(ctx context.Context, objectType, objectID string) ([]auth.Policy, error) {
+	span := createSpan(ctx, prm.tracer, retrieveOrgPolicies)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return prm.repo.RetrieveOrgPolicies(ctx, objectType, objectID)
+}
+
+func (prm policiesRepositoryMiddleware) RemovePolicies(ctx context.Context, objectType, objectID string, memberIDs ...string) error {
+	span := createSpan(ctx, prm.tracer, removeObjectPolicies)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return prm.repo.RemovePolicies(ctx, objectType, objectID, memberIDs...)
+}
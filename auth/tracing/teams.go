@@ -0,0 +1,134 @@
+package tracing
+
+import (
+	"context"
+
+	"github.com/MainfluxLabs/mainflux/auth"
+	opentracing "github.com/opentracing/opentracing-go"
+)
+
+const (
+	saveTeam                   = "save_team"
+	updateTeam                 = "update_team"
+	removeTeam                 = "remove_team"
+	retrieveTeamByID           = "retrieve_team_by_id"
+	retrieveTeamsByOrg         = "retrieve_teams_by_org"
+	assignTeamMembers          = "assign_team_members"
+	unassignTeamMembers        = "unassign_team_members"
+	retrieveTeamMembers        = "retrieve_team_members"
+	saveTeamPolicy             = "save_team_policy"
+	removeTeamPolicy           = "remove_team_policy"
+	retrieveTeamPolicies       = "retrieve_team_policies"
+	retrieveMemberTeamPolicies = "retrieve_member_team_policies"
+)
+
+var _ auth.TeamRepository = (*teamRepositoryMiddleware)(nil)
+
+type teamRepositoryMiddleware struct {
+	tracer opentracing.Tracer
+	repo   auth.TeamRepository
+}
+
+// TeamRepositoryMiddleware tracks request and their latency, and adds spans to context.
+func TeamRepositoryMiddleware(tracer opentracing.Tracer, tr auth.TeamRepository) auth.TeamRepository {
+	return teamRepositoryMiddleware{
+		tracer: tracer,
+		repo:   tr,
+	}
+}
+
+func (trm teamRepositoryMiddleware) Save(ctx context.Context, team auth.Team) error {
+	span := createSpan(ctx, trm.tracer, saveTeam)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return trm.repo.Save(ctx, team)
+}
+
+func (trm teamRepositoryMiddleware) Update(ctx context.Context, team auth.Team) error {
+	span := createSpan(ctx, trm.tracer, updateTeam)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return trm.repo.Update(ctx, team)
+}
+
+func (trm teamRepositoryMiddleware) Remove(ctx context.Context, id string) error {
+	span := createSpan(ctx, trm.tracer, removeTeam)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return trm.repo.Remove(ctx, id)
+}
+
+func (trm teamRepositoryMiddleware) RetrieveByID(ctx context.Context, id string) (auth.Team, error) {
+	span := createSpan(ctx, trm.tracer, retrieveTeamByID)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return trm.repo.RetrieveByID(ctx, id)
+}
+
+func (trm teamRepositoryMiddleware) RetrieveByOrg(ctx context.Context, orgID string, pm auth.PageMetadata) (auth.TeamsPage, error) {
+	span := createSpan(ctx, trm.tracer, retrieveTeamsByOrg)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return trm.repo.RetrieveByOrg(ctx, orgID, pm)
+}
+
+func (trm teamRepositoryMiddleware) AssignMembers(ctx context.Context, teamID string, memberIDs ...string) error {
+	span := createSpan(ctx, trm.tracer, assignTeamMembers)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return trm.repo.AssignMembers(ctx, teamID, memberIDs...)
+}
+
+func (trm teamRepositoryMiddleware) UnassignMembers(ctx context.Context, teamID string, memberIDs ...string) error {
+	span := createSpan(ctx, trm.tracer, unassignTeamMembers)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return trm.repo.UnassignMembers(ctx, teamID, memberIDs...)
+}
+
+func (trm teamRepositoryMiddleware) RetrieveMembers(ctx context.Context, teamID string, pm auth.PageMetadata) (auth.TeamMembersPage, error) {
+	span := createSpan(ctx, trm.tracer, retrieveTeamMembers)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return trm.repo.RetrieveMembers(ctx, teamID, pm)
+}
+
+func (trm teamRepositoryMiddleware) SavePolicy(ctx context.Context, tp auth.TeamPolicy) error {
+	span := createSpan(ctx, trm.tracer, saveTeamPolicy)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return trm.repo.SavePolicy(ctx, tp)
+}
+
+func (trm teamRepositoryMiddleware) RemovePolicy(ctx context.Context, teamID, groupID string) error {
+	span := createSpan(ctx, trm.tracer, removeTeamPolicy)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return trm.repo.RemovePolicy(ctx, teamID, groupID)
+}
+
+func (trm teamRepositoryMiddleware) RetrievePolicies(ctx context.Context, teamID string) ([]auth.TeamPolicy, error) {
+	span := createSpan(ctx, trm.tracer, retrieveTeamPolicies)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return trm.repo.RetrievePolicies(ctx, teamID)
+}
+
+func (trm teamRepositoryMiddleware) RetrieveMemberPolicies(ctx context.Context, memberID, groupID string) ([]string, error) {
+	span := createSpan(ctx, trm.tracer, retrieveMemberTeamPolicies)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return trm.repo.RetrieveMemberPolicies(ctx, memberID, groupID)
+}
@@ -245,3 +245,43 @@ func stateEndpoint(svc bootstrap.Service) endpoint.Endpoint {
 		return stateRes{}, nil
 	}
 }
+
+func createClaimTokensEndpoint(svc bootstrap.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(createClaimTokensReq)
+
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		tokens, err := svc.CreateClaimTokens(ctx, req.token, req.Count, req.Content)
+		if err != nil {
+			return nil, err
+		}
+
+		return claimTokensRes{Tokens: tokens}, nil
+	}
+}
+
+func claimEndpoint(svc bootstrap.Service, reader bootstrap.ConfigReader) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(claimReq)
+
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		config := bootstrap.Config{
+			ExternalID:  req.ExternalID,
+			ExternalKey: req.ExternalKey,
+			Name:        req.Name,
+		}
+
+		cfg, err := svc.Claim(ctx, req.token, req.ClaimToken, req.GroupID, config)
+		if err != nil {
+			return nil, err
+		}
+
+		return reader.ReadConfig(cfg, false)
+	}
+}
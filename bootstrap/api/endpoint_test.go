@@ -25,6 +25,7 @@ import (
 	btmocks "github.com/MainfluxLabs/mainflux/bootstrap/mocks"
 	"github.com/MainfluxLabs/mainflux/internal/apiutil"
 	"github.com/MainfluxLabs/mainflux/logger"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
 	"github.com/MainfluxLabs/mainflux/pkg/mocks"
 	thmocks "github.com/MainfluxLabs/mainflux/pkg/mocks"
 	mfsdk "github.com/MainfluxLabs/mainflux/pkg/sdk/go"
@@ -37,6 +38,7 @@ import (
 )
 
 const (
+	requestID      = "8c5c3f7e-0a52-4a87-9d5e-3e1b6f9a2c41"
 	email          = "test@example.com"
 	validToken     = email
 	invalidToken   = "invalidToken"
@@ -85,11 +87,11 @@ var (
 		CACert:     "newca",
 	}
 
-	bsErrorRes    = toJSON(apiutil.ErrorRes{Err: bootstrap.ErrBootstrap.Error()})
-	extKeyRes     = toJSON(apiutil.ErrorRes{Err: bootstrap.ErrExternalKey.Error()})
-	extSecKeyRes  = toJSON(apiutil.ErrorRes{Err: bootstrap.ErrExternalKeySecure.Error()})
-	missingIDRes  = toJSON(apiutil.ErrorRes{Err: apiutil.ErrMissingID.Error()})
-	missingKeyRes = toJSON(apiutil.ErrorRes{Err: apiutil.ErrBearerKey.Error()})
+	bsErrorRes    = toErrorRes(bootstrap.ErrBootstrap)
+	extKeyRes     = toErrorRes(bootstrap.ErrExternalKey)
+	extSecKeyRes  = toErrorRes(bootstrap.ErrExternalKeySecure)
+	missingIDRes  = toErrorRes(apiutil.ErrMissingID)
+	missingKeyRes = toErrorRes(apiutil.ErrBearerKey)
 	usersList     = []users.User{{Email: email, Password: password}}
 )
 
@@ -134,6 +136,7 @@ func (tr testRequest) make() (*http.Response, error) {
 		req.Header.Set("Content-Type", tr.contentType)
 	}
 
+	req.Header.Set(apiutil.RequestIDHeader, requestID)
 	return tr.client.Do(req)
 }
 
@@ -169,12 +172,13 @@ func dec(in []byte) ([]byte, error) {
 
 func newService(auth mainflux.AuthServiceClient, url string) bootstrap.Service {
 	things := btmocks.NewConfigsRepository()
+	claims := btmocks.NewClaimTokensRepository()
 	config := mfsdk.Config{
 		ThingsURL: url,
 	}
 
 	sdk := mfsdk.NewSDK(config)
-	return bootstrap.New(auth, things, sdk, encKey)
+	return bootstrap.New(auth, things, claims, sdk, encKey)
 }
 
 func generateChannels() map[string]things.Channel {
@@ -211,6 +215,10 @@ func toJSON(data interface{}) string {
 	return string(jsonData)
 }
 
+func toErrorRes(err errors.Error, details ...string) string {
+	return toJSON(apiutil.ErrorRes{Err: err.Msg(), Code: apiutil.ErrorCode(err), Message: err.Msg(), Details: details, TraceID: requestID})
+}
+
 func TestAdd(t *testing.T) {
 	auth := mocks.NewAuthService("", usersList)
 	ts := newThingsServer(newThingsService(auth))
@@ -1287,3 +1295,166 @@ type configPage struct {
 	Limit   uint64   `json:"limit"`
 	Configs []config `json:"configs"`
 }
+
+func TestCreateClaimTokens(t *testing.T) {
+	auth := mocks.NewAuthService("", usersList)
+	ts := newThingsServer(newThingsService(auth))
+	svc := newService(auth, ts.URL)
+	bs := newBootstrapServer(svc)
+
+	cases := []struct {
+		desc        string
+		auth        string
+		req         string
+		contentType string
+		status      int
+	}{
+		{
+			desc:        "create claim tokens with invalid token",
+			auth:        invalidToken,
+			req:         `{"count": 3, "content": "config"}`,
+			contentType: contentType,
+			status:      http.StatusUnauthorized,
+		},
+		{
+			desc:        "create claim tokens with an empty token",
+			auth:        "",
+			req:         `{"count": 3, "content": "config"}`,
+			contentType: contentType,
+			status:      http.StatusUnauthorized,
+		},
+		{
+			desc:        "create claim tokens with invalid content type",
+			auth:        validToken,
+			req:         `{"count": 3, "content": "config"}`,
+			contentType: "",
+			status:      http.StatusUnsupportedMediaType,
+		},
+		{
+			desc:        "create claim tokens",
+			auth:        validToken,
+			req:         `{"count": 3, "content": "config"}`,
+			contentType: contentType,
+			status:      http.StatusCreated,
+		},
+		{
+			desc:        "create zero claim tokens",
+			auth:        validToken,
+			req:         `{"count": 0}`,
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "create too many claim tokens",
+			auth:        validToken,
+			req:         `{"count": 1001}`,
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "create claim tokens with invalid data",
+			auth:        validToken,
+			req:         "",
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client:      bs.Client(),
+			method:      http.MethodPost,
+			url:         fmt.Sprintf("%s/things/claims", bs.URL),
+			token:       tc.auth,
+			contentType: tc.contentType,
+			body:        strings.NewReader(tc.req),
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+	}
+}
+
+func TestClaim(t *testing.T) {
+	auth := mocks.NewAuthService("", usersList)
+	ts := newThingsServer(newThingsService(auth))
+	svc := newService(auth, ts.URL)
+	bs := newBootstrapServer(svc)
+
+	tokens, err := svc.CreateClaimTokens(context.Background(), validToken, 1, addContent)
+	require.Nil(t, err, fmt.Sprintf("Creating claim tokens expected to succeed: %s.\n", err))
+
+	claimReq := fmt.Sprintf(`{"claim_token": "%s", "external_id": "%s", "external_key": "%s"}`, tokens[0], addExternalID, addExternalKey)
+
+	cases := []struct {
+		desc        string
+		auth        string
+		req         string
+		contentType string
+		status      int
+	}{
+		{
+			desc:        "claim with invalid token",
+			auth:        invalidToken,
+			req:         claimReq,
+			contentType: contentType,
+			status:      http.StatusUnauthorized,
+		},
+		{
+			desc:        "claim with invalid content type",
+			auth:        validToken,
+			req:         claimReq,
+			contentType: "",
+			status:      http.StatusUnsupportedMediaType,
+		},
+		{
+			desc:        "claim without claim token",
+			auth:        validToken,
+			req:         fmt.Sprintf(`{"external_id": "%s", "external_key": "%s"}`, addExternalID, addExternalKey),
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "claim without external ID",
+			auth:        validToken,
+			req:         fmt.Sprintf(`{"claim_token": "%s", "external_key": "%s"}`, tokens[0], addExternalKey),
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "claim with unknown claim token",
+			auth:        validToken,
+			req:         fmt.Sprintf(`{"claim_token": "%s", "external_id": "%s", "external_key": "%s"}`, unknown, addExternalID, addExternalKey),
+			contentType: contentType,
+			status:      http.StatusForbidden,
+		},
+		{
+			desc:        "claim",
+			auth:        validToken,
+			req:         claimReq,
+			contentType: contentType,
+			status:      http.StatusOK,
+		},
+		{
+			desc:        "claim with already used claim token",
+			auth:        validToken,
+			req:         claimReq,
+			contentType: contentType,
+			status:      http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client:      bs.Client(),
+			method:      http.MethodPost,
+			url:         fmt.Sprintf("%s/things/claim", bs.URL),
+			token:       tc.auth,
+			contentType: tc.contentType,
+			body:        strings.NewReader(tc.req),
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+	}
+}
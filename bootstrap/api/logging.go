@@ -28,12 +28,13 @@ func NewLoggingMiddleware(svc bootstrap.Service, logger log.Logger) bootstrap.Se
 
 func (lm *loggingMiddleware) Add(ctx context.Context, token string, cfg bootstrap.Config) (saved bootstrap.Config, err error) {
 	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "add", "latency", time.Since(begin).String())
 		message := fmt.Sprintf("Method add for token %s and thing %s took %s to complete", token, saved.ThingID, time.Since(begin))
 		if err != nil {
-			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
 			return
 		}
-		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+		l.Info(fmt.Sprintf("%s without errors.", message))
 	}(time.Now())
 
 	return lm.svc.Add(ctx, token, cfg)
@@ -41,12 +42,13 @@ func (lm *loggingMiddleware) Add(ctx context.Context, token string, cfg bootstra
 
 func (lm *loggingMiddleware) View(ctx context.Context, token, id string) (saved bootstrap.Config, err error) {
 	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "view", "latency", time.Since(begin).String())
 		message := fmt.Sprintf("Method view for token %s and thing %s took %s to complete", token, saved.ThingID, time.Since(begin))
 		if err != nil {
-			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
 			return
 		}
-		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+		l.Info(fmt.Sprintf("%s without errors.", message))
 	}(time.Now())
 
 	return lm.svc.View(ctx, token, id)
@@ -54,12 +56,13 @@ func (lm *loggingMiddleware) View(ctx context.Context, token, id string) (saved
 
 func (lm *loggingMiddleware) Update(ctx context.Context, token string, cfg bootstrap.Config) (err error) {
 	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "update", "latency", time.Since(begin).String())
 		message := fmt.Sprintf("Method update for token %s and thing %s took %s to complete", token, cfg.ThingID, time.Since(begin))
 		if err != nil {
-			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
 			return
 		}
-		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+		l.Info(fmt.Sprintf("%s without errors.", message))
 	}(time.Now())
 
 	return lm.svc.Update(ctx, token, cfg)
@@ -67,12 +70,13 @@ func (lm *loggingMiddleware) Update(ctx context.Context, token string, cfg boots
 
 func (lm *loggingMiddleware) UpdateCert(ctx context.Context, token, thingID, clientCert, clientKey, caCert string) (err error) {
 	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "update_cert", "latency", time.Since(begin).String())
 		message := fmt.Sprintf("Method update_cert for thing with id %s took %s to complete", thingID, time.Since(begin))
 		if err != nil {
-			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
 			return
 		}
-		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+		l.Info(fmt.Sprintf("%s without errors.", message))
 	}(time.Now())
 
 	return lm.svc.UpdateCert(ctx, token, thingID, clientCert, clientKey, caCert)
@@ -80,12 +84,13 @@ func (lm *loggingMiddleware) UpdateCert(ctx context.Context, token, thingID, cli
 
 func (lm *loggingMiddleware) UpdateConnections(ctx context.Context, token, id string, connections []string) (err error) {
 	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "update_connections", "latency", time.Since(begin).String())
 		message := fmt.Sprintf("Method update_connections for token %s and thing %s took %s to complete", token, id, time.Since(begin))
 		if err != nil {
-			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
 			return
 		}
-		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+		l.Info(fmt.Sprintf("%s without errors.", message))
 	}(time.Now())
 
 	return lm.svc.UpdateConnections(ctx, token, id, connections)
@@ -93,12 +98,13 @@ func (lm *loggingMiddleware) UpdateConnections(ctx context.Context, token, id st
 
 func (lm *loggingMiddleware) List(ctx context.Context, token string, filter bootstrap.Filter, offset, limit uint64) (res bootstrap.ConfigsPage, err error) {
 	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "list", "latency", time.Since(begin).String())
 		message := fmt.Sprintf("Method list for token %s and offset %d and limit %d took %s to complete", token, offset, limit, time.Since(begin))
 		if err != nil {
-			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
 			return
 		}
-		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+		l.Info(fmt.Sprintf("%s without errors.", message))
 	}(time.Now())
 
 	return lm.svc.List(ctx, token, filter, offset, limit)
@@ -106,12 +112,13 @@ func (lm *loggingMiddleware) List(ctx context.Context, token string, filter boot
 
 func (lm *loggingMiddleware) Remove(ctx context.Context, token, id string) (err error) {
 	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "remove", "latency", time.Since(begin).String())
 		message := fmt.Sprintf("Method remove for token %s and thing %s took %s to complete", token, id, time.Since(begin))
 		if err != nil {
-			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
 			return
 		}
-		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+		l.Info(fmt.Sprintf("%s without errors.", message))
 	}(time.Now())
 
 	return lm.svc.Remove(ctx, token, id)
@@ -119,12 +126,13 @@ func (lm *loggingMiddleware) Remove(ctx context.Context, token, id string) (err
 
 func (lm *loggingMiddleware) Bootstrap(ctx context.Context, externalKey, externalID string, secure bool) (cfg bootstrap.Config, err error) {
 	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "bootstrap", "latency", time.Since(begin).String())
 		message := fmt.Sprintf("Method bootstrap for thing with external id %s took %s to complete", externalID, time.Since(begin))
 		if err != nil {
-			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
 			return
 		}
-		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+		l.Info(fmt.Sprintf("%s without errors.", message))
 	}(time.Now())
 
 	return lm.svc.Bootstrap(ctx, externalKey, externalID, secure)
@@ -132,25 +140,55 @@ func (lm *loggingMiddleware) Bootstrap(ctx context.Context, externalKey, externa
 
 func (lm *loggingMiddleware) ChangeState(ctx context.Context, token, id string, state bootstrap.State) (err error) {
 	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "change_state", "latency", time.Since(begin).String())
 		message := fmt.Sprintf("Method change_state for token %s and thing %s took %s to complete", token, id, time.Since(begin))
 		if err != nil {
-			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
 			return
 		}
-		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+		l.Info(fmt.Sprintf("%s without errors.", message))
 	}(time.Now())
 
 	return lm.svc.ChangeState(ctx, token, id, state)
 }
 
+func (lm *loggingMiddleware) CreateClaimTokens(ctx context.Context, token string, count uint64, content string) (tokens []string, err error) {
+	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "create_claim_tokens", "latency", time.Since(begin).String())
+		message := fmt.Sprintf("Method create_claim_tokens for token %s and %d tokens took %s to complete", token, count, time.Since(begin))
+		if err != nil {
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		l.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.CreateClaimTokens(ctx, token, count, content)
+}
+
+func (lm *loggingMiddleware) Claim(ctx context.Context, token, claimToken, groupID string, cfg bootstrap.Config) (saved bootstrap.Config, err error) {
+	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "claim", "latency", time.Since(begin).String())
+		message := fmt.Sprintf("Method claim for token %s and thing %s took %s to complete", token, saved.ThingID, time.Since(begin))
+		if err != nil {
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		l.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.Claim(ctx, token, claimToken, groupID, cfg)
+}
+
 func (lm *loggingMiddleware) UpdateChannelHandler(ctx context.Context, channel bootstrap.Channel) (err error) {
 	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "update_channel_handler", "latency", time.Since(begin).String())
 		message := fmt.Sprintf("Method update_channel_handler for channel %s took %s to complete", channel.ID, time.Since(begin))
 		if err != nil {
-			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
 			return
 		}
-		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+		l.Info(fmt.Sprintf("%s without errors.", message))
 	}(time.Now())
 
 	return lm.svc.UpdateChannelHandler(ctx, channel)
@@ -158,12 +196,13 @@ func (lm *loggingMiddleware) UpdateChannelHandler(ctx context.Context, channel b
 
 func (lm *loggingMiddleware) RemoveConfigHandler(ctx context.Context, id string) (err error) {
 	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "remove_config_handler", "latency", time.Since(begin).String())
 		message := fmt.Sprintf("Method remove_config_handler for config %s took %s to complete", id, time.Since(begin))
 		if err != nil {
-			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
 			return
 		}
-		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+		l.Info(fmt.Sprintf("%s without errors.", message))
 	}(time.Now())
 
 	return lm.svc.RemoveConfigHandler(ctx, id)
@@ -171,12 +210,13 @@ func (lm *loggingMiddleware) RemoveConfigHandler(ctx context.Context, id string)
 
 func (lm *loggingMiddleware) RemoveChannelHandler(ctx context.Context, id string) (err error) {
 	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "remove_channel_handler", "latency", time.Since(begin).String())
 		message := fmt.Sprintf("Method remove_channel_handler for channel %s took %s to complete", id, time.Since(begin))
 		if err != nil {
-			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
 			return
 		}
-		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+		l.Info(fmt.Sprintf("%s without errors.", message))
 	}(time.Now())
 
 	return lm.svc.RemoveChannelHandler(ctx, id)
@@ -184,12 +224,13 @@ func (lm *loggingMiddleware) RemoveChannelHandler(ctx context.Context, id string
 
 func (lm *loggingMiddleware) DisconnectThingHandler(ctx context.Context, channelID, thingID string) (err error) {
 	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "disconnect_thing_handler", "latency", time.Since(begin).String())
 		message := fmt.Sprintf("Method disconnect_thing_handler for channel %s and thing %s took %s to complete", channelID, thingID, time.Since(begin))
 		if err != nil {
-			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
 			return
 		}
-		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+		l.Info(fmt.Sprintf("%s without errors.", message))
 	}(time.Now())
 
 	return lm.svc.DisconnectThingHandler(ctx, channelID, thingID)
@@ -111,6 +111,24 @@ func (mm *metricsMiddleware) ChangeState(ctx context.Context, token, id string,
 	return mm.svc.ChangeState(ctx, token, id, state)
 }
 
+func (mm *metricsMiddleware) CreateClaimTokens(ctx context.Context, token string, count uint64, content string) (tokens []string, err error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "create_claim_tokens").Add(1)
+		mm.latency.With("method", "create_claim_tokens").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.CreateClaimTokens(ctx, token, count, content)
+}
+
+func (mm *metricsMiddleware) Claim(ctx context.Context, token, claimToken, groupID string, cfg bootstrap.Config) (saved bootstrap.Config, err error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "claim").Add(1)
+		mm.latency.With("method", "claim").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.Claim(ctx, token, claimToken, groupID, cfg)
+}
+
 func (mm *metricsMiddleware) UpdateChannelHandler(ctx context.Context, channel bootstrap.Channel) (err error) {
 	defer func(begin time.Time) {
 		mm.counter.With("method", "update_channel").Add(1)
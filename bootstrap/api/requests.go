@@ -8,7 +8,10 @@ import (
 	"github.com/MainfluxLabs/mainflux/internal/apiutil"
 )
 
-const maxLimitSize = 100
+const (
+	maxLimitSize       = 100
+	maxClaimTokensSize = 1000
+)
 
 type addReq struct {
 	token       string
@@ -151,6 +154,53 @@ func (req bootstrapReq) validate() error {
 	return nil
 }
 
+type createClaimTokensReq struct {
+	token   string
+	Count   uint64 `json:"count"`
+	Content string `json:"content"`
+}
+
+func (req createClaimTokensReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	if req.Count == 0 || req.Count > maxClaimTokensSize {
+		return apiutil.ErrLimitSize
+	}
+
+	return nil
+}
+
+type claimReq struct {
+	token       string
+	ClaimToken  string `json:"claim_token"`
+	GroupID     string `json:"group_id"`
+	ExternalID  string `json:"external_id"`
+	ExternalKey string `json:"external_key"`
+	Name        string `json:"name"`
+}
+
+func (req claimReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	if req.ClaimToken == "" {
+		return apiutil.ErrMissingClaimToken
+	}
+
+	if req.ExternalID == "" {
+		return apiutil.ErrMissingID
+	}
+
+	if req.ExternalKey == "" {
+		return apiutil.ErrBearerKey
+	}
+
+	return nil
+}
+
 type changeStateReq struct {
 	token string
 	id    string
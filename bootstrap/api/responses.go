@@ -17,6 +17,7 @@ var (
 	_ mainflux.Response = (*stateRes)(nil)
 	_ mainflux.Response = (*viewRes)(nil)
 	_ mainflux.Response = (*listRes)(nil)
+	_ mainflux.Response = (*claimTokensRes)(nil)
 )
 
 type removeRes struct{}
@@ -121,3 +122,19 @@ func (res stateRes) Headers() map[string]string {
 func (res stateRes) Empty() bool {
 	return true
 }
+
+type claimTokensRes struct {
+	Tokens []string `json:"tokens"`
+}
+
+func (res claimTokensRes) Code() int {
+	return http.StatusCreated
+}
+
+func (res claimTokensRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res claimTokensRes) Empty() bool {
+	return false
+}
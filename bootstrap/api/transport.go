@@ -34,7 +34,7 @@ var (
 )
 
 // MakeHandler returns a HTTP handler for API endpoints.
-func MakeHandler(svc bootstrap.Service, reader bootstrap.ConfigReader, logger logger.Logger) http.Handler {
+func MakeHandler(svc bootstrap.Service, reader bootstrap.ConfigReader, logger logger.Logger, checks ...mainflux.HealthCheck) http.Handler {
 	opts := []kithttp.ServerOption{
 		kithttp.ServerErrorEncoder(apiutil.LoggingErrorEncoder(logger, encodeError)),
 	}
@@ -100,10 +100,23 @@ func MakeHandler(svc bootstrap.Service, reader bootstrap.ConfigReader, logger lo
 		encodeResponse,
 		opts...))
 
-	r.GetFunc("/health", mainflux.Health("bootstrap"))
+	r.Post("/things/claims", kithttp.NewServer(
+		createClaimTokensEndpoint(svc),
+		decodeCreateClaimTokensRequest,
+		encodeResponse,
+		opts...))
+
+	r.Post("/things/claim", kithttp.NewServer(
+		claimEndpoint(svc, reader),
+		decodeClaimRequest,
+		encodeResponse,
+		opts...))
+
+	r.GetFunc("/health", mainflux.Health("bootstrap", checks...))
 	r.Handle("/metrics", promhttp.Handler())
+	r.Handle("/log-level", mainflux.LogLevel(logger))
 
-	return r
+	return apiutil.RequestIDMiddleware(apiutil.LocaleMiddleware(r))
 }
 
 func decodeAddRequest(_ context.Context, r *http.Request) (interface{}, error) {
@@ -218,6 +231,32 @@ func decodeStateRequest(_ context.Context, r *http.Request) (interface{}, error)
 	return req, nil
 }
 
+func decodeCreateClaimTokensRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), contentType) {
+		return nil, apiutil.ErrUnsupportedContentType
+	}
+
+	req := createClaimTokensReq{token: apiutil.ExtractBearerToken(r)}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(apiutil.ErrMalformedEntity, err)
+	}
+
+	return req, nil
+}
+
+func decodeClaimRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), contentType) {
+		return nil, apiutil.ErrUnsupportedContentType
+	}
+
+	req := claimReq{token: apiutil.ExtractBearerToken(r)}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(apiutil.ErrMalformedEntity, err)
+	}
+
+	return req, nil
+}
+
 func decodeEntityRequest(_ context.Context, r *http.Request) (interface{}, error) {
 	req := entityReq{
 		token: apiutil.ExtractBearerToken(r),
@@ -255,7 +294,7 @@ func encodeSecureRes(_ context.Context, w http.ResponseWriter, response interfac
 	return nil
 }
 
-func encodeError(_ context.Context, err error, w http.ResponseWriter) {
+func encodeError(ctx context.Context, err error, w http.ResponseWriter) {
 	switch {
 	case errors.Contains(err, errors.ErrAuthentication),
 		err == apiutil.ErrBearerToken,
@@ -267,12 +306,14 @@ func encodeError(_ context.Context, err error, w http.ResponseWriter) {
 		errors.Contains(err, apiutil.ErrMalformedEntity),
 		err == apiutil.ErrMissingID,
 		err == apiutil.ErrBootstrapState,
+		err == apiutil.ErrMissingClaimToken,
 		err == apiutil.ErrLimitSize:
 		w.WriteHeader(http.StatusBadRequest)
 	case errors.Contains(err, errors.ErrNotFound):
 		w.WriteHeader(http.StatusNotFound)
 	case errors.Contains(err, bootstrap.ErrExternalKey),
 		errors.Contains(err, bootstrap.ErrExternalKeySecure),
+		errors.Contains(err, bootstrap.ErrClaimToken),
 		errors.Contains(err, errors.ErrAuthorization):
 		w.WriteHeader(http.StatusForbidden)
 	case errors.Contains(err, errors.ErrConflict):
@@ -292,7 +333,7 @@ func encodeError(_ context.Context, err error, w http.ResponseWriter) {
 
 	if errorVal, ok := err.(errors.Error); ok {
 		w.Header().Set("Content-Type", contentType)
-		if err := json.NewEncoder(w).Encode(apiutil.ErrorRes{Err: errorVal.Msg()}); err != nil {
+		if err := json.NewEncoder(w).Encode(apiutil.NewErrorRes(ctx, errorVal)); err != nil {
 			w.WriteHeader(http.StatusInternalServerError)
 		}
 	}
@@ -0,0 +1,62 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package bootstrap
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+)
+
+const claimTokenLen = 16
+
+// ClaimToken represents a one-time token used to claim a device in the field.
+// Claim tokens are generated in batches, e.g. to be printed as QR codes, and
+// the corresponding Thing is created only once the token is claimed.
+type ClaimToken struct {
+	// TokenHash is the hash of the token handed over to the installer.
+	TokenHash string
+	Owner     string
+	Content   string
+	ClaimedBy string
+	ThingID   string
+	CreatedAt time.Time
+	ClaimedAt time.Time
+}
+
+// Claimed returns true if the token has already been used.
+func (ct ClaimToken) Claimed() bool {
+	return !ct.ClaimedAt.IsZero()
+}
+
+// ClaimTokenRepository specifies a claim token persistence API.
+type ClaimTokenRepository interface {
+	// Save persists a batch of claim tokens.
+	Save(tokens ...ClaimToken) error
+
+	// RetrieveByToken retrieves the claim token by the hash of its value.
+	RetrieveByToken(tokenHash string) (ClaimToken, error)
+
+	// Claim marks the unclaimed token as claimed by the given user for the
+	// given Thing. The conflict error is returned if there is no unclaimed
+	// token with the given hash.
+	Claim(tokenHash, claimedBy, thingID string, claimedAt time.Time) error
+}
+
+// newClaimToken returns the random claim token along with its hash.
+func newClaimToken() (string, string, error) {
+	b := make([]byte, claimTokenLen)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", err
+	}
+	token := hex.EncodeToString(b)
+
+	return token, hashClaimToken(token), nil
+}
+
+func hashClaimToken(token string) string {
+	h := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(h[:])
+}
@@ -30,6 +30,12 @@ type Channel struct {
 	Metadata map[string]interface{}
 }
 
+// Connection represents the connection of the Config Thing to the Channel.
+type Connection struct {
+	ThingID   string
+	ChannelID string
+}
+
 // Filter is used for the search filters.
 type Filter struct {
 	FullMatch    map[string]string
@@ -62,6 +68,10 @@ type ConfigRepository interface {
 	// RetrieveByExternalID returns Config for given external ID.
 	RetrieveByExternalID(externalID string) (Config, error)
 
+	// RetrieveOwner returns the owner of the Config having the provided
+	// identifier.
+	RetrieveOwner(id string) (string, error)
+
 	// Update updates an existing Config. A non-nil error is returned
 	// to indicate operation failure.
 	Update(cfg Config) error
@@ -84,8 +94,9 @@ type ConfigRepository interface {
 	// ListExisting retrieves those channels from the given list that exist in DB.
 	ListExisting(owner string, ids []string) ([]Channel, error)
 
-	// Methods RemoveThing, UpdateChannel, and RemoveChannel are related to
-	// event sourcing. That's why these methods surpass ownership check.
+	// Methods RemoveThing, UpdateChannel, RemoveChannel, DisconnectThing,
+	// RetrieveChannels and RetrieveConnections are related to event sourcing
+	// and reconciliation. That's why these methods surpass ownership check.
 
 	// RemoveThing removes Config of the Thing with the given ID.
 	RemoveThing(id string) error
@@ -99,4 +110,13 @@ type ConfigRepository interface {
 	// DisconnectHandler changes state of the Config when the corresponding Thing is
 	// disconnected from the Channel.
 	DisconnectThing(channelID, thingID string) error
+
+	// RetrieveChannels retrieves up to limit channels whose IDs follow the
+	// given one, ordered by ID.
+	RetrieveChannels(after string, limit uint64) ([]Channel, error)
+
+	// RetrieveConnections retrieves up to limit connections of the active
+	// Configs which follow the given one, ordered by the Thing and the
+	// Channel ID.
+	RetrieveConnections(after Connection, limit uint64) ([]Connection, error)
 }
@@ -0,0 +1,72 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"sync"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/bootstrap"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+)
+
+var _ bootstrap.ClaimTokenRepository = (*claimTokenRepositoryMock)(nil)
+
+type claimTokenRepositoryMock struct {
+	mu     sync.Mutex
+	tokens map[string]bootstrap.ClaimToken
+}
+
+// NewClaimTokensRepository creates in-memory claim token repository.
+func NewClaimTokensRepository() bootstrap.ClaimTokenRepository {
+	return &claimTokenRepositoryMock{
+		tokens: make(map[string]bootstrap.ClaimToken),
+	}
+}
+
+func (ctm *claimTokenRepositoryMock) Save(tokens ...bootstrap.ClaimToken) error {
+	ctm.mu.Lock()
+	defer ctm.mu.Unlock()
+
+	for _, ct := range tokens {
+		if _, ok := ctm.tokens[ct.TokenHash]; ok {
+			return errors.ErrConflict
+		}
+	}
+
+	for _, ct := range tokens {
+		ctm.tokens[ct.TokenHash] = ct
+	}
+
+	return nil
+}
+
+func (ctm *claimTokenRepositoryMock) RetrieveByToken(tokenHash string) (bootstrap.ClaimToken, error) {
+	ctm.mu.Lock()
+	defer ctm.mu.Unlock()
+
+	ct, ok := ctm.tokens[tokenHash]
+	if !ok {
+		return bootstrap.ClaimToken{}, errors.ErrNotFound
+	}
+
+	return ct, nil
+}
+
+func (ctm *claimTokenRepositoryMock) Claim(tokenHash, claimedBy, thingID string, claimedAt time.Time) error {
+	ctm.mu.Lock()
+	defer ctm.mu.Unlock()
+
+	ct, ok := ctm.tokens[tokenHash]
+	if !ok || ct.Claimed() {
+		return errors.ErrConflict
+	}
+
+	ct.ClaimedBy = claimedBy
+	ct.ThingID = thingID
+	ct.ClaimedAt = claimedAt
+	ctm.tokens[tokenHash] = ct
+
+	return nil
+}
@@ -138,6 +138,18 @@ func (crm *configRepositoryMock) RetrieveByExternalID(externalID string) (bootst
 	return bootstrap.Config{}, errors.ErrNotFound
 }
 
+func (crm *configRepositoryMock) RetrieveOwner(id string) (string, error) {
+	crm.mu.Lock()
+	defer crm.mu.Unlock()
+
+	cfg, ok := crm.configs[id]
+	if !ok {
+		return "", errors.ErrNotFound
+	}
+
+	return cfg.Owner, nil
+}
+
 func (crm *configRepositoryMock) Update(config bootstrap.Config) error {
 	crm.mu.Lock()
 	defer crm.mu.Unlock()
@@ -285,22 +297,73 @@ func (crm *configRepositoryMock) DisconnectThing(channelID, thingID string) erro
 	crm.mu.Lock()
 	defer crm.mu.Unlock()
 
-	idx := -1
-	if config, ok := crm.configs[thingID]; ok {
-		for i, ch := range config.Channels {
-			if ch.ID == channelID {
-				idx = i
-				break
-			}
+	config, ok := crm.configs[thingID]
+	if !ok {
+		return nil
+	}
+
+	for _, ch := range config.Channels {
+		if ch.ID == channelID {
+			config.State = bootstrap.Inactive
+			crm.configs[thingID] = config
+			break
 		}
+	}
 
-		if idx != -1 {
-			config.Channels = append(config.Channels[0:idx], config.Channels[idx:]...)
+	return nil
+}
+
+func (crm *configRepositoryMock) RetrieveChannels(after string, limit uint64) ([]bootstrap.Channel, error) {
+	crm.mu.Lock()
+	defer crm.mu.Unlock()
+
+	var channels []bootstrap.Channel
+	for id, ch := range crm.channels {
+		if id > after {
+			channels = append(channels, ch)
 		}
-		crm.configs[thingID] = config
 	}
 
-	delete(crm.channels, channelID)
+	sort.SliceStable(channels, func(i, j int) bool {
+		return channels[i].ID < channels[j].ID
+	})
+	if uint64(len(channels)) > limit {
+		channels = channels[:limit]
+	}
+
+	return channels, nil
+}
 
-	return nil
+func (crm *configRepositoryMock) RetrieveConnections(after bootstrap.Connection, limit uint64) ([]bootstrap.Connection, error) {
+	crm.mu.Lock()
+	defer crm.mu.Unlock()
+
+	var conns []bootstrap.Connection
+	for _, cfg := range crm.configs {
+		if cfg.State != bootstrap.Active {
+			continue
+		}
+		for _, ch := range cfg.Channels {
+			conn := bootstrap.Connection{ThingID: cfg.ThingID, ChannelID: ch.ID}
+			if less(after, conn) {
+				conns = append(conns, conn)
+			}
+		}
+	}
+
+	sort.SliceStable(conns, func(i, j int) bool {
+		return less(conns[i], conns[j])
+	})
+	if uint64(len(conns)) > limit {
+		conns = conns[:limit]
+	}
+
+	return conns, nil
+}
+
+func less(a, b bootstrap.Connection) bool {
+	if a.ThingID != b.ThingID {
+		return a.ThingID < b.ThingID
+	}
+	return a.ChannelID < b.ChannelID
 }
@@ -0,0 +1,128 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/bootstrap"
+	"github.com/MainfluxLabs/mainflux/logger"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jmoiron/sqlx"
+)
+
+var _ bootstrap.ClaimTokenRepository = (*claimTokenRepository)(nil)
+
+type claimTokenRepository struct {
+	db  *sqlx.DB
+	log logger.Logger
+}
+
+// NewClaimTokenRepository instantiates a PostgreSQL implementation of claim
+// token repository.
+func NewClaimTokenRepository(db *sqlx.DB, log logger.Logger) bootstrap.ClaimTokenRepository {
+	return &claimTokenRepository{db: db, log: log}
+}
+
+func (cr claimTokenRepository) Save(tokens ...bootstrap.ClaimToken) error {
+	q := `INSERT INTO claim_tokens (token_hash, owner, content, created_at)
+		  VALUES (:token_hash, :owner, :content, :created_at)`
+
+	tx, err := cr.db.Beginx()
+	if err != nil {
+		return errors.Wrap(errors.ErrCreateEntity, err)
+	}
+
+	for _, ct := range tokens {
+		if _, err := tx.NamedExec(q, toDBClaimToken(ct)); err != nil {
+			e := err
+			if pgErr, ok := err.(*pgconn.PgError); ok && pgErr.Code == pgerrcode.UniqueViolation {
+				e = errors.ErrConflict
+			}
+
+			if err := tx.Rollback(); err != nil {
+				cr.log.Error(fmt.Sprintf("Failed to rollback due to %s", err))
+			}
+			return errors.Wrap(errors.ErrCreateEntity, e)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.Wrap(errors.ErrCreateEntity, err)
+	}
+
+	return nil
+}
+
+func (cr claimTokenRepository) RetrieveByToken(tokenHash string) (bootstrap.ClaimToken, error) {
+	q := `SELECT token_hash, owner, content, claimed_by, mainflux_thing, created_at, claimed_at
+		  FROM claim_tokens WHERE token_hash = $1`
+
+	var dbct dbClaimToken
+	if err := cr.db.QueryRowx(q, tokenHash).StructScan(&dbct); err != nil {
+		if err == sql.ErrNoRows {
+			return bootstrap.ClaimToken{}, errors.Wrap(errors.ErrNotFound, err)
+		}
+
+		return bootstrap.ClaimToken{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+
+	return toClaimToken(dbct), nil
+}
+
+func (cr claimTokenRepository) Claim(tokenHash, claimedBy, thingID string, claimedAt time.Time) error {
+	q := `UPDATE claim_tokens SET claimed_by = $1, mainflux_thing = $2, claimed_at = $3
+		  WHERE token_hash = $4 AND claimed_at IS NULL`
+
+	res, err := cr.db.Exec(q, claimedBy, thingID, claimedAt, tokenHash)
+	if err != nil {
+		return errors.Wrap(errors.ErrUpdateEntity, err)
+	}
+
+	cnt, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(errors.ErrUpdateEntity, err)
+	}
+
+	if cnt == 0 {
+		return errors.ErrConflict
+	}
+
+	return nil
+}
+
+type dbClaimToken struct {
+	TokenHash string         `db:"token_hash"`
+	Owner     string         `db:"owner"`
+	Content   sql.NullString `db:"content"`
+	ClaimedBy sql.NullString `db:"claimed_by"`
+	ThingID   sql.NullString `db:"mainflux_thing"`
+	CreatedAt time.Time      `db:"created_at"`
+	ClaimedAt sql.NullTime   `db:"claimed_at"`
+}
+
+func toDBClaimToken(ct bootstrap.ClaimToken) dbClaimToken {
+	return dbClaimToken{
+		TokenHash: ct.TokenHash,
+		Owner:     ct.Owner,
+		Content:   nullString(ct.Content),
+		CreatedAt: ct.CreatedAt,
+	}
+}
+
+func toClaimToken(dbct dbClaimToken) bootstrap.ClaimToken {
+	return bootstrap.ClaimToken{
+		TokenHash: dbct.TokenHash,
+		Owner:     dbct.Owner,
+		Content:   dbct.Content.String,
+		ClaimedBy: dbct.ClaimedBy.String,
+		ThingID:   dbct.ThingID.String,
+		CreatedAt: dbct.CreatedAt,
+		ClaimedAt: dbct.ClaimedAt.Time,
+	}
+}
@@ -0,0 +1,76 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/bootstrap"
+	"github.com/MainfluxLabs/mainflux/bootstrap/postgres"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClaimTokens(t *testing.T) {
+	repo := postgres.NewClaimTokenRepository(db, testLog)
+
+	hash, err := uuid.NewV4()
+	require.Nil(t, err, fmt.Sprintf("Got unexpected error: %s.\n", err))
+
+	ct := bootstrap.ClaimToken{
+		TokenHash: hash.String(),
+		Owner:     config.Owner,
+		Content:   config.Content,
+		CreatedAt: time.Now().UTC().Round(time.Millisecond),
+	}
+
+	err = repo.Save(ct)
+	assert.Nil(t, err, fmt.Sprintf("Saving claim token expected to succeed: %s.\n", err))
+
+	err = repo.Save(ct)
+	assert.True(t, errors.Contains(err, errors.ErrConflict), fmt.Sprintf("Saving duplicate claim token: expected %s got %s.\n", errors.ErrConflict, err))
+
+	_, err = repo.RetrieveByToken("unknown")
+	assert.True(t, errors.Contains(err, errors.ErrNotFound), fmt.Sprintf("Retrieving unknown claim token: expected %s got %s.\n", errors.ErrNotFound, err))
+
+	saved, err := repo.RetrieveByToken(ct.TokenHash)
+	assert.Nil(t, err, fmt.Sprintf("Retrieving claim token expected to succeed: %s.\n", err))
+	assert.False(t, saved.Claimed(), "Retrieved claim token expected not to be claimed.")
+
+	cases := []struct {
+		desc string
+		hash string
+		err  error
+	}{
+		{
+			desc: "claim token",
+			hash: ct.TokenHash,
+			err:  nil,
+		},
+		{
+			desc: "claim already claimed token",
+			hash: ct.TokenHash,
+			err:  errors.ErrConflict,
+		},
+		{
+			desc: "claim non-existing token",
+			hash: "unknown",
+			err:  errors.ErrConflict,
+		},
+	}
+
+	for _, tc := range cases {
+		err := repo.Claim(tc.hash, "installer@email.com", config.ThingID, time.Now())
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+
+	claimed, err := repo.RetrieveByToken(ct.TokenHash)
+	assert.Nil(t, err, fmt.Sprintf("Retrieving claim token expected to succeed: %s.\n", err))
+	assert.True(t, claimed.Claimed(), "Retrieved claim token expected to be claimed.")
+	assert.Equal(t, config.ThingID, claimed.ThingID, fmt.Sprintf("expected thing ID %s got %s\n", config.ThingID, claimed.ThingID))
+}
@@ -231,6 +231,20 @@ func (cr configRepository) RetrieveByExternalID(externalID string) (bootstrap.Co
 	return cfg, nil
 }
 
+func (cr configRepository) RetrieveOwner(id string) (string, error) {
+	q := `SELECT owner FROM configs WHERE mainflux_thing = $1`
+
+	var owner string
+	if err := cr.db.QueryRowx(q, id).Scan(&owner); err != nil {
+		if err == sql.ErrNoRows {
+			return "", errors.Wrap(errors.ErrNotFound, err)
+		}
+		return "", errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+
+	return owner, nil
+}
+
 func (cr configRepository) Update(cfg bootstrap.Config) error {
 	q := `UPDATE configs SET name = $1, content = $2, external_id = $3, external_key = $4 WHERE mainflux_thing = $5 AND owner = $6`
 
@@ -407,7 +421,7 @@ func (cr configRepository) RemoveChannel(id string) error {
 }
 
 func (cr configRepository) DisconnectThing(channelID, thingID string) error {
-	q := `UPDATE configs SET state = $1 WHERE EXISTS (
+	q := `UPDATE configs SET state = $1 WHERE mainflux_thing = $2 AND EXISTS (
 		SELECT 1 FROM connections WHERE config_id = $2 AND channel_id = $3)`
 	if _, err := cr.db.Exec(q, bootstrap.Inactive, thingID, channelID); err != nil {
 		return errors.Wrap(errDisconnectThing, err)
@@ -415,6 +429,56 @@ func (cr configRepository) DisconnectThing(channelID, thingID string) error {
 	return nil
 }
 
+func (cr configRepository) RetrieveChannels(after string, limit uint64) ([]bootstrap.Channel, error) {
+	q := `SELECT mainflux_channel, name, metadata FROM channels WHERE mainflux_channel > $1
+		ORDER BY mainflux_channel LIMIT $2`
+	rows, err := cr.db.Queryx(q, after, limit)
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+	defer rows.Close()
+
+	var channels []bootstrap.Channel
+	for rows.Next() {
+		var dbch dbChannel
+		if err := rows.StructScan(&dbch); err != nil {
+			return nil, errors.Wrap(errors.ErrRetrieveEntity, err)
+		}
+
+		ch, err := toChannel(dbch)
+		if err != nil {
+			return nil, err
+		}
+
+		channels = append(channels, ch)
+	}
+
+	return channels, nil
+}
+
+func (cr configRepository) RetrieveConnections(after bootstrap.Connection, limit uint64) ([]bootstrap.Connection, error) {
+	q := `SELECT conn.config_id, conn.channel_id FROM connections conn
+		JOIN configs cfg ON cfg.mainflux_thing = conn.config_id AND cfg.owner = conn.config_owner
+		WHERE cfg.state = $1 AND (conn.config_id, conn.channel_id) > ($2, $3)
+		ORDER BY conn.config_id, conn.channel_id LIMIT $4`
+	rows, err := cr.db.Query(q, bootstrap.Active, after.ThingID, after.ChannelID, limit)
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+	defer rows.Close()
+
+	var conns []bootstrap.Connection
+	for rows.Next() {
+		var conn bootstrap.Connection
+		if err := rows.Scan(&conn.ThingID, &conn.ChannelID); err != nil {
+			return nil, errors.Wrap(errors.ErrRetrieveEntity, err)
+		}
+		conns = append(conns, conn)
+	}
+
+	return conns, nil
+}
+
 func (cr configRepository) retrieveAll(owner string, filter bootstrap.Filter) (string, []interface{}) {
 	template := `WHERE owner = $1 %s`
 	params := []interface{}{owner}
@@ -151,6 +151,52 @@ func TestRetrieveByID(t *testing.T) {
 	}
 }
 
+func TestRetrieveOwner(t *testing.T) {
+	repo := postgres.NewConfigRepository(db, testLog)
+	err := deleteChannels(repo)
+	require.Nil(t, err, "Channels cleanup expected to succeed.")
+
+	c := config
+	// Use UUID to prevent conflicts.
+	uid, err := uuid.NewV4()
+	require.Nil(t, err, fmt.Sprintf("Got unexpected error: %s.\n", err))
+	c.ThingKey = uid.String()
+	c.ThingID = uid.String()
+	c.ExternalID = uid.String()
+	c.ExternalKey = uid.String()
+	id, err := repo.Save(c, channels)
+	require.Nil(t, err, fmt.Sprintf("Saving config expected to succeed: %s.\n", err))
+
+	nonexistentConfID, err := uuid.NewV4()
+	require.Nil(t, err, fmt.Sprintf("Got unexpected error: %s.\n", err))
+
+	cases := []struct {
+		desc  string
+		id    string
+		owner string
+		err   error
+	}{
+		{
+			desc:  "retrieve config owner",
+			id:    id,
+			owner: c.Owner,
+			err:   nil,
+		},
+		{
+			desc:  "retrieve owner of a non-existing config",
+			id:    nonexistentConfID.String(),
+			owner: "",
+			err:   errors.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		owner, err := repo.RetrieveOwner(tc.id)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.owner, owner, fmt.Sprintf("%s: expected owner %s got %s\n", tc.desc, tc.owner, owner))
+	}
+}
+
 func TestRetrieveAll(t *testing.T) {
 	repo := postgres.NewConfigRepository(db, testLog)
 	err := deleteChannels(repo)
@@ -100,6 +100,23 @@ func migrateDB(db *sqlx.DB) error {
 					"CREATE TABLE IF NOT EXISTS unknown_configs",
 				},
 			},
+			{
+				Id: "configs_3",
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS claim_tokens (
+						token_hash     CHAR(64) PRIMARY KEY,
+						owner          VARCHAR(254) NOT NULL,
+						content        TEXT,
+						claimed_by     VARCHAR(254),
+						mainflux_thing TEXT,
+						created_at     TIMESTAMPTZ NOT NULL,
+						claimed_at     TIMESTAMPTZ
+					)`,
+				},
+				Down: []string{
+					"DROP TABLE claim_tokens",
+				},
+			},
 		},
 	}
 
@@ -0,0 +1,135 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package bootstrap
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+
+	"github.com/MainfluxLabs/mainflux"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const reconcileBatch = 100
+
+var errReconcile = errors.New("failed to reconcile with things service")
+
+// Reconciler repairs the drift of the channels and the connections kept by
+// the bootstrap service from the things service, caused by the things
+// service events missed by the event consumer.
+type Reconciler interface {
+	// Reconcile updates the stored channels with their current names and
+	// metadata, removes the channels which no longer exist and deactivates
+	// the Configs whose Things are disconnected from their channels.
+	Reconcile(ctx context.Context) error
+}
+
+type reconciler struct {
+	configs ConfigRepository
+	things  mainflux.ThingsServiceClient
+}
+
+// NewReconciler returns the reconciler of the configs with the things
+// service reached using the given client.
+func NewReconciler(configs ConfigRepository, things mainflux.ThingsServiceClient) Reconciler {
+	return &reconciler{
+		configs: configs,
+		things:  things,
+	}
+}
+
+func (r *reconciler) Reconcile(ctx context.Context) error {
+	if err := r.reconcileChannels(ctx); err != nil {
+		return errors.Wrap(errReconcile, err)
+	}
+
+	if err := r.reconcileConnections(ctx); err != nil {
+		return errors.Wrap(errReconcile, err)
+	}
+
+	return nil
+}
+
+func (r *reconciler) reconcileChannels(ctx context.Context) error {
+	after := ""
+	for {
+		chs, err := r.configs.RetrieveChannels(after, reconcileBatch)
+		if err != nil {
+			return err
+		}
+
+		for _, ch := range chs {
+			if err := r.reconcileChannel(ctx, ch); err != nil {
+				return err
+			}
+		}
+
+		if len(chs) < reconcileBatch {
+			return nil
+		}
+		after = chs[len(chs)-1].ID
+	}
+}
+
+func (r *reconciler) reconcileChannel(ctx context.Context, ch Channel) error {
+	res, err := r.things.GetChannel(ctx, &mainflux.ChannelID{Value: ch.ID})
+	switch {
+	case status.Code(err) == codes.NotFound:
+		return r.configs.RemoveChannel(ch.ID)
+	case err != nil:
+		return err
+	}
+
+	var metadata map[string]interface{}
+	if len(res.GetMetadata()) > 0 {
+		if err := json.Unmarshal(res.GetMetadata(), &metadata); err != nil {
+			return err
+		}
+	}
+
+	if ch.Name == res.GetName() && reflect.DeepEqual(normalize(ch.Metadata), normalize(metadata)) {
+		return nil
+	}
+
+	return r.configs.UpdateChannel(Channel{ID: ch.ID, Name: res.GetName(), Metadata: metadata})
+}
+
+func (r *reconciler) reconcileConnections(ctx context.Context) error {
+	after := Connection{}
+	for {
+		conns, err := r.configs.RetrieveConnections(after, reconcileBatch)
+		if err != nil {
+			return err
+		}
+
+		for _, conn := range conns {
+			req := &mainflux.AccessByIDReq{ThingID: conn.ThingID, ChanID: conn.ChannelID}
+			_, err := r.things.CanAccessByID(ctx, req)
+			switch {
+			case status.Code(err) == codes.NotFound:
+				if err := r.configs.DisconnectThing(conn.ChannelID, conn.ThingID); err != nil {
+					return err
+				}
+			case err != nil:
+				return err
+			}
+		}
+
+		if len(conns) < reconcileBatch {
+			return nil
+		}
+		after = conns[len(conns)-1]
+	}
+}
+
+// normalize treats the missing and the empty metadata as equal.
+func normalize(metadata map[string]interface{}) map[string]interface{} {
+	if len(metadata) == 0 {
+		return nil
+	}
+	return metadata
+}
@@ -0,0 +1,114 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package bootstrap_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/MainfluxLabs/mainflux"
+	"github.com/MainfluxLabs/mainflux/bootstrap"
+	btmocks "github.com/MainfluxLabs/mainflux/bootstrap/mocks"
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type thingsClient struct {
+	mainflux.ThingsServiceClient
+	channels    map[string]bootstrap.Channel
+	connections map[string]bool
+}
+
+func (tc thingsClient) GetChannel(_ context.Context, req *mainflux.ChannelID, _ ...grpc.CallOption) (*mainflux.Channel, error) {
+	ch, ok := tc.channels[req.GetValue()]
+	if !ok {
+		return nil, status.Error(codes.NotFound, "channel not found")
+	}
+
+	metadata, err := json.Marshal(ch.Metadata)
+	if err != nil {
+		return nil, err
+	}
+
+	return &mainflux.Channel{Id: ch.ID, Name: ch.Name, Metadata: metadata}, nil
+}
+
+func (tc thingsClient) CanAccessByID(_ context.Context, req *mainflux.AccessByIDReq, _ ...grpc.CallOption) (*empty.Empty, error) {
+	if !tc.connections[req.GetThingID()+":"+req.GetChanID()] {
+		return nil, status.Error(codes.NotFound, "thing not connected")
+	}
+
+	return &empty.Empty{}, nil
+}
+
+func TestReconcile(t *testing.T) {
+	configs := btmocks.NewConfigsRepository()
+
+	updated := bootstrap.Channel{ID: "updated", Name: "name", Metadata: map[string]interface{}{"key": "value"}}
+	unchanged := bootstrap.Channel{ID: "unchanged", Name: "name"}
+	removed := bootstrap.Channel{ID: "removed", Name: "name"}
+
+	connected := config
+	connected.ExternalID = "connected"
+	connected.State = bootstrap.Active
+	connected.Channels = []bootstrap.Channel{updated, unchanged}
+	connectedID, err := configs.Save(connected, []string{updated.ID, unchanged.ID})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	disconnected := config
+	disconnected.ExternalID = "disconnected"
+	disconnected.State = bootstrap.Active
+	disconnected.Channels = []bootstrap.Channel{unchanged, removed}
+	disconnectedID, err := configs.Save(disconnected, []string{unchanged.ID, removed.ID})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	tc := thingsClient{
+		channels: map[string]bootstrap.Channel{
+			updated.ID:   {ID: updated.ID, Name: "new name", Metadata: map[string]interface{}{"key": "new value"}},
+			unchanged.ID: unchanged,
+		},
+		connections: map[string]bool{
+			connectedID + ":" + updated.ID:      true,
+			connectedID + ":" + unchanged.ID:    true,
+			disconnectedID + ":" + unchanged.ID: true,
+		},
+	}
+
+	err = bootstrap.NewReconciler(configs, tc).Reconcile(context.Background())
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	chs, err := configs.RetrieveChannels("", 10)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	expected := []bootstrap.Channel{unchanged, tc.channels[updated.ID]}
+	assert.Equal(t, expected, chs, fmt.Sprintf("expected channels %v got %v\n", expected, chs))
+
+	cases := []struct {
+		desc  string
+		id    string
+		state bootstrap.State
+	}{
+		{
+			desc:  "config connected to all channels",
+			id:    connectedID,
+			state: bootstrap.Active,
+		},
+		{
+			desc:  "config disconnected from channel",
+			id:    disconnectedID,
+			state: bootstrap.Inactive,
+		},
+	}
+
+	for _, c := range cases {
+		cfg, err := configs.RetrieveByID(config.Owner, c.id)
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s\n", c.desc, err))
+		assert.Equal(t, c.state, cfg.State, fmt.Sprintf("%s: expected state %d got %d\n", c.desc, c.state, cfg.State))
+	}
+}
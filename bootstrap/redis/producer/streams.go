@@ -152,6 +152,30 @@ func (es eventStore) ChangeState(ctx context.Context, token, id string, state bo
 	return nil
 }
 
+func (es eventStore) CreateClaimTokens(ctx context.Context, token string, count uint64, content string) ([]string, error) {
+	return es.svc.CreateClaimTokens(ctx, token, count, content)
+}
+
+func (es eventStore) Claim(ctx context.Context, token, claimToken, groupID string, cfg bootstrap.Config) (bootstrap.Config, error) {
+	saved, err := es.svc.Claim(ctx, token, claimToken, groupID, cfg)
+	if err != nil {
+		return saved, err
+	}
+
+	ev := createConfigEvent{
+		mfThing:    saved.ThingID,
+		owner:      saved.Owner,
+		name:       saved.Name,
+		externalID: saved.ExternalID,
+		content:    saved.Content,
+		timestamp:  time.Now(),
+	}
+
+	es.add(ctx, ev)
+
+	return saved, nil
+}
+
 func (es eventStore) RemoveConfigHandler(ctx context.Context, id string) error {
 	return es.svc.RemoveConfigHandler(ctx, id)
 }
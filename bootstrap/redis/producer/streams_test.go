@@ -69,12 +69,13 @@ var (
 
 func newService(auth mainflux.AuthServiceClient, url string) bootstrap.Service {
 	configs := btmocks.NewConfigsRepository()
+	claims := btmocks.NewClaimTokensRepository()
 	config := mfsdk.Config{
 		ThingsURL: url,
 	}
 
 	sdk := mfsdk.NewSDK(config)
-	return bootstrap.New(auth, configs, sdk, encKey)
+	return bootstrap.New(auth, configs, claims, sdk, encKey)
 }
 
 func newThingsService(auth mainflux.AuthServiceClient) things.Service {
@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/MainfluxLabs/mainflux"
+	"github.com/MainfluxLabs/mainflux/auth"
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
 	mfsdk "github.com/MainfluxLabs/mainflux/pkg/sdk/go"
 )
@@ -29,6 +30,9 @@ var (
 	// ErrBootstrap indicates error in getting bootstrap configuration.
 	ErrBootstrap = errors.New("failed to read bootstrap configuration")
 
+	// ErrClaimToken indicates a non-existent or already used claim token.
+	ErrClaimToken = errors.New("invalid or already used claim token")
+
 	errAddBootstrap       = errors.New("failed to add bootstrap configuration")
 	errUpdateConnections  = errors.New("failed to update connections")
 	errRemoveBootstrap    = errors.New("failed to remove bootstrap configuration")
@@ -42,6 +46,8 @@ var (
 	errCheckChannels      = errors.New("failed to check if channels exists")
 	errConnectionChannels = errors.New("failed to check channels connections")
 	errUpdateCert         = errors.New("failed to update cert")
+	errCreateClaimTokens  = errors.New("failed to create claim tokens")
+	errClaim              = errors.New("failed to claim device")
 )
 
 var _ Service = (*bootstrapService)(nil)
@@ -78,6 +84,15 @@ type Service interface {
 	// ChangeState changes state of the Thing with given ID and owner.
 	ChangeState(ctx context.Context, token, id string, state State) error
 
+	// CreateClaimTokens generates a batch of one-time claim tokens carrying
+	// the given custom configuration. Tokens are returned only once.
+	CreateClaimTokens(ctx context.Context, token string, count uint64, content string) ([]string, error)
+
+	// Claim uses the claim token to create the Thing and its Config owned by the
+	// user identified by the given token. If the group ID is provided, the Thing
+	// is assigned to that group. The created Config is returned.
+	Claim(ctx context.Context, token, claimToken, groupID string, cfg Config) (Config, error)
+
 	// Methods RemoveConfig, UpdateChannel, and RemoveChannel are used as
 	// handlers for events. That's why these methods surpass ownership check.
 
@@ -105,14 +120,16 @@ type ConfigReader interface {
 type bootstrapService struct {
 	auth    mainflux.AuthServiceClient
 	configs ConfigRepository
+	claims  ClaimTokenRepository
 	sdk     mfsdk.SDK
 	encKey  []byte
 }
 
 // New returns new Bootstrap service.
-func New(auth mainflux.AuthServiceClient, configs ConfigRepository, sdk mfsdk.SDK, encKey []byte) Service {
+func New(auth mainflux.AuthServiceClient, configs ConfigRepository, claims ClaimTokenRepository, sdk mfsdk.SDK, encKey []byte) Service {
 	return &bootstrapService{
 		configs: configs,
+		claims:  claims,
 		sdk:     sdk,
 		auth:    auth,
 		encKey:  encKey,
@@ -167,7 +184,7 @@ func (bs bootstrapService) Add(ctx context.Context, token string, cfg Config) (C
 }
 
 func (bs bootstrapService) View(ctx context.Context, token, id string) (Config, error) {
-	owner, err := bs.identify(token)
+	owner, err := bs.configOwner(token, id, auth.ReadAction)
 	if err != nil {
 		return Config{}, err
 	}
@@ -176,7 +193,7 @@ func (bs bootstrapService) View(ctx context.Context, token, id string) (Config,
 }
 
 func (bs bootstrapService) Update(ctx context.Context, token string, cfg Config) error {
-	owner, err := bs.identify(token)
+	owner, err := bs.configOwner(token, cfg.ThingID, auth.WriteAction)
 	if err != nil {
 		return err
 	}
@@ -345,6 +362,81 @@ func (bs bootstrapService) ChangeState(ctx context.Context, token, id string, st
 	return nil
 }
 
+func (bs bootstrapService) CreateClaimTokens(ctx context.Context, token string, count uint64, content string) ([]string, error) {
+	owner, err := bs.identify(token)
+	if err != nil {
+		return nil, err
+	}
+
+	var tokens []string
+	var cts []ClaimToken
+	createdAt := time.Now()
+	for i := uint64(0); i < count; i++ {
+		tkn, hash, err := newClaimToken()
+		if err != nil {
+			return nil, errors.Wrap(errCreateClaimTokens, err)
+		}
+
+		tokens = append(tokens, tkn)
+		cts = append(cts, ClaimToken{
+			TokenHash: hash,
+			Owner:     owner,
+			Content:   content,
+			CreatedAt: createdAt,
+		})
+	}
+
+	if err := bs.claims.Save(cts...); err != nil {
+		return nil, errors.Wrap(errCreateClaimTokens, err)
+	}
+
+	return tokens, nil
+}
+
+func (bs bootstrapService) Claim(ctx context.Context, token, claimToken, groupID string, cfg Config) (Config, error) {
+	installer, err := bs.identify(token)
+	if err != nil {
+		return Config{}, err
+	}
+
+	hash := hashClaimToken(claimToken)
+	ct, err := bs.claims.RetrieveByToken(hash)
+	if err != nil {
+		if errors.Contains(err, errors.ErrNotFound) {
+			return Config{}, ErrClaimToken
+		}
+		return Config{}, errors.Wrap(errClaim, err)
+	}
+
+	if ct.Claimed() {
+		return Config{}, ErrClaimToken
+	}
+
+	cfg.ThingID = ""
+	cfg.Channels = nil
+	cfg.Content = ct.Content
+
+	saved, err := bs.Add(ctx, token, cfg)
+	if err != nil {
+		return Config{}, errors.Wrap(errClaim, err)
+	}
+
+	if groupID != "" {
+		if err := bs.sdk.AssignThing([]string{saved.ThingID}, groupID, token); err != nil {
+			return Config{}, bs.revertClaim(token, installer, saved.ThingID, errors.Wrap(ErrThings, err))
+		}
+	}
+
+	if err := bs.claims.Claim(hash, installer, saved.ThingID, time.Now()); err != nil {
+		if errors.Contains(err, errors.ErrConflict) {
+			err = ErrClaimToken
+		}
+		return Config{}, bs.revertClaim(token, installer, saved.ThingID, err)
+	}
+
+	return saved, nil
+}
+
 func (bs bootstrapService) UpdateChannelHandler(ctx context.Context, channel Channel) error {
 	if err := bs.configs.UpdateChannel(channel); err != nil {
 		return errors.Wrap(errUpdateChannel, err)
@@ -373,6 +465,18 @@ func (bs bootstrapService) DisconnectThingHandler(ctx context.Context, channelID
 	return nil
 }
 
+// Method revertClaim removes the Config and the Thing created during the failed claim.
+func (bs bootstrapService) revertClaim(token, owner, thingID string, err error) error {
+	if errR := bs.configs.Remove(owner, thingID); errR != nil {
+		err = errors.Wrap(err, errR)
+	}
+	if errT := bs.sdk.DeleteThing(thingID, token); errT != nil {
+		err = errors.Wrap(err, errT)
+	}
+
+	return err
+}
+
 func (bs bootstrapService) identify(token string) (string, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
 	defer cancel()
@@ -385,6 +489,34 @@ func (bs bootstrapService) identify(token string) (string, error) {
 	return res.GetId(), nil
 }
 
+// configOwner returns the owner of the Config of the Thing with the given ID.
+// Users other than the owner can access the Config with the action if the
+// Thing is shared with them.
+func (bs bootstrapService) configOwner(token, id, action string) (string, error) {
+	user, err := bs.identify(token)
+	if err != nil {
+		return "", err
+	}
+
+	owner, err := bs.configs.RetrieveOwner(id)
+	if err != nil {
+		return "", err
+	}
+	if owner == user {
+		return owner, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	req := &mainflux.AuthorizeReq{Token: token, Subject: auth.ThingSubject, Object: id, Action: action}
+	if _, err := bs.auth.Authorize(ctx, req); err != nil {
+		return "", errors.Wrap(errors.ErrNotFound, err)
+	}
+
+	return owner, nil
+}
+
 // Method thing retrieves Mainflux Thing creating one if an empty ID is passed.
 func (bs bootstrapService) thing(token, id string) (mfsdk.Thing, error) {
 	thingID := id
@@ -16,6 +16,7 @@ import (
 	"testing"
 
 	"github.com/MainfluxLabs/mainflux"
+	"github.com/MainfluxLabs/mainflux/auth"
 	"github.com/MainfluxLabs/mainflux/bootstrap"
 	btmocks "github.com/MainfluxLabs/mainflux/bootstrap/mocks"
 	"github.com/MainfluxLabs/mainflux/logger"
@@ -36,6 +37,9 @@ const (
 	invalidToken = "invalidToken"
 	email        = "test@example.com"
 	validToken   = email
+	sharedEmail  = "shared@example.com"
+	sharedToken  = sharedEmail
+	sharedID     = "shared"
 	unknown      = "unknown"
 	password     = "password"
 	channelsNum  = 3
@@ -56,17 +60,18 @@ var (
 		Channels:    []bootstrap.Channel{channel},
 		Content:     "config",
 	}
-	usersList = []users.User{{Email: email, Password: password}}
+	usersList = []users.User{{Email: email, Password: password}, {ID: sharedID, Email: sharedEmail, Password: password}}
 )
 
 func newService(auth mainflux.AuthServiceClient, url string) bootstrap.Service {
 	things := btmocks.NewConfigsRepository()
+	claims := btmocks.NewClaimTokensRepository()
 	config := mfsdk.Config{
 		ThingsURL: url,
 	}
 
 	sdk := mfsdk.NewSDK(config)
-	return bootstrap.New(auth, things, sdk, encKey)
+	return bootstrap.New(auth, things, claims, sdk, encKey)
 }
 
 func newThingsService(auth mainflux.AuthServiceClient) things.Service {
@@ -165,6 +170,14 @@ func TestView(t *testing.T) {
 	saved, err := svc.Add(context.Background(), validToken, config)
 	require.Nil(t, err, fmt.Sprintf("Saving config expected to succeed: %s.\n", err))
 
+	c := config
+	c.ExternalID = "shared_external_id"
+	shared, err := svc.Add(context.Background(), validToken, c)
+	require.Nil(t, err, fmt.Sprintf("Saving config expected to succeed: %s.\n", err))
+
+	_, err = users.AddPolicy(context.Background(), &mainflux.PolicyReq{Token: validToken, MemberID: sharedID, Subject: auth.ThingSubject, Object: shared.ThingID, Policy: auth.ViewerRelation})
+	require.Nil(t, err, fmt.Sprintf("Sharing thing expected to succeed: %s.\n", err))
+
 	cases := []struct {
 		desc  string
 		id    string
@@ -177,6 +190,18 @@ func TestView(t *testing.T) {
 			token: validToken,
 			err:   nil,
 		},
+		{
+			desc:  "view a config of the shared thing",
+			id:    shared.ThingID,
+			token: sharedToken,
+			err:   nil,
+		},
+		{
+			desc:  "view a config of another user",
+			id:    saved.ThingID,
+			token: sharedToken,
+			err:   errors.ErrNotFound,
+		},
 		{
 			desc:  "view a non-existing config",
 			id:    unknown,
@@ -217,6 +242,16 @@ func TestUpdate(t *testing.T) {
 	nonExisting := config
 	nonExisting.ThingID = unknown
 
+	c.ExternalID = "shared_external_id"
+	shared, err := svc.Add(context.Background(), validToken, c)
+	require.Nil(t, err, fmt.Sprintf("Saving config expected to succeed: %s.\n", err))
+
+	_, err = users.AddPolicy(context.Background(), &mainflux.PolicyReq{Token: validToken, MemberID: sharedID, Subject: auth.ThingSubject, Object: shared.ThingID, Policy: auth.EditorRelation})
+	require.Nil(t, err, fmt.Sprintf("Sharing thing expected to succeed: %s.\n", err))
+
+	modifiedShared := shared
+	modifiedShared.Content = "shared-config"
+
 	cases := []struct {
 		desc   string
 		config bootstrap.Config
@@ -241,6 +276,18 @@ func TestUpdate(t *testing.T) {
 			token:  invalidToken,
 			err:    errors.ErrAuthentication,
 		},
+		{
+			desc:   "update a config of the shared thing",
+			config: modifiedShared,
+			token:  sharedToken,
+			err:    nil,
+		},
+		{
+			desc:   "update a config of another user",
+			config: modifiedCreated,
+			token:  sharedToken,
+			err:    errors.ErrNotFound,
+		},
 	}
 
 	for _, tc := range cases {
@@ -656,6 +703,114 @@ func TestChangeState(t *testing.T) {
 	}
 }
 
+func TestCreateClaimTokens(t *testing.T) {
+	users := mocks.NewAuthService("", usersList)
+
+	server := newThingsServer(newThingsService(users))
+	svc := newService(users, server.URL)
+
+	cases := []struct {
+		desc  string
+		count uint64
+		token string
+		err   error
+	}{
+		{
+			desc:  "create claim tokens with wrong credentials",
+			count: 3,
+			token: invalidToken,
+			err:   errors.ErrAuthentication,
+		},
+		{
+			desc:  "create claim tokens",
+			count: 3,
+			token: validToken,
+			err:   nil,
+		},
+	}
+
+	for _, tc := range cases {
+		tokens, err := svc.CreateClaimTokens(context.Background(), tc.token, tc.count, config.Content)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if err == nil {
+			assert.Len(t, tokens, int(tc.count), fmt.Sprintf("%s: expected %d tokens got %d\n", tc.desc, tc.count, len(tokens)))
+		}
+	}
+}
+
+func TestClaim(t *testing.T) {
+	users := mocks.NewAuthService("", usersList)
+
+	server := newThingsServer(newThingsService(users))
+	svc := newService(users, server.URL)
+
+	tokens, err := svc.CreateClaimTokens(context.Background(), validToken, 2, config.Content)
+	require.Nil(t, err, fmt.Sprintf("Creating claim tokens expected to succeed: %s.\n", err))
+
+	cfg := bootstrap.Config{
+		ExternalID:  config.ExternalID,
+		ExternalKey: config.ExternalKey,
+	}
+
+	other := cfg
+	other.ExternalID = "other_external_id"
+
+	cases := []struct {
+		desc       string
+		claimToken string
+		groupID    string
+		config     bootstrap.Config
+		token      string
+		err        error
+	}{
+		{
+			desc:       "claim with wrong credentials",
+			claimToken: tokens[0],
+			config:     cfg,
+			token:      invalidToken,
+			err:        errors.ErrAuthentication,
+		},
+		{
+			desc:       "claim with unknown claim token",
+			claimToken: unknown,
+			config:     cfg,
+			token:      validToken,
+			err:        bootstrap.ErrClaimToken,
+		},
+		{
+			desc:       "claim",
+			claimToken: tokens[0],
+			config:     cfg,
+			token:      validToken,
+			err:        nil,
+		},
+		{
+			desc:       "claim with already used claim token",
+			claimToken: tokens[0],
+			config:     other,
+			token:      validToken,
+			err:        bootstrap.ErrClaimToken,
+		},
+		{
+			desc:       "claim and assign to group",
+			claimToken: tokens[1],
+			groupID:    "1",
+			config:     other,
+			token:      validToken,
+			err:        nil,
+		},
+	}
+
+	for _, tc := range cases {
+		saved, err := svc.Claim(context.Background(), tc.token, tc.claimToken, tc.groupID, tc.config)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if err == nil {
+			assert.NotEmpty(t, saved.ThingKey, fmt.Sprintf("%s: expected thing key to be set\n", tc.desc))
+			assert.Equal(t, config.Content, saved.Content, fmt.Sprintf("%s: expected content %s got %s\n", tc.desc, config.Content, saved.Content))
+		}
+	}
+}
+
 func TestUpdateChannelHandler(t *testing.T) {
 	users := mocks.NewAuthService("", usersList)
 
@@ -0,0 +1,61 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+
+	"github.com/MainfluxLabs/mainflux/bridge"
+	"github.com/go-kit/kit/endpoint"
+)
+
+func statusEndpoint(svc bridge.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(statusReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		st, err := svc.Status(ctx, req.token)
+		if err != nil {
+			return nil, err
+		}
+
+		return toStatusRes(st), nil
+	}
+}
+
+func syncEndpoint(svc bridge.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(statusReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		st, err := svc.Sync(ctx, req.token)
+		if err != nil {
+			return nil, err
+		}
+
+		return toStatusRes(st), nil
+	}
+}
+
+func toStatusRes(st bridge.Status) statusRes {
+	res := statusRes{
+		Online:    st.Online,
+		Pending:   st.Pending,
+		Forwarded: st.Forwarded,
+		Dropped:   st.Dropped,
+		LastError: st.LastError,
+	}
+	if !st.Oldest.IsZero() {
+		res.Oldest = &st.Oldest
+	}
+	if !st.LastSyncAt.IsZero() {
+		res.LastSyncAt = &st.LastSyncAt
+	}
+
+	return res
+}
@@ -0,0 +1,84 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !test
+
+package api
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/bridge"
+	log "github.com/MainfluxLabs/mainflux/logger"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+)
+
+var _ bridge.Service = (*loggingMiddleware)(nil)
+
+type loggingMiddleware struct {
+	logger log.Logger
+	svc    bridge.Service
+}
+
+// LoggingMiddleware adds logging facilities to the core service.
+func LoggingMiddleware(svc bridge.Service, logger log.Logger) bridge.Service {
+	return &loggingMiddleware{logger, svc}
+}
+
+func (lm *loggingMiddleware) Status(ctx context.Context, token string) (st bridge.Status, err error) {
+	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "status", "latency", time.Since(begin).String())
+		message := fmt.Sprintf("Method status took %s to complete", time.Since(begin))
+		if err != nil {
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		l.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.Status(ctx, token)
+}
+
+func (lm *loggingMiddleware) Sync(ctx context.Context, token string) (st bridge.Status, err error) {
+	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "sync", "latency", time.Since(begin).String())
+		message := fmt.Sprintf("Method sync with %d pending messages took %s to complete", st.Pending, time.Since(begin))
+		if err != nil {
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		l.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.Sync(ctx, token)
+}
+
+func (lm *loggingMiddleware) Store(msg messaging.Message) (err error) {
+	defer func(begin time.Time) {
+		l := lm.logger.With("method", "store", "latency", time.Since(begin).String())
+		message := fmt.Sprintf("Method store for channel %s took %s to complete", msg.Channel, time.Since(begin))
+		if err != nil {
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		l.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.Store(msg)
+}
+
+func (lm *loggingMiddleware) Forward(ctx context.Context) (err error) {
+	defer func(begin time.Time) {
+		l := lm.logger.With("method", "forward", "latency", time.Since(begin).String())
+		message := fmt.Sprintf("Method forward took %s to complete", time.Since(begin))
+		if err != nil {
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		l.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.Forward(ctx)
+}
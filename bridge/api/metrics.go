@@ -0,0 +1,68 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !test
+
+package api
+
+import (
+	"context"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/bridge"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+	"github.com/go-kit/kit/metrics"
+)
+
+var _ bridge.Service = (*metricsMiddleware)(nil)
+
+type metricsMiddleware struct {
+	counter metrics.Counter
+	latency metrics.Histogram
+	svc     bridge.Service
+}
+
+// MetricsMiddleware instruments core service by tracking request count and latency.
+func MetricsMiddleware(svc bridge.Service, counter metrics.Counter, latency metrics.Histogram) bridge.Service {
+	return &metricsMiddleware{
+		counter: counter,
+		latency: latency,
+		svc:     svc,
+	}
+}
+
+func (ms *metricsMiddleware) Status(ctx context.Context, token string) (bridge.Status, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "status").Add(1)
+		ms.latency.With("method", "status").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.Status(ctx, token)
+}
+
+func (ms *metricsMiddleware) Sync(ctx context.Context, token string) (bridge.Status, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "sync").Add(1)
+		ms.latency.With("method", "sync").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.Sync(ctx, token)
+}
+
+func (ms *metricsMiddleware) Store(msg messaging.Message) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "store").Add(1)
+		ms.latency.With("method", "store").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.Store(msg)
+}
+
+func (ms *metricsMiddleware) Forward(ctx context.Context) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "forward").Add(1)
+		ms.latency.With("method", "forward").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.Forward(ctx)
+}
@@ -0,0 +1,35 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux"
+)
+
+var _ mainflux.Response = (*statusRes)(nil)
+
+type statusRes struct {
+	Online     bool       `json:"online"`
+	Pending    uint64     `json:"pending"`
+	Forwarded  uint64     `json:"forwarded"`
+	Dropped    uint64     `json:"dropped"`
+	Oldest     *time.Time `json:"oldest,omitempty"`
+	LastSyncAt *time.Time `json:"last_sync_at,omitempty"`
+	LastError  string     `json:"last_error,omitempty"`
+}
+
+func (res statusRes) Code() int {
+	return http.StatusOK
+}
+
+func (res statusRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res statusRes) Empty() bool {
+	return false
+}
@@ -0,0 +1,66 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package bridge
+
+import (
+	"context"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+)
+
+// Entry represents the buffered message.
+type Entry struct {
+	// Offset is the position of the buffer right after the message, used
+	// to acknowledge the messages up to and including it.
+	Offset  uint64
+	Message messaging.Message
+}
+
+// Buffer specifies an API for the persistent FIFO buffer of the messages
+// waiting to be forwarded.
+type Buffer interface {
+	// Append stores the message at the end of the buffer.
+	Append(msg messaging.Message) error
+
+	// Peek retrieves at most n oldest messages, without removing them.
+	Peek(n int) ([]Entry, error)
+
+	// Ack removes the messages up to and including the given offset.
+	Ack(offset uint64) error
+
+	// Len returns the number of the buffered messages.
+	Len() uint64
+
+	// Close closes the buffer.
+	Close() error
+}
+
+// Forwarder specifies an API for forwarding the messages to the central
+// deployment.
+type Forwarder interface {
+	// Forward publishes the message to the central deployment, and returns
+	// only once it is delivered.
+	Forward(ctx context.Context, msg messaging.Message) error
+}
+
+// Status represents the state of the synchronization with the central
+// deployment.
+type Status struct {
+	// Online indicates whether the last forwarding attempt succeeded.
+	Online bool
+	// Pending is the number of the messages waiting to be forwarded.
+	Pending uint64
+	// Forwarded is the number of the messages forwarded since the start.
+	Forwarded uint64
+	// Dropped is the number of the messages dropped since the start,
+	// because the buffer was full.
+	Dropped uint64
+	// Oldest is the creation time of the oldest pending message.
+	Oldest time.Time
+	// LastSyncAt is the time of the last successful forwarding.
+	LastSyncAt time.Time
+	// LastError is the error of the last failed forwarding attempt.
+	LastError string
+}
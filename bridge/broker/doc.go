@@ -0,0 +1,6 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package broker contains the bridge forwarder implementation publishing
+// the messages to the message broker of the central deployment.
+package broker
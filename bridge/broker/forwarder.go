@@ -0,0 +1,80 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package broker
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/bridge"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+)
+
+var _ bridge.Forwarder = (*forwarder)(nil)
+
+// PublisherFactory creates the publisher connected to the message broker
+// of the central deployment.
+type PublisherFactory func() (messaging.Publisher, error)
+
+type forwarder struct {
+	mu        sync.Mutex
+	pub       messaging.Publisher
+	connect   PublisherFactory
+	confirmed bool
+	timeout   time.Duration
+}
+
+// NewForwarder returns the forwarder publishing the messages using the
+// publisher created by the factory. The publisher is created once the first
+// message is forwarded, and recreated after the failure, so the bridge
+// starts while the uplink is down. Confirmed forwarder waits for the
+// broker to persist the message, otherwise it waits for the broker to
+// receive it, at most for the timeout.
+func NewForwarder(connect PublisherFactory, confirmed bool, timeout time.Duration) bridge.Forwarder {
+	return &forwarder{
+		connect:   connect,
+		confirmed: confirmed,
+		timeout:   timeout,
+	}
+}
+
+func (fwd *forwarder) Forward(ctx context.Context, msg messaging.Message) error {
+	fwd.mu.Lock()
+	defer fwd.mu.Unlock()
+
+	if fwd.pub == nil {
+		pub, err := fwd.connect()
+		if err != nil {
+			return err
+		}
+		fwd.pub = pub
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, fwd.timeout)
+	defer cancel()
+
+	if err := fwd.publish(ctx, msg); err != nil {
+		fwd.pub.Close()
+		fwd.pub = nil
+		return err
+	}
+
+	return nil
+}
+
+func (fwd *forwarder) publish(ctx context.Context, msg messaging.Message) error {
+	if fwd.confirmed {
+		return messaging.PublishConfirmed(ctx, fwd.pub, msg.Channel, msg)
+	}
+
+	if err := fwd.pub.Publish(msg.Channel, msg); err != nil {
+		return err
+	}
+	if p, ok := fwd.pub.(messaging.Pinger); ok {
+		return p.Ping(ctx)
+	}
+
+	return nil
+}
@@ -0,0 +1,6 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package cloud contains the forwarder of the messages to the cloud IoT
+// services, AWS IoT Core and Azure IoT Hub, over MQTT.
+package cloud
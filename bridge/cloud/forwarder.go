@@ -0,0 +1,131 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package cloud
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/bridge"
+	"github.com/MainfluxLabs/mainflux/logger"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+	mqtt "github.com/eclipse/paho.mqtt.golang"
+)
+
+const (
+	qos             = 1
+	disconnectQuiet = 250
+)
+
+var (
+	errNotConnected = errors.New("not connected to cloud IoT service")
+	errTimeout      = errors.New("cloud IoT service publish timeout")
+)
+
+// Forwarder forwards the messages to the cloud IoT service and publishes the
+// cloud-to-device messages to the edge message broker.
+type Forwarder interface {
+	bridge.Forwarder
+
+	// Close disconnects from the cloud IoT service.
+	Close() error
+}
+
+var _ Forwarder = (*forwarder)(nil)
+
+type forwarder struct {
+	client   mqtt.Client
+	provider Provider
+	pub      messaging.Publisher
+	timeout  time.Duration
+	logger   logger.Logger
+}
+
+// NewForwarder returns the forwarder connected to the MQTT broker of the
+// cloud IoT service at the given URL. The connection is retried in the
+// background, so the bridge starts while the uplink is down. Once connected,
+// the forwarder subscribes to the cloud-to-device messages and publishes
+// them to their channels using the given publisher.
+func NewForwarder(url, clientID string, provider Provider, pub messaging.Publisher, timeout time.Duration, logger logger.Logger) (Forwarder, error) {
+	fwd := &forwarder{
+		provider: provider,
+		pub:      pub,
+		timeout:  timeout,
+		logger:   logger,
+	}
+
+	opts := mqtt.NewClientOptions().
+		AddBroker(url).
+		SetClientID(clientID).
+		SetCleanSession(false).
+		SetAutoReconnect(true).
+		SetConnectRetry(true).
+		SetOnConnectHandler(fwd.subscribe)
+	if err := provider.Configure(opts); err != nil {
+		return nil, err
+	}
+
+	fwd.client = mqtt.NewClient(opts)
+	fwd.client.Connect()
+
+	return fwd, nil
+}
+
+func (fwd *forwarder) Forward(ctx context.Context, msg messaging.Message) error {
+	if !fwd.client.IsConnectionOpen() {
+		return errNotConnected
+	}
+
+	timeout := fwd.timeout
+	if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < timeout {
+		timeout = time.Until(deadline)
+	}
+
+	token := fwd.client.Publish(fwd.provider.Topic(msg), qos, false, msg.Payload)
+	if !token.WaitTimeout(timeout) {
+		return errTimeout
+	}
+
+	return token.Error()
+}
+
+func (fwd *forwarder) Close() error {
+	fwd.client.Disconnect(disconnectQuiet)
+	return nil
+}
+
+func (fwd *forwarder) subscribe(c mqtt.Client) {
+	token := c.Subscribe(fwd.provider.Commands(), qos, fwd.handle)
+	if token.Wait() && token.Error() != nil {
+		fwd.logger.Error(fmt.Sprintf("Failed to subscribe to cloud-to-device messages: %s", token.Error()))
+	}
+}
+
+func (fwd *forwarder) handle(_ mqtt.Client, m mqtt.Message) {
+	chanID, subtopic, err := fwd.provider.Command(m.Topic())
+	if err != nil {
+		fwd.logger.Warn(fmt.Sprintf("Failed to map cloud-to-device message of topic %s: %s", m.Topic(), err))
+		return
+	}
+
+	id, err := messaging.NewID()
+	if err != nil {
+		fwd.logger.Warn(fmt.Sprintf("Failed to publish cloud-to-device message: %s", err))
+		return
+	}
+
+	msg := messaging.Message{
+		Id:       id,
+		Channel:  chanID,
+		Subtopic: subtopic,
+		Protocol: bridge.Protocol,
+		Payload:  m.Payload(),
+		Created:  time.Now().UnixNano(),
+	}
+	if err := fwd.pub.Publish(msg.Channel, msg); err != nil {
+		fwd.logger.Warn(fmt.Sprintf("Failed to publish cloud-to-device message to channel %s: %s", chanID, err))
+	}
+}
@@ -0,0 +1,214 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package cloud
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+	mqtt "github.com/eclipse/paho.mqtt.golang"
+)
+
+const (
+	// AWS is the name of the AWS IoT Core provider.
+	AWS = "aws"
+	// Azure is the name of the Azure IoT Hub provider.
+	Azure = "azure"
+
+	azureAPIVersion = "2021-04-12"
+	channelProp     = "channel"
+	subtopicProp    = "subtopic"
+)
+
+var (
+	// ErrInvalidTopic indicates the cloud-to-device message received on the
+	// topic which doesn't identify the Mainflux channel.
+	ErrInvalidTopic = errors.New("invalid cloud-to-device topic")
+
+	errInvalidKey = errors.New("invalid shared access key")
+	errCACerts    = errors.New("failed to load CA certificates")
+)
+
+// Provider specifies the cloud specific authentication and the mapping of
+// the Mainflux channels to the MQTT topics of the cloud IoT service.
+type Provider interface {
+	// Configure sets the authentication of the MQTT client.
+	Configure(opts *mqtt.ClientOptions) error
+
+	// Topic returns the topic the message is published to.
+	Topic(msg messaging.Message) string
+
+	// Commands returns the topic filter of the cloud-to-device messages.
+	Commands() string
+
+	// Command returns the channel and the subtopic of the cloud-to-device
+	// message received on the given topic.
+	Command(topic string) (chanID, subtopic string, err error)
+}
+
+var _ Provider = (*aws)(nil)
+
+type aws struct {
+	prefix   string
+	certFile string
+	keyFile  string
+	caFile   string
+}
+
+// NewAWS returns the AWS IoT Core provider, authenticating by the X.509
+// certificate of the thing. Messages are published to the
+// <prefix>/channels/<channel>/messages[/<subtopic>] topics, and the commands
+// are received on the <prefix>/channels/<channel>/commands[/<subtopic>]
+// topics. The Amazon root CA is trusted, if set.
+func NewAWS(prefix, certFile, keyFile, caFile string) Provider {
+	return &aws{
+		prefix:   prefix,
+		certFile: certFile,
+		keyFile:  keyFile,
+		caFile:   caFile,
+	}
+}
+
+func (p *aws) Configure(opts *mqtt.ClientOptions) error {
+	cert, err := tls.LoadX509KeyPair(p.certFile, p.keyFile)
+	if err != nil {
+		return err
+	}
+	cfg := &tls.Config{Certificates: []tls.Certificate{cert}}
+
+	if p.caFile != "" {
+		ca, err := ioutil.ReadFile(p.caFile)
+		if err != nil {
+			return errors.Wrap(errCACerts, err)
+		}
+		cfg.RootCAs = x509.NewCertPool()
+		if !cfg.RootCAs.AppendCertsFromPEM(ca) {
+			return errCACerts
+		}
+	}
+
+	opts.SetTLSConfig(cfg)
+	return nil
+}
+
+func (p *aws) Topic(msg messaging.Message) string {
+	topic := fmt.Sprintf("%s/channels/%s/messages", p.prefix, msg.Channel)
+	if msg.Subtopic != "" {
+		topic += "/" + strings.ReplaceAll(msg.Subtopic, ".", "/")
+	}
+
+	return topic
+}
+
+func (p *aws) Commands() string {
+	return fmt.Sprintf("%s/channels/+/commands/#", p.prefix)
+}
+
+func (p *aws) Command(topic string) (string, string, error) {
+	rest := strings.TrimPrefix(topic, p.prefix+"/channels/")
+	if rest == topic {
+		return "", "", ErrInvalidTopic
+	}
+
+	tokens := strings.Split(rest, "/")
+	if len(tokens) < 2 || tokens[0] == "" || tokens[1] != "commands" {
+		return "", "", ErrInvalidTopic
+	}
+
+	return tokens[0], strings.Join(tokens[2:], "."), nil
+}
+
+var _ Provider = (*azure)(nil)
+
+type azure struct {
+	hub      string
+	deviceID string
+	key      []byte
+	ttl      time.Duration
+}
+
+// NewAzure returns the Azure IoT Hub provider of the device, authenticating
+// by the shared access signature tokens signed by the base64 encoded device
+// key and valid for the given TTL. The channel and the subtopic are carried
+// by the message properties.
+func NewAzure(hub, deviceID, key string, ttl time.Duration) (Provider, error) {
+	k, err := base64.StdEncoding.DecodeString(key)
+	if err != nil || len(k) == 0 {
+		return nil, errInvalidKey
+	}
+
+	return &azure{
+		hub:      hub,
+		deviceID: deviceID,
+		key:      k,
+		ttl:      ttl,
+	}, nil
+}
+
+func (p *azure) Configure(opts *mqtt.ClientOptions) error {
+	opts.SetClientID(p.deviceID)
+	opts.SetTLSConfig(&tls.Config{ServerName: p.hub})
+	opts.SetCredentialsProvider(func() (string, string) {
+		username := fmt.Sprintf("%s/%s/?api-version=%s", p.hub, p.deviceID, azureAPIVersion)
+		return username, p.token(time.Now().Add(p.ttl))
+	})
+
+	return nil
+}
+
+// token returns the shared access signature token of the device which
+// expires at the given time.
+func (p *azure) token(expiry time.Time) string {
+	sr := url.QueryEscape(fmt.Sprintf("%s/devices/%s", p.hub, p.deviceID))
+	se := strconv.FormatInt(expiry.Unix(), 10)
+
+	mac := hmac.New(sha256.New, p.key)
+	mac.Write([]byte(sr + "\n" + se))
+	sig := url.QueryEscape(base64.StdEncoding.EncodeToString(mac.Sum(nil)))
+
+	return fmt.Sprintf("SharedAccessSignature sr=%s&sig=%s&se=%s", sr, sig, se)
+}
+
+func (p *azure) Topic(msg messaging.Message) string {
+	props := url.Values{channelProp: []string{msg.Channel}}
+	if msg.Subtopic != "" {
+		props.Set(subtopicProp, msg.Subtopic)
+	}
+
+	return fmt.Sprintf("devices/%s/messages/events/%s", p.deviceID, props.Encode())
+}
+
+func (p *azure) Commands() string {
+	return fmt.Sprintf("devices/%s/messages/devicebound/#", p.deviceID)
+}
+
+func (p *azure) Command(topic string) (string, string, error) {
+	prefix := fmt.Sprintf("devices/%s/messages/devicebound/", p.deviceID)
+	if !strings.HasPrefix(topic, prefix) {
+		return "", "", ErrInvalidTopic
+	}
+
+	props, err := url.ParseQuery(strings.TrimPrefix(topic, prefix))
+	if err != nil {
+		return "", "", errors.Wrap(ErrInvalidTopic, err)
+	}
+
+	chanID := props.Get(channelProp)
+	if chanID == "" {
+		return "", "", ErrInvalidTopic
+	}
+
+	return chanID, props.Get(subtopicProp), nil
+}
@@ -0,0 +1,223 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package cloud_test
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/bridge/cloud"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+	mqtt "github.com/eclipse/paho.mqtt.golang"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	chanID   = "chan-id"
+	prefix   = "mainflux"
+	hub      = "hub.azure-devices.net"
+	deviceID = "edge"
+)
+
+var key = base64.StdEncoding.EncodeToString([]byte("device-key"))
+
+func TestAWSTopic(t *testing.T) {
+	p := cloud.NewAWS(prefix, "", "", "")
+
+	cases := []struct {
+		desc     string
+		subtopic string
+		topic    string
+	}{
+		{
+			desc:  "topic of message without subtopic",
+			topic: "mainflux/channels/chan-id/messages",
+		},
+		{
+			desc:     "topic of message with subtopic",
+			subtopic: "room.temp",
+			topic:    "mainflux/channels/chan-id/messages/room/temp",
+		},
+	}
+
+	for _, tc := range cases {
+		topic := p.Topic(messaging.Message{Channel: chanID, Subtopic: tc.subtopic})
+		assert.Equal(t, tc.topic, topic, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.topic, topic))
+	}
+}
+
+func TestAWSCommand(t *testing.T) {
+	p := cloud.NewAWS(prefix, "", "", "")
+	assert.Equal(t, "mainflux/channels/+/commands/#", p.Commands())
+
+	cases := []struct {
+		desc     string
+		topic    string
+		chanID   string
+		subtopic string
+		err      error
+	}{
+		{
+			desc:   "command without subtopic",
+			topic:  "mainflux/channels/chan-id/commands",
+			chanID: chanID,
+		},
+		{
+			desc:     "command with subtopic",
+			topic:    "mainflux/channels/chan-id/commands/valve/open",
+			chanID:   chanID,
+			subtopic: "valve.open",
+		},
+		{
+			desc:  "command with invalid prefix",
+			topic: "other/channels/chan-id/commands",
+			err:   cloud.ErrInvalidTopic,
+		},
+		{
+			desc:  "command without channel",
+			topic: "mainflux/channels//commands",
+			err:   cloud.ErrInvalidTopic,
+		},
+		{
+			desc:  "telemetry topic",
+			topic: "mainflux/channels/chan-id/messages",
+			err:   cloud.ErrInvalidTopic,
+		},
+	}
+
+	for _, tc := range cases {
+		ch, subtopic, err := p.Command(tc.topic)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.chanID, ch, fmt.Sprintf("%s: expected channel %s got %s\n", tc.desc, tc.chanID, ch))
+		assert.Equal(t, tc.subtopic, subtopic, fmt.Sprintf("%s: expected subtopic %s got %s\n", tc.desc, tc.subtopic, subtopic))
+	}
+}
+
+func TestNewAzure(t *testing.T) {
+	cases := []struct {
+		desc string
+		key  string
+		err  bool
+	}{
+		{
+			desc: "create provider with valid key",
+			key:  key,
+		},
+		{
+			desc: "create provider with invalid key",
+			key:  "not base64",
+			err:  true,
+		},
+		{
+			desc: "create provider with empty key",
+			key:  "",
+			err:  true,
+		},
+	}
+
+	for _, tc := range cases {
+		_, err := cloud.NewAzure(hub, deviceID, tc.key, time.Hour)
+		assert.Equal(t, tc.err, err != nil, fmt.Sprintf("%s: unexpected error %s\n", tc.desc, err))
+	}
+}
+
+func TestAzureCredentials(t *testing.T) {
+	p, err := cloud.NewAzure(hub, deviceID, key, time.Hour)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	opts := mqtt.NewClientOptions()
+	err = p.Configure(opts)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.Equal(t, deviceID, opts.ClientID)
+	assert.Equal(t, hub, opts.TLSConfig.ServerName)
+
+	username, password := opts.CredentialsProvider()
+	assert.Equal(t, "hub.azure-devices.net/edge/?api-version=2021-04-12", username)
+
+	token := strings.TrimPrefix(password, "SharedAccessSignature ")
+	params, err := url.ParseQuery(token)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.Equal(t, "hub.azure-devices.net/devices/edge", params.Get("sr"))
+
+	mac := hmac.New(sha256.New, []byte("device-key"))
+	mac.Write([]byte(url.QueryEscape(params.Get("sr")) + "\n" + params.Get("se")))
+	assert.Equal(t, base64.StdEncoding.EncodeToString(mac.Sum(nil)), params.Get("sig"))
+}
+
+func TestAzureTopic(t *testing.T) {
+	p, err := cloud.NewAzure(hub, deviceID, key, time.Hour)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc     string
+		subtopic string
+		topic    string
+	}{
+		{
+			desc:  "topic of message without subtopic",
+			topic: "devices/edge/messages/events/channel=chan-id",
+		},
+		{
+			desc:     "topic of message with subtopic",
+			subtopic: "room.temp",
+			topic:    "devices/edge/messages/events/channel=chan-id&subtopic=room.temp",
+		},
+	}
+
+	for _, tc := range cases {
+		topic := p.Topic(messaging.Message{Channel: chanID, Subtopic: tc.subtopic})
+		assert.Equal(t, tc.topic, topic, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.topic, topic))
+	}
+}
+
+func TestAzureCommand(t *testing.T) {
+	p, err := cloud.NewAzure(hub, deviceID, key, time.Hour)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.Equal(t, "devices/edge/messages/devicebound/#", p.Commands())
+
+	cases := []struct {
+		desc     string
+		topic    string
+		chanID   string
+		subtopic string
+		err      error
+	}{
+		{
+			desc:     "command with channel and subtopic",
+			topic:    "devices/edge/messages/devicebound/%24.mid=1&channel=chan-id&subtopic=valve.open",
+			chanID:   chanID,
+			subtopic: "valve.open",
+		},
+		{
+			desc:   "command with channel",
+			topic:  "devices/edge/messages/devicebound/channel=chan-id",
+			chanID: chanID,
+		},
+		{
+			desc:  "command without channel",
+			topic: "devices/edge/messages/devicebound/%24.mid=1",
+			err:   cloud.ErrInvalidTopic,
+		},
+		{
+			desc:  "command of other device",
+			topic: "devices/other/messages/devicebound/channel=chan-id",
+			err:   cloud.ErrInvalidTopic,
+		},
+	}
+
+	for _, tc := range cases {
+		ch, subtopic, err := p.Command(tc.topic)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.chanID, ch, fmt.Sprintf("%s: expected channel %s got %s\n", tc.desc, tc.chanID, ch))
+		assert.Equal(t, tc.subtopic, subtopic, fmt.Sprintf("%s: expected subtopic %s got %s\n", tc.desc, tc.subtopic, subtopic))
+	}
+}
@@ -0,0 +1,7 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package bridge contains the domain concept definitions needed to support
+// Mainflux edge bridge functionality, which stores the messages of the edge
+// deployment and forwards them to the central deployment.
+package bridge
@@ -0,0 +1,302 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package file
+
+import (
+	"encoding/binary"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/MainfluxLabs/mainflux/bridge"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+	"github.com/gogo/protobuf/proto"
+)
+
+const (
+	logFile    = "messages.log"
+	offsetFile = "offset"
+	headerSize = 4
+)
+
+var errCorrupted = errors.New("corrupted buffer file")
+
+var _ bridge.Buffer = (*buffer)(nil)
+
+// buffer stores the messages in the log file, each prefixed by the length
+// of its protobuf encoding. The offset of the oldest pending message is
+// stored in the separate file, so that the forwarded messages are skipped
+// after the restart.
+type buffer struct {
+	mu      sync.Mutex
+	dir     string
+	file    *os.File
+	offset  uint64
+	size    uint64
+	count   uint64
+	maxSize uint64
+}
+
+// New returns the buffer storing the messages in the directory. Appending
+// fails with bridge.ErrBufferFull once the pending messages take maxSize
+// bytes. Zero maxSize doesn't limit the buffer size.
+func New(dir string, maxSize uint64) (bridge.Buffer, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	file, err := os.OpenFile(filepath.Join(dir, logFile), os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return nil, err
+	}
+
+	b := &buffer{
+		dir:     dir,
+		file:    file,
+		maxSize: maxSize,
+	}
+	if err := b.load(); err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	return b, nil
+}
+
+func (b *buffer) Append(msg messaging.Message) error {
+	data, err := proto.Marshal(&msg)
+	if err != nil {
+		return err
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	rec := make([]byte, headerSize+len(data))
+	binary.BigEndian.PutUint32(rec, uint32(len(data)))
+	copy(rec[headerSize:], data)
+
+	if b.maxSize > 0 && b.size-b.offset+uint64(len(rec)) > b.maxSize {
+		return bridge.ErrBufferFull
+	}
+
+	if _, err := b.file.WriteAt(rec, int64(b.size)); err != nil {
+		return err
+	}
+	b.size += uint64(len(rec))
+	b.count++
+
+	return nil
+}
+
+func (b *buffer) Peek(n int) ([]bridge.Entry, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var entries []bridge.Entry
+	off := b.offset
+	for len(entries) < n && off < b.size {
+		data, next, err := b.read(off)
+		if err != nil {
+			return nil, err
+		}
+
+		var msg messaging.Message
+		if err := proto.Unmarshal(data, &msg); err != nil {
+			return nil, errors.Wrap(errCorrupted, err)
+		}
+		entries = append(entries, bridge.Entry{Offset: next, Message: msg})
+		off = next
+	}
+
+	return entries, nil
+}
+
+func (b *buffer) Ack(offset uint64) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if offset <= b.offset || offset > b.size {
+		return nil
+	}
+
+	acked, err := b.records(b.offset, offset)
+	if err != nil {
+		return err
+	}
+	b.offset = offset
+	b.count -= acked
+
+	if b.offset == b.size {
+		return b.truncate()
+	}
+	if b.maxSize > 0 && b.offset > b.maxSize/2 {
+		return b.compact()
+	}
+
+	return b.saveOffset()
+}
+
+func (b *buffer) Len() uint64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.count
+}
+
+func (b *buffer) Close() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.file.Close()
+}
+
+// load restores the offset and counts the pending messages. The incomplete
+// message written at the end of the file before the crash is discarded.
+func (b *buffer) load() error {
+	info, err := b.file.Stat()
+	if err != nil {
+		return err
+	}
+	b.size = uint64(info.Size())
+
+	data, err := os.ReadFile(filepath.Join(b.dir, offsetFile))
+	switch {
+	case os.IsNotExist(err):
+	case err != nil:
+		return err
+	case len(data) != 8:
+		return errCorrupted
+	default:
+		b.offset = binary.BigEndian.Uint64(data)
+	}
+	if b.offset > b.size {
+		return errCorrupted
+	}
+
+	off := b.offset
+	for off < b.size {
+		_, next, err := b.read(off)
+		if err == io.ErrUnexpectedEOF {
+			if err := b.file.Truncate(int64(off)); err != nil {
+				return err
+			}
+			b.size = off
+			break
+		}
+		if err != nil {
+			return err
+		}
+		b.count++
+		off = next
+	}
+
+	return nil
+}
+
+// read reads the message at the offset and returns the offset of the next
+// message.
+func (b *buffer) read(off uint64) ([]byte, uint64, error) {
+	header := make([]byte, headerSize)
+	if err := b.readAt(header, off); err != nil {
+		return nil, 0, err
+	}
+
+	data := make([]byte, binary.BigEndian.Uint32(header))
+	if err := b.readAt(data, off+headerSize); err != nil {
+		return nil, 0, err
+	}
+
+	return data, off + headerSize + uint64(len(data)), nil
+}
+
+func (b *buffer) readAt(p []byte, off uint64) error {
+	if off+uint64(len(p)) > b.size {
+		return io.ErrUnexpectedEOF
+	}
+	_, err := b.file.ReadAt(p, int64(off))
+	return err
+}
+
+// records returns the number of the messages between the offsets.
+func (b *buffer) records(from, to uint64) (uint64, error) {
+	var n uint64
+	for off := from; off < to; n++ {
+		header := make([]byte, headerSize)
+		if err := b.readAt(header, off); err != nil {
+			return 0, err
+		}
+		off += headerSize + uint64(binary.BigEndian.Uint32(header))
+	}
+
+	return n, nil
+}
+
+// truncate empties the buffer once all the messages are forwarded.
+func (b *buffer) truncate() error {
+	if err := b.file.Truncate(0); err != nil {
+		return err
+	}
+	b.offset = 0
+	b.size = 0
+
+	return b.saveOffset()
+}
+
+// compact moves the pending messages to the start of the new log file, so
+// that the file doesn't grow while the buffer is never drained.
+func (b *buffer) compact() error {
+	path := filepath.Join(b.dir, logFile)
+	tmp, err := os.OpenFile(path+".tmp", os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0o644)
+	if err != nil {
+		return err
+	}
+
+	src := io.NewSectionReader(b.file, int64(b.offset), int64(b.size-b.offset))
+	if _, err := io.Copy(tmp, src); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+
+	// The offset is reset before the files are swapped, so that the crash
+	// in between replays the forwarded messages instead of losing the
+	// pending ones.
+	offset := b.offset
+	b.offset = 0
+	if err := b.saveOffset(); err != nil {
+		tmp.Close()
+		b.offset = offset
+		return err
+	}
+	if err := os.Rename(path+".tmp", path); err != nil {
+		tmp.Close()
+		b.offset = offset
+		b.saveOffset()
+		return err
+	}
+
+	b.file.Close()
+	b.file = tmp
+	b.size -= offset
+
+	return nil
+}
+
+func (b *buffer) saveOffset() error {
+	data := make([]byte, 8)
+	binary.BigEndian.PutUint64(data, b.offset)
+
+	path := filepath.Join(b.dir, offsetFile)
+	if err := os.WriteFile(path+".tmp", data, 0o644); err != nil {
+		return err
+	}
+
+	return os.Rename(path+".tmp", path)
+}
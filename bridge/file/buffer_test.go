@@ -0,0 +1,122 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package file_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/MainfluxLabs/mainflux/bridge"
+	"github.com/MainfluxLabs/mainflux/bridge/file"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const chanID = "chan-id"
+
+func newMessage(i int) messaging.Message {
+	return messaging.Message{
+		Channel: chanID,
+		Payload: []byte(fmt.Sprintf(`{"n":%d}`, i)),
+		Created: int64(i),
+	}
+}
+
+func TestAppendPeekAck(t *testing.T) {
+	dir := t.TempDir()
+	buf, err := file.New(dir, 0)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	for i := 0; i < 5; i++ {
+		err := buf.Append(newMessage(i))
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	}
+	assert.Equal(t, uint64(5), buf.Len(), fmt.Sprintf("expected 5 messages got %d", buf.Len()))
+
+	entries, err := buf.Peek(3)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	require.Len(t, entries, 3, fmt.Sprintf("expected 3 entries got %d", len(entries)))
+	for i, e := range entries {
+		assert.Equal(t, newMessage(i).Payload, e.Message.Payload, fmt.Sprintf("expected message %d in order", i))
+	}
+
+	err = buf.Ack(entries[1].Offset)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.Equal(t, uint64(3), buf.Len(), fmt.Sprintf("expected 3 messages got %d", buf.Len()))
+	require.Nil(t, buf.Close(), "unexpected error on close")
+
+	buf, err = file.New(dir, 0)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.Equal(t, uint64(3), buf.Len(), fmt.Sprintf("expected 3 messages after restart got %d", buf.Len()))
+
+	entries, err = buf.Peek(10)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	require.Len(t, entries, 3, fmt.Sprintf("expected 3 entries got %d", len(entries)))
+	assert.Equal(t, newMessage(2).Payload, entries[0].Message.Payload, "expected oldest pending message after restart")
+
+	err = buf.Ack(entries[2].Offset)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.Equal(t, uint64(0), buf.Len(), fmt.Sprintf("expected empty buffer got %d messages", buf.Len()))
+
+	info, err := os.Stat(filepath.Join(dir, "messages.log"))
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.Equal(t, int64(0), info.Size(), "expected drained buffer file to be truncated")
+}
+
+func TestBufferFull(t *testing.T) {
+	dir := t.TempDir()
+	buf, err := file.New(dir, 100)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	var appended int
+	for ; appended < 100; appended++ {
+		if err := buf.Append(newMessage(appended)); err != nil {
+			assert.Equal(t, bridge.ErrBufferFull, err, fmt.Sprintf("expected %s got %s", bridge.ErrBufferFull, err))
+			break
+		}
+	}
+	require.Less(t, appended, 100, "expected buffer to fill up")
+
+	entries, err := buf.Peek(appended - 1)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	err = buf.Ack(entries[len(entries)-1].Offset)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	err = buf.Append(newMessage(appended))
+	assert.Nil(t, err, fmt.Sprintf("append after compaction: unexpected error: %s", err))
+
+	entries, err = buf.Peek(10)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	require.Len(t, entries, 2, fmt.Sprintf("expected 2 entries got %d", len(entries)))
+	assert.Equal(t, newMessage(appended-1).Payload, entries[0].Message.Payload, "expected pending message to be kept by compaction")
+	assert.Equal(t, newMessage(appended).Payload, entries[1].Message.Payload, "expected appended message after compaction")
+}
+
+func TestTornWrite(t *testing.T) {
+	dir := t.TempDir()
+	buf, err := file.New(dir, 0)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	err = buf.Append(newMessage(0))
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	require.Nil(t, buf.Close(), "unexpected error on close")
+
+	f, err := os.OpenFile(filepath.Join(dir, "messages.log"), os.O_APPEND|os.O_WRONLY, 0o644)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	_, err = f.Write([]byte{0, 0, 0, 42, 1, 2})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	f.Close()
+
+	buf, err = file.New(dir, 0)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.Equal(t, uint64(1), buf.Len(), fmt.Sprintf("expected incomplete message to be discarded got %d messages", buf.Len()))
+
+	err = buf.Append(newMessage(1))
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	entries, err := buf.Peek(10)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.Len(t, entries, 2, fmt.Sprintf("expected 2 entries got %d", len(entries)))
+}
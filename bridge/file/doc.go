@@ -0,0 +1,6 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package file contains the bridge buffer implementation storing the
+// messages in the local file system.
+package file
@@ -0,0 +1,72 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"sync"
+
+	"github.com/MainfluxLabs/mainflux/bridge"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+)
+
+var _ bridge.Buffer = (*bufferMock)(nil)
+
+type bufferMock struct {
+	mu      sync.Mutex
+	entries []bridge.Entry
+	next    uint64
+	limit   int
+}
+
+// NewBuffer returns the in-memory buffer mock holding at most limit
+// messages. Offsets are the positions of the messages, starting from one.
+func NewBuffer(limit int) bridge.Buffer {
+	return &bufferMock{limit: limit}
+}
+
+func (b *bufferMock) Append(msg messaging.Message) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if len(b.entries) >= b.limit {
+		return bridge.ErrBufferFull
+	}
+	b.next++
+	b.entries = append(b.entries, bridge.Entry{Offset: b.next, Message: msg})
+
+	return nil
+}
+
+func (b *bufferMock) Peek(n int) ([]bridge.Entry, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if n > len(b.entries) {
+		n = len(b.entries)
+	}
+
+	return append([]bridge.Entry{}, b.entries[:n]...), nil
+}
+
+func (b *bufferMock) Ack(offset uint64) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for len(b.entries) > 0 && b.entries[0].Offset <= offset {
+		b.entries = b.entries[1:]
+	}
+
+	return nil
+}
+
+func (b *bufferMock) Len() uint64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return uint64(len(b.entries))
+}
+
+func (b *bufferMock) Close() error {
+	return nil
+}
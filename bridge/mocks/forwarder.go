@@ -0,0 +1,69 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/MainfluxLabs/mainflux/bridge"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+)
+
+// ErrUplink is returned by the forwarder mock while the uplink is down.
+var ErrUplink = errors.New("uplink is down")
+
+var _ bridge.Forwarder = (*Forwarder)(nil)
+
+// Forwarder is a bridge forwarder mock recording the forwarded messages.
+type Forwarder struct {
+	mu   sync.Mutex
+	down bool
+	// failAfter is the number of the messages forwarded before the uplink
+	// goes down, negative if the uplink stays up.
+	failAfter int
+	Msgs      []messaging.Message
+}
+
+// NewForwarder returns a recording bridge forwarder mock.
+func NewForwarder() *Forwarder {
+	return &Forwarder{failAfter: -1}
+}
+
+// SetDown sets whether the uplink is down.
+func (f *Forwarder) SetDown(down bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.down = down
+	f.failAfter = -1
+}
+
+// FailAfter brings the uplink down after n more messages are forwarded.
+func (f *Forwarder) FailAfter(n int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.failAfter = n
+}
+
+func (f *Forwarder) Forward(_ context.Context, msg messaging.Message) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.failAfter == 0 {
+		f.down = true
+		f.failAfter = -1
+	}
+	if f.down {
+		return ErrUplink
+	}
+	if f.failAfter > 0 {
+		f.failAfter--
+	}
+	f.Msgs = append(f.Msgs, msg)
+
+	return nil
+}
@@ -0,0 +1,194 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package bridge
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+)
+
+const rootSubject = "root"
+
+var (
+	// ErrBufferFull indicates that the buffer reached its maximum size.
+	ErrBufferFull = errors.New("buffer is full")
+
+	// ErrBuffer indicates failure to access the buffer.
+	ErrBuffer = errors.New("failed to access buffer")
+
+	// ErrForward indicates failure to forward the message to the central
+	// deployment.
+	ErrForward = errors.New("failed to forward message")
+)
+
+// Service specifies an API that must be fullfiled by the domain service
+// implementation, and all of its decorators (e.g. logging & metrics).
+type Service interface {
+	// Status retrieves the synchronization status. Only the root admin is
+	// allowed to view the status.
+	Status(ctx context.Context, token string) (Status, error)
+
+	// Sync forwards the buffered messages immediately and returns the
+	// resulting status. Only the root admin is allowed to sync.
+	Sync(ctx context.Context, token string) (Status, error)
+
+	// Store buffers the message received from the edge message broker.
+	Store(msg messaging.Message) error
+
+	// Forward forwards the buffered messages in the order they were
+	// stored, until the buffer is drained or forwarding fails.
+	Forward(ctx context.Context) error
+}
+
+var _ Service = (*bridgeService)(nil)
+
+type bridgeService struct {
+	auth      mainflux.AuthServiceClient
+	buffer    Buffer
+	forwarder Forwarder
+	batchSize int
+
+	// fwdMu serializes forwarding, so the messages are forwarded in order.
+	fwdMu  sync.Mutex
+	mu     sync.Mutex
+	status Status
+}
+
+// New instantiates the bridge service implementation. Messages are read
+// from the buffer in batches of the given size.
+func New(auth mainflux.AuthServiceClient, buffer Buffer, forwarder Forwarder, batchSize int) Service {
+	return &bridgeService{
+		auth:      auth,
+		buffer:    buffer,
+		forwarder: forwarder,
+		batchSize: batchSize,
+	}
+}
+
+func (bs *bridgeService) Status(ctx context.Context, token string) (Status, error) {
+	if err := bs.authorize(ctx, token); err != nil {
+		return Status{}, err
+	}
+
+	return bs.currentStatus()
+}
+
+func (bs *bridgeService) Sync(ctx context.Context, token string) (Status, error) {
+	if err := bs.authorize(ctx, token); err != nil {
+		return Status{}, err
+	}
+
+	err := bs.Forward(ctx)
+	st, sErr := bs.currentStatus()
+	if err != nil {
+		return st, err
+	}
+
+	return st, sErr
+}
+
+func (bs *bridgeService) Store(msg messaging.Message) error {
+	if err := bs.buffer.Append(msg); err != nil {
+		if err == ErrBufferFull {
+			bs.mu.Lock()
+			bs.status.Dropped++
+			bs.mu.Unlock()
+			return err
+		}
+		return errors.Wrap(ErrBuffer, err)
+	}
+
+	return nil
+}
+
+func (bs *bridgeService) Forward(ctx context.Context) error {
+	bs.fwdMu.Lock()
+	defer bs.fwdMu.Unlock()
+
+	for {
+		entries, err := bs.buffer.Peek(bs.batchSize)
+		if err != nil {
+			return errors.Wrap(ErrBuffer, err)
+		}
+		if len(entries) == 0 {
+			return nil
+		}
+
+		n, fwdErr := bs.forward(ctx, entries)
+		if n > 0 {
+			if err := bs.buffer.Ack(entries[n-1].Offset); err != nil {
+				return errors.Wrap(ErrBuffer, err)
+			}
+		}
+		bs.update(n, fwdErr)
+		if fwdErr != nil {
+			return errors.Wrap(ErrForward, fwdErr)
+		}
+	}
+}
+
+// forward forwards the entries in order and returns the number of the
+// forwarded entries, stopping at the first failure.
+func (bs *bridgeService) forward(ctx context.Context, entries []Entry) (int, error) {
+	for i, e := range entries {
+		if err := bs.forwarder.Forward(ctx, e.Message); err != nil {
+			return i, err
+		}
+	}
+
+	return len(entries), nil
+}
+
+func (bs *bridgeService) update(forwarded int, err error) {
+	bs.mu.Lock()
+	defer bs.mu.Unlock()
+
+	bs.status.Forwarded += uint64(forwarded)
+	if err != nil {
+		bs.status.Online = false
+		bs.status.LastError = err.Error()
+		return
+	}
+	bs.status.Online = true
+	bs.status.LastError = ""
+	bs.status.LastSyncAt = time.Now()
+}
+
+func (bs *bridgeService) currentStatus() (Status, error) {
+	bs.mu.Lock()
+	st := bs.status
+	bs.mu.Unlock()
+
+	st.Pending = bs.buffer.Len()
+	if st.Pending == 0 {
+		return st, nil
+	}
+
+	entries, err := bs.buffer.Peek(1)
+	if err != nil {
+		return Status{}, errors.Wrap(ErrBuffer, err)
+	}
+	if len(entries) > 0 {
+		st.Oldest = time.Unix(0, entries[0].Message.Created)
+	}
+
+	return st, nil
+}
+
+func (bs *bridgeService) authorize(ctx context.Context, token string) error {
+	req := &mainflux.AuthorizeReq{
+		Token:   token,
+		Subject: rootSubject,
+	}
+	if _, err := bs.auth.Authorize(ctx, req); err != nil {
+		return errors.Wrap(errors.ErrAuthorization, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,157 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package bridge_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/bridge"
+	bridgemocks "github.com/MainfluxLabs/mainflux/bridge/mocks"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+	"github.com/MainfluxLabs/mainflux/pkg/mocks"
+	"github.com/MainfluxLabs/mainflux/users"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	adminEmail = "admin@example.com"
+	userEmail  = "user@example.com"
+	password   = "password"
+	adminID    = "admin-id"
+	userID     = "user-id"
+	chanID     = "chan-id"
+	bufferSize = 10
+	batchSize  = 3
+)
+
+var usersList = []users.User{
+	{ID: adminID, Email: adminEmail, Password: password},
+	{ID: userID, Email: userEmail, Password: password},
+}
+
+func newService(fwd bridge.Forwarder) bridge.Service {
+	auth := mocks.NewAuthService(adminID, usersList)
+	return bridge.New(auth, bridgemocks.NewBuffer(bufferSize), fwd, batchSize)
+}
+
+func newMessages(n int) []messaging.Message {
+	now := time.Now()
+	var msgs []messaging.Message
+	for i := 0; i < n; i++ {
+		msgs = append(msgs, messaging.Message{
+			Channel: chanID,
+			Payload: []byte(fmt.Sprintf(`{"n":%d}`, i)),
+			Created: now.Add(time.Duration(i-n) * time.Minute).UnixNano(),
+		})
+	}
+
+	return msgs
+}
+
+func TestStore(t *testing.T) {
+	svc := newService(bridgemocks.NewForwarder())
+
+	for i, msg := range newMessages(bufferSize + 1) {
+		var want error
+		if i == bufferSize {
+			want = bridge.ErrBufferFull
+		}
+		err := svc.Store(msg)
+		assert.Equal(t, want, err, fmt.Sprintf("store message %d: expected %s got %s\n", i, want, err))
+	}
+
+	st, err := svc.Status(context.Background(), adminEmail)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.Equal(t, uint64(bufferSize), st.Pending, fmt.Sprintf("expected %d pending messages got %d\n", bufferSize, st.Pending))
+	assert.Equal(t, uint64(1), st.Dropped, fmt.Sprintf("expected 1 dropped message got %d\n", st.Dropped))
+}
+
+func TestForward(t *testing.T) {
+	msgs := newMessages(7)
+
+	cases := []struct {
+		desc      string
+		failAfter int
+		forwarded int
+		online    bool
+		err       error
+	}{
+		{
+			desc:      "forward all buffered messages",
+			failAfter: -1,
+			forwarded: 7,
+			online:    true,
+		},
+		{
+			desc:      "forward messages until uplink goes down",
+			failAfter: 4,
+			forwarded: 4,
+			online:    false,
+			err:       bridge.ErrForward,
+		},
+		{
+			desc:      "forward messages while uplink is down",
+			failAfter: 0,
+			forwarded: 0,
+			online:    false,
+			err:       bridge.ErrForward,
+		},
+	}
+
+	for _, tc := range cases {
+		fwd := bridgemocks.NewForwarder()
+		fwd.FailAfter(tc.failAfter)
+		svc := newService(fwd)
+		for _, msg := range msgs {
+			err := svc.Store(msg)
+			require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+		}
+
+		err := svc.Forward(context.Background())
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, msgs[:tc.forwarded], append([]messaging.Message{}, fwd.Msgs...), fmt.Sprintf("%s: expected messages forwarded in order\n", tc.desc))
+
+		st, err := svc.Status(context.Background(), adminEmail)
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+		assert.Equal(t, tc.online, st.Online, fmt.Sprintf("%s: expected online %t got %t\n", tc.desc, tc.online, st.Online))
+		assert.Equal(t, uint64(tc.forwarded), st.Forwarded, fmt.Sprintf("%s: expected %d forwarded messages got %d\n", tc.desc, tc.forwarded, st.Forwarded))
+		assert.Equal(t, uint64(len(msgs)-tc.forwarded), st.Pending, fmt.Sprintf("%s: expected %d pending messages got %d\n", tc.desc, len(msgs)-tc.forwarded, st.Pending))
+
+		if tc.forwarded < len(msgs) {
+			oldest := time.Unix(0, msgs[tc.forwarded].Created)
+			assert.Equal(t, oldest, st.Oldest, fmt.Sprintf("%s: expected oldest message created at %s got %s\n", tc.desc, oldest, st.Oldest))
+			assert.Equal(t, bridgemocks.ErrUplink.Error(), st.LastError, fmt.Sprintf("%s: expected last error %s got %s\n", tc.desc, bridgemocks.ErrUplink, st.LastError))
+		}
+	}
+}
+
+func TestSync(t *testing.T) {
+	fwd := bridgemocks.NewForwarder()
+	fwd.SetDown(true)
+	svc := newService(fwd)
+	msgs := newMessages(5)
+	for _, msg := range msgs {
+		err := svc.Store(msg)
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	}
+
+	_, err := svc.Sync(context.Background(), adminEmail)
+	assert.True(t, errors.Contains(err, bridge.ErrForward), fmt.Sprintf("sync while uplink is down: expected %s got %s\n", bridge.ErrForward, err))
+
+	_, err = svc.Sync(context.Background(), userEmail)
+	assert.True(t, errors.Contains(err, errors.ErrAuthorization), fmt.Sprintf("sync as non admin: expected %s got %s\n", errors.ErrAuthorization, err))
+
+	fwd.SetDown(false)
+	st, err := svc.Sync(context.Background(), adminEmail)
+	assert.Nil(t, err, fmt.Sprintf("sync after uplink is restored: unexpected error: %s", err))
+	assert.Equal(t, msgs, fwd.Msgs, "sync after uplink is restored: expected messages forwarded in order")
+	assert.True(t, st.Online, "sync after uplink is restored: expected online status")
+	assert.Equal(t, uint64(0), st.Pending, fmt.Sprintf("sync after uplink is restored: expected no pending messages got %d", st.Pending))
+	assert.Empty(t, st.LastError, "sync after uplink is restored: expected last error to be cleared")
+}
@@ -0,0 +1,57 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package bridge
+
+import (
+	"context"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+)
+
+// Protocol is the protocol of the cloud-to-device messages published by the
+// bridge. They are not forwarded back to the cloud.
+const Protocol = "bridge"
+
+var _ messaging.MessageHandler = (*handler)(nil)
+
+type handler struct {
+	svc Service
+}
+
+// NewHandler returns the message handler which stores the messages received
+// from the edge message broker in the buffer of the service, except the
+// messages published by the bridge itself.
+func NewHandler(svc Service) messaging.MessageHandler {
+	return &handler{svc: svc}
+}
+
+func (h *handler) Handle(msg messaging.Message) error {
+	if msg.Protocol == Protocol {
+		return nil
+	}
+
+	return h.svc.Store(msg)
+}
+
+func (h *handler) Cancel() error {
+	return nil
+}
+
+// Run forwards the buffered messages every interval, until the context is
+// canceled. Failed forwarding is retried in the next interval, so messages
+// are buffered while the uplink is down.
+func Run(ctx context.Context, svc Service, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			svc.Forward(ctx)
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
@@ -28,12 +28,13 @@ func NewLoggingMiddleware(svc certs.Service, logger log.Logger) certs.Service {
 
 func (lm *loggingMiddleware) IssueCert(ctx context.Context, token, thingID, ttl string, keyBits int, keyType string) (c certs.Cert, err error) {
 	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "issue_cert", "latency", time.Since(begin).String())
 		message := fmt.Sprintf("Method issue_cert for token: %s and thing: %s took %s to complete", token, thingID, time.Since(begin))
 		if err != nil {
-			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
 			return
 		}
-		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+		l.Info(fmt.Sprintf("%s without errors.", message))
 	}(time.Now())
 
 	return lm.svc.IssueCert(ctx, token, thingID, ttl, keyBits, keyType)
@@ -41,12 +42,13 @@ func (lm *loggingMiddleware) IssueCert(ctx context.Context, token, thingID, ttl
 
 func (lm *loggingMiddleware) ListCerts(ctx context.Context, token, thingID string, offset, limit uint64) (cp certs.Page, err error) {
 	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "list_certs", "latency", time.Since(begin).String())
 		message := fmt.Sprintf("Method list_certs for token: %s and thing id: %s took %s to complete", token, thingID, time.Since(begin))
 		if err != nil {
-			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
 			return
 		}
-		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+		l.Info(fmt.Sprintf("%s without errors.", message))
 	}(time.Now())
 
 	return lm.svc.ListCerts(ctx, token, thingID, offset, limit)
@@ -54,12 +56,13 @@ func (lm *loggingMiddleware) ListCerts(ctx context.Context, token, thingID strin
 
 func (lm *loggingMiddleware) ListSerials(ctx context.Context, token, thingID string, offset, limit uint64) (cp certs.Page, err error) {
 	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "list_serials", "latency", time.Since(begin).String())
 		message := fmt.Sprintf("Method list_serials for token: %s and thing id: %s took %s to complete", token, thingID, time.Since(begin))
 		if err != nil {
-			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
 			return
 		}
-		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+		l.Info(fmt.Sprintf("%s without errors.", message))
 	}(time.Now())
 
 	return lm.svc.ListSerials(ctx, token, thingID, offset, limit)
@@ -67,12 +70,13 @@ func (lm *loggingMiddleware) ListSerials(ctx context.Context, token, thingID str
 
 func (lm *loggingMiddleware) ViewCert(ctx context.Context, token, serialID string) (c certs.Cert, err error) {
 	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "view_cert", "latency", time.Since(begin).String())
 		message := fmt.Sprintf("Method view_cert for token: %s and serial id %s took %s to complete", token, serialID, time.Since(begin))
 		if err != nil {
-			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
 			return
 		}
-		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+		l.Info(fmt.Sprintf("%s without errors.", message))
 	}(time.Now())
 
 	return lm.svc.ViewCert(ctx, token, serialID)
@@ -80,13 +84,28 @@ func (lm *loggingMiddleware) ViewCert(ctx context.Context, token, serialID strin
 
 func (lm *loggingMiddleware) RevokeCert(ctx context.Context, token, thingID string) (c certs.Revoke, err error) {
 	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "revoke_cert", "latency", time.Since(begin).String())
 		message := fmt.Sprintf("Method revoke_cert for token: %s and thing: %s took %s to complete", token, thingID, time.Since(begin))
 		if err != nil {
-			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
 			return
 		}
-		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+		l.Info(fmt.Sprintf("%s without errors.", message))
 	}(time.Now())
 
 	return lm.svc.RevokeCert(ctx, token, thingID)
 }
+
+func (lm *loggingMiddleware) RevokeThingCertsHandler(ctx context.Context, ownerID, thingID string) (err error) {
+	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "revoke_thing_certs_handler", "latency", time.Since(begin).String())
+		message := fmt.Sprintf("Method revoke_thing_certs_handler for owner: %s and thing: %s took %s to complete", ownerID, thingID, time.Since(begin))
+		if err != nil {
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		l.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.RevokeThingCertsHandler(ctx, ownerID, thingID)
+}
@@ -74,3 +74,12 @@ func (ms *metricsMiddleware) RevokeCert(ctx context.Context, token, thingID stri
 
 	return ms.svc.RevokeCert(ctx, token, thingID)
 }
+
+func (ms *metricsMiddleware) RevokeThingCertsHandler(ctx context.Context, ownerID, thingID string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "revoke_thing_certs_handler").Add(1)
+		ms.latency.With("method", "revoke_thing_certs_handler").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.RevokeThingCertsHandler(ctx, ownerID, thingID)
+}
@@ -27,7 +27,7 @@ const (
 )
 
 // MakeHandler returns a HTTP handler for API endpoints.
-func MakeHandler(svc certs.Service, logger logger.Logger) http.Handler {
+func MakeHandler(svc certs.Service, logger logger.Logger, checks ...mainflux.HealthCheck) http.Handler {
 	opts := []kithttp.ServerOption{
 		kithttp.ServerErrorEncoder(apiutil.LoggingErrorEncoder(logger, encodeError)),
 	}
@@ -63,9 +63,10 @@ func MakeHandler(svc certs.Service, logger logger.Logger) http.Handler {
 	))
 
 	r.Handle("/metrics", promhttp.Handler())
-	r.GetFunc("/health", mainflux.Health("certs"))
+	r.Handle("/log-level", mainflux.LogLevel(logger))
+	r.GetFunc("/health", mainflux.Health("certs", checks...))
 
-	return r
+	return apiutil.RequestIDMiddleware(apiutil.LocaleMiddleware(r))
 }
 
 func encodeResponse(_ context.Context, w http.ResponseWriter, response interface{}) error {
@@ -136,7 +137,7 @@ func decodeRevokeCerts(_ context.Context, r *http.Request) (interface{}, error)
 	return req, nil
 }
 
-func encodeError(_ context.Context, err error, w http.ResponseWriter) {
+func encodeError(ctx context.Context, err error, w http.ResponseWriter) {
 	switch {
 	case errors.Contains(err, errors.ErrAuthentication),
 		err == apiutil.ErrBearerToken:
@@ -162,7 +163,7 @@ func encodeError(_ context.Context, err error, w http.ResponseWriter) {
 
 	if errorVal, ok := err.(errors.Error); ok {
 		w.Header().Set("Content-Type", contentType)
-		if err := json.NewEncoder(w).Encode(apiutil.ErrorRes{Err: errorVal.Msg()}); err != nil {
+		if err := json.NewEncoder(w).Encode(apiutil.NewErrorRes(ctx, errorVal)); err != nil {
 			w.WriteHeader(http.StatusInternalServerError)
 		}
 	}
@@ -92,7 +92,15 @@ func (c *certsRepoMock) Remove(ctx context.Context, ownerID, serial string) erro
 		return errors.ErrNotFound
 	}
 	delete(c.certsBySerial, crt.Serial)
-	delete(c.certsByThingID, crt.ThingID)
+
+	thingCerts := c.certsByThingID[crt.OwnerID][crt.ThingID]
+	for i, tc := range thingCerts {
+		if tc.Serial == serial {
+			thingCerts = append(thingCerts[:i], thingCerts[i+1:]...)
+			break
+		}
+	}
+	c.certsByThingID[crt.OwnerID][crt.ThingID] = thingCerts
 	return nil
 }
 
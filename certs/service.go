@@ -44,6 +44,10 @@ type Service interface {
 
 	// RevokeCert revokes a certificate for a given serial ID
 	RevokeCert(ctx context.Context, token, serialID string) (Revoke, error)
+
+	// RevokeThingCertsHandler revokes certificates of the removed thing
+	// received from an event. That's why it surpasses the ownership check.
+	RevokeThingCertsHandler(ctx context.Context, ownerID, thingID string) error
 }
 
 // Config defines the service parameters
@@ -148,25 +152,41 @@ func (cs *certsService) RevokeCert(ctx context.Context, token, thingID string) (
 		return revoke, errors.Wrap(ErrFailedCertRevocation, err)
 	}
 
+	revoke.RevocationTime, err = cs.revokeThingCerts(ctx, u.GetId(), thing.ID)
+	return revoke, err
+}
+
+func (cs *certsService) RevokeThingCertsHandler(ctx context.Context, ownerID, thingID string) error {
+	// The removed thing may not have any certificates.
+	if _, err := cs.revokeThingCerts(ctx, ownerID, thingID); err != nil && !errors.Contains(err, errors.ErrNotFound) {
+		return err
+	}
+
+	return nil
+}
+
+// revokeThingCerts revokes all certificates of the thing and returns
+// the revocation time of the last one.
+func (cs *certsService) revokeThingCerts(ctx context.Context, ownerID, thingID string) (time.Time, error) {
 	// TODO: Replace offset and limit
 	offset, limit := uint64(0), uint64(10000)
-	cp, err := cs.certsRepo.RetrieveByThing(ctx, u.GetId(), thing.ID, offset, limit)
+	cp, err := cs.certsRepo.RetrieveByThing(ctx, ownerID, thingID, offset, limit)
 	if err != nil {
-		return revoke, errors.Wrap(ErrFailedCertRevocation, err)
+		return time.Time{}, errors.Wrap(ErrFailedCertRevocation, err)
 	}
 
+	var revTime time.Time
 	for _, c := range cp.Certs {
-		revTime, err := cs.pki.Revoke(c.Serial)
+		revTime, err = cs.pki.Revoke(c.Serial)
 		if err != nil {
-			return revoke, errors.Wrap(ErrFailedCertRevocation, err)
+			return time.Time{}, errors.Wrap(ErrFailedCertRevocation, err)
 		}
-		revoke.RevocationTime = revTime
-		if err = cs.certsRepo.Remove(context.Background(), u.GetId(), c.Serial); err != nil {
-			return revoke, errors.Wrap(errFailedToRemoveCertFromDB, err)
+		if err = cs.certsRepo.Remove(context.Background(), ownerID, c.Serial); err != nil {
+			return time.Time{}, errors.Wrap(errFailedToRemoveCertFromDB, err)
 		}
 	}
 
-	return revoke, nil
+	return revTime, nil
 }
 
 func (cs *certsService) ListCerts(ctx context.Context, token, thingID string, offset, limit uint64) (Page, error) {
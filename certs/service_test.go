@@ -228,6 +228,47 @@ func TestRevokeCert(t *testing.T) {
 
 }
 
+func TestRevokeThingCertsHandler(t *testing.T) {
+	svc, err := newService()
+	require.Nil(t, err, fmt.Sprintf("unexpected service creation error: %s\n", err))
+
+	for i := 0; i < certNum; i++ {
+		_, err = svc.IssueCert(context.Background(), token, thingID, ttl, keyBits, key)
+		require.Nil(t, err, fmt.Sprintf("unexpected cert creation error: %s\n", err))
+	}
+
+	cases := []struct {
+		desc    string
+		thingID string
+		err     error
+	}{
+		{
+			desc:    "revoke certs of the removed thing",
+			thingID: thingID,
+			err:     nil,
+		},
+		{
+			desc:    "revoke certs of the thing with revoked certs",
+			thingID: thingID,
+			err:     nil,
+		},
+		{
+			desc:    "revoke certs of the thing without certs",
+			thingID: "2",
+			err:     nil,
+		},
+	}
+
+	for _, tc := range cases {
+		err := svc.RevokeThingCertsHandler(context.Background(), usersList[0].ID, tc.thingID)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+
+	page, err := svc.ListCerts(context.Background(), token, thingID, 0, certNum)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	assert.Equal(t, 0, len(page.Certs), fmt.Sprintf("expected no certs got %d\n", len(page.Certs)))
+}
+
 func TestListCerts(t *testing.T) {
 	svc, err := newService()
 	require.Nil(t, err, fmt.Sprintf("unexpected service creation error: %s\n", err))
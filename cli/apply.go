@@ -0,0 +1,530 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"reflect"
+
+	mfxsdk "github.com/MainfluxLabs/mainflux/pkg/sdk/go"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	pageLimit  = 100
+	profileKey = "profile"
+
+	actionCreate = "+"
+	actionUpdate = "~"
+	actionDelete = "-"
+)
+
+var (
+	manifestPath string
+	dryRun       bool
+	prune        bool
+)
+
+// manifest declares the topology of things, channels, groups and their
+// connections. Entities are matched with the existing ones by name.
+type manifest struct {
+	Things      []thingManifest      `yaml:"things"`
+	Channels    []channelManifest    `yaml:"channels"`
+	Groups      []groupManifest      `yaml:"groups"`
+	Connections []connectionManifest `yaml:"connections"`
+}
+
+type thingManifest struct {
+	Name     string                 `yaml:"name"`
+	Metadata map[string]interface{} `yaml:"metadata"`
+}
+
+type channelManifest struct {
+	Name     string                 `yaml:"name"`
+	Profile  map[string]interface{} `yaml:"profile"`
+	Metadata map[string]interface{} `yaml:"metadata"`
+}
+
+type groupManifest struct {
+	Name        string                 `yaml:"name"`
+	Description string                 `yaml:"description"`
+	Metadata    map[string]interface{} `yaml:"metadata"`
+	Things      []string               `yaml:"things"`
+	Channels    []string               `yaml:"channels"`
+}
+
+type connectionManifest struct {
+	Channel string   `yaml:"channel"`
+	Things  []string `yaml:"things"`
+}
+
+// change is a single step of reconciling the platform with the manifest.
+type change struct {
+	action string
+	desc   string
+	apply  func() error
+}
+
+// applier plans the changes, resolving the IDs of the entities created
+// while the changes are applied by their names.
+type applier struct {
+	token    string
+	things   map[string]string
+	channels map[string]string
+	groups   map[string]string
+}
+
+// NewApplyCmd returns apply command.
+func NewApplyCmd() *cobra.Command {
+	cmd := cobra.Command{
+		Use:   "apply -f <manifest_file> <user_token>",
+		Short: "Apply topology manifest",
+		Long: `Reconciles the things, channels, groups and connections declared in the YAML manifest
+with the platform. The planned changes are printed before they are applied.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 1 || manifestPath == "" {
+				logUsage(cmd.Use)
+				return
+			}
+
+			m, err := readManifest(manifestPath)
+			if err != nil {
+				logError(err)
+				return
+			}
+
+			a := applier{
+				token:    args[0],
+				things:   map[string]string{},
+				channels: map[string]string{},
+				groups:   map[string]string{},
+			}
+			changes, err := a.plan(m)
+			if err != nil {
+				logError(err)
+				return
+			}
+
+			logChanges(changes)
+			if dryRun || len(changes) == 0 {
+				return
+			}
+
+			for _, c := range changes {
+				if err := c.apply(); err != nil {
+					logError(fmt.Errorf("%s %s: %w", c.action, c.desc, err))
+					return
+				}
+			}
+
+			logOK()
+		},
+	}
+
+	cmd.Flags().StringVarP(&manifestPath, "file", "f", "", "topology manifest file")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "only print the planned changes")
+	cmd.Flags().BoolVar(&prune, "prune", false, "delete the things, channels and groups, and remove the connections and group members not declared in the manifest")
+
+	return &cmd
+}
+
+func readManifest(path string) (manifest, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return manifest{}, err
+	}
+
+	var m manifest
+	if err := yaml.Unmarshal(data, &m); err != nil {
+		return manifest{}, err
+	}
+
+	return m, nil
+}
+
+func logChanges(changes []change) {
+	if len(changes) == 0 {
+		fmt.Printf("\n%s\n\n", color.BlueString("no changes"))
+		return
+	}
+
+	fmt.Println()
+	for _, c := range changes {
+		line := fmt.Sprintf("%s %s", c.action, c.desc)
+		switch c.action {
+		case actionCreate:
+			fmt.Println(color.GreenString(line))
+		case actionUpdate:
+			fmt.Println(color.YellowString(line))
+		case actionDelete:
+			fmt.Println(color.RedString(line))
+		}
+	}
+	fmt.Println()
+}
+
+// plan returns the changes in the order they are applied: entities are
+// created and updated first, followed by the group members and the
+// connections, while the pruned entities are deleted last.
+func (a *applier) plan(m manifest) ([]change, error) {
+	things, err := a.listThings()
+	if err != nil {
+		return nil, err
+	}
+	channels, err := a.listChannels()
+	if err != nil {
+		return nil, err
+	}
+	groups, err := a.listGroups()
+	if err != nil {
+		return nil, err
+	}
+
+	var changes, deletes []change
+	declared := map[string]bool{}
+	for _, tm := range m.Things {
+		declared[tm.Name] = true
+		t := mfxsdk.Thing{Name: tm.Name, Metadata: tm.Metadata}
+		cur, ok := things[tm.Name]
+		switch {
+		case !ok:
+			changes = append(changes, change{actionCreate, "thing " + tm.Name, func() error {
+				id, err := sdk.CreateThing(t, a.token)
+				a.things[t.Name] = id
+				return err
+			}})
+		case !equalJSON(cur.Metadata, t.Metadata):
+			t.ID = cur.ID
+			changes = append(changes, change{actionUpdate, "thing " + tm.Name, func() error {
+				return sdk.UpdateThing(t, a.token)
+			}})
+		}
+	}
+	for name, t := range things {
+		if prune && !declared[name] {
+			id := t.ID
+			deletes = append(deletes, change{actionDelete, "thing " + name, func() error {
+				return sdk.DeleteThing(id, a.token)
+			}})
+		}
+	}
+
+	declared = map[string]bool{}
+	for _, cm := range m.Channels {
+		declared[cm.Name] = true
+		ch := mfxsdk.Channel{Name: cm.Name, Metadata: channelMetadata(cm)}
+		cur, ok := channels[cm.Name]
+		switch {
+		case !ok:
+			changes = append(changes, change{actionCreate, "channel " + cm.Name, func() error {
+				id, err := sdk.CreateChannel(ch, a.token)
+				a.channels[ch.Name] = id
+				return err
+			}})
+		case !equalJSON(cur.Metadata, ch.Metadata):
+			ch.ID = cur.ID
+			changes = append(changes, change{actionUpdate, "channel " + cm.Name, func() error {
+				return sdk.UpdateChannel(ch, a.token)
+			}})
+		}
+	}
+	for name, ch := range channels {
+		if prune && !declared[name] {
+			id := ch.ID
+			deletes = append(deletes, change{actionDelete, "channel " + name, func() error {
+				return sdk.DeleteChannel(id, a.token)
+			}})
+		}
+	}
+
+	declared = map[string]bool{}
+	var members []change
+	for _, gm := range m.Groups {
+		declared[gm.Name] = true
+		g := mfxsdk.Group{Name: gm.Name, Description: gm.Description, Metadata: gm.Metadata}
+		cur, ok := groups[gm.Name]
+		switch {
+		case !ok:
+			changes = append(changes, change{actionCreate, "group " + gm.Name, func() error {
+				id, err := sdk.CreateGroup(g, a.token)
+				a.groups[g.Name] = id
+				return err
+			}})
+		case cur.Description != g.Description || !equalJSON(cur.Metadata, g.Metadata):
+			g.ID = cur.ID
+			changes = append(changes, change{actionUpdate, "group " + gm.Name, func() error {
+				return sdk.UpdateGroup(g, a.token)
+			}})
+		}
+
+		ms, err := a.planMembers(gm, cur.ID)
+		if err != nil {
+			return nil, err
+		}
+		members = append(members, ms...)
+	}
+	for name, g := range groups {
+		if prune && !declared[name] {
+			id := g.ID
+			deletes = append(deletes, change{actionDelete, "group " + name, func() error {
+				return sdk.DeleteGroup(id, a.token)
+			}})
+		}
+	}
+	changes = append(changes, members...)
+
+	for _, cm := range m.Connections {
+		cs, err := a.planConnections(cm)
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, cs...)
+	}
+
+	return append(changes, deletes...), nil
+}
+
+// planMembers plans the assignment of the things and channels to the group.
+func (a *applier) planMembers(gm groupManifest, groupID string) ([]change, error) {
+	var curThings, curChannels []string
+	if groupID != "" {
+		var err error
+		if curThings, err = a.groupThings(groupID); err != nil {
+			return nil, err
+		}
+		if curChannels, err = a.groupChannels(groupID); err != nil {
+			return nil, err
+		}
+	}
+
+	var changes []change
+	add, remove := a.diffMembers(gm.Things, curThings, a.things)
+	for _, name := range add {
+		name := name
+		changes = append(changes, change{actionCreate, fmt.Sprintf("group %s thing %s", gm.Name, name), func() error {
+			return sdk.AssignThing([]string{a.things[name]}, a.groups[gm.Name], a.token)
+		}})
+	}
+	for _, id := range remove {
+		id := id
+		changes = append(changes, change{actionDelete, fmt.Sprintf("group %s thing %s", gm.Name, a.name(a.things, id)), func() error {
+			return sdk.UnassignThing(a.token, a.groups[gm.Name], id)
+		}})
+	}
+
+	add, remove = a.diffMembers(gm.Channels, curChannels, a.channels)
+	for _, name := range add {
+		name := name
+		changes = append(changes, change{actionCreate, fmt.Sprintf("group %s channel %s", gm.Name, name), func() error {
+			return sdk.AssignChannel([]string{a.channels[name]}, a.groups[gm.Name], a.token)
+		}})
+	}
+	for _, id := range remove {
+		id := id
+		changes = append(changes, change{actionDelete, fmt.Sprintf("group %s channel %s", gm.Name, a.name(a.channels, id)), func() error {
+			return sdk.UnassignChannel(a.token, a.groups[gm.Name], id)
+		}})
+	}
+
+	return changes, nil
+}
+
+// planConnections plans the connection of the things to the channel.
+func (a *applier) planConnections(cm connectionManifest) ([]change, error) {
+	var cur []string
+	if chanID, ok := a.channels[cm.Channel]; ok {
+		for offset := uint64(0); ; offset += pageLimit {
+			tp, err := sdk.ThingsByChannel(a.token, chanID, offset, pageLimit, false)
+			if err != nil {
+				return nil, err
+			}
+			for _, t := range tp.Things {
+				cur = append(cur, t.ID)
+			}
+			if offset+pageLimit >= tp.Total {
+				break
+			}
+		}
+	}
+
+	var changes []change
+	add, remove := a.diffMembers(cm.Things, cur, a.things)
+	for _, name := range add {
+		name := name
+		changes = append(changes, change{actionCreate, fmt.Sprintf("connection %s %s", cm.Channel, name), func() error {
+			return sdk.Connect(mfxsdk.ConnectionIDs{ChannelID: a.channels[cm.Channel], ThingIDs: []string{a.things[name]}}, a.token)
+		}})
+	}
+	for _, id := range remove {
+		id := id
+		changes = append(changes, change{actionDelete, fmt.Sprintf("connection %s %s", cm.Channel, a.name(a.things, id)), func() error {
+			return sdk.Disconnect(mfxsdk.ConnectionIDs{ChannelID: a.channels[cm.Channel], ThingIDs: []string{id}}, a.token)
+		}})
+	}
+
+	return changes, nil
+}
+
+// diffMembers returns the names of the declared members which aren't among
+// the current member IDs, and the IDs of the undeclared current members if
+// the pruning is enabled.
+func (a *applier) diffMembers(names, curIDs []string, ids map[string]string) ([]string, []string) {
+	cur := map[string]bool{}
+	for _, id := range curIDs {
+		cur[id] = true
+	}
+
+	var add []string
+	declared := map[string]bool{}
+	for _, name := range names {
+		id, ok := ids[name]
+		declared[id] = ok
+		if !ok || !cur[id] {
+			add = append(add, name)
+		}
+	}
+
+	var remove []string
+	if prune {
+		for _, id := range curIDs {
+			if !declared[id] {
+				remove = append(remove, id)
+			}
+		}
+	}
+
+	return add, remove
+}
+
+func (a *applier) name(ids map[string]string, id string) string {
+	for name, i := range ids {
+		if i == id {
+			return name
+		}
+	}
+	return id
+}
+
+func (a *applier) listThings() (map[string]mfxsdk.Thing, error) {
+	things := map[string]mfxsdk.Thing{}
+	for offset := uint64(0); ; offset += pageLimit {
+		tp, err := sdk.Things(a.token, mfxsdk.PageMetadata{Offset: offset, Limit: pageLimit})
+		if err != nil {
+			return nil, err
+		}
+		for _, t := range tp.Things {
+			things[t.Name] = t
+			a.things[t.Name] = t.ID
+		}
+		if offset+pageLimit >= tp.Total {
+			return things, nil
+		}
+	}
+}
+
+func (a *applier) listChannels() (map[string]mfxsdk.Channel, error) {
+	channels := map[string]mfxsdk.Channel{}
+	for offset := uint64(0); ; offset += pageLimit {
+		cp, err := sdk.Channels(a.token, mfxsdk.PageMetadata{Offset: offset, Limit: pageLimit})
+		if err != nil {
+			return nil, err
+		}
+		for _, ch := range cp.Channels {
+			channels[ch.Name] = ch
+			a.channels[ch.Name] = ch.ID
+		}
+		if offset+pageLimit >= cp.Total {
+			return channels, nil
+		}
+	}
+}
+
+func (a *applier) listGroups() (map[string]mfxsdk.Group, error) {
+	groups := map[string]mfxsdk.Group{}
+	for offset := uint64(0); ; offset += pageLimit {
+		gp, err := sdk.Groups(mfxsdk.PageMetadata{Offset: offset, Limit: pageLimit}, a.token)
+		if err != nil {
+			return nil, err
+		}
+		for _, g := range gp.Groups {
+			groups[g.Name] = g
+			a.groups[g.Name] = g.ID
+		}
+		if offset+pageLimit >= gp.Total {
+			return groups, nil
+		}
+	}
+}
+
+func (a *applier) groupThings(groupID string) ([]string, error) {
+	var ids []string
+	for offset := uint64(0); ; offset += pageLimit {
+		gp, err := sdk.ListGroupThings(groupID, a.token, offset, pageLimit)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, gp.Things...)
+		if offset+pageLimit >= gp.Total {
+			return ids, nil
+		}
+	}
+}
+
+func (a *applier) groupChannels(groupID string) ([]string, error) {
+	var ids []string
+	for offset := uint64(0); ; offset += pageLimit {
+		gp, err := sdk.ListGroupChannels(groupID, a.token, offset, pageLimit)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, gp.Channels...)
+		if offset+pageLimit >= gp.Total {
+			return ids, nil
+		}
+	}
+}
+
+// channelMetadata returns the channel metadata with the channel profile
+// stored under the profile key.
+func channelMetadata(cm channelManifest) map[string]interface{} {
+	if len(cm.Profile) == 0 {
+		return cm.Metadata
+	}
+
+	md := map[string]interface{}{}
+	for k, v := range cm.Metadata {
+		md[k] = v
+	}
+	md[profileKey] = cm.Profile
+
+	return md
+}
+
+// equalJSON compares the values by their JSON representation, so the
+// numbers decoded from the manifest and from the API responses are equal.
+func equalJSON(a, b map[string]interface{}) bool {
+	if len(a) == 0 && len(b) == 0 {
+		return true
+	}
+
+	var va, vb interface{}
+	for _, p := range []struct {
+		src map[string]interface{}
+		dst *interface{}
+	}{{a, &va}, {b, &vb}} {
+		data, err := json.Marshal(p.src)
+		if err != nil {
+			return false
+		}
+		if err := json.Unmarshal(data, p.dst); err != nil {
+			return false
+		}
+	}
+
+	return reflect.DeepEqual(va, vb)
+}
@@ -48,13 +48,14 @@ var cmdMessages = []cobra.Command{
 // NewMessagesCmd returns messages command.
 func NewMessagesCmd() *cobra.Command {
 	cmd := cobra.Command{
-		Use:   "messages [send | read]",
-		Short: "Send or read messages",
-		Long:  `Send or read messages using the http-adapter and the configured database reader`,
+		Use:   "messages [send | read | tail]",
+		Short: "Send, read or tail messages",
+		Long:  `Send, read or tail messages using the http-adapter, the configured database reader and the ws-adapter`,
 	}
 
-	for i := range cmdMessages {
-		cmd.AddCommand(&cmdMessages[i])
+	cmds := append(cmdMessages, newTailCmd())
+	for i := range cmds {
+		cmd.AddCommand(&cmds[i])
 	}
 
 	return &cmd
@@ -0,0 +1,127 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/fatih/color"
+	prettyjson "github.com/hokaccha/go-prettyjson"
+	"github.com/spf13/cobra"
+)
+
+const anyElement = "*"
+
+// Filter JSON path of the streamed messages
+var filter string
+
+func newTailCmd() cobra.Command {
+	cmd := cobra.Command{
+		Use:   "tail <channel_id.subtopic> <thing_key>",
+		Short: "Tail messages",
+		Long:  `Streams live channel messages using the ws-adapter until interrupted`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 2 {
+				logUsage(cmd.Use)
+				return
+			}
+
+			ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+			defer cancel()
+
+			path := parsePath(filter)
+			if err := sdk.SubscribeMessages(ctx, args[0], args[1], func(payload []byte) {
+				logMessage(payload, path)
+			}); err != nil {
+				logError(err)
+			}
+		},
+	}
+
+	cmd.Flags().StringVar(&filter, "filter", "", `JSON path of the printed message values, e.g. "*.v" for the values of all SenML records`)
+
+	return cmd
+}
+
+// logMessage prints the message payload, or the values selected by the path,
+// prefixed by the time of receiving.
+func logMessage(payload []byte, path []string) {
+	ts := time.Now().Format("15:04:05.000")
+
+	var v interface{}
+	if err := json.Unmarshal(payload, &v); err != nil {
+		if len(path) > 0 {
+			return
+		}
+		fmt.Printf("%s %s\n", color.CyanString(ts), color.YellowString(string(payload)))
+		return
+	}
+
+	if len(path) > 0 {
+		vals := selectPath(v, path)
+		if len(vals) == 0 {
+			return
+		}
+		v = vals
+	}
+
+	var out []byte
+	var err error
+	switch RawOutput {
+	case true:
+		out, err = json.Marshal(v)
+	default:
+		out, err = prettyjson.Marshal(v)
+	}
+	if err != nil {
+		logError(err)
+		return
+	}
+
+	fmt.Printf("%s %s\n", color.CyanString(ts), string(out))
+}
+
+func parsePath(p string) []string {
+	if p == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimPrefix(p, "$."), ".")
+}
+
+// selectPath returns the values of the JSON value selected by the path of
+// object keys and array indexes. The "*" token selects all array elements.
+func selectPath(v interface{}, path []string) []interface{} {
+	if len(path) == 0 {
+		return []interface{}{v}
+	}
+
+	token, rest := path[0], path[1:]
+	switch val := v.(type) {
+	case map[string]interface{}:
+		if next, ok := val[token]; ok {
+			return selectPath(next, rest)
+		}
+	case []interface{}:
+		if token == anyElement {
+			var vals []interface{}
+			for _, e := range val {
+				vals = append(vals, selectPath(e, rest)...)
+			}
+			return vals
+		}
+		if i, err := strconv.Atoi(token); err == nil && i >= 0 && i < len(val) {
+			return selectPath(val[i], rest)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,336 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux"
+	"github.com/MainfluxLabs/mainflux/consumers"
+	"github.com/MainfluxLabs/mainflux/consumers/writers/api"
+	"github.com/MainfluxLabs/mainflux/consumers/writers/archiver"
+	"github.com/MainfluxLabs/mainflux/logger"
+	"github.com/MainfluxLabs/mainflux/pkg/archive"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging/brokers"
+	mfnats "github.com/MainfluxLabs/mainflux/pkg/messaging/nats"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging/profile"
+	thingsapi "github.com/MainfluxLabs/mainflux/things/api/auth/grpc"
+	kitprometheus "github.com/go-kit/kit/metrics/prometheus"
+	opentracing "github.com/opentracing/opentracing-go"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+	jconfig "github.com/uber/jaeger-client-go/config"
+	"golang.org/x/sync/errgroup"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+const (
+	svcName      = "archiver"
+	stopWaitTime = 5 * time.Second
+
+	defLogLevel      = "error"
+	defBrokerURL     = "nats://localhost:4222"
+	defPort          = "8195"
+	defConfigPath    = "/config.toml"
+	defPrefix        = "messages/"
+	defBatchSize     = "10000"
+	defFlushInterval = "5m"
+	defS3Endpoint    = "http://localhost:9000"
+	defS3Region      = "us-east-1"
+	defS3Bucket      = "mainflux"
+	defS3AccessKey   = ""
+	defS3SecretKey   = ""
+	defS3Timeout     = "30s"
+
+	envBrokerURL     = "MF_BROKER_URL"
+	envLogLevel      = "MF_ARCHIVER_LOG_LEVEL"
+	envPort          = "MF_ARCHIVER_PORT"
+	envConfigPath    = "MF_ARCHIVER_CONFIG_PATH"
+	envPrefix        = "MF_ARCHIVER_PREFIX"
+	envBatchSize     = "MF_ARCHIVER_BATCH_SIZE"
+	envFlushInterval = "MF_ARCHIVER_FLUSH_INTERVAL"
+	envS3Endpoint    = "MF_ARCHIVER_S3_ENDPOINT"
+	envS3Region      = "MF_ARCHIVER_S3_REGION"
+	envS3Bucket      = "MF_ARCHIVER_S3_BUCKET"
+	envS3AccessKey   = "MF_ARCHIVER_S3_ACCESS_KEY"
+	envS3SecretKey   = "MF_ARCHIVER_S3_SECRET_KEY"
+	envS3Timeout     = "MF_ARCHIVER_S3_TIMEOUT"
+
+	defClientTLS         = "false"
+	defCACerts           = ""
+	defJaegerURL         = ""
+	defThingsGRPCURL     = "localhost:8183"
+	defThingsGRPCTimeout = "1s"
+
+	envClientTLS         = "MF_ARCHIVER_CLIENT_TLS"
+	envCACerts           = "MF_ARCHIVER_CA_CERTS"
+	envJaegerURL         = "MF_JAEGER_URL"
+	envThingsGRPCURL     = "MF_THINGS_AUTH_GRPC_URL"
+	envThingsGRPCTimeout = "MF_THINGS_AUTH_GRPC_TIMEOUT"
+)
+
+type config struct {
+	brokerURL     string
+	jetStream     *brokers.JetStreamConfig
+	logLevel      string
+	port          string
+	configPath    string
+	archiver      archiver.Config
+	flushInterval time.Duration
+	s3            archive.S3Config
+	s3Timeout     time.Duration
+
+	clientTLS         bool
+	caCerts           string
+	jaegerURL         string
+	thingsGRPCURL     string
+	thingsGRPCTimeout time.Duration
+}
+
+func main() {
+	cfg := loadConfig()
+	ctx, cancel := context.WithCancel(context.Background())
+	g, ctx := errgroup.WithContext(ctx)
+
+	logger, err := logger.New(os.Stdout, cfg.logLevel)
+	if err != nil {
+		log.Fatalf(err.Error())
+	}
+
+	pubSub, err := connectToBroker(cfg, logger)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to connect to message broker: %s", err))
+		os.Exit(1)
+	}
+	defer pubSub.Close()
+
+	storage := archive.NewS3Storage(cfg.s3, &http.Client{Timeout: cfg.s3Timeout})
+	arch := archiver.New(storage, cfg.archiver)
+	repo := newService(arch, logger)
+
+	conn := connectToThings(cfg, logger)
+	defer conn.Close()
+
+	thingsTracer, thingsCloser := initJaeger("things", cfg.jaegerURL, logger)
+	defer thingsCloser.Close()
+
+	profiles := consumers.NewProfiles(thingsapi.NewClient(conn, thingsTracer, cfg.thingsGRPCTimeout))
+
+	if err = consumers.Start(svcName, pubSub, repo, profiles, cfg.configPath, logger); err != nil {
+		logger.Error(fmt.Sprintf("Failed to create archiver: %s", err))
+		os.Exit(1)
+	}
+
+	g.Go(func() error {
+		return archiver.StartFlusher(ctx, arch, cfg.flushInterval, logger)
+	})
+
+	checks := []mainflux.HealthCheck{
+		messaging.HealthCheck(pubSub),
+	}
+
+	g.Go(func() error {
+		return startHTTPServer(ctx, cfg.port, logger, checks)
+	})
+
+	g.Go(func() error {
+		if sig := errors.SignalHandler(ctx); sig != nil {
+			cancel()
+			logger.Info(fmt.Sprintf("Archiver service shutdown by signal: %s", sig))
+		}
+		return nil
+	})
+
+	if err := g.Wait(); err != nil {
+		logger.Error(fmt.Sprintf("Archiver service terminated: %s", err))
+	}
+}
+
+func loadConfig() config {
+	batchSize, err := strconv.Atoi(mainflux.Env(envBatchSize, defBatchSize))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envBatchSize, err.Error())
+	}
+
+	flushInterval, err := time.ParseDuration(mainflux.Env(envFlushInterval, defFlushInterval))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envFlushInterval, err.Error())
+	}
+
+	s3Timeout, err := time.ParseDuration(mainflux.Env(envS3Timeout, defS3Timeout))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envS3Timeout, err.Error())
+	}
+
+	tls, err := strconv.ParseBool(mainflux.Env(envClientTLS, defClientTLS))
+	if err != nil {
+		log.Fatalf("Invalid value passed for %s\n", envClientTLS)
+	}
+
+	thingsGRPCTimeout, err := time.ParseDuration(mainflux.Env(envThingsGRPCTimeout, defThingsGRPCTimeout))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envThingsGRPCTimeout, err.Error())
+	}
+
+	jetStream, err := mfnats.LoadJetStreamConfig()
+	if err != nil {
+		log.Fatalf("Failed to load JetStream config: %s", err)
+	}
+
+	return config{
+		brokerURL:  mainflux.Env(envBrokerURL, defBrokerURL),
+		jetStream:  jetStream,
+		logLevel:   mainflux.Env(envLogLevel, defLogLevel),
+		port:       mainflux.Env(envPort, defPort),
+		configPath: mainflux.Env(envConfigPath, defConfigPath),
+		archiver: archiver.Config{
+			Prefix:    mainflux.Env(envPrefix, defPrefix),
+			BatchSize: batchSize,
+		},
+		flushInterval: flushInterval,
+		s3: archive.S3Config{
+			Endpoint:  mainflux.Env(envS3Endpoint, defS3Endpoint),
+			Region:    mainflux.Env(envS3Region, defS3Region),
+			Bucket:    mainflux.Env(envS3Bucket, defS3Bucket),
+			AccessKey: mainflux.Env(envS3AccessKey, defS3AccessKey),
+			SecretKey: mainflux.Env(envS3SecretKey, defS3SecretKey),
+		},
+		s3Timeout: s3Timeout,
+
+		clientTLS:         tls,
+		caCerts:           mainflux.Env(envCACerts, defCACerts),
+		jaegerURL:         mainflux.Env(envJaegerURL, defJaegerURL),
+		thingsGRPCURL:     mainflux.Env(envThingsGRPCURL, defThingsGRPCURL),
+		thingsGRPCTimeout: thingsGRPCTimeout,
+	}
+}
+
+func newService(arch archiver.Archiver, logger logger.Logger) consumers.Consumer {
+	var svc consumers.Consumer = arch
+	svc = api.LoggingMiddleware(svc, logger)
+	svc = api.MetricsMiddleware(
+		svc,
+		kitprometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: "archiver",
+			Subsystem: "message_writer",
+			Name:      "request_count",
+			Help:      "Number of requests received.",
+		}, []string{"method"}),
+		kitprometheus.NewSummaryFrom(stdprometheus.SummaryOpts{
+			Namespace: "archiver",
+			Subsystem: "message_writer",
+			Name:      "request_latency_microseconds",
+			Help:      "Total duration of requests in microseconds.",
+		}, []string{"method"}),
+	)
+
+	return svc
+}
+
+func startHTTPServer(ctx context.Context, port string, logger logger.Logger, checks []mainflux.HealthCheck) error {
+	p := fmt.Sprintf(":%s", port)
+	errCh := make(chan error)
+	server := &http.Server{Addr: p, Handler: api.MakeHandler(svcName, checks...)}
+
+	logger.Info(fmt.Sprintf("Archiver service started, exposed port %s", port))
+	go func() {
+		errCh <- server.ListenAndServe()
+	}()
+
+	select {
+	case <-ctx.Done():
+		ctxShutdown, cancelShutdown := context.WithTimeout(context.Background(), stopWaitTime)
+		defer cancelShutdown()
+		if err := server.Shutdown(ctxShutdown); err != nil {
+			logger.Error(fmt.Sprintf("Archiver service error occurred during shutdown at %s: %s", p, err))
+			return fmt.Errorf("archiver service occurred during shutdown at %s: %w", p, err)
+		}
+		logger.Info(fmt.Sprintf("Archiver service shutdown of http at %s", p))
+		return nil
+	case err := <-errCh:
+		return err
+	}
+}
+
+func connectToBroker(cfg config, logger logger.Logger) (messaging.PubSub, error) {
+	var pubSub messaging.PubSub
+	var err error
+	switch {
+	case cfg.jetStream != nil:
+		pubSub, err = brokers.NewJetStreamPubSub(cfg.brokerURL, "", *cfg.jetStream, logger)
+	default:
+		pubSub, err = brokers.NewPubSub(cfg.brokerURL, "", logger)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	pubSub = profile.NewPubSub(pubSub, messaging.PersistFlag, kitprometheus.NewCounterFrom(stdprometheus.CounterOpts{
+		Namespace: "archiver",
+		Subsystem: "message_writer",
+		Name:      "subscriber_skipped_messages",
+		Help:      "Number of received messages skipped because their profile flag is not set.",
+	}, []string{"flag"}))
+
+	return pubSub, nil
+}
+
+func initJaeger(svcName, url string, logger logger.Logger) (opentracing.Tracer, io.Closer) {
+	if url == "" {
+		return opentracing.NoopTracer{}, ioutil.NopCloser(nil)
+	}
+
+	tracer, closer, err := jconfig.Configuration{
+		ServiceName: svcName,
+		Sampler: &jconfig.SamplerConfig{
+			Type:  "const",
+			Param: 1,
+		},
+		Reporter: &jconfig.ReporterConfig{
+			LocalAgentHostPort: url,
+			LogSpans:           true,
+		},
+	}.NewTracer()
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to init Jaeger client: %s", err))
+		os.Exit(1)
+	}
+
+	return tracer, closer
+}
+
+func connectToThings(cfg config, logger logger.Logger) *grpc.ClientConn {
+	var opts []grpc.DialOption
+	if cfg.clientTLS {
+		if cfg.caCerts != "" {
+			tpc, err := credentials.NewClientTLSFromFile(cfg.caCerts, "")
+			if err != nil {
+				logger.Error(fmt.Sprintf("Failed to load certs: %s", err))
+				os.Exit(1)
+			}
+			opts = append(opts, grpc.WithTransportCredentials(tpc))
+		}
+	} else {
+		logger.Info("gRPC communication is not encrypted")
+		opts = append(opts, grpc.WithInsecure())
+	}
+
+	conn, err := grpc.Dial(cfg.thingsGRPCURL, opts...)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to connect to things service: %s", err))
+		os.Exit(1)
+	}
+	logger.Info(fmt.Sprintf("Established gRPC connection to things via gRPC: %s", cfg.thingsGRPCURL))
+	return conn
+}
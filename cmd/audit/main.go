@@ -0,0 +1,355 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux"
+	"github.com/MainfluxLabs/mainflux/audit"
+	"github.com/MainfluxLabs/mainflux/audit/api"
+	"github.com/MainfluxLabs/mainflux/audit/postgres"
+	auditredis "github.com/MainfluxLabs/mainflux/audit/redis"
+	"github.com/MainfluxLabs/mainflux/audit/syslog"
+	authapi "github.com/MainfluxLabs/mainflux/auth/api/grpc"
+	"github.com/MainfluxLabs/mainflux/logger"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/uuid"
+	kitprometheus "github.com/go-kit/kit/metrics/prometheus"
+	"github.com/go-redis/redis/v8"
+	"github.com/jmoiron/sqlx"
+	opentracing "github.com/opentracing/opentracing-go"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+	jconfig "github.com/uber/jaeger-client-go/config"
+	"golang.org/x/sync/errgroup"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+const (
+	stopWaitTime = 5 * time.Second
+
+	defLogLevel        = "error"
+	defDBHost          = "localhost"
+	defDBPort          = "5432"
+	defDBUser          = "mainflux"
+	defDBPass          = "mainflux"
+	defDB              = "audit"
+	defDBSSLMode       = "disable"
+	defDBSSLCert       = ""
+	defDBSSLKey        = ""
+	defDBSSLRootCert   = ""
+	defHTTPPort        = "8193"
+	defServerCert      = ""
+	defServerKey       = ""
+	defJaegerURL       = ""
+	defESURL           = "localhost:6379"
+	defESPass          = ""
+	defESDB            = "0"
+	defESConsumerName  = "audit"
+	defSyslogEnabled   = "false"
+	defSyslogNetwork   = ""
+	defSyslogAddress   = ""
+	defAuthTLS         = "false"
+	defAuthCACerts     = ""
+	defAuthGRPCURL     = "localhost:8181"
+	defAuthGRPCTimeout = "1s"
+
+	envLogLevel        = "MF_AUDIT_LOG_LEVEL"
+	envDBHost          = "MF_AUDIT_DB_HOST"
+	envDBPort          = "MF_AUDIT_DB_PORT"
+	envDBUser          = "MF_AUDIT_DB_USER"
+	envDBPass          = "MF_AUDIT_DB_PASS"
+	envDB              = "MF_AUDIT_DB"
+	envDBSSLMode       = "MF_AUDIT_DB_SSL_MODE"
+	envDBSSLCert       = "MF_AUDIT_DB_SSL_CERT"
+	envDBSSLKey        = "MF_AUDIT_DB_SSL_KEY"
+	envDBSSLRootCert   = "MF_AUDIT_DB_SSL_ROOT_CERT"
+	envHTTPPort        = "MF_AUDIT_HTTP_PORT"
+	envServerCert      = "MF_AUDIT_SERVER_CERT"
+	envServerKey       = "MF_AUDIT_SERVER_KEY"
+	envJaegerURL       = "MF_JAEGER_URL"
+	envESURL           = "MF_AUDIT_ES_URL"
+	envESPass          = "MF_AUDIT_ES_PASS"
+	envESDB            = "MF_AUDIT_ES_DB"
+	envESConsumerName  = "MF_AUDIT_EVENT_CONSUMER"
+	envSyslogEnabled   = "MF_AUDIT_SYSLOG_ENABLED"
+	envSyslogNetwork   = "MF_AUDIT_SYSLOG_NETWORK"
+	envSyslogAddress   = "MF_AUDIT_SYSLOG_ADDRESS"
+	envAuthTLS         = "MF_AUTH_CLIENT_TLS"
+	envAuthCACerts     = "MF_AUTH_CA_CERTS"
+	envAuthGRPCURL     = "MF_AUTH_GRPC_URL"
+	envAuthGRPCTimeout = "MF_AUTH_GRPC_TIMEOUT"
+)
+
+type config struct {
+	logLevel        string
+	dbConfig        postgres.Config
+	httpPort        string
+	serverCert      string
+	serverKey       string
+	jaegerURL       string
+	esURL           string
+	esPass          string
+	esDB            string
+	esConsumerName  string
+	syslogEnabled   bool
+	syslogNetwork   string
+	syslogAddress   string
+	authTLS         bool
+	authCACerts     string
+	authGRPCURL     string
+	authGRPCTimeout time.Duration
+}
+
+func main() {
+	cfg := loadConfig()
+	ctx, cancel := context.WithCancel(context.Background())
+	g, ctx := errgroup.WithContext(ctx)
+
+	logger, err := logger.New(os.Stdout, cfg.logLevel)
+	if err != nil {
+		log.Fatalf(err.Error())
+	}
+
+	db := connectToDB(cfg.dbConfig, logger)
+	defer db.Close()
+
+	esClient := connectToRedis(cfg.esURL, cfg.esPass, cfg.esDB, logger)
+	defer esClient.Close()
+
+	authTracer, authCloser := initJaeger("auth", cfg.jaegerURL, logger)
+	defer authCloser.Close()
+
+	auth, close := connectToAuth(cfg, authTracer, logger)
+	if close != nil {
+		defer close()
+	}
+
+	tracer, closer := initJaeger("audit", cfg.jaegerURL, logger)
+	defer closer.Close()
+
+	svc := newService(db, auth, cfg, logger)
+	checks := []mainflux.HealthCheck{
+		{Name: "database", Check: db.PingContext},
+		{Name: "es", Check: func(ctx context.Context) error { return esClient.Ping(ctx).Err() }},
+	}
+
+	g.Go(func() error {
+		return startHTTPServer(ctx, tracer, svc, cfg.httpPort, cfg.serverCert, cfg.serverKey, logger, checks)
+	})
+
+	g.Go(func() error {
+		logger.Info("Subscribed to Redis Event Store")
+		return auditredis.NewEventStore(svc, esClient, cfg.esConsumerName, logger).Subscribe(ctx)
+	})
+
+	g.Go(func() error {
+		if sig := errors.SignalHandler(ctx); sig != nil {
+			cancel()
+			logger.Info(fmt.Sprintf("Audit service shutdown by signal: %s", sig))
+		}
+		return nil
+	})
+
+	if err := g.Wait(); err != nil {
+		logger.Error(fmt.Sprintf("Audit service terminated: %s", err))
+	}
+}
+
+func loadConfig() config {
+	authGRPCTimeout, err := time.ParseDuration(mainflux.Env(envAuthGRPCTimeout, defAuthGRPCTimeout))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envAuthGRPCTimeout, err.Error())
+	}
+
+	tls, err := strconv.ParseBool(mainflux.Env(envAuthTLS, defAuthTLS))
+	if err != nil {
+		log.Fatalf("Invalid value passed for %s\n", envAuthTLS)
+	}
+
+	syslogEnabled, err := strconv.ParseBool(mainflux.Env(envSyslogEnabled, defSyslogEnabled))
+	if err != nil {
+		log.Fatalf("Invalid value passed for %s\n", envSyslogEnabled)
+	}
+
+	dbConfig := postgres.Config{
+		Host:        mainflux.Env(envDBHost, defDBHost),
+		Port:        mainflux.Env(envDBPort, defDBPort),
+		User:        mainflux.Env(envDBUser, defDBUser),
+		Pass:        mainflux.Env(envDBPass, defDBPass),
+		Name:        mainflux.Env(envDB, defDB),
+		SSLMode:     mainflux.Env(envDBSSLMode, defDBSSLMode),
+		SSLCert:     mainflux.Env(envDBSSLCert, defDBSSLCert),
+		SSLKey:      mainflux.Env(envDBSSLKey, defDBSSLKey),
+		SSLRootCert: mainflux.Env(envDBSSLRootCert, defDBSSLRootCert),
+	}
+
+	return config{
+		logLevel:        mainflux.Env(envLogLevel, defLogLevel),
+		dbConfig:        dbConfig,
+		httpPort:        mainflux.Env(envHTTPPort, defHTTPPort),
+		serverCert:      mainflux.Env(envServerCert, defServerCert),
+		serverKey:       mainflux.Env(envServerKey, defServerKey),
+		jaegerURL:       mainflux.Env(envJaegerURL, defJaegerURL),
+		esURL:           mainflux.Env(envESURL, defESURL),
+		esPass:          mainflux.Env(envESPass, defESPass),
+		esDB:            mainflux.Env(envESDB, defESDB),
+		esConsumerName:  mainflux.Env(envESConsumerName, defESConsumerName),
+		syslogEnabled:   syslogEnabled,
+		syslogNetwork:   mainflux.Env(envSyslogNetwork, defSyslogNetwork),
+		syslogAddress:   mainflux.Env(envSyslogAddress, defSyslogAddress),
+		authTLS:         tls,
+		authCACerts:     mainflux.Env(envAuthCACerts, defAuthCACerts),
+		authGRPCURL:     mainflux.Env(envAuthGRPCURL, defAuthGRPCURL),
+		authGRPCTimeout: authGRPCTimeout,
+	}
+}
+
+func initJaeger(svcName, url string, logger logger.Logger) (opentracing.Tracer, io.Closer) {
+	if url == "" {
+		return opentracing.NoopTracer{}, ioutil.NopCloser(nil)
+	}
+
+	tracer, closer, err := jconfig.Configuration{
+		ServiceName: svcName,
+		Sampler: &jconfig.SamplerConfig{
+			Type:  "const",
+			Param: 1,
+		},
+		Reporter: &jconfig.ReporterConfig{
+			LocalAgentHostPort: url,
+			LogSpans:           true,
+		},
+	}.NewTracer()
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to init Jaeger: %s", err))
+		os.Exit(1)
+	}
+
+	return tracer, closer
+}
+
+func connectToDB(dbConfig postgres.Config, logger logger.Logger) *sqlx.DB {
+	db, err := postgres.Connect(dbConfig)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to connect to postgres: %s", err))
+		os.Exit(1)
+	}
+	return db
+}
+
+func connectToRedis(url, pass, db string, logger logger.Logger) *redis.Client {
+	n, err := strconv.Atoi(db)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to connect to redis: %s", err))
+		os.Exit(1)
+	}
+
+	return redis.NewClient(&redis.Options{
+		Addr:     url,
+		Password: pass,
+		DB:       n,
+	})
+}
+
+func connectToAuth(cfg config, tracer opentracing.Tracer, logger logger.Logger) (mainflux.AuthServiceClient, func() error) {
+	var opts []grpc.DialOption
+	if cfg.authTLS {
+		if cfg.authCACerts != "" {
+			tpc, err := credentials.NewClientTLSFromFile(cfg.authCACerts, "")
+			if err != nil {
+				logger.Error(fmt.Sprintf("Failed to create tls credentials: %s", err))
+				os.Exit(1)
+			}
+			opts = append(opts, grpc.WithTransportCredentials(tpc))
+		}
+	} else {
+		opts = append(opts, grpc.WithInsecure())
+		logger.Info("gRPC communication is not encrypted")
+	}
+
+	conn, err := grpc.Dial(cfg.authGRPCURL, opts...)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to connect to auth service: %s", err))
+		os.Exit(1)
+	}
+
+	return authapi.NewClient(tracer, conn, cfg.authGRPCTimeout), conn.Close
+}
+
+func newService(db *sqlx.DB, auth mainflux.AuthServiceClient, cfg config, logger logger.Logger) audit.Service {
+	repo := postgres.New(postgres.NewDatabase(db))
+
+	var exporter audit.Exporter
+	if cfg.syslogEnabled {
+		e, err := syslog.New(cfg.syslogNetwork, cfg.syslogAddress)
+		if err != nil {
+			logger.Error(fmt.Sprintf("Failed to connect to syslog: %s", err))
+			os.Exit(1)
+		}
+		exporter = e
+	}
+
+	svc := audit.New(auth, repo, uuid.New(), exporter)
+	svc = api.LoggingMiddleware(svc, logger)
+	svc = api.MetricsMiddleware(
+		svc,
+		kitprometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: "audit",
+			Subsystem: "api",
+			Name:      "request_count",
+			Help:      "Number of requests received.",
+		}, []string{"method"}),
+		kitprometheus.NewSummaryFrom(stdprometheus.SummaryOpts{
+			Namespace: "audit",
+			Subsystem: "api",
+			Name:      "request_latency_microseconds",
+			Help:      "Total duration of requests in microseconds.",
+		}, []string{"method"}),
+	)
+
+	return svc
+}
+
+func startHTTPServer(ctx context.Context, tracer opentracing.Tracer, svc audit.Service, port string, certFile string, keyFile string, logger logger.Logger, checks []mainflux.HealthCheck) error {
+	p := fmt.Sprintf(":%s", port)
+	errCh := make(chan error)
+	server := &http.Server{Addr: p, Handler: api.MakeHandler(svc, tracer, logger, checks...)}
+
+	switch {
+	case certFile != "" || keyFile != "":
+		logger.Info(fmt.Sprintf("Audit service started using https, cert %s key %s, exposed port %s", certFile, keyFile, port))
+		go func() {
+			errCh <- server.ListenAndServeTLS(certFile, keyFile)
+		}()
+	default:
+		logger.Info(fmt.Sprintf("Audit service started using http, exposed port %s", port))
+		go func() {
+			errCh <- server.ListenAndServe()
+		}()
+	}
+
+	select {
+	case <-ctx.Done():
+		ctxShutdown, cancelShutdown := context.WithTimeout(context.Background(), stopWaitTime)
+		defer cancelShutdown()
+		if err := server.Shutdown(ctxShutdown); err != nil {
+			logger.Error(fmt.Sprintf("Audit service error occurred during shutdown at %s: %s", p, err))
+			return fmt.Errorf("audit service error occurred during shutdown at %s: %w", p, err)
+		}
+		logger.Info(fmt.Sprintf("Audit service shutdown of http at %s", p))
+		return nil
+	case err := <-errCh:
+		return err
+	}
+}
@@ -13,12 +13,14 @@ import (
 	"time"
 
 	"github.com/MainfluxLabs/mainflux"
+	auditredis "github.com/MainfluxLabs/mainflux/audit/redis"
 	"github.com/MainfluxLabs/mainflux/auth"
 	api "github.com/MainfluxLabs/mainflux/auth/api"
 	grpcapi "github.com/MainfluxLabs/mainflux/auth/api/grpc"
 	httpapi "github.com/MainfluxLabs/mainflux/auth/api/http"
 	"github.com/MainfluxLabs/mainflux/auth/jwt"
 	"github.com/MainfluxLabs/mainflux/auth/postgres"
+	rediscache "github.com/MainfluxLabs/mainflux/auth/redis"
 	"github.com/MainfluxLabs/mainflux/auth/tracing"
 	"github.com/MainfluxLabs/mainflux/logger"
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
@@ -26,6 +28,7 @@ import (
 	thingsapi "github.com/MainfluxLabs/mainflux/things/api/auth/grpc"
 	usersapi "github.com/MainfluxLabs/mainflux/users/api/grpc"
 	kitprometheus "github.com/go-kit/kit/metrics/prometheus"
+	"github.com/go-redis/redis/v8"
 	"github.com/jmoiron/sqlx"
 	"github.com/opentracing/opentracing-go"
 	stdprometheus "github.com/prometheus/client_golang/prometheus"
@@ -56,7 +59,15 @@ const (
 	defServerCert      = ""
 	defServerKey       = ""
 	defJaegerURL       = ""
-	defLoginDuration   = "10h"
+	defLoginDuration   = "15m"
+	defRefreshDuration = "24h"
+	defRedisURL        = "localhost:6379"
+	defRedisPass       = ""
+	defRedisDB         = "0"
+	defESURL           = "localhost:6379"
+	defESPass          = ""
+	defESDB            = "0"
+	defAuditHashKey    = ""
 	defAdminEmail      = ""
 	defTimeout         = "1s"
 	defThingsGRPCURL   = "localhost:8183"
@@ -84,6 +95,14 @@ const (
 	envServerKey       = "MF_AUTH_SERVER_KEY"
 	envJaegerURL       = "MF_JAEGER_URL"
 	envLoginDuration   = "MF_AUTH_LOGIN_TOKEN_DURATION"
+	envRefreshDuration = "MF_AUTH_REFRESH_TOKEN_DURATION"
+	envRedisURL        = "MF_AUTH_REDIS_URL"
+	envRedisPass       = "MF_AUTH_REDIS_PASS"
+	envRedisDB         = "MF_AUTH_REDIS_DB"
+	envESURL           = "MF_AUTH_ES_URL"
+	envESPass          = "MF_AUTH_ES_PASS"
+	envESDB            = "MF_AUTH_ES_DB"
+	envAuditHashKey    = "MF_AUDIT_HASH_KEY"
 	envAdminEmail      = "MF_USERS_ADMIN_EMAIL"
 	envThingsGRPCURL   = "MF_THINGS_AUTH_GRPC_URL"
 	envThingsCACerts   = "MF_THINGS_CA_CERTS"
@@ -103,6 +122,14 @@ type config struct {
 	serverKey       string
 	jaegerURL       string
 	loginDuration   time.Duration
+	refreshDuration time.Duration
+	redisURL        string
+	redisPass       string
+	redisDB         string
+	esURL           string
+	esPass          string
+	esDB            string
+	auditHashKey    string
 	timeout         time.Duration
 	adminEmail      string
 	thingsClientTLS bool
@@ -147,10 +174,22 @@ func main() {
 
 	tc := thingsapi.NewClient(thConn, thingsTracer, cfg.timeout)
 
-	svc := newService(db, tc, uc, dbTracer, cfg.secret, logger, cfg.loginDuration)
+	redisClient := connectToRedis(cfg.redisURL, cfg.redisPass, cfg.redisDB, logger)
+	defer redisClient.Close()
+
+	esClient := connectToRedis(cfg.esURL, cfg.esPass, cfg.esDB, logger)
+	defer esClient.Close()
+
+	svc := newService(db, redisClient, tc, uc, dbTracer, cfg, logger)
+	checks := []mainflux.HealthCheck{
+		{Name: "database", Check: db.PingContext},
+		{Name: "cache", Check: func(ctx context.Context) error { return redisClient.Ping(ctx).Err() }},
+		{Name: "es", Check: func(ctx context.Context) error { return esClient.Ping(ctx).Err() }},
+	}
 
 	g.Go(func() error {
-		return startHTTPServer(ctx, tracer, svc, cfg.httpPort, cfg.serverCert, cfg.serverKey, logger)
+		handler := auditredis.NewHandler(httpapi.MakeHandler(svc, tracer, logger, checks...), "auth", httpapi.NewIdentifier(svc), esClient, cfg.auditHashKey, logger)
+		return startHTTPServer(ctx, handler, cfg.httpPort, cfg.serverCert, cfg.serverKey, logger)
 	})
 	g.Go(func() error {
 		return startGRPCServer(ctx, tracer, svc, cfg.grpcPort, cfg.serverCert, cfg.serverKey, logger)
@@ -196,6 +235,11 @@ func loadConfig() config {
 		log.Fatal(err)
 	}
 
+	refreshDuration, err := time.ParseDuration(mainflux.Env(envRefreshDuration, defRefreshDuration))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envRefreshDuration, err.Error())
+	}
+
 	timeout, err := time.ParseDuration(mainflux.Env(envTimeout, defTimeout))
 	if err != nil {
 		log.Fatalf("Invalid %s value: %s", envTimeout, err.Error())
@@ -211,6 +255,14 @@ func loadConfig() config {
 		serverKey:       mainflux.Env(envServerKey, defServerKey),
 		jaegerURL:       mainflux.Env(envJaegerURL, defJaegerURL),
 		loginDuration:   loginDuration,
+		refreshDuration: refreshDuration,
+		redisURL:        mainflux.Env(envRedisURL, defRedisURL),
+		redisPass:       mainflux.Env(envRedisPass, defRedisPass),
+		redisDB:         mainflux.Env(envRedisDB, defRedisDB),
+		esURL:           mainflux.Env(envESURL, defESURL),
+		esPass:          mainflux.Env(envESPass, defESPass),
+		esDB:            mainflux.Env(envESDB, defESDB),
+		auditHashKey:    mainflux.Env(envAuditHashKey, defAuditHashKey),
 		timeout:         timeout,
 		adminEmail:      mainflux.Env(envAdminEmail, defAdminEmail),
 		thingsClientTLS: thingsClientTLS,
@@ -247,6 +299,20 @@ func initJaeger(svcName, url string, logger logger.Logger) (opentracing.Tracer,
 	return tracer, closer
 }
 
+func connectToRedis(redisURL, redisPass, redisDB string, logger logger.Logger) *redis.Client {
+	db, err := strconv.Atoi(redisDB)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to connect to redis: %s", err))
+		os.Exit(1)
+	}
+
+	return redis.NewClient(&redis.Options{
+		Addr:     redisURL,
+		Password: redisPass,
+		DB:       db,
+	})
+}
+
 func connectToDB(dbConfig postgres.Config, logger logger.Logger) *sqlx.DB {
 	db, err := postgres.Connect(dbConfig)
 	if err != nil {
@@ -307,20 +373,28 @@ func connectToThings(cfg config, logger logger.Logger) *grpc.ClientConn {
 	return conn
 }
 
-func newService(db *sqlx.DB, tc mainflux.ThingsServiceClient, uc mainflux.UsersServiceClient, tracer opentracing.Tracer, secret string, logger logger.Logger, duration time.Duration) auth.Service {
+func newService(db *sqlx.DB, redisClient *redis.Client, tc mainflux.ThingsServiceClient, uc mainflux.UsersServiceClient, tracer opentracing.Tracer, cfg config, logger logger.Logger) auth.Service {
 	orgsRepo := postgres.NewOrgRepo(db)
 	orgsRepo = tracing.OrgRepositoryMiddleware(tracer, orgsRepo)
 
 	database := postgres.NewDatabase(db)
 	keysRepo := tracing.New(postgres.New(database), tracer)
+	revocationsRepo := rediscache.NewRevocationRepository(redisClient, cfg.refreshDuration)
+	sessionsRepo := rediscache.NewSessionRepository(redisClient, cfg.refreshDuration)
 
 	rolesRepo := postgres.NewRolesRepo(db)
 	rolesRepo = tracing.RolesRepositoryMiddleware(tracer, rolesRepo)
 
+	policiesRepo := postgres.NewPoliciesRepo(db)
+	policiesRepo = tracing.PoliciesRepositoryMiddleware(tracer, policiesRepo)
+
+	teamsRepo := postgres.NewTeamRepo(db)
+	teamsRepo = tracing.TeamRepositoryMiddleware(tracer, teamsRepo)
+
 	idProvider := uuid.New()
-	t := jwt.New(secret)
+	t := jwt.New(cfg.secret)
 
-	svc := auth.New(orgsRepo, tc, uc, keysRepo, rolesRepo, idProvider, t, duration)
+	svc := auth.New(orgsRepo, tc, uc, keysRepo, revocationsRepo, sessionsRepo, rolesRepo, policiesRepo, teamsRepo, idProvider, t, cfg.loginDuration, cfg.refreshDuration)
 	svc = api.LoggingMiddleware(svc, logger)
 	svc = api.MetricsMiddleware(
 		svc,
@@ -341,9 +415,9 @@ func newService(db *sqlx.DB, tc mainflux.ThingsServiceClient, uc mainflux.UsersS
 	return svc
 }
 
-func startHTTPServer(ctx context.Context, tracer opentracing.Tracer, svc auth.Service, port string, certFile string, keyFile string, logger logger.Logger) error {
+func startHTTPServer(ctx context.Context, handler http.Handler, port string, certFile string, keyFile string, logger logger.Logger) error {
 	p := fmt.Sprintf(":%s", port)
-	server := &http.Server{Addr: p, Handler: httpapi.MakeHandler(svc, tracer, logger)}
+	server := &http.Server{Addr: p, Handler: handler}
 	errCh := make(chan error)
 	protocol := httpProtocol
 	switch {
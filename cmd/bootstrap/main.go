@@ -16,6 +16,7 @@ import (
 	"time"
 
 	"github.com/MainfluxLabs/mainflux"
+	auditredis "github.com/MainfluxLabs/mainflux/audit/redis"
 	authapi "github.com/MainfluxLabs/mainflux/auth/api/grpc"
 	"github.com/MainfluxLabs/mainflux/bootstrap"
 	api "github.com/MainfluxLabs/mainflux/bootstrap/api"
@@ -23,8 +24,10 @@ import (
 	rediscons "github.com/MainfluxLabs/mainflux/bootstrap/redis/consumer"
 	redisprod "github.com/MainfluxLabs/mainflux/bootstrap/redis/producer"
 	"github.com/MainfluxLabs/mainflux/logger"
+	mfapi "github.com/MainfluxLabs/mainflux/pkg/api"
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
 	mfsdk "github.com/MainfluxLabs/mainflux/pkg/sdk/go"
+	thingsapi "github.com/MainfluxLabs/mainflux/things/api/auth/grpc"
 	kitprometheus "github.com/go-kit/kit/metrics/prometheus"
 	r "github.com/go-redis/redis/v8"
 	"github.com/jmoiron/sqlx"
@@ -41,83 +44,105 @@ const (
 	httpProtocol  = "http"
 	httpsProtocol = "https"
 
-	defLogLevel        = "error"
-	defDBHost          = "localhost"
-	defDBPort          = "5432"
-	defDBUser          = "mainflux"
-	defDBPass          = "mainflux"
-	defDB              = "bootstrap"
-	defDBSSLMode       = "disable"
-	defDBSSLCert       = ""
-	defDBSSLKey        = ""
-	defDBSSLRootCert   = ""
-	defEncryptKey      = "12345678910111213141516171819202"
-	defClientTLS       = "false"
-	defCACerts         = ""
-	defPort            = "8180"
-	defServerCert      = ""
-	defServerKey       = ""
-	defThingsURL       = "http://localhost"
-	defThingsESURL     = "localhost:6379"
-	defThingsESPass    = ""
-	defThingsESDB      = "0"
-	defESURL           = "localhost:6379"
-	defESPass          = ""
-	defESDB            = "0"
-	defESConsumerName  = "bootstrap"
-	defJaegerURL       = ""
-	defAuthGRPCURL     = "localhost:8181"
-	defAuthGRPCTimeout = "1s"
-
-	envLogLevel        = "MF_BOOTSTRAP_LOG_LEVEL"
-	envDBHost          = "MF_BOOTSTRAP_DB_HOST"
-	envDBPort          = "MF_BOOTSTRAP_DB_PORT"
-	envDBUser          = "MF_BOOTSTRAP_DB_USER"
-	envDBPass          = "MF_BOOTSTRAP_DB_PASS"
-	envDB              = "MF_BOOTSTRAP_DB"
-	envDBSSLMode       = "MF_BOOTSTRAP_DB_SSL_MODE"
-	envDBSSLCert       = "MF_BOOTSTRAP_DB_SSL_CERT"
-	envDBSSLKey        = "MF_BOOTSTRAP_DB_SSL_KEY"
-	envDBSSLRootCert   = "MF_BOOTSTRAP_DB_SSL_ROOT_CERT"
-	envEncryptKey      = "MF_BOOTSTRAP_ENCRYPT_KEY"
-	envClientTLS       = "MF_BOOTSTRAP_CLIENT_TLS"
-	envCACerts         = "MF_BOOTSTRAP_CA_CERTS"
-	envPort            = "MF_BOOTSTRAP_PORT"
-	envServerCert      = "MF_BOOTSTRAP_SERVER_CERT"
-	envServerKey       = "MF_BOOTSTRAP_SERVER_KEY"
-	envThingsURL       = "MF_THINGS_URL"
-	envThingsESURL     = "MF_THINGS_ES_URL"
-	envThingsESPass    = "MF_THINGS_ES_PASS"
-	envThingsESDB      = "MF_THINGS_ES_DB"
-	envESURL           = "MF_BOOTSTRAP_ES_URL"
-	envESPass          = "MF_BOOTSTRAP_ES_PASS"
-	envESDB            = "MF_BOOTSTRAP_ES_DB"
-	envESConsumerName  = "MF_BOOTSTRAP_EVENT_CONSUMER"
-	envJaegerURL       = "MF_JAEGER_URL"
-	envAuthGRPCURL     = "MF_AUTH_GRPC_URL"
-	envAuthGRPCTimeout = "MF_AUTH_GRPC_TIMEOUT"
+	defLogLevel          = "error"
+	defDBHost            = "localhost"
+	defDBPort            = "5432"
+	defDBUser            = "mainflux"
+	defDBPass            = "mainflux"
+	defDB                = "bootstrap"
+	defDBSSLMode         = "disable"
+	defDBSSLCert         = ""
+	defDBSSLKey          = ""
+	defDBSSLRootCert     = ""
+	defEncryptKey        = "12345678910111213141516171819202"
+	defClientTLS         = "false"
+	defCACerts           = ""
+	defPort              = "8180"
+	defServerCert        = ""
+	defServerKey         = ""
+	defThingsURL         = "http://localhost"
+	defThingsESURL       = "localhost:6379"
+	defThingsESPass      = ""
+	defThingsESDB        = "0"
+	defESURL             = "localhost:6379"
+	defESPass            = ""
+	defESDB              = "0"
+	defAuditHashKey      = ""
+	defESConsumerName    = "bootstrap"
+	defJaegerURL         = ""
+	defCORSOrigins       = ""
+	defHSTSMaxAge        = "0s"
+	defCSP               = ""
+	defV1Sunset          = ""
+	defAuthGRPCURL       = "localhost:8181"
+	defAuthGRPCTimeout   = "1s"
+	defThingsGRPCURL     = "localhost:8183"
+	defThingsGRPCTimeout = "1s"
+	defReconcileInterval = "1h"
+
+	envLogLevel          = "MF_BOOTSTRAP_LOG_LEVEL"
+	envDBHost            = "MF_BOOTSTRAP_DB_HOST"
+	envDBPort            = "MF_BOOTSTRAP_DB_PORT"
+	envDBUser            = "MF_BOOTSTRAP_DB_USER"
+	envDBPass            = "MF_BOOTSTRAP_DB_PASS"
+	envDB                = "MF_BOOTSTRAP_DB"
+	envDBSSLMode         = "MF_BOOTSTRAP_DB_SSL_MODE"
+	envDBSSLCert         = "MF_BOOTSTRAP_DB_SSL_CERT"
+	envDBSSLKey          = "MF_BOOTSTRAP_DB_SSL_KEY"
+	envDBSSLRootCert     = "MF_BOOTSTRAP_DB_SSL_ROOT_CERT"
+	envEncryptKey        = "MF_BOOTSTRAP_ENCRYPT_KEY"
+	envClientTLS         = "MF_BOOTSTRAP_CLIENT_TLS"
+	envCACerts           = "MF_BOOTSTRAP_CA_CERTS"
+	envPort              = "MF_BOOTSTRAP_PORT"
+	envServerCert        = "MF_BOOTSTRAP_SERVER_CERT"
+	envServerKey         = "MF_BOOTSTRAP_SERVER_KEY"
+	envThingsURL         = "MF_THINGS_URL"
+	envThingsESURL       = "MF_THINGS_ES_URL"
+	envThingsESPass      = "MF_THINGS_ES_PASS"
+	envThingsESDB        = "MF_THINGS_ES_DB"
+	envESURL             = "MF_BOOTSTRAP_ES_URL"
+	envESPass            = "MF_BOOTSTRAP_ES_PASS"
+	envESDB              = "MF_BOOTSTRAP_ES_DB"
+	envAuditHashKey      = "MF_AUDIT_HASH_KEY"
+	envESConsumerName    = "MF_BOOTSTRAP_EVENT_CONSUMER"
+	envJaegerURL         = "MF_JAEGER_URL"
+	envCORSOrigins       = "MF_CORS_ALLOWED_ORIGINS"
+	envHSTSMaxAge        = "MF_HSTS_MAX_AGE"
+	envCSP               = "MF_CONTENT_SECURITY_POLICY"
+	envV1Sunset          = "MF_API_V1_SUNSET"
+	envAuthGRPCURL       = "MF_AUTH_GRPC_URL"
+	envAuthGRPCTimeout   = "MF_AUTH_GRPC_TIMEOUT"
+	envThingsGRPCURL     = "MF_THINGS_AUTH_GRPC_URL"
+	envThingsGRPCTimeout = "MF_THINGS_AUTH_GRPC_TIMEOUT"
+	envReconcileInterval = "MF_BOOTSTRAP_RECONCILE_INTERVAL"
 )
 
 type config struct {
-	logLevel        string
-	dbConfig        postgres.Config
-	clientTLS       bool
-	encKey          []byte
-	caCerts         string
-	httpPort        string
-	serverCert      string
-	serverKey       string
-	thingsURL       string
-	esThingsURL     string
-	esThingsPass    string
-	esThingsDB      string
-	esURL           string
-	esPass          string
-	esDB            string
-	esConsumerName  string
-	jaegerURL       string
-	authGRPCURL     string
-	authGRPCTimeout time.Duration
+	logLevel          string
+	dbConfig          postgres.Config
+	clientTLS         bool
+	encKey            []byte
+	caCerts           string
+	httpPort          string
+	serverCert        string
+	serverKey         string
+	thingsURL         string
+	esThingsURL       string
+	esThingsPass      string
+	esThingsDB        string
+	esURL             string
+	esPass            string
+	esDB              string
+	auditHashKey      string
+	esConsumerName    string
+	jaegerURL         string
+	securityConfig    mfapi.SecurityConfig
+	v1Sunset          time.Time
+	authGRPCURL       string
+	authGRPCTimeout   time.Duration
+	thingsGRPCURL     string
+	thingsGRPCTimeout time.Duration
+	reconcileInterval time.Duration
 }
 
 func main() {
@@ -148,13 +173,32 @@ func main() {
 	auth := authapi.NewClient(authTracer, authConn, cfg.authGRPCTimeout)
 
 	svc := newService(auth, db, logger, esClient, cfg)
+	checks := []mainflux.HealthCheck{
+		{Name: "database", Check: db.PingContext},
+		{Name: "es", Check: func(ctx context.Context) error { return esClient.Ping(ctx).Err() }},
+		{Name: "things_es", Check: func(ctx context.Context) error { return thingsESConn.Ping(ctx).Err() }},
+	}
 
 	g.Go(func() error {
-		return startHTTPServer(ctx, svc, cfg, logger)
+		return startHTTPServer(ctx, svc, auth, esClient, cfg, logger, checks)
 	})
 
 	go subscribeToThingsES(svc, thingsESConn, cfg.esConsumerName, logger)
 
+	if cfg.reconcileInterval > 0 {
+		thingsTracer, thingsCloser := initJaeger("things", cfg.jaegerURL, logger)
+		defer thingsCloser.Close()
+
+		thingsConn := connectToThings(cfg, logger)
+		defer thingsConn.Close()
+
+		tc := thingsapi.NewClient(thingsConn, thingsTracer, cfg.thingsGRPCTimeout)
+		r := bootstrap.NewReconciler(postgres.NewConfigRepository(db, logger), tc)
+		g.Go(func() error {
+			return reconcile(ctx, r, cfg.reconcileInterval, logger)
+		})
+	}
+
 	g.Go(func() error {
 		if sig := errors.SignalHandler(ctx); sig != nil {
 			cancel()
@@ -189,6 +233,14 @@ func loadConfig() config {
 	if err != nil {
 		log.Fatalf("Invalid %s value: %s", envAuthGRPCTimeout, err.Error())
 	}
+	thingsGRPCTimeout, err := time.ParseDuration(mainflux.Env(envThingsGRPCTimeout, defThingsGRPCTimeout))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envThingsGRPCTimeout, err.Error())
+	}
+	reconcileInterval, err := time.ParseDuration(mainflux.Env(envReconcileInterval, defReconcileInterval))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envReconcileInterval, err.Error())
+	}
 	encKey, err := hex.DecodeString(mainflux.Env(envEncryptKey, defEncryptKey))
 	if err != nil {
 		log.Fatalf("Invalid %s value: %s", envEncryptKey, err.Error())
@@ -200,26 +252,50 @@ func loadConfig() config {
 		log.Fatalf("Invalid %s value: %s", envEncryptKey, err.Error())
 	}
 
+	hstsMaxAge, err := time.ParseDuration(mainflux.Env(envHSTSMaxAge, defHSTSMaxAge))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envHSTSMaxAge, err.Error())
+	}
+
+	var v1Sunset time.Time
+	if sunset := mainflux.Env(envV1Sunset, defV1Sunset); sunset != "" {
+		if v1Sunset, err = time.Parse(time.RFC3339, sunset); err != nil {
+			log.Fatalf("Invalid %s value: %s", envV1Sunset, err.Error())
+		}
+	}
+
+	securityConfig := mfapi.SecurityConfig{
+		AllowedOrigins:        mfapi.ParseList(mainflux.Env(envCORSOrigins, defCORSOrigins)),
+		HSTSMaxAge:            hstsMaxAge,
+		ContentSecurityPolicy: mainflux.Env(envCSP, defCSP),
+	}
+
 	return config{
-		logLevel:        mainflux.Env(envLogLevel, defLogLevel),
-		dbConfig:        dbConfig,
-		clientTLS:       tls,
-		encKey:          encKey,
-		caCerts:         mainflux.Env(envCACerts, defCACerts),
-		httpPort:        mainflux.Env(envPort, defPort),
-		serverCert:      mainflux.Env(envServerCert, defServerCert),
-		serverKey:       mainflux.Env(envServerKey, defServerKey),
-		thingsURL:       mainflux.Env(envThingsURL, defThingsURL),
-		esThingsURL:     mainflux.Env(envThingsESURL, defThingsESURL),
-		esThingsPass:    mainflux.Env(envThingsESPass, defThingsESPass),
-		esThingsDB:      mainflux.Env(envThingsESDB, defThingsESDB),
-		esURL:           mainflux.Env(envESURL, defESURL),
-		esPass:          mainflux.Env(envESPass, defESPass),
-		esDB:            mainflux.Env(envESDB, defESDB),
-		esConsumerName:  mainflux.Env(envESConsumerName, defESConsumerName),
-		jaegerURL:       mainflux.Env(envJaegerURL, defJaegerURL),
-		authGRPCURL:     mainflux.Env(envAuthGRPCURL, defAuthGRPCURL),
-		authGRPCTimeout: authGRPCTimeout,
+		logLevel:          mainflux.Env(envLogLevel, defLogLevel),
+		dbConfig:          dbConfig,
+		clientTLS:         tls,
+		encKey:            encKey,
+		caCerts:           mainflux.Env(envCACerts, defCACerts),
+		httpPort:          mainflux.Env(envPort, defPort),
+		serverCert:        mainflux.Env(envServerCert, defServerCert),
+		serverKey:         mainflux.Env(envServerKey, defServerKey),
+		thingsURL:         mainflux.Env(envThingsURL, defThingsURL),
+		esThingsURL:       mainflux.Env(envThingsESURL, defThingsESURL),
+		esThingsPass:      mainflux.Env(envThingsESPass, defThingsESPass),
+		esThingsDB:        mainflux.Env(envThingsESDB, defThingsESDB),
+		esURL:             mainflux.Env(envESURL, defESURL),
+		esPass:            mainflux.Env(envESPass, defESPass),
+		esDB:              mainflux.Env(envESDB, defESDB),
+		auditHashKey:      mainflux.Env(envAuditHashKey, defAuditHashKey),
+		esConsumerName:    mainflux.Env(envESConsumerName, defESConsumerName),
+		jaegerURL:         mainflux.Env(envJaegerURL, defJaegerURL),
+		securityConfig:    securityConfig,
+		v1Sunset:          v1Sunset,
+		authGRPCURL:       mainflux.Env(envAuthGRPCURL, defAuthGRPCURL),
+		authGRPCTimeout:   authGRPCTimeout,
+		thingsGRPCURL:     mainflux.Env(envThingsGRPCURL, defThingsGRPCURL),
+		thingsGRPCTimeout: thingsGRPCTimeout,
+		reconcileInterval: reconcileInterval,
 	}
 }
 
@@ -279,7 +355,9 @@ func newService(ac mainflux.AuthServiceClient, db *sqlx.DB, logger logger.Logger
 
 	sdk := mfsdk.NewSDK(config)
 
-	svc := bootstrap.New(ac, thingsRepo, sdk, cfg.encKey)
+	claimsRepo := postgres.NewClaimTokenRepository(db, logger)
+
+	svc := bootstrap.New(ac, thingsRepo, claimsRepo, sdk, cfg.encKey)
 	svc = redisprod.NewEventStoreMiddleware(svc, esClient)
 	svc = api.NewLoggingMiddleware(svc, logger)
 	svc = api.MetricsMiddleware(
@@ -325,9 +403,36 @@ func connectToAuth(cfg config, logger logger.Logger) *grpc.ClientConn {
 	return conn
 }
 
-func startHTTPServer(ctx context.Context, svc bootstrap.Service, cfg config, logger logger.Logger) error {
+func connectToThings(cfg config, logger logger.Logger) *grpc.ClientConn {
+	var opts []grpc.DialOption
+	if cfg.clientTLS {
+		if cfg.caCerts != "" {
+			tpc, err := credentials.NewClientTLSFromFile(cfg.caCerts, "")
+			if err != nil {
+				logger.Error(fmt.Sprintf("Failed to create tls credentials: %s", err))
+				os.Exit(1)
+			}
+			opts = append(opts, grpc.WithTransportCredentials(tpc))
+		}
+	} else {
+		opts = append(opts, grpc.WithInsecure())
+		logger.Info("gRPC communication is not encrypted")
+	}
+
+	conn, err := grpc.Dial(cfg.thingsGRPCURL, opts...)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to connect to things service: %s", err))
+		os.Exit(1)
+	}
+
+	return conn
+}
+
+func startHTTPServer(ctx context.Context, svc bootstrap.Service, auth mainflux.AuthServiceClient, esClient *r.Client, cfg config, logger logger.Logger, checks []mainflux.HealthCheck) error {
 	p := fmt.Sprintf(":%s", cfg.httpPort)
-	server := &http.Server{Addr: p, Handler: api.MakeHandler(svc, bootstrap.NewConfigReader(cfg.encKey), logger)}
+	handler := auditredis.NewHandler(api.MakeHandler(svc, bootstrap.NewConfigReader(cfg.encKey), logger, checks...), "bootstrap", auth, esClient, cfg.auditHashKey, logger)
+	handler = mfapi.SecurityMiddleware(cfg.securityConfig, mfapi.Versioned(handler, handler, cfg.v1Sunset))
+	server := &http.Server{Addr: p, Handler: handler}
 	errCh := make(chan error)
 	protocol := httpProtocol
 	switch {
@@ -368,3 +473,22 @@ func subscribeToThingsES(svc bootstrap.Service, client *r.Client, consumer strin
 		logger.Warn(fmt.Sprintf("Bootstrap service failed to subscribe to event sourcing: %s", err))
 	}
 }
+
+// reconcile periodically reconciles the configs with the things service,
+// until the context is canceled.
+func reconcile(ctx context.Context, r bootstrap.Reconciler, interval time.Duration, logger logger.Logger) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := r.Reconcile(ctx); err != nil {
+			logger.Warn(fmt.Sprintf("Failed to reconcile bootstrap configs: %s", err))
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,432 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux"
+	authapi "github.com/MainfluxLabs/mainflux/auth/api/grpc"
+	"github.com/MainfluxLabs/mainflux/bridge"
+	"github.com/MainfluxLabs/mainflux/bridge/api"
+	bridgebroker "github.com/MainfluxLabs/mainflux/bridge/broker"
+	"github.com/MainfluxLabs/mainflux/bridge/cloud"
+	"github.com/MainfluxLabs/mainflux/bridge/file"
+	"github.com/MainfluxLabs/mainflux/logger"
+	mfapi "github.com/MainfluxLabs/mainflux/pkg/api"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging/brokers"
+	kitprometheus "github.com/go-kit/kit/metrics/prometheus"
+	opentracing "github.com/opentracing/opentracing-go"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+	jconfig "github.com/uber/jaeger-client-go/config"
+	"golang.org/x/sync/errgroup"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+const (
+	stopWaitTime = 5 * time.Second
+	svcName      = "bridge"
+
+	defLogLevel        = "error"
+	defBrokerURL       = "nats://localhost:4222"
+	defCloudURL        = ""
+	defCloudConfirm    = "false"
+	defCloudTimeout    = "5s"
+	defCloudProvider   = ""
+	defCloudClientID   = "mainflux-bridge"
+	defCloudCert       = ""
+	defCloudKey        = ""
+	defCloudCACerts    = ""
+	defCloudSASKey     = ""
+	defCloudSASTTL     = "1h"
+	defCloudPrefix     = "mainflux"
+	defChannels        = ""
+	defBufferDir       = "/var/lib/mainfluxlabs/bridge"
+	defBufferMaxSize   = "1073741824"
+	defBatchSize       = "100"
+	defSyncInterval    = "1s"
+	defHTTPPort        = "8199"
+	defServerCert      = ""
+	defServerKey       = ""
+	defJaegerURL       = ""
+	defAuthTLS         = "false"
+	defAuthCACerts     = ""
+	defAuthGRPCURL     = "localhost:8181"
+	defAuthGRPCTimeout = "1s"
+
+	envLogLevel        = "MF_BRIDGE_LOG_LEVEL"
+	envBrokerURL       = "MF_BROKER_URL"
+	envCloudURL        = "MF_BRIDGE_CLOUD_URL"
+	envCloudConfirm    = "MF_BRIDGE_CLOUD_CONFIRM"
+	envCloudTimeout    = "MF_BRIDGE_CLOUD_TIMEOUT"
+	envCloudProvider   = "MF_BRIDGE_CLOUD_PROVIDER"
+	envCloudClientID   = "MF_BRIDGE_CLOUD_CLIENT_ID"
+	envCloudCert       = "MF_BRIDGE_CLOUD_CERT"
+	envCloudKey        = "MF_BRIDGE_CLOUD_KEY"
+	envCloudCACerts    = "MF_BRIDGE_CLOUD_CA_CERTS"
+	envCloudSASKey     = "MF_BRIDGE_CLOUD_SAS_KEY"
+	envCloudSASTTL     = "MF_BRIDGE_CLOUD_SAS_TTL"
+	envCloudPrefix     = "MF_BRIDGE_CLOUD_TOPIC_PREFIX"
+	envChannels        = "MF_BRIDGE_CHANNELS"
+	envBufferDir       = "MF_BRIDGE_BUFFER_DIR"
+	envBufferMaxSize   = "MF_BRIDGE_BUFFER_MAX_SIZE"
+	envBatchSize       = "MF_BRIDGE_BATCH_SIZE"
+	envSyncInterval    = "MF_BRIDGE_SYNC_INTERVAL"
+	envHTTPPort        = "MF_BRIDGE_HTTP_PORT"
+	envServerCert      = "MF_BRIDGE_SERVER_CERT"
+	envServerKey       = "MF_BRIDGE_SERVER_KEY"
+	envJaegerURL       = "MF_JAEGER_URL"
+	envAuthTLS         = "MF_AUTH_CLIENT_TLS"
+	envAuthCACerts     = "MF_AUTH_CA_CERTS"
+	envAuthGRPCURL     = "MF_AUTH_GRPC_URL"
+	envAuthGRPCTimeout = "MF_AUTH_GRPC_TIMEOUT"
+)
+
+type config struct {
+	logLevel        string
+	brokerURL       string
+	cloudURL        string
+	cloudConfirm    bool
+	cloudTimeout    time.Duration
+	cloudProvider   string
+	cloudClientID   string
+	cloudCert       string
+	cloudKey        string
+	cloudCACerts    string
+	cloudSASKey     string
+	cloudSASTTL     time.Duration
+	cloudPrefix     string
+	channels        []string
+	bufferDir       string
+	bufferMaxSize   uint64
+	batchSize       int
+	syncInterval    time.Duration
+	httpPort        string
+	serverCert      string
+	serverKey       string
+	jaegerURL       string
+	authTLS         bool
+	authCACerts     string
+	authGRPCURL     string
+	authGRPCTimeout time.Duration
+}
+
+func main() {
+	cfg := loadConfig()
+	ctx, cancel := context.WithCancel(context.Background())
+	g, ctx := errgroup.WithContext(ctx)
+
+	logger, err := logger.New(os.Stdout, cfg.logLevel)
+	if err != nil {
+		log.Fatalf(err.Error())
+	}
+
+	if cfg.cloudURL == "" {
+		logger.Error(fmt.Sprintf("Missing central message broker URL, set %s", envCloudURL))
+		os.Exit(1)
+	}
+	if cfg.cloudProvider == "" {
+		if _, err := messaging.Scheme(cfg.cloudURL); err != nil {
+			logger.Error(fmt.Sprintf("Invalid %s value: %s", envCloudURL, err))
+			os.Exit(1)
+		}
+	}
+
+	buffer, err := file.New(cfg.bufferDir, cfg.bufferMaxSize)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to open buffer in %s: %s", cfg.bufferDir, err))
+		os.Exit(1)
+	}
+	defer buffer.Close()
+
+	pubSub, err := brokers.NewPubSub(cfg.brokerURL, "", logger)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to connect to message broker: %s", err))
+		os.Exit(1)
+	}
+	defer pubSub.Close()
+
+	authTracer, authCloser := initJaeger("auth", cfg.jaegerURL, logger)
+	defer authCloser.Close()
+
+	auth, close := connectToAuth(cfg, authTracer, logger)
+	if close != nil {
+		defer close()
+	}
+
+	tracer, closer := initJaeger("bridge", cfg.jaegerURL, logger)
+	defer closer.Close()
+
+	fwd, closeFwd := newForwarder(cfg, pubSub, logger)
+	if closeFwd != nil {
+		defer closeFwd()
+	}
+
+	svc := newService(buffer, auth, fwd, cfg, logger)
+	checks := []mainflux.HealthCheck{
+		messaging.HealthCheck(pubSub),
+	}
+
+	for _, subject := range subjects(cfg.channels) {
+		if err := pubSub.Subscribe(svcName, subject, bridge.NewHandler(svc)); err != nil {
+			logger.Error(fmt.Sprintf("Failed to subscribe to message broker: %s", err))
+			os.Exit(1)
+		}
+	}
+
+	g.Go(func() error {
+		return bridge.Run(ctx, svc, cfg.syncInterval)
+	})
+
+	g.Go(func() error {
+		return startHTTPServer(ctx, tracer, svc, cfg.httpPort, cfg.serverCert, cfg.serverKey, logger, checks)
+	})
+
+	g.Go(func() error {
+		if sig := errors.SignalHandler(ctx); sig != nil {
+			cancel()
+			logger.Info(fmt.Sprintf("Bridge service shutdown by signal: %s", sig))
+		}
+		return nil
+	})
+
+	if err := g.Wait(); err != nil {
+		logger.Error(fmt.Sprintf("Bridge service terminated: %s", err))
+	}
+}
+
+func loadConfig() config {
+	authGRPCTimeout, err := time.ParseDuration(mainflux.Env(envAuthGRPCTimeout, defAuthGRPCTimeout))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envAuthGRPCTimeout, err.Error())
+	}
+
+	cloudTimeout, err := time.ParseDuration(mainflux.Env(envCloudTimeout, defCloudTimeout))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envCloudTimeout, err.Error())
+	}
+
+	cloudSASTTL, err := time.ParseDuration(mainflux.Env(envCloudSASTTL, defCloudSASTTL))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envCloudSASTTL, err.Error())
+	}
+
+	syncInterval, err := time.ParseDuration(mainflux.Env(envSyncInterval, defSyncInterval))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envSyncInterval, err.Error())
+	}
+
+	cloudConfirm, err := strconv.ParseBool(mainflux.Env(envCloudConfirm, defCloudConfirm))
+	if err != nil {
+		log.Fatalf("Invalid value passed for %s\n", envCloudConfirm)
+	}
+
+	bufferMaxSize, err := strconv.ParseUint(mainflux.Env(envBufferMaxSize, defBufferMaxSize), 10, 64)
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envBufferMaxSize, err.Error())
+	}
+
+	batchSize, err := strconv.Atoi(mainflux.Env(envBatchSize, defBatchSize))
+	if err != nil || batchSize < 1 {
+		log.Fatalf("Invalid value passed for %s\n", envBatchSize)
+	}
+
+	tls, err := strconv.ParseBool(mainflux.Env(envAuthTLS, defAuthTLS))
+	if err != nil {
+		log.Fatalf("Invalid value passed for %s\n", envAuthTLS)
+	}
+
+	return config{
+		logLevel:        mainflux.Env(envLogLevel, defLogLevel),
+		brokerURL:       mainflux.Env(envBrokerURL, defBrokerURL),
+		cloudURL:        mainflux.Env(envCloudURL, defCloudURL),
+		cloudConfirm:    cloudConfirm,
+		cloudTimeout:    cloudTimeout,
+		cloudProvider:   mainflux.Env(envCloudProvider, defCloudProvider),
+		cloudClientID:   mainflux.Env(envCloudClientID, defCloudClientID),
+		cloudCert:       mainflux.Env(envCloudCert, defCloudCert),
+		cloudKey:        mainflux.Env(envCloudKey, defCloudKey),
+		cloudCACerts:    mainflux.Env(envCloudCACerts, defCloudCACerts),
+		cloudSASKey:     mainflux.Env(envCloudSASKey, defCloudSASKey),
+		cloudSASTTL:     cloudSASTTL,
+		cloudPrefix:     mainflux.Env(envCloudPrefix, defCloudPrefix),
+		channels:        mfapi.ParseList(mainflux.Env(envChannels, defChannels)),
+		bufferDir:       mainflux.Env(envBufferDir, defBufferDir),
+		bufferMaxSize:   bufferMaxSize,
+		batchSize:       batchSize,
+		syncInterval:    syncInterval,
+		httpPort:        mainflux.Env(envHTTPPort, defHTTPPort),
+		serverCert:      mainflux.Env(envServerCert, defServerCert),
+		serverKey:       mainflux.Env(envServerKey, defServerKey),
+		jaegerURL:       mainflux.Env(envJaegerURL, defJaegerURL),
+		authTLS:         tls,
+		authCACerts:     mainflux.Env(envAuthCACerts, defAuthCACerts),
+		authGRPCURL:     mainflux.Env(envAuthGRPCURL, defAuthGRPCURL),
+		authGRPCTimeout: authGRPCTimeout,
+	}
+}
+
+func initJaeger(svcName, url string, logger logger.Logger) (opentracing.Tracer, io.Closer) {
+	if url == "" {
+		return opentracing.NoopTracer{}, ioutil.NopCloser(nil)
+	}
+
+	tracer, closer, err := jconfig.Configuration{
+		ServiceName: svcName,
+		Sampler: &jconfig.SamplerConfig{
+			Type:  "const",
+			Param: 1,
+		},
+		Reporter: &jconfig.ReporterConfig{
+			LocalAgentHostPort: url,
+			LogSpans:           true,
+		},
+	}.NewTracer()
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to init Jaeger: %s", err))
+		os.Exit(1)
+	}
+
+	return tracer, closer
+}
+
+func connectToAuth(cfg config, tracer opentracing.Tracer, logger logger.Logger) (mainflux.AuthServiceClient, func() error) {
+	var opts []grpc.DialOption
+	if cfg.authTLS {
+		if cfg.authCACerts != "" {
+			tpc, err := credentials.NewClientTLSFromFile(cfg.authCACerts, "")
+			if err != nil {
+				logger.Error(fmt.Sprintf("Failed to create tls credentials: %s", err))
+				os.Exit(1)
+			}
+			opts = append(opts, grpc.WithTransportCredentials(tpc))
+		}
+	} else {
+		opts = append(opts, grpc.WithInsecure())
+		logger.Info("gRPC communication is not encrypted")
+	}
+
+	conn, err := grpc.Dial(cfg.authGRPCURL, opts...)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to connect to auth service: %s", err))
+		os.Exit(1)
+	}
+
+	return authapi.NewClient(tracer, conn, cfg.authGRPCTimeout), conn.Close
+}
+
+func subjects(channels []string) []string {
+	if len(channels) == 0 {
+		return []string{brokers.SubjectAllChannels}
+	}
+
+	var subjects []string
+	for _, ch := range channels {
+		subjects = append(subjects, fmt.Sprintf("channels.%s", ch), fmt.Sprintf("channels.%s.>", ch))
+	}
+
+	return subjects
+}
+
+func newForwarder(cfg config, pub messaging.Publisher, logger logger.Logger) (bridge.Forwarder, func() error) {
+	var provider cloud.Provider
+	switch cfg.cloudProvider {
+	case "":
+		connect := func() (messaging.Publisher, error) {
+			return brokers.NewPublisher(cfg.cloudURL)
+		}
+		return bridgebroker.NewForwarder(connect, cfg.cloudConfirm, cfg.cloudTimeout), nil
+	case cloud.AWS:
+		provider = cloud.NewAWS(cfg.cloudPrefix, cfg.cloudCert, cfg.cloudKey, cfg.cloudCACerts)
+	case cloud.Azure:
+		u, err := url.Parse(cfg.cloudURL)
+		if err != nil {
+			logger.Error(fmt.Sprintf("Invalid %s value: %s", envCloudURL, err))
+			os.Exit(1)
+		}
+		provider, err = cloud.NewAzure(u.Hostname(), cfg.cloudClientID, cfg.cloudSASKey, cfg.cloudSASTTL)
+		if err != nil {
+			logger.Error(fmt.Sprintf("Invalid %s value: %s", envCloudSASKey, err))
+			os.Exit(1)
+		}
+	default:
+		logger.Error(fmt.Sprintf("Invalid %s value: %s", envCloudProvider, cfg.cloudProvider))
+		os.Exit(1)
+	}
+
+	fwd, err := cloud.NewForwarder(cfg.cloudURL, cfg.cloudClientID, provider, pub, cfg.cloudTimeout, logger)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to connect to %s cloud IoT service: %s", cfg.cloudProvider, err))
+		os.Exit(1)
+	}
+
+	return fwd, fwd.Close
+}
+
+func newService(buffer bridge.Buffer, auth mainflux.AuthServiceClient, fwd bridge.Forwarder, cfg config, logger logger.Logger) bridge.Service {
+	svc := bridge.New(auth, buffer, fwd, cfg.batchSize)
+	svc = api.LoggingMiddleware(svc, logger)
+	svc = api.MetricsMiddleware(
+		svc,
+		kitprometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: "bridge",
+			Subsystem: "api",
+			Name:      "request_count",
+			Help:      "Number of requests received.",
+		}, []string{"method"}),
+		kitprometheus.NewSummaryFrom(stdprometheus.SummaryOpts{
+			Namespace: "bridge",
+			Subsystem: "api",
+			Name:      "request_latency_microseconds",
+			Help:      "Total duration of requests in microseconds.",
+		}, []string{"method"}),
+	)
+
+	return svc
+}
+
+func startHTTPServer(ctx context.Context, tracer opentracing.Tracer, svc bridge.Service, port string, certFile string, keyFile string, logger logger.Logger, checks []mainflux.HealthCheck) error {
+	p := fmt.Sprintf(":%s", port)
+	errCh := make(chan error)
+	server := &http.Server{Addr: p, Handler: api.MakeHandler(svc, tracer, logger, checks...)}
+
+	switch {
+	case certFile != "" || keyFile != "":
+		logger.Info(fmt.Sprintf("Bridge service started using https, cert %s key %s, exposed port %s", certFile, keyFile, port))
+		go func() {
+			errCh <- server.ListenAndServeTLS(certFile, keyFile)
+		}()
+	default:
+		logger.Info(fmt.Sprintf("Bridge service started using http, exposed port %s", port))
+		go func() {
+			errCh <- server.ListenAndServe()
+		}()
+	}
+
+	select {
+	case <-ctx.Done():
+		ctxShutdown, cancelShutdown := context.WithTimeout(context.Background(), stopWaitTime)
+		defer cancelShutdown()
+		if err := server.Shutdown(ctxShutdown); err != nil {
+			logger.Error(fmt.Sprintf("Bridge service error occurred during shutdown at %s: %s", p, err))
+			return fmt.Errorf("bridge service error occurred during shutdown at %s: %w", p, err)
+		}
+		logger.Info(fmt.Sprintf("Bridge service shutdown of http at %s", p))
+		return nil
+	case err := <-errCh:
+		return err
+	}
+}
@@ -21,10 +21,12 @@ import (
 	"github.com/MainfluxLabs/mainflux/certs/api"
 	vault "github.com/MainfluxLabs/mainflux/certs/pki"
 	"github.com/MainfluxLabs/mainflux/certs/postgres"
+	rediscons "github.com/MainfluxLabs/mainflux/certs/redis/consumer"
 	"github.com/MainfluxLabs/mainflux/logger"
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
 	mfsdk "github.com/MainfluxLabs/mainflux/pkg/sdk/go"
 	kitprometheus "github.com/go-kit/kit/metrics/prometheus"
+	r "github.com/go-redis/redis/v8"
 	"github.com/jmoiron/sqlx"
 	"github.com/opentracing/opentracing-go"
 	stdprometheus "github.com/prometheus/client_golang/prometheus"
@@ -57,6 +59,10 @@ const (
 	defJaegerURL       = ""
 	defAuthGRPCURL     = "localhost:8181"
 	defAuthGRPCTimeout = "1s"
+	defThingsESURL     = "localhost:6379"
+	defThingsESPass    = ""
+	defThingsESDB      = "0"
+	defESConsumerName  = "certs"
 
 	defSignCAPath     = "ca.crt"
 	defSignCAKeyPath  = "ca.key"
@@ -88,6 +94,10 @@ const (
 	envAuthGRPCURL     = "MF_AUTH_GRPC_URL"
 	envAuthGRPCTimeout = "MF_AUTH_GRPC_TIMEOUT"
 	envThingsURL       = "MF_THINGS_URL"
+	envThingsESURL     = "MF_THINGS_ES_URL"
+	envThingsESPass    = "MF_THINGS_ES_PASS"
+	envThingsESDB      = "MF_THINGS_ES_DB"
+	envESConsumerName  = "MF_CERTS_EVENT_CONSUMER"
 	envSignCAPath      = "MF_CERTS_SIGN_CA_PATH"
 	envSignCAKey       = "MF_CERTS_SIGN_CA_KEY_PATH"
 	envSignHoursValid  = "MF_CERTS_SIGN_HOURS_VALID"
@@ -119,6 +129,10 @@ type config struct {
 	jaegerURL       string
 	authGRPCURL     string
 	authGRPCTimeout time.Duration
+	esThingsURL     string
+	esThingsPass    string
+	esThingsDB      string
+	esConsumerName  string
 	// Sign and issue certificates without 3rd party PKI
 	signCAPath     string
 	signCAKeyPath  string
@@ -166,10 +180,20 @@ func main() {
 
 	auth := authapi.NewClient(authTracer, authConn, cfg.authGRPCTimeout)
 
+	thingsESConn := connectToRedis(cfg.esThingsURL, cfg.esThingsPass, cfg.esThingsDB, logger)
+	defer thingsESConn.Close()
+
 	svc := newService(auth, db, logger, tlsCert, caCert, cfg, pkiClient)
 
+	go subscribeToThingsES(svc, thingsESConn, cfg.esConsumerName, logger)
+
+	checks := []mainflux.HealthCheck{
+		{Name: "database", Check: db.PingContext},
+		{Name: "things_es", Check: func(ctx context.Context) error { return thingsESConn.Ping(ctx).Err() }},
+	}
+
 	g.Go(func() error {
-		return startHTTPServer(ctx, svc, cfg, logger)
+		return startHTTPServer(ctx, svc, cfg, logger, checks)
 	})
 
 	g.Go(func() error {
@@ -225,6 +249,10 @@ func loadConfig() config {
 		jaegerURL:       mainflux.Env(envJaegerURL, defJaegerURL),
 		authGRPCURL:     mainflux.Env(envAuthGRPCURL, defAuthGRPCURL),
 		authGRPCTimeout: authGRPCTimeout,
+		esThingsURL:     mainflux.Env(envThingsESURL, defThingsESURL),
+		esThingsPass:    mainflux.Env(envThingsESPass, defThingsESPass),
+		esThingsDB:      mainflux.Env(envThingsESDB, defThingsESDB),
+		esConsumerName:  mainflux.Env(envESConsumerName, defESConsumerName),
 
 		signCAKeyPath:  mainflux.Env(envSignCAKey, defSignCAKeyPath),
 		signCAPath:     mainflux.Env(envSignCAPath, defSignCAPath),
@@ -248,6 +276,20 @@ func connectToDB(dbConfig postgres.Config, logger logger.Logger) *sqlx.DB {
 	return db
 }
 
+func connectToRedis(redisURL, redisPass, redisDB string, logger logger.Logger) *r.Client {
+	db, err := strconv.Atoi(redisDB)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to connect to redis: %s", err))
+		os.Exit(1)
+	}
+
+	return r.NewClient(&r.Options{
+		Addr:     redisURL,
+		Password: redisPass,
+		DB:       db,
+	})
+}
+
 func connectToAuth(cfg config, logger logger.Logger) *grpc.ClientConn {
 	var opts []grpc.DialOption
 	if cfg.clientTLS {
@@ -348,10 +390,10 @@ func newService(ac mainflux.AuthServiceClient, db *sqlx.DB, logger logger.Logger
 	return svc
 }
 
-func startHTTPServer(ctx context.Context, svc certs.Service, cfg config, logger logger.Logger) error {
+func startHTTPServer(ctx context.Context, svc certs.Service, cfg config, logger logger.Logger, checks []mainflux.HealthCheck) error {
 	p := fmt.Sprintf(":%s", cfg.httpPort)
 	errCh := make(chan error)
-	server := &http.Server{Addr: p, Handler: api.MakeHandler(svc, logger)}
+	server := &http.Server{Addr: p, Handler: api.MakeHandler(svc, logger, checks...)}
 	switch {
 	case cfg.serverCert != "" || cfg.serverKey != "":
 		logger.Info(fmt.Sprintf("Certs service started using https on port %s with cert %s key %s", cfg.httpPort, cfg.serverCert, cfg.serverKey))
@@ -362,7 +404,7 @@ func startHTTPServer(ctx context.Context, svc certs.Service, cfg config, logger
 	default:
 		logger.Info(fmt.Sprintf("Certs service started using http on port %s", cfg.httpPort))
 		go func() {
-			errCh <- http.ListenAndServe(p, api.MakeHandler(svc, logger))
+			errCh <- http.ListenAndServe(p, api.MakeHandler(svc, logger, checks...))
 		}()
 	}
 	select {
@@ -418,3 +460,11 @@ func loadCertificates(conf config) (tls.Certificate, *x509.Certificate, error) {
 
 	return tlsCert, caCert, nil
 }
+
+func subscribeToThingsES(svc certs.Service, client *r.Client, consumer string, logger logger.Logger) {
+	eventStore := rediscons.NewEventStore(svc, client, consumer, logger)
+	logger.Info("Subscribed to Redis Event Store")
+	if err := eventStore.Subscribe(context.Background(), "mainflux.things"); err != nil {
+		logger.Warn(fmt.Sprintf("Certs service failed to subscribe to event sourcing: %s", err))
+	}
+}
@@ -12,7 +12,10 @@ import (
 	"github.com/spf13/cobra"
 )
 
-const defURL string = "http://localhost"
+const (
+	defURL   string = "http://localhost"
+	defWSURL string = "ws://localhost:8190"
+)
 
 func main() {
 	msgContentType := string(sdk.CTJSONSenML)
@@ -22,6 +25,7 @@ func main() {
 		UsersURL:        defURL,
 		ReaderURL:       defURL,
 		HTTPAdapterURL:  fmt.Sprintf("%s/http", defURL),
+		WSAdapterURL:    defWSURL,
 		BootstrapURL:    defURL,
 		CertsURL:        defURL,
 		MsgContentType:  sdk.ContentType(msgContentType),
@@ -51,6 +55,7 @@ func main() {
 	bootstrapCmd := cli.NewBootstrapCmd()
 	certsCmd := cli.NewCertsCmd()
 	keysCmd := cli.NewKeysCmd()
+	applyCmd := cli.NewApplyCmd()
 
 	// Root Commands
 	rootCmd.AddCommand(healthCmd)
@@ -63,6 +68,7 @@ func main() {
 	rootCmd.AddCommand(bootstrapCmd)
 	rootCmd.AddCommand(certsCmd)
 	rootCmd.AddCommand(keysCmd)
+	rootCmd.AddCommand(applyCmd)
 
 	// Root Flags
 	rootCmd.PersistentFlags().StringVarP(
@@ -113,6 +119,14 @@ func main() {
 		"HTTP adapter URL",
 	)
 
+	rootCmd.PersistentFlags().StringVarP(
+		&sdkConf.WSAdapterURL,
+		"ws-url",
+		"w",
+		sdkConf.WSAdapterURL,
+		"WebSocket adapter URL",
+	)
+
 	rootCmd.PersistentFlags().StringVarP(
 		&msgContentType,
 		"content-type",
@@ -0,0 +1,186 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux"
+	"github.com/MainfluxLabs/mainflux/consumers"
+	"github.com/MainfluxLabs/mainflux/consumers/deriver"
+	"github.com/MainfluxLabs/mainflux/consumers/writers/api"
+	"github.com/MainfluxLabs/mainflux/logger"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging/brokers"
+	mfnats "github.com/MainfluxLabs/mainflux/pkg/messaging/nats"
+	kitprometheus "github.com/go-kit/kit/metrics/prometheus"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	svcName      = "deriver"
+	stopWaitTime = 5 * time.Second
+
+	defLogLevel   = "error"
+	defBrokerURL  = "nats://localhost:4222"
+	defPort       = "8201"
+	defConfigPath = "/config.toml"
+
+	envBrokerURL  = "MF_BROKER_URL"
+	envLogLevel   = "MF_DERIVER_LOG_LEVEL"
+	envPort       = "MF_DERIVER_PORT"
+	envConfigPath = "MF_DERIVER_CONFIG_PATH"
+)
+
+type config struct {
+	brokerURL  string
+	jetStream  *brokers.JetStreamConfig
+	logLevel   string
+	port       string
+	configPath string
+}
+
+func main() {
+	cfg := loadConfig()
+	ctx, cancel := context.WithCancel(context.Background())
+	g, ctx := errgroup.WithContext(ctx)
+
+	logger, err := logger.New(os.Stdout, cfg.logLevel)
+	if err != nil {
+		log.Fatalf(err.Error())
+	}
+
+	derivations, err := deriver.LoadDerivations(cfg.configPath)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to load derivations: %s", err))
+		os.Exit(1)
+	}
+
+	pubSub, err := connectToBroker(cfg, logger)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to connect to message broker: %s", err))
+		os.Exit(1)
+	}
+	defer pubSub.Close()
+
+	repo, err := newService(pubSub, derivations, logger)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to create deriver: %s", err))
+		os.Exit(1)
+	}
+
+	if err = consumers.Start(svcName, pubSub, repo, nil, cfg.configPath, logger); err != nil {
+		logger.Error(fmt.Sprintf("Failed to create deriver: %s", err))
+		os.Exit(1)
+	}
+
+	checks := []mainflux.HealthCheck{
+		messaging.HealthCheck(pubSub),
+	}
+
+	g.Go(func() error {
+		return startHTTPServer(ctx, cfg.port, logger, checks)
+	})
+
+	g.Go(func() error {
+		if sig := errors.SignalHandler(ctx); sig != nil {
+			cancel()
+			logger.Info(fmt.Sprintf("Deriver service shutdown by signal: %s", sig))
+		}
+		return nil
+	})
+
+	if err := g.Wait(); err != nil {
+		logger.Error(fmt.Sprintf("Deriver service terminated: %s", err))
+	}
+}
+
+func loadConfig() config {
+	jetStream, err := mfnats.LoadJetStreamConfig()
+	if err != nil {
+		log.Fatalf("Failed to load JetStream config: %s", err)
+	}
+
+	return config{
+		brokerURL:  mainflux.Env(envBrokerURL, defBrokerURL),
+		jetStream:  jetStream,
+		logLevel:   mainflux.Env(envLogLevel, defLogLevel),
+		port:       mainflux.Env(envPort, defPort),
+		configPath: mainflux.Env(envConfigPath, defConfigPath),
+	}
+}
+
+func newService(pub messaging.Publisher, derivations []deriver.Derivation, logger logger.Logger) (consumers.Consumer, error) {
+	svc, err := deriver.New(pub, derivations, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	svc = api.LoggingMiddleware(svc, logger)
+	svc = api.MetricsMiddleware(
+		svc,
+		kitprometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: "deriver",
+			Subsystem: "message_writer",
+			Name:      "request_count",
+			Help:      "Number of requests received.",
+		}, []string{"method"}),
+		kitprometheus.NewSummaryFrom(stdprometheus.SummaryOpts{
+			Namespace: "deriver",
+			Subsystem: "message_writer",
+			Name:      "request_latency_microseconds",
+			Help:      "Total duration of requests in microseconds.",
+		}, []string{"method"}),
+	)
+
+	return svc, nil
+}
+
+func startHTTPServer(ctx context.Context, port string, logger logger.Logger, checks []mainflux.HealthCheck) error {
+	p := fmt.Sprintf(":%s", port)
+	errCh := make(chan error)
+	server := &http.Server{Addr: p, Handler: api.MakeHandler(svcName, checks...)}
+
+	logger.Info(fmt.Sprintf("Deriver service started, exposed port %s", port))
+	go func() {
+		errCh <- server.ListenAndServe()
+	}()
+
+	select {
+	case <-ctx.Done():
+		ctxShutdown, cancelShutdown := context.WithTimeout(context.Background(), stopWaitTime)
+		defer cancelShutdown()
+		if err := server.Shutdown(ctxShutdown); err != nil {
+			logger.Error(fmt.Sprintf("Deriver service error occurred during shutdown at %s: %s", p, err))
+			return fmt.Errorf("deriver service occurred during shutdown at %s: %w", p, err)
+		}
+		logger.Info(fmt.Sprintf("Deriver service shutdown of http at %s", p))
+		return nil
+	case err := <-errCh:
+		return err
+	}
+}
+
+func connectToBroker(cfg config, logger logger.Logger) (messaging.PubSub, error) {
+	var pubSub messaging.PubSub
+	var err error
+	switch {
+	case cfg.jetStream != nil:
+		pubSub, err = brokers.NewJetStreamPubSub(cfg.brokerURL, "", *cfg.jetStream, logger)
+	default:
+		pubSub, err = brokers.NewPubSub(cfg.brokerURL, "", logger)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return pubSub, nil
+}
@@ -0,0 +1,432 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux"
+	authapi "github.com/MainfluxLabs/mainflux/auth/api/grpc"
+	"github.com/MainfluxLabs/mainflux/consumers"
+	"github.com/MainfluxLabs/mainflux/consumers/exporters"
+	"github.com/MainfluxLabs/mainflux/consumers/exporters/api"
+	"github.com/MainfluxLabs/mainflux/consumers/exporters/bigquery"
+	"github.com/MainfluxLabs/mainflux/consumers/exporters/kafka"
+	"github.com/MainfluxLabs/mainflux/consumers/exporters/postgres"
+	"github.com/MainfluxLabs/mainflux/consumers/exporters/s3"
+	"github.com/MainfluxLabs/mainflux/logger"
+	"github.com/MainfluxLabs/mainflux/pkg/archive"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging/brokers"
+	mfnats "github.com/MainfluxLabs/mainflux/pkg/messaging/nats"
+	"github.com/MainfluxLabs/mainflux/pkg/uuid"
+	thingsapi "github.com/MainfluxLabs/mainflux/things/api/auth/grpc"
+	kitprometheus "github.com/go-kit/kit/metrics/prometheus"
+	"github.com/jmoiron/sqlx"
+	opentracing "github.com/opentracing/opentracing-go"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+	jconfig "github.com/uber/jaeger-client-go/config"
+	"golang.org/x/sync/errgroup"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+const (
+	svcName      = "exporter"
+	stopWaitTime = 5 * time.Second
+
+	defLogLevel            = "error"
+	defBrokerURL           = "nats://localhost:4222"
+	defDBHost              = "localhost"
+	defDBPort              = "5432"
+	defDBUser              = "mainflux"
+	defDBPass              = "mainflux"
+	defDB                  = "exporter"
+	defDBSSLMode           = "disable"
+	defDBSSLCert           = ""
+	defDBSSLKey            = ""
+	defDBSSLRootCert       = ""
+	defConfigPath          = "/config.toml"
+	defHTTPPort            = "8198"
+	defServerCert          = ""
+	defServerKey           = ""
+	defJaegerURL           = ""
+	defClientTLS           = "false"
+	defCACerts             = ""
+	defAuthGRPCURL         = "localhost:8181"
+	defAuthGRPCTimeout     = "1s"
+	defThingsGRPCURL       = "localhost:8183"
+	defThingsGRPCTimeout   = "1s"
+	defSinkTimeout         = "30s"
+	defKafkaURL            = ""
+	defS3Endpoint          = ""
+	defS3Region            = "us-east-1"
+	defS3Bucket            = "mainflux"
+	defS3AccessKey         = ""
+	defS3SecretKey         = ""
+	defBigQueryURL         = ""
+	defBigQueryCredentials = ""
+
+	envLogLevel            = "MF_EXPORTER_LOG_LEVEL"
+	envBrokerURL           = "MF_BROKER_URL"
+	envDBHost              = "MF_EXPORTER_DB_HOST"
+	envDBPort              = "MF_EXPORTER_DB_PORT"
+	envDBUser              = "MF_EXPORTER_DB_USER"
+	envDBPass              = "MF_EXPORTER_DB_PASS"
+	envDB                  = "MF_EXPORTER_DB"
+	envDBSSLMode           = "MF_EXPORTER_DB_SSL_MODE"
+	envDBSSLCert           = "MF_EXPORTER_DB_SSL_CERT"
+	envDBSSLKey            = "MF_EXPORTER_DB_SSL_KEY"
+	envDBSSLRootCert       = "MF_EXPORTER_DB_SSL_ROOT_CERT"
+	envConfigPath          = "MF_EXPORTER_CONFIG_PATH"
+	envHTTPPort            = "MF_EXPORTER_HTTP_PORT"
+	envServerCert          = "MF_EXPORTER_SERVER_CERT"
+	envServerKey           = "MF_EXPORTER_SERVER_KEY"
+	envJaegerURL           = "MF_JAEGER_URL"
+	envClientTLS           = "MF_EXPORTER_CLIENT_TLS"
+	envCACerts             = "MF_EXPORTER_CA_CERTS"
+	envAuthGRPCURL         = "MF_AUTH_GRPC_URL"
+	envAuthGRPCTimeout     = "MF_AUTH_GRPC_TIMEOUT"
+	envThingsGRPCURL       = "MF_THINGS_AUTH_GRPC_URL"
+	envThingsGRPCTimeout   = "MF_THINGS_AUTH_GRPC_TIMEOUT"
+	envSinkTimeout         = "MF_EXPORTER_SINK_TIMEOUT"
+	envKafkaURL            = "MF_EXPORTER_KAFKA_URL"
+	envS3Endpoint          = "MF_EXPORTER_S3_ENDPOINT"
+	envS3Region            = "MF_EXPORTER_S3_REGION"
+	envS3Bucket            = "MF_EXPORTER_S3_BUCKET"
+	envS3AccessKey         = "MF_EXPORTER_S3_ACCESS_KEY"
+	envS3SecretKey         = "MF_EXPORTER_S3_SECRET_KEY"
+	envBigQueryURL         = "MF_EXPORTER_BIGQUERY_URL"
+	envBigQueryCredentials = "MF_EXPORTER_BIGQUERY_CREDENTIALS"
+)
+
+type config struct {
+	brokerURL           string
+	jetStream           *brokers.JetStreamConfig
+	logLevel            string
+	dbConfig            postgres.Config
+	configPath          string
+	httpPort            string
+	serverCert          string
+	serverKey           string
+	jaegerURL           string
+	clientTLS           bool
+	caCerts             string
+	authGRPCURL         string
+	authGRPCTimeout     time.Duration
+	thingsGRPCURL       string
+	thingsGRPCTimeout   time.Duration
+	sinkTimeout         time.Duration
+	kafkaURL            string
+	s3                  archive.S3Config
+	bigQueryURL         string
+	bigQueryCredentials string
+}
+
+func main() {
+	cfg := loadConfig()
+	ctx, cancel := context.WithCancel(context.Background())
+	g, ctx := errgroup.WithContext(ctx)
+
+	logger, err := logger.New(os.Stdout, cfg.logLevel)
+	if err != nil {
+		log.Fatalf(err.Error())
+	}
+
+	db := connectToDB(cfg.dbConfig, logger)
+	defer db.Close()
+
+	pubSub, err := connectToBroker(cfg, logger)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to connect to message broker: %s", err))
+		os.Exit(1)
+	}
+	defer pubSub.Close()
+
+	authTracer, authCloser := initJaeger("auth", cfg.jaegerURL, logger)
+	defer authCloser.Close()
+
+	authConn := connectToGRPC(cfg, cfg.authGRPCURL, logger)
+	defer authConn.Close()
+	auth := authapi.NewClient(authTracer, authConn, cfg.authGRPCTimeout)
+
+	thingsTracer, thingsCloser := initJaeger("things", cfg.jaegerURL, logger)
+	defer thingsCloser.Close()
+
+	thingsConn := connectToGRPC(cfg, cfg.thingsGRPCURL, logger)
+	defer thingsConn.Close()
+	things := thingsapi.NewClient(thingsConn, thingsTracer, cfg.thingsGRPCTimeout)
+
+	tracer, closer := initJaeger("exporter", cfg.jaegerURL, logger)
+	defer closer.Close()
+
+	svc := newService(db, auth, things, cfg, logger)
+	checks := []mainflux.HealthCheck{
+		{Name: "database", Check: db.PingContext},
+		messaging.HealthCheck(pubSub),
+	}
+
+	if err = consumers.Start(svcName, pubSub, svc, nil, cfg.configPath, logger); err != nil {
+		logger.Error(fmt.Sprintf("Failed to create exporter: %s", err))
+		os.Exit(1)
+	}
+
+	g.Go(func() error {
+		return startHTTPServer(ctx, tracer, svc, cfg.httpPort, cfg.serverCert, cfg.serverKey, logger, checks)
+	})
+
+	g.Go(func() error {
+		if sig := errors.SignalHandler(ctx); sig != nil {
+			cancel()
+			logger.Info(fmt.Sprintf("Exporter service shutdown by signal: %s", sig))
+		}
+		return nil
+	})
+
+	if err := g.Wait(); err != nil {
+		logger.Error(fmt.Sprintf("Exporter service terminated: %s", err))
+	}
+}
+
+func loadConfig() config {
+	authGRPCTimeout, err := time.ParseDuration(mainflux.Env(envAuthGRPCTimeout, defAuthGRPCTimeout))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envAuthGRPCTimeout, err.Error())
+	}
+
+	thingsGRPCTimeout, err := time.ParseDuration(mainflux.Env(envThingsGRPCTimeout, defThingsGRPCTimeout))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envThingsGRPCTimeout, err.Error())
+	}
+
+	sinkTimeout, err := time.ParseDuration(mainflux.Env(envSinkTimeout, defSinkTimeout))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envSinkTimeout, err.Error())
+	}
+
+	tls, err := strconv.ParseBool(mainflux.Env(envClientTLS, defClientTLS))
+	if err != nil {
+		log.Fatalf("Invalid value passed for %s\n", envClientTLS)
+	}
+
+	dbConfig := postgres.Config{
+		Host:        mainflux.Env(envDBHost, defDBHost),
+		Port:        mainflux.Env(envDBPort, defDBPort),
+		User:        mainflux.Env(envDBUser, defDBUser),
+		Pass:        mainflux.Env(envDBPass, defDBPass),
+		Name:        mainflux.Env(envDB, defDB),
+		SSLMode:     mainflux.Env(envDBSSLMode, defDBSSLMode),
+		SSLCert:     mainflux.Env(envDBSSLCert, defDBSSLCert),
+		SSLKey:      mainflux.Env(envDBSSLKey, defDBSSLKey),
+		SSLRootCert: mainflux.Env(envDBSSLRootCert, defDBSSLRootCert),
+	}
+
+	jetStream, err := mfnats.LoadJetStreamConfig()
+	if err != nil {
+		log.Fatalf("Failed to load JetStream config: %s", err)
+	}
+
+	return config{
+		brokerURL:         mainflux.Env(envBrokerURL, defBrokerURL),
+		jetStream:         jetStream,
+		logLevel:          mainflux.Env(envLogLevel, defLogLevel),
+		dbConfig:          dbConfig,
+		configPath:        mainflux.Env(envConfigPath, defConfigPath),
+		httpPort:          mainflux.Env(envHTTPPort, defHTTPPort),
+		serverCert:        mainflux.Env(envServerCert, defServerCert),
+		serverKey:         mainflux.Env(envServerKey, defServerKey),
+		jaegerURL:         mainflux.Env(envJaegerURL, defJaegerURL),
+		clientTLS:         tls,
+		caCerts:           mainflux.Env(envCACerts, defCACerts),
+		authGRPCURL:       mainflux.Env(envAuthGRPCURL, defAuthGRPCURL),
+		authGRPCTimeout:   authGRPCTimeout,
+		thingsGRPCURL:     mainflux.Env(envThingsGRPCURL, defThingsGRPCURL),
+		thingsGRPCTimeout: thingsGRPCTimeout,
+		sinkTimeout:       sinkTimeout,
+		kafkaURL:          mainflux.Env(envKafkaURL, defKafkaURL),
+		s3: archive.S3Config{
+			Endpoint:  mainflux.Env(envS3Endpoint, defS3Endpoint),
+			Region:    mainflux.Env(envS3Region, defS3Region),
+			Bucket:    mainflux.Env(envS3Bucket, defS3Bucket),
+			AccessKey: mainflux.Env(envS3AccessKey, defS3AccessKey),
+			SecretKey: mainflux.Env(envS3SecretKey, defS3SecretKey),
+		},
+		bigQueryURL:         mainflux.Env(envBigQueryURL, defBigQueryURL),
+		bigQueryCredentials: mainflux.Env(envBigQueryCredentials, defBigQueryCredentials),
+	}
+}
+
+func connectToBroker(cfg config, logger logger.Logger) (messaging.PubSub, error) {
+	if cfg.jetStream != nil {
+		return brokers.NewJetStreamPubSub(cfg.brokerURL, "", *cfg.jetStream, logger)
+	}
+
+	logger.Warn("JetStream is not enabled, messages failed to be exported are not redelivered")
+	return brokers.NewPubSub(cfg.brokerURL, "", logger)
+}
+
+func initJaeger(svcName, url string, logger logger.Logger) (opentracing.Tracer, io.Closer) {
+	if url == "" {
+		return opentracing.NoopTracer{}, ioutil.NopCloser(nil)
+	}
+
+	tracer, closer, err := jconfig.Configuration{
+		ServiceName: svcName,
+		Sampler: &jconfig.SamplerConfig{
+			Type:  "const",
+			Param: 1,
+		},
+		Reporter: &jconfig.ReporterConfig{
+			LocalAgentHostPort: url,
+			LogSpans:           true,
+		},
+	}.NewTracer()
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to init Jaeger: %s", err))
+		os.Exit(1)
+	}
+
+	return tracer, closer
+}
+
+func connectToDB(dbConfig postgres.Config, logger logger.Logger) *sqlx.DB {
+	db, err := postgres.Connect(dbConfig)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to connect to postgres: %s", err))
+		os.Exit(1)
+	}
+	return db
+}
+
+func connectToGRPC(cfg config, url string, logger logger.Logger) *grpc.ClientConn {
+	var opts []grpc.DialOption
+	if cfg.clientTLS {
+		if cfg.caCerts != "" {
+			tpc, err := credentials.NewClientTLSFromFile(cfg.caCerts, "")
+			if err != nil {
+				logger.Error(fmt.Sprintf("Failed to create tls credentials: %s", err))
+				os.Exit(1)
+			}
+			opts = append(opts, grpc.WithTransportCredentials(tpc))
+		}
+	} else {
+		opts = append(opts, grpc.WithInsecure())
+		logger.Info("gRPC communication is not encrypted")
+	}
+
+	conn, err := grpc.Dial(url, opts...)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to connect to %s: %s", url, err))
+		os.Exit(1)
+	}
+
+	return conn
+}
+
+// newSinks returns the sinks of the configured connector types. Connectors
+// of the types whose sink is not configured can't be created.
+func newSinks(cfg config, logger logger.Logger) map[string]exporters.Sink {
+	client := &http.Client{Timeout: cfg.sinkTimeout}
+	sinks := make(map[string]exporters.Sink)
+
+	if cfg.kafkaURL != "" {
+		sinks[exporters.TypeKafka] = kafka.New(cfg.kafkaURL, client)
+	}
+
+	if cfg.s3.Endpoint != "" {
+		sinks[exporters.TypeS3] = s3.New(archive.NewS3Storage(cfg.s3, client))
+	}
+
+	if cfg.bigQueryCredentials != "" {
+		data, err := ioutil.ReadFile(cfg.bigQueryCredentials)
+		if err != nil {
+			logger.Error(fmt.Sprintf("Failed to read BigQuery credentials: %s", err))
+			os.Exit(1)
+		}
+
+		var creds bigquery.Credentials
+		if err := json.Unmarshal(data, &creds); err != nil {
+			logger.Error(fmt.Sprintf("Failed to parse BigQuery credentials: %s", err))
+			os.Exit(1)
+		}
+
+		sinks[exporters.TypeBigQuery] = bigquery.New(bigquery.Config{Endpoint: cfg.bigQueryURL, Credentials: creds}, client)
+	}
+
+	return sinks
+}
+
+func newService(db *sqlx.DB, auth mainflux.AuthServiceClient, things mainflux.ThingsServiceClient, cfg config, logger logger.Logger) exporters.Service {
+	database := postgres.NewDatabase(db)
+	connectors := postgres.NewConnectorRepository(database)
+
+	svc := exporters.New(auth, things, connectors, newSinks(cfg, logger), uuid.New())
+	svc = api.LoggingMiddleware(svc, logger)
+	svc = api.MetricsMiddleware(
+		svc,
+		kitprometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: "exporter",
+			Subsystem: "api",
+			Name:      "request_count",
+			Help:      "Number of requests received.",
+		}, []string{"method"}),
+		kitprometheus.NewSummaryFrom(stdprometheus.SummaryOpts{
+			Namespace: "exporter",
+			Subsystem: "api",
+			Name:      "request_latency_microseconds",
+			Help:      "Total duration of requests in microseconds.",
+		}, []string{"method"}),
+		kitprometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: "exporter",
+			Subsystem: "api",
+			Name:      "export_lag_seconds",
+			Help:      "Time between the creation and the export of the last exported message of the channel.",
+		}, []string{"channel"}),
+	)
+
+	return svc
+}
+
+func startHTTPServer(ctx context.Context, tracer opentracing.Tracer, svc exporters.Service, port string, certFile string, keyFile string, logger logger.Logger, checks []mainflux.HealthCheck) error {
+	p := fmt.Sprintf(":%s", port)
+	errCh := make(chan error)
+	server := &http.Server{Addr: p, Handler: api.MakeHandler(svc, tracer, logger, checks...)}
+
+	switch {
+	case certFile != "" || keyFile != "":
+		logger.Info(fmt.Sprintf("Exporter service started using https, cert %s key %s, exposed port %s", certFile, keyFile, port))
+		go func() {
+			errCh <- server.ListenAndServeTLS(certFile, keyFile)
+		}()
+	default:
+		logger.Info(fmt.Sprintf("Exporter service started using http, exposed port %s", port))
+		go func() {
+			errCh <- server.ListenAndServe()
+		}()
+	}
+
+	select {
+	case <-ctx.Done():
+		ctxShutdown, cancelShutdown := context.WithTimeout(context.Background(), stopWaitTime)
+		defer cancelShutdown()
+		if err := server.Shutdown(ctxShutdown); err != nil {
+			logger.Error(fmt.Sprintf("Exporter service error occurred during shutdown at %s: %s", p, err))
+			return fmt.Errorf("exporter service error occurred during shutdown at %s: %w", p, err)
+		}
+		logger.Info(fmt.Sprintf("Exporter service shutdown of http at %s", p))
+		return nil
+	case err := <-errCh:
+		return err
+	}
+}
@@ -17,11 +17,21 @@ import (
 	"github.com/MainfluxLabs/mainflux"
 	adapter "github.com/MainfluxLabs/mainflux/http"
 	"github.com/MainfluxLabs/mainflux/http/api"
+	"github.com/MainfluxLabs/mainflux/http/tts"
 	"github.com/MainfluxLabs/mainflux/logger"
+	"github.com/MainfluxLabs/mainflux/pkg/auth"
+	"github.com/MainfluxLabs/mainflux/pkg/dedup"
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
 	"github.com/MainfluxLabs/mainflux/pkg/messaging/brokers"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging/profile"
+	"github.com/MainfluxLabs/mainflux/pkg/mirror"
+	"github.com/MainfluxLabs/mainflux/pkg/resilience"
+	"github.com/MainfluxLabs/mainflux/pkg/sequence"
+	"github.com/MainfluxLabs/mainflux/pkg/signature"
 	thingsapi "github.com/MainfluxLabs/mainflux/things/api/auth/grpc"
 	kitprometheus "github.com/go-kit/kit/metrics/prometheus"
+	"github.com/go-redis/redis/v8"
 	"github.com/opentracing/opentracing-go"
 	stdprometheus "github.com/prometheus/client_golang/prometheus"
 	jconfig "github.com/uber/jaeger-client-go/config"
@@ -33,23 +43,53 @@ import (
 const (
 	stopWaitTime = 5 * time.Second
 
-	defLogLevel          = "error"
-	defClientTLS         = "false"
-	defCACerts           = ""
-	defPort              = "8180"
-	defBrokerURL         = "nats://localhost:4222"
-	defJaegerURL         = ""
-	defThingsGRPCURL     = "localhost:8183"
-	defThingsGRPCTimeout = "1s"
-
-	envLogLevel          = "MF_HTTP_ADAPTER_LOG_LEVEL"
-	envClientTLS         = "MF_HTTP_ADAPTER_CLIENT_TLS"
-	envCACerts           = "MF_HTTP_ADAPTER_CA_CERTS"
-	envPort              = "MF_HTTP_ADAPTER_PORT"
-	envBrokerURL         = "MF_BROKER_URL"
-	envJaegerURL         = "MF_JAEGER_URL"
-	envThingsGRPCURL     = "MF_THINGS_AUTH_GRPC_URL"
-	envThingsGRPCTimeout = "MF_THINGS_AUTH_GRPC_TIMEOUT"
+	defLogLevel            = "error"
+	defClientTLS           = "false"
+	defCACerts             = ""
+	defPort                = "8180"
+	defBrokerURL           = "nats://localhost:4222"
+	defJaegerURL           = ""
+	defThingsGRPCURL       = "localhost:8183"
+	defThingsGRPCTimeout   = "1s"
+	defGRPCRetries         = "3"
+	defGRPCBreakerFailures = "5"
+	defGRPCBreakerTimeout  = "10s"
+	defThingsCacheTTL      = "0"
+	defSigningKeyTTL       = "30s"
+	defDedupWindow         = "0"
+	defMirrorEnabled       = "false"
+	defThingsESURL         = "localhost:6379"
+	defThingsESPass        = ""
+	defThingsESDB          = "0"
+	defSeqURL              = ""
+	defSeqPass             = ""
+	defSeqDB               = "0"
+	defTTSAPIKey           = ""
+	defTTSRoutesPath       = ""
+
+	envLogLevel                  = "MF_HTTP_ADAPTER_LOG_LEVEL"
+	envClientTLS                 = "MF_HTTP_ADAPTER_CLIENT_TLS"
+	envCACerts                   = "MF_HTTP_ADAPTER_CA_CERTS"
+	envPort                      = "MF_HTTP_ADAPTER_PORT"
+	envBrokerURL                 = "MF_BROKER_URL"
+	envJaegerURL                 = "MF_JAEGER_URL"
+	envThingsGRPCURL             = "MF_THINGS_AUTH_GRPC_URL"
+	envThingsGRPCTimeout         = "MF_THINGS_AUTH_GRPC_TIMEOUT"
+	envThingsGRPCRetries         = "MF_THINGS_AUTH_GRPC_RETRIES"
+	envThingsGRPCBreakerFailures = "MF_THINGS_AUTH_GRPC_BREAKER_FAILURES"
+	envThingsGRPCBreakerTimeout  = "MF_THINGS_AUTH_GRPC_BREAKER_TIMEOUT"
+	envThingsCacheTTL            = "MF_HTTP_ADAPTER_THINGS_CACHE_TTL"
+	envSigningKeyTTL             = "MF_HTTP_ADAPTER_SIGNING_KEY_CACHE_TTL"
+	envDedupWindow               = "MF_HTTP_ADAPTER_DEDUP_WINDOW"
+	envMirrorEnabled             = "MF_HTTP_ADAPTER_MIRROR_ENABLED"
+	envThingsESURL               = "MF_THINGS_ES_URL"
+	envThingsESPass              = "MF_THINGS_ES_PASS"
+	envThingsESDB                = "MF_THINGS_ES_DB"
+	envSeqURL                    = "MF_SEQUENCE_REDIS_URL"
+	envSeqPass                   = "MF_SEQUENCE_REDIS_PASS"
+	envSeqDB                     = "MF_SEQUENCE_REDIS_DB"
+	envTTSAPIKey                 = "MF_HTTP_ADAPTER_TTS_API_KEY"
+	envTTSRoutesPath             = "MF_HTTP_ADAPTER_TTS_ROUTES_PATH"
 )
 
 type config struct {
@@ -61,6 +101,19 @@ type config struct {
 	jaegerURL         string
 	thingsGRPCURL     string
 	thingsGRPCTimeout time.Duration
+	thingsResilience  resilience.Config
+	thingsCacheTTL    time.Duration
+	signingKeyTTL     time.Duration
+	dedupWindow       time.Duration
+	mirrorEnabled     bool
+	thingsESURL       string
+	thingsESPass      string
+	thingsESDB        string
+	seqURL            string
+	seqPass           string
+	seqDB             string
+	ttsAPIKey         string
+	ttsRoutesPath     string
 }
 
 func main() {
@@ -89,8 +142,63 @@ func main() {
 	}
 	defer pub.Close()
 
+	if cfg.seqURL != "" {
+		seqConn := connectToRedis(cfg.seqURL, cfg.seqPass, cfg.seqDB, logger)
+		defer seqConn.Close()
+		pub = sequence.NewPublisher(pub, sequence.NewRedisSequencer(seqConn))
+	}
+
+	checks := []mainflux.HealthCheck{messaging.HealthCheck(pub)}
+
 	tc := thingsapi.NewClient(conn, thingsTracer, cfg.thingsGRPCTimeout)
-	svc := adapter.New(pub, tc)
+	if cfg.thingsCacheTTL > 0 {
+		thingsESConn := connectToRedis(cfg.thingsESURL, cfg.thingsESPass, cfg.thingsESDB, logger)
+		defer thingsESConn.Close()
+
+		cache := auth.NewThingsCache(tc, cfg.thingsCacheTTL)
+		g.Go(func() error {
+			return auth.Invalidate(ctx, thingsESConn, cache)
+		})
+		tc = cache
+		checks = append(checks, mainflux.HealthCheck{
+			Name:  "things_es",
+			Check: func(ctx context.Context) error { return thingsESConn.Ping(ctx).Err() },
+		})
+	}
+
+	pub = profile.NewPublisher(pub, tc)
+	if cfg.mirrorEnabled {
+		pub = mirror.NewPublisher(pub, tc, logger)
+	}
+
+	if cfg.dedupWindow > 0 {
+		pub = dedup.NewPublisher(pub, dedup.NewFilter(cfg.dedupWindow), kitprometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: "http_adapter",
+			Subsystem: "dedup",
+			Name:      "suppressed_count",
+			Help:      "Number of duplicate messages suppressed within the de-duplication window.",
+		}, []string{}))
+	}
+
+	verifier := signature.MetricsMiddleware(
+		signature.NewVerifier(tc, cfg.signingKeyTTL),
+		kitprometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: "http_adapter",
+			Subsystem: "signature",
+			Name:      "rejected_count",
+			Help:      "Number of messages rejected due to the missing or invalid signature.",
+		}, []string{"reason"}),
+	)
+	ttsRoutes := tts.Routes{}
+	if cfg.ttsRoutesPath != "" {
+		ttsRoutes, err = tts.LoadRoutes(cfg.ttsRoutesPath)
+		if err != nil {
+			logger.Error(fmt.Sprintf("Failed to load The Things Stack routes: %s", err))
+			os.Exit(1)
+		}
+	}
+
+	svc := adapter.New(pub, tc, verifier, cfg.ttsAPIKey, ttsRoutes)
 
 	svc = api.LoggingMiddleware(svc, logger)
 	svc = api.MetricsMiddleware(
@@ -110,7 +218,7 @@ func main() {
 	)
 
 	g.Go(func() error {
-		return startHTTPServer(ctx, svc, cfg, logger, tracer)
+		return startHTTPServer(ctx, svc, cfg, logger, tracer, checks)
 	})
 	g.Go(func() error {
 		if sig := errors.SignalHandler(ctx); sig != nil {
@@ -137,6 +245,26 @@ func loadConfig() config {
 		log.Fatalf("Invalid %s value: %s", envThingsGRPCTimeout, err.Error())
 	}
 
+	thingsCacheTTL, err := time.ParseDuration(mainflux.Env(envThingsCacheTTL, defThingsCacheTTL))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envThingsCacheTTL, err.Error())
+	}
+
+	signingKeyTTL, err := time.ParseDuration(mainflux.Env(envSigningKeyTTL, defSigningKeyTTL))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envSigningKeyTTL, err.Error())
+	}
+
+	dedupWindow, err := time.ParseDuration(mainflux.Env(envDedupWindow, defDedupWindow))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envDedupWindow, err.Error())
+	}
+
+	mirrorEnabled, err := strconv.ParseBool(mainflux.Env(envMirrorEnabled, defMirrorEnabled))
+	if err != nil {
+		log.Fatalf("Invalid value passed for %s\n", envMirrorEnabled)
+	}
+
 	return config{
 		brokerURL:         mainflux.Env(envBrokerURL, defBrokerURL),
 		logLevel:          mainflux.Env(envLogLevel, defLogLevel),
@@ -146,6 +274,56 @@ func loadConfig() config {
 		jaegerURL:         mainflux.Env(envJaegerURL, defJaegerURL),
 		thingsGRPCURL:     mainflux.Env(envThingsGRPCURL, defThingsGRPCURL),
 		thingsGRPCTimeout: thingsGRPCTimeout,
+		thingsResilience:  loadResilienceConfig(envThingsGRPCRetries, envThingsGRPCBreakerFailures, envThingsGRPCBreakerTimeout),
+		thingsCacheTTL:    thingsCacheTTL,
+		signingKeyTTL:     signingKeyTTL,
+		dedupWindow:       dedupWindow,
+		mirrorEnabled:     mirrorEnabled,
+		thingsESURL:       mainflux.Env(envThingsESURL, defThingsESURL),
+		thingsESPass:      mainflux.Env(envThingsESPass, defThingsESPass),
+		thingsESDB:        mainflux.Env(envThingsESDB, defThingsESDB),
+		seqURL:            mainflux.Env(envSeqURL, defSeqURL),
+		seqPass:           mainflux.Env(envSeqPass, defSeqPass),
+		seqDB:             mainflux.Env(envSeqDB, defSeqDB),
+		ttsAPIKey:         mainflux.Env(envTTSAPIKey, defTTSAPIKey),
+		ttsRoutesPath:     mainflux.Env(envTTSRoutesPath, defTTSRoutesPath),
+	}
+}
+
+func connectToRedis(redisURL, redisPass, redisDB string, logger logger.Logger) *redis.Client {
+	db, err := strconv.Atoi(redisDB)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to connect to redis: %s", err))
+		os.Exit(1)
+	}
+
+	return redis.NewClient(&redis.Options{
+		Addr:     redisURL,
+		Password: redisPass,
+		DB:       db,
+	})
+}
+
+func loadResilienceConfig(envRetries, envFailures, envTimeout string) resilience.Config {
+	retries, err := strconv.ParseUint(mainflux.Env(envRetries, defGRPCRetries), 10, 64)
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envRetries, err.Error())
+	}
+
+	failures, err := strconv.ParseUint(mainflux.Env(envFailures, defGRPCBreakerFailures), 10, 32)
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envFailures, err.Error())
+	}
+
+	timeout, err := time.ParseDuration(mainflux.Env(envTimeout, defGRPCBreakerTimeout))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envTimeout, err.Error())
+	}
+
+	return resilience.Config{
+		MaxRetries:  retries,
+		MaxFailures: uint32(failures),
+		OpenTimeout: timeout,
 	}
 }
 
@@ -189,6 +367,8 @@ func connectToThings(cfg config, logger logger.Logger) *grpc.ClientConn {
 		opts = append(opts, grpc.WithInsecure())
 	}
 
+	opts = append(opts, grpc.WithUnaryInterceptor(resilience.UnaryClientInterceptor(cfg.thingsResilience)))
+
 	conn, err := grpc.Dial(cfg.thingsGRPCURL, opts...)
 	if err != nil {
 		logger.Error(fmt.Sprintf("Failed to connect to things service: %s", err))
@@ -197,10 +377,10 @@ func connectToThings(cfg config, logger logger.Logger) *grpc.ClientConn {
 	return conn
 }
 
-func startHTTPServer(ctx context.Context, svc adapter.Service, cfg config, logger logger.Logger, tracer opentracing.Tracer) error {
+func startHTTPServer(ctx context.Context, svc adapter.Service, cfg config, logger logger.Logger, tracer opentracing.Tracer, checks []mainflux.HealthCheck) error {
 	p := fmt.Sprintf(":%s", cfg.port)
 	errCh := make(chan error)
-	server := &http.Server{Addr: p, Handler: api.MakeHandler(svc, tracer, logger)}
+	server := &http.Server{Addr: p, Handler: api.MakeHandler(svc, tracer, logger, checks...)}
 	logger.Info(fmt.Sprintf("HTTP adapter service started on port %s", cfg.port))
 	go func() {
 		errCh <- server.ListenAndServe()
@@ -6,22 +6,31 @@ package main
 import (
 	"context"
 	"fmt"
+	"io"
+	"io/ioutil"
 	"log"
 	"net/http"
 	"os"
+	"strconv"
 	"time"
 
 	"github.com/MainfluxLabs/mainflux"
 	"github.com/MainfluxLabs/mainflux/consumers"
 	"github.com/MainfluxLabs/mainflux/consumers/writers/api"
+	"github.com/MainfluxLabs/mainflux/consumers/writers/broker"
 	"github.com/MainfluxLabs/mainflux/consumers/writers/influxdb"
 	"github.com/MainfluxLabs/mainflux/logger"
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
-	"github.com/MainfluxLabs/mainflux/pkg/messaging/brokers"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+	thingsapi "github.com/MainfluxLabs/mainflux/things/api/auth/grpc"
 	kitprometheus "github.com/go-kit/kit/metrics/prometheus"
 	influxdb2 "github.com/influxdata/influxdb-client-go/v2"
+	opentracing "github.com/opentracing/opentracing-go"
 	stdprometheus "github.com/prometheus/client_golang/prometheus"
+	jconfig "github.com/uber/jaeger-client-go/config"
 	"golang.org/x/sync/errgroup"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 )
 
 const (
@@ -39,6 +48,8 @@ const (
 	defDBBucket   = "mainflux-bucket"
 	defDBOrg      = "mainflux"
 	defDBToken    = "mainflux-token"
+	defDB         = "mainflux"
+	defDBVersion  = influxV2
 
 	envBrokerURL  = "MF_BROKER_URL"
 	envLogLevel   = "MF_INFLUX_WRITER_LOG_LEVEL"
@@ -51,10 +62,27 @@ const (
 	envDBBucket   = "MF_INFLUXDB_BUCKET"
 	envDBOrg      = "MF_INFLUXDB_ORG"
 	envDBToken    = "MF_INFLUXDB_TOKEN"
+	envDB         = "MF_INFLUXDB_DB"
+	envDBVersion  = "MF_INFLUXDB_VERSION"
+
+	influxV1 = "1"
+	influxV2 = "2"
+
+	defClientTLS         = "false"
+	defCACerts           = ""
+	defJaegerURL         = ""
+	defThingsGRPCURL     = "localhost:8183"
+	defThingsGRPCTimeout = "1s"
+
+	envClientTLS         = "MF_INFLUX_WRITER_CLIENT_TLS"
+	envCACerts           = "MF_INFLUX_WRITER_CA_CERTS"
+	envJaegerURL         = "MF_JAEGER_URL"
+	envThingsGRPCURL     = "MF_THINGS_AUTH_GRPC_URL"
+	envThingsGRPCTimeout = "MF_THINGS_AUTH_GRPC_TIMEOUT"
 )
 
 type config struct {
-	brokerURL  string
+	broker     broker.Config
 	logLevel   string
 	port       string
 	dbHost     string
@@ -65,7 +93,15 @@ type config struct {
 	dbBucket   string
 	dbOrg      string
 	dbToken    string
+	dbName     string
+	dbVersion  string
 	dbUrl      string
+
+	clientTLS         bool
+	caCerts           string
+	jaegerURL         string
+	thingsGRPCURL     string
+	thingsGRPCTimeout time.Duration
 }
 
 func main() {
@@ -78,7 +114,7 @@ func main() {
 		log.Fatalf(err.Error())
 	}
 
-	pubSub, err := brokers.NewPubSub(cfg.brokerURL, "", logger)
+	pubSub, err := broker.Connect("influxdb", cfg.broker, logger)
 	if err != nil {
 		logger.Error(fmt.Sprintf("Failed to connect to message broker: %s", err))
 		os.Exit(1)
@@ -97,13 +133,37 @@ func main() {
 	repo = api.LoggingMiddleware(repo, logger)
 	repo = api.MetricsMiddleware(repo, counter, latency)
 
-	if err := consumers.Start(svcName, pubSub, repo, cfg.configPath, logger); err != nil {
+	conn := connectToThings(cfg, logger)
+	defer conn.Close()
+
+	thingsTracer, thingsCloser := initJaeger("things", cfg.jaegerURL, logger)
+	defer thingsCloser.Close()
+
+	profiles := consumers.NewProfiles(thingsapi.NewClient(conn, thingsTracer, cfg.thingsGRPCTimeout))
+
+	if err := consumers.Start(svcName, pubSub, repo, profiles, cfg.configPath, logger); err != nil {
 		logger.Error(fmt.Sprintf("Failed to start InfluxDB writer: %s", err))
 		os.Exit(1)
 	}
 
+	// InfluxDB 1.x compatibility API doesn't support deleting messages.
+	if cfg.dbVersion == influxV2 {
+		purger := newPurger(client, repoCfg)
+		g.Go(func() error {
+			return consumers.StartPurger(ctx, purger, profiles, cfg.configPath, logger)
+		})
+	}
+
+	checks := []mainflux.HealthCheck{
+		{Name: "database", Check: func(ctx context.Context) error {
+			_, err := client.Ping(ctx)
+			return err
+		}},
+		messaging.HealthCheck(pubSub),
+	}
+
 	g.Go(func() error {
-		return startHTTPService(ctx, cfg.port, logger)
+		return startHTTPService(ctx, cfg.port, logger, checks)
 	})
 
 	g.Go(func() error {
@@ -126,8 +186,23 @@ func connectToInfluxDB(cfg config) (influxdb2.Client, error) {
 }
 
 func loadConfigs() (config, influxdb.RepoConfig) {
+	tls, err := strconv.ParseBool(mainflux.Env(envClientTLS, defClientTLS))
+	if err != nil {
+		log.Fatalf("Invalid value passed for %s\n", envClientTLS)
+	}
+
+	thingsGRPCTimeout, err := time.ParseDuration(mainflux.Env(envThingsGRPCTimeout, defThingsGRPCTimeout))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envThingsGRPCTimeout, err.Error())
+	}
+
+	brokerConfig, err := broker.LoadConfig(mainflux.Env(envBrokerURL, defBrokerURL))
+	if err != nil {
+		log.Fatalf(err.Error())
+	}
+
 	cfg := config{
-		brokerURL:  mainflux.Env(envBrokerURL, defBrokerURL),
+		broker:     brokerConfig,
 		logLevel:   mainflux.Env(envLogLevel, defLogLevel),
 		port:       mainflux.Env(envPort, defPort),
 		dbHost:     mainflux.Env(envDBHost, defDBHost),
@@ -138,9 +213,29 @@ func loadConfigs() (config, influxdb.RepoConfig) {
 		dbBucket:   mainflux.Env(envDBBucket, defDBBucket),
 		dbOrg:      mainflux.Env(envDBOrg, defDBOrg),
 		dbToken:    mainflux.Env(envDBToken, defDBToken),
+		dbName:     mainflux.Env(envDB, defDB),
+		dbVersion:  mainflux.Env(envDBVersion, defDBVersion),
+
+		clientTLS:         tls,
+		caCerts:           mainflux.Env(envCACerts, defCACerts),
+		jaegerURL:         mainflux.Env(envJaegerURL, defJaegerURL),
+		thingsGRPCURL:     mainflux.Env(envThingsGRPCURL, defThingsGRPCURL),
+		thingsGRPCTimeout: thingsGRPCTimeout,
 	}
 	cfg.dbUrl = fmt.Sprintf("http://%s:%s", cfg.dbHost, cfg.dbPort)
 
+	switch cfg.dbVersion {
+	case influxV2:
+	case influxV1:
+		// InfluxDB 1.8+ is accessed using its 2.x compatibility API, which
+		// authenticates using username and password and writes to the database.
+		cfg.dbToken = fmt.Sprintf("%s:%s", cfg.dbUser, cfg.dbPass)
+		cfg.dbOrg = ""
+		cfg.dbBucket = cfg.dbName
+	default:
+		log.Fatalf("Invalid %s value: %s", envDBVersion, cfg.dbVersion)
+	}
+
 	repoCfg := influxdb.RepoConfig{
 		Bucket: cfg.dbBucket,
 		Org:    cfg.dbOrg,
@@ -166,10 +261,37 @@ func makeMetrics() (*kitprometheus.Counter, *kitprometheus.Summary) {
 	return counter, latency
 }
 
-func startHTTPService(ctx context.Context, port string, logger logger.Logger) error {
+func newPurger(client influxdb2.Client, repoCfg influxdb.RepoConfig) consumers.Purger {
+	purger := influxdb.NewPurger(client, repoCfg)
+	purger = api.PurgerMetricsMiddleware(
+		purger,
+		kitprometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: "influxdb",
+			Subsystem: "message_writer",
+			Name:      "purge_count",
+			Help:      "Number of expired messages purges.",
+		}, []string{"method"}),
+		kitprometheus.NewSummaryFrom(stdprometheus.SummaryOpts{
+			Namespace: "influxdb",
+			Subsystem: "message_writer",
+			Name:      "purge_latency_microseconds",
+			Help:      "Total duration of purges in microseconds.",
+		}, []string{"method"}),
+		kitprometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: "influxdb",
+			Subsystem: "message_writer",
+			Name:      "purged_messages",
+			Help:      "Number of purged expired messages.",
+		}, []string{"channel"}),
+	)
+
+	return purger
+}
+
+func startHTTPService(ctx context.Context, port string, logger logger.Logger, checks []mainflux.HealthCheck) error {
 	p := fmt.Sprintf(":%s", port)
 	errCh := make(chan error)
-	server := &http.Server{Addr: p, Handler: api.MakeHandler(svcName)}
+	server := &http.Server{Addr: p, Handler: api.MakeHandler(svcName, checks...)}
 
 	logger.Info(fmt.Sprintf("InfluxDB writer service started, exposed port %s", p))
 
@@ -191,3 +313,52 @@ func startHTTPService(ctx context.Context, port string, logger logger.Logger) er
 		return err
 	}
 }
+
+func initJaeger(svcName, url string, logger logger.Logger) (opentracing.Tracer, io.Closer) {
+	if url == "" {
+		return opentracing.NoopTracer{}, ioutil.NopCloser(nil)
+	}
+
+	tracer, closer, err := jconfig.Configuration{
+		ServiceName: svcName,
+		Sampler: &jconfig.SamplerConfig{
+			Type:  "const",
+			Param: 1,
+		},
+		Reporter: &jconfig.ReporterConfig{
+			LocalAgentHostPort: url,
+			LogSpans:           true,
+		},
+	}.NewTracer()
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to init Jaeger client: %s", err))
+		os.Exit(1)
+	}
+
+	return tracer, closer
+}
+
+func connectToThings(cfg config, logger logger.Logger) *grpc.ClientConn {
+	var opts []grpc.DialOption
+	if cfg.clientTLS {
+		if cfg.caCerts != "" {
+			tpc, err := credentials.NewClientTLSFromFile(cfg.caCerts, "")
+			if err != nil {
+				logger.Error(fmt.Sprintf("Failed to load certs: %s", err))
+				os.Exit(1)
+			}
+			opts = append(opts, grpc.WithTransportCredentials(tpc))
+		}
+	} else {
+		logger.Info("gRPC communication is not encrypted")
+		opts = append(opts, grpc.WithInsecure())
+	}
+
+	conn, err := grpc.Dial(cfg.thingsGRPCURL, opts...)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to connect to things service: %s", err))
+		os.Exit(1)
+	}
+	logger.Info(fmt.Sprintf("Established gRPC connection to things via gRPC: %s", cfg.thingsGRPCURL))
+	return conn
+}
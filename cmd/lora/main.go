@@ -6,6 +6,8 @@ package main
 import (
 	"context"
 	"fmt"
+	"io"
+	"io/ioutil"
 	"log"
 	"net/http"
 	"os"
@@ -18,16 +20,25 @@ import (
 	"github.com/MainfluxLabs/mainflux/lora/api"
 	"github.com/MainfluxLabs/mainflux/lora/mqtt"
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
 	"github.com/MainfluxLabs/mainflux/pkg/messaging/brokers"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging/profile"
+	thingsapi "github.com/MainfluxLabs/mainflux/things/api/auth/grpc"
 	mqttPaho "github.com/eclipse/paho.mqtt.golang"
 	r "github.com/go-redis/redis/v8"
+	opentracing "github.com/opentracing/opentracing-go"
+	jconfig "github.com/uber/jaeger-client-go/config"
 	"golang.org/x/sync/errgroup"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 
 	"github.com/MainfluxLabs/mainflux/lora/redis"
 	kitprometheus "github.com/go-kit/kit/metrics/prometheus"
 	stdprometheus "github.com/prometheus/client_golang/prometheus"
 )
 
+var errLoRaNotConnected = errors.New("not connected to LoRa MQTT broker")
+
 const (
 	stopWaitTime = 5 * time.Second
 
@@ -47,6 +58,12 @@ const (
 	defRouteMapPass   = ""
 	defRouteMapDB     = "0"
 
+	defClientTLS         = "false"
+	defCACerts           = ""
+	defJaegerURL         = ""
+	defThingsGRPCURL     = "localhost:8183"
+	defThingsGRPCTimeout = "1s"
+
 	envHTTPPort       = "MF_LORA_ADAPTER_HTTP_PORT"
 	envMsgURL         = "MF_LORA_ADAPTER_MESSAGES_URL"
 	envBrokerURL      = "MF_BROKER_URL"
@@ -63,6 +80,12 @@ const (
 	envRouteMapPass   = "MF_LORA_ADAPTER_ROUTE_MAP_PASS"
 	envRouteMapDB     = "MF_LORA_ADAPTER_ROUTE_MAP_DB"
 
+	envClientTLS         = "MF_LORA_ADAPTER_CLIENT_TLS"
+	envCACerts           = "MF_LORA_ADAPTER_CA_CERTS"
+	envJaegerURL         = "MF_JAEGER_URL"
+	envThingsGRPCURL     = "MF_THINGS_AUTH_GRPC_URL"
+	envThingsGRPCTimeout = "MF_THINGS_AUTH_GRPC_TIMEOUT"
+
 	thingsRMPrefix   = "thing"
 	channelsRMPrefix = "channel"
 	connsRMPrefix    = "connection"
@@ -84,6 +107,12 @@ type config struct {
 	routeMapURL    string
 	routeMapPass   string
 	routeMapDB     string
+
+	clientTLS         bool
+	caCerts           string
+	jaegerURL         string
+	thingsGRPCURL     string
+	thingsGRPCTimeout time.Duration
 }
 
 func main() {
@@ -102,12 +131,21 @@ func main() {
 	esConn := connectToRedis(cfg.esURL, cfg.esPass, cfg.esDB, logger)
 	defer esConn.Close()
 
+	conn := connectToThings(cfg, logger)
+	defer conn.Close()
+
+	thingsTracer, thingsCloser := initJaeger("things", cfg.jaegerURL, logger)
+	defer thingsCloser.Close()
+
+	tc := thingsapi.NewClient(conn, thingsTracer, cfg.thingsGRPCTimeout)
+
 	pub, err := brokers.NewPublisher(cfg.brokerURL)
 	if err != nil {
 		logger.Error(fmt.Sprintf("Failed to connect to message broker: %s", err))
 		os.Exit(1)
 	}
 	defer pub.Close()
+	pub = profile.NewPublisher(pub, tc)
 
 	thingsRM := newRouteMapRepository(rmConn, thingsRMPrefix, logger)
 	chansRM := newRouteMapRepository(rmConn, channelsRMPrefix, logger)
@@ -136,8 +174,20 @@ func main() {
 	go subscribeToLoRaBroker(svc, mqttConn, cfg.msgTimeout, cfg.msgTopic, logger)
 	go subscribeToThingsES(svc, esConn, cfg.esConsumerName, logger)
 
+	checks := []mainflux.HealthCheck{
+		messaging.HealthCheck(pub),
+		{Name: "lora_broker", Check: func(ctx context.Context) error {
+			if !mqttConn.IsConnectionOpen() {
+				return errLoRaNotConnected
+			}
+			return nil
+		}},
+		{Name: "route_map", Check: func(ctx context.Context) error { return rmConn.Ping(ctx).Err() }},
+		{Name: "es", Check: func(ctx context.Context) error { return esConn.Ping(ctx).Err() }},
+	}
+
 	g.Go(func() error {
-		return startHTTPServer(ctx, cfg, logger)
+		return startHTTPServer(ctx, cfg, logger, checks)
 	})
 
 	g.Go(func() error {
@@ -160,6 +210,16 @@ func loadConfig() config {
 		log.Fatalf("Invalid %s value: %s", envMsgTimeout, err.Error())
 	}
 
+	tls, err := strconv.ParseBool(mainflux.Env(envClientTLS, defClientTLS))
+	if err != nil {
+		log.Fatalf("Invalid value passed for %s\n", envClientTLS)
+	}
+
+	thingsGRPCTimeout, err := time.ParseDuration(mainflux.Env(envThingsGRPCTimeout, defThingsGRPCTimeout))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envThingsGRPCTimeout, err.Error())
+	}
+
 	return config{
 		httpPort:       mainflux.Env(envHTTPPort, defHTTPPort),
 		msgURL:         mainflux.Env(envMsgURL, defMsgURL),
@@ -176,7 +236,62 @@ func loadConfig() config {
 		routeMapURL:    mainflux.Env(envRouteMapURL, defRouteMapURL),
 		routeMapPass:   mainflux.Env(envRouteMapPass, defRouteMapPass),
 		routeMapDB:     mainflux.Env(envRouteMapDB, defRouteMapDB),
+
+		clientTLS:         tls,
+		caCerts:           mainflux.Env(envCACerts, defCACerts),
+		jaegerURL:         mainflux.Env(envJaegerURL, defJaegerURL),
+		thingsGRPCURL:     mainflux.Env(envThingsGRPCURL, defThingsGRPCURL),
+		thingsGRPCTimeout: thingsGRPCTimeout,
+	}
+}
+
+func initJaeger(svcName, url string, logger logger.Logger) (opentracing.Tracer, io.Closer) {
+	if url == "" {
+		return opentracing.NoopTracer{}, ioutil.NopCloser(nil)
+	}
+
+	tracer, closer, err := jconfig.Configuration{
+		ServiceName: svcName,
+		Sampler: &jconfig.SamplerConfig{
+			Type:  "const",
+			Param: 1,
+		},
+		Reporter: &jconfig.ReporterConfig{
+			LocalAgentHostPort: url,
+			LogSpans:           true,
+		},
+	}.NewTracer()
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to init Jaeger client: %s", err))
+		os.Exit(1)
+	}
+
+	return tracer, closer
+}
+
+func connectToThings(cfg config, logger logger.Logger) *grpc.ClientConn {
+	var opts []grpc.DialOption
+	if cfg.clientTLS {
+		if cfg.caCerts != "" {
+			tpc, err := credentials.NewClientTLSFromFile(cfg.caCerts, "")
+			if err != nil {
+				logger.Error(fmt.Sprintf("Failed to load certs: %s", err))
+				os.Exit(1)
+			}
+			opts = append(opts, grpc.WithTransportCredentials(tpc))
+		}
+	} else {
+		logger.Info("gRPC communication is not encrypted")
+		opts = append(opts, grpc.WithInsecure())
+	}
+
+	conn, err := grpc.Dial(cfg.thingsGRPCURL, opts...)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to connect to things service: %s", err))
+		os.Exit(1)
 	}
+	logger.Info(fmt.Sprintf("Established gRPC connection to things via gRPC: %s", cfg.thingsGRPCURL))
+	return conn
 }
 
 func connectToMQTTBroker(url, user, password string, timeout time.Duration, logger logger.Logger) mqttPaho.Client {
@@ -238,15 +353,15 @@ func newRouteMapRepository(client *r.Client, prefix string, logger logger.Logger
 	return redis.NewRouteMapRepository(client, prefix)
 }
 
-func startHTTPServer(ctx context.Context, cfg config, logger logger.Logger) error {
+func startHTTPServer(ctx context.Context, cfg config, logger logger.Logger, checks []mainflux.HealthCheck) error {
 	p := fmt.Sprintf(":%s", cfg.httpPort)
 	errCh := make(chan error)
-	server := &http.Server{Addr: p, Handler: api.MakeHandler()}
+	server := &http.Server{Addr: p, Handler: api.MakeHandler(checks...)}
 
 	logger.Info(fmt.Sprintf("LoRa-adapter service started, exposed port %s", cfg.httpPort))
 
 	go func() {
-		errCh <- http.ListenAndServe(p, api.MakeHandler())
+		errCh <- http.ListenAndServe(p, api.MakeHandler(checks...))
 	}()
 
 	select {
@@ -0,0 +1,281 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux"
+	"github.com/MainfluxLabs/mainflux/logger"
+	"github.com/MainfluxLabs/mainflux/modbus"
+	"github.com/MainfluxLabs/mainflux/modbus/api"
+	"github.com/MainfluxLabs/mainflux/modbus/rtu"
+	"github.com/MainfluxLabs/mainflux/modbus/tcp"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging/brokers"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging/profile"
+	thingsapi "github.com/MainfluxLabs/mainflux/things/api/auth/grpc"
+	kitprometheus "github.com/go-kit/kit/metrics/prometheus"
+	opentracing "github.com/opentracing/opentracing-go"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+	jconfig "github.com/uber/jaeger-client-go/config"
+	"golang.org/x/sync/errgroup"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+const (
+	stopWaitTime = 5 * time.Second
+
+	defLogLevel     = "error"
+	defHTTPPort     = "8191"
+	defBrokerURL    = "nats://localhost:4222"
+	defConfigPath   = "/config.toml"
+	defTransport    = "tcp"
+	defPollInterval = "10s"
+	defTimeout      = "5s"
+
+	defClientTLS         = "false"
+	defCACerts           = ""
+	defJaegerURL         = ""
+	defThingsGRPCURL     = "localhost:8183"
+	defThingsGRPCTimeout = "1s"
+
+	envLogLevel     = "MF_MODBUS_ADAPTER_LOG_LEVEL"
+	envHTTPPort     = "MF_MODBUS_ADAPTER_HTTP_PORT"
+	envBrokerURL    = "MF_BROKER_URL"
+	envConfigPath   = "MF_MODBUS_ADAPTER_CONFIG_PATH"
+	envTransport    = "MF_MODBUS_ADAPTER_TRANSPORT"
+	envPollInterval = "MF_MODBUS_ADAPTER_POLL_INTERVAL"
+	envTimeout      = "MF_MODBUS_ADAPTER_TIMEOUT"
+
+	envClientTLS         = "MF_MODBUS_ADAPTER_CLIENT_TLS"
+	envCACerts           = "MF_MODBUS_ADAPTER_CA_CERTS"
+	envJaegerURL         = "MF_JAEGER_URL"
+	envThingsGRPCURL     = "MF_THINGS_AUTH_GRPC_URL"
+	envThingsGRPCTimeout = "MF_THINGS_AUTH_GRPC_TIMEOUT"
+)
+
+type config struct {
+	logLevel     string
+	httpPort     string
+	brokerURL    string
+	configPath   string
+	transport    string
+	pollInterval time.Duration
+	timeout      time.Duration
+
+	clientTLS         bool
+	caCerts           string
+	jaegerURL         string
+	thingsGRPCURL     string
+	thingsGRPCTimeout time.Duration
+}
+
+func main() {
+	cfg := loadConfig()
+	ctx, cancel := context.WithCancel(context.Background())
+	g, ctx := errgroup.WithContext(ctx)
+
+	logger, err := logger.New(os.Stdout, cfg.logLevel)
+	if err != nil {
+		log.Fatalf(err.Error())
+	}
+
+	devices, err := modbus.LoadConfig(cfg.configPath, cfg.pollInterval)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to load devices configuration: %s", err))
+		os.Exit(1)
+	}
+
+	conn := connectToThings(cfg, logger)
+	defer conn.Close()
+
+	thingsTracer, thingsCloser := initJaeger("things", cfg.jaegerURL, logger)
+	defer thingsCloser.Close()
+
+	tc := thingsapi.NewClient(conn, thingsTracer, cfg.thingsGRPCTimeout)
+
+	pub, err := brokers.NewPublisher(cfg.brokerURL)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to connect to message broker: %s", err))
+		os.Exit(1)
+	}
+	defer pub.Close()
+	pub = profile.NewPublisher(pub, tc)
+
+	svc := modbus.New(pub, newDialer(cfg, logger))
+	svc = api.LoggingMiddleware(svc, logger)
+	svc = api.MetricsMiddleware(
+		svc,
+		kitprometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: "modbus_adapter",
+			Subsystem: "api",
+			Name:      "request_count",
+			Help:      "Number of requests received.",
+		}, []string{"method"}),
+		kitprometheus.NewSummaryFrom(stdprometheus.SummaryOpts{
+			Namespace: "modbus_adapter",
+			Subsystem: "api",
+			Name:      "request_latency_microseconds",
+			Help:      "Total duration of requests in microseconds.",
+		}, []string{"method"}),
+	)
+
+	modbus.Start(ctx, svc, devices.Devices, logger)
+	logger.Info(fmt.Sprintf("Polling %d Modbus devices", len(devices.Devices)))
+
+	checks := []mainflux.HealthCheck{messaging.HealthCheck(pub)}
+
+	g.Go(func() error {
+		return startHTTPServer(ctx, cfg, logger, checks)
+	})
+
+	g.Go(func() error {
+		if sig := errors.SignalHandler(ctx); sig != nil {
+			cancel()
+			logger.Info(fmt.Sprintf("Modbus adapter shutdown by signal: %s", sig))
+		}
+		return nil
+	})
+
+	if err := g.Wait(); err != nil {
+		logger.Error(fmt.Sprintf("Modbus adapter terminated: %s", err))
+	}
+}
+
+func loadConfig() config {
+	pollInterval, err := time.ParseDuration(mainflux.Env(envPollInterval, defPollInterval))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envPollInterval, err.Error())
+	}
+
+	timeout, err := time.ParseDuration(mainflux.Env(envTimeout, defTimeout))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envTimeout, err.Error())
+	}
+
+	tls, err := strconv.ParseBool(mainflux.Env(envClientTLS, defClientTLS))
+	if err != nil {
+		log.Fatalf("Invalid value passed for %s\n", envClientTLS)
+	}
+
+	thingsGRPCTimeout, err := time.ParseDuration(mainflux.Env(envThingsGRPCTimeout, defThingsGRPCTimeout))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envThingsGRPCTimeout, err.Error())
+	}
+
+	return config{
+		logLevel:     mainflux.Env(envLogLevel, defLogLevel),
+		httpPort:     mainflux.Env(envHTTPPort, defHTTPPort),
+		brokerURL:    mainflux.Env(envBrokerURL, defBrokerURL),
+		configPath:   mainflux.Env(envConfigPath, defConfigPath),
+		transport:    mainflux.Env(envTransport, defTransport),
+		pollInterval: pollInterval,
+		timeout:      timeout,
+
+		clientTLS:         tls,
+		caCerts:           mainflux.Env(envCACerts, defCACerts),
+		jaegerURL:         mainflux.Env(envJaegerURL, defJaegerURL),
+		thingsGRPCURL:     mainflux.Env(envThingsGRPCURL, defThingsGRPCURL),
+		thingsGRPCTimeout: thingsGRPCTimeout,
+	}
+}
+
+func initJaeger(svcName, url string, logger logger.Logger) (opentracing.Tracer, io.Closer) {
+	if url == "" {
+		return opentracing.NoopTracer{}, ioutil.NopCloser(nil)
+	}
+
+	tracer, closer, err := jconfig.Configuration{
+		ServiceName: svcName,
+		Sampler: &jconfig.SamplerConfig{
+			Type:  "const",
+			Param: 1,
+		},
+		Reporter: &jconfig.ReporterConfig{
+			LocalAgentHostPort: url,
+			LogSpans:           true,
+		},
+	}.NewTracer()
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to init Jaeger client: %s", err))
+		os.Exit(1)
+	}
+
+	return tracer, closer
+}
+
+func connectToThings(cfg config, logger logger.Logger) *grpc.ClientConn {
+	var opts []grpc.DialOption
+	if cfg.clientTLS {
+		if cfg.caCerts != "" {
+			tpc, err := credentials.NewClientTLSFromFile(cfg.caCerts, "")
+			if err != nil {
+				logger.Error(fmt.Sprintf("Failed to load certs: %s", err))
+				os.Exit(1)
+			}
+			opts = append(opts, grpc.WithTransportCredentials(tpc))
+		}
+	} else {
+		logger.Info("gRPC communication is not encrypted")
+		opts = append(opts, grpc.WithInsecure())
+	}
+
+	conn, err := grpc.Dial(cfg.thingsGRPCURL, opts...)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to connect to things service: %s", err))
+		os.Exit(1)
+	}
+	logger.Info(fmt.Sprintf("Established gRPC connection to things via gRPC: %s", cfg.thingsGRPCURL))
+	return conn
+}
+
+func newDialer(cfg config, logger logger.Logger) modbus.Dialer {
+	switch cfg.transport {
+	case "tcp":
+		return tcp.NewDialer(cfg.timeout)
+	case "rtu":
+		return rtu.NewDialer(cfg.timeout)
+	default:
+		logger.Error(fmt.Sprintf("Unknown Modbus transport %s", cfg.transport))
+		os.Exit(1)
+		return nil
+	}
+}
+
+func startHTTPServer(ctx context.Context, cfg config, logger logger.Logger, checks []mainflux.HealthCheck) error {
+	p := fmt.Sprintf(":%s", cfg.httpPort)
+	errCh := make(chan error)
+	server := &http.Server{Addr: p, Handler: api.MakeHandler(checks...)}
+
+	logger.Info(fmt.Sprintf("Modbus adapter service started, exposed port %s", cfg.httpPort))
+
+	go func() {
+		errCh <- server.ListenAndServe()
+	}()
+
+	select {
+	case <-ctx.Done():
+		ctxShutdown, cancelShutdown := context.WithTimeout(context.Background(), stopWaitTime)
+		defer cancelShutdown()
+		if err := server.Shutdown(ctxShutdown); err != nil {
+			logger.Error(fmt.Sprintf("Modbus adapter service error occurred during shutdown at %s: %s", p, err))
+			return fmt.Errorf("modbus adapter service error occurred during shutdown at %s: %w", p, err)
+		}
+		logger.Info(fmt.Sprintf("Modbus adapter service shutdown of http at %s", p))
+		return nil
+	case err := <-errCh:
+		return err
+	}
+}
@@ -17,12 +17,15 @@ import (
 	"github.com/MainfluxLabs/mainflux"
 	authapi "github.com/MainfluxLabs/mainflux/auth/api/grpc"
 	"github.com/MainfluxLabs/mainflux/logger"
+	mfapi "github.com/MainfluxLabs/mainflux/pkg/api"
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
 	"github.com/MainfluxLabs/mainflux/readers"
 	"github.com/MainfluxLabs/mainflux/readers/api"
 	"github.com/MainfluxLabs/mainflux/readers/mongodb"
+	rediscache "github.com/MainfluxLabs/mainflux/readers/redis"
 	thingsapi "github.com/MainfluxLabs/mainflux/things/api/auth/grpc"
 	kitprometheus "github.com/go-kit/kit/metrics/prometheus"
+	"github.com/go-redis/redis/v8"
 	opentracing "github.com/opentracing/opentracing-go"
 	stdprometheus "github.com/prometheus/client_golang/prometheus"
 	jconfig "github.com/uber/jaeger-client-go/config"
@@ -36,52 +39,71 @@ import (
 const (
 	stopWaitTime = 5 * time.Second
 
-	defLogLevel          = "error"
-	defPort              = "8180"
-	defDB                = "mainflux"
-	defDBHost            = "localhost"
-	defDBPort            = "27017"
-	defClientTLS         = "false"
-	defCACerts           = ""
-	defServerCert        = ""
-	defServerKey         = ""
-	defJaegerURL         = ""
-	defThingsGRPCURL     = "localhost:8183"
-	defThingsGRPCTimeout = "1s"
-	defAuthGRPCURL       = "localhost:8181"
-	defAuthGRPCTimeout   = "1s"
-
-	envLogLevel          = "MF_MONGO_READER_LOG_LEVEL"
-	envPort              = "MF_MONGO_READER_PORT"
-	envDB                = "MF_MONGO_READER_DB"
-	envDBHost            = "MF_MONGO_READER_DB_HOST"
-	envDBPort            = "MF_MONGO_READER_DB_PORT"
-	envClientTLS         = "MF_MONGO_READER_CLIENT_TLS"
-	envCACerts           = "MF_MONGO_READER_CA_CERTS"
-	envServerCert        = "MF_MONGO_READER_SERVER_CERT"
-	envServerKey         = "MF_MONGO_READER_SERVER_KEY"
-	envJaegerURL         = "MF_JAEGER_URL"
-	envThingsGRPCURL     = "MF_THINGS_AUTH_GRPC_URL"
-	envThingsGRPCTimeout = "MF_THINGS_AUTH_GRPC_TIMEOUT"
-	envAuthGRPCURL       = "MF_AUTH_GRPC_URL"
-	envAuthGRPCTimeout   = "MF_AUTH_GRPC_TIMEOUT"
+	defLogLevel           = "error"
+	defPort               = "8180"
+	defDB                 = "mainflux"
+	defDBHost             = "localhost"
+	defDBPort             = "27017"
+	defClientTLS          = "false"
+	defCACerts            = ""
+	defServerCert         = ""
+	defServerKey          = ""
+	defJaegerURL          = ""
+	defCORSOrigins        = ""
+	defHSTSMaxAge         = "0s"
+	defCSP                = ""
+	defV1Sunset           = ""
+	defLastValueCacheURL  = ""
+	defLastValueCachePass = ""
+	defLastValueCacheDB   = "0"
+	defThingsGRPCURL      = "localhost:8183"
+	defThingsGRPCTimeout  = "1s"
+	defAuthGRPCURL        = "localhost:8181"
+	defAuthGRPCTimeout    = "1s"
+
+	envLogLevel           = "MF_MONGO_READER_LOG_LEVEL"
+	envPort               = "MF_MONGO_READER_PORT"
+	envDB                 = "MF_MONGO_READER_DB"
+	envDBHost             = "MF_MONGO_READER_DB_HOST"
+	envDBPort             = "MF_MONGO_READER_DB_PORT"
+	envClientTLS          = "MF_MONGO_READER_CLIENT_TLS"
+	envCACerts            = "MF_MONGO_READER_CA_CERTS"
+	envServerCert         = "MF_MONGO_READER_SERVER_CERT"
+	envServerKey          = "MF_MONGO_READER_SERVER_KEY"
+	envJaegerURL          = "MF_JAEGER_URL"
+	envCORSOrigins        = "MF_CORS_ALLOWED_ORIGINS"
+	envHSTSMaxAge         = "MF_HSTS_MAX_AGE"
+	envCSP                = "MF_CONTENT_SECURITY_POLICY"
+	envV1Sunset           = "MF_API_V1_SUNSET"
+	envLastValueCacheURL  = "MF_LAST_VALUE_CACHE_URL"
+	envLastValueCachePass = "MF_LAST_VALUE_CACHE_PASS"
+	envLastValueCacheDB   = "MF_LAST_VALUE_CACHE_DB"
+	envThingsGRPCURL      = "MF_THINGS_AUTH_GRPC_URL"
+	envThingsGRPCTimeout  = "MF_THINGS_AUTH_GRPC_TIMEOUT"
+	envAuthGRPCURL        = "MF_AUTH_GRPC_URL"
+	envAuthGRPCTimeout    = "MF_AUTH_GRPC_TIMEOUT"
 )
 
 type config struct {
-	logLevel          string
-	port              string
-	dbName            string
-	dbHost            string
-	dbPort            string
-	clientTLS         bool
-	caCerts           string
-	serverCert        string
-	serverKey         string
-	jaegerURL         string
-	thingsGRPCURL     string
-	authGRPCURL       string
-	thingsGRPCTimeout time.Duration
-	authGRPCTimeout   time.Duration
+	logLevel           string
+	port               string
+	dbName             string
+	dbHost             string
+	dbPort             string
+	clientTLS          bool
+	caCerts            string
+	serverCert         string
+	serverKey          string
+	jaegerURL          string
+	securityConfig     mfapi.SecurityConfig
+	v1Sunset           time.Time
+	lastValueCacheURL  string
+	lastValueCachePass string
+	lastValueCacheDB   string
+	thingsGRPCURL      string
+	authGRPCURL        string
+	thingsGRPCTimeout  time.Duration
+	authGRPCTimeout    time.Duration
 }
 
 func main() {
@@ -113,8 +135,20 @@ func main() {
 
 	repo := newService(db, logger)
 
+	// Last messages are not served if the last value cache is not set.
+	var lvc readers.LastValueCache
+	if cfg.lastValueCacheURL != "" {
+		cacheClient := connectToRedis(cfg.lastValueCacheURL, cfg.lastValueCachePass, cfg.lastValueCacheDB, logger)
+		defer cacheClient.Close()
+		lvc = rediscache.New(cacheClient)
+	}
+
+	checks := []mainflux.HealthCheck{
+		{Name: "database", Check: func(ctx context.Context) error { return db.Client().Ping(ctx, nil) }},
+	}
+
 	g.Go(func() error {
-		return startHTTPServer(ctx, repo, tc, auth, cfg, logger)
+		return startHTTPServer(ctx, repo, lvc, tc, auth, cfg, logger, checks)
 	})
 
 	g.Go(func() error {
@@ -173,21 +207,44 @@ func loadConfigs() config {
 		log.Fatalf("Invalid %s value: %s", envAuthGRPCTimeout, err.Error())
 	}
 
+	hstsMaxAge, err := time.ParseDuration(mainflux.Env(envHSTSMaxAge, defHSTSMaxAge))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envHSTSMaxAge, err.Error())
+	}
+
+	var v1Sunset time.Time
+	if sunset := mainflux.Env(envV1Sunset, defV1Sunset); sunset != "" {
+		if v1Sunset, err = time.Parse(time.RFC3339, sunset); err != nil {
+			log.Fatalf("Invalid %s value: %s", envV1Sunset, err.Error())
+		}
+	}
+
+	securityConfig := mfapi.SecurityConfig{
+		AllowedOrigins:        mfapi.ParseList(mainflux.Env(envCORSOrigins, defCORSOrigins)),
+		HSTSMaxAge:            hstsMaxAge,
+		ContentSecurityPolicy: mainflux.Env(envCSP, defCSP),
+	}
+
 	return config{
-		logLevel:          mainflux.Env(envLogLevel, defLogLevel),
-		port:              mainflux.Env(envPort, defPort),
-		dbName:            mainflux.Env(envDB, defDB),
-		dbHost:            mainflux.Env(envDBHost, defDBHost),
-		dbPort:            mainflux.Env(envDBPort, defDBPort),
-		clientTLS:         tls,
-		caCerts:           mainflux.Env(envCACerts, defCACerts),
-		serverCert:        mainflux.Env(envServerCert, defServerCert),
-		serverKey:         mainflux.Env(envServerKey, defServerKey),
-		jaegerURL:         mainflux.Env(envJaegerURL, defJaegerURL),
-		thingsGRPCURL:     mainflux.Env(envThingsGRPCURL, defThingsGRPCURL),
-		authGRPCURL:       mainflux.Env(envAuthGRPCURL, defAuthGRPCURL),
-		thingsGRPCTimeout: thingsGRPCTimeout,
-		authGRPCTimeout:   authGRPCTimeout,
+		logLevel:           mainflux.Env(envLogLevel, defLogLevel),
+		port:               mainflux.Env(envPort, defPort),
+		dbName:             mainflux.Env(envDB, defDB),
+		dbHost:             mainflux.Env(envDBHost, defDBHost),
+		dbPort:             mainflux.Env(envDBPort, defDBPort),
+		clientTLS:          tls,
+		caCerts:            mainflux.Env(envCACerts, defCACerts),
+		serverCert:         mainflux.Env(envServerCert, defServerCert),
+		serverKey:          mainflux.Env(envServerKey, defServerKey),
+		jaegerURL:          mainflux.Env(envJaegerURL, defJaegerURL),
+		securityConfig:     securityConfig,
+		v1Sunset:           v1Sunset,
+		lastValueCacheURL:  mainflux.Env(envLastValueCacheURL, defLastValueCacheURL),
+		lastValueCachePass: mainflux.Env(envLastValueCachePass, defLastValueCachePass),
+		lastValueCacheDB:   mainflux.Env(envLastValueCacheDB, defLastValueCacheDB),
+		thingsGRPCURL:      mainflux.Env(envThingsGRPCURL, defThingsGRPCURL),
+		authGRPCURL:        mainflux.Env(envAuthGRPCURL, defAuthGRPCURL),
+		thingsGRPCTimeout:  thingsGRPCTimeout,
+		authGRPCTimeout:    authGRPCTimeout,
 	}
 }
 
@@ -202,6 +259,20 @@ func connectToMongoDB(host, port, name string, logger logger.Logger) *mongo.Data
 	return client.Database(name)
 }
 
+func connectToRedis(cacheURL, cachePass, cacheDB string, logger logger.Logger) *redis.Client {
+	db, err := strconv.Atoi(cacheDB)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to connect to cache: %s", err))
+		os.Exit(1)
+	}
+
+	return redis.NewClient(&redis.Options{
+		Addr:     cacheURL,
+		Password: cachePass,
+		DB:       db,
+	})
+}
+
 func initJaeger(svcName, url string, logger logger.Logger) (opentracing.Tracer, io.Closer) {
 	if url == "" {
 		return opentracing.NoopTracer{}, ioutil.NopCloser(nil)
@@ -273,10 +344,11 @@ func newService(db *mongo.Database, logger logger.Logger) readers.MessageReposit
 	return repo
 }
 
-func startHTTPServer(ctx context.Context, repo readers.MessageRepository, tc mainflux.ThingsServiceClient, ac mainflux.AuthServiceClient, cfg config, logger logger.Logger) error {
+func startHTTPServer(ctx context.Context, repo readers.MessageRepository, lvc readers.LastValueCache, tc mainflux.ThingsServiceClient, ac mainflux.AuthServiceClient, cfg config, logger logger.Logger, checks []mainflux.HealthCheck) error {
 	p := fmt.Sprintf(":%s", cfg.port)
 	errCh := make(chan error)
-	server := &http.Server{Addr: p, Handler: api.MakeHandler(repo, tc, ac, "mongodb-reader", logger)}
+	handler := api.MakeHandler(repo, nil, lvc, nil, tc, ac, "mongodb-reader", logger, checks...)
+	server := &http.Server{Addr: p, Handler: mfapi.SecurityMiddleware(cfg.securityConfig, mfapi.Versioned(handler, handler, cfg.v1Sunset))}
 
 	switch {
 	case cfg.serverCert != "" || cfg.serverKey != "":
@@ -6,23 +6,32 @@ package main
 import (
 	"context"
 	"fmt"
+	"io"
+	"io/ioutil"
 	"log"
 	"net/http"
 	"os"
+	"strconv"
 	"time"
 
 	"github.com/MainfluxLabs/mainflux"
 	"github.com/MainfluxLabs/mainflux/consumers"
 	"github.com/MainfluxLabs/mainflux/consumers/writers/api"
+	"github.com/MainfluxLabs/mainflux/consumers/writers/broker"
 	"github.com/MainfluxLabs/mainflux/consumers/writers/mongodb"
 	"github.com/MainfluxLabs/mainflux/logger"
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
-	"github.com/MainfluxLabs/mainflux/pkg/messaging/brokers"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+	thingsapi "github.com/MainfluxLabs/mainflux/things/api/auth/grpc"
 	kitprometheus "github.com/go-kit/kit/metrics/prometheus"
+	opentracing "github.com/opentracing/opentracing-go"
 	stdprometheus "github.com/prometheus/client_golang/prometheus"
+	jconfig "github.com/uber/jaeger-client-go/config"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 	"golang.org/x/sync/errgroup"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 )
 
 const (
@@ -44,16 +53,34 @@ const (
 	envDBHost     = "MF_MONGO_WRITER_DB_HOST"
 	envDBPort     = "MF_MONGO_WRITER_DB_PORT"
 	envConfigPath = "MF_MONGO_WRITER_CONFIG_PATH"
+
+	defClientTLS         = "false"
+	defCACerts           = ""
+	defJaegerURL         = ""
+	defThingsGRPCURL     = "localhost:8183"
+	defThingsGRPCTimeout = "1s"
+
+	envClientTLS         = "MF_MONGO_WRITER_CLIENT_TLS"
+	envCACerts           = "MF_MONGO_WRITER_CA_CERTS"
+	envJaegerURL         = "MF_JAEGER_URL"
+	envThingsGRPCURL     = "MF_THINGS_AUTH_GRPC_URL"
+	envThingsGRPCTimeout = "MF_THINGS_AUTH_GRPC_TIMEOUT"
 )
 
 type config struct {
-	brokerURL  string
+	broker     broker.Config
 	logLevel   string
 	port       string
 	dbName     string
 	dbHost     string
 	dbPort     string
 	configPath string
+
+	clientTLS         bool
+	caCerts           string
+	jaegerURL         string
+	thingsGRPCURL     string
+	thingsGRPCTimeout time.Duration
 }
 
 func main() {
@@ -66,7 +93,7 @@ func main() {
 		log.Fatal(err)
 	}
 
-	pubSub, err := brokers.NewPubSub(cfg.brokerURL, "", logger)
+	pubSub, err := broker.Connect("mongodb", cfg.broker, logger)
 	if err != nil {
 		logger.Error(fmt.Sprintf("Failed to connect to message broker: %s", err))
 		os.Exit(1)
@@ -81,19 +108,41 @@ func main() {
 	}
 
 	db := client.Database(cfg.dbName)
+	if err := mongodb.CreateIndexes(ctx, db); err != nil {
+		logger.Error(fmt.Sprintf("Failed to create MongoDB indexes: %s", err))
+		os.Exit(1)
+	}
 	repo := mongodb.New(db)
 
 	counter, latency := makeMetrics()
 	repo = api.LoggingMiddleware(repo, logger)
 	repo = api.MetricsMiddleware(repo, counter, latency)
 
-	if err := consumers.Start(svcName, pubSub, repo, cfg.configPath, logger); err != nil {
+	conn := connectToThings(cfg, logger)
+	defer conn.Close()
+
+	thingsTracer, thingsCloser := initJaeger("things", cfg.jaegerURL, logger)
+	defer thingsCloser.Close()
+
+	profiles := consumers.NewProfiles(thingsapi.NewClient(conn, thingsTracer, cfg.thingsGRPCTimeout))
+
+	if err := consumers.Start(svcName, pubSub, repo, profiles, cfg.configPath, logger); err != nil {
 		logger.Error(fmt.Sprintf("Failed to start MongoDB writer: %s", err))
 		os.Exit(1)
 	}
 
+	purger := newPurger(db)
 	g.Go(func() error {
-		return startHTTPService(ctx, cfg.port, logger)
+		return consumers.StartPurger(ctx, purger, profiles, cfg.configPath, logger)
+	})
+
+	checks := []mainflux.HealthCheck{
+		{Name: "database", Check: func(ctx context.Context) error { return client.Ping(ctx, nil) }},
+		messaging.HealthCheck(pubSub),
+	}
+
+	g.Go(func() error {
+		return startHTTPService(ctx, cfg.port, logger, checks)
 	})
 
 	g.Go(func() error {
@@ -111,14 +160,35 @@ func main() {
 }
 
 func loadConfigs() config {
+	tls, err := strconv.ParseBool(mainflux.Env(envClientTLS, defClientTLS))
+	if err != nil {
+		log.Fatalf("Invalid value passed for %s\n", envClientTLS)
+	}
+
+	thingsGRPCTimeout, err := time.ParseDuration(mainflux.Env(envThingsGRPCTimeout, defThingsGRPCTimeout))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envThingsGRPCTimeout, err.Error())
+	}
+
+	brokerConfig, err := broker.LoadConfig(mainflux.Env(envBrokerURL, defBrokerURL))
+	if err != nil {
+		log.Fatalf(err.Error())
+	}
+
 	return config{
-		brokerURL:  mainflux.Env(envBrokerURL, defBrokerURL),
+		broker:     brokerConfig,
 		logLevel:   mainflux.Env(envLogLevel, defLogLevel),
 		port:       mainflux.Env(envPort, defPort),
 		dbName:     mainflux.Env(envDB, defDB),
 		dbHost:     mainflux.Env(envDBHost, defDBHost),
 		dbPort:     mainflux.Env(envDBPort, defDBPort),
 		configPath: mainflux.Env(envConfigPath, defConfigPath),
+
+		clientTLS:         tls,
+		caCerts:           mainflux.Env(envCACerts, defCACerts),
+		jaegerURL:         mainflux.Env(envJaegerURL, defJaegerURL),
+		thingsGRPCURL:     mainflux.Env(envThingsGRPCURL, defThingsGRPCURL),
+		thingsGRPCTimeout: thingsGRPCTimeout,
 	}
 }
 
@@ -140,10 +210,37 @@ func makeMetrics() (*kitprometheus.Counter, *kitprometheus.Summary) {
 	return counter, latency
 }
 
-func startHTTPService(ctx context.Context, port string, logger logger.Logger) error {
+func newPurger(db *mongo.Database) consumers.Purger {
+	purger := mongodb.NewPurger(db)
+	purger = api.PurgerMetricsMiddleware(
+		purger,
+		kitprometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: "mongodb",
+			Subsystem: "message_writer",
+			Name:      "purge_count",
+			Help:      "Number of expired messages purges.",
+		}, []string{"method"}),
+		kitprometheus.NewSummaryFrom(stdprometheus.SummaryOpts{
+			Namespace: "mongodb",
+			Subsystem: "message_writer",
+			Name:      "purge_latency_microseconds",
+			Help:      "Total duration of purges in microseconds.",
+		}, []string{"method"}),
+		kitprometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: "mongodb",
+			Subsystem: "message_writer",
+			Name:      "purged_messages",
+			Help:      "Number of purged expired messages.",
+		}, []string{"channel"}),
+	)
+
+	return purger
+}
+
+func startHTTPService(ctx context.Context, port string, logger logger.Logger, checks []mainflux.HealthCheck) error {
 	p := fmt.Sprintf(":%s", port)
 	errCh := make(chan error)
-	server := &http.Server{Addr: p, Handler: api.MakeHandler(svcName)}
+	server := &http.Server{Addr: p, Handler: api.MakeHandler(svcName, checks...)}
 
 	logger.Info(fmt.Sprintf("MongoDB writer service started, exposed port %s", p))
 
@@ -166,3 +263,52 @@ func startHTTPService(ctx context.Context, port string, logger logger.Logger) er
 	}
 
 }
+
+func initJaeger(svcName, url string, logger logger.Logger) (opentracing.Tracer, io.Closer) {
+	if url == "" {
+		return opentracing.NoopTracer{}, ioutil.NopCloser(nil)
+	}
+
+	tracer, closer, err := jconfig.Configuration{
+		ServiceName: svcName,
+		Sampler: &jconfig.SamplerConfig{
+			Type:  "const",
+			Param: 1,
+		},
+		Reporter: &jconfig.ReporterConfig{
+			LocalAgentHostPort: url,
+			LogSpans:           true,
+		},
+	}.NewTracer()
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to init Jaeger client: %s", err))
+		os.Exit(1)
+	}
+
+	return tracer, closer
+}
+
+func connectToThings(cfg config, logger logger.Logger) *grpc.ClientConn {
+	var opts []grpc.DialOption
+	if cfg.clientTLS {
+		if cfg.caCerts != "" {
+			tpc, err := credentials.NewClientTLSFromFile(cfg.caCerts, "")
+			if err != nil {
+				logger.Error(fmt.Sprintf("Failed to load certs: %s", err))
+				os.Exit(1)
+			}
+			opts = append(opts, grpc.WithTransportCredentials(tpc))
+		}
+	} else {
+		logger.Info("gRPC communication is not encrypted")
+		opts = append(opts, grpc.WithInsecure())
+	}
+
+	conn, err := grpc.Dial(cfg.thingsGRPCURL, opts...)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to connect to things service: %s", err))
+		os.Exit(1)
+	}
+	logger.Info(fmt.Sprintf("Established gRPC connection to things via gRPC: %s", cfg.thingsGRPCURL))
+	return conn
+}
@@ -9,24 +9,32 @@ import (
 	"net/http"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/MainfluxLabs/mainflux"
 	authapi "github.com/MainfluxLabs/mainflux/auth/api/grpc"
+	"github.com/MainfluxLabs/mainflux/logger"
 	"github.com/MainfluxLabs/mainflux/mqtt"
 	mqttapi "github.com/MainfluxLabs/mainflux/mqtt/api"
 	mqttapihttp "github.com/MainfluxLabs/mainflux/mqtt/api/http"
 	"github.com/MainfluxLabs/mainflux/mqtt/postgres"
 	mqttredis "github.com/MainfluxLabs/mainflux/mqtt/redis"
+	mfapi "github.com/MainfluxLabs/mainflux/pkg/api"
 	"github.com/MainfluxLabs/mainflux/pkg/auth"
+	"github.com/MainfluxLabs/mainflux/pkg/dedup"
+	"github.com/MainfluxLabs/mainflux/pkg/drain"
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
 	"github.com/MainfluxLabs/mainflux/pkg/messaging"
 	"github.com/MainfluxLabs/mainflux/pkg/messaging/brokers"
 	mqttpub "github.com/MainfluxLabs/mainflux/pkg/messaging/mqtt"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging/profile"
+	"github.com/MainfluxLabs/mainflux/pkg/mirror"
+	"github.com/MainfluxLabs/mainflux/pkg/resilience"
+	"github.com/MainfluxLabs/mainflux/pkg/sequence"
+	"github.com/MainfluxLabs/mainflux/pkg/signature"
 	"github.com/MainfluxLabs/mainflux/pkg/ulid"
 	thingsapi "github.com/MainfluxLabs/mainflux/things/api/auth/grpc"
-	"github.com/MainfluxLabs/mproxy/logger"
-	mp "github.com/MainfluxLabs/mproxy/pkg/mqtt"
 	"github.com/MainfluxLabs/mproxy/pkg/session"
 	ws "github.com/MainfluxLabs/mproxy/pkg/websocket"
 	"github.com/cenkalti/backoff/v4"
@@ -47,88 +55,141 @@ const (
 	httpsProtocol = "https"
 	stopWaitTime  = 5 * time.Second
 
-	defLogLevel          = "error"
-	defMQTTPort          = "1883"
-	defTargetHost        = "0.0.0.0"
-	defTargetPort        = "1883"
-	defTimeout           = "30s" // 30 seconds
-	defTargetHealthCheck = ""
-	defHTTPPort          = "8080"
-	defHTTPTargetHost    = "localhost"
-	defHTTPTargetPort    = "8080"
-	defHTTPTargetPath    = "/mqtt"
-	defWSPort            = "8285"
-	defThingsGRPCURL     = "localhost:8183"
-	defThingsGRPCTimeout = "1s"
-	defBrokerURL         = "nats://localhost:4222"
-	defJaegerURL         = ""
-	defClientTLS         = "false"
-	defCACerts           = ""
-	defInstance          = ""
-	defESURL             = "localhost:6379"
-	defESPass            = ""
-	defESDB              = "0"
-	defAuthcacheURL      = "localhost:6379"
-	defAuthCachePass     = ""
-	defAuthCacheDB       = "0"
-	defDBHost            = "localhost"
-	defAuthGRPCURL       = "localhost:8181"
-	defDBPort            = "5432"
-	defDBUser            = "mainflux"
-	defDBPass            = "mainflux"
-	defDB                = "subscriptions"
-	defDBSSLMode         = "disable"
-	defDBSSLCert         = ""
-	defDBSSLKey          = ""
-	defDBSSLRootCert     = ""
-	defServerKey         = ""
-	defServerCert        = ""
-	defAuthGRPCTimeout   = "1s"
-
-	envLogLevel          = "MF_MQTT_ADAPTER_LOG_LEVEL"
-	envMQTTPort          = "MF_MQTT_ADAPTER_MQTT_PORT"
-	envTargetHost        = "MF_MQTT_ADAPTER_MQTT_TARGET_HOST"
-	envTargetPort        = "MF_MQTT_ADAPTER_MQTT_TARGET_PORT"
-	envTargetHealthCheck = "MF_MQTT_ADAPTER_MQTT_TARGET_HEALTH_CHECK"
-	envTimeout           = "MF_MQTT_ADAPTER_FORWARDER_TIMEOUT"
-	envHTTPPort          = "MF_MQTT_ADAPTER_HTTP_PORT"
-	envHTTPTargetHost    = "MF_MQTT_ADAPTER_WS_TARGET_HOST"
-	envHTTPTargetPort    = "MF_MQTT_ADAPTER_WS_TARGET_PORT"
-	envHTTPTargetPath    = "MF_MQTT_ADAPTER_WS_TARGET_PATH"
-	envWSPort            = "MF_MQTT_ADAPTER_WS_PORT"
-	envThingsGRPCURL     = "MF_THINGS_AUTH_GRPC_URL"
-	envThingsGRPCTimeout = "MF_THINGS_AUTH_GRPC_TIMEOUT"
-	envBrokerURL         = "MF_BROKER_URL"
-	envJaegerURL         = "MF_JAEGER_URL"
-	envClientTLS         = "MF_MQTT_ADAPTER_CLIENT_TLS"
-	envCACerts           = "MF_MQTT_ADAPTER_CA_CERTS"
-	envInstance          = "MF_MQTT_ADAPTER_INSTANCE"
-	envESURL             = "MF_MQTT_ADAPTER_ES_URL"
-	envESPass            = "MF_MQTT_ADAPTER_ES_PASS"
-	envESDB              = "MF_MQTT_ADAPTER_ES_DB"
-	envAuthCacheURL      = "MF_AUTH_CACHE_URL"
-	envAuthCachePass     = "MF_AUTH_CACHE_PASS"
-	envAuthCacheDB       = "MF_AUTH_CACHE_DB"
-	envServerCert        = "MF_MQTT_ADAPTER_SERVER_CERT"
-	envServerKey         = "MF_MQTT_ADAPTER_SERVER_KEY"
-	envDBHost            = "MF_MQTT_ADAPTER_DB_HOST"
-	envDBPort            = "MF_MQTT_ADAPTER_DB_PORT"
-	envDBUser            = "MF_MQTT_ADAPTER_DB_USER"
-	envDBPass            = "MF_MQTT_ADAPTER_DB_PASS"
-	envDB                = "MF_MQTT_ADAPTER_DB"
-	envDBSSLMode         = "MF_MQTT_ADAPTER_DB_SSL_MODE"
-	envDBSSLCert         = "MF_MQTT_ADAPTER_DB_SSL_CERT"
-	envDBSSLKey          = "MF_MQTT_ADAPTER_DB_SSL_KEY"
-	envDBSSLRootCert     = "MF_MQTT_ADAPTER_DB_SSL_ROOT_CERT"
-	envAuthGRPCURL       = "MF_AUTH_GRPC_URL"
-	envAuthGRPCTimeout   = "MF_AUTH_GRPC_TIMEOUT"
+	defLogLevel            = "error"
+	defMQTTPort            = "1883"
+	defTargetHost          = "0.0.0.0"
+	defTargetPort          = "1883"
+	defTimeout             = "30s" // 30 seconds
+	defFwdClients          = "1"
+	defFwdInFlight         = "0"
+	defRetainLatest        = "false"
+	defTargetHealthCheck   = ""
+	defHTTPPort            = "8080"
+	defHTTPTargetHost      = "localhost"
+	defHTTPTargetPort      = "8080"
+	defHTTPTargetPath      = "/mqtt"
+	defWSPort              = "8285"
+	defThingsGRPCURL       = "localhost:8183"
+	defThingsGRPCTimeout   = "1s"
+	defGRPCRetries         = "3"
+	defGRPCBreakerFailures = "5"
+	defGRPCBreakerTimeout  = "10s"
+	defBrokerURL           = "nats://localhost:4222"
+	defJaegerURL           = ""
+	defClientTLS           = "false"
+	defCACerts             = ""
+	defInstance            = ""
+	defESURL               = "localhost:6379"
+	defESPass              = ""
+	defESDB                = "0"
+	defAuthcacheURL        = "localhost:6379"
+	defAuthCachePass       = ""
+	defAuthCacheDB         = "0"
+	defThingsCacheTTL      = "0"
+	defSigningKeyTTL       = "30s"
+	defDedupWindow         = "0"
+	defMirrorEnabled       = "false"
+	defSharedSessions      = "false"
+	defSparkplugGroups     = ""
+	defThingsESURL         = "localhost:6379"
+	defThingsESPass        = ""
+	defThingsESDB          = "0"
+	defSeqURL              = ""
+	defSeqPass             = ""
+	defSeqDB               = "0"
+	defDBHost              = "localhost"
+	defAuthGRPCURL         = "localhost:8181"
+	defDBPort              = "5432"
+	defDBUser              = "mainflux"
+	defDBPass              = "mainflux"
+	defDB                  = "subscriptions"
+	defDBSSLMode           = "disable"
+	defDBSSLCert           = ""
+	defDBSSLKey            = ""
+	defDBSSLRootCert       = ""
+	defServerKey           = ""
+	defServerCert          = ""
+	defAuthGRPCTimeout     = "1s"
+	defDrainTimeout        = "30s"
+	defDrainKey            = ""
+
+	envLogLevel                  = "MF_MQTT_ADAPTER_LOG_LEVEL"
+	envMQTTPort                  = "MF_MQTT_ADAPTER_MQTT_PORT"
+	envTargetHost                = "MF_MQTT_ADAPTER_MQTT_TARGET_HOST"
+	envTargetPort                = "MF_MQTT_ADAPTER_MQTT_TARGET_PORT"
+	envTargetHealthCheck         = "MF_MQTT_ADAPTER_MQTT_TARGET_HEALTH_CHECK"
+	envTimeout                   = "MF_MQTT_ADAPTER_FORWARDER_TIMEOUT"
+	envFwdClients                = "MF_MQTT_ADAPTER_FORWARDER_CLIENTS"
+	envFwdInFlight               = "MF_MQTT_ADAPTER_FORWARDER_IN_FLIGHT"
+	envRetainLatest              = "MF_MQTT_ADAPTER_RETAIN_LATEST"
+	envHTTPPort                  = "MF_MQTT_ADAPTER_HTTP_PORT"
+	envHTTPTargetHost            = "MF_MQTT_ADAPTER_WS_TARGET_HOST"
+	envHTTPTargetPort            = "MF_MQTT_ADAPTER_WS_TARGET_PORT"
+	envHTTPTargetPath            = "MF_MQTT_ADAPTER_WS_TARGET_PATH"
+	envWSPort                    = "MF_MQTT_ADAPTER_WS_PORT"
+	envThingsGRPCURL             = "MF_THINGS_AUTH_GRPC_URL"
+	envThingsGRPCTimeout         = "MF_THINGS_AUTH_GRPC_TIMEOUT"
+	envThingsGRPCRetries         = "MF_THINGS_AUTH_GRPC_RETRIES"
+	envThingsGRPCBreakerFailures = "MF_THINGS_AUTH_GRPC_BREAKER_FAILURES"
+	envThingsGRPCBreakerTimeout  = "MF_THINGS_AUTH_GRPC_BREAKER_TIMEOUT"
+	envBrokerURL                 = "MF_BROKER_URL"
+	envJaegerURL                 = "MF_JAEGER_URL"
+	envClientTLS                 = "MF_MQTT_ADAPTER_CLIENT_TLS"
+	envCACerts                   = "MF_MQTT_ADAPTER_CA_CERTS"
+	envInstance                  = "MF_MQTT_ADAPTER_INSTANCE"
+	envESURL                     = "MF_MQTT_ADAPTER_ES_URL"
+	envESPass                    = "MF_MQTT_ADAPTER_ES_PASS"
+	envESDB                      = "MF_MQTT_ADAPTER_ES_DB"
+	envAuthCacheURL              = "MF_AUTH_CACHE_URL"
+	envAuthCachePass             = "MF_AUTH_CACHE_PASS"
+	envAuthCacheDB               = "MF_AUTH_CACHE_DB"
+	envThingsCacheTTL            = "MF_MQTT_ADAPTER_THINGS_CACHE_TTL"
+	envSigningKeyTTL             = "MF_MQTT_ADAPTER_SIGNING_KEY_CACHE_TTL"
+	envDedupWindow               = "MF_MQTT_ADAPTER_DEDUP_WINDOW"
+	envMirrorEnabled             = "MF_MQTT_ADAPTER_MIRROR_ENABLED"
+	envSharedSessions            = "MF_MQTT_ADAPTER_SHARED_SESSIONS"
+	envSparkplugGroups           = "MF_MQTT_ADAPTER_SPARKPLUG_GROUPS"
+	envThingsESURL               = "MF_THINGS_ES_URL"
+	envThingsESPass              = "MF_THINGS_ES_PASS"
+	envThingsESDB                = "MF_THINGS_ES_DB"
+	envSeqURL                    = "MF_SEQUENCE_REDIS_URL"
+	envSeqPass                   = "MF_SEQUENCE_REDIS_PASS"
+	envSeqDB                     = "MF_SEQUENCE_REDIS_DB"
+	envServerCert                = "MF_MQTT_ADAPTER_SERVER_CERT"
+	envServerKey                 = "MF_MQTT_ADAPTER_SERVER_KEY"
+	envDBHost                    = "MF_MQTT_ADAPTER_DB_HOST"
+	envDBPort                    = "MF_MQTT_ADAPTER_DB_PORT"
+	envDBUser                    = "MF_MQTT_ADAPTER_DB_USER"
+	envDBPass                    = "MF_MQTT_ADAPTER_DB_PASS"
+	envDB                        = "MF_MQTT_ADAPTER_DB"
+	envDBSSLMode                 = "MF_MQTT_ADAPTER_DB_SSL_MODE"
+	envDBSSLCert                 = "MF_MQTT_ADAPTER_DB_SSL_CERT"
+	envDBSSLKey                  = "MF_MQTT_ADAPTER_DB_SSL_KEY"
+	envDBSSLRootCert             = "MF_MQTT_ADAPTER_DB_SSL_ROOT_CERT"
+	envAuthGRPCURL               = "MF_AUTH_GRPC_URL"
+	envAuthGRPCTimeout           = "MF_AUTH_GRPC_TIMEOUT"
+	envAuthGRPCRetries           = "MF_AUTH_GRPC_RETRIES"
+	envAuthGRPCBreakerFailures   = "MF_AUTH_GRPC_BREAKER_FAILURES"
+	envAuthGRPCBreakerTimeout    = "MF_AUTH_GRPC_BREAKER_TIMEOUT"
+	envDrainTimeout              = "MF_MQTT_ADAPTER_DRAIN_TIMEOUT"
+	envDrainKey                  = "MF_MQTT_ADAPTER_DRAIN_KEY"
 )
 
+// authNonIdempotent contains auth service methods which are never retried.
+var authNonIdempotent = []string{
+	"/mainflux.AuthService/Issue",
+	"/mainflux.AuthService/AddPolicy",
+	"/mainflux.AuthService/Assign",
+	"/mainflux.AuthService/AssignRole",
+}
+
 type config struct {
 	port              string
 	targetHost        string
 	targetPort        string
 	timeout           time.Duration
+	fwdPool           mqttpub.PoolConfig
+	retainLatest      bool
 	targetHealthCheck string
 	httpPort          string
 	wsPort            string
@@ -139,6 +200,7 @@ type config struct {
 	logLevel          string
 	thingsGRPCURL     string
 	thingsGRPCTimeout time.Duration
+	thingsResilience  resilience.Config
 	brokerURL         string
 	authGRPCURL       string
 	clientTLS         bool
@@ -150,10 +212,25 @@ type config struct {
 	authCacheURL      string
 	authPass          string
 	authCacheDB       string
+	thingsCacheTTL    time.Duration
+	signingKeyTTL     time.Duration
+	dedupWindow       time.Duration
+	mirrorEnabled     bool
+	sharedSessions    bool
+	sparkplugGroups   map[string]string
+	thingsESURL       string
+	thingsESPass      string
+	thingsESDB        string
+	seqURL            string
+	seqPass           string
+	seqDB             string
 	serverCert        string
 	serverKey         string
 	authGRPCTimeout   time.Duration
+	authResilience    resilience.Config
 	dbConfig          postgres.Config
+	drainTimeout      time.Duration
+	drainKey          string
 }
 
 func main() {
@@ -194,14 +271,30 @@ func main() {
 	}
 	defer nps.Close()
 
-	mpub, err := mqttpub.NewPublisher(fmt.Sprintf("%s:%s", cfg.targetHost, cfg.targetPort), cfg.timeout)
+	fps := profile.NewPubSub(nps, messaging.ForwardFlag, kitprometheus.NewCounterFrom(stdprometheus.CounterOpts{
+		Namespace: "mqtt_adapter",
+		Subsystem: "forwarder",
+		Name:      "skipped_messages",
+		Help:      "Number of received messages skipped because their profile flag is not set.",
+	}, []string{"flag"}))
+
+	mpub, err := newForwarderPublisher(cfg, logger)
 	if err != nil {
 		logger.Error(fmt.Sprintf("Failed to create MQTT publisher: %s", err))
 		os.Exit(1)
 	}
 
-	fwd := mqtt.NewForwarder(brokers.SubjectAllChannels, logger)
-	if err := fwd.Forward(svcName, nps, mpub); err != nil {
+	var lpub messaging.Publisher
+	if cfg.retainLatest {
+		lpub, err = mqttpub.NewRetainedPublisher(fmt.Sprintf("%s:%s", cfg.targetHost, cfg.targetPort), cfg.timeout)
+		if err != nil {
+			logger.Error(fmt.Sprintf("Failed to create MQTT latest state publisher: %s", err))
+			os.Exit(1)
+		}
+	}
+
+	fwd := mqtt.NewForwarder(brokers.SubjectAllChannels, lpub, logger)
+	if err := fwd.Forward(svcName, fps, mpub); err != nil {
 		logger.Error(fmt.Sprintf("Failed to forward message broker messages: %s", err))
 		os.Exit(1)
 	}
@@ -213,6 +306,12 @@ func main() {
 	}
 	defer np.Close()
 
+	if cfg.seqURL != "" {
+		seqConn := connectToRedis(cfg.seqURL, cfg.seqPass, cfg.seqDB, logger)
+		defer seqConn.Close()
+		np = sequence.NewPublisher(np, sequence.NewRedisSequencer(seqConn))
+	}
+
 	es := mqttredis.NewEventStore(ec, cfg.instance)
 
 	ac := connectToRedis(cfg.authCacheURL, cfg.authPass, cfg.authCacheDB, logger)
@@ -231,19 +330,84 @@ func main() {
 	usersAuthConn := connectToAuth(cfg, logger)
 	defer usersAuthConn.Close()
 
+	checks := []mainflux.HealthCheck{
+		{Name: "database", Check: db.PingContext},
+		messaging.HealthCheck(np),
+		{Name: "mqtt_broker", Check: messaging.HealthCheck(mpub).Check},
+		{Name: "es", Check: func(ctx context.Context) error { return ec.Ping(ctx).Err() }},
+		{Name: "auth_cache", Check: func(ctx context.Context) error { return ac.Ping(ctx).Err() }},
+	}
+
 	usersAuth := authapi.NewClient(usersAuthTracer, usersAuthConn, cfg.authGRPCTimeout)
 	tc := thingsapi.NewClient(conn, thingsTracer, cfg.thingsGRPCTimeout)
+	if cfg.thingsCacheTTL > 0 {
+		thingsESConn := connectToRedis(cfg.thingsESURL, cfg.thingsESPass, cfg.thingsESDB, logger)
+		defer thingsESConn.Close()
+
+		cache := auth.NewThingsCache(tc, cfg.thingsCacheTTL)
+		g.Go(func() error {
+			return auth.Invalidate(ctx, thingsESConn, cache)
+		})
+		tc = cache
+		checks = append(checks, mainflux.HealthCheck{
+			Name:  "things_es",
+			Check: func(ctx context.Context) error { return thingsESConn.Ping(ctx).Err() },
+		})
+	}
+
+	np = profile.NewPublisher(np, tc)
+	if cfg.mirrorEnabled {
+		np = mirror.NewPublisher(np, tc, logger)
+	}
+
+	if cfg.dedupWindow > 0 {
+		np = dedup.NewPublisher(np, dedup.NewFilter(cfg.dedupWindow), kitprometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: "mqtt_adapter",
+			Subsystem: "dedup",
+			Name:      "suppressed_count",
+			Help:      "Number of duplicate messages suppressed within the de-duplication window.",
+		}, []string{}))
+	}
 
 	authClient := auth.New(ac, tc)
 
-	svc := newService(usersAuth, tc, db, logger)
+	// Sparkplug B topics are accepted once the groups are mapped to channels.
+	var sp *mqtt.Sparkplug
+	if len(cfg.sparkplugGroups) > 0 {
+		sp = mqtt.NewSparkplug(cfg.sparkplugGroups)
+	}
+
+	svc := newService(usersAuth, tc, db, sp, logger)
+
+	// Last wills of the clients connected through the MQTT proxy
+	wills := mqtt.NewWills()
+
+	// Sessions shared by the replicas recognize the connections taken over
+	// by the reconnected clients.
+	sessions := mqtt.NewSessions()
+	if cfg.sharedSessions {
+		sessions = mqttredis.NewSessions(ec, cfg.instance)
+	}
+
+	drainer := drain.New()
+
+	verifier := signature.MetricsMiddleware(
+		signature.NewVerifier(tc, cfg.signingKeyTTL),
+		kitprometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: "mqtt_adapter",
+			Subsystem: "signature",
+			Name:      "rejected_count",
+			Help:      "Number of messages rejected due to the missing or invalid signature.",
+		}, []string{"reason"}),
+	)
 
 	// Event handler for MQTT hooks
-	h := mqtt.NewHandler([]messaging.Publisher{np}, es, logger, authClient, svc)
+	h := mqtt.NewHandler([]messaging.Publisher{np}, es, logger, authClient, svc, wills, sessions, verifier, sp)
+	h = mqtt.NewDrainHandler(h, drainer)
 
 	logger.Info(fmt.Sprintf("Starting MQTT proxy on port %s", cfg.port))
 	g.Go(func() error {
-		return proxyMQTT(ctx, cfg, logger, h)
+		return proxyMQTT(ctx, cfg, logger, h, wills)
 	})
 
 	logger.Info(fmt.Sprintf("Starting MQTT over WS  proxy on port %s", cfg.httpPort))
@@ -253,7 +417,18 @@ func main() {
 
 	errs := make(chan error, 2)
 	g.Go(func() error {
-		return startHTTPServer(ctx, svc, tracer, cfg, logger, errs)
+		return startHTTPServer(ctx, svc, drainer, tracer, cfg, logger, errs, checks)
+	})
+
+	g.Go(func() error {
+		if err := drain.Run(ctx, drainer, cfg.drainTimeout); err != nil {
+			logger.Warn(fmt.Sprintf("mProxy drain deadline exceeded: %s", err))
+		}
+		if ctx.Err() == nil {
+			cancel()
+			logger.Info("mProxy shutdown after drain")
+		}
+		return nil
 	})
 
 	g.Go(func() error {
@@ -285,11 +460,65 @@ func loadConfig() config {
 		log.Fatalf("Invalid %s value: %s", envTimeout, err.Error())
 	}
 
+	fwdClients, err := strconv.Atoi(mainflux.Env(envFwdClients, defFwdClients))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envFwdClients, err.Error())
+	}
+
+	fwdInFlight, err := strconv.Atoi(mainflux.Env(envFwdInFlight, defFwdInFlight))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envFwdInFlight, err.Error())
+	}
+
+	retainLatest, err := strconv.ParseBool(mainflux.Env(envRetainLatest, defRetainLatest))
+	if err != nil {
+		log.Fatalf("Invalid value passed for %s\n", envRetainLatest)
+	}
+
 	authGRPCTimeout, err := time.ParseDuration(mainflux.Env(envAuthGRPCTimeout, defAuthGRPCTimeout))
 	if err != nil {
 		log.Fatalf("Invalid %s value: %s", envAuthGRPCTimeout, err.Error())
 	}
 
+	thingsCacheTTL, err := time.ParseDuration(mainflux.Env(envThingsCacheTTL, defThingsCacheTTL))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envThingsCacheTTL, err.Error())
+	}
+
+	signingKeyTTL, err := time.ParseDuration(mainflux.Env(envSigningKeyTTL, defSigningKeyTTL))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envSigningKeyTTL, err.Error())
+	}
+
+	dedupWindow, err := time.ParseDuration(mainflux.Env(envDedupWindow, defDedupWindow))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envDedupWindow, err.Error())
+	}
+
+	mirrorEnabled, err := strconv.ParseBool(mainflux.Env(envMirrorEnabled, defMirrorEnabled))
+	if err != nil {
+		log.Fatalf("Invalid value passed for %s\n", envMirrorEnabled)
+	}
+
+	sharedSessions, err := strconv.ParseBool(mainflux.Env(envSharedSessions, defSharedSessions))
+	if err != nil {
+		log.Fatalf("Invalid value passed for %s\n", envSharedSessions)
+	}
+
+	sparkplugGroups := make(map[string]string)
+	for _, g := range mfapi.ParseList(mainflux.Env(envSparkplugGroups, defSparkplugGroups)) {
+		group := strings.SplitN(g, ":", 2)
+		if len(group) != 2 || group[0] == "" || group[1] == "" {
+			log.Fatalf("Invalid value passed for %s\n", envSparkplugGroups)
+		}
+		sparkplugGroups[group[0]] = group[1]
+	}
+
+	drainTimeout, err := time.ParseDuration(mainflux.Env(envDrainTimeout, defDrainTimeout))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envDrainTimeout, err.Error())
+	}
+
 	dbConfig := postgres.Config{
 		Host:        mainflux.Env(envDBHost, defDBHost),
 		Port:        mainflux.Env(envDBPort, defDBPort),
@@ -307,6 +536,8 @@ func loadConfig() config {
 		targetHost:        mainflux.Env(envTargetHost, defTargetHost),
 		targetPort:        mainflux.Env(envTargetPort, defTargetPort),
 		timeout:           mqttTimeout,
+		fwdPool:           mqttpub.PoolConfig{Clients: fwdClients, InFlight: fwdInFlight},
+		retainLatest:      retainLatest,
 		targetHealthCheck: mainflux.Env(envTargetHealthCheck, defTargetHealthCheck),
 		httpPort:          mainflux.Env(envHTTPPort, defHTTPPort),
 		wsPort:            mainflux.Env(envWSPort, defWSPort),
@@ -316,6 +547,7 @@ func loadConfig() config {
 		jaegerURL:         mainflux.Env(envJaegerURL, defJaegerURL),
 		thingsGRPCURL:     mainflux.Env(envThingsGRPCURL, defThingsGRPCURL),
 		thingsGRPCTimeout: thingsGRPCTimeout,
+		thingsResilience:  loadResilienceConfig(envThingsGRPCRetries, envThingsGRPCBreakerFailures, envThingsGRPCBreakerTimeout),
 		brokerURL:         mainflux.Env(envBrokerURL, defBrokerURL),
 		authGRPCURL:       mainflux.Env(envAuthGRPCURL, defAuthGRPCURL),
 		logLevel:          mainflux.Env(envLogLevel, defLogLevel),
@@ -328,10 +560,64 @@ func loadConfig() config {
 		authCacheURL:      mainflux.Env(envAuthCacheURL, defAuthcacheURL),
 		authPass:          mainflux.Env(envAuthCachePass, defAuthCachePass),
 		authCacheDB:       mainflux.Env(envAuthCacheDB, defAuthCacheDB),
+		thingsCacheTTL:    thingsCacheTTL,
+		signingKeyTTL:     signingKeyTTL,
+		dedupWindow:       dedupWindow,
+		mirrorEnabled:     mirrorEnabled,
+		sharedSessions:    sharedSessions,
+		sparkplugGroups:   sparkplugGroups,
+		thingsESURL:       mainflux.Env(envThingsESURL, defThingsESURL),
+		thingsESPass:      mainflux.Env(envThingsESPass, defThingsESPass),
+		thingsESDB:        mainflux.Env(envThingsESDB, defThingsESDB),
+		seqURL:            mainflux.Env(envSeqURL, defSeqURL),
+		seqPass:           mainflux.Env(envSeqPass, defSeqPass),
+		seqDB:             mainflux.Env(envSeqDB, defSeqDB),
 		serverCert:        mainflux.Env(envServerCert, defServerCert),
 		serverKey:         mainflux.Env(envServerKey, defServerKey),
 		authGRPCTimeout:   authGRPCTimeout,
+		authResilience:    loadResilienceConfig(envAuthGRPCRetries, envAuthGRPCBreakerFailures, envAuthGRPCBreakerTimeout),
 		dbConfig:          dbConfig,
+		drainTimeout:      drainTimeout,
+		drainKey:          mainflux.Env(envDrainKey, defDrainKey),
+	}
+}
+
+// newForwarderPublisher returns the publisher forwarding the messages to the
+// MQTT broker. The pooled publisher is used only if more than one client or
+// the in-flight window is configured.
+func newForwarderPublisher(cfg config, logger logger.Logger) (messaging.Publisher, error) {
+	address := fmt.Sprintf("%s:%s", cfg.targetHost, cfg.targetPort)
+	if cfg.fwdPool.Clients <= 1 && cfg.fwdPool.InFlight <= 0 {
+		return mqttpub.NewPublisher(address, cfg.timeout)
+	}
+
+	return mqttpub.NewPoolPublisher(address, cfg.timeout, cfg.fwdPool, func(topic string, msg messaging.Message, err error) {
+		if err != nil {
+			logger.Warn(fmt.Sprintf("Failed to forward message to MQTT topic %s: %s", topic, err))
+		}
+	})
+}
+
+func loadResilienceConfig(envRetries, envFailures, envTimeout string) resilience.Config {
+	retries, err := strconv.ParseUint(mainflux.Env(envRetries, defGRPCRetries), 10, 64)
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envRetries, err.Error())
+	}
+
+	failures, err := strconv.ParseUint(mainflux.Env(envFailures, defGRPCBreakerFailures), 10, 32)
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envFailures, err.Error())
+	}
+
+	timeout, err := time.ParseDuration(mainflux.Env(envTimeout, defGRPCBreakerTimeout))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envTimeout, err.Error())
+	}
+
+	return resilience.Config{
+		MaxRetries:  retries,
+		MaxFailures: uint32(failures),
+		OpenTimeout: timeout,
 	}
 }
 
@@ -375,6 +661,8 @@ func connectToThings(cfg config, logger logger.Logger) *grpc.ClientConn {
 		opts = append(opts, grpc.WithInsecure())
 	}
 
+	opts = append(opts, grpc.WithUnaryInterceptor(resilience.UnaryClientInterceptor(cfg.thingsResilience)))
+
 	conn, err := grpc.Dial(cfg.thingsGRPCURL, opts...)
 	if err != nil {
 		logger.Error(fmt.Sprintf("Failed to connect to things service: %s", err))
@@ -397,10 +685,10 @@ func connectToRedis(redisURL, redisPass, redisDB string, logger logger.Logger) *
 	})
 }
 
-func proxyMQTT(ctx context.Context, cfg config, logger logger.Logger, handler session.Handler) error {
+func proxyMQTT(ctx context.Context, cfg config, logger logger.Logger, handler session.Handler, wills *mqtt.Wills) error {
 	address := fmt.Sprintf(":%s", cfg.port)
 	target := fmt.Sprintf("%s:%s", cfg.targetHost, cfg.targetPort)
-	mp := mp.New(address, target, handler, logger)
+	mp := mqtt.NewProxy(address, target, handler, wills, logger)
 
 	errCh := make(chan error)
 	go func() {
@@ -463,10 +751,10 @@ func connectToDB(dbConfig postgres.Config, logger logger.Logger) *sqlx.DB {
 	return db
 }
 
-func newService(ac mainflux.AuthServiceClient, tc mainflux.ThingsServiceClient, db *sqlx.DB, logger logger.Logger) mqtt.Service {
+func newService(ac mainflux.AuthServiceClient, tc mainflux.ThingsServiceClient, db *sqlx.DB, sp *mqtt.Sparkplug, logger logger.Logger) mqtt.Service {
 	subscriptions := postgres.NewRepository(db)
 	idp := ulid.New()
-	svc := mqtt.NewMqttService(ac, tc, subscriptions, idp)
+	svc := mqtt.NewMqttService(ac, tc, subscriptions, idp, sp)
 
 	svc = mqttapi.LoggingMiddleware(svc, logger)
 	svc = mqttapi.MetricsMiddleware(
@@ -488,11 +776,11 @@ func newService(ac mainflux.AuthServiceClient, tc mainflux.ThingsServiceClient,
 	return svc
 }
 
-func startHTTPServer(ctx context.Context, svc mqtt.Service, tracer opentracing.Tracer, cfg config, logger logger.Logger, errs chan error) error {
+func startHTTPServer(ctx context.Context, svc mqtt.Service, drainer drain.Drainer, tracer opentracing.Tracer, cfg config, logger logger.Logger, errs chan error, checks []mainflux.HealthCheck) error {
 	p := fmt.Sprintf(":%s", cfg.httpPort)
 	errCh := make(chan error)
 	protocol := httpProtocol
-	server := &http.Server{Addr: p, Handler: mqttapihttp.MakeHandler(tracer, svc, logger)}
+	server := &http.Server{Addr: p, Handler: drain.NewHandler(drainer, cfg.drainKey, mqttapihttp.MakeHandler(tracer, svc, logger, checks...))}
 
 	switch {
 	case cfg.serverCert != "" || cfg.serverKey != "":
@@ -541,6 +829,10 @@ func connectToAuth(cfg config, logger logger.Logger) *grpc.ClientConn {
 		logger.Info("gRPC communication is not encrypted")
 	}
 
+	authCfg := cfg.authResilience
+	authCfg.NonIdempotent = authNonIdempotent
+	opts = append(opts, grpc.WithUnaryInterceptor(resilience.UnaryClientInterceptor(authCfg)))
+
 	conn, err := grpc.Dial(cfg.authGRPCURL, opts...)
 	if err != nil {
 		logger.Error(fmt.Sprintf("Failed to connect to auth service: %s", err))
@@ -5,6 +5,7 @@ package main
 
 import (
 	"context"
+	"encoding/base64"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -17,12 +18,21 @@ import (
 	"github.com/MainfluxLabs/mainflux"
 	authapi "github.com/MainfluxLabs/mainflux/auth/api/grpc"
 	"github.com/MainfluxLabs/mainflux/logger"
+	mfapi "github.com/MainfluxLabs/mainflux/pkg/api"
+	parchive "github.com/MainfluxLabs/mainflux/pkg/archive"
+	"github.com/MainfluxLabs/mainflux/pkg/encryption"
+	epostgres "github.com/MainfluxLabs/mainflux/pkg/encryption/postgres"
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/uuid"
 	"github.com/MainfluxLabs/mainflux/readers"
 	"github.com/MainfluxLabs/mainflux/readers/api"
+	"github.com/MainfluxLabs/mainflux/readers/archive"
+	"github.com/MainfluxLabs/mainflux/readers/exports"
 	"github.com/MainfluxLabs/mainflux/readers/postgres"
+	rediscache "github.com/MainfluxLabs/mainflux/readers/redis"
 	thingsapi "github.com/MainfluxLabs/mainflux/things/api/auth/grpc"
 	kitprometheus "github.com/go-kit/kit/metrics/prometheus"
+	"github.com/go-redis/redis/v8"
 	"github.com/jmoiron/sqlx"
 	opentracing "github.com/opentracing/opentracing-go"
 	stdprometheus "github.com/prometheus/client_golang/prometheus"
@@ -36,56 +46,104 @@ const (
 	svcName      = "postgres-reader"
 	stopWaitTime = 5 * time.Second
 
-	defLogLevel          = "error"
-	defPort              = "8180"
-	defClientTLS         = "false"
-	defCACerts           = ""
-	defDBHost            = "localhost"
-	defDBPort            = "5432"
-	defDBUser            = "mainflux"
-	defDBPass            = "mainflux"
-	defDB                = "mainflux"
-	defDBSSLMode         = "disable"
-	defDBSSLCert         = ""
-	defDBSSLKey          = ""
-	defDBSSLRootCert     = ""
-	defJaegerURL         = ""
-	defThingsGRPCURL     = "localhost:8183"
-	defThingsGRPCTimeout = "1s"
-	defAuthGRPCURL       = "localhost:8181"
-	defAuthGRPCTimeout   = "1s"
-
-	envLogLevel          = "MF_POSTGRES_READER_LOG_LEVEL"
-	envPort              = "MF_POSTGRES_READER_PORT"
-	envClientTLS         = "MF_POSTGRES_READER_CLIENT_TLS"
-	envCACerts           = "MF_POSTGRES_READER_CA_CERTS"
-	envDBHost            = "MF_POSTGRES_READER_DB_HOST"
-	envDBPort            = "MF_POSTGRES_READER_DB_PORT"
-	envDBUser            = "MF_POSTGRES_READER_DB_USER"
-	envDBPass            = "MF_POSTGRES_READER_DB_PASS"
-	envDB                = "MF_POSTGRES_READER_DB"
-	envDBSSLMode         = "MF_POSTGRES_READER_DB_SSL_MODE"
-	envDBSSLCert         = "MF_POSTGRES_READER_DB_SSL_CERT"
-	envDBSSLKey          = "MF_POSTGRES_READER_DB_SSL_KEY"
-	envDBSSLRootCert     = "MF_POSTGRES_READER_DB_SSL_ROOT_CERT"
-	envJaegerURL         = "MF_JAEGER_URL"
-	envThingsGRPCURL     = "MF_THINGS_AUTH_GRPC_URL"
-	envThingsGRPCTimeout = "MF_THINGS_AUTH_GRPC_TIMEOUT"
-	envAuthGRPCURL       = "MF_AUTH_GRPC_URL"
-	envAuthGRPCTimeout   = "MF_AUTH_GRPC_TIMEOUT"
+	defArchiveTimeout = 30 * time.Second
+
+	defLogLevel           = "error"
+	defPort               = "8180"
+	defClientTLS          = "false"
+	defCACerts            = ""
+	defDBHost             = "localhost"
+	defDBPort             = "5432"
+	defDBUser             = "mainflux"
+	defDBPass             = "mainflux"
+	defDB                 = "mainflux"
+	defDBSSLMode          = "disable"
+	defDBSSLCert          = ""
+	defDBSSLKey           = ""
+	defDBSSLRootCert      = ""
+	defJaegerURL          = ""
+	defCORSOrigins        = ""
+	defHSTSMaxAge         = "0s"
+	defCSP                = ""
+	defV1Sunset           = ""
+	defLastValueCacheURL  = ""
+	defLastValueCachePass = ""
+	defLastValueCacheDB   = "0"
+	defThingsGRPCURL      = "localhost:8183"
+	defThingsGRPCTimeout  = "1s"
+	defAuthGRPCURL        = "localhost:8181"
+	defAuthGRPCTimeout    = "1s"
+	defArchiveRetention   = ""
+	defArchivePrefix      = "messages/"
+	defArchiveEndpoint    = "http://localhost:9000"
+	defArchiveRegion      = "us-east-1"
+	defArchiveBucket      = "mainflux"
+	defArchiveAccessKey   = ""
+	defArchiveSecretKey   = ""
+	defEncryptionKey      = ""
+	defExportPrefix       = ""
+	defExportRate         = "10000"
+	defExportURLTTL       = "1h"
+
+	envLogLevel           = "MF_POSTGRES_READER_LOG_LEVEL"
+	envPort               = "MF_POSTGRES_READER_PORT"
+	envClientTLS          = "MF_POSTGRES_READER_CLIENT_TLS"
+	envCACerts            = "MF_POSTGRES_READER_CA_CERTS"
+	envDBHost             = "MF_POSTGRES_READER_DB_HOST"
+	envDBPort             = "MF_POSTGRES_READER_DB_PORT"
+	envDBUser             = "MF_POSTGRES_READER_DB_USER"
+	envDBPass             = "MF_POSTGRES_READER_DB_PASS"
+	envDB                 = "MF_POSTGRES_READER_DB"
+	envDBSSLMode          = "MF_POSTGRES_READER_DB_SSL_MODE"
+	envDBSSLCert          = "MF_POSTGRES_READER_DB_SSL_CERT"
+	envDBSSLKey           = "MF_POSTGRES_READER_DB_SSL_KEY"
+	envDBSSLRootCert      = "MF_POSTGRES_READER_DB_SSL_ROOT_CERT"
+	envJaegerURL          = "MF_JAEGER_URL"
+	envCORSOrigins        = "MF_CORS_ALLOWED_ORIGINS"
+	envHSTSMaxAge         = "MF_HSTS_MAX_AGE"
+	envCSP                = "MF_CONTENT_SECURITY_POLICY"
+	envV1Sunset           = "MF_API_V1_SUNSET"
+	envLastValueCacheURL  = "MF_LAST_VALUE_CACHE_URL"
+	envLastValueCachePass = "MF_LAST_VALUE_CACHE_PASS"
+	envLastValueCacheDB   = "MF_LAST_VALUE_CACHE_DB"
+	envThingsGRPCURL      = "MF_THINGS_AUTH_GRPC_URL"
+	envThingsGRPCTimeout  = "MF_THINGS_AUTH_GRPC_TIMEOUT"
+	envAuthGRPCURL        = "MF_AUTH_GRPC_URL"
+	envAuthGRPCTimeout    = "MF_AUTH_GRPC_TIMEOUT"
+	envArchiveRetention   = "MF_POSTGRES_READER_ARCHIVE_RETENTION"
+	envArchivePrefix      = "MF_POSTGRES_READER_ARCHIVE_PREFIX"
+	envArchiveEndpoint    = "MF_POSTGRES_READER_ARCHIVE_S3_ENDPOINT"
+	envArchiveRegion      = "MF_POSTGRES_READER_ARCHIVE_S3_REGION"
+	envArchiveBucket      = "MF_POSTGRES_READER_ARCHIVE_S3_BUCKET"
+	envArchiveAccessKey   = "MF_POSTGRES_READER_ARCHIVE_S3_ACCESS_KEY"
+	envArchiveSecretKey   = "MF_POSTGRES_READER_ARCHIVE_S3_SECRET_KEY"
+	envEncryptionKey      = "MF_POSTGRES_READER_ENCRYPTION_KEY"
+	envExportPrefix       = "MF_POSTGRES_READER_EXPORT_PREFIX"
+	envExportRate         = "MF_POSTGRES_READER_EXPORT_RATE"
+	envExportURLTTL       = "MF_POSTGRES_READER_EXPORT_URL_TTL"
 )
 
 type config struct {
-	logLevel          string
-	port              string
-	clientTLS         bool
-	caCerts           string
-	dbConfig          postgres.Config
-	jaegerURL         string
-	thingsGRPCURL     string
-	authGRPCURL       string
-	thingsGRPCTimeout time.Duration
-	authGRPCTimeout   time.Duration
+	logLevel           string
+	port               string
+	clientTLS          bool
+	caCerts            string
+	dbConfig           postgres.Config
+	jaegerURL          string
+	securityConfig     mfapi.SecurityConfig
+	v1Sunset           time.Time
+	lastValueCacheURL  string
+	lastValueCachePass string
+	lastValueCacheDB   string
+	thingsGRPCURL      string
+	authGRPCURL        string
+	thingsGRPCTimeout  time.Duration
+	authGRPCTimeout    time.Duration
+	archiveRetention   time.Duration
+	archivePrefix      string
+	archiveS3          parchive.S3Config
+	encryptionKey      []byte
+	export             exports.Config
 }
 
 func main() {
@@ -117,10 +175,25 @@ func main() {
 	db := connectToDB(cfg.dbConfig, logger)
 	defer db.Close()
 
-	repo := newService(db, logger)
+	kr := newKeyring(db, cfg.encryptionKey, logger)
+	repo := newService(db, kr, cfg, logger)
+
+	// Last messages are not served if the last value cache is not set.
+	var lvc readers.LastValueCache
+	if cfg.lastValueCacheURL != "" {
+		cacheClient := connectToRedis(cfg.lastValueCacheURL, cfg.lastValueCachePass, cfg.lastValueCacheDB, logger)
+		defer cacheClient.Close()
+		lvc = rediscache.New(cacheClient)
+	}
+
+	es := newExportService(ctx, repo, cfg, logger)
+
+	checks := []mainflux.HealthCheck{
+		{Name: "database", Check: db.PingContext},
+	}
 
 	g.Go(func() error {
-		return startHTTPServer(ctx, repo, tc, auth, cfg.port, logger)
+		return startHTTPServer(ctx, repo, lvc, es, kr, tc, auth, cfg.securityConfig, cfg.v1Sunset, cfg.port, logger, checks)
 	})
 
 	g.Go(func() error {
@@ -190,17 +263,84 @@ func loadConfig() config {
 		log.Fatalf("Invalid %s value: %s", envAuthGRPCTimeout, err.Error())
 	}
 
+	// Archive is not read if the retention is not set.
+	var archiveRetention time.Duration
+	if r := mainflux.Env(envArchiveRetention, defArchiveRetention); r != "" {
+		archiveRetention, err = time.ParseDuration(r)
+		if err != nil {
+			log.Fatalf("Invalid %s value: %s", envArchiveRetention, err.Error())
+		}
+	}
+
+	archiveS3 := parchive.S3Config{
+		Endpoint:  mainflux.Env(envArchiveEndpoint, defArchiveEndpoint),
+		Region:    mainflux.Env(envArchiveRegion, defArchiveRegion),
+		Bucket:    mainflux.Env(envArchiveBucket, defArchiveBucket),
+		AccessKey: mainflux.Env(envArchiveAccessKey, defArchiveAccessKey),
+		SecretKey: mainflux.Env(envArchiveSecretKey, defArchiveSecretKey),
+	}
+
+	exportRate, err := strconv.ParseUint(mainflux.Env(envExportRate, defExportRate), 10, 64)
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envExportRate, err.Error())
+	}
+
+	exportURLTTL, err := time.ParseDuration(mainflux.Env(envExportURLTTL, defExportURLTTL))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envExportURLTTL, err.Error())
+	}
+
+	export := exports.Config{
+		Prefix: mainflux.Env(envExportPrefix, defExportPrefix),
+		Rate:   exportRate,
+		URLTTL: exportURLTTL,
+	}
+
+	// Message payloads are not decrypted if the encryption key is not set.
+	encryptionKey, err := base64.StdEncoding.DecodeString(mainflux.Env(envEncryptionKey, defEncryptionKey))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envEncryptionKey, err.Error())
+	}
+
+	hstsMaxAge, err := time.ParseDuration(mainflux.Env(envHSTSMaxAge, defHSTSMaxAge))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envHSTSMaxAge, err.Error())
+	}
+
+	var v1Sunset time.Time
+	if sunset := mainflux.Env(envV1Sunset, defV1Sunset); sunset != "" {
+		if v1Sunset, err = time.Parse(time.RFC3339, sunset); err != nil {
+			log.Fatalf("Invalid %s value: %s", envV1Sunset, err.Error())
+		}
+	}
+
+	securityConfig := mfapi.SecurityConfig{
+		AllowedOrigins:        mfapi.ParseList(mainflux.Env(envCORSOrigins, defCORSOrigins)),
+		HSTSMaxAge:            hstsMaxAge,
+		ContentSecurityPolicy: mainflux.Env(envCSP, defCSP),
+	}
+
 	return config{
-		logLevel:          mainflux.Env(envLogLevel, defLogLevel),
-		port:              mainflux.Env(envPort, defPort),
-		clientTLS:         tls,
-		caCerts:           mainflux.Env(envCACerts, defCACerts),
-		dbConfig:          dbConfig,
-		jaegerURL:         mainflux.Env(envJaegerURL, defJaegerURL),
-		thingsGRPCURL:     mainflux.Env(envThingsGRPCURL, defThingsGRPCURL),
-		authGRPCURL:       mainflux.Env(envAuthGRPCURL, defAuthGRPCURL),
-		thingsGRPCTimeout: thingsGRPCTimeout,
-		authGRPCTimeout:   authGRPCTimeout,
+		logLevel:           mainflux.Env(envLogLevel, defLogLevel),
+		port:               mainflux.Env(envPort, defPort),
+		clientTLS:          tls,
+		caCerts:            mainflux.Env(envCACerts, defCACerts),
+		dbConfig:           dbConfig,
+		jaegerURL:          mainflux.Env(envJaegerURL, defJaegerURL),
+		securityConfig:     securityConfig,
+		v1Sunset:           v1Sunset,
+		lastValueCacheURL:  mainflux.Env(envLastValueCacheURL, defLastValueCacheURL),
+		lastValueCachePass: mainflux.Env(envLastValueCachePass, defLastValueCachePass),
+		lastValueCacheDB:   mainflux.Env(envLastValueCacheDB, defLastValueCacheDB),
+		thingsGRPCURL:      mainflux.Env(envThingsGRPCURL, defThingsGRPCURL),
+		authGRPCURL:        mainflux.Env(envAuthGRPCURL, defAuthGRPCURL),
+		thingsGRPCTimeout:  thingsGRPCTimeout,
+		authGRPCTimeout:    authGRPCTimeout,
+		archiveRetention:   archiveRetention,
+		archivePrefix:      mainflux.Env(envArchivePrefix, defArchivePrefix),
+		archiveS3:          archiveS3,
+		encryptionKey:      encryptionKey,
+		export:             export,
 	}
 }
 
@@ -213,6 +353,20 @@ func connectToDB(dbConfig postgres.Config, logger logger.Logger) *sqlx.DB {
 	return db
 }
 
+func connectToRedis(cacheURL, cachePass, cacheDB string, logger logger.Logger) *redis.Client {
+	db, err := strconv.Atoi(cacheDB)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to connect to cache: %s", err))
+		os.Exit(1)
+	}
+
+	return redis.NewClient(&redis.Options{
+		Addr:     cacheURL,
+		Password: cachePass,
+		DB:       db,
+	})
+}
+
 func initJaeger(svcName, url string, logger logger.Logger) (opentracing.Tracer, io.Closer) {
 	if url == "" {
 		return opentracing.NoopTracer{}, ioutil.NopCloser(nil)
@@ -262,8 +416,36 @@ func connectToThings(cfg config, logger logger.Logger) *grpc.ClientConn {
 	return conn
 }
 
-func newService(db *sqlx.DB, logger logger.Logger) readers.MessageRepository {
+// newKeyring returns the keyring of the per-channel data keys, or nil
+// if the message payloads encryption is not enabled.
+func newKeyring(db *sqlx.DB, masterKey []byte, logger logger.Logger) encryption.Keyring {
+	if len(masterKey) == 0 {
+		return nil
+	}
+
+	kms, err := encryption.NewLocalKMS(masterKey)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to create KMS: %s", err))
+		os.Exit(1)
+	}
+
+	if err := epostgres.Migrate(db); err != nil {
+		logger.Error(fmt.Sprintf("Failed to migrate data keys: %s", err))
+		os.Exit(1)
+	}
+
+	return encryption.NewKeyring(kms, epostgres.NewKeyRepository(db))
+}
+
+func newService(db *sqlx.DB, kr encryption.Keyring, cfg config, logger logger.Logger) readers.MessageRepository {
 	svc := postgres.New(db)
+	if cfg.archiveRetention > 0 {
+		storage := parchive.NewS3Storage(cfg.archiveS3, &http.Client{Timeout: defArchiveTimeout})
+		svc = archive.NewFallback(svc, archive.New(storage, cfg.archivePrefix), cfg.archiveRetention)
+	}
+	if kr != nil {
+		svc = api.DecryptionMiddleware(svc, kr)
+	}
 	svc = api.LoggingMiddleware(svc, logger)
 	svc = api.MetricsMiddleware(
 		svc,
@@ -284,10 +466,28 @@ func newService(db *sqlx.DB, logger logger.Logger) readers.MessageRepository {
 	return svc
 }
 
-func startHTTPServer(ctx context.Context, repo readers.MessageRepository, tc mainflux.ThingsServiceClient, ac mainflux.AuthServiceClient, port string, logger logger.Logger) error {
+// newExportService returns the service exporting the messages to the archive
+// storage, resuming the interrupted jobs. Export jobs are disabled if the
+// export prefix is not set.
+func newExportService(ctx context.Context, repo readers.MessageRepository, cfg config, logger logger.Logger) exports.Service {
+	if cfg.export.Prefix == "" {
+		return nil
+	}
+
+	storage := parchive.NewS3Storage(cfg.archiveS3, &http.Client{Timeout: defArchiveTimeout})
+	es := exports.New(repo, storage, uuid.New(), cfg.export, logger)
+	if err := es.Resume(ctx); err != nil {
+		logger.Warn(fmt.Sprintf("Failed to resume export jobs: %s", err))
+	}
+
+	return es
+}
+
+func startHTTPServer(ctx context.Context, repo readers.MessageRepository, lvc readers.LastValueCache, es exports.Service, kr encryption.Keyring, tc mainflux.ThingsServiceClient, ac mainflux.AuthServiceClient, secCfg mfapi.SecurityConfig, v1Sunset time.Time, port string, logger logger.Logger, checks []mainflux.HealthCheck) error {
 	p := fmt.Sprintf(":%s", port)
 	errCh := make(chan error)
-	server := &http.Server{Addr: p, Handler: api.MakeHandler(repo, tc, ac, svcName, logger)}
+	handler := api.MakeHandler(repo, kr, lvc, es, tc, ac, svcName, logger, checks...)
+	server := &http.Server{Addr: p, Handler: mfapi.SecurityMiddleware(secCfg, mfapi.Versioned(handler, handler, v1Sunset))}
 
 	logger.Info(fmt.Sprintf("Postgres reader service started, exposed port %s", port))
 	go func() {
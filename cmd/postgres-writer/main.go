@@ -5,28 +5,41 @@ package main
 
 import (
 	"context"
+	"encoding/base64"
 	"fmt"
+	"io"
+	"io/ioutil"
 	"log"
 	"net/http"
 	"os"
+	"strconv"
 	"time"
 
 	"github.com/MainfluxLabs/mainflux"
 	"github.com/MainfluxLabs/mainflux/consumers"
 	"github.com/MainfluxLabs/mainflux/consumers/writers/api"
+	"github.com/MainfluxLabs/mainflux/consumers/writers/broker"
 	"github.com/MainfluxLabs/mainflux/consumers/writers/postgres"
 	"github.com/MainfluxLabs/mainflux/logger"
+	"github.com/MainfluxLabs/mainflux/pkg/encryption"
+	epostgres "github.com/MainfluxLabs/mainflux/pkg/encryption/postgres"
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
-	"github.com/MainfluxLabs/mainflux/pkg/messaging/brokers"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+	thingsapi "github.com/MainfluxLabs/mainflux/things/api/auth/grpc"
 	kitprometheus "github.com/go-kit/kit/metrics/prometheus"
 	"github.com/jmoiron/sqlx"
+	opentracing "github.com/opentracing/opentracing-go"
 	stdprometheus "github.com/prometheus/client_golang/prometheus"
+	jconfig "github.com/uber/jaeger-client-go/config"
 	"golang.org/x/sync/errgroup"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 )
 
 const (
-	svcName      = "postgres-writer"
-	stopWaitTime = 5 * time.Second
+	svcName           = "postgres-writer"
+	stopWaitTime      = 5 * time.Second
+	partitionInterval = time.Hour
 
 	defLogLevel      = "error"
 	defBrokerURL     = "nats://localhost:4222"
@@ -41,6 +54,9 @@ const (
 	defDBSSLKey      = ""
 	defDBSSLRootCert = ""
 	defConfigPath    = "/config.toml"
+	defPartition     = ""
+	defPartitionTTL  = "0"
+	defEncryptionKey = ""
 
 	envBrokerURL     = "MF_BROKER_URL"
 	envLogLevel      = "MF_POSTGRES_WRITER_LOG_LEVEL"
@@ -55,14 +71,37 @@ const (
 	envDBSSLKey      = "MF_POSTGRES_WRITER_DB_SSL_KEY"
 	envDBSSLRootCert = "MF_POSTGRES_WRITER_DB_SSL_ROOT_CERT"
 	envConfigPath    = "MF_POSTGRES_WRITER_CONFIG_PATH"
+	envPartition     = "MF_POSTGRES_WRITER_PARTITION"
+	envPartitionTTL  = "MF_POSTGRES_WRITER_PARTITION_RETENTION"
+	envEncryptionKey = "MF_POSTGRES_WRITER_ENCRYPTION_KEY"
+
+	defClientTLS         = "false"
+	defCACerts           = ""
+	defJaegerURL         = ""
+	defThingsGRPCURL     = "localhost:8183"
+	defThingsGRPCTimeout = "1s"
+
+	envClientTLS         = "MF_POSTGRES_WRITER_CLIENT_TLS"
+	envCACerts           = "MF_POSTGRES_WRITER_CA_CERTS"
+	envJaegerURL         = "MF_JAEGER_URL"
+	envThingsGRPCURL     = "MF_THINGS_AUTH_GRPC_URL"
+	envThingsGRPCTimeout = "MF_THINGS_AUTH_GRPC_TIMEOUT"
 )
 
 type config struct {
-	brokerURL  string
-	logLevel   string
-	port       string
-	configPath string
-	dbConfig   postgres.Config
+	broker        broker.Config
+	logLevel      string
+	port          string
+	configPath    string
+	dbConfig      postgres.Config
+	partition     *postgres.PartitionConfig
+	encryptionKey []byte
+
+	clientTLS         bool
+	caCerts           string
+	jaegerURL         string
+	thingsGRPCURL     string
+	thingsGRPCTimeout time.Duration
 }
 
 func main() {
@@ -75,7 +114,7 @@ func main() {
 		log.Fatalf(err.Error())
 	}
 
-	pubSub, err := brokers.NewPubSub(cfg.brokerURL, "", logger)
+	pubSub, err := broker.Connect("postgres", cfg.broker, logger)
 	if err != nil {
 		logger.Error(fmt.Sprintf("Failed to connect to message broker: %s", err))
 		os.Exit(1)
@@ -85,14 +124,43 @@ func main() {
 	db := connectToDB(cfg.dbConfig, logger)
 	defer db.Close()
 
-	repo := newService(db, logger)
+	repo := newService(db, cfg.encryptionKey, logger)
+
+	conn := connectToThings(cfg, logger)
+	defer conn.Close()
+
+	thingsTracer, thingsCloser := initJaeger("things", cfg.jaegerURL, logger)
+	defer thingsCloser.Close()
 
-	if err = consumers.Start(svcName, pubSub, repo, cfg.configPath, logger); err != nil {
+	profiles := consumers.NewProfiles(thingsapi.NewClient(conn, thingsTracer, cfg.thingsGRPCTimeout))
+
+	if err = consumers.Start(svcName, pubSub, repo, profiles, cfg.configPath, logger); err != nil {
 		logger.Error(fmt.Sprintf("Failed to create Postgres writer: %s", err))
 	}
 
+	purger := newPurger(db)
+	g.Go(func() error {
+		return consumers.StartPurger(ctx, purger, profiles, cfg.configPath, logger)
+	})
+
+	if cfg.partition != nil {
+		partitioner, err := postgres.NewPartitioner(db, *cfg.partition)
+		if err != nil {
+			logger.Error(fmt.Sprintf("Failed to create messages partitioner: %s", err))
+			os.Exit(1)
+		}
+		g.Go(func() error {
+			return postgres.StartPartitioner(ctx, partitioner, partitionInterval, logger)
+		})
+	}
+
+	checks := []mainflux.HealthCheck{
+		{Name: "database", Check: db.PingContext},
+		messaging.HealthCheck(pubSub),
+	}
+
 	g.Go(func() error {
-		return startHTTPServer(ctx, cfg.port, logger)
+		return startHTTPServer(ctx, cfg.port, logger, checks)
 	})
 
 	g.Go(func() error {
@@ -122,12 +190,60 @@ func loadConfig() config {
 		SSLRootCert: mainflux.Env(envDBSSLRootCert, defDBSSLRootCert),
 	}
 
+	// Message payloads are not encrypted if the encryption key is not set.
+	encryptionKey, err := base64.StdEncoding.DecodeString(mainflux.Env(envEncryptionKey, defEncryptionKey))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envEncryptionKey, err.Error())
+	}
+
+	tls, err := strconv.ParseBool(mainflux.Env(envClientTLS, defClientTLS))
+	if err != nil {
+		log.Fatalf("Invalid value passed for %s\n", envClientTLS)
+	}
+
+	thingsGRPCTimeout, err := time.ParseDuration(mainflux.Env(envThingsGRPCTimeout, defThingsGRPCTimeout))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envThingsGRPCTimeout, err.Error())
+	}
+
+	brokerConfig, err := broker.LoadConfig(mainflux.Env(envBrokerURL, defBrokerURL))
+	if err != nil {
+		log.Fatalf(err.Error())
+	}
+
 	return config{
-		brokerURL:  mainflux.Env(envBrokerURL, defBrokerURL),
-		logLevel:   mainflux.Env(envLogLevel, defLogLevel),
-		port:       mainflux.Env(envPort, defPort),
-		configPath: mainflux.Env(envConfigPath, defConfigPath),
-		dbConfig:   dbConfig,
+		broker:        brokerConfig,
+		logLevel:      mainflux.Env(envLogLevel, defLogLevel),
+		port:          mainflux.Env(envPort, defPort),
+		configPath:    mainflux.Env(envConfigPath, defConfigPath),
+		dbConfig:      dbConfig,
+		partition:     loadPartitionConfig(),
+		encryptionKey: encryptionKey,
+
+		clientTLS:         tls,
+		caCerts:           mainflux.Env(envCACerts, defCACerts),
+		jaegerURL:         mainflux.Env(envJaegerURL, defJaegerURL),
+		thingsGRPCURL:     mainflux.Env(envThingsGRPCURL, defThingsGRPCURL),
+		thingsGRPCTimeout: thingsGRPCTimeout,
+	}
+}
+
+// loadPartitionConfig returns messages partitioning configuration,
+// or nil if partitioning is not enabled.
+func loadPartitionConfig() *postgres.PartitionConfig {
+	interval := mainflux.Env(envPartition, defPartition)
+	if interval == "" {
+		return nil
+	}
+
+	retention, err := time.ParseDuration(mainflux.Env(envPartitionTTL, defPartitionTTL))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envPartitionTTL, err.Error())
+	}
+
+	return &postgres.PartitionConfig{
+		Interval:  interval,
+		Retention: retention,
 	}
 }
 
@@ -140,8 +256,20 @@ func connectToDB(dbConfig postgres.Config, logger logger.Logger) *sqlx.DB {
 	return db
 }
 
-func newService(db *sqlx.DB, logger logger.Logger) consumers.Consumer {
+func newService(db *sqlx.DB, masterKey []byte, logger logger.Logger) consumers.Consumer {
 	svc := postgres.New(db)
+	if len(masterKey) > 0 {
+		kms, err := encryption.NewLocalKMS(masterKey)
+		if err != nil {
+			logger.Error(fmt.Sprintf("Failed to create KMS: %s", err))
+			os.Exit(1)
+		}
+		if err := epostgres.Migrate(db); err != nil {
+			logger.Error(fmt.Sprintf("Failed to migrate data keys: %s", err))
+			os.Exit(1)
+		}
+		svc = api.EncryptionMiddleware(svc, encryption.NewKeyring(kms, epostgres.NewKeyRepository(db)))
+	}
 	svc = api.LoggingMiddleware(svc, logger)
 	svc = api.MetricsMiddleware(
 		svc,
@@ -162,10 +290,37 @@ func newService(db *sqlx.DB, logger logger.Logger) consumers.Consumer {
 	return svc
 }
 
-func startHTTPServer(ctx context.Context, port string, logger logger.Logger) error {
+func newPurger(db *sqlx.DB) consumers.Purger {
+	purger := postgres.NewPurger(db)
+	purger = api.PurgerMetricsMiddleware(
+		purger,
+		kitprometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: "postgres",
+			Subsystem: "message_writer",
+			Name:      "purge_count",
+			Help:      "Number of expired messages purges.",
+		}, []string{"method"}),
+		kitprometheus.NewSummaryFrom(stdprometheus.SummaryOpts{
+			Namespace: "postgres",
+			Subsystem: "message_writer",
+			Name:      "purge_latency_microseconds",
+			Help:      "Total duration of purges in microseconds.",
+		}, []string{"method"}),
+		kitprometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: "postgres",
+			Subsystem: "message_writer",
+			Name:      "purged_messages",
+			Help:      "Number of purged expired messages.",
+		}, []string{"channel"}),
+	)
+
+	return purger
+}
+
+func startHTTPServer(ctx context.Context, port string, logger logger.Logger, checks []mainflux.HealthCheck) error {
 	p := fmt.Sprintf(":%s", port)
 	errCh := make(chan error)
-	server := &http.Server{Addr: p, Handler: api.MakeHandler(svcName)}
+	server := &http.Server{Addr: p, Handler: api.MakeHandler(svcName, checks...)}
 
 	logger.Info(fmt.Sprintf("Postgres writer service started, exposed port %s", port))
 	go func() {
@@ -186,3 +341,52 @@ func startHTTPServer(ctx context.Context, port string, logger logger.Logger) err
 		return err
 	}
 }
+
+func initJaeger(svcName, url string, logger logger.Logger) (opentracing.Tracer, io.Closer) {
+	if url == "" {
+		return opentracing.NoopTracer{}, ioutil.NopCloser(nil)
+	}
+
+	tracer, closer, err := jconfig.Configuration{
+		ServiceName: svcName,
+		Sampler: &jconfig.SamplerConfig{
+			Type:  "const",
+			Param: 1,
+		},
+		Reporter: &jconfig.ReporterConfig{
+			LocalAgentHostPort: url,
+			LogSpans:           true,
+		},
+	}.NewTracer()
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to init Jaeger client: %s", err))
+		os.Exit(1)
+	}
+
+	return tracer, closer
+}
+
+func connectToThings(cfg config, logger logger.Logger) *grpc.ClientConn {
+	var opts []grpc.DialOption
+	if cfg.clientTLS {
+		if cfg.caCerts != "" {
+			tpc, err := credentials.NewClientTLSFromFile(cfg.caCerts, "")
+			if err != nil {
+				logger.Error(fmt.Sprintf("Failed to load certs: %s", err))
+				os.Exit(1)
+			}
+			opts = append(opts, grpc.WithTransportCredentials(tpc))
+		}
+	} else {
+		logger.Info("gRPC communication is not encrypted")
+		opts = append(opts, grpc.WithInsecure())
+	}
+
+	conn, err := grpc.Dial(cfg.thingsGRPCURL, opts...)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to connect to things service: %s", err))
+		os.Exit(1)
+	}
+	logger.Info(fmt.Sprintf("Established gRPC connection to things via gRPC: %s", cfg.thingsGRPCURL))
+	return conn
+}
@@ -44,6 +44,7 @@ const (
 	defBSContent       = ""
 	defCertsHoursValid = "2400h"
 	defCertsKeyBits    = "4096"
+	defIdempotencyTTL  = "24h"
 
 	envConfigFile       = "MF_PROVISION_CONFIG_FILE"
 	envLogLevel         = "MF_PROVISION_LOG_LEVEL"
@@ -65,6 +66,7 @@ const (
 	envBSContent        = "MF_PROVISION_BS_CONTENT"
 	envCertsHoursValid  = "MF_PROVISION_CERTS_HOURS_VALID"
 	envCertsKeyBits     = "MF_PROVISION_CERTS_RSA_BITS"
+	envIdempotencyTTL   = "MF_PROVISION_IDEMPOTENCY_TTL"
 
 	contentType = "application/json"
 )
@@ -110,7 +112,14 @@ func main() {
 	}
 	SDK := mfSDK.NewSDK(SDKCfg)
 
-	svc := provision.New(cfg, SDK, logger)
+	idempotencyTTL, err := time.ParseDuration(mainflux.Env(envIdempotencyTTL, defIdempotencyTTL))
+	if err != nil {
+		logger.Error(fmt.Sprintf("Invalid %s value: %s", envIdempotencyTTL, err))
+		os.Exit(1)
+	}
+	results := provision.NewResultCache(idempotencyTTL)
+
+	svc := provision.New(cfg, SDK, results, logger)
 	svc = api.NewLoggingMiddleware(svc, logger)
 
 	g.Go(func() error {
@@ -0,0 +1,425 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux"
+	authapi "github.com/MainfluxLabs/mainflux/auth/api/grpc"
+	"github.com/MainfluxLabs/mainflux/internal/email"
+	"github.com/MainfluxLabs/mainflux/logger"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	mfsdk "github.com/MainfluxLabs/mainflux/pkg/sdk/go"
+	"github.com/MainfluxLabs/mainflux/pkg/uuid"
+	"github.com/MainfluxLabs/mainflux/readers/timescale"
+	"github.com/MainfluxLabs/mainflux/reports"
+	"github.com/MainfluxLabs/mainflux/reports/api"
+	repemail "github.com/MainfluxLabs/mainflux/reports/email"
+	"github.com/MainfluxLabs/mainflux/reports/fs"
+	"github.com/MainfluxLabs/mainflux/reports/postgres"
+	repthings "github.com/MainfluxLabs/mainflux/reports/things"
+	thingsapi "github.com/MainfluxLabs/mainflux/things/api/auth/grpc"
+	kitprometheus "github.com/go-kit/kit/metrics/prometheus"
+	"github.com/jmoiron/sqlx"
+	opentracing "github.com/opentracing/opentracing-go"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+	jconfig "github.com/uber/jaeger-client-go/config"
+	"golang.org/x/sync/errgroup"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+const (
+	stopWaitTime = 5 * time.Second
+
+	defLogLevel            = "error"
+	defDBHost              = "localhost"
+	defDBPort              = "5432"
+	defDBUser              = "mainflux"
+	defDBPass              = "mainflux"
+	defDB                  = "reports"
+	defDBSSLMode           = "disable"
+	defDBSSLCert           = ""
+	defDBSSLKey            = ""
+	defDBSSLRootCert       = ""
+	defReaderDBHost        = "localhost"
+	defReaderDBPort        = "5432"
+	defReaderDBUser        = "mainflux"
+	defReaderDBPass        = "mainflux"
+	defReaderDB            = "mainflux"
+	defReaderDBSSLMode     = "disable"
+	defReaderDBSSLCert     = ""
+	defReaderDBSSLKey      = ""
+	defReaderDBSSLRootCert = ""
+	defHTTPPort            = "8196"
+	defServerCert          = ""
+	defServerKey           = ""
+	defJaegerURL           = ""
+	defStorageDir          = "/reports"
+	defScheduleInterval    = "1m"
+	defThingsURL           = "http://localhost"
+	defClientTLS           = "false"
+	defCACerts             = ""
+	defAuthGRPCURL         = "localhost:8181"
+	defAuthGRPCTimeout     = "1s"
+	defThingsGRPCURL       = "localhost:8183"
+	defThingsGRPCTimeout   = "1s"
+	defEmailHost           = "localhost"
+	defEmailPort           = "25"
+	defEmailUsername       = "root"
+	defEmailPassword       = ""
+	defEmailFromAddress    = ""
+	defEmailFromName       = ""
+	defEmailTemplatesDir   = ""
+	defEmailLocale         = "en"
+
+	envLogLevel            = "MF_REPORTS_LOG_LEVEL"
+	envDBHost              = "MF_REPORTS_DB_HOST"
+	envDBPort              = "MF_REPORTS_DB_PORT"
+	envDBUser              = "MF_REPORTS_DB_USER"
+	envDBPass              = "MF_REPORTS_DB_PASS"
+	envDB                  = "MF_REPORTS_DB"
+	envDBSSLMode           = "MF_REPORTS_DB_SSL_MODE"
+	envDBSSLCert           = "MF_REPORTS_DB_SSL_CERT"
+	envDBSSLKey            = "MF_REPORTS_DB_SSL_KEY"
+	envDBSSLRootCert       = "MF_REPORTS_DB_SSL_ROOT_CERT"
+	envReaderDBHost        = "MF_REPORTS_READER_DB_HOST"
+	envReaderDBPort        = "MF_REPORTS_READER_DB_PORT"
+	envReaderDBUser        = "MF_REPORTS_READER_DB_USER"
+	envReaderDBPass        = "MF_REPORTS_READER_DB_PASS"
+	envReaderDB            = "MF_REPORTS_READER_DB"
+	envReaderDBSSLMode     = "MF_REPORTS_READER_DB_SSL_MODE"
+	envReaderDBSSLCert     = "MF_REPORTS_READER_DB_SSL_CERT"
+	envReaderDBSSLKey      = "MF_REPORTS_READER_DB_SSL_KEY"
+	envReaderDBSSLRootCert = "MF_REPORTS_READER_DB_SSL_ROOT_CERT"
+	envHTTPPort            = "MF_REPORTS_HTTP_PORT"
+	envServerCert          = "MF_REPORTS_SERVER_CERT"
+	envServerKey           = "MF_REPORTS_SERVER_KEY"
+	envJaegerURL           = "MF_JAEGER_URL"
+	envStorageDir          = "MF_REPORTS_STORAGE_DIR"
+	envScheduleInterval    = "MF_REPORTS_SCHEDULE_INTERVAL"
+	envThingsURL           = "MF_THINGS_URL"
+	envClientTLS           = "MF_REPORTS_CLIENT_TLS"
+	envCACerts             = "MF_REPORTS_CA_CERTS"
+	envAuthGRPCURL         = "MF_AUTH_GRPC_URL"
+	envAuthGRPCTimeout     = "MF_AUTH_GRPC_TIMEOUT"
+	envThingsGRPCURL       = "MF_THINGS_AUTH_GRPC_URL"
+	envThingsGRPCTimeout   = "MF_THINGS_AUTH_GRPC_TIMEOUT"
+	envEmailHost           = "MF_EMAIL_HOST"
+	envEmailPort           = "MF_EMAIL_PORT"
+	envEmailUsername       = "MF_EMAIL_USERNAME"
+	envEmailPassword       = "MF_EMAIL_PASSWORD"
+	envEmailFromAddress    = "MF_EMAIL_FROM_ADDRESS"
+	envEmailFromName       = "MF_EMAIL_FROM_NAME"
+	envEmailTemplatesDir   = "MF_EMAIL_TEMPLATES_DIR"
+	envEmailLocale         = "MF_EMAIL_LOCALE"
+)
+
+type config struct {
+	logLevel          string
+	dbConfig          postgres.Config
+	readerDBConfig    timescale.Config
+	httpPort          string
+	serverCert        string
+	serverKey         string
+	jaegerURL         string
+	storageDir        string
+	scheduleInterval  time.Duration
+	thingsURL         string
+	clientTLS         bool
+	caCerts           string
+	authGRPCURL       string
+	authGRPCTimeout   time.Duration
+	thingsGRPCURL     string
+	thingsGRPCTimeout time.Duration
+	emailConf         email.Config
+}
+
+func main() {
+	cfg := loadConfig()
+	ctx, cancel := context.WithCancel(context.Background())
+	g, ctx := errgroup.WithContext(ctx)
+
+	logger, err := logger.New(os.Stdout, cfg.logLevel)
+	if err != nil {
+		log.Fatalf(err.Error())
+	}
+
+	db := connectToDB(cfg.dbConfig, logger)
+	defer db.Close()
+
+	readerDB := connectToReaderDB(cfg.readerDBConfig, logger)
+	defer readerDB.Close()
+
+	authTracer, authCloser := initJaeger("auth", cfg.jaegerURL, logger)
+	defer authCloser.Close()
+
+	authConn := connectToGRPC(cfg, cfg.authGRPCURL, logger)
+	defer authConn.Close()
+	auth := authapi.NewClient(authTracer, authConn, cfg.authGRPCTimeout)
+
+	thingsTracer, thingsCloser := initJaeger("things", cfg.jaegerURL, logger)
+	defer thingsCloser.Close()
+
+	thingsConn := connectToGRPC(cfg, cfg.thingsGRPCURL, logger)
+	defer thingsConn.Close()
+	things := thingsapi.NewClient(thingsConn, thingsTracer, cfg.thingsGRPCTimeout)
+
+	tracer, closer := initJaeger("reports", cfg.jaegerURL, logger)
+	defer closer.Close()
+
+	svc := newService(db, readerDB, auth, things, cfg, logger)
+	checks := []mainflux.HealthCheck{
+		{Name: "database", Check: db.PingContext},
+		{Name: "reader_database", Check: readerDB.PingContext},
+	}
+
+	g.Go(func() error {
+		return startHTTPServer(ctx, tracer, svc, cfg.httpPort, cfg.serverCert, cfg.serverKey, logger, checks)
+	})
+
+	g.Go(func() error {
+		reports.Start(ctx, svc, cfg.scheduleInterval, logger)
+		return nil
+	})
+
+	g.Go(func() error {
+		if sig := errors.SignalHandler(ctx); sig != nil {
+			cancel()
+			logger.Info(fmt.Sprintf("Reports service shutdown by signal: %s", sig))
+		}
+		return nil
+	})
+
+	if err := g.Wait(); err != nil {
+		logger.Error(fmt.Sprintf("Reports service terminated: %s", err))
+	}
+}
+
+func loadConfig() config {
+	authGRPCTimeout, err := time.ParseDuration(mainflux.Env(envAuthGRPCTimeout, defAuthGRPCTimeout))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envAuthGRPCTimeout, err.Error())
+	}
+
+	thingsGRPCTimeout, err := time.ParseDuration(mainflux.Env(envThingsGRPCTimeout, defThingsGRPCTimeout))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envThingsGRPCTimeout, err.Error())
+	}
+
+	scheduleInterval, err := time.ParseDuration(mainflux.Env(envScheduleInterval, defScheduleInterval))
+	if err != nil || scheduleInterval <= 0 {
+		log.Fatalf("Invalid %s value: %s", envScheduleInterval, mainflux.Env(envScheduleInterval, defScheduleInterval))
+	}
+
+	tls, err := strconv.ParseBool(mainflux.Env(envClientTLS, defClientTLS))
+	if err != nil {
+		log.Fatalf("Invalid value passed for %s\n", envClientTLS)
+	}
+
+	dbConfig := postgres.Config{
+		Host:        mainflux.Env(envDBHost, defDBHost),
+		Port:        mainflux.Env(envDBPort, defDBPort),
+		User:        mainflux.Env(envDBUser, defDBUser),
+		Pass:        mainflux.Env(envDBPass, defDBPass),
+		Name:        mainflux.Env(envDB, defDB),
+		SSLMode:     mainflux.Env(envDBSSLMode, defDBSSLMode),
+		SSLCert:     mainflux.Env(envDBSSLCert, defDBSSLCert),
+		SSLKey:      mainflux.Env(envDBSSLKey, defDBSSLKey),
+		SSLRootCert: mainflux.Env(envDBSSLRootCert, defDBSSLRootCert),
+	}
+
+	readerDBConfig := timescale.Config{
+		Host:        mainflux.Env(envReaderDBHost, defReaderDBHost),
+		Port:        mainflux.Env(envReaderDBPort, defReaderDBPort),
+		User:        mainflux.Env(envReaderDBUser, defReaderDBUser),
+		Pass:        mainflux.Env(envReaderDBPass, defReaderDBPass),
+		Name:        mainflux.Env(envReaderDB, defReaderDB),
+		SSLMode:     mainflux.Env(envReaderDBSSLMode, defReaderDBSSLMode),
+		SSLCert:     mainflux.Env(envReaderDBSSLCert, defReaderDBSSLCert),
+		SSLKey:      mainflux.Env(envReaderDBSSLKey, defReaderDBSSLKey),
+		SSLRootCert: mainflux.Env(envReaderDBSSLRootCert, defReaderDBSSLRootCert),
+	}
+
+	emailConf := email.Config{
+		FromAddress:  mainflux.Env(envEmailFromAddress, defEmailFromAddress),
+		FromName:     mainflux.Env(envEmailFromName, defEmailFromName),
+		Host:         mainflux.Env(envEmailHost, defEmailHost),
+		Port:         mainflux.Env(envEmailPort, defEmailPort),
+		Username:     mainflux.Env(envEmailUsername, defEmailUsername),
+		Password:     mainflux.Env(envEmailPassword, defEmailPassword),
+		TemplatesDir: mainflux.Env(envEmailTemplatesDir, defEmailTemplatesDir),
+		Locale:       mainflux.Env(envEmailLocale, defEmailLocale),
+	}
+
+	return config{
+		logLevel:          mainflux.Env(envLogLevel, defLogLevel),
+		dbConfig:          dbConfig,
+		readerDBConfig:    readerDBConfig,
+		httpPort:          mainflux.Env(envHTTPPort, defHTTPPort),
+		serverCert:        mainflux.Env(envServerCert, defServerCert),
+		serverKey:         mainflux.Env(envServerKey, defServerKey),
+		jaegerURL:         mainflux.Env(envJaegerURL, defJaegerURL),
+		storageDir:        mainflux.Env(envStorageDir, defStorageDir),
+		scheduleInterval:  scheduleInterval,
+		thingsURL:         mainflux.Env(envThingsURL, defThingsURL),
+		clientTLS:         tls,
+		caCerts:           mainflux.Env(envCACerts, defCACerts),
+		authGRPCURL:       mainflux.Env(envAuthGRPCURL, defAuthGRPCURL),
+		authGRPCTimeout:   authGRPCTimeout,
+		thingsGRPCURL:     mainflux.Env(envThingsGRPCURL, defThingsGRPCURL),
+		thingsGRPCTimeout: thingsGRPCTimeout,
+		emailConf:         emailConf,
+	}
+}
+
+func initJaeger(svcName, url string, logger logger.Logger) (opentracing.Tracer, io.Closer) {
+	if url == "" {
+		return opentracing.NoopTracer{}, ioutil.NopCloser(nil)
+	}
+
+	tracer, closer, err := jconfig.Configuration{
+		ServiceName: svcName,
+		Sampler: &jconfig.SamplerConfig{
+			Type:  "const",
+			Param: 1,
+		},
+		Reporter: &jconfig.ReporterConfig{
+			LocalAgentHostPort: url,
+			LogSpans:           true,
+		},
+	}.NewTracer()
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to init Jaeger: %s", err))
+		os.Exit(1)
+	}
+
+	return tracer, closer
+}
+
+func connectToDB(dbConfig postgres.Config, logger logger.Logger) *sqlx.DB {
+	db, err := postgres.Connect(dbConfig)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to connect to postgres: %s", err))
+		os.Exit(1)
+	}
+	return db
+}
+
+func connectToReaderDB(dbConfig timescale.Config, logger logger.Logger) *sqlx.DB {
+	db, err := timescale.Connect(dbConfig)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to connect to timescale: %s", err))
+		os.Exit(1)
+	}
+	return db
+}
+
+func connectToGRPC(cfg config, url string, logger logger.Logger) *grpc.ClientConn {
+	var opts []grpc.DialOption
+	if cfg.clientTLS {
+		if cfg.caCerts != "" {
+			tpc, err := credentials.NewClientTLSFromFile(cfg.caCerts, "")
+			if err != nil {
+				logger.Error(fmt.Sprintf("Failed to create tls credentials: %s", err))
+				os.Exit(1)
+			}
+			opts = append(opts, grpc.WithTransportCredentials(tpc))
+		}
+	} else {
+		opts = append(opts, grpc.WithInsecure())
+		logger.Info("gRPC communication is not encrypted")
+	}
+
+	conn, err := grpc.Dial(url, opts...)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to connect to %s: %s", url, err))
+		os.Exit(1)
+	}
+
+	return conn
+}
+
+func newService(db, readerDB *sqlx.DB, auth mainflux.AuthServiceClient, things mainflux.ThingsServiceClient, cfg config, logger logger.Logger) reports.Service {
+	database := postgres.NewDatabase(db)
+	reportRepo := postgres.NewReportRepository(database)
+	messages := timescale.New(readerDB)
+
+	storage, err := fs.New(cfg.storageDir)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to create reports storage: %s", err))
+		os.Exit(1)
+	}
+
+	agent, err := email.New(&cfg.emailConf)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to create email agent: %s", err))
+		os.Exit(1)
+	}
+
+	sdk := mfsdk.NewSDK(mfsdk.Config{ThingsURL: cfg.thingsURL})
+	groups := repthings.New(sdk)
+
+	svc := reports.New(auth, things, groups, reportRepo, messages, storage, repemail.New(agent), uuid.New())
+	svc = api.LoggingMiddleware(svc, logger)
+	svc = api.MetricsMiddleware(
+		svc,
+		kitprometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: "reports",
+			Subsystem: "api",
+			Name:      "request_count",
+			Help:      "Number of requests received.",
+		}, []string{"method"}),
+		kitprometheus.NewSummaryFrom(stdprometheus.SummaryOpts{
+			Namespace: "reports",
+			Subsystem: "api",
+			Name:      "request_latency_microseconds",
+			Help:      "Total duration of requests in microseconds.",
+		}, []string{"method"}),
+	)
+
+	return svc
+}
+
+func startHTTPServer(ctx context.Context, tracer opentracing.Tracer, svc reports.Service, port string, certFile string, keyFile string, logger logger.Logger, checks []mainflux.HealthCheck) error {
+	p := fmt.Sprintf(":%s", port)
+	errCh := make(chan error)
+	server := &http.Server{Addr: p, Handler: api.MakeHandler(svc, tracer, logger, checks...)}
+
+	switch {
+	case certFile != "" || keyFile != "":
+		logger.Info(fmt.Sprintf("Reports service started using https, cert %s key %s, exposed port %s", certFile, keyFile, port))
+		go func() {
+			errCh <- server.ListenAndServeTLS(certFile, keyFile)
+		}()
+	default:
+		logger.Info(fmt.Sprintf("Reports service started using http, exposed port %s", port))
+		go func() {
+			errCh <- server.ListenAndServe()
+		}()
+	}
+
+	select {
+	case <-ctx.Done():
+		ctxShutdown, cancelShutdown := context.WithTimeout(context.Background(), stopWaitTime)
+		defer cancelShutdown()
+		if err := server.Shutdown(ctxShutdown); err != nil {
+			logger.Error(fmt.Sprintf("Reports service error occurred during shutdown at %s: %s", p, err))
+			return fmt.Errorf("reports service error occurred during shutdown at %s: %w", p, err)
+		}
+		logger.Info(fmt.Sprintf("Reports service shutdown of http at %s", p))
+		return nil
+	case err := <-errCh:
+		return err
+	}
+}
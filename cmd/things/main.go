@@ -16,9 +16,15 @@ import (
 	"time"
 
 	"github.com/MainfluxLabs/mainflux"
+	auditredis "github.com/MainfluxLabs/mainflux/audit/redis"
 	authapi "github.com/MainfluxLabs/mainflux/auth/api/grpc"
 	"github.com/MainfluxLabs/mainflux/logger"
+	mfapi "github.com/MainfluxLabs/mainflux/pkg/api"
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/idprefix"
+	"github.com/MainfluxLabs/mainflux/pkg/jwks"
+	"github.com/MainfluxLabs/mainflux/pkg/ksuid"
+	"github.com/MainfluxLabs/mainflux/pkg/ulid"
 	"github.com/MainfluxLabs/mainflux/pkg/uuid"
 	"github.com/MainfluxLabs/mainflux/things"
 	"github.com/MainfluxLabs/mainflux/things/api"
@@ -26,6 +32,7 @@ import (
 	authhttpapi "github.com/MainfluxLabs/mainflux/things/api/auth/http"
 	thhttpapi "github.com/MainfluxLabs/mainflux/things/api/things/http"
 	"github.com/MainfluxLabs/mainflux/things/postgres"
+	thingsreaders "github.com/MainfluxLabs/mainflux/things/readers"
 	rediscache "github.com/MainfluxLabs/mainflux/things/redis"
 	localusers "github.com/MainfluxLabs/mainflux/things/standalone"
 	"github.com/MainfluxLabs/mainflux/things/tracing"
@@ -61,6 +68,7 @@ const (
 	defESURL           = "localhost:6379"
 	defESPass          = ""
 	defESDB            = "0"
+	defAuditHashKey    = ""
 	defHTTPPort        = "8182"
 	defAuthHTTPPort    = "8989"
 	defAuthGRPCPort    = "8181"
@@ -69,8 +77,17 @@ const (
 	defStandaloneEmail = ""
 	defStandaloneToken = ""
 	defJaegerURL       = ""
+	defCORSOrigins     = ""
+	defHSTSMaxAge      = "0s"
+	defCSP             = ""
+	defV1Sunset        = ""
 	defAuthGRPCURL     = "localhost:8181"
 	defAuthGRPCTimeout = "1s"
+	defKeyIssuersFile  = ""
+	defJWKSRefresh     = "15m"
+	defReaderURL       = ""
+	defIDScheme        = uuidScheme
+	defIDPrefixes      = "false"
 
 	envLogLevel        = "MF_THINGS_LOG_LEVEL"
 	envDBHost          = "MF_THINGS_DB_HOST"
@@ -90,6 +107,7 @@ const (
 	envESURL           = "MF_THINGS_ES_URL"
 	envESPass          = "MF_THINGS_ES_PASS"
 	envESDB            = "MF_THINGS_ES_DB"
+	envAuditHashKey    = "MF_AUDIT_HASH_KEY"
 	envHTTPPort        = "MF_THINGS_HTTP_PORT"
 	envAuthHTTPPort    = "MF_THINGS_AUTH_HTTP_PORT"
 	envAuthGRPCPort    = "MF_THINGS_AUTH_GRPC_PORT"
@@ -98,8 +116,24 @@ const (
 	envStandaloneEmail = "MF_THINGS_STANDALONE_EMAIL"
 	envStandaloneToken = "MF_THINGS_STANDALONE_TOKEN"
 	envJaegerURL       = "MF_JAEGER_URL"
+	envCORSOrigins     = "MF_CORS_ALLOWED_ORIGINS"
+	envHSTSMaxAge      = "MF_HSTS_MAX_AGE"
+	envCSP             = "MF_CONTENT_SECURITY_POLICY"
+	envV1Sunset        = "MF_API_V1_SUNSET"
 	envAuthGRPCURL     = "MF_AUTH_GRPC_URL"
 	envauthGRPCTimeout = "MF_AUTH_GRPC_TIMEOUT"
+	envKeyIssuersFile  = "MF_THINGS_KEY_ISSUERS_FILE"
+	envJWKSRefresh     = "MF_THINGS_JWKS_REFRESH_INTERVAL"
+	envReaderURL       = "MF_THINGS_READER_URL"
+	envIDScheme        = "MF_THINGS_ID_SCHEME"
+	envIDPrefixes      = "MF_THINGS_ID_PREFIXES"
+
+	uuidScheme  = "uuid"
+	ulidScheme  = "ulid"
+	ksuidScheme = "ksuid"
+
+	thingIDPrefix   = "thg_"
+	channelIDPrefix = "chn_"
 )
 
 type config struct {
@@ -113,6 +147,7 @@ type config struct {
 	esURL           string
 	esPass          string
 	esDB            string
+	auditHashKey    string
 	httpPort        string
 	authHTTPPort    string
 	authGRPCPort    string
@@ -121,8 +156,15 @@ type config struct {
 	standaloneEmail string
 	standaloneToken string
 	jaegerURL       string
+	securityConfig  mfapi.SecurityConfig
+	v1Sunset        time.Time
 	authGRPCURL     string
 	authGRPCTimeout time.Duration
+	keyIssuersFile  string
+	jwksRefresh     time.Duration
+	readerURL       string
+	idScheme        string
+	idPrefixes      bool
 }
 
 func main() {
@@ -156,13 +198,29 @@ func main() {
 	dbTracer, dbCloser := initJaeger("things_db", cfg.jaegerURL, logger)
 	defer dbCloser.Close()
 
+	migrateOwners(ctx, db, auth, logger)
+
 	cacheTracer, cacheCloser := initJaeger("things_cache", cfg.jaegerURL, logger)
 	defer cacheCloser.Close()
 
-	svc := newService(auth, dbTracer, cacheTracer, db, cacheClient, esClient, logger)
+	keys := newKeyValidator(cfg, logger)
+	messages := newMessageCounter(cfg)
+
+	thingIDs := newEntityIDProvider(cfg.idScheme, thingIDPrefix, cfg.idPrefixes)
+	channelIDs := newEntityIDProvider(cfg.idScheme, channelIDPrefix, cfg.idPrefixes)
+	idProvider := newIDProvider(cfg.idScheme)
+
+	svc := newService(auth, dbTracer, cacheTracer, db, cacheClient, esClient, thingIDs, channelIDs, idProvider, keys, messages, logger)
+	checks := []mainflux.HealthCheck{
+		{Name: "database", Check: db.PingContext},
+		{Name: "cache", Check: func(ctx context.Context) error { return cacheClient.Ping(ctx).Err() }},
+		{Name: "es", Check: func(ctx context.Context) error { return esClient.Ping(ctx).Err() }},
+	}
 
 	g.Go(func() error {
-		return startHTTPServer(ctx, "thing-http", thhttpapi.MakeHandler(thingsTracer, svc, logger), cfg.httpPort, cfg, logger)
+		handler := auditredis.NewHandler(thhttpapi.MakeHandler(thingsTracer, svc, logger, checks...), "things", auth, esClient, cfg.auditHashKey, logger)
+		handler = mfapi.SecurityMiddleware(cfg.securityConfig, mfapi.Versioned(handler, handler, cfg.v1Sunset))
+		return startHTTPServer(ctx, "thing-http", handler, cfg.httpPort, cfg, logger)
 	})
 
 	g.Go(func() error {
@@ -209,6 +267,39 @@ func loadConfig() config {
 		SSLRootCert: mainflux.Env(envDBSSLRootCert, defDBSSLRootCert),
 	}
 
+	hstsMaxAge, err := time.ParseDuration(mainflux.Env(envHSTSMaxAge, defHSTSMaxAge))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envHSTSMaxAge, err.Error())
+	}
+
+	jwksRefresh, err := time.ParseDuration(mainflux.Env(envJWKSRefresh, defJWKSRefresh))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envJWKSRefresh, err.Error())
+	}
+
+	idScheme := mainflux.Env(envIDScheme, defIDScheme)
+	if idScheme != uuidScheme && idScheme != ulidScheme && idScheme != ksuidScheme {
+		log.Fatalf("Invalid %s value %s, expected %s, %s or %s", envIDScheme, idScheme, uuidScheme, ulidScheme, ksuidScheme)
+	}
+
+	idPrefixes, err := strconv.ParseBool(mainflux.Env(envIDPrefixes, defIDPrefixes))
+	if err != nil {
+		log.Fatalf("Invalid value passed for %s\n", envIDPrefixes)
+	}
+
+	var v1Sunset time.Time
+	if sunset := mainflux.Env(envV1Sunset, defV1Sunset); sunset != "" {
+		if v1Sunset, err = time.Parse(time.RFC3339, sunset); err != nil {
+			log.Fatalf("Invalid %s value: %s", envV1Sunset, err.Error())
+		}
+	}
+
+	securityConfig := mfapi.SecurityConfig{
+		AllowedOrigins:        mfapi.ParseList(mainflux.Env(envCORSOrigins, defCORSOrigins)),
+		HSTSMaxAge:            hstsMaxAge,
+		ContentSecurityPolicy: mainflux.Env(envCSP, defCSP),
+	}
+
 	return config{
 		logLevel:        mainflux.Env(envLogLevel, defLogLevel),
 		dbConfig:        dbConfig,
@@ -220,6 +311,7 @@ func loadConfig() config {
 		esURL:           mainflux.Env(envESURL, defESURL),
 		esPass:          mainflux.Env(envESPass, defESPass),
 		esDB:            mainflux.Env(envESDB, defESDB),
+		auditHashKey:    mainflux.Env(envAuditHashKey, defAuditHashKey),
 		httpPort:        mainflux.Env(envHTTPPort, defHTTPPort),
 		authHTTPPort:    mainflux.Env(envAuthHTTPPort, defAuthHTTPPort),
 		authGRPCPort:    mainflux.Env(envAuthGRPCPort, defAuthGRPCPort),
@@ -228,8 +320,15 @@ func loadConfig() config {
 		standaloneEmail: mainflux.Env(envStandaloneEmail, defStandaloneEmail),
 		standaloneToken: mainflux.Env(envStandaloneToken, defStandaloneToken),
 		jaegerURL:       mainflux.Env(envJaegerURL, defJaegerURL),
+		securityConfig:  securityConfig,
+		v1Sunset:        v1Sunset,
 		authGRPCURL:     mainflux.Env(envAuthGRPCURL, defAuthGRPCURL),
 		authGRPCTimeout: authGRPCTimeout,
+		keyIssuersFile:  mainflux.Env(envKeyIssuersFile, defKeyIssuersFile),
+		jwksRefresh:     jwksRefresh,
+		readerURL:       mainflux.Env(envReaderURL, defReaderURL),
+		idScheme:        idScheme,
+		idPrefixes:      idPrefixes,
 	}
 }
 
@@ -280,6 +379,16 @@ func connectToDB(dbConfig postgres.Config, logger logger.Logger) *sqlx.DB {
 	return db
 }
 
+// migrateOwners registers the owner policies of the things and the channels
+// created before the policies were introduced.
+func migrateOwners(ctx context.Context, db *sqlx.DB, ac mainflux.AuthServiceClient, logger logger.Logger) {
+	database := postgres.NewDatabase(db)
+	if err := things.MigrateOwners(ctx, ac, postgres.NewThingRepository(database), postgres.NewChannelRepository(database)); err != nil {
+		logger.Error(fmt.Sprintf("Failed to migrate owners: %s", err))
+		os.Exit(1)
+	}
+}
+
 func createAuthClient(cfg config, tracer opentracing.Tracer, logger logger.Logger) (mainflux.AuthServiceClient, func() error) {
 	if cfg.standaloneEmail != "" && cfg.standaloneToken != "" {
 		return localusers.NewAuthService(cfg.standaloneEmail, cfg.standaloneToken), nil
@@ -314,7 +423,56 @@ func connectToAuth(cfg config, logger logger.Logger) *grpc.ClientConn {
 	return conn
 }
 
-func newService(ac mainflux.AuthServiceClient, dbTracer opentracing.Tracer, cacheTracer opentracing.Tracer, db *sqlx.DB, cacheClient *redis.Client, esClient *redis.Client, logger logger.Logger) things.Service {
+func newKeyValidator(cfg config, logger logger.Logger) things.ExternalKeyValidator {
+	if cfg.keyIssuersFile == "" {
+		return nil
+	}
+
+	issuers, err := jwks.LoadIssuers(cfg.keyIssuersFile)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to load thing key issuers: %s", err))
+		os.Exit(1)
+	}
+	logger.Info(fmt.Sprintf("Accepting thing keys issued by %d external issuers", len(issuers)))
+
+	return jwks.NewValidator(issuers, &http.Client{Timeout: 10 * time.Second}, cfg.jwksRefresh)
+}
+
+func newMessageCounter(cfg config) things.MessageCounter {
+	if cfg.readerURL == "" {
+		return nil
+	}
+
+	return thingsreaders.NewMessageCounter(cfg.readerURL, &http.Client{Timeout: 10 * time.Second})
+}
+
+// newIDProvider returns the provider of the group and template IDs. These
+// are stored as UUIDs, so both sortable schemes use ULIDs in the UUID form.
+func newIDProvider(scheme string) mainflux.IDProvider {
+	switch scheme {
+	case ulidScheme, ksuidScheme:
+		return ulid.NewUUID()
+	default:
+		return uuid.New()
+	}
+}
+
+// newEntityIDProvider returns the provider of the thing or channel IDs,
+// optionally prefixed with the entity type.
+func newEntityIDProvider(scheme, prefix string, prefixed bool) mainflux.IDProvider {
+	idp := newIDProvider(scheme)
+	if scheme == ksuidScheme {
+		idp = ksuid.New()
+	}
+
+	if prefixed {
+		return idprefix.New(idp, prefix)
+	}
+
+	return idp
+}
+
+func newService(ac mainflux.AuthServiceClient, dbTracer opentracing.Tracer, cacheTracer opentracing.Tracer, db *sqlx.DB, cacheClient *redis.Client, esClient *redis.Client, thingIDs, channelIDs, idProvider mainflux.IDProvider, keys things.ExternalKeyValidator, messages things.MessageCounter, logger logger.Logger) things.Service {
 	database := postgres.NewDatabase(db)
 
 	thingsRepo := postgres.NewThingRepository(database)
@@ -326,14 +484,16 @@ func newService(ac mainflux.AuthServiceClient, dbTracer opentracing.Tracer, cach
 	groupsRepo := postgres.NewGroupRepo(database)
 	groupsRepo = tracing.GroupRepositoryMiddleware(dbTracer, groupsRepo)
 
+	templatesRepo := postgres.NewGroupTemplateRepository(database)
+	templatesRepo = tracing.GroupTemplateRepositoryMiddleware(dbTracer, templatesRepo)
+
 	chanCache := rediscache.NewChannelCache(cacheClient)
 	chanCache = tracing.ChannelCacheMiddleware(cacheTracer, chanCache)
 
 	thingCache := rediscache.NewThingCache(cacheClient)
 	thingCache = tracing.ThingCacheMiddleware(cacheTracer, thingCache)
-	idProvider := uuid.New()
 
-	svc := things.New(ac, thingsRepo, channelsRepo, groupsRepo, chanCache, thingCache, idProvider)
+	svc := things.New(ac, thingsRepo, channelsRepo, groupsRepo, templatesRepo, chanCache, thingCache, thingIDs, channelIDs, idProvider, uuid.New(), keys, messages)
 	svc = rediscache.NewEventStoreMiddleware(svc, esClient)
 	svc = api.LoggingMiddleware(svc, logger)
 	svc = api.MetricsMiddleware(
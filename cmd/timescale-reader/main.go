@@ -17,12 +17,15 @@ import (
 	"github.com/MainfluxLabs/mainflux"
 	authapi "github.com/MainfluxLabs/mainflux/auth/api/grpc"
 	"github.com/MainfluxLabs/mainflux/logger"
+	mfapi "github.com/MainfluxLabs/mainflux/pkg/api"
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
 	"github.com/MainfluxLabs/mainflux/readers"
 	"github.com/MainfluxLabs/mainflux/readers/api"
+	rediscache "github.com/MainfluxLabs/mainflux/readers/redis"
 	"github.com/MainfluxLabs/mainflux/readers/timescale"
 	thingsapi "github.com/MainfluxLabs/mainflux/things/api/auth/grpc"
 	kitprometheus "github.com/go-kit/kit/metrics/prometheus"
+	"github.com/go-redis/redis/v8"
 	"github.com/jmoiron/sqlx"
 	opentracing "github.com/opentracing/opentracing-go"
 	stdprometheus "github.com/prometheus/client_golang/prometheus"
@@ -36,56 +39,75 @@ const (
 	svcName      = "timescaledb-reader"
 	stopWaitTime = 5 * time.Second
 
-	defLogLevel          = "error"
-	defPort              = "8911"
-	defClientTLS         = "false"
-	defCACerts           = ""
-	defDBHost            = "localhost"
-	defDBPort            = "5432"
-	defDBUser            = "mainflux"
-	defDBPass            = "mainflux"
-	defDB                = "mainflux"
-	defDBSSLMode         = "disable"
-	defDBSSLCert         = ""
-	defDBSSLKey          = ""
-	defDBSSLRootCert     = ""
-	defJaegerURL         = ""
-	defThingsGRPCURL     = "localhost:8183"
-	defThingsGRPCTimeout = "1s"
-	defAuthGRPCURL       = "localhost:8181"
-	defAuthGRPCTimeout   = "1s"
-
-	envLogLevel          = "MF_TIMESCALE_READER_LOG_LEVEL"
-	envPort              = "MF_TIMESCALE_READER_PORT"
-	envClientTLS         = "MF_TIMESCALE_READER_CLIENT_TLS"
-	envCACerts           = "MF_TIMESCALE_READER_CA_CERTS"
-	envDBHost            = "MF_TIMESCALE_READER_DB_HOST"
-	envDBPort            = "MF_TIMESCALE_READER_DB_PORT"
-	envDBUser            = "MF_TIMESCALE_READER_DB_USER"
-	envDBPass            = "MF_TIMESCALE_READER_DB_PASS"
-	envDB                = "MF_TIMESCALE_READER_DB"
-	envDBSSLMode         = "MF_TIMESCALE_READER_DB_SSL_MODE"
-	envDBSSLCert         = "MF_TIMESCALE_READER_DB_SSL_CERT"
-	envDBSSLKey          = "MF_TIMESCALE_READER_DB_SSL_KEY"
-	envDBSSLRootCert     = "MF_TIMESCALE_READER_DB_SSL_ROOT_CERT"
-	envJaegerURL         = "MF_JAEGER_URL"
-	envThingsGRPCURL     = "MF_THINGS_AUTH_GRPC_URL"
-	envThingsGRPCTimeout = "MF_THINGS_AUTH_GRPC_TIMEOUT"
-	envAuthGRPCURL       = "MF_AUTH_GRPC_URL"
-	envAuthGRPCTimeout   = "MF_AUTH_GRPC_TIMEOUT"
+	defLogLevel           = "error"
+	defPort               = "8911"
+	defClientTLS          = "false"
+	defCACerts            = ""
+	defDBHost             = "localhost"
+	defDBPort             = "5432"
+	defDBUser             = "mainflux"
+	defDBPass             = "mainflux"
+	defDB                 = "mainflux"
+	defDBSSLMode          = "disable"
+	defDBSSLCert          = ""
+	defDBSSLKey           = ""
+	defDBSSLRootCert      = ""
+	defJaegerURL          = ""
+	defCORSOrigins        = ""
+	defHSTSMaxAge         = "0s"
+	defCSP                = ""
+	defV1Sunset           = ""
+	defLastValueCacheURL  = ""
+	defLastValueCachePass = ""
+	defLastValueCacheDB   = "0"
+	defThingsGRPCURL      = "localhost:8183"
+	defThingsGRPCTimeout  = "1s"
+	defAuthGRPCURL        = "localhost:8181"
+	defAuthGRPCTimeout    = "1s"
+
+	envLogLevel           = "MF_TIMESCALE_READER_LOG_LEVEL"
+	envPort               = "MF_TIMESCALE_READER_PORT"
+	envClientTLS          = "MF_TIMESCALE_READER_CLIENT_TLS"
+	envCACerts            = "MF_TIMESCALE_READER_CA_CERTS"
+	envDBHost             = "MF_TIMESCALE_READER_DB_HOST"
+	envDBPort             = "MF_TIMESCALE_READER_DB_PORT"
+	envDBUser             = "MF_TIMESCALE_READER_DB_USER"
+	envDBPass             = "MF_TIMESCALE_READER_DB_PASS"
+	envDB                 = "MF_TIMESCALE_READER_DB"
+	envDBSSLMode          = "MF_TIMESCALE_READER_DB_SSL_MODE"
+	envDBSSLCert          = "MF_TIMESCALE_READER_DB_SSL_CERT"
+	envDBSSLKey           = "MF_TIMESCALE_READER_DB_SSL_KEY"
+	envDBSSLRootCert      = "MF_TIMESCALE_READER_DB_SSL_ROOT_CERT"
+	envJaegerURL          = "MF_JAEGER_URL"
+	envCORSOrigins        = "MF_CORS_ALLOWED_ORIGINS"
+	envHSTSMaxAge         = "MF_HSTS_MAX_AGE"
+	envCSP                = "MF_CONTENT_SECURITY_POLICY"
+	envV1Sunset           = "MF_API_V1_SUNSET"
+	envLastValueCacheURL  = "MF_LAST_VALUE_CACHE_URL"
+	envLastValueCachePass = "MF_LAST_VALUE_CACHE_PASS"
+	envLastValueCacheDB   = "MF_LAST_VALUE_CACHE_DB"
+	envThingsGRPCURL      = "MF_THINGS_AUTH_GRPC_URL"
+	envThingsGRPCTimeout  = "MF_THINGS_AUTH_GRPC_TIMEOUT"
+	envAuthGRPCURL        = "MF_AUTH_GRPC_URL"
+	envAuthGRPCTimeout    = "MF_AUTH_GRPC_TIMEOUT"
 )
 
 type config struct {
-	logLevel          string
-	port              string
-	clientTLS         bool
-	caCerts           string
-	dbConfig          timescale.Config
-	jaegerURL         string
-	thingsGRPCURL     string
-	authGRPCURL       string
-	thingsGRPCTimeout time.Duration
-	authGRPCTimeout   time.Duration
+	logLevel           string
+	port               string
+	clientTLS          bool
+	caCerts            string
+	dbConfig           timescale.Config
+	jaegerURL          string
+	securityConfig     mfapi.SecurityConfig
+	v1Sunset           time.Time
+	lastValueCacheURL  string
+	lastValueCachePass string
+	lastValueCacheDB   string
+	thingsGRPCURL      string
+	authGRPCURL        string
+	thingsGRPCTimeout  time.Duration
+	authGRPCTimeout    time.Duration
 }
 
 func main() {
@@ -118,8 +140,20 @@ func main() {
 
 	repo := newService(db, logger)
 
+	// Last messages are not served if the last value cache is not set.
+	var lvc readers.LastValueCache
+	if cfg.lastValueCacheURL != "" {
+		cacheClient := connectToRedis(cfg.lastValueCacheURL, cfg.lastValueCachePass, cfg.lastValueCacheDB, logger)
+		defer cacheClient.Close()
+		lvc = rediscache.New(cacheClient)
+	}
+
+	checks := []mainflux.HealthCheck{
+		{Name: "database", Check: db.PingContext},
+	}
+
 	g.Go(func() error {
-		return startHTTPServer(ctx, repo, tc, auth, cfg.port, logger)
+		return startHTTPServer(ctx, repo, lvc, tc, auth, cfg.securityConfig, cfg.v1Sunset, cfg.port, logger, checks)
 	})
 
 	g.Go(func() error {
@@ -163,17 +197,40 @@ func loadConfig() config {
 		log.Fatalf("Invalid %s value: %s", envThingsGRPCTimeout, err.Error())
 	}
 
+	hstsMaxAge, err := time.ParseDuration(mainflux.Env(envHSTSMaxAge, defHSTSMaxAge))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envHSTSMaxAge, err.Error())
+	}
+
+	var v1Sunset time.Time
+	if sunset := mainflux.Env(envV1Sunset, defV1Sunset); sunset != "" {
+		if v1Sunset, err = time.Parse(time.RFC3339, sunset); err != nil {
+			log.Fatalf("Invalid %s value: %s", envV1Sunset, err.Error())
+		}
+	}
+
+	securityConfig := mfapi.SecurityConfig{
+		AllowedOrigins:        mfapi.ParseList(mainflux.Env(envCORSOrigins, defCORSOrigins)),
+		HSTSMaxAge:            hstsMaxAge,
+		ContentSecurityPolicy: mainflux.Env(envCSP, defCSP),
+	}
+
 	return config{
-		logLevel:          mainflux.Env(envLogLevel, defLogLevel),
-		port:              mainflux.Env(envPort, defPort),
-		clientTLS:         tls,
-		caCerts:           mainflux.Env(envCACerts, defCACerts),
-		dbConfig:          dbConfig,
-		jaegerURL:         mainflux.Env(envJaegerURL, defJaegerURL),
-		thingsGRPCURL:     mainflux.Env(envThingsGRPCURL, defThingsGRPCURL),
-		thingsGRPCTimeout: thingsGRPCTimeout,
-		authGRPCURL:       mainflux.Env(envAuthGRPCURL, defAuthGRPCURL),
-		authGRPCTimeout:   authGRPCTimeout,
+		logLevel:           mainflux.Env(envLogLevel, defLogLevel),
+		port:               mainflux.Env(envPort, defPort),
+		clientTLS:          tls,
+		caCerts:            mainflux.Env(envCACerts, defCACerts),
+		dbConfig:           dbConfig,
+		jaegerURL:          mainflux.Env(envJaegerURL, defJaegerURL),
+		securityConfig:     securityConfig,
+		v1Sunset:           v1Sunset,
+		lastValueCacheURL:  mainflux.Env(envLastValueCacheURL, defLastValueCacheURL),
+		lastValueCachePass: mainflux.Env(envLastValueCachePass, defLastValueCachePass),
+		lastValueCacheDB:   mainflux.Env(envLastValueCacheDB, defLastValueCacheDB),
+		thingsGRPCURL:      mainflux.Env(envThingsGRPCURL, defThingsGRPCURL),
+		thingsGRPCTimeout:  thingsGRPCTimeout,
+		authGRPCURL:        mainflux.Env(envAuthGRPCURL, defAuthGRPCURL),
+		authGRPCTimeout:    authGRPCTimeout,
 	}
 }
 
@@ -212,6 +269,20 @@ func connectToDB(dbConfig timescale.Config, logger logger.Logger) *sqlx.DB {
 	return db
 }
 
+func connectToRedis(cacheURL, cachePass, cacheDB string, logger logger.Logger) *redis.Client {
+	db, err := strconv.Atoi(cacheDB)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to connect to cache: %s", err))
+		os.Exit(1)
+	}
+
+	return redis.NewClient(&redis.Options{
+		Addr:     cacheURL,
+		Password: cachePass,
+		DB:       db,
+	})
+}
+
 func initJaeger(svcName, url string, logger logger.Logger) (opentracing.Tracer, io.Closer) {
 	if url == "" {
 		return opentracing.NoopTracer{}, ioutil.NopCloser(nil)
@@ -282,10 +353,11 @@ func newService(db *sqlx.DB, logger logger.Logger) readers.MessageRepository {
 	return svc
 }
 
-func startHTTPServer(ctx context.Context, repo readers.MessageRepository, tc mainflux.ThingsServiceClient, ac mainflux.AuthServiceClient, port string, logger logger.Logger) error {
+func startHTTPServer(ctx context.Context, repo readers.MessageRepository, lvc readers.LastValueCache, tc mainflux.ThingsServiceClient, ac mainflux.AuthServiceClient, secCfg mfapi.SecurityConfig, v1Sunset time.Time, port string, logger logger.Logger, checks []mainflux.HealthCheck) error {
 	p := fmt.Sprintf(":%s", port)
 	errCh := make(chan error)
-	server := &http.Server{Addr: p, Handler: api.MakeHandler(repo, tc, ac, svcName, logger)}
+	handler := api.MakeHandler(repo, nil, lvc, nil, tc, ac, svcName, logger, checks...)
+	server := &http.Server{Addr: p, Handler: mfapi.SecurityMiddleware(secCfg, mfapi.Versioned(handler, handler, v1Sunset))}
 
 	logger.Info(fmt.Sprintf("Timescale reader service started, exposed port %s", port))
 	go func() {
@@ -16,6 +16,7 @@ import (
 	"strconv"
 	"time"
 
+	auditredis "github.com/MainfluxLabs/mainflux/audit/redis"
 	"github.com/MainfluxLabs/mainflux/internal/email"
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
 	"github.com/MainfluxLabs/mainflux/pkg/uuid"
@@ -30,10 +31,12 @@ import (
 	"github.com/MainfluxLabs/mainflux"
 	authapi "github.com/MainfluxLabs/mainflux/auth/api/grpc"
 	"github.com/MainfluxLabs/mainflux/logger"
+	mfapi "github.com/MainfluxLabs/mainflux/pkg/api"
 	grpcapi "github.com/MainfluxLabs/mainflux/users/api/grpc"
 	httpapi "github.com/MainfluxLabs/mainflux/users/api/http"
 	"github.com/MainfluxLabs/mainflux/users/postgres"
 	kitprometheus "github.com/go-kit/kit/metrics/prometheus"
+	"github.com/go-redis/redis/v8"
 	"github.com/jmoiron/sqlx"
 	opentracing "github.com/opentracing/opentracing-go"
 	stdprometheus "github.com/prometheus/client_golang/prometheus"
@@ -57,19 +60,26 @@ const (
 	defServerCert    = ""
 	defServerKey     = ""
 	defJaegerURL     = ""
-
-	defEmailHost        = "localhost"
-	defEmailPort        = "25"
-	defEmailUsername    = "root"
-	defEmailPassword    = ""
-	defEmailFromAddress = ""
-	defEmailFromName    = ""
-	defEmailTemplate    = "email.tmpl"
-	defAdminEmail       = ""
-	defAdminPassword    = ""
-	defPassRegex        = "^.{8,}$"
-
-	defTokenResetEndpoint = "/reset-request" // URL where user lands after click on the reset link from email
+	defCORSOrigins   = ""
+	defHSTSMaxAge    = "0s"
+	defCSP           = ""
+	defV1Sunset      = ""
+
+	defEmailHost         = "localhost"
+	defEmailPort         = "25"
+	defEmailUsername     = "root"
+	defEmailPassword     = ""
+	defEmailFromAddress  = ""
+	defEmailFromName     = ""
+	defEmailTemplatesDir = ""
+	defEmailLocale       = "en"
+	defAdminEmail        = ""
+	defAdminPassword     = ""
+	defPassRegex         = "^.{8,}$"
+
+	defTokenResetEndpoint = "/reset-request"     // URL where user lands after click on the reset link from email
+	defInvitationEndpoint = "/accept-invitation" // URL where user lands after click on the invitation link from email
+	defInvitationDuration = "168h"
 
 	defAuthTLS         = "false"
 	defAuthCACerts     = ""
@@ -79,6 +89,12 @@ const (
 
 	defSelfRegister = "true" // By default, everybody can create a user. Otherwise, only admin can create a user.
 
+	defResetInterval = "1m"
+	defESURL         = "localhost:6379"
+	defESPass        = ""
+	defESDB          = "0"
+	defAuditHashKey  = ""
+
 	envLogLevel      = "MF_USERS_LOG_LEVEL"
 	envDBHost        = "MF_USERS_DB_HOST"
 	envDBPort        = "MF_USERS_DB_PORT"
@@ -93,20 +109,27 @@ const (
 	envServerCert    = "MF_USERS_SERVER_CERT"
 	envServerKey     = "MF_USERS_SERVER_KEY"
 	envJaegerURL     = "MF_JAEGER_URL"
+	envCORSOrigins   = "MF_CORS_ALLOWED_ORIGINS"
+	envHSTSMaxAge    = "MF_HSTS_MAX_AGE"
+	envCSP           = "MF_CONTENT_SECURITY_POLICY"
+	envV1Sunset      = "MF_API_V1_SUNSET"
 
 	envAdminEmail    = "MF_USERS_ADMIN_EMAIL"
 	envAdminPassword = "MF_USERS_ADMIN_PASSWORD"
 	envPassRegex     = "MF_USERS_PASS_REGEX"
 
-	envEmailHost        = "MF_EMAIL_HOST"
-	envEmailPort        = "MF_EMAIL_PORT"
-	envEmailUsername    = "MF_EMAIL_USERNAME"
-	envEmailPassword    = "MF_EMAIL_PASSWORD"
-	envEmailFromAddress = "MF_EMAIL_FROM_ADDRESS"
-	envEmailFromName    = "MF_EMAIL_FROM_NAME"
-	envEmailTemplate    = "MF_EMAIL_TEMPLATE"
+	envEmailHost         = "MF_EMAIL_HOST"
+	envEmailPort         = "MF_EMAIL_PORT"
+	envEmailUsername     = "MF_EMAIL_USERNAME"
+	envEmailPassword     = "MF_EMAIL_PASSWORD"
+	envEmailFromAddress  = "MF_EMAIL_FROM_ADDRESS"
+	envEmailFromName     = "MF_EMAIL_FROM_NAME"
+	envEmailTemplatesDir = "MF_EMAIL_TEMPLATES_DIR"
+	envEmailLocale       = "MF_EMAIL_LOCALE"
 
 	envTokenResetEndpoint = "MF_TOKEN_RESET_ENDPOINT"
+	envInvitationEndpoint = "MF_USERS_INVITATION_ENDPOINT"
+	envInvitationDuration = "MF_USERS_INVITATION_DURATION"
 
 	envAuthTLS         = "MF_AUTH_CLIENT_TLS"
 	envAuthCACerts     = "MF_AUTH_CA_CERTS"
@@ -115,6 +138,12 @@ const (
 	envGRPCPort        = "MF_USERS_GRPC_PORT"
 
 	envSelfRegister = "MF_USERS_ALLOW_SELF_REGISTER"
+
+	envResetInterval = "MF_USERS_RESET_REQUEST_INTERVAL"
+	envESURL         = "MF_USERS_ES_URL"
+	envESPass        = "MF_USERS_ES_PASS"
+	envESDB          = "MF_USERS_ES_DB"
+	envAuditHashKey  = "MF_AUDIT_HASH_KEY"
 )
 
 type config struct {
@@ -126,7 +155,11 @@ type config struct {
 	serverCert      string
 	serverKey       string
 	jaegerURL       string
+	securityConfig  mfapi.SecurityConfig
+	v1Sunset        time.Time
 	resetURL        string
+	inviteURL       string
+	inviteDuration  time.Duration
 	authTLS         bool
 	authCACerts     string
 	authURL         string
@@ -135,6 +168,11 @@ type config struct {
 	adminPassword   string
 	passRegex       *regexp.Regexp
 	selfRegister    bool
+	resetInterval   time.Duration
+	esURL           string
+	esPass          string
+	esDB            string
+	auditHashKey    string
 }
 
 func main() {
@@ -166,10 +204,19 @@ func main() {
 	dbTracer, dbCloser := initJaeger("users_db", cfg.jaegerURL, logger)
 	defer dbCloser.Close()
 
+	esClient := connectToRedis(cfg.esURL, cfg.esPass, cfg.esDB, logger)
+	defer esClient.Close()
+
 	svc := newService(db, dbTracer, auth, cfg, logger)
+	checks := []mainflux.HealthCheck{
+		{Name: "database", Check: db.PingContext},
+		{Name: "es", Check: func(ctx context.Context) error { return esClient.Ping(ctx).Err() }},
+	}
 
 	g.Go(func() error {
-		return startHTTPServer(ctx, tracer, svc, cfg.httpPort, cfg.serverCert, cfg.serverKey, logger)
+		handler := auditredis.NewHandler(httpapi.MakeHandler(svc, tracer, logger, checks...), "users", auth, esClient, cfg.auditHashKey, logger)
+		handler = mfapi.SecurityMiddleware(cfg.securityConfig, mfapi.Versioned(handler, handler, cfg.v1Sunset))
+		return startHTTPServer(ctx, handler, cfg.httpPort, cfg.serverCert, cfg.serverKey, logger)
 	})
 
 	g.Go(func() error {
@@ -210,6 +257,16 @@ func loadConfig() config {
 		log.Fatalf("Invalid %s value: %s", envSelfRegister, err.Error())
 	}
 
+	resetInterval, err := time.ParseDuration(mainflux.Env(envResetInterval, defResetInterval))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envResetInterval, err.Error())
+	}
+
+	inviteDuration, err := time.ParseDuration(mainflux.Env(envInvitationDuration, defInvitationDuration))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envInvitationDuration, err.Error())
+	}
+
 	dbConfig := postgres.Config{
 		Host:        mainflux.Env(envDBHost, defDBHost),
 		Port:        mainflux.Env(envDBPort, defDBPort),
@@ -223,13 +280,32 @@ func loadConfig() config {
 	}
 
 	emailConf := email.Config{
-		FromAddress: mainflux.Env(envEmailFromAddress, defEmailFromAddress),
-		FromName:    mainflux.Env(envEmailFromName, defEmailFromName),
-		Host:        mainflux.Env(envEmailHost, defEmailHost),
-		Port:        mainflux.Env(envEmailPort, defEmailPort),
-		Username:    mainflux.Env(envEmailUsername, defEmailUsername),
-		Password:    mainflux.Env(envEmailPassword, defEmailPassword),
-		Template:    mainflux.Env(envEmailTemplate, defEmailTemplate),
+		FromAddress:  mainflux.Env(envEmailFromAddress, defEmailFromAddress),
+		FromName:     mainflux.Env(envEmailFromName, defEmailFromName),
+		Host:         mainflux.Env(envEmailHost, defEmailHost),
+		Port:         mainflux.Env(envEmailPort, defEmailPort),
+		Username:     mainflux.Env(envEmailUsername, defEmailUsername),
+		Password:     mainflux.Env(envEmailPassword, defEmailPassword),
+		TemplatesDir: mainflux.Env(envEmailTemplatesDir, defEmailTemplatesDir),
+		Locale:       mainflux.Env(envEmailLocale, defEmailLocale),
+	}
+
+	hstsMaxAge, err := time.ParseDuration(mainflux.Env(envHSTSMaxAge, defHSTSMaxAge))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envHSTSMaxAge, err.Error())
+	}
+
+	var v1Sunset time.Time
+	if sunset := mainflux.Env(envV1Sunset, defV1Sunset); sunset != "" {
+		if v1Sunset, err = time.Parse(time.RFC3339, sunset); err != nil {
+			log.Fatalf("Invalid %s value: %s", envV1Sunset, err.Error())
+		}
+	}
+
+	securityConfig := mfapi.SecurityConfig{
+		AllowedOrigins:        mfapi.ParseList(mainflux.Env(envCORSOrigins, defCORSOrigins)),
+		HSTSMaxAge:            hstsMaxAge,
+		ContentSecurityPolicy: mainflux.Env(envCSP, defCSP),
 	}
 
 	return config{
@@ -241,7 +317,11 @@ func loadConfig() config {
 		serverCert:      mainflux.Env(envServerCert, defServerCert),
 		serverKey:       mainflux.Env(envServerKey, defServerKey),
 		jaegerURL:       mainflux.Env(envJaegerURL, defJaegerURL),
+		securityConfig:  securityConfig,
+		v1Sunset:        v1Sunset,
 		resetURL:        mainflux.Env(envTokenResetEndpoint, defTokenResetEndpoint),
+		inviteURL:       mainflux.Env(envInvitationEndpoint, defInvitationEndpoint),
+		inviteDuration:  inviteDuration,
 		authTLS:         tls,
 		authCACerts:     mainflux.Env(envAuthCACerts, defAuthCACerts),
 		authURL:         mainflux.Env(envAuthGRPCURL, defAuthGRPCURL),
@@ -250,6 +330,11 @@ func loadConfig() config {
 		adminPassword:   mainflux.Env(envAdminPassword, defAdminPassword),
 		passRegex:       passRegex,
 		selfRegister:    selfRegister,
+		resetInterval:   resetInterval,
+		esURL:           mainflux.Env(envESURL, defESURL),
+		esPass:          mainflux.Env(envESPass, defESPass),
+		esDB:            mainflux.Env(envESDB, defESDB),
+		auditHashKey:    mainflux.Env(envAuditHashKey, defAuditHashKey),
 	}
 
 }
@@ -277,6 +362,20 @@ func initJaeger(svcName, url string, logger logger.Logger) (opentracing.Tracer,
 
 	return tracer, closer
 }
+func connectToRedis(esURL, esPass string, esDB string, logger logger.Logger) *redis.Client {
+	db, err := strconv.Atoi(esDB)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to connect to event store: %s", err))
+		os.Exit(1)
+	}
+
+	return redis.NewClient(&redis.Options{
+		Addr:     esURL,
+		Password: esPass,
+		DB:       db,
+	})
+}
+
 func connectToDB(dbConfig postgres.Config, logger logger.Logger) *sqlx.DB {
 	db, err := postgres.Connect(dbConfig)
 	if err != nil {
@@ -315,15 +414,19 @@ func newService(db *sqlx.DB, tracer opentracing.Tracer, ac mainflux.AuthServiceC
 	database := postgres.NewDatabase(db)
 	hasher := bcrypt.New()
 	userRepo := tracing.UserRepositoryMiddleware(postgres.NewUserRepo(database), tracer)
+	totpRepo := tracing.TOTPRepositoryMiddleware(postgres.NewTOTPRepo(database), tracer)
+	invitationRepo := tracing.InvitationRepositoryMiddleware(postgres.NewInvitationRepo(database), tracer)
+	brandingRepo := tracing.BrandingRepositoryMiddleware(postgres.NewBrandingRepo(database), tracer)
 
-	emailer, err := emailer.New(c.resetURL, &c.emailConf)
+	emailer, err := emailer.New(c.resetURL, c.inviteURL, &c.emailConf)
 	if err != nil {
 		logger.Error(fmt.Sprintf("Failed to configure e-mailing util: %s", err.Error()))
 	}
 
 	idProvider := uuid.New()
 
-	svc := users.New(userRepo, hasher, ac, emailer, idProvider, c.passRegex)
+	svc := users.New(userRepo, totpRepo, invitationRepo, brandingRepo, hasher, ac, emailer, idProvider, c.passRegex, c.inviteDuration)
+	svc = httpapi.ResetLimitMiddleware(svc, c.resetInterval)
 	svc = httpapi.LoggingMiddleware(svc, logger)
 	svc = httpapi.MetricsMiddleware(
 		svc,
@@ -361,10 +464,10 @@ func createAdmin(svc users.Service, c config) error {
 	return nil
 }
 
-func startHTTPServer(ctx context.Context, tracer opentracing.Tracer, svc users.Service, port string, certFile string, keyFile string, logger logger.Logger) error {
+func startHTTPServer(ctx context.Context, handler http.Handler, port string, certFile string, keyFile string, logger logger.Logger) error {
 	p := fmt.Sprintf(":%s", port)
 	errCh := make(chan error)
-	server := &http.Server{Addr: p, Handler: httpapi.MakeHandler(svc, tracer, logger)}
+	server := &http.Server{Addr: p, Handler: handler}
 
 	switch {
 	case certFile != "" || keyFile != "":
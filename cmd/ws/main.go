@@ -16,12 +16,21 @@ import (
 
 	"github.com/MainfluxLabs/mainflux"
 	kitprometheus "github.com/go-kit/kit/metrics/prometheus"
+	"github.com/go-redis/redis/v8"
 	"golang.org/x/sync/errgroup"
 
 	logger "github.com/MainfluxLabs/mainflux/logger"
+	"github.com/MainfluxLabs/mainflux/pkg/auth"
+	"github.com/MainfluxLabs/mainflux/pkg/dedup"
+	"github.com/MainfluxLabs/mainflux/pkg/drain"
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
 	"github.com/MainfluxLabs/mainflux/pkg/messaging"
 	"github.com/MainfluxLabs/mainflux/pkg/messaging/brokers"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging/profile"
+	"github.com/MainfluxLabs/mainflux/pkg/mirror"
+	"github.com/MainfluxLabs/mainflux/pkg/resilience"
+	"github.com/MainfluxLabs/mainflux/pkg/sequence"
+	"github.com/MainfluxLabs/mainflux/pkg/signature"
 	thingsapi "github.com/MainfluxLabs/mainflux/things/api/auth/grpc"
 	adapter "github.com/MainfluxLabs/mainflux/ws"
 	"github.com/MainfluxLabs/mainflux/ws/api"
@@ -36,23 +45,53 @@ import (
 const (
 	stopWaitTime = 5 * time.Second
 
-	defPort              = "8190"
-	defBrokerURL         = "nats://localhost:4222"
-	defLogLevel          = "error"
-	defClientTLS         = "false"
-	defCACerts           = ""
-	defJaegerURL         = ""
-	defThingsGRPCURL     = "localhost:8183"
-	defThingsGRPCTimeout = "1s"
-
-	envPort              = "MF_WS_ADAPTER_PORT"
-	envBrokerURL         = "MF_BROKER_URL"
-	envLogLevel          = "MF_WS_ADAPTER_LOG_LEVEL"
-	envClientTLS         = "MF_WS_ADAPTER_CLIENT_TLS"
-	envCACerts           = "MF_WS_ADAPTER_CA_CERTS"
-	envJaegerURL         = "MF_JAEGER_URL"
-	envThingsGRPCURL     = "MF_THINGS_AUTH_GRPC_URL"
-	envThingsGRPCTimeout = "MF_THINGS_AUTH_GRPC_TIMEOUT"
+	defPort                = "8190"
+	defBrokerURL           = "nats://localhost:4222"
+	defLogLevel            = "error"
+	defClientTLS           = "false"
+	defCACerts             = ""
+	defJaegerURL           = ""
+	defThingsGRPCURL       = "localhost:8183"
+	defThingsGRPCTimeout   = "1s"
+	defGRPCRetries         = "3"
+	defGRPCBreakerFailures = "5"
+	defGRPCBreakerTimeout  = "10s"
+	defThingsCacheTTL      = "0"
+	defSigningKeyTTL       = "30s"
+	defDedupWindow         = "0"
+	defMirrorEnabled       = "false"
+	defThingsESURL         = "localhost:6379"
+	defThingsESPass        = ""
+	defThingsESDB          = "0"
+	defSeqURL              = ""
+	defSeqPass             = ""
+	defSeqDB               = "0"
+	defDrainTimeout        = "30s"
+	defDrainKey            = ""
+
+	envPort                      = "MF_WS_ADAPTER_PORT"
+	envBrokerURL                 = "MF_BROKER_URL"
+	envLogLevel                  = "MF_WS_ADAPTER_LOG_LEVEL"
+	envClientTLS                 = "MF_WS_ADAPTER_CLIENT_TLS"
+	envCACerts                   = "MF_WS_ADAPTER_CA_CERTS"
+	envJaegerURL                 = "MF_JAEGER_URL"
+	envThingsGRPCURL             = "MF_THINGS_AUTH_GRPC_URL"
+	envThingsGRPCTimeout         = "MF_THINGS_AUTH_GRPC_TIMEOUT"
+	envThingsGRPCRetries         = "MF_THINGS_AUTH_GRPC_RETRIES"
+	envThingsGRPCBreakerFailures = "MF_THINGS_AUTH_GRPC_BREAKER_FAILURES"
+	envThingsGRPCBreakerTimeout  = "MF_THINGS_AUTH_GRPC_BREAKER_TIMEOUT"
+	envThingsCacheTTL            = "MF_WS_ADAPTER_THINGS_CACHE_TTL"
+	envSigningKeyTTL             = "MF_WS_ADAPTER_SIGNING_KEY_CACHE_TTL"
+	envDedupWindow               = "MF_WS_ADAPTER_DEDUP_WINDOW"
+	envMirrorEnabled             = "MF_WS_ADAPTER_MIRROR_ENABLED"
+	envThingsESURL               = "MF_THINGS_ES_URL"
+	envThingsESPass              = "MF_THINGS_ES_PASS"
+	envThingsESDB                = "MF_THINGS_ES_DB"
+	envSeqURL                    = "MF_SEQUENCE_REDIS_URL"
+	envSeqPass                   = "MF_SEQUENCE_REDIS_PASS"
+	envSeqDB                     = "MF_SEQUENCE_REDIS_DB"
+	envDrainTimeout              = "MF_WS_ADAPTER_DRAIN_TIMEOUT"
+	envDrainKey                  = "MF_WS_ADAPTER_DRAIN_KEY"
 )
 
 type config struct {
@@ -64,6 +103,19 @@ type config struct {
 	jaegerURL         string
 	thingsGRPCURL     string
 	thingsGRPCTimeout time.Duration
+	thingsResilience  resilience.Config
+	thingsCacheTTL    time.Duration
+	signingKeyTTL     time.Duration
+	dedupWindow       time.Duration
+	mirrorEnabled     bool
+	thingsESURL       string
+	thingsESPass      string
+	thingsESDB        string
+	seqURL            string
+	seqPass           string
+	seqDB             string
+	drainTimeout      time.Duration
+	drainKey          string
 }
 
 func main() {
@@ -83,7 +135,22 @@ func main() {
 	thingsTracer, thingsCloser := initJaeger("things", cfg.jaegerURL, logger)
 	defer thingsCloser.Close()
 
+	var checks []mainflux.HealthCheck
 	tc := thingsapi.NewClient(conn, thingsTracer, cfg.thingsGRPCTimeout)
+	if cfg.thingsCacheTTL > 0 {
+		thingsESConn := connectToRedis(cfg.thingsESURL, cfg.thingsESPass, cfg.thingsESDB, logger)
+		defer thingsESConn.Close()
+
+		cache := auth.NewThingsCache(tc, cfg.thingsCacheTTL)
+		g.Go(func() error {
+			return auth.Invalidate(ctx, thingsESConn, cache)
+		})
+		tc = cache
+		checks = append(checks, mainflux.HealthCheck{
+			Name:  "things_es",
+			Check: func(ctx context.Context) error { return thingsESConn.Ping(ctx).Err() },
+		})
+	}
 
 	nps, err := brokers.NewPubSub(cfg.brokerURL, "", logger)
 	if err != nil {
@@ -92,10 +159,50 @@ func main() {
 	}
 	defer nps.Close()
 
-	svc := newService(tc, nps, logger)
+	if cfg.seqURL != "" {
+		seqConn := connectToRedis(cfg.seqURL, cfg.seqPass, cfg.seqDB, logger)
+		defer seqConn.Close()
+		nps = sequence.NewPubSub(nps, sequence.NewRedisSequencer(seqConn))
+	}
+
+	nps = profile.NewPublishingPubSub(nps, tc)
+	if cfg.mirrorEnabled {
+		nps = mirror.NewPubSub(nps, tc, logger)
+	}
+
+	if cfg.dedupWindow > 0 {
+		nps = dedup.NewPubSub(nps, dedup.NewFilter(cfg.dedupWindow), kitprometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: "ws_adapter",
+			Subsystem: "dedup",
+			Name:      "suppressed_count",
+			Help:      "Number of duplicate messages suppressed within the de-duplication window.",
+		}, []string{}))
+	}
+
+	nps = profile.NewPubSub(nps, messaging.ForwardFlag, kitprometheus.NewCounterFrom(stdprometheus.CounterOpts{
+		Namespace: "ws_adapter",
+		Subsystem: "subscriber",
+		Name:      "skipped_messages",
+		Help:      "Number of received messages skipped because their profile flag is not set.",
+	}, []string{"flag"}))
+	checks = append(checks, messaging.HealthCheck(nps))
+
+	drainer := drain.New()
+	svc := newService(tc, nps, drainer, cfg.signingKeyTTL, logger)
 
 	g.Go(func() error {
-		return startWSServer(ctx, cfg, svc, logger)
+		return startWSServer(ctx, cfg, svc, drainer, logger, checks)
+	})
+
+	g.Go(func() error {
+		if err := drain.Run(ctx, drainer, cfg.drainTimeout); err != nil {
+			logger.Warn(fmt.Sprintf("WS adapter service drain deadline exceeded: %s", err))
+		}
+		if ctx.Err() == nil {
+			cancel()
+			logger.Info("WS adapter service shutdown after drain")
+		}
+		return nil
 	})
 
 	g.Go(func() error {
@@ -123,6 +230,31 @@ func loadConfig() config {
 		log.Fatalf("Invalid %s value: %s", envThingsGRPCTimeout, err.Error())
 	}
 
+	thingsCacheTTL, err := time.ParseDuration(mainflux.Env(envThingsCacheTTL, defThingsCacheTTL))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envThingsCacheTTL, err.Error())
+	}
+
+	signingKeyTTL, err := time.ParseDuration(mainflux.Env(envSigningKeyTTL, defSigningKeyTTL))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envSigningKeyTTL, err.Error())
+	}
+
+	dedupWindow, err := time.ParseDuration(mainflux.Env(envDedupWindow, defDedupWindow))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envDedupWindow, err.Error())
+	}
+
+	mirrorEnabled, err := strconv.ParseBool(mainflux.Env(envMirrorEnabled, defMirrorEnabled))
+	if err != nil {
+		log.Fatalf("Invalid value passed for %s\n", envMirrorEnabled)
+	}
+
+	drainTimeout, err := time.ParseDuration(mainflux.Env(envDrainTimeout, defDrainTimeout))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envDrainTimeout, err.Error())
+	}
+
 	return config{
 		brokerURL:         mainflux.Env(envBrokerURL, defBrokerURL),
 		port:              mainflux.Env(envPort, defPort),
@@ -132,6 +264,19 @@ func loadConfig() config {
 		jaegerURL:         mainflux.Env(envJaegerURL, defJaegerURL),
 		thingsGRPCURL:     mainflux.Env(envThingsGRPCURL, defThingsGRPCURL),
 		thingsGRPCTimeout: thingsGRPCTimeout,
+		thingsResilience:  loadResilienceConfig(envThingsGRPCRetries, envThingsGRPCBreakerFailures, envThingsGRPCBreakerTimeout),
+		thingsCacheTTL:    thingsCacheTTL,
+		signingKeyTTL:     signingKeyTTL,
+		dedupWindow:       dedupWindow,
+		mirrorEnabled:     mirrorEnabled,
+		thingsESURL:       mainflux.Env(envThingsESURL, defThingsESURL),
+		thingsESPass:      mainflux.Env(envThingsESPass, defThingsESPass),
+		thingsESDB:        mainflux.Env(envThingsESDB, defThingsESDB),
+		seqURL:            mainflux.Env(envSeqURL, defSeqURL),
+		seqPass:           mainflux.Env(envSeqPass, defSeqPass),
+		seqDB:             mainflux.Env(envSeqDB, defSeqDB),
+		drainTimeout:      drainTimeout,
+		drainKey:          mainflux.Env(envDrainKey, defDrainKey),
 	}
 }
 
@@ -151,6 +296,8 @@ func connectToThings(cfg config, logger logger.Logger) *grpc.ClientConn {
 		opts = append(opts, grpc.WithTransportCredentials(insecure.NewCredentials()))
 	}
 
+	opts = append(opts, grpc.WithUnaryInterceptor(resilience.UnaryClientInterceptor(cfg.thingsResilience)))
+
 	conn, err := grpc.Dial(cfg.thingsGRPCURL, opts...)
 	if err != nil {
 		logger.Error(fmt.Sprintf("Failed to connect to things service: %s", err))
@@ -160,6 +307,43 @@ func connectToThings(cfg config, logger logger.Logger) *grpc.ClientConn {
 	return conn
 }
 
+func connectToRedis(redisURL, redisPass, redisDB string, logger logger.Logger) *redis.Client {
+	db, err := strconv.Atoi(redisDB)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to connect to redis: %s", err))
+		os.Exit(1)
+	}
+
+	return redis.NewClient(&redis.Options{
+		Addr:     redisURL,
+		Password: redisPass,
+		DB:       db,
+	})
+}
+
+func loadResilienceConfig(envRetries, envFailures, envTimeout string) resilience.Config {
+	retries, err := strconv.ParseUint(mainflux.Env(envRetries, defGRPCRetries), 10, 64)
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envRetries, err.Error())
+	}
+
+	failures, err := strconv.ParseUint(mainflux.Env(envFailures, defGRPCBreakerFailures), 10, 32)
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envFailures, err.Error())
+	}
+
+	timeout, err := time.ParseDuration(mainflux.Env(envTimeout, defGRPCBreakerTimeout))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envTimeout, err.Error())
+	}
+
+	return resilience.Config{
+		MaxRetries:  retries,
+		MaxFailures: uint32(failures),
+		OpenTimeout: timeout,
+	}
+}
+
 func initJaeger(svcName, url string, logger logger.Logger) (opentracing.Tracer, io.Closer) {
 	if url == "" {
 		return opentracing.NoopTracer{}, ioutil.NopCloser(nil)
@@ -184,8 +368,18 @@ func initJaeger(svcName, url string, logger logger.Logger) (opentracing.Tracer,
 	return tracer, closer
 }
 
-func newService(tc mainflux.ThingsServiceClient, nps messaging.PubSub, logger logger.Logger) adapter.Service {
-	svc := adapter.New(tc, nps)
+func newService(tc mainflux.ThingsServiceClient, nps messaging.PubSub, drainer drain.Drainer, signingKeyTTL time.Duration, logger logger.Logger) adapter.Service {
+	verifier := signature.MetricsMiddleware(
+		signature.NewVerifier(tc, signingKeyTTL),
+		kitprometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: "ws_adapter",
+			Subsystem: "signature",
+			Name:      "rejected_count",
+			Help:      "Number of messages rejected due to the missing or invalid signature.",
+		}, []string{"reason"}),
+	)
+	svc := adapter.New(tc, nps, verifier)
+	svc = api.DrainMiddleware(svc, drainer)
 	svc = api.LoggingMiddleware(svc, logger)
 	svc = api.MetricsMiddleware(
 		svc,
@@ -206,10 +400,10 @@ func newService(tc mainflux.ThingsServiceClient, nps messaging.PubSub, logger lo
 	return svc
 }
 
-func startWSServer(ctx context.Context, cfg config, svc adapter.Service, l logger.Logger) error {
+func startWSServer(ctx context.Context, cfg config, svc adapter.Service, drainer drain.Drainer, l logger.Logger, checks []mainflux.HealthCheck) error {
 	p := fmt.Sprintf(":%s", cfg.port)
 	errCh := make(chan error, 2)
-	server := &http.Server{Addr: p, Handler: api.MakeHandler(svc, l)}
+	server := &http.Server{Addr: p, Handler: drain.NewHandler(drainer, cfg.drainKey, api.MakeHandler(svc, l, checks...))}
 	l.Info(fmt.Sprintf("WS adapter service started, exposed port %s", cfg.port))
 
 	go func() {
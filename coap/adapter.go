@@ -15,6 +15,7 @@ import (
 
 	"github.com/MainfluxLabs/mainflux"
 	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+	"github.com/MainfluxLabs/mainflux/pkg/signature"
 )
 
 const chansPrefix = "channels"
@@ -39,17 +40,19 @@ var _ Service = (*adapterService)(nil)
 
 // Observers is a map of maps,
 type adapterService struct {
-	things  mainflux.ThingsServiceClient
-	pubsub  messaging.PubSub
-	obsLock sync.Mutex
+	things   mainflux.ThingsServiceClient
+	pubsub   messaging.PubSub
+	verifier signature.Verifier
+	obsLock  sync.Mutex
 }
 
 // New instantiates the CoAP adapter implementation.
-func New(things mainflux.ThingsServiceClient, pubsub messaging.PubSub) Service {
+func New(things mainflux.ThingsServiceClient, pubsub messaging.PubSub, verifier signature.Verifier) Service {
 	as := &adapterService{
-		things:  things,
-		pubsub:  pubsub,
-		obsLock: sync.Mutex{},
+		things:   things,
+		pubsub:   pubsub,
+		verifier: verifier,
+		obsLock:  sync.Mutex{},
 	}
 
 	return as
@@ -66,6 +69,18 @@ func (svc *adapterService) Publish(ctx context.Context, key string, msg messagin
 	}
 	msg.Publisher = thid.GetValue()
 
+	payload, err := svc.verifier.Verify(ctx, msg)
+	if err != nil {
+		return err
+	}
+	msg.Payload = payload
+
+	id, err := messaging.NewID()
+	if err != nil {
+		return err
+	}
+	msg.Id = id
+
 	return svc.pubsub.Publish(msg.Channel, msg)
 }
 
@@ -0,0 +1,47 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+
+	"github.com/MainfluxLabs/mainflux/coap"
+	"github.com/MainfluxLabs/mainflux/pkg/drain"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+)
+
+var _ coap.Service = (*drainMiddleware)(nil)
+
+type drainMiddleware struct {
+	drainer drain.Drainer
+	svc     coap.Service
+}
+
+// DrainMiddleware rejects new observe requests once the drain is started
+// and tracks in-flight publishes.
+func DrainMiddleware(svc coap.Service, drainer drain.Drainer) coap.Service {
+	return &drainMiddleware{
+		drainer: drainer,
+		svc:     svc,
+	}
+}
+
+func (dm *drainMiddleware) Publish(ctx context.Context, key string, msg messaging.Message) error {
+	done := dm.drainer.Track()
+	defer done()
+
+	return dm.svc.Publish(ctx, key, msg)
+}
+
+func (dm *drainMiddleware) Subscribe(ctx context.Context, key, chanID, subtopic string, c coap.Client) error {
+	if dm.drainer.Draining() {
+		return drain.ErrDraining
+	}
+
+	return dm.svc.Subscribe(ctx, key, chanID, subtopic, c)
+}
+
+func (dm *drainMiddleware) Unsubscribe(ctx context.Context, key, chanID, subtopic, token string) error {
+	return dm.svc.Unsubscribe(ctx, key, chanID, subtopic, token)
+}
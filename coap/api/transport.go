@@ -13,6 +13,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/MainfluxLabs/mainflux/pkg/drain"
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
 
 	"github.com/go-zoo/bone"
@@ -20,6 +21,7 @@ import (
 	"github.com/MainfluxLabs/mainflux/coap"
 	log "github.com/MainfluxLabs/mainflux/logger"
 	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+	"github.com/MainfluxLabs/mainflux/pkg/signature"
 	"github.com/plgd-dev/go-coap/v2/message"
 	"github.com/plgd-dev/go-coap/v2/message/codes"
 	"github.com/plgd-dev/go-coap/v2/mux"
@@ -50,9 +52,9 @@ var (
 )
 
 // MakeHandler returns a HTTP handler for API endpoints.
-func MakeHTTPHandler() http.Handler {
+func MakeHTTPHandler(checks ...mainflux.HealthCheck) http.Handler {
 	b := bone.New()
-	b.GetFunc("/health", mainflux.Health(protocol))
+	b.GetFunc("/health", mainflux.Health(protocol, checks...))
 	b.Handle("/metrics", promhttp.Handler())
 
 	return b
@@ -109,8 +111,13 @@ func handler(w mux.ResponseWriter, m *mux.Message) {
 		case err == errors.ErrNotFound:
 			resp.Code = codes.NotFound
 		case errors.Contains(err, errors.ErrAuthorization),
-			errors.Contains(err, errors.ErrAuthentication):
+			errors.Contains(err, errors.ErrAuthentication),
+			errors.Contains(err, signature.ErrMissingSignature),
+			errors.Contains(err, signature.ErrInvalidSignature),
+			errors.Contains(err, signature.ErrExpiredSignature):
 			resp.Code = codes.Unauthorized
+		case errors.Contains(err, drain.ErrDraining):
+			resp.Code = codes.ServiceUnavailable
 		default:
 			resp.Code = codes.InternalServerError
 		}
@@ -0,0 +1,121 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/commands"
+	"github.com/go-kit/kit/endpoint"
+)
+
+func sendCommandEndpoint(svc commands.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(sendCommandReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		// The timeout is already validated by the request.
+		timeout, _ := time.ParseDuration(req.Timeout)
+		cmd := commands.Command{
+			ThingID:   req.thingID,
+			ChannelID: req.ChannelID,
+			Name:      req.Name,
+			Payload:   req.Payload,
+			Timeout:   timeout,
+		}
+
+		saved, err := svc.SendCommand(ctx, req.token, cmd)
+		if err != nil {
+			return nil, err
+		}
+
+		res := toCommandRes(saved)
+		res.created = true
+		return res, nil
+	}
+}
+
+func viewCommandEndpoint(svc commands.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(viewReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		cmd, err := svc.ViewCommand(ctx, req.token, req.id)
+		if err != nil {
+			return nil, err
+		}
+
+		return toCommandRes(cmd), nil
+	}
+}
+
+func listCommandsEndpoint(svc commands.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		pm := commands.PageMetadata{
+			Offset: req.offset,
+			Limit:  req.limit,
+			Status: req.status,
+		}
+		page, err := svc.ListCommands(ctx, req.token, req.thingID, pm)
+		if err != nil {
+			return nil, err
+		}
+
+		res := commandsPageRes{
+			pageRes: pageRes{
+				Total:  page.Total,
+				Offset: page.Offset,
+				Limit:  page.Limit,
+			},
+			Commands: []commandRes{},
+		}
+		for _, cmd := range page.Commands {
+			res.Commands = append(res.Commands, toCommandRes(cmd))
+		}
+
+		return res, nil
+	}
+}
+
+func ackCommandEndpoint(svc commands.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(ackReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		if err := svc.AckCommand(ctx, req.key, req.id, req.Response); err != nil {
+			return nil, err
+		}
+
+		return emptyRes{code: http.StatusOK}, nil
+	}
+}
+
+func toCommandRes(cmd commands.Command) commandRes {
+	return commandRes{
+		ID:        cmd.ID,
+		ThingID:   cmd.ThingID,
+		ChannelID: cmd.ChannelID,
+		Name:      cmd.Name,
+		Payload:   cmd.Payload,
+		Response:  cmd.Response,
+		Status:    cmd.Status,
+		Timeout:   cmd.Timeout.String(),
+		ExpiresAt: cmd.ExpiresAt,
+		CreatedAt: cmd.CreatedAt,
+		UpdatedAt: cmd.UpdatedAt,
+	}
+}
@@ -0,0 +1,97 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !test
+
+package api
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/commands"
+	log "github.com/MainfluxLabs/mainflux/logger"
+)
+
+var _ commands.Service = (*loggingMiddleware)(nil)
+
+type loggingMiddleware struct {
+	logger log.Logger
+	svc    commands.Service
+}
+
+// LoggingMiddleware adds logging facilities to the core service.
+func LoggingMiddleware(svc commands.Service, logger log.Logger) commands.Service {
+	return &loggingMiddleware{logger, svc}
+}
+
+func (lm *loggingMiddleware) SendCommand(ctx context.Context, token string, cmd commands.Command) (saved commands.Command, err error) {
+	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "send_command", "latency", time.Since(begin).String())
+		message := fmt.Sprintf("Method send_command with the id %s to thing %s for token %s took %s to complete", saved.ID, cmd.ThingID, token, time.Since(begin))
+		if err != nil {
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		l.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.SendCommand(ctx, token, cmd)
+}
+
+func (lm *loggingMiddleware) ViewCommand(ctx context.Context, token, id string) (cmd commands.Command, err error) {
+	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "view_command", "latency", time.Since(begin).String())
+		message := fmt.Sprintf("Method view_command with the id %s for token %s took %s to complete", id, token, time.Since(begin))
+		if err != nil {
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		l.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ViewCommand(ctx, token, id)
+}
+
+func (lm *loggingMiddleware) ListCommands(ctx context.Context, token, thingID string, pm commands.PageMetadata) (page commands.CommandsPage, err error) {
+	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "list_commands", "latency", time.Since(begin).String())
+		message := fmt.Sprintf("Method list_commands for thing %s and token %s took %s to complete", thingID, token, time.Since(begin))
+		if err != nil {
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		l.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ListCommands(ctx, token, thingID, pm)
+}
+
+func (lm *loggingMiddleware) AckCommand(ctx context.Context, thingKey, id string, response []byte) (err error) {
+	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "ack_command", "latency", time.Since(begin).String())
+		message := fmt.Sprintf("Method ack_command with the id %s took %s to complete", id, time.Since(begin))
+		if err != nil {
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		l.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.AckCommand(ctx, thingKey, id, response)
+}
+
+func (lm *loggingMiddleware) ExpireCommands(ctx context.Context) (err error) {
+	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "expire_commands", "latency", time.Since(begin).String())
+		message := fmt.Sprintf("Method expire_commands took %s to complete", time.Since(begin))
+		if err != nil {
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		l.Debug(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ExpireCommands(ctx)
+}
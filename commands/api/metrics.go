@@ -0,0 +1,76 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !test
+
+package api
+
+import (
+	"context"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/commands"
+	"github.com/go-kit/kit/metrics"
+)
+
+var _ commands.Service = (*metricsMiddleware)(nil)
+
+type metricsMiddleware struct {
+	counter metrics.Counter
+	latency metrics.Histogram
+	svc     commands.Service
+}
+
+// MetricsMiddleware instruments core service by tracking request count and latency.
+func MetricsMiddleware(svc commands.Service, counter metrics.Counter, latency metrics.Histogram) commands.Service {
+	return &metricsMiddleware{
+		counter: counter,
+		latency: latency,
+		svc:     svc,
+	}
+}
+
+func (ms *metricsMiddleware) SendCommand(ctx context.Context, token string, cmd commands.Command) (commands.Command, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "send_command").Add(1)
+		ms.latency.With("method", "send_command").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.SendCommand(ctx, token, cmd)
+}
+
+func (ms *metricsMiddleware) ViewCommand(ctx context.Context, token, id string) (commands.Command, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "view_command").Add(1)
+		ms.latency.With("method", "view_command").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ViewCommand(ctx, token, id)
+}
+
+func (ms *metricsMiddleware) ListCommands(ctx context.Context, token, thingID string, pm commands.PageMetadata) (commands.CommandsPage, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "list_commands").Add(1)
+		ms.latency.With("method", "list_commands").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ListCommands(ctx, token, thingID, pm)
+}
+
+func (ms *metricsMiddleware) AckCommand(ctx context.Context, thingKey, id string, response []byte) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "ack_command").Add(1)
+		ms.latency.With("method", "ack_command").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.AckCommand(ctx, thingKey, id, response)
+}
+
+func (ms *metricsMiddleware) ExpireCommands(ctx context.Context) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "expire_commands").Add(1)
+		ms.latency.With("method", "expire_commands").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ExpireCommands(ctx)
+}
@@ -0,0 +1,115 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/commands"
+	"github.com/MainfluxLabs/mainflux/internal/apiutil"
+)
+
+const (
+	maxLimitSize = 100
+	maxNameSize  = 254
+	maxTimeout   = 24 * time.Hour
+)
+
+type sendCommandReq struct {
+	token     string
+	thingID   string
+	ChannelID string          `json:"channel_id"`
+	Name      string          `json:"name"`
+	Payload   json.RawMessage `json:"payload,omitempty"`
+	Timeout   string          `json:"timeout,omitempty"`
+}
+
+func (req sendCommandReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	if req.thingID == "" {
+		return apiutil.ErrMissingID
+	}
+
+	if req.ChannelID == "" || req.Name == "" || len(req.Name) > maxNameSize {
+		return apiutil.ErrMalformedEntity
+	}
+
+	if req.Timeout != "" {
+		timeout, err := time.ParseDuration(req.Timeout)
+		if err != nil || timeout <= 0 || timeout > maxTimeout {
+			return apiutil.ErrMalformedEntity
+		}
+	}
+
+	return nil
+}
+
+type viewReq struct {
+	token string
+	id    string
+}
+
+func (req viewReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	if req.id == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return nil
+}
+
+type listReq struct {
+	token   string
+	thingID string
+	status  string
+	offset  uint64
+	limit   uint64
+}
+
+func (req listReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	if req.thingID == "" {
+		return apiutil.ErrMissingID
+	}
+
+	if req.limit > maxLimitSize {
+		return apiutil.ErrLimitSize
+	}
+
+	switch req.status {
+	case "", commands.StatusQueued, commands.StatusDelivered, commands.StatusAcked, commands.StatusTimedOut:
+	default:
+		return apiutil.ErrInvalidQueryParams
+	}
+
+	return nil
+}
+
+type ackReq struct {
+	key      string
+	id       string
+	Response json.RawMessage `json:"response,omitempty"`
+}
+
+func (req ackReq) validate() error {
+	if req.key == "" {
+		return apiutil.ErrBearerKey
+	}
+
+	if req.id == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return nil
+}
@@ -0,0 +1,74 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package commands
+
+import (
+	"context"
+	"time"
+)
+
+// Command statuses.
+const (
+	StatusQueued    = "queued"
+	StatusDelivered = "delivered"
+	StatusAcked     = "acked"
+	StatusTimedOut  = "timed_out"
+)
+
+// Command represents a command sent to a thing over the control subtopic of
+// a channel. Its ID is the correlation ID the thing uses to acknowledge it.
+type Command struct {
+	ID        string
+	OwnerID   string
+	ThingID   string
+	ChannelID string
+	Name      string
+	Payload   []byte
+	Response  []byte
+	Status    string
+	Timeout   time.Duration
+	ExpiresAt time.Time
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// CommandsPage contains page related metadata as well as list of commands
+// that belong to this page.
+type CommandsPage struct {
+	PageMetadata
+	Commands []Command
+}
+
+// PageMetadata contains page metadata that helps navigation.
+type PageMetadata struct {
+	Total  uint64
+	Offset uint64
+	Limit  uint64
+	Status string
+}
+
+// CommandRepository specifies a command persistence API.
+type CommandRepository interface {
+	// Save persists the command.
+	Save(ctx context.Context, cmd Command) error
+
+	// MarkDelivered moves the queued command to the delivered status.
+	MarkDelivered(ctx context.Context, id string, at time.Time) error
+
+	// Ack moves the queued or delivered command to the acked status and
+	// stores the response of the thing. ErrInvalidStatus is returned if the
+	// command is not pending anymore.
+	Ack(ctx context.Context, id string, response []byte, at time.Time) error
+
+	// ExpirePending moves all queued and delivered commands expired at the
+	// given time to the timed out status, returning the number of commands.
+	ExpirePending(ctx context.Context, at time.Time) (uint64, error)
+
+	// RetrieveByID retrieves the command having the provided identifier.
+	RetrieveByID(ctx context.Context, id string) (Command, error)
+
+	// RetrieveByThing retrieves the subset of commands sent by the owner to
+	// the thing, optionally filtered by status.
+	RetrieveByThing(ctx context.Context, owner, thingID string, pm PageMetadata) (CommandsPage, error)
+}
@@ -0,0 +1,30 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package commands
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/logger"
+)
+
+// Start times out pending commands past their deadline on every tick of the
+// given interval, until the context is canceled.
+func Start(ctx context.Context, svc Service, interval time.Duration, logger logger.Logger) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := svc.ExpireCommands(ctx); err != nil {
+				logger.Warn(fmt.Sprintf("Failed to time out expired commands: %s", err))
+			}
+		}
+	}
+}
@@ -0,0 +1,147 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/commands"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+)
+
+var _ commands.CommandRepository = (*commandRepositoryMock)(nil)
+
+type commandRepositoryMock struct {
+	mu       sync.Mutex
+	commands map[string]commands.Command
+}
+
+// NewCommandRepository returns a new command repository mock.
+func NewCommandRepository() commands.CommandRepository {
+	return &commandRepositoryMock{
+		commands: make(map[string]commands.Command),
+	}
+}
+
+func (crm *commandRepositoryMock) Save(_ context.Context, cmd commands.Command) error {
+	crm.mu.Lock()
+	defer crm.mu.Unlock()
+
+	if _, ok := crm.commands[cmd.ID]; ok {
+		return errors.ErrConflict
+	}
+
+	crm.commands[cmd.ID] = cmd
+	return nil
+}
+
+func (crm *commandRepositoryMock) MarkDelivered(_ context.Context, id string, at time.Time) error {
+	return crm.update(id, at, nil, commands.StatusDelivered, commands.StatusQueued)
+}
+
+func (crm *commandRepositoryMock) Ack(_ context.Context, id string, response []byte, at time.Time) error {
+	return crm.update(id, at, response, commands.StatusAcked, commands.StatusQueued, commands.StatusDelivered)
+}
+
+func (crm *commandRepositoryMock) ExpirePending(_ context.Context, at time.Time) (uint64, error) {
+	crm.mu.Lock()
+	defer crm.mu.Unlock()
+
+	var cnt uint64
+	for id, cmd := range crm.commands {
+		if !pending(cmd) || cmd.ExpiresAt.After(at) {
+			continue
+		}
+		cmd.Status = commands.StatusTimedOut
+		cmd.UpdatedAt = at
+		crm.commands[id] = cmd
+		cnt++
+	}
+
+	return cnt, nil
+}
+
+func (crm *commandRepositoryMock) RetrieveByID(_ context.Context, id string) (commands.Command, error) {
+	crm.mu.Lock()
+	defer crm.mu.Unlock()
+
+	cmd, ok := crm.commands[id]
+	if !ok {
+		return commands.Command{}, errors.ErrNotFound
+	}
+
+	return cmd, nil
+}
+
+func (crm *commandRepositoryMock) RetrieveByThing(_ context.Context, owner, thingID string, pm commands.PageMetadata) (commands.CommandsPage, error) {
+	crm.mu.Lock()
+	defer crm.mu.Unlock()
+
+	var items []commands.Command
+	for _, cmd := range crm.commands {
+		if cmd.OwnerID != owner || cmd.ThingID != thingID {
+			continue
+		}
+		if pm.Status != "" && cmd.Status != pm.Status {
+			continue
+		}
+		items = append(items, cmd)
+	}
+
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].ID < items[j].ID
+	})
+
+	page := commands.CommandsPage{
+		PageMetadata: commands.PageMetadata{
+			Total:  uint64(len(items)),
+			Offset: pm.Offset,
+			Limit:  pm.Limit,
+			Status: pm.Status,
+		},
+	}
+
+	start := int(pm.Offset)
+	if start > len(items) {
+		start = len(items)
+	}
+	end := start + int(pm.Limit)
+	if pm.Limit == 0 || end > len(items) {
+		end = len(items)
+	}
+	page.Commands = items[start:end]
+
+	return page, nil
+}
+
+func (crm *commandRepositoryMock) update(id string, at time.Time, response []byte, status string, from ...string) error {
+	crm.mu.Lock()
+	defer crm.mu.Unlock()
+
+	cmd, ok := crm.commands[id]
+	if !ok {
+		return errors.ErrNotFound
+	}
+
+	for _, st := range from {
+		if cmd.Status == st {
+			cmd.Status = status
+			cmd.UpdatedAt = at
+			if response != nil {
+				cmd.Response = response
+			}
+			crm.commands[id] = cmd
+			return nil
+		}
+	}
+
+	return commands.ErrInvalidStatus
+}
+
+func pending(cmd commands.Command) bool {
+	return cmd.Status == commands.StatusQueued || cmd.Status == commands.StatusDelivered
+}
@@ -0,0 +1,35 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"sync"
+
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+)
+
+var _ messaging.Publisher = (*Publisher)(nil)
+
+// Publisher is a message publisher mock recording published messages.
+type Publisher struct {
+	mu       sync.Mutex
+	Messages []messaging.Message
+}
+
+// NewPublisher returns a recording message publisher mock.
+func NewPublisher() *Publisher {
+	return &Publisher{}
+}
+
+func (pub *Publisher) Publish(_ string, msg messaging.Message) error {
+	pub.mu.Lock()
+	defer pub.mu.Unlock()
+
+	pub.Messages = append(pub.Messages, msg)
+	return nil
+}
+
+func (pub *Publisher) Close() error {
+	return nil
+}
@@ -0,0 +1,249 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/commands"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+const pendingStatuses = `status IN (:queued, :delivered)`
+
+var _ commands.CommandRepository = (*commandRepository)(nil)
+
+type commandRepository struct {
+	db Database
+}
+
+// NewCommandRepository instantiates a PostgreSQL implementation of command repository.
+func NewCommandRepository(db Database) commands.CommandRepository {
+	return &commandRepository{db: db}
+}
+
+func (cr commandRepository) Save(ctx context.Context, cmd commands.Command) error {
+	q := `INSERT INTO commands (id, owner_id, thing_id, channel_id, name, payload, response, status, timeout, expires_at, created_at, updated_at)
+		VALUES (:id, :owner_id, :thing_id, :channel_id, :name, :payload, :response, :status, :timeout, :expires_at, :created_at, :updated_at)`
+
+	if _, err := cr.db.NamedExecContext(ctx, q, toDBCommand(cmd)); err != nil {
+		if pgErr, ok := err.(*pgconn.PgError); ok && pgErr.Code == pgerrcode.UniqueViolation {
+			return errors.Wrap(errors.ErrConflict, err)
+		}
+		return errors.Wrap(errors.ErrCreateEntity, err)
+	}
+
+	return nil
+}
+
+func (cr commandRepository) MarkDelivered(ctx context.Context, id string, at time.Time) error {
+	q := `UPDATE commands SET status = :status, updated_at = :updated_at WHERE id = :id AND status = :queued`
+	params := map[string]interface{}{
+		"id":         id,
+		"status":     commands.StatusDelivered,
+		"queued":     commands.StatusQueued,
+		"updated_at": at,
+	}
+
+	return cr.update(ctx, q, params)
+}
+
+func (cr commandRepository) Ack(ctx context.Context, id string, response []byte, at time.Time) error {
+	q := fmt.Sprintf(`UPDATE commands SET status = :status, response = :response, updated_at = :updated_at
+		WHERE id = :id AND %s`, pendingStatuses)
+	params := map[string]interface{}{
+		"id":         id,
+		"status":     commands.StatusAcked,
+		"response":   nullJSON(response),
+		"queued":     commands.StatusQueued,
+		"delivered":  commands.StatusDelivered,
+		"updated_at": at,
+	}
+
+	return cr.update(ctx, q, params)
+}
+
+func (cr commandRepository) ExpirePending(ctx context.Context, at time.Time) (uint64, error) {
+	q := fmt.Sprintf(`UPDATE commands SET status = :status, updated_at = :updated_at
+		WHERE expires_at <= :updated_at AND %s`, pendingStatuses)
+	params := map[string]interface{}{
+		"status":     commands.StatusTimedOut,
+		"queued":     commands.StatusQueued,
+		"delivered":  commands.StatusDelivered,
+		"updated_at": at,
+	}
+
+	res, err := cr.db.NamedExecContext(ctx, q, params)
+	if err != nil {
+		return 0, errors.Wrap(errors.ErrUpdateEntity, err)
+	}
+
+	cnt, err := res.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(errors.ErrUpdateEntity, err)
+	}
+
+	return uint64(cnt), nil
+}
+
+func (cr commandRepository) RetrieveByID(ctx context.Context, id string) (commands.Command, error) {
+	q := `SELECT id, owner_id, thing_id, channel_id, name, payload, response, status, timeout, expires_at, created_at, updated_at
+		FROM commands WHERE id = $1`
+
+	var dbc dbCommand
+	if err := cr.db.QueryRowxContext(ctx, q, id).StructScan(&dbc); err != nil {
+		if err == sql.ErrNoRows {
+			return commands.Command{}, errors.Wrap(errors.ErrNotFound, err)
+		}
+		return commands.Command{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+
+	return toCommand(dbc), nil
+}
+
+func (cr commandRepository) RetrieveByThing(ctx context.Context, owner, thingID string, pm commands.PageMetadata) (commands.CommandsPage, error) {
+	sq := ""
+	if pm.Status != "" {
+		sq = " AND status = :status"
+	}
+
+	q := fmt.Sprintf(`SELECT id, owner_id, thing_id, channel_id, name, payload, response, status, timeout, expires_at, created_at, updated_at
+		FROM commands WHERE owner_id = :owner_id AND thing_id = :thing_id%s ORDER BY created_at DESC LIMIT :limit OFFSET :offset`, sq)
+	params := map[string]interface{}{
+		"owner_id": owner,
+		"thing_id": thingID,
+		"status":   pm.Status,
+		"limit":    pm.Limit,
+		"offset":   pm.Offset,
+	}
+
+	rows, err := cr.db.NamedQueryContext(ctx, q, params)
+	if err != nil {
+		return commands.CommandsPage{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+	defer rows.Close()
+
+	var items []commands.Command
+	for rows.Next() {
+		var dbc dbCommand
+		if err := rows.StructScan(&dbc); err != nil {
+			return commands.CommandsPage{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+		}
+		items = append(items, toCommand(dbc))
+	}
+
+	cq := fmt.Sprintf(`SELECT COUNT(*) FROM commands WHERE owner_id = :owner_id AND thing_id = :thing_id%s`, sq)
+	total, err := total(ctx, cr.db, cq, params)
+	if err != nil {
+		return commands.CommandsPage{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+
+	return commands.CommandsPage{
+		PageMetadata: commands.PageMetadata{
+			Total:  total,
+			Offset: pm.Offset,
+			Limit:  pm.Limit,
+			Status: pm.Status,
+		},
+		Commands: items,
+	}, nil
+}
+
+// update executes the status transition query. Since the query matches only
+// commands in the allowed statuses, a command which exists but wasn't
+// updated is not pending anymore.
+func (cr commandRepository) update(ctx context.Context, query string, params map[string]interface{}) error {
+	res, err := cr.db.NamedExecContext(ctx, query, params)
+	if err != nil {
+		return errors.Wrap(errors.ErrUpdateEntity, err)
+	}
+
+	if cnt, err := res.RowsAffected(); err == nil && cnt == 0 {
+		if _, err := cr.RetrieveByID(ctx, params["id"].(string)); err != nil {
+			return err
+		}
+		return commands.ErrInvalidStatus
+	}
+
+	return nil
+}
+
+func total(ctx context.Context, db Database, query string, params interface{}) (uint64, error) {
+	rows, err := db.NamedQueryContext(ctx, query, params)
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	var total uint64
+	if rows.Next() {
+		if err := rows.Scan(&total); err != nil {
+			return 0, err
+		}
+	}
+
+	return total, nil
+}
+
+type dbCommand struct {
+	ID        string    `db:"id"`
+	OwnerID   string    `db:"owner_id"`
+	ThingID   string    `db:"thing_id"`
+	ChannelID string    `db:"channel_id"`
+	Name      string    `db:"name"`
+	Payload   []byte    `db:"payload"`
+	Response  []byte    `db:"response"`
+	Status    string    `db:"status"`
+	Timeout   int64     `db:"timeout"`
+	ExpiresAt time.Time `db:"expires_at"`
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+func toDBCommand(cmd commands.Command) dbCommand {
+	return dbCommand{
+		ID:        cmd.ID,
+		OwnerID:   cmd.OwnerID,
+		ThingID:   cmd.ThingID,
+		ChannelID: cmd.ChannelID,
+		Name:      cmd.Name,
+		Payload:   nullJSON(cmd.Payload),
+		Response:  nullJSON(cmd.Response),
+		Status:    cmd.Status,
+		Timeout:   int64(cmd.Timeout / time.Millisecond),
+		ExpiresAt: cmd.ExpiresAt,
+		CreatedAt: cmd.CreatedAt,
+		UpdatedAt: cmd.UpdatedAt,
+	}
+}
+
+func toCommand(cmd dbCommand) commands.Command {
+	return commands.Command{
+		ID:        cmd.ID,
+		OwnerID:   cmd.OwnerID,
+		ThingID:   cmd.ThingID,
+		ChannelID: cmd.ChannelID,
+		Name:      cmd.Name,
+		Payload:   cmd.Payload,
+		Response:  cmd.Response,
+		Status:    cmd.Status,
+		Timeout:   time.Duration(cmd.Timeout) * time.Millisecond,
+		ExpiresAt: cmd.ExpiresAt,
+		CreatedAt: cmd.CreatedAt,
+		UpdatedAt: cmd.UpdatedAt,
+	}
+}
+
+// nullJSON maps the empty JSON document to NULL.
+func nullJSON(data []byte) []byte {
+	if len(data) == 0 {
+		return nil
+	}
+	return data
+}
@@ -0,0 +1,205 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+)
+
+const (
+	protocol       = "commands"
+	subtopicPrefix = "commands"
+	defTimeout     = 30 * time.Second
+)
+
+var (
+	// ErrInvalidStatus indicates acknowledgment of a command which is not pending.
+	ErrInvalidStatus = errors.New("command is not pending")
+
+	// ErrPublish indicates failure to deliver a command.
+	ErrPublish = errors.New("failed to publish command")
+)
+
+// Service specifies an API that must be fullfiled by the domain service
+// implementation, and all of its decorators (e.g. logging & metrics).
+type Service interface {
+	// SendCommand publishes the command to the thing on the control subtopic
+	// of the channel and returns it together with its correlation ID.
+	SendCommand(ctx context.Context, token string, cmd Command) (Command, error)
+
+	// ViewCommand retrieves the command.
+	ViewCommand(ctx context.Context, token, id string) (Command, error)
+
+	// ListCommands retrieves the history of commands sent to the thing.
+	ListCommands(ctx context.Context, token, thingID string, pm PageMetadata) (CommandsPage, error)
+
+	// AckCommand acknowledges the command on behalf of the thing identified
+	// by the key, storing the thing response.
+	AckCommand(ctx context.Context, thingKey, id string, response []byte) error
+
+	// ExpireCommands times out all pending commands past their deadline.
+	ExpireCommands(ctx context.Context) error
+}
+
+// Message represents the command as published to the thing.
+type Message struct {
+	ID      string          `json:"id"`
+	Name    string          `json:"name"`
+	Payload json.RawMessage `json:"payload,omitempty"`
+}
+
+var _ Service = (*commandsService)(nil)
+
+type commandsService struct {
+	auth       mainflux.AuthServiceClient
+	things     mainflux.ThingsServiceClient
+	commands   CommandRepository
+	publisher  messaging.Publisher
+	idProvider mainflux.IDProvider
+}
+
+// New instantiates the commands service implementation.
+func New(auth mainflux.AuthServiceClient, things mainflux.ThingsServiceClient, commands CommandRepository, publisher messaging.Publisher, idp mainflux.IDProvider) Service {
+	return &commandsService{
+		auth:       auth,
+		things:     things,
+		commands:   commands,
+		publisher:  publisher,
+		idProvider: idp,
+	}
+}
+
+func (cs *commandsService) SendCommand(ctx context.Context, token string, cmd Command) (Command, error) {
+	res, err := cs.auth.Identify(ctx, &mainflux.Token{Value: token})
+	if err != nil {
+		return Command{}, errors.Wrap(errors.ErrAuthentication, err)
+	}
+
+	if _, err := cs.things.IsChannelOwner(ctx, &mainflux.ChannelOwnerReq{Owner: res.GetId(), ChanID: cmd.ChannelID}); err != nil {
+		return Command{}, errors.Wrap(errors.ErrAuthorization, err)
+	}
+
+	if _, err := cs.things.CanAccessByID(ctx, &mainflux.AccessByIDReq{ThingID: cmd.ThingID, ChanID: cmd.ChannelID}); err != nil {
+		return Command{}, errors.Wrap(errors.ErrAuthorization, err)
+	}
+
+	id, err := cs.idProvider.ID()
+	if err != nil {
+		return Command{}, err
+	}
+
+	if cmd.Timeout == 0 {
+		cmd.Timeout = defTimeout
+	}
+
+	timestamp := getTimestamp()
+	cmd.ID = id
+	cmd.OwnerID = res.GetId()
+	cmd.Status = StatusQueued
+	cmd.Response = nil
+	cmd.ExpiresAt = timestamp.Add(cmd.Timeout)
+	cmd.CreatedAt = timestamp
+	cmd.UpdatedAt = timestamp
+
+	payload, err := json.Marshal(Message{ID: cmd.ID, Name: cmd.Name, Payload: cmd.Payload})
+	if err != nil {
+		return Command{}, errors.Wrap(errors.ErrMalformedEntity, err)
+	}
+
+	if err := cs.commands.Save(ctx, cmd); err != nil {
+		return Command{}, err
+	}
+
+	msgID, err := messaging.NewID()
+	if err != nil {
+		return Command{}, errors.Wrap(ErrPublish, err)
+	}
+
+	// The command stays queued if publishing fails, until it times out.
+	msg := messaging.Message{
+		Id:       msgID,
+		Channel:  cmd.ChannelID,
+		Subtopic: fmt.Sprintf("%s.%s", subtopicPrefix, cmd.ThingID),
+		Protocol: protocol,
+		Payload:  payload,
+		Created:  timestamp.UnixNano(),
+	}
+	if err := cs.publisher.Publish(msg.Channel, msg); err != nil {
+		return Command{}, errors.Wrap(ErrPublish, err)
+	}
+
+	// The thing may have acknowledged the command in the meantime, in which
+	// case it is not queued anymore.
+	if err := cs.commands.MarkDelivered(ctx, cmd.ID, getTimestamp()); err != nil && !errors.Contains(err, ErrInvalidStatus) {
+		return Command{}, err
+	}
+
+	return cs.commands.RetrieveByID(ctx, cmd.ID)
+}
+
+func (cs *commandsService) ViewCommand(ctx context.Context, token, id string) (Command, error) {
+	res, err := cs.auth.Identify(ctx, &mainflux.Token{Value: token})
+	if err != nil {
+		return Command{}, errors.Wrap(errors.ErrAuthentication, err)
+	}
+
+	cmd, err := cs.commands.RetrieveByID(ctx, id)
+	if err != nil {
+		return Command{}, err
+	}
+
+	if cmd.OwnerID != res.GetId() {
+		return Command{}, errors.ErrNotFound
+	}
+
+	return cmd, nil
+}
+
+func (cs *commandsService) ListCommands(ctx context.Context, token, thingID string, pm PageMetadata) (CommandsPage, error) {
+	res, err := cs.auth.Identify(ctx, &mainflux.Token{Value: token})
+	if err != nil {
+		return CommandsPage{}, errors.Wrap(errors.ErrAuthentication, err)
+	}
+
+	return cs.commands.RetrieveByThing(ctx, res.GetId(), thingID, pm)
+}
+
+func (cs *commandsService) AckCommand(ctx context.Context, thingKey, id string, response []byte) error {
+	thID, err := cs.things.Identify(ctx, &mainflux.Token{Value: thingKey})
+	if err != nil {
+		return errors.Wrap(errors.ErrAuthentication, err)
+	}
+
+	cmd, err := cs.commands.RetrieveByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if cmd.ThingID != thID.GetValue() {
+		return errors.ErrNotFound
+	}
+
+	timestamp := getTimestamp()
+	if timestamp.After(cmd.ExpiresAt) {
+		return ErrInvalidStatus
+	}
+
+	return cs.commands.Ack(ctx, id, response, timestamp)
+}
+
+func (cs *commandsService) ExpireCommands(ctx context.Context) error {
+	_, err := cs.commands.ExpirePending(ctx, getTimestamp())
+	return err
+}
+
+func getTimestamp() time.Time {
+	return time.Now().UTC().Round(time.Millisecond)
+}
@@ -0,0 +1,260 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package commands_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/commands"
+	cmdmocks "github.com/MainfluxLabs/mainflux/commands/mocks"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/mocks"
+	"github.com/MainfluxLabs/mainflux/pkg/uuid"
+	"github.com/MainfluxLabs/mainflux/users"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	userEmail      = "user@example.com"
+	otherUserEmail = "otherUser@example.com"
+	password       = "password"
+	thingID        = "thing-1"
+	channelID      = "channel-1"
+)
+
+var (
+	user      = users.User{ID: "user-1", Email: userEmail, Password: password}
+	otherUser = users.User{ID: "user-2", Email: otherUserEmail, Password: password}
+	usersList = []users.User{user, otherUser}
+	command   = commands.Command{
+		ThingID:   thingID,
+		ChannelID: channelID,
+		Name:      "reboot",
+		Payload:   []byte(`{"delay":5}`),
+	}
+)
+
+func newService(pub *cmdmocks.Publisher) commands.Service {
+	auth := mocks.NewAuthService("", usersList)
+	things := mocks.NewThingsServiceClient(map[string]string{user.ID: channelID}, nil)
+	return commands.New(auth, things, cmdmocks.NewCommandRepository(), pub, uuid.NewMock())
+}
+
+func send(t *testing.T, svc commands.Service, cmd commands.Command) commands.Command {
+	saved, err := svc.SendCommand(context.Background(), userEmail, cmd)
+	require.Nil(t, err, fmt.Sprintf("unexpected error sending command: %s", err))
+	return saved
+}
+
+func TestSendCommand(t *testing.T) {
+	pub := cmdmocks.NewPublisher()
+	svc := newService(pub)
+
+	otherThing := command
+	otherThing.ThingID = "invalid"
+
+	cases := []struct {
+		desc  string
+		token string
+		cmd   commands.Command
+		err   error
+	}{
+		{
+			desc:  "send command",
+			token: userEmail,
+			cmd:   command,
+			err:   nil,
+		},
+		{
+			desc:  "send command with invalid token",
+			token: "invalid",
+			cmd:   command,
+			err:   errors.ErrAuthentication,
+		},
+		{
+			desc:  "send command over channel of other user",
+			token: otherUserEmail,
+			cmd:   command,
+			err:   errors.ErrAuthorization,
+		},
+		{
+			desc:  "send command to thing not connected to channel",
+			token: userEmail,
+			cmd:   otherThing,
+			err:   errors.ErrAuthorization,
+		},
+	}
+
+	for _, tc := range cases {
+		cmd, err := svc.SendCommand(context.Background(), tc.token, tc.cmd)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected error %s got %s\n", tc.desc, tc.err, err))
+		if tc.err != nil {
+			continue
+		}
+		assert.NotEmpty(t, cmd.ID, fmt.Sprintf("%s: expected correlation ID to be set\n", tc.desc))
+		assert.Equal(t, commands.StatusDelivered, cmd.Status, fmt.Sprintf("%s: expected status %s got %s\n", tc.desc, commands.StatusDelivered, cmd.Status))
+		assert.Equal(t, 30*time.Second, cmd.Timeout, fmt.Sprintf("%s: expected default timeout got %s\n", tc.desc, cmd.Timeout))
+	}
+
+	require.Len(t, pub.Messages, 1)
+	msg := pub.Messages[0]
+	assert.Equal(t, channelID, msg.Channel)
+	assert.Equal(t, fmt.Sprintf("commands.%s", thingID), msg.Subtopic)
+
+	var published commands.Message
+	require.Nil(t, json.Unmarshal(msg.Payload, &published))
+	assert.Equal(t, command.Name, published.Name)
+	assert.JSONEq(t, string(command.Payload), string(published.Payload))
+	assert.NotEmpty(t, published.ID)
+}
+
+func TestAckCommand(t *testing.T) {
+	svc := newService(cmdmocks.NewPublisher())
+	cmd := send(t, svc, command)
+	acked := send(t, svc, command)
+	err := svc.AckCommand(context.Background(), thingID, acked.ID, nil)
+	require.Nil(t, err, fmt.Sprintf("unexpected error acknowledging command: %s", err))
+
+	response := []byte(`{"rebooted":true}`)
+	cases := []struct {
+		desc string
+		key  string
+		id   string
+		err  error
+	}{
+		{
+			desc: "ack command with invalid key",
+			key:  "invalid",
+			id:   cmd.ID,
+			err:  errors.ErrAuthentication,
+		},
+		{
+			desc: "ack command sent to other thing",
+			key:  "thing-2",
+			id:   cmd.ID,
+			err:  errors.ErrNotFound,
+		},
+		{
+			desc: "ack non-existing command",
+			key:  thingID,
+			id:   "non-existing",
+			err:  errors.ErrNotFound,
+		},
+		{
+			desc: "ack command",
+			key:  thingID,
+			id:   cmd.ID,
+			err:  nil,
+		},
+		{
+			desc: "ack already acknowledged command",
+			key:  thingID,
+			id:   acked.ID,
+			err:  commands.ErrInvalidStatus,
+		},
+	}
+
+	for _, tc := range cases {
+		err := svc.AckCommand(context.Background(), tc.key, tc.id, response)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected error %s got %s\n", tc.desc, tc.err, err))
+	}
+
+	saved, err := svc.ViewCommand(context.Background(), userEmail, cmd.ID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error viewing command: %s", err))
+	assert.Equal(t, commands.StatusAcked, saved.Status)
+	assert.Equal(t, response, saved.Response)
+}
+
+func TestExpireCommands(t *testing.T) {
+	svc := newService(cmdmocks.NewPublisher())
+
+	short := command
+	short.Timeout = time.Millisecond
+	expired := send(t, svc, short)
+	pending := send(t, svc, command)
+
+	time.Sleep(10 * time.Millisecond)
+	err := svc.ExpireCommands(context.Background())
+	require.Nil(t, err, fmt.Sprintf("unexpected error expiring commands: %s", err))
+
+	cmd, err := svc.ViewCommand(context.Background(), userEmail, expired.ID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error viewing command: %s", err))
+	assert.Equal(t, commands.StatusTimedOut, cmd.Status)
+
+	cmd, err = svc.ViewCommand(context.Background(), userEmail, pending.ID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error viewing command: %s", err))
+	assert.Equal(t, commands.StatusDelivered, cmd.Status)
+
+	err = svc.AckCommand(context.Background(), thingID, expired.ID, nil)
+	assert.True(t, errors.Contains(err, commands.ErrInvalidStatus), fmt.Sprintf("expected error %s got %s\n", commands.ErrInvalidStatus, err))
+}
+
+func TestListCommands(t *testing.T) {
+	svc := newService(cmdmocks.NewPublisher())
+
+	n := 5
+	for i := 0; i < n; i++ {
+		cmd := send(t, svc, command)
+		if i%2 == 0 {
+			err := svc.AckCommand(context.Background(), thingID, cmd.ID, nil)
+			require.Nil(t, err, fmt.Sprintf("unexpected error acknowledging command: %s", err))
+		}
+	}
+
+	cases := []struct {
+		desc  string
+		token string
+		pm    commands.PageMetadata
+		size  int
+		total uint64
+		err   error
+	}{
+		{
+			desc:  "list all commands",
+			token: userEmail,
+			pm:    commands.PageMetadata{Limit: 10},
+			size:  n,
+			total: uint64(n),
+		},
+		{
+			desc:  "list acknowledged commands",
+			token: userEmail,
+			pm:    commands.PageMetadata{Limit: 10, Status: commands.StatusAcked},
+			size:  3,
+			total: 3,
+		},
+		{
+			desc:  "list commands with offset",
+			token: userEmail,
+			pm:    commands.PageMetadata{Offset: 4, Limit: 10},
+			size:  1,
+			total: uint64(n),
+		},
+		{
+			desc:  "list commands of other user",
+			token: otherUserEmail,
+			pm:    commands.PageMetadata{Limit: 10},
+			size:  0,
+			total: 0,
+		},
+		{
+			desc:  "list commands with invalid token",
+			token: "invalid",
+			pm:    commands.PageMetadata{Limit: 10},
+			err:   errors.ErrAuthentication,
+		},
+	}
+
+	for _, tc := range cases {
+		page, err := svc.ListCommands(context.Background(), tc.token, thingID, tc.pm)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected error %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.size, len(page.Commands), fmt.Sprintf("%s: expected %d commands got %d\n", tc.desc, tc.size, len(page.Commands)))
+		assert.Equal(t, tc.total, page.Total, fmt.Sprintf("%s: expected total %d got %d\n", tc.desc, tc.total, page.Total))
+	}
+}
@@ -0,0 +1,269 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package deriver
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"sync"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/consumers"
+	"github.com/MainfluxLabs/mainflux/logger"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+	"github.com/MainfluxLabs/mainflux/pkg/transformers/senml"
+	mfsenml "github.com/MainfluxLabs/senml"
+	"github.com/pelletier/go-toml"
+)
+
+// Protocol is the protocol of the derived messages.
+const Protocol = "deriver"
+
+var (
+	// ErrInvalidDerivation indicates the derivation which can't be evaluated.
+	ErrInvalidDerivation = errors.New("invalid derivation")
+
+	// ErrPublish indicates failure to publish the derived message.
+	ErrPublish = errors.New("failed to publish derived message")
+
+	errUnsupportedMessages = errors.New("only SenML messages can be derived from")
+	errOpenConfFile        = errors.New("unable to open configuration file")
+	errParseConfFile       = errors.New("unable to parse configuration file")
+)
+
+// Record defines the derived SenML record, e.g. power = voltage * current.
+type Record struct {
+	Name       string `toml:"name"`
+	Expression string `toml:"expression"`
+	Unit       string `toml:"unit"`
+}
+
+// Derivation defines the virtual channel whose messages are derived from
+// the messages of the source channels.
+type Derivation struct {
+	Channel string   `toml:"channel"`
+	Sources []string `toml:"sources"`
+	Records []Record `toml:"records"`
+}
+
+var _ consumers.Consumer = (*deriver)(nil)
+
+type record struct {
+	Record
+	expr expression
+	vars []string
+}
+
+type derivation struct {
+	channel string
+	records []record
+	// values are the latest values of the records of the source channels.
+	values map[string]float64
+}
+
+type deriver struct {
+	mu          sync.Mutex
+	publisher   messaging.Publisher
+	derivations map[string][]*derivation
+	logger      logger.Logger
+}
+
+// LoadDerivations loads the derivations from the consumer configuration file.
+func LoadDerivations(configPath string) ([]Derivation, error) {
+	data, err := ioutil.ReadFile(configPath)
+	if err != nil {
+		return nil, errors.Wrap(errOpenConfFile, err)
+	}
+
+	var cfg struct {
+		Derivations []Derivation `toml:"derivations"`
+	}
+	if err := toml.Unmarshal(data, &cfg); err != nil {
+		return nil, errors.Wrap(errParseConfFile, err)
+	}
+
+	return cfg.Derivations, nil
+}
+
+// New returns the consumer which evaluates the derivations of the consumed
+// SenML messages and publishes the derived messages to the virtual channels.
+// The derivations with the invalid expressions, as well as the ones deriving
+// the channel from itself, directly or through other derivations, are rejected.
+func New(pub messaging.Publisher, derivations []Derivation, logger logger.Logger) (consumers.Consumer, error) {
+	if err := checkLoops(derivations); err != nil {
+		return nil, err
+	}
+
+	d := &deriver{
+		publisher:   pub,
+		derivations: make(map[string][]*derivation),
+		logger:      logger,
+	}
+	for _, dc := range derivations {
+		if dc.Channel == "" || len(dc.Sources) == 0 || len(dc.Records) == 0 {
+			return nil, errors.Wrap(ErrInvalidDerivation, fmt.Errorf("derivation of channel %q requires sources and records", dc.Channel))
+		}
+
+		dv := &derivation{
+			channel: dc.Channel,
+			values:  make(map[string]float64),
+		}
+		for _, rc := range dc.Records {
+			if rc.Name == "" {
+				return nil, errors.Wrap(ErrInvalidDerivation, fmt.Errorf("record of channel %s requires name", dc.Channel))
+			}
+			expr, vars, err := parse(rc.Expression)
+			if err != nil {
+				return nil, errors.Wrap(ErrInvalidDerivation, errors.Wrap(fmt.Errorf("record %s of channel %s", rc.Name, dc.Channel), err))
+			}
+			if len(vars) == 0 {
+				return nil, errors.Wrap(ErrInvalidDerivation, fmt.Errorf("record %s of channel %s references no records", rc.Name, dc.Channel))
+			}
+			dv.records = append(dv.records, record{Record: rc, expr: expr, vars: vars})
+		}
+
+		for _, src := range dc.Sources {
+			d.derivations[src] = append(d.derivations[src], dv)
+		}
+	}
+
+	return d, nil
+}
+
+func (d *deriver) Consume(messages interface{}) error {
+	msgs, ok := messages.([]senml.Message)
+	if !ok {
+		return errors.Wrap(ErrPublish, errUnsupportedMessages)
+	}
+	if len(msgs) == 0 {
+		return nil
+	}
+
+	// Consumed messages are the records of a single message.
+	dvs := d.derivations[msgs[0].Channel]
+	for _, dv := range dvs {
+		records := d.derive(dv, msgs)
+		if len(records) == 0 {
+			continue
+		}
+
+		if err := d.publish(dv.channel, msgs[0].Publisher, records); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// derive updates the latest values of the derivation with the values of the
+// records and evaluates the derived records which reference any of them.
+// Derived records are evaluated once all the referenced values are received,
+// and take the latest time of the records.
+func (d *deriver) derive(dv *derivation, msgs []senml.Message) []mfsenml.Record {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	updated := make(map[string]bool)
+	var t float64
+	for _, msg := range msgs {
+		if msg.Value == nil {
+			continue
+		}
+		dv.values[msg.Name] = *msg.Value
+		updated[msg.Name] = true
+		if msg.Time > t {
+			t = msg.Time
+		}
+	}
+
+	var records []mfsenml.Record
+	for _, r := range dv.records {
+		if !r.ready(dv.values, updated) {
+			continue
+		}
+
+		v, err := evaluate(r.expr, dv.values)
+		if err != nil {
+			d.logger.Warn(fmt.Sprintf("Failed to derive record %s of channel %s: %s", r.Name, dv.channel, err))
+			continue
+		}
+		records = append(records, mfsenml.Record{
+			Name:  r.Name,
+			Unit:  r.Unit,
+			Time:  t,
+			Value: &v,
+		})
+	}
+
+	return records
+}
+
+// ready reports whether all the values referenced by the record are received
+// and any of them is updated.
+func (r record) ready(values map[string]float64, updated map[string]bool) bool {
+	var changed bool
+	for _, v := range r.vars {
+		if _, ok := values[v]; !ok {
+			return false
+		}
+		changed = changed || updated[v]
+	}
+	return changed
+}
+
+func (d *deriver) publish(chanID, publisher string, records []mfsenml.Record) error {
+	payload, err := json.Marshal(records)
+	if err != nil {
+		return errors.Wrap(ErrPublish, err)
+	}
+
+	id, err := messaging.NewID()
+	if err != nil {
+		return errors.Wrap(ErrPublish, err)
+	}
+
+	msg := messaging.Message{
+		Id:        id,
+		Channel:   chanID,
+		Publisher: publisher,
+		Protocol:  Protocol,
+		Payload:   payload,
+		Created:   time.Now().UnixNano(),
+	}
+	if err := d.publisher.Publish(chanID, msg); err != nil {
+		return errors.Wrap(ErrPublish, err)
+	}
+
+	return nil
+}
+
+// checkLoops rejects the derivations of the channels which are their own
+// sources, directly or through the other derived channels.
+func checkLoops(derivations []Derivation) error {
+	sources := make(map[string][]string)
+	for _, dc := range derivations {
+		sources[dc.Channel] = append(sources[dc.Channel], dc.Sources...)
+	}
+
+	for _, dc := range derivations {
+		visited := map[string]bool{}
+		stack := append([]string{}, dc.Sources...)
+		for len(stack) > 0 {
+			src := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if src == dc.Channel {
+				return errors.Wrap(ErrInvalidDerivation, fmt.Errorf("channel %s is derived from itself", dc.Channel))
+			}
+			if visited[src] {
+				continue
+			}
+			visited[src] = true
+			stack = append(stack, sources[src]...)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,207 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package deriver_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/MainfluxLabs/mainflux/consumers/deriver"
+	"github.com/MainfluxLabs/mainflux/logger"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+	"github.com/MainfluxLabs/mainflux/pkg/transformers/senml"
+	mfsenml "github.com/MainfluxLabs/senml"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	meterID   = "meter"
+	sensorID  = "sensor"
+	powerID   = "power"
+	publisher = "publisher"
+)
+
+type mockPublisher struct {
+	published []messaging.Message
+}
+
+func (mp *mockPublisher) Publish(topic string, msg messaging.Message) error {
+	mp.published = append(mp.published, msg)
+	return nil
+}
+
+func (mp *mockPublisher) Close() error {
+	return nil
+}
+
+func message(chanID, name string, value, t float64) senml.Message {
+	return senml.Message{
+		Channel:   chanID,
+		Publisher: publisher,
+		Name:      name,
+		Value:     &value,
+		Time:      t,
+	}
+}
+
+func TestNew(t *testing.T) {
+	records := []deriver.Record{{Name: "power", Expression: "voltage * current"}}
+
+	cases := []struct {
+		desc        string
+		derivations []deriver.Derivation
+		err         error
+	}{
+		{
+			desc:        "create deriver",
+			derivations: []deriver.Derivation{{Channel: powerID, Sources: []string{meterID}, Records: records}},
+			err:         nil,
+		},
+		{
+			desc:        "create deriver without sources",
+			derivations: []deriver.Derivation{{Channel: powerID, Records: records}},
+			err:         deriver.ErrInvalidDerivation,
+		},
+		{
+			desc: "create deriver with invalid expression",
+			derivations: []deriver.Derivation{{
+				Channel: powerID,
+				Sources: []string{meterID},
+				Records: []deriver.Record{{Name: "power", Expression: "voltage * (current"}},
+			}},
+			err: deriver.ErrInvalidDerivation,
+		},
+		{
+			desc: "create deriver with unknown function",
+			derivations: []deriver.Derivation{{
+				Channel: powerID,
+				Sources: []string{meterID},
+				Records: []deriver.Record{{Name: "power", Expression: "log(voltage)"}},
+			}},
+			err: deriver.ErrInvalidDerivation,
+		},
+		{
+			desc: "create deriver with constant expression",
+			derivations: []deriver.Derivation{{
+				Channel: powerID,
+				Sources: []string{meterID},
+				Records: []deriver.Record{{Name: "power", Expression: "230 * 5"}},
+			}},
+			err: deriver.ErrInvalidDerivation,
+		},
+		{
+			desc:        "create deriver of channel derived from itself",
+			derivations: []deriver.Derivation{{Channel: powerID, Sources: []string{meterID, powerID}, Records: records}},
+			err:         deriver.ErrInvalidDerivation,
+		},
+		{
+			desc: "create deriver of channels derived from each other",
+			derivations: []deriver.Derivation{
+				{Channel: powerID, Sources: []string{meterID}, Records: records},
+				{Channel: meterID, Sources: []string{powerID}, Records: records},
+			},
+			err: deriver.ErrInvalidDerivation,
+		},
+	}
+
+	for _, tc := range cases {
+		_, err := deriver.New(&mockPublisher{}, tc.derivations, logger.NewMock())
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.err, err))
+	}
+}
+
+func TestConsume(t *testing.T) {
+	pub := &mockPublisher{}
+	derivations := []deriver.Derivation{
+		{
+			Channel: powerID,
+			Sources: []string{meterID, sensorID},
+			Records: []deriver.Record{
+				{Name: "power", Expression: "voltage * current", Unit: "W"},
+				{Name: "resistance", Expression: "voltage / current", Unit: "Ohm"},
+				{Name: "heat", Expression: "max(temperature - 20, 0) ^ 2 / -(-2)"},
+			},
+		},
+	}
+	d, err := deriver.New(pub, derivations, logger.NewMock())
+	require.Nil(t, err, fmt.Sprintf("create deriver: unexpected error: %s", err))
+
+	cases := []struct {
+		desc    string
+		msgs    interface{}
+		records []mfsenml.Record
+		err     error
+	}{
+		{
+			desc:    "consume message missing referenced record",
+			msgs:    []senml.Message{message(meterID, "voltage", 230, 1)},
+			records: nil,
+			err:     nil,
+		},
+		{
+			desc:    "consume message completing referenced records",
+			msgs:    []senml.Message{message(meterID, "current", 2, 2)},
+			records: []mfsenml.Record{{Name: "power", Unit: "W", Value: float(460), Time: 2}, {Name: "resistance", Unit: "Ohm", Value: float(115), Time: 2}},
+			err:     nil,
+		},
+		{
+			desc:    "consume message with division by zero",
+			msgs:    []senml.Message{message(meterID, "current", 0, 3)},
+			records: []mfsenml.Record{{Name: "power", Unit: "W", Value: float(0), Time: 3}},
+			err:     nil,
+		},
+		{
+			desc:    "consume message of other source channel",
+			msgs:    []senml.Message{message(sensorID, "temperature", 26, 4)},
+			records: []mfsenml.Record{{Name: "heat", Value: float(18), Time: 4}},
+			err:     nil,
+		},
+		{
+			desc:    "consume message of unrelated records",
+			msgs:    []senml.Message{message(meterID, "frequency", 50, 5)},
+			records: nil,
+			err:     nil,
+		},
+		{
+			desc:    "consume message of non-source channel",
+			msgs:    []senml.Message{message("other", "voltage", 230, 6)},
+			records: nil,
+			err:     nil,
+		},
+		{
+			desc:    "consume JSON messages",
+			msgs:    map[string]interface{}{"voltage": 230},
+			records: nil,
+			err:     deriver.ErrPublish,
+		},
+	}
+
+	for _, tc := range cases {
+		pub.published = nil
+		err := d.Consume(tc.msgs)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.err, err))
+
+		if tc.records == nil {
+			assert.Empty(t, pub.published, fmt.Sprintf("%s: expected no published messages got %v", tc.desc, pub.published))
+			continue
+		}
+
+		require.Len(t, pub.published, 1, fmt.Sprintf("%s: expected one published message got %d", tc.desc, len(pub.published)))
+		msg := pub.published[0]
+		assert.Equal(t, powerID, msg.Channel, fmt.Sprintf("%s: expected channel %s got %s", tc.desc, powerID, msg.Channel))
+		assert.Equal(t, deriver.Protocol, msg.Protocol, fmt.Sprintf("%s: expected protocol %s got %s", tc.desc, deriver.Protocol, msg.Protocol))
+
+		var records []mfsenml.Record
+		err = json.Unmarshal(msg.Payload, &records)
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error decoding payload: %s", tc.desc, err))
+		assert.Equal(t, tc.records, records, fmt.Sprintf("%s: expected records %v got %v", tc.desc, tc.records, records))
+	}
+}
+
+func float(v float64) *float64 {
+	return &v
+}
@@ -0,0 +1,6 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package deriver contains the deriver of the virtual channel messages,
+// computed from the SenML messages of the source channels.
+package deriver
@@ -0,0 +1,314 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package deriver
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+)
+
+var (
+	errParse    = errors.New("failed to parse expression")
+	errEvaluate = errors.New("failed to evaluate expression")
+)
+
+// functions are the functions available in the expressions, keyed by name.
+var functions = map[string]struct {
+	args int
+	fn   func(args []float64) float64
+}{
+	"abs":  {args: 1, fn: func(a []float64) float64 { return math.Abs(a[0]) }},
+	"sqrt": {args: 1, fn: func(a []float64) float64 { return math.Sqrt(a[0]) }},
+	"min":  {args: 2, fn: func(a []float64) float64 { return math.Min(a[0], a[1]) }},
+	"max":  {args: 2, fn: func(a []float64) float64 { return math.Max(a[0], a[1]) }},
+}
+
+// expression is the parsed arithmetic expression over the values of the
+// SenML records, referenced by the record names.
+type expression interface {
+	eval(values map[string]float64) float64
+}
+
+type number float64
+
+func (n number) eval(map[string]float64) float64 {
+	return float64(n)
+}
+
+type variable string
+
+func (v variable) eval(values map[string]float64) float64 {
+	return values[string(v)]
+}
+
+type unary struct {
+	x expression
+}
+
+func (u unary) eval(values map[string]float64) float64 {
+	return -u.x.eval(values)
+}
+
+type binary struct {
+	op   byte
+	x, y expression
+}
+
+func (b binary) eval(values map[string]float64) float64 {
+	x, y := b.x.eval(values), b.y.eval(values)
+	switch b.op {
+	case '+':
+		return x + y
+	case '-':
+		return x - y
+	case '*':
+		return x * y
+	case '/':
+		return x / y
+	default:
+		return math.Pow(x, y)
+	}
+}
+
+type call struct {
+	fn   func(args []float64) float64
+	args []expression
+}
+
+func (c call) eval(values map[string]float64) float64 {
+	args := make([]float64, len(c.args))
+	for i, a := range c.args {
+		args[i] = a.eval(values)
+	}
+	return c.fn(args)
+}
+
+// evaluate evaluates the expression, rejecting the results which can't be
+// stored, such as the ones of the division by zero.
+func evaluate(e expression, values map[string]float64) (float64, error) {
+	v := e.eval(values)
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return 0, errors.Wrap(errEvaluate, fmt.Errorf("result %v is not a finite number", v))
+	}
+	return v, nil
+}
+
+// parser is the recursive descent parser of the expressions, which supports
+// the numbers, the record names, the +, -, *, / and ^ operators, the
+// parentheses and the functions.
+type parser struct {
+	src  string
+	pos  int
+	vars map[string]bool
+}
+
+// parse parses the expression and returns it together with the names of the
+// records it references.
+func parse(src string) (expression, []string, error) {
+	p := &parser{src: src, vars: map[string]bool{}}
+	e, err := p.expr()
+	if err != nil {
+		return nil, nil, errors.Wrap(errParse, err)
+	}
+	if p.skip(); p.pos < len(p.src) {
+		return nil, nil, errors.Wrap(errParse, fmt.Errorf("unexpected %q at %d", p.src[p.pos], p.pos))
+	}
+
+	var vars []string
+	for v := range p.vars {
+		vars = append(vars, v)
+	}
+
+	return e, vars, nil
+}
+
+func (p *parser) expr() (expression, error) {
+	x, err := p.term()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		op := p.peek()
+		if op != '+' && op != '-' {
+			return x, nil
+		}
+		p.pos++
+		y, err := p.term()
+		if err != nil {
+			return nil, err
+		}
+		x = binary{op: op, x: x, y: y}
+	}
+}
+
+func (p *parser) term() (expression, error) {
+	x, err := p.unary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		op := p.peek()
+		if op != '*' && op != '/' {
+			return x, nil
+		}
+		p.pos++
+		y, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		x = binary{op: op, x: x, y: y}
+	}
+}
+
+func (p *parser) unary() (expression, error) {
+	if p.peek() == '-' {
+		p.pos++
+		x, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		return unary{x: x}, nil
+	}
+	return p.power()
+}
+
+// power parses the right associative exponentiation, binding tighter than
+// the unary minus of its base.
+func (p *parser) power() (expression, error) {
+	x, err := p.primary()
+	if err != nil {
+		return nil, err
+	}
+	if p.peek() != '^' {
+		return x, nil
+	}
+	p.pos++
+	y, err := p.unary()
+	if err != nil {
+		return nil, err
+	}
+	return binary{op: '^', x: x, y: y}, nil
+}
+
+func (p *parser) primary() (expression, error) {
+	c := p.peek()
+	switch {
+	case c == '(':
+		p.pos++
+		x, err := p.expr()
+		if err != nil {
+			return nil, err
+		}
+		if p.peek() != ')' {
+			return nil, fmt.Errorf("missing ) at %d", p.pos)
+		}
+		p.pos++
+		return x, nil
+	case c == '.' || isDigit(c):
+		return p.number()
+	case c == '_' || isLetter(c):
+		return p.name()
+	case c == 0:
+		return nil, fmt.Errorf("unexpected end of expression")
+	default:
+		return nil, fmt.Errorf("unexpected %q at %d", c, p.pos)
+	}
+}
+
+func (p *parser) number() (expression, error) {
+	start := p.pos
+	for p.pos < len(p.src) && (isDigit(p.src[p.pos]) || p.src[p.pos] == '.') {
+		p.pos++
+	}
+	// Exponent of the number, e.g. 1e-3.
+	if p.pos < len(p.src) && (p.src[p.pos] == 'e' || p.src[p.pos] == 'E') {
+		p.pos++
+		if p.pos < len(p.src) && (p.src[p.pos] == '+' || p.src[p.pos] == '-') {
+			p.pos++
+		}
+		for p.pos < len(p.src) && isDigit(p.src[p.pos]) {
+			p.pos++
+		}
+	}
+
+	n, err := strconv.ParseFloat(p.src[start:p.pos], 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid number %s at %d", p.src[start:p.pos], start)
+	}
+	return number(n), nil
+}
+
+// name parses the record name or the function call. Record names consist of
+// the letters, digits, underscores, dots and colons, so the names of the
+// records with the base name, e.g. urn:dev:1:voltage, are referenced as well.
+func (p *parser) name() (expression, error) {
+	start := p.pos
+	for p.pos < len(p.src) && isNameChar(p.src[p.pos]) {
+		p.pos++
+	}
+	name := p.src[start:p.pos]
+
+	if p.peek() != '(' {
+		p.vars[name] = true
+		return variable(name), nil
+	}
+
+	f, ok := functions[strings.ToLower(name)]
+	if !ok {
+		return nil, fmt.Errorf("unknown function %s at %d", name, start)
+	}
+	p.pos++
+
+	var args []expression
+	for p.peek() != ')' {
+		if len(args) > 0 {
+			if p.peek() != ',' {
+				return nil, fmt.Errorf("missing , at %d", p.pos)
+			}
+			p.pos++
+		}
+		a, err := p.expr()
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, a)
+	}
+	p.pos++
+
+	if len(args) != f.args {
+		return nil, fmt.Errorf("function %s takes %d arguments, got %d", name, f.args, len(args))
+	}
+	return call{fn: f.fn, args: args}, nil
+}
+
+// peek returns the next non-space character, or 0 at the end of the expression.
+func (p *parser) peek() byte {
+	p.skip()
+	if p.pos >= len(p.src) {
+		return 0
+	}
+	return p.src[p.pos]
+}
+
+func (p *parser) skip() {
+	for p.pos < len(p.src) && (p.src[p.pos] == ' ' || p.src[p.pos] == '\t') {
+		p.pos++
+	}
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isLetter(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+func isNameChar(c byte) bool {
+	return c == '_' || c == '.' || c == ':' || isDigit(c) || isLetter(c)
+}
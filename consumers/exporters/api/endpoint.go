@@ -0,0 +1,172 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/consumers/exporters"
+	"github.com/go-kit/kit/endpoint"
+)
+
+func createConnectorEndpoint(svc exporters.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(createConnectorReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		c := exporters.Connector{
+			Name:        req.Name,
+			ChannelID:   req.ChannelID,
+			GroupID:     req.GroupID,
+			Type:        req.Type,
+			Destination: req.Destination,
+		}
+		saved, err := svc.CreateConnector(ctx, req.token, c)
+		if err != nil {
+			return nil, err
+		}
+
+		res := toConnectorRes(saved)
+		res.created = true
+		return res, nil
+	}
+}
+
+func viewConnectorEndpoint(svc exporters.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(viewReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		c, err := svc.ViewConnector(ctx, req.token, req.id)
+		if err != nil {
+			return nil, err
+		}
+
+		return toConnectorRes(c), nil
+	}
+}
+
+func listConnectorsEndpoint(svc exporters.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		page, err := svc.ListConnectors(ctx, req.token, exporters.PageMetadata{Offset: req.offset, Limit: req.limit})
+		if err != nil {
+			return nil, err
+		}
+
+		res := connectorsPageRes{
+			pageRes: pageRes{
+				Total:  page.Total,
+				Offset: page.Offset,
+				Limit:  page.Limit,
+			},
+			Connectors: []connectorRes{},
+		}
+		for _, c := range page.Connectors {
+			res.Connectors = append(res.Connectors, toConnectorRes(c))
+		}
+
+		return res, nil
+	}
+}
+
+func updateConnectorEndpoint(svc exporters.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(updateConnectorReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		c := exporters.Connector{
+			ID:          req.id,
+			Name:        req.Name,
+			Destination: req.Destination,
+		}
+		if err := svc.UpdateConnector(ctx, req.token, c); err != nil {
+			return nil, err
+		}
+
+		return emptyRes{code: http.StatusOK}, nil
+	}
+}
+
+func removeConnectorEndpoint(svc exporters.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(viewReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		if err := svc.RemoveConnector(ctx, req.token, req.id); err != nil {
+			return nil, err
+		}
+
+		return emptyRes{code: http.StatusNoContent}, nil
+	}
+}
+
+func pauseConnectorEndpoint(svc exporters.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(viewReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		if err := svc.PauseConnector(ctx, req.token, req.id); err != nil {
+			return nil, err
+		}
+
+		return emptyRes{code: http.StatusOK}, nil
+	}
+}
+
+func resumeConnectorEndpoint(svc exporters.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(viewReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		if err := svc.ResumeConnector(ctx, req.token, req.id); err != nil {
+			return nil, err
+		}
+
+		return emptyRes{code: http.StatusOK}, nil
+	}
+}
+
+func toConnectorRes(c exporters.Connector) connectorRes {
+	res := connectorRes{
+		ID:          c.ID,
+		Name:        c.Name,
+		ChannelID:   c.ChannelID,
+		GroupID:     c.GroupID,
+		Type:        c.Type,
+		Destination: c.Destination,
+		Status:      c.Status,
+		Stats: statsRes{
+			Exported:  c.Stats.Exported,
+			Failed:    c.Stats.Failed,
+			LagMs:     int64(c.Stats.Lag / time.Millisecond),
+			LastError: c.Stats.LastError,
+		},
+		CreatedAt: c.CreatedAt,
+		UpdatedAt: c.UpdatedAt,
+	}
+	if !c.Stats.LastExportedAt.IsZero() {
+		res.Stats.LastExportedAt = &c.Stats.LastExportedAt
+	}
+
+	return res
+}
@@ -0,0 +1,139 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !test
+
+package api
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/consumers/exporters"
+	log "github.com/MainfluxLabs/mainflux/logger"
+)
+
+var _ exporters.Service = (*loggingMiddleware)(nil)
+
+type loggingMiddleware struct {
+	logger log.Logger
+	svc    exporters.Service
+}
+
+// LoggingMiddleware adds logging facilities to the core service.
+func LoggingMiddleware(svc exporters.Service, logger log.Logger) exporters.Service {
+	return &loggingMiddleware{logger, svc}
+}
+
+func (lm *loggingMiddleware) CreateConnector(ctx context.Context, token string, c exporters.Connector) (saved exporters.Connector, err error) {
+	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "create_connector", "latency", time.Since(begin).String())
+		message := fmt.Sprintf("Method create_connector with the id %s for token %s took %s to complete", saved.ID, token, time.Since(begin))
+		if err != nil {
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		l.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.CreateConnector(ctx, token, c)
+}
+
+func (lm *loggingMiddleware) ViewConnector(ctx context.Context, token, id string) (c exporters.Connector, err error) {
+	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "view_connector", "latency", time.Since(begin).String())
+		message := fmt.Sprintf("Method view_connector with the id %s for token %s took %s to complete", id, token, time.Since(begin))
+		if err != nil {
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		l.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ViewConnector(ctx, token, id)
+}
+
+func (lm *loggingMiddleware) ListConnectors(ctx context.Context, token string, pm exporters.PageMetadata) (page exporters.ConnectorsPage, err error) {
+	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "list_connectors", "latency", time.Since(begin).String())
+		message := fmt.Sprintf("Method list_connectors for token %s took %s to complete", token, time.Since(begin))
+		if err != nil {
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		l.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ListConnectors(ctx, token, pm)
+}
+
+func (lm *loggingMiddleware) UpdateConnector(ctx context.Context, token string, c exporters.Connector) (err error) {
+	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "update_connector", "latency", time.Since(begin).String())
+		message := fmt.Sprintf("Method update_connector with the id %s for token %s took %s to complete", c.ID, token, time.Since(begin))
+		if err != nil {
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		l.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.UpdateConnector(ctx, token, c)
+}
+
+func (lm *loggingMiddleware) RemoveConnector(ctx context.Context, token, id string) (err error) {
+	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "remove_connector", "latency", time.Since(begin).String())
+		message := fmt.Sprintf("Method remove_connector with the id %s for token %s took %s to complete", id, token, time.Since(begin))
+		if err != nil {
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		l.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.RemoveConnector(ctx, token, id)
+}
+
+func (lm *loggingMiddleware) PauseConnector(ctx context.Context, token, id string) (err error) {
+	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "pause_connector", "latency", time.Since(begin).String())
+		message := fmt.Sprintf("Method pause_connector with the id %s for token %s took %s to complete", id, token, time.Since(begin))
+		if err != nil {
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		l.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.PauseConnector(ctx, token, id)
+}
+
+func (lm *loggingMiddleware) ResumeConnector(ctx context.Context, token, id string) (err error) {
+	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "resume_connector", "latency", time.Since(begin).String())
+		message := fmt.Sprintf("Method resume_connector with the id %s for token %s took %s to complete", id, token, time.Since(begin))
+		if err != nil {
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		l.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ResumeConnector(ctx, token, id)
+}
+
+func (lm *loggingMiddleware) Consume(msgs interface{}) (err error) {
+	defer func(begin time.Time) {
+		l := lm.logger.With("method", "consume", "latency", time.Since(begin).String())
+		message := fmt.Sprintf("Method consume took %s to complete", time.Since(begin))
+		if err != nil {
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		l.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.Consume(msgs)
+}
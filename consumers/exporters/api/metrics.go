@@ -0,0 +1,112 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !test
+
+package api
+
+import (
+	"context"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/consumers/exporters"
+	"github.com/go-kit/kit/metrics"
+)
+
+var _ exporters.Service = (*metricsMiddleware)(nil)
+
+type metricsMiddleware struct {
+	counter metrics.Counter
+	latency metrics.Histogram
+	lag     metrics.Gauge
+	svc     exporters.Service
+}
+
+// MetricsMiddleware instruments core service by tracking request count and
+// latency, and the export lag of the consumed messages by channel.
+func MetricsMiddleware(svc exporters.Service, counter metrics.Counter, latency metrics.Histogram, lag metrics.Gauge) exporters.Service {
+	return &metricsMiddleware{
+		counter: counter,
+		latency: latency,
+		lag:     lag,
+		svc:     svc,
+	}
+}
+
+func (ms *metricsMiddleware) CreateConnector(ctx context.Context, token string, c exporters.Connector) (exporters.Connector, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "create_connector").Add(1)
+		ms.latency.With("method", "create_connector").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.CreateConnector(ctx, token, c)
+}
+
+func (ms *metricsMiddleware) ViewConnector(ctx context.Context, token, id string) (exporters.Connector, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "view_connector").Add(1)
+		ms.latency.With("method", "view_connector").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ViewConnector(ctx, token, id)
+}
+
+func (ms *metricsMiddleware) ListConnectors(ctx context.Context, token string, pm exporters.PageMetadata) (exporters.ConnectorsPage, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "list_connectors").Add(1)
+		ms.latency.With("method", "list_connectors").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ListConnectors(ctx, token, pm)
+}
+
+func (ms *metricsMiddleware) UpdateConnector(ctx context.Context, token string, c exporters.Connector) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "update_connector").Add(1)
+		ms.latency.With("method", "update_connector").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.UpdateConnector(ctx, token, c)
+}
+
+func (ms *metricsMiddleware) RemoveConnector(ctx context.Context, token, id string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "remove_connector").Add(1)
+		ms.latency.With("method", "remove_connector").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.RemoveConnector(ctx, token, id)
+}
+
+func (ms *metricsMiddleware) PauseConnector(ctx context.Context, token, id string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "pause_connector").Add(1)
+		ms.latency.With("method", "pause_connector").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.PauseConnector(ctx, token, id)
+}
+
+func (ms *metricsMiddleware) ResumeConnector(ctx context.Context, token, id string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "resume_connector").Add(1)
+		ms.latency.With("method", "resume_connector").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ResumeConnector(ctx, token, id)
+}
+
+func (ms *metricsMiddleware) Consume(msgs interface{}) (err error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "consume").Add(1)
+		ms.latency.With("method", "consume").Observe(time.Since(begin).Seconds())
+		if err != nil {
+			return
+		}
+		if m, err := exporters.Messages(msgs); err == nil && len(m) > 0 {
+			ms.lag.With("channel", m[0].Channel).Set(exporters.Lag(m, time.Now()).Seconds())
+		}
+	}(time.Now())
+
+	return ms.svc.Consume(msgs)
+}
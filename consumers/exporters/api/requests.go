@@ -0,0 +1,112 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"github.com/MainfluxLabs/mainflux/consumers/exporters"
+	"github.com/MainfluxLabs/mainflux/internal/apiutil"
+)
+
+const (
+	maxLimitSize = 100
+	maxNameSize  = 1024
+)
+
+type createConnectorReq struct {
+	token       string
+	Name        string `json:"name,omitempty"`
+	ChannelID   string `json:"channel_id,omitempty"`
+	GroupID     string `json:"group_id,omitempty"`
+	Type        string `json:"type"`
+	Destination string `json:"destination"`
+}
+
+func (req createConnectorReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	// The connector exports the messages of either a channel or a group.
+	if (req.ChannelID == "") == (req.GroupID == "") {
+		return apiutil.ErrMalformedEntity
+	}
+
+	switch req.Type {
+	case exporters.TypeKafka, exporters.TypeS3, exporters.TypeBigQuery:
+	default:
+		return exporters.ErrInvalidType
+	}
+
+	if len(req.Name) > maxNameSize {
+		return apiutil.ErrNameSize
+	}
+
+	if req.Destination == "" {
+		return apiutil.ErrMalformedEntity
+	}
+
+	return nil
+}
+
+type updateConnectorReq struct {
+	token       string
+	id          string
+	Name        string `json:"name,omitempty"`
+	Destination string `json:"destination"`
+}
+
+func (req updateConnectorReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	if req.id == "" {
+		return apiutil.ErrMissingID
+	}
+
+	if len(req.Name) > maxNameSize {
+		return apiutil.ErrNameSize
+	}
+
+	if req.Destination == "" {
+		return apiutil.ErrMalformedEntity
+	}
+
+	return nil
+}
+
+type viewReq struct {
+	token string
+	id    string
+}
+
+func (req viewReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	if req.id == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return nil
+}
+
+type listReq struct {
+	token  string
+	offset uint64
+	limit  uint64
+}
+
+func (req listReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	if req.limit > maxLimitSize {
+		return apiutil.ErrLimitSize
+	}
+
+	return nil
+}
@@ -0,0 +1,101 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux"
+)
+
+var (
+	_ mainflux.Response = (*connectorRes)(nil)
+	_ mainflux.Response = (*connectorsPageRes)(nil)
+	_ mainflux.Response = (*emptyRes)(nil)
+)
+
+type pageRes struct {
+	Total  uint64 `json:"total"`
+	Offset uint64 `json:"offset"`
+	Limit  uint64 `json:"limit"`
+}
+
+type statsRes struct {
+	Exported       uint64     `json:"exported"`
+	Failed         uint64     `json:"failed"`
+	LagMs          int64      `json:"lag_ms"`
+	LastExportedAt *time.Time `json:"last_exported_at,omitempty"`
+	LastError      string     `json:"last_error,omitempty"`
+}
+
+type connectorRes struct {
+	ID          string    `json:"id"`
+	Name        string    `json:"name,omitempty"`
+	ChannelID   string    `json:"channel_id,omitempty"`
+	GroupID     string    `json:"group_id,omitempty"`
+	Type        string    `json:"type"`
+	Destination string    `json:"destination"`
+	Status      string    `json:"status"`
+	Stats       statsRes  `json:"stats"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+	created     bool
+}
+
+func (res connectorRes) Code() int {
+	if res.created {
+		return http.StatusCreated
+	}
+
+	return http.StatusOK
+}
+
+func (res connectorRes) Headers() map[string]string {
+	if res.created {
+		return map[string]string{
+			"Location": fmt.Sprintf("/connectors/%s", res.ID),
+		}
+	}
+
+	return map[string]string{}
+}
+
+func (res connectorRes) Empty() bool {
+	return false
+}
+
+type connectorsPageRes struct {
+	pageRes
+	Connectors []connectorRes `json:"connectors"`
+}
+
+func (res connectorsPageRes) Code() int {
+	return http.StatusOK
+}
+
+func (res connectorsPageRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res connectorsPageRes) Empty() bool {
+	return false
+}
+
+type emptyRes struct {
+	code int
+}
+
+func (res emptyRes) Code() int {
+	return res.code
+}
+
+func (res emptyRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res emptyRes) Empty() bool {
+	return true
+}
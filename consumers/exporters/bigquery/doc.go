@@ -0,0 +1,6 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package bigquery contains the export sink streaming the messages to the
+// BigQuery tables.
+package bigquery
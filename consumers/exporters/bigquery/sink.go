@@ -0,0 +1,253 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package bigquery
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/consumers/exporters"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/golang-jwt/jwt/v4"
+)
+
+const (
+	defEndpoint = "https://bigquery.googleapis.com"
+	scope       = "https://www.googleapis.com/auth/bigquery.insertdata"
+	grantType   = "urn:ietf:params:oauth:grant-type:jwt-bearer"
+	tokenTTL    = time.Hour
+	// expiryDelta is the time before the expiry the token is refreshed at.
+	expiryDelta = time.Minute
+)
+
+var (
+	// ErrInvalidTable indicates a malformed BigQuery table destination.
+	ErrInvalidTable = errors.New("invalid BigQuery table")
+
+	errToken = errors.New("failed to obtain BigQuery access token")
+)
+
+var _ exporters.Sink = (*sink)(nil)
+
+// Credentials represents the Google service account key, as downloaded
+// from the Google Cloud console.
+type Credentials struct {
+	ProjectID   string `json:"project_id"`
+	ClientEmail string `json:"client_email"`
+	PrivateKey  string `json:"private_key"`
+	TokenURI    string `json:"token_uri"`
+}
+
+// Config defines the BigQuery sink.
+type Config struct {
+	// Endpoint is the BigQuery API URL, defaults to the Google API.
+	Endpoint    string
+	Credentials Credentials
+}
+
+type sink struct {
+	cfg    Config
+	client *http.Client
+
+	mu      sync.Mutex
+	token   string
+	expires time.Time
+}
+
+type row struct {
+	JSON rowData `json:"json"`
+}
+
+type rowData struct {
+	Channel string `json:"channel"`
+	Created string `json:"created"`
+	Data    string `json:"data"`
+}
+
+type insertAllReq struct {
+	Rows []row `json:"rows"`
+}
+
+type insertAllRes struct {
+	InsertErrors []struct {
+		Index  int `json:"index"`
+		Errors []struct {
+			Reason  string `json:"reason"`
+			Message string `json:"message"`
+		} `json:"errors"`
+	} `json:"insertErrors"`
+}
+
+// New returns the sink streaming the messages to the BigQuery tables,
+// authenticated as the service account. The destination table is given as
+// dataset.table of the service account project, or as project.dataset.table.
+// Tables are expected to have the channel STRING, created TIMESTAMP and
+// data STRING columns.
+func New(cfg Config, client *http.Client) exporters.Sink {
+	if cfg.Endpoint == "" {
+		cfg.Endpoint = defEndpoint
+	}
+	cfg.Endpoint = strings.TrimSuffix(cfg.Endpoint, "/")
+
+	return &sink{
+		cfg:    cfg,
+		client: client,
+	}
+}
+
+func (s *sink) Export(ctx context.Context, table string, msgs []exporters.Message) error {
+	project, dataset, name, err := s.parseTable(table)
+	if err != nil {
+		return err
+	}
+
+	var req insertAllReq
+	for _, msg := range msgs {
+		req.Rows = append(req.Rows, row{JSON: rowData{
+			Channel: msg.Channel,
+			Created: msg.Created.UTC().Format(time.RFC3339Nano),
+			Data:    string(msg.Data),
+		}})
+	}
+
+	body, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+
+	token, err := s.accessToken(ctx)
+	if err != nil {
+		return err
+	}
+
+	u := fmt.Sprintf("%s/bigquery/v2/projects/%s/datasets/%s/tables/%s/insertAll",
+		s.cfg.Endpoint, url.PathEscape(project), url.PathEscape(dataset), url.PathEscape(name))
+	r, err := http.NewRequestWithContext(ctx, http.MethodPost, u, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	r.Header.Set("Content-Type", "application/json")
+	r.Header.Set("Authorization", "Bearer "+token)
+
+	res, err := s.client.Do(r)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	data, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return err
+	}
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected response status %d: %s", res.StatusCode, strings.TrimSpace(string(data)))
+	}
+
+	// Rows are inserted independently, so the failed rows are reported
+	// in the successful response.
+	var iar insertAllRes
+	if err := json.Unmarshal(data, &iar); err != nil {
+		return err
+	}
+	if len(iar.InsertErrors) > 0 {
+		ie := iar.InsertErrors[0]
+		var reasons []string
+		for _, e := range ie.Errors {
+			reasons = append(reasons, fmt.Sprintf("%s: %s", e.Reason, e.Message))
+		}
+		return fmt.Errorf("failed to insert %d rows, row %d: %s", len(iar.InsertErrors), ie.Index, strings.Join(reasons, "; "))
+	}
+
+	return nil
+}
+
+func (s *sink) parseTable(table string) (string, string, string, error) {
+	parts := strings.Split(table, ".")
+	for _, p := range parts {
+		if p == "" {
+			return "", "", "", ErrInvalidTable
+		}
+	}
+
+	switch len(parts) {
+	case 2:
+		return s.cfg.Credentials.ProjectID, parts[0], parts[1], nil
+	case 3:
+		return parts[0], parts[1], parts[2], nil
+	default:
+		return "", "", "", ErrInvalidTable
+	}
+}
+
+// accessToken returns the cached access token of the service account, or
+// exchanges the signed JWT assertion for the new one if it is about to
+// expire.
+func (s *sink) accessToken(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token != "" && time.Now().Add(expiryDelta).Before(s.expires) {
+		return s.token, nil
+	}
+
+	key, err := jwt.ParseRSAPrivateKeyFromPEM([]byte(s.cfg.Credentials.PrivateKey))
+	if err != nil {
+		return "", errors.Wrap(errToken, err)
+	}
+
+	now := time.Now()
+	claims := jwt.MapClaims{
+		"iss":   s.cfg.Credentials.ClientEmail,
+		"scope": scope,
+		"aud":   s.cfg.Credentials.TokenURI,
+		"iat":   now.Unix(),
+		"exp":   now.Add(tokenTTL).Unix(),
+	}
+	assertion, err := jwt.NewWithClaims(jwt.SigningMethodRS256, claims).SignedString(key)
+	if err != nil {
+		return "", errors.Wrap(errToken, err)
+	}
+
+	form := url.Values{
+		"grant_type": {grantType},
+		"assertion":  {assertion},
+	}
+	r, err := http.NewRequestWithContext(ctx, http.MethodPost, s.cfg.Credentials.TokenURI, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", errors.Wrap(errToken, err)
+	}
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	res, err := s.client.Do(r)
+	if err != nil {
+		return "", errors.Wrap(errToken, err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		data, _ := ioutil.ReadAll(res.Body)
+		return "", errors.Wrap(errToken, fmt.Errorf("unexpected response status %d: %s", res.StatusCode, strings.TrimSpace(string(data))))
+	}
+
+	var tr struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int64  `json:"expires_in"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&tr); err != nil {
+		return "", errors.Wrap(errToken, err)
+	}
+
+	s.token = tr.AccessToken
+	s.expires = now.Add(time.Duration(tr.ExpiresIn) * time.Second)
+
+	return s.token, nil
+}
@@ -0,0 +1,100 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package exporters
+
+import (
+	"context"
+	"time"
+)
+
+// Connector types.
+const (
+	TypeKafka    = "kafka"
+	TypeS3       = "s3"
+	TypeBigQuery = "bigquery"
+)
+
+// Connector statuses.
+const (
+	StatusActive = "active"
+	StatusPaused = "paused"
+)
+
+// Connector represents the continuous export of the messages of a channel,
+// or of all the channels of a group, to the external sink. The destination
+// identifies the Kafka topic, the S3 key prefix or the BigQuery table the
+// messages are exported to, depending on the connector type.
+type Connector struct {
+	ID          string
+	OwnerID     string
+	Name        string
+	ChannelID   string
+	GroupID     string
+	Type        string
+	Destination string
+	Status      string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	Stats       Stats
+}
+
+// Stats contains the export statistics of the connector.
+type Stats struct {
+	// Exported is the number of exported messages.
+	Exported uint64
+	// Failed is the number of failed exports.
+	Failed uint64
+	// Lag is the time between the creation and the export of the newest
+	// message of the last export.
+	Lag time.Duration
+	// LastExportedAt is the time of the last successful export.
+	LastExportedAt time.Time
+	// LastError is the failure reason of the last failed export.
+	LastError string
+}
+
+// ConnectorsPage contains page related metadata as well as list of
+// connectors that belong to this page.
+type ConnectorsPage struct {
+	PageMetadata
+	Connectors []Connector
+}
+
+// PageMetadata contains page metadata that helps navigation.
+type PageMetadata struct {
+	Total  uint64
+	Offset uint64
+	Limit  uint64
+}
+
+// ConnectorRepository specifies a connector persistence API.
+type ConnectorRepository interface {
+	// Save persists the connector.
+	Save(ctx context.Context, c Connector) error
+
+	// Update updates the connector name and destination.
+	Update(ctx context.Context, c Connector) error
+
+	// UpdateStatus updates the status of the connector owned by the user.
+	UpdateStatus(ctx context.Context, owner, id, status string) error
+
+	// RetrieveByID retrieves the connector having the provided identifier.
+	RetrieveByID(ctx context.Context, id string) (Connector, error)
+
+	// RetrieveByOwner retrieves the subset of connectors owned by the specified user.
+	RetrieveByOwner(ctx context.Context, owner string, pm PageMetadata) (ConnectorsPage, error)
+
+	// RetrieveActive retrieves the active connectors of the channel and of
+	// the group the channel belongs to.
+	RetrieveActive(ctx context.Context, chanID, groupID string) ([]Connector, error)
+
+	// Remove removes the connector owned by the user.
+	Remove(ctx context.Context, owner, id string) error
+
+	// RecordExport adds the exported messages to the connector statistics.
+	RecordExport(ctx context.Context, id string, n uint64, lag time.Duration, at time.Time) error
+
+	// RecordFailure adds the failed export to the connector statistics.
+	RecordFailure(ctx context.Context, id, reason string) error
+}
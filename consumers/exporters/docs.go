@@ -0,0 +1,6 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package exporters contains the domain concept definitions needed to
+// support Mainflux continuous message export functionality.
+package exporters
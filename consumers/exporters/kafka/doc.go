@@ -0,0 +1,6 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package kafka contains the export sink producing the messages to the
+// Kafka topics using the Kafka REST Proxy.
+package kafka
@@ -0,0 +1,78 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package kafka
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/MainfluxLabs/mainflux/consumers/exporters"
+)
+
+const contentType = "application/vnd.kafka.json.v2+json"
+
+var _ exporters.Sink = (*sink)(nil)
+
+type sink struct {
+	url    string
+	client *http.Client
+}
+
+type record struct {
+	Key   string          `json:"key"`
+	Value json.RawMessage `json:"value"`
+}
+
+type produceReq struct {
+	Records []record `json:"records"`
+}
+
+// New returns the sink producing the messages to the Kafka topics using the
+// Kafka REST Proxy available at the given URL. Messages are keyed by their
+// channel, so that the messages of a channel are kept in order.
+func New(url string, client *http.Client) exporters.Sink {
+	return &sink{
+		url:    strings.TrimSuffix(url, "/"),
+		client: client,
+	}
+}
+
+func (s *sink) Export(ctx context.Context, topic string, msgs []exporters.Message) error {
+	var req produceReq
+	for _, msg := range msgs {
+		req.Records = append(req.Records, record{Key: msg.Channel, Value: msg.Data})
+	}
+
+	body, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+
+	u := fmt.Sprintf("%s/topics/%s", s.url, url.PathEscape(topic))
+	r, err := http.NewRequestWithContext(ctx, http.MethodPost, u, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	r.Header.Set("Content-Type", contentType)
+	r.Header.Set("Accept", "application/vnd.kafka.v2+json")
+
+	res, err := s.client.Do(r)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		data, _ := ioutil.ReadAll(res.Body)
+		return fmt.Errorf("unexpected response status %d: %s", res.StatusCode, strings.TrimSpace(string(data)))
+	}
+
+	return nil
+}
@@ -0,0 +1,179 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/consumers/exporters"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+)
+
+var _ exporters.ConnectorRepository = (*connectorRepositoryMock)(nil)
+
+type connectorRepositoryMock struct {
+	mu         sync.Mutex
+	connectors map[string]exporters.Connector
+}
+
+// NewConnectorRepository returns a new connector repository mock.
+func NewConnectorRepository() exporters.ConnectorRepository {
+	return &connectorRepositoryMock{
+		connectors: make(map[string]exporters.Connector),
+	}
+}
+
+func (crm *connectorRepositoryMock) Save(_ context.Context, c exporters.Connector) error {
+	crm.mu.Lock()
+	defer crm.mu.Unlock()
+
+	if _, ok := crm.connectors[c.ID]; ok {
+		return errors.ErrConflict
+	}
+
+	crm.connectors[c.ID] = c
+	return nil
+}
+
+func (crm *connectorRepositoryMock) Update(_ context.Context, c exporters.Connector) error {
+	crm.mu.Lock()
+	defer crm.mu.Unlock()
+
+	saved, ok := crm.connectors[c.ID]
+	if !ok || saved.OwnerID != c.OwnerID {
+		return errors.ErrNotFound
+	}
+
+	saved.Name = c.Name
+	saved.Destination = c.Destination
+	saved.UpdatedAt = c.UpdatedAt
+	crm.connectors[c.ID] = saved
+	return nil
+}
+
+func (crm *connectorRepositoryMock) UpdateStatus(_ context.Context, owner, id, status string) error {
+	crm.mu.Lock()
+	defer crm.mu.Unlock()
+
+	c, ok := crm.connectors[id]
+	if !ok || c.OwnerID != owner {
+		return errors.ErrNotFound
+	}
+
+	c.Status = status
+	crm.connectors[id] = c
+	return nil
+}
+
+func (crm *connectorRepositoryMock) RetrieveByID(_ context.Context, id string) (exporters.Connector, error) {
+	crm.mu.Lock()
+	defer crm.mu.Unlock()
+
+	c, ok := crm.connectors[id]
+	if !ok {
+		return exporters.Connector{}, errors.ErrNotFound
+	}
+
+	return c, nil
+}
+
+func (crm *connectorRepositoryMock) RetrieveByOwner(_ context.Context, owner string, pm exporters.PageMetadata) (exporters.ConnectorsPage, error) {
+	crm.mu.Lock()
+	defer crm.mu.Unlock()
+
+	var items []exporters.Connector
+	for _, c := range crm.connectors {
+		if c.OwnerID == owner {
+			items = append(items, c)
+		}
+	}
+
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].ID < items[j].ID
+	})
+
+	start := int(pm.Offset)
+	if start > len(items) {
+		start = len(items)
+	}
+	end := start + int(pm.Limit)
+	if pm.Limit == 0 || end > len(items) {
+		end = len(items)
+	}
+
+	page := exporters.ConnectorsPage{
+		PageMetadata: exporters.PageMetadata{
+			Total:  uint64(len(items)),
+			Offset: pm.Offset,
+			Limit:  pm.Limit,
+		},
+		Connectors: items[start:end],
+	}
+
+	return page, nil
+}
+
+func (crm *connectorRepositoryMock) RetrieveActive(_ context.Context, chanID, groupID string) ([]exporters.Connector, error) {
+	crm.mu.Lock()
+	defer crm.mu.Unlock()
+
+	var items []exporters.Connector
+	for _, c := range crm.connectors {
+		if c.Status != exporters.StatusActive {
+			continue
+		}
+		if (c.ChannelID != "" && c.ChannelID == chanID) || (c.GroupID != "" && c.GroupID == groupID) {
+			items = append(items, c)
+		}
+	}
+
+	return items, nil
+}
+
+func (crm *connectorRepositoryMock) Remove(_ context.Context, owner, id string) error {
+	crm.mu.Lock()
+	defer crm.mu.Unlock()
+
+	c, ok := crm.connectors[id]
+	if !ok || c.OwnerID != owner {
+		return errors.ErrNotFound
+	}
+
+	delete(crm.connectors, id)
+	return nil
+}
+
+func (crm *connectorRepositoryMock) RecordExport(_ context.Context, id string, n uint64, lag time.Duration, at time.Time) error {
+	crm.mu.Lock()
+	defer crm.mu.Unlock()
+
+	c, ok := crm.connectors[id]
+	if !ok {
+		return errors.ErrNotFound
+	}
+
+	c.Stats.Exported += n
+	c.Stats.Lag = lag
+	c.Stats.LastExportedAt = at
+	crm.connectors[id] = c
+	return nil
+}
+
+func (crm *connectorRepositoryMock) RecordFailure(_ context.Context, id, reason string) error {
+	crm.mu.Lock()
+	defer crm.mu.Unlock()
+
+	c, ok := crm.connectors[id]
+	if !ok {
+		return errors.ErrNotFound
+	}
+
+	c.Stats.Failed++
+	c.Stats.LastError = reason
+	crm.connectors[id] = c
+	return nil
+}
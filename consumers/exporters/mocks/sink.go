@@ -0,0 +1,41 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"context"
+	"sync"
+
+	"github.com/MainfluxLabs/mainflux/consumers/exporters"
+)
+
+var _ exporters.Sink = (*Sink)(nil)
+
+// Sink is an export sink mock recording exported messages by destination.
+type Sink struct {
+	mu       sync.Mutex
+	err      error
+	Messages map[string][]exporters.Message
+}
+
+// NewSink returns a recording export sink mock, which fails with the given
+// error if it is not nil.
+func NewSink(err error) *Sink {
+	return &Sink{
+		err:      err,
+		Messages: make(map[string][]exporters.Message),
+	}
+}
+
+func (s *Sink) Export(_ context.Context, destination string, msgs []exporters.Message) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.err != nil {
+		return s.err
+	}
+
+	s.Messages[destination] = append(s.Messages[destination], msgs...)
+	return nil
+}
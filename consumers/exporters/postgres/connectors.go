@@ -0,0 +1,284 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/consumers/exporters"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+const connectorColumns = `id, owner_id, name, channel_id, group_id, type, destination, status,
+	exported, failed, lag, last_exported_at, last_error, created_at, updated_at`
+
+var _ exporters.ConnectorRepository = (*connectorRepository)(nil)
+
+type connectorRepository struct {
+	db Database
+}
+
+// NewConnectorRepository instantiates a PostgreSQL implementation of connector repository.
+func NewConnectorRepository(db Database) exporters.ConnectorRepository {
+	return &connectorRepository{db: db}
+}
+
+func (cr connectorRepository) Save(ctx context.Context, c exporters.Connector) error {
+	q := `INSERT INTO connectors (id, owner_id, name, channel_id, group_id, type, destination, status, created_at, updated_at)
+		VALUES (:id, :owner_id, :name, :channel_id, :group_id, :type, :destination, :status, :created_at, :updated_at)`
+
+	if _, err := cr.db.NamedExecContext(ctx, q, toDBConnector(c)); err != nil {
+		if pgErr, ok := err.(*pgconn.PgError); ok && pgErr.Code == pgerrcode.UniqueViolation {
+			return errors.Wrap(errors.ErrConflict, err)
+		}
+		return errors.Wrap(errors.ErrCreateEntity, err)
+	}
+
+	return nil
+}
+
+func (cr connectorRepository) Update(ctx context.Context, c exporters.Connector) error {
+	q := `UPDATE connectors SET name = :name, destination = :destination, updated_at = :updated_at
+		WHERE id = :id AND owner_id = :owner_id`
+
+	res, err := cr.db.NamedExecContext(ctx, q, toDBConnector(c))
+	if err != nil {
+		return errors.Wrap(errors.ErrUpdateEntity, err)
+	}
+
+	if cnt, err := res.RowsAffected(); err == nil && cnt == 0 {
+		return errors.ErrNotFound
+	}
+
+	return nil
+}
+
+func (cr connectorRepository) UpdateStatus(ctx context.Context, owner, id, status string) error {
+	q := `UPDATE connectors SET status = :status, updated_at = :updated_at WHERE id = :id AND owner_id = :owner_id`
+	params := map[string]interface{}{
+		"id":         id,
+		"owner_id":   owner,
+		"status":     status,
+		"updated_at": time.Now().UTC(),
+	}
+
+	res, err := cr.db.NamedExecContext(ctx, q, params)
+	if err != nil {
+		return errors.Wrap(errors.ErrUpdateEntity, err)
+	}
+
+	if cnt, err := res.RowsAffected(); err == nil && cnt == 0 {
+		return errors.ErrNotFound
+	}
+
+	return nil
+}
+
+func (cr connectorRepository) RetrieveByID(ctx context.Context, id string) (exporters.Connector, error) {
+	q := `SELECT ` + connectorColumns + ` FROM connectors WHERE id = $1`
+
+	var dbc dbConnector
+	if err := cr.db.QueryRowxContext(ctx, q, id).StructScan(&dbc); err != nil {
+		if err == sql.ErrNoRows {
+			return exporters.Connector{}, errors.Wrap(errors.ErrNotFound, err)
+		}
+		return exporters.Connector{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+
+	return toConnector(dbc), nil
+}
+
+func (cr connectorRepository) RetrieveByOwner(ctx context.Context, owner string, pm exporters.PageMetadata) (exporters.ConnectorsPage, error) {
+	q := `SELECT ` + connectorColumns + ` FROM connectors WHERE owner_id = :owner_id
+		ORDER BY created_at DESC LIMIT :limit OFFSET :offset`
+	params := map[string]interface{}{
+		"owner_id": owner,
+		"limit":    pm.Limit,
+		"offset":   pm.Offset,
+	}
+
+	items, err := cr.retrieve(ctx, q, params)
+	if err != nil {
+		return exporters.ConnectorsPage{}, err
+	}
+
+	cq := `SELECT COUNT(*) FROM connectors WHERE owner_id = :owner_id`
+	total, err := total(ctx, cr.db, cq, params)
+	if err != nil {
+		return exporters.ConnectorsPage{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+
+	return exporters.ConnectorsPage{
+		PageMetadata: exporters.PageMetadata{
+			Total:  total,
+			Offset: pm.Offset,
+			Limit:  pm.Limit,
+		},
+		Connectors: items,
+	}, nil
+}
+
+func (cr connectorRepository) RetrieveActive(ctx context.Context, chanID, groupID string) ([]exporters.Connector, error) {
+	q := `SELECT ` + connectorColumns + ` FROM connectors
+		WHERE status = :status AND (channel_id = :channel_id OR group_id = :group_id)`
+	params := map[string]interface{}{
+		"status":     exporters.StatusActive,
+		"channel_id": chanID,
+		"group_id":   groupID,
+	}
+
+	return cr.retrieve(ctx, q, params)
+}
+
+func (cr connectorRepository) Remove(ctx context.Context, owner, id string) error {
+	q := `DELETE FROM connectors WHERE id = :id AND owner_id = :owner_id`
+	params := map[string]interface{}{
+		"id":       id,
+		"owner_id": owner,
+	}
+
+	res, err := cr.db.NamedExecContext(ctx, q, params)
+	if err != nil {
+		return errors.Wrap(errors.ErrRemoveEntity, err)
+	}
+
+	if cnt, err := res.RowsAffected(); err == nil && cnt == 0 {
+		return errors.ErrNotFound
+	}
+
+	return nil
+}
+
+func (cr connectorRepository) RecordExport(ctx context.Context, id string, n uint64, lag time.Duration, at time.Time) error {
+	q := `UPDATE connectors SET exported = exported + :n, lag = :lag, last_exported_at = :at WHERE id = :id`
+	params := map[string]interface{}{
+		"id":  id,
+		"n":   n,
+		"lag": int64(lag / time.Millisecond),
+		"at":  at,
+	}
+
+	if _, err := cr.db.NamedExecContext(ctx, q, params); err != nil {
+		return errors.Wrap(errors.ErrUpdateEntity, err)
+	}
+
+	return nil
+}
+
+func (cr connectorRepository) RecordFailure(ctx context.Context, id, reason string) error {
+	q := `UPDATE connectors SET failed = failed + 1, last_error = :reason WHERE id = :id`
+	params := map[string]interface{}{
+		"id":     id,
+		"reason": reason,
+	}
+
+	if _, err := cr.db.NamedExecContext(ctx, q, params); err != nil {
+		return errors.Wrap(errors.ErrUpdateEntity, err)
+	}
+
+	return nil
+}
+
+func (cr connectorRepository) retrieve(ctx context.Context, query string, params interface{}) ([]exporters.Connector, error) {
+	rows, err := cr.db.NamedQueryContext(ctx, query, params)
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+	defer rows.Close()
+
+	var items []exporters.Connector
+	for rows.Next() {
+		var dbc dbConnector
+		if err := rows.StructScan(&dbc); err != nil {
+			return nil, errors.Wrap(errors.ErrRetrieveEntity, err)
+		}
+		items = append(items, toConnector(dbc))
+	}
+
+	return items, nil
+}
+
+func total(ctx context.Context, db Database, query string, params interface{}) (uint64, error) {
+	rows, err := db.NamedQueryContext(ctx, query, params)
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	var total uint64
+	if rows.Next() {
+		if err := rows.Scan(&total); err != nil {
+			return 0, err
+		}
+	}
+
+	return total, nil
+}
+
+type dbConnector struct {
+	ID             string         `db:"id"`
+	OwnerID        string         `db:"owner_id"`
+	Name           sql.NullString `db:"name"`
+	ChannelID      sql.NullString `db:"channel_id"`
+	GroupID        sql.NullString `db:"group_id"`
+	Type           string         `db:"type"`
+	Destination    string         `db:"destination"`
+	Status         string         `db:"status"`
+	Exported       int64          `db:"exported"`
+	Failed         int64          `db:"failed"`
+	Lag            int64          `db:"lag"`
+	LastExportedAt sql.NullTime   `db:"last_exported_at"`
+	LastError      sql.NullString `db:"last_error"`
+	CreatedAt      time.Time      `db:"created_at"`
+	UpdatedAt      time.Time      `db:"updated_at"`
+}
+
+func toDBConnector(c exporters.Connector) dbConnector {
+	return dbConnector{
+		ID:          c.ID,
+		OwnerID:     c.OwnerID,
+		Name:        nullString(c.Name),
+		ChannelID:   nullString(c.ChannelID),
+		GroupID:     nullString(c.GroupID),
+		Type:        c.Type,
+		Destination: c.Destination,
+		Status:      c.Status,
+		CreatedAt:   c.CreatedAt,
+		UpdatedAt:   c.UpdatedAt,
+	}
+}
+
+func toConnector(dbc dbConnector) exporters.Connector {
+	c := exporters.Connector{
+		ID:          dbc.ID,
+		OwnerID:     dbc.OwnerID,
+		Name:        dbc.Name.String,
+		ChannelID:   dbc.ChannelID.String,
+		GroupID:     dbc.GroupID.String,
+		Type:        dbc.Type,
+		Destination: dbc.Destination,
+		Status:      dbc.Status,
+		CreatedAt:   dbc.CreatedAt.UTC(),
+		UpdatedAt:   dbc.UpdatedAt.UTC(),
+		Stats: exporters.Stats{
+			Exported:  uint64(dbc.Exported),
+			Failed:    uint64(dbc.Failed),
+			Lag:       time.Duration(dbc.Lag) * time.Millisecond,
+			LastError: dbc.LastError.String,
+		},
+	}
+	if dbc.LastExportedAt.Valid {
+		c.Stats.LastExportedAt = dbc.LastExportedAt.Time.UTC()
+	}
+
+	return c
+}
+
+func nullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
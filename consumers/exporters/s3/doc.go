@@ -0,0 +1,6 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package s3 contains the export sink storing the messages as objects of
+// the S3-compatible object storage.
+package s3
@@ -0,0 +1,45 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package s3
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/consumers/exporters"
+	"github.com/MainfluxLabs/mainflux/pkg/archive"
+)
+
+var _ exporters.Sink = (*sink)(nil)
+
+type sink struct {
+	storage archive.Storage
+}
+
+// New returns the sink storing every export as the JSON lines object of
+// the storage. Objects are stored under the destination prefix, followed
+// by the channel and the day of the export, e.g.
+// <prefix><channel>/2006-01-02/<unix_nano>.jsonl.
+func New(storage archive.Storage) exporters.Sink {
+	return &sink{storage: storage}
+}
+
+func (s *sink) Export(ctx context.Context, prefix string, msgs []exporters.Message) error {
+	if len(msgs) == 0 {
+		return nil
+	}
+
+	var buf bytes.Buffer
+	for _, msg := range msgs {
+		buf.Write(msg.Data)
+		buf.WriteByte('\n')
+	}
+
+	now := time.Now().UTC()
+	key := fmt.Sprintf("%s%s/%s/%d.jsonl", prefix, msgs[0].Channel, now.Format(archive.DayLayout), now.UnixNano())
+
+	return s.storage.Put(ctx, key, buf.Bytes())
+}
@@ -0,0 +1,286 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package exporters
+
+import (
+	"context"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux"
+	"github.com/MainfluxLabs/mainflux/consumers"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+)
+
+var (
+	// ErrMessage indicates an unsupported type of the consumed messages.
+	ErrMessage = errors.New("unsupported messages type")
+
+	// ErrInvalidType indicates an unsupported connector type.
+	ErrInvalidType = errors.New("invalid connector type")
+
+	// ErrExport indicates failure to export the messages to the sink.
+	ErrExport = errors.New("failed to export messages")
+)
+
+// Service specifies an API that must be fullfiled by the domain service
+// implementation, and all of its decorators (e.g. logging & metrics).
+type Service interface {
+	// CreateConnector creates the active connector of the channel or group
+	// owned by the user.
+	CreateConnector(ctx context.Context, token string, c Connector) (Connector, error)
+
+	// ViewConnector retrieves the connector together with its statistics.
+	ViewConnector(ctx context.Context, token, id string) (Connector, error)
+
+	// ListConnectors retrieves the connectors created by the user.
+	ListConnectors(ctx context.Context, token string, pm PageMetadata) (ConnectorsPage, error)
+
+	// UpdateConnector updates the connector name and destination.
+	UpdateConnector(ctx context.Context, token string, c Connector) error
+
+	// RemoveConnector removes the connector.
+	RemoveConnector(ctx context.Context, token, id string) error
+
+	// PauseConnector stops the export of the new messages of the connector.
+	PauseConnector(ctx context.Context, token, id string) error
+
+	// ResumeConnector resumes the export of the new messages of the
+	// connector. Messages received while the connector was paused are not
+	// exported.
+	ResumeConnector(ctx context.Context, token, id string) error
+
+	consumers.Consumer
+}
+
+var _ Service = (*exporterService)(nil)
+
+type exporterService struct {
+	auth       mainflux.AuthServiceClient
+	things     mainflux.ThingsServiceClient
+	connectors ConnectorRepository
+	sinks      map[string]Sink
+	idProvider mainflux.IDProvider
+}
+
+// New instantiates the exporter service implementation. Sinks are keyed by
+// the type of the connectors they export the messages of.
+func New(auth mainflux.AuthServiceClient, things mainflux.ThingsServiceClient, connectors ConnectorRepository, sinks map[string]Sink, idp mainflux.IDProvider) Service {
+	return &exporterService{
+		auth:       auth,
+		things:     things,
+		connectors: connectors,
+		sinks:      sinks,
+		idProvider: idp,
+	}
+}
+
+func (es *exporterService) CreateConnector(ctx context.Context, token string, c Connector) (Connector, error) {
+	res, err := es.auth.Identify(ctx, &mainflux.Token{Value: token})
+	if err != nil {
+		return Connector{}, errors.Wrap(errors.ErrAuthentication, err)
+	}
+
+	if _, ok := es.sinks[c.Type]; !ok {
+		return Connector{}, ErrInvalidType
+	}
+
+	if err := es.authorize(ctx, res.GetId(), c); err != nil {
+		return Connector{}, err
+	}
+
+	id, err := es.idProvider.ID()
+	if err != nil {
+		return Connector{}, err
+	}
+
+	timestamp := getTimestamp()
+	c.ID = id
+	c.OwnerID = res.GetId()
+	c.Status = StatusActive
+	c.CreatedAt = timestamp
+	c.UpdatedAt = timestamp
+	c.Stats = Stats{}
+
+	if err := es.connectors.Save(ctx, c); err != nil {
+		return Connector{}, err
+	}
+
+	return c, nil
+}
+
+func (es *exporterService) ViewConnector(ctx context.Context, token, id string) (Connector, error) {
+	res, err := es.auth.Identify(ctx, &mainflux.Token{Value: token})
+	if err != nil {
+		return Connector{}, errors.Wrap(errors.ErrAuthentication, err)
+	}
+
+	return es.ownedConnector(ctx, res.GetId(), id)
+}
+
+func (es *exporterService) ListConnectors(ctx context.Context, token string, pm PageMetadata) (ConnectorsPage, error) {
+	res, err := es.auth.Identify(ctx, &mainflux.Token{Value: token})
+	if err != nil {
+		return ConnectorsPage{}, errors.Wrap(errors.ErrAuthentication, err)
+	}
+
+	return es.connectors.RetrieveByOwner(ctx, res.GetId(), pm)
+}
+
+func (es *exporterService) UpdateConnector(ctx context.Context, token string, c Connector) error {
+	res, err := es.auth.Identify(ctx, &mainflux.Token{Value: token})
+	if err != nil {
+		return errors.Wrap(errors.ErrAuthentication, err)
+	}
+
+	if _, err := es.ownedConnector(ctx, res.GetId(), c.ID); err != nil {
+		return err
+	}
+
+	c.OwnerID = res.GetId()
+	c.UpdatedAt = getTimestamp()
+
+	return es.connectors.Update(ctx, c)
+}
+
+func (es *exporterService) RemoveConnector(ctx context.Context, token, id string) error {
+	res, err := es.auth.Identify(ctx, &mainflux.Token{Value: token})
+	if err != nil {
+		return errors.Wrap(errors.ErrAuthentication, err)
+	}
+
+	return es.connectors.Remove(ctx, res.GetId(), id)
+}
+
+func (es *exporterService) PauseConnector(ctx context.Context, token, id string) error {
+	return es.updateStatus(ctx, token, id, StatusPaused)
+}
+
+func (es *exporterService) ResumeConnector(ctx context.Context, token, id string) error {
+	return es.updateStatus(ctx, token, id, StatusActive)
+}
+
+// Consume exports the messages to the sinks of the active connectors of
+// their channel. A non-nil error is returned if the export to any of the
+// sinks fails, so that the messages are redelivered by the broker and
+// exported again. Hence, the messages are exported at least once.
+func (es *exporterService) Consume(messages interface{}) error {
+	msgs, err := Messages(messages)
+	if err != nil {
+		return err
+	}
+	if len(msgs) == 0 {
+		return nil
+	}
+
+	ctx := context.Background()
+	chanID := msgs[0].Channel
+	group, err := es.things.GetChannelGroup(ctx, &mainflux.ChannelID{Value: chanID})
+	if err != nil {
+		return err
+	}
+
+	conns, err := es.connectors.RetrieveActive(ctx, chanID, group.GetId())
+	if err != nil {
+		return err
+	}
+
+	// Connectors are exported independently, so that the failure of one
+	// of them doesn't prevent the export to the others.
+	var errs error
+	for _, c := range conns {
+		if err := es.export(ctx, c, msgs); err != nil && errs == nil {
+			errs = err
+		}
+	}
+
+	return errs
+}
+
+// export exports the messages to the sink of the connector and records the
+// export statistics.
+func (es *exporterService) export(ctx context.Context, c Connector, msgs []Message) error {
+	sink, ok := es.sinks[c.Type]
+	if !ok {
+		if err := es.connectors.RecordFailure(ctx, c.ID, ErrInvalidType.Error()); err != nil {
+			return err
+		}
+		return errors.Wrap(ErrExport, ErrInvalidType)
+	}
+
+	if err := sink.Export(ctx, c.Destination, msgs); err != nil {
+		if err := es.connectors.RecordFailure(ctx, c.ID, err.Error()); err != nil {
+			return err
+		}
+		return errors.Wrap(ErrExport, err)
+	}
+
+	now := time.Now().UTC()
+	return es.connectors.RecordExport(ctx, c.ID, uint64(len(msgs)), Lag(msgs, now), now)
+}
+
+// authorize checks that the user owns the channel or the group of the
+// connector.
+func (es *exporterService) authorize(ctx context.Context, owner string, c Connector) error {
+	if c.ChannelID != "" {
+		if _, err := es.things.IsChannelOwner(ctx, &mainflux.ChannelOwnerReq{Owner: owner, ChanID: c.ChannelID}); err != nil {
+			return errors.Wrap(errors.ErrAuthorization, err)
+		}
+		return nil
+	}
+
+	res, err := es.things.GetGroupsByIDs(ctx, &mainflux.GroupsReq{Ids: []string{c.GroupID}})
+	if err != nil {
+		return err
+	}
+	for _, gr := range res.GetGroups() {
+		if gr.GetId() == c.GroupID && gr.GetOwnerID() == owner {
+			return nil
+		}
+	}
+
+	return errors.ErrAuthorization
+}
+
+func (es *exporterService) updateStatus(ctx context.Context, token, id, status string) error {
+	res, err := es.auth.Identify(ctx, &mainflux.Token{Value: token})
+	if err != nil {
+		return errors.Wrap(errors.ErrAuthentication, err)
+	}
+
+	return es.connectors.UpdateStatus(ctx, res.GetId(), id, status)
+}
+
+func (es *exporterService) ownedConnector(ctx context.Context, owner, id string) (Connector, error) {
+	c, err := es.connectors.RetrieveByID(ctx, id)
+	if err != nil {
+		return Connector{}, err
+	}
+
+	if c.OwnerID != owner {
+		return Connector{}, errors.ErrNotFound
+	}
+
+	return c, nil
+}
+
+// Lag returns the time between the creation of the newest message and the
+// given time.
+func Lag(msgs []Message, now time.Time) time.Duration {
+	var newest time.Time
+	for _, msg := range msgs {
+		if msg.Created.After(newest) {
+			newest = msg.Created
+		}
+	}
+
+	if lag := now.Sub(newest); lag > 0 {
+		return lag
+	}
+
+	return 0
+}
+
+func getTimestamp() time.Time {
+	return time.Now().UTC().Round(time.Millisecond)
+}
@@ -0,0 +1,445 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package exporters_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/consumers/exporters"
+	expmocks "github.com/MainfluxLabs/mainflux/consumers/exporters/mocks"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/mocks"
+	"github.com/MainfluxLabs/mainflux/pkg/transformers/senml"
+	"github.com/MainfluxLabs/mainflux/pkg/uuid"
+	"github.com/MainfluxLabs/mainflux/things"
+	"github.com/MainfluxLabs/mainflux/users"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	userEmail      = "user@example.com"
+	otherUserEmail = "otherUser@example.com"
+	password       = "password"
+	channelID      = "channel-1"
+	groupID        = "group-1"
+	topic          = "telemetry"
+)
+
+var (
+	user      = users.User{ID: "user-1", Email: userEmail, Password: password}
+	otherUser = users.User{ID: "user-2", Email: otherUserEmail, Password: password}
+	usersList = []users.User{user, otherUser}
+	connector = exporters.Connector{
+		Name:        "telemetry",
+		ChannelID:   channelID,
+		Type:        exporters.TypeKafka,
+		Destination: topic,
+	}
+)
+
+func newService(repo exporters.ConnectorRepository, sink exporters.Sink) exporters.Service {
+	auth := mocks.NewAuthService("", usersList)
+	groups := map[string]things.Group{groupID: {ID: groupID, OwnerID: user.ID}}
+	tc := mocks.NewThingsServiceClient(map[string]string{user.ID: channelID}, groups)
+	sinks := map[string]exporters.Sink{exporters.TypeKafka: sink}
+
+	return exporters.New(auth, tc, repo, sinks, uuid.NewMock())
+}
+
+func newMessages(n int) []senml.Message {
+	var msgs []senml.Message
+	now := float64(time.Now().Unix())
+	for i := 0; i < n; i++ {
+		val := float64(i)
+		msgs = append(msgs, senml.Message{
+			Channel: channelID,
+			Name:    "temperature",
+			Time:    now,
+			Value:   &val,
+		})
+	}
+
+	return msgs
+}
+
+func TestCreateConnector(t *testing.T) {
+	svc := newService(expmocks.NewConnectorRepository(), expmocks.NewSink(nil))
+
+	cases := []struct {
+		desc      string
+		token     string
+		connector exporters.Connector
+		err       error
+	}{
+		{
+			desc:      "create channel connector",
+			token:     userEmail,
+			connector: connector,
+			err:       nil,
+		},
+		{
+			desc:      "create group connector",
+			token:     userEmail,
+			connector: exporters.Connector{GroupID: groupID, Type: exporters.TypeKafka, Destination: topic},
+			err:       nil,
+		},
+		{
+			desc:      "create connector of channel owned by other user",
+			token:     otherUserEmail,
+			connector: connector,
+			err:       errors.ErrAuthorization,
+		},
+		{
+			desc:      "create connector of group owned by other user",
+			token:     otherUserEmail,
+			connector: exporters.Connector{GroupID: groupID, Type: exporters.TypeKafka, Destination: topic},
+			err:       errors.ErrAuthorization,
+		},
+		{
+			desc:      "create connector of unsupported type",
+			token:     userEmail,
+			connector: exporters.Connector{ChannelID: channelID, Type: exporters.TypeBigQuery, Destination: "dataset.table"},
+			err:       exporters.ErrInvalidType,
+		},
+		{
+			desc:      "create connector with invalid token",
+			token:     "invalid",
+			connector: connector,
+			err:       errors.ErrAuthentication,
+		},
+	}
+
+	for _, tc := range cases {
+		c, err := svc.CreateConnector(context.Background(), tc.token, tc.connector)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected error %s got %s\n", tc.desc, tc.err, err))
+		if tc.err != nil {
+			continue
+		}
+		assert.NotEmpty(t, c.ID, fmt.Sprintf("%s: expected connector ID to be set\n", tc.desc))
+		assert.Equal(t, user.ID, c.OwnerID, fmt.Sprintf("%s: expected owner %s got %s\n", tc.desc, user.ID, c.OwnerID))
+		assert.Equal(t, exporters.StatusActive, c.Status, fmt.Sprintf("%s: expected status %s got %s\n", tc.desc, exporters.StatusActive, c.Status))
+	}
+}
+
+func TestViewConnector(t *testing.T) {
+	svc := newService(expmocks.NewConnectorRepository(), expmocks.NewSink(nil))
+	saved, err := svc.CreateConnector(context.Background(), userEmail, connector)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc  string
+		token string
+		id    string
+		err   error
+	}{
+		{
+			desc:  "view connector",
+			token: userEmail,
+			id:    saved.ID,
+			err:   nil,
+		},
+		{
+			desc:  "view connector of other user",
+			token: otherUserEmail,
+			id:    saved.ID,
+			err:   errors.ErrNotFound,
+		},
+		{
+			desc:  "view non-existing connector",
+			token: userEmail,
+			id:    "non-existing",
+			err:   errors.ErrNotFound,
+		},
+		{
+			desc:  "view connector with invalid token",
+			token: "invalid",
+			id:    saved.ID,
+			err:   errors.ErrAuthentication,
+		},
+	}
+
+	for _, tc := range cases {
+		c, err := svc.ViewConnector(context.Background(), tc.token, tc.id)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected error %s got %s\n", tc.desc, tc.err, err))
+		if tc.err == nil {
+			assert.Equal(t, saved, c, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, saved, c))
+		}
+	}
+}
+
+func TestListConnectors(t *testing.T) {
+	svc := newService(expmocks.NewConnectorRepository(), expmocks.NewSink(nil))
+	for i := 0; i < 3; i++ {
+		_, err := svc.CreateConnector(context.Background(), userEmail, connector)
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	}
+
+	cases := []struct {
+		desc  string
+		token string
+		pm    exporters.PageMetadata
+		size  int
+		total uint64
+		err   error
+	}{
+		{
+			desc:  "list connectors",
+			token: userEmail,
+			pm:    exporters.PageMetadata{Limit: 10},
+			size:  3,
+			total: 3,
+			err:   nil,
+		},
+		{
+			desc:  "list connectors with offset",
+			token: userEmail,
+			pm:    exporters.PageMetadata{Offset: 2, Limit: 10},
+			size:  1,
+			total: 3,
+			err:   nil,
+		},
+		{
+			desc:  "list connectors of other user",
+			token: otherUserEmail,
+			pm:    exporters.PageMetadata{Limit: 10},
+			size:  0,
+			total: 0,
+			err:   nil,
+		},
+		{
+			desc:  "list connectors with invalid token",
+			token: "invalid",
+			pm:    exporters.PageMetadata{Limit: 10},
+			err:   errors.ErrAuthentication,
+		},
+	}
+
+	for _, tc := range cases {
+		page, err := svc.ListConnectors(context.Background(), tc.token, tc.pm)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected error %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.size, len(page.Connectors), fmt.Sprintf("%s: expected %d connectors got %d\n", tc.desc, tc.size, len(page.Connectors)))
+		assert.Equal(t, tc.total, page.Total, fmt.Sprintf("%s: expected total %d got %d\n", tc.desc, tc.total, page.Total))
+	}
+}
+
+func TestUpdateConnector(t *testing.T) {
+	svc := newService(expmocks.NewConnectorRepository(), expmocks.NewSink(nil))
+	saved, err := svc.CreateConnector(context.Background(), userEmail, connector)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	updated := exporters.Connector{ID: saved.ID, Name: "updated", Destination: "updated"}
+
+	cases := []struct {
+		desc      string
+		token     string
+		connector exporters.Connector
+		err       error
+	}{
+		{
+			desc:      "update connector",
+			token:     userEmail,
+			connector: updated,
+			err:       nil,
+		},
+		{
+			desc:      "update connector of other user",
+			token:     otherUserEmail,
+			connector: updated,
+			err:       errors.ErrNotFound,
+		},
+		{
+			desc:      "update non-existing connector",
+			token:     userEmail,
+			connector: exporters.Connector{ID: "non-existing", Destination: topic},
+			err:       errors.ErrNotFound,
+		},
+		{
+			desc:      "update connector with invalid token",
+			token:     "invalid",
+			connector: updated,
+			err:       errors.ErrAuthentication,
+		},
+	}
+
+	for _, tc := range cases {
+		err := svc.UpdateConnector(context.Background(), tc.token, tc.connector)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected error %s got %s\n", tc.desc, tc.err, err))
+	}
+
+	c, err := svc.ViewConnector(context.Background(), userEmail, saved.ID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.Equal(t, updated.Destination, c.Destination, fmt.Sprintf("expected destination %s got %s\n", updated.Destination, c.Destination))
+	assert.Equal(t, saved.ChannelID, c.ChannelID, fmt.Sprintf("expected channel %s got %s\n", saved.ChannelID, c.ChannelID))
+}
+
+func TestRemoveConnector(t *testing.T) {
+	svc := newService(expmocks.NewConnectorRepository(), expmocks.NewSink(nil))
+	saved, err := svc.CreateConnector(context.Background(), userEmail, connector)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc  string
+		token string
+		id    string
+		err   error
+	}{
+		{
+			desc:  "remove connector of other user",
+			token: otherUserEmail,
+			id:    saved.ID,
+			err:   errors.ErrNotFound,
+		},
+		{
+			desc:  "remove connector with invalid token",
+			token: "invalid",
+			id:    saved.ID,
+			err:   errors.ErrAuthentication,
+		},
+		{
+			desc:  "remove connector",
+			token: userEmail,
+			id:    saved.ID,
+			err:   nil,
+		},
+		{
+			desc:  "remove removed connector",
+			token: userEmail,
+			id:    saved.ID,
+			err:   errors.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		err := svc.RemoveConnector(context.Background(), tc.token, tc.id)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected error %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestPauseResumeConnector(t *testing.T) {
+	sink := expmocks.NewSink(nil)
+	svc := newService(expmocks.NewConnectorRepository(), sink)
+	saved, err := svc.CreateConnector(context.Background(), userEmail, connector)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc     string
+		token    string
+		id       string
+		pause    bool
+		status   string
+		exported int
+		err      error
+	}{
+		{
+			desc:     "pause connector",
+			token:    userEmail,
+			id:       saved.ID,
+			pause:    true,
+			status:   exporters.StatusPaused,
+			exported: 0,
+			err:      nil,
+		},
+		{
+			desc:     "pause connector of other user",
+			token:    otherUserEmail,
+			id:       saved.ID,
+			pause:    true,
+			status:   exporters.StatusPaused,
+			exported: 0,
+			err:      errors.ErrNotFound,
+		},
+		{
+			desc:     "resume connector",
+			token:    userEmail,
+			id:       saved.ID,
+			status:   exporters.StatusActive,
+			exported: 2,
+			err:      nil,
+		},
+		{
+			desc:     "resume connector with invalid token",
+			token:    "invalid",
+			id:       saved.ID,
+			status:   exporters.StatusActive,
+			exported: 4,
+			err:      errors.ErrAuthentication,
+		},
+	}
+
+	for _, tc := range cases {
+		var err error
+		switch tc.pause {
+		case true:
+			err = svc.PauseConnector(context.Background(), tc.token, tc.id)
+		default:
+			err = svc.ResumeConnector(context.Background(), tc.token, tc.id)
+		}
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected error %s got %s\n", tc.desc, tc.err, err))
+
+		c, err := svc.ViewConnector(context.Background(), userEmail, saved.ID)
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+		assert.Equal(t, tc.status, c.Status, fmt.Sprintf("%s: expected status %s got %s\n", tc.desc, tc.status, c.Status))
+
+		err = svc.Consume(newMessages(2))
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+		assert.Equal(t, tc.exported, len(sink.Messages[topic]), fmt.Sprintf("%s: expected %d exported messages got %d\n", tc.desc, tc.exported, len(sink.Messages[topic])))
+	}
+}
+
+func TestConsume(t *testing.T) {
+	sinkErr := errors.New("sink unavailable")
+
+	cases := []struct {
+		desc     string
+		sink     *expmocks.Sink
+		msgs     interface{}
+		exported uint64
+		failed   uint64
+		err      error
+	}{
+		{
+			desc:     "consume messages",
+			sink:     expmocks.NewSink(nil),
+			msgs:     newMessages(3),
+			exported: 3,
+			failed:   0,
+			err:      nil,
+		},
+		{
+			desc:     "consume messages with failing sink",
+			sink:     expmocks.NewSink(sinkErr),
+			msgs:     newMessages(3),
+			exported: 0,
+			failed:   1,
+			err:      exporters.ErrExport,
+		},
+		{
+			desc:     "consume messages of unsupported type",
+			sink:     expmocks.NewSink(nil),
+			msgs:     "invalid",
+			exported: 0,
+			failed:   0,
+			err:      exporters.ErrMessage,
+		},
+	}
+
+	for _, tc := range cases {
+		svc := newService(expmocks.NewConnectorRepository(), tc.sink)
+		saved, err := svc.CreateConnector(context.Background(), userEmail, connector)
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+
+		err = svc.Consume(tc.msgs)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected error %s got %s\n", tc.desc, tc.err, err))
+
+		c, err := svc.ViewConnector(context.Background(), userEmail, saved.ID)
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+		assert.Equal(t, tc.exported, c.Stats.Exported, fmt.Sprintf("%s: expected %d exported messages got %d\n", tc.desc, tc.exported, c.Stats.Exported))
+		assert.Equal(t, tc.failed, c.Stats.Failed, fmt.Sprintf("%s: expected %d failed exports got %d\n", tc.desc, tc.failed, c.Stats.Failed))
+		assert.Equal(t, int(tc.exported), len(tc.sink.Messages[topic]), fmt.Sprintf("%s: expected %d messages in sink got %d\n", tc.desc, tc.exported, len(tc.sink.Messages[topic])))
+	}
+}
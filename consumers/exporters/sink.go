@@ -0,0 +1,69 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package exporters
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	mfjson "github.com/MainfluxLabs/mainflux/pkg/transformers/json"
+	"github.com/MainfluxLabs/mainflux/pkg/transformers/senml"
+)
+
+// Message represents the exported message.
+type Message struct {
+	Channel string
+	Created time.Time
+	// Data is the JSON encoded message.
+	Data json.RawMessage
+}
+
+// Sink specifies an API for exporting the messages to the external system.
+type Sink interface {
+	// Export delivers the messages to the destination, such as the Kafka
+	// topic, S3 key prefix or BigQuery table. Messages are either delivered
+	// as a whole, or a non-nil error is returned.
+	Export(ctx context.Context, destination string, msgs []Message) error
+}
+
+// Messages converts the consumed SenML or JSON messages to the exported
+// messages.
+func Messages(messages interface{}) ([]Message, error) {
+	var msgs []Message
+	switch m := messages.(type) {
+	case []senml.Message:
+		for _, msg := range m {
+			data, err := json.Marshal(msg)
+			if err != nil {
+				return nil, err
+			}
+			msgs = append(msgs, Message{
+				Channel: msg.Channel,
+				Created: fromSeconds(msg.Time),
+				Data:    data,
+			})
+		}
+	case mfjson.Messages:
+		for _, msg := range m.Data {
+			data, err := json.Marshal(msg)
+			if err != nil {
+				return nil, err
+			}
+			msgs = append(msgs, Message{
+				Channel: msg.Channel,
+				Created: time.Unix(0, msg.Created),
+				Data:    data,
+			})
+		}
+	default:
+		return nil, ErrMessage
+	}
+
+	return msgs, nil
+}
+
+func fromSeconds(t float64) time.Time {
+	return time.Unix(0, int64(t*float64(time.Second)))
+}
@@ -0,0 +1,48 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package consumers
+
+import (
+	"github.com/MainfluxLabs/mainflux/pkg/transformers"
+	"github.com/MainfluxLabs/mainflux/pkg/transformers/senml"
+)
+
+// filterConfig lists the names of the SenML records which are stored or
+// dropped before consuming. Filters set in the consumer configuration apply
+// to the messages of all channels, and the filter set in the channel
+// profile to the messages of the channel.
+type filterConfig struct {
+	Include []string `toml:"include" json:"include"`
+	Exclude []string `toml:"exclude" json:"exclude"`
+}
+
+func makeFilters(cfgs []filterConfig) []transformers.Transformation {
+	var fs []transformers.Transformation
+	for _, fc := range cfgs {
+		fs = append(fs, makeFilter(fc))
+	}
+
+	return fs
+}
+
+func makeFilter(cfg filterConfig) transformers.Transformation {
+	return senml.FilterRecords(cfg.Include, cfg.Exclude)
+}
+
+// apply applies the transformations to the messages. It returns false if
+// all the messages are dropped.
+func apply(ts []transformers.Transformation, msgs interface{}) (interface{}, bool, error) {
+	var err error
+	for _, t := range ts {
+		if msgs, err = t(msgs); err != nil {
+			return nil, false, err
+		}
+	}
+
+	if ms, ok := msgs.([]senml.Message); ok && len(ms) == 0 {
+		return nil, false, nil
+	}
+
+	return msgs, true, nil
+}
@@ -0,0 +1,216 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package consumers_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/MainfluxLabs/mainflux"
+	"github.com/MainfluxLabs/mainflux/consumers"
+	"github.com/MainfluxLabs/mainflux/logger"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+	"github.com/MainfluxLabs/mainflux/pkg/transformers/senml"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+var errNotFound = errors.New("channel not found")
+
+type thingsClient struct {
+	mainflux.ThingsServiceClient
+	metadata map[string]string
+}
+
+func (tc thingsClient) GetChannel(_ context.Context, req *mainflux.ChannelID, _ ...grpc.CallOption) (*mainflux.Channel, error) {
+	md, ok := tc.metadata[req.GetValue()]
+	if !ok {
+		return nil, errNotFound
+	}
+
+	return &mainflux.Channel{Id: req.GetValue(), Metadata: []byte(md)}, nil
+}
+
+type subscriber struct {
+	handler messaging.MessageHandler
+}
+
+func (s *subscriber) Subscribe(id, topic string, handler messaging.MessageHandler) error {
+	s.handler = handler
+	return nil
+}
+
+func (s *subscriber) Unsubscribe(id, topic string) error {
+	return nil
+}
+
+func (s *subscriber) Close() error {
+	return nil
+}
+
+type consumer struct {
+	consumed []interface{}
+}
+
+func (c *consumer) Consume(msgs interface{}) error {
+	c.consumed = append(c.consumed, msgs)
+	return nil
+}
+
+func TestStartFilters(t *testing.T) {
+	path := writeConfig(t, `
+[transformer]
+format = "senml"
+
+[[filters]]
+exclude = ["rssi"]
+`)
+
+	things := thingsClient{
+		metadata: map[string]string{
+			"chan-1": `{"profile": {"filter": {"include": ["temperature", "humidity"]}}}`,
+			"chan-2": `{"profile": {"filter": {"exclude": ["uptime"]}}}`,
+			"chan-3": `{"name": "sensors"}`,
+			"chan-4": `{"profile": {"filter": {"include": "temperature"}}}`,
+		},
+	}
+
+	sub := &subscriber{}
+	c := &consumer{}
+	err := consumers.Start("writer", sub, c, consumers.NewProfiles(things), path, logger.NewMock())
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	payload := []byte(`[{"n":"temperature","v":23},{"n":"humidity","v":40},{"n":"uptime","v":100},{"n":"rssi","v":-70}]`)
+
+	cases := []struct {
+		desc    string
+		channel string
+		payload []byte
+		names   []string
+		err     bool
+	}{
+		{
+			desc:    "filter messages of channel with include list",
+			channel: "chan-1",
+			payload: payload,
+			names:   []string{"temperature", "humidity"},
+		},
+		{
+			desc:    "filter messages of channel with exclude list",
+			channel: "chan-2",
+			payload: payload,
+			names:   []string{"temperature", "humidity"},
+		},
+		{
+			desc:    "filter messages of channel without filter",
+			channel: "chan-3",
+			payload: payload,
+			names:   []string{"temperature", "humidity", "uptime"},
+		},
+		{
+			desc:    "drop all messages",
+			channel: "chan-1",
+			payload: []byte(`[{"n":"rssi","v":-70}]`),
+			names:   nil,
+		},
+		{
+			desc:    "filter messages of channel with malformed filter",
+			channel: "chan-4",
+			payload: payload,
+			err:     true,
+		},
+		{
+			desc:    "filter messages of non-existing channel",
+			channel: "chan-5",
+			payload: payload,
+			err:     true,
+		},
+	}
+
+	for _, tc := range cases {
+		c.consumed = nil
+		err := sub.handler.Handle(messaging.Message{Channel: tc.channel, Payload: tc.payload})
+		assert.Equal(t, tc.err, err != nil, fmt.Sprintf("%s: expected error %t got %s", tc.desc, tc.err, err))
+
+		var names []string
+		for _, msgs := range c.consumed {
+			for _, m := range msgs.([]senml.Message) {
+				names = append(names, m.Name)
+			}
+		}
+		assert.Equal(t, tc.names, names, fmt.Sprintf("%s: expected %v got %v", tc.desc, tc.names, names))
+	}
+}
+
+func TestStartTransformations(t *testing.T) {
+	path := writeConfig(t, `
+[transformer]
+format = "auto"
+
+[[transformer.units]]
+from = "mbar"
+to = "hPa"
+scale = 1.0
+`)
+
+	things := thingsClient{
+		metadata: map[string]string{
+			"chan-1": `{"profile": {"transformations": [{"type": "unit_normalization", "units": ["Cel", "hPa"]}]}}`,
+			"chan-2": `{"profile": {"transformations": [{"type": "rename", "fields": {"temp": "temperature"}}]}}`,
+			"chan-3": `{"profile": {"transformations": [{"type": "unknown"}]}}`,
+		},
+	}
+
+	sub := &subscriber{}
+	c := &consumer{}
+	err := consumers.Start("writer", sub, c, consumers.NewProfiles(things), path, logger.NewMock())
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	payload := []byte(`[{"n":"temp","u":"K","v":300},{"n":"pressure","u":"mbar","v":1000}]`)
+
+	cases := []struct {
+		desc    string
+		channel string
+		names   []string
+		units   []string
+		err     bool
+	}{
+		{
+			desc:    "normalize units of channel messages",
+			channel: "chan-1",
+			names:   []string{"temp", "pressure"},
+			units:   []string{"Cel", "hPa"},
+		},
+		{
+			desc:    "rename records of channel messages",
+			channel: "chan-2",
+			names:   []string{"temperature", "pressure"},
+			units:   []string{"K", "mbar"},
+		},
+		{
+			desc:    "transform messages of channel with unknown transformation",
+			channel: "chan-3",
+			err:     true,
+		},
+	}
+
+	for _, tc := range cases {
+		c.consumed = nil
+		err := sub.handler.Handle(messaging.Message{Channel: tc.channel, Payload: payload})
+		assert.Equal(t, tc.err, err != nil, fmt.Sprintf("%s: expected error %t got %s", tc.desc, tc.err, err))
+
+		var names, units []string
+		for _, msgs := range c.consumed {
+			for _, m := range msgs.([]senml.Message) {
+				names = append(names, m.Name)
+				units = append(units, m.Unit)
+			}
+		}
+		assert.Equal(t, tc.names, names, fmt.Sprintf("%s: expected names %v got %v", tc.desc, tc.names, names))
+		assert.Equal(t, tc.units, units, fmt.Sprintf("%s: expected units %v got %v", tc.desc, tc.units, units))
+	}
+}
@@ -4,9 +4,11 @@
 package consumers
 
 import (
+	"context"
 	"fmt"
 	"io/ioutil"
 	"os"
+	"strconv"
 	"strings"
 
 	"github.com/pelletier/go-toml"
@@ -14,15 +16,22 @@ import (
 	"github.com/MainfluxLabs/mainflux/logger"
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
 	"github.com/MainfluxLabs/mainflux/pkg/messaging"
-	"github.com/MainfluxLabs/mainflux/pkg/messaging/brokers"
 	"github.com/MainfluxLabs/mainflux/pkg/transformers"
 	"github.com/MainfluxLabs/mainflux/pkg/transformers/json"
+	"github.com/MainfluxLabs/mainflux/pkg/transformers/protobuf"
 	"github.com/MainfluxLabs/mainflux/pkg/transformers/senml"
 )
 
 const (
 	defContentType = "application/senml+json"
 	defFormat      = "senml"
+
+	unitConversion    = "unit_conversion"
+	unitNormalization = "unit_normalization"
+	rename            = "rename"
+	flatten           = "flatten"
+	timeField         = "time_field"
+	senmlMapping      = "senml"
 )
 
 var (
@@ -32,25 +41,42 @@ var (
 
 // Start method starts consuming messages received from Message broker.
 // This method transforms messages to SenML format before
-// using MessageRepository to store them.
-func Start(id string, sub messaging.Subscriber, consumer Consumer, configPath string, logger logger.Logger) error {
+// using MessageRepository to store them. The transformations, filters and
+// protobuf schemas set in the channel profiles are applied to the messages
+// of their channels, unless profiles are nil.
+func Start(id string, sub messaging.Subscriber, consumer Consumer, profiles *Profiles, configPath string, logger logger.Logger) error {
 	cfg, err := loadConfig(configPath)
 	if err != nil {
 		logger.Warn(fmt.Sprintf("Failed to load consumer config: %s", err))
 	}
 
-	transformer := makeTransformer(cfg.TransformerCfg, logger)
+	units := makeUnits(cfg.TransformerCfg.Units)
+	transformer := makeTransformer(cfg.TransformerCfg, units, profiles, logger)
+	filters := makeFilters(cfg.FilterCfgs)
+
+	subjects, err := cfg.SubscriberCfg.subjects()
+	if err != nil {
+		return err
+	}
 
-	for _, subject := range cfg.SubscriberCfg.Subjects {
-		if err := sub.Subscribe(id, subject, handle(transformer, consumer)); err != nil {
+	for _, subject := range subjects {
+		if err := sub.Subscribe(id, subject, handle(transformer, filters, profiles, units, consumer)); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
-func handle(t transformers.Transformer, c Consumer) handleFunc {
+func handle(t transformers.Transformer, fs []transformers.Transformation, ps *Profiles, units senml.UnitRegistry, c Consumer) handleFunc {
 	return func(msg messaging.Message) error {
+		var p profile
+		if ps != nil {
+			var err error
+			if p, err = ps.retrieve(context.Background(), msg.Channel); err != nil {
+				return err
+			}
+		}
+
 		m := interface{}(msg)
 		var err error
 		if t != nil {
@@ -59,6 +85,21 @@ func handle(t transformers.Transformer, c Consumer) handleFunc {
 				return err
 			}
 		}
+
+		ts, err := makeTransformations(p.transformations, units)
+		if err != nil {
+			return errors.Wrap(errMalformedProfile, err)
+		}
+		ts = append(ts, fs...)
+		if p.filter != nil {
+			ts = append(ts, makeFilter(*p.filter))
+		}
+
+		m, ok, err := apply(ts, m)
+		if err != nil || !ok {
+			return err
+		}
+
 		return c.Consume(m)
 	}
 }
@@ -74,26 +115,45 @@ func (h handleFunc) Cancel() error {
 	return nil
 }
 
-type subscriberConfig struct {
-	Subjects []string `toml:"subjects"`
+type transformerConfig struct {
+	Format          string                 `toml:"format"`
+	ContentType     string                 `toml:"content_type"`
+	TimeFields      []json.TimeField       `toml:"time_fields"`
+	ProtobufFields  map[string]string      `toml:"protobuf_fields"`
+	Units           []unitConfig           `toml:"units"`
+	Transformations []transformationConfig `toml:"transformations"`
 }
 
-type transformerConfig struct {
-	Format      string           `toml:"format"`
-	ContentType string           `toml:"content_type"`
-	TimeFields  []json.TimeField `toml:"time_fields"`
+type unitConfig struct {
+	From   string  `toml:"from"`
+	To     string  `toml:"to"`
+	Scale  float64 `toml:"scale"`
+	Offset float64 `toml:"offset"`
+}
+
+// transformationConfig describes the transformation applied to the messages
+// of all channels, if set in the consumer configuration, or to the messages
+// of the channel, if set in the channel profile.
+type transformationConfig struct {
+	Type      string            `toml:"type" json:"type"`
+	From      string            `toml:"from" json:"from"`
+	To        string            `toml:"to" json:"to"`
+	Scale     float64           `toml:"scale" json:"scale"`
+	Offset    float64           `toml:"offset" json:"offset"`
+	Fields    map[string]string `toml:"fields" json:"fields"`
+	Units     []string          `toml:"units" json:"units"`
+	TimeField json.TimeField    `toml:"time_field" json:"time_field"`
 }
 
 type config struct {
 	SubscriberCfg  subscriberConfig  `toml:"subscriber"`
 	TransformerCfg transformerConfig `toml:"transformer"`
+	RetentionCfg   retentionConfig   `toml:"retention"`
+	FilterCfgs     []filterConfig    `toml:"filters"`
 }
 
 func loadConfig(configPath string) (config, error) {
 	cfg := config{
-		SubscriberCfg: subscriberConfig{
-			Subjects: []string{brokers.SubjectAllChannels},
-		},
 		TransformerCfg: transformerConfig{
 			Format:      defFormat,
 			ContentType: defContentType,
@@ -112,7 +172,7 @@ func loadConfig(configPath string) (config, error) {
 	return cfg, nil
 }
 
-func makeTransformer(cfg transformerConfig, logger logger.Logger) transformers.Transformer {
+func makeTransformer(cfg transformerConfig, units senml.UnitRegistry, profiles *Profiles, logger logger.Logger) transformers.Transformer {
 	switch strings.ToUpper(cfg.Format) {
 	case "SENML":
 		logger.Info("Using SenML transformer")
@@ -120,9 +180,93 @@ func makeTransformer(cfg transformerConfig, logger logger.Logger) transformers.T
 	case "JSON":
 		logger.Info("Using JSON transformer")
 		return json.New(cfg.TimeFields)
+	case "AUTO":
+		logger.Info("Using content type aware transformers pipeline")
+		return makePipeline(cfg, units, profiles, logger)
 	default:
 		logger.Error(fmt.Sprintf("Can't create transformer: unknown transformer type %s", cfg.Format))
 		os.Exit(1)
 		return nil
 	}
 }
+
+func makePipeline(cfg transformerConfig, units senml.UnitRegistry, profiles *Profiles, logger logger.Logger) transformers.Transformer {
+	fields := make(map[int32]string)
+	for num, name := range cfg.ProtobufFields {
+		fields[fieldNumber(num, logger)] = name
+	}
+
+	var schemas protobuf.SchemaResolver
+	if profiles != nil {
+		schemas = profiles.schema
+	}
+
+	p := transformers.NewPipeline(nil)
+	p.Register(transformers.SenMLJSON, senml.New(senml.JSON))
+	p.Register(transformers.SenMLCBOR, senml.New(senml.CBOR))
+	p.Register(transformers.JSON, json.New(cfg.TimeFields))
+	p.Register(transformers.CBOR, json.NewCBOR(cfg.TimeFields))
+	p.Register(transformers.Protobuf, protobuf.New(fields, schemas, cfg.TimeFields))
+
+	ts, err := makeTransformations(cfg.Transformations, units)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Can't create transformer: %s", err))
+		os.Exit(1)
+	}
+	p.AddTransformations(transformers.AllChannels, ts...)
+
+	return p
+}
+
+// makeUnits returns the unit registry extended with the configured
+// conversions.
+func makeUnits(cfgs []unitConfig) senml.UnitRegistry {
+	units := senml.NewUnitRegistry()
+	for _, u := range cfgs {
+		units.Register(senml.UnitConversion{From: u.From, To: u.To, Scale: scaleOrDefault(u.Scale), Offset: u.Offset})
+	}
+
+	return units
+}
+
+func makeTransformations(cfgs []transformationConfig, units senml.UnitRegistry) ([]transformers.Transformation, error) {
+	var ts []transformers.Transformation
+	for _, tc := range cfgs {
+		switch tc.Type {
+		case unitConversion:
+			ts = append(ts, senml.ConvertUnit(tc.From, tc.To, scaleOrDefault(tc.Scale), tc.Offset))
+		case unitNormalization:
+			ts = append(ts, senml.NormalizeUnits(units, tc.Units...))
+		case rename:
+			ts = append(ts, senml.RenameRecords(tc.Fields), json.RenameFields(tc.Fields))
+		case flatten:
+			ts = append(ts, json.FlattenPayload())
+		case timeField:
+			ts = append(ts, json.ExtractTime(tc.TimeField))
+		case senmlMapping:
+			ts = append(ts, json.ToSenML(tc.Fields))
+		default:
+			return nil, fmt.Errorf("unknown transformation type %s", tc.Type)
+		}
+	}
+
+	return ts, nil
+}
+
+// fieldNumber parses the protobuf field number used as the configuration key.
+func fieldNumber(num string, logger logger.Logger) int32 {
+	n, err := strconv.ParseInt(num, 10, 32)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Can't create transformer: invalid protobuf field number %s", num))
+		os.Exit(1)
+	}
+	return int32(n)
+}
+
+// scaleOrDefault returns the identity scale if the scale is not set.
+func scaleOrDefault(scale float64) float64 {
+	if scale == 0 {
+		return 1
+	}
+	return scale
+}
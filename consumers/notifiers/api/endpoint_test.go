@@ -29,6 +29,7 @@ import (
 )
 
 const (
+	requestID   = "8c5c3f7e-0a52-4a87-9d5e-3e1b6f9a2c41"
 	contentType = "application/json"
 	email       = "user@example.com"
 	contact1    = "email1@example.com"
@@ -40,10 +41,10 @@ const (
 )
 
 var (
-	notFoundRes   = toJSON(apiutil.ErrorRes{Err: errors.ErrNotFound.Error()})
-	unauthRes     = toJSON(apiutil.ErrorRes{Err: errors.ErrAuthentication.Error()})
-	invalidRes    = toJSON(apiutil.ErrorRes{Err: apiutil.ErrInvalidQueryParams.Error()})
-	missingTokRes = toJSON(apiutil.ErrorRes{Err: apiutil.ErrBearerToken.Error()})
+	notFoundRes   = toErrorRes(errors.ErrNotFound)
+	unauthRes     = toErrorRes(errors.ErrAuthentication)
+	invalidRes    = toErrorRes(apiutil.ErrInvalidQueryParams)
+	missingTokRes = toErrorRes(apiutil.ErrBearerToken)
 	usersList     = []users.User{{Email: email, Password: password}}
 )
 
@@ -67,6 +68,7 @@ func (tr testRequest) make() (*http.Response, error) {
 	if tr.contentType != "" {
 		req.Header.Set("Content-Type", tr.contentType)
 	}
+	req.Header.Set(apiutil.RequestIDHeader, requestID)
 	return tr.client.Do(req)
 }
 
@@ -90,6 +92,10 @@ func toJSON(data interface{}) string {
 	return string(jsonData)
 }
 
+func toErrorRes(err errors.Error, details ...string) string {
+	return toJSON(apiutil.ErrorRes{Err: err.Msg(), Code: apiutil.ErrorCode(err), Message: err.Msg(), Details: details, TraceID: requestID})
+}
+
 func TestCreate(t *testing.T) {
 	svc := newService()
 	ss := newServer(svc)
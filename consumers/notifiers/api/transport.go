@@ -32,7 +32,7 @@ const (
 )
 
 // MakeHandler returns a HTTP handler for API endpoints.
-func MakeHandler(svc notifiers.Service, tracer opentracing.Tracer, logger logger.Logger) http.Handler {
+func MakeHandler(svc notifiers.Service, tracer opentracing.Tracer, logger logger.Logger, checks ...mainflux.HealthCheck) http.Handler {
 	opts := []kithttp.ServerOption{
 		kithttp.ServerErrorEncoder(apiutil.LoggingErrorEncoder(logger, encodeError)),
 	}
@@ -67,10 +67,11 @@ func MakeHandler(svc notifiers.Service, tracer opentracing.Tracer, logger logger
 		opts...,
 	))
 
-	mux.GetFunc("/health", mainflux.Health("notifier"))
+	mux.GetFunc("/health", mainflux.Health("notifier", checks...))
 	mux.Handle("/metrics", promhttp.Handler())
+	mux.Handle("/log-level", mainflux.LogLevel(logger))
 
-	return mux
+	return apiutil.RequestIDMiddleware(apiutil.LocaleMiddleware(mux))
 }
 
 func decodeCreate(_ context.Context, r *http.Request) (interface{}, error) {
@@ -138,7 +139,7 @@ func encodeResponse(_ context.Context, w http.ResponseWriter, response interface
 	return json.NewEncoder(w).Encode(response)
 }
 
-func encodeError(_ context.Context, err error, w http.ResponseWriter) {
+func encodeError(ctx context.Context, err error, w http.ResponseWriter) {
 	switch {
 	case errors.Contains(err, apiutil.ErrMalformedEntity),
 		err == apiutil.ErrInvalidContact,
@@ -167,7 +168,7 @@ func encodeError(_ context.Context, err error, w http.ResponseWriter) {
 
 	if errorVal, ok := err.(errors.Error); ok {
 		w.Header().Set("Content-Type", contentType)
-		if err := json.NewEncoder(w).Encode(apiutil.ErrorRes{Err: errorVal.Msg()}); err != nil {
+		if err := json.NewEncoder(w).Encode(apiutil.NewErrorRes(ctx, errorVal)); err != nil {
 			w.WriteHeader(http.StatusInternalServerError)
 		}
 	}
@@ -4,17 +4,12 @@
 package smtp
 
 import (
-	"fmt"
-
 	notifiers "github.com/MainfluxLabs/mainflux/consumers/notifiers"
 	"github.com/MainfluxLabs/mainflux/internal/email"
 	"github.com/MainfluxLabs/mainflux/pkg/messaging"
 )
 
-const (
-	footer          = "Sent by Mainflux SMTP Notification"
-	contentTemplate = "A publisher with an id %s sent the message over %s with the following values \n %s"
-)
+const notificationTemplate = "notification"
 
 var _ notifiers.Notifier = (*notifier)(nil)
 
@@ -28,13 +23,18 @@ func New(agent *email.Agent) notifiers.Notifier {
 }
 
 func (n *notifier) Notify(from string, to []string, msg messaging.Message) error {
-	subject := fmt.Sprintf(`Notification for Channel %s`, msg.Channel)
-	if msg.Subtopic != "" {
-		subject = fmt.Sprintf("%s and subtopic %s", subject, msg.Subtopic)
+	m := email.Message{
+		To:       to,
+		From:     from,
+		Template: notificationTemplate,
+		Data: map[string]interface{}{
+			"Channel":   msg.Channel,
+			"Subtopic":  msg.Subtopic,
+			"Publisher": msg.Publisher,
+			"Protocol":  msg.Protocol,
+			"Payload":   string(msg.Payload),
+		},
 	}
 
-	values := string(msg.Payload)
-	content := fmt.Sprintf(contentTemplate, msg.Publisher, msg.Protocol, values)
-
-	return n.agent.Send(to, from, subject, "", content, footer)
+	return n.agent.Send(m)
 }
@@ -0,0 +1,170 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package consumers
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/transformers/protobuf"
+)
+
+const (
+	// profileKey is the channel metadata key holding the channel profile.
+	profileKey = "profile"
+
+	// profileTTL is the time the retrieved channel profiles are cached for.
+	profileTTL = time.Minute
+)
+
+var errMalformedProfile = errors.New("malformed channel profile")
+
+// channelProfile contains the consumer settings of the channel profile.
+type channelProfile struct {
+	Retention       string                    `json:"retention"`
+	Filter          *filterConfig             `json:"filter"`
+	Transformations []transformationConfig    `json:"transformations"`
+	ProtobufSchema  map[string]protobuf.Field `json:"protobuf_schema"`
+}
+
+// profile contains the parsed consumer settings of the channel profile.
+type profile struct {
+	retention       time.Duration
+	filter          *filterConfig
+	transformations []transformationConfig
+	schema          protobuf.Schema
+}
+
+type profileEntry struct {
+	profile profile
+	expires time.Time
+}
+
+// Profiles retrieves the consumer settings, i.e. the retention TTL, the
+// SenML records filter, the transformations and the protobuf schema, from
+// the profiles of the channels of the consumed messages. Profiles are
+// cached for a minute, and the expired ones are used as a fallback while
+// the things service is unavailable.
+type Profiles struct {
+	things   mainflux.ThingsServiceClient
+	mu       sync.Mutex
+	profiles map[string]profileEntry
+}
+
+// NewProfiles returns the channel profiles retrieved using the things
+// service client.
+func NewProfiles(things mainflux.ThingsServiceClient) *Profiles {
+	return &Profiles{
+		things:   things,
+		profiles: make(map[string]profileEntry),
+	}
+}
+
+// retrieve returns the consumer settings of the channel profile.
+func (ps *Profiles) retrieve(ctx context.Context, chanID string) (profile, error) {
+	ps.mu.Lock()
+	e, ok := ps.profiles[chanID]
+	ps.mu.Unlock()
+	if ok && time.Now().Before(e.expires) {
+		return e.profile, nil
+	}
+
+	ch, err := ps.things.GetChannel(ctx, &mainflux.ChannelID{Value: chanID})
+	if err != nil {
+		if ok {
+			return e.profile, nil
+		}
+		return profile{}, err
+	}
+
+	p, err := parseProfile(ch.GetMetadata())
+	if err != nil {
+		return profile{}, err
+	}
+
+	ps.mu.Lock()
+	ps.profiles[chanID] = profileEntry{profile: p, expires: time.Now().Add(profileTTL)}
+	ps.mu.Unlock()
+
+	return p, nil
+}
+
+// schema returns the protobuf schema of the channel profile, or nil if the
+// profile can't be retrieved.
+func (ps *Profiles) schema(chanID string) protobuf.Schema {
+	p, err := ps.retrieve(context.Background(), chanID)
+	if err != nil {
+		return nil
+	}
+	return p.schema
+}
+
+// retentions returns the retention TTLs of the retrieved channel profiles
+// mapped by the channel ID.
+func (ps *Profiles) retentions() map[string]time.Duration {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+
+	ttls := make(map[string]time.Duration)
+	for chanID, e := range ps.profiles {
+		if e.profile.retention > 0 {
+			ttls[chanID] = e.profile.retention
+		}
+	}
+
+	return ttls
+}
+
+func parseProfile(metadata []byte) (profile, error) {
+	var m map[string]json.RawMessage
+	if len(metadata) > 0 {
+		if err := json.Unmarshal(metadata, &m); err != nil {
+			return profile{}, errors.Wrap(errMalformedProfile, err)
+		}
+	}
+
+	data, ok := m[profileKey]
+	if !ok {
+		return profile{}, nil
+	}
+
+	var cp channelProfile
+	if err := json.Unmarshal(data, &cp); err != nil {
+		return profile{}, errors.Wrap(errMalformedProfile, err)
+	}
+
+	p := profile{
+		filter:          cp.Filter,
+		transformations: cp.Transformations,
+	}
+
+	if cp.Retention != "" {
+		ttl, err := time.ParseDuration(cp.Retention)
+		if err != nil || ttl <= 0 {
+			return profile{}, errors.Wrap(errMalformedProfile, errInvalidRetention)
+		}
+		p.retention = ttl
+	}
+
+	if len(cp.ProtobufSchema) > 0 {
+		p.schema = make(protobuf.Schema)
+		for num, f := range cp.ProtobufSchema {
+			n, err := strconv.ParseInt(num, 10, 32)
+			if err != nil {
+				return profile{}, errors.Wrap(errMalformedProfile, err)
+			}
+			p.schema[int32(n)] = f
+		}
+		if err := p.schema.Validate(); err != nil {
+			return profile{}, errors.Wrap(errMalformedProfile, err)
+		}
+	}
+
+	return p, nil
+}
@@ -0,0 +1,111 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package consumers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/logger"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+)
+
+const defPurgeInterval = time.Hour
+
+var errInvalidRetention = errors.New("invalid retention configuration")
+
+// Purger specifies stored messages removal API.
+type Purger interface {
+	// Channels returns the IDs of the channels with stored messages.
+	Channels(ctx context.Context) ([]string, error)
+
+	// Purge removes the messages of the channel created before the
+	// given time and returns the number of removed messages.
+	Purge(ctx context.Context, chanID string, before time.Time) (uint64, error)
+}
+
+type retentionConfig struct {
+	Interval string `toml:"interval"`
+}
+
+// StartPurger periodically removes the messages of the channels whose
+// profile sets the retention TTL, until the context is canceled. Messages
+// expire once they are older than the TTL of their channel. All the
+// channels with stored messages are purged, and their profiles are
+// retrieved from the things service.
+func StartPurger(ctx context.Context, purger Purger, profiles *Profiles, configPath string, logger logger.Logger) error {
+	cfg, err := loadConfig(configPath)
+	if err != nil {
+		logger.Warn(fmt.Sprintf("Failed to load consumer config: %s", err))
+	}
+
+	interval, err := parseRetention(cfg.RetentionCfg)
+	if err != nil {
+		return err
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		purge(ctx, purger, retentions(ctx, purger, profiles, logger), logger)
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// retentions returns the retention TTLs of the channels with stored
+// messages mapped by the channel ID.
+func retentions(ctx context.Context, purger Purger, profiles *Profiles, logger logger.Logger) map[string]time.Duration {
+	chanIDs, err := purger.Channels(ctx)
+	if err != nil {
+		logger.Warn(fmt.Sprintf("Failed to retrieve channels with stored messages: %s", err))
+		// Channels whose messages were consumed are still purged.
+		return profiles.retentions()
+	}
+
+	ttls := make(map[string]time.Duration)
+	for _, chanID := range chanIDs {
+		p, err := profiles.retrieve(ctx, chanID)
+		if err != nil {
+			logger.Warn(fmt.Sprintf("Failed to retrieve retention of channel %s: %s", chanID, err))
+			continue
+		}
+		if p.retention > 0 {
+			ttls[chanID] = p.retention
+		}
+	}
+
+	return ttls
+}
+
+func purge(ctx context.Context, purger Purger, ttls map[string]time.Duration, logger logger.Logger) {
+	now := time.Now()
+	for chanID, ttl := range ttls {
+		n, err := purger.Purge(ctx, chanID, now.Add(-ttl))
+		if err != nil {
+			logger.Warn(fmt.Sprintf("Failed to purge expired messages of channel %s: %s", chanID, err))
+			continue
+		}
+		logger.Info(fmt.Sprintf("Purged %d expired messages of channel %s", n, chanID))
+	}
+}
+
+func parseRetention(cfg retentionConfig) (time.Duration, error) {
+	if cfg.Interval == "" {
+		return defPurgeInterval, nil
+	}
+
+	interval, err := time.ParseDuration(cfg.Interval)
+	if err != nil || interval <= 0 {
+		return 0, errors.Wrap(errInvalidRetention, fmt.Errorf("invalid purge interval %q", cfg.Interval))
+	}
+
+	return interval, nil
+}
@@ -0,0 +1,139 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package consumers_test
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/consumers"
+	"github.com/MainfluxLabs/mainflux/logger"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type purger struct {
+	mu       sync.Mutex
+	channels []string
+	before   map[string]time.Time
+	done     chan struct{}
+}
+
+func (p *purger) Channels(ctx context.Context) ([]string, error) {
+	return p.channels, nil
+}
+
+func (p *purger) Purge(ctx context.Context, chanID string, before time.Time) (uint64, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.before[chanID] = before
+	if len(p.before) == 2 {
+		close(p.done)
+	}
+
+	return 1, nil
+}
+
+func writeConfig(t *testing.T, cfg string) string {
+	dir, err := ioutil.TempDir("", "consumers")
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	t.Cleanup(func() { os.RemoveAll(dir) })
+
+	path := filepath.Join(dir, "config.toml")
+	err = ioutil.WriteFile(path, []byte(cfg), 0644)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	return path
+}
+
+func TestStartPurger(t *testing.T) {
+	path := writeConfig(t, `
+[transformer]
+format = "senml"
+
+[retention]
+interval = "1h"
+`)
+
+	things := thingsClient{
+		metadata: map[string]string{
+			"chan-1": `{"profile": {"retention": "1h"}}`,
+			"chan-2": `{"profile": {"retention": "24h"}}`,
+			"chan-3": `{"profile": {"mirror_to": "chan-1"}}`,
+		},
+	}
+	profiles := consumers.NewProfiles(things)
+
+	// Channels with stored messages are purged even if none of their
+	// messages were consumed since the start.
+	p := &purger{
+		channels: []string{"chan-1", "chan-2", "chan-3"},
+		before:   make(map[string]time.Time),
+		done:     make(chan struct{}),
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	errs := make(chan error)
+	start := time.Now()
+	go func() {
+		errs <- consumers.StartPurger(ctx, p, profiles, path, logger.NewMock())
+	}()
+
+	select {
+	case <-p.done:
+	case <-time.After(time.Second):
+		t.Fatal("expected expired messages to be purged")
+	}
+	cancel()
+	assert.Nil(t, <-errs, "expected purger to stop without errors")
+
+	cases := map[string]time.Duration{
+		"chan-1": time.Hour,
+		"chan-2": 24 * time.Hour,
+	}
+	assert.Len(t, p.before, len(cases), "expected only the channels with retention to be purged")
+	for chanID, ttl := range cases {
+		before := p.before[chanID]
+		assert.False(t, before.Before(start.Add(-ttl)), fmt.Sprintf("%s: expected messages older than %s to be purged", chanID, ttl))
+		assert.False(t, before.After(time.Now().Add(-ttl)), fmt.Sprintf("%s: expected messages newer than %s to be kept", chanID, ttl))
+	}
+}
+
+func TestStartPurgerInvalidConfig(t *testing.T) {
+	path := writeConfig(t, `
+[retention]
+interval = "forever"
+`)
+
+	p := &purger{before: make(map[string]time.Time), done: make(chan struct{})}
+	err := consumers.StartPurger(context.Background(), p, consumers.NewProfiles(thingsClient{}), path, logger.NewMock())
+	assert.NotNil(t, err, "expected error for invalid purge interval")
+}
+
+func TestRetrieveInvalidRetention(t *testing.T) {
+	path := writeConfig(t, `
+[transformer]
+format = "senml"
+`)
+
+	things := thingsClient{
+		metadata: map[string]string{"chan-1": `{"profile": {"retention": "forever"}}`},
+	}
+
+	sub := &subscriber{}
+	c := &consumer{}
+	err := consumers.Start("writer", sub, c, consumers.NewProfiles(things), path, logger.NewMock())
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	err = sub.handler.Handle(messaging.Message{Channel: "chan-1", Payload: []byte(`[{"n":"temperature","v":23}]`)})
+	assert.NotNil(t, err, "expected error for invalid retention TTL")
+	assert.Empty(t, c.consumed, "expected message not to be consumed")
+}
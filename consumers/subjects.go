@@ -0,0 +1,85 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package consumers
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging/brokers"
+)
+
+const (
+	channelsPrefix = "channels"
+	anyToken       = "*"
+	allTokens      = ">"
+)
+
+var errInvalidSubtopic = errors.New("invalid subscriber subtopic")
+
+// subscriberConfig lists the message broker subjects the consumer subscribes
+// to. The subjects of the listed channels and subtopics are subscribed to
+// along with the listed subjects.
+type subscriberConfig struct {
+	Subjects  []string `toml:"subjects"`
+	Channels  []string `toml:"channels"`
+	Subtopics []string `toml:"subtopics"`
+}
+
+// subjects returns the message broker subjects to subscribe to. If neither
+// subjects nor channels and subtopics are set, the messages of all channels
+// are subscribed to.
+func (sc subscriberConfig) subjects() ([]string, error) {
+	subjects := append([]string{}, sc.Subjects...)
+	if len(sc.Channels) == 0 && len(sc.Subtopics) == 0 {
+		if len(subjects) == 0 {
+			subjects = append(subjects, brokers.SubjectAllChannels)
+		}
+		return subjects, nil
+	}
+
+	for _, st := range sc.Subtopics {
+		if err := validateSubtopic(st); err != nil {
+			return nil, err
+		}
+	}
+
+	channels := sc.Channels
+	if len(channels) == 0 {
+		channels = []string{anyToken}
+	}
+
+	for _, ch := range channels {
+		subject := fmt.Sprintf("%s.%s", channelsPrefix, ch)
+		if len(sc.Subtopics) == 0 {
+			// The messages without the subtopic are published to the channel
+			// subject, which isn't matched by the subtopic wildcard.
+			subjects = append(subjects, subject, subject+"."+allTokens)
+			continue
+		}
+		for _, st := range sc.Subtopics {
+			subjects = append(subjects, subject+"."+st)
+		}
+	}
+
+	return subjects, nil
+}
+
+// validateSubtopic checks that the subtopic consists of non-empty tokens, that
+// the wildcards are whole tokens and that the multi-token wildcard is only used
+// as its last token.
+func validateSubtopic(subtopic string) error {
+	tokens := strings.Split(subtopic, ".")
+	for i, t := range tokens {
+		switch {
+		case t == "",
+			t == allTokens && i != len(tokens)-1,
+			t != anyToken && t != allTokens && strings.ContainsAny(t, anyToken+allTokens):
+			return errors.Wrap(errInvalidSubtopic, errors.New(subtopic))
+		}
+	}
+
+	return nil
+}
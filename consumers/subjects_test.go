@@ -0,0 +1,114 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package consumers_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/MainfluxLabs/mainflux/consumers"
+	"github.com/MainfluxLabs/mainflux/logger"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+	"github.com/stretchr/testify/assert"
+)
+
+type topicsSubscriber struct {
+	topics []string
+}
+
+func (s *topicsSubscriber) Subscribe(id, topic string, handler messaging.MessageHandler) error {
+	s.topics = append(s.topics, topic)
+	return nil
+}
+
+func (s *topicsSubscriber) Unsubscribe(id, topic string) error {
+	return nil
+}
+
+func (s *topicsSubscriber) Close() error {
+	return nil
+}
+
+func TestStartSubjects(t *testing.T) {
+	cases := []struct {
+		desc   string
+		cfg    string
+		topics []string
+		err    bool
+	}{
+		{
+			desc:   "subscribe to all channels by default",
+			cfg:    ``,
+			topics: []string{"channels.>"},
+		},
+		{
+			desc: "subscribe to listed subjects",
+			cfg: `
+[subscriber]
+subjects = ["channels.*.telemetry.>"]
+`,
+			topics: []string{"channels.*.telemetry.>"},
+		},
+		{
+			desc: "subscribe to subtopics of all channels",
+			cfg: `
+[subscriber]
+subtopics = ["telemetry.>", "status"]
+`,
+			topics: []string{"channels.*.telemetry.>", "channels.*.status"},
+		},
+		{
+			desc: "subscribe to listed channels",
+			cfg: `
+[subscriber]
+channels = ["chan-1", "chan-2"]
+`,
+			topics: []string{"channels.chan-1", "channels.chan-1.>", "channels.chan-2", "channels.chan-2.>"},
+		},
+		{
+			desc: "subscribe to subtopics of listed channels along with subjects",
+			cfg: `
+[subscriber]
+subjects = ["replay.channels.>"]
+channels = ["chan-1"]
+subtopics = ["telemetry.*.temperature"]
+`,
+			topics: []string{"replay.channels.>", "channels.chan-1.telemetry.*.temperature"},
+		},
+		{
+			desc: "subscribe to subtopic with wildcard before last token",
+			cfg: `
+[subscriber]
+subtopics = ["telemetry.>.temperature"]
+`,
+			err: true,
+		},
+		{
+			desc: "subscribe to subtopic with empty token",
+			cfg: `
+[subscriber]
+subtopics = ["telemetry..temperature"]
+`,
+			err: true,
+		},
+		{
+			desc: "subscribe to subtopic with wildcard within token",
+			cfg: `
+[subscriber]
+subtopics = ["telemetry*"]
+`,
+			err: true,
+		},
+	}
+
+	for _, tc := range cases {
+		sub := &topicsSubscriber{}
+		err := consumers.Start("writer", sub, &consumer{}, nil, writeConfig(t, tc.cfg), logger.NewMock())
+		assert.Equal(t, tc.err, err != nil, fmt.Sprintf("%s: expected error %t got %s", tc.desc, tc.err, err))
+		if tc.err {
+			continue
+		}
+		assert.Equal(t, tc.topics, sub.topics, fmt.Sprintf("%s: expected topics %v got %v", tc.desc, tc.topics, sub.topics))
+	}
+}
@@ -0,0 +1,49 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+
+	"github.com/MainfluxLabs/mainflux/consumers"
+	"github.com/MainfluxLabs/mainflux/pkg/encryption"
+	mfjson "github.com/MainfluxLabs/mainflux/pkg/transformers/json"
+)
+
+var _ consumers.Consumer = (*encryptionMiddleware)(nil)
+
+type encryptionMiddleware struct {
+	keyring  encryption.Keyring
+	consumer consumers.Consumer
+}
+
+// EncryptionMiddleware encrypts JSON message payloads with the per-channel
+// data keys before they are stored. SenML messages are stored unencrypted,
+// since their values are used for filtering and aggregation.
+func EncryptionMiddleware(consumer consumers.Consumer, keyring encryption.Keyring) consumers.Consumer {
+	return &encryptionMiddleware{
+		keyring:  keyring,
+		consumer: consumer,
+	}
+}
+
+func (em *encryptionMiddleware) Consume(msgs interface{}) error {
+	m, ok := msgs.(mfjson.Messages)
+	if !ok {
+		return em.consumer.Consume(msgs)
+	}
+
+	data := make([]mfjson.Message, len(m.Data))
+	for i, msg := range m.Data {
+		payload, err := encryption.EncryptPayload(context.Background(), em.keyring, msg.Channel, msg.Payload)
+		if err != nil {
+			return err
+		}
+		msg.Payload = payload
+		data[i] = msg
+	}
+	m.Data = data
+
+	return em.consumer.Consume(m)
+}
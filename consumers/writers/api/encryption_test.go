@@ -0,0 +1,61 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/MainfluxLabs/mainflux/consumers/writers/api"
+	"github.com/MainfluxLabs/mainflux/pkg/encryption"
+	"github.com/MainfluxLabs/mainflux/pkg/encryption/mocks"
+	mfjson "github.com/MainfluxLabs/mainflux/pkg/transformers/json"
+	"github.com/MainfluxLabs/mainflux/pkg/transformers/senml"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const chanID = "50e6b371-60ff-45cf-bb52-8200e7cde536"
+
+type consumer struct {
+	msgs interface{}
+}
+
+func (c *consumer) Consume(msgs interface{}) error {
+	c.msgs = msgs
+	return nil
+}
+
+func TestEncryptionMiddleware(t *testing.T) {
+	kms, err := encryption.NewLocalKMS([]byte("01234567890123456789012345678901"))
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	kr := encryption.NewKeyring(kms, mocks.NewKeyRepository())
+
+	next := &consumer{}
+	c := api.EncryptionMiddleware(next, kr)
+
+	payload := mfjson.Payload{"temperature": 21.5}
+	msgs := mfjson.Messages{
+		Data:   []mfjson.Message{{Channel: chanID, Payload: payload}},
+		Format: "messages",
+	}
+	err = c.Consume(msgs)
+	require.Nil(t, err, fmt.Sprintf("consume JSON messages: unexpected error: %s", err))
+
+	consumed, ok := next.msgs.(mfjson.Messages)
+	require.True(t, ok, "consume JSON messages: expected JSON messages")
+	require.Len(t, consumed.Data, 1, "consume JSON messages: expected single message")
+	assert.NotContains(t, consumed.Data[0].Payload, "temperature", "consume JSON messages: expected encrypted payload")
+	assert.Equal(t, payload, msgs.Data[0].Payload, "consume JSON messages: expected original message to be unchanged")
+
+	dec, err := encryption.DecryptPayload(context.Background(), kr, chanID, consumed.Data[0].Payload)
+	assert.Nil(t, err, fmt.Sprintf("decrypt consumed payload: unexpected error: %s", err))
+	assert.Equal(t, map[string]interface{}(payload), dec, fmt.Sprintf("decrypt consumed payload: expected %v got %v", payload, dec))
+
+	senmlMsgs := []senml.Message{{Channel: chanID, Name: "temperature"}}
+	err = c.Consume(senmlMsgs)
+	assert.Nil(t, err, fmt.Sprintf("consume SenML messages: unexpected error: %s", err))
+	assert.Equal(t, senmlMsgs, next.msgs, "consume SenML messages: expected unchanged messages")
+}
@@ -30,12 +30,13 @@ func LoggingMiddleware(consumer consumers.Consumer, logger log.Logger) consumers
 
 func (lm *loggingMiddleware) Consume(msgs interface{}) (err error) {
 	defer func(begin time.Time) {
+		l := lm.logger.With("method", "consume", "latency", time.Since(begin).String())
 		message := fmt.Sprintf("Method consume took %s to complete", time.Since(begin))
 		if err != nil {
-			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
 			return
 		}
-		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+		l.Info(fmt.Sprintf("%s without errors.", message))
 	}(time.Now())
 
 	return lm.consumer.Consume(msgs)
@@ -6,13 +6,17 @@
 package api
 
 import (
+	"context"
 	"time"
 
 	"github.com/go-kit/kit/metrics"
 	"github.com/MainfluxLabs/mainflux/consumers"
 )
 
-var _ consumers.Consumer = (*metricsMiddleware)(nil)
+var (
+	_ consumers.Consumer = (*metricsMiddleware)(nil)
+	_ consumers.Purger   = (*purgerMetricsMiddleware)(nil)
+)
 
 type metricsMiddleware struct {
 	counter  metrics.Counter
@@ -37,3 +41,38 @@ func (mm *metricsMiddleware) Consume(msgs interface{}) error {
 	}(time.Now())
 	return mm.consumer.Consume(msgs)
 }
+
+type purgerMetricsMiddleware struct {
+	counter metrics.Counter
+	latency metrics.Histogram
+	purged  metrics.Counter
+	purger  consumers.Purger
+}
+
+// PurgerMetricsMiddleware returns new messages purger with Purge method
+// wrapped to expose metrics, including the number of purged messages.
+func PurgerMetricsMiddleware(purger consumers.Purger, counter metrics.Counter, latency metrics.Histogram, purged metrics.Counter) consumers.Purger {
+	return &purgerMetricsMiddleware{
+		counter: counter,
+		latency: latency,
+		purged:  purged,
+		purger:  purger,
+	}
+}
+
+func (mm *purgerMetricsMiddleware) Channels(ctx context.Context) ([]string, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "channels").Add(1)
+		mm.latency.With("method", "channels").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return mm.purger.Channels(ctx)
+}
+
+func (mm *purgerMetricsMiddleware) Purge(ctx context.Context, chanID string, before time.Time) (n uint64, err error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "purge").Add(1)
+		mm.latency.With("method", "purge").Observe(time.Since(begin).Seconds())
+		mm.purged.With("channel", chanID).Add(float64(n))
+	}(time.Now())
+	return mm.purger.Purge(ctx, chanID, before)
+}
@@ -12,9 +12,9 @@ import (
 )
 
 // MakeHandler returns a HTTP API handler with health check and metrics.
-func MakeHandler(svcName string) http.Handler {
+func MakeHandler(svcName string, checks ...mainflux.HealthCheck) http.Handler {
 	r := bone.New()
-	r.GetFunc("/health", mainflux.Health(svcName))
+	r.GetFunc("/health", mainflux.Health(svcName, checks...))
 	r.Handle("/metrics", promhttp.Handler())
 
 	return r
@@ -0,0 +1,137 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package archiver
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/consumers"
+	"github.com/MainfluxLabs/mainflux/logger"
+	"github.com/MainfluxLabs/mainflux/pkg/archive"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/transformers/senml"
+)
+
+var errUnsupportedMessages = errors.New("only SenML messages can be archived")
+
+// Config defines the batching of archived messages.
+type Config struct {
+	// Prefix is the key prefix of archive objects.
+	Prefix string
+	// BatchSize is the number of buffered messages which triggers the upload.
+	BatchSize int
+}
+
+// Archiver batches SenML messages and uploads the batches to the object storage.
+type Archiver interface {
+	consumers.Consumer
+
+	// Flush uploads all buffered messages. Messages which failed to be
+	// uploaded are kept in the buffer and uploaded by the next flush.
+	Flush(ctx context.Context) error
+}
+
+var _ Archiver = (*archiver)(nil)
+
+type archiver struct {
+	mu      sync.Mutex
+	storage archive.Storage
+	cfg     Config
+	batches map[archive.Partition][]senml.Message
+	size    int
+}
+
+// New returns new archiver of SenML messages.
+func New(storage archive.Storage, cfg Config) Archiver {
+	return &archiver{
+		storage: storage,
+		cfg:     cfg,
+		batches: make(map[archive.Partition][]senml.Message),
+	}
+}
+
+func (a *archiver) Consume(messages interface{}) error {
+	msgs, ok := messages.([]senml.Message)
+	if !ok {
+		return errors.Wrap(errors.ErrSaveMessage, errUnsupportedMessages)
+	}
+
+	a.mu.Lock()
+	for _, msg := range msgs {
+		p := archive.PartitionOf(msg)
+		a.batches[p] = append(a.batches[p], msg)
+	}
+	a.size += len(msgs)
+	full := a.size >= a.cfg.BatchSize
+	a.mu.Unlock()
+
+	if full {
+		return a.Flush(context.Background())
+	}
+
+	return nil
+}
+
+func (a *archiver) Flush(ctx context.Context) error {
+	a.mu.Lock()
+	batches := a.batches
+	a.batches = make(map[archive.Partition][]senml.Message)
+	a.size = 0
+	a.mu.Unlock()
+
+	var err error
+	now := time.Now()
+	for p, msgs := range batches {
+		if e := a.upload(ctx, p, msgs, now); e != nil {
+			a.restore(p, msgs)
+			err = e
+		}
+	}
+	if err != nil {
+		return errors.Wrap(errors.ErrSaveMessage, err)
+	}
+
+	return nil
+}
+
+func (a *archiver) upload(ctx context.Context, p archive.Partition, msgs []senml.Message, now time.Time) error {
+	data, err := archive.Encode(msgs)
+	if err != nil {
+		return err
+	}
+
+	return a.storage.Put(ctx, p.Key(a.cfg.Prefix, now), data)
+}
+
+func (a *archiver) restore(p archive.Partition, msgs []senml.Message) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.batches[p] = append(msgs, a.batches[p]...)
+	a.size += len(msgs)
+}
+
+// StartFlusher flushes the archiver periodically, until the context is
+// canceled. Buffered messages are flushed once more before returning.
+func StartFlusher(ctx context.Context, a Archiver, interval time.Duration, logger logger.Logger) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			if err := a.Flush(context.Background()); err != nil {
+				logger.Warn(fmt.Sprintf("Failed to flush archived messages: %s", err))
+			}
+			return nil
+		case <-ticker.C:
+			if err := a.Flush(ctx); err != nil {
+				logger.Warn(fmt.Sprintf("Failed to flush archived messages: %s", err))
+			}
+		}
+	}
+}
@@ -0,0 +1,104 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package archiver_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/MainfluxLabs/mainflux/consumers/writers/archiver"
+	"github.com/MainfluxLabs/mainflux/pkg/archive"
+	"github.com/MainfluxLabs/mainflux/pkg/archive/mocks"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/transformers/json"
+	"github.com/MainfluxLabs/mainflux/pkg/transformers/senml"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	prefix    = "messages/"
+	batchSize = 10
+	chanID    = "50e6b371-60ff-45cf-bb52-8200e7cde536"
+	// day is 2022-01-01 UTC.
+	day = 1640995200
+)
+
+func TestConsume(t *testing.T) {
+	storage := mocks.NewStorage()
+	a := archiver.New(storage, archiver.Config{Prefix: prefix, BatchSize: batchSize})
+
+	v := 21.0
+	var msgs []senml.Message
+	for i := 0; i < batchSize-1; i++ {
+		msgs = append(msgs, senml.Message{Channel: chanID, Name: "temperature", Value: &v, Time: float64(day + i*3600)})
+	}
+
+	cases := []struct {
+		desc    string
+		msgs    interface{}
+		objects int
+		err     error
+	}{
+		{
+			desc:    "consume messages smaller than batch",
+			msgs:    msgs,
+			objects: 0,
+			err:     nil,
+		},
+		{
+			desc:    "consume messages filling the batch",
+			msgs:    []senml.Message{{Channel: chanID, Name: "temperature", Value: &v, Time: day + 86400}},
+			objects: 2,
+			err:     nil,
+		},
+		{
+			desc:    "consume JSON messages",
+			msgs:    json.Messages{},
+			objects: 2,
+			err:     errors.ErrSaveMessage,
+		},
+	}
+
+	for _, tc := range cases {
+		err := a.Consume(tc.msgs)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		keys, err := storage.List(context.Background(), prefix)
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+		assert.Equal(t, tc.objects, len(keys), fmt.Sprintf("%s: expected %d objects got %d\n", tc.desc, tc.objects, len(keys)))
+	}
+}
+
+func TestFlush(t *testing.T) {
+	storage := mocks.NewStorage()
+	a := archiver.New(storage, archiver.Config{Prefix: prefix, BatchSize: batchSize})
+
+	v := 21.0
+	msgs := []senml.Message{
+		{Channel: chanID, Name: "temperature", Value: &v, Time: day},
+		{Channel: chanID, Name: "temperature", Value: &v, Time: day + 60},
+	}
+	err := a.Consume(msgs)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	err = a.Flush(context.Background())
+	assert.Nil(t, err, fmt.Sprintf("flush messages: expected no error got %s", err))
+
+	keys, err := storage.List(context.Background(), fmt.Sprintf("%s%s/2022-01-01/", prefix, chanID))
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	require.Equal(t, 1, len(keys), fmt.Sprintf("expected 1 object got %d", len(keys)))
+
+	data, err := storage.Get(context.Background(), keys[0])
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	archived, err := archive.Decode(data)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.Equal(t, msgs, archived, fmt.Sprintf("expected %v got %v", msgs, archived))
+
+	err = a.Flush(context.Background())
+	assert.Nil(t, err, fmt.Sprintf("flush empty buffer: expected no error got %s", err))
+	keys, err = storage.List(context.Background(), prefix)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.Equal(t, 1, len(keys), fmt.Sprintf("expected 1 object got %d", len(keys)))
+}
@@ -0,0 +1,5 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package archiver contains the archiver of SenML messages to the object storage.
+package archiver
@@ -0,0 +1,128 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package broker
+
+import (
+	"fmt"
+
+	"github.com/MainfluxLabs/mainflux/logger"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging/brokers"
+	mfnats "github.com/MainfluxLabs/mainflux/pkg/messaging/nats"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging/partition"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging/profile"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging/workers"
+	kitprometheus "github.com/go-kit/kit/metrics/prometheus"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+)
+
+// Config represents the configuration of the writer's subscription to the
+// message broker.
+type Config struct {
+	URL          string
+	JetStream    *brokers.JetStreamConfig
+	Workers      *workers.Config
+	Partitioning *partition.Config
+}
+
+// LoadConfig reads the JetStream, workers and partitioning configuration
+// of the subscription to the message broker at the URL from the environment.
+func LoadConfig(url string) (Config, error) {
+	jetStream, err := mfnats.LoadJetStreamConfig()
+	if err != nil {
+		return Config{}, fmt.Errorf("failed to load JetStream config: %s", err)
+	}
+
+	pool, err := workers.LoadConfig()
+	if err != nil {
+		return Config{}, fmt.Errorf("failed to load workers config: %s", err)
+	}
+
+	partitioning, err := partition.LoadConfig()
+	if err != nil {
+		return Config{}, fmt.Errorf("failed to load partitioning config: %s", err)
+	}
+
+	return Config{
+		URL:          url,
+		JetStream:    jetStream,
+		Workers:      pool,
+		Partitioning: partitioning,
+	}, nil
+}
+
+// Connect returns the pubsub of the writer. Messages are received using
+// JetStream if it's enabled, divided among the writer replicas if the
+// partitioning is enabled, handled by the workers if the pool is configured
+// and skipped unless their profile sets the persist flag. Metrics of the
+// subscription are exposed under the namespace of the writer.
+func Connect(namespace string, cfg Config, logger logger.Logger) (messaging.PubSub, error) {
+	// Replicas of the partitioning group share the subscriptions, so each
+	// message is received by a single replica.
+	var queue string
+	if cfg.Partitioning != nil {
+		queue = cfg.Partitioning.Group
+	}
+
+	var pubSub messaging.PubSub
+	var err error
+	switch {
+	case cfg.JetStream != nil:
+		pubSub, err = brokers.NewJetStreamPubSub(cfg.URL, queue, *cfg.JetStream, logger)
+	default:
+		pubSub, err = brokers.NewPubSub(cfg.URL, queue, logger)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Partitioning forwards the messages before they are queued for the
+	// workers, so the workers handle only the messages of owned channels.
+	if cfg.Partitioning != nil {
+		p := partition.NewPartitioner(cfg.Partitioning.Member, cfg.Partitioning.Handoff)
+		membership, err := brokers.NewMembership(cfg.URL, *cfg.Partitioning, p, logger)
+		if err != nil {
+			pubSub.Close()
+			return nil, err
+		}
+		pubSub = partition.NewPubSub(pubSub, p, membership, kitprometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "message_writer",
+			Name:      "subscriber_foreign_messages",
+			Help:      "Number of received messages forwarded to the replica owning their channel.",
+		}, []string{}), logger)
+	}
+
+	if cfg.Workers != nil {
+		pubSub = workers.NewPubSub(pubSub, *cfg.Workers, workers.Metrics{
+			Queued: kitprometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+				Namespace: namespace,
+				Subsystem: "message_writer",
+				Name:      "subscriber_queued_messages",
+				Help:      "Number of received messages waiting for a worker.",
+			}, []string{"topic"}),
+			Blocked: kitprometheus.NewCounterFrom(stdprometheus.CounterOpts{
+				Namespace: namespace,
+				Subsystem: "message_writer",
+				Name:      "subscriber_blocked_count",
+				Help:      "Number of messages received while the worker queue was full.",
+			}, []string{"topic"}),
+			Wait: kitprometheus.NewSummaryFrom(stdprometheus.SummaryOpts{
+				Namespace: namespace,
+				Subsystem: "message_writer",
+				Name:      "subscriber_queue_wait_seconds",
+				Help:      "Time the messages received while the worker queue was full waited for the queue space.",
+			}, []string{"topic"}),
+		}, logger)
+	}
+
+	pubSub = profile.NewPubSub(pubSub, messaging.PersistFlag, kitprometheus.NewCounterFrom(stdprometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "message_writer",
+		Name:      "subscriber_skipped_messages",
+		Help:      "Number of received messages skipped because their profile flag is not set.",
+	}, []string{"flag"}))
+
+	return pubSub, nil
+}
@@ -0,0 +1,6 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package broker contains the message broker subscription shared by the
+// writer services.
+package broker
@@ -28,5 +28,9 @@ func senmlFields(msg senml.Message) fields {
 		ret["sum"] = *msg.Sum
 	}
 
+	if msg.Seq != 0 {
+		ret["seq"] = msg.Seq
+	}
+
 	return ret
 }
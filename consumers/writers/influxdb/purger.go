@@ -0,0 +1,61 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package influxdb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/consumers"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	influxdb2 "github.com/influxdata/influxdb-client-go/v2"
+)
+
+var errPurge = errors.New("failed to purge messages")
+
+var _ consumers.Purger = (*influxRepo)(nil)
+
+// NewPurger returns new InfluxDB purger of expired messages.
+func NewPurger(client influxdb2.Client, config RepoConfig) consumers.Purger {
+	return &influxRepo{
+		client: client,
+		cfg:    config,
+	}
+}
+
+// Channels returns the values of the channel tag of all measurements.
+func (repo *influxRepo) Channels(ctx context.Context) ([]string, error) {
+	q := fmt.Sprintf(`import "influxdata/influxdb/schema"
+schema.tagValues(bucket: "%s", tag: "channel", start: 0)`, repo.cfg.Bucket)
+
+	resp, err := repo.client.QueryAPI(repo.cfg.Org).Query(ctx, q)
+	if err != nil {
+		return nil, errors.Wrap(errPurge, err)
+	}
+	defer resp.Close()
+
+	var chanIDs []string
+	for resp.Next() {
+		if chanID, ok := resp.Record().Value().(string); ok {
+			chanIDs = append(chanIDs, chanID)
+		}
+	}
+	if err := resp.Err(); err != nil {
+		return nil, errors.Wrap(errPurge, err)
+	}
+
+	return chanIDs, nil
+}
+
+// Purge deletes the points of the channel from all measurements. Since
+// InfluxDB doesn't report the number of deleted points, zero is returned.
+func (repo *influxRepo) Purge(ctx context.Context, chanID string, before time.Time) (uint64, error) {
+	predicate := fmt.Sprintf(`channel="%s"`, chanID)
+	if err := repo.client.DeleteAPI().DeleteWithName(ctx, repo.cfg.Org, repo.cfg.Bucket, time.Unix(0, 0), before, predicate); err != nil {
+		return 0, errors.Wrap(errPurge, err)
+	}
+
+	return 0, nil
+}
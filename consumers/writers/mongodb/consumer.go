@@ -6,6 +6,7 @@ package mongodb
 import (
 	"context"
 
+	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
 
 	"github.com/MainfluxLabs/mainflux/consumers"
@@ -43,12 +44,12 @@ func (repo *mongoRepo) saveSenml(messages interface{}) error {
 		return errors.ErrSaveMessage
 	}
 	coll := repo.db.Collection(senmlCollection)
-	var dbMsgs []interface{}
+	var models []mongo.WriteModel
 	for _, msg := range msgs {
-		dbMsgs = append(dbMsgs, msg)
+		models = append(models, writeModel(msg.ID, msg))
 	}
 
-	_, err := coll.InsertMany(context.Background(), dbMsgs)
+	_, err := coll.BulkWrite(context.Background(), models)
 	if err != nil {
 		return errors.Wrap(errors.ErrSaveMessage, err)
 	}
@@ -57,17 +58,31 @@ func (repo *mongoRepo) saveSenml(messages interface{}) error {
 }
 
 func (repo *mongoRepo) saveJSON(msgs json.Messages) error {
-	m := []interface{}{}
+	models := []mongo.WriteModel{}
 	for _, msg := range msgs.Data {
-		m = append(m, msg)
+		models = append(models, writeModel(msg.ID, msg))
 	}
 
 	coll := repo.db.Collection(msgs.Format)
 
-	_, err := coll.InsertMany(context.Background(), m)
+	_, err := coll.BulkWrite(context.Background(), models)
 	if err != nil {
 		return errors.Wrap(errors.ErrSaveMessage, err)
 	}
 
 	return nil
 }
+
+// writeModel returns the write of the message document. The message whose ID
+// is assigned is upserted by the ID, so that the redelivered message is
+// stored only once. Other messages are inserted.
+func writeModel(id string, doc interface{}) mongo.WriteModel {
+	if id == "" {
+		return mongo.NewInsertOneModel().SetDocument(doc)
+	}
+
+	return mongo.NewUpdateOneModel().
+		SetFilter(bson.M{"_id": id}).
+		SetUpdate(bson.M{"$setOnInsert": doc}).
+		SetUpsert(true)
+}
@@ -0,0 +1,28 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mongodb
+
+import (
+	"context"
+
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+var errCreateIndexes = errors.New("failed to create messages indexes")
+
+// CreateIndexes creates the index of SenML messages by channel and time,
+// which is used by the MongoDB reader to aggregate the messages. Creating
+// the index is a no-op if the index already exists.
+func CreateIndexes(ctx context.Context, db *mongo.Database) error {
+	index := mongo.IndexModel{
+		Keys: bson.D{{Key: "channel", Value: 1}, {Key: "time", Value: -1}},
+	}
+	if _, err := db.Collection(senmlCollection).Indexes().CreateOne(ctx, index); err != nil {
+		return errors.Wrap(errCreateIndexes, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,75 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mongodb
+
+import (
+	"context"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/consumers"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+var errPurge = errors.New("failed to purge messages")
+
+var _ consumers.Purger = (*mongoRepo)(nil)
+
+// NewPurger returns new MongoDB purger of expired messages.
+func NewPurger(db *mongo.Database) consumers.Purger {
+	return &mongoRepo{db}
+}
+
+func (repo *mongoRepo) Channels(ctx context.Context) ([]string, error) {
+	names, err := repo.db.ListCollectionNames(ctx, bson.D{})
+	if err != nil {
+		return nil, errors.Wrap(errPurge, err)
+	}
+
+	set := make(map[string]struct{})
+	for _, name := range names {
+		vals, err := repo.db.Collection(name).Distinct(ctx, "channel", bson.D{})
+		if err != nil {
+			return nil, errors.Wrap(errPurge, err)
+		}
+		for _, v := range vals {
+			if chanID, ok := v.(string); ok {
+				set[chanID] = struct{}{}
+			}
+		}
+	}
+
+	chanIDs := make([]string, 0, len(set))
+	for chanID := range set {
+		chanIDs = append(chanIDs, chanID)
+	}
+
+	return chanIDs, nil
+}
+
+func (repo *mongoRepo) Purge(ctx context.Context, chanID string, before time.Time) (uint64, error) {
+	// JSON messages are stored in the collections named by message format.
+	names, err := repo.db.ListCollectionNames(ctx, bson.D{})
+	if err != nil {
+		return 0, errors.Wrap(errPurge, err)
+	}
+
+	var total uint64
+	for _, name := range names {
+		// SenML messages time is in seconds, while JSON messages are created in nanoseconds.
+		filter := bson.M{"channel": chanID, "created": bson.M{"$lt": before.UnixNano()}}
+		if name == senmlCollection {
+			filter = bson.M{"channel": chanID, "time": bson.M{"$lt": float64(before.UnixNano()) / 1e9}}
+		}
+
+		res, err := repo.db.Collection(name).DeleteMany(ctx, filter)
+		if err != nil {
+			return total, errors.Wrap(errPurge, err)
+		}
+		total += uint64(res.DeletedCount)
+	}
+
+	return total, nil
+}
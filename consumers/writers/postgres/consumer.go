@@ -51,10 +51,11 @@ func (pr postgresRepo) saveSenml(messages interface{}) (err error) {
 	}
 	q := `INSERT INTO messages (id, channel, subtopic, publisher, protocol,
           name, unit, value, string_value, bool_value, data_value, sum,
-          time, update_time)
+          time, update_time, seq)
           VALUES (:id, :channel, :subtopic, :publisher, :protocol, :name, :unit,
           :value, :string_value, :bool_value, :data_value, :sum,
-          :time, :update_time);`
+          :time, :update_time, :seq)
+          ON CONFLICT DO NOTHING;`
 
 	tx, err := pr.db.BeginTxx(context.Background(), nil)
 	if err != nil {
@@ -74,11 +75,11 @@ func (pr postgresRepo) saveSenml(messages interface{}) (err error) {
 	}()
 
 	for _, msg := range msgs {
-		id, err := uuid.NewV4()
+		id, err := messageID(msg.ID)
 		if err != nil {
 			return err
 		}
-		m := senmlMessage{Message: msg, ID: id.String()}
+		m := senmlMessage{Message: msg, ID: id}
 		if _, err := tx.NamedExec(q, m); err != nil {
 			pgErr, ok := err.(*pgconn.PgError)
 			if ok {
@@ -126,7 +127,8 @@ func (pr postgresRepo) insertJSON(msgs mfjson.Messages) error {
 	}()
 
 	q := `INSERT INTO %s (id, channel, created, subtopic, publisher, protocol, payload)
-          VALUES (:id, :channel, :created, :subtopic, :publisher, :protocol, :payload);`
+          VALUES (:id, :channel, :created, :subtopic, :publisher, :protocol, :payload)
+          ON CONFLICT DO NOTHING;`
 	q = fmt.Sprintf(q, msgs.Format)
 
 	for _, m := range msgs.Data {
@@ -153,7 +155,7 @@ func (pr postgresRepo) insertJSON(msgs mfjson.Messages) error {
 }
 
 func (pr postgresRepo) createTable(name string) error {
-	q := `CREATE TABLE IF NOT EXISTS %s (
+	q := `CREATE TABLE IF NOT EXISTS %[1]s (
             id            UUID,
             created       BIGINT,
             channel       VARCHAR(254),
@@ -162,7 +164,8 @@ func (pr postgresRepo) createTable(name string) error {
             protocol      TEXT,
             payload       JSONB,
             PRIMARY KEY (id)
-        )`
+        );
+        CREATE INDEX IF NOT EXISTS %[1]s_channel_created_idx ON %[1]s (channel, created);`
 	q = fmt.Sprintf(q, name)
 
 	_, err := pr.db.Exec(q)
@@ -185,7 +188,7 @@ type jsonMessage struct {
 }
 
 func toJSONMessage(msg mfjson.Message) (jsonMessage, error) {
-	id, err := uuid.NewV4()
+	id, err := messageID(msg.ID)
 	if err != nil {
 		return jsonMessage{}, err
 	}
@@ -200,7 +203,7 @@ func toJSONMessage(msg mfjson.Message) (jsonMessage, error) {
 	}
 
 	m := jsonMessage{
-		ID:        id.String(),
+		ID:        id,
 		Channel:   msg.Channel,
 		Created:   msg.Created,
 		Subtopic:  msg.Subtopic,
@@ -211,3 +214,19 @@ func toJSONMessage(msg mfjson.Message) (jsonMessage, error) {
 
 	return m, nil
 }
+
+// messageID returns the ID of the stored message. Messages whose ID is not
+// assigned on ingress are stored with the random ID, and hence stored again
+// if they are redelivered.
+func messageID(id string) (string, error) {
+	if id != "" {
+		return id, nil
+	}
+
+	uid, err := uuid.NewV4()
+	if err != nil {
+		return "", err
+	}
+
+	return uid.String(), nil
+}
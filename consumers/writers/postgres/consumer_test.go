@@ -111,3 +111,35 @@ func TestSaveJSON(t *testing.T) {
 	err = repo.Consume(msgs)
 	assert.Nil(t, err, fmt.Sprintf("expected no error got %s\n", err))
 }
+
+func TestSaveSenMLRedelivered(t *testing.T) {
+	repo := postgres.New(db)
+
+	chid, err := uuid.NewV4()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	now := time.Now().Unix()
+	var msgs []senml.Message
+	for i := 0; i < msgsNum; i++ {
+		id, err := uuid.NewV4()
+		require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+		msgs = append(msgs, senml.Message{
+			ID:      id.String(),
+			Channel: chid.String(),
+			Name:    "temperature",
+			Value:   &v,
+			Time:    float64(now + int64(i)),
+		})
+	}
+
+	for i := 0; i < 2; i++ {
+		err = repo.Consume(msgs)
+		assert.Nil(t, err, fmt.Sprintf("expected no error got %s\n", err))
+	}
+
+	var total int
+	err = db.Get(&total, `SELECT COUNT(*) FROM messages WHERE channel = $1`, chid.String())
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	assert.Equal(t, msgsNum, total, fmt.Sprintf("expected %d stored messages got %d\n", msgsNum, total))
+}
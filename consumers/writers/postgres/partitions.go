@@ -0,0 +1,287 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/logger"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/jmoiron/sqlx"
+)
+
+const (
+	// DayPartition partitions SenML messages by day.
+	DayPartition = "day"
+	// MonthPartition partitions SenML messages by month.
+	MonthPartition = "month"
+
+	partitionPrefix = "messages_p"
+	// oldPartition contains the messages stored before the
+	// partitioning is set up and the late messages.
+	oldPartition = "messages_old"
+	// defaultPartition contains the messages outside of the ranges of the
+	// other partitions, e.g. the messages timestamped ahead of the created
+	// partitions, until their partition is created.
+	defaultPartition = "messages_default"
+)
+
+var (
+	// ErrInvalidPartition indicates unsupported partition interval.
+	ErrInvalidPartition = errors.New("invalid partition interval")
+
+	errPartition = errors.New("failed to manage messages partitions")
+)
+
+// PartitionConfig defines the time partitioning of SenML messages table.
+type PartitionConfig struct {
+	// Interval is the time range of a single partition, day or month.
+	Interval string
+	// Retention is the age after which the partitions are dropped.
+	// Partitions are kept forever if the retention is zero.
+	Retention time.Duration
+}
+
+// Partitioner manages time partitions of SenML messages table.
+type Partitioner interface {
+	// Setup converts the messages table to the table partitioned by time,
+	// unless it is already partitioned. Existing messages are kept in the
+	// partition of old messages.
+	Setup(ctx context.Context, now time.Time) error
+
+	// Maintain creates the partitions of the current and the next interval,
+	// moving their messages out of the default partition, and drops the
+	// partitions older than the retention.
+	Maintain(ctx context.Context, now time.Time) error
+}
+
+type partitioner struct {
+	db  *sqlx.DB
+	cfg PartitionConfig
+}
+
+// NewPartitioner returns new partitioner of SenML messages table.
+func NewPartitioner(db *sqlx.DB, cfg PartitionConfig) (Partitioner, error) {
+	if cfg.Interval != DayPartition && cfg.Interval != MonthPartition {
+		return nil, ErrInvalidPartition
+	}
+
+	return &partitioner{db: db, cfg: cfg}, nil
+}
+
+// StartPartitioner sets up the partitioning and maintains the partitions
+// periodically, until the context is canceled.
+func StartPartitioner(ctx context.Context, p Partitioner, interval time.Duration, logger logger.Logger) error {
+	if err := p.Setup(ctx, time.Now()); err != nil {
+		return err
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := p.Maintain(ctx, time.Now()); err != nil {
+			logger.Warn(fmt.Sprintf("Failed to maintain messages partitions: %s", err))
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func (p *partitioner) Setup(ctx context.Context, now time.Time) error {
+	var partitioned bool
+	q := `SELECT EXISTS (SELECT 1 FROM pg_partitioned_table pt
+		JOIN pg_class c ON c.oid = pt.partrelid
+		WHERE c.relname = 'messages' AND c.relnamespace = current_schema()::regnamespace);`
+	if err := p.db.GetContext(ctx, &partitioned, q); err != nil {
+		return errors.Wrap(errPartition, err)
+	}
+	if partitioned {
+		return nil
+	}
+
+	start := p.periodStart(now)
+	end := p.nextPeriod(start)
+
+	// Messages of the current interval are moved to its partition,
+	// so that the table can be attached as the partition of old messages.
+	stmts := []string{
+		fmt.Sprintf(`ALTER TABLE messages RENAME TO %s;`, oldPartition),
+		fmt.Sprintf(`ALTER TABLE %s DROP CONSTRAINT IF EXISTS messages_pkey;`, oldPartition),
+		fmt.Sprintf(`ALTER TABLE %s ALTER COLUMN id SET NOT NULL, ALTER COLUMN time SET NOT NULL;`, oldPartition),
+		fmt.Sprintf(`CREATE TABLE messages (LIKE %s INCLUDING DEFAULTS, PRIMARY KEY (id, time)) PARTITION BY RANGE (time);`, oldPartition),
+		`CREATE INDEX IF NOT EXISTS messages_channel_time_idx ON messages (channel, time);`,
+		p.createPartition(start, end),
+		p.createDefaultPartition(),
+		fmt.Sprintf(`INSERT INTO messages SELECT * FROM %s WHERE time >= %d;`, oldPartition, start.Unix()),
+		fmt.Sprintf(`DELETE FROM %s WHERE time >= %d;`, oldPartition, start.Unix()),
+		fmt.Sprintf(`ALTER TABLE messages ATTACH PARTITION %s FOR VALUES FROM (MINVALUE) TO (%d);`, oldPartition, start.Unix()),
+	}
+
+	return p.execTx(ctx, stmts)
+}
+
+func (p *partitioner) Maintain(ctx context.Context, now time.Time) error {
+	// Tables partitioned before the default partition was introduced
+	// get it on the first maintenance.
+	if _, err := p.db.ExecContext(ctx, p.createDefaultPartition()); err != nil {
+		return errors.Wrap(errPartition, err)
+	}
+
+	start := p.periodStart(now)
+	next := p.nextPeriod(start)
+	for _, s := range []time.Time{start, next} {
+		if err := p.addPartition(ctx, s); err != nil {
+			return err
+		}
+	}
+
+	if p.cfg.Retention == 0 {
+		return nil
+	}
+
+	q := `SELECT c.relname FROM pg_inherits i
+		JOIN pg_class c ON c.oid = i.inhrelid
+		JOIN pg_class pc ON pc.oid = i.inhparent
+		WHERE pc.relname = 'messages' AND pc.relnamespace = current_schema()::regnamespace;`
+	var partitions []string
+	if err := p.db.SelectContext(ctx, &partitions, q); err != nil {
+		return errors.Wrap(errPartition, err)
+	}
+
+	expiry := now.Add(-p.cfg.Retention)
+	for _, name := range partitions {
+		s, ok := p.parsePartition(name)
+		if !ok || p.nextPeriod(s).After(expiry) {
+			continue
+		}
+		if _, err := p.db.ExecContext(ctx, fmt.Sprintf(`DROP TABLE IF EXISTS %s;`, name)); err != nil {
+			return errors.Wrap(errPartition, err)
+		}
+	}
+
+	q = fmt.Sprintf(`DELETE FROM %s WHERE time < $1;`, defaultPartition)
+	if _, err := p.db.ExecContext(ctx, q, p.periodStart(expiry).Unix()); err != nil {
+		return errors.Wrap(errPartition, err)
+	}
+
+	return nil
+}
+
+// addPartition creates the partition of the interval starting at the given
+// time. Partition can't be created while the default partition contains its
+// messages, so the default partition is detached and its messages of the
+// interval are moved to the created partition.
+func (p *partitioner) addPartition(ctx context.Context, start time.Time) error {
+	end := p.nextPeriod(start)
+
+	var pending bool
+	q := fmt.Sprintf(`SELECT EXISTS (SELECT 1 FROM %s WHERE time >= $1 AND time < $2);`, defaultPartition)
+	if err := p.db.GetContext(ctx, &pending, q, start.Unix(), end.Unix()); err != nil {
+		return errors.Wrap(errPartition, err)
+	}
+	if !pending {
+		if _, err := p.db.ExecContext(ctx, p.createPartition(start, end)); err != nil {
+			return errors.Wrap(errPartition, err)
+		}
+		return nil
+	}
+
+	stmts := []string{
+		fmt.Sprintf(`ALTER TABLE messages DETACH PARTITION %s;`, defaultPartition),
+		p.createPartition(start, end),
+		fmt.Sprintf(`INSERT INTO messages SELECT * FROM %s WHERE time >= %d AND time < %d;`, defaultPartition, start.Unix(), end.Unix()),
+		fmt.Sprintf(`DELETE FROM %s WHERE time >= %d AND time < %d;`, defaultPartition, start.Unix(), end.Unix()),
+		fmt.Sprintf(`ALTER TABLE messages ATTACH PARTITION %s DEFAULT;`, defaultPartition),
+	}
+
+	return p.execTx(ctx, stmts)
+}
+
+func (p *partitioner) execTx(ctx context.Context, stmts []string) (err error) {
+	tx, err := p.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(errPartition, err)
+	}
+	defer func() {
+		if err != nil {
+			if txErr := tx.Rollback(); txErr != nil {
+				err = errors.Wrap(err, errors.Wrap(errTransRollback, txErr))
+			}
+			return
+		}
+
+		if err = tx.Commit(); err != nil {
+			err = errors.Wrap(errPartition, err)
+		}
+	}()
+
+	for _, stmt := range stmts {
+		if _, err := tx.ExecContext(ctx, stmt); err != nil {
+			return errors.Wrap(errPartition, err)
+		}
+	}
+
+	return nil
+}
+
+func (p *partitioner) createPartition(start, end time.Time) string {
+	return fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s PARTITION OF messages FOR VALUES FROM (%d) TO (%d);`,
+		p.partitionName(start), start.Unix(), end.Unix())
+}
+
+func (p *partitioner) createDefaultPartition() string {
+	return fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s PARTITION OF messages DEFAULT;`, defaultPartition)
+}
+
+func (p *partitioner) periodStart(t time.Time) time.Time {
+	t = t.UTC()
+	if p.cfg.Interval == MonthPartition {
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	}
+
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func (p *partitioner) nextPeriod(start time.Time) time.Time {
+	if p.cfg.Interval == MonthPartition {
+		return start.AddDate(0, 1, 0)
+	}
+
+	return start.AddDate(0, 0, 1)
+}
+
+func (p *partitioner) layout() string {
+	if p.cfg.Interval == MonthPartition {
+		return "200601"
+	}
+
+	return "20060102"
+}
+
+func (p *partitioner) partitionName(start time.Time) string {
+	return partitionPrefix + start.Format(p.layout())
+}
+
+// parsePartition returns the start of the partition interval. The partition
+// of old messages is not parsed, since it is not dropped automatically.
+func (p *partitioner) parsePartition(name string) (time.Time, bool) {
+	if !strings.HasPrefix(name, partitionPrefix) {
+		return time.Time{}, false
+	}
+
+	start, err := time.Parse(p.layout(), strings.TrimPrefix(name, partitionPrefix))
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	return start, true
+}
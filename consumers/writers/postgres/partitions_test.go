@@ -0,0 +1,135 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/consumers/writers/postgres"
+	"github.com/MainfluxLabs/mainflux/pkg/transformers/senml"
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewPartitioner(t *testing.T) {
+	cases := []struct {
+		desc     string
+		interval string
+		err      error
+	}{
+		{
+			desc:     "create partitioner by day",
+			interval: postgres.DayPartition,
+			err:      nil,
+		},
+		{
+			desc:     "create partitioner by month",
+			interval: postgres.MonthPartition,
+			err:      nil,
+		},
+		{
+			desc:     "create partitioner by invalid interval",
+			interval: "week",
+			err:      postgres.ErrInvalidPartition,
+		},
+	}
+
+	for _, tc := range cases {
+		_, err := postgres.NewPartitioner(db, postgres.PartitionConfig{Interval: tc.interval})
+		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestPartitions(t *testing.T) {
+	repo := postgres.New(db)
+	p, err := postgres.NewPartitioner(db, postgres.PartitionConfig{Interval: postgres.DayPartition, Retention: 24 * time.Hour})
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	now := time.Now()
+	err = p.Setup(context.Background(), now)
+	require.Nil(t, err, fmt.Sprintf("setup partitions: expected no error got %s", err))
+	err = p.Setup(context.Background(), now)
+	require.Nil(t, err, fmt.Sprintf("setup already partitioned table: expected no error got %s", err))
+
+	err = p.Maintain(context.Background(), now)
+	require.Nil(t, err, fmt.Sprintf("maintain partitions: expected no error got %s", err))
+
+	chid, err := uuid.NewV4()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	msgs := []senml.Message{
+		{Channel: chid.String(), Value: &v, Time: float64(now.Unix())},
+		{Channel: chid.String(), Value: &v, Time: float64(now.Add(-72 * time.Hour).Unix())},
+	}
+	err = repo.Consume(msgs)
+	assert.Nil(t, err, fmt.Sprintf("save partitioned messages: expected no error got %s", err))
+
+	var partitions []string
+	q := `SELECT c.relname FROM pg_inherits i JOIN pg_class c ON c.oid = i.inhrelid
+		JOIN pg_class pc ON pc.oid = i.inhparent WHERE pc.relname = 'messages';`
+	err = db.Select(&partitions, q)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	current := fmt.Sprintf("messages_p%s", now.UTC().Format("20060102"))
+	next := fmt.Sprintf("messages_p%s", now.UTC().AddDate(0, 0, 1).Format("20060102"))
+	assert.Contains(t, partitions, current, fmt.Sprintf("expected partition %s to exist", current))
+	assert.Contains(t, partitions, next, fmt.Sprintf("expected partition %s to exist", next))
+	assert.Contains(t, partitions, "messages_old", "expected partition of old messages to exist")
+	assert.Contains(t, partitions, "messages_default", "expected default partition to exist")
+
+	// Partitions of the current day expire two days later.
+	later := now.AddDate(0, 0, 3)
+	err = p.Maintain(context.Background(), later)
+	require.Nil(t, err, fmt.Sprintf("maintain expired partitions: expected no error got %s", err))
+
+	var count int
+	err = db.Get(&count, `SELECT COUNT(*) FROM messages WHERE channel = $1;`, chid.String())
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	assert.Equal(t, 1, count, fmt.Sprintf("expected old message to be kept, got %d messages", count))
+
+	err = p.Maintain(context.Background(), now)
+	require.Nil(t, err, fmt.Sprintf("maintain partitions: expected no error got %s", err))
+}
+
+func TestOutOfRangePartitions(t *testing.T) {
+	repo := postgres.New(db)
+	p, err := postgres.NewPartitioner(db, postgres.PartitionConfig{Interval: postgres.DayPartition})
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	now := time.Now()
+	err = p.Setup(context.Background(), now)
+	require.Nil(t, err, fmt.Sprintf("setup partitions: expected no error got %s", err))
+	err = p.Maintain(context.Background(), now)
+	require.Nil(t, err, fmt.Sprintf("maintain partitions: expected no error got %s", err))
+
+	chid, err := uuid.NewV4()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	// Message is timestamped ahead of the created partitions.
+	future := now.AddDate(0, 0, 30)
+	msgs := []senml.Message{{Channel: chid.String(), Value: &v, Time: float64(future.Unix())}}
+	err = repo.Consume(msgs)
+	assert.Nil(t, err, fmt.Sprintf("save message without partition: expected no error got %s", err))
+
+	var count int
+	err = db.Get(&count, `SELECT COUNT(*) FROM messages_default WHERE channel = $1;`, chid.String())
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	assert.Equal(t, 1, count, fmt.Sprintf("expected message in default partition, got %d messages", count))
+
+	err = p.Maintain(context.Background(), future)
+	require.Nil(t, err, fmt.Sprintf("maintain partitions of pending messages: expected no error got %s", err))
+
+	partition := fmt.Sprintf("messages_p%s", future.UTC().Format("20060102"))
+	err = db.Get(&count, fmt.Sprintf(`SELECT COUNT(*) FROM %s WHERE channel = $1;`, partition), chid.String())
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	assert.Equal(t, 1, count, fmt.Sprintf("expected message moved to partition %s, got %d messages", partition, count))
+
+	err = db.Get(&count, `SELECT COUNT(*) FROM messages_default WHERE channel = $1;`, chid.String())
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	assert.Equal(t, 0, count, fmt.Sprintf("expected no messages in default partition, got %d messages", count))
+}
@@ -0,0 +1,101 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/consumers"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/jmoiron/sqlx"
+)
+
+var errPurge = errors.New("failed to purge messages")
+
+var _ consumers.Purger = (*postgresRepo)(nil)
+
+// NewPurger returns new PostgreSQL purger of expired messages.
+func NewPurger(db *sqlx.DB) consumers.Purger {
+	return &postgresRepo{db: db}
+}
+
+func (pr postgresRepo) Channels(ctx context.Context) ([]string, error) {
+	tables, err := pr.jsonTables(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Channels are read from the indexes on the channel column.
+	q := `SELECT DISTINCT channel FROM messages`
+	for _, t := range tables {
+		q += fmt.Sprintf(` UNION SELECT DISTINCT channel FROM %s`, t)
+	}
+
+	var chanIDs []string
+	if err := pr.db.SelectContext(ctx, &chanIDs, q); err != nil {
+		return nil, errors.Wrap(errPurge, err)
+	}
+
+	return chanIDs, nil
+}
+
+func (pr postgresRepo) Purge(ctx context.Context, chanID string, before time.Time) (uint64, error) {
+	// SenML messages time is in seconds, while JSON messages are created in nanoseconds.
+	q := `DELETE FROM messages WHERE channel = $1 AND time < $2;`
+	total, err := pr.delete(ctx, q, chanID, float64(before.UnixNano())/1e9)
+	if err != nil {
+		return 0, err
+	}
+
+	tables, err := pr.jsonTables(ctx)
+	if err != nil {
+		return total, err
+	}
+
+	for _, t := range tables {
+		q := fmt.Sprintf(`DELETE FROM %s WHERE channel = $1 AND created < $2;`, t)
+		n, err := pr.delete(ctx, q, chanID, before.UnixNano())
+		if err != nil {
+			return total, err
+		}
+		total += n
+	}
+
+	return total, nil
+}
+
+func (pr postgresRepo) delete(ctx context.Context, q string, args ...interface{}) (uint64, error) {
+	res, err := pr.db.ExecContext(ctx, q, args...)
+	if err != nil {
+		return 0, errors.Wrap(errPurge, err)
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(errPurge, err)
+	}
+
+	return uint64(n), nil
+}
+
+// jsonTables returns the names of the tables of JSON messages, which are
+// created per message format. Only the tables having exactly the columns of
+// the tables created by the writer are returned, so that the other tables
+// of the database are never purged.
+func (pr postgresRepo) jsonTables(ctx context.Context) ([]string, error) {
+	q := `SELECT table_name FROM information_schema.columns
+		WHERE table_schema = current_schema()
+		GROUP BY table_name
+		HAVING COUNT(*) = 7 AND bool_and(column_name IN
+			('id', 'created', 'channel', 'subtopic', 'publisher', 'protocol', 'payload'));`
+
+	var tables []string
+	if err := pr.db.SelectContext(ctx, &tables, q); err != nil {
+		return nil, errors.Wrap(errPurge, err)
+	}
+
+	return tables, nil
+}
@@ -0,0 +1,101 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/MainfluxLabs/mainflux/consumers"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	mfjson "github.com/MainfluxLabs/mainflux/pkg/transformers/json"
+	"github.com/MainfluxLabs/mainflux/pkg/transformers/senml"
+	"github.com/go-redis/redis/v8"
+)
+
+const keyPrefix = "lastvalue"
+
+// setNewer stores the messages of the channel hash, given as field, time and
+// message triples, unless the cached message of the field is newer. This
+// keeps the cache consistent when messages are consumed out of order.
+var setNewer = redis.NewScript(`
+for i = 1, #ARGV, 3 do
+	local cur = redis.call('HGET', KEYS[1], ARGV[i])
+	if not cur or (tonumber(cjson.decode(cur)['time']) or 0) <= tonumber(ARGV[i + 1]) then
+		redis.call('HSET', KEYS[1], ARGV[i], ARGV[i + 2])
+	end
+end
+return 0
+`)
+
+var _ consumers.Consumer = (*cache)(nil)
+
+type cache struct {
+	client *redis.Client
+}
+
+// New returns new last value cache writer. The cache keeps the last SenML
+// message of every publisher and name of the channel, while JSON messages
+// are ignored since they have no name.
+func New(client *redis.Client) consumers.Consumer {
+	return &cache{client: client}
+}
+
+func (c *cache) Consume(message interface{}) error {
+	if _, ok := message.(mfjson.Messages); ok {
+		return nil
+	}
+
+	msgs, ok := message.([]senml.Message)
+	if !ok {
+		return errors.ErrSaveMessage
+	}
+
+	// Only the newest message of every field of the batch is stored.
+	latest := make(map[string]map[string]senml.Message)
+	for _, msg := range msgs {
+		if msg.Name == "" {
+			continue
+		}
+		fields, ok := latest[msg.Channel]
+		if !ok {
+			fields = make(map[string]senml.Message)
+			latest[msg.Channel] = fields
+		}
+		f := field(msg.Publisher, msg.Name)
+		if cur, ok := fields[f]; !ok || cur.Time <= msg.Time {
+			fields[f] = msg
+		}
+	}
+
+	ctx := context.Background()
+	for chanID, fields := range latest {
+		args := make([]interface{}, 0, 3*len(fields))
+		for f, msg := range fields {
+			data, err := json.Marshal(msg)
+			if err != nil {
+				return errors.Wrap(errors.ErrSaveMessage, err)
+			}
+			args = append(args, f, msg.Time, data)
+		}
+
+		if err := setNewer.Run(ctx, c.client, []string{key(chanID)}, args...).Err(); err != nil && err != redis.Nil {
+			return errors.Wrap(errors.ErrSaveMessage, err)
+		}
+	}
+
+	return nil
+}
+
+// key returns the key of the hash keeping the last messages of the channel.
+func key(chanID string) string {
+	return fmt.Sprintf("%s:%s", keyPrefix, chanID)
+}
+
+// field returns the hash field keeping the last message of the publisher
+// with the given name.
+func field(publisher, name string) string {
+	return fmt.Sprintf("%s:%s", publisher, name)
+}
@@ -7,11 +7,13 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
 
 	"github.com/MainfluxLabs/mainflux/consumers"
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
 	mfjson "github.com/MainfluxLabs/mainflux/pkg/transformers/json"
 	"github.com/MainfluxLabs/mainflux/pkg/transformers/senml"
+	"github.com/gofrs/uuid"
 	"github.com/jackc/pgerrcode"
 	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jmoiron/sqlx" // required for DB access
@@ -21,8 +23,13 @@ var (
 	errInvalidMessage = errors.New("invalid message representation")
 	errTransRollback  = errors.New("failed to rollback transaction")
 	errNoTable        = errors.New("relation does not exist")
+	errNoColumn       = errors.New("column does not exist")
 )
 
+// insertBatchSize is the maximum number of messages inserted by a single
+// statement, which keeps the number of statement parameters under the limit.
+const insertBatchSize = 1000
+
 var _ consumers.Consumer = (*timescaleRepo)(nil)
 
 type timescaleRepo struct {
@@ -48,13 +55,6 @@ func (tr timescaleRepo) saveSenml(messages interface{}) (err error) {
 	if !ok {
 		return errors.ErrSaveMessage
 	}
-	q := `INSERT INTO messages (channel, subtopic, publisher, protocol,
-          name, unit, value, string_value, bool_value, data_value, sum,
-          time, update_time)
-          VALUES (:channel, :subtopic, :publisher, :protocol, :name, :unit,
-          :value, :string_value, :bool_value, :data_value, :sum,
-          :time, :update_time);`
-
 	tx, err := tr.db.BeginTxx(context.Background(), nil)
 	if err != nil {
 		return errors.Wrap(errors.ErrSaveMessage, err)
@@ -72,9 +72,18 @@ func (tr timescaleRepo) saveSenml(messages interface{}) (err error) {
 		}
 	}()
 
-	for _, msg := range msgs {
-		m := senmlMessage{Message: msg}
-		if _, err := tx.NamedExec(q, m); err != nil {
+	// Messages are inserted in batches, since a single multi-row insert
+	// is considerably faster than inserting the messages one by one.
+	for start := 0; start < len(msgs); start += insertBatchSize {
+		end := start + insertBatchSize
+		if end > len(msgs) {
+			end = len(msgs)
+		}
+		q, args, err := senmlInsert(msgs[start:end])
+		if err != nil {
+			return errors.Wrap(errors.ErrSaveMessage, err)
+		}
+		if _, err := tx.Exec(q, args...); err != nil {
 			pgErr, ok := err.(*pgconn.PgError)
 			if ok {
 				switch pgErr.Code {
@@ -91,7 +100,7 @@ func (tr timescaleRepo) saveSenml(messages interface{}) (err error) {
 
 func (tr timescaleRepo) saveJSON(msgs mfjson.Messages) error {
 	if err := tr.insertJSON(msgs); err != nil {
-		if err == errNoTable {
+		if err == errNoTable || err == errNoColumn {
 			if err := tr.createTable(msgs.Format); err != nil {
 				return err
 			}
@@ -120,8 +129,9 @@ func (tr timescaleRepo) insertJSON(msgs mfjson.Messages) error {
 		}
 	}()
 
-	q := `INSERT INTO %s (channel, created, subtopic, publisher, protocol, payload)
-          VALUES (:channel, :created, :subtopic, :publisher, :protocol, :payload);`
+	q := `INSERT INTO %s (id, channel, created, subtopic, publisher, protocol, payload)
+          VALUES (:id, :channel, :created, :subtopic, :publisher, :protocol, :payload)
+          ON CONFLICT (id) DO NOTHING;`
 	q = fmt.Sprintf(q, msgs.Format)
 
 	for _, m := range msgs.Data {
@@ -138,6 +148,8 @@ func (tr timescaleRepo) insertJSON(msgs mfjson.Messages) error {
 					return errors.Wrap(errors.ErrSaveMessage, errInvalidMessage)
 				case pgerrcode.UndefinedTable:
 					return errNoTable
+				case pgerrcode.UndefinedColumn:
+					return errNoColumn
 				}
 			}
 			return err
@@ -146,27 +158,67 @@ func (tr timescaleRepo) insertJSON(msgs mfjson.Messages) error {
 	return nil
 }
 
+// createTable creates the table of JSON messages. Tables created before the
+// messages were identified by the ID are upgraded, so that the messages
+// sharing the creation time, publisher and subtopic are stored as well.
 func (tr timescaleRepo) createTable(name string) error {
-	q := `CREATE TABLE IF NOT EXISTS %s (
+	q := `CREATE TABLE IF NOT EXISTS %[1]s (
+            id            UUID,
             created       BIGINT NOT NULL,
             channel       VARCHAR(254),
             subtopic      VARCHAR(254),
             publisher     VARCHAR(254),
             protocol      TEXT,
-            payload       JSONB,
-            PRIMARY KEY (created, publisher, subtopic)
-        );`
+            payload       JSONB
+        );
+        ALTER TABLE %[1]s ADD COLUMN IF NOT EXISTS id UUID;
+        ALTER TABLE %[1]s DROP CONSTRAINT IF EXISTS %[1]s_pkey;
+        CREATE UNIQUE INDEX IF NOT EXISTS %[1]s_id_idx ON %[1]s (id);
+        CREATE INDEX IF NOT EXISTS %[1]s_channel_created_idx ON %[1]s (channel, created);`
 	q = fmt.Sprintf(q, name)
 
 	_, err := tr.db.Exec(q)
 	return err
 }
 
-type senmlMessage struct {
-	senml.Message
+// senmlInsert returns the statement inserting the messages and its arguments.
+// Redelivered messages are already stored under the same ID, so they are skipped.
+func senmlInsert(msgs []senml.Message) (string, []interface{}, error) {
+	var sb strings.Builder
+	sb.WriteString(`INSERT INTO messages (id, channel, subtopic, publisher, protocol,
+          name, unit, value, string_value, bool_value, data_value, sum,
+          time, update_time, seq) VALUES `)
+
+	const cols = 15
+	args := make([]interface{}, 0, len(msgs)*cols)
+	for i, msg := range msgs {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		sb.WriteString("(")
+		for j := 1; j <= cols; j++ {
+			if j > 1 {
+				sb.WriteString(", ")
+			}
+			sb.WriteString(fmt.Sprintf("$%d", i*cols+j))
+		}
+		sb.WriteString(")")
+
+		id, err := messageID(msg.ID)
+		if err != nil {
+			return "", nil, err
+		}
+		args = append(args, id, msg.Channel, msg.Subtopic, msg.Publisher, msg.Protocol,
+			msg.Name, msg.Unit, msg.Value, msg.StringValue, msg.BoolValue, msg.DataValue, msg.Sum,
+			msg.Time, msg.UpdateTime, msg.Seq)
+	}
+	sb.WriteString(" ON CONFLICT (id, time) DO NOTHING;")
+
+	return sb.String(), args, nil
 }
 
 type jsonMessage struct {
+	ID        string `db:"id"`
 	Channel   string `db:"channel"`
 	Created   int64  `db:"created"`
 	Subtopic  string `db:"subtopic"`
@@ -176,6 +228,11 @@ type jsonMessage struct {
 }
 
 func toJSONMessage(msg mfjson.Message) (jsonMessage, error) {
+	id, err := messageID(msg.ID)
+	if err != nil {
+		return jsonMessage{}, errors.Wrap(errors.ErrSaveMessage, err)
+	}
+
 	data := []byte("{}")
 	if msg.Payload != nil {
 		b, err := json.Marshal(msg.Payload)
@@ -186,6 +243,7 @@ func toJSONMessage(msg mfjson.Message) (jsonMessage, error) {
 	}
 
 	m := jsonMessage{
+		ID:        id,
 		Channel:   msg.Channel,
 		Created:   msg.Created,
 		Subtopic:  msg.Subtopic,
@@ -196,3 +254,18 @@ func toJSONMessage(msg mfjson.Message) (jsonMessage, error) {
 
 	return m, nil
 }
+
+// messageID returns the stored message ID, which is random for the messages
+// without the ID assigned on ingress.
+func messageID(id string) (string, error) {
+	if id != "" {
+		return id, nil
+	}
+
+	uid, err := uuid.NewV4()
+	if err != nil {
+		return "", err
+	}
+
+	return uid.String(), nil
+}
@@ -72,6 +72,75 @@ func TestSaveSenML(t *testing.T) {
 	assert.Nil(t, err, fmt.Sprintf("expected no error got %s\n", err))
 }
 
+func TestSaveSenMLRedelivered(t *testing.T) {
+	repo := timescale.New(db)
+
+	chid, err := uuid.NewV4()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	pubid, err := uuid.NewV4()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	now := time.Now().Unix()
+	var msgs []senml.Message
+	for i := 0; i < msgsNum; i++ {
+		id, err := uuid.NewV4()
+		require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+		msgs = append(msgs, senml.Message{
+			ID:        id.String(),
+			Channel:   chid.String(),
+			Publisher: pubid.String(),
+			Subtopic:  subtopic,
+			Name:      "temperature",
+			Value:     &v,
+			Time:      float64(now + int64(i)),
+		})
+	}
+
+	for i := 0; i < 2; i++ {
+		err = repo.Consume(msgs)
+		assert.Nil(t, err, fmt.Sprintf("expected no error got %s\n", err))
+	}
+
+	var total int
+	err = db.Get(&total, `SELECT COUNT(*) FROM messages WHERE channel = $1`, chid.String())
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	assert.Equal(t, msgsNum, total, fmt.Sprintf("expected %d stored messages got %d\n", msgsNum, total))
+}
+
+func TestSaveSenMLSameTime(t *testing.T) {
+	repo := timescale.New(db)
+
+	chid, err := uuid.NewV4()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	pubid, err := uuid.NewV4()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	now := float64(time.Now().Unix())
+	var msgs []senml.Message
+	for i := 0; i < msgsNum; i++ {
+		id, err := uuid.NewV4()
+		require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+		value := float64(i)
+		msgs = append(msgs, senml.Message{
+			ID:        id.String(),
+			Channel:   chid.String(),
+			Publisher: pubid.String(),
+			Subtopic:  subtopic,
+			Name:      "temperature",
+			Value:     &value,
+			Time:      now,
+		})
+	}
+
+	err = repo.Consume(msgs)
+	assert.Nil(t, err, fmt.Sprintf("expected no error got %s\n", err))
+
+	var total int
+	err = db.Get(&total, `SELECT COUNT(*) FROM messages WHERE channel = $1`, chid.String())
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	assert.Equal(t, msgsNum, total, fmt.Sprintf("expected %d stored messages got %d\n", msgsNum, total))
+}
+
 func TestSaveJSON(t *testing.T) {
 	repo := timescale.New(db)
 
@@ -70,6 +70,99 @@ func migrateDB(db *sqlx.DB) error {
 					"DROP TABLE messages",
 				},
 			},
+			{
+				// Continuous aggregates can't be created in a transaction.
+				Id:                   "messages_2",
+				DisableTransactionUp: true,
+				Up: []string{
+					`CREATE OR REPLACE FUNCTION unix_now() RETURNS BIGINT LANGUAGE SQL STABLE AS $$ SELECT extract(epoch FROM now())::BIGINT $$`,
+					`SELECT set_integer_now_func('messages', 'unix_now', replace_if_exists => TRUE)`,
+					`ALTER TABLE messages SET (timescaledb.compress, timescaledb.compress_segmentby = 'channel, publisher, subtopic, name', timescaledb.compress_orderby = 'time DESC')`,
+					`SELECT add_compression_policy('messages', BIGINT '604800', if_not_exists => TRUE)`,
+					`CREATE MATERIALIZED VIEW IF NOT EXISTS messages_hourly
+                        WITH (timescaledb.continuous, timescaledb.materialized_only = FALSE) AS
+                        SELECT channel, name, time_bucket(BIGINT '3600', time) AS bucket,
+                            SUM(value) AS sum_value, COUNT(value) AS count_value,
+                            MIN(value) AS min_value, MAX(value) AS max_value
+                        FROM messages
+                        GROUP BY channel, name, bucket
+                        WITH NO DATA`,
+					`SELECT add_continuous_aggregate_policy('messages_hourly', start_offset => BIGINT '2592000', end_offset => BIGINT '3600', schedule_interval => INTERVAL '1 hour', if_not_exists => TRUE)`,
+				},
+				DisableTransactionDown: true,
+				Down: []string{
+					"DROP MATERIALIZED VIEW IF EXISTS messages_hourly",
+					"SELECT remove_compression_policy('messages', if_exists => TRUE)",
+				},
+			},
+			{
+				Id: "messages_3",
+				Up: []string{
+					`ALTER TABLE messages ADD COLUMN IF NOT EXISTS seq BIGINT NOT NULL DEFAULT 0`,
+				},
+				Down: []string{
+					"ALTER TABLE messages DROP COLUMN seq",
+				},
+			},
+			{
+				// Unique indexes of the hypertable have to contain the time column.
+				Id: "messages_4",
+				Up: []string{
+					`ALTER TABLE messages ADD COLUMN IF NOT EXISTS id UUID`,
+					`ALTER TABLE messages DROP CONSTRAINT IF EXISTS messages_pkey`,
+					`CREATE UNIQUE INDEX IF NOT EXISTS messages_id_time_idx ON messages (id, time)`,
+					`CREATE INDEX IF NOT EXISTS messages_channel_time_idx ON messages (channel, time DESC)`,
+				},
+				Down: []string{
+					"DROP INDEX IF EXISTS messages_channel_time_idx",
+					"DROP INDEX IF EXISTS messages_id_time_idx",
+					"ALTER TABLE messages DROP COLUMN id",
+				},
+			},
+			{
+				// Channel and thing IDs aren't necessarily UUIDs. Columns of the
+				// compressed hypertable can't be altered, so the compression and
+				// the continuous aggregate are recreated around the change.
+				Id:                   "messages_5",
+				DisableTransactionUp: true,
+				Up: []string{
+					`DROP MATERIALIZED VIEW IF EXISTS messages_hourly`,
+					`SELECT remove_compression_policy('messages', if_exists => TRUE)`,
+					`SELECT decompress_chunk(c, TRUE) FROM show_chunks('messages') c`,
+					`ALTER TABLE messages SET (timescaledb.compress = FALSE)`,
+					`ALTER TABLE messages ALTER COLUMN channel TYPE VARCHAR(254), ALTER COLUMN publisher TYPE VARCHAR(254)`,
+					`ALTER TABLE messages SET (timescaledb.compress, timescaledb.compress_segmentby = 'channel, publisher, subtopic, name', timescaledb.compress_orderby = 'time DESC')`,
+					`SELECT add_compression_policy('messages', BIGINT '604800', if_not_exists => TRUE)`,
+					`CREATE MATERIALIZED VIEW IF NOT EXISTS messages_hourly
+                        WITH (timescaledb.continuous, timescaledb.materialized_only = FALSE) AS
+                        SELECT channel, name, time_bucket(BIGINT '3600', time) AS bucket,
+                            SUM(value) AS sum_value, COUNT(value) AS count_value,
+                            MIN(value) AS min_value, MAX(value) AS max_value
+                        FROM messages
+                        GROUP BY channel, name, bucket
+                        WITH NO DATA`,
+					`SELECT add_continuous_aggregate_policy('messages_hourly', start_offset => BIGINT '2592000', end_offset => BIGINT '3600', schedule_interval => INTERVAL '1 hour', if_not_exists => TRUE)`,
+				},
+				DisableTransactionDown: true,
+				Down: []string{
+					`DROP MATERIALIZED VIEW IF EXISTS messages_hourly`,
+					`SELECT remove_compression_policy('messages', if_exists => TRUE)`,
+					`SELECT decompress_chunk(c, TRUE) FROM show_chunks('messages') c`,
+					`ALTER TABLE messages SET (timescaledb.compress = FALSE)`,
+					`ALTER TABLE messages ALTER COLUMN channel TYPE UUID USING channel::uuid, ALTER COLUMN publisher TYPE UUID USING publisher::uuid`,
+					`ALTER TABLE messages SET (timescaledb.compress, timescaledb.compress_segmentby = 'channel, publisher, subtopic, name', timescaledb.compress_orderby = 'time DESC')`,
+					`SELECT add_compression_policy('messages', BIGINT '604800', if_not_exists => TRUE)`,
+					`CREATE MATERIALIZED VIEW IF NOT EXISTS messages_hourly
+                        WITH (timescaledb.continuous, timescaledb.materialized_only = FALSE) AS
+                        SELECT channel, name, time_bucket(BIGINT '3600', time) AS bucket,
+                            SUM(value) AS sum_value, COUNT(value) AS count_value,
+                            MIN(value) AS min_value, MAX(value) AS max_value
+                        FROM messages
+                        GROUP BY channel, name, bucket
+                        WITH NO DATA`,
+					`SELECT add_continuous_aggregate_policy('messages_hourly', start_offset => BIGINT '2592000', end_offset => BIGINT '3600', schedule_interval => INTERVAL '1 hour', if_not_exists => TRUE)`,
+				},
+			},
 		},
 	}
 
@@ -4,15 +4,20 @@
 package mainflux
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
+	"sync"
+	"time"
 )
 
 const (
 	contentType     = "Content-Type"
 	contentTypeJSON = "application/health+json"
 	svcStatus       = "pass"
+	failStatus      = "fail"
 	description     = " service"
+	checkTimeout    = 5 * time.Second
 )
 
 var (
@@ -46,10 +51,37 @@ type HealthInfo struct {
 
 	// BuildTime contains service build time.
 	BuildTime string `json:"build_time"`
+
+	// Checks contains statuses of the service dependencies.
+	Checks map[string]CheckInfo `json:"checks,omitempty"`
+}
+
+// CheckInfo contains the status of the service dependency.
+type CheckInfo struct {
+	// Status contains dependency status.
+	Status string `json:"status"`
+
+	// Latency contains the duration of the dependency check.
+	Latency string `json:"latency"`
+
+	// Error contains the reason of the failed check.
+	Error string `json:"error,omitempty"`
+}
+
+// HealthCheck represents the availability check of the service dependency,
+// such as the database, the message broker or the cache.
+type HealthCheck struct {
+	// Name is the dependency name reported in the health response.
+	Name string
+
+	// Check returns an error if the dependency is unavailable.
+	Check func(ctx context.Context) error
 }
 
-// Health exposes an HTTP handler for retrieving service health.
-func Health(service string) http.HandlerFunc {
+// Health exposes an HTTP handler for retrieving service health. Service is
+// reported as failed, with the Service Unavailable status code, if any of
+// the dependency checks fails.
+func Health(service string, checks ...HealthCheck) http.HandlerFunc {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Add(contentType, contentTypeJSON)
 		if r.Method != http.MethodGet && r.Method != http.MethodHead {
@@ -65,10 +97,54 @@ func Health(service string) http.HandlerFunc {
 			BuildTime:   BuildTime,
 		}
 
-		w.WriteHeader(http.StatusOK)
+		code := http.StatusOK
+		if len(checks) > 0 {
+			res.Checks = runChecks(r.Context(), checks)
+			for _, c := range res.Checks {
+				if c.Status != svcStatus {
+					res.Status = failStatus
+					code = http.StatusServiceUnavailable
+				}
+			}
+		}
+
+		w.WriteHeader(code)
 
 		if err := json.NewEncoder(w).Encode(res); err != nil {
 			w.WriteHeader(http.StatusInternalServerError)
 		}
 	})
 }
+
+func runChecks(ctx context.Context, checks []HealthCheck) map[string]CheckInfo {
+	ctx, cancel := context.WithTimeout(ctx, checkTimeout)
+	defer cancel()
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	res := make(map[string]CheckInfo, len(checks))
+	for _, hc := range checks {
+		wg.Add(1)
+		go func(hc HealthCheck) {
+			defer wg.Done()
+
+			start := time.Now()
+			err := hc.Check(ctx)
+			info := CheckInfo{
+				Status:  svcStatus,
+				Latency: time.Since(start).String(),
+			}
+			if err != nil {
+				info.Status = failStatus
+				info.Error = err.Error()
+			}
+
+			mu.Lock()
+			res[hc.Name] = info
+			mu.Unlock()
+		}(hc)
+	}
+	wg.Wait()
+
+	return res
+}
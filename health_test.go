@@ -0,0 +1,77 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mainflux_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/MainfluxLabs/mainflux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHealth(t *testing.T) {
+	pass := mainflux.HealthCheck{
+		Name:  "database",
+		Check: func(context.Context) error { return nil },
+	}
+	fail := mainflux.HealthCheck{
+		Name:  "broker",
+		Check: func(context.Context) error { return errors.New("connection closed") },
+	}
+
+	cases := []struct {
+		desc   string
+		checks []mainflux.HealthCheck
+		code   int
+		status string
+		failed []string
+	}{
+		{
+			desc:   "health without dependency checks",
+			code:   http.StatusOK,
+			status: "pass",
+		},
+		{
+			desc:   "health with passing dependency checks",
+			checks: []mainflux.HealthCheck{pass},
+			code:   http.StatusOK,
+			status: "pass",
+		},
+		{
+			desc:   "health with failing dependency check",
+			checks: []mainflux.HealthCheck{pass, fail},
+			code:   http.StatusServiceUnavailable,
+			status: "fail",
+			failed: []string{fail.Name},
+		},
+	}
+
+	for _, tc := range cases {
+		req := httptest.NewRequest(http.MethodGet, "/health", nil)
+		rec := httptest.NewRecorder()
+		mainflux.Health("test", tc.checks...).ServeHTTP(rec, req)
+		assert.Equal(t, tc.code, rec.Code, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.code, rec.Code))
+
+		var res mainflux.HealthInfo
+		err := json.NewDecoder(rec.Body).Decode(&res)
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.Status, fmt.Sprintf("%s: expected status %s got %s", tc.desc, tc.status, res.Status))
+		assert.Equal(t, len(tc.checks), len(res.Checks), fmt.Sprintf("%s: expected %d checks got %d", tc.desc, len(tc.checks), len(res.Checks)))
+
+		var failed []string
+		for name, c := range res.Checks {
+			if c.Status != "pass" {
+				failed = append(failed, name)
+			}
+		}
+		assert.ElementsMatch(t, tc.failed, failed, fmt.Sprintf("%s: expected failed checks %v got %v", tc.desc, tc.failed, failed))
+	}
+}
@@ -7,15 +7,40 @@ package http
 
 import (
 	"context"
+	"crypto/subtle"
+	"time"
 
 	"github.com/MainfluxLabs/mainflux"
+	"github.com/MainfluxLabs/mainflux/http/remotewrite"
+	"github.com/MainfluxLabs/mainflux/http/tts"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
 	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+	"github.com/MainfluxLabs/mainflux/pkg/signature"
+	"github.com/MainfluxLabs/senml"
 )
 
+const (
+	ttsProtocol         = "lora"
+	remoteWriteProtocol = "prometheus"
+)
+
+// ErrRouteNotFound indicates the uplink message of the end device without
+// the route.
+var ErrRouteNotFound = errors.New("route not found for this device EUI")
+
 // Service specifies coap service API.
 type Service interface {
-	// Publish Messssage
-	Publish(ctx context.Context, token string, msg messaging.Message) error
+	// Publish Messssage. If confirm is set, Publish returns only after the
+	// message broker acknowledges that the message is persisted.
+	Publish(ctx context.Context, token string, confirm bool, msg messaging.Message) error
+
+	// PublishUplink publishes the uplink message of The Things Stack webhook
+	// authorized by the API key to the channel routed for its end device.
+	PublishUplink(ctx context.Context, apiKey string, up tts.Uplink) error
+
+	// PublishMetrics publishes the samples of the Prometheus remote write
+	// time series as SenML messages to the channels named by their labels.
+	PublishMetrics(ctx context.Context, token string, series []remotewrite.TimeSeries) error
 }
 
 var _ Service = (*adapterService)(nil)
@@ -23,17 +48,24 @@ var _ Service = (*adapterService)(nil)
 type adapterService struct {
 	publisher messaging.Publisher
 	things    mainflux.ThingsServiceClient
+	verifier  signature.Verifier
+	ttsKey    string
+	ttsRoutes tts.Routes
 }
 
-// New instantiates the HTTP adapter implementation.
-func New(publisher messaging.Publisher, things mainflux.ThingsServiceClient) Service {
+// New instantiates the HTTP adapter implementation. Uplink messages of The
+// Things Stack are rejected if the webhook API key is empty.
+func New(publisher messaging.Publisher, things mainflux.ThingsServiceClient, verifier signature.Verifier, ttsKey string, ttsRoutes tts.Routes) Service {
 	return &adapterService{
 		publisher: publisher,
 		things:    things,
+		verifier:  verifier,
+		ttsKey:    ttsKey,
+		ttsRoutes: ttsRoutes,
 	}
 }
 
-func (as *adapterService) Publish(ctx context.Context, token string, msg messaging.Message) error {
+func (as *adapterService) Publish(ctx context.Context, token string, confirm bool, msg messaging.Message) error {
 	ar := &mainflux.AccessByKeyReq{
 		Token:  token,
 		ChanID: msg.Channel,
@@ -44,5 +76,93 @@ func (as *adapterService) Publish(ctx context.Context, token string, msg messagi
 	}
 	msg.Publisher = thid.GetValue()
 
+	payload, err := as.verifier.Verify(ctx, msg)
+	if err != nil {
+		return err
+	}
+	msg.Payload = payload
+
+	id, err := messaging.NewID()
+	if err != nil {
+		return err
+	}
+	msg.Id = id
+
+	if confirm {
+		return messaging.PublishConfirmed(ctx, as.publisher, msg.Channel, msg)
+	}
+
+	return as.publisher.Publish(msg.Channel, msg)
+}
+
+func (as *adapterService) PublishUplink(ctx context.Context, apiKey string, up tts.Uplink) error {
+	if as.ttsKey == "" || subtle.ConstantTimeCompare([]byte(apiKey), []byte(as.ttsKey)) != 1 {
+		return errors.ErrAuthentication
+	}
+
+	if err := up.Validate(); err != nil {
+		return err
+	}
+
+	r, ok := as.ttsRoutes.Route(up.EndDeviceIDs.DevEUI)
+	if !ok {
+		return ErrRouteNotFound
+	}
+
+	ar := &mainflux.AccessByIDReq{
+		ThingID: r.ThingID,
+		ChanID:  r.ChannelID,
+	}
+	if _, err := as.things.CanAccessByID(ctx, ar); err != nil {
+		return err
+	}
+
+	payload, err := up.Payload()
+	if err != nil {
+		return err
+	}
+
+	id, err := messaging.NewID()
+	if err != nil {
+		return err
+	}
+
+	msg := messaging.Message{
+		Id:        id,
+		Channel:   r.ChannelID,
+		Subtopic:  r.Subtopic,
+		Publisher: r.ThingID,
+		Protocol:  ttsProtocol,
+		Payload:   payload,
+		Created:   time.Now().UnixNano(),
+	}
+
 	return as.publisher.Publish(msg.Channel, msg)
 }
+
+func (as *adapterService) PublishMetrics(ctx context.Context, token string, series []remotewrite.TimeSeries) error {
+	batches, err := remotewrite.Batches(series)
+	if err != nil {
+		return err
+	}
+
+	for _, b := range batches {
+		payload, err := senml.Encode(senml.Pack{Records: b.Records}, senml.JSON)
+		if err != nil {
+			return err
+		}
+
+		msg := messaging.Message{
+			Channel:  b.Channel,
+			Subtopic: b.Subtopic,
+			Protocol: remoteWriteProtocol,
+			Payload:  payload,
+			Created:  time.Now().UnixNano(),
+		}
+		if err := as.Publish(ctx, token, false, msg); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -18,7 +18,42 @@ func sendMessageEndpoint(svc http.Service) endpoint.Endpoint {
 			return nil, err
 		}
 
-		err := svc.Publish(ctx, req.token, req.msg)
-		return nil, err
+		if err := svc.Publish(ctx, req.token, req.confirm, req.msg); err != nil {
+			return nil, err
+		}
+
+		return publishRes{confirmed: req.confirm}, nil
+	}
+}
+
+func publishUplinkEndpoint(svc http.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(publishUplinkReq)
+
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		if err := svc.PublishUplink(ctx, req.apiKey, req.uplink); err != nil {
+			return nil, err
+		}
+
+		return publishRes{}, nil
+	}
+}
+
+func publishMetricsEndpoint(svc http.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(publishMetricsReq)
+
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		if err := svc.PublishMetrics(ctx, req.token, req.series); err != nil {
+			return nil, err
+		}
+
+		return publishRes{}, nil
 	}
 }
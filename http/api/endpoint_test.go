@@ -6,6 +6,7 @@ package api_test
 import (
 	"fmt"
 	"io"
+	"math"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -14,18 +15,30 @@ import (
 	"github.com/MainfluxLabs/mainflux"
 	adapter "github.com/MainfluxLabs/mainflux/http"
 	"github.com/MainfluxLabs/mainflux/http/api"
+	"github.com/MainfluxLabs/mainflux/http/tts"
 	"github.com/MainfluxLabs/mainflux/internal/apiutil"
 	"github.com/MainfluxLabs/mainflux/logger"
 	"github.com/MainfluxLabs/mainflux/pkg/mocks"
+	"github.com/MainfluxLabs/mainflux/pkg/signature"
+	"github.com/golang/snappy"
 	"github.com/opentracing/opentracing-go/mocktracer"
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/encoding/protowire"
 )
 
-const ServiceErrToken = "unavailable"
+const (
+	ServiceErrToken = "unavailable"
+	ttsAPIKey       = "tts_api_key"
+	devEUI          = "70B3D57ED0000001"
+)
 
 func newService(tc mainflux.ThingsServiceClient) adapter.Service {
 	pub := mocks.NewPublisher()
-	return adapter.New(pub, tc)
+	routes := tts.Routes{
+		devEUI:             {DevEUI: devEUI, ThingID: "1", ChannelID: "1"},
+		"70B3D57ED0000002": {DevEUI: "70B3D57ED0000002", ThingID: "1", ChannelID: "2"},
+	}
+	return adapter.New(pub, tc, signature.NewVerifier(tc, 0), ttsAPIKey, routes)
 }
 
 func newHTTPServer(svc adapter.Service) *httptest.Server {
@@ -79,6 +92,7 @@ func TestPublish(t *testing.T) {
 
 	cases := map[string]struct {
 		chanID      string
+		query       string
 		msg         string
 		contentType string
 		key         string
@@ -106,6 +120,38 @@ func TestPublish(t *testing.T) {
 			key:         thingKey,
 			status:      http.StatusAccepted,
 		},
+		"publish message with broker confirmation": {
+			chanID:      chanID,
+			query:       "?confirm=true",
+			msg:         msg,
+			contentType: ctSenmlJSON,
+			key:         thingKey,
+			status:      http.StatusCreated,
+		},
+		"publish message without broker confirmation": {
+			chanID:      chanID,
+			query:       "?confirm=false",
+			msg:         msg,
+			contentType: ctSenmlJSON,
+			key:         thingKey,
+			status:      http.StatusAccepted,
+		},
+		"publish message with invalid confirm": {
+			chanID:      chanID,
+			query:       "?confirm=invalid",
+			msg:         msg,
+			contentType: ctSenmlJSON,
+			key:         thingKey,
+			status:      http.StatusBadRequest,
+		},
+		"publish message with ignored profile query": {
+			chanID:      chanID,
+			query:       "?forward=false&persist=invalid",
+			msg:         msg,
+			contentType: ctSenmlJSON,
+			key:         thingKey,
+			status:      http.StatusAccepted,
+		},
 		"publish message with empty key": {
 			chanID:      chanID,
 			msg:         msg,
@@ -163,7 +209,193 @@ func TestPublish(t *testing.T) {
 		req := testRequest{
 			client:      ts.Client(),
 			method:      http.MethodPost,
-			url:         fmt.Sprintf("%s/channels/%s/messages", ts.URL, tc.chanID),
+			url:         fmt.Sprintf("%s/channels/%s/messages%s", ts.URL, tc.chanID, tc.query),
+			contentType: tc.contentType,
+			token:       tc.key,
+			body:        strings.NewReader(tc.msg),
+			basicAuth:   tc.basicAuth,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", desc, tc.status, res.StatusCode))
+	}
+}
+
+func TestPublishUplink(t *testing.T) {
+	thingsClient := mocks.NewThingsServiceClient(map[string]string{"thing_key": "1"}, nil)
+	svc := newService(thingsClient)
+	ts := newHTTPServer(svc)
+	defer ts.Close()
+
+	uplink := `{"end_device_ids":{"device_id":"dev","dev_eui":"%s"},"uplink_message":{"f_port":1,"frm_payload":"AQI=","decoded_payload":{"temperature":21.5}}}`
+
+	cases := map[string]struct {
+		msg         string
+		contentType string
+		key         string
+		status      int
+	}{
+		"publish uplink": {
+			msg:         fmt.Sprintf(uplink, devEUI),
+			contentType: "application/json",
+			key:         ttsAPIKey,
+			status:      http.StatusAccepted,
+		},
+		"publish uplink with invalid API key": {
+			msg:         fmt.Sprintf(uplink, devEUI),
+			contentType: "application/json",
+			key:         "invalid",
+			status:      http.StatusUnauthorized,
+		},
+		"publish uplink without API key": {
+			msg:         fmt.Sprintf(uplink, devEUI),
+			contentType: "application/json",
+			key:         "",
+			status:      http.StatusUnauthorized,
+		},
+		"publish uplink of unknown device": {
+			msg:         fmt.Sprintf(uplink, "70B3D57ED00000FF"),
+			contentType: "application/json",
+			key:         ttsAPIKey,
+			status:      http.StatusNotFound,
+		},
+		"publish uplink to unconnected channel": {
+			msg:         fmt.Sprintf(uplink, "70B3D57ED0000002"),
+			contentType: "application/json",
+			key:         ttsAPIKey,
+			status:      http.StatusForbidden,
+		},
+		"publish uplink without uplink message": {
+			msg:         fmt.Sprintf(`{"end_device_ids":{"dev_eui":"%s"}}`, devEUI),
+			contentType: "application/json",
+			key:         ttsAPIKey,
+			status:      http.StatusBadRequest,
+		},
+		"publish malformed uplink": {
+			msg:         "{",
+			contentType: "application/json",
+			key:         ttsAPIKey,
+			status:      http.StatusBadRequest,
+		},
+		"publish uplink without content type": {
+			msg:         fmt.Sprintf(uplink, devEUI),
+			contentType: "",
+			key:         ttsAPIKey,
+			status:      http.StatusUnsupportedMediaType,
+		},
+	}
+
+	for desc, tc := range cases {
+		req, err := http.NewRequest(http.MethodPost, fmt.Sprintf("%s/integrations/tts/uplink", ts.URL), strings.NewReader(tc.msg))
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", desc, err))
+		if tc.key != "" {
+			req.Header.Set("Authorization", apiutil.BearerPrefix+tc.key)
+		}
+		if tc.contentType != "" {
+			req.Header.Set("Content-Type", tc.contentType)
+		}
+		res, err := ts.Client().Do(req)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", desc, tc.status, res.StatusCode))
+	}
+}
+
+// remoteWriteReq returns the remote write request with the single sample of
+// the series with the given labels.
+func remoteWriteReq(labels map[string]string) string {
+	var series []byte
+	for name, value := range labels {
+		var l []byte
+		l = protowire.AppendTag(l, 1, protowire.BytesType)
+		l = protowire.AppendString(l, name)
+		l = protowire.AppendTag(l, 2, protowire.BytesType)
+		l = protowire.AppendString(l, value)
+		series = protowire.AppendTag(series, 1, protowire.BytesType)
+		series = protowire.AppendBytes(series, l)
+	}
+	var s []byte
+	s = protowire.AppendTag(s, 1, protowire.Fixed64Type)
+	s = protowire.AppendFixed64(s, math.Float64bits(1))
+	s = protowire.AppendTag(s, 2, protowire.VarintType)
+	s = protowire.AppendVarint(s, 1600000000000)
+	series = protowire.AppendTag(series, 2, protowire.BytesType)
+	series = protowire.AppendBytes(series, s)
+
+	var req []byte
+	req = protowire.AppendTag(req, 1, protowire.BytesType)
+	req = protowire.AppendBytes(req, series)
+
+	return string(snappy.Encode(nil, req))
+}
+
+func TestPublishMetrics(t *testing.T) {
+	chanID := "1"
+	thingKey := "thing_key"
+	ctProtobuf := "application/x-protobuf"
+	thingsClient := mocks.NewThingsServiceClient(map[string]string{thingKey: chanID}, nil)
+	svc := newService(thingsClient)
+	ts := newHTTPServer(svc)
+	defer ts.Close()
+
+	msg := remoteWriteReq(map[string]string{"__name__": "up", "job": "node", "mf_channel": chanID})
+
+	cases := map[string]struct {
+		msg         string
+		contentType string
+		key         string
+		basicAuth   bool
+		status      int
+	}{
+		"publish metrics": {
+			msg:         msg,
+			contentType: ctProtobuf,
+			key:         thingKey,
+			status:      http.StatusAccepted,
+		},
+		"publish metrics with basic auth": {
+			msg:         msg,
+			contentType: ctProtobuf,
+			key:         thingKey,
+			basicAuth:   true,
+			status:      http.StatusAccepted,
+		},
+		"publish metrics with invalid key": {
+			msg:         msg,
+			contentType: ctProtobuf,
+			key:         "invalid",
+			status:      http.StatusUnauthorized,
+		},
+		"publish metrics with empty key": {
+			msg:         msg,
+			contentType: ctProtobuf,
+			key:         "",
+			status:      http.StatusUnauthorized,
+		},
+		"publish metrics without channel label": {
+			msg:         remoteWriteReq(map[string]string{"__name__": "up"}),
+			contentType: ctProtobuf,
+			key:         thingKey,
+			status:      http.StatusBadRequest,
+		},
+		"publish malformed metrics": {
+			msg:         "malformed",
+			contentType: ctProtobuf,
+			key:         thingKey,
+			status:      http.StatusBadRequest,
+		},
+		"publish metrics without content type": {
+			msg:         msg,
+			contentType: "",
+			key:         thingKey,
+			status:      http.StatusUnsupportedMediaType,
+		},
+	}
+
+	for desc, tc := range cases {
+		req := testRequest{
+			client:      ts.Client(),
+			method:      http.MethodPost,
+			url:         fmt.Sprintf("%s/prometheus/write", ts.URL),
 			contentType: tc.contentType,
 			token:       tc.key,
 			body:        strings.NewReader(tc.msg),
@@ -11,6 +11,8 @@ import (
 	"time"
 
 	"github.com/MainfluxLabs/mainflux/http"
+	"github.com/MainfluxLabs/mainflux/http/remotewrite"
+	"github.com/MainfluxLabs/mainflux/http/tts"
 	log "github.com/MainfluxLabs/mainflux/logger"
 	"github.com/MainfluxLabs/mainflux/pkg/messaging"
 )
@@ -27,19 +29,48 @@ func LoggingMiddleware(svc http.Service, logger log.Logger) http.Service {
 	return &loggingMiddleware{logger, svc}
 }
 
-func (lm *loggingMiddleware) Publish(ctx context.Context, token string, msg messaging.Message) (err error) {
+func (lm *loggingMiddleware) Publish(ctx context.Context, token string, confirm bool, msg messaging.Message) (err error) {
 	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "publish", "latency", time.Since(begin).String())
 		destChannel := msg.Channel
 		if msg.Subtopic != "" {
 			destChannel = fmt.Sprintf("%s.%s", destChannel, msg.Subtopic)
 		}
 		message := fmt.Sprintf("Method publish to channel %s took %s to complete", destChannel, time.Since(begin))
 		if err != nil {
-			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
 			return
 		}
-		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+		l.Info(fmt.Sprintf("%s without errors.", message))
 	}(time.Now())
 
-	return lm.svc.Publish(ctx, token, msg)
+	return lm.svc.Publish(ctx, token, confirm, msg)
+}
+
+func (lm *loggingMiddleware) PublishUplink(ctx context.Context, apiKey string, up tts.Uplink) (err error) {
+	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "publish_uplink", "latency", time.Since(begin).String())
+		message := fmt.Sprintf("Method publish_uplink of device %s took %s to complete", up.EndDeviceIDs.DevEUI, time.Since(begin))
+		if err != nil {
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		l.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.PublishUplink(ctx, apiKey, up)
+}
+
+func (lm *loggingMiddleware) PublishMetrics(ctx context.Context, token string, series []remotewrite.TimeSeries) (err error) {
+	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "publish_metrics", "latency", time.Since(begin).String())
+		message := fmt.Sprintf("Method publish_metrics of %d time series took %s to complete", len(series), time.Since(begin))
+		if err != nil {
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		l.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.PublishMetrics(ctx, token, series)
 }
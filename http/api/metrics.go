@@ -11,6 +11,8 @@ import (
 
 	"github.com/go-kit/kit/metrics"
 	"github.com/MainfluxLabs/mainflux/http"
+	"github.com/MainfluxLabs/mainflux/http/remotewrite"
+	"github.com/MainfluxLabs/mainflux/http/tts"
 	"github.com/MainfluxLabs/mainflux/pkg/messaging"
 )
 
@@ -31,11 +33,29 @@ func MetricsMiddleware(svc http.Service, counter metrics.Counter, latency metric
 	}
 }
 
-func (mm *metricsMiddleware) Publish(ctx context.Context, token string, msg messaging.Message) error {
+func (mm *metricsMiddleware) Publish(ctx context.Context, token string, confirm bool, msg messaging.Message) error {
 	defer func(begin time.Time) {
 		mm.counter.With("method", "publish").Add(1)
 		mm.latency.With("method", "publish").Observe(time.Since(begin).Seconds())
 	}(time.Now())
 
-	return mm.svc.Publish(ctx, token, msg)
+	return mm.svc.Publish(ctx, token, confirm, msg)
+}
+
+func (mm *metricsMiddleware) PublishUplink(ctx context.Context, apiKey string, up tts.Uplink) error {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "publish_uplink").Add(1)
+		mm.latency.With("method", "publish_uplink").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.PublishUplink(ctx, apiKey, up)
+}
+
+func (mm *metricsMiddleware) PublishMetrics(ctx context.Context, token string, series []remotewrite.TimeSeries) error {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "publish_metrics").Add(1)
+		mm.latency.With("method", "publish_metrics").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.PublishMetrics(ctx, token, series)
 }
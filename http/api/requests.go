@@ -4,13 +4,16 @@
 package api
 
 import (
+	"github.com/MainfluxLabs/mainflux/http/remotewrite"
+	"github.com/MainfluxLabs/mainflux/http/tts"
 	"github.com/MainfluxLabs/mainflux/internal/apiutil"
 	"github.com/MainfluxLabs/mainflux/pkg/messaging"
 )
 
 type publishReq struct {
-	msg   messaging.Message
-	token string
+	msg     messaging.Message
+	token   string
+	confirm bool
 }
 
 func (req publishReq) validate() error {
@@ -20,3 +23,29 @@ func (req publishReq) validate() error {
 
 	return nil
 }
+
+type publishUplinkReq struct {
+	apiKey string
+	uplink tts.Uplink
+}
+
+func (req publishUplinkReq) validate() error {
+	if req.apiKey == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	return req.uplink.Validate()
+}
+
+type publishMetricsReq struct {
+	token  string
+	series []remotewrite.TimeSeries
+}
+
+func (req publishMetricsReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	return nil
+}
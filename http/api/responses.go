@@ -0,0 +1,10 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+type publishRes struct {
+	// confirmed indicates that the message broker acknowledged the
+	// persistence of the message.
+	confirmed bool
+}
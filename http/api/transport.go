@@ -15,10 +15,13 @@ import (
 
 	"github.com/MainfluxLabs/mainflux"
 	adapter "github.com/MainfluxLabs/mainflux/http"
+	"github.com/MainfluxLabs/mainflux/http/remotewrite"
+	"github.com/MainfluxLabs/mainflux/http/tts"
 	"github.com/MainfluxLabs/mainflux/internal/apiutil"
 	"github.com/MainfluxLabs/mainflux/logger"
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
 	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+	"github.com/MainfluxLabs/mainflux/pkg/signature"
 	kitot "github.com/go-kit/kit/tracing/opentracing"
 	kithttp "github.com/go-kit/kit/transport/http"
 	"github.com/go-zoo/bone"
@@ -33,6 +36,9 @@ const (
 	ctSenmlJSON = "application/senml+json"
 	ctSenmlCBOR = "application/senml+cbor"
 	ctJSON      = "application/json"
+	ctProtobuf  = "application/x-protobuf"
+	confirmKey  = "confirm"
+	msgIDHeader = "X-Message-ID"
 )
 
 var (
@@ -42,7 +48,7 @@ var (
 var channelPartRegExp = regexp.MustCompile(`^/channels/([\w\-]+)/messages(/[^?]*)?(\?.*)?$`)
 
 // MakeHandler returns a HTTP handler for API endpoints.
-func MakeHandler(svc adapter.Service, tracer opentracing.Tracer, logger logger.Logger) http.Handler {
+func MakeHandler(svc adapter.Service, tracer opentracing.Tracer, logger logger.Logger, checks ...mainflux.HealthCheck) http.Handler {
 	opts := []kithttp.ServerOption{
 		kithttp.ServerErrorEncoder(encodeError),
 	}
@@ -62,10 +68,25 @@ func MakeHandler(svc adapter.Service, tracer opentracing.Tracer, logger logger.L
 		opts...,
 	))
 
-	r.GetFunc("/health", mainflux.Health("http"))
+	r.Post("/integrations/tts/uplink", kithttp.NewServer(
+		kitot.TraceServer(tracer, "publish_uplink")(publishUplinkEndpoint(svc)),
+		decodeUplink,
+		encodeResponse,
+		opts...,
+	))
+
+	r.Post("/prometheus/write", kithttp.NewServer(
+		kitot.TraceServer(tracer, "publish_metrics")(publishMetricsEndpoint(svc)),
+		decodeMetrics,
+		encodeResponse,
+		opts...,
+	))
+
+	r.GetFunc("/health", mainflux.Health("http", checks...))
 	r.Handle("/metrics", promhttp.Handler())
+	r.Handle("/log-level", mainflux.LogLevel(logger))
 
-	return r
+	return apiutil.RequestIDMiddleware(apiutil.LocaleMiddleware(r))
 }
 
 func parseSubtopic(subtopic string) (string, error) {
@@ -113,13 +134,11 @@ func decodeRequest(ctx context.Context, r *http.Request) (interface{}, error) {
 		return nil, err
 	}
 
-	var token string
-	_, pass, ok := r.BasicAuth()
-	switch {
-	case ok:
-		token = pass
-	case !ok:
-		token = apiutil.ExtractThingKey(r)
+	token := extractThingKey(r)
+
+	confirm, err := apiutil.ReadBoolQuery(r, confirmKey, false)
+	if err != nil {
+		return nil, err
 	}
 
 	payload, err := ioutil.ReadAll(r.Body)
@@ -135,30 +154,98 @@ func decodeRequest(ctx context.Context, r *http.Request) (interface{}, error) {
 			Subtopic: subtopic,
 			Payload:  payload,
 			Created:  time.Now().UnixNano(),
+			ClientId: r.Header.Get(msgIDHeader),
 		},
-		token: token,
+		token:   token,
+		confirm: confirm,
+	}
+
+	return req, nil
+}
+
+func decodeMetrics(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), ctProtobuf) {
+		return nil, apiutil.ErrUnsupportedContentType
+	}
+
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return nil, apiutil.ErrMalformedEntity
+	}
+	defer r.Body.Close()
+
+	series, err := remotewrite.Decode(body)
+	if err != nil {
+		return nil, err
+	}
+
+	req := publishMetricsReq{
+		token:  extractThingKey(r),
+		series: series,
+	}
+
+	return req, nil
+}
+
+// extractThingKey returns the thing key sent as the password of the Basic
+// Authentication or in the Thing authorization header.
+func extractThingKey(r *http.Request) string {
+	if _, pass, ok := r.BasicAuth(); ok {
+		return pass
+	}
+
+	return apiutil.ExtractThingKey(r)
+}
+
+func decodeUplink(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), ctJSON) {
+		return nil, apiutil.ErrUnsupportedContentType
+	}
+
+	req := publishUplinkReq{apiKey: apiutil.ExtractBearerToken(r)}
+	if err := json.NewDecoder(r.Body).Decode(&req.uplink); err != nil {
+		return nil, errors.Wrap(apiutil.ErrMalformedEntity, err)
 	}
 
 	return req, nil
 }
 
 func encodeResponse(_ context.Context, w http.ResponseWriter, response interface{}) error {
+	if res, ok := response.(publishRes); ok && res.confirmed {
+		w.WriteHeader(http.StatusCreated)
+		return nil
+	}
+
 	w.WriteHeader(http.StatusAccepted)
 	return nil
 }
 
-func encodeError(_ context.Context, err error, w http.ResponseWriter) {
+func encodeError(ctx context.Context, err error, w http.ResponseWriter) {
 	switch {
 	case errors.Contains(err, errors.ErrAuthentication),
+		errors.Contains(err, signature.ErrMissingSignature),
+		errors.Contains(err, signature.ErrInvalidSignature),
+		errors.Contains(err, signature.ErrExpiredSignature),
 		err == apiutil.ErrBearerToken:
 		w.WriteHeader(http.StatusUnauthorized)
 	case errors.Contains(err, errors.ErrAuthorization):
 		w.WriteHeader(http.StatusForbidden)
 	case errors.Contains(err, apiutil.ErrUnsupportedContentType):
 		w.WriteHeader(http.StatusUnsupportedMediaType)
+	case errors.Contains(err, adapter.ErrRouteNotFound):
+		w.WriteHeader(http.StatusNotFound)
 	case errors.Contains(err, errMalformedSubtopic),
-		errors.Contains(err, apiutil.ErrMalformedEntity):
+		errors.Contains(err, tts.ErrMalformedUplink),
+		errors.Contains(err, remotewrite.ErrMalformedRequest),
+		errors.Contains(err, remotewrite.ErrMissingChannel),
+		errors.Contains(err, remotewrite.ErrMalformedSubtopic),
+		errors.Contains(err, apiutil.ErrMalformedEntity),
+		errors.Contains(err, apiutil.ErrInvalidQueryParams):
 		w.WriteHeader(http.StatusBadRequest)
+	case errors.Contains(err, messaging.ErrConfirmNotSupported):
+		w.WriteHeader(http.StatusNotImplemented)
+	case errors.Contains(err, messaging.ErrNotConfirmed):
+		w.WriteHeader(http.StatusServiceUnavailable)
 
 	default:
 		switch e, ok := status.FromError(err); {
@@ -180,7 +267,7 @@ func encodeError(_ context.Context, err error, w http.ResponseWriter) {
 
 	if errorVal, ok := err.(errors.Error); ok {
 		w.Header().Set("Content-Type", ctJSON)
-		if err := json.NewEncoder(w).Encode(apiutil.ErrorRes{Err: errorVal.Msg()}); err != nil {
+		if err := json.NewEncoder(w).Encode(apiutil.NewErrorRes(ctx, errorVal)); err != nil {
 			w.WriteHeader(http.StatusInternalServerError)
 		}
 	}
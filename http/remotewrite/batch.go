@@ -0,0 +1,118 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package remotewrite
+
+import (
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/senml"
+)
+
+const (
+	// ChannelLabel is the label of the time series naming the channel its
+	// samples are published to.
+	ChannelLabel = "mf_channel"
+	// SubtopicLabel is the optional label of the time series naming the
+	// subtopic its samples are published to.
+	SubtopicLabel = "mf_subtopic"
+
+	metricNameLabel = "__name__"
+)
+
+var (
+	// ErrMissingChannel indicates the time series without the channel label.
+	ErrMissingChannel = errors.New("missing channel label")
+
+	// ErrMalformedSubtopic indicates the subtopic label with wildcards.
+	ErrMalformedSubtopic = errors.New("malformed subtopic label")
+)
+
+// Batch contains the samples of the time series published to the same
+// channel and subtopic, as the SenML records.
+type Batch struct {
+	Channel  string
+	Subtopic string
+	Records  []senml.Record
+}
+
+// Batches groups the samples of the time series by their channel and subtopic
+// labels. Records are named by the metric name and the remaining labels of
+// the series, e.g. up{instance="edge:9100",job="node"}. Samples which are
+// not numbers, such as the staleness markers, are skipped.
+func Batches(series []TimeSeries) ([]Batch, error) {
+	type key struct{ channel, subtopic string }
+	batches := map[key]*Batch{}
+	var keys []key
+
+	for _, ts := range series {
+		var k key
+		var name string
+		var labels []Label
+		for _, l := range ts.Labels {
+			switch l.Name {
+			case ChannelLabel:
+				k.channel = l.Value
+			case SubtopicLabel:
+				k.subtopic = l.Value
+			case metricNameLabel:
+				name = l.Value
+			default:
+				labels = append(labels, l)
+			}
+		}
+		if k.channel == "" {
+			return nil, ErrMissingChannel
+		}
+		if strings.ContainsAny(k.subtopic, "*>") {
+			return nil, ErrMalformedSubtopic
+		}
+
+		b, ok := batches[k]
+		if !ok {
+			b = &Batch{Channel: k.channel, Subtopic: k.subtopic}
+			batches[k] = b
+			keys = append(keys, k)
+		}
+
+		n := seriesName(name, labels)
+		for _, s := range ts.Samples {
+			if math.IsNaN(s.Value) || math.IsInf(s.Value, 0) {
+				continue
+			}
+			v := s.Value
+			b.Records = append(b.Records, senml.Record{
+				Name:  n,
+				Time:  float64(s.Timestamp) / 1e3,
+				Value: &v,
+			})
+		}
+	}
+
+	var ret []Batch
+	for _, k := range keys {
+		if b := batches[k]; len(b.Records) > 0 {
+			ret = append(ret, *b)
+		}
+	}
+
+	return ret, nil
+}
+
+func seriesName(name string, labels []Label) string {
+	if len(labels) == 0 {
+		return name
+	}
+
+	sort.Slice(labels, func(i, j int) bool { return labels[i].Name < labels[j].Name })
+	pairs := make([]string, len(labels))
+	for i, l := range labels {
+		pairs[i] = l.Name + "=" + strconv.Quote(l.Value)
+	}
+
+	return name + "{" + strings.Join(pairs, ",") + "}"
+}
@@ -0,0 +1,94 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package remotewrite_test
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/MainfluxLabs/mainflux/http/remotewrite"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/senml"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBatches(t *testing.T) {
+	one, half := 1.0, 0.5
+
+	cases := []struct {
+		desc    string
+		series  []remotewrite.TimeSeries
+		batches []remotewrite.Batch
+		err     error
+	}{
+		{
+			desc: "batch series by channel and subtopic",
+			series: []remotewrite.TimeSeries{
+				{
+					Labels: []remotewrite.Label{
+						{Name: "__name__", Value: "up"},
+						{Name: "job", Value: "node"},
+						{Name: "instance", Value: "edge:9100"},
+						{Name: "mf_channel", Value: "1"},
+					},
+					Samples: []remotewrite.Sample{{Value: 1, Timestamp: 1600000000000}},
+				},
+				{
+					Labels: []remotewrite.Label{
+						{Name: "__name__", Value: "load1"},
+						{Name: "mf_channel", Value: "1"},
+						{Name: "mf_subtopic", Value: "edge.node"},
+					},
+					Samples: []remotewrite.Sample{
+						{Value: 0.5, Timestamp: 1600000000000},
+						{Value: math.NaN(), Timestamp: 1600000015000},
+					},
+				},
+			},
+			batches: []remotewrite.Batch{
+				{
+					Channel: "1",
+					Records: []senml.Record{{Name: `up{instance="edge:9100",job="node"}`, Time: 1600000000, Value: &one}},
+				},
+				{
+					Channel:  "1",
+					Subtopic: "edge.node",
+					Records:  []senml.Record{{Name: "load1", Time: 1600000000, Value: &half}},
+				},
+			},
+			err: nil,
+		},
+		{
+			desc: "batch series without channel label",
+			series: []remotewrite.TimeSeries{
+				{
+					Labels:  []remotewrite.Label{{Name: "__name__", Value: "up"}},
+					Samples: []remotewrite.Sample{{Value: 1, Timestamp: 1600000000000}},
+				},
+			},
+			err: remotewrite.ErrMissingChannel,
+		},
+		{
+			desc: "batch series with wildcard subtopic label",
+			series: []remotewrite.TimeSeries{
+				{
+					Labels: []remotewrite.Label{
+						{Name: "__name__", Value: "up"},
+						{Name: "mf_channel", Value: "1"},
+						{Name: "mf_subtopic", Value: "edge.>"},
+					},
+					Samples: []remotewrite.Sample{{Value: 1, Timestamp: 1600000000000}},
+				},
+			},
+			err: remotewrite.ErrMalformedSubtopic,
+		},
+	}
+
+	for _, tc := range cases {
+		batches, err := remotewrite.Batches(tc.series)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.err, err))
+		assert.Equal(t, tc.batches, batches, fmt.Sprintf("%s: expected %v got %v", tc.desc, tc.batches, batches))
+	}
+}
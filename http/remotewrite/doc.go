@@ -0,0 +1,6 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package remotewrite contains the decoding of the Prometheus remote write
+// requests and the mapping of their time series to the channels.
+package remotewrite
@@ -0,0 +1,156 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package remotewrite
+
+import (
+	"math"
+
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/golang/snappy"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// Field numbers of the Prometheus remote write protobuf messages.
+const (
+	requestTimeseries = 1
+
+	seriesLabels  = 1
+	seriesSamples = 2
+
+	labelName  = 1
+	labelValue = 2
+
+	sampleValue     = 1
+	sampleTimestamp = 2
+)
+
+// ErrMalformedRequest indicates the request body which is not the snappy
+// compressed Prometheus remote write protobuf request.
+var ErrMalformedRequest = errors.New("malformed remote write request")
+
+// TimeSeries represents the samples of the series identified by its labels.
+type TimeSeries struct {
+	Labels  []Label
+	Samples []Sample
+}
+
+// Label represents the label of the time series.
+type Label struct {
+	Name  string
+	Value string
+}
+
+// Sample represents the value of the time series at the timestamp in
+// milliseconds since the Unix epoch.
+type Sample struct {
+	Value     float64
+	Timestamp int64
+}
+
+// Decode decompresses and decodes the remote write request body. Metadata,
+// exemplars and native histograms are skipped.
+func Decode(body []byte) ([]TimeSeries, error) {
+	data, err := snappy.Decode(nil, body)
+	if err != nil {
+		return nil, errors.Wrap(ErrMalformedRequest, err)
+	}
+
+	var series []TimeSeries
+	err = fields(data, func(num protowire.Number, typ protowire.Type, v uint64, b []byte) error {
+		if num != requestTimeseries || typ != protowire.BytesType {
+			return nil
+		}
+		ts, err := decodeSeries(b)
+		if err != nil {
+			return err
+		}
+		series = append(series, ts)
+		return nil
+	})
+
+	return series, err
+}
+
+func decodeSeries(data []byte) (TimeSeries, error) {
+	var ts TimeSeries
+	err := fields(data, func(num protowire.Number, typ protowire.Type, v uint64, b []byte) error {
+		if typ != protowire.BytesType {
+			return nil
+		}
+		switch num {
+		case seriesLabels:
+			var l Label
+			err := fields(b, func(num protowire.Number, _ protowire.Type, _ uint64, b []byte) error {
+				switch num {
+				case labelName:
+					l.Name = string(b)
+				case labelValue:
+					l.Value = string(b)
+				}
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+			ts.Labels = append(ts.Labels, l)
+		case seriesSamples:
+			var s Sample
+			err := fields(b, func(num protowire.Number, _ protowire.Type, v uint64, _ []byte) error {
+				switch num {
+				case sampleValue:
+					s.Value = math.Float64frombits(v)
+				case sampleTimestamp:
+					s.Timestamp = int64(v)
+				}
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+			ts.Samples = append(ts.Samples, s)
+		}
+		return nil
+	})
+
+	return ts, err
+}
+
+// fields calls fn for each field of the protobuf message. Varint and fixed
+// values are passed as v, and length-delimited values as b.
+func fields(data []byte, fn func(num protowire.Number, typ protowire.Type, v uint64, b []byte) error) error {
+	for len(data) > 0 {
+		num, typ, n := protowire.ConsumeTag(data)
+		if n < 0 {
+			return ErrMalformedRequest
+		}
+		data = data[n:]
+
+		var v uint64
+		var b []byte
+		switch typ {
+		case protowire.VarintType:
+			v, n = protowire.ConsumeVarint(data)
+		case protowire.Fixed32Type:
+			var v32 uint32
+			v32, n = protowire.ConsumeFixed32(data)
+			v = uint64(v32)
+		case protowire.Fixed64Type:
+			v, n = protowire.ConsumeFixed64(data)
+		case protowire.BytesType:
+			b, n = protowire.ConsumeBytes(data)
+		default:
+			n = protowire.ConsumeFieldValue(num, typ, data)
+		}
+		if n < 0 {
+			return ErrMalformedRequest
+		}
+		data = data[n:]
+
+		if err := fn(num, typ, v, b); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,92 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package remotewrite_test
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/MainfluxLabs/mainflux/http/remotewrite"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/golang/snappy"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+func encode(series []remotewrite.TimeSeries) []byte {
+	var req []byte
+	for _, ts := range series {
+		var s []byte
+		for _, l := range ts.Labels {
+			var lb []byte
+			lb = protowire.AppendTag(lb, 1, protowire.BytesType)
+			lb = protowire.AppendString(lb, l.Name)
+			lb = protowire.AppendTag(lb, 2, protowire.BytesType)
+			lb = protowire.AppendString(lb, l.Value)
+			s = protowire.AppendTag(s, 1, protowire.BytesType)
+			s = protowire.AppendBytes(s, lb)
+		}
+		for _, smp := range ts.Samples {
+			var sb []byte
+			sb = protowire.AppendTag(sb, 1, protowire.Fixed64Type)
+			sb = protowire.AppendFixed64(sb, math.Float64bits(smp.Value))
+			sb = protowire.AppendTag(sb, 2, protowire.VarintType)
+			sb = protowire.AppendVarint(sb, uint64(smp.Timestamp))
+			s = protowire.AppendTag(s, 2, protowire.BytesType)
+			s = protowire.AppendBytes(s, sb)
+		}
+		req = protowire.AppendTag(req, 1, protowire.BytesType)
+		req = protowire.AppendBytes(req, s)
+	}
+
+	return snappy.Encode(nil, req)
+}
+
+func TestDecode(t *testing.T) {
+	series := []remotewrite.TimeSeries{
+		{
+			Labels: []remotewrite.Label{
+				{Name: "__name__", Value: "up"},
+				{Name: "mf_channel", Value: "1"},
+			},
+			Samples: []remotewrite.Sample{
+				{Value: 1, Timestamp: 1600000000000},
+				{Value: 0.5, Timestamp: 1600000015000},
+			},
+		},
+	}
+
+	cases := []struct {
+		desc   string
+		body   []byte
+		series []remotewrite.TimeSeries
+		err    error
+	}{
+		{
+			desc:   "decode valid request",
+			body:   encode(series),
+			series: series,
+			err:    nil,
+		},
+		{
+			desc: "decode uncompressed request",
+			body: []byte{0x0a, 0x02, 0x0a},
+			err:  remotewrite.ErrMalformedRequest,
+		},
+		{
+			desc: "decode truncated request",
+			body: snappy.Encode(nil, []byte{0x0a, 0x05, 0x0a}),
+			err:  remotewrite.ErrMalformedRequest,
+		},
+	}
+
+	for _, tc := range cases {
+		series, err := remotewrite.Decode(tc.body)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.err, err))
+		if tc.err == nil {
+			assert.Equal(t, tc.series, series, fmt.Sprintf("%s: expected %v got %v", tc.desc, tc.series, series))
+		}
+	}
+}
@@ -0,0 +1,6 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package tts contains the routing and the decoding of the uplink messages
+// received from The Things Stack webhooks.
+package tts
@@ -0,0 +1,72 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package tts
+
+import (
+	"io/ioutil"
+	"strings"
+
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/pelletier/go-toml"
+)
+
+var (
+	errOpenRoutesFile  = errors.New("unable to open routes file")
+	errParseRoutesFile = errors.New("unable to parse routes file")
+
+	// ErrInvalidRoute indicates a route without the device EUI, thing or channel.
+	ErrInvalidRoute = errors.New("invalid route configuration")
+)
+
+// Route maps the end device to the thing publishing its uplink messages
+// and the channel they are published to.
+type Route struct {
+	DevEUI    string `toml:"dev_eui"`
+	ThingID   string `toml:"thing_id"`
+	ChannelID string `toml:"channel_id"`
+	Subtopic  string `toml:"subtopic"`
+}
+
+// Routes contains the routes of the end devices, keyed by their EUIs.
+type Routes map[string]Route
+
+type routesFile struct {
+	Routes []Route `toml:"routes"`
+}
+
+// LoadRoutes reads and validates the routes file.
+func LoadRoutes(path string) (Routes, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(errOpenRoutesFile, err)
+	}
+
+	var f routesFile
+	if err := toml.Unmarshal(data, &f); err != nil {
+		return nil, errors.Wrap(errParseRoutesFile, err)
+	}
+
+	routes := make(Routes)
+	for _, r := range f.Routes {
+		if r.DevEUI == "" || r.ThingID == "" || r.ChannelID == "" {
+			return nil, ErrInvalidRoute
+		}
+		routes[normalizeEUI(r.DevEUI)] = r
+	}
+
+	return routes, nil
+}
+
+// Route returns the route of the end device with the given EUI.
+func (rs Routes) Route(devEUI string) (Route, bool) {
+	r, ok := rs[normalizeEUI(devEUI)]
+	return r, ok
+}
+
+// normalizeEUI returns the EUI in the upper case hex format without the
+// separators, as sent by The Things Stack.
+func normalizeEUI(eui string) string {
+	eui = strings.NewReplacer("-", "", ":", "").Replace(eui)
+	return strings.ToUpper(eui)
+}
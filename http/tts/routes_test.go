@@ -0,0 +1,70 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package tts_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/MainfluxLabs/mainflux/http/tts"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadRoutes(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tts")
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	defer os.RemoveAll(dir)
+
+	cases := []struct {
+		desc    string
+		content string
+		eui     string
+		route   tts.Route
+		err     error
+	}{
+		{
+			desc: "load valid routes",
+			content: `[[routes]]
+dev_eui = "70-b3-d5-7e-d0-00-00-01"
+thing_id = "thing"
+channel_id = "channel"
+subtopic = "lora"
+`,
+			eui:   "70B3D57ED0000001",
+			route: tts.Route{DevEUI: "70-b3-d5-7e-d0-00-00-01", ThingID: "thing", ChannelID: "channel", Subtopic: "lora"},
+			err:   nil,
+		},
+		{
+			desc: "load route without channel",
+			content: `[[routes]]
+dev_eui = "70B3D57ED0000001"
+thing_id = "thing"
+`,
+			err: tts.ErrInvalidRoute,
+		},
+	}
+
+	for i, tc := range cases {
+		path := filepath.Join(dir, fmt.Sprintf("routes%d.toml", i))
+		err := ioutil.WriteFile(path, []byte(tc.content), 0644)
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+
+		routes, err := tts.LoadRoutes(path)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.err, err))
+		if tc.err != nil {
+			continue
+		}
+		route, ok := routes.Route(tc.eui)
+		assert.True(t, ok, fmt.Sprintf("%s: expected route of %s", tc.desc, tc.eui))
+		assert.Equal(t, tc.route, route, fmt.Sprintf("%s: expected %v got %v", tc.desc, tc.route, route))
+	}
+
+	_, err = tts.LoadRoutes(filepath.Join(dir, "missing.toml"))
+	assert.NotNil(t, err, "loading missing routes file: expected error")
+}
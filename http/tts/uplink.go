@@ -0,0 +1,119 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package tts
+
+import (
+	"sort"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/senml"
+)
+
+// ErrMalformedUplink indicates the uplink message without the device EUI or
+// the payload.
+var ErrMalformedUplink = errors.New("malformed uplink message")
+
+// Uplink represents the uplink message of The Things Stack webhook.
+type Uplink struct {
+	EndDeviceIDs  EndDeviceIDs   `json:"end_device_ids"`
+	ReceivedAt    time.Time      `json:"received_at"`
+	UplinkMessage *UplinkMessage `json:"uplink_message"`
+}
+
+// EndDeviceIDs identifies the end device the uplink message is sent by.
+type EndDeviceIDs struct {
+	DeviceID       string         `json:"device_id"`
+	DevEUI         string         `json:"dev_eui"`
+	ApplicationIDs ApplicationIDs `json:"application_ids"`
+}
+
+// ApplicationIDs identifies the application of the end device.
+type ApplicationIDs struct {
+	ApplicationID string `json:"application_id"`
+}
+
+// UplinkMessage represents the uplink frame of the end device. The frame
+// payload is decoded by the payload formatter of the application or the
+// end device into the decoded payload fields.
+type UplinkMessage struct {
+	FPort          uint32                 `json:"f_port"`
+	FCnt           uint32                 `json:"f_cnt"`
+	FRMPayload     []byte                 `json:"frm_payload"`
+	DecodedPayload map[string]interface{} `json:"decoded_payload"`
+	ReceivedAt     time.Time              `json:"received_at"`
+}
+
+// Validate checks whether the uplink message identifies the end device and
+// carries the payload.
+func (up Uplink) Validate() error {
+	if up.EndDeviceIDs.DevEUI == "" || up.UplinkMessage == nil {
+		return ErrMalformedUplink
+	}
+
+	if len(up.UplinkMessage.DecodedPayload) == 0 && len(up.UplinkMessage.FRMPayload) == 0 {
+		return ErrMalformedUplink
+	}
+
+	return nil
+}
+
+// Payload returns the decoded payload fields as the SenML JSON pack, or the
+// raw frame payload if the payload is not decoded by The Things Stack.
+// Fields of the nested objects are named by their paths, e.g. gps/lat, and
+// the fields which are null or lists are skipped.
+func (up Uplink) Payload() ([]byte, error) {
+	if err := up.Validate(); err != nil {
+		return nil, err
+	}
+
+	if len(up.UplinkMessage.DecodedPayload) == 0 {
+		return up.UplinkMessage.FRMPayload, nil
+	}
+
+	ts := up.UplinkMessage.ReceivedAt
+	if ts.IsZero() {
+		ts = up.ReceivedAt
+	}
+	var t float64
+	if !ts.IsZero() {
+		t = float64(ts.UnixNano()) / 1e9
+	}
+
+	records := records("", up.UplinkMessage.DecodedPayload, t)
+	if len(records) == 0 {
+		return nil, ErrMalformedUplink
+	}
+
+	return senml.Encode(senml.Pack{Records: records}, senml.JSON)
+}
+
+func records(prefix string, fields map[string]interface{}, t float64) []senml.Record {
+	names := make([]string, 0, len(fields))
+	for n := range fields {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+
+	var recs []senml.Record
+	for _, n := range names {
+		r := senml.Record{Name: prefix + n, Time: t}
+		switch v := fields[n].(type) {
+		case float64:
+			r.Value = &v
+		case bool:
+			r.BoolValue = &v
+		case string:
+			r.StringValue = &v
+		case map[string]interface{}:
+			recs = append(recs, records(prefix+n+"/", v, t)...)
+			continue
+		default:
+			continue
+		}
+		recs = append(recs, r)
+	}
+
+	return recs
+}
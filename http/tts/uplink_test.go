@@ -0,0 +1,83 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package tts_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/http/tts"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPayload(t *testing.T) {
+	received := time.Unix(1600000000, 0)
+	ids := tts.EndDeviceIDs{DevEUI: "70B3D57ED0000001"}
+
+	cases := []struct {
+		desc    string
+		uplink  tts.Uplink
+		payload string
+		err     error
+	}{
+		{
+			desc: "payload of decoded fields",
+			uplink: tts.Uplink{
+				EndDeviceIDs: ids,
+				UplinkMessage: &tts.UplinkMessage{
+					DecodedPayload: map[string]interface{}{
+						"temperature": 21.5,
+						"alarm":       true,
+						"gps":         map[string]interface{}{"lat": 45.0},
+						"readings":    []interface{}{1.0, 2.0},
+					},
+					ReceivedAt: received,
+				},
+			},
+			payload: `[{"n":"alarm","t":1600000000,"vb":true},{"n":"gps/lat","t":1600000000,"v":45},{"n":"temperature","t":1600000000,"v":21.5}]`,
+			err:     nil,
+		},
+		{
+			desc: "payload of raw frame",
+			uplink: tts.Uplink{
+				EndDeviceIDs:  ids,
+				UplinkMessage: &tts.UplinkMessage{FRMPayload: []byte{0x01, 0x02}},
+			},
+			payload: "\x01\x02",
+			err:     nil,
+		},
+		{
+			desc: "payload of decoded fields without values",
+			uplink: tts.Uplink{
+				EndDeviceIDs: ids,
+				UplinkMessage: &tts.UplinkMessage{
+					DecodedPayload: map[string]interface{}{"readings": []interface{}{1.0}},
+				},
+			},
+			err: tts.ErrMalformedUplink,
+		},
+		{
+			desc:   "payload without uplink message",
+			uplink: tts.Uplink{EndDeviceIDs: ids},
+			err:    tts.ErrMalformedUplink,
+		},
+		{
+			desc: "payload without device EUI",
+			uplink: tts.Uplink{
+				UplinkMessage: &tts.UplinkMessage{FRMPayload: []byte{0x01}},
+			},
+			err: tts.ErrMalformedUplink,
+		},
+	}
+
+	for _, tc := range cases {
+		payload, err := tc.uplink.Payload()
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.err, err))
+		if tc.err == nil {
+			assert.Equal(t, tc.payload, string(payload), fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.payload, payload))
+		}
+	}
+}
@@ -0,0 +1,92 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package apiutil
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+)
+
+// errorCodes maps the error messages to the stable machine-readable codes
+// returned in the error responses. Errors are matched by the message, the
+// same way errors.Contains does.
+var errorCodes = map[string]string{
+	ErrBearerToken.Error():            "missing_bearer_token",
+	ErrBearerKey.Error():              "missing_bearer_key",
+	ErrMissingID.Error():              "missing_id",
+	ErrMissingRole.Error():            "missing_role",
+	ErrMissingObject.Error():          "missing_object",
+	ErrInvalidSubject.Error():         "invalid_subject",
+	ErrInvalidAction.Error():          "invalid_action",
+	ErrInvalidAuthKey.Error():         "invalid_auth_key",
+	ErrInvalidIDFormat.Error():        "invalid_id_format",
+	ErrNameSize.Error():               "invalid_name_size",
+	ErrExternalIDSize.Error():         "invalid_external_id_size",
+	ErrInvalidLocation.Error():        "invalid_location",
+	ErrEmailSize.Error():              "invalid_email_size",
+	ErrInvalidStatus.Error():          "invalid_status",
+	ErrLimitSize.Error():              "invalid_limit",
+	ErrOffsetSize.Error():             "invalid_offset",
+	ErrInvalidOrder.Error():           "invalid_order",
+	ErrInvalidDirection.Error():       "invalid_direction",
+	ErrInvalidVersion.Error():         "invalid_version",
+	ErrEmptyList.Error():              "empty_list",
+	ErrMissingCertData.Error():        "missing_cert_data",
+	ErrInvalidTopic.Error():           "invalid_topic",
+	ErrInvalidContact.Error():         "invalid_contact",
+	ErrMissingEmail.Error():           "missing_email",
+	ErrMissingHost.Error():            "missing_host",
+	ErrMissingPass.Error():            "missing_password",
+	ErrMissingConfPass.Error():        "missing_confirm_password",
+	ErrInvalidResetPass.Error():       "invalid_reset_password",
+	ErrInvalidComparator.Error():      "invalid_comparator",
+	ErrMissingMemberType.Error():      "missing_member_type",
+	ErrInvalidAPIKey.Error():          "invalid_api_key_type",
+	ErrMaxLevelExceeded.Error():       "max_level_exceeded",
+	ErrBootstrapState.Error():         "invalid_bootstrap_state",
+	ErrMissingClaimToken.Error():      "missing_claim_token",
+	ErrUnsupportedContentType.Error(): "unsupported_content_type",
+	ErrInvalidQueryParams.Error():     "invalid_query_params",
+	ErrNotFoundParam.Error():          "missing_query_param",
+	ErrInvalidMemberRole.Error():      "invalid_member_role",
+	ErrMalformedEntity.Error():        "malformed_entity",
+	ErrInvalidPolicy.Error():          "invalid_policy",
+	errors.ErrAuthentication.Error():  "authentication_failed",
+	errors.ErrAuthorization.Error():   "authorization_failed",
+	errors.ErrNotFound.Error():        "not_found",
+	errors.ErrConflict.Error():        "conflict",
+	errors.ErrCreateEntity.Error():    "create_failed",
+	errors.ErrRetrieveEntity.Error():  "retrieve_failed",
+	errors.ErrUpdateEntity.Error():    "update_failed",
+	errors.ErrRemoveEntity.Error():    "remove_failed",
+	errors.ErrScanMetadata.Error():    "scan_metadata_failed",
+	errors.ErrSaveMessage.Error():     "save_message_failed",
+}
+
+// detailedCodes are the codes of the request validation errors whose wrapped
+// errors, such as JSON decoding errors, are returned as the error details.
+var detailedCodes = map[string]bool{
+	"malformed_entity":     true,
+	"invalid_query_params": true,
+}
+
+// ErrorCode returns the machine-readable code of the error. Errors without a
+// registered code are identified by their message converted to snake case.
+func ErrorCode(err errors.Error) string {
+	if code, ok := errorCodes[err.Msg()]; ok {
+		return code
+	}
+
+	return snakeCase(err.Msg())
+}
+
+func snakeCase(msg string) string {
+	fields := strings.FieldsFunc(strings.ToLower(msg), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	return strings.Join(fields, "_")
+}
@@ -39,6 +39,12 @@ var (
 	// ErrNameSize indicates that name size exceeds the max.
 	ErrNameSize = errors.New("invalid name size")
 
+	// ErrExternalIDSize indicates that external ID exceeds the max length.
+	ErrExternalIDSize = errors.New("invalid external id size")
+
+	// ErrInvalidLocation indicates an invalid geographic location or area.
+	ErrInvalidLocation = errors.New("invalid location")
+
 	// ErrEmailSize indicates that email size exceeds the max.
 	ErrEmailSize = errors.New("invalid email size")
 
@@ -57,6 +63,9 @@ var (
 	// ErrInvalidDirection indicates an invalid list direction.
 	ErrInvalidDirection = errors.New("invalid list direction provided")
 
+	// ErrInvalidVersion indicates an invalid entity version.
+	ErrInvalidVersion = errors.New("invalid version")
+
 	// ErrEmptyList indicates that entity data is empty.
 	ErrEmptyList = errors.New("empty list provided")
 
@@ -99,6 +108,9 @@ var (
 	// ErrBootstrapState indicates an invalid boostrap state.
 	ErrBootstrapState = errors.New("invalid bootstrap state")
 
+	// ErrMissingClaimToken indicates missing claim token.
+	ErrMissingClaimToken = errors.New("missing claim token")
+
 	// ErrUnsupportedContentType indicates unacceptable or lack of Content-Type
 	ErrUnsupportedContentType = errors.New("unsupported content type")
 
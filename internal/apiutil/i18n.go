@@ -0,0 +1,118 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package apiutil
+
+import (
+	"context"
+	"embed"
+	"encoding/json"
+	"net/http"
+	"path"
+	"sort"
+	"strings"
+
+	"golang.org/x/text/language"
+)
+
+const defLocale = "en"
+
+//go:embed locales/*.json
+var localesFS embed.FS
+
+type localeCtxKey struct{}
+
+// catalogs maps the locale to the translations of the error messages, keyed
+// by the English message.
+var catalogs, locales, matcher = loadCatalogs()
+
+func loadCatalogs() (map[string]map[string]string, []string, language.Matcher) {
+	catalogs := make(map[string]map[string]string)
+	paths, err := localesFS.ReadDir("locales")
+	if err != nil {
+		panic(err)
+	}
+
+	for _, p := range paths {
+		data, err := localesFS.ReadFile(path.Join("locales", p.Name()))
+		if err != nil {
+			panic(err)
+		}
+
+		catalog := make(map[string]string)
+		if err := json.Unmarshal(data, &catalog); err != nil {
+			panic(err)
+		}
+		catalogs[strings.TrimSuffix(p.Name(), path.Ext(p.Name()))] = catalog
+	}
+
+	// The default locale goes first, so that it is matched when none of the
+	// locales preferred by the client is supported.
+	locales := []string{}
+	for l := range catalogs {
+		locales = append(locales, l)
+	}
+	sort.Strings(locales)
+	locales = append([]string{defLocale}, locales...)
+
+	tags := []language.Tag{}
+	for _, l := range locales {
+		tags = append(tags, language.MustParse(l))
+	}
+
+	return catalogs, locales, language.NewMatcher(tags)
+}
+
+// LocaleMiddleware stores the supported locale that best matches the client
+// Accept-Language header in the request context, so that the error messages
+// are translated to it.
+func LocaleMiddleware(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Vary", "Accept-Language")
+
+		locale := MatchLocale(r.Header.Get("Accept-Language"))
+		if locale == defLocale {
+			h.ServeHTTP(w, r)
+			return
+		}
+
+		ctx := context.WithValue(r.Context(), localeCtxKey{}, locale)
+		h.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// MatchLocale returns the supported locale that best matches the value of
+// the Accept-Language header, or the default locale.
+func MatchLocale(acceptLanguage string) string {
+	prefs, _, err := language.ParseAcceptLanguage(acceptLanguage)
+	if err != nil || len(prefs) == 0 {
+		return defLocale
+	}
+
+	_, idx, conf := matcher.Match(prefs...)
+	if conf == language.No {
+		return defLocale
+	}
+
+	return locales[idx]
+}
+
+// LocaleFromContext returns the locale stored in the context, or the default
+// locale.
+func LocaleFromContext(ctx context.Context) string {
+	if locale, ok := ctx.Value(localeCtxKey{}).(string); ok {
+		return locale
+	}
+
+	return defLocale
+}
+
+// Translate returns the message translated to the locale, or the message
+// itself if there is no translation.
+func Translate(locale, msg string) string {
+	if t, ok := catalogs[locale][msg]; ok {
+		return t
+	}
+
+	return msg
+}
@@ -0,0 +1,93 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package apiutil_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/MainfluxLabs/mainflux/internal/apiutil"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchLocale(t *testing.T) {
+	cases := []struct {
+		desc           string
+		acceptLanguage string
+		locale         string
+	}{
+		{"match supported locale", "de", "de"},
+		{"match supported base language", "de-AT", "de"},
+		{"match preferred supported locale", "fr;q=0.9, es;q=0.8, de;q=0.5", "es"},
+		{"match unsupported locale", "fr", "en"},
+		{"match empty header", "", "en"},
+		{"match malformed header", ";;q=x", "en"},
+	}
+
+	for _, tc := range cases {
+		locale := apiutil.MatchLocale(tc.acceptLanguage)
+		assert.Equal(t, tc.locale, locale, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.locale, locale))
+	}
+}
+
+func TestNewErrorRes(t *testing.T) {
+	requestID := "8c5c3f7e-0a52-4a87-9d5e-3e1b6f9a2c41"
+	msg := apiutil.ErrMalformedEntity.Error()
+
+	cases := []struct {
+		desc           string
+		acceptLanguage string
+		err            errors.Error
+		res            apiutil.ErrorRes
+	}{
+		{
+			desc:           "error response without locale",
+			acceptLanguage: "",
+			err:            apiutil.ErrMalformedEntity,
+			res:            apiutil.ErrorRes{Err: msg, Code: "malformed_entity", Message: msg, TraceID: requestID},
+		},
+		{
+			desc:           "error response in supported locale",
+			acceptLanguage: "de-DE,de;q=0.9,en;q=0.8",
+			err:            apiutil.ErrMalformedEntity,
+			res:            apiutil.ErrorRes{Err: msg, Code: "malformed_entity", Message: "Fehlerhafte Entitätsangabe", TraceID: requestID},
+		},
+		{
+			desc:           "error response with details",
+			acceptLanguage: "",
+			err:            errors.Wrap(apiutil.ErrMalformedEntity, errors.New("unexpected EOF")).(errors.Error),
+			res:            apiutil.ErrorRes{Err: msg, Code: "malformed_entity", Message: msg, Details: []string{"unexpected EOF"}, TraceID: requestID},
+		},
+		{
+			desc:           "error response without details",
+			acceptLanguage: "",
+			err:            errors.Wrap(errors.ErrNotFound, errors.New("sql: no rows in result set")).(errors.Error),
+			res:            apiutil.ErrorRes{Err: errors.ErrNotFound.Error(), Code: "not_found", Message: errors.ErrNotFound.Error(), TraceID: requestID},
+		},
+		{
+			desc:           "error response without code and translation",
+			acceptLanguage: "es",
+			err:            errors.New("Second factor is required"),
+			res:            apiutil.ErrorRes{Err: "Second factor is required", Code: "second_factor_is_required", Message: "Second factor is required", TraceID: requestID},
+		},
+	}
+
+	for _, tc := range cases {
+		var ctx context.Context
+		h := apiutil.RequestIDMiddleware(apiutil.LocaleMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx = r.Context()
+		})))
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("Accept-Language", tc.acceptLanguage)
+		req.Header.Set(apiutil.RequestIDHeader, requestID)
+		h.ServeHTTP(httptest.NewRecorder(), req)
+
+		res := apiutil.NewErrorRes(ctx, tc.err)
+		assert.Equal(t, tc.res, res, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.res, res))
+	}
+}
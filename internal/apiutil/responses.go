@@ -3,7 +3,42 @@
 
 package apiutil
 
-// ErrorRes represents the HTTP error response body.
+import (
+	"context"
+
+	"github.com/MainfluxLabs/mainflux/logger"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+)
+
+// ErrorRes represents the HTTP error response body. Code is the stable
+// machine-readable error code that clients can branch on, while Message is
+// the human-readable message, translated to the locale requested by the
+// client. Err is the English error message, kept for the clients of the
+// previous error response body. TraceID is the request ID used to correlate
+// the error with the service logs.
 type ErrorRes struct {
-	Err string `json:"error"`
+	Err     string   `json:"error"`
+	Code    string   `json:"code"`
+	Message string   `json:"message"`
+	Details []string `json:"details,omitempty"`
+	TraceID string   `json:"trace_id,omitempty"`
+}
+
+// NewErrorRes returns the error response for the error, translated to the
+// locale carried by the context.
+func NewErrorRes(ctx context.Context, err errors.Error) ErrorRes {
+	res := ErrorRes{
+		Err:     err.Msg(),
+		Code:    ErrorCode(err),
+		Message: Translate(LocaleFromContext(ctx), err.Msg()),
+		TraceID: logger.RequestIDFromContext(ctx),
+	}
+
+	if detailedCodes[res.Code] {
+		for e := err.Err(); e != nil; e = e.Err() {
+			res.Details = append(res.Details, e.Msg())
+		}
+	}
+
+	return res
 }
@@ -13,8 +13,34 @@ import (
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
 	kithttp "github.com/go-kit/kit/transport/http"
 	"github.com/go-zoo/bone"
+	"github.com/gofrs/uuid"
 )
 
+// RequestIDHeader is the header carrying the ID used to correlate the
+// request across the services and their logs.
+const RequestIDHeader = "X-Request-ID"
+
+// RequestIDMiddleware reuses the request ID sent by the client, or generates
+// the new one, returns it in the response header and stores it in the request
+// context so that it is added to the log entries.
+func RequestIDMiddleware(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(RequestIDHeader)
+		if id == "" {
+			uid, err := uuid.NewV4()
+			if err != nil {
+				h.ServeHTTP(w, r)
+				return
+			}
+			id = uid.String()
+		}
+
+		w.Header().Set(RequestIDHeader, id)
+		ctx := logger.NewRequestIDContext(r.Context(), id)
+		h.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
 // LoggingErrorEncoder is a go-kit error encoder logging decorator.
 func LoggingErrorEncoder(logger logger.Logger, enc kithttp.ErrorEncoder) kithttp.ErrorEncoder {
 	return func(ctx context.Context, err error, w http.ResponseWriter) {
@@ -51,7 +77,7 @@ func LoggingErrorEncoder(logger logger.Logger, enc kithttp.ErrorEncoder) kithttp
 			errors.Contains(err, ErrMalformedEntity),
 			errors.Contains(err, ErrInvalidPolicy),
 			errors.Contains(err, ErrInvalidQueryParams):
-			logger.Error(err.Error())
+			logger.WithContext(ctx).Error(err.Error())
 		}
 
 		enc(ctx, err, w)
@@ -4,31 +4,15 @@
 package email
 
 import (
-	"bytes"
+	"io"
 	"net/mail"
 	"strconv"
-	"text/template"
 
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
 	"gopkg.in/gomail.v2"
 )
 
-var (
-	// ErrMissingEmailTemplate missing email template file
-	errMissingEmailTemplate = errors.New("Missing e-mail template file")
-	errParseTemplate        = errors.New("Parse e-mail template failed")
-	errExecTemplate         = errors.New("Execute e-mail template failed")
-	errSendMail             = errors.New("Sending e-mail failed")
-)
-
-type email struct {
-	To      []string
-	From    string
-	Subject string
-	Header  string
-	Content string
-	Footer  string
-}
+var errSendMail = errors.New("Sending e-mail failed")
 
 // Config email agent configuration.
 type Config struct {
@@ -38,14 +22,47 @@ type Config struct {
 	Password    string
 	FromAddress string
 	FromName    string
-	Template    string
+	// TemplatesDir is the optional directory of templates overriding
+	// the built-in ones, laid out as <locale>/<template>.tmpl.
+	TemplatesDir string
+	// Locale is the locale used when the message doesn't specify one,
+	// or when the template is missing in the message locale.
+	Locale string
+}
+
+// Attachment represents the file attached to the e-mail.
+type Attachment struct {
+	Name string
+	Data []byte
+}
+
+// Branding contains the variables used to brand the e-mail sent on behalf
+// of the organization. Empty values fall back to the platform defaults.
+type Branding struct {
+	Name         string
+	LogoURL      string
+	PrimaryColor string
+	SupportEmail string
+	Footer       string
+}
+
+// Message represents the e-mail rendered from the named template.
+type Message struct {
+	To       []string
+	From     string
+	Template string
+	Locale   string
+	Branding Branding
+	// Data contains the template specific values.
+	Data        map[string]interface{}
+	Attachments []Attachment
 }
 
 // Agent for mailing
 type Agent struct {
-	conf *Config
-	tmpl *template.Template
-	dial *gomail.Dialer
+	conf      *Config
+	templates *Templates
+	dial      *gomail.Dialer
 }
 
 // New creates new email agent
@@ -59,43 +76,42 @@ func New(c *Config) (*Agent, error) {
 	d := gomail.NewDialer(c.Host, port, c.Username, c.Password)
 	a.dial = d
 
-	tmpl, err := template.ParseFiles(c.Template)
+	tmpls, err := NewTemplates(c.TemplatesDir, c.Locale)
 	if err != nil {
-		return a, errors.Wrap(errParseTemplate, err)
+		return a, err
 	}
-	a.tmpl = tmpl
+	a.templates = tmpls
 	return a, nil
 }
 
-// Send sends e-mail
-func (a *Agent) Send(To []string, From, Subject, Header, Content, Footer string) error {
-	if a.tmpl == nil {
-		return errMissingEmailTemplate
-	}
-
-	buff := new(bytes.Buffer)
-	e := email{
-		To:      To,
-		From:    From,
-		Subject: Subject,
-		Header:  Header,
-		Content: Content,
-		Footer:  Footer,
-	}
-	if From == "" {
+// Send renders the message template and sends the e-mail with the plain
+// text part and the optional HTML part.
+func (a *Agent) Send(msg Message) error {
+	if msg.From == "" {
 		from := mail.Address{Name: a.conf.FromName, Address: a.conf.FromAddress}
-		e.From = from.String()
+		msg.From = from.String()
 	}
 
-	if err := a.tmpl.Execute(buff, e); err != nil {
-		return errors.Wrap(errExecTemplate, err)
+	r, err := a.templates.Render(msg)
+	if err != nil {
+		return err
 	}
 
 	m := gomail.NewMessage()
-	m.SetHeader("From", e.From)
-	m.SetHeader("To", To...)
-	m.SetHeader("Subject", Subject)
-	m.SetBody("text/plain", buff.String())
+	m.SetHeader("From", msg.From)
+	m.SetHeader("To", msg.To...)
+	m.SetHeader("Subject", r.Subject)
+	m.SetBody("text/plain", r.Text)
+	if r.HTML != "" {
+		m.AddAlternative("text/html", r.HTML)
+	}
+	for _, att := range msg.Attachments {
+		data := att.Data
+		m.Attach(att.Name, gomail.SetCopyFunc(func(w io.Writer) error {
+			_, err := w.Write(data)
+			return err
+		}))
+	}
 
 	if err := a.dial.DialAndSend(m); err != nil {
 		return errors.Wrap(errSendMail, err)
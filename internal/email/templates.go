@@ -0,0 +1,177 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package email
+
+import (
+	"bytes"
+	"embed"
+	htmltemplate "html/template"
+	"io/fs"
+	"os"
+	"path"
+	"strings"
+	texttemplate "text/template"
+
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+)
+
+const (
+	defLocale   = "en"
+	templateExt = ".tmpl"
+	subjectPart = "subject"
+	textPart    = "text"
+	htmlPart    = "html"
+)
+
+var (
+	// ErrMissingTemplate indicates that the template exists neither in the
+	// requested nor in the default locale.
+	ErrMissingTemplate = errors.New("Missing e-mail template")
+	errParseTemplate   = errors.New("Parse e-mail template failed")
+	errExecTemplate    = errors.New("Execute e-mail template failed")
+)
+
+//go:embed templates
+var builtin embed.FS
+
+// Rendered contains the parts of the rendered e-mail.
+type Rendered struct {
+	Subject string
+	Text    string
+	HTML    string
+}
+
+type template struct {
+	text *texttemplate.Template
+	html *htmltemplate.Template
+}
+
+// Templates renders the named and localized e-mail templates. Every template
+// defines the "subject" and the "text" parts, and optionally the "html" part.
+type Templates struct {
+	locale    string
+	templates map[string]map[string]template
+}
+
+// NewTemplates loads the built-in templates, overridden by the templates
+// found in the provided directory.
+func NewTemplates(dir, locale string) (*Templates, error) {
+	if locale == "" {
+		locale = defLocale
+	}
+	t := &Templates{
+		locale:    locale,
+		templates: make(map[string]map[string]template),
+	}
+
+	fsys, err := fs.Sub(builtin, "templates")
+	if err != nil {
+		return nil, errors.Wrap(errParseTemplate, err)
+	}
+	if err := t.load(fsys); err != nil {
+		return nil, err
+	}
+	if dir != "" {
+		if err := t.load(os.DirFS(dir)); err != nil {
+			return nil, err
+		}
+	}
+
+	return t, nil
+}
+
+func (t *Templates) load(fsys fs.FS) error {
+	paths, err := fs.Glob(fsys, "*/*"+templateExt)
+	if err != nil {
+		return errors.Wrap(errParseTemplate, err)
+	}
+
+	for _, p := range paths {
+		data, err := fs.ReadFile(fsys, p)
+		if err != nil {
+			return errors.Wrap(errParseTemplate, err)
+		}
+
+		name := strings.TrimSuffix(path.Base(p), templateExt)
+		text, err := texttemplate.New(name).Parse(string(data))
+		if err != nil {
+			return errors.Wrap(errParseTemplate, err)
+		}
+		html, err := htmltemplate.New(name).Parse(string(data))
+		if err != nil {
+			return errors.Wrap(errParseTemplate, err)
+		}
+		if text.Lookup(subjectPart) == nil || text.Lookup(textPart) == nil {
+			return errors.Wrap(errParseTemplate, errors.New(p+" must define subject and text"))
+		}
+
+		locale := path.Dir(p)
+		if _, ok := t.templates[locale]; !ok {
+			t.templates[locale] = make(map[string]template)
+		}
+		t.templates[locale][name] = template{text: text, html: html}
+	}
+
+	return nil
+}
+
+// Render renders the message template in the message locale, falling back
+// to its base language and then to the default locale.
+func (t *Templates) Render(msg Message) (Rendered, error) {
+	tmpl, ok := t.lookup(msg.Template, msg.Locale)
+	if !ok {
+		return Rendered{}, errors.Wrap(ErrMissingTemplate, errors.New(msg.Template))
+	}
+
+	data := struct {
+		To       []string
+		From     string
+		Branding Branding
+		Data     map[string]interface{}
+	}{
+		To:       msg.To,
+		From:     msg.From,
+		Branding: msg.Branding,
+		Data:     msg.Data,
+	}
+
+	var r Rendered
+	var buf bytes.Buffer
+	if err := tmpl.text.ExecuteTemplate(&buf, subjectPart, data); err != nil {
+		return Rendered{}, errors.Wrap(errExecTemplate, err)
+	}
+	r.Subject = strings.TrimSpace(buf.String())
+
+	buf.Reset()
+	if err := tmpl.text.ExecuteTemplate(&buf, textPart, data); err != nil {
+		return Rendered{}, errors.Wrap(errExecTemplate, err)
+	}
+	r.Text = buf.String()
+
+	if tmpl.html.Lookup(htmlPart) != nil {
+		buf.Reset()
+		if err := tmpl.html.ExecuteTemplate(&buf, htmlPart, data); err != nil {
+			return Rendered{}, errors.Wrap(errExecTemplate, err)
+		}
+		r.HTML = buf.String()
+	}
+
+	return r, nil
+}
+
+func (t *Templates) lookup(name, locale string) (template, bool) {
+	locales := []string{locale}
+	if i := strings.IndexAny(locale, "-_"); i > 0 {
+		locales = append(locales, locale[:i])
+	}
+	locales = append(locales, t.locale, defLocale)
+
+	for _, l := range locales {
+		if tmpl, ok := t.templates[l][name]; ok {
+			return tmpl, true
+		}
+	}
+
+	return template{}, false
+}
@@ -0,0 +1,87 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package email_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/MainfluxLabs/mainflux/internal/email"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const deInvitation = `{{define "subject"}}Einladung{{end}}
+{{define "text"}}Sie wurden eingeladen: {{.Data.URL}}{{end}}`
+
+func TestRender(t *testing.T) {
+	dir := t.TempDir()
+	err := os.MkdirAll(filepath.Join(dir, "de"), 0o755)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	err = os.WriteFile(filepath.Join(dir, "de", "invitation.tmpl"), []byte(deInvitation), 0o644)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	tmpls, err := email.NewTemplates(dir, "en")
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	branding := email.Branding{
+		Name:         "Acme <Corp>",
+		PrimaryColor: "#ff0000",
+		SupportEmail: "support@acme.com",
+	}
+	data := map[string]interface{}{"URL": "http://localhost/accept?token=abc"}
+
+	cases := []struct {
+		desc    string
+		msg     email.Message
+		subject string
+		text    string
+		html    string
+		err     error
+	}{
+		{
+			desc:    "render branded invitation",
+			msg:     email.Message{Template: "invitation", Branding: branding, Data: data},
+			subject: "Invitation to Acme <Corp>",
+			text:    "support@acme.com",
+			html:    "Acme &lt;Corp&gt;",
+		},
+		{
+			desc:    "render invitation in overridden locale",
+			msg:     email.Message{Template: "invitation", Locale: "de", Data: data},
+			subject: "Einladung",
+			text:    "Sie wurden eingeladen: http://localhost/accept?token=abc",
+		},
+		{
+			desc:    "render invitation in regional variant of overridden locale",
+			msg:     email.Message{Template: "invitation", Locale: "de-AT", Data: data},
+			subject: "Einladung",
+			text:    "Sie wurden eingeladen",
+		},
+		{
+			desc:    "render invitation in unknown locale",
+			msg:     email.Message{Template: "invitation", Locale: "fr", Data: data},
+			subject: "Invitation",
+			text:    "Follow the link below to accept the invitation.",
+			html:    "Accept invitation",
+		},
+		{
+			desc: "render unknown template",
+			msg:  email.Message{Template: "unknown"},
+			err:  email.ErrMissingTemplate,
+		},
+	}
+
+	for _, tc := range cases {
+		r, err := tmpls.Render(tc.msg)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.subject, r.Subject, fmt.Sprintf("%s: expected subject %s got %s\n", tc.desc, tc.subject, r.Subject))
+		assert.True(t, strings.Contains(r.Text, tc.text), fmt.Sprintf("%s: expected text to contain %s got %s\n", tc.desc, tc.text, r.Text))
+		assert.True(t, strings.Contains(r.HTML, tc.html), fmt.Sprintf("%s: expected HTML to contain %s got %s\n", tc.desc, tc.html, r.HTML))
+	}
+}
@@ -0,0 +1,44 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package logger
+
+import (
+	"context"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/uber/jaeger-client-go"
+)
+
+const (
+	requestIDKey = "request_id"
+	traceIDKey   = "trace_id"
+)
+
+type requestIDCtxKey struct{}
+
+// NewRequestIDContext returns the context carrying the given request ID.
+func NewRequestIDContext(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDCtxKey{}, requestID)
+}
+
+// RequestIDFromContext returns the request ID carried by the context, if any.
+func RequestIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDCtxKey{}).(string)
+	return id
+}
+
+func contextFields(ctx context.Context) []interface{} {
+	var fields []interface{}
+	if id := RequestIDFromContext(ctx); id != "" {
+		fields = append(fields, requestIDKey, id)
+	}
+
+	if span := opentracing.SpanFromContext(ctx); span != nil {
+		if sc, ok := span.Context().(jaeger.SpanContext); ok {
+			fields = append(fields, traceIDKey, sc.TraceID().String())
+		}
+	}
+
+	return fields
+}
@@ -3,4 +3,10 @@
 
 // Package logger contains logger API definition, wrapper that
 // can be used around any other logger.
+//
+// Log entries are written in JSON format. Loggers derived using With and
+// WithContext add structured fields, such as the request and trace IDs, to
+// every entry so that the entries can be correlated across the services.
+// The log level is shared between the logger and the loggers derived from
+// it, and can be changed at runtime using SetLevel.
 package logger
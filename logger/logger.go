@@ -4,8 +4,10 @@
 package logger
 
 import (
+	"context"
 	"fmt"
 	"io"
+	"sync/atomic"
 	"time"
 
 	"github.com/go-kit/kit/log"
@@ -21,13 +23,22 @@ type Logger interface {
 	Warn(string)
 	// Error logs any object in JSON format on error level.
 	Error(string)
+	// With returns the logger which adds the given key-value pairs to every log entry.
+	With(keyvals ...interface{}) Logger
+	// WithContext returns the logger which adds the request and trace IDs
+	// carried by the given context to every log entry.
+	WithContext(ctx context.Context) Logger
+	// Level returns the current log level.
+	Level() Level
+	// SetLevel changes the log level of the logger and all loggers derived from it.
+	SetLevel(Level)
 }
 
 var _ Logger = (*logger)(nil)
 
 type logger struct {
 	kitLogger log.Logger
-	level     Level
+	level     *int32
 }
 
 // New returns wrapped go kit logger.
@@ -39,29 +50,50 @@ func New(out io.Writer, levelText string) (Logger, error) {
 	}
 	l := log.NewJSONLogger(log.NewSyncWriter(out))
 	l = log.With(l, "ts", log.DefaultTimestampUTC)
-	return &logger{l, level}, err
+	lvl := int32(level)
+	return &logger{l, &lvl}, err
 }
 
 func (l logger) Debug(msg string) {
-	if Debug.isAllowed(l.level) {
+	if Debug.isAllowed(l.Level()) {
 		l.kitLogger.Log("level", Debug.String(), "message", msg)
 	}
 }
 
 func (l logger) Info(msg string) {
-	if Info.isAllowed(l.level) {
+	if Info.isAllowed(l.Level()) {
 		l.kitLogger.Log("level", Info.String(), "message", msg)
 	}
 }
 
 func (l logger) Warn(msg string) {
-	if Warn.isAllowed(l.level) {
+	if Warn.isAllowed(l.Level()) {
 		l.kitLogger.Log("level", Warn.String(), "message", msg)
 	}
 }
 
 func (l logger) Error(msg string) {
-	if Error.isAllowed(l.level) {
+	if Error.isAllowed(l.Level()) {
 		l.kitLogger.Log("level", Error.String(), "message", msg)
 	}
 }
+
+func (l logger) With(keyvals ...interface{}) Logger {
+	if len(keyvals) == 0 {
+		return l
+	}
+
+	return &logger{log.With(l.kitLogger, keyvals...), l.level}
+}
+
+func (l logger) WithContext(ctx context.Context) Logger {
+	return l.With(contextFields(ctx)...)
+}
+
+func (l logger) Level() Level {
+	return Level(atomic.LoadInt32(l.level))
+}
+
+func (l logger) SetLevel(level Level) {
+	atomic.StoreInt32(l.level, int32(level))
+}
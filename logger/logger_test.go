@@ -4,13 +4,16 @@
 package logger_test
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"testing"
 
 	log "github.com/MainfluxLabs/mainflux/logger"
+	"github.com/opentracing/opentracing-go"
 	"github.com/stretchr/testify/assert"
+	"github.com/uber/jaeger-client-go"
 )
 
 var _ io.Writer = (*mockWriter)(nil)
@@ -39,6 +42,14 @@ type logMsg struct {
 	Message string `json:"message"`
 }
 
+type fieldsMsg struct {
+	Level     string `json:"level"`
+	Message   string `json:"message"`
+	Method    string `json:"method"`
+	RequestID string `json:"request_id"`
+	TraceID   string `json:"trace_id"`
+}
+
 func TestDebug(t *testing.T) {
 	cases := map[string]struct {
 		input    string
@@ -124,3 +135,66 @@ func TestError(t *testing.T) {
 		assert.Equal(t, tc.output, output, fmt.Sprintf("%s: expected %s got %s", desc, tc.output, output))
 	}
 }
+
+func TestWith(t *testing.T) {
+	writer := mockWriter{}
+	logger, _ := log.New(&writer, log.Info.String())
+
+	logger.With("method", "create_things").Info("input_string")
+	var output fieldsMsg
+	err := json.Unmarshal(writer.value, &output)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	expected := fieldsMsg{Level: log.Info.String(), Message: "input_string", Method: "create_things"}
+	assert.Equal(t, expected, output, fmt.Sprintf("expected %v got %v", expected, output))
+}
+
+func TestWithContext(t *testing.T) {
+	tracer, closer := jaeger.NewTracer("test", jaeger.NewConstSampler(true), jaeger.NewNullReporter())
+	defer closer.Close()
+	span := tracer.StartSpan("test")
+	defer span.Finish()
+	traceID := span.Context().(jaeger.SpanContext).TraceID().String()
+
+	cases := map[string]struct {
+		ctx    context.Context
+		output fieldsMsg
+	}{
+		"log without request context": {
+			ctx:    context.Background(),
+			output: fieldsMsg{Level: log.Info.String(), Message: "input_string"},
+		},
+		"log with request ID": {
+			ctx:    log.NewRequestIDContext(context.Background(), "request-id"),
+			output: fieldsMsg{Level: log.Info.String(), Message: "input_string", RequestID: "request-id"},
+		},
+		"log with request ID and trace ID": {
+			ctx:    opentracing.ContextWithSpan(log.NewRequestIDContext(context.Background(), "request-id"), span),
+			output: fieldsMsg{Level: log.Info.String(), Message: "input_string", RequestID: "request-id", TraceID: traceID},
+		},
+	}
+
+	for desc, tc := range cases {
+		writer := mockWriter{}
+		logger, _ := log.New(&writer, log.Info.String())
+		logger.WithContext(tc.ctx).Info("input_string")
+		var output fieldsMsg
+		err := json.Unmarshal(writer.value, &output)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", desc, err))
+		assert.Equal(t, tc.output, output, fmt.Sprintf("%s: expected %v got %v", desc, tc.output, output))
+	}
+}
+
+func TestSetLevel(t *testing.T) {
+	writer := mockWriter{}
+	logger, _ := log.New(&writer, log.Info.String())
+	derived := logger.With("method", "create_things")
+
+	logger.SetLevel(log.Debug)
+	assert.Equal(t, log.Debug, derived.Level(), fmt.Sprintf("expected %s got %s", log.Debug, derived.Level()))
+
+	derived.Debug("input_string")
+	output, err := writer.Read()
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	expected := logMsg{log.Debug.String(), "input_string"}
+	assert.Equal(t, expected, output, fmt.Sprintf("expected %s got %s", expected, output))
+}
@@ -3,6 +3,8 @@
 
 package logger
 
+import "context"
+
 var _ Logger = (*loggerMock)(nil)
 
 type loggerMock struct{}
@@ -23,3 +25,18 @@ func (l loggerMock) Warn(msg string) {
 
 func (l loggerMock) Error(msg string) {
 }
+
+func (l loggerMock) With(keyvals ...interface{}) Logger {
+	return l
+}
+
+func (l loggerMock) WithContext(ctx context.Context) Logger {
+	return l
+}
+
+func (l loggerMock) Level() Level {
+	return Debug
+}
+
+func (l loggerMock) SetLevel(level Level) {
+}
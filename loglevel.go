@@ -0,0 +1,51 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mainflux
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/MainfluxLabs/mainflux/logger"
+)
+
+// LogLevelInfo contains log level endpoint request and response.
+type LogLevelInfo struct {
+	// Level contains the service log level.
+	Level string `json:"level"`
+}
+
+// LogLevel exposes an HTTP handler for retrieving and changing the service
+// log level at runtime. The new level is set using PUT request with the
+// LogLevelInfo body.
+func LogLevel(l logger.Logger) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+		case http.MethodPut:
+			var req LogLevelInfo
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+
+			var level logger.Level
+			if err := level.UnmarshalText(req.Level); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+
+			l.SetLevel(level)
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		w.Header().Set(contentType, "application/json")
+		res := LogLevelInfo{Level: l.Level().String()}
+		if err := json.NewEncoder(w).Encode(res); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+}
@@ -0,0 +1,80 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mainflux_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/MainfluxLabs/mainflux"
+	"github.com/MainfluxLabs/mainflux/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogLevel(t *testing.T) {
+	l, err := logger.New(io.Discard, logger.Info.String())
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc   string
+		method string
+		body   string
+		code   int
+		level  string
+	}{
+		{
+			desc:   "retrieve log level",
+			method: http.MethodGet,
+			code:   http.StatusOK,
+			level:  logger.Info.String(),
+		},
+		{
+			desc:   "change log level",
+			method: http.MethodPut,
+			body:   `{"level":"debug"}`,
+			code:   http.StatusOK,
+			level:  logger.Debug.String(),
+		},
+		{
+			desc:   "change log level to invalid level",
+			method: http.MethodPut,
+			body:   `{"level":"verbose"}`,
+			code:   http.StatusBadRequest,
+		},
+		{
+			desc:   "change log level with malformed body",
+			method: http.MethodPut,
+			body:   `{"level":`,
+			code:   http.StatusBadRequest,
+		},
+		{
+			desc:   "change log level with unsupported method",
+			method: http.MethodPost,
+			body:   `{"level":"error"}`,
+			code:   http.StatusMethodNotAllowed,
+		},
+	}
+
+	for _, tc := range cases {
+		req := httptest.NewRequest(tc.method, "/log-level", strings.NewReader(tc.body))
+		rec := httptest.NewRecorder()
+		mainflux.LogLevel(l).ServeHTTP(rec, req)
+		assert.Equal(t, tc.code, rec.Code, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.code, rec.Code))
+		if tc.code != http.StatusOK {
+			continue
+		}
+
+		var res mainflux.LogLevelInfo
+		err := json.NewDecoder(rec.Body).Decode(&res)
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+		assert.Equal(t, tc.level, res.Level, fmt.Sprintf("%s: expected level %s got %s", tc.desc, tc.level, res.Level))
+		assert.Equal(t, tc.level, l.Level().String(), fmt.Sprintf("%s: expected logger level %s got %s", tc.desc, tc.level, l.Level()))
+	}
+}
@@ -113,8 +113,14 @@ func (as *adapterService) Publish(ctx context.Context, m Message) error {
 		payload = []byte(jo)
 	}
 
+	id, err := messaging.NewID()
+	if err != nil {
+		return err
+	}
+
 	// Publish on Mainflux Message broker
 	msg := messaging.Message{
+		Id:        id,
 		Publisher: thingID,
 		Protocol:  protocol,
 		Channel:   chanID,
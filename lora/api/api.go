@@ -12,9 +12,9 @@ import (
 )
 
 // MakeHandler returns a HTTP handler for API endpoints.
-func MakeHandler() http.Handler {
+func MakeHandler(checks ...mainflux.HealthCheck) http.Handler {
 	r := bone.New()
-	r.GetFunc("/health", mainflux.Health("lora-adapter"))
+	r.GetFunc("/health", mainflux.Health("lora-adapter", checks...))
 	r.Handle("/metrics", promhttp.Handler())
 
 	return r
@@ -0,0 +1,152 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package modbus
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+	"github.com/MainfluxLabs/senml"
+)
+
+const protocol = "modbus"
+
+// ErrConnect indicates failure to connect to a Modbus device.
+var ErrConnect = errors.New("failed to connect to modbus device")
+
+// Service specifies an API that must be fullfiled by the domain service
+// implementation, and all of its decorators (e.g. logging & metrics).
+type Service interface {
+	// Poll reads all configured registers of the device and publishes
+	// them as a SenML message to the device channel.
+	Poll(ctx context.Context, dev Device) error
+}
+
+var _ Service = (*adapterService)(nil)
+
+type adapterService struct {
+	publisher messaging.Publisher
+	dial      Dialer
+	mu        sync.Mutex
+	clients   map[string]Client
+}
+
+// New instantiates the Modbus adapter implementation.
+func New(publisher messaging.Publisher, dial Dialer) Service {
+	return &adapterService{
+		publisher: publisher,
+		dial:      dial,
+		clients:   make(map[string]Client),
+	}
+}
+
+func (as *adapterService) Poll(ctx context.Context, dev Device) error {
+	client, err := as.client(dev.Address)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	t := float64(now.UnixNano()) / 1e9
+
+	var records []senml.Record
+	for _, r := range dev.Registers {
+		rec, err := read(client, dev.SlaveID, r)
+		if err != nil {
+			as.drop(dev.Address)
+			return err
+		}
+		rec.Time = t
+		records = append(records, rec)
+	}
+
+	if len(records) == 0 {
+		return nil
+	}
+
+	payload, err := senml.Encode(senml.Pack{Records: records}, senml.JSON)
+	if err != nil {
+		return err
+	}
+
+	id, err := messaging.NewID()
+	if err != nil {
+		return err
+	}
+
+	msg := messaging.Message{
+		Id:        id,
+		Channel:   dev.ChannelID,
+		Subtopic:  dev.Subtopic,
+		Publisher: dev.ThingID,
+		Protocol:  protocol,
+		Payload:   payload,
+		Created:   now.UnixNano(),
+	}
+
+	return as.publisher.Publish(msg.Channel, msg)
+}
+
+func read(client Client, slaveID uint8, r Register) (senml.Record, error) {
+	rec := senml.Record{
+		Name: r.Name,
+		Unit: r.Unit,
+	}
+
+	switch r.Kind {
+	case Coil, DiscreteInput:
+		bits, err := client.ReadBits(slaveID, r.Kind, r.Address, 1)
+		if err != nil {
+			return senml.Record{}, errors.Wrap(ErrRead, err)
+		}
+		if len(bits) == 0 {
+			return senml.Record{}, ErrRead
+		}
+		b := bits[0]
+		rec.BoolValue = &b
+	default:
+		words, err := client.ReadRegisters(slaveID, r.Kind, r.Address, r.Quantity())
+		if err != nil {
+			return senml.Record{}, errors.Wrap(ErrRead, err)
+		}
+		v, err := decode(r, words)
+		if err != nil {
+			return senml.Record{}, err
+		}
+		rec.Value = &v
+	}
+
+	return rec, nil
+}
+
+func (as *adapterService) client(address string) (Client, error) {
+	as.mu.Lock()
+	defer as.mu.Unlock()
+
+	if c, ok := as.clients[address]; ok {
+		return c, nil
+	}
+
+	c, err := as.dial(address)
+	if err != nil {
+		return nil, errors.Wrap(ErrConnect, err)
+	}
+	as.clients[address] = c
+
+	return c, nil
+}
+
+// drop closes and forgets the client so the next poll reconnects.
+func (as *adapterService) drop(address string) {
+	as.mu.Lock()
+	defer as.mu.Unlock()
+
+	if c, ok := as.clients[address]; ok {
+		c.Close()
+		delete(as.clients, address)
+	}
+}
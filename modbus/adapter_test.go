@@ -0,0 +1,178 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package modbus_test
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/modbus"
+	"github.com/MainfluxLabs/mainflux/modbus/mocks"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/senml"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	address  = "plc-1:502"
+	thingID  = "thingID-1"
+	chanID   = "chanID-1"
+	subtopic = "line1"
+)
+
+func newService(pub *mocks.Publisher) modbus.Service {
+	bits := math.Float32bits(21.5)
+	registers := map[uint16]uint16{
+		0: 1234,
+		1: 0xFFFE,
+		2: uint16(bits >> 16),
+		3: uint16(bits),
+		4: 1,
+		5: 0,
+	}
+	client := mocks.NewClient(registers, map[uint16]bool{0: true})
+	dialer := mocks.NewDialer(map[string]modbus.Client{address: client})
+
+	return modbus.New(pub, dialer)
+}
+
+func TestPoll(t *testing.T) {
+	pub := mocks.NewPublisher()
+	svc := newService(pub)
+
+	dev := modbus.Device{
+		Address:   address,
+		ThingID:   thingID,
+		ChannelID: chanID,
+		Subtopic:  subtopic,
+		Interval:  time.Second,
+	}
+
+	cases := []struct {
+		desc      string
+		address   string
+		registers []modbus.Register
+		values    []interface{}
+		err       error
+	}{
+		{
+			desc:    "poll scaled uint16 register",
+			address: address,
+			registers: []modbus.Register{
+				{Name: "voltage", Unit: "V", Kind: modbus.HoldingRegister, Address: 0, Type: modbus.Uint16, Scale: 0.1},
+			},
+			values: []interface{}{123.4},
+		},
+		{
+			desc:    "poll signed, float and 32-bit registers",
+			address: address,
+			registers: []modbus.Register{
+				{Name: "offset", Kind: modbus.InputRegister, Address: 1, Type: modbus.Int16},
+				{Name: "temperature", Unit: "Cel", Kind: modbus.HoldingRegister, Address: 2, Type: modbus.Float32},
+				{Name: "counter", Kind: modbus.HoldingRegister, Address: 4, Type: modbus.Uint32, SwapWords: true},
+			},
+			values: []interface{}{-2.0, 21.5, 1.0},
+		},
+		{
+			desc:    "poll coil",
+			address: address,
+			registers: []modbus.Register{
+				{Name: "running", Kind: modbus.Coil, Address: 0},
+			},
+			values: []interface{}{true},
+		},
+		{
+			desc:    "poll missing register",
+			address: address,
+			registers: []modbus.Register{
+				{Name: "missing", Kind: modbus.HoldingRegister, Address: 100, Type: modbus.Uint16},
+			},
+			err: modbus.ErrRead,
+		},
+		{
+			desc:    "poll unreachable device",
+			address: "unknown:502",
+			registers: []modbus.Register{
+				{Name: "voltage", Kind: modbus.HoldingRegister, Address: 0, Type: modbus.Uint16},
+			},
+			err: modbus.ErrConnect,
+		},
+	}
+
+	for _, tc := range cases {
+		pub.Messages = nil
+		dev.Address = tc.address
+		dev.Registers = tc.registers
+
+		err := svc.Poll(context.Background(), dev)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected error %s got %s\n", tc.desc, tc.err, err))
+		if tc.err != nil {
+			continue
+		}
+
+		require.Len(t, pub.Messages, 1, fmt.Sprintf("%s: expected one published message", tc.desc))
+		msg := pub.Messages[0]
+		assert.Equal(t, chanID, msg.Channel, fmt.Sprintf("%s: expected channel %s got %s\n", tc.desc, chanID, msg.Channel))
+		assert.Equal(t, thingID, msg.Publisher, fmt.Sprintf("%s: expected publisher %s got %s\n", tc.desc, thingID, msg.Publisher))
+		assert.Equal(t, subtopic, msg.Subtopic, fmt.Sprintf("%s: expected subtopic %s got %s\n", tc.desc, subtopic, msg.Subtopic))
+
+		pack, err := senml.Decode(msg.Payload, senml.JSON)
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error decoding payload: %s\n", tc.desc, err))
+		require.Len(t, pack.Records, len(tc.values))
+		for i, rec := range pack.Records {
+			assert.Equal(t, tc.registers[i].Name, rec.Name, fmt.Sprintf("%s: expected name %s got %s\n", tc.desc, tc.registers[i].Name, rec.Name))
+			switch v := tc.values[i].(type) {
+			case bool:
+				require.NotNil(t, rec.BoolValue)
+				assert.Equal(t, v, *rec.BoolValue, fmt.Sprintf("%s: expected value %v got %v\n", tc.desc, v, *rec.BoolValue))
+			case float64:
+				require.NotNil(t, rec.Value)
+				assert.InDelta(t, v, *rec.Value, 1e-6, fmt.Sprintf("%s: expected value %v got %v\n", tc.desc, v, *rec.Value))
+			}
+		}
+	}
+}
+
+func TestValidateDevice(t *testing.T) {
+	cases := []struct {
+		desc string
+		dev  modbus.Device
+		err  error
+	}{
+		{
+			desc: "valid device",
+			dev: modbus.Device{Address: address, ThingID: thingID, ChannelID: chanID, Interval: time.Second, Registers: []modbus.Register{
+				{Name: "voltage", Kind: modbus.HoldingRegister, Type: modbus.Uint16},
+			}},
+		},
+		{
+			desc: "device without channel",
+			dev:  modbus.Device{Address: address, ThingID: thingID, Interval: time.Second},
+			err:  modbus.ErrInvalidDevice,
+		},
+		{
+			desc: "register with unknown type",
+			dev: modbus.Device{Address: address, ThingID: thingID, ChannelID: chanID, Interval: time.Second, Registers: []modbus.Register{
+				{Name: "voltage", Kind: modbus.HoldingRegister, Type: "float64"},
+			}},
+			err: modbus.ErrInvalidRegister,
+		},
+		{
+			desc: "register with unknown kind",
+			dev: modbus.Device{Address: address, ThingID: thingID, ChannelID: chanID, Interval: time.Second, Registers: []modbus.Register{
+				{Name: "voltage", Kind: "memory", Type: modbus.Uint16},
+			}},
+			err: modbus.ErrInvalidRegister,
+		},
+	}
+
+	for _, tc := range cases {
+		err := tc.dev.Validate()
+		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected error %s got %s\n", tc.desc, tc.err, err))
+	}
+}
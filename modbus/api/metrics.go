@@ -0,0 +1,40 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !test
+
+package api
+
+import (
+	"context"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/modbus"
+	"github.com/go-kit/kit/metrics"
+)
+
+var _ modbus.Service = (*metricsMiddleware)(nil)
+
+type metricsMiddleware struct {
+	counter metrics.Counter
+	latency metrics.Histogram
+	svc     modbus.Service
+}
+
+// MetricsMiddleware instruments core service by tracking request count and latency.
+func MetricsMiddleware(svc modbus.Service, counter metrics.Counter, latency metrics.Histogram) modbus.Service {
+	return &metricsMiddleware{
+		counter: counter,
+		latency: latency,
+		svc:     svc,
+	}
+}
+
+func (mm *metricsMiddleware) Poll(ctx context.Context, dev modbus.Device) error {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "poll").Add(1)
+		mm.latency.With("method", "poll").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.Poll(ctx, dev)
+}
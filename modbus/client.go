@@ -0,0 +1,67 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package modbus
+
+import (
+	"encoding/binary"
+	"math"
+
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+)
+
+// ErrRead indicates failure while reading values from a device.
+var ErrRead = errors.New("failed to read from modbus device")
+
+// Client specifies an API for reading values from a Modbus device.
+type Client interface {
+	// ReadRegisters reads quantity 16-bit registers of the given kind
+	// starting at address.
+	ReadRegisters(slaveID uint8, kind string, address, quantity uint16) ([]uint16, error)
+
+	// ReadBits reads quantity coils or discrete inputs starting at address.
+	ReadBits(slaveID uint8, kind string, address, quantity uint16) ([]bool, error)
+
+	// Close closes the underlying connection.
+	Close() error
+}
+
+// Dialer creates a client connected to the device at the given address.
+type Dialer func(address string) (Client, error)
+
+// decode converts raw register words to a scaled numeric value.
+func decode(r Register, words []uint16) (float64, error) {
+	if len(words) < int(r.Quantity()) {
+		return 0, ErrRead
+	}
+
+	if r.Quantity() == 2 && r.SwapWords {
+		words = []uint16{words[1], words[0]}
+	}
+
+	var v float64
+	switch r.Type {
+	case Uint16:
+		v = float64(words[0])
+	case Int16:
+		v = float64(int16(words[0]))
+	case Uint32:
+		v = float64(uint32(words[0])<<16 | uint32(words[1]))
+	case Int32:
+		v = float64(int32(uint32(words[0])<<16 | uint32(words[1])))
+	case Float32:
+		b := make([]byte, 4)
+		binary.BigEndian.PutUint16(b, words[0])
+		binary.BigEndian.PutUint16(b[2:], words[1])
+		v = float64(math.Float32frombits(binary.BigEndian.Uint32(b)))
+	default:
+		return 0, ErrInvalidRegister
+	}
+
+	scale := r.Scale
+	if scale == 0 {
+		scale = 1
+	}
+
+	return v*scale + r.Offset, nil
+}
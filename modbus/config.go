@@ -0,0 +1,156 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package modbus
+
+import (
+	"io/ioutil"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/pelletier/go-toml"
+)
+
+// Supported register kinds.
+const (
+	HoldingRegister = "holding"
+	InputRegister   = "input"
+	Coil            = "coil"
+	DiscreteInput   = "discrete"
+)
+
+// Supported register value types.
+const (
+	Uint16  = "uint16"
+	Int16   = "int16"
+	Uint32  = "uint32"
+	Int32   = "int32"
+	Float32 = "float32"
+	Bool    = "bool"
+)
+
+var (
+	errOpenConfFile  = errors.New("unable to open configuration file")
+	errParseConfFile = errors.New("unable to parse configuration file")
+
+	// ErrInvalidRegister indicates a register with an unsupported kind or type.
+	ErrInvalidRegister = errors.New("invalid register configuration")
+
+	// ErrInvalidDevice indicates a device without address or channel mapping.
+	ErrInvalidDevice = errors.New("invalid device configuration")
+)
+
+// Register describes a single value read from a Modbus device and the
+// way it is converted to a SenML record.
+type Register struct {
+	Name    string  `toml:"name"`
+	Unit    string  `toml:"unit"`
+	Kind    string  `toml:"kind"`
+	Address uint16  `toml:"address"`
+	Type    string  `toml:"type"`
+	Scale   float64 `toml:"scale"`
+	Offset  float64 `toml:"offset"`
+	// SwapWords reverses the order of 16-bit words for 32-bit values
+	// (little-endian word order used by some vendors).
+	SwapWords bool `toml:"swap_words"`
+}
+
+// Device represents a Modbus slave polled by the adapter and the channel
+// its values are published to.
+type Device struct {
+	Name      string        `toml:"name"`
+	Address   string        `toml:"address"`
+	SlaveID   uint8         `toml:"slave_id"`
+	ThingID   string        `toml:"thing_id"`
+	ChannelID string        `toml:"channel_id"`
+	Subtopic  string        `toml:"subtopic"`
+	Interval  time.Duration `toml:"-"`
+	Period    string        `toml:"interval"`
+	Registers []Register    `toml:"registers"`
+}
+
+// Config contains the list of devices polled by the adapter.
+type Config struct {
+	Devices []Device `toml:"devices"`
+}
+
+// LoadConfig reads and validates the devices configuration file.
+func LoadConfig(path string, defInterval time.Duration) (Config, error) {
+	var cfg Config
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return cfg, errors.Wrap(errOpenConfFile, err)
+	}
+
+	if err := toml.Unmarshal(data, &cfg); err != nil {
+		return cfg, errors.Wrap(errParseConfFile, err)
+	}
+
+	for i := range cfg.Devices {
+		dev := &cfg.Devices[i]
+		dev.Interval = defInterval
+		if dev.Period != "" {
+			d, err := time.ParseDuration(dev.Period)
+			if err != nil {
+				return Config{}, errors.Wrap(errParseConfFile, err)
+			}
+			dev.Interval = d
+		}
+		if err := dev.Validate(); err != nil {
+			return Config{}, err
+		}
+	}
+
+	return cfg, nil
+}
+
+// Validate checks whether the device and its registers are well-formed.
+func (d Device) Validate() error {
+	if d.Address == "" || d.ChannelID == "" || d.ThingID == "" || d.Interval <= 0 {
+		return ErrInvalidDevice
+	}
+
+	for _, r := range d.Registers {
+		if err := r.Validate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Validate checks whether the register kind and type are supported.
+func (r Register) Validate() error {
+	if r.Name == "" {
+		return ErrInvalidRegister
+	}
+
+	switch r.Kind {
+	case Coil, DiscreteInput:
+		if r.Type != "" && r.Type != Bool {
+			return ErrInvalidRegister
+		}
+		return nil
+	case HoldingRegister, InputRegister:
+	default:
+		return ErrInvalidRegister
+	}
+
+	switch r.Type {
+	case Uint16, Int16, Uint32, Int32, Float32:
+		return nil
+	default:
+		return ErrInvalidRegister
+	}
+}
+
+// Quantity returns the number of 16-bit registers or bits the value occupies.
+func (r Register) Quantity() uint16 {
+	switch r.Type {
+	case Uint32, Int32, Float32:
+		return 2
+	default:
+		return 1
+	}
+}
@@ -0,0 +1,83 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/MainfluxLabs/mainflux/modbus"
+)
+
+var (
+	// ErrUnreachable is returned when dialing an unknown device address.
+	ErrUnreachable = errors.New("device unreachable")
+
+	errNoRegister = errors.New("illegal data address")
+)
+
+var _ modbus.Client = (*clientMock)(nil)
+
+type clientMock struct {
+	mu        sync.Mutex
+	registers map[uint16]uint16
+	bits      map[uint16]bool
+}
+
+// NewClient returns mock Modbus client serving the provided register
+// and bit values.
+func NewClient(registers map[uint16]uint16, bits map[uint16]bool) modbus.Client {
+	return &clientMock{
+		registers: registers,
+		bits:      bits,
+	}
+}
+
+// NewDialer returns mock dialer that connects to the provided clients by
+// their addresses.
+func NewDialer(clients map[string]modbus.Client) modbus.Dialer {
+	return func(address string) (modbus.Client, error) {
+		c, ok := clients[address]
+		if !ok {
+			return nil, ErrUnreachable
+		}
+		return c, nil
+	}
+}
+
+func (cm *clientMock) ReadRegisters(_ uint8, _ string, address, quantity uint16) ([]uint16, error) {
+	cm.mu.Lock()
+	defer cm.mu.Unlock()
+
+	var words []uint16
+	for i := uint16(0); i < quantity; i++ {
+		w, ok := cm.registers[address+i]
+		if !ok {
+			return nil, errNoRegister
+		}
+		words = append(words, w)
+	}
+
+	return words, nil
+}
+
+func (cm *clientMock) ReadBits(_ uint8, _ string, address, quantity uint16) ([]bool, error) {
+	cm.mu.Lock()
+	defer cm.mu.Unlock()
+
+	var bits []bool
+	for i := uint16(0); i < quantity; i++ {
+		b, ok := cm.bits[address+i]
+		if !ok {
+			return nil, errNoRegister
+		}
+		bits = append(bits, b)
+	}
+
+	return bits, nil
+}
+
+func (cm *clientMock) Close() error {
+	return nil
+}
@@ -0,0 +1,37 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"sync"
+
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+)
+
+// Publisher is a mock publisher which keeps published messages in memory.
+type Publisher struct {
+	mu       sync.Mutex
+	Messages []messaging.Message
+}
+
+var _ messaging.Publisher = (*Publisher)(nil)
+
+// NewPublisher returns mock message publisher.
+func NewPublisher() *Publisher {
+	return &Publisher{}
+}
+
+// Publish stores the message.
+func (pub *Publisher) Publish(_ string, msg messaging.Message) error {
+	pub.mu.Lock()
+	defer pub.mu.Unlock()
+
+	pub.Messages = append(pub.Messages, msg)
+	return nil
+}
+
+// Close does nothing.
+func (pub *Publisher) Close() error {
+	return nil
+}
@@ -0,0 +1,86 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package modbus
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+)
+
+const exceptionFlag = 0x80
+
+var functionCodes = map[string]byte{
+	Coil:            0x01,
+	DiscreteInput:   0x02,
+	HoldingRegister: 0x03,
+	InputRegister:   0x04,
+}
+
+// ErrMalformedResponse indicates an invalid response frame.
+var ErrMalformedResponse = errors.New("malformed modbus response")
+
+// ReadRequest returns the protocol data unit of a read request for the
+// given register kind.
+func ReadRequest(kind string, address, quantity uint16) ([]byte, error) {
+	fc, ok := functionCodes[kind]
+	if !ok {
+		return nil, ErrInvalidRegister
+	}
+
+	pdu := make([]byte, 5)
+	pdu[0] = fc
+	binary.BigEndian.PutUint16(pdu[1:], address)
+	binary.BigEndian.PutUint16(pdu[3:], quantity)
+
+	return pdu, nil
+}
+
+// ResponseData validates the response protocol data unit against the
+// request function code and returns its data bytes.
+func ResponseData(kind string, pdu []byte) ([]byte, error) {
+	fc := functionCodes[kind]
+	if len(pdu) < 2 {
+		return nil, ErrMalformedResponse
+	}
+
+	if pdu[0] == fc|exceptionFlag {
+		return nil, errors.Wrap(ErrRead, errors.New(fmt.Sprintf("exception code %d", pdu[1])))
+	}
+
+	if pdu[0] != fc || int(pdu[1]) != len(pdu)-2 {
+		return nil, ErrMalformedResponse
+	}
+
+	return pdu[2:], nil
+}
+
+// Words converts response data to 16-bit register values.
+func Words(data []byte, quantity uint16) ([]uint16, error) {
+	if len(data) != int(quantity)*2 {
+		return nil, ErrMalformedResponse
+	}
+
+	words := make([]uint16, quantity)
+	for i := range words {
+		words[i] = binary.BigEndian.Uint16(data[i*2:])
+	}
+
+	return words, nil
+}
+
+// Bits converts response data to coil or discrete input values.
+func Bits(data []byte, quantity uint16) ([]bool, error) {
+	if len(data) < (int(quantity)+7)/8 {
+		return nil, ErrMalformedResponse
+	}
+
+	bits := make([]bool, quantity)
+	for i := range bits {
+		bits[i] = data[i/8]&(1<<uint(i%8)) != 0
+	}
+
+	return bits, nil
+}
@@ -0,0 +1,36 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package modbus
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/logger"
+)
+
+// Start polls every configured device on its own schedule until the
+// context is canceled.
+func Start(ctx context.Context, svc Service, devices []Device, logger logger.Logger) {
+	for _, dev := range devices {
+		go poll(ctx, svc, dev, logger)
+	}
+}
+
+func poll(ctx context.Context, svc Service, dev Device, logger logger.Logger) {
+	ticker := time.NewTicker(dev.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := svc.Poll(ctx, dev); err != nil {
+				logger.Warn(fmt.Sprintf("Failed to poll device %s at %s: %s", dev.Name, dev.Address, err))
+			}
+		}
+	}
+}
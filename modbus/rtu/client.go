@@ -0,0 +1,129 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package rtu contains the Modbus RTU client implementation. The serial
+// line (baud rate, parity, stop bits) is expected to be configured by the
+// host, e.g. using stty, before the adapter opens the device file.
+package rtu
+
+import (
+	"encoding/binary"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/modbus"
+)
+
+const (
+	maxFrameLen = 256
+	crcLen      = 2
+)
+
+var _ modbus.Client = (*client)(nil)
+
+type client struct {
+	mu      sync.Mutex
+	port    *os.File
+	timeout time.Duration
+}
+
+// NewDialer returns a Dialer that opens Modbus RTU serial devices.
+func NewDialer(timeout time.Duration) modbus.Dialer {
+	return func(device string) (modbus.Client, error) {
+		port, err := os.OpenFile(device, os.O_RDWR, 0)
+		if err != nil {
+			return nil, err
+		}
+
+		return &client{port: port, timeout: timeout}, nil
+	}
+}
+
+func (c *client) ReadRegisters(slaveID uint8, kind string, address, quantity uint16) ([]uint16, error) {
+	data, err := c.read(slaveID, kind, address, quantity)
+	if err != nil {
+		return nil, err
+	}
+
+	return modbus.Words(data, quantity)
+}
+
+func (c *client) ReadBits(slaveID uint8, kind string, address, quantity uint16) ([]bool, error) {
+	data, err := c.read(slaveID, kind, address, quantity)
+	if err != nil {
+		return nil, err
+	}
+
+	return modbus.Bits(data, quantity)
+}
+
+func (c *client) Close() error {
+	return c.port.Close()
+}
+
+func (c *client) read(slaveID uint8, kind string, address, quantity uint16) ([]byte, error) {
+	pdu, err := modbus.ReadRequest(kind, address, quantity)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	frame := append([]byte{slaveID}, pdu...)
+	sum := crc(frame)
+	frame = append(frame, byte(sum), byte(sum>>8))
+
+	// Deadlines are best effort since not every serial driver supports them.
+	c.port.SetDeadline(time.Now().Add(c.timeout))
+
+	if _, err := c.port.Write(frame); err != nil {
+		return nil, err
+	}
+
+	// Slave ID, function code and byte count or exception code.
+	head := make([]byte, 3)
+	if _, err := io.ReadFull(c.port, head); err != nil {
+		return nil, err
+	}
+
+	n := int(head[2]) + crcLen
+	if head[1]&0x80 != 0 {
+		n = crcLen
+	}
+	if len(head)+n > maxFrameLen {
+		return nil, modbus.ErrMalformedResponse
+	}
+
+	rest := make([]byte, n)
+	if _, err := io.ReadFull(c.port, rest); err != nil {
+		return nil, err
+	}
+
+	res := append(head, rest...)
+	body := res[:len(res)-crcLen]
+	if head[0] != slaveID || binary.LittleEndian.Uint16(res[len(res)-crcLen:]) != crc(body) {
+		return nil, modbus.ErrMalformedResponse
+	}
+
+	return modbus.ResponseData(kind, body[1:])
+}
+
+// crc computes the Modbus CRC-16 checksum.
+func crc(data []byte) uint16 {
+	sum := uint16(0xFFFF)
+	for _, b := range data {
+		sum ^= uint16(b)
+		for i := 0; i < 8; i++ {
+			if sum&1 != 0 {
+				sum = sum>>1 ^ 0xA001
+				continue
+			}
+			sum >>= 1
+		}
+	}
+
+	return sum
+}
@@ -0,0 +1,107 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package tcp contains the Modbus TCP client implementation.
+package tcp
+
+import (
+	"encoding/binary"
+	"io"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/modbus"
+)
+
+const (
+	headerLen  = 7
+	protocolID = 0
+	maxPDULen  = 253
+)
+
+var _ modbus.Client = (*client)(nil)
+
+type client struct {
+	mu      sync.Mutex
+	conn    net.Conn
+	timeout time.Duration
+	txID    uint16
+}
+
+// NewDialer returns a Dialer that opens Modbus TCP connections.
+func NewDialer(timeout time.Duration) modbus.Dialer {
+	return func(address string) (modbus.Client, error) {
+		conn, err := net.DialTimeout("tcp", address, timeout)
+		if err != nil {
+			return nil, err
+		}
+
+		return &client{conn: conn, timeout: timeout}, nil
+	}
+}
+
+func (c *client) ReadRegisters(slaveID uint8, kind string, address, quantity uint16) ([]uint16, error) {
+	data, err := c.read(slaveID, kind, address, quantity)
+	if err != nil {
+		return nil, err
+	}
+
+	return modbus.Words(data, quantity)
+}
+
+func (c *client) ReadBits(slaveID uint8, kind string, address, quantity uint16) ([]bool, error) {
+	data, err := c.read(slaveID, kind, address, quantity)
+	if err != nil {
+		return nil, err
+	}
+
+	return modbus.Bits(data, quantity)
+}
+
+func (c *client) Close() error {
+	return c.conn.Close()
+}
+
+func (c *client) read(slaveID uint8, kind string, address, quantity uint16) ([]byte, error) {
+	pdu, err := modbus.ReadRequest(kind, address, quantity)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.txID++
+	frame := make([]byte, headerLen+len(pdu))
+	binary.BigEndian.PutUint16(frame, c.txID)
+	binary.BigEndian.PutUint16(frame[2:], protocolID)
+	binary.BigEndian.PutUint16(frame[4:], uint16(len(pdu)+1))
+	frame[6] = slaveID
+	copy(frame[headerLen:], pdu)
+
+	if err := c.conn.SetDeadline(time.Now().Add(c.timeout)); err != nil {
+		return nil, err
+	}
+
+	if _, err := c.conn.Write(frame); err != nil {
+		return nil, err
+	}
+
+	header := make([]byte, headerLen)
+	if _, err := io.ReadFull(c.conn, header); err != nil {
+		return nil, err
+	}
+
+	length := int(binary.BigEndian.Uint16(header[4:]))
+	if binary.BigEndian.Uint16(header) != c.txID || length < 2 || length-1 > maxPDULen || header[6] != slaveID {
+		return nil, modbus.ErrMalformedResponse
+	}
+
+	res := make([]byte, length-1)
+	if _, err := io.ReadFull(c.conn, res); err != nil {
+		return nil, err
+	}
+
+	return modbus.ResponseData(kind, res)
+}
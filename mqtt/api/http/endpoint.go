@@ -46,3 +46,44 @@ func listSubscriptions(svc mqtt.Service) endpoint.Endpoint {
 		return res, nil
 	}
 }
+
+func viewSparkplugGroup(svc mqtt.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(viewSparkplugGroupReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		g, err := svc.ViewSparkplugGroup(ctx, req.token, req.key, req.group)
+		if err != nil {
+			return nil, err
+		}
+
+		res := viewSparkplugGroupRes{
+			ID:        g.ID,
+			ChannelID: g.ChannelID,
+			Nodes:     []sparkplugNodeRes{},
+		}
+		for _, n := range g.Nodes {
+			node := sparkplugNodeRes{
+				ID:      n.ID,
+				Online:  n.Online,
+				Seq:     n.Seq,
+				BirthAt: timeRes(n.BirthAt),
+				DeathAt: timeRes(n.DeathAt),
+				Devices: []sparkplugDeviceRes{},
+			}
+			for _, d := range n.Devices {
+				node.Devices = append(node.Devices, sparkplugDeviceRes{
+					ID:      d.ID,
+					Online:  d.Online,
+					BirthAt: timeRes(d.BirthAt),
+					DeathAt: timeRes(d.DeathAt),
+				})
+			}
+			res.Nodes = append(res.Nodes, node)
+		}
+
+		return res, nil
+	}
+}
@@ -39,3 +39,21 @@ func (req listSubscriptionsReq) validate() error {
 
 	return nil
 }
+
+type viewSparkplugGroupReq struct {
+	group string
+	token string
+	key   string
+}
+
+func (req viewSparkplugGroupReq) validate() error {
+	if req.group == "" {
+		return apiutil.ErrMissingID
+	}
+
+	if req.token == "" && req.key == "" {
+		return errAuthHeader
+	}
+
+	return nil
+}
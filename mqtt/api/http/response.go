@@ -2,7 +2,12 @@
 // SPDX-License-Identifier: Apache-2.0
 package http
 
-import "github.com/MainfluxLabs/mainflux"
+import (
+	"net/http"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux"
+)
 
 var _ mainflux.Response = (*listSubscriptionsRes)(nil)
 
@@ -37,3 +42,48 @@ type pageRes struct {
 	Offset uint64 `json:"offset"`
 	Limit  uint64 `json:"limit"`
 }
+
+var _ mainflux.Response = (*viewSparkplugGroupRes)(nil)
+
+type viewSparkplugGroupRes struct {
+	ID        string             `json:"id"`
+	ChannelID string             `json:"channel_id"`
+	Nodes     []sparkplugNodeRes `json:"nodes"`
+}
+
+func (res viewSparkplugGroupRes) Code() int {
+	return http.StatusOK
+}
+
+func (res viewSparkplugGroupRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res viewSparkplugGroupRes) Empty() bool {
+	return false
+}
+
+type sparkplugNodeRes struct {
+	ID      string               `json:"id"`
+	Online  bool                 `json:"online"`
+	Seq     uint64               `json:"seq"`
+	BirthAt *time.Time           `json:"birth_at,omitempty"`
+	DeathAt *time.Time           `json:"death_at,omitempty"`
+	Devices []sparkplugDeviceRes `json:"devices"`
+}
+
+type sparkplugDeviceRes struct {
+	ID      string     `json:"id"`
+	Online  bool       `json:"online"`
+	BirthAt *time.Time `json:"birth_at,omitempty"`
+	DeathAt *time.Time `json:"death_at,omitempty"`
+}
+
+// timeRes returns nil for the zero time, so it's omitted from the response.
+func timeRes(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+
+	return &t
+}
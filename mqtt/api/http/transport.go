@@ -29,7 +29,7 @@ const (
 )
 
 // MakeHandler returns a HTTP handler for API endpoints.
-func MakeHandler(tracer opentracing.Tracer, svc mqtt.Service, logger logger.Logger) http.Handler {
+func MakeHandler(tracer opentracing.Tracer, svc mqtt.Service, logger logger.Logger, checks ...mainflux.HealthCheck) http.Handler {
 	opts := []kithttp.ServerOption{
 		kithttp.ServerErrorEncoder(apiutil.LoggingErrorEncoder(logger, encodeError)),
 	}
@@ -43,10 +43,18 @@ func MakeHandler(tracer opentracing.Tracer, svc mqtt.Service, logger logger.Logg
 		opts...,
 	))
 
-	r.GetFunc("/health", mainflux.Health("mqtt"))
+	r.Get("/sparkplug/groups/:group", kithttp.NewServer(
+		kitot.TraceServer(tracer, "view_sparkplug_group")(viewSparkplugGroup(svc)),
+		decodeViewSparkplugGroup,
+		encodeResponse,
+		opts...,
+	))
+
+	r.GetFunc("/health", mainflux.Health("mqtt", checks...))
 	r.Handle("/metrics", promhttp.Handler())
+	r.Handle("/log-level", mainflux.LogLevel(logger))
 
-	return r
+	return apiutil.RequestIDMiddleware(apiutil.LocaleMiddleware(r))
 }
 
 func decodeListSubscriptions(ctx context.Context, r *http.Request) (interface{}, error) {
@@ -71,6 +79,14 @@ func decodeListSubscriptions(ctx context.Context, r *http.Request) (interface{},
 	}, nil
 }
 
+func decodeViewSparkplugGroup(_ context.Context, r *http.Request) (interface{}, error) {
+	return viewSparkplugGroupReq{
+		group: bone.GetValue(r, "group"),
+		token: apiutil.ExtractBearerToken(r),
+		key:   apiutil.ExtractThingKey(r),
+	}, nil
+}
+
 func encodeResponse(_ context.Context, w http.ResponseWriter, response interface{}) error {
 	w.Header().Set("Content-Type", contentType)
 
@@ -89,7 +105,7 @@ func encodeResponse(_ context.Context, w http.ResponseWriter, response interface
 	return json.NewEncoder(w).Encode(response)
 }
 
-func encodeError(_ context.Context, err error, w http.ResponseWriter) {
+func encodeError(ctx context.Context, err error, w http.ResponseWriter) {
 	switch {
 	case errors.Contains(err, apiutil.ErrMalformedEntity),
 		errors.Contains(err, apiutil.ErrInvalidQueryParams):
@@ -98,6 +114,8 @@ func encodeError(_ context.Context, err error, w http.ResponseWriter) {
 		err == apiutil.ErrBearerToken:
 	case errors.Contains(err, errors.ErrAuthorization):
 		w.WriteHeader(http.StatusForbidden)
+	case errors.Contains(err, errors.ErrNotFound):
+		w.WriteHeader(http.StatusNotFound)
 	case errors.Contains(err, apiutil.ErrUnsupportedContentType):
 		w.WriteHeader(http.StatusUnsupportedMediaType)
 	default:
@@ -106,7 +124,7 @@ func encodeError(_ context.Context, err error, w http.ResponseWriter) {
 
 	if errorVal, ok := err.(errors.Error); ok {
 		w.Header().Set("Content-Type", contentType)
-		if err := json.NewEncoder(w).Encode(apiutil.ErrorRes{Err: errorVal.Msg()}); err != nil {
+		if err := json.NewEncoder(w).Encode(apiutil.NewErrorRes(ctx, errorVal)); err != nil {
 			w.WriteHeader(http.StatusInternalServerError)
 		}
 	}
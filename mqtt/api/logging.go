@@ -29,12 +29,13 @@ func LoggingMiddleware(svc mqtt.Service, logger log.Logger) mqtt.Service {
 
 func (lm *loggingMiddleware) ListSubscriptions(ctx context.Context, chanID, token, key string, pm mqtt.PageMetadata) (page mqtt.Page, err error) {
 	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "list_subscriptions", "latency", time.Since(begin).String())
 		message := fmt.Sprintf("Method list_subscriptions took %s to complete", time.Since(begin))
 		if err != nil {
-			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
 			return
 		}
-		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+		l.Info(fmt.Sprintf("%s without errors.", message))
 	}(time.Now())
 
 	return lm.svc.ListSubscriptions(ctx, chanID, token, key, pm)
@@ -42,12 +43,13 @@ func (lm *loggingMiddleware) ListSubscriptions(ctx context.Context, chanID, toke
 
 func (lm *loggingMiddleware) CreateSubscription(ctx context.Context, sub mqtt.Subscription) (err error) {
 	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "create_subscription", "latency", time.Since(begin).String())
 		message := fmt.Sprintf("Method create_subscription took %s to complete", time.Since(begin))
 		if err != nil {
-			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
 			return
 		}
-		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+		l.Info(fmt.Sprintf("%s without errors.", message))
 	}(time.Now())
 
 	return lm.svc.CreateSubscription(ctx, sub)
@@ -55,12 +57,13 @@ func (lm *loggingMiddleware) CreateSubscription(ctx context.Context, sub mqtt.Su
 
 func (lm *loggingMiddleware) RemoveSubscription(ctx context.Context, sub mqtt.Subscription) (err error) {
 	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "remove_subscription", "latency", time.Since(begin).String())
 		message := fmt.Sprintf("Method remove_subscription took %s to complete", time.Since(begin))
 		if err != nil {
-			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
 			return
 		}
-		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+		l.Info(fmt.Sprintf("%s without errors.", message))
 	}(time.Now())
 
 	return lm.svc.RemoveSubscription(ctx, sub)
@@ -68,12 +71,13 @@ func (lm *loggingMiddleware) RemoveSubscription(ctx context.Context, sub mqtt.Su
 
 func (lm *loggingMiddleware) HasClientID(ctx context.Context, clientID string) (err error) {
 	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "has_client_id", "latency", time.Since(begin).String())
 		message := fmt.Sprintf("Method has_client_id took %s to complete", time.Since(begin))
 		if err != nil {
-			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
 			return
 		}
-		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+		l.Info(fmt.Sprintf("%s without errors.", message))
 	}(time.Now())
 
 	return lm.svc.HasClientID(ctx, clientID)
@@ -81,13 +85,28 @@ func (lm *loggingMiddleware) HasClientID(ctx context.Context, clientID string) (
 
 func (lm *loggingMiddleware) UpdateStatus(ctx context.Context, sub mqtt.Subscription) (err error) {
 	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "update_status", "latency", time.Since(begin).String())
 		message := fmt.Sprintf("Method update_status took %s to complete", time.Since(begin))
 		if err != nil {
-			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
 			return
 		}
-		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+		l.Info(fmt.Sprintf("%s without errors.", message))
 	}(time.Now())
 
 	return lm.svc.UpdateStatus(ctx, sub)
 }
+
+func (lm *loggingMiddleware) ViewSparkplugGroup(ctx context.Context, token, key, group string) (g mqtt.SparkplugGroup, err error) {
+	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "view_sparkplug_group", "latency", time.Since(begin).String())
+		message := fmt.Sprintf("Method view_sparkplug_group for group %s took %s to complete", group, time.Since(begin))
+		if err != nil {
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		l.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ViewSparkplugGroup(ctx, token, key, group)
+}
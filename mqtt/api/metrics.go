@@ -76,3 +76,12 @@ func (ms *metricsMiddleware) UpdateStatus(ctx context.Context, sub mqtt.Subscrip
 
 	return ms.svc.UpdateStatus(ctx, sub)
 }
+
+func (ms *metricsMiddleware) ViewSparkplugGroup(ctx context.Context, token, key, group string) (mqtt.SparkplugGroup, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "view_sparkplug_group").Add(1)
+		ms.latency.With("method", "view_sparkplug_group").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ViewSparkplugGroup(ctx, token, key, group)
+}
@@ -0,0 +1,48 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mqtt
+
+import (
+	"github.com/MainfluxLabs/mainflux/pkg/drain"
+	"github.com/MainfluxLabs/mproxy/pkg/session"
+)
+
+var _ session.Handler = (*drainHandler)(nil)
+
+type drainHandler struct {
+	session.Handler
+	drainer drain.Drainer
+}
+
+// NewDrainHandler returns handler which rejects new connections once the
+// drain is started and tracks publishes to the message broker, including
+// publishes of the last wills of disconnected clients.
+func NewDrainHandler(h session.Handler, drainer drain.Drainer) session.Handler {
+	return &drainHandler{
+		Handler: h,
+		drainer: drainer,
+	}
+}
+
+func (dh *drainHandler) AuthConnect(c *session.Client) error {
+	if dh.drainer.Draining() {
+		return drain.ErrDraining
+	}
+
+	return dh.Handler.AuthConnect(c)
+}
+
+func (dh *drainHandler) Publish(c *session.Client, topic *string, payload *[]byte) {
+	done := dh.drainer.Track()
+	defer done()
+
+	dh.Handler.Publish(c, topic, payload)
+}
+
+func (dh *drainHandler) Disconnect(c *session.Client) {
+	done := dh.drainer.Track()
+	defer done()
+
+	dh.Handler.Disconnect(c)
+}
@@ -14,6 +14,7 @@ import (
 const (
 	channels = "channels"
 	messages = "messages"
+	latest   = "latest"
 )
 
 // Forwarder specifies MQTT forwarder interface API.
@@ -25,35 +26,53 @@ type Forwarder interface {
 
 type forwarder struct {
 	topic  string
+	latest messaging.Publisher
 	logger log.Logger
 }
 
-// NewForwarder returns new Forwarder implementation.
-func NewForwarder(topic string, logger log.Logger) Forwarder {
+// NewForwarder returns new Forwarder implementation. If the latest state
+// publisher is not nil, messages are also published to the latest state
+// topics channels/<channel_id>/latest/<subtopic>, which are expected to be
+// retained by the MQTT broker.
+func NewForwarder(topic string, latest messaging.Publisher, logger log.Logger) Forwarder {
 	return forwarder{
 		topic:  topic,
+		latest: latest,
 		logger: logger,
 	}
 }
 
 func (f forwarder) Forward(id string, sub messaging.Subscriber, pub messaging.Publisher) error {
-	return sub.Subscribe(id, f.topic, handle(pub, f.logger))
+	return sub.Subscribe(id, f.topic, handle(pub, f.latest, f.logger))
 }
 
-func handle(pub messaging.Publisher, logger log.Logger) handleFunc {
+func handle(pub, latestPub messaging.Publisher, logger log.Logger) handleFunc {
 	return func(msg messaging.Message) error {
-		if msg.Protocol == protocol {
+		// Messages published over MQTT are already delivered to the
+		// subscribers by the broker, so they only update the latest state.
+		forward := msg.Protocol != protocol
+		if !forward && latestPub == nil {
 			return nil
 		}
 		// Use concatenation instead of fmt.Sprintf for the
 		// sake of simplicity and performance.
-		topic := channels + "/" + msg.Channel + "/" + messages
+		var subtopic string
 		if msg.Subtopic != "" {
-			topic += "/" + strings.ReplaceAll(msg.Subtopic, ".", "/")
+			subtopic = "/" + strings.ReplaceAll(msg.Subtopic, ".", "/")
 		}
 		go func() {
-			if err := pub.Publish(topic, msg); err != nil {
-				logger.Warn(fmt.Sprintf("Failed to forward message: %s", err))
+			if forward {
+				topic := channels + "/" + msg.Channel + "/" + messages + subtopic
+				if err := pub.Publish(topic, msg); err != nil {
+					logger.Warn(fmt.Sprintf("Failed to forward message: %s", err))
+				}
+			}
+			if latestPub == nil {
+				return
+			}
+			latestTopic := channels + "/" + msg.Channel + "/" + latest + subtopic
+			if err := latestPub.Publish(latestTopic, msg); err != nil {
+				logger.Warn(fmt.Sprintf("Failed to publish latest state: %s", err))
 			}
 		}()
 		return nil
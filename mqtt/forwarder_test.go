@@ -0,0 +1,115 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mqtt_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/logger"
+	"github.com/MainfluxLabs/mainflux/mqtt"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const publishTimeout = 100 * time.Millisecond
+
+type subscriber struct {
+	handler messaging.MessageHandler
+}
+
+func (s *subscriber) Subscribe(id, topic string, h messaging.MessageHandler) error {
+	s.handler = h
+	return nil
+}
+
+func (s *subscriber) Unsubscribe(id, topic string) error {
+	return nil
+}
+
+func (s *subscriber) Close() error {
+	return nil
+}
+
+type publisher struct {
+	topics chan string
+}
+
+func newPublisher() publisher {
+	return publisher{topics: make(chan string, 10)}
+}
+
+func (p publisher) Publish(topic string, msg messaging.Message) error {
+	p.topics <- topic
+	return nil
+}
+
+func (p publisher) Close() error {
+	return nil
+}
+
+func (p publisher) published() []string {
+	var topics []string
+	timeout := time.After(publishTimeout)
+	for {
+		select {
+		case topic := <-p.topics:
+			topics = append(topics, topic)
+		case <-timeout:
+			return topics
+		}
+	}
+}
+
+func TestForward(t *testing.T) {
+	cases := []struct {
+		desc   string
+		msg    messaging.Message
+		latest bool
+		pub    []string
+		latPub []string
+	}{
+		{
+			desc:   "forward HTTP message",
+			msg:    messaging.Message{Channel: chanID, Subtopic: "temp.room", Protocol: "http"},
+			latest: true,
+			pub:    []string{fmt.Sprintf("channels/%s/messages/temp/room", chanID)},
+			latPub: []string{fmt.Sprintf("channels/%s/latest/temp/room", chanID)},
+		},
+		{
+			desc:   "forward MQTT message",
+			msg:    messaging.Message{Channel: chanID, Subtopic: "temp.room", Protocol: "mqtt"},
+			latest: true,
+			pub:    nil,
+			latPub: []string{fmt.Sprintf("channels/%s/latest/temp/room", chanID)},
+		},
+		{
+			desc:   "forward MQTT message without latest state",
+			msg:    messaging.Message{Channel: chanID, Protocol: "mqtt"},
+			latest: false,
+			pub:    nil,
+			latPub: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		pub, latPub := newPublisher(), newPublisher()
+		var latest messaging.Publisher
+		if tc.latest {
+			latest = latPub
+		}
+
+		sub := &subscriber{}
+		fwd := mqtt.NewForwarder("channels.>", latest, logger.NewMock())
+		err := fwd.Forward(clientID, sub, pub)
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+
+		err = sub.handler.Handle(tc.msg)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+		assert.Equal(t, tc.pub, pub.published(), fmt.Sprintf("%s: unexpected forwarded topics", tc.desc))
+		assert.Equal(t, tc.latPub, latPub.published(), fmt.Sprintf("%s: unexpected latest state topics", tc.desc))
+	}
+}
@@ -9,13 +9,17 @@ import (
 	"net/url"
 	"regexp"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/MainfluxLabs/mainflux/logger"
 	"github.com/MainfluxLabs/mainflux/mqtt/redis"
+	"github.com/MainfluxLabs/mainflux/pkg/acl"
 	"github.com/MainfluxLabs/mainflux/pkg/auth"
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
 	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+	"github.com/MainfluxLabs/mainflux/pkg/signature"
+	"github.com/MainfluxLabs/mainflux/pkg/sparkplug"
 	"github.com/MainfluxLabs/mproxy/pkg/session"
 )
 
@@ -39,13 +43,19 @@ const (
 	LogErrFailedPublish                = "failed to publish: "
 	LogErrFailedDisconnect             = "failed to disconnect: "
 	LogErrFailedPublishDisconnectEvent = "failed to publish disconnect event: "
-	logErrFailedParseSubtopic          = "failed to parse subtopic: "
 	LogErrFailedPublishConnectEvent    = "failed to publish connect event: "
 	LogErrFailedPublishToMsgBroker     = "failed to publish to mainflux message broker: "
+	LogInfoPublishedWill               = "published will of client_id %s to the topic %s"
+	LogErrFailedPublishWill            = "failed to publish will: "
+	LogInfoSessionTakenOver            = "session of client_id %s taken over by the newer connection"
+	LogErrFailedClaimSession           = "failed to claim session: "
+	LogErrFailedReleaseSession         = "failed to release session: "
+	LogErrFailedUpdateStatus           = "failed to update subscriptions status: "
 )
 
 var (
 	channelRegExp                = regexp.MustCompile(`^\/?channels\/([\w\-]+)\/messages(\/[^?]*)?(\?.*)?$`)
+	latestRegExp                 = regexp.MustCompile(`^\/?channels\/([\w\-]+)\/latest(\/[^?]*)?$`)
 	ErrMalformedSubtopic         = errors.New("malformed subtopic")
 	ErrClientNotInitialized      = errors.New("client is not initialized")
 	ErrMalformedTopic            = errors.New("malformed topic")
@@ -63,17 +73,31 @@ type handler struct {
 	logger     logger.Logger
 	es         redis.EventStore
 	service    Service
+	wills      *Wills
+	sessions   Sessions
+	verifier   signature.Verifier
+	sparkplug  *Sparkplug
+
+	// conns keeps the IDs of the connections handled by the replica.
+	mu    sync.Mutex
+	conns map[*session.Client]string
 }
 
-// NewHandler creates new Handler entity
+// NewHandler creates new Handler entity. Sparkplug B topics are accepted
+// only if the Sparkplug B support is not nil.
 func NewHandler(publishers []messaging.Publisher, es redis.EventStore,
-	logger logger.Logger, auth auth.Client, svc Service) session.Handler {
+	logger logger.Logger, auth auth.Client, svc Service, wills *Wills, sessions Sessions, verifier signature.Verifier, sp *Sparkplug) session.Handler {
 	return &handler{
 		es:         es,
 		logger:     logger,
 		publishers: publishers,
 		auth:       auth,
 		service:    svc,
+		wills:      wills,
+		sessions:   sessions,
+		verifier:   verifier,
+		sparkplug:  sp,
+		conns:      make(map[*session.Client]string),
 	}
 }
 
@@ -114,7 +138,30 @@ func (h *handler) AuthPublish(c *session.Client, topic *string, payload *[]byte)
 		return ErrMissingTopicPub
 	}
 
-	return h.authAccess(c.Username, *topic)
+	chanID, subtopic, err := h.authAccess(c.Username, *topic)
+	if err != nil {
+		return err
+	}
+
+	if payload == nil {
+		return nil
+	}
+
+	// Signed payload is replaced by the verified one before it's
+	// forwarded to the broker.
+	msg := messaging.Message{
+		Publisher: c.Username,
+		Channel:   chanID,
+		Subtopic:  subtopic,
+		Payload:   *payload,
+	}
+	p, err := h.verifier.Verify(context.Background(), msg)
+	if err != nil {
+		return err
+	}
+	*payload = p
+
+	return nil
 }
 
 // AuthSubscribe is called on device publish,
@@ -127,11 +174,28 @@ func (h *handler) AuthSubscribe(c *session.Client, topics *[]string) error {
 		return ErrMissingTopicSub
 	}
 
+	var chanIDs, subtopics []string
 	for _, v := range *topics {
-		if err := h.authAccess(c.Username, v); err != nil {
+		chanID, subtopic, err := h.parseFilter(v)
+		if err != nil {
 			return err
 		}
+		chanIDs = append(chanIDs, chanID)
+		subtopics = append(subtopics, subtopic)
+	}
+
+	if err := h.auth.AuthorizeBatch(context.Background(), chanIDs, c.Username); err != nil {
+		return err
+	}
 
+	for i, chanID := range chanIDs {
+		a, err := h.auth.TopicACL(context.Background(), chanID)
+		if err != nil {
+			return err
+		}
+		if !acl.Allows(a.Subscribe, c.Username, subtopics[i]) {
+			return errors.ErrAuthorization
+		}
 	}
 
 	return nil
@@ -145,6 +209,21 @@ func (h *handler) Connect(c *session.Client) {
 	}
 
 	h.logger.Info(fmt.Sprintf(LogInfoConnected, c.ID))
+
+	connID, err := messaging.NewID()
+	if err != nil {
+		h.logger.Error(LogErrFailedClaimSession + err.Error())
+		return
+	}
+	if err := h.sessions.Claim(context.Background(), c.ID, c.Username, connID); err != nil {
+		h.logger.Error(LogErrFailedClaimSession + err.Error())
+		return
+	}
+	h.mu.Lock()
+	h.conns[c] = connID
+	h.mu.Unlock()
+
+	h.updateStatus(c.ID, connected)
 }
 
 // Publish - after client successfully published
@@ -154,13 +233,23 @@ func (h *handler) Publish(c *session.Client, topic *string, payload *[]byte) {
 		return
 	}
 	h.logger.Info(fmt.Sprintf(LogInfoPublished, c.ID, *topic))
+
+	if err := h.publish(c.Username, *topic, *payload); err != nil {
+		h.logger.Error(LogErrFailedPublish + err.Error())
+	}
+}
+
+func (h *handler) publish(username, topic string, payload []byte) error {
+	if sparkplug.IsTopic(topic) {
+		return h.publishSparkplug(username, topic, payload)
+	}
+
 	// Topics are in the format:
 	// channels/<channel_id>/messages/<subtopic>/.../ct/<content_type>
 
-	channelParts := channelRegExp.FindStringSubmatch(*topic)
+	channelParts := channelRegExp.FindStringSubmatch(topic)
 	if len(channelParts) < 2 {
-		h.logger.Error(LogErrFailedPublish + (ErrMalformedTopic).Error())
-		return
+		return ErrMalformedTopic
 	}
 
 	chanID := channelParts[1]
@@ -168,19 +257,29 @@ func (h *handler) Publish(c *session.Client, topic *string, payload *[]byte) {
 
 	subtopic, err := parseSubtopic(subtopic)
 	if err != nil {
-		h.logger.Error(logErrFailedParseSubtopic + err.Error())
-		return
+		return err
+	}
+
+	id, err := messaging.NewID()
+	if err != nil {
+		return err
 	}
 
 	msg := messaging.Message{
+		Id:        id,
 		Protocol:  protocol,
 		Channel:   chanID,
 		Subtopic:  subtopic,
-		Publisher: c.Username,
-		Payload:   *payload,
+		Publisher: username,
+		Payload:   payload,
 		Created:   time.Now().UnixNano(),
 	}
+	h.send(msg)
+
+	return nil
+}
 
+func (h *handler) send(msg messaging.Message) {
 	for _, pub := range h.publishers {
 		if err := pub.Publish(msg.Channel, msg); err != nil {
 			h.logger.Error(LogErrFailedPublishToMsgBroker + err.Error())
@@ -203,8 +302,13 @@ func (h *handler) Subscribe(c *session.Client, topics *[]string) {
 
 	for _, s := range subs {
 		err = h.service.CreateSubscription(context.Background(), s)
-		if err != nil {
-			h.logger.Error(LogErrFailedSubscribe + (ErrSubscriptionAlreadyExists).Error())
+		switch {
+		case errors.Contains(err, errors.ErrConflict):
+			// Subscription is kept from the previous session of the
+			// client, possibly made through another replica.
+			h.updateStatus(c.ID, connected)
+		case err != nil:
+			h.logger.Error(LogErrFailedSubscribe + err.Error())
 			return
 		}
 	}
@@ -241,25 +345,159 @@ func (h *handler) Disconnect(c *session.Client) {
 	}
 
 	h.logger.Error(fmt.Sprintf(LogInfoDisconnected, c.ID, c.Username))
+
+	if !h.release(c) {
+		h.logger.Info(fmt.Sprintf(LogInfoSessionTakenOver, c.ID))
+		return
+	}
+
+	// The will remains registered only if the client did not send DISCONNECT.
+	if will, ok := h.wills.Pop(c.ID); ok {
+		h.publishWill(c, will)
+	}
+
 	if err := h.es.Disconnect(c.Username); err != nil {
 		h.logger.Error(LogErrFailedPublishDisconnectEvent + err.Error())
 	}
+
+	h.updateStatus(c.ID, disconnected)
+}
+
+// release releases the session of the client connection and returns
+// whether the connection was the client session. The connection that was
+// never claimed as the session, e.g. the refused one, is handled as the
+// session of the client.
+func (h *handler) release(c *session.Client) bool {
+	h.mu.Lock()
+	connID, ok := h.conns[c]
+	delete(h.conns, c)
+	h.mu.Unlock()
+
+	if !ok {
+		return true
+	}
+
+	owner, err := h.sessions.Release(context.Background(), c.ID, connID)
+	if err != nil {
+		h.logger.Error(LogErrFailedReleaseSession + err.Error())
+		return true
+	}
+
+	return owner
+}
+
+func (h *handler) updateStatus(clientID, status string) {
+	sub := Subscription{
+		ClientID:  clientID,
+		Status:    status,
+		CreatedAt: float64(time.Now().UnixNano()) / float64(1e9),
+	}
+	if err := h.service.UpdateStatus(context.Background(), sub); err != nil {
+		h.logger.Error(LogErrFailedUpdateStatus + err.Error())
+	}
+}
+
+func (h *handler) publishWill(c *session.Client, will Will) {
+	if _, _, err := h.authAccess(c.Username, will.Topic); err != nil {
+		h.logger.Error(LogErrFailedPublishWill + err.Error())
+		return
+	}
+
+	if err := h.publish(c.Username, will.Topic, will.Payload); err != nil {
+		h.logger.Error(LogErrFailedPublishWill + err.Error())
+		return
+	}
+
+	h.logger.Info(fmt.Sprintf(LogInfoPublishedWill, c.ID, will.Topic))
+}
+
+// authAccess authorizes the thing to publish to the topic and returns the
+// channel and the subtopic of the topic.
+func (h *handler) authAccess(username string, topic string) (string, string, error) {
+	var chanID, subtopic string
+	var err error
+	switch {
+	case sparkplug.IsTopic(topic):
+		_, chanID, subtopic, err = h.parseSparkplugTopic(topic)
+	default:
+		chanID, subtopic, err = parseTopic(topic)
+	}
+	if err != nil {
+		return "", "", err
+	}
+
+	if err := h.auth.Authorize(context.Background(), chanID, username); err != nil {
+		return "", "", err
+	}
+
+	// Topic ACL of the channel restricts subtopics the thing may publish to.
+	a, err := h.auth.TopicACL(context.Background(), chanID)
+	if err != nil {
+		return "", "", err
+	}
+	if !acl.Allows(a.Publish, username, subtopic) {
+		return "", "", errors.ErrAuthorization
+	}
+
+	return chanID, subtopic, nil
+}
+
+// parseFilter returns the channel and the subtopic of the topic filter.
+// Latest state topics can be subscribed to, but not published to.
+func (h *handler) parseFilter(filter string) (string, string, error) {
+	switch {
+	case sparkplug.IsTopic(filter):
+		return h.parseSparkplugFilter(filter)
+	case latestRegExp.MatchString(filter):
+		return parseLatestFilter(filter)
+	default:
+		return parseTopic(filter)
+	}
+}
+
+// parseLatestFilter returns the channel and the subtopic of the latest state
+// topic filter, i.e. channels/<channel_id>/latest/<subtopic>.
+func parseLatestFilter(filter string) (string, string, error) {
+	parts := latestRegExp.FindStringSubmatch(filter)
+	if len(parts) < 3 {
+		return "", "", ErrMalformedTopic
+	}
+
+	subtopic, err := parseSubtopic(parts[2])
+	if err != nil {
+		return "", "", err
+	}
+
+	return parts[1], subtopic, nil
 }
 
-func (h *handler) authAccess(username string, topic string) error {
+func parseTopic(topic string) (string, string, error) {
+	chanID, err := parseChanID(topic)
+	if err != nil {
+		return "", "", err
+	}
+
+	subtopic, err := parseSubtopic(channelRegExp.FindStringSubmatch(topic)[2])
+	if err != nil {
+		return "", "", err
+	}
+
+	return chanID, subtopic, nil
+}
+
+func parseChanID(topic string) (string, error) {
 	// Topics are in the format:
 	// channels/<channel_id>/messages/<subtopic>/.../ct/<content_type>
 	if !channelRegExp.Match([]byte(topic)) {
-		return ErrMalformedTopic
+		return "", ErrMalformedTopic
 	}
 
 	channelParts := channelRegExp.FindStringSubmatch(topic)
 	if len(channelParts) < 1 {
-		return ErrMalformedTopic
+		return "", ErrMalformedTopic
 	}
 
-	chanID := channelParts[1]
-	return h.auth.Authorize(context.Background(), chanID, username)
+	return channelParts[1], nil
 }
 
 func parseSubtopic(subtopic string) (string, error) {
@@ -294,15 +532,7 @@ func parseSubtopic(subtopic string) (string, error) {
 func (h *handler) getSubcriptions(c *session.Client, topics *[]string) ([]Subscription, error) {
 	var subs []Subscription
 	for _, t := range *topics {
-		channelAndSubtopic := channelRegExp.FindStringSubmatch(t)
-		if len(channelAndSubtopic) < 2 {
-			return nil, ErrMalformedTopic
-		}
-
-		chanID := channelAndSubtopic[1]
-		subtopic := channelAndSubtopic[2]
-
-		subtopic, err := parseSubtopic(subtopic)
+		chanID, subtopic, err := h.parseFilter(t)
 		if err != nil {
 			return nil, err
 		}
@@ -2,6 +2,7 @@ package mqtt_test
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"log"
 	"testing"
@@ -9,21 +10,29 @@ import (
 	"github.com/MainfluxLabs/mainflux/logger"
 	"github.com/MainfluxLabs/mainflux/mqtt"
 	"github.com/MainfluxLabs/mainflux/mqtt/mocks"
+	"github.com/MainfluxLabs/mainflux/pkg/acl"
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
 	"github.com/MainfluxLabs/mainflux/pkg/messaging"
 	pubmocks "github.com/MainfluxLabs/mainflux/pkg/mocks"
+	"github.com/MainfluxLabs/mainflux/pkg/signature"
+	"github.com/MainfluxLabs/mainflux/pkg/sparkplug"
 	"github.com/MainfluxLabs/mproxy/pkg/session"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protowire"
 )
 
 const (
 	thingID               = "513d02d2-16c1-4f23-98be-9e12f8fee898"
 	chanID                = "123e4567-e89b-12d3-a456-000000000001"
+	aclChanID             = "123e4567-e89b-12d3-a456-000000000002"
 	invalidID             = "invalidID"
 	clientID              = "clientID"
 	password              = "password"
 	subtopic              = "testSubtopic"
 	invalidChannelIDTopic = "channels/**/messages"
+	sparkplugGroup        = "plant"
+	sparkplugNode         = "node"
 )
 
 var (
@@ -34,6 +43,23 @@ var (
 	topics              = []string{topic}
 	invalidTopics       = []string{invalidTopic}
 	invalidChanIDTopics = []string{fmt.Sprintf(topicMsg, invalidTopic)}
+	aclTopic            = fmt.Sprintf(topicMsg, aclChanID)
+	allowedPubTopic     = fmt.Sprintf("%s/devices/%s/temperature", aclTopic, thingID)
+	deniedPubTopic      = fmt.Sprintf("%s/devices/%s/temperature", aclTopic, invalidID)
+	allowedSubTopics    = []string{fmt.Sprintf("%s/commands/%s/#", aclTopic, thingID)}
+	deniedSubTopics     = []string{fmt.Sprintf("%s/commands/#", aclTopic)}
+	topicACL            = acl.ACL{
+		Publish:   []string{"devices/{thing_id}/#"},
+		Subscribe: []string{"commands/{thing_id}/#"},
+	}
+	sparkplugTopic      = fmt.Sprintf("spBv1.0/%s/NBIRTH/%s", sparkplugGroup, sparkplugNode)
+	sparkplugCmdTopics  = []string{fmt.Sprintf("spBv1.0/%s/NCMD/%s", sparkplugGroup, sparkplugNode)}
+	unmappedSpTopic     = fmt.Sprintf("spBv1.0/%s/NBIRTH/%s", invalidID, sparkplugNode)
+	unmappedSpCmdTopics = []string{fmt.Sprintf("spBv1.0/%s/NCMD/%s", invalidID, sparkplugNode)}
+	sparkplugGroups     = mqtt.NewSparkplug(map[string]string{sparkplugGroup: chanID})
+	latestTopic         = fmt.Sprintf("channels/%s/latest", chanID)
+	latestTopics        = []string{latestTopic + "/#"}
+	deniedLatestTopics  = []string{fmt.Sprintf("channels/%s/latest/commands/#", aclChanID)}
 	//Test log messages for cases the handler does not provide a return value.
 	logBuffer     = bytes.Buffer{}
 	sessionClient = session.Client{
@@ -135,6 +161,48 @@ func TestAuthPublish(t *testing.T) {
 			topic:   &topic,
 			payload: payload,
 		},
+		{
+			desc:    "publish to subtopic allowed by topic ACL",
+			client:  &sessionClient,
+			err:     nil,
+			topic:   &allowedPubTopic,
+			payload: payload,
+		},
+		{
+			desc:    "publish to subtopic denied by topic ACL",
+			client:  &sessionClient,
+			err:     errors.ErrAuthorization,
+			topic:   &deniedPubTopic,
+			payload: payload,
+		},
+		{
+			desc:    "publish without subtopic to channel with topic ACL",
+			client:  &sessionClient,
+			err:     errors.ErrAuthorization,
+			topic:   &aclTopic,
+			payload: payload,
+		},
+		{
+			desc:    "publish to sparkplug topic of mapped group",
+			client:  &sessionClient,
+			err:     nil,
+			topic:   &sparkplugTopic,
+			payload: payload,
+		},
+		{
+			desc:    "publish to sparkplug topic of unmapped group",
+			client:  &sessionClient,
+			err:     mqtt.ErrMalformedTopic,
+			topic:   &unmappedSpTopic,
+			payload: payload,
+		},
+		{
+			desc:    "publish to latest state topic",
+			client:  &sessionClient,
+			err:     mqtt.ErrMalformedTopic,
+			topic:   &latestTopic,
+			payload: payload,
+		},
 	}
 
 	for _, tc := range cases {
@@ -188,6 +256,42 @@ func TestAuthSubscribe(t *testing.T) {
 			err:    nil,
 			topic:  &topics,
 		},
+		{
+			desc:   "subscribe to topics allowed by topic ACL",
+			client: &sessionClient,
+			err:    nil,
+			topic:  &allowedSubTopics,
+		},
+		{
+			desc:   "subscribe to topics denied by topic ACL",
+			client: &sessionClient,
+			err:    errors.ErrAuthorization,
+			topic:  &deniedSubTopics,
+		},
+		{
+			desc:   "subscribe to sparkplug topics of mapped group",
+			client: &sessionClient,
+			err:    nil,
+			topic:  &sparkplugCmdTopics,
+		},
+		{
+			desc:   "subscribe to sparkplug topics of unmapped group",
+			client: &sessionClient,
+			err:    mqtt.ErrMalformedTopic,
+			topic:  &unmappedSpCmdTopics,
+		},
+		{
+			desc:   "subscribe to latest state topics",
+			client: &sessionClient,
+			err:    nil,
+			topic:  &latestTopics,
+		},
+		{
+			desc:   "subscribe to latest state topics denied by topic ACL",
+			client: &sessionClient,
+			err:    errors.ErrAuthorization,
+			topic:  &deniedLatestTopics,
+		},
 	}
 
 	for _, tc := range cases {
@@ -317,9 +421,16 @@ func TestSubscribe(t *testing.T) {
 			topic:  topics,
 			logMsg: fmt.Sprintf(mqtt.LogInfoSubscribed, clientID, topics[0]),
 		},
+		{
+			desc:   "subscribe to topics subscribed in previous session",
+			client: &sessionClient,
+			topic:  topics,
+			logMsg: fmt.Sprintf(mqtt.LogInfoSubscribed, clientID, topics[0]),
+		},
 	}
 
 	for _, tc := range cases {
+		logBuffer.Reset()
 		handler.Subscribe(tc.client, &tc.topic)
 		assert.Contains(t, logBuffer.String(), tc.logMsg)
 	}
@@ -385,13 +496,109 @@ func TestDisconnect(t *testing.T) {
 	}
 }
 
+func TestDisconnectWithWill(t *testing.T) {
+	wills := mqtt.NewWills()
+	handler := newHandlerWithWills(wills)
+
+	cases := []struct {
+		desc   string
+		will   mqtt.Will
+		logMsg string
+	}{
+		{
+			desc:   "disconnect with will to authorized topic",
+			will:   mqtt.Will{Topic: topic, Payload: payload},
+			logMsg: fmt.Sprintf(mqtt.LogInfoPublishedWill, clientID, topic),
+		},
+		{
+			desc:   "disconnect with will to unauthorized topic",
+			will:   mqtt.Will{Topic: fmt.Sprintf(topicMsg, invalidID), Payload: payload},
+			logMsg: mqtt.LogErrFailedPublishWill,
+		},
+		{
+			desc:   "disconnect with will to malformed topic",
+			will:   mqtt.Will{Topic: invalidTopic, Payload: payload},
+			logMsg: mqtt.LogErrFailedPublishWill + mqtt.ErrMalformedTopic.Error(),
+		},
+	}
+
+	for _, tc := range cases {
+		logBuffer.Reset()
+		wills.Save(clientID, tc.will)
+		handler.Disconnect(&sessionClient)
+		assert.Contains(t, logBuffer.String(), tc.logMsg, tc.desc)
+
+		_, ok := wills.Pop(clientID)
+		assert.False(t, ok, fmt.Sprintf("%s: expected will to be discarded", tc.desc))
+	}
+}
+
+func TestDisconnectTakenOver(t *testing.T) {
+	wills := mqtt.NewWills()
+	handler := newHandlerWithWills(wills)
+
+	// Client reconnects before its previous connection is closed.
+	prev, next := sessionClient, sessionClient
+	handler.Connect(&prev)
+	handler.Connect(&next)
+	wills.Save(clientID, mqtt.Will{Topic: topic, Payload: payload})
+
+	logBuffer.Reset()
+	handler.Disconnect(&prev)
+	assert.Contains(t, logBuffer.String(), fmt.Sprintf(mqtt.LogInfoSessionTakenOver, clientID), "disconnect taken over connection")
+	assert.NotContains(t, logBuffer.String(), fmt.Sprintf(mqtt.LogInfoPublishedWill, clientID, topic), "disconnect taken over connection: expected will not to be published")
+
+	logBuffer.Reset()
+	handler.Disconnect(&next)
+	assert.NotContains(t, logBuffer.String(), fmt.Sprintf(mqtt.LogInfoSessionTakenOver, clientID), "disconnect latest connection")
+	assert.Contains(t, logBuffer.String(), fmt.Sprintf(mqtt.LogInfoPublishedWill, clientID, topic), "disconnect latest connection: expected will to be published")
+}
+
+func TestPublishSparkplug(t *testing.T) {
+	handler := newHandler()
+	svc := newService()
+	logBuffer.Reset()
+
+	// NBIRTH payload with the bdSeq metric.
+	metric := protowire.AppendTag(nil, 1, protowire.BytesType)
+	metric = protowire.AppendString(metric, "bdSeq")
+	metric = protowire.AppendTag(metric, 4, protowire.VarintType)
+	metric = protowire.AppendVarint(metric, uint64(sparkplug.UInt64))
+	metric = protowire.AppendTag(metric, 11, protowire.VarintType)
+	metric = protowire.AppendVarint(metric, 1)
+	birth := protowire.AppendTag(nil, 2, protowire.BytesType)
+	birth = protowire.AppendBytes(birth, metric)
+
+	handler.Publish(&sessionClient, &sparkplugTopic, &birth)
+	assert.NotContains(t, logBuffer.String(), mqtt.LogErrFailedPublish, "publish sparkplug birth: unexpected error")
+
+	g, err := svc.ViewSparkplugGroup(context.Background(), exampleUser1, "", sparkplugGroup)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.Equal(t, chanID, g.ChannelID)
+	require.Len(t, g.Nodes, 1)
+	assert.Equal(t, sparkplugNode, g.Nodes[0].ID)
+	assert.True(t, g.Nodes[0].Online, "expected edge node online after birth")
+
+	malformed := []byte{0xff}
+	handler.Publish(&sessionClient, &sparkplugTopic, &malformed)
+	assert.Contains(t, logBuffer.String(), mqtt.LogErrFailedPublish+sparkplug.ErrMalformedPayload.Error())
+
+	_, err = svc.ViewSparkplugGroup(context.Background(), exampleUser1, "", invalidID)
+	assert.True(t, errors.Contains(err, errors.ErrNotFound), fmt.Sprintf("view unmapped group: expected %s got %s\n", errors.ErrNotFound, err))
+}
+
 func newHandler() session.Handler {
+	return newHandlerWithWills(mqtt.NewWills())
+}
+
+func newHandlerWithWills(wills *mqtt.Wills) session.Handler {
 	logger, err := logger.New(&logBuffer, "debug")
 	if err != nil {
 		log.Fatalf("failed to create logger: %s", err)
 	}
 
-	authClient := mocks.NewClient(map[string]string{password: thingID}, map[string]interface{}{chanID: thingID})
+	authClient := mocks.NewClient(map[string]string{password: thingID}, map[string]interface{}{chanID: thingID, aclChanID: thingID}, map[string]acl.ACL{aclChanID: topicACL})
 	eventStore := mocks.NewEventStore()
-	return mqtt.NewHandler([]messaging.Publisher{pubmocks.NewPublisher()}, eventStore, logger, authClient, newService())
+	verifier := signature.NewVerifier(pubmocks.NewThingsServiceClient(nil, nil), 0)
+	return mqtt.NewHandler([]messaging.Publisher{pubmocks.NewPublisher()}, eventStore, logger, authClient, newService(), wills, mqtt.NewSessions(), verifier, sparkplugGroups)
 }
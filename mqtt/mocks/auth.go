@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/MainfluxLabs/mainflux"
+	"github.com/MainfluxLabs/mainflux/pkg/acl"
 	"github.com/MainfluxLabs/mainflux/pkg/auth"
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
 	"github.com/golang/protobuf/ptypes/empty"
@@ -15,10 +16,11 @@ var _ mainflux.AuthServiceClient = (*authServiceMock)(nil)
 type MockClient struct {
 	key   map[string]string
 	conns map[string]interface{}
+	acls  map[string]acl.ACL
 }
 
-func NewClient(key map[string]string, conns map[string]interface{}) auth.Client {
-	return MockClient{key: key, conns: conns}
+func NewClient(key map[string]string, conns map[string]interface{}, acls map[string]acl.ACL) auth.Client {
+	return MockClient{key: key, conns: conns, acls: acls}
 }
 
 func (cli MockClient) Authorize(ctx context.Context, chanID, thingID string) error {
@@ -30,6 +32,15 @@ func (cli MockClient) Authorize(ctx context.Context, chanID, thingID string) err
 	return errors.ErrAuthentication
 }
 
+func (cli MockClient) AuthorizeBatch(ctx context.Context, chanIDs []string, thingID string) error {
+	for _, chanID := range chanIDs {
+		if err := cli.Authorize(ctx, chanID, thingID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (cli MockClient) Identify(ctx context.Context, thingKey string) (string, error) {
 	if id, ok := cli.key[thingKey]; ok {
 		return id, nil
@@ -37,6 +48,10 @@ func (cli MockClient) Identify(ctx context.Context, thingKey string) (string, er
 	return "", errors.ErrAuthentication
 }
 
+func (cli MockClient) TopicACL(ctx context.Context, chanID string) (acl.ACL, error) {
+	return cli.acls[chanID], nil
+}
+
 type SubjectSet struct {
 	Object   string
 	Relation string
@@ -81,6 +96,10 @@ func (svc authServiceMock) AddPolicy(ctx context.Context, req *mainflux.PolicyRe
 	panic("not implemented")
 }
 
+func (svc authServiceMock) ClaimOwnership(ctx context.Context, req *mainflux.OwnershipReq, _ ...grpc.CallOption) (r *empty.Empty, err error) {
+	panic("not implemented")
+}
+
 func (svc authServiceMock) Members(ctx context.Context, req *mainflux.MembersReq, _ ...grpc.CallOption) (r *mainflux.MembersRes, err error) {
 	panic("not implemented")
 }
@@ -92,3 +111,11 @@ func (svc authServiceMock) Assign(ctx context.Context, req *mainflux.Assignment,
 func (svc authServiceMock) AssignRole(ctx context.Context, req *mainflux.AssignRoleReq, _ ...grpc.CallOption) (r *empty.Empty, err error) {
 	panic("not implemented")
 }
+
+func (svc authServiceMock) RetrieveRole(ctx context.Context, req *mainflux.RetrieveRoleReq, _ ...grpc.CallOption) (r *mainflux.RetrieveRoleRes, err error) {
+	panic("not implemented")
+}
+
+func (svc authServiceMock) RetrieveMemberships(ctx context.Context, req *mainflux.MembershipsReq, _ ...grpc.CallOption) (r *mainflux.MembershipsRes, err error) {
+	panic("not implemented")
+}
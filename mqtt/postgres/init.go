@@ -59,6 +59,16 @@ func migrateDB(db *sqlx.DB) error {
 					`DROP TABLE IF EXISTS subscriptions`,
 				},
 			},
+			{
+				// Channel and thing IDs aren't necessarily UUIDs.
+				Id: "mqtt_2",
+				Up: []string{
+					`ALTER TABLE IF EXISTS subscriptions ALTER COLUMN channel_id TYPE VARCHAR(254), ALTER COLUMN thing_id TYPE VARCHAR(254)`,
+				},
+				Down: []string{
+					`ALTER TABLE IF EXISTS subscriptions ALTER COLUMN channel_id TYPE UUID USING channel_id::uuid, ALTER COLUMN thing_id TYPE UUID USING thing_id::uuid`,
+				},
+			},
 		},
 	}
 	_, err := migrate.Exec(db.DB, "postgres", migrations, migrate.Up)
@@ -0,0 +1,132 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mqtt
+
+import (
+	"io"
+	"net"
+
+	"github.com/MainfluxLabs/mproxy/logger"
+	mperrors "github.com/MainfluxLabs/mproxy/pkg/errors"
+	"github.com/MainfluxLabs/mproxy/pkg/session"
+	mptls "github.com/MainfluxLabs/mproxy/pkg/tls"
+	"github.com/eclipse/paho.mqtt.golang/packets"
+)
+
+// Proxy is an MQTT proxy which, unlike the mProxy one, keeps track of the
+// last wills of the connected clients so that they can be propagated when
+// a client disconnects ungracefully.
+type Proxy struct {
+	address string
+	target  string
+	handler session.Handler
+	wills   *Wills
+	logger  logger.Logger
+	dialer  net.Dialer
+}
+
+// NewProxy returns a new MQTT Proxy instance.
+func NewProxy(address, target string, handler session.Handler, wills *Wills, logger logger.Logger) *Proxy {
+	return &Proxy{
+		address: address,
+		target:  target,
+		handler: handler,
+		wills:   wills,
+		logger:  logger,
+	}
+}
+
+// Listen of the server, this will block.
+func (p Proxy) Listen() error {
+	l, err := net.Listen("tcp", p.address)
+	if err != nil {
+		return err
+	}
+	defer l.Close()
+
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			p.logger.Warn("Accept error " + err.Error())
+			continue
+		}
+
+		p.logger.Info("Accepted new client")
+		go p.handle(conn)
+	}
+}
+
+func (p Proxy) handle(inbound net.Conn) {
+	defer p.close(inbound)
+	outbound, err := p.dialer.Dial("tcp", p.target)
+	if err != nil {
+		p.logger.Error("Cannot connect to remote broker " + p.target + " due to: " + err.Error())
+		return
+	}
+	defer p.close(outbound)
+
+	clientCert, err := mptls.ClientCert(inbound)
+	if err != nil {
+		p.logger.Error("Failed to get client certificate: " + err.Error())
+		return
+	}
+
+	pr, pw := io.Pipe()
+	defer pr.Close()
+	go p.inspect(inbound, pw)
+
+	s := session.New(inspectedConn{Conn: inbound, r: pr}, outbound, p.handler, p.logger, clientCert)
+
+	if err = s.Stream(); !mperrors.Contains(err, io.EOF) {
+		p.logger.Warn("Broken connection for client: " + s.Client.ID + " with error: " + err.Error())
+	}
+}
+
+// inspect reads the packets sent by the client, records its will on CONNECT
+// and discards it on DISCONNECT, and passes the packets on to the session.
+func (p Proxy) inspect(conn net.Conn, pw *io.PipeWriter) {
+	var clientID string
+	for {
+		pkt, err := packets.ReadPacket(conn)
+		if err != nil {
+			pw.CloseWithError(err)
+			return
+		}
+
+		switch pk := pkt.(type) {
+		case *packets.ConnectPacket:
+			clientID = pk.ClientIdentifier
+			if pk.WillFlag {
+				p.wills.Save(clientID, Will{Topic: pk.WillTopic, Payload: pk.WillMessage})
+				break
+			}
+			// Will of the previous connection of the client is
+			// replaced by the absence of one.
+			p.wills.Remove(clientID)
+		case *packets.DisconnectPacket:
+			p.wills.Remove(clientID)
+		}
+
+		if err := pkt.Write(pw); err != nil {
+			return
+		}
+	}
+}
+
+func (p Proxy) close(conn net.Conn) {
+	if err := conn.Close(); err != nil {
+		p.logger.Warn("Error closing connection " + err.Error())
+	}
+}
+
+// inspectedConn reads the client packets from the inspecting pipe,
+// while writes go directly to the client connection.
+type inspectedConn struct {
+	net.Conn
+	r io.Reader
+}
+
+func (c inspectedConn) Read(b []byte) (int, error) {
+	return c.r.Read(b)
+}
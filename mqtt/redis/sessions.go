@@ -0,0 +1,75 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package redis
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+const sessionPrefix = "mqtt:session"
+
+// releaseScript deletes the session only if it still belongs to the
+// connection, so that the session claimed by the newer connection in the
+// meantime is kept.
+var releaseScript = redis.NewScript(`
+if redis.call("HGET", KEYS[1], "conn_id") == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0
+`)
+
+// Sessions specifies the API of the MQTT client sessions.
+type Sessions interface {
+	// Claim makes the connection the session of the client.
+	Claim(ctx context.Context, clientID, thingID, connID string) error
+
+	// Release removes the session of the client if it still belongs to
+	// the connection, and returns whether it did.
+	Release(ctx context.Context, clientID, connID string) (bool, error)
+}
+
+var _ Sessions = (*sessions)(nil)
+
+type sessions struct {
+	client   *redis.Client
+	instance string
+}
+
+// NewSessions returns the client sessions stored in Redis and shared by
+// all the adapter replicas. Sessions record the replica instance of the
+// connection.
+func NewSessions(client *redis.Client, instance string) Sessions {
+	return &sessions{
+		client:   client,
+		instance: instance,
+	}
+}
+
+func (s *sessions) Claim(ctx context.Context, clientID, thingID, connID string) error {
+	values := map[string]interface{}{
+		"conn_id":      connID,
+		"thing_id":     thingID,
+		"instance":     s.instance,
+		"connected_at": time.Now().Unix(),
+	}
+
+	return s.client.HSet(ctx, sessionKey(clientID), values).Err()
+}
+
+func (s *sessions) Release(ctx context.Context, clientID, connID string) (bool, error) {
+	n, err := releaseScript.Run(ctx, s.client, []string{sessionKey(clientID)}, connID).Int()
+	if err != nil {
+		return false, err
+	}
+
+	return n == 1, nil
+}
+
+func sessionKey(clientID string) string {
+	return fmt.Sprintf("%s:%s", sessionPrefix, clientID)
+}
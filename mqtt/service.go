@@ -24,6 +24,10 @@ type Service interface {
 
 	// UpdateStatus updates the subscription status for a given client ID.
 	UpdateStatus(ctx context.Context, sub Subscription) error
+
+	// ViewSparkplugGroup retrieves the Sparkplug B group mapped to the
+	// channel, together with the state of its edge nodes.
+	ViewSparkplugGroup(ctx context.Context, token, key, group string) (SparkplugGroup, error)
 }
 
 type mqttService struct {
@@ -31,15 +35,18 @@ type mqttService struct {
 	things        mainflux.ThingsServiceClient
 	subscriptions Repository
 	idp           mainflux.IDProvider
+	sparkplug     *Sparkplug
 }
 
-// NewMqttService instantiates the MQTT service implementation.
-func NewMqttService(auth mainflux.AuthServiceClient, things mainflux.ThingsServiceClient, subscriptions Repository, idp mainflux.IDProvider) Service {
+// NewMqttService instantiates the MQTT service implementation. Sparkplug B
+// groups are not found if the Sparkplug B support is nil.
+func NewMqttService(auth mainflux.AuthServiceClient, things mainflux.ThingsServiceClient, subscriptions Repository, idp mainflux.IDProvider, sp *Sparkplug) Service {
 	return &mqttService{
 		auth:          auth,
 		things:        things,
 		subscriptions: subscriptions,
 		idp:           idp,
+		sparkplug:     sp,
 	}
 }
 
@@ -72,6 +79,19 @@ func (ms *mqttService) HasClientID(ctx context.Context, clientID string) error {
 	return ms.subscriptions.HasClientID(ctx, clientID)
 }
 
+func (ms *mqttService) ViewSparkplugGroup(ctx context.Context, token, key, group string) (SparkplugGroup, error) {
+	g, err := ms.sparkplug.Group(group)
+	if err != nil {
+		return SparkplugGroup{}, err
+	}
+
+	if err := ms.authorize(ctx, token, key, g.ChannelID); err != nil {
+		return SparkplugGroup{}, err
+	}
+
+	return g, nil
+}
+
 func (ms *mqttService) authorize(ctx context.Context, token, key, chanID string) (err error) {
 	switch {
 	case token != "":
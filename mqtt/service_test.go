@@ -32,7 +32,7 @@ func newService() mqtt.Service {
 	mockAuthzDB["*"] = []mocks.SubjectSet{{Object: "user", Relation: "create"}}
 	tc := thmocks.NewThingsServiceClient(map[string]string{exampleUser1: chanID},nil)
 	ac := mocks.NewAuth(map[string]string{exampleUser1: exampleUser1, adminUser: adminUser}, mockAuthzDB)
-	return mqtt.NewMqttService(ac, tc, repo, idProvider)
+	return mqtt.NewMqttService(ac, tc, repo, idProvider, sparkplugGroups)
 }
 
 func TestCreateSubscription(t *testing.T) {
@@ -0,0 +1,60 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mqtt
+
+import (
+	"context"
+	"sync"
+)
+
+// Sessions specifies the API of the client sessions shared by the adapter
+// replicas. The session of the client is its latest connection, identified
+// by the connection ID. Once the client reconnects, possibly to another replica, the
+// broker takes its session over, and the closing of the previous connection
+// must not be handled as the client disconnect.
+type Sessions interface {
+	// Claim makes the connection the session of the client, replacing the
+	// session of its previous connection.
+	Claim(ctx context.Context, clientID, thingID, connID string) error
+
+	// Release removes the session of the client if it still belongs to
+	// the connection, and returns whether it did.
+	Release(ctx context.Context, clientID, connID string) (bool, error)
+}
+
+var _ Sessions = (*localSessions)(nil)
+
+type localSessions struct {
+	mu       sync.Mutex
+	sessions map[string]string
+}
+
+// NewSessions returns the sessions kept in memory, which are not shared
+// with the other replicas.
+func NewSessions() Sessions {
+	return &localSessions{
+		sessions: make(map[string]string),
+	}
+}
+
+func (ls *localSessions) Claim(_ context.Context, clientID, _, connID string) error {
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+
+	ls.sessions[clientID] = connID
+
+	return nil
+}
+
+func (ls *localSessions) Release(_ context.Context, clientID, connID string) (bool, error) {
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+
+	if id, ok := ls.sessions[clientID]; !ok || id != connID {
+		return false, nil
+	}
+	delete(ls.sessions, clientID)
+
+	return true, nil
+}
@@ -0,0 +1,156 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mqtt
+
+import (
+	"strings"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+	"github.com/MainfluxLabs/mainflux/pkg/sparkplug"
+)
+
+// SparkplugGroup represents the Sparkplug B group mapped to the channel,
+// together with the state of its edge nodes.
+type SparkplugGroup struct {
+	ID        string
+	ChannelID string
+	Nodes     []sparkplug.NodeState
+}
+
+// Sparkplug maps the Sparkplug B groups to the channels and keeps the birth
+// and death state of their edge nodes and devices.
+type Sparkplug struct {
+	groups map[string]string
+	state  *sparkplug.State
+}
+
+// NewSparkplug returns the Sparkplug B support of the groups mapped to the
+// channels by their IDs.
+func NewSparkplug(groups map[string]string) *Sparkplug {
+	return &Sparkplug{
+		groups: groups,
+		state:  sparkplug.NewState(),
+	}
+}
+
+// Group returns the group mapped to the channel. Groups are not found if
+// the Sparkplug B support is nil.
+func (sp *Sparkplug) Group(id string) (SparkplugGroup, error) {
+	chanID, err := sp.channel(id)
+	if err != nil {
+		return SparkplugGroup{}, errors.ErrNotFound
+	}
+
+	return SparkplugGroup{
+		ID:        id,
+		ChannelID: chanID,
+		Nodes:     sp.state.Nodes(id),
+	}, nil
+}
+
+// channel returns the channel the group is mapped to. Sparkplug B topics are
+// malformed if the support is not enabled.
+func (sp *Sparkplug) channel(group string) (string, error) {
+	if sp == nil {
+		return "", ErrMalformedTopic
+	}
+
+	chanID, ok := sp.groups[group]
+	if !ok {
+		return "", ErrMalformedTopic
+	}
+
+	return chanID, nil
+}
+
+// parseSparkplugTopic returns the channel of the group and the subtopic
+// of the edge node or device the topic is published to.
+func (h *handler) parseSparkplugTopic(topic string) (sparkplug.Topic, string, string, error) {
+	t, err := sparkplug.ParseTopic(topic)
+	if err != nil {
+		return sparkplug.Topic{}, "", "", err
+	}
+
+	chanID, err := h.sparkplug.channel(t.Group)
+	if err != nil {
+		return sparkplug.Topic{}, "", "", err
+	}
+
+	subtopic, err := parseSubtopic(t.Node + "/" + t.Device)
+	if err != nil {
+		return sparkplug.Topic{}, "", "", err
+	}
+
+	return t, chanID, subtopic, nil
+}
+
+// parseSparkplugFilter returns the channel of the group and the subtopic of
+// the topic filter of the group, e.g. spBv1.0/<group_id>/NCMD/<edge_node_id>.
+func (h *handler) parseSparkplugFilter(filter string) (string, string, error) {
+	levels := strings.Split(filter, "/")
+	if len(levels) < 3 {
+		return "", "", ErrMalformedTopic
+	}
+
+	chanID, err := h.sparkplug.channel(levels[1])
+	if err != nil {
+		return "", "", err
+	}
+
+	subtopic, err := parseSubtopic(strings.Join(levels[3:], "/"))
+	if err != nil {
+		return "", "", err
+	}
+
+	return chanID, subtopic, nil
+}
+
+// publishSparkplug updates the state of the edge node or device and
+// publishes the metrics of its births and data messages as SenML.
+func (h *handler) publishSparkplug(username, topic string, payload []byte) error {
+	t, chanID, subtopic, err := h.parseSparkplugTopic(topic)
+	if err != nil {
+		return err
+	}
+
+	p, err := sparkplug.Decode(payload)
+	if err != nil {
+		return err
+	}
+	h.sparkplug.state.Update(t, &p)
+
+	switch t.Type {
+	case sparkplug.NBIRTH, sparkplug.DBIRTH, sparkplug.NDATA, sparkplug.DDATA:
+	default:
+		return nil
+	}
+
+	data, err := sparkplug.SenML(p)
+	switch {
+	case err == sparkplug.ErrNoMetrics:
+		return nil
+	case err != nil:
+		return err
+	}
+
+	id, err := messaging.NewID()
+	if err != nil {
+		return err
+	}
+
+	msg := messaging.Message{
+		Id:        id,
+		Protocol:  protocol,
+		Channel:   chanID,
+		Subtopic:  subtopic,
+		Publisher: username,
+		Payload:   data,
+		Created:   time.Now().UnixNano(),
+	}
+	h.send(msg)
+
+	return nil
+}
@@ -0,0 +1,54 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mqtt
+
+import "sync"
+
+// Will represents the last will and testament of an MQTT client.
+type Will struct {
+	Topic   string
+	Payload []byte
+}
+
+// Wills keeps the last wills of the connected clients. A will is removed
+// when its client disconnects gracefully, so the wills that remain at the
+// time the connection is lost are the ones that have to be published.
+type Wills struct {
+	mu    sync.Mutex
+	wills map[string]Will
+}
+
+// NewWills returns an empty wills registry.
+func NewWills() *Wills {
+	return &Wills{
+		wills: make(map[string]Will),
+	}
+}
+
+// Save stores the will of the client.
+func (w *Wills) Save(clientID string, will Will) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.wills[clientID] = will
+}
+
+// Remove discards the will of the client.
+func (w *Wills) Remove(clientID string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	delete(w.wills, clientID)
+}
+
+// Pop retrieves and discards the will of the client.
+func (w *Wills) Pop(clientID string) (Will, bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	will, ok := w.wills[clientID]
+	delete(w.wills, clientID)
+
+	return will, ok
+}
@@ -0,0 +1,282 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/MainfluxLabs/mainflux/ota"
+	"github.com/go-kit/kit/endpoint"
+)
+
+func uploadFirmwareEndpoint(svc ota.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(uploadFirmwareReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		fw := ota.Firmware{
+			Name:    req.name,
+			Version: req.version,
+		}
+		saved, err := svc.UploadFirmware(ctx, req.token, fw, req.data)
+		if err != nil {
+			return nil, err
+		}
+
+		res := toFirmwareRes(saved)
+		res.created = true
+		return res, nil
+	}
+}
+
+func viewFirmwareEndpoint(svc ota.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(viewReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		fw, err := svc.ViewFirmware(ctx, req.token, req.id)
+		if err != nil {
+			return nil, err
+		}
+
+		return toFirmwareRes(fw), nil
+	}
+}
+
+func listFirmwareEndpoint(svc ota.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		page, err := svc.ListFirmware(ctx, req.token, ota.PageMetadata{Offset: req.offset, Limit: req.limit})
+		if err != nil {
+			return nil, err
+		}
+
+		res := firmwarePageRes{
+			pageRes: pageRes{
+				Total:  page.Total,
+				Offset: page.Offset,
+				Limit:  page.Limit,
+			},
+			Firmware: []firmwareRes{},
+		}
+		for _, fw := range page.Firmware {
+			res.Firmware = append(res.Firmware, toFirmwareRes(fw))
+		}
+
+		return res, nil
+	}
+}
+
+func removeFirmwareEndpoint(svc ota.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(viewReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		if err := svc.RemoveFirmware(ctx, req.token, req.id); err != nil {
+			return nil, err
+		}
+
+		return emptyRes{code: http.StatusNoContent}, nil
+	}
+}
+
+func downloadFirmwareEndpoint(svc ota.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(downloadReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		fw, data, err := svc.DownloadFirmware(ctx, req.key, req.id)
+		if err != nil {
+			return nil, err
+		}
+
+		res := downloadRes{
+			checksum: fw.Checksum,
+			version:  fw.Version,
+			size:     fw.Size,
+			data:     data,
+		}
+
+		return res, nil
+	}
+}
+
+func createCampaignEndpoint(svc ota.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(createCampaignReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		c := ota.Campaign{
+			Name:               req.Name,
+			FirmwareID:         req.FirmwareID,
+			RollbackFirmwareID: req.RollbackFirmwareID,
+			GroupID:            req.GroupID,
+			ChannelID:          req.ChannelID,
+		}
+		saved, err := svc.CreateCampaign(ctx, req.token, c)
+		if err != nil {
+			return nil, err
+		}
+
+		res := toCampaignRes(saved)
+		res.created = true
+		return res, nil
+	}
+}
+
+func viewCampaignEndpoint(svc ota.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(viewReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		c, err := svc.ViewCampaign(ctx, req.token, req.id)
+		if err != nil {
+			return nil, err
+		}
+
+		return toCampaignRes(c), nil
+	}
+}
+
+func listCampaignsEndpoint(svc ota.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		page, err := svc.ListCampaigns(ctx, req.token, ota.PageMetadata{Offset: req.offset, Limit: req.limit})
+		if err != nil {
+			return nil, err
+		}
+
+		res := campaignsPageRes{
+			pageRes: pageRes{
+				Total:  page.Total,
+				Offset: page.Offset,
+				Limit:  page.Limit,
+			},
+			Campaigns: []campaignRes{},
+		}
+		for _, c := range page.Campaigns {
+			res.Campaigns = append(res.Campaigns, toCampaignRes(c))
+		}
+
+		return res, nil
+	}
+}
+
+func startCampaignEndpoint(svc ota.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(viewReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		if err := svc.StartCampaign(ctx, req.token, req.id); err != nil {
+			return nil, err
+		}
+
+		return emptyRes{code: http.StatusAccepted}, nil
+	}
+}
+
+func rollbackCampaignEndpoint(svc ota.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(viewReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		if err := svc.RollbackCampaign(ctx, req.token, req.id); err != nil {
+			return nil, err
+		}
+
+		return emptyRes{code: http.StatusAccepted}, nil
+	}
+}
+
+func listStatusesEndpoint(svc ota.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(viewReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		statuses, err := svc.ListStatuses(ctx, req.token, req.id)
+		if err != nil {
+			return nil, err
+		}
+
+		res := statusesRes{Statuses: []statusRes{}}
+		for _, st := range statuses {
+			res.Statuses = append(res.Statuses, statusRes{
+				ThingID:    st.ThingID,
+				FirmwareID: st.FirmwareID,
+				Status:     st.Status,
+				Error:      st.Error,
+				UpdatedAt:  st.UpdatedAt,
+			})
+		}
+
+		return res, nil
+	}
+}
+
+func reportStatusEndpoint(svc ota.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(reportStatusReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		if err := svc.ReportStatus(ctx, req.key, req.campaignID, req.Status, req.Error); err != nil {
+			return nil, err
+		}
+
+		return emptyRes{code: http.StatusNoContent}, nil
+	}
+}
+
+func toFirmwareRes(fw ota.Firmware) firmwareRes {
+	return firmwareRes{
+		ID:        fw.ID,
+		Name:      fw.Name,
+		Version:   fw.Version,
+		Size:      fw.Size,
+		Checksum:  fw.Checksum,
+		CreatedAt: fw.CreatedAt,
+	}
+}
+
+func toCampaignRes(c ota.Campaign) campaignRes {
+	return campaignRes{
+		ID:                 c.ID,
+		Name:               c.Name,
+		FirmwareID:         c.FirmwareID,
+		RollbackFirmwareID: c.RollbackFirmwareID,
+		GroupID:            c.GroupID,
+		ChannelID:          c.ChannelID,
+		Status:             c.Status,
+		CreatedAt:          c.CreatedAt,
+		UpdatedAt:          c.UpdatedAt,
+	}
+}
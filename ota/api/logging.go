@@ -0,0 +1,196 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !test
+
+package api
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	log "github.com/MainfluxLabs/mainflux/logger"
+	"github.com/MainfluxLabs/mainflux/ota"
+)
+
+var _ ota.Service = (*loggingMiddleware)(nil)
+
+type loggingMiddleware struct {
+	logger log.Logger
+	svc    ota.Service
+}
+
+// LoggingMiddleware adds logging facilities to the core service.
+func LoggingMiddleware(svc ota.Service, logger log.Logger) ota.Service {
+	return &loggingMiddleware{logger, svc}
+}
+
+func (lm *loggingMiddleware) UploadFirmware(ctx context.Context, token string, fw ota.Firmware, data io.Reader) (saved ota.Firmware, err error) {
+	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "upload_firmware", "latency", time.Since(begin).String())
+		message := fmt.Sprintf("Method upload_firmware with the id %s for token %s took %s to complete", saved.ID, token, time.Since(begin))
+		if err != nil {
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		l.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.UploadFirmware(ctx, token, fw, data)
+}
+
+func (lm *loggingMiddleware) ViewFirmware(ctx context.Context, token, id string) (fw ota.Firmware, err error) {
+	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "view_firmware", "latency", time.Since(begin).String())
+		message := fmt.Sprintf("Method view_firmware with the id %s for token %s took %s to complete", id, token, time.Since(begin))
+		if err != nil {
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		l.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ViewFirmware(ctx, token, id)
+}
+
+func (lm *loggingMiddleware) ListFirmware(ctx context.Context, token string, pm ota.PageMetadata) (page ota.FirmwarePage, err error) {
+	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "list_firmware", "latency", time.Since(begin).String())
+		message := fmt.Sprintf("Method list_firmware for token %s took %s to complete", token, time.Since(begin))
+		if err != nil {
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		l.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ListFirmware(ctx, token, pm)
+}
+
+func (lm *loggingMiddleware) RemoveFirmware(ctx context.Context, token, id string) (err error) {
+	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "remove_firmware", "latency", time.Since(begin).String())
+		message := fmt.Sprintf("Method remove_firmware with the id %s for token %s took %s to complete", id, token, time.Since(begin))
+		if err != nil {
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		l.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.RemoveFirmware(ctx, token, id)
+}
+
+func (lm *loggingMiddleware) DownloadFirmware(ctx context.Context, thingKey, id string) (fw ota.Firmware, data io.ReadCloser, err error) {
+	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "download_firmware", "latency", time.Since(begin).String())
+		message := fmt.Sprintf("Method download_firmware with the id %s took %s to complete", id, time.Since(begin))
+		if err != nil {
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		l.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.DownloadFirmware(ctx, thingKey, id)
+}
+
+func (lm *loggingMiddleware) CreateCampaign(ctx context.Context, token string, c ota.Campaign) (saved ota.Campaign, err error) {
+	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "create_campaign", "latency", time.Since(begin).String())
+		message := fmt.Sprintf("Method create_campaign with the id %s for token %s took %s to complete", saved.ID, token, time.Since(begin))
+		if err != nil {
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		l.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.CreateCampaign(ctx, token, c)
+}
+
+func (lm *loggingMiddleware) ViewCampaign(ctx context.Context, token, id string) (c ota.Campaign, err error) {
+	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "view_campaign", "latency", time.Since(begin).String())
+		message := fmt.Sprintf("Method view_campaign with the id %s for token %s took %s to complete", id, token, time.Since(begin))
+		if err != nil {
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		l.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ViewCampaign(ctx, token, id)
+}
+
+func (lm *loggingMiddleware) ListCampaigns(ctx context.Context, token string, pm ota.PageMetadata) (page ota.CampaignsPage, err error) {
+	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "list_campaigns", "latency", time.Since(begin).String())
+		message := fmt.Sprintf("Method list_campaigns for token %s took %s to complete", token, time.Since(begin))
+		if err != nil {
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		l.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ListCampaigns(ctx, token, pm)
+}
+
+func (lm *loggingMiddleware) StartCampaign(ctx context.Context, token, id string) (err error) {
+	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "start_campaign", "latency", time.Since(begin).String())
+		message := fmt.Sprintf("Method start_campaign with the id %s for token %s took %s to complete", id, token, time.Since(begin))
+		if err != nil {
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		l.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.StartCampaign(ctx, token, id)
+}
+
+func (lm *loggingMiddleware) RollbackCampaign(ctx context.Context, token, id string) (err error) {
+	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "rollback_campaign", "latency", time.Since(begin).String())
+		message := fmt.Sprintf("Method rollback_campaign with the id %s for token %s took %s to complete", id, token, time.Since(begin))
+		if err != nil {
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		l.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.RollbackCampaign(ctx, token, id)
+}
+
+func (lm *loggingMiddleware) ListStatuses(ctx context.Context, token, id string) (statuses []ota.DeviceStatus, err error) {
+	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "list_statuses", "latency", time.Since(begin).String())
+		message := fmt.Sprintf("Method list_statuses for campaign %s for token %s took %s to complete", id, token, time.Since(begin))
+		if err != nil {
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		l.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ListStatuses(ctx, token, id)
+}
+
+func (lm *loggingMiddleware) ReportStatus(ctx context.Context, thingKey, campaignID, status, errMsg string) (err error) {
+	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "report_status", "latency", time.Since(begin).String())
+		message := fmt.Sprintf("Method report_status for campaign %s with status %s took %s to complete", campaignID, status, time.Since(begin))
+		if err != nil {
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		l.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ReportStatus(ctx, thingKey, campaignID, status, errMsg)
+}
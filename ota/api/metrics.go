@@ -0,0 +1,140 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !test
+
+package api
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/ota"
+	"github.com/go-kit/kit/metrics"
+)
+
+var _ ota.Service = (*metricsMiddleware)(nil)
+
+type metricsMiddleware struct {
+	counter metrics.Counter
+	latency metrics.Histogram
+	svc     ota.Service
+}
+
+// MetricsMiddleware instruments core service by tracking request count and latency.
+func MetricsMiddleware(svc ota.Service, counter metrics.Counter, latency metrics.Histogram) ota.Service {
+	return &metricsMiddleware{
+		counter: counter,
+		latency: latency,
+		svc:     svc,
+	}
+}
+
+func (ms *metricsMiddleware) UploadFirmware(ctx context.Context, token string, fw ota.Firmware, data io.Reader) (ota.Firmware, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "upload_firmware").Add(1)
+		ms.latency.With("method", "upload_firmware").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.UploadFirmware(ctx, token, fw, data)
+}
+
+func (ms *metricsMiddleware) ViewFirmware(ctx context.Context, token, id string) (ota.Firmware, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "view_firmware").Add(1)
+		ms.latency.With("method", "view_firmware").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ViewFirmware(ctx, token, id)
+}
+
+func (ms *metricsMiddleware) ListFirmware(ctx context.Context, token string, pm ota.PageMetadata) (ota.FirmwarePage, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "list_firmware").Add(1)
+		ms.latency.With("method", "list_firmware").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ListFirmware(ctx, token, pm)
+}
+
+func (ms *metricsMiddleware) RemoveFirmware(ctx context.Context, token, id string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "remove_firmware").Add(1)
+		ms.latency.With("method", "remove_firmware").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.RemoveFirmware(ctx, token, id)
+}
+
+func (ms *metricsMiddleware) DownloadFirmware(ctx context.Context, thingKey, id string) (ota.Firmware, io.ReadCloser, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "download_firmware").Add(1)
+		ms.latency.With("method", "download_firmware").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.DownloadFirmware(ctx, thingKey, id)
+}
+
+func (ms *metricsMiddleware) CreateCampaign(ctx context.Context, token string, c ota.Campaign) (ota.Campaign, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "create_campaign").Add(1)
+		ms.latency.With("method", "create_campaign").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.CreateCampaign(ctx, token, c)
+}
+
+func (ms *metricsMiddleware) ViewCampaign(ctx context.Context, token, id string) (ota.Campaign, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "view_campaign").Add(1)
+		ms.latency.With("method", "view_campaign").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ViewCampaign(ctx, token, id)
+}
+
+func (ms *metricsMiddleware) ListCampaigns(ctx context.Context, token string, pm ota.PageMetadata) (ota.CampaignsPage, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "list_campaigns").Add(1)
+		ms.latency.With("method", "list_campaigns").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ListCampaigns(ctx, token, pm)
+}
+
+func (ms *metricsMiddleware) StartCampaign(ctx context.Context, token, id string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "start_campaign").Add(1)
+		ms.latency.With("method", "start_campaign").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.StartCampaign(ctx, token, id)
+}
+
+func (ms *metricsMiddleware) RollbackCampaign(ctx context.Context, token, id string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "rollback_campaign").Add(1)
+		ms.latency.With("method", "rollback_campaign").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.RollbackCampaign(ctx, token, id)
+}
+
+func (ms *metricsMiddleware) ListStatuses(ctx context.Context, token, id string) ([]ota.DeviceStatus, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "list_statuses").Add(1)
+		ms.latency.With("method", "list_statuses").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ListStatuses(ctx, token, id)
+}
+
+func (ms *metricsMiddleware) ReportStatus(ctx context.Context, thingKey, campaignID, status, errMsg string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "report_status").Add(1)
+		ms.latency.With("method", "report_status").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ReportStatus(ctx, thingKey, campaignID, status, errMsg)
+}
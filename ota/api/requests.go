@@ -0,0 +1,127 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"io"
+
+	"github.com/MainfluxLabs/mainflux/internal/apiutil"
+)
+
+const maxLimitSize = 100
+
+type uploadFirmwareReq struct {
+	token   string
+	name    string
+	version string
+	data    io.Reader
+}
+
+func (req uploadFirmwareReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	if req.version == "" {
+		return apiutil.ErrMalformedEntity
+	}
+
+	return nil
+}
+
+type viewReq struct {
+	token string
+	id    string
+}
+
+func (req viewReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	if req.id == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return nil
+}
+
+type listReq struct {
+	token  string
+	offset uint64
+	limit  uint64
+}
+
+func (req listReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	if req.limit > maxLimitSize {
+		return apiutil.ErrLimitSize
+	}
+
+	return nil
+}
+
+type downloadReq struct {
+	key string
+	id  string
+}
+
+func (req downloadReq) validate() error {
+	if req.key == "" {
+		return apiutil.ErrBearerKey
+	}
+
+	if req.id == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return nil
+}
+
+type createCampaignReq struct {
+	token              string
+	Name               string `json:"name,omitempty"`
+	FirmwareID         string `json:"firmware_id"`
+	RollbackFirmwareID string `json:"rollback_firmware_id,omitempty"`
+	GroupID            string `json:"group_id"`
+	ChannelID          string `json:"channel_id"`
+}
+
+func (req createCampaignReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	if req.FirmwareID == "" || req.GroupID == "" || req.ChannelID == "" {
+		return apiutil.ErrMalformedEntity
+	}
+
+	return nil
+}
+
+type reportStatusReq struct {
+	key        string
+	campaignID string
+	Status     string `json:"status"`
+	Error      string `json:"error,omitempty"`
+}
+
+func (req reportStatusReq) validate() error {
+	if req.key == "" {
+		return apiutil.ErrBearerKey
+	}
+
+	if req.campaignID == "" {
+		return apiutil.ErrMissingID
+	}
+
+	if req.Status == "" {
+		return apiutil.ErrMalformedEntity
+	}
+
+	return nil
+}
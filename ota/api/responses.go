@@ -0,0 +1,176 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux"
+)
+
+var (
+	_ mainflux.Response = (*firmwareRes)(nil)
+	_ mainflux.Response = (*firmwarePageRes)(nil)
+	_ mainflux.Response = (*campaignRes)(nil)
+	_ mainflux.Response = (*campaignsPageRes)(nil)
+	_ mainflux.Response = (*statusesRes)(nil)
+	_ mainflux.Response = (*emptyRes)(nil)
+)
+
+type pageRes struct {
+	Total  uint64 `json:"total"`
+	Offset uint64 `json:"offset"`
+	Limit  uint64 `json:"limit"`
+}
+
+type firmwareRes struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name,omitempty"`
+	Version   string    `json:"version"`
+	Size      int64     `json:"size"`
+	Checksum  string    `json:"checksum"`
+	CreatedAt time.Time `json:"created_at"`
+	created   bool
+}
+
+func (res firmwareRes) Code() int {
+	if res.created {
+		return http.StatusCreated
+	}
+
+	return http.StatusOK
+}
+
+func (res firmwareRes) Headers() map[string]string {
+	if res.created {
+		return map[string]string{
+			"Location": fmt.Sprintf("/firmware/%s", res.ID),
+		}
+	}
+
+	return map[string]string{}
+}
+
+func (res firmwareRes) Empty() bool {
+	return false
+}
+
+type firmwarePageRes struct {
+	pageRes
+	Firmware []firmwareRes `json:"firmware"`
+}
+
+func (res firmwarePageRes) Code() int {
+	return http.StatusOK
+}
+
+func (res firmwarePageRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res firmwarePageRes) Empty() bool {
+	return false
+}
+
+type downloadRes struct {
+	checksum string
+	version  string
+	size     int64
+	data     io.ReadCloser
+}
+
+type campaignRes struct {
+	ID                 string    `json:"id"`
+	Name               string    `json:"name,omitempty"`
+	FirmwareID         string    `json:"firmware_id"`
+	RollbackFirmwareID string    `json:"rollback_firmware_id,omitempty"`
+	GroupID            string    `json:"group_id"`
+	ChannelID          string    `json:"channel_id"`
+	Status             string    `json:"status"`
+	CreatedAt          time.Time `json:"created_at"`
+	UpdatedAt          time.Time `json:"updated_at"`
+	created            bool
+}
+
+func (res campaignRes) Code() int {
+	if res.created {
+		return http.StatusCreated
+	}
+
+	return http.StatusOK
+}
+
+func (res campaignRes) Headers() map[string]string {
+	if res.created {
+		return map[string]string{
+			"Location": fmt.Sprintf("/campaigns/%s", res.ID),
+		}
+	}
+
+	return map[string]string{}
+}
+
+func (res campaignRes) Empty() bool {
+	return false
+}
+
+type campaignsPageRes struct {
+	pageRes
+	Campaigns []campaignRes `json:"campaigns"`
+}
+
+func (res campaignsPageRes) Code() int {
+	return http.StatusOK
+}
+
+func (res campaignsPageRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res campaignsPageRes) Empty() bool {
+	return false
+}
+
+type statusRes struct {
+	ThingID    string    `json:"thing_id"`
+	FirmwareID string    `json:"firmware_id"`
+	Status     string    `json:"status"`
+	Error      string    `json:"error,omitempty"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+type statusesRes struct {
+	Statuses []statusRes `json:"statuses"`
+}
+
+func (res statusesRes) Code() int {
+	return http.StatusOK
+}
+
+func (res statusesRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res statusesRes) Empty() bool {
+	return false
+}
+
+type emptyRes struct {
+	code int
+}
+
+func (res emptyRes) Code() int {
+	return res.code
+}
+
+func (res emptyRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res emptyRes) Empty() bool {
+	return true
+}
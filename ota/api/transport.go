@@ -0,0 +1,289 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/MainfluxLabs/mainflux"
+	"github.com/MainfluxLabs/mainflux/internal/apiutil"
+	"github.com/MainfluxLabs/mainflux/logger"
+	"github.com/MainfluxLabs/mainflux/ota"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	kitot "github.com/go-kit/kit/tracing/opentracing"
+	kithttp "github.com/go-kit/kit/transport/http"
+	"github.com/go-zoo/bone"
+	opentracing "github.com/opentracing/opentracing-go"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const (
+	contentType       = "application/json"
+	binaryContentType = "application/octet-stream"
+	offsetKey         = "offset"
+	limitKey          = "limit"
+	nameKey           = "name"
+	versionKey        = "version"
+	defOffset         = 0
+	defLimit          = 10
+)
+
+// MakeHandler returns a HTTP handler for API endpoints.
+func MakeHandler(svc ota.Service, tracer opentracing.Tracer, logger logger.Logger, checks ...mainflux.HealthCheck) http.Handler {
+	opts := []kithttp.ServerOption{
+		kithttp.ServerErrorEncoder(apiutil.LoggingErrorEncoder(logger, encodeError)),
+	}
+
+	r := bone.New()
+
+	r.Post("/firmware", kithttp.NewServer(
+		kitot.TraceServer(tracer, "upload_firmware")(uploadFirmwareEndpoint(svc)),
+		decodeUpload,
+		encodeResponse,
+		opts...,
+	))
+
+	r.Get("/firmware", kithttp.NewServer(
+		kitot.TraceServer(tracer, "list_firmware")(listFirmwareEndpoint(svc)),
+		decodeList,
+		encodeResponse,
+		opts...,
+	))
+
+	r.Get("/firmware/:id", kithttp.NewServer(
+		kitot.TraceServer(tracer, "view_firmware")(viewFirmwareEndpoint(svc)),
+		decodeView,
+		encodeResponse,
+		opts...,
+	))
+
+	r.Delete("/firmware/:id", kithttp.NewServer(
+		kitot.TraceServer(tracer, "remove_firmware")(removeFirmwareEndpoint(svc)),
+		decodeView,
+		encodeResponse,
+		opts...,
+	))
+
+	r.Get("/firmware/:id/download", kithttp.NewServer(
+		kitot.TraceServer(tracer, "download_firmware")(downloadFirmwareEndpoint(svc)),
+		decodeDownload,
+		encodeDownload,
+		opts...,
+	))
+
+	r.Post("/campaigns", kithttp.NewServer(
+		kitot.TraceServer(tracer, "create_campaign")(createCampaignEndpoint(svc)),
+		decodeCreateCampaign,
+		encodeResponse,
+		opts...,
+	))
+
+	r.Get("/campaigns", kithttp.NewServer(
+		kitot.TraceServer(tracer, "list_campaigns")(listCampaignsEndpoint(svc)),
+		decodeList,
+		encodeResponse,
+		opts...,
+	))
+
+	r.Get("/campaigns/:id", kithttp.NewServer(
+		kitot.TraceServer(tracer, "view_campaign")(viewCampaignEndpoint(svc)),
+		decodeView,
+		encodeResponse,
+		opts...,
+	))
+
+	r.Post("/campaigns/:id/start", kithttp.NewServer(
+		kitot.TraceServer(tracer, "start_campaign")(startCampaignEndpoint(svc)),
+		decodeView,
+		encodeResponse,
+		opts...,
+	))
+
+	r.Post("/campaigns/:id/rollback", kithttp.NewServer(
+		kitot.TraceServer(tracer, "rollback_campaign")(rollbackCampaignEndpoint(svc)),
+		decodeView,
+		encodeResponse,
+		opts...,
+	))
+
+	r.Get("/campaigns/:id/statuses", kithttp.NewServer(
+		kitot.TraceServer(tracer, "list_statuses")(listStatusesEndpoint(svc)),
+		decodeView,
+		encodeResponse,
+		opts...,
+	))
+
+	r.Put("/campaigns/:id/status", kithttp.NewServer(
+		kitot.TraceServer(tracer, "report_status")(reportStatusEndpoint(svc)),
+		decodeReportStatus,
+		encodeResponse,
+		opts...,
+	))
+
+	r.GetFunc("/health", mainflux.Health("ota", checks...))
+	r.Handle("/metrics", promhttp.Handler())
+	r.Handle("/log-level", mainflux.LogLevel(logger))
+
+	return apiutil.RequestIDMiddleware(apiutil.LocaleMiddleware(r))
+}
+
+func decodeUpload(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), binaryContentType) {
+		return nil, apiutil.ErrUnsupportedContentType
+	}
+
+	name, err := apiutil.ReadStringQuery(r, nameKey, "")
+	if err != nil {
+		return nil, err
+	}
+
+	version, err := apiutil.ReadStringQuery(r, versionKey, "")
+	if err != nil {
+		return nil, err
+	}
+
+	req := uploadFirmwareReq{
+		token:   apiutil.ExtractBearerToken(r),
+		name:    name,
+		version: version,
+		data:    r.Body,
+	}
+
+	return req, nil
+}
+
+func decodeView(_ context.Context, r *http.Request) (interface{}, error) {
+	req := viewReq{
+		token: apiutil.ExtractBearerToken(r),
+		id:    bone.GetValue(r, "id"),
+	}
+
+	return req, nil
+}
+
+func decodeList(_ context.Context, r *http.Request) (interface{}, error) {
+	offset, err := apiutil.ReadUintQuery(r, offsetKey, defOffset)
+	if err != nil {
+		return nil, err
+	}
+
+	limit, err := apiutil.ReadLimitQuery(r, limitKey, defLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	req := listReq{
+		token:  apiutil.ExtractBearerToken(r),
+		offset: offset,
+		limit:  limit,
+	}
+
+	return req, nil
+}
+
+func decodeDownload(_ context.Context, r *http.Request) (interface{}, error) {
+	req := downloadReq{
+		key: apiutil.ExtractThingKey(r),
+		id:  bone.GetValue(r, "id"),
+	}
+
+	return req, nil
+}
+
+func decodeCreateCampaign(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), contentType) {
+		return nil, apiutil.ErrUnsupportedContentType
+	}
+
+	req := createCampaignReq{token: apiutil.ExtractBearerToken(r)}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(apiutil.ErrMalformedEntity, err)
+	}
+
+	return req, nil
+}
+
+func decodeReportStatus(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), contentType) {
+		return nil, apiutil.ErrUnsupportedContentType
+	}
+
+	req := reportStatusReq{
+		key:        apiutil.ExtractThingKey(r),
+		campaignID: bone.GetValue(r, "id"),
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(apiutil.ErrMalformedEntity, err)
+	}
+
+	return req, nil
+}
+
+func encodeResponse(_ context.Context, w http.ResponseWriter, response interface{}) error {
+	if ar, ok := response.(mainflux.Response); ok {
+		for k, v := range ar.Headers() {
+			w.Header().Set(k, v)
+		}
+		w.Header().Set("Content-Type", contentType)
+		w.WriteHeader(ar.Code())
+
+		if ar.Empty() {
+			return nil
+		}
+	}
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+func encodeDownload(_ context.Context, w http.ResponseWriter, response interface{}) error {
+	res := response.(downloadRes)
+	defer res.data.Close()
+
+	w.Header().Set("Content-Type", binaryContentType)
+	w.Header().Set("Content-Length", strconv.FormatInt(res.size, 10))
+	w.Header().Set("X-Firmware-Version", res.version)
+	w.Header().Set("X-Firmware-Checksum", res.checksum)
+	w.WriteHeader(http.StatusOK)
+
+	_, err := io.Copy(w, res.data)
+	return err
+}
+
+func encodeError(ctx context.Context, err error, w http.ResponseWriter) {
+	switch {
+	case errors.Contains(err, apiutil.ErrMalformedEntity),
+		err == apiutil.ErrMissingID,
+		err == apiutil.ErrLimitSize,
+		errors.Contains(err, apiutil.ErrInvalidQueryParams),
+		errors.Contains(err, ota.ErrInvalidStatus),
+		errors.Contains(err, ota.ErrMissingRollback):
+		w.WriteHeader(http.StatusBadRequest)
+	case errors.Contains(err, errors.ErrAuthentication),
+		err == apiutil.ErrBearerToken,
+		err == apiutil.ErrBearerKey:
+		w.WriteHeader(http.StatusUnauthorized)
+	case errors.Contains(err, errors.ErrAuthorization):
+		w.WriteHeader(http.StatusForbidden)
+	case errors.Contains(err, errors.ErrNotFound):
+		w.WriteHeader(http.StatusNotFound)
+	case errors.Contains(err, errors.ErrConflict):
+		w.WriteHeader(http.StatusConflict)
+	case errors.Contains(err, apiutil.ErrUnsupportedContentType):
+		w.WriteHeader(http.StatusUnsupportedMediaType)
+	default:
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+
+	if errorVal, ok := err.(errors.Error); ok {
+		w.Header().Set("Content-Type", contentType)
+		if err := json.NewEncoder(w).Encode(apiutil.NewErrorRes(ctx, errorVal)); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}
+}
@@ -0,0 +1,91 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package ota
+
+import (
+	"context"
+	"time"
+)
+
+// Campaign statuses.
+const (
+	CampaignCreated    = "created"
+	CampaignRunning    = "running"
+	CampaignRolledBack = "rolled_back"
+)
+
+// Device update statuses.
+const (
+	StatusPending     = "pending"
+	StatusDownloading = "downloading"
+	StatusApplied     = "applied"
+	StatusFailed      = "failed"
+)
+
+// Campaign represents a firmware rollout targeting all things of a group.
+// Commands are delivered over the control channel, while the rollback
+// firmware is used to revert devices if the rollout goes wrong.
+type Campaign struct {
+	ID                 string
+	OwnerID            string
+	Name               string
+	FirmwareID         string
+	RollbackFirmwareID string
+	GroupID            string
+	ChannelID          string
+	Status             string
+	CreatedAt          time.Time
+	UpdatedAt          time.Time
+}
+
+// CampaignsPage contains page related metadata as well as list of campaigns
+// that belong to this page.
+type CampaignsPage struct {
+	PageMetadata
+	Campaigns []Campaign
+}
+
+// DeviceStatus represents the update status of a single thing within a campaign.
+type DeviceStatus struct {
+	CampaignID string
+	ThingID    string
+	FirmwareID string
+	Status     string
+	Error      string
+	UpdatedAt  time.Time
+}
+
+// CampaignRepository specifies a campaign persistence API.
+type CampaignRepository interface {
+	// Save persists the campaign.
+	Save(ctx context.Context, c Campaign) error
+
+	// UpdateStatus updates the status of the campaign.
+	UpdateStatus(ctx context.Context, id, status string, updatedAt time.Time) error
+
+	// RetrieveByID retrieves the campaign having the provided identifier.
+	RetrieveByID(ctx context.Context, id string) (Campaign, error)
+
+	// RetrieveByOwner retrieves the subset of campaigns owned by the specified user.
+	RetrieveByOwner(ctx context.Context, owner string, pm PageMetadata) (CampaignsPage, error)
+
+	// SaveStatuses creates or updates per-device statuses.
+	SaveStatuses(ctx context.Context, statuses ...DeviceStatus) error
+
+	// RetrieveStatus retrieves the status of the thing in the campaign.
+	RetrieveStatus(ctx context.Context, campaignID, thingID string) (DeviceStatus, error)
+
+	// RetrieveStatuses retrieves the statuses of all things in the campaign.
+	RetrieveStatuses(ctx context.Context, campaignID string) ([]DeviceStatus, error)
+
+	// RetrieveStatusByFirmware retrieves the status of the thing in any
+	// campaign which dispatched the firmware to the thing.
+	RetrieveStatusByFirmware(ctx context.Context, thingID, firmwareID string) (DeviceStatus, error)
+}
+
+// Things specifies an API for resolving the things targeted by a campaign.
+type Things interface {
+	// GroupThings retrieves IDs of all things assigned to the group.
+	GroupThings(token, groupID string) ([]string, error)
+}
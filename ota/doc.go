@@ -0,0 +1,6 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package ota contains the domain concept definitions needed to support
+// Mainflux over-the-air firmware update functionality.
+package ota
@@ -0,0 +1,62 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package ota
+
+import (
+	"context"
+	"io"
+	"time"
+)
+
+// Firmware represents an uploaded firmware image.
+type Firmware struct {
+	ID        string
+	OwnerID   string
+	Name      string
+	Version   string
+	Size      int64
+	Checksum  string
+	CreatedAt time.Time
+}
+
+// FirmwarePage contains page related metadata as well as list of firmware
+// images that belong to this page.
+type FirmwarePage struct {
+	PageMetadata
+	Firmware []Firmware
+}
+
+// PageMetadata contains page metadata that helps navigation.
+type PageMetadata struct {
+	Total  uint64
+	Offset uint64
+	Limit  uint64
+}
+
+// FirmwareRepository specifies a firmware metadata persistence API.
+type FirmwareRepository interface {
+	// Save persists the firmware metadata.
+	Save(ctx context.Context, fw Firmware) error
+
+	// RetrieveByID retrieves the firmware having the provided identifier.
+	RetrieveByID(ctx context.Context, id string) (Firmware, error)
+
+	// RetrieveByOwner retrieves the subset of firmware owned by the specified user.
+	RetrieveByOwner(ctx context.Context, owner string, pm PageMetadata) (FirmwarePage, error)
+
+	// Remove removes the firmware having the provided identifier.
+	Remove(ctx context.Context, owner, id string) error
+}
+
+// Storage specifies a firmware image storage API.
+type Storage interface {
+	// Save stores the image read from r and returns its size and SHA-256 checksum.
+	Save(id string, r io.Reader) (int64, string, error)
+
+	// Open opens the stored image for reading.
+	Open(id string) (io.ReadCloser, error)
+
+	// Remove removes the stored image.
+	Remove(id string) error
+}
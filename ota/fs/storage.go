@@ -0,0 +1,63 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package fs contains the filesystem backed firmware image storage.
+package fs
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/MainfluxLabs/mainflux/ota"
+)
+
+var _ ota.Storage = (*storage)(nil)
+
+type storage struct {
+	dir string
+}
+
+// New instantiates a storage keeping firmware images in the given directory.
+func New(dir string) (ota.Storage, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+
+	return &storage{dir: dir}, nil
+}
+
+func (s *storage) Save(id string, r io.Reader) (int64, string, error) {
+	f, err := os.Create(s.path(id))
+	if err != nil {
+		return 0, "", err
+	}
+	defer f.Close()
+
+	hash := sha256.New()
+	n, err := io.Copy(io.MultiWriter(f, hash), r)
+	if err != nil {
+		os.Remove(f.Name())
+		return 0, "", err
+	}
+
+	return n, hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+func (s *storage) Open(id string) (io.ReadCloser, error) {
+	return os.Open(s.path(id))
+}
+
+func (s *storage) Remove(id string) error {
+	if err := os.Remove(s.path(id)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	return nil
+}
+
+func (s *storage) path(id string) string {
+	return filepath.Join(s.dir, filepath.Base(id))
+}
@@ -0,0 +1,157 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/ota"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+)
+
+var _ ota.CampaignRepository = (*campaignRepositoryMock)(nil)
+
+type campaignRepositoryMock struct {
+	mu        sync.Mutex
+	campaigns map[string]ota.Campaign
+	statuses  map[string]map[string]ota.DeviceStatus
+}
+
+// NewCampaignRepository returns a new campaign repository mock.
+func NewCampaignRepository() ota.CampaignRepository {
+	return &campaignRepositoryMock{
+		campaigns: make(map[string]ota.Campaign),
+		statuses:  make(map[string]map[string]ota.DeviceStatus),
+	}
+}
+
+func (crm *campaignRepositoryMock) Save(_ context.Context, c ota.Campaign) error {
+	crm.mu.Lock()
+	defer crm.mu.Unlock()
+
+	if _, ok := crm.campaigns[c.ID]; ok {
+		return errors.ErrConflict
+	}
+
+	crm.campaigns[c.ID] = c
+	return nil
+}
+
+func (crm *campaignRepositoryMock) UpdateStatus(_ context.Context, id, status string, updatedAt time.Time) error {
+	crm.mu.Lock()
+	defer crm.mu.Unlock()
+
+	c, ok := crm.campaigns[id]
+	if !ok {
+		return errors.ErrNotFound
+	}
+
+	c.Status = status
+	c.UpdatedAt = updatedAt
+	crm.campaigns[id] = c
+
+	return nil
+}
+
+func (crm *campaignRepositoryMock) RetrieveByID(_ context.Context, id string) (ota.Campaign, error) {
+	crm.mu.Lock()
+	defer crm.mu.Unlock()
+
+	c, ok := crm.campaigns[id]
+	if !ok {
+		return ota.Campaign{}, errors.ErrNotFound
+	}
+
+	return c, nil
+}
+
+func (crm *campaignRepositoryMock) RetrieveByOwner(_ context.Context, owner string, pm ota.PageMetadata) (ota.CampaignsPage, error) {
+	crm.mu.Lock()
+	defer crm.mu.Unlock()
+
+	var items []ota.Campaign
+	for _, c := range crm.campaigns {
+		if c.OwnerID == owner {
+			items = append(items, c)
+		}
+	}
+
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].ID < items[j].ID
+	})
+
+	page := ota.CampaignsPage{
+		PageMetadata: ota.PageMetadata{
+			Total:  uint64(len(items)),
+			Offset: pm.Offset,
+			Limit:  pm.Limit,
+		},
+	}
+
+	start, end := bounds(pm, len(items))
+	page.Campaigns = items[start:end]
+
+	return page, nil
+}
+
+func (crm *campaignRepositoryMock) SaveStatuses(_ context.Context, statuses ...ota.DeviceStatus) error {
+	crm.mu.Lock()
+	defer crm.mu.Unlock()
+
+	for _, st := range statuses {
+		if _, ok := crm.campaigns[st.CampaignID]; !ok {
+			return errors.ErrNotFound
+		}
+		if _, ok := crm.statuses[st.CampaignID]; !ok {
+			crm.statuses[st.CampaignID] = make(map[string]ota.DeviceStatus)
+		}
+		crm.statuses[st.CampaignID][st.ThingID] = st
+	}
+
+	return nil
+}
+
+func (crm *campaignRepositoryMock) RetrieveStatus(_ context.Context, campaignID, thingID string) (ota.DeviceStatus, error) {
+	crm.mu.Lock()
+	defer crm.mu.Unlock()
+
+	st, ok := crm.statuses[campaignID][thingID]
+	if !ok {
+		return ota.DeviceStatus{}, errors.ErrNotFound
+	}
+
+	return st, nil
+}
+
+func (crm *campaignRepositoryMock) RetrieveStatusByFirmware(_ context.Context, thingID, firmwareID string) (ota.DeviceStatus, error) {
+	crm.mu.Lock()
+	defer crm.mu.Unlock()
+
+	for _, sts := range crm.statuses {
+		if st, ok := sts[thingID]; ok && st.FirmwareID == firmwareID {
+			return st, nil
+		}
+	}
+
+	return ota.DeviceStatus{}, errors.ErrNotFound
+}
+
+func (crm *campaignRepositoryMock) RetrieveStatuses(_ context.Context, campaignID string) ([]ota.DeviceStatus, error) {
+	crm.mu.Lock()
+	defer crm.mu.Unlock()
+
+	var statuses []ota.DeviceStatus
+	for _, st := range crm.statuses[campaignID] {
+		statuses = append(statuses, st)
+	}
+
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].ThingID < statuses[j].ThingID
+	})
+
+	return statuses, nil
+}
@@ -0,0 +1,107 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/MainfluxLabs/mainflux/ota"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+)
+
+var _ ota.FirmwareRepository = (*firmwareRepositoryMock)(nil)
+
+type firmwareRepositoryMock struct {
+	mu       sync.Mutex
+	firmware map[string]ota.Firmware
+}
+
+// NewFirmwareRepository returns a new firmware repository mock.
+func NewFirmwareRepository() ota.FirmwareRepository {
+	return &firmwareRepositoryMock{
+		firmware: make(map[string]ota.Firmware),
+	}
+}
+
+func (frm *firmwareRepositoryMock) Save(_ context.Context, fw ota.Firmware) error {
+	frm.mu.Lock()
+	defer frm.mu.Unlock()
+
+	if _, ok := frm.firmware[fw.ID]; ok {
+		return errors.ErrConflict
+	}
+
+	frm.firmware[fw.ID] = fw
+	return nil
+}
+
+func (frm *firmwareRepositoryMock) RetrieveByID(_ context.Context, id string) (ota.Firmware, error) {
+	frm.mu.Lock()
+	defer frm.mu.Unlock()
+
+	fw, ok := frm.firmware[id]
+	if !ok {
+		return ota.Firmware{}, errors.ErrNotFound
+	}
+
+	return fw, nil
+}
+
+func (frm *firmwareRepositoryMock) RetrieveByOwner(_ context.Context, owner string, pm ota.PageMetadata) (ota.FirmwarePage, error) {
+	frm.mu.Lock()
+	defer frm.mu.Unlock()
+
+	var items []ota.Firmware
+	for _, fw := range frm.firmware {
+		if fw.OwnerID == owner {
+			items = append(items, fw)
+		}
+	}
+
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].ID < items[j].ID
+	})
+
+	page := ota.FirmwarePage{
+		PageMetadata: ota.PageMetadata{
+			Total:  uint64(len(items)),
+			Offset: pm.Offset,
+			Limit:  pm.Limit,
+		},
+	}
+
+	start, end := bounds(pm, len(items))
+	page.Firmware = items[start:end]
+
+	return page, nil
+}
+
+func (frm *firmwareRepositoryMock) Remove(_ context.Context, owner, id string) error {
+	frm.mu.Lock()
+	defer frm.mu.Unlock()
+
+	fw, ok := frm.firmware[id]
+	if !ok || fw.OwnerID != owner {
+		return errors.ErrNotFound
+	}
+
+	delete(frm.firmware, id)
+	return nil
+}
+
+func bounds(pm ota.PageMetadata, total int) (int, int) {
+	start := int(pm.Offset)
+	if start > total {
+		start = total
+	}
+
+	end := start + int(pm.Limit)
+	if pm.Limit == 0 || end > total {
+		end = total
+	}
+
+	return start, end
+}
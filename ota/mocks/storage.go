@@ -0,0 +1,64 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"sync"
+
+	"github.com/MainfluxLabs/mainflux/ota"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+)
+
+var _ ota.Storage = (*storageMock)(nil)
+
+type storageMock struct {
+	mu     sync.Mutex
+	images map[string][]byte
+}
+
+// NewStorage returns an in-memory firmware storage mock.
+func NewStorage() ota.Storage {
+	return &storageMock{
+		images: make(map[string][]byte),
+	}
+}
+
+func (sm *storageMock) Save(id string, r io.Reader) (int64, string, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return 0, "", err
+	}
+
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	sm.images[id] = data
+	sum := sha256.Sum256(data)
+
+	return int64(len(data)), hex.EncodeToString(sum[:]), nil
+}
+
+func (sm *storageMock) Open(id string) (io.ReadCloser, error) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	data, ok := sm.images[id]
+	if !ok {
+		return nil, errors.ErrNotFound
+	}
+
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+func (sm *storageMock) Remove(id string) error {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	delete(sm.images, id)
+	return nil
+}
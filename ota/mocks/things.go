@@ -0,0 +1,30 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"github.com/MainfluxLabs/mainflux/ota"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+)
+
+var _ ota.Things = (*thingsMock)(nil)
+
+type thingsMock struct {
+	groups map[string][]string
+}
+
+// NewThings returns a group things resolver mock using the given group to
+// thing IDs mapping.
+func NewThings(groups map[string][]string) ota.Things {
+	return &thingsMock{groups: groups}
+}
+
+func (tm *thingsMock) GroupThings(_, groupID string) ([]string, error) {
+	ids, ok := tm.groups[groupID]
+	if !ok {
+		return nil, errors.ErrNotFound
+	}
+
+	return ids, nil
+}
@@ -0,0 +1,264 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/ota"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+var _ ota.CampaignRepository = (*campaignRepository)(nil)
+
+type campaignRepository struct {
+	db Database
+}
+
+// NewCampaignRepository instantiates a PostgreSQL implementation of campaign repository.
+func NewCampaignRepository(db Database) ota.CampaignRepository {
+	return &campaignRepository{db: db}
+}
+
+func (cr campaignRepository) Save(ctx context.Context, c ota.Campaign) error {
+	q := `INSERT INTO campaigns (id, owner_id, name, firmware_id, rollback_firmware_id, group_id, channel_id, status, created_at, updated_at)
+		VALUES (:id, :owner_id, :name, :firmware_id, :rollback_firmware_id, :group_id, :channel_id, :status, :created_at, :updated_at)`
+
+	if _, err := cr.db.NamedExecContext(ctx, q, toDBCampaign(c)); err != nil {
+		if pgErr, ok := err.(*pgconn.PgError); ok {
+			switch pgErr.Code {
+			case pgerrcode.UniqueViolation:
+				return errors.Wrap(errors.ErrConflict, err)
+			case pgerrcode.ForeignKeyViolation:
+				return errors.Wrap(errors.ErrNotFound, err)
+			}
+		}
+		return errors.Wrap(errors.ErrCreateEntity, err)
+	}
+
+	return nil
+}
+
+func (cr campaignRepository) UpdateStatus(ctx context.Context, id, status string, updatedAt time.Time) error {
+	q := `UPDATE campaigns SET status = :status, updated_at = :updated_at WHERE id = :id`
+	params := map[string]interface{}{
+		"id":         id,
+		"status":     status,
+		"updated_at": updatedAt,
+	}
+
+	res, err := cr.db.NamedExecContext(ctx, q, params)
+	if err != nil {
+		return errors.Wrap(errors.ErrUpdateEntity, err)
+	}
+
+	if cnt, err := res.RowsAffected(); err == nil && cnt == 0 {
+		return errors.ErrNotFound
+	}
+
+	return nil
+}
+
+func (cr campaignRepository) RetrieveByID(ctx context.Context, id string) (ota.Campaign, error) {
+	q := `SELECT id, owner_id, name, firmware_id, rollback_firmware_id, group_id, channel_id, status, created_at, updated_at
+		FROM campaigns WHERE id = $1`
+
+	var dbc dbCampaign
+	if err := cr.db.QueryRowxContext(ctx, q, id).StructScan(&dbc); err != nil {
+		if err == sql.ErrNoRows {
+			return ota.Campaign{}, errors.Wrap(errors.ErrNotFound, err)
+		}
+		return ota.Campaign{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+
+	return toCampaign(dbc), nil
+}
+
+func (cr campaignRepository) RetrieveByOwner(ctx context.Context, owner string, pm ota.PageMetadata) (ota.CampaignsPage, error) {
+	q := `SELECT id, owner_id, name, firmware_id, rollback_firmware_id, group_id, channel_id, status, created_at, updated_at
+		FROM campaigns WHERE owner_id = :owner_id ORDER BY created_at DESC LIMIT :limit OFFSET :offset`
+	params := map[string]interface{}{
+		"owner_id": owner,
+		"limit":    pm.Limit,
+		"offset":   pm.Offset,
+	}
+
+	rows, err := cr.db.NamedQueryContext(ctx, q, params)
+	if err != nil {
+		return ota.CampaignsPage{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+	defer rows.Close()
+
+	var items []ota.Campaign
+	for rows.Next() {
+		var dbc dbCampaign
+		if err := rows.StructScan(&dbc); err != nil {
+			return ota.CampaignsPage{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+		}
+		items = append(items, toCampaign(dbc))
+	}
+
+	cq := `SELECT COUNT(*) FROM campaigns WHERE owner_id = :owner_id`
+	total, err := total(ctx, cr.db, cq, params)
+	if err != nil {
+		return ota.CampaignsPage{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+
+	return ota.CampaignsPage{
+		PageMetadata: ota.PageMetadata{
+			Total:  total,
+			Offset: pm.Offset,
+			Limit:  pm.Limit,
+		},
+		Campaigns: items,
+	}, nil
+}
+
+func (cr campaignRepository) SaveStatuses(ctx context.Context, statuses ...ota.DeviceStatus) error {
+	q := `INSERT INTO campaign_statuses (campaign_id, thing_id, firmware_id, status, error, updated_at)
+		VALUES (:campaign_id, :thing_id, :firmware_id, :status, :error, :updated_at)
+		ON CONFLICT (campaign_id, thing_id)
+		DO UPDATE SET firmware_id = :firmware_id, status = :status, error = :error, updated_at = :updated_at`
+
+	for _, st := range statuses {
+		if _, err := cr.db.NamedExecContext(ctx, q, toDBStatus(st)); err != nil {
+			if pgErr, ok := err.(*pgconn.PgError); ok && pgErr.Code == pgerrcode.ForeignKeyViolation {
+				return errors.Wrap(errors.ErrNotFound, err)
+			}
+			return errors.Wrap(errors.ErrUpdateEntity, err)
+		}
+	}
+
+	return nil
+}
+
+func (cr campaignRepository) RetrieveStatus(ctx context.Context, campaignID, thingID string) (ota.DeviceStatus, error) {
+	q := `SELECT campaign_id, thing_id, firmware_id, status, error, updated_at
+		FROM campaign_statuses WHERE campaign_id = $1 AND thing_id = $2`
+
+	var dbs dbStatus
+	if err := cr.db.QueryRowxContext(ctx, q, campaignID, thingID).StructScan(&dbs); err != nil {
+		if err == sql.ErrNoRows {
+			return ota.DeviceStatus{}, errors.Wrap(errors.ErrNotFound, err)
+		}
+		return ota.DeviceStatus{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+
+	return toStatus(dbs), nil
+}
+
+func (cr campaignRepository) RetrieveStatusByFirmware(ctx context.Context, thingID, firmwareID string) (ota.DeviceStatus, error) {
+	q := `SELECT campaign_id, thing_id, firmware_id, status, error, updated_at
+		FROM campaign_statuses WHERE thing_id = $1 AND firmware_id = $2 LIMIT 1`
+
+	var dbs dbStatus
+	if err := cr.db.QueryRowxContext(ctx, q, thingID, firmwareID).StructScan(&dbs); err != nil {
+		if err == sql.ErrNoRows {
+			return ota.DeviceStatus{}, errors.Wrap(errors.ErrNotFound, err)
+		}
+		return ota.DeviceStatus{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+
+	return toStatus(dbs), nil
+}
+
+func (cr campaignRepository) RetrieveStatuses(ctx context.Context, campaignID string) ([]ota.DeviceStatus, error) {
+	q := `SELECT campaign_id, thing_id, firmware_id, status, error, updated_at
+		FROM campaign_statuses WHERE campaign_id = :campaign_id ORDER BY thing_id`
+
+	rows, err := cr.db.NamedQueryContext(ctx, q, map[string]interface{}{"campaign_id": campaignID})
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+	defer rows.Close()
+
+	var statuses []ota.DeviceStatus
+	for rows.Next() {
+		var dbs dbStatus
+		if err := rows.StructScan(&dbs); err != nil {
+			return nil, errors.Wrap(errors.ErrRetrieveEntity, err)
+		}
+		statuses = append(statuses, toStatus(dbs))
+	}
+
+	return statuses, nil
+}
+
+type dbCampaign struct {
+	ID                 string         `db:"id"`
+	OwnerID            string         `db:"owner_id"`
+	Name               string         `db:"name"`
+	FirmwareID         string         `db:"firmware_id"`
+	RollbackFirmwareID sql.NullString `db:"rollback_firmware_id"`
+	GroupID            string         `db:"group_id"`
+	ChannelID          string         `db:"channel_id"`
+	Status             string         `db:"status"`
+	CreatedAt          time.Time      `db:"created_at"`
+	UpdatedAt          time.Time      `db:"updated_at"`
+}
+
+func toDBCampaign(c ota.Campaign) dbCampaign {
+	return dbCampaign{
+		ID:                 c.ID,
+		OwnerID:            c.OwnerID,
+		Name:               c.Name,
+		FirmwareID:         c.FirmwareID,
+		RollbackFirmwareID: sql.NullString{String: c.RollbackFirmwareID, Valid: c.RollbackFirmwareID != ""},
+		GroupID:            c.GroupID,
+		ChannelID:          c.ChannelID,
+		Status:             c.Status,
+		CreatedAt:          c.CreatedAt,
+		UpdatedAt:          c.UpdatedAt,
+	}
+}
+
+func toCampaign(c dbCampaign) ota.Campaign {
+	return ota.Campaign{
+		ID:                 c.ID,
+		OwnerID:            c.OwnerID,
+		Name:               c.Name,
+		FirmwareID:         c.FirmwareID,
+		RollbackFirmwareID: c.RollbackFirmwareID.String,
+		GroupID:            c.GroupID,
+		ChannelID:          c.ChannelID,
+		Status:             c.Status,
+		CreatedAt:          c.CreatedAt,
+		UpdatedAt:          c.UpdatedAt,
+	}
+}
+
+type dbStatus struct {
+	CampaignID string         `db:"campaign_id"`
+	ThingID    string         `db:"thing_id"`
+	FirmwareID string         `db:"firmware_id"`
+	Status     string         `db:"status"`
+	Error      sql.NullString `db:"error"`
+	UpdatedAt  time.Time      `db:"updated_at"`
+}
+
+func toDBStatus(st ota.DeviceStatus) dbStatus {
+	return dbStatus{
+		CampaignID: st.CampaignID,
+		ThingID:    st.ThingID,
+		FirmwareID: st.FirmwareID,
+		Status:     st.Status,
+		Error:      sql.NullString{String: st.Error, Valid: st.Error != ""},
+		UpdatedAt:  st.UpdatedAt,
+	}
+}
+
+func toStatus(st dbStatus) ota.DeviceStatus {
+	return ota.DeviceStatus{
+		CampaignID: st.CampaignID,
+		ThingID:    st.ThingID,
+		FirmwareID: st.FirmwareID,
+		Status:     st.Status,
+		Error:      st.Error.String,
+		UpdatedAt:  st.UpdatedAt,
+	}
+}
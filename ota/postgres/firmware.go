@@ -0,0 +1,167 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/ota"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+var _ ota.FirmwareRepository = (*firmwareRepository)(nil)
+
+type firmwareRepository struct {
+	db Database
+}
+
+// NewFirmwareRepository instantiates a PostgreSQL implementation of firmware repository.
+func NewFirmwareRepository(db Database) ota.FirmwareRepository {
+	return &firmwareRepository{db: db}
+}
+
+func (fr firmwareRepository) Save(ctx context.Context, fw ota.Firmware) error {
+	q := `INSERT INTO firmware (id, owner_id, name, version, size, checksum, created_at)
+		VALUES (:id, :owner_id, :name, :version, :size, :checksum, :created_at)`
+
+	if _, err := fr.db.NamedExecContext(ctx, q, toDBFirmware(fw)); err != nil {
+		if pgErr, ok := err.(*pgconn.PgError); ok && pgErr.Code == pgerrcode.UniqueViolation {
+			return errors.Wrap(errors.ErrConflict, err)
+		}
+		return errors.Wrap(errors.ErrCreateEntity, err)
+	}
+
+	return nil
+}
+
+func (fr firmwareRepository) RetrieveByID(ctx context.Context, id string) (ota.Firmware, error) {
+	q := `SELECT id, owner_id, name, version, size, checksum, created_at FROM firmware WHERE id = $1`
+
+	var dbfw dbFirmware
+	if err := fr.db.QueryRowxContext(ctx, q, id).StructScan(&dbfw); err != nil {
+		if err == sql.ErrNoRows {
+			return ota.Firmware{}, errors.Wrap(errors.ErrNotFound, err)
+		}
+		return ota.Firmware{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+
+	return toFirmware(dbfw), nil
+}
+
+func (fr firmwareRepository) RetrieveByOwner(ctx context.Context, owner string, pm ota.PageMetadata) (ota.FirmwarePage, error) {
+	q := `SELECT id, owner_id, name, version, size, checksum, created_at FROM firmware
+		WHERE owner_id = :owner_id ORDER BY created_at DESC LIMIT :limit OFFSET :offset`
+	params := map[string]interface{}{
+		"owner_id": owner,
+		"limit":    pm.Limit,
+		"offset":   pm.Offset,
+	}
+
+	rows, err := fr.db.NamedQueryContext(ctx, q, params)
+	if err != nil {
+		return ota.FirmwarePage{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+	defer rows.Close()
+
+	var items []ota.Firmware
+	for rows.Next() {
+		var dbfw dbFirmware
+		if err := rows.StructScan(&dbfw); err != nil {
+			return ota.FirmwarePage{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+		}
+		items = append(items, toFirmware(dbfw))
+	}
+
+	cq := `SELECT COUNT(*) FROM firmware WHERE owner_id = :owner_id`
+	total, err := total(ctx, fr.db, cq, params)
+	if err != nil {
+		return ota.FirmwarePage{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+
+	return ota.FirmwarePage{
+		PageMetadata: ota.PageMetadata{
+			Total:  total,
+			Offset: pm.Offset,
+			Limit:  pm.Limit,
+		},
+		Firmware: items,
+	}, nil
+}
+
+func (fr firmwareRepository) Remove(ctx context.Context, owner, id string) error {
+	q := `DELETE FROM firmware WHERE owner_id = :owner_id AND id = :id`
+	params := map[string]interface{}{
+		"owner_id": owner,
+		"id":       id,
+	}
+
+	res, err := fr.db.NamedExecContext(ctx, q, params)
+	if err != nil {
+		if pgErr, ok := err.(*pgconn.PgError); ok && pgErr.Code == pgerrcode.ForeignKeyViolation {
+			return errors.Wrap(errors.ErrConflict, err)
+		}
+		return errors.Wrap(errors.ErrRemoveEntity, err)
+	}
+
+	if cnt, err := res.RowsAffected(); err == nil && cnt == 0 {
+		return errors.ErrNotFound
+	}
+
+	return nil
+}
+
+func total(ctx context.Context, db Database, query string, params interface{}) (uint64, error) {
+	rows, err := db.NamedQueryContext(ctx, query, params)
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	var total uint64
+	if rows.Next() {
+		if err := rows.Scan(&total); err != nil {
+			return 0, err
+		}
+	}
+
+	return total, nil
+}
+
+type dbFirmware struct {
+	ID        string    `db:"id"`
+	OwnerID   string    `db:"owner_id"`
+	Name      string    `db:"name"`
+	Version   string    `db:"version"`
+	Size      int64     `db:"size"`
+	Checksum  string    `db:"checksum"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+func toDBFirmware(fw ota.Firmware) dbFirmware {
+	return dbFirmware{
+		ID:        fw.ID,
+		OwnerID:   fw.OwnerID,
+		Name:      fw.Name,
+		Version:   fw.Version,
+		Size:      fw.Size,
+		Checksum:  fw.Checksum,
+		CreatedAt: fw.CreatedAt,
+	}
+}
+
+func toFirmware(fw dbFirmware) ota.Firmware {
+	return ota.Firmware{
+		ID:        fw.ID,
+		OwnerID:   fw.OwnerID,
+		Name:      fw.Name,
+		Version:   fw.Version,
+		Size:      fw.Size,
+		Checksum:  fw.Checksum,
+		CreatedAt: fw.CreatedAt,
+	}
+}
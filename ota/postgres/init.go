@@ -0,0 +1,102 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"fmt"
+
+	_ "github.com/jackc/pgx/v5/stdlib" // required for SQL access
+	"github.com/jmoiron/sqlx"
+	migrate "github.com/rubenv/sql-migrate"
+)
+
+// Config defines the options that are used when connecting to a PostgreSQL instance
+type Config struct {
+	Host        string
+	Port        string
+	User        string
+	Pass        string
+	Name        string
+	SSLMode     string
+	SSLCert     string
+	SSLKey      string
+	SSLRootCert string
+}
+
+// Connect creates a connection to the PostgreSQL instance and applies any
+// unapplied database migrations. A non-nil error is returned to indicate
+// failure.
+func Connect(cfg Config) (*sqlx.DB, error) {
+	url := fmt.Sprintf("host=%s port=%s user=%s dbname=%s password=%s sslmode=%s sslcert=%s sslkey=%s sslrootcert=%s", cfg.Host, cfg.Port, cfg.User, cfg.Name, cfg.Pass, cfg.SSLMode, cfg.SSLCert, cfg.SSLKey, cfg.SSLRootCert)
+
+	db, err := sqlx.Open("pgx", url)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := migrateDB(db); err != nil {
+		return nil, err
+	}
+
+	return db, nil
+}
+
+func migrateDB(db *sqlx.DB) error {
+	migrations := &migrate.MemoryMigrationSource{
+		Migrations: []*migrate.Migration{
+			{
+				Id: "ota_1",
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS firmware (
+                        id          VARCHAR(254) PRIMARY KEY,
+                        owner_id    VARCHAR(254) NOT NULL,
+                        name        VARCHAR(254),
+                        version     VARCHAR(254) NOT NULL,
+                        size        BIGINT NOT NULL,
+                        checksum    VARCHAR(64) NOT NULL,
+                        created_at  TIMESTAMPTZ NOT NULL
+                    )`,
+					`CREATE TABLE IF NOT EXISTS campaigns (
+                        id                   VARCHAR(254) PRIMARY KEY,
+                        owner_id             VARCHAR(254) NOT NULL,
+                        name                 VARCHAR(254),
+                        firmware_id          VARCHAR(254) NOT NULL REFERENCES firmware (id),
+                        rollback_firmware_id VARCHAR(254),
+                        group_id             VARCHAR(254) NOT NULL,
+                        channel_id           VARCHAR(254) NOT NULL,
+                        status               VARCHAR(32) NOT NULL,
+                        created_at           TIMESTAMPTZ NOT NULL,
+                        updated_at           TIMESTAMPTZ NOT NULL
+                    )`,
+					`CREATE TABLE IF NOT EXISTS campaign_statuses (
+                        campaign_id VARCHAR(254) NOT NULL REFERENCES campaigns (id) ON DELETE CASCADE,
+                        thing_id    VARCHAR(254) NOT NULL,
+                        firmware_id VARCHAR(254) NOT NULL,
+                        status      VARCHAR(32) NOT NULL,
+                        error       TEXT,
+                        updated_at  TIMESTAMPTZ NOT NULL,
+                        PRIMARY KEY (campaign_id, thing_id)
+                    )`,
+				},
+				Down: []string{
+					"DROP TABLE IF EXISTS campaign_statuses",
+					"DROP TABLE IF EXISTS campaigns",
+					"DROP TABLE IF EXISTS firmware",
+				},
+			},
+			{
+				Id: "ota_2",
+				Up: []string{
+					`CREATE INDEX IF NOT EXISTS campaign_statuses_thing_firmware ON campaign_statuses (thing_id, firmware_id)`,
+				},
+				Down: []string{
+					"DROP INDEX IF EXISTS campaign_statuses_thing_firmware",
+				},
+			},
+		},
+	}
+
+	_, err := migrate.Exec(db.DB, "postgres", migrations, migrate.Up)
+	return err
+}
@@ -0,0 +1,468 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package ota
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+)
+
+const (
+	protocol       = "ota"
+	subtopicPrefix = "ota"
+	cmdUpdate      = "update"
+	cmdRollback    = "rollback"
+)
+
+var (
+	// ErrInvalidStatus indicates an unknown device or campaign status transition.
+	ErrInvalidStatus = errors.New("invalid status")
+
+	// ErrMissingRollback indicates a rollback of a campaign without rollback firmware.
+	ErrMissingRollback = errors.New("campaign has no rollback firmware")
+
+	// ErrPublish indicates failure to deliver an update command.
+	ErrPublish = errors.New("failed to publish update command")
+)
+
+// Service specifies an API that must be fullfiled by the domain service
+// implementation, and all of its decorators (e.g. logging & metrics).
+type Service interface {
+	// UploadFirmware stores the firmware image and its metadata.
+	UploadFirmware(ctx context.Context, token string, fw Firmware, data io.Reader) (Firmware, error)
+
+	// ViewFirmware retrieves the firmware metadata.
+	ViewFirmware(ctx context.Context, token, id string) (Firmware, error)
+
+	// ListFirmware retrieves the firmware uploaded by the user.
+	ListFirmware(ctx context.Context, token string, pm PageMetadata) (FirmwarePage, error)
+
+	// RemoveFirmware removes the firmware image and its metadata.
+	RemoveFirmware(ctx context.Context, token, id string) error
+
+	// DownloadFirmware returns the firmware image to the thing identified by
+	// the key. Only the things targeted by a campaign of the firmware can
+	// download it.
+	DownloadFirmware(ctx context.Context, thingKey, id string) (Firmware, io.ReadCloser, error)
+
+	// CreateCampaign creates a rollout campaign for the group. Both the group
+	// and the control channel must be owned by the user.
+	CreateCampaign(ctx context.Context, token string, c Campaign) (Campaign, error)
+
+	// ViewCampaign retrieves the campaign.
+	ViewCampaign(ctx context.Context, token, id string) (Campaign, error)
+
+	// ListCampaigns retrieves the campaigns created by the user.
+	ListCampaigns(ctx context.Context, token string, pm PageMetadata) (CampaignsPage, error)
+
+	// StartCampaign sends update commands to all things of the campaign group.
+	StartCampaign(ctx context.Context, token, id string) error
+
+	// RollbackCampaign sends rollback commands to all things of the campaign.
+	RollbackCampaign(ctx context.Context, token, id string) error
+
+	// ListStatuses retrieves per-device statuses of the campaign.
+	ListStatuses(ctx context.Context, token, id string) ([]DeviceStatus, error)
+
+	// ReportStatus updates the status of the thing identified by the key.
+	ReportStatus(ctx context.Context, thingKey, campaignID, status, errMsg string) error
+}
+
+// Command represents the update command sent to a device.
+type Command struct {
+	Command    string `json:"command"`
+	CampaignID string `json:"campaign_id"`
+	FirmwareID string `json:"firmware_id"`
+	Version    string `json:"version"`
+	Checksum   string `json:"checksum"`
+	Size       int64  `json:"size"`
+	URL        string `json:"url"`
+}
+
+var _ Service = (*otaService)(nil)
+
+type otaService struct {
+	auth       mainflux.AuthServiceClient
+	things     mainflux.ThingsServiceClient
+	groups     Things
+	firmware   FirmwareRepository
+	campaigns  CampaignRepository
+	storage    Storage
+	publisher  messaging.Publisher
+	idProvider mainflux.IDProvider
+	baseURL    string
+}
+
+// New instantiates the OTA service implementation.
+func New(auth mainflux.AuthServiceClient, things mainflux.ThingsServiceClient, groups Things, firmware FirmwareRepository, campaigns CampaignRepository, storage Storage, publisher messaging.Publisher, idp mainflux.IDProvider, baseURL string) Service {
+	return &otaService{
+		auth:       auth,
+		things:     things,
+		groups:     groups,
+		firmware:   firmware,
+		campaigns:  campaigns,
+		storage:    storage,
+		publisher:  publisher,
+		idProvider: idp,
+		baseURL:    baseURL,
+	}
+}
+
+func (ots *otaService) UploadFirmware(ctx context.Context, token string, fw Firmware, data io.Reader) (Firmware, error) {
+	res, err := ots.auth.Identify(ctx, &mainflux.Token{Value: token})
+	if err != nil {
+		return Firmware{}, errors.Wrap(errors.ErrAuthentication, err)
+	}
+
+	id, err := ots.idProvider.ID()
+	if err != nil {
+		return Firmware{}, err
+	}
+
+	fw.ID = id
+	fw.OwnerID = res.GetId()
+	fw.CreatedAt = getTimestamp()
+
+	fw.Size, fw.Checksum, err = ots.storage.Save(id, data)
+	if err != nil {
+		return Firmware{}, errors.Wrap(errors.ErrCreateEntity, err)
+	}
+
+	if err := ots.firmware.Save(ctx, fw); err != nil {
+		ots.storage.Remove(id)
+		return Firmware{}, err
+	}
+
+	return fw, nil
+}
+
+func (ots *otaService) ViewFirmware(ctx context.Context, token, id string) (Firmware, error) {
+	res, err := ots.auth.Identify(ctx, &mainflux.Token{Value: token})
+	if err != nil {
+		return Firmware{}, errors.Wrap(errors.ErrAuthentication, err)
+	}
+
+	return ots.ownedFirmware(ctx, res.GetId(), id)
+}
+
+func (ots *otaService) ListFirmware(ctx context.Context, token string, pm PageMetadata) (FirmwarePage, error) {
+	res, err := ots.auth.Identify(ctx, &mainflux.Token{Value: token})
+	if err != nil {
+		return FirmwarePage{}, errors.Wrap(errors.ErrAuthentication, err)
+	}
+
+	return ots.firmware.RetrieveByOwner(ctx, res.GetId(), pm)
+}
+
+func (ots *otaService) RemoveFirmware(ctx context.Context, token, id string) error {
+	res, err := ots.auth.Identify(ctx, &mainflux.Token{Value: token})
+	if err != nil {
+		return errors.Wrap(errors.ErrAuthentication, err)
+	}
+
+	if err := ots.firmware.Remove(ctx, res.GetId(), id); err != nil {
+		return err
+	}
+
+	return ots.storage.Remove(id)
+}
+
+func (ots *otaService) DownloadFirmware(ctx context.Context, thingKey, id string) (Firmware, io.ReadCloser, error) {
+	thID, err := ots.things.Identify(ctx, &mainflux.Token{Value: thingKey})
+	if err != nil {
+		return Firmware{}, nil, errors.Wrap(errors.ErrAuthentication, err)
+	}
+
+	if _, err := ots.campaigns.RetrieveStatusByFirmware(ctx, thID.GetValue(), id); err != nil {
+		return Firmware{}, nil, err
+	}
+
+	fw, err := ots.firmware.RetrieveByID(ctx, id)
+	if err != nil {
+		return Firmware{}, nil, err
+	}
+
+	data, err := ots.storage.Open(id)
+	if err != nil {
+		return Firmware{}, nil, errors.Wrap(errors.ErrNotFound, err)
+	}
+
+	return fw, data, nil
+}
+
+func (ots *otaService) CreateCampaign(ctx context.Context, token string, c Campaign) (Campaign, error) {
+	res, err := ots.auth.Identify(ctx, &mainflux.Token{Value: token})
+	if err != nil {
+		return Campaign{}, errors.Wrap(errors.ErrAuthentication, err)
+	}
+
+	if _, err := ots.ownedFirmware(ctx, res.GetId(), c.FirmwareID); err != nil {
+		return Campaign{}, err
+	}
+
+	if c.RollbackFirmwareID != "" {
+		if _, err := ots.ownedFirmware(ctx, res.GetId(), c.RollbackFirmwareID); err != nil {
+			return Campaign{}, err
+		}
+	}
+
+	if err := ots.authorizeTargets(ctx, res.GetId(), c); err != nil {
+		return Campaign{}, err
+	}
+
+	id, err := ots.idProvider.ID()
+	if err != nil {
+		return Campaign{}, err
+	}
+
+	timestamp := getTimestamp()
+	c.ID = id
+	c.OwnerID = res.GetId()
+	c.Status = CampaignCreated
+	c.CreatedAt = timestamp
+	c.UpdatedAt = timestamp
+
+	if err := ots.campaigns.Save(ctx, c); err != nil {
+		return Campaign{}, err
+	}
+
+	return c, nil
+}
+
+func (ots *otaService) ViewCampaign(ctx context.Context, token, id string) (Campaign, error) {
+	res, err := ots.auth.Identify(ctx, &mainflux.Token{Value: token})
+	if err != nil {
+		return Campaign{}, errors.Wrap(errors.ErrAuthentication, err)
+	}
+
+	return ots.ownedCampaign(ctx, res.GetId(), id)
+}
+
+func (ots *otaService) ListCampaigns(ctx context.Context, token string, pm PageMetadata) (CampaignsPage, error) {
+	res, err := ots.auth.Identify(ctx, &mainflux.Token{Value: token})
+	if err != nil {
+		return CampaignsPage{}, errors.Wrap(errors.ErrAuthentication, err)
+	}
+
+	return ots.campaigns.RetrieveByOwner(ctx, res.GetId(), pm)
+}
+
+func (ots *otaService) StartCampaign(ctx context.Context, token, id string) error {
+	res, err := ots.auth.Identify(ctx, &mainflux.Token{Value: token})
+	if err != nil {
+		return errors.Wrap(errors.ErrAuthentication, err)
+	}
+
+	c, err := ots.ownedCampaign(ctx, res.GetId(), id)
+	if err != nil {
+		return err
+	}
+
+	if c.Status != CampaignCreated {
+		return ErrInvalidStatus
+	}
+
+	thingIDs, err := ots.groups.GroupThings(token, c.GroupID)
+	if err != nil {
+		return err
+	}
+
+	if err := ots.dispatch(ctx, c, c.FirmwareID, cmdUpdate, thingIDs); err != nil {
+		return err
+	}
+
+	return ots.campaigns.UpdateStatus(ctx, c.ID, CampaignRunning, getTimestamp())
+}
+
+func (ots *otaService) RollbackCampaign(ctx context.Context, token, id string) error {
+	res, err := ots.auth.Identify(ctx, &mainflux.Token{Value: token})
+	if err != nil {
+		return errors.Wrap(errors.ErrAuthentication, err)
+	}
+
+	c, err := ots.ownedCampaign(ctx, res.GetId(), id)
+	if err != nil {
+		return err
+	}
+
+	if c.RollbackFirmwareID == "" {
+		return ErrMissingRollback
+	}
+
+	if c.Status != CampaignRunning {
+		return ErrInvalidStatus
+	}
+
+	statuses, err := ots.campaigns.RetrieveStatuses(ctx, c.ID)
+	if err != nil {
+		return err
+	}
+
+	var thingIDs []string
+	for _, st := range statuses {
+		thingIDs = append(thingIDs, st.ThingID)
+	}
+
+	if err := ots.dispatch(ctx, c, c.RollbackFirmwareID, cmdRollback, thingIDs); err != nil {
+		return err
+	}
+
+	return ots.campaigns.UpdateStatus(ctx, c.ID, CampaignRolledBack, getTimestamp())
+}
+
+func (ots *otaService) ListStatuses(ctx context.Context, token, id string) ([]DeviceStatus, error) {
+	res, err := ots.auth.Identify(ctx, &mainflux.Token{Value: token})
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrAuthentication, err)
+	}
+
+	if _, err := ots.ownedCampaign(ctx, res.GetId(), id); err != nil {
+		return nil, err
+	}
+
+	return ots.campaigns.RetrieveStatuses(ctx, id)
+}
+
+func (ots *otaService) ReportStatus(ctx context.Context, thingKey, campaignID, status, errMsg string) error {
+	thID, err := ots.things.Identify(ctx, &mainflux.Token{Value: thingKey})
+	if err != nil {
+		return errors.Wrap(errors.ErrAuthentication, err)
+	}
+
+	switch status {
+	case StatusDownloading, StatusApplied, StatusFailed:
+	default:
+		return ErrInvalidStatus
+	}
+
+	st, err := ots.campaigns.RetrieveStatus(ctx, campaignID, thID.GetValue())
+	if err != nil {
+		return err
+	}
+
+	st.Status = status
+	st.Error = errMsg
+	st.UpdatedAt = getTimestamp()
+
+	return ots.campaigns.SaveStatuses(ctx, st)
+}
+
+// dispatch publishes the command to every thing on its own subtopic of the
+// control channel and marks the things as pending.
+func (ots *otaService) dispatch(ctx context.Context, c Campaign, firmwareID, command string, thingIDs []string) error {
+	fw, err := ots.firmware.RetrieveByID(ctx, firmwareID)
+	if err != nil {
+		return err
+	}
+
+	cmd := Command{
+		Command:    command,
+		CampaignID: c.ID,
+		FirmwareID: fw.ID,
+		Version:    fw.Version,
+		Checksum:   fw.Checksum,
+		Size:       fw.Size,
+		URL:        fmt.Sprintf("%s/firmware/%s/download", ots.baseURL, fw.ID),
+	}
+
+	payload, err := json.Marshal(cmd)
+	if err != nil {
+		return err
+	}
+
+	timestamp := getTimestamp()
+	var statuses []DeviceStatus
+	for _, thID := range thingIDs {
+		id, err := messaging.NewID()
+		if err != nil {
+			return errors.Wrap(ErrPublish, err)
+		}
+
+		msg := messaging.Message{
+			Id:       id,
+			Channel:  c.ChannelID,
+			Subtopic: fmt.Sprintf("%s.%s", subtopicPrefix, thID),
+			Protocol: protocol,
+			Payload:  payload,
+			Created:  timestamp.UnixNano(),
+		}
+		if err := ots.publisher.Publish(msg.Channel, msg); err != nil {
+			return errors.Wrap(ErrPublish, err)
+		}
+
+		statuses = append(statuses, DeviceStatus{
+			CampaignID: c.ID,
+			ThingID:    thID,
+			FirmwareID: fw.ID,
+			Status:     StatusPending,
+			UpdatedAt:  timestamp,
+		})
+	}
+
+	if len(statuses) == 0 {
+		return nil
+	}
+
+	return ots.campaigns.SaveStatuses(ctx, statuses...)
+}
+
+// authorizeTargets checks whether the user owns the group and the control
+// channel of the campaign, so that no commands are sent to the things and
+// over the channels of other users.
+func (ots *otaService) authorizeTargets(ctx context.Context, owner string, c Campaign) error {
+	req := &mainflux.ChannelOwnerReq{Owner: owner, ChanID: c.ChannelID}
+	if _, err := ots.things.IsChannelOwner(ctx, req); err != nil {
+		return errors.Wrap(errors.ErrAuthorization, err)
+	}
+
+	res, err := ots.things.GetGroupsByIDs(ctx, &mainflux.GroupsReq{Ids: []string{c.GroupID}})
+	if err != nil {
+		return err
+	}
+
+	for _, gr := range res.GetGroups() {
+		if gr.GetId() == c.GroupID && gr.GetOwnerID() == owner {
+			return nil
+		}
+	}
+
+	return errors.ErrAuthorization
+}
+
+func (ots *otaService) ownedFirmware(ctx context.Context, owner, id string) (Firmware, error) {
+	fw, err := ots.firmware.RetrieveByID(ctx, id)
+	if err != nil {
+		return Firmware{}, err
+	}
+
+	if fw.OwnerID != owner {
+		return Firmware{}, errors.ErrNotFound
+	}
+
+	return fw, nil
+}
+
+func (ots *otaService) ownedCampaign(ctx context.Context, owner, id string) (Campaign, error) {
+	c, err := ots.campaigns.RetrieveByID(ctx, id)
+	if err != nil {
+		return Campaign{}, err
+	}
+
+	if c.OwnerID != owner {
+		return Campaign{}, errors.ErrNotFound
+	}
+
+	return c, nil
+}
+
+func getTimestamp() time.Time {
+	return time.Now().UTC().Round(time.Millisecond)
+}
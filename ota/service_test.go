@@ -0,0 +1,310 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package ota_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/MainfluxLabs/mainflux/ota"
+	otamocks "github.com/MainfluxLabs/mainflux/ota/mocks"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/mocks"
+	"github.com/MainfluxLabs/mainflux/pkg/uuid"
+	"github.com/MainfluxLabs/mainflux/things"
+	"github.com/MainfluxLabs/mainflux/users"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	userEmail      = "user@example.com"
+	otherUserEmail = "otherUser@example.com"
+	password       = "password"
+	thingKey       = "thing-1"
+	groupID        = "group-1"
+	otherGroupID   = "group-2"
+	channelID      = "channel-1"
+	otherChannelID = "channel-2"
+	baseURL        = "http://localhost:9024"
+	image          = "firmware image"
+)
+
+var (
+	user      = users.User{ID: "user-1", Email: userEmail, Password: password}
+	otherUser = users.User{ID: "user-2", Email: otherUserEmail, Password: password}
+	usersList = []users.User{user, otherUser}
+	groups    = map[string][]string{groupID: {"thing-1", "thing-2"}, otherGroupID: {"thing-3"}}
+	channels  = map[string]string{user.ID: channelID, otherUser.ID: otherChannelID}
+	thGroups  = map[string]things.Group{
+		groupID:      {ID: groupID, OwnerID: user.ID},
+		otherGroupID: {ID: otherGroupID, OwnerID: otherUser.ID},
+	}
+)
+
+func newService(pub *otamocks.Publisher) ota.Service {
+	auth := mocks.NewAuthService("", usersList)
+	things := mocks.NewThingsServiceClient(channels, thGroups)
+	return ota.New(auth, things, otamocks.NewThings(groups), otamocks.NewFirmwareRepository(), otamocks.NewCampaignRepository(), otamocks.NewStorage(), pub, uuid.NewMock(), baseURL)
+}
+
+func upload(t *testing.T, svc ota.Service, version string) ota.Firmware {
+	fw, err := svc.UploadFirmware(context.Background(), userEmail, ota.Firmware{Name: "fw", Version: version}, strings.NewReader(image))
+	require.Nil(t, err, fmt.Sprintf("unexpected error uploading firmware: %s", err))
+	return fw
+}
+
+func TestUploadFirmware(t *testing.T) {
+	svc := newService(otamocks.NewPublisher())
+
+	cases := []struct {
+		desc  string
+		token string
+		err   error
+	}{
+		{
+			desc:  "upload firmware",
+			token: userEmail,
+			err:   nil,
+		},
+		{
+			desc:  "upload firmware with invalid token",
+			token: "invalid",
+			err:   errors.ErrAuthentication,
+		},
+	}
+
+	for _, tc := range cases {
+		fw, err := svc.UploadFirmware(context.Background(), tc.token, ota.Firmware{Version: "1.0.0"}, strings.NewReader(image))
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected error %s got %s\n", tc.desc, tc.err, err))
+		if tc.err != nil {
+			continue
+		}
+		assert.Equal(t, int64(len(image)), fw.Size, fmt.Sprintf("%s: expected size %d got %d\n", tc.desc, len(image), fw.Size))
+		assert.Len(t, fw.Checksum, 64, fmt.Sprintf("%s: expected SHA-256 checksum got %s\n", tc.desc, fw.Checksum))
+	}
+}
+
+func TestDownloadFirmware(t *testing.T) {
+	svc := newService(otamocks.NewPublisher())
+	fw := upload(t, svc, "1.0.0")
+	untargeted := upload(t, svc, "2.0.0")
+
+	c, err := svc.CreateCampaign(context.Background(), userEmail, ota.Campaign{FirmwareID: fw.ID, GroupID: groupID, ChannelID: channelID})
+	require.Nil(t, err, fmt.Sprintf("unexpected error creating campaign: %s", err))
+	err = svc.StartCampaign(context.Background(), userEmail, c.ID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error starting campaign: %s", err))
+
+	cases := []struct {
+		desc string
+		key  string
+		id   string
+		err  error
+	}{
+		{
+			desc: "download firmware",
+			key:  thingKey,
+			id:   fw.ID,
+			err:  nil,
+		},
+		{
+			desc: "download firmware with invalid thing key",
+			key:  "invalid",
+			id:   fw.ID,
+			err:  errors.ErrAuthentication,
+		},
+		{
+			desc: "download firmware by thing outside campaign",
+			key:  "thing-3",
+			id:   fw.ID,
+			err:  errors.ErrNotFound,
+		},
+		{
+			desc: "download firmware without campaign",
+			key:  thingKey,
+			id:   untargeted.ID,
+			err:  errors.ErrNotFound,
+		},
+		{
+			desc: "download non-existing firmware",
+			key:  thingKey,
+			id:   "non-existing",
+			err:  errors.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		_, data, err := svc.DownloadFirmware(context.Background(), tc.key, tc.id)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected error %s got %s\n", tc.desc, tc.err, err))
+		if tc.err != nil {
+			continue
+		}
+		body, err := io.ReadAll(data)
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error reading image: %s\n", tc.desc, err))
+		assert.Equal(t, image, string(body), fmt.Sprintf("%s: expected image %s got %s\n", tc.desc, image, body))
+	}
+}
+
+func TestCreateCampaign(t *testing.T) {
+	svc := newService(otamocks.NewPublisher())
+	fw := upload(t, svc, "1.0.0")
+
+	cases := []struct {
+		desc     string
+		token    string
+		campaign ota.Campaign
+		err      error
+	}{
+		{
+			desc:     "create campaign",
+			token:    userEmail,
+			campaign: ota.Campaign{FirmwareID: fw.ID, GroupID: groupID, ChannelID: channelID},
+			err:      nil,
+		},
+		{
+			desc:     "create campaign with invalid token",
+			token:    "invalid",
+			campaign: ota.Campaign{FirmwareID: fw.ID, GroupID: groupID, ChannelID: channelID},
+			err:      errors.ErrAuthentication,
+		},
+		{
+			desc:     "create campaign with firmware owned by other user",
+			token:    otherUserEmail,
+			campaign: ota.Campaign{FirmwareID: fw.ID, GroupID: groupID, ChannelID: channelID},
+			err:      errors.ErrNotFound,
+		},
+		{
+			desc:     "create campaign with non-existing rollback firmware",
+			token:    userEmail,
+			campaign: ota.Campaign{FirmwareID: fw.ID, RollbackFirmwareID: "non-existing", GroupID: groupID, ChannelID: channelID},
+			err:      errors.ErrNotFound,
+		},
+		{
+			desc:     "create campaign with channel owned by other user",
+			token:    userEmail,
+			campaign: ota.Campaign{FirmwareID: fw.ID, GroupID: groupID, ChannelID: otherChannelID},
+			err:      errors.ErrAuthorization,
+		},
+		{
+			desc:     "create campaign with group owned by other user",
+			token:    userEmail,
+			campaign: ota.Campaign{FirmwareID: fw.ID, GroupID: otherGroupID, ChannelID: channelID},
+			err:      errors.ErrAuthorization,
+		},
+	}
+
+	for _, tc := range cases {
+		c, err := svc.CreateCampaign(context.Background(), tc.token, tc.campaign)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected error %s got %s\n", tc.desc, tc.err, err))
+		if tc.err == nil {
+			assert.Equal(t, ota.CampaignCreated, c.Status, fmt.Sprintf("%s: expected status %s got %s\n", tc.desc, ota.CampaignCreated, c.Status))
+		}
+	}
+}
+
+func TestCampaignLifecycle(t *testing.T) {
+	pub := otamocks.NewPublisher()
+	svc := newService(pub)
+	fw := upload(t, svc, "2.0.0")
+	prev := upload(t, svc, "1.0.0")
+
+	c, err := svc.CreateCampaign(context.Background(), userEmail, ota.Campaign{
+		FirmwareID:         fw.ID,
+		RollbackFirmwareID: prev.ID,
+		GroupID:            groupID,
+		ChannelID:          channelID,
+	})
+	require.Nil(t, err, fmt.Sprintf("unexpected error creating campaign: %s", err))
+
+	err = svc.RollbackCampaign(context.Background(), userEmail, c.ID)
+	assert.True(t, errors.Contains(err, ota.ErrInvalidStatus), fmt.Sprintf("rollback of not started campaign: expected error %s got %s\n", ota.ErrInvalidStatus, err))
+
+	err = svc.StartCampaign(context.Background(), otherUserEmail, c.ID)
+	assert.True(t, errors.Contains(err, errors.ErrNotFound), fmt.Sprintf("start campaign owned by other user: expected error %s got %s\n", errors.ErrNotFound, err))
+
+	err = svc.StartCampaign(context.Background(), userEmail, c.ID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error starting campaign: %s", err))
+
+	require.Len(t, pub.Messages, len(groups[groupID]))
+	for i, msg := range pub.Messages {
+		assert.Equal(t, channelID, msg.Channel)
+		assert.Equal(t, fmt.Sprintf("ota.%s", groups[groupID][i]), msg.Subtopic)
+
+		var cmd ota.Command
+		require.Nil(t, json.Unmarshal(msg.Payload, &cmd))
+		assert.Equal(t, "update", cmd.Command)
+		assert.Equal(t, fw.Version, cmd.Version)
+		assert.Equal(t, fmt.Sprintf("%s/firmware/%s/download", baseURL, fw.ID), cmd.URL)
+	}
+
+	err = svc.StartCampaign(context.Background(), userEmail, c.ID)
+	assert.True(t, errors.Contains(err, ota.ErrInvalidStatus), fmt.Sprintf("start running campaign: expected error %s got %s\n", ota.ErrInvalidStatus, err))
+
+	cases := []struct {
+		desc   string
+		key    string
+		status string
+		err    error
+	}{
+		{
+			desc:   "report applied status",
+			key:    "thing-1",
+			status: ota.StatusApplied,
+			err:    nil,
+		},
+		{
+			desc:   "report failed status",
+			key:    "thing-2",
+			status: ota.StatusFailed,
+			err:    nil,
+		},
+		{
+			desc:   "report unknown status",
+			key:    "thing-1",
+			status: "unknown",
+			err:    ota.ErrInvalidStatus,
+		},
+		{
+			desc:   "report status of thing outside campaign",
+			key:    "thing-3",
+			status: ota.StatusApplied,
+			err:    errors.ErrNotFound,
+		},
+		{
+			desc:   "report status with invalid key",
+			key:    "invalid",
+			status: ota.StatusApplied,
+			err:    errors.ErrAuthentication,
+		},
+	}
+
+	for _, tc := range cases {
+		err := svc.ReportStatus(context.Background(), tc.key, c.ID, tc.status, "")
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected error %s got %s\n", tc.desc, tc.err, err))
+	}
+
+	statuses, err := svc.ListStatuses(context.Background(), userEmail, c.ID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error listing statuses: %s", err))
+	require.Len(t, statuses, 2)
+	assert.Equal(t, ota.StatusApplied, statuses[0].Status)
+	assert.Equal(t, ota.StatusFailed, statuses[1].Status)
+
+	pub.Messages = nil
+	err = svc.RollbackCampaign(context.Background(), userEmail, c.ID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error rolling back campaign: %s", err))
+	require.Len(t, pub.Messages, 2)
+
+	var cmd ota.Command
+	require.Nil(t, json.Unmarshal(pub.Messages[0].Payload, &cmd))
+	assert.Equal(t, "rollback", cmd.Command)
+	assert.Equal(t, prev.ID, cmd.FirmwareID)
+
+	c, err = svc.ViewCampaign(context.Background(), userEmail, c.ID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error viewing campaign: %s", err))
+	assert.Equal(t, ota.CampaignRolledBack, c.Status)
+}
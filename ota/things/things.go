@@ -0,0 +1,40 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package things contains the SDK backed implementation of the group
+// things resolver.
+package things
+
+import (
+	"github.com/MainfluxLabs/mainflux/ota"
+	mfsdk "github.com/MainfluxLabs/mainflux/pkg/sdk/go"
+)
+
+const pageLimit = 100
+
+var _ ota.Things = (*things)(nil)
+
+type things struct {
+	sdk mfsdk.SDK
+}
+
+// New returns a group things resolver backed by the Mainflux SDK.
+func New(sdk mfsdk.SDK) ota.Things {
+	return &things{sdk: sdk}
+}
+
+func (t *things) GroupThings(token, groupID string) ([]string, error) {
+	var ids []string
+	for offset := uint64(0); ; offset += pageLimit {
+		page, err := t.sdk.ListGroupThings(groupID, token, offset, pageLimit)
+		if err != nil {
+			return nil, err
+		}
+
+		ids = append(ids, page.Things...)
+
+		if offset+pageLimit >= page.Total {
+			return ids, nil
+		}
+	}
+}
@@ -0,0 +1,143 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package acl
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+)
+
+const (
+	// profileKey is the channel metadata key holding the channel profile.
+	profileKey = "profile"
+
+	// aclKey is the channel profile key holding the topic ACL.
+	aclKey = "acl"
+
+	// ThingID is the pattern placeholder replaced by the ID of the thing
+	// whose access is checked.
+	ThingID = "{thing_id}"
+
+	singleLevel = "+"
+	multiLevel  = "#"
+)
+
+// ErrMalformedACL indicates malformed topic ACL or one of its patterns.
+var ErrMalformedACL = errors.New("malformed topic ACL")
+
+// ACL represents subtopic patterns things are allowed to publish and
+// subscribe to. Patterns use MQTT wildcards, i.e. "+" matches a single
+// level and "#" matches all the remaining levels. Empty list of patterns
+// doesn't restrict the access.
+type ACL struct {
+	Publish   []string `json:"publish,omitempty"`
+	Subscribe []string `json:"subscribe,omitempty"`
+}
+
+// Parse reads the topic ACL from the profile in the channel metadata and
+// validates its patterns.
+func Parse(metadata map[string]interface{}) (ACL, error) {
+	p, ok := metadata[profileKey]
+	if !ok {
+		return ACL{}, nil
+	}
+
+	profile, ok := p.(map[string]interface{})
+	if !ok {
+		return ACL{}, ErrMalformedACL
+	}
+
+	val, ok := profile[aclKey]
+	if !ok {
+		return ACL{}, nil
+	}
+
+	data, err := json.Marshal(val)
+	if err != nil {
+		return ACL{}, errors.Wrap(ErrMalformedACL, err)
+	}
+
+	var a ACL
+	if err := json.Unmarshal(data, &a); err != nil {
+		return ACL{}, errors.Wrap(ErrMalformedACL, err)
+	}
+
+	for _, p := range append(a.Publish, a.Subscribe...) {
+		if err := Validate(p); err != nil {
+			return ACL{}, err
+		}
+	}
+
+	return a, nil
+}
+
+// Validate checks whether the pattern is well formed, i.e. wildcards
+// occupy whole levels and the multi-level wildcard is the last one.
+func Validate(pattern string) error {
+	levels := split(pattern)
+	if len(levels) == 0 {
+		return ErrMalformedACL
+	}
+
+	for i, l := range levels {
+		switch {
+		case l == multiLevel && i != len(levels)-1:
+			return ErrMalformedACL
+		case l != multiLevel && l != singleLevel && strings.ContainsAny(l, multiLevel+singleLevel):
+			return ErrMalformedACL
+		}
+	}
+
+	return nil
+}
+
+// Allows checks whether any of the patterns covers the subtopic used by
+// the thing. Subtopic may contain the subscription wildcards, in which
+// case all the subtopics it matches must be covered by the pattern.
+func Allows(patterns []string, thingID, subtopic string) bool {
+	if len(patterns) == 0 {
+		return true
+	}
+
+	topic := split(subtopic)
+	for _, p := range patterns {
+		if covers(split(strings.ReplaceAll(p, ThingID, thingID)), topic) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func covers(pattern, topic []string) bool {
+	for i, p := range pattern {
+		if p == multiLevel {
+			return true
+		}
+		if i >= len(topic) {
+			return false
+		}
+
+		t := topic[i]
+		switch {
+		case t == multiLevel || t == ">":
+			return false
+		case p == singleLevel:
+			continue
+		case t == singleLevel || t == "*" || t != p:
+			return false
+		}
+	}
+
+	return len(pattern) == len(topic)
+}
+
+// split splits the topic on both MQTT and NATS level separators.
+func split(topic string) []string {
+	return strings.FieldsFunc(topic, func(r rune) bool {
+		return r == '/' || r == '.'
+	})
+}
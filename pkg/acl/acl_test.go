@@ -0,0 +1,195 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package acl_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/MainfluxLabs/mainflux/pkg/acl"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+const thingID = "513d02d2-16c1-4f23-98be-9e12f8fee898"
+
+func TestParse(t *testing.T) {
+	cases := []struct {
+		desc     string
+		metadata map[string]interface{}
+		acl      acl.ACL
+		err      error
+	}{
+		{
+			desc:     "parse metadata without ACL",
+			metadata: map[string]interface{}{"field": "value"},
+			acl:      acl.ACL{},
+			err:      nil,
+		},
+		{
+			desc:     "parse profile without ACL",
+			metadata: map[string]interface{}{"profile": map[string]interface{}{"mirror_to": "mirror"}},
+			acl:      acl.ACL{},
+			err:      nil,
+		},
+		{
+			desc:     "parse ACL outside of profile",
+			metadata: map[string]interface{}{"acl": map[string]interface{}{"publish": []interface{}{"devices/#"}}},
+			acl:      acl.ACL{},
+			err:      nil,
+		},
+		{
+			desc: "parse valid ACL",
+			metadata: map[string]interface{}{
+				"profile": map[string]interface{}{
+					"acl": map[string]interface{}{
+						"publish":   []interface{}{"devices/{thing_id}/#"},
+						"subscribe": []interface{}{"commands/+"},
+					},
+				},
+			},
+			acl: acl.ACL{
+				Publish:   []string{"devices/{thing_id}/#"},
+				Subscribe: []string{"commands/+"},
+			},
+			err: nil,
+		},
+		{
+			desc:     "parse ACL of invalid type",
+			metadata: map[string]interface{}{"profile": map[string]interface{}{"acl": "devices/#"}},
+			acl:      acl.ACL{},
+			err:      acl.ErrMalformedACL,
+		},
+		{
+			desc:     "parse ACL of malformed profile",
+			metadata: map[string]interface{}{"profile": "devices/#"},
+			acl:      acl.ACL{},
+			err:      acl.ErrMalformedACL,
+		},
+		{
+			desc: "parse ACL with malformed pattern",
+			metadata: map[string]interface{}{
+				"profile": map[string]interface{}{
+					"acl": map[string]interface{}{
+						"publish": []interface{}{"devices/#/temperature"},
+					},
+				},
+			},
+			acl: acl.ACL{},
+			err: acl.ErrMalformedACL,
+		},
+	}
+
+	for _, tc := range cases {
+		a, err := acl.Parse(tc.metadata)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.acl, a, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.acl, a))
+	}
+}
+
+func TestValidate(t *testing.T) {
+	cases := map[string]error{
+		"devices/{thing_id}/#": nil,
+		"devices/+/status":     nil,
+		"#":                    nil,
+		"":                     acl.ErrMalformedACL,
+		"devices/#/status":     acl.ErrMalformedACL,
+		"devices/temp#":        acl.ErrMalformedACL,
+		"devices/+temp":        acl.ErrMalformedACL,
+	}
+
+	for pattern, expected := range cases {
+		err := acl.Validate(pattern)
+		assert.Equal(t, expected, err, fmt.Sprintf("validate %s: expected %s got %s\n", pattern, expected, err))
+	}
+}
+
+func TestAllows(t *testing.T) {
+	patterns := []string{"devices/{thing_id}/#", "commands/+/status"}
+
+	cases := []struct {
+		desc     string
+		patterns []string
+		subtopic string
+		allowed  bool
+	}{
+		{
+			desc:     "access without patterns",
+			patterns: nil,
+			subtopic: "any/subtopic",
+			allowed:  true,
+		},
+		{
+			desc:     "access subtopic of the thing",
+			patterns: patterns,
+			subtopic: fmt.Sprintf("devices/%s/temperature", thingID),
+			allowed:  true,
+		},
+		{
+			desc:     "access subtopic of the thing using dot separators",
+			patterns: patterns,
+			subtopic: fmt.Sprintf("devices.%s.temperature", thingID),
+			allowed:  true,
+		},
+		{
+			desc:     "access parent level of the multi-level wildcard",
+			patterns: patterns,
+			subtopic: fmt.Sprintf("devices/%s", thingID),
+			allowed:  true,
+		},
+		{
+			desc:     "access subtopic of another thing",
+			patterns: patterns,
+			subtopic: "devices/other/temperature",
+			allowed:  false,
+		},
+		{
+			desc:     "access subtopic matching single-level wildcard",
+			patterns: patterns,
+			subtopic: "commands/reboot/status",
+			allowed:  true,
+		},
+		{
+			desc:     "access subtopic longer than the pattern",
+			patterns: patterns,
+			subtopic: "commands/reboot/status/details",
+			allowed:  false,
+		},
+		{
+			desc:     "access without subtopic",
+			patterns: patterns,
+			subtopic: "",
+			allowed:  false,
+		},
+		{
+			desc:     "subscribe to single-level wildcard covered by the pattern",
+			patterns: patterns,
+			subtopic: "commands/+/status",
+			allowed:  true,
+		},
+		{
+			desc:     "subscribe to multi-level wildcard covered by the pattern",
+			patterns: patterns,
+			subtopic: fmt.Sprintf("devices/%s/#", thingID),
+			allowed:  true,
+		},
+		{
+			desc:     "subscribe to multi-level wildcard not covered by the pattern",
+			patterns: patterns,
+			subtopic: "commands/#",
+			allowed:  false,
+		},
+		{
+			desc:     "subscribe to single-level wildcard not covered by the pattern",
+			patterns: patterns,
+			subtopic: "devices/*/temperature",
+			allowed:  false,
+		},
+	}
+
+	for _, tc := range cases {
+		allowed := acl.Allows(tc.patterns, thingID, tc.subtopic)
+		assert.Equal(t, tc.allowed, allowed, fmt.Sprintf("%s: expected %t got %t\n", tc.desc, tc.allowed, allowed))
+	}
+}
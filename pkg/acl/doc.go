@@ -0,0 +1,6 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package acl contains the topic ACL patterns which restrict subtopics
+// things may publish and subscribe to within their connected channels.
+package acl
@@ -0,0 +1,6 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package api contains the HTTP middlewares shared by the services' HTTP
+// servers, such as the CORS and security headers middleware and the API version router.
+package api
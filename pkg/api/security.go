@@ -0,0 +1,135 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	anyOrigin = "*"
+
+	originHeader         = "Origin"
+	varyHeader           = "Vary"
+	requestMethodHeader  = "Access-Control-Request-Method"
+	allowOriginHeader    = "Access-Control-Allow-Origin"
+	allowMethodsHeader   = "Access-Control-Allow-Methods"
+	allowHeadersHeader   = "Access-Control-Allow-Headers"
+	exposeHeadersHeader  = "Access-Control-Expose-Headers"
+	maxAgeHeader         = "Access-Control-Max-Age"
+	hstsHeader           = "Strict-Transport-Security"
+	cspHeader            = "Content-Security-Policy"
+	contentTypeOptions   = "X-Content-Type-Options"
+	frameOptionsHeader   = "X-Frame-Options"
+	referrerPolicyHeader = "Referrer-Policy"
+
+	preflightMaxAge = 10 * time.Minute
+)
+
+var (
+	defMethods       = []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete, http.MethodOptions}
+	defHeaders       = []string{"Authorization", "Content-Type", "Idempotency-Key", "X-Request-ID"}
+	defExposeHeaders = []string{"X-Request-ID", deprecationHeader, sunsetHeader, linkHeader}
+)
+
+// SecurityConfig contains the CORS and security headers configuration.
+type SecurityConfig struct {
+	// AllowedOrigins contains the origins allowed to make cross-origin
+	// requests, where "*" allows any origin. CORS is disabled if empty.
+	AllowedOrigins []string
+
+	// AllowedMethods contains the methods allowed in cross-origin requests.
+	AllowedMethods []string
+
+	// AllowedHeaders contains the headers allowed in cross-origin requests.
+	AllowedHeaders []string
+
+	// HSTSMaxAge is the Strict-Transport-Security max age. The header is
+	// omitted if the max age is zero.
+	HSTSMaxAge time.Duration
+
+	// ContentSecurityPolicy is the Content-Security-Policy header value.
+	// The header is omitted if empty.
+	ContentSecurityPolicy string
+}
+
+// ParseList splits the comma separated list of values, as passed by the
+// environment variables, omitting the empty values.
+func ParseList(list string) []string {
+	var ret []string
+	for _, v := range strings.Split(list, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			ret = append(ret, v)
+		}
+	}
+
+	return ret
+}
+
+// SecurityMiddleware sets the CORS and security response headers according
+// to the given configuration and responds to CORS preflight requests.
+func SecurityMiddleware(cfg SecurityConfig, h http.Handler) http.Handler {
+	methods := strings.Join(orDefault(cfg.AllowedMethods, defMethods), ", ")
+	headers := strings.Join(orDefault(cfg.AllowedHeaders, defHeaders), ", ")
+	expose := strings.Join(defExposeHeaders, ", ")
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hdr := w.Header()
+		hdr.Set(contentTypeOptions, "nosniff")
+		hdr.Set(frameOptionsHeader, "DENY")
+		hdr.Set(referrerPolicyHeader, "no-referrer")
+		if cfg.HSTSMaxAge > 0 {
+			hdr.Set(hstsHeader, "max-age="+strconv.FormatInt(int64(cfg.HSTSMaxAge.Seconds()), 10)+"; includeSubDomains")
+		}
+		if cfg.ContentSecurityPolicy != "" {
+			hdr.Set(cspHeader, cfg.ContentSecurityPolicy)
+		}
+
+		origin := r.Header.Get(originHeader)
+		if origin == "" || len(cfg.AllowedOrigins) == 0 {
+			h.ServeHTTP(w, r)
+			return
+		}
+
+		hdr.Add(varyHeader, originHeader)
+		if !originAllowed(cfg.AllowedOrigins, origin) {
+			h.ServeHTTP(w, r)
+			return
+		}
+
+		hdr.Set(allowOriginHeader, origin)
+		hdr.Set(exposeHeadersHeader, expose)
+
+		if r.Method == http.MethodOptions && r.Header.Get(requestMethodHeader) != "" {
+			hdr.Set(allowMethodsHeader, methods)
+			hdr.Set(allowHeadersHeader, headers)
+			hdr.Set(maxAgeHeader, strconv.Itoa(int(preflightMaxAge.Seconds())))
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		h.ServeHTTP(w, r)
+	})
+}
+
+func originAllowed(allowed []string, origin string) bool {
+	for _, o := range allowed {
+		if o == anyOrigin || strings.EqualFold(o, origin) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func orDefault(vals, def []string) []string {
+	if len(vals) == 0 {
+		return def
+	}
+
+	return vals
+}
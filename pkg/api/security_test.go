@@ -0,0 +1,152 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/pkg/api"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	allowedOrigin = "https://dashboard.example.com"
+	otherOrigin   = "https://other.example.com"
+	csp           = "default-src 'self'"
+)
+
+func TestParseList(t *testing.T) {
+	cases := []struct {
+		desc string
+		list string
+		res  []string
+	}{
+		{
+			desc: "parse empty list",
+			list: "",
+			res:  nil,
+		},
+		{
+			desc: "parse list with single value",
+			list: allowedOrigin,
+			res:  []string{allowedOrigin},
+		},
+		{
+			desc: "parse list with spaces and empty values",
+			list: fmt.Sprintf(" %s, ,%s,", allowedOrigin, otherOrigin),
+			res:  []string{allowedOrigin, otherOrigin},
+		},
+	}
+
+	for _, tc := range cases {
+		res := api.ParseList(tc.list)
+		assert.Equal(t, tc.res, res, fmt.Sprintf("%s: expected %v got %v", tc.desc, tc.res, res))
+	}
+}
+
+func TestSecurityMiddleware(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	cfg := api.SecurityConfig{
+		AllowedOrigins:        []string{allowedOrigin},
+		HSTSMaxAge:            time.Hour,
+		ContentSecurityPolicy: csp,
+	}
+
+	cases := []struct {
+		desc        string
+		cfg         api.SecurityConfig
+		method      string
+		origin      string
+		preflight   bool
+		status      int
+		allowOrigin string
+		hsts        string
+		csp         string
+	}{
+		{
+			desc:   "request without origin",
+			cfg:    cfg,
+			method: http.MethodGet,
+			status: http.StatusOK,
+			hsts:   "max-age=3600; includeSubDomains",
+			csp:    csp,
+		},
+		{
+			desc:        "request from allowed origin",
+			cfg:         cfg,
+			method:      http.MethodGet,
+			origin:      allowedOrigin,
+			status:      http.StatusOK,
+			allowOrigin: allowedOrigin,
+			hsts:        "max-age=3600; includeSubDomains",
+			csp:         csp,
+		},
+		{
+			desc:   "request from not allowed origin",
+			cfg:    cfg,
+			method: http.MethodGet,
+			origin: otherOrigin,
+			status: http.StatusOK,
+			hsts:   "max-age=3600; includeSubDomains",
+			csp:    csp,
+		},
+		{
+			desc:        "preflight request from allowed origin",
+			cfg:         cfg,
+			method:      http.MethodOptions,
+			origin:      allowedOrigin,
+			preflight:   true,
+			status:      http.StatusNoContent,
+			allowOrigin: allowedOrigin,
+			hsts:        "max-age=3600; includeSubDomains",
+			csp:         csp,
+		},
+		{
+			desc:        "request from any origin allowed",
+			cfg:         api.SecurityConfig{AllowedOrigins: []string{"*"}},
+			method:      http.MethodGet,
+			origin:      otherOrigin,
+			status:      http.StatusOK,
+			allowOrigin: otherOrigin,
+		},
+		{
+			desc:   "request with CORS disabled",
+			cfg:    api.SecurityConfig{},
+			method: http.MethodGet,
+			origin: allowedOrigin,
+			status: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		req := httptest.NewRequest(tc.method, "/things", nil)
+		if tc.origin != "" {
+			req.Header.Set("Origin", tc.origin)
+		}
+		if tc.preflight {
+			req.Header.Set("Access-Control-Request-Method", http.MethodPost)
+		}
+		rec := httptest.NewRecorder()
+		api.SecurityMiddleware(tc.cfg, next).ServeHTTP(rec, req)
+
+		assert.Equal(t, tc.status, rec.Code, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, rec.Code))
+		allowOrigin := rec.Header().Get("Access-Control-Allow-Origin")
+		assert.Equal(t, tc.allowOrigin, allowOrigin, fmt.Sprintf("%s: expected allowed origin %s got %s", tc.desc, tc.allowOrigin, allowOrigin))
+		hsts := rec.Header().Get("Strict-Transport-Security")
+		assert.Equal(t, tc.hsts, hsts, fmt.Sprintf("%s: expected HSTS header %s got %s", tc.desc, tc.hsts, hsts))
+		policy := rec.Header().Get("Content-Security-Policy")
+		assert.Equal(t, tc.csp, policy, fmt.Sprintf("%s: expected CSP header %s got %s", tc.desc, tc.csp, policy))
+		assert.Equal(t, "nosniff", rec.Header().Get("X-Content-Type-Options"), fmt.Sprintf("%s: expected nosniff content type options", tc.desc))
+		if tc.preflight {
+			assert.NotEmpty(t, rec.Header().Get("Access-Control-Allow-Methods"), fmt.Sprintf("%s: expected allowed methods", tc.desc))
+		}
+	}
+}
@@ -0,0 +1,113 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	// V1 is the initial API version, served both with and without the
+	// version prefix.
+	V1 = "v1"
+	// V2 is the API version introducing the breaking changes.
+	V2 = "v2"
+
+	deprecationHeader = "Deprecation"
+	sunsetHeader      = "Sunset"
+	linkHeader        = "Link"
+)
+
+// Version represents a single API version served under the "/<name>" path
+// prefix.
+type Version struct {
+	// Name is the version name used as the path prefix, e.g. "v2".
+	Name string
+
+	// Handler serves the version requests with the prefix stripped.
+	Handler http.Handler
+
+	// Deprecated marks the version as deprecated, which is announced using
+	// the Deprecation response header.
+	Deprecated bool
+
+	// Sunset is the time after which the deprecated version is going to be
+	// removed, announced using the Sunset response header if set.
+	Sunset time.Time
+
+	// Successor is the name of the version replacing the deprecated one,
+	// announced using the Link response header if set.
+	Successor string
+}
+
+// VersionRouter returns the handler routing the requests to the API versions
+// by their path prefixes. Requests without the version prefix are routed to
+// the first version, so that the existing clients keep working.
+func VersionRouter(versions ...Version) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if len(versions) == 0 {
+			http.NotFound(w, r)
+			return
+		}
+
+		v := versions[0]
+		for _, ver := range versions {
+			if path, ok := stripVersion(r.URL.Path, ver.Name); ok {
+				v = ver
+				r = withPath(r, path, ver.Name)
+				break
+			}
+		}
+
+		if v.Deprecated {
+			hdr := w.Header()
+			hdr.Set(deprecationHeader, "true")
+			if !v.Sunset.IsZero() {
+				hdr.Set(sunsetHeader, v.Sunset.UTC().Format(http.TimeFormat))
+			}
+			if v.Successor != "" {
+				hdr.Set(linkHeader, fmt.Sprintf(`</%s>; rel="successor-version"`, v.Successor))
+			}
+		}
+
+		v.Handler.ServeHTTP(w, r)
+	})
+}
+
+func stripVersion(path, name string) (string, bool) {
+	prefix := "/" + name
+	switch {
+	case path == prefix:
+		return "/", true
+	case strings.HasPrefix(path, prefix+"/"):
+		return strings.TrimPrefix(path, prefix), true
+	default:
+		return "", false
+	}
+}
+
+func withPath(r *http.Request, path, name string) *http.Request {
+	r2 := r.Clone(r.Context())
+	r2.URL.Path = path
+	if r.URL.RawPath != "" {
+		r2.URL.RawPath, _ = stripVersion(r.URL.RawPath, name)
+	}
+	r2.RequestURI = r2.URL.RequestURI()
+
+	return r2
+}
+
+// Versioned returns the handler serving the service API as both v1 and v2,
+// with v1 served by the v1Handler and v2 by the v2Handler. Services which
+// haven't introduced breaking changes yet pass the same handler for both.
+// v1 is marked deprecated, in favor of v2, once its sunset time is set.
+func Versioned(v1Handler, v2Handler http.Handler, v1Sunset time.Time) http.Handler {
+	return VersionRouter(
+		Version{Name: V1, Handler: v1Handler, Deprecated: !v1Sunset.IsZero(), Sunset: v1Sunset, Successor: V2},
+		Version{Name: V2, Handler: v2Handler},
+	)
+}
@@ -0,0 +1,93 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/pkg/api"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVersionRouter(t *testing.T) {
+	handler := func(version string) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("X-Version", version)
+			w.Header().Set("X-Path", r.URL.Path)
+		})
+	}
+
+	sunset := time.Date(2027, time.January, 1, 0, 0, 0, 0, time.UTC)
+	router := api.VersionRouter(
+		api.Version{Name: api.V1, Handler: handler(api.V1), Deprecated: true, Sunset: sunset, Successor: api.V2},
+		api.Version{Name: api.V2, Handler: handler(api.V2)},
+	)
+
+	cases := []struct {
+		desc       string
+		path       string
+		version    string
+		routed     string
+		deprecated bool
+	}{
+		{
+			desc:       "route request without version prefix",
+			path:       "/things/1",
+			version:    api.V1,
+			routed:     "/things/1",
+			deprecated: true,
+		},
+		{
+			desc:       "route v1 request",
+			path:       "/v1/things/1",
+			version:    api.V1,
+			routed:     "/things/1",
+			deprecated: true,
+		},
+		{
+			desc:    "route v2 request",
+			path:    "/v2/things/1",
+			version: api.V2,
+			routed:  "/things/1",
+		},
+		{
+			desc:    "route v2 root request",
+			path:    "/v2",
+			version: api.V2,
+			routed:  "/",
+		},
+		{
+			desc:       "route request with version-like path",
+			path:       "/v2things",
+			version:    api.V1,
+			routed:     "/v2things",
+			deprecated: true,
+		},
+	}
+
+	for _, tc := range cases {
+		req := httptest.NewRequest(http.MethodGet, tc.path, nil)
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		version := rec.Header().Get("X-Version")
+		assert.Equal(t, tc.version, version, fmt.Sprintf("%s: expected version %s got %s", tc.desc, tc.version, version))
+		path := rec.Header().Get("X-Path")
+		assert.Equal(t, tc.routed, path, fmt.Sprintf("%s: expected path %s got %s", tc.desc, tc.routed, path))
+
+		var deprecation, sunsetHdr, link string
+		if tc.deprecated {
+			deprecation = "true"
+			sunsetHdr = sunset.Format(http.TimeFormat)
+			link = `</v2>; rel="successor-version"`
+		}
+		assert.Equal(t, deprecation, rec.Header().Get("Deprecation"), fmt.Sprintf("%s: unexpected Deprecation header", tc.desc))
+		assert.Equal(t, sunsetHdr, rec.Header().Get("Sunset"), fmt.Sprintf("%s: unexpected Sunset header", tc.desc))
+		assert.Equal(t, link, rec.Header().Get("Link"), fmt.Sprintf("%s: unexpected Link header", tc.desc))
+	}
+}
@@ -0,0 +1,153 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package archive
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/transformers/senml"
+)
+
+const (
+	// DayLayout is the layout of the day partition of archived messages.
+	DayLayout = "2006-01-02"
+
+	extension = ".json.gz"
+)
+
+var (
+	// ErrNotFound indicates a non-existent archive object.
+	ErrNotFound = errors.New("archive object not found")
+
+	errEncode = errors.New("failed to encode archived messages")
+	errDecode = errors.New("failed to decode archived messages")
+)
+
+// Storage specifies the object storage of archived messages.
+type Storage interface {
+	// Put stores the object with the given key.
+	Put(ctx context.Context, key string, data []byte) error
+
+	// Get retrieves the object with the given key.
+	Get(ctx context.Context, key string) ([]byte, error)
+
+	// List retrieves the keys of the objects starting with the given prefix.
+	List(ctx context.Context, prefix string) ([]string, error)
+}
+
+// Part identifies the uploaded part of the multipart object.
+type Part struct {
+	Number int    `json:"number"`
+	ETag   string `json:"etag"`
+}
+
+// MultipartStorage specifies the object storage of the objects uploaded in
+// parts, which are downloaded using the signed URLs.
+type MultipartStorage interface {
+	Storage
+
+	// CreateUpload starts the multipart upload of the object with the given
+	// key and returns the upload ID.
+	CreateUpload(ctx context.Context, key string) (string, error)
+
+	// UploadPart uploads the part of the object with the given number.
+	UploadPart(ctx context.Context, key, uploadID string, number int, data []byte) (Part, error)
+
+	// CompleteUpload assembles the object from the uploaded parts.
+	CompleteUpload(ctx context.Context, key, uploadID string, parts []Part) error
+
+	// SignURL returns the URL of the object which is valid for the given duration.
+	SignURL(key string, ttl time.Duration) (string, error)
+}
+
+// Partition identifies the messages of a channel published during a day.
+type Partition struct {
+	Channel string
+	Day     string
+}
+
+// PartitionOf returns the partition of the message.
+func PartitionOf(msg senml.Message) Partition {
+	sec, dec := math.Modf(msg.Time)
+	t := time.Unix(int64(sec), int64(dec*1e9)).UTC()
+
+	return Partition{
+		Channel: msg.Channel,
+		Day:     t.Format(DayLayout),
+	}
+}
+
+// Prefix returns the key prefix of the partition objects.
+func (p Partition) Prefix(prefix string) string {
+	return fmt.Sprintf("%s%s/%s/", prefix, p.Channel, p.Day)
+}
+
+// Key returns the key of the partition object created at the given time.
+func (p Partition) Key(prefix string, created time.Time) string {
+	return fmt.Sprintf("%s%d%s", p.Prefix(prefix), created.UnixNano(), extension)
+}
+
+// ParseKey returns the partition of the object with the given key.
+func ParseKey(prefix, key string) (Partition, bool) {
+	parts := strings.Split(strings.TrimPrefix(key, prefix), "/")
+	if len(parts) != 3 || !strings.HasSuffix(parts[2], extension) {
+		return Partition{}, false
+	}
+	if _, err := time.Parse(DayLayout, parts[1]); err != nil {
+		return Partition{}, false
+	}
+
+	return Partition{Channel: parts[0], Day: parts[1]}, true
+}
+
+// Encode encodes the messages to the gzip compressed JSON lines.
+func Encode(msgs []senml.Message) ([]byte, error) {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	enc := json.NewEncoder(zw)
+	for _, msg := range msgs {
+		if err := enc.Encode(msg); err != nil {
+			return nil, errors.Wrap(errEncode, err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return nil, errors.Wrap(errEncode, err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// Decode decodes the messages from the gzip compressed JSON lines.
+func Decode(data []byte) ([]senml.Message, error) {
+	zr, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, errors.Wrap(errDecode, err)
+	}
+	defer zr.Close()
+
+	var msgs []senml.Message
+	sc := bufio.NewScanner(zr)
+	sc.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for sc.Scan() {
+		var msg senml.Message
+		if err := json.Unmarshal(sc.Bytes(), &msg); err != nil {
+			return nil, errors.Wrap(errDecode, err)
+		}
+		msgs = append(msgs, msg)
+	}
+	if err := sc.Err(); err != nil {
+		return nil, errors.Wrap(errDecode, err)
+	}
+
+	return msgs, nil
+}
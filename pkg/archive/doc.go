@@ -0,0 +1,6 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package archive contains the object storage of archived SenML messages,
+// shared by the archiver and the archive reader.
+package archive
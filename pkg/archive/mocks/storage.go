@@ -0,0 +1,124 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/pkg/archive"
+)
+
+// URLPrefix is the prefix of the signed URLs of the mocked objects.
+const URLPrefix = "http://storage.mock/"
+
+var _ archive.MultipartStorage = (*storageMock)(nil)
+
+type storageMock struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+	uploads map[string]map[int][]byte
+}
+
+// NewStorage returns mock of archive storage.
+func NewStorage() archive.Storage {
+	return NewMultipartStorage()
+}
+
+// NewMultipartStorage returns mock of archive storage supporting the
+// multipart uploads.
+func NewMultipartStorage() archive.MultipartStorage {
+	return &storageMock{
+		objects: make(map[string][]byte),
+		uploads: make(map[string]map[int][]byte),
+	}
+}
+
+func (sm *storageMock) Put(_ context.Context, key string, data []byte) error {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	sm.objects[key] = data
+	return nil
+}
+
+func (sm *storageMock) Get(_ context.Context, key string) ([]byte, error) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	data, ok := sm.objects[key]
+	if !ok {
+		return nil, archive.ErrNotFound
+	}
+
+	return data, nil
+}
+
+func (sm *storageMock) List(_ context.Context, prefix string) ([]string, error) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	var keys []string
+	for key := range sm.objects {
+		if strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	return keys, nil
+}
+
+func (sm *storageMock) CreateUpload(_ context.Context, key string) (string, error) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	id := fmt.Sprintf("%s-%d", key, len(sm.uploads)+1)
+	sm.uploads[id] = make(map[int][]byte)
+	return id, nil
+}
+
+func (sm *storageMock) UploadPart(_ context.Context, key, uploadID string, number int, data []byte) (archive.Part, error) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	parts, ok := sm.uploads[uploadID]
+	if !ok {
+		return archive.Part{}, archive.ErrNotFound
+	}
+	parts[number] = data
+
+	return archive.Part{Number: number, ETag: fmt.Sprintf("%s-%d", uploadID, number)}, nil
+}
+
+func (sm *storageMock) CompleteUpload(_ context.Context, key, uploadID string, parts []archive.Part) error {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	uploaded, ok := sm.uploads[uploadID]
+	if !ok {
+		return archive.ErrNotFound
+	}
+
+	var data []byte
+	for _, p := range parts {
+		part, ok := uploaded[p.Number]
+		if !ok {
+			return archive.ErrNotFound
+		}
+		data = append(data, part...)
+	}
+	sm.objects[key] = data
+	delete(sm.uploads, uploadID)
+
+	return nil
+}
+
+func (sm *storageMock) SignURL(key string, _ time.Duration) (string, error) {
+	return URLPrefix + key, nil
+}
@@ -0,0 +1,387 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package archive
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+)
+
+const (
+	algorithm    = "AWS4-HMAC-SHA256"
+	service      = "s3"
+	amzDate      = "20060102T150405Z"
+	amzDay       = "20060102"
+	emptyPayload = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+	unsigned     = "UNSIGNED-PAYLOAD"
+	maxURLTTL    = 7 * 24 * time.Hour
+)
+
+var errStorage = errors.New("failed to access archive storage")
+
+var _ MultipartStorage = (*s3Storage)(nil)
+
+// S3Config defines the S3-compatible object storage of archived messages.
+type S3Config struct {
+	// Endpoint is the storage URL, e.g. https://s3.eu-central-1.amazonaws.com.
+	Endpoint  string
+	Region    string
+	Bucket    string
+	AccessKey string
+	SecretKey string
+}
+
+type s3Storage struct {
+	cfg    S3Config
+	client *http.Client
+}
+
+// NewS3Storage returns the S3-compatible object storage. Objects are
+// addressed using the path style, which is supported by AWS S3 and by
+// the S3-compatible storages, such as MinIO.
+func NewS3Storage(cfg S3Config, client *http.Client) MultipartStorage {
+	cfg.Endpoint = strings.TrimSuffix(cfg.Endpoint, "/")
+
+	return &s3Storage{
+		cfg:    cfg,
+		client: client,
+	}
+}
+
+func (s *s3Storage) Put(ctx context.Context, key string, data []byte) error {
+	res, err := s.do(ctx, http.MethodPut, key, nil, data)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return errors.Wrap(errStorage, s.responseError(res))
+	}
+
+	return nil
+}
+
+func (s *s3Storage) Get(ctx context.Context, key string) ([]byte, error) {
+	res, err := s.do(ctx, http.MethodGet, key, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	switch res.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return nil, ErrNotFound
+	default:
+		return nil, errors.Wrap(errStorage, s.responseError(res))
+	}
+
+	data, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, errors.Wrap(errStorage, err)
+	}
+
+	return data, nil
+}
+
+type listResult struct {
+	Contents []struct {
+		Key string `xml:"Key"`
+	} `xml:"Contents"`
+	IsTruncated           bool   `xml:"IsTruncated"`
+	NextContinuationToken string `xml:"NextContinuationToken"`
+}
+
+func (s *s3Storage) List(ctx context.Context, prefix string) ([]string, error) {
+	var keys []string
+	token := ""
+	for {
+		query := url.Values{}
+		query.Set("list-type", "2")
+		query.Set("prefix", prefix)
+		if token != "" {
+			query.Set("continuation-token", token)
+		}
+
+		res, err := s.do(ctx, http.MethodGet, "", query, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		if res.StatusCode != http.StatusOK {
+			err := s.responseError(res)
+			res.Body.Close()
+			return nil, errors.Wrap(errStorage, err)
+		}
+
+		var lr listResult
+		err = xml.NewDecoder(res.Body).Decode(&lr)
+		res.Body.Close()
+		if err != nil {
+			return nil, errors.Wrap(errStorage, err)
+		}
+
+		for _, c := range lr.Contents {
+			keys = append(keys, c.Key)
+		}
+		if !lr.IsTruncated || lr.NextContinuationToken == "" {
+			return keys, nil
+		}
+		token = lr.NextContinuationToken
+	}
+}
+
+type initiateResult struct {
+	UploadID string `xml:"UploadId"`
+}
+
+func (s *s3Storage) CreateUpload(ctx context.Context, key string) (string, error) {
+	query := url.Values{}
+	query.Set("uploads", "")
+
+	res, err := s.do(ctx, http.MethodPost, key, query, nil)
+	if err != nil {
+		return "", err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return "", errors.Wrap(errStorage, s.responseError(res))
+	}
+
+	var ir initiateResult
+	if err := xml.NewDecoder(res.Body).Decode(&ir); err != nil {
+		return "", errors.Wrap(errStorage, err)
+	}
+
+	return ir.UploadID, nil
+}
+
+func (s *s3Storage) UploadPart(ctx context.Context, key, uploadID string, number int, data []byte) (Part, error) {
+	query := url.Values{}
+	query.Set("partNumber", strconv.Itoa(number))
+	query.Set("uploadId", uploadID)
+
+	res, err := s.do(ctx, http.MethodPut, key, query, data)
+	if err != nil {
+		return Part{}, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return Part{}, errors.Wrap(errStorage, s.responseError(res))
+	}
+
+	return Part{Number: number, ETag: res.Header.Get("ETag")}, nil
+}
+
+type completeUpload struct {
+	XMLName xml.Name       `xml:"CompleteMultipartUpload"`
+	Parts   []completePart `xml:"Part"`
+}
+
+type completePart struct {
+	PartNumber int    `xml:"PartNumber"`
+	ETag       string `xml:"ETag"`
+}
+
+func (s *s3Storage) CompleteUpload(ctx context.Context, key, uploadID string, parts []Part) error {
+	cu := completeUpload{}
+	for _, p := range parts {
+		cu.Parts = append(cu.Parts, completePart{PartNumber: p.Number, ETag: p.ETag})
+	}
+	body, err := xml.Marshal(cu)
+	if err != nil {
+		return errors.Wrap(errStorage, err)
+	}
+
+	query := url.Values{}
+	query.Set("uploadId", uploadID)
+
+	res, err := s.do(ctx, http.MethodPost, key, query, body)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	// The completion may fail after the response status is sent,
+	// in which case the error is returned in the response body.
+	data, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return errors.Wrap(errStorage, err)
+	}
+	if res.StatusCode != http.StatusOK || bytes.Contains(data, []byte("<Error>")) {
+		return errors.Wrap(errStorage, fmt.Errorf("unexpected response status %d: %s", res.StatusCode, bytes.TrimSpace(data)))
+	}
+
+	return nil
+}
+
+// SignURL returns the URL of the object signed using the query parameters
+// of the AWS Signature Version 4, which is valid for at most seven days.
+func (s *s3Storage) SignURL(key string, ttl time.Duration) (string, error) {
+	if ttl > maxURLTTL {
+		ttl = maxURLTTL
+	}
+
+	u, err := url.Parse(fmt.Sprintf("%s/%s/%s", s.cfg.Endpoint, s.cfg.Bucket, key))
+	if err != nil {
+		return "", errors.Wrap(errStorage, err)
+	}
+
+	now := time.Now().UTC()
+	scope := s.scope(now)
+	query := url.Values{}
+	query.Set("X-Amz-Algorithm", algorithm)
+	query.Set("X-Amz-Credential", fmt.Sprintf("%s/%s", s.cfg.AccessKey, scope))
+	query.Set("X-Amz-Date", now.Format(amzDate))
+	query.Set("X-Amz-Expires", strconv.Itoa(int(ttl.Seconds())))
+	query.Set("X-Amz-SignedHeaders", "host")
+	u.RawQuery = canonicalQuery(query)
+
+	canonicalRequest := strings.Join([]string{
+		http.MethodGet,
+		u.EscapedPath(),
+		u.RawQuery,
+		fmt.Sprintf("host:%s\n", u.Host),
+		"host",
+		unsigned,
+	}, "\n")
+
+	u.RawQuery = fmt.Sprintf("%s&X-Amz-Signature=%s", u.RawQuery, s.signature(now, scope, canonicalRequest))
+
+	return u.String(), nil
+}
+
+func (s *s3Storage) do(ctx context.Context, method, key string, query url.Values, body []byte) (*http.Response, error) {
+	u, err := url.Parse(fmt.Sprintf("%s/%s/%s", s.cfg.Endpoint, s.cfg.Bucket, key))
+	if err != nil {
+		return nil, errors.Wrap(errStorage, err)
+	}
+	u.RawQuery = canonicalQuery(query)
+
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, errors.Wrap(errStorage, err)
+	}
+	s.sign(req, body, time.Now().UTC())
+
+	res, err := s.client.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(errStorage, err)
+	}
+
+	return res, nil
+}
+
+// sign signs the request using the AWS Signature Version 4.
+func (s *s3Storage) sign(req *http.Request, body []byte, now time.Time) {
+	payloadHash := emptyPayload
+	if len(body) > 0 {
+		payloadHash = hashHex(body)
+	}
+	req.Header.Set("X-Amz-Date", now.Format(amzDate))
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	headers := []string{"host", "x-amz-content-sha256", "x-amz-date"}
+	var canonicalHeaders strings.Builder
+	for _, h := range headers {
+		v := req.Header.Get(h)
+		if h == "host" {
+			v = req.URL.Host
+		}
+		canonicalHeaders.WriteString(fmt.Sprintf("%s:%s\n", h, strings.TrimSpace(v)))
+	}
+	signedHeaders := strings.Join(headers, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.RawQuery,
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := s.scope(now)
+	signature := s.signature(now, scope, canonicalRequest)
+
+	req.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		algorithm, s.cfg.AccessKey, scope, signedHeaders, signature))
+}
+
+func (s *s3Storage) scope(now time.Time) string {
+	return fmt.Sprintf("%s/%s/%s/aws4_request", now.Format(amzDay), s.cfg.Region, service)
+}
+
+// signature returns the signature of the canonical request.
+func (s *s3Storage) signature(now time.Time, scope, canonicalRequest string) string {
+	stringToSign := strings.Join([]string{
+		algorithm,
+		now.Format(amzDate),
+		scope,
+		hashHex([]byte(canonicalRequest)),
+	}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+s.cfg.SecretKey), now.Format(amzDay))
+	key = hmacSHA256(key, s.cfg.Region)
+	key = hmacSHA256(key, service)
+	key = hmacSHA256(key, "aws4_request")
+
+	return hex.EncodeToString(hmacSHA256(key, stringToSign))
+}
+
+func (s *s3Storage) responseError(res *http.Response) error {
+	body, _ := ioutil.ReadAll(res.Body)
+	return fmt.Errorf("unexpected response status %d: %s", res.StatusCode, strings.TrimSpace(string(body)))
+}
+
+// canonicalQuery returns the query string sorted by the keys,
+// with the spaces encoded as required by the signature.
+func canonicalQuery(query url.Values) string {
+	keys := make([]string, 0, len(query))
+	for k := range query {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var params []string
+	for _, k := range keys {
+		for _, v := range query[k] {
+			params = append(params, fmt.Sprintf("%s=%s", escape(k), escape(v)))
+		}
+	}
+
+	return strings.Join(params, "&")
+}
+
+func escape(s string) string {
+	return strings.ReplaceAll(url.QueryEscape(s), "+", "%20")
+}
+
+func hashHex(data []byte) string {
+	h := sha256.Sum256(data)
+	return hex.EncodeToString(h[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
@@ -0,0 +1,195 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package archive_test
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/pkg/archive"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const bucket = "archive"
+
+// s3Server is a minimal S3-compatible storage, listing objects in pages of two keys.
+type s3Server struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+	uploads map[string]map[string][]byte
+}
+
+func (s *s3Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=access/") &&
+		!strings.HasPrefix(r.URL.Query().Get("X-Amz-Credential"), "access/") {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	key := strings.TrimPrefix(r.URL.Path, fmt.Sprintf("/%s/", bucket))
+	query := r.URL.Query()
+	switch {
+	case r.Method == http.MethodPost && query.Has("uploads"):
+		id := fmt.Sprintf("upload-%d", len(s.uploads)+1)
+		s.uploads[id] = map[string][]byte{}
+		w.Write([]byte(fmt.Sprintf("<InitiateMultipartUploadResult><UploadId>%s</UploadId></InitiateMultipartUploadResult>", id)))
+	case r.Method == http.MethodPut && query.Has("uploadId"):
+		data, _ := ioutil.ReadAll(r.Body)
+		s.uploads[query.Get("uploadId")][query.Get("partNumber")] = data
+		w.Header().Set("ETag", fmt.Sprintf(`"etag-%s"`, query.Get("partNumber")))
+	case r.Method == http.MethodPost && query.Has("uploadId"):
+		parts := s.uploads[query.Get("uploadId")]
+		body, _ := ioutil.ReadAll(r.Body)
+		var data []byte
+		for i := 1; i <= len(parts); i++ {
+			if !strings.Contains(string(body), fmt.Sprintf(`<PartNumber>%d</PartNumber><ETag>&#34;etag-%d&#34;</ETag>`, i, i)) {
+				w.Write([]byte("<Error><Code>InvalidPart</Code></Error>"))
+				return
+			}
+			data = append(data, parts[strconv.Itoa(i)]...)
+		}
+		s.objects[key] = data
+		delete(s.uploads, query.Get("uploadId"))
+	case r.Method == http.MethodPut:
+		data, _ := ioutil.ReadAll(r.Body)
+		s.objects[key] = data
+	case key != "":
+		data, ok := s.objects[key]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write(data)
+	default:
+		var keys []string
+		for k := range s.objects {
+			if strings.HasPrefix(k, r.URL.Query().Get("prefix")) {
+				keys = append(keys, k)
+			}
+		}
+		sort.Strings(keys)
+
+		start := 0
+		if token := r.URL.Query().Get("continuation-token"); token != "" {
+			start = sort.SearchStrings(keys, token)
+		}
+		end := start + 2
+		truncated := end < len(keys)
+		if !truncated {
+			end = len(keys)
+		}
+
+		var sb strings.Builder
+		sb.WriteString("<ListBucketResult>")
+		for _, k := range keys[start:end] {
+			sb.WriteString(fmt.Sprintf("<Contents><Key>%s</Key></Contents>", k))
+		}
+		if truncated {
+			sb.WriteString(fmt.Sprintf("<IsTruncated>true</IsTruncated><NextContinuationToken>%s</NextContinuationToken>", keys[end]))
+		}
+		sb.WriteString("</ListBucketResult>")
+		w.Write([]byte(sb.String()))
+	}
+}
+
+func TestS3Storage(t *testing.T) {
+	ts := httptest.NewServer(&s3Server{objects: map[string][]byte{}, uploads: map[string]map[string][]byte{}})
+	defer ts.Close()
+
+	cfg := archive.S3Config{
+		Endpoint:  ts.URL,
+		Region:    "us-east-1",
+		Bucket:    bucket,
+		AccessKey: "access",
+		SecretKey: "secret",
+	}
+	storage := archive.NewS3Storage(cfg, ts.Client())
+
+	keys := []string{"messages/a/2022-01-01/1.json.gz", "messages/a/2022-01-02/2.json.gz", "messages/b/2022-01-01/3.json.gz"}
+	for _, key := range keys {
+		err := storage.Put(context.Background(), key, []byte(key))
+		require.Nil(t, err, fmt.Sprintf("put object %s: unexpected error: %s", key, err))
+	}
+
+	data, err := storage.Get(context.Background(), keys[0])
+	assert.Nil(t, err, fmt.Sprintf("get object: expected no error got %s", err))
+	assert.Equal(t, keys[0], string(data), fmt.Sprintf("get object: expected %s got %s", keys[0], data))
+
+	_, err = storage.Get(context.Background(), "messages/c/2022-01-01/4.json.gz")
+	assert.Equal(t, archive.ErrNotFound, err, fmt.Sprintf("get non-existent object: expected %s got %s", archive.ErrNotFound, err))
+
+	cases := map[string]struct {
+		prefix string
+		keys   []string
+	}{
+		"list all objects":          {prefix: "messages/", keys: keys},
+		"list channel objects":      {prefix: "messages/a/", keys: keys[0:2]},
+		"list non-existent prefix":  {prefix: "messages/c/", keys: nil},
+		"list objects of channel b": {prefix: "messages/b/", keys: keys[2:]},
+	}
+	for desc, tc := range cases {
+		res, err := storage.List(context.Background(), tc.prefix)
+		assert.Nil(t, err, fmt.Sprintf("%s: expected no error got %s", desc, err))
+		assert.Equal(t, tc.keys, res, fmt.Sprintf("%s: expected %v got %v", desc, tc.keys, res))
+	}
+}
+
+func TestS3MultipartUpload(t *testing.T) {
+	ts := httptest.NewServer(&s3Server{objects: map[string][]byte{}, uploads: map[string]map[string][]byte{}})
+	defer ts.Close()
+
+	cfg := archive.S3Config{
+		Endpoint:  ts.URL,
+		Region:    "us-east-1",
+		Bucket:    bucket,
+		AccessKey: "access",
+		SecretKey: "secret",
+	}
+	storage := archive.NewS3Storage(cfg, ts.Client())
+
+	key := "exports/a/1.ndjson.gz"
+	uploadID, err := storage.CreateUpload(context.Background(), key)
+	require.Nil(t, err, fmt.Sprintf("create upload: unexpected error: %s", err))
+
+	var parts []archive.Part
+	for i, data := range []string{"first-", "second"} {
+		part, err := storage.UploadPart(context.Background(), key, uploadID, i+1, []byte(data))
+		require.Nil(t, err, fmt.Sprintf("upload part %d: unexpected error: %s", i+1, err))
+		parts = append(parts, part)
+	}
+
+	err = storage.CompleteUpload(context.Background(), key, uploadID, parts[1:])
+	assert.NotNil(t, err, "complete upload with missing part: expected error got nil")
+
+	err = storage.CompleteUpload(context.Background(), key, uploadID, parts)
+	require.Nil(t, err, fmt.Sprintf("complete upload: unexpected error: %s", err))
+
+	data, err := storage.Get(context.Background(), key)
+	assert.Nil(t, err, fmt.Sprintf("get uploaded object: expected no error got %s", err))
+	assert.Equal(t, "first-second", string(data), fmt.Sprintf("get uploaded object: expected %s got %s", "first-second", data))
+
+	u, err := storage.SignURL(key, time.Hour)
+	require.Nil(t, err, fmt.Sprintf("sign url: unexpected error: %s", err))
+	assert.Contains(t, u, "X-Amz-Expires=3600", fmt.Sprintf("sign url: expected expiration in %s", u))
+	assert.Contains(t, u, "X-Amz-Signature=", fmt.Sprintf("sign url: expected signature in %s", u))
+
+	res, err := ts.Client().Get(u)
+	require.Nil(t, err, fmt.Sprintf("get signed url: unexpected error: %s", err))
+	defer res.Body.Close()
+	body, _ := ioutil.ReadAll(res.Body)
+	assert.Equal(t, "first-second", string(body), fmt.Sprintf("get signed url: expected %s got %s", "first-second", body))
+}
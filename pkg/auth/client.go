@@ -7,13 +7,17 @@ import (
 	"context"
 
 	"github.com/MainfluxLabs/mainflux"
+	"github.com/MainfluxLabs/mainflux/pkg/acl"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
 	"github.com/go-redis/redis/v8"
 )
 
 // Client represents Auth cache.
 type Client interface {
 	Authorize(ctx context.Context, chanID, thingID string) error
+	AuthorizeBatch(ctx context.Context, chanIDs []string, thingID string) error
 	Identify(ctx context.Context, thingKey string) (string, error)
+	TopicACL(ctx context.Context, chanID string) (acl.ACL, error)
 }
 
 const (
@@ -63,3 +67,40 @@ func (c client) Authorize(ctx context.Context, chanID, thingID string) error {
 	_, err := c.things.CanAccessByID(ctx, ar)
 	return err
 }
+
+func (c client) AuthorizeBatch(ctx context.Context, chanIDs []string, thingID string) error {
+	var missing []string
+	for _, chanID := range chanIDs {
+		if !c.redisClient.SIsMember(ctx, chanPrefix+":"+chanID, thingID).Val() {
+			missing = append(missing, chanID)
+		}
+	}
+
+	if len(missing) == 0 {
+		return nil
+	}
+
+	ar := &mainflux.AccessBatchReq{
+		ThingID: thingID,
+		ChanIDs: missing,
+	}
+	res, err := c.things.CanAccessBatch(ctx, ar)
+	if err != nil {
+		return err
+	}
+
+	if len(res.GetChanIDs()) != len(missing) {
+		return errors.ErrAuthorization
+	}
+
+	return nil
+}
+
+func (c client) TopicACL(ctx context.Context, chanID string) (acl.ACL, error) {
+	res, err := c.things.GetTopicACL(ctx, &mainflux.ChannelID{Value: chanID})
+	if err != nil {
+		return acl.ACL{}, err
+	}
+
+	return acl.ACL{Publish: res.GetPublish(), Subscribe: res.GetSubscribe()}, nil
+}
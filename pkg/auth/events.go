@@ -0,0 +1,84 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package auth
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+const (
+	thingsStream = "mainflux.things"
+
+	// Failed reads of the events are retried with the exponential backoff.
+	minBackoff = 100 * time.Millisecond
+	maxBackoff = 5 * time.Second
+
+	thingUpdate     = "thing.update"
+	thingRemove     = "thing.remove"
+	thingUpdateKey  = "thing.update_key"
+	thingUpdateSK   = "thing.update_signing_key"
+	thingRemoveSK   = "thing.remove_signing_key"
+	thingDisconnect = "thing.disconnect"
+	channelUpdate   = "channel.update"
+	channelRemove   = "channel.remove"
+)
+
+// Invalidate reads events published by the things service and removes
+// affected entries from the cache, until the context is canceled. Only
+// events published after the call are taken into account.
+func Invalidate(ctx context.Context, es *redis.Client, cache ThingsCache) error {
+	lastID := "$"
+	backoff := minBackoff
+	for {
+		streams, err := es.XRead(ctx, &redis.XReadArgs{
+			Streams: []string{thingsStream, lastID},
+			Count:   100,
+		}).Result()
+		if ctx.Err() != nil {
+			return nil
+		}
+		if err != nil {
+			select {
+			case <-time.After(backoff):
+			case <-ctx.Done():
+				return nil
+			}
+			if backoff *= 2; backoff > maxBackoff {
+				backoff = maxBackoff
+			}
+			continue
+		}
+		backoff = minBackoff
+		if len(streams) == 0 {
+			continue
+		}
+
+		for _, msg := range streams[0].Messages {
+			lastID = msg.ID
+			handleEvent(cache, msg.Values)
+		}
+	}
+}
+
+func handleEvent(cache ThingsCache, event map[string]interface{}) {
+	switch event["operation"] {
+	case thingUpdate, thingRemove, thingUpdateKey, thingUpdateSK, thingRemoveSK:
+		cache.RemoveThing(read(event, "id"))
+	case thingDisconnect:
+		cache.Disconnect(read(event, "chan_id"), read(event, "thing_id"))
+	case channelUpdate:
+		cache.RemoveTopicACL(read(event, "id"))
+		cache.RemoveChannelProfile(read(event, "id"))
+	case channelRemove:
+		cache.RemoveChannel(read(event, "id"))
+	}
+}
+
+func read(event map[string]interface{}, key string) string {
+	val, _ := event[key].(string)
+	return val
+}
@@ -0,0 +1,407 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package auth
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux"
+	"github.com/MainfluxLabs/mainflux/pkg/resilience"
+	"github.com/golang/protobuf/ptypes/empty"
+	"google.golang.org/grpc"
+	"gopkg.in/square/go-jose.v2/jwt"
+)
+
+// ThingsCache represents things service client which caches successful
+// identifications and authorizations for the configured TTL. Expired
+// entries are used as a fallback while the things service is unavailable.
+type ThingsCache interface {
+	mainflux.ThingsServiceClient
+
+	// RemoveThing removes cached identity, authorizations and signing key
+	// of the thing.
+	RemoveThing(thingID string)
+
+	// RemoveChannel removes cached authorizations to access the channel,
+	// its topic ACL and its profile.
+	RemoveChannel(chanID string)
+
+	// RemoveTopicACL removes cached topic ACL of the channel.
+	RemoveTopicACL(chanID string)
+
+	// RemoveChannelProfile removes cached profile of the channel.
+	RemoveChannelProfile(chanID string)
+
+	// Disconnect removes cached authorization of the thing to access the channel.
+	Disconnect(chanID, thingID string)
+}
+
+var _ ThingsCache = (*thingsCache)(nil)
+
+// keyEntry is the cached identity of the thing key. Entries of the external
+// keys are valid until the keys expire, even as a fallback.
+type keyEntry struct {
+	thingID string
+	expires time.Time
+	until   time.Time
+}
+
+// valid reports whether the key may still be used as a fallback.
+func (e keyEntry) valid() bool {
+	return e.until.IsZero() || time.Now().Before(e.until)
+}
+
+type signingKeyEntry struct {
+	sk      *mainflux.SigningKey
+	expires time.Time
+}
+
+type topicACLEntry struct {
+	acl     *mainflux.TopicACL
+	expires time.Time
+}
+
+type profileEntry struct {
+	profile *mainflux.ChannelProfile
+	expires time.Time
+}
+
+type thingsCache struct {
+	mainflux.ThingsServiceClient
+	ttl         time.Duration
+	mu          sync.Mutex
+	keys        map[string]keyEntry
+	conns       map[string]map[string]time.Time
+	signingKeys map[string]signingKeyEntry
+	topicACLs   map[string]topicACLEntry
+	profiles    map[string]profileEntry
+}
+
+// NewThingsCache returns things service client which caches results
+// of the wrapped client. Denied access is never cached.
+func NewThingsCache(things mainflux.ThingsServiceClient, ttl time.Duration) ThingsCache {
+	return &thingsCache{
+		ThingsServiceClient: things,
+		ttl:                 ttl,
+		keys:                make(map[string]keyEntry),
+		conns:               make(map[string]map[string]time.Time),
+		signingKeys:         make(map[string]signingKeyEntry),
+		topicACLs:           make(map[string]topicACLEntry),
+		profiles:            make(map[string]profileEntry),
+	}
+}
+
+func (tc *thingsCache) Identify(ctx context.Context, req *mainflux.Token, opts ...grpc.CallOption) (*mainflux.ThingID, error) {
+	e, ok := tc.key(req.GetValue())
+	if ok && time.Now().Before(e.expires) {
+		return &mainflux.ThingID{Value: e.thingID}, nil
+	}
+
+	res, err := tc.ThingsServiceClient.Identify(ctx, req, opts...)
+	if err != nil {
+		if ok && e.valid() && resilience.Unavailable(err) {
+			return &mainflux.ThingID{Value: e.thingID}, nil
+		}
+		return nil, err
+	}
+	tc.saveKey(req.GetValue(), res.GetValue())
+
+	return res, nil
+}
+
+func (tc *thingsCache) CanAccessByKey(ctx context.Context, req *mainflux.AccessByKeyReq, opts ...grpc.CallOption) (*mainflux.ThingID, error) {
+	e, ok := tc.key(req.GetToken())
+	var expires time.Time
+	if ok {
+		expires, ok = tc.conn(req.GetChanID(), e.thingID)
+	}
+	if ok && time.Now().Before(e.expires) && time.Now().Before(expires) {
+		return &mainflux.ThingID{Value: e.thingID}, nil
+	}
+
+	res, err := tc.ThingsServiceClient.CanAccessByKey(ctx, req, opts...)
+	if err != nil {
+		if ok && e.valid() && resilience.Unavailable(err) {
+			return &mainflux.ThingID{Value: e.thingID}, nil
+		}
+		return nil, err
+	}
+	tc.saveKey(req.GetToken(), res.GetValue())
+	tc.connect(res.GetValue(), req.GetChanID())
+
+	return res, nil
+}
+
+func (tc *thingsCache) CanAccessByID(ctx context.Context, req *mainflux.AccessByIDReq, opts ...grpc.CallOption) (*empty.Empty, error) {
+	expires, ok := tc.conn(req.GetChanID(), req.GetThingID())
+	if ok && time.Now().Before(expires) {
+		return &empty.Empty{}, nil
+	}
+
+	res, err := tc.ThingsServiceClient.CanAccessByID(ctx, req, opts...)
+	if err != nil {
+		if ok && resilience.Unavailable(err) {
+			return &empty.Empty{}, nil
+		}
+		return nil, err
+	}
+	tc.connect(req.GetThingID(), req.GetChanID())
+
+	return res, nil
+}
+
+func (tc *thingsCache) CanAccessBatch(ctx context.Context, req *mainflux.AccessBatchReq, opts ...grpc.CallOption) (*mainflux.AccessBatchRes, error) {
+	var cached, stale, missing []string
+	for _, chanID := range req.GetChanIDs() {
+		expires, ok := tc.conn(chanID, req.GetThingID())
+		switch {
+		case ok && time.Now().Before(expires):
+			cached = append(cached, chanID)
+		case ok:
+			stale = append(stale, chanID)
+			missing = append(missing, chanID)
+		default:
+			missing = append(missing, chanID)
+		}
+	}
+
+	if len(missing) == 0 {
+		return &mainflux.AccessBatchRes{ChanIDs: cached}, nil
+	}
+
+	res, err := tc.ThingsServiceClient.CanAccessBatch(ctx, &mainflux.AccessBatchReq{ThingID: req.GetThingID(), ChanIDs: missing}, opts...)
+	if err != nil {
+		if resilience.Unavailable(err) {
+			return &mainflux.AccessBatchRes{ChanIDs: append(cached, stale...)}, nil
+		}
+		return nil, err
+	}
+	tc.connect(req.GetThingID(), res.GetChanIDs()...)
+
+	return &mainflux.AccessBatchRes{ChanIDs: append(cached, res.GetChanIDs()...)}, nil
+}
+
+func (tc *thingsCache) GetSigningKey(ctx context.Context, req *mainflux.ThingID, opts ...grpc.CallOption) (*mainflux.SigningKey, error) {
+	e, ok := tc.signingKey(req.GetValue())
+	if ok && time.Now().Before(e.expires) {
+		return e.sk, nil
+	}
+
+	res, err := tc.ThingsServiceClient.GetSigningKey(ctx, req, opts...)
+	if err != nil {
+		if ok && resilience.Unavailable(err) {
+			return e.sk, nil
+		}
+		return nil, err
+	}
+	tc.saveSigningKey(req.GetValue(), res)
+
+	return res, nil
+}
+
+func (tc *thingsCache) GetTopicACL(ctx context.Context, req *mainflux.ChannelID, opts ...grpc.CallOption) (*mainflux.TopicACL, error) {
+	e, ok := tc.topicACL(req.GetValue())
+	if ok && time.Now().Before(e.expires) {
+		return e.acl, nil
+	}
+
+	res, err := tc.ThingsServiceClient.GetTopicACL(ctx, req, opts...)
+	if err != nil {
+		if ok && resilience.Unavailable(err) {
+			return e.acl, nil
+		}
+		return nil, err
+	}
+	tc.saveTopicACL(req.GetValue(), res)
+
+	return res, nil
+}
+
+func (tc *thingsCache) GetChannelProfile(ctx context.Context, req *mainflux.ChannelID, opts ...grpc.CallOption) (*mainflux.ChannelProfile, error) {
+	e, ok := tc.profile(req.GetValue())
+	if ok && time.Now().Before(e.expires) {
+		return e.profile, nil
+	}
+
+	res, err := tc.ThingsServiceClient.GetChannelProfile(ctx, req, opts...)
+	if err != nil {
+		if ok && resilience.Unavailable(err) {
+			return e.profile, nil
+		}
+		return nil, err
+	}
+	tc.saveProfile(req.GetValue(), res)
+
+	return res, nil
+}
+
+func (tc *thingsCache) RemoveThing(thingID string) {
+	tc.mu.Lock()
+	defer tc.mu.Unlock()
+
+	delete(tc.conns, thingID)
+	delete(tc.signingKeys, thingID)
+	for key, e := range tc.keys {
+		if e.thingID == thingID {
+			delete(tc.keys, key)
+		}
+	}
+}
+
+func (tc *thingsCache) RemoveChannel(chanID string) {
+	tc.mu.Lock()
+	defer tc.mu.Unlock()
+
+	delete(tc.topicACLs, chanID)
+	delete(tc.profiles, chanID)
+	for _, chans := range tc.conns {
+		delete(chans, chanID)
+	}
+}
+
+func (tc *thingsCache) RemoveTopicACL(chanID string) {
+	tc.mu.Lock()
+	defer tc.mu.Unlock()
+
+	delete(tc.topicACLs, chanID)
+}
+
+func (tc *thingsCache) RemoveChannelProfile(chanID string) {
+	tc.mu.Lock()
+	defer tc.mu.Unlock()
+
+	delete(tc.profiles, chanID)
+}
+
+func (tc *thingsCache) Disconnect(chanID, thingID string) {
+	tc.mu.Lock()
+	defer tc.mu.Unlock()
+
+	delete(tc.conns[thingID], chanID)
+}
+
+// key returns the cached entry of the thing key, including the expired one.
+func (tc *thingsCache) key(key string) (keyEntry, bool) {
+	tc.mu.Lock()
+	defer tc.mu.Unlock()
+
+	e, ok := tc.keys[key]
+	return e, ok
+}
+
+// conn returns the expiration time of the cached connection.
+func (tc *thingsCache) conn(chanID, thingID string) (time.Time, bool) {
+	tc.mu.Lock()
+	defer tc.mu.Unlock()
+
+	expires, ok := tc.conns[thingID][chanID]
+	return expires, ok
+}
+
+// signingKey returns the cached signing key of the thing, including the expired one.
+func (tc *thingsCache) signingKey(thingID string) (signingKeyEntry, bool) {
+	tc.mu.Lock()
+	defer tc.mu.Unlock()
+
+	e, ok := tc.signingKeys[thingID]
+	return e, ok
+}
+
+// topicACL returns the cached topic ACL of the channel, including the expired one.
+func (tc *thingsCache) topicACL(chanID string) (topicACLEntry, bool) {
+	tc.mu.Lock()
+	defer tc.mu.Unlock()
+
+	e, ok := tc.topicACLs[chanID]
+	return e, ok
+}
+
+func (tc *thingsCache) saveTopicACL(chanID string, acl *mainflux.TopicACL) {
+	tc.mu.Lock()
+	defer tc.mu.Unlock()
+
+	tc.topicACLs[chanID] = topicACLEntry{acl: acl, expires: time.Now().Add(tc.ttl)}
+}
+
+// profile returns the cached profile of the channel, including the expired one.
+func (tc *thingsCache) profile(chanID string) (profileEntry, bool) {
+	tc.mu.Lock()
+	defer tc.mu.Unlock()
+
+	e, ok := tc.profiles[chanID]
+	return e, ok
+}
+
+func (tc *thingsCache) saveProfile(chanID string, profile *mainflux.ChannelProfile) {
+	tc.mu.Lock()
+	defer tc.mu.Unlock()
+
+	tc.profiles[chanID] = profileEntry{profile: profile, expires: time.Now().Add(tc.ttl)}
+}
+
+func (tc *thingsCache) saveSigningKey(thingID string, sk *mainflux.SigningKey) {
+	tc.mu.Lock()
+	defer tc.mu.Unlock()
+
+	tc.signingKeys[thingID] = signingKeyEntry{sk: sk, expires: time.Now().Add(tc.ttl)}
+}
+
+func (tc *thingsCache) saveKey(key, thingID string) {
+	until, ok := keyExpiry(key)
+	if !ok {
+		return
+	}
+
+	expires := time.Now().Add(tc.ttl)
+	if !until.IsZero() && until.Before(expires) {
+		expires = until
+	}
+
+	tc.mu.Lock()
+	defer tc.mu.Unlock()
+
+	tc.keys[key] = keyEntry{thingID: thingID, expires: expires, until: until}
+}
+
+// keyExpiry returns the expiration time of the external key, i.e. of the
+// JWT issued by the external identity provider, or zero time for the keys
+// which don't expire. External keys without the expiration are not cached.
+// The key is already validated by the things service, so its claims are
+// parsed without verification.
+func keyExpiry(key string) (time.Time, bool) {
+	if strings.Count(key, ".") != 2 {
+		return time.Time{}, true
+	}
+
+	tok, err := jwt.ParseSigned(key)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	var claims jwt.Claims
+	if err := tok.UnsafeClaimsWithoutVerification(&claims); err != nil || claims.Expiry == nil {
+		return time.Time{}, false
+	}
+
+	return claims.Expiry.Time(), true
+}
+
+func (tc *thingsCache) connect(thingID string, chanIDs ...string) {
+	tc.mu.Lock()
+	defer tc.mu.Unlock()
+
+	chans, ok := tc.conns[thingID]
+	if !ok {
+		chans = make(map[string]time.Time)
+		tc.conns[thingID] = chans
+	}
+
+	expires := time.Now().Add(tc.ttl)
+	for _, chanID := range chanIDs {
+		chans[chanID] = expires
+	}
+}
@@ -0,0 +1,526 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package auth_test
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux"
+	"github.com/MainfluxLabs/mainflux/pkg/auth"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/go-redis/redis/v8"
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	jose "gopkg.in/square/go-jose.v2"
+	"gopkg.in/square/go-jose.v2/jwt"
+)
+
+const (
+	thingKey = "thing-key"
+	thingID  = "thing-id"
+	chanID   = "chan-id"
+	ttl      = time.Minute
+)
+
+var (
+	_ mainflux.ThingsServiceClient = (*thingsClient)(nil)
+
+	errUnavailable = status.Error(codes.Unavailable, "things service is unavailable")
+)
+
+// thingsClient counts calls made to the things service.
+type thingsClient struct {
+	mainflux.ThingsServiceClient
+	keys  map[string]bool
+	conns map[string]bool
+	calls int
+	down  bool
+}
+
+func newThingsClient() *thingsClient {
+	return &thingsClient{keys: map[string]bool{thingKey: true}, conns: map[string]bool{chanID: true}}
+}
+
+func (tc *thingsClient) Identify(_ context.Context, req *mainflux.Token, _ ...grpc.CallOption) (*mainflux.ThingID, error) {
+	tc.calls++
+	if tc.down {
+		return nil, errUnavailable
+	}
+	if !tc.keys[req.GetValue()] {
+		return nil, errors.ErrAuthentication
+	}
+	return &mainflux.ThingID{Value: thingID}, nil
+}
+
+func (tc *thingsClient) CanAccessByKey(ctx context.Context, req *mainflux.AccessByKeyReq, _ ...grpc.CallOption) (*mainflux.ThingID, error) {
+	if _, err := tc.Identify(ctx, &mainflux.Token{Value: req.GetToken()}); err != nil {
+		return nil, err
+	}
+	if !tc.conns[req.GetChanID()] {
+		return nil, errors.ErrAuthorization
+	}
+	return &mainflux.ThingID{Value: thingID}, nil
+}
+
+func (tc *thingsClient) CanAccessByID(_ context.Context, req *mainflux.AccessByIDReq, _ ...grpc.CallOption) (*empty.Empty, error) {
+	tc.calls++
+	if tc.down {
+		return nil, errUnavailable
+	}
+	if req.GetThingID() != thingID || !tc.conns[req.GetChanID()] {
+		return nil, errors.ErrAuthorization
+	}
+	return &empty.Empty{}, nil
+}
+
+func (tc *thingsClient) CanAccessBatch(_ context.Context, req *mainflux.AccessBatchReq, _ ...grpc.CallOption) (*mainflux.AccessBatchRes, error) {
+	tc.calls++
+	res := &mainflux.AccessBatchRes{}
+	for _, chID := range req.GetChanIDs() {
+		if req.GetThingID() == thingID && tc.conns[chID] {
+			res.ChanIDs = append(res.ChanIDs, chID)
+		}
+	}
+	return res, nil
+}
+
+func (tc *thingsClient) GetSigningKey(_ context.Context, req *mainflux.ThingID, _ ...grpc.CallOption) (*mainflux.SigningKey, error) {
+	tc.calls++
+	if tc.down {
+		return nil, errUnavailable
+	}
+	return &mainflux.SigningKey{}, nil
+}
+
+func (tc *thingsClient) GetTopicACL(_ context.Context, req *mainflux.ChannelID, _ ...grpc.CallOption) (*mainflux.TopicACL, error) {
+	tc.calls++
+	if tc.down {
+		return nil, errUnavailable
+	}
+	return &mainflux.TopicACL{Publish: []string{"devices/{thing_id}/#"}}, nil
+}
+
+func (tc *thingsClient) GetChannelProfile(_ context.Context, req *mainflux.ChannelID, _ ...grpc.CallOption) (*mainflux.ChannelProfile, error) {
+	tc.calls++
+	if tc.down {
+		return nil, errUnavailable
+	}
+	return &mainflux.ChannelProfile{MirrorTo: "mirror-id"}, nil
+}
+
+func TestCanAccessByKey(t *testing.T) {
+	tc := newThingsClient()
+	cache := auth.NewThingsCache(tc, ttl)
+
+	cases := []struct {
+		desc   string
+		key    string
+		chanID string
+		remove func()
+		calls  int
+		err    error
+	}{
+		{
+			desc:   "access channel with valid key",
+			key:    thingKey,
+			chanID: chanID,
+			calls:  1,
+			err:    nil,
+		},
+		{
+			desc:   "access channel with cached key",
+			key:    thingKey,
+			chanID: chanID,
+			calls:  1,
+			err:    nil,
+		},
+		{
+			desc:   "access unconnected channel with cached key",
+			key:    thingKey,
+			chanID: "unconnected",
+			calls:  2,
+			err:    errors.ErrAuthorization,
+		},
+		{
+			desc:   "access channel with invalid key",
+			key:    "invalid",
+			chanID: chanID,
+			calls:  3,
+			err:    errors.ErrAuthentication,
+		},
+		{
+			desc:   "access channel after the thing is disconnected",
+			key:    thingKey,
+			chanID: chanID,
+			remove: func() {
+				delete(tc.conns, chanID)
+				cache.Disconnect(chanID, thingID)
+			},
+			calls: 4,
+			err:   errors.ErrAuthorization,
+		},
+	}
+
+	for _, c := range cases {
+		if c.remove != nil {
+			c.remove()
+		}
+		_, err := cache.CanAccessByKey(context.Background(), &mainflux.AccessByKeyReq{Token: c.key, ChanID: c.chanID})
+		assert.True(t, errors.Contains(err, c.err), fmt.Sprintf("%s: expected %s got %s\n", c.desc, c.err, err))
+		assert.Equal(t, c.calls, tc.calls, fmt.Sprintf("%s: expected %d calls got %d\n", c.desc, c.calls, tc.calls))
+	}
+}
+
+func TestCanAccessBatch(t *testing.T) {
+	tc := newThingsClient()
+	tc.conns["other"] = true
+	cache := auth.NewThingsCache(tc, ttl)
+
+	_, err := cache.CanAccessByID(context.Background(), &mainflux.AccessByIDReq{ThingID: thingID, ChanID: chanID})
+	assert.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	cases := []struct {
+		desc    string
+		chanIDs []string
+		res     []string
+		calls   int
+	}{
+		{
+			desc:    "access cached channel",
+			chanIDs: []string{chanID},
+			res:     []string{chanID},
+			calls:   1,
+		},
+		{
+			desc:    "access cached and uncached channels",
+			chanIDs: []string{chanID, "other", "unconnected"},
+			res:     []string{chanID, "other"},
+			calls:   2,
+		},
+		{
+			desc:    "access channels cached by the batch",
+			chanIDs: []string{chanID, "other"},
+			res:     []string{chanID, "other"},
+			calls:   2,
+		},
+	}
+
+	for _, c := range cases {
+		res, err := cache.CanAccessBatch(context.Background(), &mainflux.AccessBatchReq{ThingID: thingID, ChanIDs: c.chanIDs})
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s\n", c.desc, err))
+		assert.ElementsMatch(t, c.res, res.GetChanIDs(), fmt.Sprintf("%s: expected %v got %v\n", c.desc, c.res, res.GetChanIDs()))
+		assert.Equal(t, c.calls, tc.calls, fmt.Sprintf("%s: expected %d calls got %d\n", c.desc, c.calls, tc.calls))
+	}
+}
+
+func TestRemoveThing(t *testing.T) {
+	tc := newThingsClient()
+	cache := auth.NewThingsCache(tc, ttl)
+
+	_, err := cache.Identify(context.Background(), &mainflux.Token{Value: thingKey})
+	assert.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	_, err = cache.CanAccessByID(context.Background(), &mainflux.AccessByIDReq{ThingID: thingID, ChanID: chanID})
+	assert.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	cache.RemoveThing(thingID)
+
+	_, err = cache.Identify(context.Background(), &mainflux.Token{Value: thingKey})
+	assert.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	_, err = cache.CanAccessByID(context.Background(), &mainflux.AccessByIDReq{ThingID: thingID, ChanID: chanID})
+	assert.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	assert.Equal(t, 4, tc.calls, fmt.Sprintf("expected removed thing to be retrieved from things service, got %d calls\n", tc.calls))
+}
+
+func TestExpiration(t *testing.T) {
+	tc := newThingsClient()
+	cache := auth.NewThingsCache(tc, time.Millisecond)
+
+	_, err := cache.Identify(context.Background(), &mainflux.Token{Value: thingKey})
+	assert.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	time.Sleep(2 * time.Millisecond)
+
+	_, err = cache.Identify(context.Background(), &mainflux.Token{Value: thingKey})
+	assert.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	assert.Equal(t, 2, tc.calls, fmt.Sprintf("expected expired key to be retrieved from things service, got %d calls\n", tc.calls))
+}
+
+func TestUnavailableFallback(t *testing.T) {
+	tc := newThingsClient()
+	cache := auth.NewThingsCache(tc, time.Millisecond)
+
+	_, err := cache.Identify(context.Background(), &mainflux.Token{Value: thingKey})
+	assert.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	_, err = cache.CanAccessByID(context.Background(), &mainflux.AccessByIDReq{ThingID: thingID, ChanID: chanID})
+	assert.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	time.Sleep(2 * time.Millisecond)
+	tc.down = true
+
+	cases := []struct {
+		desc string
+		key  string
+		err  error
+	}{
+		{
+			desc: "access channel with expired key while things service is unavailable",
+			key:  thingKey,
+			err:  nil,
+		},
+		{
+			desc: "access channel with uncached key while things service is unavailable",
+			key:  "uncached",
+			err:  errUnavailable,
+		},
+	}
+
+	for _, c := range cases {
+		_, err := cache.CanAccessByKey(context.Background(), &mainflux.AccessByKeyReq{Token: c.key, ChanID: chanID})
+		assert.Equal(t, status.Code(c.err), status.Code(err), fmt.Sprintf("%s: expected %s got %s\n", c.desc, c.err, err))
+	}
+}
+
+// externalKey issues the JWT of the thing which expires at the given time.
+func externalKey(t *testing.T, exp time.Time) string {
+	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.HS256, Key: []byte("secret")}, nil)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	claims := jwt.Claims{Subject: thingID}
+	if !exp.IsZero() {
+		claims.Expiry = jwt.NewNumericDate(exp)
+	}
+
+	key, err := jwt.Signed(signer).Claims(claims).CompactSerialize()
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	return key
+}
+
+func TestExternalKeyExpiration(t *testing.T) {
+	valid := externalKey(t, time.Now().Add(time.Hour))
+	expired := externalKey(t, time.Now().Add(-time.Second))
+	unlimited := externalKey(t, time.Time{})
+
+	cases := []struct {
+		desc  string
+		key   string
+		calls int
+		err   error
+	}{
+		{
+			desc:  "identify with cached external key while things service is unavailable",
+			key:   valid,
+			calls: 1,
+			err:   nil,
+		},
+		{
+			desc:  "identify with expired external key while things service is unavailable",
+			key:   expired,
+			calls: 2,
+			err:   errUnavailable,
+		},
+		{
+			desc:  "identify with external key without expiration while things service is unavailable",
+			key:   unlimited,
+			calls: 2,
+			err:   errUnavailable,
+		},
+	}
+
+	for _, c := range cases {
+		tc := newThingsClient()
+		tc.keys[c.key] = true
+		cache := auth.NewThingsCache(tc, ttl)
+
+		_, err := cache.Identify(context.Background(), &mainflux.Token{Value: c.key})
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s\n", c.desc, err))
+
+		tc.down = true
+		_, err = cache.Identify(context.Background(), &mainflux.Token{Value: c.key})
+		assert.Equal(t, status.Code(c.err), status.Code(err), fmt.Sprintf("%s: expected %s got %s\n", c.desc, c.err, err))
+		assert.Equal(t, c.calls, tc.calls, fmt.Sprintf("%s: expected %d calls got %d\n", c.desc, c.calls, tc.calls))
+	}
+}
+
+func TestGetSigningKey(t *testing.T) {
+	tc := newThingsClient()
+	cache := auth.NewThingsCache(tc, ttl)
+
+	cases := []struct {
+		desc   string
+		remove func()
+		calls  int
+	}{
+		{
+			desc:  "get signing key",
+			calls: 1,
+		},
+		{
+			desc:  "get cached signing key",
+			calls: 1,
+		},
+		{
+			desc:   "get signing key after the thing is removed",
+			remove: func() { cache.RemoveThing(thingID) },
+			calls:  2,
+		},
+	}
+
+	for _, c := range cases {
+		if c.remove != nil {
+			c.remove()
+		}
+		_, err := cache.GetSigningKey(context.Background(), &mainflux.ThingID{Value: thingID})
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s\n", c.desc, err))
+		assert.Equal(t, c.calls, tc.calls, fmt.Sprintf("%s: expected %d calls got %d\n", c.desc, c.calls, tc.calls))
+	}
+}
+
+func TestGetTopicACL(t *testing.T) {
+	tc := newThingsClient()
+	cache := auth.NewThingsCache(tc, ttl)
+
+	cases := []struct {
+		desc   string
+		remove func()
+		down   bool
+		calls  int
+		err    error
+	}{
+		{
+			desc:  "get topic ACL",
+			calls: 1,
+		},
+		{
+			desc:  "get cached topic ACL",
+			calls: 1,
+		},
+		{
+			desc:   "get topic ACL after the channel is updated",
+			remove: func() { cache.RemoveTopicACL(chanID) },
+			calls:  2,
+		},
+		{
+			desc:   "get topic ACL after the channel is removed",
+			remove: func() { cache.RemoveChannel(chanID) },
+			calls:  3,
+		},
+		{
+			desc:   "get topic ACL of the removed channel while things service is unavailable",
+			remove: func() { cache.RemoveChannel(chanID) },
+			down:   true,
+			calls:  4,
+			err:    errUnavailable,
+		},
+	}
+
+	for _, c := range cases {
+		if c.remove != nil {
+			c.remove()
+		}
+		tc.down = c.down
+		_, err := cache.GetTopicACL(context.Background(), &mainflux.ChannelID{Value: chanID})
+		assert.Equal(t, status.Code(c.err), status.Code(err), fmt.Sprintf("%s: expected %s got %s\n", c.desc, c.err, err))
+		assert.Equal(t, c.calls, tc.calls, fmt.Sprintf("%s: expected %d calls got %d\n", c.desc, c.calls, tc.calls))
+	}
+}
+
+func TestGetChannelProfile(t *testing.T) {
+	tc := newThingsClient()
+	cache := auth.NewThingsCache(tc, ttl)
+
+	cases := []struct {
+		desc   string
+		remove func()
+		down   bool
+		calls  int
+		err    error
+	}{
+		{
+			desc:  "get channel profile",
+			calls: 1,
+		},
+		{
+			desc:  "get cached channel profile",
+			calls: 1,
+		},
+		{
+			desc:   "get channel profile after the channel is updated",
+			remove: func() { cache.RemoveChannelProfile(chanID) },
+			calls:  2,
+		},
+		{
+			desc:   "get channel profile after the channel is removed",
+			remove: func() { cache.RemoveChannel(chanID) },
+			calls:  3,
+		},
+		{
+			desc:  "get expired channel profile while things service is unavailable",
+			down:  true,
+			calls: 3,
+		},
+	}
+
+	for _, c := range cases {
+		if c.remove != nil {
+			c.remove()
+		}
+		tc.down = c.down
+		_, err := cache.GetChannelProfile(context.Background(), &mainflux.ChannelID{Value: chanID})
+		assert.Equal(t, status.Code(c.err), status.Code(err), fmt.Sprintf("%s: expected %s got %s\n", c.desc, c.err, err))
+		assert.Equal(t, c.calls, tc.calls, fmt.Sprintf("%s: expected %d calls got %d\n", c.desc, c.calls, tc.calls))
+	}
+}
+
+// countingHook counts the commands processed by the Redis client.
+type countingHook struct {
+	calls int32
+}
+
+func (h *countingHook) BeforeProcess(ctx context.Context, _ redis.Cmder) (context.Context, error) {
+	atomic.AddInt32(&h.calls, 1)
+	return ctx, nil
+}
+
+func (h *countingHook) AfterProcess(context.Context, redis.Cmder) error {
+	return nil
+}
+
+func (h *countingHook) BeforeProcessPipeline(ctx context.Context, _ []redis.Cmder) (context.Context, error) {
+	return ctx, nil
+}
+
+func (h *countingHook) AfterProcessPipeline(context.Context, []redis.Cmder) error {
+	return nil
+}
+
+func TestInvalidateBackoff(t *testing.T) {
+	es := redis.NewClient(&redis.Options{
+		Addr:       "localhost:0",
+		MaxRetries: -1,
+		Dialer: func(context.Context, string, string) (net.Conn, error) {
+			return nil, errors.New("unreachable")
+		},
+	})
+	defer es.Close()
+	hook := &countingHook{}
+	es.AddHook(hook)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+	err := auth.Invalidate(ctx, es, auth.NewThingsCache(newThingsClient(), ttl))
+	assert.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	calls := atomic.LoadInt32(&hook.calls)
+	assert.LessOrEqual(t, calls, int32(5), fmt.Sprintf("expected failed reads to be retried with backoff, got %d reads\n", calls))
+}
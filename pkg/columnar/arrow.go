@@ -0,0 +1,212 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package columnar
+
+import (
+	"io"
+	"math"
+)
+
+// Arrow IPC format constants, as defined by the Arrow Message.fbs and
+// Schema.fbs FlatBuffers schemas.
+const (
+	arrowContinuation = 0xFFFFFFFF
+	arrowVersionV5    = 4
+
+	arrowHeaderSchema      = 1
+	arrowHeaderRecordBatch = 3
+
+	arrowTypeInt           = 2
+	arrowTypeFloatingPoint = 3
+	arrowTypeUtf8          = 5
+	arrowTypeBool          = 6
+
+	arrowPrecisionDouble = 2
+)
+
+var _ Writer = (*arrowWriter)(nil)
+
+type arrowWriter struct {
+	w       io.Writer
+	columns []Column
+	closed  bool
+}
+
+// NewArrowWriter writes the schema of the columns to the writer and returns
+// the writer of the rows in the Arrow IPC stream format.
+func NewArrowWriter(w io.Writer, columns []Column) (Writer, error) {
+	aw := &arrowWriter{
+		w:       w,
+		columns: columns,
+	}
+
+	fields := fbTables{}
+	for _, c := range columns {
+		fields = append(fields, arrowField(c))
+	}
+	schema := fbTable{
+		fbScalar(0, 2, 0),
+		fbRef(1, fields),
+	}
+
+	if err := aw.writeMessage(arrowHeaderSchema, schema, nil); err != nil {
+		return nil, err
+	}
+
+	return aw, nil
+}
+
+func (aw *arrowWriter) Write(rows [][]interface{}) error {
+	if aw.closed {
+		return ErrClosed
+	}
+	if len(rows) == 0 {
+		return nil
+	}
+
+	cols, err := split(aw.columns, rows)
+	if err != nil {
+		return err
+	}
+
+	var body, nodes, buffers []byte
+	addBuffer := func(data []byte) {
+		buffers = appendUint64(buffers, uint64(len(body)))
+		buffers = appendUint64(buffers, uint64(len(data)))
+		body = append(body, data...)
+		for len(body)%8 != 0 {
+			body = append(body, 0)
+		}
+	}
+
+	numBuffers := 0
+	for i, c := range cols {
+		nodes = appendUint64(nodes, uint64(len(rows)))
+		nodes = appendUint64(nodes, uint64(c.nulls))
+
+		addBuffer(bitmap(c.valid))
+		numBuffers++
+
+		values := c.values
+		switch aw.columns[i].Type {
+		case String:
+			var offsets, data []byte
+			offsets = appendUint32(offsets, 0)
+			for r := range rows {
+				if c.valid[r] {
+					data = append(data, values[0].(string)...)
+					values = values[1:]
+				}
+				offsets = appendUint32(offsets, uint32(len(data)))
+			}
+			addBuffer(offsets)
+			addBuffer(data)
+			numBuffers += 2
+		case Float64, Int64:
+			var data []byte
+			for r := range rows {
+				var v uint64
+				if c.valid[r] {
+					v = fixedBits(values[0])
+					values = values[1:]
+				}
+				data = appendUint64(data, v)
+			}
+			addBuffer(data)
+			numBuffers++
+		case Bool:
+			flags := make([]bool, len(rows))
+			for r := range rows {
+				if c.valid[r] {
+					flags[r] = values[0].(bool)
+					values = values[1:]
+				}
+			}
+			addBuffer(bitmap(flags))
+			numBuffers++
+		}
+	}
+
+	batch := fbTable{
+		fbScalar(0, 8, uint64(len(rows))),
+		fbRef(1, fbStructs{len: len(cols), data: nodes}),
+		fbRef(2, fbStructs{len: numBuffers, data: buffers}),
+	}
+
+	return aw.writeMessage(arrowHeaderRecordBatch, batch, body)
+}
+
+func (aw *arrowWriter) Close() error {
+	if aw.closed {
+		return ErrClosed
+	}
+	aw.closed = true
+
+	// The end of stream marker is the continuation followed by zero length.
+	var eos []byte
+	eos = appendUint32(eos, arrowContinuation)
+	eos = appendUint32(eos, 0)
+	_, err := aw.w.Write(eos)
+
+	return err
+}
+
+// writeMessage writes the encapsulated message consisting of the metadata
+// length prefix, the metadata padded to 8 bytes, and the message body.
+func (aw *arrowWriter) writeMessage(headerType byte, header fbTable, body []byte) error {
+	meta := fbFinish(fbTable{
+		fbScalar(0, 2, arrowVersionV5),
+		fbScalar(1, 1, uint64(headerType)),
+		fbRef(2, header),
+		fbScalar(3, 8, uint64(len(body))),
+	})
+	for len(meta)%8 != 0 {
+		meta = append(meta, 0)
+	}
+
+	msg := make([]byte, 0, 8+len(meta)+len(body))
+	msg = appendUint32(msg, arrowContinuation)
+	msg = appendUint32(msg, uint32(len(meta)))
+	msg = append(msg, meta...)
+	msg = append(msg, body...)
+
+	_, err := aw.w.Write(msg)
+	return err
+}
+
+func arrowField(c Column) fbTable {
+	var typeType byte
+	typ := fbTable{}
+	switch c.Type {
+	case String:
+		typeType = arrowTypeUtf8
+	case Float64:
+		typeType = arrowTypeFloatingPoint
+		typ = fbTable{fbScalar(0, 2, arrowPrecisionDouble)}
+	case Int64:
+		typeType = arrowTypeInt
+		typ = fbTable{fbScalar(0, 4, 64), fbScalar(1, 1, 1)}
+	case Bool:
+		typeType = arrowTypeBool
+	}
+
+	return fbTable{
+		fbRef(0, fbString(c.Name)),
+		fbScalar(1, 1, 1),
+		fbScalar(2, 1, uint64(typeType)),
+		fbRef(3, typ),
+		fbRef(5, fbTables{}),
+	}
+}
+
+func fixedBits(v interface{}) uint64 {
+	switch v := v.(type) {
+	case float64:
+		return math.Float64bits(v)
+	case int64:
+		return uint64(v)
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,151 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package columnar
+
+import (
+	"encoding/binary"
+	"io"
+
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+)
+
+const (
+	// ArrowContentType is the media type of the Arrow IPC stream format.
+	ArrowContentType = "application/vnd.apache.arrow.stream"
+	// ParquetContentType is the media type of the Parquet format.
+	ParquetContentType = "application/vnd.apache.parquet"
+)
+
+// Type represents the type of the column values.
+type Type int
+
+const (
+	// String is the type of UTF-8 string values.
+	String Type = iota
+	// Float64 is the type of double precision floating point values.
+	Float64
+	// Int64 is the type of signed 64-bit integer values.
+	Int64
+	// Bool is the type of boolean values.
+	Bool
+)
+
+var (
+	// ErrInvalidValue indicates a value which doesn't match the column type.
+	ErrInvalidValue = errors.New("invalid column value")
+
+	// ErrInvalidRow indicates a row whose length differs from the number
+	// of columns.
+	ErrInvalidRow = errors.New("invalid row length")
+
+	// ErrClosed indicates writing to a closed writer.
+	ErrClosed = errors.New("writer is closed")
+)
+
+// Column describes a column of the written data. All the columns are
+// nullable.
+type Column struct {
+	Name string
+	Type Type
+}
+
+// Writer specifies the streaming encoder of rows to the columnar format.
+// Values of the rows are string, float64, int64 or bool, depending on the
+// column type, or nil for the null values.
+type Writer interface {
+	// Write encodes the rows as a single record batch or row group.
+	Write(rows [][]interface{}) error
+
+	// Close completes the encoded stream. It doesn't close the underlying
+	// writer.
+	Close() error
+}
+
+// column contains the values of a single column of the written rows.
+type column struct {
+	valid  []bool
+	nulls  int
+	values []interface{}
+}
+
+// split splits the rows to the columns, checking the value types.
+func split(columns []Column, rows [][]interface{}) ([]column, error) {
+	cols := make([]column, len(columns))
+	for i := range cols {
+		cols[i].valid = make([]bool, len(rows))
+	}
+
+	for r, row := range rows {
+		if len(row) != len(columns) {
+			return nil, ErrInvalidRow
+		}
+
+		for i, v := range row {
+			if v == nil {
+				cols[i].nulls++
+				continue
+			}
+
+			if !validValue(columns[i].Type, v) {
+				return nil, errors.Wrap(ErrInvalidValue, errors.New(columns[i].Name))
+			}
+
+			cols[i].valid[r] = true
+			cols[i].values = append(cols[i].values, v)
+		}
+	}
+
+	return cols, nil
+}
+
+func validValue(t Type, v interface{}) bool {
+	switch v.(type) {
+	case string:
+		return t == String
+	case float64:
+		return t == Float64
+	case int64:
+		return t == Int64
+	case bool:
+		return t == Bool
+	default:
+		return false
+	}
+}
+
+// bitmap returns the LSB numbered bitmap of the flags.
+func bitmap(flags []bool) []byte {
+	bits := make([]byte, (len(flags)+7)/8)
+	for i, f := range flags {
+		if f {
+			bits[i/8] |= 1 << (uint(i) % 8)
+		}
+	}
+
+	return bits
+}
+
+// countingWriter counts the bytes written to the underlying writer.
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (cw *countingWriter) Write(p []byte) (int, error) {
+	n, err := cw.w.Write(p)
+	cw.n += int64(n)
+	return n, err
+}
+
+func appendUint32(b []byte, v uint32) []byte {
+	var buf [4]byte
+	binary.LittleEndian.PutUint32(buf[:], v)
+	return append(b, buf[:]...)
+}
+
+func appendUint64(b []byte, v uint64) []byte {
+	var buf [8]byte
+	binary.LittleEndian.PutUint64(buf[:], v)
+	return append(b, buf[:]...)
+}
@@ -0,0 +1,357 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package columnar_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/MainfluxLabs/mainflux/pkg/columnar"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	columns = []columnar.Column{
+		{Name: "publisher", Type: columnar.String},
+		{Name: "value", Type: columnar.Float64},
+		{Name: "seq", Type: columnar.Int64},
+		{Name: "bool_value", Type: columnar.Bool},
+	}
+	rows = [][]interface{}{
+		{"thing-1", 20.5, int64(1), true},
+		{nil, nil, nil, nil},
+		{"thing-2", -1.25, int64(3), false},
+	}
+)
+
+func TestWriters(t *testing.T) {
+	writers := map[string]func(*bytes.Buffer) (columnar.Writer, error){
+		"arrow": func(b *bytes.Buffer) (columnar.Writer, error) {
+			return columnar.NewArrowWriter(b, columns)
+		},
+		"parquet": func(b *bytes.Buffer) (columnar.Writer, error) {
+			return columnar.NewParquetWriter(b, columns)
+		},
+	}
+
+	cases := []struct {
+		desc string
+		rows [][]interface{}
+		err  error
+	}{
+		{
+			desc: "write rows",
+			rows: rows,
+			err:  nil,
+		},
+		{
+			desc: "write no rows",
+			rows: [][]interface{}{},
+			err:  nil,
+		},
+		{
+			desc: "write row with invalid value type",
+			rows: [][]interface{}{{"thing-1", "20.5", int64(1), true}},
+			err:  columnar.ErrInvalidValue,
+		},
+		{
+			desc: "write row with invalid length",
+			rows: [][]interface{}{{"thing-1"}},
+			err:  columnar.ErrInvalidRow,
+		},
+	}
+
+	for name, newWriter := range writers {
+		for _, tc := range cases {
+			w, err := newWriter(&bytes.Buffer{})
+			require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", name, err))
+
+			err = w.Write(tc.rows)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: %s: expected %s got %s", name, tc.desc, tc.err, err))
+		}
+
+		w, err := newWriter(&bytes.Buffer{})
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", name, err))
+		require.Nil(t, w.Close(), fmt.Sprintf("%s: unexpected close error", name))
+		err = w.Write(rows)
+		assert.True(t, errors.Contains(err, columnar.ErrClosed), fmt.Sprintf("%s: write to closed writer: expected %s got %s", name, columnar.ErrClosed, err))
+	}
+}
+
+func TestArrowWriter(t *testing.T) {
+	buf := &bytes.Buffer{}
+	w, err := columnar.NewArrowWriter(buf, columns)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	require.Nil(t, w.Write(rows), "unexpected write error")
+	require.Nil(t, w.Write(rows[:1]), "unexpected write error")
+	require.Nil(t, w.Close(), "unexpected close error")
+
+	data := buf.Bytes()
+
+	// Schema message.
+	msg, body, data := readArrowMessage(t, data)
+	assert.Equal(t, uint64(1), msg.uint(1, 1), "expected schema message")
+	assert.Empty(t, body, "expected schema message without body")
+	fields := msg.table(2).vector(1)
+	require.Equal(t, len(columns), len(fields), "unexpected number of schema fields")
+	types := []uint64{5, 3, 2, 6}
+	for i, f := range fields {
+		field := msg.at(f)
+		assert.Equal(t, columns[i].Name, field.string(0), "unexpected field name")
+		assert.Equal(t, types[i], field.uint(2, 1), fmt.Sprintf("unexpected %s field type", columns[i].Name))
+		assert.Equal(t, uint64(1), field.uint(1, 1), "expected nullable field")
+	}
+
+	// Record batch messages.
+	for _, expected := range [][][]interface{}{rows, rows[:1]} {
+		msg, body, data = readArrowMessage(t, data)
+		assert.Equal(t, uint64(3), msg.uint(1, 1), "expected record batch message")
+		assert.Equal(t, uint64(len(body)), msg.uint(3, 8), "unexpected body length")
+
+		batch := msg.table(2)
+		assert.Equal(t, uint64(len(expected)), batch.uint(0, 8), "unexpected record batch length")
+		nodes := batch.structs(1, 16)
+		buffers := batch.structs(2, 16)
+		require.Equal(t, len(columns), len(nodes), "unexpected number of field nodes")
+		require.Equal(t, 9, len(buffers), "unexpected number of buffers")
+
+		buffer := func(i int) []byte {
+			off := binary.LittleEndian.Uint64(buffers[i])
+			return body[off : off+binary.LittleEndian.Uint64(buffers[i][8:])]
+		}
+		validity := buffer(0)
+		offsets, strs := buffer(1), buffer(2)
+		values, seqs, bools := buffer(4), buffer(6), buffer(8)
+
+		for r, row := range expected {
+			valid := validity[r/8]&(1<<(uint(r)%8)) != 0
+			assert.Equal(t, row[0] != nil, valid, "unexpected validity")
+			if row[0] != nil {
+				start, end := binary.LittleEndian.Uint32(offsets[4*r:]), binary.LittleEndian.Uint32(offsets[4*r+4:])
+				assert.Equal(t, row[0], string(strs[start:end]), "unexpected string value")
+				assert.Equal(t, row[1], math.Float64frombits(binary.LittleEndian.Uint64(values[8*r:])), "unexpected float value")
+				assert.Equal(t, row[2], int64(binary.LittleEndian.Uint64(seqs[8*r:])), "unexpected integer value")
+				assert.Equal(t, row[3], bools[r/8]&(1<<(uint(r)%8)) != 0, "unexpected bool value")
+			}
+		}
+	}
+
+	assert.Equal(t, []byte{0xFF, 0xFF, 0xFF, 0xFF, 0, 0, 0, 0}, data, "expected end of stream marker")
+}
+
+func TestParquetWriter(t *testing.T) {
+	buf := &bytes.Buffer{}
+	w, err := columnar.NewParquetWriter(buf, columns)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	require.Nil(t, w.Write(rows), "unexpected write error")
+	require.Nil(t, w.Write(rows[:1]), "unexpected write error")
+	require.Nil(t, w.Close(), "unexpected close error")
+
+	data := buf.Bytes()
+	require.Equal(t, "PAR1", string(data[:4]), "expected leading magic number")
+	require.Equal(t, "PAR1", string(data[len(data)-4:]), "expected trailing magic number")
+	size := int(binary.LittleEndian.Uint32(data[len(data)-8:]))
+	meta := readThriftStruct(t, bytes.NewReader(data[len(data)-8-size:len(data)-8]))
+
+	assert.Equal(t, int64(1), meta[1], "unexpected version")
+	assert.Equal(t, int64(len(rows)+1), meta[3], "unexpected number of rows")
+	assert.Equal(t, []byte("mainflux"), meta[6], "unexpected creator")
+
+	schema := meta[2].([]interface{})
+	require.Equal(t, len(columns)+1, len(schema), "unexpected number of schema elements")
+	assert.Equal(t, int64(len(columns)), schema[0].(map[int16]interface{})[5], "unexpected number of root children")
+	for i, c := range columns {
+		assert.Equal(t, []byte(c.Name), schema[i+1].(map[int16]interface{})[4], "unexpected schema element name")
+	}
+
+	rowGroups := meta[4].([]interface{})
+	require.Equal(t, 2, len(rowGroups), "unexpected number of row groups")
+	rg := rowGroups[0].(map[int16]interface{})
+	assert.Equal(t, int64(len(rows)), rg[3], "unexpected row group rows")
+
+	// Decode the data page of the publisher column of the first row group.
+	chunk := rg[1].([]interface{})[0].(map[int16]interface{})
+	offset := chunk[3].(map[int16]interface{})[9].(int64)
+	r := bytes.NewReader(data[offset:])
+	header := readThriftStruct(t, r)
+	assert.Equal(t, int64(len(rows)), header[5].(map[int16]interface{})[1], "unexpected number of page values")
+
+	page := make([]byte, header[2].(int64))
+	_, err = r.Read(page)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	levelsLen := binary.LittleEndian.Uint32(page)
+	levels := page[4 : 4+levelsLen]
+	assert.Equal(t, []byte{3, 5}, levels, "unexpected definition levels")
+
+	values := page[4+levelsLen:]
+	var strs []string
+	for len(values) > 0 {
+		n := binary.LittleEndian.Uint32(values)
+		strs = append(strs, string(values[4:4+n]))
+		values = values[4+n:]
+	}
+	assert.Equal(t, []string{"thing-1", "thing-2"}, strs, "unexpected page values")
+}
+
+// fbTable is the FlatBuffers table of the decoded buffer.
+type fbTable struct {
+	buf []byte
+	pos int
+}
+
+func (t fbTable) field(id int) int {
+	vtable := t.pos - int(int32(binary.LittleEndian.Uint32(t.buf[t.pos:])))
+	if 4+2*id >= int(binary.LittleEndian.Uint16(t.buf[vtable:])) {
+		return 0
+	}
+	off := int(binary.LittleEndian.Uint16(t.buf[vtable+4+2*id:]))
+	if off == 0 {
+		return 0
+	}
+
+	return t.pos + off
+}
+
+func (t fbTable) uint(id, size int) uint64 {
+	at := t.field(id)
+	if at == 0 {
+		return 0
+	}
+	switch size {
+	case 1:
+		return uint64(t.buf[at])
+	case 2:
+		return uint64(binary.LittleEndian.Uint16(t.buf[at:]))
+	case 4:
+		return uint64(binary.LittleEndian.Uint32(t.buf[at:]))
+	default:
+		return binary.LittleEndian.Uint64(t.buf[at:])
+	}
+}
+
+func (t fbTable) ref(id int) int {
+	at := t.field(id)
+	return at + int(binary.LittleEndian.Uint32(t.buf[at:]))
+}
+
+func (t fbTable) at(pos int) fbTable {
+	return fbTable{buf: t.buf, pos: pos}
+}
+
+func (t fbTable) table(id int) fbTable {
+	return t.at(t.ref(id))
+}
+
+func (t fbTable) string(id int) string {
+	pos := t.ref(id)
+	n := int(binary.LittleEndian.Uint32(t.buf[pos:]))
+	return string(t.buf[pos+4 : pos+4+n])
+}
+
+// vector returns the positions of the tables of the vector.
+func (t fbTable) vector(id int) []int {
+	pos := t.ref(id)
+	n := int(binary.LittleEndian.Uint32(t.buf[pos:]))
+	var tables []int
+	for i := 0; i < n; i++ {
+		at := pos + 4 + 4*i
+		tables = append(tables, at+int(binary.LittleEndian.Uint32(t.buf[at:])))
+	}
+
+	return tables
+}
+
+func (t fbTable) structs(id, size int) [][]byte {
+	pos := t.ref(id)
+	n := int(binary.LittleEndian.Uint32(t.buf[pos:]))
+	var structs [][]byte
+	for i := 0; i < n; i++ {
+		structs = append(structs, t.buf[pos+4+size*i:pos+4+size*(i+1)])
+	}
+
+	return structs
+}
+
+func readArrowMessage(t *testing.T, data []byte) (fbTable, []byte, []byte) {
+	require.Equal(t, uint32(0xFFFFFFFF), binary.LittleEndian.Uint32(data), "expected continuation marker")
+	size := int(binary.LittleEndian.Uint32(data[4:]))
+	require.Equal(t, 0, (8+size)%8, "expected 8-byte aligned metadata")
+
+	meta := data[8 : 8+size]
+	msg := fbTable{buf: meta, pos: int(binary.LittleEndian.Uint32(meta))}
+	require.Equal(t, uint64(4), msg.uint(0, 2), "unexpected metadata version")
+	bodyLen := int(msg.uint(3, 8))
+	require.Equal(t, 0, bodyLen%8, "expected 8-byte aligned body")
+
+	return msg, data[8+size : 8+size+bodyLen], data[8+size+bodyLen:]
+}
+
+// readThriftStruct decodes the Thrift compact protocol struct to the map of
+// the field values.
+func readThriftStruct(t *testing.T, r *bytes.Reader) map[int16]interface{} {
+	fields := map[int16]interface{}{}
+	var id int16
+	for {
+		b, err := r.ReadByte()
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+		if b == 0 {
+			return fields
+		}
+
+		typ := b & 0x0F
+		if delta := int16(b >> 4); delta != 0 {
+			id += delta
+		} else {
+			id = int16(readZigzag(t, r))
+		}
+		fields[id] = readThriftValue(t, r, typ)
+	}
+}
+
+func readThriftValue(t *testing.T, r *bytes.Reader, typ byte) interface{} {
+	switch typ {
+	case 1:
+		return true
+	case 2:
+		return false
+	case 5, 6:
+		return readZigzag(t, r)
+	case 8:
+		n, err := binary.ReadUvarint(r)
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+		v := make([]byte, n)
+		_, err = r.Read(v)
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+		return v
+	case 9:
+		b, err := r.ReadByte()
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+		n := uint64(b >> 4)
+		if n == 15 {
+			n, err = binary.ReadUvarint(r)
+			require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+		}
+		var list []interface{}
+		for i := uint64(0); i < n; i++ {
+			list = append(list, readThriftValue(t, r, b&0x0F))
+		}
+		return list
+	case 12:
+		return readThriftStruct(t, r)
+	default:
+		require.Fail(t, fmt.Sprintf("unexpected thrift type %d", typ))
+		return nil
+	}
+}
+
+func readZigzag(t *testing.T, r *bytes.Reader) int64 {
+	v, err := binary.ReadUvarint(r)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	return int64(v>>1) ^ -int64(v&1)
+}
@@ -0,0 +1,7 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package columnar contains the streaming encoders of tabular data to the
+// Apache Arrow IPC stream and Apache Parquet columnar formats, used to export
+// large message extracts to data analysis tools.
+package columnar
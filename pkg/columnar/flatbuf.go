@@ -0,0 +1,168 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package columnar
+
+import (
+	"encoding/binary"
+	"sort"
+)
+
+// The Arrow IPC metadata is encoded using the FlatBuffers format. The
+// builder below writes the buffer front to back: every table is preceded by
+// its vtable and followed by the objects it refers to, so that all the
+// offsets point forward, as the format requires.
+
+// fbObject is the FlatBuffers object which can be referred by an offset.
+type fbObject interface {
+	write(b *fbBuilder) int
+}
+
+// fbField is the field of a table, either a scalar or an offset to the
+// referred object.
+type fbField struct {
+	id    int
+	size  int
+	value uint64
+	ref   fbObject
+}
+
+func fbScalar(id, size int, value uint64) fbField {
+	return fbField{id: id, size: size, value: value}
+}
+
+func fbRef(id int, ref fbObject) fbField {
+	return fbField{id: id, size: 4, ref: ref}
+}
+
+// fbTable is the table consisting of the fields.
+type fbTable []fbField
+
+// fbString is the null terminated string.
+type fbString string
+
+// fbTables is the vector of tables.
+type fbTables []fbTable
+
+// fbStructs is the vector of the structs of 8-byte aligned fields.
+type fbStructs struct {
+	len  int
+	data []byte
+}
+
+type fbBuilder struct {
+	buf []byte
+}
+
+// fbFinish returns the buffer with the root table.
+func fbFinish(root fbTable) []byte {
+	b := &fbBuilder{buf: make([]byte, 4)}
+	pos := root.write(b)
+	binary.LittleEndian.PutUint32(b.buf, uint32(pos))
+
+	return b.buf
+}
+
+func (b *fbBuilder) pad(align int) {
+	for len(b.buf)%align != 0 {
+		b.buf = append(b.buf, 0)
+	}
+}
+
+func (b *fbBuilder) putRef(at, pos int) {
+	binary.LittleEndian.PutUint32(b.buf[at:], uint32(pos-at))
+}
+
+func (t fbTable) write(b *fbBuilder) int {
+	fields := make([]fbField, len(t))
+	copy(fields, t)
+	// Place the largest fields first to avoid the padding.
+	sort.SliceStable(fields, func(i, j int) bool { return fields[i].size > fields[j].size })
+
+	n := 0
+	offsets := make(map[int]int, len(fields))
+	size := 4
+	for _, f := range fields {
+		if f.id >= n {
+			n = f.id + 1
+		}
+		for size%f.size != 0 {
+			size++
+		}
+		offsets[f.id] = size
+		size += f.size
+	}
+
+	b.pad(2)
+	vtable := len(b.buf)
+	b.buf = append(b.buf, 0, 0, 0, 0)
+	binary.LittleEndian.PutUint16(b.buf[vtable:], uint16(4+2*n))
+	binary.LittleEndian.PutUint16(b.buf[vtable+2:], uint16(size))
+	for id := 0; id < n; id++ {
+		var off [2]byte
+		binary.LittleEndian.PutUint16(off[:], uint16(offsets[id]))
+		b.buf = append(b.buf, off[:]...)
+	}
+
+	b.pad(8)
+	pos := len(b.buf)
+	b.buf = append(b.buf, make([]byte, size)...)
+	binary.LittleEndian.PutUint32(b.buf[pos:], uint32(int32(pos-vtable)))
+
+	for _, f := range fields {
+		at := pos + offsets[f.id]
+		switch f.size {
+		case 1:
+			b.buf[at] = byte(f.value)
+		case 2:
+			binary.LittleEndian.PutUint16(b.buf[at:], uint16(f.value))
+		case 4:
+			binary.LittleEndian.PutUint32(b.buf[at:], uint32(f.value))
+		case 8:
+			binary.LittleEndian.PutUint64(b.buf[at:], f.value)
+		}
+	}
+
+	for _, f := range fields {
+		if f.ref != nil {
+			b.putRef(pos+offsets[f.id], f.ref.write(b))
+		}
+	}
+
+	return pos
+}
+
+func (s fbString) write(b *fbBuilder) int {
+	b.pad(4)
+	pos := len(b.buf)
+	b.buf = appendUint32(b.buf, uint32(len(s)))
+	b.buf = append(b.buf, s...)
+	b.buf = append(b.buf, 0)
+
+	return pos
+}
+
+func (v fbTables) write(b *fbBuilder) int {
+	b.pad(4)
+	pos := len(b.buf)
+	b.buf = appendUint32(b.buf, uint32(len(v)))
+	b.buf = append(b.buf, make([]byte, 4*len(v))...)
+	for i, t := range v {
+		at := pos + 4 + 4*i
+		b.putRef(at, t.write(b))
+	}
+
+	return pos
+}
+
+func (v fbStructs) write(b *fbBuilder) int {
+	// The vector length precedes the 8-byte aligned structs.
+	for (len(b.buf)+4)%8 != 0 {
+		b.buf = append(b.buf, 0)
+	}
+	pos := len(b.buf)
+	b.buf = appendUint32(b.buf, uint32(v.len))
+	b.buf = append(b.buf, v.data...)
+
+	return pos
+}
@@ -0,0 +1,230 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package columnar
+
+import (
+	"io"
+	"math"
+)
+
+// Parquet format constants, as defined by the parquet.thrift file metadata
+// definition.
+const (
+	parquetMagic   = "PAR1"
+	parquetVersion = 1
+	parquetCreator = "mainflux"
+
+	parquetBoolean   = 0
+	parquetInt64     = 2
+	parquetDouble    = 5
+	parquetByteArray = 6
+
+	parquetOptional      = 1
+	parquetConvertedUTF8 = 0
+	parquetDataPage      = 0
+	parquetPlain         = 0
+	parquetRLE           = 3
+	parquetUncompressed  = 0
+)
+
+var _ Writer = (*parquetWriter)(nil)
+
+type parquetChunk struct {
+	offset    int64
+	size      int64
+	numValues int64
+}
+
+type parquetRowGroup struct {
+	numRows int64
+	size    int64
+	chunks  []parquetChunk
+}
+
+type parquetWriter struct {
+	w         *countingWriter
+	columns   []Column
+	numRows   int64
+	rowGroups []parquetRowGroup
+	closed    bool
+}
+
+// NewParquetWriter returns the writer of the rows in the Parquet format.
+// Every written batch of the rows is stored as a row group consisting of a
+// single uncompressed data page per column, and the file metadata is
+// written on close.
+func NewParquetWriter(w io.Writer, columns []Column) (Writer, error) {
+	pw := &parquetWriter{
+		w:       &countingWriter{w: w},
+		columns: columns,
+	}
+
+	if _, err := io.WriteString(pw.w, parquetMagic); err != nil {
+		return nil, err
+	}
+
+	return pw, nil
+}
+
+func (pw *parquetWriter) Write(rows [][]interface{}) error {
+	if pw.closed {
+		return ErrClosed
+	}
+	if len(rows) == 0 {
+		return nil
+	}
+
+	cols, err := split(pw.columns, rows)
+	if err != nil {
+		return err
+	}
+
+	rg := parquetRowGroup{numRows: int64(len(rows))}
+	for _, c := range cols {
+		page := parquetPage(c)
+
+		t := newThriftWriter()
+		t.i32(1, parquetDataPage)
+		t.i32(2, int32(len(page)))
+		t.i32(3, int32(len(page)))
+		t.structField(5)
+		t.i32(1, int32(len(rows)))
+		t.i32(2, parquetPlain)
+		t.i32(3, parquetRLE)
+		t.i32(4, parquetRLE)
+		t.end()
+		t.end()
+
+		chunk := parquetChunk{
+			offset:    pw.w.n,
+			size:      int64(len(t.buf) + len(page)),
+			numValues: int64(len(rows)),
+		}
+		if _, err := pw.w.Write(append(t.buf, page...)); err != nil {
+			return err
+		}
+
+		rg.chunks = append(rg.chunks, chunk)
+		rg.size += chunk.size
+	}
+
+	pw.numRows += rg.numRows
+	pw.rowGroups = append(pw.rowGroups, rg)
+
+	return nil
+}
+
+func (pw *parquetWriter) Close() error {
+	if pw.closed {
+		return ErrClosed
+	}
+	pw.closed = true
+
+	t := newThriftWriter()
+	t.i32(1, parquetVersion)
+
+	t.list(2, thriftStruct, len(pw.columns)+1)
+	t.begin()
+	t.binary(4, "schema")
+	t.i32(5, int32(len(pw.columns)))
+	t.end()
+	for _, c := range pw.columns {
+		t.begin()
+		t.i32(1, parquetType(c.Type))
+		t.i32(3, parquetOptional)
+		t.binary(4, c.Name)
+		if c.Type == String {
+			t.i32(6, parquetConvertedUTF8)
+		}
+		t.end()
+	}
+
+	t.i64(3, pw.numRows)
+
+	t.list(4, thriftStruct, len(pw.rowGroups))
+	for _, rg := range pw.rowGroups {
+		t.begin()
+		t.list(1, thriftStruct, len(rg.chunks))
+		for i, chunk := range rg.chunks {
+			t.begin()
+			t.i64(2, chunk.offset)
+			t.structField(3)
+			t.i32(1, parquetType(pw.columns[i].Type))
+			t.listI32(2, parquetPlain, parquetRLE)
+			t.listBinary(3, pw.columns[i].Name)
+			t.i32(4, parquetUncompressed)
+			t.i64(5, chunk.numValues)
+			t.i64(6, chunk.size)
+			t.i64(7, chunk.size)
+			t.i64(9, chunk.offset)
+			t.end()
+			t.end()
+		}
+		t.i64(2, rg.size)
+		t.i64(3, rg.numRows)
+		t.end()
+	}
+
+	t.binary(6, parquetCreator)
+	t.end()
+
+	meta := appendUint32(t.buf, uint32(len(t.buf)))
+	meta = append(meta, parquetMagic...)
+	_, err := pw.w.Write(meta)
+
+	return err
+}
+
+// parquetPage returns the data page of the column values, consisting of the
+// definition levels, which mark the non-null values, followed by the
+// plain encoded non-null values.
+func parquetPage(c column) []byte {
+	// The definition levels are encoded as a single bit-packed run of the
+	// RLE/bit-packing hybrid encoding, with the bit width of 1.
+	levels := varint(nil, uint64((len(c.valid)+7)/8)<<1|1)
+	levels = append(levels, bitmap(c.valid)...)
+
+	page := appendUint32(nil, uint32(len(levels)))
+	page = append(page, levels...)
+
+	var bools []bool
+	for _, v := range c.values {
+		switch v := v.(type) {
+		case string:
+			page = appendUint32(page, uint32(len(v)))
+			page = append(page, v...)
+		case float64:
+			page = appendUint64(page, math.Float64bits(v))
+		case int64:
+			page = appendUint64(page, uint64(v))
+		case bool:
+			bools = append(bools, v)
+		}
+	}
+	page = append(page, bitmap(bools)...)
+
+	return page
+}
+
+func parquetType(t Type) int32 {
+	switch t {
+	case Float64:
+		return parquetDouble
+	case Int64:
+		return parquetInt64
+	case Bool:
+		return parquetBoolean
+	default:
+		return parquetByteArray
+	}
+}
+
+func varint(b []byte, v uint64) []byte {
+	for v >= 0x80 {
+		b = append(b, byte(v)|0x80)
+		v >>= 7
+	}
+
+	return append(b, byte(v))
+}
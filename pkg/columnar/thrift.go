@@ -0,0 +1,111 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package columnar
+
+// The Parquet metadata is encoded using the Thrift compact protocol.
+const (
+	thriftI32    = 5
+	thriftI64    = 6
+	thriftBinary = 8
+	thriftList   = 9
+	thriftStruct = 12
+)
+
+// thriftWriter encodes the structs using the Thrift compact protocol. The
+// fields are written in the order of their IDs.
+type thriftWriter struct {
+	buf []byte
+	// ids is the stack of the last written field IDs of the nested structs.
+	ids []int16
+}
+
+func newThriftWriter() *thriftWriter {
+	return &thriftWriter{ids: []int16{0}}
+}
+
+func (t *thriftWriter) field(id int16, typ byte) {
+	last := &t.ids[len(t.ids)-1]
+	if delta := id - *last; delta > 0 && delta <= 15 {
+		t.buf = append(t.buf, byte(delta)<<4|typ)
+	} else {
+		t.buf = append(t.buf, typ)
+		t.varint(zigzag(int64(id)))
+	}
+	*last = id
+}
+
+func (t *thriftWriter) varint(v uint64) {
+	t.buf = varint(t.buf, v)
+}
+
+func zigzag(v int64) uint64 {
+	return uint64((v << 1) ^ (v >> 63))
+}
+
+func (t *thriftWriter) i32(id int16, v int32) {
+	t.field(id, thriftI32)
+	t.varint(zigzag(int64(v)))
+}
+
+func (t *thriftWriter) i64(id int16, v int64) {
+	t.field(id, thriftI64)
+	t.varint(zigzag(v))
+}
+
+func (t *thriftWriter) binary(id int16, v string) {
+	t.field(id, thriftBinary)
+	t.str(v)
+}
+
+func (t *thriftWriter) str(v string) {
+	t.varint(uint64(len(v)))
+	t.buf = append(t.buf, v...)
+}
+
+// list writes the header of the list field of n elements of the type.
+func (t *thriftWriter) list(id int16, elemType byte, n int) {
+	t.field(id, thriftList)
+	if n < 15 {
+		t.buf = append(t.buf, byte(n)<<4|elemType)
+		return
+	}
+	t.buf = append(t.buf, 0xF0|elemType)
+	t.varint(uint64(n))
+}
+
+// listI32 writes the list field of the i32 elements.
+func (t *thriftWriter) listI32(id int16, vs ...int32) {
+	t.list(id, thriftI32, len(vs))
+	for _, v := range vs {
+		t.varint(zigzag(int64(v)))
+	}
+}
+
+// listBinary writes the list field of the binary elements.
+func (t *thriftWriter) listBinary(id int16, vs ...string) {
+	t.list(id, thriftBinary, len(vs))
+	for _, v := range vs {
+		t.str(v)
+	}
+}
+
+// structField begins the struct field, which is completed by end.
+func (t *thriftWriter) structField(id int16) {
+	t.field(id, thriftStruct)
+	t.begin()
+}
+
+// begin begins the struct, such as the list element, which is completed
+// by end.
+func (t *thriftWriter) begin() {
+	t.ids = append(t.ids, 0)
+}
+
+// end writes the stop field of the struct.
+func (t *thriftWriter) end() {
+	t.buf = append(t.buf, 0)
+	if len(t.ids) > 1 {
+		t.ids = t.ids[:len(t.ids)-1]
+	}
+}
@@ -0,0 +1,93 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package dedup
+
+import (
+	"crypto/sha256"
+	"sync"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+)
+
+// Filter detects the messages already seen within the time window.
+type Filter interface {
+	// Duplicate reports whether the same message of the same publisher
+	// has been seen within the window, and records the message otherwise.
+	Duplicate(msg messaging.Message) bool
+}
+
+var _ Filter = (*filter)(nil)
+
+type filter struct {
+	mu     sync.Mutex
+	window time.Duration
+	seen   map[[sha256.Size]byte]time.Time
+	swept  time.Time
+}
+
+// NewFilter returns the filter of the messages seen within the window.
+func NewFilter(window time.Duration) Filter {
+	return &filter{
+		window: window,
+		seen:   make(map[[sha256.Size]byte]time.Time),
+		swept:  time.Now(),
+	}
+}
+
+func (f *filter) Duplicate(msg messaging.Message) bool {
+	k := key(msg)
+	now := time.Now()
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.sweep(now)
+
+	if seen, ok := f.seen[k]; ok && now.Sub(seen) < f.window {
+		return true
+	}
+	f.seen[k] = now
+
+	return false
+}
+
+// sweep removes the expired messages at most once per window, so the
+// memory is bounded by the number of messages received within two windows.
+func (f *filter) sweep(now time.Time) {
+	if now.Sub(f.swept) < f.window {
+		return
+	}
+
+	for k, seen := range f.seen {
+		if now.Sub(seen) >= f.window {
+			delete(f.seen, k)
+		}
+	}
+	f.swept = now
+}
+
+// key identifies the message by its publisher, destination and the message
+// ID assigned by the client, or the payload if the client didn't assign it.
+// Creation time is omitted since it's assigned on each resend.
+func key(msg messaging.Message) [sha256.Size]byte {
+	h := sha256.New()
+	for _, s := range []string{msg.Publisher, msg.Channel, msg.Subtopic} {
+		h.Write([]byte(s))
+		h.Write([]byte{0})
+	}
+
+	if msg.ClientId != "" {
+		h.Write([]byte{1})
+		h.Write([]byte(msg.ClientId))
+	} else {
+		h.Write([]byte{0})
+		h.Write(msg.Payload)
+	}
+
+	var k [sha256.Size]byte
+	copy(k[:], h.Sum(nil))
+
+	return k
+}
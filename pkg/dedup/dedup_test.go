@@ -0,0 +1,150 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package dedup_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/pkg/dedup"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+	"github.com/go-kit/kit/metrics"
+	"github.com/stretchr/testify/assert"
+)
+
+const window = 100 * time.Millisecond
+
+var msg = messaging.Message{
+	Channel:   "1",
+	Subtopic:  "temperature",
+	Publisher: "2",
+	Protocol:  "http",
+	Payload:   []byte(`[{"n":"temp","v":23.5}]`),
+	Created:   1,
+}
+
+func TestDuplicate(t *testing.T) {
+	f := dedup.NewFilter(window)
+
+	resent := msg
+	resent.Created = 2
+
+	otherPublisher := msg
+	otherPublisher.Publisher = "3"
+
+	otherSubtopic := msg
+	otherSubtopic.Subtopic = "humidity"
+
+	otherPayload := msg
+	otherPayload.Payload = []byte(`[{"n":"temp","v":24}]`)
+
+	withClientID := msg
+	withClientID.ClientId = "1"
+
+	resentWithClientID := otherPayload
+	resentWithClientID.ClientId = "1"
+
+	otherClientID := msg
+	otherClientID.ClientId = "2"
+
+	cases := []struct {
+		desc  string
+		msg   messaging.Message
+		delay time.Duration
+		dup   bool
+	}{
+		{
+			desc: "first message",
+			msg:  msg,
+			dup:  false,
+		},
+		{
+			desc: "resent message within the window",
+			msg:  resent,
+			dup:  true,
+		},
+		{
+			desc: "same message of other publisher",
+			msg:  otherPublisher,
+			dup:  false,
+		},
+		{
+			desc: "same payload on other subtopic",
+			msg:  otherSubtopic,
+			dup:  false,
+		},
+		{
+			desc: "other payload",
+			msg:  otherPayload,
+			dup:  false,
+		},
+		{
+			desc: "message with client ID",
+			msg:  withClientID,
+			dup:  false,
+		},
+		{
+			desc: "resent message with client ID and other payload",
+			msg:  resentWithClientID,
+			dup:  true,
+		},
+		{
+			desc: "same payload with other client ID",
+			msg:  otherClientID,
+			dup:  false,
+		},
+		{
+			desc:  "resent message after the window",
+			msg:   resent,
+			delay: window,
+			dup:   false,
+		},
+	}
+
+	for _, tc := range cases {
+		time.Sleep(tc.delay)
+		dup := f.Duplicate(tc.msg)
+		assert.Equal(t, tc.dup, dup, fmt.Sprintf("%s: expected %t got %t\n", tc.desc, tc.dup, dup))
+	}
+}
+
+type publisher struct {
+	published []messaging.Message
+}
+
+func (p *publisher) Publish(topic string, msg messaging.Message) error {
+	p.published = append(p.published, msg)
+	return nil
+}
+
+func (p *publisher) Close() error {
+	return nil
+}
+
+type counter struct {
+	count float64
+}
+
+func (c *counter) With(labelValues ...string) metrics.Counter {
+	return c
+}
+
+func (c *counter) Add(delta float64) {
+	c.count += delta
+}
+
+func TestPublish(t *testing.T) {
+	pub := &publisher{}
+	cnt := &counter{}
+	dp := dedup.NewPublisher(pub, dedup.NewFilter(window), cnt)
+
+	for i := 0; i < 3; i++ {
+		err := dp.Publish(msg.Channel, msg)
+		assert.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	}
+
+	assert.Len(t, pub.published, 1, fmt.Sprintf("expected 1 published message got %d", len(pub.published)))
+	assert.Equal(t, float64(2), cnt.count, fmt.Sprintf("expected 2 suppressed messages got %v", cnt.count))
+}
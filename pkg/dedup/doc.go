@@ -0,0 +1,6 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package dedup contains the suppression of the duplicate messages resent
+// by the publishers within the configured time window.
+package dedup
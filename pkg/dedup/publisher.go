@@ -0,0 +1,87 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package dedup
+
+import (
+	"context"
+
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+	"github.com/go-kit/kit/metrics"
+)
+
+var (
+	_ messaging.Publisher = (*publisher)(nil)
+	_ messaging.PubSub    = (*pubsub)(nil)
+)
+
+type publisher struct {
+	messaging.Publisher
+	filter  Filter
+	counter metrics.Counter
+}
+
+// NewPublisher returns the publisher which drops the duplicate messages
+// detected by the filter, counting them by the counter.
+func NewPublisher(pub messaging.Publisher, filter Filter, counter metrics.Counter) messaging.Publisher {
+	return &publisher{
+		Publisher: pub,
+		filter:    filter,
+		counter:   counter,
+	}
+}
+
+func (p *publisher) Publish(topic string, msg messaging.Message) error {
+	if p.filter.Duplicate(msg) {
+		p.counter.Add(1)
+		return nil
+	}
+
+	return p.Publisher.Publish(topic, msg)
+}
+
+// PublishConfirmed publishes the message using the underlying publisher,
+// if supported, and waits for the broker acknowledgement. The duplicate
+// messages are dropped, since their originals are already published.
+func (p *publisher) PublishConfirmed(ctx context.Context, topic string, msg messaging.Message) error {
+	if _, ok := p.Publisher.(messaging.Confirmer); !ok {
+		return messaging.ErrConfirmNotSupported
+	}
+
+	if p.filter.Duplicate(msg) {
+		p.counter.Add(1)
+		return nil
+	}
+
+	return messaging.PublishConfirmed(ctx, p.Publisher, topic, msg)
+}
+
+// Ping checks the connection of the underlying publisher, if supported.
+func (p *publisher) Ping(ctx context.Context) error {
+	if pinger, ok := p.Publisher.(messaging.Pinger); ok {
+		return pinger.Ping(ctx)
+	}
+	return nil
+}
+
+type pubsub struct {
+	*publisher
+	messaging.Subscriber
+}
+
+// NewPubSub returns the pubsub which drops the duplicate messages detected
+// by the filter on publishing, counting them by the counter.
+func NewPubSub(ps messaging.PubSub, filter Filter, counter metrics.Counter) messaging.PubSub {
+	return &pubsub{
+		publisher: &publisher{
+			Publisher: ps,
+			filter:    filter,
+			counter:   counter,
+		},
+		Subscriber: ps,
+	}
+}
+
+func (ps *pubsub) Close() error {
+	return ps.Subscriber.Close()
+}
@@ -0,0 +1,7 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package drain contains the graceful drain of the protocol adapters used
+// during rolling deploys. Once the drain is started, adapters reject new
+// connections and wait for the in-flight publishes before exiting.
+package drain
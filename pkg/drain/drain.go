@@ -0,0 +1,139 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package drain
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+)
+
+// ErrDraining indicates that the new connection is rejected because
+// the service is draining.
+var ErrDraining = errors.New("service is draining")
+
+// Drainer tracks in-flight publishes and the drain state of the service.
+type Drainer interface {
+	// Start starts the drain. Starting the already started drain is a no-op.
+	Start()
+
+	// Started returns the channel which is closed once the drain is started.
+	Started() <-chan struct{}
+
+	// Draining reports whether the drain is started, i.e. whether new
+	// connections must be rejected.
+	Draining() bool
+
+	// Track registers the in-flight publish and returns the function
+	// which marks it as done.
+	Track() func()
+
+	// Wait waits until there are no in-flight publishes or the context is done.
+	Wait(ctx context.Context) error
+}
+
+var _ Drainer = (*drainer)(nil)
+
+type drainer struct {
+	mu       sync.Mutex
+	once     sync.Once
+	started  chan struct{}
+	inFlight int
+	idle     chan struct{}
+}
+
+// New returns new drainer.
+func New() Drainer {
+	return &drainer{
+		started: make(chan struct{}),
+		idle:    make(chan struct{}),
+	}
+}
+
+func (d *drainer) Start() {
+	d.once.Do(func() {
+		close(d.started)
+	})
+}
+
+func (d *drainer) Started() <-chan struct{} {
+	return d.started
+}
+
+func (d *drainer) Draining() bool {
+	select {
+	case <-d.started:
+		return true
+	default:
+		return false
+	}
+}
+
+func (d *drainer) Track() func() {
+	d.mu.Lock()
+	d.inFlight++
+	d.mu.Unlock()
+
+	var once sync.Once
+	return func() {
+		once.Do(d.done)
+	}
+}
+
+func (d *drainer) done() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.inFlight--
+	if d.inFlight == 0 {
+		close(d.idle)
+		d.idle = make(chan struct{})
+	}
+}
+
+func (d *drainer) Wait(ctx context.Context) error {
+	for {
+		d.mu.Lock()
+		if d.inFlight == 0 {
+			d.mu.Unlock()
+			return nil
+		}
+		idle := d.idle
+		d.mu.Unlock()
+
+		select {
+		case <-idle:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// Run waits for the SIGTERM signal or the drain started by the admin
+// endpoint, and then drains the service, waiting for the in-flight
+// publishes at most for the given timeout. It returns without draining
+// if the context is done first.
+func Run(ctx context.Context, d Drainer, timeout time.Duration) error {
+	c := make(chan os.Signal, 1)
+	signal.Notify(c, syscall.SIGTERM)
+	defer signal.Stop(c)
+
+	select {
+	case <-c:
+		d.Start()
+	case <-d.Started():
+	case <-ctx.Done():
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	return d.Wait(ctx)
+}
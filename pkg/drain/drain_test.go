@@ -0,0 +1,134 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package drain_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/pkg/drain"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	drainKey = "drain-key"
+	timeout  = 50 * time.Millisecond
+)
+
+func TestWait(t *testing.T) {
+	d := drain.New()
+	done := d.Track()
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	err := d.Wait(ctx)
+	assert.Equal(t, context.DeadlineExceeded, err, fmt.Sprintf("wait with in-flight publish: expected %s got %s\n", context.DeadlineExceeded, err))
+
+	go func() {
+		time.Sleep(timeout / 2)
+		done()
+	}()
+
+	ctx, cancel = context.WithTimeout(context.Background(), timeout*2)
+	defer cancel()
+	err = d.Wait(ctx)
+	assert.Nil(t, err, fmt.Sprintf("wait for finished publish: unexpected error %s\n", err))
+
+	done()
+	err = d.Wait(context.Background())
+	assert.Nil(t, err, fmt.Sprintf("wait after repeated done: unexpected error %s\n", err))
+}
+
+func TestRun(t *testing.T) {
+	d := drain.New()
+	done := d.Track()
+
+	errs := make(chan error)
+	go func() {
+		errs <- drain.Run(context.Background(), d, timeout*2)
+	}()
+
+	assert.False(t, d.Draining(), "expected service not to be draining before the drain is started")
+	d.Start()
+	assert.True(t, d.Draining(), "expected service to be draining after the drain is started")
+
+	time.Sleep(timeout)
+	done()
+	err := <-errs
+	assert.Nil(t, err, fmt.Sprintf("run drain: unexpected error %s\n", err))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err = drain.Run(ctx, drain.New(), timeout)
+	assert.Nil(t, err, fmt.Sprintf("run with canceled context: unexpected error %s\n", err))
+}
+
+func TestHandler(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	cases := []struct {
+		desc     string
+		key      string
+		method   string
+		path     string
+		auth     string
+		status   int
+		draining bool
+	}{
+		{
+			desc:   "drain with invalid key",
+			key:    drainKey,
+			method: http.MethodPost,
+			path:   "/drain",
+			auth:   "invalid",
+			status: http.StatusUnauthorized,
+		},
+		{
+			desc:   "drain with invalid method",
+			key:    drainKey,
+			method: http.MethodGet,
+			path:   "/drain",
+			auth:   drainKey,
+			status: http.StatusMethodNotAllowed,
+		},
+		{
+			desc:   "drain with disabled endpoint",
+			method: http.MethodPost,
+			path:   "/drain",
+			status: http.StatusOK,
+		},
+		{
+			desc:   "request passed to the next handler",
+			key:    drainKey,
+			method: http.MethodGet,
+			path:   "/health",
+			status: http.StatusOK,
+		},
+		{
+			desc:     "drain with valid key",
+			key:      drainKey,
+			method:   http.MethodPost,
+			path:     "/drain",
+			auth:     drainKey,
+			status:   http.StatusAccepted,
+			draining: true,
+		},
+	}
+
+	for _, tc := range cases {
+		d := drain.New()
+		req := httptest.NewRequest(tc.method, tc.path, nil)
+		req.Header.Set("Authorization", tc.auth)
+		rec := httptest.NewRecorder()
+		drain.NewHandler(d, tc.key, next).ServeHTTP(rec, req)
+		assert.Equal(t, tc.status, rec.Code, fmt.Sprintf("%s: expected status code %d got %d\n", tc.desc, tc.status, rec.Code))
+		assert.Equal(t, tc.draining, d.Draining(), fmt.Sprintf("%s: expected draining %t got %t\n", tc.desc, tc.draining, d.Draining()))
+	}
+}
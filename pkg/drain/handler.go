@@ -0,0 +1,37 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package drain
+
+import (
+	"crypto/subtle"
+	"net/http"
+)
+
+const drainPath = "/drain"
+
+// NewHandler returns HTTP handler which starts the drain on POST /drain
+// requests carrying the drain key in the Authorization header. All other
+// requests are passed to the given handler. The drain endpoint is disabled
+// if the drain key is empty.
+func NewHandler(d Drainer, key string, h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if key == "" || r.URL.Path != drainPath {
+			h.ServeHTTP(w, r)
+			return
+		}
+
+		if r.Method != http.MethodPost {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		if subtle.ConstantTimeCompare([]byte(r.Header.Get("Authorization")), []byte(key)) != 1 {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		d.Start()
+		w.WriteHeader(http.StatusAccepted)
+	})
+}
@@ -0,0 +1,7 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package encryption contains the envelope encryption of the messages stored
+// by the writers. Message payloads are encrypted using the per-channel data
+// keys, which are stored wrapped by the key management service.
+package encryption
@@ -0,0 +1,60 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package encryption
+
+import (
+	"context"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+)
+
+var (
+	// ErrInvalidKey indicates the master key which can't be used for encryption.
+	ErrInvalidKey = errors.New("invalid encryption key")
+
+	// ErrEncrypt indicates failure to encrypt the data.
+	ErrEncrypt = errors.New("failed to encrypt data")
+
+	// ErrDecrypt indicates failure to decrypt the data, e.g. due to the
+	// wrong key or tampered ciphertext.
+	ErrDecrypt = errors.New("failed to decrypt data")
+)
+
+// KMS represents the key management service which wraps and unwraps data keys.
+type KMS interface {
+	// Encrypt wraps the data key.
+	Encrypt(ctx context.Context, plaintext []byte) ([]byte, error)
+
+	// Decrypt unwraps the data key.
+	Decrypt(ctx context.Context, ciphertext []byte) ([]byte, error)
+}
+
+// DataKey represents the version of the channel data key, wrapped by the KMS.
+type DataKey struct {
+	ChannelID string
+	Version   uint64
+	Key       []byte
+	CreatedAt time.Time
+}
+
+// KeyRepository specifies data keys persistence API.
+type KeyRepository interface {
+	// Save persists the data key. ErrConflict is returned if the version
+	// of the channel data key already exists.
+	Save(ctx context.Context, dk DataKey) error
+
+	// Retrieve retrieves the given version of the channel data key.
+	Retrieve(ctx context.Context, chanID string, version uint64) (DataKey, error)
+
+	// RetrieveLatest retrieves the latest version of the channel data key.
+	RetrieveLatest(ctx context.Context, chanID string) (DataKey, error)
+}
+
+// Ciphertext represents the data encrypted using the given version of
+// the channel data key.
+type Ciphertext struct {
+	Version uint64
+	Data    []byte
+}
@@ -0,0 +1,191 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package encryption_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/MainfluxLabs/mainflux/pkg/encryption"
+	"github.com/MainfluxLabs/mainflux/pkg/encryption/mocks"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	chanID    = "50e6b371-60ff-45cf-bb52-8200e7cde536"
+	otherChan = "a2b6b3f2-9b3a-4f31-9a4e-2bde37d1e9f1"
+)
+
+var masterKey = []byte("01234567890123456789012345678901")
+
+func newKeyring(t *testing.T) encryption.Keyring {
+	kms, err := encryption.NewLocalKMS(masterKey)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	return encryption.NewKeyring(kms, mocks.NewKeyRepository())
+}
+
+func TestNewLocalKMS(t *testing.T) {
+	cases := []struct {
+		desc string
+		key  []byte
+		err  error
+	}{
+		{
+			desc: "create local KMS with valid key",
+			key:  masterKey,
+			err:  nil,
+		},
+		{
+			desc: "create local KMS with short key",
+			key:  masterKey[:16],
+			err:  encryption.ErrInvalidKey,
+		},
+		{
+			desc: "create local KMS with empty key",
+			key:  nil,
+			err:  encryption.ErrInvalidKey,
+		},
+	}
+
+	for _, tc := range cases {
+		_, err := encryption.NewLocalKMS(tc.key)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestEncryptDecrypt(t *testing.T) {
+	kr := newKeyring(t)
+	plaintext := []byte("plaintext")
+
+	ct, err := kr.Encrypt(context.Background(), chanID, plaintext)
+	require.Nil(t, err, fmt.Sprintf("encrypt: unexpected error: %s", err))
+	assert.Equal(t, uint64(1), ct.Version, fmt.Sprintf("encrypt: expected key version 1 got %d", ct.Version))
+	assert.NotContains(t, string(ct.Data), string(plaintext), "encrypt: ciphertext contains plaintext")
+
+	cases := []struct {
+		desc   string
+		chanID string
+		ct     encryption.Ciphertext
+		data   []byte
+		err    error
+	}{
+		{
+			desc:   "decrypt ciphertext",
+			chanID: chanID,
+			ct:     ct,
+			data:   plaintext,
+			err:    nil,
+		},
+		{
+			desc:   "decrypt ciphertext of another channel",
+			chanID: otherChan,
+			ct:     ct,
+			err:    errors.ErrNotFound,
+		},
+		{
+			desc:   "decrypt ciphertext with non-existing key version",
+			chanID: chanID,
+			ct:     encryption.Ciphertext{Version: 2, Data: ct.Data},
+			err:    errors.ErrNotFound,
+		},
+		{
+			desc:   "decrypt tampered ciphertext",
+			chanID: chanID,
+			ct:     encryption.Ciphertext{Version: ct.Version, Data: append([]byte{0}, ct.Data[1:]...)},
+			err:    encryption.ErrDecrypt,
+		},
+		{
+			desc:   "decrypt too short ciphertext",
+			chanID: chanID,
+			ct:     encryption.Ciphertext{Version: ct.Version, Data: ct.Data[:4]},
+			err:    encryption.ErrDecrypt,
+		},
+	}
+
+	for _, tc := range cases {
+		data, err := kr.Decrypt(context.Background(), tc.chanID, tc.ct)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.data, data, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.data, data))
+	}
+}
+
+func TestRotate(t *testing.T) {
+	kr := newKeyring(t)
+	plaintext := []byte("plaintext")
+
+	old, err := kr.Encrypt(context.Background(), chanID, plaintext)
+	require.Nil(t, err, fmt.Sprintf("encrypt: unexpected error: %s", err))
+
+	version, err := kr.Rotate(context.Background(), chanID)
+	require.Nil(t, err, fmt.Sprintf("rotate: unexpected error: %s", err))
+	assert.Equal(t, uint64(2), version, fmt.Sprintf("rotate: expected key version 2 got %d", version))
+
+	ct, err := kr.Encrypt(context.Background(), chanID, plaintext)
+	require.Nil(t, err, fmt.Sprintf("encrypt: unexpected error: %s", err))
+	assert.Equal(t, version, ct.Version, fmt.Sprintf("encrypt after rotation: expected key version %d got %d", version, ct.Version))
+
+	for _, c := range []encryption.Ciphertext{old, ct} {
+		data, err := kr.Decrypt(context.Background(), chanID, c)
+		assert.Nil(t, err, fmt.Sprintf("decrypt key version %d: unexpected error: %s", c.Version, err))
+		assert.Equal(t, plaintext, data, fmt.Sprintf("decrypt key version %d: expected %s got %s", c.Version, plaintext, data))
+	}
+
+	version, err = kr.Rotate(context.Background(), otherChan)
+	assert.Nil(t, err, fmt.Sprintf("rotate channel without key: unexpected error: %s", err))
+	assert.Equal(t, uint64(1), version, fmt.Sprintf("rotate channel without key: expected key version 1 got %d", version))
+}
+
+func TestPayload(t *testing.T) {
+	kr := newKeyring(t)
+	payload := map[string]interface{}{
+		"temperature": 21.5,
+		"unit":        "C",
+	}
+
+	enc, err := encryption.EncryptPayload(context.Background(), kr, chanID, payload)
+	require.Nil(t, err, fmt.Sprintf("encrypt payload: unexpected error: %s", err))
+	assert.NotContains(t, enc, "temperature", "encrypt payload: encrypted payload contains plaintext field")
+
+	cases := []struct {
+		desc    string
+		payload map[string]interface{}
+		res     map[string]interface{}
+		err     error
+	}{
+		{
+			desc:    "decrypt encrypted payload",
+			payload: enc,
+			res:     payload,
+			err:     nil,
+		},
+		{
+			desc:    "decrypt encrypted payload with float key version",
+			payload: map[string]interface{}{"ciphertext": enc["ciphertext"], "key_version": float64(1)},
+			res:     payload,
+			err:     nil,
+		},
+		{
+			desc:    "decrypt plaintext payload",
+			payload: payload,
+			res:     payload,
+			err:     nil,
+		},
+		{
+			desc:    "decrypt payload with integer key version",
+			payload: map[string]interface{}{"ciphertext": "AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA", "key_version": 1},
+			res:     map[string]interface{}{"ciphertext": "AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA", "key_version": 1},
+			err:     nil,
+		},
+	}
+
+	for _, tc := range cases {
+		res, err := encryption.DecryptPayload(context.Background(), kr, chanID, tc.payload)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.res, res, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.res, res))
+	}
+}
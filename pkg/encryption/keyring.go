@@ -0,0 +1,213 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package encryption
+
+import (
+	"context"
+	"crypto/cipher"
+	"crypto/rand"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+)
+
+// latestTTL is the period after which the latest data key version is
+// retrieved again, so the keys rotated by other services are taken
+// into account.
+const latestTTL = time.Minute
+
+// Keyring encrypts and decrypts data using the per-channel data keys.
+type Keyring interface {
+	// Encrypt encrypts the data using the latest version of the channel
+	// data key. The first version of the data key is created if the channel
+	// doesn't have one.
+	Encrypt(ctx context.Context, chanID string, plaintext []byte) (Ciphertext, error)
+
+	// Decrypt decrypts the data using the version of the channel data key
+	// which is used to encrypt it.
+	Decrypt(ctx context.Context, chanID string, ct Ciphertext) ([]byte, error)
+
+	// Rotate creates the new version of the channel data key, which is used
+	// to encrypt the data from then on. Previous versions are kept in order
+	// to decrypt the already encrypted data.
+	Rotate(ctx context.Context, chanID string) (uint64, error)
+}
+
+var _ Keyring = (*keyring)(nil)
+
+type latestVersion struct {
+	version uint64
+	expires time.Time
+}
+
+type keyring struct {
+	kms    KMS
+	keys   KeyRepository
+	mu     sync.Mutex
+	aeads  map[string]map[uint64]cipher.AEAD
+	latest map[string]latestVersion
+}
+
+// NewKeyring returns keyring which keeps data keys in the given repository,
+// wrapped by the KMS. Unwrapped data keys are cached in memory.
+func NewKeyring(kms KMS, keys KeyRepository) Keyring {
+	return &keyring{
+		kms:    kms,
+		keys:   keys,
+		aeads:  make(map[string]map[uint64]cipher.AEAD),
+		latest: make(map[string]latestVersion),
+	}
+}
+
+func (kr *keyring) Encrypt(ctx context.Context, chanID string, plaintext []byte) (Ciphertext, error) {
+	version, err := kr.latestVersion(ctx, chanID)
+	if err != nil {
+		return Ciphertext{}, err
+	}
+
+	aead, err := kr.aead(ctx, chanID, version)
+	if err != nil {
+		return Ciphertext{}, err
+	}
+
+	data, err := seal(aead, plaintext, []byte(chanID))
+	if err != nil {
+		return Ciphertext{}, err
+	}
+
+	return Ciphertext{Version: version, Data: data}, nil
+}
+
+func (kr *keyring) Decrypt(ctx context.Context, chanID string, ct Ciphertext) ([]byte, error) {
+	aead, err := kr.aead(ctx, chanID, ct.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	return open(aead, ct.Data, []byte(chanID))
+}
+
+func (kr *keyring) Rotate(ctx context.Context, chanID string) (uint64, error) {
+	var version uint64
+	dk, err := kr.keys.RetrieveLatest(ctx, chanID)
+	switch {
+	case err == nil:
+		version = dk.Version
+	case !errors.Contains(err, errors.ErrNotFound):
+		return 0, err
+	}
+
+	key := make([]byte, keySize)
+	if _, err := io.ReadFull(rand.Reader, key); err != nil {
+		return 0, errors.Wrap(ErrEncrypt, err)
+	}
+
+	wrapped, err := kr.kms.Encrypt(ctx, key)
+	if err != nil {
+		return 0, err
+	}
+
+	dk = DataKey{
+		ChannelID: chanID,
+		Version:   version + 1,
+		Key:       wrapped,
+		CreatedAt: time.Now(),
+	}
+	if err := kr.keys.Save(ctx, dk); err != nil {
+		return 0, err
+	}
+
+	aead, err := newAEAD(key)
+	if err != nil {
+		return 0, err
+	}
+	kr.save(chanID, dk.Version, aead)
+
+	return dk.Version, nil
+}
+
+// latestVersion returns the latest version of the channel data key,
+// creating the first one if the channel doesn't have it.
+func (kr *keyring) latestVersion(ctx context.Context, chanID string) (uint64, error) {
+	kr.mu.Lock()
+	lv, ok := kr.latest[chanID]
+	kr.mu.Unlock()
+	if ok && time.Now().Before(lv.expires) {
+		return lv.version, nil
+	}
+
+	dk, err := kr.keys.RetrieveLatest(ctx, chanID)
+	if err == nil {
+		kr.setLatest(chanID, dk.Version)
+		return dk.Version, nil
+	}
+	if !errors.Contains(err, errors.ErrNotFound) {
+		return 0, err
+	}
+
+	version, err := kr.Rotate(ctx, chanID)
+	if errors.Contains(err, errors.ErrConflict) {
+		// The first version is concurrently created by another writer.
+		dk, err = kr.keys.RetrieveLatest(ctx, chanID)
+		version = dk.Version
+	}
+	if err != nil {
+		return 0, err
+	}
+	kr.setLatest(chanID, version)
+
+	return version, nil
+}
+
+// aead returns cipher which uses the given version of the channel data key.
+func (kr *keyring) aead(ctx context.Context, chanID string, version uint64) (cipher.AEAD, error) {
+	kr.mu.Lock()
+	aead, ok := kr.aeads[chanID][version]
+	kr.mu.Unlock()
+	if ok {
+		return aead, nil
+	}
+
+	dk, err := kr.keys.Retrieve(ctx, chanID, version)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := kr.kms.Decrypt(ctx, dk.Key)
+	if err != nil {
+		return nil, err
+	}
+
+	if aead, err = newAEAD(key); err != nil {
+		return nil, err
+	}
+	kr.save(chanID, version, aead)
+
+	return aead, nil
+}
+
+func (kr *keyring) save(chanID string, version uint64, aead cipher.AEAD) {
+	kr.mu.Lock()
+	defer kr.mu.Unlock()
+
+	aeads, ok := kr.aeads[chanID]
+	if !ok {
+		aeads = make(map[uint64]cipher.AEAD)
+		kr.aeads[chanID] = aeads
+	}
+	aeads[version] = aead
+
+	if lv := kr.latest[chanID]; version >= lv.version {
+		kr.latest[chanID] = latestVersion{version: version, expires: time.Now().Add(latestTTL)}
+	}
+}
+
+func (kr *keyring) setLatest(chanID string, version uint64) {
+	kr.mu.Lock()
+	defer kr.mu.Unlock()
+
+	kr.latest[chanID] = latestVersion{version: version, expires: time.Now().Add(latestTTL)}
+}
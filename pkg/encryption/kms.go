@@ -0,0 +1,79 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package encryption
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"io"
+
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+)
+
+const keySize = 32
+
+var _ KMS = (*localKMS)(nil)
+
+type localKMS struct {
+	aead cipher.AEAD
+}
+
+// NewLocalKMS returns KMS which wraps data keys using AES-256-GCM and the
+// master key provided in the service configuration.
+func NewLocalKMS(masterKey []byte) (KMS, error) {
+	aead, err := newAEAD(masterKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &localKMS{aead: aead}, nil
+}
+
+func (kms *localKMS) Encrypt(_ context.Context, plaintext []byte) ([]byte, error) {
+	return seal(kms.aead, plaintext, nil)
+}
+
+func (kms *localKMS) Decrypt(_ context.Context, ciphertext []byte) ([]byte, error) {
+	return open(kms.aead, ciphertext, nil)
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	if len(key) != keySize {
+		return nil, ErrInvalidKey
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, errors.Wrap(ErrInvalidKey, err)
+	}
+
+	return cipher.NewGCM(block)
+}
+
+// seal encrypts the plaintext and prepends the random nonce to the result.
+// Additional data is authenticated, but not included in the result.
+func seal(aead cipher.AEAD, plaintext, ad []byte) ([]byte, error) {
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, errors.Wrap(ErrEncrypt, err)
+	}
+
+	return aead.Seal(nonce, nonce, plaintext, ad), nil
+}
+
+func open(aead cipher.AEAD, ciphertext, ad []byte) ([]byte, error) {
+	if len(ciphertext) < aead.NonceSize() {
+		return nil, ErrDecrypt
+	}
+
+	nonce, data := ciphertext[:aead.NonceSize()], ciphertext[aead.NonceSize():]
+	plaintext, err := aead.Open(nil, nonce, data, ad)
+	if err != nil {
+		return nil, errors.Wrap(ErrDecrypt, err)
+	}
+
+	return plaintext, nil
+}
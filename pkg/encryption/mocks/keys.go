@@ -0,0 +1,71 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"context"
+	"sync"
+
+	"github.com/MainfluxLabs/mainflux/pkg/encryption"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+)
+
+var _ encryption.KeyRepository = (*keyRepositoryMock)(nil)
+
+type keyRepositoryMock struct {
+	mu   sync.Mutex
+	keys map[string][]encryption.DataKey
+}
+
+// NewKeyRepository returns in-memory data keys repository.
+func NewKeyRepository() encryption.KeyRepository {
+	return &keyRepositoryMock{
+		keys: make(map[string][]encryption.DataKey),
+	}
+}
+
+func (krm *keyRepositoryMock) Save(_ context.Context, dk encryption.DataKey) error {
+	krm.mu.Lock()
+	defer krm.mu.Unlock()
+
+	for _, k := range krm.keys[dk.ChannelID] {
+		if k.Version == dk.Version {
+			return errors.ErrConflict
+		}
+	}
+	krm.keys[dk.ChannelID] = append(krm.keys[dk.ChannelID], dk)
+
+	return nil
+}
+
+func (krm *keyRepositoryMock) Retrieve(_ context.Context, chanID string, version uint64) (encryption.DataKey, error) {
+	krm.mu.Lock()
+	defer krm.mu.Unlock()
+
+	for _, k := range krm.keys[chanID] {
+		if k.Version == version {
+			return k, nil
+		}
+	}
+
+	return encryption.DataKey{}, errors.ErrNotFound
+}
+
+func (krm *keyRepositoryMock) RetrieveLatest(_ context.Context, chanID string) (encryption.DataKey, error) {
+	krm.mu.Lock()
+	defer krm.mu.Unlock()
+
+	var latest encryption.DataKey
+	for _, k := range krm.keys[chanID] {
+		if k.Version > latest.Version {
+			latest = k
+		}
+	}
+
+	if latest.Version == 0 {
+		return encryption.DataKey{}, errors.ErrNotFound
+	}
+
+	return latest, nil
+}
@@ -0,0 +1,88 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package encryption
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+)
+
+const (
+	ciphertextKey = "ciphertext"
+	keyVersionKey = "key_version"
+)
+
+// EncryptPayload encrypts the JSON message payload. Encrypted payload is
+// JSON object containing the base64 encoded ciphertext and the version of
+// the channel data key used to encrypt it.
+func EncryptPayload(ctx context.Context, kr Keyring, chanID string, payload map[string]interface{}) (map[string]interface{}, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, errors.Wrap(ErrEncrypt, err)
+	}
+
+	ct, err := kr.Encrypt(ctx, chanID, data)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{
+		ciphertextKey: base64.StdEncoding.EncodeToString(ct.Data),
+		keyVersionKey: ct.Version,
+	}, nil
+}
+
+// DecryptPayload decrypts the JSON message payload encrypted by the
+// EncryptPayload. Payloads which are not encrypted are returned unchanged.
+func DecryptPayload(ctx context.Context, kr Keyring, chanID string, payload map[string]interface{}) (map[string]interface{}, error) {
+	ct, ok := ciphertext(payload)
+	if !ok {
+		return payload, nil
+	}
+
+	data, err := kr.Decrypt(ctx, chanID, ct)
+	if err != nil {
+		return nil, err
+	}
+
+	var ret map[string]interface{}
+	if err := json.Unmarshal(data, &ret); err != nil {
+		return nil, errors.Wrap(ErrDecrypt, err)
+	}
+
+	return ret, nil
+}
+
+// ciphertext parses the encrypted payload. Payload is considered encrypted
+// only if it contains nothing but the ciphertext and the key version.
+func ciphertext(payload map[string]interface{}) (Ciphertext, bool) {
+	if len(payload) != 2 {
+		return Ciphertext{}, false
+	}
+
+	enc, ok := payload[ciphertextKey].(string)
+	if !ok {
+		return Ciphertext{}, false
+	}
+
+	var version uint64
+	switch v := payload[keyVersionKey].(type) {
+	case uint64:
+		version = v
+	case float64:
+		version = uint64(v)
+	default:
+		return Ciphertext{}, false
+	}
+
+	data, err := base64.StdEncoding.DecodeString(enc)
+	if err != nil {
+		return Ciphertext{}, false
+	}
+
+	return Ciphertext{Version: version, Data: data}, true
+}
@@ -0,0 +1,40 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"github.com/jmoiron/sqlx"
+	migrate "github.com/rubenv/sql-migrate"
+)
+
+// migrationsTable keeps the data keys migrations apart from the migrations
+// of the service which shares the database.
+const migrationsTable = "channel_keys_migrations"
+
+// Migrate applies any unapplied data keys migrations to the database.
+func Migrate(db *sqlx.DB) error {
+	migrations := &migrate.MemoryMigrationSource{
+		Migrations: []*migrate.Migration{
+			{
+				Id: "channel_keys_1",
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS channel_keys (
+                        channel     VARCHAR(254),
+                        version     BIGINT,
+                        key         BYTEA NOT NULL,
+                        created_at  TIMESTAMPTZ NOT NULL,
+                        PRIMARY KEY (channel, version)
+                    )`,
+				},
+				Down: []string{
+					"DROP TABLE channel_keys",
+				},
+			},
+		},
+	}
+
+	ms := migrate.MigrationSet{TableName: migrationsTable}
+	_, err := ms.Exec(db.DB, "postgres", migrations, migrate.Up)
+	return err
+}
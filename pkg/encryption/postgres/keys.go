@@ -0,0 +1,93 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/pkg/encryption"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jmoiron/sqlx"
+)
+
+var _ encryption.KeyRepository = (*keyRepository)(nil)
+
+type keyRepository struct {
+	db *sqlx.DB
+}
+
+// NewKeyRepository instantiates a PostgreSQL implementation of data keys
+// repository.
+func NewKeyRepository(db *sqlx.DB) encryption.KeyRepository {
+	return &keyRepository{db: db}
+}
+
+func (kr keyRepository) Save(ctx context.Context, dk encryption.DataKey) error {
+	q := `INSERT INTO channel_keys (channel, version, key, created_at)
+          VALUES (:channel, :version, :key, :created_at);`
+
+	if _, err := kr.db.NamedExecContext(ctx, q, toDBKey(dk)); err != nil {
+		pgErr, ok := err.(*pgconn.PgError)
+		if ok && pgErr.Code == pgerrcode.UniqueViolation {
+			return errors.Wrap(errors.ErrConflict, err)
+		}
+		return errors.Wrap(errors.ErrCreateEntity, err)
+	}
+
+	return nil
+}
+
+func (kr keyRepository) Retrieve(ctx context.Context, chanID string, version uint64) (encryption.DataKey, error) {
+	q := `SELECT channel, version, key, created_at FROM channel_keys WHERE channel = $1 AND version = $2;`
+
+	return kr.retrieve(ctx, q, chanID, version)
+}
+
+func (kr keyRepository) RetrieveLatest(ctx context.Context, chanID string) (encryption.DataKey, error) {
+	q := `SELECT channel, version, key, created_at FROM channel_keys WHERE channel = $1
+          ORDER BY version DESC LIMIT 1;`
+
+	return kr.retrieve(ctx, q, chanID)
+}
+
+func (kr keyRepository) retrieve(ctx context.Context, q string, args ...interface{}) (encryption.DataKey, error) {
+	var dbk dbKey
+	if err := kr.db.QueryRowxContext(ctx, q, args...).StructScan(&dbk); err != nil {
+		if err == sql.ErrNoRows {
+			return encryption.DataKey{}, errors.Wrap(errors.ErrNotFound, err)
+		}
+		return encryption.DataKey{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+
+	return toKey(dbk), nil
+}
+
+type dbKey struct {
+	Channel   string    `db:"channel"`
+	Version   int64     `db:"version"`
+	Key       []byte    `db:"key"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+func toDBKey(dk encryption.DataKey) dbKey {
+	return dbKey{
+		Channel:   dk.ChannelID,
+		Version:   int64(dk.Version),
+		Key:       dk.Key,
+		CreatedAt: dk.CreatedAt,
+	}
+}
+
+func toKey(dbk dbKey) encryption.DataKey {
+	return encryption.DataKey{
+		ChannelID: dbk.Channel,
+		Version:   uint64(dbk.Version),
+		Key:       dbk.Key,
+		CreatedAt: dbk.CreatedAt,
+	}
+}
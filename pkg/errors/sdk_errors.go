@@ -5,25 +5,22 @@ package errors
 
 import (
 	"encoding/json"
-	"errors"
 	"fmt"
 	"net/http"
 )
 
-const err = "error"
-
 var (
-	// ErrJSONErrKey indicates response body did not contain erorr message.
+	// ErrJSONErrKey indicates response body did not contain erorr code and message.
 	errJSONKey = New("response body expected error message json key not found")
-
-	// ErrUnknown indicates that an unknown error was found in the response body.
-	errUnknown = New("unknown error")
 )
 
 // SDKError is an error type for Mainflux SDK.
 type SDKError interface {
 	Error
 	StatusCode() int
+
+	// Code returns the machine-readable error code from the response body.
+	Code() string
 }
 
 var _ SDKError = (*sdkError)(nil)
@@ -31,6 +28,14 @@ var _ SDKError = (*sdkError)(nil)
 type sdkError struct {
 	*customError
 	statusCode int
+	code       string
+}
+
+// errorRes is the error response body returned by the services.
+type errorRes struct {
+	Err     string `json:"error"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
 }
 
 func (ce *sdkError) Error() string {
@@ -47,6 +52,10 @@ func (ce *sdkError) StatusCode() int {
 	return ce.statusCode
 }
 
+func (ce *sdkError) Code() string {
+	return ce.code
+}
+
 // NewSDKError returns an SDK Error that formats as the given text.
 func NewSDKError(err error) SDKError {
 	return &sdkError{
@@ -79,17 +88,27 @@ func CheckError(resp *http.Response, expectedStatusCodes ...int) SDKError {
 		}
 	}
 
-	var content map[string]interface{}
-	if err := json.NewDecoder(resp.Body).Decode(&content); err != nil {
+	var res errorRes
+	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
 		return NewSDKErrorWithStatus(err, resp.StatusCode)
 	}
 
-	if msg, ok := content[err]; ok {
-		if v, ok := msg.(string); ok {
-			return NewSDKErrorWithStatus(errors.New(v), resp.StatusCode)
-		}
-		return NewSDKErrorWithStatus(errUnknown, resp.StatusCode)
+	// English error message is preferred over the translated one, and
+	// the services without error codes return only the error message.
+	msg := res.Err
+	if msg == "" {
+		msg = res.Message
+	}
+	if msg == "" {
+		return NewSDKErrorWithStatus(errJSONKey, resp.StatusCode)
 	}
 
-	return NewSDKErrorWithStatus(errJSONKey, resp.StatusCode)
+	return &sdkError{
+		statusCode: resp.StatusCode,
+		code:       res.Code,
+		customError: &customError{
+			msg: msg,
+			err: nil,
+		},
+	}
 }
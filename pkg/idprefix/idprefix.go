@@ -0,0 +1,33 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package idprefix provides an identity provider which prefixes the IDs with
+// the type of the identified entity.
+package idprefix
+
+import "github.com/MainfluxLabs/mainflux"
+
+var _ mainflux.IDProvider = (*prefixProvider)(nil)
+
+type prefixProvider struct {
+	idp    mainflux.IDProvider
+	prefix string
+}
+
+// New instantiates a provider which prefixes the IDs generated by the
+// given provider with the prefix, e.g. thg_ for things.
+func New(idp mainflux.IDProvider, prefix string) mainflux.IDProvider {
+	return &prefixProvider{
+		idp:    idp,
+		prefix: prefix,
+	}
+}
+
+func (pp *prefixProvider) ID() (string, error) {
+	id, err := pp.idp.ID()
+	if err != nil {
+		return "", err
+	}
+
+	return pp.prefix + id, nil
+}
@@ -0,0 +1,42 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package idprefix_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/MainfluxLabs/mainflux"
+	"github.com/MainfluxLabs/mainflux/pkg/idprefix"
+	"github.com/MainfluxLabs/mainflux/pkg/ksuid"
+	"github.com/MainfluxLabs/mainflux/pkg/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestID(t *testing.T) {
+	cases := []struct {
+		desc   string
+		idp    mainflux.IDProvider
+		prefix string
+	}{
+		{
+			desc:   "generate thing UUID",
+			idp:    uuid.New(),
+			prefix: "thg_",
+		},
+		{
+			desc:   "generate channel KSUID",
+			idp:    ksuid.New(),
+			prefix: "chn_",
+		},
+	}
+
+	for _, tc := range cases {
+		id, err := idprefix.New(tc.idp, tc.prefix).ID()
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+		assert.True(t, strings.HasPrefix(id, tc.prefix), fmt.Sprintf("%s: expected ID %s to have prefix %s", tc.desc, id, tc.prefix))
+	}
+}
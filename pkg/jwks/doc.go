@@ -0,0 +1,7 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package jwks contains the validation of the JWTs issued to things by the
+// external PKI, using the public keys published by the issuer as the JSON
+// Web Key Set.
+package jwks
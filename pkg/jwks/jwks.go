@@ -0,0 +1,257 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package jwks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/pelletier/go-toml"
+	"golang.org/x/sync/singleflight"
+	jose "gopkg.in/square/go-jose.v2"
+	"gopkg.in/square/go-jose.v2/jwt"
+)
+
+// minRefresh is the minimal interval between the key set fetches triggered
+// by the tokens signed with unknown keys.
+const minRefresh = time.Minute
+
+var (
+	// ErrUnknownIssuer indicates that the token is issued by the issuer
+	// which is not configured.
+	ErrUnknownIssuer = errors.New("unknown token issuer")
+
+	// ErrInvalidToken indicates malformed token, token with invalid
+	// signature, expired token or token issued to another audience.
+	ErrInvalidToken = errors.New("invalid token")
+
+	// ErrMissingClaim indicates that the token doesn't contain the claim
+	// mapped to the thing identity.
+	ErrMissingClaim = errors.New("missing identity claim")
+
+	// ErrFetchKeys indicates failure to fetch the key set of the issuer.
+	ErrFetchKeys = errors.New("failed to fetch key set")
+
+	errMissingExpiry = errors.New("missing expiration claim")
+)
+
+// Issuer represents the external PKI which issues the JWTs to things.
+type Issuer struct {
+	// Issuer is the value of the "iss" claim of the issued tokens.
+	Issuer string `toml:"issuer"`
+
+	// URL is the URL of the JSON Web Key Set of the issuer.
+	URL string `toml:"jwks_url"`
+
+	// Owner is the ID of the user whose things the issuer issues tokens to.
+	Owner string `toml:"owner"`
+
+	// Audience is the value the "aud" claim of the issued tokens must
+	// contain, so that the tokens issued to other services are rejected.
+	Audience string `toml:"audience"`
+
+	// Claim is the claim which holds the external ID of the thing.
+	// Defaults to "sub".
+	Claim string `toml:"claim"`
+}
+
+type issuersFile struct {
+	Issuers []Issuer `toml:"issuers"`
+}
+
+// LoadIssuers reads the issuers from the TOML file.
+func LoadIssuers(file string) ([]Issuer, error) {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	var f issuersFile
+	if err := toml.Unmarshal(data, &f); err != nil {
+		return nil, err
+	}
+
+	for i, iss := range f.Issuers {
+		if iss.Issuer == "" || iss.URL == "" || iss.Owner == "" || iss.Audience == "" {
+			return nil, fmt.Errorf("issuer %d: issuer, jwks_url, owner and audience are required", i)
+		}
+		if iss.Claim == "" {
+			f.Issuers[i].Claim = "sub"
+		}
+	}
+
+	return f.Issuers, nil
+}
+
+// Validator validates the tokens issued to things by the external PKI.
+type Validator interface {
+	// Validate validates the token and returns the owner and the external
+	// ID of the thing the token is issued to.
+	Validate(ctx context.Context, token string) (string, string, error)
+}
+
+var _ Validator = (*validator)(nil)
+
+type keySet struct {
+	keys      *jose.JSONWebKeySet
+	fetchedAt time.Time
+}
+
+type validator struct {
+	mu      sync.Mutex
+	issuers map[string]Issuer
+	keys    map[string]keySet
+	fetches singleflight.Group
+	client  *http.Client
+	refresh time.Duration
+}
+
+// NewValidator returns validator of the tokens issued by the given issuers.
+// Key sets of the issuers are fetched on demand and refreshed after the
+// refresh interval, or earlier if the token is signed with the unknown key.
+func NewValidator(issuers []Issuer, client *http.Client, refresh time.Duration) Validator {
+	v := &validator{
+		issuers: make(map[string]Issuer),
+		keys:    make(map[string]keySet),
+		client:  client,
+		refresh: refresh,
+	}
+	for _, iss := range issuers {
+		v.issuers[iss.Issuer] = iss
+	}
+
+	return v
+}
+
+func (v *validator) Validate(ctx context.Context, token string) (string, string, error) {
+	tok, err := jwt.ParseSigned(token)
+	if err != nil {
+		return "", "", errors.Wrap(ErrInvalidToken, err)
+	}
+
+	var unverified jwt.Claims
+	if err := tok.UnsafeClaimsWithoutVerification(&unverified); err != nil {
+		return "", "", errors.Wrap(ErrInvalidToken, err)
+	}
+
+	iss, ok := v.issuers[unverified.Issuer]
+	if !ok {
+		return "", "", ErrUnknownIssuer
+	}
+
+	kid := ""
+	if len(tok.Headers) > 0 {
+		kid = tok.Headers[0].KeyID
+	}
+
+	keys, err := v.keySet(ctx, iss, kid)
+	if err != nil {
+		return "", "", err
+	}
+
+	var claims jwt.Claims
+	custom := map[string]interface{}{}
+	if err := tok.Claims(keys, &claims, &custom); err != nil {
+		return "", "", errors.Wrap(ErrInvalidToken, err)
+	}
+
+	if claims.Expiry == nil {
+		return "", "", errors.Wrap(ErrInvalidToken, errMissingExpiry)
+	}
+
+	expected := jwt.Expected{
+		Issuer:   iss.Issuer,
+		Audience: jwt.Audience{iss.Audience},
+		Time:     time.Now(),
+	}
+	if err := claims.Validate(expected); err != nil {
+		return "", "", errors.Wrap(ErrInvalidToken, err)
+	}
+
+	extID, ok := custom[iss.Claim].(string)
+	if !ok || extID == "" {
+		return "", "", ErrMissingClaim
+	}
+
+	return iss.Owner, extID, nil
+}
+
+// keySet returns the key set of the issuer, fetching it if it's missing,
+// stale, or doesn't contain the key with the given ID. Key set is fetched
+// without holding the lock, and concurrent fetches of the same key set are
+// merged, so the slow issuer doesn't block the validation of other tokens.
+func (v *validator) keySet(ctx context.Context, iss Issuer, kid string) (*jose.JSONWebKeySet, error) {
+	v.mu.Lock()
+	ks, ok := v.keys[iss.URL]
+	v.mu.Unlock()
+
+	age := time.Since(ks.fetchedAt)
+	switch {
+	case !ok, age > v.refresh:
+	case kid != "" && len(ks.keys.Key(kid)) == 0 && age > minRefresh:
+	default:
+		return ks.keys, nil
+	}
+
+	res, err, _ := v.fetches.Do(iss.URL, func() (interface{}, error) {
+		keys, err := v.fetch(ctx, iss.URL)
+		if err != nil {
+			return nil, err
+		}
+
+		v.mu.Lock()
+		v.keys[iss.URL] = keySet{keys: keys, fetchedAt: time.Now()}
+		v.mu.Unlock()
+
+		return keys, nil
+	})
+	if err != nil {
+		if ok {
+			// Keep using the previous key set while the issuer is unavailable.
+			return ks.keys, nil
+		}
+		return nil, err
+	}
+
+	return res.(*jose.JSONWebKeySet), nil
+}
+
+func (v *validator) fetch(ctx context.Context, url string) (*jose.JSONWebKeySet, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, errors.Wrap(ErrFetchKeys, err)
+	}
+
+	res, err := v.client.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(ErrFetchKeys, err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, errors.Wrap(ErrFetchKeys, fmt.Errorf("unexpected status code %d", res.StatusCode))
+	}
+
+	var jwks jose.JSONWebKeySet
+	if err := json.NewDecoder(res.Body).Decode(&jwks); err != nil {
+		return nil, errors.Wrap(ErrFetchKeys, err)
+	}
+
+	// Only the public keys are used, so the tokens can't be forged using
+	// the symmetric keys published by mistake.
+	keys := &jose.JSONWebKeySet{}
+	for _, k := range jwks.Keys {
+		if k.IsPublic() {
+			keys.Keys = append(keys.Keys, k)
+		}
+	}
+
+	return keys, nil
+}
@@ -0,0 +1,225 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package jwks_test
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/jwks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	jose "gopkg.in/square/go-jose.v2"
+	"gopkg.in/square/go-jose.v2/jwt"
+)
+
+const (
+	issuer   = "https://pki.example.com"
+	audience = "mainflux"
+	owner    = "574106f7-030e-4881-8ab0-151195c29f94"
+	keyID    = "key-1"
+	thingID  = "device-1"
+)
+
+func newSigner(t *testing.T, key *rsa.PrivateKey, kid string) jose.Signer {
+	opts := (&jose.SignerOptions{}).WithType("JWT").WithHeader("kid", kid)
+	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.RS256, Key: key}, opts)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	return signer
+}
+
+func issue(t *testing.T, signer jose.Signer, iss, sub, aud string, exp time.Time) string {
+	claims := jwt.Claims{
+		Issuer:   iss,
+		Subject:  sub,
+		IssuedAt: jwt.NewNumericDate(time.Now()),
+	}
+	if aud != "" {
+		claims.Audience = jwt.Audience{aud}
+	}
+	if !exp.IsZero() {
+		claims.Expiry = jwt.NewNumericDate(exp)
+	}
+	token, err := jwt.Signed(signer).Claims(claims).CompactSerialize()
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	return token
+}
+
+func TestValidate(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	keySet := jose.JSONWebKeySet{
+		Keys: []jose.JSONWebKey{{Key: key.Public(), KeyID: keyID, Algorithm: string(jose.RS256), Use: "sig"}},
+	}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(keySet)
+	}))
+	defer ts.Close()
+
+	issuers := []jwks.Issuer{{Issuer: issuer, URL: ts.URL, Owner: owner, Audience: audience, Claim: "sub"}}
+	v := jwks.NewValidator(issuers, ts.Client(), time.Hour)
+
+	signer := newSigner(t, key, keyID)
+	exp := time.Now().Add(time.Hour)
+
+	cases := map[string]struct {
+		token string
+		owner string
+		extID string
+		err   error
+	}{
+		"validate valid token": {
+			token: issue(t, signer, issuer, thingID, audience, exp),
+			owner: owner,
+			extID: thingID,
+			err:   nil,
+		},
+		"validate expired token": {
+			token: issue(t, signer, issuer, thingID, audience, time.Now().Add(-time.Hour)),
+			err:   jwks.ErrInvalidToken,
+		},
+		"validate token without expiration": {
+			token: issue(t, signer, issuer, thingID, audience, time.Time{}),
+			err:   jwks.ErrInvalidToken,
+		},
+		"validate token issued to other audience": {
+			token: issue(t, signer, issuer, thingID, "other", exp),
+			err:   jwks.ErrInvalidToken,
+		},
+		"validate token without audience": {
+			token: issue(t, signer, issuer, thingID, "", exp),
+			err:   jwks.ErrInvalidToken,
+		},
+		"validate token of unknown issuer": {
+			token: issue(t, signer, "https://unknown.example.com", thingID, audience, exp),
+			err:   jwks.ErrUnknownIssuer,
+		},
+		"validate token signed with unknown key": {
+			token: issue(t, newSigner(t, otherKey, keyID), issuer, thingID, audience, exp),
+			err:   jwks.ErrInvalidToken,
+		},
+		"validate token without identity claim": {
+			token: issue(t, signer, issuer, "", audience, exp),
+			err:   jwks.ErrMissingClaim,
+		},
+		"validate malformed token": {
+			token: "invalid.token.value",
+			err:   jwks.ErrInvalidToken,
+		},
+	}
+
+	for desc, tc := range cases {
+		ow, extID, err := v.Validate(context.Background(), tc.token)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", desc, tc.err, err))
+		assert.Equal(t, tc.owner, ow, fmt.Sprintf("%s: expected owner %s got %s\n", desc, tc.owner, ow))
+		assert.Equal(t, tc.extID, extID, fmt.Sprintf("%s: expected external ID %s got %s\n", desc, tc.extID, extID))
+	}
+}
+
+func TestValidateSlowIssuer(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	keySet := jose.JSONWebKeySet{
+		Keys: []jose.JSONWebKey{{Key: key.Public(), KeyID: keyID, Algorithm: string(jose.RS256), Use: "sig"}},
+	}
+
+	var fetches int32
+	requested := make(chan struct{}, 1)
+	release := make(chan struct{})
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&fetches, 1)
+		requested <- struct{}{}
+		<-release
+		json.NewEncoder(w).Encode(keySet)
+	}))
+	defer slow.Close()
+	fast := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(keySet)
+	}))
+	defer fast.Close()
+
+	slowIssuer := "https://slow.example.com"
+	issuers := []jwks.Issuer{
+		{Issuer: slowIssuer, URL: slow.URL, Owner: owner, Audience: audience, Claim: "sub"},
+		{Issuer: issuer, URL: fast.URL, Owner: owner, Audience: audience, Claim: "sub"},
+	}
+	v := jwks.NewValidator(issuers, http.DefaultClient, time.Hour)
+
+	signer := newSigner(t, key, keyID)
+	exp := time.Now().Add(time.Hour)
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 3)
+	for i := 0; i < cap(errs); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, _, err := v.Validate(context.Background(), issue(t, signer, slowIssuer, thingID, audience, exp))
+			errs <- err
+		}()
+	}
+	<-requested
+
+	done := make(chan error)
+	go func() {
+		_, _, err := v.Validate(context.Background(), issue(t, signer, issuer, thingID, audience, exp))
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		assert.Nil(t, err, fmt.Sprintf("validate token of available issuer: unexpected error: %s", err))
+	case <-time.After(time.Second):
+		t.Error("validate token of available issuer: blocked by the fetch of the slow issuer key set")
+	}
+
+	close(release)
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		assert.Nil(t, err, fmt.Sprintf("validate token of slow issuer: unexpected error: %s", err))
+	}
+	assert.Equal(t, int32(1), atomic.LoadInt32(&fetches), "expected concurrent fetches of the key set to be merged")
+}
+
+func TestLoadIssuers(t *testing.T) {
+	dir := t.TempDir()
+
+	valid := filepath.Join(dir, "valid.toml")
+	data := []byte(`
+[[issuers]]
+issuer = "https://pki.example.com"
+jwks_url = "https://pki.example.com/.well-known/jwks.json"
+owner = "574106f7-030e-4881-8ab0-151195c29f94"
+audience = "mainflux"
+`)
+	require.Nil(t, os.WriteFile(valid, data, 0644))
+
+	invalid := filepath.Join(dir, "invalid.toml")
+	require.Nil(t, os.WriteFile(invalid, []byte("[[issuers]]\nissuer = \"https://pki.example.com\"\n"), 0644))
+
+	issuers, err := jwks.LoadIssuers(valid)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	require.Len(t, issuers, 1)
+	assert.Equal(t, "sub", issuers[0].Claim, fmt.Sprintf("expected default claim sub got %s", issuers[0].Claim))
+
+	_, err = jwks.LoadIssuers(invalid)
+	assert.NotNil(t, err, "expected error loading issuer without JWKS URL, owner and audience")
+}
@@ -0,0 +1,64 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package ksuid provides a KSUID identity provider.
+package ksuid
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"math/big"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+)
+
+const (
+	// epoch is the KSUID epoch, i.e. the Unix time of 2014-05-13T16:53:20Z,
+	// which extends the range of the 32-bit timestamp to year 2150.
+	epoch = 1400000000
+
+	timestampLen = 4
+	payloadLen   = 16
+	encodedLen   = 27
+	alphabet     = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+)
+
+// ErrGeneratingID indicates error in generating KSUID
+var ErrGeneratingID = errors.New("generating id failed")
+
+var _ mainflux.IDProvider = (*ksuidProvider)(nil)
+
+type ksuidProvider struct{}
+
+// New instantiates a KSUID provider.
+func New() mainflux.IDProvider {
+	return &ksuidProvider{}
+}
+
+func (kp *ksuidProvider) ID() (string, error) {
+	var id [timestampLen + payloadLen]byte
+	binary.BigEndian.PutUint32(id[:timestampLen], uint32(time.Now().Unix()-epoch))
+	if _, err := rand.Read(id[timestampLen:]); err != nil {
+		return "", errors.Wrap(ErrGeneratingID, err)
+	}
+
+	return encode(id[:]), nil
+}
+
+// encode returns the base62 encoding of the ID, left padded with zeros to the
+// fixed length, so that the encoded IDs sort in the order of their timestamps.
+func encode(id []byte) string {
+	n := new(big.Int).SetBytes(id)
+	base := big.NewInt(int64(len(alphabet)))
+	rem := new(big.Int)
+
+	enc := make([]byte, encodedLen)
+	for i := encodedLen - 1; i >= 0; i-- {
+		n.DivMod(n, base, rem)
+		enc[i] = alphabet[rem.Int64()]
+	}
+
+	return string(enc)
+}
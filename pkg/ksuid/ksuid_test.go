@@ -0,0 +1,65 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package ksuid_test
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/pkg/ksuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	count    = 3
+	epoch    = 1400000000
+	alphabet = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+)
+
+func parse(id string) (time.Time, error) {
+	if len(id) != 27 {
+		return time.Time{}, fmt.Errorf("invalid KSUID length %d", len(id))
+	}
+
+	n := new(big.Int)
+	base := big.NewInt(int64(len(alphabet)))
+	for _, c := range id {
+		i := strings.IndexRune(alphabet, c)
+		if i < 0 {
+			return time.Time{}, fmt.Errorf("invalid KSUID character %c", c)
+		}
+		n.Mul(n, base)
+		n.Add(n, big.NewInt(int64(i)))
+	}
+
+	// Timestamp is stored in the most significant 32 bits of the 160-bit ID.
+	ts := new(big.Int).Rsh(n, 128).Int64()
+	return time.Unix(ts+epoch, 0), nil
+}
+
+func TestID(t *testing.T) {
+	idp := ksuid.New()
+
+	var prev string
+	for i := 0; i < count; i++ {
+		before := time.Now().Truncate(time.Second)
+		id, err := idp.ID()
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+		created, err := parse(id)
+		require.Nil(t, err, fmt.Sprintf("invalid ID %s: %s", id, err))
+		assert.False(t, created.Before(before), fmt.Sprintf("expected ID time after %s got %s", before, created))
+		assert.False(t, created.After(time.Now()), fmt.Sprintf("expected ID time before now got %s", created))
+
+		assert.Less(t, prev, id, fmt.Sprintf("expected ID %s to sort after %s", id, prev))
+		prev = id
+
+		// IDs are ordered only across seconds.
+		time.Sleep(1100 * time.Millisecond)
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package brokers selects the message broker implementation by the scheme
+// of the message broker URL. All the supported brokers are linked into the
+// binaries and register themselves in the messaging broker registry, so
+// services switch the broker only by changing the broker URL.
+package brokers
+
+import (
+	"errors"
+
+	"github.com/MainfluxLabs/mainflux/logger"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging/nats"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging/partition"
+	// RabbitMQ registers itself for the amqp URL schemes.
+	_ "github.com/MainfluxLabs/mainflux/pkg/messaging/rabbitmq"
+)
+
+// SubjectAllChannels represents subject to subscribe for all the channels.
+const SubjectAllChannels = "channels.>"
+
+var (
+	errJetStreamNotSupported = errors.New("JetStream is supported only by NATS message broker")
+	errPartitionNotSupported = errors.New("partitioning is supported only by NATS message broker")
+)
+
+// JetStreamConfig represents the configuration of JetStream subscriptions.
+type JetStreamConfig = nats.JetStreamConfig
+
+// NewPublisher returns the publisher of the message broker selected by the
+// URL scheme.
+func NewPublisher(url string) (messaging.Publisher, error) {
+	return messaging.NewPublisher(url)
+}
+
+// NewPubSub returns the publisher/subscriber of the message broker
+// selected by the URL scheme.
+func NewPubSub(url, queue string, logger logger.Logger) (messaging.PubSub, error) {
+	return messaging.NewPubSub(url, queue, logger)
+}
+
+// NewJetStreamPubSub returns message publisher/subscriber which provides
+// at-least-once delivery using NATS JetStream durable consumers. Brokers
+// other than NATS are not supported.
+func NewJetStreamPubSub(url, queue string, cfg JetStreamConfig, logger logger.Logger) (messaging.PubSub, error) {
+	scheme, err := messaging.Scheme(url)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, s := range nats.Schemes {
+		if s == scheme {
+			return nats.NewJetStreamPubSub(url, queue, cfg, logger)
+		}
+	}
+
+	return nil, errJetStreamNotSupported
+}
+
+// NewMembership joins the member of the partitioner to the partitioning
+// group, rebalancing the partitioner on the membership changes and
+// forwarding the messages among the members. Brokers other than NATS are
+// not supported.
+func NewMembership(url string, cfg partition.Config, p *partition.Partitioner, logger logger.Logger) (partition.Membership, error) {
+	scheme, err := messaging.Scheme(url)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, s := range nats.Schemes {
+		if s == scheme {
+			return nats.NewMembership(url, cfg, p, logger)
+		}
+	}
+
+	return nil, errPartitionNotSupported
+}
@@ -0,0 +1,7 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package faults contains the publisher and subscriber middleware injecting
+// latency, dropped messages and broker disconnects, used to test how the
+// adapters and the consumers handle the message broker failures.
+package faults
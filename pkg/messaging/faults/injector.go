@@ -0,0 +1,95 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package faults
+
+import (
+	"errors"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// ErrDisconnected indicates that the connection to the message broker
+// is down.
+var ErrDisconnected = errors.New("message broker disconnected")
+
+// Config represents the faults injected into the published and the
+// received messages.
+type Config struct {
+	// Latency is the delay added to every published and received message.
+	Latency time.Duration
+
+	// DropRate is the probability, between 0 and 1, that the message is lost.
+	DropRate float64
+
+	// Seed seeds the random drops, so that the test runs are reproducible.
+	Seed int64
+}
+
+// Injector decides which faults are injected. It is safe for concurrent
+// use, and its faults can be changed while the messages flow, so tests can
+// script the broker outages.
+type Injector struct {
+	mu           sync.Mutex
+	cfg          Config
+	rand         *rand.Rand
+	disconnected bool
+}
+
+// NewInjector returns the fault injector using the given config.
+func NewInjector(cfg Config) *Injector {
+	return &Injector{
+		cfg:  cfg,
+		rand: rand.New(rand.NewSource(cfg.Seed)),
+	}
+}
+
+// Set replaces the latency and the drop rate of the injector.
+func (i *Injector) Set(latency time.Duration, dropRate float64) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	i.cfg.Latency = latency
+	i.cfg.DropRate = dropRate
+}
+
+// Disconnect simulates the lost broker connection. Publishing and
+// subscribing fail with ErrDisconnected, and the received messages are
+// lost until Reconnect is called.
+func (i *Injector) Disconnect() {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	i.disconnected = true
+}
+
+// Reconnect restores the broker connection.
+func (i *Injector) Reconnect() {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	i.disconnected = false
+}
+
+// Connected returns whether the broker connection is up.
+func (i *Injector) Connected() bool {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	return !i.disconnected
+}
+
+// inject waits for the latency and returns whether the message is dropped.
+func (i *Injector) inject() (drop bool) {
+	i.mu.Lock()
+	latency := i.cfg.Latency
+	drop = i.cfg.DropRate > 0 && i.rand.Float64() < i.cfg.DropRate
+	i.mu.Unlock()
+
+	if latency > 0 {
+		time.Sleep(latency)
+	}
+
+	return drop
+}
@@ -0,0 +1,137 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package faults
+
+import (
+	"context"
+
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+)
+
+var (
+	_ messaging.Publisher = (*publisher)(nil)
+	_ messaging.PubSub    = (*pubsub)(nil)
+)
+
+type publisher struct {
+	messaging.Publisher
+	inj *Injector
+}
+
+// NewPublisher returns the publisher injecting the faults of the injector
+// into the published messages. Dropped messages are reported as published,
+// the same as the messages the broker loses.
+func NewPublisher(pub messaging.Publisher, inj *Injector) messaging.Publisher {
+	return &publisher{
+		Publisher: pub,
+		inj:       inj,
+	}
+}
+
+func (pub *publisher) Publish(topic string, msg messaging.Message) error {
+	if !pub.inj.Connected() {
+		return ErrDisconnected
+	}
+	if pub.inj.inject() {
+		return nil
+	}
+
+	return pub.Publisher.Publish(topic, msg)
+}
+
+// PublishConfirmed publishes the message using the underlying publisher.
+// Dropped messages are reported as not confirmed.
+func (pub *publisher) PublishConfirmed(ctx context.Context, topic string, msg messaging.Message) error {
+	if !pub.inj.Connected() {
+		return ErrDisconnected
+	}
+	if pub.inj.inject() {
+		return messaging.ErrNotConfirmed
+	}
+
+	return messaging.PublishConfirmed(ctx, pub.Publisher, topic, msg)
+}
+
+// Ping fails while the broker is disconnected, and checks the connection of
+// the underlying publisher otherwise, if supported.
+func (pub *publisher) Ping(ctx context.Context) error {
+	if !pub.inj.Connected() {
+		return ErrDisconnected
+	}
+	if pinger, ok := pub.Publisher.(messaging.Pinger); ok {
+		return pinger.Ping(ctx)
+	}
+	return nil
+}
+
+type pubsub struct {
+	*publisher
+	sub messaging.Subscriber
+}
+
+// NewPubSub returns the pubsub injecting the faults of the injector into
+// both the published and the received messages. Dropped received messages
+// are not passed to the handlers.
+func NewPubSub(ps messaging.PubSub, inj *Injector) messaging.PubSub {
+	return &pubsub{
+		publisher: &publisher{Publisher: ps, inj: inj},
+		sub:       ps,
+	}
+}
+
+func (ps *pubsub) Subscribe(id, topic string, handler messaging.MessageHandler) error {
+	if !ps.inj.Connected() {
+		return ErrDisconnected
+	}
+
+	h := &faultyHandler{MessageHandler: handler, inj: ps.inj}
+	if ah, ok := handler.(messaging.AsyncMessageHandler); ok {
+		return ps.sub.Subscribe(id, topic, &faultyAsyncHandler{faultyHandler: h, async: ah})
+	}
+
+	return ps.sub.Subscribe(id, topic, h)
+}
+
+func (ps *pubsub) Unsubscribe(id, topic string) error {
+	if !ps.inj.Connected() {
+		return ErrDisconnected
+	}
+
+	return ps.sub.Unsubscribe(id, topic)
+}
+
+type faultyHandler struct {
+	messaging.MessageHandler
+	inj *Injector
+}
+
+func (h *faultyHandler) Handle(msg messaging.Message) error {
+	if h.lost() {
+		return nil
+	}
+
+	return h.MessageHandler.Handle(msg)
+}
+
+// lost injects the faults and returns whether the message is lost.
+func (h *faultyHandler) lost() bool {
+	if !h.inj.Connected() {
+		return true
+	}
+	return h.inj.inject()
+}
+
+type faultyAsyncHandler struct {
+	*faultyHandler
+	async messaging.AsyncMessageHandler
+}
+
+func (h *faultyAsyncHandler) HandleAsync(msg messaging.Message, done func(error)) {
+	if h.lost() {
+		done(nil)
+		return
+	}
+
+	h.async.HandleAsync(msg, done)
+}
@@ -0,0 +1,126 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package faults_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging/faults"
+	"github.com/MainfluxLabs/mainflux/pkg/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	topic    = "channels.1"
+	subTopic = "channels.>"
+	msgCount = 1000
+)
+
+var errBroker = errors.New("broker error")
+
+type handler struct {
+	msgs []messaging.Message
+}
+
+func (h *handler) Handle(msg messaging.Message) error {
+	h.msgs = append(h.msgs, msg)
+	return nil
+}
+
+func (h *handler) Cancel() error {
+	return nil
+}
+
+func TestPublish(t *testing.T) {
+	cases := []struct {
+		desc       string
+		dropRate   float64
+		disconnect bool
+		mockErr    error
+		err        error
+		published  int
+	}{
+		{
+			desc:      "publish message",
+			published: 1,
+		},
+		{
+			desc:      "publish dropped message",
+			dropRate:  1,
+			published: 0,
+		},
+		{
+			desc:       "publish message while disconnected",
+			disconnect: true,
+			err:        faults.ErrDisconnected,
+			published:  0,
+		},
+		{
+			desc:      "publish message with broker error",
+			mockErr:   errBroker,
+			err:       errBroker,
+			published: 0,
+		},
+	}
+
+	for _, tc := range cases {
+		ps := mocks.NewPubSub()
+		ps.FailPublish(tc.mockErr)
+		inj := faults.NewInjector(faults.Config{DropRate: tc.dropRate})
+		if tc.disconnect {
+			inj.Disconnect()
+		}
+
+		err := faults.NewPublisher(ps, inj).Publish(topic, messaging.Message{Channel: "1"})
+		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.err, err))
+		assert.Len(t, ps.Published(), tc.published, fmt.Sprintf("%s: expected %d published messages", tc.desc, tc.published))
+	}
+}
+
+func TestPublishLatency(t *testing.T) {
+	latency := 20 * time.Millisecond
+	inj := faults.NewInjector(faults.Config{Latency: latency})
+	pub := faults.NewPublisher(mocks.NewPubSub(), inj)
+
+	start := time.Now()
+	err := pub.Publish(topic, messaging.Message{Channel: "1"})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.GreaterOrEqual(t, int64(time.Since(start)), int64(latency), "expected publish to be delayed")
+}
+
+func TestSubscribe(t *testing.T) {
+	ps := mocks.NewPubSub()
+	inj := faults.NewInjector(faults.Config{DropRate: 0.5, Seed: 1})
+	fps := faults.NewPubSub(ps, inj)
+
+	h := &handler{}
+	err := fps.Subscribe("writer", subTopic, h)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	for i := 0; i < msgCount; i++ {
+		err := ps.Publish(topic, messaging.Message{Channel: "1"})
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	}
+	assert.InDelta(t, msgCount/2, len(h.msgs), msgCount/10, fmt.Sprintf("expected about half of %d messages handled got %d", msgCount, len(h.msgs)))
+
+	inj.Set(0, 0)
+	inj.Disconnect()
+	handled := len(h.msgs)
+	err = ps.Publish(topic, messaging.Message{Channel: "1"})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.Len(t, h.msgs, handled, "expected message received while disconnected to be lost")
+
+	err = fps.Subscribe("writer", subTopic, h)
+	assert.Equal(t, faults.ErrDisconnected, err, fmt.Sprintf("expected %s got %s", faults.ErrDisconnected, err))
+
+	inj.Reconnect()
+	err = ps.Publish(topic, messaging.Message{Channel: "1"})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.Len(t, h.msgs, handled+1, "expected message received after reconnect to be handled")
+}
@@ -0,0 +1,18 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package messaging
+
+import "github.com/gofrs/uuid"
+
+// NewID returns the new message ID. Adapters assign the ID to the messages on
+// ingress, so that the consumers recognize the messages redelivered by the
+// message broker and store them only once.
+func NewID() (string, error) {
+	id, err := uuid.NewV4()
+	if err != nil {
+		return "", err
+	}
+
+	return id.String(), nil
+}
@@ -0,0 +1,126 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mqtt
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+	mqtt "github.com/eclipse/paho.mqtt.golang"
+	"github.com/gogo/protobuf/proto"
+)
+
+var (
+	_ messaging.Publisher = (*poolPublisher)(nil)
+	_ messaging.Pinger    = (*poolPublisher)(nil)
+)
+
+// PoolConfig represents the configuration of the pooled MQTT publisher.
+type PoolConfig struct {
+	// Clients is the number of the MQTT client connections the messages
+	// are published over.
+	Clients int
+	// InFlight is the maximum number of the published messages which are
+	// not acknowledged by the MQTT broker yet. If zero, publishing waits
+	// for the acknowledgement of each message.
+	InFlight int
+}
+
+// CompletionFunc is called with the result of publishing of the message,
+// once the MQTT broker acknowledges the message or publishing fails.
+type CompletionFunc func(topic string, msg messaging.Message, err error)
+
+type poolPublisher struct {
+	clients  []mqtt.Client
+	next     uint64
+	timeout  time.Duration
+	inFlight chan struct{}
+	done     CompletionFunc
+	wg       sync.WaitGroup
+}
+
+// NewPoolPublisher returns the MQTT message publisher publishing the
+// messages over the pool of MQTT clients in turn. If the in-flight window
+// is configured, Publish returns as soon as the message is sent, blocking
+// only while the window is full, and the completion function, if any, is
+// called once the MQTT broker acknowledges the message.
+func NewPoolPublisher(address string, timeout time.Duration, cfg PoolConfig, done CompletionFunc) (messaging.Publisher, error) {
+	n := cfg.Clients
+	if n < 1 {
+		n = 1
+	}
+
+	pub := &poolPublisher{
+		timeout: timeout,
+		done:    done,
+	}
+	if cfg.InFlight > 0 {
+		pub.inFlight = make(chan struct{}, cfg.InFlight)
+	}
+
+	for i := 0; i < n; i++ {
+		client, err := newClient(address, fmt.Sprintf("mqtt-publisher-%d", i), timeout)
+		if err != nil {
+			pub.Close()
+			return nil, err
+		}
+		pub.clients = append(pub.clients, client)
+	}
+
+	return pub, nil
+}
+
+func (pub *poolPublisher) Publish(topic string, msg messaging.Message) error {
+	if topic == "" {
+		return ErrEmptyTopic
+	}
+	data, err := proto.Marshal(&msg)
+	if err != nil {
+		return err
+	}
+
+	client := pub.clients[atomic.AddUint64(&pub.next, 1)%uint64(len(pub.clients))]
+	if pub.inFlight == nil {
+		return wait(client.Publish(topic, qos, false, data), pub.timeout)
+	}
+
+	pub.inFlight <- struct{}{}
+	token := client.Publish(topic, qos, false, data)
+	pub.wg.Add(1)
+	go func() {
+		defer pub.wg.Done()
+		err := wait(token, pub.timeout)
+		<-pub.inFlight
+		if pub.done != nil {
+			pub.done(topic, msg, err)
+		}
+	}()
+
+	return nil
+}
+
+func (pub *poolPublisher) Ping(_ context.Context) error {
+	for _, c := range pub.clients {
+		if !c.IsConnectionOpen() {
+			return errNotConnected
+		}
+	}
+
+	return nil
+}
+
+// Close waits for the in-flight messages to be acknowledged and closes the
+// connections of the pool.
+func (pub *poolPublisher) Close() error {
+	pub.wg.Wait()
+	for _, c := range pub.clients {
+		c.Disconnect(uint(pub.timeout))
+	}
+
+	return nil
+}
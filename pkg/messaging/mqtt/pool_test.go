@@ -0,0 +1,95 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mqtt_test
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+	mqtt_pubsub "github.com/MainfluxLabs/mainflux/pkg/messaging/mqtt"
+	mqtt "github.com/eclipse/paho.mqtt.golang"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	poolTopic    = "pool"
+	poolMessages = 50
+)
+
+func TestPoolPublisher(t *testing.T) {
+	received := make(chan []byte, poolMessages)
+	client, err := newClient(address, "clientID-pool", brokerTimeout)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	token := client.Subscribe(poolTopic, qos, func(c mqtt.Client, m mqtt.Message) {
+		received <- m.Payload()
+	})
+	if ok := token.WaitTimeout(tokenTimeout); !ok {
+		assert.Fail(t, fmt.Sprintf("failed to subscribe to topic %s", poolTopic))
+	}
+	require.Nil(t, token.Error(), fmt.Sprintf("got unexpected error: %s", token.Error()))
+	t.Cleanup(func() {
+		client.Unsubscribe(poolTopic).WaitTimeout(tokenTimeout)
+		client.Disconnect(100)
+	})
+
+	cases := []struct {
+		desc string
+		cfg  mqtt_pubsub.PoolConfig
+	}{
+		{
+			desc: "publish messages synchronously over pool",
+			cfg:  mqtt_pubsub.PoolConfig{Clients: 3},
+		},
+		{
+			desc: "publish messages asynchronously over pool",
+			cfg:  mqtt_pubsub.PoolConfig{Clients: 3, InFlight: 10},
+		},
+	}
+
+	for _, tc := range cases {
+		var mu sync.Mutex
+		var completed []error
+		done := func(topic string, msg messaging.Message, err error) {
+			mu.Lock()
+			defer mu.Unlock()
+			completed = append(completed, err)
+		}
+
+		pub, err := mqtt_pubsub.NewPoolPublisher(address, brokerTimeout, tc.cfg, done)
+		require.Nil(t, err, fmt.Sprintf("%s: got unexpected error: %s", tc.desc, err))
+
+		err = pub.Publish("", messaging.Message{Payload: data})
+		assert.Equal(t, mqtt_pubsub.ErrEmptyTopic, err, fmt.Sprintf("%s: expected %s got %s", tc.desc, mqtt_pubsub.ErrEmptyTopic, err))
+
+		for i := 0; i < poolMessages; i++ {
+			err := pub.Publish(poolTopic, messaging.Message{Payload: data})
+			assert.Nil(t, err, fmt.Sprintf("%s: got unexpected error: %s", tc.desc, err))
+		}
+
+		for i := 0; i < poolMessages; i++ {
+			select {
+			case <-received:
+			case <-time.After(brokerTimeout):
+				t.Fatalf("%s: received %d of %d messages", tc.desc, i, poolMessages)
+			}
+		}
+
+		err = pub.Close()
+		assert.Nil(t, err, fmt.Sprintf("%s: got unexpected error: %s", tc.desc, err))
+
+		mu.Lock()
+		if tc.cfg.InFlight > 0 {
+			assert.Len(t, completed, poolMessages, fmt.Sprintf("%s: expected completion of each message", tc.desc))
+			for _, err := range completed {
+				assert.Nil(t, err, fmt.Sprintf("%s: got unexpected error: %s", tc.desc, err))
+			}
+		}
+		mu.Unlock()
+	}
+}
@@ -4,6 +4,7 @@
 package mqtt
 
 import (
+	"context"
 	"errors"
 	"time"
 
@@ -12,13 +13,20 @@ import (
 	"github.com/gogo/protobuf/proto"
 )
 
-var errPublishTimeout = errors.New("failed to publish due to timeout reached")
+var (
+	errPublishTimeout = errors.New("failed to publish due to timeout reached")
+	errNotConnected   = errors.New("not connected to MQTT broker")
+)
 
-var _ messaging.Publisher = (*publisher)(nil)
+var (
+	_ messaging.Publisher = (*publisher)(nil)
+	_ messaging.Pinger    = (*publisher)(nil)
+)
 
 type publisher struct {
 	client  mqtt.Client
 	timeout time.Duration
+	retain  bool
 }
 
 // NewPublisher returns a new MQTT message publisher.
@@ -35,6 +43,23 @@ func NewPublisher(address string, timeout time.Duration) (messaging.Publisher, e
 	return ret, nil
 }
 
+// NewRetainedPublisher returns a new MQTT message publisher publishing the
+// messages as retained, so that the MQTT broker keeps the last message of
+// each topic and delivers it to the newly subscribed clients.
+func NewRetainedPublisher(address string, timeout time.Duration) (messaging.Publisher, error) {
+	client, err := newClient(address, "mqtt-retained-publisher", timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := publisher{
+		client:  client,
+		timeout: timeout,
+		retain:  true,
+	}
+	return ret, nil
+}
+
 func (pub publisher) Publish(topic string, msg messaging.Message) error {
 	if topic == "" {
 		return ErrEmptyTopic
@@ -43,19 +68,32 @@ func (pub publisher) Publish(topic string, msg messaging.Message) error {
 	if err != nil {
 		return err
 	}
-	token := pub.client.Publish(topic, qos, false, data)
-	if token.Error() != nil {
-		return token.Error()
-	}
-	ok := token.WaitTimeout(pub.timeout)
-	if !ok {
-		return errPublishTimeout
+	token := pub.client.Publish(topic, qos, pub.retain, data)
+
+	return wait(token, pub.timeout)
+}
+
+func (pub publisher) Ping(_ context.Context) error {
+	if !pub.client.IsConnectionOpen() {
+		return errNotConnected
 	}
 
-	return token.Error()
+	return nil
 }
 
 func (pub publisher) Close() error {
 	pub.client.Disconnect(uint(pub.timeout))
 	return nil
 }
+
+// wait waits for the MQTT broker to acknowledge the publishing of the token.
+func wait(token mqtt.Token, timeout time.Duration) error {
+	if token.Error() != nil {
+		return token.Error()
+	}
+	if ok := token.WaitTimeout(timeout); !ok {
+		return errPublishTimeout
+	}
+
+	return token.Error()
+}
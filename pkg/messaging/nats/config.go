@@ -0,0 +1,61 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package nats
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux"
+)
+
+const (
+	defJetStreamEnabled    = "false"
+	defJetStreamStream     = "mainflux"
+	defJetStreamMaxAge     = "720h"
+	defJetStreamAckWait    = "30s"
+	defJetStreamMaxDeliver = "5"
+
+	envJetStreamEnabled    = "MF_JETSTREAM_ENABLED"
+	envJetStreamStream     = "MF_JETSTREAM_STREAM"
+	envJetStreamMaxAge     = "MF_JETSTREAM_MAX_AGE"
+	envJetStreamAckWait    = "MF_JETSTREAM_ACK_WAIT"
+	envJetStreamMaxDeliver = "MF_JETSTREAM_MAX_DELIVER"
+)
+
+// LoadJetStreamConfig reads the configuration of JetStream subscriptions
+// from the MF_JETSTREAM_* environment variables. It returns nil if
+// JetStream is disabled.
+func LoadJetStreamConfig() (*JetStreamConfig, error) {
+	enabled, err := strconv.ParseBool(mainflux.Env(envJetStreamEnabled, defJetStreamEnabled))
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s value: %s", envJetStreamEnabled, err)
+	}
+	if !enabled {
+		return nil, nil
+	}
+
+	maxAge, err := time.ParseDuration(mainflux.Env(envJetStreamMaxAge, defJetStreamMaxAge))
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s value: %s", envJetStreamMaxAge, err)
+	}
+
+	ackWait, err := time.ParseDuration(mainflux.Env(envJetStreamAckWait, defJetStreamAckWait))
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s value: %s", envJetStreamAckWait, err)
+	}
+
+	maxDeliver, err := strconv.Atoi(mainflux.Env(envJetStreamMaxDeliver, defJetStreamMaxDeliver))
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s value: %s", envJetStreamMaxDeliver, err)
+	}
+
+	return &JetStreamConfig{
+		Stream:     mainflux.Env(envJetStreamStream, defJetStreamStream),
+		MaxAge:     maxAge,
+		AckWait:    ackWait,
+		MaxDeliver: maxDeliver,
+	}, nil
+}
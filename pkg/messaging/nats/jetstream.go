@@ -0,0 +1,152 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package nats
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	log "github.com/MainfluxLabs/mainflux/logger"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+	"github.com/gogo/protobuf/proto"
+	broker "github.com/nats-io/nats.go"
+)
+
+const subjectAllChannels = "channels.>"
+
+// JetStreamConfig represents the configuration of JetStream subscriptions.
+type JetStreamConfig struct {
+	// Stream is the name of the stream persisting messages of all channels.
+	Stream string
+	// MaxAge is the maximum age of the messages kept in the stream.
+	MaxAge time.Duration
+	// AckWait is the time after which the message that is not
+	// acknowledged is redelivered.
+	AckWait time.Duration
+	// MaxDeliver is the maximum number of the message deliveries.
+	// Negative value allows unlimited redeliveries.
+	MaxDeliver int
+}
+
+// NewJetStreamPubSub returns NATS message publisher/subscriber which
+// provides at-least-once delivery using JetStream durable consumers.
+// Messages are acknowledged once they are successfully handled, while
+// messages whose handling failed are redelivered. The stream persisting
+// messages of all channels is created unless it already exists.
+// Parameter queue has the same meaning as in NewPubSub.
+func NewJetStreamPubSub(url, queue string, cfg JetStreamConfig, logger log.Logger) (messaging.PubSub, error) {
+	conn, err := broker.Connect(url, broker.MaxReconnects(maxReconnects))
+	if err != nil {
+		return nil, err
+	}
+
+	js, err := conn.JetStream()
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	if err := CreateStream(js, cfg.Stream, cfg.MaxAge); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	ret := &pubsub{
+		publisher: publisher{
+			conn: conn,
+		},
+		js:            js,
+		jsConfig:      cfg,
+		queue:         queue,
+		logger:        logger,
+		subscriptions: make(map[string]map[string]subscription),
+	}
+	return ret, nil
+}
+
+// CreateStream creates the JetStream stream persisting messages of all
+// channels, unless the stream already exists. Messages older than maxAge
+// are discarded from the stream.
+func CreateStream(js broker.JetStreamContext, name string, maxAge time.Duration) error {
+	_, err := js.StreamInfo(name)
+	switch err {
+	case nil:
+		return nil
+	case broker.ErrStreamNotFound:
+		_, err := js.AddStream(&broker.StreamConfig{
+			Name:     name,
+			Subjects: []string{subjectAllChannels},
+			MaxAge:   maxAge,
+			Storage:  broker.FileStorage,
+		})
+		return err
+	default:
+		return err
+	}
+}
+
+func (ps *pubsub) jsSubscribe(id, topic string, h messaging.MessageHandler) (*broker.Subscription, error) {
+	opts := []broker.SubOpt{
+		broker.BindStream(ps.jsConfig.Stream),
+		broker.Durable(durableName(id, topic)),
+		broker.DeliverNew(),
+		broker.ManualAck(),
+		broker.AckWait(ps.jsConfig.AckWait),
+		broker.MaxDeliver(ps.jsConfig.MaxDeliver),
+	}
+
+	nh := ps.jsHandler(h)
+	if ps.queue != "" {
+		return ps.js.QueueSubscribe(topic, ps.queue, nh, opts...)
+	}
+
+	return ps.js.Subscribe(topic, nh, opts...)
+}
+
+func (ps *pubsub) jsHandler(h messaging.MessageHandler) broker.MsgHandler {
+	return func(m *broker.Msg) {
+		var msg messaging.Message
+		if err := proto.Unmarshal(m.Data, &msg); err != nil {
+			ps.logger.Warn(fmt.Sprintf("Failed to unmarshal received message: %s", err))
+			// Malformed message can't be handled, so there is no point in redelivering it.
+			if err := m.Ack(); err != nil {
+				ps.logger.Warn(fmt.Sprintf("Failed to acknowledge message: %s", err))
+			}
+			return
+		}
+
+		if ah, ok := h.(messaging.AsyncMessageHandler); ok {
+			ah.HandleAsync(msg, func(err error) {
+				ps.ack(m, err)
+			})
+			return
+		}
+
+		ps.ack(m, h.Handle(msg))
+	}
+}
+
+// ack acknowledges the handled message, or negatively acknowledges it if
+// handling failed, so that it is redelivered.
+func (ps *pubsub) ack(m *broker.Msg, err error) {
+	if err != nil {
+		ps.logger.Warn(fmt.Sprintf("Failed to handle Mainflux message: %s", err))
+		if err := m.Nak(); err != nil {
+			ps.logger.Warn(fmt.Sprintf("Failed to negatively acknowledge message: %s", err))
+		}
+		return
+	}
+
+	if err := m.Ack(); err != nil {
+		ps.logger.Warn(fmt.Sprintf("Failed to acknowledge message: %s", err))
+	}
+}
+
+// durableName returns the consumer name unique for the subscriber ID
+// and topic. Durable names must not contain wildcards and dots.
+func durableName(id, topic string) string {
+	r := strings.NewReplacer(".", "_", "*", "any", ">", "all")
+	return r.Replace(fmt.Sprintf("%s_%s", id, topic))
+}
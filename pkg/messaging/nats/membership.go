@@ -0,0 +1,281 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package nats
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	log "github.com/MainfluxLabs/mainflux/logger"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging/partition"
+	"github.com/gogo/protobuf/proto"
+	broker "github.com/nats-io/nats.go"
+)
+
+const (
+	partitionPrefix = "partition"
+	// discoveryWait is the time the new member waits for the heartbeats of
+	// the existing members before taking its share of the channels.
+	discoveryWait = time.Second
+	// forwardTimeout is the time the member waits for the owner of the
+	// channel to handle the forwarded message.
+	forwardTimeout = 10 * time.Second
+	// subscriptionHeader carries the subscription of the forwarded message.
+	subscriptionHeader = "Mf-Subscription"
+)
+
+// ErrNotServed indicates that the member doesn't handle the messages
+// forwarded to the subscription.
+var ErrNotServed = errors.New("subscription not served by the member")
+
+var _ partition.Membership = (*membership)(nil)
+
+type membership struct {
+	conn        *broker.Conn
+	cfg         partition.Config
+	partitioner *partition.Partitioner
+	logger      log.Logger
+	mu          sync.Mutex
+	seen        map[string]time.Time
+	handlers    map[string]messaging.MessageHandler
+	done        chan struct{}
+	wg          sync.WaitGroup
+}
+
+// NewMembership joins the member of the partitioner to the group of the
+// configuration. Members announce themselves by the heartbeats published to
+// the group subject, and the partitioner is rebalanced whenever a member
+// joins, leaves, or stops sending the heartbeats for longer than TTL. The
+// partitioner is first rebalanced once the existing members are discovered.
+// Messages are forwarded to the members by the requests published to the
+// member subject. Closing the membership leaves the group.
+func NewMembership(url string, cfg partition.Config, p *partition.Partitioner, logger log.Logger) (partition.Membership, error) {
+	conn, err := broker.Connect(url, broker.MaxReconnects(maxReconnects))
+	if err != nil {
+		return nil, err
+	}
+
+	m := &membership{
+		conn:        conn,
+		cfg:         cfg,
+		partitioner: p,
+		logger:      logger,
+		seen:        make(map[string]time.Time),
+		handlers:    make(map[string]messaging.MessageHandler),
+		done:        make(chan struct{}),
+	}
+
+	if _, err := conn.Subscribe(m.subject("heartbeat"), m.handleHeartbeat); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	if _, err := conn.Subscribe(m.subject("leave"), m.handleLeave); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	if _, err := conn.Subscribe(m.subject("members."+p.Member()), m.handleForward); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	m.heartbeat()
+	time.Sleep(discoveryWait)
+	p.Rebalance(m.members())
+
+	m.wg.Add(1)
+	go m.run()
+
+	return m, nil
+}
+
+func (m *membership) Close() error {
+	close(m.done)
+	m.wg.Wait()
+
+	if err := m.conn.Publish(m.subject("leave"), []byte(m.partitioner.Member())); err != nil {
+		m.logger.Warn(fmt.Sprintf("Failed to leave partitioning group: %s", err))
+	}
+	if err := m.conn.Drain(); err != nil {
+		m.conn.Close()
+		return err
+	}
+
+	return nil
+}
+
+func (m *membership) Forward(member, subscription string, msg messaging.Message) error {
+	data, err := proto.Marshal(&msg)
+	if err != nil {
+		return err
+	}
+
+	req := broker.NewMsg(m.subject("members." + member))
+	req.Header.Set(subscriptionHeader, subscription)
+	req.Data = data
+
+	res, err := m.conn.RequestMsg(req, forwardTimeout)
+	if err != nil {
+		return fmt.Errorf("failed to forward message to member %s: %w", member, err)
+	}
+	if len(res.Data) > 0 {
+		return fmt.Errorf("member %s failed to handle message: %s", member, res.Data)
+	}
+
+	return nil
+}
+
+func (m *membership) Serve(subscription string, handler messaging.MessageHandler) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.handlers[subscription] = handler
+}
+
+func (m *membership) Stop(subscription string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.handlers, subscription)
+}
+
+// handleForward handles the message forwarded by another member and
+// responds with the error of handling it, if any. Forwarded messages are
+// handled regardless of the channel owner, so they are never forwarded
+// again.
+func (m *membership) handleForward(req *broker.Msg) {
+	m.mu.Lock()
+	h, ok := m.handlers[req.Header.Get(subscriptionHeader)]
+	m.mu.Unlock()
+
+	if !ok {
+		m.respond(req, ErrNotServed)
+		return
+	}
+
+	var msg messaging.Message
+	if err := proto.Unmarshal(req.Data, &msg); err != nil {
+		m.respond(req, err)
+		return
+	}
+
+	if ah, ok := h.(messaging.AsyncMessageHandler); ok {
+		ah.HandleAsync(msg, func(err error) {
+			m.respond(req, err)
+		})
+		return
+	}
+
+	m.respond(req, h.Handle(msg))
+}
+
+func (m *membership) respond(req *broker.Msg, err error) {
+	var data []byte
+	if err != nil {
+		data = []byte(err.Error())
+	}
+
+	if err := req.Respond(data); err != nil {
+		m.logger.Warn(fmt.Sprintf("Failed to respond to forwarded message: %s", err))
+	}
+}
+
+func (m *membership) run() {
+	defer m.wg.Done()
+
+	ticker := time.NewTicker(m.cfg.Heartbeat)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			m.heartbeat()
+			if m.expire() {
+				m.partitioner.Rebalance(m.members())
+			}
+		case <-m.done:
+			return
+		}
+	}
+}
+
+func (m *membership) heartbeat() {
+	if err := m.conn.Publish(m.subject("heartbeat"), []byte(m.partitioner.Member())); err != nil {
+		m.logger.Warn(fmt.Sprintf("Failed to publish partitioning heartbeat: %s", err))
+	}
+}
+
+func (m *membership) handleHeartbeat(msg *broker.Msg) {
+	member := string(msg.Data)
+	if member == "" || member == m.partitioner.Member() {
+		return
+	}
+
+	m.mu.Lock()
+	_, ok := m.seen[member]
+	m.seen[member] = time.Now()
+	m.mu.Unlock()
+
+	if ok {
+		return
+	}
+
+	// Announce the member to the new member right away, so it doesn't
+	// wait for the next heartbeat to discover the group.
+	m.heartbeat()
+	m.rebalance()
+}
+
+func (m *membership) handleLeave(msg *broker.Msg) {
+	m.mu.Lock()
+	_, ok := m.seen[string(msg.Data)]
+	delete(m.seen, string(msg.Data))
+	m.mu.Unlock()
+
+	if ok {
+		m.rebalance()
+	}
+}
+
+// rebalance rebalances the partitioner once the discovery is over.
+func (m *membership) rebalance() {
+	if m.partitioner.Members() != nil {
+		m.partitioner.Rebalance(m.members())
+	}
+}
+
+// expire removes the members whose heartbeats are older than TTL and
+// returns whether any member is removed.
+func (m *membership) expire() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var expired bool
+	for member, t := range m.seen {
+		if time.Since(t) > m.cfg.TTL {
+			delete(m.seen, member)
+			expired = true
+		}
+	}
+
+	return expired
+}
+
+func (m *membership) members() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	ret := make([]string, 0, len(m.seen))
+	for member := range m.seen {
+		ret = append(ret, member)
+	}
+
+	return ret
+}
+
+func (m *membership) subject(kind string) string {
+	return fmt.Sprintf("%s.%s.%s", partitionPrefix, m.cfg.Group, kind)
+}
@@ -4,6 +4,7 @@
 package nats
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/gogo/protobuf/proto"
@@ -16,7 +17,11 @@ import (
 // will never give up on retrying to re-establish connection to NATS server.
 const maxReconnects = -1
 
-var _ messaging.Publisher = (*publisher)(nil)
+var (
+	_ messaging.Publisher = (*publisher)(nil)
+	_ messaging.Pinger    = (*publisher)(nil)
+	_ messaging.Confirmer = (*publisher)(nil)
+)
 
 type publisher struct {
 	conn *broker.Conn
@@ -46,18 +51,50 @@ func (pub *publisher) Publish(topic string, msg messaging.Message) error {
 		return err
 	}
 
-	subject := fmt.Sprintf("%s.%s", chansPrefix, topic)
-	if msg.Subtopic != "" {
-		subject = fmt.Sprintf("%s.%s", subject, msg.Subtopic)
+	if err := pub.conn.Publish(subject(topic, msg), data); err != nil {
+		return err
 	}
-	if err := pub.conn.Publish(subject, data); err != nil {
+
+	return nil
+}
+
+// PublishConfirmed publishes the message to the JetStream stream and waits
+// for the stream acknowledgement. Publishing fails if there is no stream
+// persisting the messages of the channel.
+func (pub *publisher) PublishConfirmed(ctx context.Context, topic string, msg messaging.Message) error {
+	if topic == "" {
+		return ErrEmptyTopic
+	}
+	data, err := proto.Marshal(&msg)
+	if err != nil {
+		return err
+	}
+
+	js, err := pub.conn.JetStream()
+	if err != nil {
+		return err
+	}
+	if _, err := js.Publish(subject(topic, msg), data, broker.Context(ctx)); err != nil {
 		return err
 	}
 
 	return nil
 }
 
+func (pub *publisher) Ping(ctx context.Context) error {
+	return pub.conn.FlushWithContext(ctx)
+}
+
 func (pub *publisher) Close() error {
 	pub.conn.Close()
 	return nil
 }
+
+func subject(topic string, msg messaging.Message) string {
+	subject := fmt.Sprintf("%s.%s", chansPrefix, topic)
+	if msg.Subtopic != "" {
+		subject = fmt.Sprintf("%s.%s", subject, msg.Subtopic)
+	}
+
+	return subject
+}
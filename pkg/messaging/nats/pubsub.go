@@ -6,6 +6,7 @@ package nats
 import (
 	"errors"
 	"fmt"
+	"strings"
 	"sync"
 
 	"github.com/gogo/protobuf/proto"
@@ -33,6 +34,8 @@ type subscription struct {
 
 type pubsub struct {
 	publisher
+	js            broker.JetStreamContext
+	jsConfig      JetStreamConfig
 	logger        log.Logger
 	mu            sync.Mutex
 	queue         string
@@ -94,6 +97,20 @@ func (ps *pubsub) Subscribe(id, topic string, handler messaging.MessageHandler)
 		ps.subscriptions[topic] = s
 	}
 
+	// Only the channel subjects are persisted to the stream, so other
+	// subjects are subscribed to without JetStream.
+	if ps.js != nil && strings.HasPrefix(topic, chansPrefix+".") {
+		sub, err := ps.jsSubscribe(id, topic, handler)
+		if err != nil {
+			return err
+		}
+		s[id] = subscription{
+			Subscription: sub,
+			cancel:       handler.Cancel,
+		}
+		return nil
+	}
+
 	nh := ps.natsHandler(handler)
 
 	if ps.queue != "" {
@@ -0,0 +1,16 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package nats
+
+import "github.com/MainfluxLabs/mainflux/pkg/messaging"
+
+// Schemes are the URL schemes of the NATS message broker.
+var Schemes = []string{"nats", "tls"}
+
+func init() {
+	messaging.Register(messaging.BrokerFactory{
+		NewPublisher: NewPublisher,
+		NewPubSub:    NewPubSub,
+	}, Schemes...)
+}
@@ -0,0 +1,69 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package partition
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux"
+)
+
+const (
+	defGroup     = ""
+	defMember    = ""
+	defHeartbeat = "5s"
+	defTTL       = "15s"
+	defHandoff   = "10s"
+
+	envGroup     = "MF_SUBSCRIBER_PARTITION_GROUP"
+	envMember    = "MF_SUBSCRIBER_PARTITION_MEMBER"
+	envHeartbeat = "MF_SUBSCRIBER_PARTITION_HEARTBEAT"
+	envTTL       = "MF_SUBSCRIBER_PARTITION_TTL"
+	envHandoff   = "MF_SUBSCRIBER_PARTITION_HANDOFF"
+)
+
+// LoadConfig reads the configuration of dividing the channels among the
+// service replicas from the MF_SUBSCRIBER_PARTITION_* environment variables.
+// Member defaults to the hostname. It returns nil if partitioning is
+// disabled.
+func LoadConfig() (*Config, error) {
+	group := mainflux.Env(envGroup, defGroup)
+	if group == "" {
+		return nil, nil
+	}
+
+	member := mainflux.Env(envMember, defMember)
+	if member == "" {
+		hostname, err := os.Hostname()
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve %s default value: %s", envMember, err)
+		}
+		member = hostname
+	}
+
+	heartbeat, err := time.ParseDuration(mainflux.Env(envHeartbeat, defHeartbeat))
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s value: %s", envHeartbeat, err)
+	}
+
+	ttl, err := time.ParseDuration(mainflux.Env(envTTL, defTTL))
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s value: %s", envTTL, err)
+	}
+
+	handoff, err := time.ParseDuration(mainflux.Env(envHandoff, defHandoff))
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s value: %s", envHandoff, err)
+	}
+
+	return &Config{
+		Group:     group,
+		Member:    member,
+		Heartbeat: heartbeat,
+		TTL:       ttl,
+		Handoff:   handoff,
+	}, nil
+}
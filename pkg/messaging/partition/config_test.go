@@ -0,0 +1,62 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package partition_test
+
+import (
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/pkg/messaging/partition"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadConfig(t *testing.T) {
+	hostname, err := os.Hostname()
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc      string
+		group     string
+		member    string
+		heartbeat string
+		cfg       *partition.Config
+		err       bool
+	}{
+		{
+			desc: "load default config",
+			cfg:  nil,
+		},
+		{
+			desc:  "load config with group",
+			group: "writers",
+			cfg:   &partition.Config{Group: "writers", Member: hostname, Heartbeat: 5 * time.Second, TTL: 15 * time.Second, Handoff: 10 * time.Second},
+		},
+		{
+			desc:      "load config with group, member and heartbeat",
+			group:     "writers",
+			member:    "writer-1",
+			heartbeat: "1s",
+			cfg:       &partition.Config{Group: "writers", Member: "writer-1", Heartbeat: time.Second, TTL: 15 * time.Second, Handoff: 10 * time.Second},
+		},
+		{
+			desc:      "load config with invalid heartbeat",
+			group:     "writers",
+			heartbeat: "often",
+			err:       true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Setenv("MF_SUBSCRIBER_PARTITION_GROUP", tc.group)
+		t.Setenv("MF_SUBSCRIBER_PARTITION_MEMBER", tc.member)
+		t.Setenv("MF_SUBSCRIBER_PARTITION_HEARTBEAT", tc.heartbeat)
+
+		cfg, err := partition.LoadConfig()
+		assert.Equal(t, tc.err, err != nil, fmt.Sprintf("%s: expected error %t got %s", tc.desc, tc.err, err))
+		assert.Equal(t, tc.cfg, cfg, fmt.Sprintf("%s: expected %v got %v", tc.desc, tc.cfg, cfg))
+	}
+}
@@ -0,0 +1,7 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package partition contains the subscriber middleware dividing the channels
+// among the replicas of a service by consistent hashing, so each replica
+// handles only the messages of the channels it owns.
+package partition
@@ -0,0 +1,141 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package partition
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// Config represents the partitioning configuration of the service replica.
+type Config struct {
+	// Group is the name of the group of the replicas dividing the channels.
+	Group string
+	// Member is the unique ID of the replica in the group.
+	Member string
+	// Heartbeat is the interval of announcing the replica to the group.
+	Heartbeat time.Duration
+	// TTL is the time after which the replica which stopped announcing
+	// itself is removed from the group.
+	TTL time.Duration
+	// Handoff is the time after the membership change during which both
+	// the previous and the new owner of a channel handle its messages.
+	Handoff time.Duration
+}
+
+// Partitioner tracks the members of the group and decides which channels
+// are owned by the member.
+type Partitioner struct {
+	member  string
+	handoff time.Duration
+	mu      sync.RWMutex
+	members []string
+	current *ring
+	prev    *ring
+	until   time.Time
+}
+
+// NewPartitioner returns the partitioner of the member. The member owns no
+// channels until the first rebalance.
+func NewPartitioner(member string, handoff time.Duration) *Partitioner {
+	return &Partitioner{
+		member:  member,
+		handoff: handoff,
+	}
+}
+
+// Member returns the ID of the member.
+func (p *Partitioner) Member() string {
+	return p.member
+}
+
+// Members returns the sorted IDs of the live members of the group.
+func (p *Partitioner) Members() []string {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	return append([]string(nil), p.members...)
+}
+
+// Rebalance divides the channels among the live members. The member itself
+// is always considered live. The channels moved to another member remain
+// owned by the previous owner during the handoff period too, since the
+// members don't observe the membership change at the same time. Messages
+// of the moved channels received by the previous owner during the handoff
+// are handled by it instead of being forwarded to the new owner.
+func (p *Partitioner) Rebalance(members []string) {
+	ms := normalize(p.member, members)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if equal(ms, p.members) {
+		return
+	}
+
+	if p.current != nil {
+		p.prev = p.current
+		p.until = time.Now().Add(p.handoff)
+	}
+	p.members = ms
+	p.current = newRing(ms)
+}
+
+// Owns returns whether the member handles the messages of the channel.
+func (p *Partitioner) Owns(channel string) bool {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	if p.current == nil {
+		return false
+	}
+	if p.current.owner(channel) == p.member {
+		return true
+	}
+
+	return p.prev != nil && time.Now().Before(p.until) && p.prev.owner(channel) == p.member
+}
+
+// Owner returns the ID of the member the channel is currently mapped to. It
+// returns an empty string before the first rebalance.
+func (p *Partitioner) Owner(channel string) string {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	if p.current == nil {
+		return ""
+	}
+
+	return p.current.owner(channel)
+}
+
+// normalize returns the sorted unique members, including the member itself.
+func normalize(member string, members []string) []string {
+	set := make(map[string]struct{}, len(members)+1)
+	var ret []string
+	for _, m := range append([]string{member}, members...) {
+		if _, ok := set[m]; ok || m == "" {
+			continue
+		}
+		set[m] = struct{}{}
+		ret = append(ret, m)
+	}
+	sort.Strings(ret)
+
+	return ret
+}
+
+func equal(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
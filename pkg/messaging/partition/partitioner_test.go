@@ -0,0 +1,119 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package partition_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/pkg/messaging/partition"
+	"github.com/stretchr/testify/assert"
+)
+
+const channels = 1000
+
+var members = []string{"writer-1", "writer-2", "writer-3"}
+
+func newPartitioners(members []string, handoff time.Duration) []*partition.Partitioner {
+	var ps []*partition.Partitioner
+	for _, m := range members {
+		p := partition.NewPartitioner(m, handoff)
+		p.Rebalance(members)
+		ps = append(ps, p)
+	}
+
+	return ps
+}
+
+func owners(ps []*partition.Partitioner, channel string) []string {
+	var ret []string
+	for _, p := range ps {
+		if p.Owns(channel) {
+			ret = append(ret, p.Member())
+		}
+	}
+
+	return ret
+}
+
+func TestOwns(t *testing.T) {
+	p := partition.NewPartitioner("writer-1", 0)
+	assert.False(t, p.Owns("1"), "expected no channels to be owned before rebalance")
+
+	p.Rebalance(nil)
+	assert.Equal(t, []string{"writer-1"}, p.Members(), "expected the member itself to be live")
+	assert.True(t, p.Owns("1"), "expected the only member to own all the channels")
+
+	ps := newPartitioners(members, 0)
+	counts := map[string]int{}
+	for i := 0; i < channels; i++ {
+		ch := fmt.Sprintf("%d", i)
+		os := owners(ps, ch)
+		assert.Len(t, os, 1, fmt.Sprintf("expected channel %s to have exactly one owner got %v", ch, os))
+		for _, o := range os {
+			counts[o]++
+		}
+	}
+
+	for _, m := range members {
+		assert.Greater(t, counts[m], channels/len(members)/2, fmt.Sprintf("expected %s to own a fair share of the channels got %d", m, counts[m]))
+	}
+}
+
+func TestRebalance(t *testing.T) {
+	ps := newPartitioners(members, 0)
+	prev := map[string]string{}
+	for i := 0; i < channels; i++ {
+		ch := fmt.Sprintf("%d", i)
+		prev[ch] = owners(ps, ch)[0]
+	}
+
+	// Member leaves the group, and only its channels are moved.
+	left := members[:2]
+	for _, p := range ps[:2] {
+		p.Rebalance(left)
+	}
+	for ch, o := range prev {
+		os := owners(ps[:2], ch)
+		assert.Len(t, os, 1, fmt.Sprintf("expected channel %s to have exactly one owner got %v", ch, os))
+		if o != members[2] {
+			assert.Equal(t, []string{o}, os, fmt.Sprintf("expected channel %s to stay with %s", ch, o))
+		}
+	}
+}
+
+func TestHandoff(t *testing.T) {
+	handoff := 100 * time.Millisecond
+	ps := newPartitioners(members[:2], handoff)
+	prev := map[string]string{}
+	for i := 0; i < channels; i++ {
+		ch := fmt.Sprintf("%d", i)
+		prev[ch] = owners(ps, ch)[0]
+	}
+
+	// New member joins, and the previous owners keep their channels
+	// during the handoff.
+	ps = append(ps, partition.NewPartitioner(members[2], handoff))
+	for _, p := range ps {
+		p.Rebalance(members)
+	}
+
+	moved := 0
+	for ch, o := range prev {
+		os := owners(ps, ch)
+		assert.Contains(t, os, o, fmt.Sprintf("expected channel %s to stay with %s during handoff", ch, o))
+		if len(os) == 2 {
+			assert.Contains(t, os, members[2], fmt.Sprintf("expected channel %s to be moved to the new member", ch))
+			moved++
+		}
+	}
+	assert.Greater(t, moved, 0, "expected channels to be moved to the new member")
+
+	time.Sleep(handoff)
+	for ch := range prev {
+		os := owners(ps, ch)
+		assert.Len(t, os, 1, fmt.Sprintf("expected channel %s to have exactly one owner after handoff got %v", ch, os))
+	}
+}
@@ -0,0 +1,162 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package partition
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	log "github.com/MainfluxLabs/mainflux/logger"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+	"github.com/go-kit/kit/metrics"
+)
+
+var _ messaging.PubSub = (*pubsub)(nil)
+
+// Membership represents the membership of the replica in the group, which
+// hands the messages over to the members owning their channels.
+type Membership interface {
+	io.Closer
+
+	// Forward hands the message received by the subscription over to the
+	// member and returns the result of handling it by the member.
+	Forward(member, subscription string, msg messaging.Message) error
+
+	// Serve handles the messages of the subscription forwarded to the
+	// member by the handler.
+	Serve(subscription string, handler messaging.MessageHandler)
+
+	// Stop stops handling the messages forwarded to the subscription.
+	Stop(subscription string)
+}
+
+type pubsub struct {
+	messaging.PubSub
+	partitioner *Partitioner
+	membership  Membership
+	forwarded   metrics.Counter
+	logger      log.Logger
+}
+
+// NewPubSub returns the pubsub which hands the received messages of the
+// channels not owned by the member of the partitioner over to their owners
+// using the membership. Forwarded messages are counted by the forwarded
+// counter.
+//
+// The underlying pubsub has to share the subscriptions among the members of
+// the group, e.g. using the queue group named by the group, so each message
+// is received by a single member. The message is acknowledged to the broker
+// once it's handled by its owner, and redelivered if the owner failed to
+// handle it. Closing the pubsub leaves the group by closing the membership.
+func NewPubSub(ps messaging.PubSub, p *Partitioner, membership Membership, forwarded metrics.Counter, logger log.Logger) messaging.PubSub {
+	return &pubsub{
+		PubSub:      ps,
+		partitioner: p,
+		membership:  membership,
+		forwarded:   forwarded,
+		logger:      logger,
+	}
+}
+
+func (ps *pubsub) Subscribe(id, topic string, handler messaging.MessageHandler) error {
+	sub := subscription(id, topic)
+	ps.membership.Serve(sub, handler)
+
+	f := &filter{
+		MessageHandler: handler,
+		subscription:   sub,
+		partitioner:    ps.partitioner,
+		membership:     ps.membership,
+		forwarded:      ps.forwarded,
+	}
+
+	var err error
+	if ah, ok := handler.(messaging.AsyncMessageHandler); ok {
+		err = ps.PubSub.Subscribe(id, topic, &asyncFilter{filter: f, async: ah})
+	} else {
+		err = ps.PubSub.Subscribe(id, topic, f)
+	}
+	if err != nil {
+		ps.membership.Stop(sub)
+		return err
+	}
+
+	return nil
+}
+
+func (ps *pubsub) Unsubscribe(id, topic string) error {
+	if err := ps.PubSub.Unsubscribe(id, topic); err != nil {
+		return err
+	}
+	ps.membership.Stop(subscription(id, topic))
+
+	return nil
+}
+
+func (ps *pubsub) Close() error {
+	if err := ps.membership.Close(); err != nil {
+		ps.PubSub.Close()
+		return err
+	}
+
+	return ps.PubSub.Close()
+}
+
+// Ping checks the connection of the underlying pubsub, if supported.
+func (ps *pubsub) Ping(ctx context.Context) error {
+	if pinger, ok := ps.PubSub.(messaging.Pinger); ok {
+		return pinger.Ping(ctx)
+	}
+	return nil
+}
+
+// subscription returns the key identifying the subscription among the
+// members of the group.
+func subscription(id, topic string) string {
+	return fmt.Sprintf("%s:%s", id, topic)
+}
+
+type filter struct {
+	messaging.MessageHandler
+	subscription string
+	partitioner  *Partitioner
+	membership   Membership
+	forwarded    metrics.Counter
+}
+
+func (f *filter) Handle(msg messaging.Message) error {
+	if !f.owns(msg) {
+		return f.forward(msg)
+	}
+
+	return f.MessageHandler.Handle(msg)
+}
+
+func (f *filter) owns(msg messaging.Message) bool {
+	return f.partitioner.Owns(msg.Channel)
+}
+
+func (f *filter) forward(msg messaging.Message) error {
+	f.forwarded.Add(1)
+	return f.membership.Forward(f.partitioner.Owner(msg.Channel), f.subscription, msg)
+}
+
+type asyncFilter struct {
+	*filter
+	async messaging.AsyncMessageHandler
+}
+
+// HandleAsync forwards the messages of the channels owned by other members
+// without blocking the subscription until the owner handles them.
+func (f *asyncFilter) HandleAsync(msg messaging.Message, done func(error)) {
+	if !f.owns(msg) {
+		go func() {
+			done(f.forward(msg))
+		}()
+		return
+	}
+
+	f.async.HandleAsync(msg, done)
+}
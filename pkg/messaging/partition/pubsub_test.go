@@ -0,0 +1,222 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package partition_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/MainfluxLabs/mainflux/logger"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging/partition"
+	"github.com/go-kit/kit/metrics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const topic = "channels.>"
+
+var errForward = errors.New("failed to forward message")
+
+type subscriber struct {
+	messaging.Publisher
+	id      string
+	handler messaging.MessageHandler
+}
+
+func (s *subscriber) Subscribe(id, topic string, h messaging.MessageHandler) error {
+	s.id = id
+	s.handler = h
+	return nil
+}
+
+func (s *subscriber) Unsubscribe(id, topic string) error {
+	return s.handler.Cancel()
+}
+
+func (s *subscriber) Close() error {
+	return nil
+}
+
+type membership struct {
+	closed    bool
+	err       error
+	forwarded map[string][]messaging.Message
+	handlers  map[string]messaging.MessageHandler
+}
+
+func newMembership(err error) *membership {
+	return &membership{
+		err:       err,
+		forwarded: make(map[string][]messaging.Message),
+		handlers:  make(map[string]messaging.MessageHandler),
+	}
+}
+
+func (m *membership) Close() error {
+	m.closed = true
+	return nil
+}
+
+func (m *membership) Forward(member, subscription string, msg messaging.Message) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.forwarded[member] = append(m.forwarded[member], msg)
+	return nil
+}
+
+func (m *membership) Serve(subscription string, h messaging.MessageHandler) {
+	m.handlers[subscription] = h
+}
+
+func (m *membership) Stop(subscription string) {
+	delete(m.handlers, subscription)
+}
+
+type handler struct {
+	msgs []messaging.Message
+}
+
+func (h *handler) Handle(msg messaging.Message) error {
+	h.msgs = append(h.msgs, msg)
+	return nil
+}
+
+func (h *handler) Cancel() error {
+	return nil
+}
+
+type asyncHandler struct {
+	handler
+}
+
+func (h *asyncHandler) HandleAsync(msg messaging.Message, done func(error)) {
+	done(h.Handle(msg))
+}
+
+type counter struct {
+	value float64
+}
+
+func (c *counter) With(labelValues ...string) metrics.Counter {
+	return c
+}
+
+func (c *counter) Add(delta float64) {
+	c.value += delta
+}
+
+func TestSubscribe(t *testing.T) {
+	ps := newPartitioners(members, 0)
+	p := ps[0]
+
+	var owned, foreign string
+	for i := 0; owned == "" || foreign == ""; i++ {
+		ch := fmt.Sprintf("%d", i)
+		if p.Owns(ch) {
+			owned = ch
+			continue
+		}
+		foreign = ch
+	}
+
+	cases := []struct {
+		desc      string
+		channel   string
+		err       error
+		handled   bool
+		forwarded bool
+	}{
+		{
+			desc:      "handle message of owned channel",
+			channel:   owned,
+			err:       nil,
+			handled:   true,
+			forwarded: false,
+		},
+		{
+			desc:      "forward message of channel owned by another member",
+			channel:   foreign,
+			err:       nil,
+			handled:   false,
+			forwarded: true,
+		},
+		{
+			desc:      "forward message of channel owned by another member with forwarding error",
+			channel:   foreign,
+			err:       errForward,
+			handled:   false,
+			forwarded: false,
+		},
+	}
+
+	for _, tc := range cases {
+		sub := &subscriber{}
+		fwd := &counter{}
+		mb := newMembership(tc.err)
+		pubsub := partition.NewPubSub(sub, p, mb, fwd, logger.NewMock())
+
+		h := &handler{}
+		err := pubsub.Subscribe("writer", topic, h)
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+		assert.Equal(t, "writer", sub.id, fmt.Sprintf("%s: expected subscription ID to be shared by the members", tc.desc))
+
+		err = sub.handler.Handle(messaging.Message{Channel: tc.channel})
+		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.err, err))
+		assert.Equal(t, tc.handled, len(h.msgs) == 1, fmt.Sprintf("%s: expected handled %t got %d messages", tc.desc, tc.handled, len(h.msgs)))
+
+		owner := p.Owner(tc.channel)
+		assert.Equal(t, tc.forwarded, len(mb.forwarded[owner]) == 1, fmt.Sprintf("%s: expected forwarded %t got %d messages", tc.desc, tc.forwarded, len(mb.forwarded[owner])))
+
+		var want float64
+		if !tc.handled {
+			want = 1
+		}
+		assert.Equal(t, want, fwd.value, fmt.Sprintf("%s: expected %v forwarded messages got %v", tc.desc, want, fwd.value))
+
+		ah := &asyncHandler{}
+		err = pubsub.Subscribe("writer", topic, ah)
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+		async, ok := sub.handler.(messaging.AsyncMessageHandler)
+		require.True(t, ok, fmt.Sprintf("%s: expected async handler to stay async", tc.desc))
+
+		done := make(chan error, 1)
+		async.HandleAsync(messaging.Message{Channel: tc.channel}, func(err error) {
+			done <- err
+		})
+		err = <-done
+		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.err, err))
+		assert.Equal(t, tc.handled, len(ah.msgs) == 1, fmt.Sprintf("%s: expected async handled %t got %d messages", tc.desc, tc.handled, len(ah.msgs)))
+
+		err = pubsub.Close()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+		assert.True(t, mb.closed, fmt.Sprintf("%s: expected membership to be closed", tc.desc))
+	}
+}
+
+func TestServe(t *testing.T) {
+	p := partition.NewPartitioner(members[0], 0)
+	p.Rebalance(members)
+
+	sub := &subscriber{}
+	mb := newMembership(nil)
+	pubsub := partition.NewPubSub(sub, p, mb, &counter{}, logger.NewMock())
+
+	h := &handler{}
+	err := pubsub.Subscribe("writer", topic, h)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	require.Len(t, mb.handlers, 1, "expected forwarded messages of the subscription to be served")
+
+	for _, served := range mb.handlers {
+		err := served.Handle(messaging.Message{Channel: "1"})
+		assert.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	}
+	assert.Len(t, h.msgs, 1, fmt.Sprintf("expected forwarded message to be handled got %d messages", len(h.msgs)))
+
+	err = pubsub.Unsubscribe("writer", topic)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.Len(t, mb.handlers, 0, "expected forwarded messages of the subscription not to be served after unsubscribe")
+}
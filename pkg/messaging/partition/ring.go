@@ -0,0 +1,63 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package partition
+
+import (
+	"hash/crc32"
+	"sort"
+	"strconv"
+)
+
+// vnodes is the number of the points of each member on the ring. More
+// points spread the channels more evenly among the members.
+const vnodes = 128
+
+type point struct {
+	hash   uint32
+	member string
+}
+
+// ring is the consistent hash ring of the members. Adding or removing a
+// member moves only the keys of the neighbouring points of that member.
+type ring struct {
+	points []point
+}
+
+func newRing(members []string) *ring {
+	r := &ring{points: make([]point, 0, len(members)*vnodes)}
+	for _, m := range members {
+		for i := 0; i < vnodes; i++ {
+			r.points = append(r.points, point{hash: hash(m + "#" + strconv.Itoa(i)), member: m})
+		}
+	}
+
+	sort.Slice(r.points, func(i, j int) bool {
+		if r.points[i].hash == r.points[j].hash {
+			return r.points[i].member < r.points[j].member
+		}
+		return r.points[i].hash < r.points[j].hash
+	})
+
+	return r
+}
+
+// owner returns the member owning the key, or an empty string if the
+// ring has no members.
+func (r *ring) owner(key string) string {
+	if len(r.points) == 0 {
+		return ""
+	}
+
+	h := hash(key)
+	i := sort.Search(len(r.points), func(i int) bool { return r.points[i].hash >= h })
+	if i == len(r.points) {
+		i = 0
+	}
+
+	return r.points[i].member
+}
+
+func hash(key string) uint32 {
+	return crc32.ChecksumIEEE([]byte(key))
+}
@@ -0,0 +1,31 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package messaging
+
+// Flags of the message profile.
+const (
+	// ForwardFlag is set if the message is forwarded to the subscribers,
+	// e.g. the WebSocket and CoAP clients and the MQTT broker.
+	ForwardFlag = "forward"
+
+	// PersistFlag is set if the message is persisted by the writers.
+	PersistFlag = "persist"
+)
+
+// HasFlag reports whether the profile flag is set for the message. The
+// messages without the profile are both forwarded and persisted.
+func (m *Message) HasFlag(flag string) bool {
+	if m.Profile == nil {
+		return true
+	}
+
+	switch flag {
+	case ForwardFlag:
+		return m.Profile.Forward
+	case PersistFlag:
+		return m.Profile.Persist
+	default:
+		return false
+	}
+}
@@ -0,0 +1,8 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package profile contains the publisher middleware setting the profile of
+// the published messages from the profile of their channel, and the
+// subscriber middleware passing to the handlers only the messages whose
+// profile has the given flag set.
+package profile
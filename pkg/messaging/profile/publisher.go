@@ -0,0 +1,103 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package profile
+
+import (
+	"context"
+
+	"github.com/MainfluxLabs/mainflux"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+)
+
+var (
+	_ messaging.Publisher = (*publisher)(nil)
+	_ messaging.PubSub    = (*publishingPubSub)(nil)
+)
+
+type publisher struct {
+	messaging.Publisher
+	things mainflux.ThingsServiceClient
+}
+
+// NewPublisher returns the publisher which sets the profile of each message
+// to the message profile of its channel before publishing, replacing the
+// profile set by the client. Messages of the channels without the message
+// profile are published without the profile, i.e. they are both forwarded
+// and persisted.
+func NewPublisher(pub messaging.Publisher, things mainflux.ThingsServiceClient) messaging.Publisher {
+	return &publisher{
+		Publisher: pub,
+		things:    things,
+	}
+}
+
+func (p *publisher) Publish(topic string, msg messaging.Message) error {
+	msg, err := p.setProfile(context.Background(), msg)
+	if err != nil {
+		return err
+	}
+
+	return p.Publisher.Publish(topic, msg)
+}
+
+// PublishConfirmed publishes the message using the underlying publisher, if
+// supported, waiting for the broker acknowledgement.
+func (p *publisher) PublishConfirmed(ctx context.Context, topic string, msg messaging.Message) error {
+	if _, ok := p.Publisher.(messaging.Confirmer); !ok {
+		return messaging.ErrConfirmNotSupported
+	}
+
+	msg, err := p.setProfile(ctx, msg)
+	if err != nil {
+		return err
+	}
+
+	return messaging.PublishConfirmed(ctx, p.Publisher, topic, msg)
+}
+
+// Ping checks the connection of the underlying publisher, if supported.
+func (p *publisher) Ping(ctx context.Context) error {
+	if pinger, ok := p.Publisher.(messaging.Pinger); ok {
+		return pinger.Ping(ctx)
+	}
+	return nil
+}
+
+func (p *publisher) setProfile(ctx context.Context, msg messaging.Message) (messaging.Message, error) {
+	profile, err := p.things.GetChannelProfile(ctx, &mainflux.ChannelID{Value: msg.Channel})
+	if err != nil {
+		return messaging.Message{}, err
+	}
+
+	msg.Profile = nil
+	if mp := profile.GetMessage(); mp != nil {
+		msg.Profile = &messaging.Profile{
+			Forward: mp.GetForward(),
+			Persist: mp.GetPersist(),
+		}
+	}
+
+	return msg, nil
+}
+
+type publishingPubSub struct {
+	*publisher
+	messaging.Subscriber
+}
+
+// NewPublishingPubSub returns the pubsub which sets the profile of each
+// message to the message profile of its channel on publishing.
+func NewPublishingPubSub(ps messaging.PubSub, things mainflux.ThingsServiceClient) messaging.PubSub {
+	return &publishingPubSub{
+		publisher: &publisher{
+			Publisher: ps,
+			things:    things,
+		},
+		Subscriber: ps,
+	}
+}
+
+func (ps *publishingPubSub) Close() error {
+	return ps.Subscriber.Close()
+}
@@ -0,0 +1,108 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package profile_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/MainfluxLabs/mainflux"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging/profile"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+)
+
+var errNotFound = errors.New("channel not found")
+
+type thingsClient struct {
+	mainflux.ThingsServiceClient
+	profiles map[string]*mainflux.MessageProfile
+}
+
+func (tc thingsClient) GetChannelProfile(_ context.Context, req *mainflux.ChannelID, _ ...grpc.CallOption) (*mainflux.ChannelProfile, error) {
+	mp, ok := tc.profiles[req.GetValue()]
+	if !ok {
+		return nil, errNotFound
+	}
+
+	return &mainflux.ChannelProfile{Message: mp}, nil
+}
+
+type publisher struct {
+	published []messaging.Message
+}
+
+func (p *publisher) Publish(topic string, msg messaging.Message) error {
+	p.published = append(p.published, msg)
+	return nil
+}
+
+func (p *publisher) Close() error {
+	return nil
+}
+
+func TestPublishProfile(t *testing.T) {
+	things := thingsClient{
+		profiles: map[string]*mainflux.MessageProfile{
+			"plain":       nil,
+			"unforwarded": {Forward: false, Persist: true},
+			"unpersisted": {Forward: true, Persist: false},
+		},
+	}
+
+	cases := []struct {
+		desc    string
+		channel string
+		profile *messaging.Profile
+		want    *messaging.Profile
+		err     error
+	}{
+		{
+			desc:    "publish message of channel without message profile",
+			channel: "plain",
+			want:    nil,
+		},
+		{
+			desc:    "publish message with client profile to channel without message profile",
+			channel: "plain",
+			profile: &messaging.Profile{Forward: false, Persist: false},
+			want:    nil,
+		},
+		{
+			desc:    "publish message of channel with unforwarded messages",
+			channel: "unforwarded",
+			want:    &messaging.Profile{Forward: false, Persist: true},
+		},
+		{
+			desc:    "publish message with client profile to channel with unpersisted messages",
+			channel: "unpersisted",
+			profile: &messaging.Profile{Forward: false, Persist: true},
+			want:    &messaging.Profile{Forward: true, Persist: false},
+		},
+		{
+			desc:    "publish message of non-existing channel",
+			channel: "non-existing",
+			err:     errNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		pub := &publisher{}
+		p := profile.NewPublisher(pub, things)
+
+		err := p.Publish(topic, messaging.Message{Channel: tc.channel, Profile: tc.profile})
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if tc.err != nil {
+			assert.Empty(t, pub.published, fmt.Sprintf("%s: expected no published messages", tc.desc))
+			continue
+		}
+
+		if assert.Len(t, pub.published, 1, tc.desc) {
+			assert.Equal(t, tc.want, pub.published[0].Profile, fmt.Sprintf("%s: expected profile %v got %v\n", tc.desc, tc.want, pub.published[0].Profile))
+		}
+	}
+}
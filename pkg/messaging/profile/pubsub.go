@@ -0,0 +1,61 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package profile
+
+import (
+	"context"
+
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+	"github.com/go-kit/kit/metrics"
+)
+
+var _ messaging.PubSub = (*pubsub)(nil)
+
+type pubsub struct {
+	messaging.PubSub
+	flag    string
+	skipped metrics.Counter
+}
+
+// NewPubSub returns the pubsub which skips the received messages whose
+// profile doesn't have the flag set. Skipped messages are counted by the
+// skipped counter, labeled by the flag.
+func NewPubSub(ps messaging.PubSub, flag string, skipped metrics.Counter) messaging.PubSub {
+	return &pubsub{
+		PubSub:  ps,
+		flag:    flag,
+		skipped: skipped,
+	}
+}
+
+func (ps *pubsub) Subscribe(id, topic string, handler messaging.MessageHandler) error {
+	return ps.PubSub.Subscribe(id, topic, &filter{
+		MessageHandler: handler,
+		flag:           ps.flag,
+		skipped:        ps.skipped,
+	})
+}
+
+// Ping checks the connection of the underlying pubsub, if supported.
+func (ps *pubsub) Ping(ctx context.Context) error {
+	if pinger, ok := ps.PubSub.(messaging.Pinger); ok {
+		return pinger.Ping(ctx)
+	}
+	return nil
+}
+
+type filter struct {
+	messaging.MessageHandler
+	flag    string
+	skipped metrics.Counter
+}
+
+func (f *filter) Handle(msg messaging.Message) error {
+	if !msg.HasFlag(f.flag) {
+		f.skipped.With("flag", f.flag).Add(1)
+		return nil
+	}
+
+	return f.MessageHandler.Handle(msg)
+}
@@ -0,0 +1,121 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package profile_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging/profile"
+	"github.com/go-kit/kit/metrics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const topic = "channels.1"
+
+type subscriber struct {
+	messaging.Publisher
+	handler messaging.MessageHandler
+}
+
+func (s *subscriber) Subscribe(id, topic string, h messaging.MessageHandler) error {
+	s.handler = h
+	return nil
+}
+
+func (s *subscriber) Unsubscribe(id, topic string) error {
+	return s.handler.Cancel()
+}
+
+func (s *subscriber) Close() error {
+	return nil
+}
+
+type handler struct {
+	msgs []messaging.Message
+}
+
+func (h *handler) Handle(msg messaging.Message) error {
+	h.msgs = append(h.msgs, msg)
+	return nil
+}
+
+func (h *handler) Cancel() error {
+	return nil
+}
+
+type counter struct {
+	labels map[string]float64
+	label  string
+}
+
+func (c *counter) With(labelValues ...string) metrics.Counter {
+	return &counter{labels: c.labels, label: labelValues[len(labelValues)-1]}
+}
+
+func (c *counter) Add(delta float64) {
+	c.labels[c.label] += delta
+}
+
+func TestSubscribe(t *testing.T) {
+	cases := []struct {
+		desc    string
+		flag    string
+		profile *messaging.Profile
+		handled bool
+	}{
+		{
+			desc:    "handle message without profile",
+			flag:    messaging.PersistFlag,
+			profile: nil,
+			handled: true,
+		},
+		{
+			desc:    "handle message with persist flag set",
+			flag:    messaging.PersistFlag,
+			profile: &messaging.Profile{Persist: true},
+			handled: true,
+		},
+		{
+			desc:    "skip message without persist flag set",
+			flag:    messaging.PersistFlag,
+			profile: &messaging.Profile{Forward: true},
+			handled: false,
+		},
+		{
+			desc:    "handle message with forward flag set",
+			flag:    messaging.ForwardFlag,
+			profile: &messaging.Profile{Forward: true},
+			handled: true,
+		},
+		{
+			desc:    "skip message without forward flag set",
+			flag:    messaging.ForwardFlag,
+			profile: &messaging.Profile{Persist: true},
+			handled: false,
+		},
+	}
+
+	for _, tc := range cases {
+		sub := &subscriber{}
+		skipped := &counter{labels: map[string]float64{}}
+		ps := profile.NewPubSub(sub, tc.flag, skipped)
+
+		h := &handler{}
+		err := ps.Subscribe("writer", topic, h)
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+
+		err = sub.handler.Handle(messaging.Message{Channel: "1", Profile: tc.profile})
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+		assert.Equal(t, tc.handled, len(h.msgs) == 1, fmt.Sprintf("%s: expected handled %t got %d messages", tc.desc, tc.handled, len(h.msgs)))
+
+		var want float64
+		if !tc.handled {
+			want = 1
+		}
+		assert.Equal(t, want, skipped.labels[tc.flag], fmt.Sprintf("%s: expected %v skipped messages got %v", tc.desc, want, skipped.labels[tc.flag]))
+	}
+}
@@ -3,6 +3,23 @@
 
 package messaging
 
+import (
+	"context"
+	"errors"
+
+	"github.com/MainfluxLabs/mainflux"
+)
+
+var (
+	// ErrConfirmNotSupported indicates that the publisher can't confirm
+	// that the message broker persisted the message.
+	ErrConfirmNotSupported = errors.New("publish confirmation is not supported by the message broker")
+
+	// ErrNotConfirmed indicates that the message broker refused to persist
+	// the message.
+	ErrNotConfirmed = errors.New("message broker did not confirm the message")
+)
+
 // Publisher specifies message publishing API.
 type Publisher interface {
 	// Publishes message to the stream.
@@ -21,6 +38,17 @@ type MessageHandler interface {
 	Cancel() error
 }
 
+// AsyncMessageHandler represents the MessageHandler which handles messages
+// asynchronously. Subscribers which acknowledge messages to the broker use
+// it to acknowledge the messages only after they are handled.
+type AsyncMessageHandler interface {
+	MessageHandler
+
+	// HandleAsync queues the message for handling and calls done with the
+	// result of handling once the message is handled.
+	HandleAsync(msg Message, done func(error))
+}
+
 // Subscriber specifies message subscription API.
 type Subscriber interface {
 	// Subscribe subscribes to the message stream and consumes messages.
@@ -34,6 +62,46 @@ type Subscriber interface {
 	Close() error
 }
 
+// Pinger specifies the message broker connection check API.
+type Pinger interface {
+	// Ping checks whether the connection to the message broker is alive.
+	Ping(ctx context.Context) error
+}
+
+// Confirmer specifies the API of publishing messages whose persistence is
+// acknowledged by the message broker.
+type Confirmer interface {
+	// PublishConfirmed publishes the message to the stream and waits until
+	// the message broker acknowledges that the message is persisted.
+	PublishConfirmed(ctx context.Context, topic string, msg Message) error
+}
+
+// PublishConfirmed publishes the message using the publisher and waits for
+// the message broker acknowledgement. ErrConfirmNotSupported is returned if
+// the publisher doesn't implement Confirmer.
+func PublishConfirmed(ctx context.Context, pub Publisher, topic string, msg Message) error {
+	c, ok := pub.(Confirmer)
+	if !ok {
+		return ErrConfirmNotSupported
+	}
+
+	return c.PublishConfirmed(ctx, topic, msg)
+}
+
+// HealthCheck returns the message broker health check of the publisher.
+// The check always passes if the publisher doesn't implement Pinger.
+func HealthCheck(pub Publisher) mainflux.HealthCheck {
+	return mainflux.HealthCheck{
+		Name: "broker",
+		Check: func(ctx context.Context) error {
+			if p, ok := pub.(Pinger); ok {
+				return p.Ping(ctx)
+			}
+			return nil
+		},
+	}
+}
+
 // PubSub  represents aggregation interface for publisher and subscriber.
 type PubSub interface {
 	Publisher
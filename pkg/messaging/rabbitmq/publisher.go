@@ -7,17 +7,26 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"sync"
 
 	"github.com/gogo/protobuf/proto"
 	"github.com/MainfluxLabs/mainflux/pkg/messaging"
 	amqp "github.com/rabbitmq/amqp091-go"
 )
 
-var _ messaging.Publisher = (*publisher)(nil)
+var (
+	_ messaging.Publisher = (*publisher)(nil)
+	_ messaging.Pinger    = (*publisher)(nil)
+	_ messaging.Confirmer = (*publisher)(nil)
+)
 
 type publisher struct {
 	conn *amqp.Connection
 	ch   *amqp.Channel
+	// confirmCh is the channel in the confirm mode used for the confirmed
+	// publishing, opened on the first confirmed publish.
+	confirmMu sync.Mutex
+	confirmCh *amqp.Channel
 }
 
 // NewPublisher returns RabbitMQ message Publisher.
@@ -49,24 +58,14 @@ func (pub *publisher) Publish(topic string, msg messaging.Message) error {
 	if err != nil {
 		return err
 	}
-	subject := fmt.Sprintf("%s.%s", chansPrefix, topic)
-	if msg.Subtopic != "" {
-		subject = fmt.Sprintf("%s.%s", subject, msg.Subtopic)
-	}
-	subject = formatTopic(subject)
 
 	err = pub.ch.PublishWithContext(
 		context.Background(),
 		exchangeName,
-		subject,
+		subject(topic, msg),
 		false,
 		false,
-		amqp.Publishing{
-			Headers:     amqp.Table{},
-			ContentType: "application/octet-stream",
-			AppId:       "mainflux-publisher",
-			Body:        data,
-		})
+		publishing(data))
 
 	if err != nil {
 		return err
@@ -75,6 +74,51 @@ func (pub *publisher) Publish(topic string, msg messaging.Message) error {
 	return nil
 }
 
+// PublishConfirmed publishes the message and waits for the publisher
+// confirmation of the broker.
+func (pub *publisher) PublishConfirmed(ctx context.Context, topic string, msg messaging.Message) error {
+	if topic == "" {
+		return ErrEmptyTopic
+	}
+	data, err := proto.Marshal(&msg)
+	if err != nil {
+		return err
+	}
+
+	ch, err := pub.confirmChannel()
+	if err != nil {
+		return err
+	}
+
+	dc, err := ch.PublishWithDeferredConfirmWithContext(ctx, exchangeName, subject(topic, msg), false, false, publishing(data))
+	if err != nil {
+		return err
+	}
+
+	acked := make(chan bool, 1)
+	go func() {
+		acked <- dc.Wait()
+	}()
+
+	select {
+	case ok := <-acked:
+		if !ok {
+			return messaging.ErrNotConfirmed
+		}
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (pub *publisher) Ping(_ context.Context) error {
+	if pub.conn.IsClosed() {
+		return amqp.ErrClosed
+	}
+
+	return nil
+}
+
 func (pub *publisher) Close() error {
 	if err := pub.ch.Close(); err != nil {
 		return err
@@ -82,6 +126,45 @@ func (pub *publisher) Close() error {
 	return pub.conn.Close()
 }
 
+func (pub *publisher) confirmChannel() (*amqp.Channel, error) {
+	pub.confirmMu.Lock()
+	defer pub.confirmMu.Unlock()
+
+	if pub.confirmCh != nil && !pub.confirmCh.IsClosed() {
+		return pub.confirmCh, nil
+	}
+
+	ch, err := pub.conn.Channel()
+	if err != nil {
+		return nil, err
+	}
+	if err := ch.Confirm(false); err != nil {
+		ch.Close()
+		return nil, err
+	}
+	pub.confirmCh = ch
+
+	return ch, nil
+}
+
+func subject(topic string, msg messaging.Message) string {
+	subject := fmt.Sprintf("%s.%s", chansPrefix, topic)
+	if msg.Subtopic != "" {
+		subject = fmt.Sprintf("%s.%s", subject, msg.Subtopic)
+	}
+
+	return formatTopic(subject)
+}
+
+func publishing(data []byte) amqp.Publishing {
+	return amqp.Publishing{
+		Headers:     amqp.Table{},
+		ContentType: "application/octet-stream",
+		AppId:       "mainflux-publisher",
+		Body:        data,
+	}
+}
+
 func formatTopic(topic string) string {
 	return strings.Replace(topic, ">", "#", -1)
 }
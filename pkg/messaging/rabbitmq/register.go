@@ -0,0 +1,16 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package rabbitmq
+
+import "github.com/MainfluxLabs/mainflux/pkg/messaging"
+
+// Schemes are the URL schemes of the RabbitMQ message broker.
+var Schemes = []string{"amqp", "amqps"}
+
+func init() {
+	messaging.Register(messaging.BrokerFactory{
+		NewPublisher: NewPublisher,
+		NewPubSub:    NewPubSub,
+	}, Schemes...)
+}
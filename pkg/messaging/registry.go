@@ -0,0 +1,118 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package messaging
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+
+	log "github.com/MainfluxLabs/mainflux/logger"
+)
+
+var (
+	// ErrUnsupportedBroker indicates that there is no message broker
+	// registered for the scheme of the broker URL.
+	ErrUnsupportedBroker = errors.New("unsupported message broker")
+
+	// ErrMalformedBrokerURL indicates that the message broker URL can't be parsed.
+	ErrMalformedBrokerURL = errors.New("malformed message broker URL")
+)
+
+// BrokerFactory creates the publishers and the pubsubs of a message broker.
+type BrokerFactory struct {
+	// NewPublisher returns the message publisher connected to the URL.
+	NewPublisher func(url string) (Publisher, error)
+
+	// NewPubSub returns the message publisher/subscriber connected to the
+	// URL. Subscribers with the same non-empty queue share the messages.
+	NewPubSub func(url, queue string, logger log.Logger) (PubSub, error)
+}
+
+var (
+	factoriesMu sync.RWMutex
+	factories   = map[string]BrokerFactory{}
+)
+
+// Register makes the message broker factory available for the broker URLs
+// with the given schemes. Broker implementations register themselves in
+// their init functions. Register panics if a scheme is registered twice.
+func Register(f BrokerFactory, schemes ...string) {
+	factoriesMu.Lock()
+	defer factoriesMu.Unlock()
+
+	for _, s := range schemes {
+		s = strings.ToLower(s)
+		if _, ok := factories[s]; ok {
+			panic(fmt.Sprintf("messaging: broker already registered for scheme %s", s))
+		}
+		factories[s] = f
+	}
+}
+
+// Schemes returns the sorted URL schemes of the registered message brokers.
+func Schemes() []string {
+	factoriesMu.RLock()
+	defer factoriesMu.RUnlock()
+
+	var schemes []string
+	for s := range factories {
+		schemes = append(schemes, s)
+	}
+	sort.Strings(schemes)
+
+	return schemes
+}
+
+// NewPublisher returns the publisher of the message broker registered for
+// the scheme of the URL.
+func NewPublisher(url string) (Publisher, error) {
+	f, err := factory(url)
+	if err != nil {
+		return nil, err
+	}
+
+	return f.NewPublisher(url)
+}
+
+// NewPubSub returns the publisher/subscriber of the message broker
+// registered for the scheme of the URL.
+func NewPubSub(url, queue string, logger log.Logger) (PubSub, error) {
+	f, err := factory(url)
+	if err != nil {
+		return nil, err
+	}
+
+	return f.NewPubSub(url, queue, logger)
+}
+
+// Scheme returns the lower-cased scheme of the message broker URL.
+func Scheme(brokerURL string) (string, error) {
+	u, err := url.Parse(brokerURL)
+	if err != nil || u.Scheme == "" {
+		return "", ErrMalformedBrokerURL
+	}
+
+	return strings.ToLower(u.Scheme), nil
+}
+
+func factory(url string) (BrokerFactory, error) {
+	scheme, err := Scheme(url)
+	if err != nil {
+		return BrokerFactory{}, err
+	}
+
+	factoriesMu.RLock()
+	defer factoriesMu.RUnlock()
+
+	f, ok := factories[scheme]
+	if !ok {
+		return BrokerFactory{}, ErrUnsupportedBroker
+	}
+
+	return f, nil
+}
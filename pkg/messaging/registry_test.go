@@ -0,0 +1,80 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package messaging_test
+
+import (
+	"fmt"
+	"testing"
+
+	log "github.com/MainfluxLabs/mainflux/logger"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+	"github.com/stretchr/testify/assert"
+)
+
+type publisher struct {
+	url string
+}
+
+func (pub publisher) Publish(topic string, msg messaging.Message) error {
+	return nil
+}
+
+func (pub publisher) Close() error {
+	return nil
+}
+
+func TestNewPublisher(t *testing.T) {
+	messaging.Register(messaging.BrokerFactory{
+		NewPublisher: func(url string) (messaging.Publisher, error) {
+			return publisher{url: url}, nil
+		},
+		NewPubSub: func(url, queue string, logger log.Logger) (messaging.PubSub, error) {
+			return nil, nil
+		},
+	}, "test", "tests")
+
+	cases := []struct {
+		desc string
+		url  string
+		err  error
+	}{
+		{
+			desc: "create publisher of registered broker",
+			url:  "test://localhost:4222",
+			err:  nil,
+		},
+		{
+			desc: "create publisher of broker registered with other scheme",
+			url:  "tests://localhost:4222",
+			err:  nil,
+		},
+		{
+			desc: "create publisher with upper case scheme",
+			url:  "TEST://localhost:4222",
+			err:  nil,
+		},
+		{
+			desc: "create publisher of unregistered broker",
+			url:  "kafka://localhost:9092",
+			err:  messaging.ErrUnsupportedBroker,
+		},
+		{
+			desc: "create publisher with URL without scheme",
+			url:  "localhost",
+			err:  messaging.ErrMalformedBrokerURL,
+		},
+	}
+
+	for _, tc := range cases {
+		pub, err := messaging.NewPublisher(tc.url)
+		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.err, err))
+		if err == nil {
+			assert.Equal(t, tc.url, pub.(publisher).url, fmt.Sprintf("%s: expected publisher of %s", tc.desc, tc.url))
+		}
+	}
+
+	assert.Panics(t, func() {
+		messaging.Register(messaging.BrokerFactory{}, "test")
+	}, "registering the scheme twice should panic")
+}
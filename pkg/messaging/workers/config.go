@@ -0,0 +1,42 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package workers
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/MainfluxLabs/mainflux"
+)
+
+const (
+	defWorkers   = "0"
+	defQueueSize = "100"
+
+	envWorkers   = "MF_SUBSCRIBER_WORKERS"
+	envQueueSize = "MF_SUBSCRIBER_QUEUE_SIZE"
+)
+
+// LoadConfig reads the worker pool configuration of the subscriptions from
+// the MF_SUBSCRIBER_WORKERS and MF_SUBSCRIBER_QUEUE_SIZE environment
+// variables. It returns nil if the messages are handled by the subscriber.
+func LoadConfig() (*Config, error) {
+	n, err := strconv.Atoi(mainflux.Env(envWorkers, defWorkers))
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s value: %s", envWorkers, err)
+	}
+	if n <= 0 {
+		return nil, nil
+	}
+
+	size, err := strconv.Atoi(mainflux.Env(envQueueSize, defQueueSize))
+	if err != nil || size < 0 {
+		return nil, fmt.Errorf("invalid %s value: %s", envQueueSize, mainflux.Env(envQueueSize, defQueueSize))
+	}
+
+	return &Config{
+		Workers:   n,
+		QueueSize: size,
+	}, nil
+}
@@ -0,0 +1,58 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package workers_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/MainfluxLabs/mainflux/pkg/messaging/workers"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadConfig(t *testing.T) {
+	cases := []struct {
+		desc      string
+		workers   string
+		queueSize string
+		cfg       *workers.Config
+		err       bool
+	}{
+		{
+			desc: "load default config",
+			cfg:  nil,
+		},
+		{
+			desc:    "load config with workers",
+			workers: "4",
+			cfg:     &workers.Config{Workers: 4, QueueSize: 100},
+		},
+		{
+			desc:      "load config with workers and queue size",
+			workers:   "4",
+			queueSize: "10",
+			cfg:       &workers.Config{Workers: 4, QueueSize: 10},
+		},
+		{
+			desc:    "load config with invalid workers",
+			workers: "many",
+			err:     true,
+		},
+		{
+			desc:      "load config with negative queue size",
+			workers:   "4",
+			queueSize: "-1",
+			err:       true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Setenv("MF_SUBSCRIBER_WORKERS", tc.workers)
+		t.Setenv("MF_SUBSCRIBER_QUEUE_SIZE", tc.queueSize)
+
+		cfg, err := workers.LoadConfig()
+		assert.Equal(t, tc.err, err != nil, fmt.Sprintf("%s: expected error %t got %s", tc.desc, tc.err, err))
+		assert.Equal(t, tc.cfg, cfg, fmt.Sprintf("%s: expected %v got %v", tc.desc, tc.cfg, cfg))
+	}
+}
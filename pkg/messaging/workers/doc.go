@@ -0,0 +1,6 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package workers contains the subscriber middleware handling the messages
+// of each subscription concurrently by a pool of workers.
+package workers
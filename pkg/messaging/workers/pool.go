@@ -0,0 +1,137 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package workers
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	log "github.com/MainfluxLabs/mainflux/logger"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+	"github.com/go-kit/kit/metrics"
+)
+
+// ErrPoolClosed indicates that the message is received after the
+// subscription is canceled.
+var ErrPoolClosed = errors.New("worker pool is closed")
+
+var _ messaging.AsyncMessageHandler = (*pool)(nil)
+
+// Config represents the worker pool configuration of the subscriptions.
+type Config struct {
+	// Workers is the number of the goroutines concurrently handling the
+	// messages of a subscription.
+	Workers int
+	// QueueSize is the number of the messages of a subscription waiting
+	// for a free worker. Once the queue is full, receiving of the messages
+	// of the subscription blocks until a worker takes a message.
+	QueueSize int
+}
+
+// Metrics represents the backpressure metrics of the worker pools, labeled
+// by the subscription topic.
+type Metrics struct {
+	// Queued tracks the number of the messages waiting for a worker.
+	Queued metrics.Gauge
+	// Blocked counts the messages received while the queue was full.
+	Blocked metrics.Counter
+	// Wait observes the time in seconds the blocked messages waited for
+	// the space in the queue.
+	Wait metrics.Histogram
+}
+
+type task struct {
+	msg  messaging.Message
+	done func(error)
+}
+
+type pool struct {
+	topic   string
+	handler messaging.MessageHandler
+	metrics Metrics
+	logger  log.Logger
+	tasks   chan task
+	mu      sync.RWMutex
+	closed  bool
+	wg      sync.WaitGroup
+}
+
+func newPool(topic string, h messaging.MessageHandler, cfg Config, m Metrics, logger log.Logger) *pool {
+	p := &pool{
+		topic:   topic,
+		handler: h,
+		metrics: m,
+		logger:  logger,
+		tasks:   make(chan task, cfg.QueueSize),
+	}
+
+	for i := 0; i < cfg.Workers; i++ {
+		p.wg.Add(1)
+		go p.work()
+	}
+
+	return p
+}
+
+// Handle queues the message for handling. Handling errors are logged,
+// since they can't be returned to the subscriber.
+func (p *pool) Handle(msg messaging.Message) error {
+	p.HandleAsync(msg, func(err error) {
+		if err != nil {
+			p.logger.Warn(fmt.Sprintf("Failed to handle Mainflux message: %s", err))
+		}
+	})
+
+	return nil
+}
+
+func (p *pool) HandleAsync(msg messaging.Message, done func(error)) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	if p.closed {
+		done(ErrPoolClosed)
+		return
+	}
+
+	t := task{msg: msg, done: done}
+	p.metrics.Queued.With("topic", p.topic).Add(1)
+	select {
+	case p.tasks <- t:
+	default:
+		p.metrics.Blocked.With("topic", p.topic).Add(1)
+		begin := time.Now()
+		p.tasks <- t
+		p.metrics.Wait.With("topic", p.topic).Observe(time.Since(begin).Seconds())
+	}
+}
+
+// Cancel waits for the queued messages to be handled and cancels the
+// subscription handler.
+func (p *pool) Cancel() error {
+	p.stop()
+	return p.handler.Cancel()
+}
+
+func (p *pool) stop() {
+	p.mu.Lock()
+	if !p.closed {
+		p.closed = true
+		close(p.tasks)
+	}
+	p.mu.Unlock()
+
+	p.wg.Wait()
+}
+
+func (p *pool) work() {
+	defer p.wg.Done()
+
+	for t := range p.tasks {
+		p.metrics.Queued.With("topic", p.topic).Add(-1)
+		t.done(p.handler.Handle(t.msg))
+	}
+}
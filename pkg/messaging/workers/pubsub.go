@@ -0,0 +1,51 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package workers
+
+import (
+	"context"
+
+	log "github.com/MainfluxLabs/mainflux/logger"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+)
+
+var _ messaging.PubSub = (*pubsub)(nil)
+
+type pubsub struct {
+	messaging.PubSub
+	cfg     Config
+	metrics Metrics
+	logger  log.Logger
+}
+
+// NewPubSub returns the pubsub which handles the messages of each
+// subscription by its own pool of workers. The messages of a subscription
+// are not handled in the order of receiving. Subscribers which acknowledge
+// the messages to the broker acknowledge them once a worker handles them.
+func NewPubSub(ps messaging.PubSub, cfg Config, m Metrics, logger log.Logger) messaging.PubSub {
+	return &pubsub{
+		PubSub:  ps,
+		cfg:     cfg,
+		metrics: m,
+		logger:  logger,
+	}
+}
+
+func (ps *pubsub) Subscribe(id, topic string, handler messaging.MessageHandler) error {
+	p := newPool(topic, handler, ps.cfg, ps.metrics, ps.logger)
+	if err := ps.PubSub.Subscribe(id, topic, p); err != nil {
+		p.stop()
+		return err
+	}
+
+	return nil
+}
+
+// Ping checks the connection of the underlying pubsub, if supported.
+func (ps *pubsub) Ping(ctx context.Context) error {
+	if pinger, ok := ps.PubSub.(messaging.Pinger); ok {
+		return pinger.Ping(ctx)
+	}
+	return nil
+}
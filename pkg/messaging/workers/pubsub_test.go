@@ -0,0 +1,177 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package workers_test
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/logger"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging/workers"
+	"github.com/go-kit/kit/metrics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	topic   = "channels.1"
+	timeout = time.Second
+)
+
+var errHandle = errors.New("failed to handle message")
+
+type subscriber struct {
+	messaging.Publisher
+	handlers map[string]messaging.MessageHandler
+}
+
+func (s *subscriber) Subscribe(id, topic string, h messaging.MessageHandler) error {
+	s.handlers[topic] = h
+	return nil
+}
+
+func (s *subscriber) Unsubscribe(id, topic string) error {
+	if err := s.handlers[topic].Cancel(); err != nil {
+		return err
+	}
+	delete(s.handlers, topic)
+	return nil
+}
+
+func (s *subscriber) Close() error {
+	return nil
+}
+
+// handler blocks handling of each message until it is released.
+type handler struct {
+	started  chan messaging.Message
+	release  chan struct{}
+	canceled bool
+}
+
+func (h *handler) Handle(msg messaging.Message) error {
+	h.started <- msg
+	<-h.release
+	if msg.Subtopic == "fail" {
+		return errHandle
+	}
+	return nil
+}
+
+func (h *handler) Cancel() error {
+	h.canceled = true
+	return nil
+}
+
+type metric struct {
+	mu    sync.Mutex
+	value float64
+}
+
+func (m *metric) With(labelValues ...string) *metric {
+	return m
+}
+
+func (m *metric) add(delta float64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.value += delta
+}
+
+func (m *metric) get() float64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.value
+}
+
+type gauge struct{ *metric }
+
+func (g gauge) With(labelValues ...string) metrics.Gauge { return g }
+func (g gauge) Set(value float64)                        {}
+func (g gauge) Add(delta float64)                        { g.add(delta) }
+
+type counter struct{ *metric }
+
+func (c counter) With(labelValues ...string) metrics.Counter { return c }
+func (c counter) Add(delta float64)                          { c.add(delta) }
+
+type histogram struct{ *metric }
+
+func (h histogram) With(labelValues ...string) metrics.Histogram { return h }
+func (h histogram) Observe(value float64)                        { h.add(1) }
+
+func TestSubscribe(t *testing.T) {
+	queued, blocked, wait := &metric{}, &metric{}, &metric{}
+	sub := &subscriber{handlers: map[string]messaging.MessageHandler{}}
+	ps := workers.NewPubSub(sub, workers.Config{Workers: 2, QueueSize: 1}, workers.Metrics{
+		Queued:  gauge{queued},
+		Blocked: counter{blocked},
+		Wait:    histogram{wait},
+	}, logger.NewMock())
+
+	h := &handler{started: make(chan messaging.Message), release: make(chan struct{})}
+	err := ps.Subscribe("1", topic, h)
+	require.Nil(t, err, fmt.Sprintf("subscribe got unexpected error: %s", err))
+
+	ah, ok := sub.handlers[topic].(messaging.AsyncMessageHandler)
+	require.True(t, ok, "subscription handler should handle messages asynchronously")
+
+	results := make(chan error, 4)
+	done := func(err error) { results <- err }
+
+	// Both workers handle the messages concurrently.
+	ah.HandleAsync(messaging.Message{Subtopic: "ok"}, done)
+	ah.HandleAsync(messaging.Message{Subtopic: "fail"}, done)
+	for i := 0; i < 2; i++ {
+		select {
+		case <-h.started:
+		case <-time.After(timeout):
+			t.Fatalf("message %d is not handled concurrently", i)
+		}
+	}
+
+	// The third message waits in the queue, and the fourth one blocks
+	// until the queue has space.
+	ah.HandleAsync(messaging.Message{Subtopic: "ok"}, done)
+	before := blocked.get()
+	blockedDone := make(chan struct{})
+	go func() {
+		ah.HandleAsync(messaging.Message{Subtopic: "ok"}, done)
+		close(blockedDone)
+	}()
+	assert.Eventually(t, func() bool { return blocked.get() == before+1 }, timeout, time.Millisecond, "fourth message should block on full queue")
+	select {
+	case <-blockedDone:
+		t.Fatal("fourth message should not be queued while queue is full")
+	default:
+	}
+
+	var errs []error
+	for i := 0; i < 4; i++ {
+		h.release <- struct{}{}
+		if i >= 2 {
+			continue
+		}
+		<-h.started
+	}
+	for i := 0; i < 4; i++ {
+		errs = append(errs, <-results)
+	}
+	<-blockedDone
+
+	assert.ElementsMatch(t, []error{nil, errHandle, nil, nil}, errs, "expected handling results of all messages")
+	assert.Equal(t, float64(0), queued.get(), "expected no queued messages")
+	assert.Equal(t, blocked.get(), wait.get(), "expected observed wait of each blocked message")
+
+	err = sub.Unsubscribe("1", topic)
+	assert.Nil(t, err, fmt.Sprintf("unsubscribe got unexpected error: %s", err))
+	assert.True(t, h.canceled, "subscription handler should be canceled")
+
+	ah.HandleAsync(messaging.Message{}, done)
+	assert.Equal(t, workers.ErrPoolClosed, <-results, "expected error handling message after cancel")
+}
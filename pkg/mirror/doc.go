@@ -0,0 +1,6 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package mirror contains the publishing of the copies of the messages to
+// the mirror channels configured by the channel profiles.
+package mirror
@@ -0,0 +1,133 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mirror
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/MainfluxLabs/mainflux"
+	"github.com/MainfluxLabs/mainflux/logger"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+)
+
+var (
+	_ messaging.Publisher = (*publisher)(nil)
+	_ messaging.PubSub    = (*pubsub)(nil)
+)
+
+type publisher struct {
+	messaging.Publisher
+	things mainflux.ThingsServiceClient
+	logger logger.Logger
+}
+
+// NewPublisher returns the publisher which publishes the copy of each
+// message to the mirror channel of its channel, following the chain of the
+// mirror channels until the channel without the mirror or the already
+// visited channel is reached.
+func NewPublisher(pub messaging.Publisher, things mainflux.ThingsServiceClient, logger logger.Logger) messaging.Publisher {
+	return &publisher{
+		Publisher: pub,
+		things:    things,
+		logger:    logger,
+	}
+}
+
+func (p *publisher) Publish(topic string, msg messaging.Message) error {
+	if err := p.Publisher.Publish(topic, msg); err != nil {
+		return err
+	}
+
+	p.mirror(context.Background(), msg, p.Publisher.Publish)
+	return nil
+}
+
+// PublishConfirmed publishes the message and its mirror copies using the
+// underlying publisher, if supported, waiting for the broker
+// acknowledgement of each of them.
+func (p *publisher) PublishConfirmed(ctx context.Context, topic string, msg messaging.Message) error {
+	if _, ok := p.Publisher.(messaging.Confirmer); !ok {
+		return messaging.ErrConfirmNotSupported
+	}
+
+	if err := messaging.PublishConfirmed(ctx, p.Publisher, topic, msg); err != nil {
+		return err
+	}
+
+	p.mirror(ctx, msg, func(topic string, msg messaging.Message) error {
+		return messaging.PublishConfirmed(ctx, p.Publisher, topic, msg)
+	})
+	return nil
+}
+
+// Ping checks the connection of the underlying publisher, if supported.
+func (p *publisher) Ping(ctx context.Context) error {
+	if pinger, ok := p.Publisher.(messaging.Pinger); ok {
+		return pinger.Ping(ctx)
+	}
+	return nil
+}
+
+// mirror publishes the copies of the already published message. Failures
+// are logged, since the message itself is accepted.
+func (p *publisher) mirror(ctx context.Context, msg messaging.Message, publish func(string, messaging.Message) error) {
+	visited := map[string]bool{msg.Channel: true}
+	chanID := msg.Channel
+	for {
+		profile, err := p.things.GetChannelProfile(ctx, &mainflux.ChannelID{Value: chanID})
+		if err != nil {
+			p.logger.Warn(fmt.Sprintf("Failed to retrieve profile of channel %s: %s", chanID, err))
+			return
+		}
+
+		mirrorTo := profile.GetMirrorTo()
+		if mirrorTo == "" {
+			return
+		}
+		if visited[mirrorTo] {
+			p.logger.Warn(fmt.Sprintf("Mirroring loop detected at channel %s, message of channel %s not mirrored to channel %s", chanID, msg.Channel, mirrorTo))
+			return
+		}
+		visited[mirrorTo] = true
+
+		id, err := messaging.NewID()
+		if err != nil {
+			p.logger.Warn(fmt.Sprintf("Failed to mirror message to channel %s: %s", mirrorTo, err))
+			return
+		}
+
+		m := msg
+		m.Id = id
+		m.Channel = mirrorTo
+		if err := publish(mirrorTo, m); err != nil {
+			p.logger.Warn(fmt.Sprintf("Failed to mirror message to channel %s: %s", mirrorTo, err))
+			return
+		}
+
+		chanID = mirrorTo
+	}
+}
+
+type pubsub struct {
+	*publisher
+	messaging.Subscriber
+}
+
+// NewPubSub returns the pubsub which publishes the copies of the messages
+// to the mirror channels on publishing.
+func NewPubSub(ps messaging.PubSub, things mainflux.ThingsServiceClient, logger logger.Logger) messaging.PubSub {
+	return &pubsub{
+		publisher: &publisher{
+			Publisher: ps,
+			things:    things,
+			logger:    logger,
+		},
+		Subscriber: ps,
+	}
+}
+
+func (ps *pubsub) Close() error {
+	return ps.Subscriber.Close()
+}
@@ -0,0 +1,103 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mirror_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/MainfluxLabs/mainflux"
+	"github.com/MainfluxLabs/mainflux/logger"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+	"github.com/MainfluxLabs/mainflux/pkg/mirror"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+)
+
+type thingsClient struct {
+	mainflux.ThingsServiceClient
+	mirrors map[string]string
+}
+
+func (tc thingsClient) GetChannelProfile(_ context.Context, req *mainflux.ChannelID, _ ...grpc.CallOption) (*mainflux.ChannelProfile, error) {
+	return &mainflux.ChannelProfile{MirrorTo: tc.mirrors[req.GetValue()]}, nil
+}
+
+type publisher struct {
+	published []messaging.Message
+}
+
+func (p *publisher) Publish(topic string, msg messaging.Message) error {
+	p.published = append(p.published, msg)
+	return nil
+}
+
+func (p *publisher) Close() error {
+	return nil
+}
+
+func TestPublish(t *testing.T) {
+	tc := thingsClient{
+		mirrors: map[string]string{
+			"chained":  "mirror",
+			"mirror":   "debug",
+			"looped":   "loop",
+			"loop":     "looped",
+			"self":     "self",
+			"analysed": "analytics",
+		},
+	}
+
+	cases := []struct {
+		desc     string
+		channel  string
+		channels []string
+	}{
+		{
+			desc:     "publish message of channel without mirror",
+			channel:  "plain",
+			channels: []string{"plain"},
+		},
+		{
+			desc:     "publish message of channel with mirror",
+			channel:  "analysed",
+			channels: []string{"analysed", "analytics"},
+		},
+		{
+			desc:     "publish message of channel with chained mirrors",
+			channel:  "chained",
+			channels: []string{"chained", "mirror", "debug"},
+		},
+		{
+			desc:     "publish message of channels mirrored to each other",
+			channel:  "looped",
+			channels: []string{"looped", "loop"},
+		},
+		{
+			desc:     "publish message of channel mirrored to itself",
+			channel:  "self",
+			channels: []string{"self"},
+		},
+	}
+
+	for _, c := range cases {
+		pub := &publisher{}
+		mp := mirror.NewPublisher(pub, tc, logger.NewMock())
+		msg := messaging.Message{Id: "id", Channel: c.channel, Payload: []byte("payload")}
+
+		err := mp.Publish(msg.Channel, msg)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s\n", c.desc, err))
+
+		var channels []string
+		ids := map[string]bool{}
+		for _, m := range pub.published {
+			channels = append(channels, m.Channel)
+			ids[m.Id] = true
+			assert.Equal(t, msg.Payload, m.Payload, fmt.Sprintf("%s: expected payload %s got %s\n", c.desc, msg.Payload, m.Payload))
+		}
+		assert.Equal(t, c.channels, channels, fmt.Sprintf("%s: expected channels %v got %v\n", c.desc, c.channels, channels))
+		assert.Equal(t, len(c.channels), len(ids), fmt.Sprintf("%s: expected %d distinct message IDs got %d\n", c.desc, len(c.channels), len(ids)))
+	}
+}
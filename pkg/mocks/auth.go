@@ -5,6 +5,7 @@ package mocks
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/MainfluxLabs/mainflux"
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
@@ -18,6 +19,8 @@ var _ mainflux.AuthServiceClient = (*authServiceMock)(nil)
 type authServiceMock struct {
 	roles        map[string]string
 	usersByEmail map[string]users.User
+	memberships  map[string][]string
+	policies     map[string]string
 }
 
 // NewAuthService creates mock of users service.
@@ -32,6 +35,8 @@ func NewAuthService(adminID string, userList []users.User) mainflux.AuthServiceC
 	return &authServiceMock{
 		roles:        roles,
 		usersByEmail: usersByEmail,
+		memberships:  make(map[string][]string),
+		policies:     make(map[string]string),
 	}
 }
 
@@ -63,6 +68,16 @@ func (svc authServiceMock) Authorize(ctx context.Context, req *mainflux.Authoriz
 		if svc.roles["root"] != u.ID {
 			return &empty.Empty{}, errors.ErrAuthorization
 		}
+	case "thing", "channel":
+		switch svc.policies[policyKey(u.ID, req.Subject, req.Object)] {
+		case "owner", "editor":
+		case "viewer":
+			if req.Action == "read_write" {
+				return &empty.Empty{}, errors.ErrAuthorization
+			}
+		default:
+			return &empty.Empty{}, errors.ErrAuthorization
+		}
 	default:
 		return &empty.Empty{}, errors.ErrAuthorization
 	}
@@ -75,13 +90,43 @@ func (svc authServiceMock) Members(ctx context.Context, req *mainflux.MembersReq
 }
 
 func (svc authServiceMock) Assign(ctx context.Context, req *mainflux.Assignment, _ ...grpc.CallOption) (r *empty.Empty, err error) {
-	panic("not implemented")
+	if _, ok := svc.usersByEmail[req.GetToken()]; !ok {
+		return &empty.Empty{}, errors.ErrAuthentication
+	}
+	svc.memberships[req.GetMemberID()] = append(svc.memberships[req.GetMemberID()], req.GetGroupID())
+
+	return &empty.Empty{}, nil
 }
 
 func (svc authServiceMock) AddPolicy(ctx context.Context, in *mainflux.PolicyReq, opts ...grpc.CallOption) (r *empty.Empty, err error) {
-	panic("not implemented")
+	if _, ok := svc.usersByEmail[in.GetToken()]; !ok {
+		return &empty.Empty{}, errors.ErrAuthentication
+	}
+	svc.policies[policyKey(in.GetMemberID(), in.GetSubject(), in.GetObject())] = in.GetPolicy()
+
+	return &empty.Empty{}, nil
+}
+
+func (svc authServiceMock) ClaimOwnership(ctx context.Context, in *mainflux.OwnershipReq, opts ...grpc.CallOption) (r *empty.Empty, err error) {
+	return &empty.Empty{}, nil
 }
 
 func (svc authServiceMock) AssignRole(ctx context.Context, in *mainflux.AssignRoleReq, opts ...grpc.CallOption) (r *empty.Empty, err error) {
 	panic("not implemented")
 }
+
+func (svc authServiceMock) RetrieveRole(ctx context.Context, req *mainflux.RetrieveRoleReq, _ ...grpc.CallOption) (r *mainflux.RetrieveRoleRes, err error) {
+	if svc.roles["root"] == req.GetId() {
+		return &mainflux.RetrieveRoleRes{Role: "root"}, nil
+	}
+
+	return &mainflux.RetrieveRoleRes{}, nil
+}
+
+func (svc authServiceMock) RetrieveMemberships(ctx context.Context, req *mainflux.MembershipsReq, _ ...grpc.CallOption) (r *mainflux.MembershipsRes, err error) {
+	return &mainflux.MembershipsRes{OrgIDs: svc.memberships[req.GetId()]}, nil
+}
+
+func policyKey(memberID, subject, object string) string {
+	return fmt.Sprintf("%s:%s:%s", memberID, subject, object)
+}
@@ -3,7 +3,11 @@
 
 package mocks
 
-import "github.com/MainfluxLabs/mainflux/pkg/messaging"
+import (
+	"context"
+
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+)
 
 type mockPublisher struct{}
 
@@ -16,6 +20,10 @@ func (pub mockPublisher) Publish(topic string, msg messaging.Message) error {
 	return nil
 }
 
+func (pub mockPublisher) PublishConfirmed(ctx context.Context, topic string, msg messaging.Message) error {
+	return nil
+}
+
 func (pub mockPublisher) Close() error {
 	return nil
 }
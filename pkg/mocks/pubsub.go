@@ -0,0 +1,161 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+)
+
+var _ messaging.PubSub = (*PubSub)(nil)
+
+// PubSub is the in-memory message publisher-subscriber whose failures are
+// scripted by the tests. Published messages are delivered synchronously to
+// the handlers subscribed to the matching topics.
+type PubSub struct {
+	mu            sync.Mutex
+	subs          map[string]subscription
+	published     []messaging.Message
+	publishErrs   []error
+	subscribeErrs []error
+	pingErr       error
+}
+
+type subscription struct {
+	topic   string
+	handler messaging.MessageHandler
+}
+
+// NewPubSub returns mock message publisher-subscriber.
+func NewPubSub() *PubSub {
+	return &PubSub{
+		subs: map[string]subscription{},
+	}
+}
+
+// FailPublish makes the next publishes return the given errors in order.
+// Nil errors let the message be published.
+func (ps *PubSub) FailPublish(errs ...error) {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+
+	ps.publishErrs = append(ps.publishErrs, errs...)
+}
+
+// FailSubscribe makes the next subscriptions return the given errors
+// in order. Nil errors let the subscription succeed.
+func (ps *PubSub) FailSubscribe(errs ...error) {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+
+	ps.subscribeErrs = append(ps.subscribeErrs, errs...)
+}
+
+// FailPing makes the connection checks return the given error, until it is
+// called with nil.
+func (ps *PubSub) FailPing(err error) {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+
+	ps.pingErr = err
+}
+
+// Published returns the successfully published messages.
+func (ps *PubSub) Published() []messaging.Message {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+
+	return append([]messaging.Message{}, ps.published...)
+}
+
+func (ps *PubSub) Publish(topic string, msg messaging.Message) error {
+	ps.mu.Lock()
+	if err := pop(&ps.publishErrs); err != nil {
+		ps.mu.Unlock()
+		return err
+	}
+	ps.published = append(ps.published, msg)
+	var handlers []messaging.MessageHandler
+	for _, s := range ps.subs {
+		if matches(s.topic, topic) {
+			handlers = append(handlers, s.handler)
+		}
+	}
+	ps.mu.Unlock()
+
+	for _, h := range handlers {
+		h.Handle(msg)
+	}
+
+	return nil
+}
+
+func (ps *PubSub) PublishConfirmed(ctx context.Context, topic string, msg messaging.Message) error {
+	return ps.Publish(topic, msg)
+}
+
+func (ps *PubSub) Subscribe(id, topic string, handler messaging.MessageHandler) error {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+
+	if err := pop(&ps.subscribeErrs); err != nil {
+		return err
+	}
+	ps.subs[id+topic] = subscription{topic: topic, handler: handler}
+
+	return nil
+}
+
+func (ps *PubSub) Unsubscribe(id, topic string) error {
+	ps.mu.Lock()
+	s, ok := ps.subs[id+topic]
+	delete(ps.subs, id+topic)
+	ps.mu.Unlock()
+
+	if !ok {
+		return nil
+	}
+	return s.handler.Cancel()
+}
+
+func (ps *PubSub) Ping(ctx context.Context) error {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+
+	return ps.pingErr
+}
+
+func (ps *PubSub) Close() error {
+	return nil
+}
+
+func pop(errs *[]error) error {
+	if len(*errs) == 0 {
+		return nil
+	}
+	err := (*errs)[0]
+	*errs = (*errs)[1:]
+
+	return err
+}
+
+// matches reports whether the subject matches the subscription topic,
+// which may contain the NATS wildcards.
+func matches(topic, subject string) bool {
+	tt := strings.Split(topic, ".")
+	st := strings.Split(subject, ".")
+	for i, t := range tt {
+		if t == ">" {
+			return len(st) > i
+		}
+		if i >= len(st) || (t != "*" && t != st[i]) {
+			return false
+		}
+	}
+
+	return len(tt) == len(st)
+}
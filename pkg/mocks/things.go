@@ -9,6 +9,7 @@ import (
 	"sync"
 
 	"github.com/MainfluxLabs/mainflux"
+	"github.com/MainfluxLabs/mainflux/pkg/acl"
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
 	"github.com/MainfluxLabs/mainflux/things"
 )
@@ -71,6 +72,10 @@ func (svc *mainfluxThings) ViewThing(_ context.Context, owner, id string) (thing
 	return things.Thing{}, errors.ErrNotFound
 }
 
+func (svc *mainfluxThings) ViewThingByExternalID(context.Context, string, string) (things.Thing, error) {
+	panic("not implemented")
+}
+
 func (svc *mainfluxThings) Connect(_ context.Context, owner, chID string, thIDs []string) error {
 	svc.mu.Lock()
 	defer svc.mu.Unlock()
@@ -161,10 +166,58 @@ func (svc *mainfluxThings) UpdateKey(context.Context, string, string, string) er
 	panic("not implemented")
 }
 
+func (svc *mainfluxThings) UpdateSigningKey(context.Context, string, things.SigningKey) error {
+	panic("not implemented")
+}
+
+func (svc *mainfluxThings) ViewSigningKey(context.Context, string, string) (things.SigningKey, error) {
+	panic("not implemented")
+}
+
+func (svc *mainfluxThings) RemoveSigningKey(context.Context, string, string) error {
+	panic("not implemented")
+}
+
+func (svc *mainfluxThings) GetSigningKey(context.Context, string) (things.SigningKey, error) {
+	panic("not implemented")
+}
+
+func (svc *mainfluxThings) GetTopicACL(context.Context, string) (acl.ACL, error) {
+	panic("not implemented")
+}
+
+func (svc *mainfluxThings) GetChannelGroup(context.Context, string) (things.Group, error) {
+	panic("not implemented")
+}
+
+func (svc *mainfluxThings) GetChannelProfile(context.Context, string) (things.ChannelProfile, error) {
+	panic("not implemented")
+}
+
+func (svc *mainfluxThings) GetChannel(context.Context, string) (things.Channel, error) {
+	panic("not implemented")
+}
+
 func (svc *mainfluxThings) ListThings(context.Context, string, bool, things.PageMetadata) (things.Page, error) {
 	panic("not implemented")
 }
 
+func (svc *mainfluxThings) SearchThings(context.Context, string, things.PageMetadata) (things.Page, error) {
+	panic("not implemented")
+}
+
+func (svc *mainfluxThings) ListChannelVersions(context.Context, string, string, things.PageMetadata) (things.ChannelVersionsPage, error) {
+	panic("not implemented")
+}
+
+func (svc *mainfluxThings) RollbackChannel(context.Context, string, string, uint64) (things.Channel, error) {
+	panic("not implemented")
+}
+
+func (svc *mainfluxThings) ListConnections(context.Context, string, things.ConnectionsFilter, things.PageMetadata) (things.ConnectionsPage, error) {
+	panic("not implemented")
+}
+
 func (svc *mainfluxThings) ViewChannelByThing(context.Context, string, string) (things.Channel, error) {
 	panic("not implemented")
 }
@@ -181,6 +234,10 @@ func (svc *mainfluxThings) Restore(context.Context, string, things.Backup) error
 	panic("not implemented")
 }
 
+func (svc *mainfluxThings) AuthorizeAdmin(context.Context, string) error {
+	panic("not implemented")
+}
+
 func (svc *mainfluxThings) CreateChannels(_ context.Context, owner string, chs ...things.Channel) ([]things.Channel, error) {
 	svc.mu.Lock()
 	defer svc.mu.Unlock()
@@ -207,6 +264,10 @@ func (svc *mainfluxThings) ListChannels(context.Context, string, bool, things.Pa
 	panic("not implemented")
 }
 
+func (svc *mainfluxThings) SearchChannels(context.Context, string, things.PageMetadata) (things.ChannelsPage, error) {
+	panic("not implemented")
+}
+
 func (svc *mainfluxThings) RemoveChannels(context.Context, string, ...string) error {
 	panic("not implemented")
 }
@@ -257,6 +318,10 @@ func (svc *mainfluxThings) ListGroups(ctx context.Context, token string, admin b
 	panic("not implemented")
 }
 
+func (svc *mainfluxThings) SearchGroups(ctx context.Context, token string, pm things.PageMetadata) (things.GroupPage, error) {
+	panic("not implemented")
+}
+
 func (svc *mainfluxThings) ListGroupsByIDs(ctx context.Context, groupIDs []string) ([]things.Group, error) {
 	panic("not implemented")
 }
@@ -273,8 +338,22 @@ func (svc *mainfluxThings) ViewGroup(ctx context.Context, token, id string) (thi
 	panic("not implemented")
 }
 
-func (svc *mainfluxThings) AssignThing(ctx context.Context, token string, groupID string, thingIDs ...string) error {
-	panic("not implemented")
+func (svc *mainfluxThings) AssignThing(_ context.Context, token string, groupID string, thingIDs ...string) error {
+	svc.mu.Lock()
+	defer svc.mu.Unlock()
+
+	userID, err := svc.auth.Identify(context.Background(), &mainflux.Token{Value: token})
+	if err != nil {
+		return errors.ErrAuthentication
+	}
+
+	for _, id := range thingIDs {
+		if t, ok := svc.things[id]; !ok || t.Owner != userID.Email {
+			return errors.ErrNotFound
+		}
+	}
+
+	return nil
 }
 
 func (svc *mainfluxThings) UnassignThing(ctx context.Context, token string, groupID string, thingIDs ...string) error {
@@ -293,6 +372,26 @@ func (svc *mainfluxThings) UnassignChannel(ctx context.Context, token string, gr
 	panic("not implemented")
 }
 
+func (svc *mainfluxThings) AssignThingsBatch(ctx context.Context, token, groupID string, thingIDs ...string) (things.BatchResult, error) {
+	panic("not implemented")
+}
+
+func (svc *mainfluxThings) UnassignThingsBatch(ctx context.Context, token, groupID string, thingIDs ...string) (things.BatchResult, error) {
+	panic("not implemented")
+}
+
+func (svc *mainfluxThings) AssignChannelsBatch(ctx context.Context, token, groupID string, channelIDs ...string) (things.BatchResult, error) {
+	panic("not implemented")
+}
+
+func (svc *mainfluxThings) UnassignChannelsBatch(ctx context.Context, token, groupID string, channelIDs ...string) (things.BatchResult, error) {
+	panic("not implemented")
+}
+
+func (svc *mainfluxThings) ViewGroupStats(ctx context.Context, token, groupID string) (things.GroupStats, error) {
+	panic("not implemented")
+}
+
 func (svc *mainfluxThings) ViewChannelMembership(ctx context.Context, token string, channelID string) (things.Group, error) {
 	panic("not implemented")
 }
@@ -300,3 +399,31 @@ func (svc *mainfluxThings) ViewChannelMembership(ctx context.Context, token stri
 func (svc *mainfluxThings) ListGroupChannels(ctx context.Context, token, groupID string, pm things.PageMetadata) (things.GroupChannelsPage, error) {
 	panic("not implemented")
 }
+
+func (svc *mainfluxThings) CreateGroupTemplate(ctx context.Context, token string, gt things.GroupTemplate) (things.GroupTemplate, error) {
+	panic("not implemented")
+}
+
+func (svc *mainfluxThings) ViewGroupTemplate(ctx context.Context, token, id string) (things.GroupTemplate, error) {
+	panic("not implemented")
+}
+
+func (svc *mainfluxThings) ListGroupTemplates(ctx context.Context, token string, pm things.PageMetadata) (things.GroupTemplatesPage, error) {
+	panic("not implemented")
+}
+
+func (svc *mainfluxThings) RemoveGroupTemplate(ctx context.Context, token, id string) error {
+	panic("not implemented")
+}
+
+func (svc *mainfluxThings) CreateGroupFromTemplate(ctx context.Context, token, templateID string, g things.Group) (things.Group, error) {
+	panic("not implemented")
+}
+
+func (svc *mainfluxThings) ExportGroup(ctx context.Context, token, groupID string) (things.GroupExport, error) {
+	panic("not implemented")
+}
+
+func (svc *mainfluxThings) ImportGroup(ctx context.Context, token string, ge things.GroupExport, rekey bool) (things.GroupExport, error) {
+	panic("not implemented")
+}
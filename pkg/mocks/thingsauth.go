@@ -52,7 +52,21 @@ func (svc thingsServiceMock) CanAccessByKey(ctx context.Context, in *mainflux.Ac
 	return &mainflux.ThingID{Value: token}, nil
 }
 
-func (svc thingsServiceMock) CanAccessByID(context.Context, *mainflux.AccessByIDReq, ...grpc.CallOption) (*empty.Empty, error) {
+func (svc thingsServiceMock) CanAccessByID(ctx context.Context, in *mainflux.AccessByIDReq, opts ...grpc.CallOption) (*empty.Empty, error) {
+	if in.GetThingID() == "" || in.GetThingID() == "invalid" {
+		return nil, errors.ErrAuthorization
+	}
+
+	for _, id := range svc.channels {
+		if id == in.GetChanID() {
+			return &empty.Empty{}, nil
+		}
+	}
+
+	return nil, errors.ErrAuthorization
+}
+
+func (svc thingsServiceMock) CanAccessBatch(context.Context, *mainflux.AccessBatchReq, ...grpc.CallOption) (*mainflux.AccessBatchRes, error) {
 	panic("not implemented")
 }
 
@@ -65,8 +79,13 @@ func (svc thingsServiceMock) IsChannelOwner(ctx context.Context, in *mainflux.Ch
 	return nil, errors.ErrAuthorization
 }
 
-func (svc thingsServiceMock) Identify(context.Context, *mainflux.Token, ...grpc.CallOption) (*mainflux.ThingID, error) {
-	panic("not implemented")
+func (svc thingsServiceMock) Identify(ctx context.Context, in *mainflux.Token, opts ...grpc.CallOption) (*mainflux.ThingID, error) {
+	key := in.GetValue()
+	if key == "" || key == "invalid" {
+		return nil, errors.ErrAuthentication
+	}
+
+	return &mainflux.ThingID{Value: key}, nil
 }
 
 func (svc thingsServiceMock) GetGroupsByIDs(ctx context.Context, req *mainflux.GroupsReq, opts ...grpc.CallOption) (*mainflux.GroupsRes, error) {
@@ -79,3 +98,23 @@ func (svc thingsServiceMock) GetGroupsByIDs(ctx context.Context, req *mainflux.G
 
 	return &mainflux.GroupsRes{Groups: groups}, nil
 }
+
+func (svc thingsServiceMock) GetSigningKey(context.Context, *mainflux.ThingID, ...grpc.CallOption) (*mainflux.SigningKey, error) {
+	return &mainflux.SigningKey{}, nil
+}
+
+func (svc thingsServiceMock) GetTopicACL(context.Context, *mainflux.ChannelID, ...grpc.CallOption) (*mainflux.TopicACL, error) {
+	return &mainflux.TopicACL{}, nil
+}
+
+func (svc thingsServiceMock) GetChannelGroup(context.Context, *mainflux.ChannelID, ...grpc.CallOption) (*mainflux.Group, error) {
+	return &mainflux.Group{}, nil
+}
+
+func (svc thingsServiceMock) GetChannelProfile(context.Context, *mainflux.ChannelID, ...grpc.CallOption) (*mainflux.ChannelProfile, error) {
+	return &mainflux.ChannelProfile{}, nil
+}
+
+func (svc thingsServiceMock) GetChannel(context.Context, *mainflux.ChannelID, ...grpc.CallOption) (*mainflux.Channel, error) {
+	panic("not implemented")
+}
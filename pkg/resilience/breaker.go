@@ -0,0 +1,73 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package resilience
+
+import (
+	"sync"
+	"time"
+)
+
+type state int
+
+const (
+	closed state = iota
+	open
+	halfOpen
+)
+
+// breaker opens after the configured number of consecutive failures.
+// Once the open timeout expires, a single probe call is let through
+// and the breaker is closed again if the probe succeeds.
+type breaker struct {
+	mu          sync.Mutex
+	maxFailures uint32
+	openTimeout time.Duration
+	state       state
+	failures    uint32
+	openedAt    time.Time
+}
+
+func newBreaker(maxFailures uint32, openTimeout time.Duration) *breaker {
+	return &breaker{
+		maxFailures: maxFailures,
+		openTimeout: openTimeout,
+	}
+}
+
+// allow reports whether the call can be made.
+func (b *breaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case open:
+		if time.Since(b.openedAt) < b.openTimeout {
+			return false
+		}
+		b.state = halfOpen
+		return true
+	case halfOpen:
+		return false
+	default:
+		return true
+	}
+}
+
+// done records the outcome of the allowed call.
+func (b *breaker) done(success bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if success {
+		b.state = closed
+		b.failures = 0
+		return
+	}
+
+	b.failures++
+	if b.state == halfOpen || b.failures >= b.maxFailures {
+		b.state = open
+		b.openedAt = time.Now()
+	}
+}
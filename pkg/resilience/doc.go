@@ -0,0 +1,7 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package resilience contains gRPC client interceptor which retries
+// failed idempotent calls and protects the called service with the
+// circuit breaker.
+package resilience
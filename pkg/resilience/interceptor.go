@@ -0,0 +1,90 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package resilience
+
+import (
+	"context"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	initialInterval = 50 * time.Millisecond
+	maxInterval     = time.Second
+)
+
+// ErrCircuitOpen indicates that the call is rejected because the circuit
+// breaker is open. The error has the Unavailable gRPC status code.
+var ErrCircuitOpen = status.Error(codes.Unavailable, "circuit breaker is open")
+
+// Config contains retries and circuit breaker configuration.
+type Config struct {
+	// MaxRetries is the number of retries of the failed idempotent call.
+	MaxRetries uint64
+	// MaxFailures is the number of consecutive failures which opens the breaker.
+	// The breaker is disabled if it's zero.
+	MaxFailures uint32
+	// OpenTimeout is the duration for which the open breaker rejects calls.
+	OpenTimeout time.Duration
+	// NonIdempotent contains full names of the methods which are never
+	// retried, e.g. "/mainflux.AuthService/Issue".
+	NonIdempotent []string
+}
+
+// UnaryClientInterceptor returns interceptor which retries failed idempotent
+// calls with the exponential backoff and rejects calls while the circuit
+// breaker is open. Only the calls which failed due to unavailability of the
+// service are retried and counted as breaker failures.
+func UnaryClientInterceptor(cfg Config) grpc.UnaryClientInterceptor {
+	b := newBreaker(cfg.MaxFailures, cfg.OpenTimeout)
+	nonIdempotent := make(map[string]bool)
+	for _, m := range cfg.NonIdempotent {
+		nonIdempotent[m] = true
+	}
+
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		call := func() error {
+			if cfg.MaxFailures > 0 && !b.allow() {
+				return backoff.Permanent(ErrCircuitOpen)
+			}
+
+			err := invoker(ctx, method, req, reply, cc, opts...)
+			failed := Unavailable(err)
+			if cfg.MaxFailures > 0 {
+				b.done(!failed)
+			}
+			if err != nil && !failed {
+				return backoff.Permanent(err)
+			}
+
+			return err
+		}
+
+		var retries uint64
+		if !nonIdempotent[method] {
+			retries = cfg.MaxRetries
+		}
+
+		bo := backoff.NewExponentialBackOff()
+		bo.InitialInterval = initialInterval
+		bo.MaxInterval = maxInterval
+
+		return backoff.Retry(call, backoff.WithContext(backoff.WithMaxRetries(bo, retries), ctx))
+	}
+}
+
+// Unavailable reports whether the call failed because the called
+// service is unavailable.
+func Unavailable(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,161 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package resilience_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/pkg/resilience"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	method        = "/mainflux.ThingsService/Identify"
+	nonIdempotent = "/mainflux.AuthService/Issue"
+	openTimeout   = 50 * time.Millisecond
+)
+
+var (
+	errUnavailable = status.Error(codes.Unavailable, "unavailable")
+	errNotFound    = status.Error(codes.NotFound, "not found")
+)
+
+// invoker fails the first failures calls with the given error.
+type invoker struct {
+	failures int
+	err      error
+	calls    int
+}
+
+func (i *invoker) invoke(_ context.Context, _ string, _, _ interface{}, _ *grpc.ClientConn, _ ...grpc.CallOption) error {
+	i.calls++
+	if i.calls <= i.failures {
+		return i.err
+	}
+	return nil
+}
+
+func TestRetries(t *testing.T) {
+	cfg := resilience.Config{
+		MaxRetries:    2,
+		NonIdempotent: []string{nonIdempotent},
+	}
+
+	cases := []struct {
+		desc     string
+		method   string
+		failures int
+		callErr  error
+		calls    int
+		err      error
+	}{
+		{
+			desc:     "call recovered by retries",
+			method:   method,
+			failures: 2,
+			callErr:  errUnavailable,
+			calls:    3,
+			err:      nil,
+		},
+		{
+			desc:     "call failing after all retries",
+			method:   method,
+			failures: 3,
+			callErr:  errUnavailable,
+			calls:    3,
+			err:      errUnavailable,
+		},
+		{
+			desc:     "call failing with non-retryable error",
+			method:   method,
+			failures: 1,
+			callErr:  errNotFound,
+			calls:    1,
+			err:      errNotFound,
+		},
+		{
+			desc:     "non-idempotent call failing with unavailable service",
+			method:   nonIdempotent,
+			failures: 1,
+			callErr:  errUnavailable,
+			calls:    1,
+			err:      errUnavailable,
+		},
+	}
+
+	for _, c := range cases {
+		inv := &invoker{failures: c.failures, err: c.callErr}
+		interceptor := resilience.UnaryClientInterceptor(cfg)
+		err := interceptor(context.Background(), c.method, nil, nil, nil, inv.invoke)
+		assert.Equal(t, c.err, err, fmt.Sprintf("%s: expected %s got %s\n", c.desc, c.err, err))
+		assert.Equal(t, c.calls, inv.calls, fmt.Sprintf("%s: expected %d calls got %d\n", c.desc, c.calls, inv.calls))
+	}
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	cfg := resilience.Config{
+		MaxFailures: 2,
+		OpenTimeout: openTimeout,
+	}
+	interceptor := resilience.UnaryClientInterceptor(cfg)
+	inv := &invoker{failures: 3, err: errUnavailable}
+
+	cases := []struct {
+		desc  string
+		sleep time.Duration
+		calls int
+		err   error
+	}{
+		{
+			desc:  "first failed call",
+			calls: 1,
+			err:   errUnavailable,
+		},
+		{
+			desc:  "call opening the breaker",
+			calls: 2,
+			err:   errUnavailable,
+		},
+		{
+			desc:  "call rejected by the open breaker",
+			calls: 2,
+			err:   resilience.ErrCircuitOpen,
+		},
+		{
+			desc:  "failed probe call reopening the breaker",
+			sleep: openTimeout,
+			calls: 3,
+			err:   errUnavailable,
+		},
+		{
+			desc:  "call rejected by the reopened breaker",
+			calls: 3,
+			err:   resilience.ErrCircuitOpen,
+		},
+		{
+			desc:  "successful probe call closing the breaker",
+			sleep: openTimeout,
+			calls: 4,
+			err:   nil,
+		},
+		{
+			desc:  "call through the closed breaker",
+			calls: 5,
+			err:   nil,
+		},
+	}
+
+	for _, c := range cases {
+		time.Sleep(c.sleep)
+		err := interceptor(context.Background(), method, nil, nil, nil, inv.invoke)
+		assert.Equal(t, c.err, err, fmt.Sprintf("%s: expected %s got %s\n", c.desc, c.err, err))
+		assert.Equal(t, c.calls, inv.calls, fmt.Sprintf("%s: expected %d calls got %d\n", c.desc, c.calls, inv.calls))
+	}
+}
@@ -4,6 +4,7 @@
 package sdk
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -11,6 +12,7 @@ import (
 	"strings"
 
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/gorilla/websocket"
 )
 
 func (sdk mfSDK) SendMessage(chanName, msg, key string) error {
@@ -85,3 +87,46 @@ func (sdk mfSDK) SetContentType(ct ContentType) error {
 
 	return nil
 }
+
+func (sdk mfSDK) SubscribeMessages(ctx context.Context, chanName, key string, handle func(payload []byte)) error {
+	chanNameParts := strings.SplitN(chanName, ".", 2)
+	chanID := chanNameParts[0]
+	subtopicPart := ""
+	if len(chanNameParts) == 2 {
+		subtopicPart = fmt.Sprintf("/%s", strings.Replace(chanNameParts[1], ".", "/", -1))
+	}
+
+	url := fmt.Sprintf("%s/channels/%s/messages%s", sdk.wsAdapterURL, chanID, subtopicPart)
+	header := http.Header{}
+	header.Set("Authorization", key)
+
+	conn, resp, err := sdk.dialer.DialContext(ctx, url, header)
+	if err != nil {
+		if resp != nil {
+			return errors.Wrap(ErrFailedSubscribe, errors.New(resp.Status))
+		}
+		return errors.Wrap(ErrFailedSubscribe, err)
+	}
+	defer conn.Close()
+
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			conn.Close()
+		case <-done:
+		}
+	}()
+
+	for {
+		_, payload, err := conn.ReadMessage()
+		if err != nil {
+			if ctx.Err() != nil || websocket.IsCloseError(err, websocket.CloseNormalClosure) {
+				return nil
+			}
+			return errors.Wrap(ErrFailedSubscribe, err)
+		}
+		handle(payload)
+	}
+}
@@ -4,6 +4,7 @@
 package sdk_test
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -15,13 +16,15 @@ import (
 	"github.com/MainfluxLabs/mainflux/logger"
 	"github.com/MainfluxLabs/mainflux/pkg/mocks"
 	sdk "github.com/MainfluxLabs/mainflux/pkg/sdk/go"
+	"github.com/MainfluxLabs/mainflux/pkg/signature"
+	"github.com/gorilla/websocket"
 	"github.com/opentracing/opentracing-go/mocktracer"
 	"github.com/stretchr/testify/assert"
 )
 
 func newMessageService(tc mainflux.ThingsServiceClient) adapter.Service {
 	pub := mocks.NewPublisher()
-	return adapter.New(pub, tc)
+	return adapter.New(pub, tc, signature.NewVerifier(tc, 0), "", nil)
 }
 
 func newMessageServer(svc adapter.Service) *httptest.Server {
@@ -133,3 +136,59 @@ func TestSetContentType(t *testing.T) {
 		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected error %s, got %s", tc.desc, tc.err, err))
 	}
 }
+
+func newWSServer(key string, msgs []string) *httptest.Server {
+	upgrader := websocket.Upgrader{}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != key {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		for _, m := range msgs {
+			conn.WriteMessage(websocket.TextMessage, []byte(m))
+		}
+		conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+	}))
+}
+
+func TestSubscribeMessages(t *testing.T) {
+	atoken := "auth_token"
+	msgs := []string{`[{"n":"current","v":1.6}]`, `[{"n":"current","v":1.7}]`}
+	ts := newWSServer(atoken, msgs)
+	defer ts.Close()
+
+	mainfluxSDK := sdk.NewSDK(sdk.Config{
+		WSAdapterURL: "ws" + ts.URL[len("http"):],
+	})
+
+	cases := map[string]struct {
+		auth string
+		msgs []string
+		err  error
+	}{
+		"subscribe to messages": {
+			auth: atoken,
+			msgs: msgs,
+			err:  nil,
+		},
+		"subscribe to messages with invalid key": {
+			auth: "invalid",
+			msgs: nil,
+			err:  createError(sdk.ErrFailedSubscribe, http.StatusForbidden),
+		},
+	}
+
+	for desc, tc := range cases {
+		var received []string
+		err := mainfluxSDK.SubscribeMessages(context.Background(), "1", tc.auth, func(payload []byte) {
+			received = append(received, string(payload))
+		})
+		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected error %s, got %s", desc, tc.err, err))
+		assert.Equal(t, tc.msgs, received, fmt.Sprintf("%s: expected messages %v got %v", desc, tc.msgs, received))
+	}
+}
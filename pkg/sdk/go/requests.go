@@ -37,3 +37,7 @@ type deleteThingsReq struct {
 type deleteGroupsReq struct {
 	GroupIDs []string `json:"group_ids"`
 }
+
+type refreshTokenReq struct {
+	RefreshToken string `json:"refresh_token"`
+}
@@ -11,7 +11,8 @@ import (
 )
 
 type tokenRes struct {
-	Token string `json:"token,omitempty"`
+	Token        string `json:"token,omitempty"`
+	RefreshToken string `json:"refresh_token,omitempty"`
 }
 
 type createThingsRes struct {
@@ -0,0 +1,177 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package sdk
+
+import (
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	defInitialBackoff = 100 * time.Millisecond
+	defMaxBackoff     = 5 * time.Second
+
+	headerRateLimit          = "X-RateLimit-Limit"
+	headerRateLimitRemaining = "X-RateLimit-Remaining"
+	headerRateLimitReset     = "X-RateLimit-Reset"
+	headerRetryAfter         = "Retry-After"
+)
+
+// RetryConfig contains the parameters of retrying the idempotent requests,
+// i.e. GET, HEAD, PUT, DELETE and OPTIONS, failed with the server error.
+// Requests are retried with the exponential backoff starting at the
+// InitialBackoff and capped at the MaxBackoff, and aren't retried if
+// MaxRetries is 0.
+type RetryConfig struct {
+	MaxRetries     int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+}
+
+// RateLimit contains the rate limit reported by the last response which
+// carried the rate limit headers.
+type RateLimit struct {
+	// Limit is the maximum number of requests in the current window.
+	Limit int
+
+	// Remaining is the number of requests remaining in the current window.
+	Remaining int
+
+	// Reset is the time the current window is reset.
+	Reset time.Time
+
+	// RetryAfter is the time to wait before the next request, reported by
+	// the rate limited responses.
+	RetryAfter time.Duration
+}
+
+type rateLimits struct {
+	mu    sync.RWMutex
+	limit RateLimit
+}
+
+func (rl *rateLimits) get() RateLimit {
+	rl.mu.RLock()
+	defer rl.mu.RUnlock()
+	return rl.limit
+}
+
+// update stores the rate limit reported by the response headers, if any.
+func (rl *rateLimits) update(h http.Header) {
+	limit, lok := headerInt(h, headerRateLimit)
+	remaining, rok := headerInt(h, headerRateLimitRemaining)
+	reset, sok := headerInt(h, headerRateLimitReset)
+	retryAfter, aok := headerInt(h, headerRetryAfter)
+	if !lok && !rok && !sok && !aok {
+		return
+	}
+
+	l := RateLimit{
+		Limit:      limit,
+		Remaining:  remaining,
+		RetryAfter: time.Duration(retryAfter) * time.Second,
+	}
+	if sok {
+		l.Reset = time.Unix(int64(reset), 0)
+	}
+
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+	rl.limit = l
+}
+
+func headerInt(h http.Header, key string) (int, bool) {
+	v, err := strconv.Atoi(h.Get(key))
+	if err != nil {
+		return 0, false
+	}
+	return v, true
+}
+
+// transport tracks the rate limits and retries the idempotent requests
+// failed with the server error.
+type transport struct {
+	next   http.RoundTripper
+	cfg    RetryConfig
+	limits *rateLimits
+}
+
+func newTransport(next http.RoundTripper, cfg RetryConfig, limits *rateLimits) http.RoundTripper {
+	if cfg.InitialBackoff <= 0 {
+		cfg.InitialBackoff = defInitialBackoff
+	}
+	if cfg.MaxBackoff <= 0 {
+		cfg.MaxBackoff = defMaxBackoff
+	}
+
+	return &transport{
+		next:   next,
+		cfg:    cfg,
+		limits: limits,
+	}
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	retries := 0
+	if idempotent(req.Method) && (req.Body == nil || req.GetBody != nil) {
+		retries = t.cfg.MaxRetries
+	}
+
+	backoff := t.cfg.InitialBackoff
+	for attempt := 0; ; attempt++ {
+		res, err := t.next.RoundTrip(req)
+		if err == nil {
+			t.limits.update(res.Header)
+		}
+		if attempt >= retries || (err == nil && res.StatusCode < http.StatusInternalServerError) {
+			return res, err
+		}
+
+		wait := backoff
+		if res != nil {
+			if ra, ok := headerInt(res.Header, headerRetryAfter); ok && time.Duration(ra)*time.Second > wait {
+				wait = time.Duration(ra) * time.Second
+			}
+			io.Copy(ioutil.Discard, res.Body)
+			res.Body.Close()
+		}
+		if wait > t.cfg.MaxBackoff {
+			wait = t.cfg.MaxBackoff
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+
+		if backoff *= 2; backoff > t.cfg.MaxBackoff {
+			backoff = t.cfg.MaxBackoff
+		}
+
+		if req.Body != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req = req.Clone(req.Context())
+			req.Body = body
+		}
+	}
+}
+
+func idempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete, http.MethodOptions:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,98 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package sdk_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	sdk "github.com/MainfluxLabs/mainflux/pkg/sdk/go"
+	"github.com/stretchr/testify/assert"
+)
+
+func newFailingServer(failures int32, status int, calls *int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Limit", "100")
+		w.Header().Set("X-RateLimit-Remaining", "99")
+		w.Header().Set("X-RateLimit-Reset", "1700000000")
+		if atomic.AddInt32(calls, 1) <= failures {
+			w.WriteHeader(status)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+}
+
+func TestRetry(t *testing.T) {
+	retry := sdk.RetryConfig{
+		MaxRetries:     2,
+		InitialBackoff: time.Millisecond,
+		MaxBackoff:     10 * time.Millisecond,
+	}
+
+	cases := []struct {
+		desc     string
+		retry    sdk.RetryConfig
+		failures int32
+		status   int
+		err      error
+		calls    int32
+	}{
+		{
+			desc:     "remove thing after server errors",
+			retry:    retry,
+			failures: 2,
+			status:   http.StatusServiceUnavailable,
+			err:      nil,
+			calls:    3,
+		},
+		{
+			desc:     "remove thing with server errors exceeding retries",
+			retry:    retry,
+			failures: 3,
+			status:   http.StatusServiceUnavailable,
+			err:      createError(sdk.ErrFailedRemoval, http.StatusServiceUnavailable),
+			calls:    3,
+		},
+		{
+			desc:     "remove thing with client error",
+			retry:    retry,
+			failures: 1,
+			status:   http.StatusNotFound,
+			err:      createError(sdk.ErrFailedRemoval, http.StatusNotFound),
+			calls:    1,
+		},
+		{
+			desc:     "remove thing after server error without retries",
+			retry:    sdk.RetryConfig{},
+			failures: 1,
+			status:   http.StatusInternalServerError,
+			err:      createError(sdk.ErrFailedRemoval, http.StatusInternalServerError),
+			calls:    1,
+		},
+	}
+
+	for _, tc := range cases {
+		var calls int32
+		ts := newFailingServer(tc.failures, tc.status, &calls)
+		mainfluxSDK := sdk.NewSDK(sdk.Config{
+			ThingsURL: ts.URL,
+			Retry:     tc.retry,
+		})
+
+		err := mainfluxSDK.DeleteThing("1", token)
+		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected error %s got %s", tc.desc, tc.err, err))
+		assert.Equal(t, tc.calls, atomic.LoadInt32(&calls), fmt.Sprintf("%s: expected %d calls got %d", tc.desc, tc.calls, calls))
+
+		rl := mainfluxSDK.RateLimit()
+		assert.Equal(t, 100, rl.Limit, fmt.Sprintf("%s: expected rate limit %d got %d", tc.desc, 100, rl.Limit))
+		assert.Equal(t, 99, rl.Remaining, fmt.Sprintf("%s: expected remaining rate limit %d got %d", tc.desc, 99, rl.Remaining))
+		assert.Equal(t, time.Unix(1700000000, 0), rl.Reset, fmt.Sprintf("%s: expected rate limit reset %s got %s", tc.desc, time.Unix(1700000000, 0), rl.Reset))
+		ts.Close()
+	}
+}
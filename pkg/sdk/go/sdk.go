@@ -4,6 +4,7 @@
 package sdk
 
 import (
+	"context"
 	"crypto/tls"
 	"encoding/json"
 	"errors"
@@ -15,6 +16,7 @@ import (
 
 	"github.com/MainfluxLabs/mainflux"
 	"github.com/MainfluxLabs/mainflux/internal/apiutil"
+	"github.com/gorilla/websocket"
 )
 
 const (
@@ -53,6 +55,9 @@ var (
 	// ErrFailedRead indicates that read messages failed.
 	ErrFailedRead = errors.New("failed to read messages")
 
+	// ErrFailedSubscribe indicates that subscribing to messages failed.
+	ErrFailedSubscribe = errors.New("failed to subscribe to messages")
+
 	// ErrInvalidContentType indicates that non-existent message content type
 	// was passed.
 	ErrInvalidContentType = errors.New("Unknown Content Type")
@@ -113,10 +118,18 @@ type Group struct {
 
 // Thing represents mainflux thing.
 type Thing struct {
-	ID       string                 `json:"id,omitempty"`
-	Name     string                 `json:"name,omitempty"`
-	Key      string                 `json:"key,omitempty"`
-	Metadata map[string]interface{} `json:"metadata,omitempty"`
+	ID         string                 `json:"id,omitempty"`
+	Name       string                 `json:"name,omitempty"`
+	Key        string                 `json:"key,omitempty"`
+	ExternalID string                 `json:"external_id,omitempty"`
+	Location   *Location              `json:"location,omitempty"`
+	Metadata   map[string]interface{} `json:"metadata,omitempty"`
+}
+
+// Location represents the geographic location of the thing.
+type Location struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
 }
 
 // Channel represents mainflux channel.
@@ -149,6 +162,17 @@ type SDK interface {
 	// CreateToken receives credentials and returns user token.
 	CreateToken(user User) (string, error)
 
+	// Login receives credentials and returns the user access token along
+	// with the refresh token.
+	Login(user User) (Token, error)
+
+	// RefreshToken returns the new access and refresh tokens given the
+	// refresh token.
+	RefreshToken(refreshToken string) (Token, error)
+
+	// RateLimit returns the rate limit reported by the last response.
+	RateLimit() RateLimit
+
 	// RegisterUser registers mainflux user.
 	RegisterUser(user User) (string, error)
 
@@ -264,6 +288,12 @@ type SDK interface {
 	// ReadMessages read messages of specified channel.
 	ReadMessages(chanID, token string) (MessagesPage, error)
 
+	// SubscribeMessages subscribes to the messages of the channel over the
+	// WebSocket adapter and calls handle with the payload of each received
+	// message, until the context is canceled or the adapter closes the
+	// connection.
+	SubscribeMessages(ctx context.Context, chanName, key string, handle func(payload []byte)) error
+
 	// SetContentType sets message content type.
 	SetContentType(ct ContentType) error
 
@@ -315,12 +345,15 @@ type mfSDK struct {
 	bootstrapURL   string
 	certsURL       string
 	httpAdapterURL string
+	wsAdapterURL   string
 	readerURL      string
 	thingsURL      string
 	usersURL       string
 
 	msgContentType ContentType
 	client         *http.Client
+	dialer         *websocket.Dialer
+	limits         *rateLimits
 }
 
 // Config contains sdk configuration parameters.
@@ -329,36 +362,54 @@ type Config struct {
 	BootstrapURL   string
 	CertsURL       string
 	HTTPAdapterURL string
+	WSAdapterURL   string
 	ReaderURL      string
 	ThingsURL      string
 	UsersURL       string
 
 	MsgContentType  ContentType
 	TLSVerification bool
+
+	// Retry configures the retries of the idempotent requests failed with
+	// the server error.
+	Retry RetryConfig
 }
 
 // NewSDK returns new mainflux SDK instance.
 func NewSDK(conf Config) SDK {
+	limits := &rateLimits{}
+	tr := &http.Transport{
+		TLSClientConfig: &tls.Config{
+			InsecureSkipVerify: !conf.TLSVerification,
+		},
+	}
+
 	return &mfSDK{
 		authURL:        conf.AuthURL,
 		bootstrapURL:   conf.BootstrapURL,
 		certsURL:       conf.CertsURL,
 		httpAdapterURL: conf.HTTPAdapterURL,
+		wsAdapterURL:   conf.WSAdapterURL,
 		readerURL:      conf.ReaderURL,
 		thingsURL:      conf.ThingsURL,
 		usersURL:       conf.UsersURL,
 
 		msgContentType: conf.MsgContentType,
 		client: &http.Client{
-			Transport: &http.Transport{
-				TLSClientConfig: &tls.Config{
-					InsecureSkipVerify: !conf.TLSVerification,
-				},
-			},
+			Transport: newTransport(tr, conf.Retry, limits),
+		},
+		dialer: &websocket.Dialer{
+			Proxy:           http.ProxyFromEnvironment,
+			TLSClientConfig: tr.TLSClientConfig,
 		},
+		limits: limits,
 	}
 }
 
+func (sdk mfSDK) RateLimit() RateLimit {
+	return sdk.limits.get()
+}
+
 func (sdk mfSDK) sendRequest(req *http.Request, token, contentType string) (*http.Response, error) {
 	if token != "" {
 		req.Header.Set("Authorization", apiutil.BearerPrefix+token)
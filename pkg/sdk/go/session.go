@@ -0,0 +1,146 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package sdk
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+)
+
+// expirySkew is the time before the access token expiry at which the token
+// is refreshed, so the requests don't fail due to the clock skew.
+const expirySkew = 30 * time.Second
+
+var errUnauthorized = errors.New(fmt.Sprintf("%d %s", http.StatusUnauthorized, http.StatusText(http.StatusUnauthorized)))
+
+// Token contains the user access token along with the refresh token used to
+// obtain the new access token once it expires.
+type Token struct {
+	AccessToken  string
+	RefreshToken string
+}
+
+// Session keeps the access token of the user valid, so the long-running
+// integrations don't need to handle the token expiry.
+type Session interface {
+	// Token returns the valid access token. The expired access token is
+	// refreshed using the refresh token, and the user logs in again if the
+	// refresh fails.
+	Token() (string, error)
+
+	// Do calls fn with the valid access token. If fn fails because the
+	// access token is rejected, the token is refreshed and fn is called once
+	// more.
+	Do(fn func(token string) error) error
+}
+
+type session struct {
+	sdk     SDK
+	user    User
+	mu      sync.Mutex
+	token   Token
+	expires time.Time
+}
+
+// NewSession returns the session of the user, logging the user in on the
+// first use.
+func NewSession(sdk SDK, user User) Session {
+	return &session{
+		sdk:  sdk,
+		user: user,
+	}
+}
+
+func (s *session) Token() (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token.AccessToken != "" && (s.expires.IsZero() || time.Now().Add(expirySkew).Before(s.expires)) {
+		return s.token.AccessToken, nil
+	}
+
+	if err := s.renew(); err != nil {
+		return "", err
+	}
+
+	return s.token.AccessToken, nil
+}
+
+func (s *session) Do(fn func(token string) error) error {
+	token, err := s.Token()
+	if err != nil {
+		return err
+	}
+
+	if err := fn(token); !errors.Contains(err, errUnauthorized) {
+		return err
+	}
+
+	s.invalidate(token)
+	if token, err = s.Token(); err != nil {
+		return err
+	}
+
+	return fn(token)
+}
+
+// renew refreshes the access token, falling back to the login if there is
+// no refresh token or the refresh fails.
+func (s *session) renew() error {
+	var t Token
+	var err error
+	if s.token.RefreshToken != "" {
+		t, err = s.sdk.RefreshToken(s.token.RefreshToken)
+	}
+	if s.token.RefreshToken == "" || err != nil {
+		if t, err = s.sdk.Login(s.user); err != nil {
+			return err
+		}
+	}
+
+	s.token = t
+	s.expires = expiry(t.AccessToken)
+	return nil
+}
+
+// invalidate marks the rejected access token as expired, unless it was
+// already renewed by the concurrent call.
+func (s *session) invalidate(token string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token.AccessToken == token {
+		s.token.AccessToken = ""
+	}
+}
+
+// expiry returns the expiry time of the JWT access token. It returns zero
+// time if the token isn't a JWT or has no expiry.
+func expiry(token string) time.Time {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return time.Time{}
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return time.Time{}
+	}
+
+	var claims struct {
+		ExpiresAt int64 `json:"exp"`
+	}
+	if err := json.Unmarshal(data, &claims); err != nil || claims.ExpiresAt == 0 {
+		return time.Time{}
+	}
+
+	return time.Unix(claims.ExpiresAt, 0)
+}
@@ -0,0 +1,129 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package sdk_test
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	sdk "github.com/MainfluxLabs/mainflux/pkg/sdk/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// tokenServer issues the JWT-like access tokens with the given lifetime and
+// counts the logins and the refreshes.
+type tokenServer struct {
+	mu        sync.Mutex
+	lifetime  time.Duration
+	issued    int
+	logins    int
+	refreshes int
+}
+
+func (ts *tokenServer) issue(w http.ResponseWriter) {
+	ts.issued++
+	claims, _ := json.Marshal(map[string]int64{"exp": time.Now().Add(ts.lifetime).Unix()})
+	access := fmt.Sprintf("header.%s.%d", base64.RawURLEncoding.EncodeToString(claims), ts.issued)
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(map[string]string{
+		"token":         access,
+		"refresh_token": fmt.Sprintf("refresh-%d", ts.issued),
+	})
+}
+
+func (ts *tokenServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	switch r.URL.Path {
+	case "/tokens":
+		ts.logins++
+		ts.issue(w)
+	case "/tokens/refresh":
+		ts.refreshes++
+		ts.issue(w)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func TestSessionToken(t *testing.T) {
+	cases := []struct {
+		desc      string
+		lifetime  time.Duration
+		logins    int
+		refreshes int
+	}{
+		{
+			desc:      "reuse valid access token",
+			lifetime:  time.Hour,
+			logins:    1,
+			refreshes: 0,
+		},
+		{
+			desc:      "refresh expired access token",
+			lifetime:  time.Second,
+			logins:    1,
+			refreshes: 1,
+		},
+	}
+
+	for _, tc := range cases {
+		srv := &tokenServer{lifetime: tc.lifetime}
+		ts := httptest.NewServer(srv)
+		mainfluxSDK := sdk.NewSDK(sdk.Config{
+			AuthURL:  ts.URL,
+			UsersURL: ts.URL,
+		})
+		s := sdk.NewSession(mainfluxSDK, sdk.User{Email: email, Password: "password"})
+
+		first, err := s.Token()
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+		second, err := s.Token()
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+
+		assert.Equal(t, tc.refreshes == 0, first == second, fmt.Sprintf("%s: expected token reuse %t", tc.desc, tc.refreshes == 0))
+		assert.Equal(t, tc.logins, srv.logins, fmt.Sprintf("%s: expected %d logins got %d", tc.desc, tc.logins, srv.logins))
+		assert.Equal(t, tc.refreshes, srv.refreshes, fmt.Sprintf("%s: expected %d refreshes got %d", tc.desc, tc.refreshes, srv.refreshes))
+		ts.Close()
+	}
+}
+
+func TestSessionDo(t *testing.T) {
+	srv := &tokenServer{lifetime: time.Hour}
+	ts := httptest.NewServer(srv)
+	defer ts.Close()
+
+	mainfluxSDK := sdk.NewSDK(sdk.Config{
+		AuthURL:  ts.URL,
+		UsersURL: ts.URL,
+	})
+	s := sdk.NewSession(mainfluxSDK, sdk.User{Email: email, Password: "password"})
+
+	var tokens []string
+	err := s.Do(func(token string) error {
+		tokens = append(tokens, token)
+		if len(tokens) == 1 {
+			return createError(sdk.ErrFailedFetch, http.StatusUnauthorized)
+		}
+		return nil
+	})
+	assert.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	require.Len(t, tokens, 2, fmt.Sprintf("expected 2 calls got %d", len(tokens)))
+	assert.NotEqual(t, tokens[0], tokens[1], "expected refreshed token on retry")
+	assert.Equal(t, 1, srv.refreshes, fmt.Sprintf("expected 1 refresh got %d", srv.refreshes))
+
+	err = s.Do(func(token string) error {
+		return createError(sdk.ErrFailedFetch, http.StatusNotFound)
+	})
+	assert.Equal(t, createError(sdk.ErrFailedFetch, http.StatusNotFound), err, fmt.Sprintf("expected error %s got %s", createError(sdk.ErrFailedFetch, http.StatusNotFound), err))
+	assert.Equal(t, 1, srv.refreshes, fmt.Sprintf("expected 1 refresh got %d", srv.refreshes))
+}
@@ -50,11 +50,12 @@ func newThingsService() things.Service {
 	thingsRepo := thmocks.NewThingRepository(conns)
 	channelsRepo := thmocks.NewChannelRepository(thingsRepo, conns)
 	groupsRepo := thmocks.NewGroupRepository()
+	templatesRepo := thmocks.NewGroupTemplateRepository()
 	chanCache := thmocks.NewChannelCache()
 	thingCache := thmocks.NewThingCache()
 	idProvider := uuid.NewMock()
 
-	return things.New(auth, thingsRepo, channelsRepo, groupsRepo, chanCache, thingCache, idProvider)
+	return things.New(auth, thingsRepo, channelsRepo, groupsRepo, templatesRepo, chanCache, thingCache, idProvider, idProvider, idProvider, idProvider, nil, nil)
 }
 
 func newThingsServer(svc things.Service) *httptest.Server {
@@ -261,7 +262,7 @@ func TestThing(t *testing.T) {
 	for _, tc := range cases {
 		respTh, err := mainfluxSDK.Thing(tc.thID, tc.token)
 		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected error %s, got %s", tc.desc, tc.err, err))
-		assert.Equal(t, tc.response, respTh, fmt.Sprintf("%s: expected response thing %s, got %s", tc.desc, tc.response, respTh))
+		assert.Equal(t, tc.response, respTh, fmt.Sprintf("%s: expected response thing %v, got %v", tc.desc, tc.response, respTh))
 	}
 }
 
@@ -362,7 +363,7 @@ func TestThings(t *testing.T) {
 		}
 		page, err := mainfluxSDK.Things(tc.token, filter)
 		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected error %s, got %s", tc.desc, tc.err, err))
-		assert.Equal(t, tc.response, page.Things, fmt.Sprintf("%s: expected response channel %s, got %s", tc.desc, tc.response, page.Things))
+		assert.Equal(t, tc.response, page.Things, fmt.Sprintf("%s: expected response channel %v, got %v", tc.desc, tc.response, page.Things))
 	}
 }
 
@@ -510,7 +511,7 @@ func TestThingsByChannel(t *testing.T) {
 	for _, tc := range cases {
 		page, err := mainfluxSDK.ThingsByChannel(tc.token, tc.channel, tc.offset, tc.limit, tc.disconnected)
 		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected error %s, got %s", tc.desc, tc.err, err))
-		assert.Equal(t, tc.response, page.Things, fmt.Sprintf("%s: expected response channel %s, got %s", tc.desc, tc.response, page.Things))
+		assert.Equal(t, tc.response, page.Things, fmt.Sprintf("%s: expected response channel %v, got %v", tc.desc, tc.response, page.Things))
 	}
 }
 
@@ -18,6 +18,7 @@ const (
 	usersEndpoint        = "users"
 	registrationEndpoint = "register"
 	tokensEndpoint       = "tokens"
+	refreshEndpoint      = "tokens/refresh"
 	passwordEndpoint     = "password"
 	membersEndpoint      = "members"
 )
@@ -135,33 +136,56 @@ func (sdk mfSDK) Users(token string, pm PageMetadata) (UsersPage, error) {
 }
 
 func (sdk mfSDK) CreateToken(user User) (string, error) {
-	data, err := json.Marshal(user)
+	t, err := sdk.Login(user)
 	if err != nil {
 		return "", err
 	}
 
+	return t.AccessToken, nil
+}
+
+func (sdk mfSDK) Login(user User) (Token, error) {
+	data, err := json.Marshal(user)
+	if err != nil {
+		return Token{}, err
+	}
+
 	url := fmt.Sprintf("%s/%s", sdk.usersURL, tokensEndpoint)
+	return sdk.createToken(url, data)
+}
+
+func (sdk mfSDK) RefreshToken(refreshToken string) (Token, error) {
+	data, err := json.Marshal(refreshTokenReq{RefreshToken: refreshToken})
+	if err != nil {
+		return Token{}, err
+	}
+
+	url := fmt.Sprintf("%s/%s", sdk.authURL, refreshEndpoint)
+	return sdk.createToken(url, data)
+}
+
+func (sdk mfSDK) createToken(url string, data []byte) (Token, error) {
 	resp, err := sdk.client.Post(url, string(CTJSON), bytes.NewReader(data))
 	if err != nil {
-		return "", err
+		return Token{}, err
 	}
 	defer resp.Body.Close()
 
 	body, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
-		return "", err
+		return Token{}, err
 	}
 
 	if resp.StatusCode != http.StatusCreated {
-		return "", errors.Wrap(ErrFailedCreation, errors.New(resp.Status))
+		return Token{}, errors.Wrap(ErrFailedCreation, errors.New(resp.Status))
 	}
 
 	var tr tokenRes
 	if err := json.Unmarshal(body, &tr); err != nil {
-		return "", err
+		return Token{}, err
 	}
 
-	return tr.Token, nil
+	return Token{AccessToken: tr.Token, RefreshToken: tr.RefreshToken}, nil
 }
 
 func (sdk mfSDK) UpdateUser(u User, token string) error {
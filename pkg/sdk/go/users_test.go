@@ -10,6 +10,7 @@ import (
 	"net/http/httptest"
 	"regexp"
 	"testing"
+	"time"
 
 	"github.com/MainfluxLabs/mainflux/logger"
 	"github.com/MainfluxLabs/mainflux/pkg/mocks"
@@ -28,6 +29,8 @@ const (
 	userEmail    = "user@example.com"
 	validPass    = "validPass"
 	registerUser = "register@example.com"
+
+	inviteDuration = 24 * time.Hour
 )
 
 var (
@@ -40,13 +43,16 @@ var (
 
 func newUserService() users.Service {
 	usersRepo := usmocks.NewUserRepository(usersList)
+	totpRepo := usmocks.NewTOTPRepository()
+	invitationRepo := usmocks.NewInvitationRepository()
+	brandingRepo := usmocks.NewBrandingRepository()
 	hasher := usmocks.NewHasher()
 	idProvider := uuid.New()
 	admin.ID, _ = idProvider.ID()
 	auth := mocks.NewAuthService(admin.ID, usersList)
 	emailer := usmocks.NewEmailer()
 
-	return users.New(usersRepo, hasher, auth, emailer, idProvider, passRegex)
+	return users.New(usersRepo, totpRepo, invitationRepo, brandingRepo, hasher, auth, emailer, idProvider, passRegex, inviteDuration)
 }
 
 func newUserServer(svc users.Service) *httptest.Server {
@@ -67,8 +73,9 @@ func TestCreateUser(t *testing.T) {
 
 	sdkUser := sdk.User{Email: registerUser, Password: validPass}
 
-	token, err := svc.Login(context.Background(), admin)
+	tokenRes, err := svc.Login(context.Background(), admin, users.Client{})
 	require.Nil(t, err, fmt.Sprintf("unexpected error login: %s", err))
+	token := tokenRes.AccessToken
 
 	mainfluxSDK := sdk.NewSDK(sdkConf)
 	cases := []struct {
@@ -201,8 +208,9 @@ func TestCreateToken(t *testing.T) {
 	mainfluxSDK := sdk.NewSDK(sdkConf)
 	sdkUser := sdk.User{Email: userEmail, Password: validPass}
 
-	token, err := svc.Login(context.Background(), users.User{Email: sdkUser.Email, Password: sdkUser.Password})
+	tokenRes, err := svc.Login(context.Background(), users.User{Email: sdkUser.Email, Password: sdkUser.Password}, users.Client{})
 	require.Nil(t, err, fmt.Sprintf("unexpected error login: %s", err))
+	token := tokenRes.AccessToken
 
 	cases := []struct {
 		desc  string
@@ -0,0 +1,6 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package sequence contains the assignment of the per publisher
+// monotonically increasing sequence numbers to the messages at ingress.
+package sequence
@@ -0,0 +1,97 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package sequence
+
+import (
+	"context"
+
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+)
+
+var (
+	_ messaging.Publisher = (*publisher)(nil)
+	_ messaging.PubSub    = (*pubsub)(nil)
+)
+
+type publisher struct {
+	messaging.Publisher
+	seq Sequencer
+}
+
+// NewPublisher returns the publisher which assigns the sequence numbers to
+// the messages of publishers before publishing them.
+func NewPublisher(pub messaging.Publisher, seq Sequencer) messaging.Publisher {
+	return &publisher{
+		Publisher: pub,
+		seq:       seq,
+	}
+}
+
+func (p *publisher) Publish(topic string, msg messaging.Message) error {
+	msg, err := p.assign(context.Background(), msg)
+	if err != nil {
+		return err
+	}
+
+	return p.Publisher.Publish(topic, msg)
+}
+
+// PublishConfirmed assigns the sequence number to the message and publishes
+// it using the underlying publisher, if supported, waiting for the broker
+// acknowledgement.
+func (p *publisher) PublishConfirmed(ctx context.Context, topic string, msg messaging.Message) error {
+	if _, ok := p.Publisher.(messaging.Confirmer); !ok {
+		return messaging.ErrConfirmNotSupported
+	}
+
+	msg, err := p.assign(ctx, msg)
+	if err != nil {
+		return err
+	}
+
+	return messaging.PublishConfirmed(ctx, p.Publisher, topic, msg)
+}
+
+// Ping checks the connection of the underlying publisher, if supported.
+func (p *publisher) Ping(ctx context.Context) error {
+	if pinger, ok := p.Publisher.(messaging.Pinger); ok {
+		return pinger.Ping(ctx)
+	}
+	return nil
+}
+
+func (p *publisher) assign(ctx context.Context, msg messaging.Message) (messaging.Message, error) {
+	if msg.Publisher == "" || msg.Seq != 0 {
+		return msg, nil
+	}
+
+	seq, err := p.seq.Next(ctx, msg.Publisher)
+	if err != nil {
+		return messaging.Message{}, err
+	}
+	msg.Seq = seq
+
+	return msg, nil
+}
+
+type pubsub struct {
+	*publisher
+	messaging.Subscriber
+}
+
+// NewPubSub returns the pubsub which assigns the sequence numbers to the
+// messages of publishers on publishing.
+func NewPubSub(ps messaging.PubSub, seq Sequencer) messaging.PubSub {
+	return &pubsub{
+		publisher: &publisher{
+			Publisher: ps,
+			seq:       seq,
+		},
+		Subscriber: ps,
+	}
+}
+
+func (ps *pubsub) Close() error {
+	return ps.Subscriber.Close()
+}
@@ -0,0 +1,85 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package sequence_test
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+	"github.com/MainfluxLabs/mainflux/pkg/sequence"
+	"github.com/stretchr/testify/assert"
+)
+
+type sequencer struct {
+	mu   sync.Mutex
+	last map[string]uint64
+}
+
+func (s *sequencer) Next(_ context.Context, publisher string) (uint64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.last[publisher]++
+	return s.last[publisher], nil
+}
+
+type publisher struct {
+	published []messaging.Message
+}
+
+func (p *publisher) Publish(topic string, msg messaging.Message) error {
+	p.published = append(p.published, msg)
+	return nil
+}
+
+func (p *publisher) Close() error {
+	return nil
+}
+
+func TestPublish(t *testing.T) {
+	pub := &publisher{}
+	sp := sequence.NewPublisher(pub, &sequencer{last: map[string]uint64{}})
+
+	cases := []struct {
+		desc string
+		msg  messaging.Message
+		seq  uint64
+	}{
+		{
+			desc: "publish first message of publisher",
+			msg:  messaging.Message{Channel: "1", Publisher: "1"},
+			seq:  1,
+		},
+		{
+			desc: "publish second message of publisher",
+			msg:  messaging.Message{Channel: "1", Publisher: "1"},
+			seq:  2,
+		},
+		{
+			desc: "publish first message of other publisher",
+			msg:  messaging.Message{Channel: "1", Publisher: "2"},
+			seq:  1,
+		},
+		{
+			desc: "publish message with assigned sequence number",
+			msg:  messaging.Message{Channel: "1", Publisher: "1", Seq: 10},
+			seq:  10,
+		},
+		{
+			desc: "publish message without publisher",
+			msg:  messaging.Message{Channel: "1"},
+			seq:  0,
+		},
+	}
+
+	for i, tc := range cases {
+		err := sp.Publish(tc.msg.Channel, tc.msg)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s\n", tc.desc, err))
+		seq := pub.published[i].Seq
+		assert.Equal(t, tc.seq, seq, fmt.Sprintf("%s: expected sequence number %d got %d\n", tc.desc, tc.seq, seq))
+	}
+}
@@ -0,0 +1,45 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package sequence
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/go-redis/redis/v8"
+)
+
+const keyPrefix = "seq"
+
+// ErrAssignSequence indicates failure to assign the sequence number.
+var ErrAssignSequence = errors.New("failed to assign sequence number")
+
+// Sequencer assigns the sequence numbers to the messages of publishers.
+type Sequencer interface {
+	// Next returns the next sequence number of the publisher, starting
+	// from 1.
+	Next(ctx context.Context, publisher string) (uint64, error)
+}
+
+var _ Sequencer = (*redisSequencer)(nil)
+
+type redisSequencer struct {
+	client *redis.Client
+}
+
+// NewRedisSequencer returns sequencer which keeps the last sequence numbers
+// in Redis, so they are shared by all the adapters.
+func NewRedisSequencer(client *redis.Client) Sequencer {
+	return &redisSequencer{client: client}
+}
+
+func (rs *redisSequencer) Next(ctx context.Context, publisher string) (uint64, error) {
+	seq, err := rs.client.Incr(ctx, fmt.Sprintf("%s:%s", keyPrefix, publisher)).Uint64()
+	if err != nil {
+		return 0, errors.Wrap(ErrAssignSequence, err)
+	}
+
+	return seq, nil
+}
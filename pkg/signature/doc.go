@@ -0,0 +1,6 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package signature contains the verification of the message payloads
+// signed by things using HMAC-SHA256 or Ed25519.
+package signature
@@ -0,0 +1,42 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package signature
+
+import (
+	"context"
+
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+	"github.com/go-kit/kit/metrics"
+)
+
+var _ Verifier = (*metricsMiddleware)(nil)
+
+type metricsMiddleware struct {
+	counter  metrics.Counter
+	verifier Verifier
+}
+
+// MetricsMiddleware counts payloads rejected due to the missing, invalid or
+// expired signature, labeled by the rejection reason.
+func MetricsMiddleware(verifier Verifier, counter metrics.Counter) Verifier {
+	return &metricsMiddleware{
+		counter:  counter,
+		verifier: verifier,
+	}
+}
+
+func (mm *metricsMiddleware) Verify(ctx context.Context, msg messaging.Message) ([]byte, error) {
+	payload, err := mm.verifier.Verify(ctx, msg)
+	switch {
+	case errors.Contains(err, ErrMissingSignature):
+		mm.counter.With("reason", "missing_signature").Add(1)
+	case errors.Contains(err, ErrInvalidSignature):
+		mm.counter.With("reason", "invalid_signature").Add(1)
+	case errors.Contains(err, ErrExpiredSignature):
+		mm.counter.With("reason", "expired_signature").Add(1)
+	}
+
+	return payload, err
+}
@@ -0,0 +1,143 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package signature
+
+import (
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/json"
+	"strconv"
+
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+)
+
+const (
+	// HMACSHA256 represents HMAC-SHA256 signatures computed using the
+	// secret shared between the thing and the things service.
+	HMACSHA256 = "hmac-sha256"
+
+	// Ed25519 represents Ed25519 signatures verified using the public
+	// key of the thing.
+	Ed25519 = "ed25519"
+
+	minSecretSize = sha256.Size
+)
+
+var (
+	// ErrUnsupportedAlgorithm indicates unsupported signature algorithm.
+	ErrUnsupportedAlgorithm = errors.New("unsupported signature algorithm")
+
+	// ErrInvalidKey indicates signing key which can't be used with the algorithm.
+	ErrInvalidKey = errors.New("invalid signing key")
+
+	// ErrMissingSignature indicates unsigned payload of the thing with the signing key.
+	ErrMissingSignature = errors.New("missing message signature")
+
+	// ErrInvalidSignature indicates payload signature which doesn't match the signing key.
+	ErrInvalidSignature = errors.New("invalid message signature")
+
+	// ErrExpiredSignature indicates message signed outside of the acceptance window.
+	ErrExpiredSignature = errors.New("expired message signature")
+)
+
+// Envelope represents the signed message payload. Things with the signing
+// key publish payloads wrapped in the envelope, which is JSON object with
+// base64 encoded payload, the signing time in Unix seconds and the signature
+// of the message data, see Data.
+type Envelope struct {
+	Payload   []byte `json:"payload"`
+	Timestamp int64  `json:"timestamp"`
+	Signature []byte `json:"signature"`
+}
+
+// Data returns the signed data of the message, i.e. the channel, the
+// subtopic, the signing time and the payload, separated by newlines. Signing
+// the channel and the subtopic along with the payload prevents the signed
+// payload from being published to another channel or subtopic, while the
+// signing time limits its replay to the acceptance window.
+func Data(channel, subtopic string, timestamp int64, payload []byte) []byte {
+	data := make([]byte, 0, len(channel)+len(subtopic)+len(payload)+23)
+	data = append(data, channel...)
+	data = append(data, '\n')
+	data = append(data, subtopic...)
+	data = append(data, '\n')
+	data = strconv.AppendInt(data, timestamp, 10)
+	data = append(data, '\n')
+	return append(data, payload...)
+}
+
+// Open parses the signed payload envelope.
+func Open(data []byte) (Envelope, error) {
+	var env Envelope
+	if err := json.Unmarshal(data, &env); err != nil {
+		return Envelope{}, errors.Wrap(ErrMissingSignature, err)
+	}
+
+	if len(env.Signature) == 0 {
+		return Envelope{}, ErrMissingSignature
+	}
+
+	return env, nil
+}
+
+// Validate checks whether the key can be used to verify signatures computed
+// with the given algorithm. HMAC-SHA256 secret must be at least 32 bytes long,
+// while Ed25519 key must be the public key.
+func Validate(alg string, key []byte) error {
+	switch alg {
+	case HMACSHA256:
+		if len(key) < minSecretSize {
+			return ErrInvalidKey
+		}
+	case Ed25519:
+		if len(key) != ed25519.PublicKeySize {
+			return ErrInvalidKey
+		}
+	default:
+		return ErrUnsupportedAlgorithm
+	}
+
+	return nil
+}
+
+// Sign signs the data with the given algorithm. Key is the HMAC-SHA256
+// secret or the Ed25519 private key.
+func Sign(alg string, key, data []byte) ([]byte, error) {
+	switch alg {
+	case HMACSHA256:
+		mac := hmac.New(sha256.New, key)
+		mac.Write(data)
+		return mac.Sum(nil), nil
+	case Ed25519:
+		if len(key) != ed25519.PrivateKeySize {
+			return nil, ErrInvalidKey
+		}
+		return ed25519.Sign(key, data), nil
+	default:
+		return nil, ErrUnsupportedAlgorithm
+	}
+}
+
+// Verify verifies the data signature using the signing key of the thing.
+func Verify(alg string, key, data, sig []byte) error {
+	if err := Validate(alg, key); err != nil {
+		return err
+	}
+
+	switch alg {
+	case HMACSHA256:
+		mac := hmac.New(sha256.New, key)
+		mac.Write(data)
+		if !hmac.Equal(sig, mac.Sum(nil)) {
+			return ErrInvalidSignature
+		}
+	case Ed25519:
+		if !ed25519.Verify(key, data, sig) {
+			return ErrInvalidSignature
+		}
+	}
+
+	return nil
+}
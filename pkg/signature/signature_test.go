@@ -0,0 +1,351 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package signature_test
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+	"github.com/MainfluxLabs/mainflux/pkg/signature"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+const (
+	hmacThingID     = "hmac-thing"
+	ed25519ThingID  = "ed25519-thing"
+	unsignedThingID = "unsigned-thing"
+	channel         = "channel"
+	subtopic        = "room.temperature"
+)
+
+var (
+	payload = []byte(`[{"n":"temperature","v":21.5}]`)
+	secret  = []byte("0123456789abcdef0123456789abcdef")
+)
+
+type thingsClient struct {
+	mainflux.ThingsServiceClient
+	keys  map[string]*mainflux.SigningKey
+	calls *int
+}
+
+func (tc thingsClient) GetSigningKey(_ context.Context, req *mainflux.ThingID, _ ...grpc.CallOption) (*mainflux.SigningKey, error) {
+	if tc.calls != nil {
+		*tc.calls++
+	}
+
+	if sk, ok := tc.keys[req.GetValue()]; ok {
+		return sk, nil
+	}
+
+	return &mainflux.SigningKey{}, nil
+}
+
+func envelope(t *testing.T, payload []byte, ts int64, sig []byte) []byte {
+	data, err := json.Marshal(signature.Envelope{Payload: payload, Timestamp: ts, Signature: sig})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	return data
+}
+
+func sign(t *testing.T, alg string, key []byte, channel, subtopic string, ts int64) []byte {
+	sig, err := signature.Sign(alg, key, signature.Data(channel, subtopic, ts, payload))
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	return sig
+}
+
+func TestVerify(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(nil)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	_, otherPriv, err := ed25519.GenerateKey(nil)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	now := time.Now().Unix()
+	stale := time.Now().Add(-2 * signature.Window).Unix()
+
+	hmacSig := sign(t, signature.HMACSHA256, secret, channel, subtopic, now)
+	edSig := sign(t, signature.Ed25519, priv, channel, subtopic, now)
+	otherSig := sign(t, signature.Ed25519, otherPriv, channel, subtopic, now)
+	staleSig := sign(t, signature.Ed25519, priv, channel, subtopic, stale)
+
+	v := signature.NewVerifier(thingsClient{
+		keys: map[string]*mainflux.SigningKey{
+			hmacThingID:    {Algorithm: signature.HMACSHA256, Key: secret},
+			ed25519ThingID: {Algorithm: signature.Ed25519, Key: pub},
+		},
+	}, time.Minute)
+
+	cases := []struct {
+		desc     string
+		thingID  string
+		channel  string
+		subtopic string
+		payload  []byte
+		res      []byte
+		err      error
+	}{
+		{
+			desc:     "verify payload of thing without signing key",
+			thingID:  unsignedThingID,
+			channel:  channel,
+			subtopic: subtopic,
+			payload:  payload,
+			res:      payload,
+			err:      nil,
+		},
+		{
+			desc:     "verify payload signed with HMAC-SHA256",
+			thingID:  hmacThingID,
+			channel:  channel,
+			subtopic: subtopic,
+			payload:  envelope(t, payload, now, hmacSig),
+			res:      payload,
+			err:      nil,
+		},
+		{
+			desc:     "verify payload signed with Ed25519",
+			thingID:  ed25519ThingID,
+			channel:  channel,
+			subtopic: subtopic,
+			payload:  envelope(t, payload, now, edSig),
+			res:      payload,
+			err:      nil,
+		},
+		{
+			desc:     "verify unsigned payload",
+			thingID:  hmacThingID,
+			channel:  channel,
+			subtopic: subtopic,
+			payload:  payload,
+			err:      signature.ErrMissingSignature,
+		},
+		{
+			desc:     "verify envelope without signature",
+			thingID:  ed25519ThingID,
+			channel:  channel,
+			subtopic: subtopic,
+			payload:  envelope(t, payload, now, nil),
+			err:      signature.ErrMissingSignature,
+		},
+		{
+			desc:     "verify payload signed with other algorithm",
+			thingID:  hmacThingID,
+			channel:  channel,
+			subtopic: subtopic,
+			payload:  envelope(t, payload, now, edSig),
+			err:      signature.ErrInvalidSignature,
+		},
+		{
+			desc:     "verify payload signed with other key",
+			thingID:  ed25519ThingID,
+			channel:  channel,
+			subtopic: subtopic,
+			payload:  envelope(t, payload, now, otherSig),
+			err:      signature.ErrInvalidSignature,
+		},
+		{
+			desc:     "verify tampered payload",
+			thingID:  ed25519ThingID,
+			channel:  channel,
+			subtopic: subtopic,
+			payload:  envelope(t, []byte(`[{"n":"temperature","v":99}]`), now, edSig),
+			err:      signature.ErrInvalidSignature,
+		},
+		{
+			desc:     "verify payload published to other channel",
+			thingID:  ed25519ThingID,
+			channel:  "other-channel",
+			subtopic: subtopic,
+			payload:  envelope(t, payload, now, edSig),
+			err:      signature.ErrInvalidSignature,
+		},
+		{
+			desc:     "verify payload published to other subtopic",
+			thingID:  ed25519ThingID,
+			channel:  channel,
+			subtopic: "other.subtopic",
+			payload:  envelope(t, payload, now, edSig),
+			err:      signature.ErrInvalidSignature,
+		},
+		{
+			desc:     "verify payload with tampered timestamp",
+			thingID:  ed25519ThingID,
+			channel:  channel,
+			subtopic: subtopic,
+			payload:  envelope(t, payload, now+1, edSig),
+			err:      signature.ErrInvalidSignature,
+		},
+		{
+			desc:     "verify payload signed outside acceptance window",
+			thingID:  ed25519ThingID,
+			channel:  channel,
+			subtopic: subtopic,
+			payload:  envelope(t, payload, stale, staleSig),
+			err:      signature.ErrExpiredSignature,
+		},
+	}
+
+	for _, tc := range cases {
+		msg := messaging.Message{
+			Publisher: tc.thingID,
+			Channel:   tc.channel,
+			Subtopic:  tc.subtopic,
+			Payload:   tc.payload,
+		}
+		res, err := v.Verify(context.Background(), msg)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.res, res, fmt.Sprintf("%s: expected payload %s got %s\n", tc.desc, tc.res, res))
+	}
+}
+
+func TestVerifyCached(t *testing.T) {
+	now := time.Now().Unix()
+	otherSecret := []byte("fedcba9876543210fedcba9876543210")
+	sig := sign(t, signature.HMACSHA256, secret, channel, subtopic, now)
+	otherSig := sign(t, signature.HMACSHA256, otherSecret, channel, subtopic, now)
+
+	var calls int
+	keys := map[string]*mainflux.SigningKey{
+		hmacThingID: {Algorithm: signature.HMACSHA256, Key: secret},
+	}
+	v := signature.NewVerifier(thingsClient{keys: keys, calls: &calls}, time.Minute)
+
+	cases := []struct {
+		desc    string
+		rotate  func()
+		payload []byte
+		res     []byte
+		calls   int
+		err     error
+	}{
+		{
+			desc:    "verify payload retrieving signing key",
+			payload: envelope(t, payload, now, sig),
+			res:     payload,
+			calls:   1,
+			err:     nil,
+		},
+		{
+			desc:    "verify payload using cached signing key",
+			payload: envelope(t, payload, now, sig),
+			res:     payload,
+			calls:   1,
+			err:     nil,
+		},
+		{
+			desc:    "verify payload signed with other key",
+			payload: envelope(t, payload, now, otherSig),
+			calls:   2,
+			err:     signature.ErrInvalidSignature,
+		},
+		{
+			desc:    "verify payload signed with rotated signing key",
+			rotate:  func() { keys[hmacThingID] = &mainflux.SigningKey{Algorithm: signature.HMACSHA256, Key: otherSecret} },
+			payload: envelope(t, payload, now, otherSig),
+			res:     payload,
+			calls:   3,
+			err:     nil,
+		},
+		{
+			desc:    "verify payload signed with previous signing key",
+			payload: envelope(t, payload, now, sig),
+			calls:   4,
+			err:     signature.ErrInvalidSignature,
+		},
+		{
+			desc:    "verify unsigned payload once signing key is removed",
+			rotate:  func() { delete(keys, hmacThingID) },
+			payload: payload,
+			res:     payload,
+			calls:   5,
+			err:     nil,
+		},
+		{
+			desc:    "verify unsigned payload without caching absence of signing key",
+			payload: payload,
+			res:     payload,
+			calls:   6,
+			err:     nil,
+		},
+		{
+			desc:    "verify unsigned payload once signing key is set",
+			rotate:  func() { keys[hmacThingID] = &mainflux.SigningKey{Algorithm: signature.HMACSHA256, Key: secret} },
+			payload: payload,
+			calls:   7,
+			err:     signature.ErrMissingSignature,
+		},
+	}
+
+	for _, tc := range cases {
+		if tc.rotate != nil {
+			tc.rotate()
+		}
+		msg := messaging.Message{
+			Publisher: hmacThingID,
+			Channel:   channel,
+			Subtopic:  subtopic,
+			Payload:   tc.payload,
+		}
+		res, err := v.Verify(context.Background(), msg)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.res, res, fmt.Sprintf("%s: expected payload %s got %s\n", tc.desc, tc.res, res))
+		assert.Equal(t, tc.calls, calls, fmt.Sprintf("%s: expected %d signing key retrievals got %d\n", tc.desc, tc.calls, calls))
+	}
+}
+
+func TestValidate(t *testing.T) {
+	pub, _, err := ed25519.GenerateKey(nil)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc string
+		alg  string
+		key  []byte
+		err  error
+	}{
+		{
+			desc: "validate HMAC-SHA256 secret",
+			alg:  signature.HMACSHA256,
+			key:  secret,
+			err:  nil,
+		},
+		{
+			desc: "validate short HMAC-SHA256 secret",
+			alg:  signature.HMACSHA256,
+			key:  secret[:16],
+			err:  signature.ErrInvalidKey,
+		},
+		{
+			desc: "validate Ed25519 public key",
+			alg:  signature.Ed25519,
+			key:  pub,
+			err:  nil,
+		},
+		{
+			desc: "validate invalid Ed25519 public key",
+			alg:  signature.Ed25519,
+			key:  secret[:16],
+			err:  signature.ErrInvalidKey,
+		},
+		{
+			desc: "validate unsupported algorithm",
+			alg:  "rsa",
+			key:  secret,
+			err:  signature.ErrUnsupportedAlgorithm,
+		},
+	}
+
+	for _, tc := range cases {
+		err := signature.Validate(tc.alg, tc.key)
+		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
@@ -0,0 +1,133 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package signature
+
+import (
+	"context"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+	lru "github.com/hashicorp/golang-lru"
+)
+
+const (
+	// Window is the maximal difference between the signing time of the
+	// message and the time it's verified at.
+	Window = 5 * time.Minute
+
+	// cacheSize is the maximal number of the cached signing keys. The least
+	// recently used keys are evicted once it's reached.
+	cacheSize = 10000
+)
+
+// Verifier verifies signatures of the payloads published by things.
+type Verifier interface {
+	// Verify returns the payload which is published by the message
+	// publisher. Payloads of things without the signing key are returned
+	// unchanged, while things with the signing key must publish signed
+	// envelopes, whose verified payload is returned. Envelopes signed for
+	// another channel or subtopic, or outside of the window, are rejected.
+	Verify(ctx context.Context, msg messaging.Message) ([]byte, error)
+}
+
+var _ Verifier = (*verifier)(nil)
+
+type keyEntry struct {
+	sk      *mainflux.SigningKey
+	expires time.Time
+}
+
+type verifier struct {
+	things mainflux.ThingsServiceClient
+	ttl    time.Duration
+	keys   *lru.Cache
+}
+
+// NewVerifier returns verifier which retrieves signing keys of things
+// from the things service and caches them per publisher for the given TTL.
+// Signing keys are not cached if the TTL is zero.
+func NewVerifier(things mainflux.ThingsServiceClient, ttl time.Duration) Verifier {
+	// The cache can't fail to be created since its size is positive.
+	keys, _ := lru.New(cacheSize)
+
+	return &verifier{
+		things: things,
+		ttl:    ttl,
+		keys:   keys,
+	}
+}
+
+func (v *verifier) Verify(ctx context.Context, msg messaging.Message) ([]byte, error) {
+	sk, cached, err := v.signingKey(ctx, msg.Publisher)
+	if err != nil {
+		return nil, err
+	}
+
+	payload, err := verify(sk, msg)
+	if err == nil || !cached || errors.Contains(err, ErrExpiredSignature) {
+		return payload, err
+	}
+
+	// Message rejected using the cached signing key is verified once again
+	// using the retrieved one, so that the rotated and removed signing keys
+	// take effect before the cached ones expire.
+	v.keys.Remove(msg.Publisher)
+	if sk, _, err = v.signingKey(ctx, msg.Publisher); err != nil {
+		return nil, err
+	}
+
+	return verify(sk, msg)
+}
+
+// signingKey returns the signing key of the thing, reporting whether it's
+// taken from the cache.
+func (v *verifier) signingKey(ctx context.Context, thingID string) (*mainflux.SigningKey, bool, error) {
+	if val, ok := v.keys.Get(thingID); ok {
+		e := val.(keyEntry)
+		if time.Now().Before(e.expires) {
+			return e.sk, true, nil
+		}
+		v.keys.Remove(thingID)
+	}
+
+	sk, err := v.things.GetSigningKey(ctx, &mainflux.ThingID{Value: thingID})
+	if err != nil {
+		return nil, false, err
+	}
+
+	// Absence of the signing key isn't cached, so that the signing key set
+	// for the thing is enforced immediately, rather than once the cached
+	// absence expires.
+	if v.ttl > 0 && sk.GetAlgorithm() != "" {
+		v.keys.Add(thingID, keyEntry{sk: sk, expires: time.Now().Add(v.ttl)})
+	}
+
+	return sk, false, nil
+}
+
+func verify(sk *mainflux.SigningKey, msg messaging.Message) ([]byte, error) {
+	if sk.GetAlgorithm() == "" {
+		return msg.Payload, nil
+	}
+
+	env, err := Open(msg.Payload)
+	if err != nil {
+		return nil, err
+	}
+
+	data := Data(msg.Channel, msg.Subtopic, env.Timestamp, env.Payload)
+	if err := Verify(sk.GetAlgorithm(), sk.GetKey(), data, env.Signature); err != nil {
+		return nil, err
+	}
+
+	// Signing time is checked only once the signature is verified, so that
+	// the forged timestamps are reported as invalid signatures.
+	if d := time.Since(time.Unix(env.Timestamp, 0)); d > Window || d < -Window {
+		return nil, ErrExpiredSignature
+	}
+
+	return env.Payload, nil
+}
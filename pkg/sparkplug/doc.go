@@ -0,0 +1,7 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package sparkplug contains the decoding of the Sparkplug B messages, their
+// conversion to SenML and the tracking of the birth and death of the edge
+// nodes and devices.
+package sparkplug
@@ -0,0 +1,195 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package sparkplug
+
+import (
+	"math"
+
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// Sparkplug B metric data types.
+const (
+	Int8     uint32 = 1
+	Int16    uint32 = 2
+	Int32    uint32 = 3
+	Int64    uint32 = 4
+	UInt8    uint32 = 5
+	UInt16   uint32 = 6
+	UInt32   uint32 = 7
+	UInt64   uint32 = 8
+	Float    uint32 = 9
+	Double   uint32 = 10
+	Boolean  uint32 = 11
+	String   uint32 = 12
+	DateTime uint32 = 13
+	Text     uint32 = 14
+	UUID     uint32 = 15
+	DataSet  uint32 = 16
+	Bytes    uint32 = 17
+	File     uint32 = 18
+	Template uint32 = 19
+)
+
+// Field numbers of the Sparkplug B Payload and Metric protobuf messages.
+const (
+	payloadTimestamp = 1
+	payloadMetrics   = 2
+	payloadSeq       = 3
+
+	metricName      = 1
+	metricAlias     = 2
+	metricTimestamp = 3
+	metricDatatype  = 4
+	metricIsNull    = 7
+	metricInt       = 10
+	metricLong      = 11
+	metricFloat     = 12
+	metricDouble    = 13
+	metricBoolean   = 14
+	metricString    = 15
+	metricBytes     = 16
+)
+
+// ErrMalformedPayload indicates the payload which is not the valid Sparkplug B
+// protobuf payload.
+var ErrMalformedPayload = errors.New("malformed sparkplug payload")
+
+// Payload represents the Sparkplug B payload.
+type Payload struct {
+	// Timestamp is the time the payload was created, in milliseconds since
+	// the Unix epoch.
+	Timestamp uint64
+	Seq       uint64
+	Metrics   []Metric
+}
+
+// Metric represents the Sparkplug B metric. Value is int64, uint64, float64,
+// bool, string or []byte, depending on the data type, and nil if the metric
+// is null or its type is not supported.
+type Metric struct {
+	Name      string
+	Alias     uint64
+	HasAlias  bool
+	Timestamp uint64
+	Datatype  uint32
+	Value     interface{}
+}
+
+// Decode decodes the Sparkplug B protobuf payload. Data sets, templates,
+// properties and metadata of the metrics are skipped.
+func Decode(data []byte) (Payload, error) {
+	var p Payload
+	err := fields(data, func(num protowire.Number, typ protowire.Type, v uint64, b []byte) error {
+		switch {
+		case num == payloadTimestamp && typ == protowire.VarintType:
+			p.Timestamp = v
+		case num == payloadSeq && typ == protowire.VarintType:
+			p.Seq = v
+		case num == payloadMetrics && typ == protowire.BytesType:
+			m, err := decodeMetric(b)
+			if err != nil {
+				return err
+			}
+			p.Metrics = append(p.Metrics, m)
+		}
+		return nil
+	})
+
+	return p, err
+}
+
+func decodeMetric(data []byte) (Metric, error) {
+	var m Metric
+	var raw uint64
+	var str []byte
+	var isNull bool
+	err := fields(data, func(num protowire.Number, typ protowire.Type, v uint64, b []byte) error {
+		switch num {
+		case metricName:
+			m.Name = string(b)
+		case metricAlias:
+			m.Alias, m.HasAlias = v, true
+		case metricTimestamp:
+			m.Timestamp = v
+		case metricDatatype:
+			m.Datatype = uint32(v)
+		case metricIsNull:
+			isNull = v != 0
+		case metricInt, metricLong, metricFloat, metricDouble, metricBoolean:
+			raw = v
+		case metricString, metricBytes:
+			str = b
+		}
+		return nil
+	})
+	if err != nil || isNull {
+		return m, err
+	}
+
+	switch m.Datatype {
+	case Int8:
+		m.Value = int64(int8(raw))
+	case Int16:
+		m.Value = int64(int16(raw))
+	case Int32:
+		m.Value = int64(int32(raw))
+	case Int64:
+		m.Value = int64(raw)
+	case UInt8, UInt16, UInt32, UInt64, DateTime:
+		m.Value = raw
+	case Float:
+		m.Value = float64(math.Float32frombits(uint32(raw)))
+	case Double:
+		m.Value = math.Float64frombits(raw)
+	case Boolean:
+		m.Value = raw != 0
+	case String, Text, UUID:
+		m.Value = string(str)
+	case Bytes, File:
+		m.Value = str
+	}
+
+	return m, nil
+}
+
+// fields calls fn for each field of the protobuf message. Varint and fixed
+// values are passed as v, and length-delimited values as b.
+func fields(data []byte, fn func(num protowire.Number, typ protowire.Type, v uint64, b []byte) error) error {
+	for len(data) > 0 {
+		num, typ, n := protowire.ConsumeTag(data)
+		if n < 0 {
+			return ErrMalformedPayload
+		}
+		data = data[n:]
+
+		var v uint64
+		var b []byte
+		switch typ {
+		case protowire.VarintType:
+			v, n = protowire.ConsumeVarint(data)
+		case protowire.Fixed32Type:
+			var v32 uint32
+			v32, n = protowire.ConsumeFixed32(data)
+			v = uint64(v32)
+		case protowire.Fixed64Type:
+			v, n = protowire.ConsumeFixed64(data)
+		case protowire.BytesType:
+			b, n = protowire.ConsumeBytes(data)
+		default:
+			n = protowire.ConsumeFieldValue(num, typ, data)
+		}
+		if n < 0 {
+			return ErrMalformedPayload
+		}
+		data = data[n:]
+
+		if err := fn(num, typ, v, b); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
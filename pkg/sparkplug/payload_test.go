@@ -0,0 +1,149 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package sparkplug_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/MainfluxLabs/mainflux/pkg/sparkplug"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+type metric struct {
+	name     string
+	alias    uint64
+	ts       uint64
+	datatype uint32
+	fields   []byte
+}
+
+func encodeMetric(m metric) []byte {
+	var b []byte
+	if m.name != "" {
+		b = protowire.AppendTag(b, 1, protowire.BytesType)
+		b = protowire.AppendString(b, m.name)
+	}
+	if m.alias != 0 {
+		b = protowire.AppendTag(b, 2, protowire.VarintType)
+		b = protowire.AppendVarint(b, m.alias)
+	}
+	if m.ts != 0 {
+		b = protowire.AppendTag(b, 3, protowire.VarintType)
+		b = protowire.AppendVarint(b, m.ts)
+	}
+	b = protowire.AppendTag(b, 4, protowire.VarintType)
+	b = protowire.AppendVarint(b, uint64(m.datatype))
+
+	return append(b, m.fields...)
+}
+
+func varint(num protowire.Number, v uint64) []byte {
+	b := protowire.AppendTag(nil, num, protowire.VarintType)
+	return protowire.AppendVarint(b, v)
+}
+
+func encode(ts, seq uint64, metrics ...metric) []byte {
+	b := varint(1, ts)
+	for _, m := range metrics {
+		b = protowire.AppendTag(b, 2, protowire.BytesType)
+		b = protowire.AppendBytes(b, encodeMetric(m))
+	}
+
+	return append(b, varint(3, seq)...)
+}
+
+func TestDecode(t *testing.T) {
+	minusOne := uint64(math.MaxUint32)
+	float := protowire.AppendFixed32(protowire.AppendTag(nil, 12, protowire.Fixed32Type), math.Float32bits(1.5))
+	double := protowire.AppendFixed64(protowire.AppendTag(nil, 13, protowire.Fixed64Type), math.Float64bits(-2.25))
+	str := protowire.AppendString(protowire.AppendTag(nil, 15, protowire.BytesType), "running")
+	bytes := protowire.AppendBytes(protowire.AppendTag(nil, 16, protowire.BytesType), []byte{1, 2})
+	// Data set value of the metric is skipped.
+	dataSet := protowire.AppendBytes(protowire.AppendTag(nil, 17, protowire.BytesType), []byte{8, 1})
+
+	data := encode(1000, 7,
+		metric{name: "int8", datatype: sparkplug.Int8, fields: varint(10, minusOne)},
+		metric{name: "int64", datatype: sparkplug.Int64, fields: varint(11, uint64(math.MaxUint64))},
+		metric{name: "uint32", alias: 3, datatype: sparkplug.UInt32, fields: varint(10, 42)},
+		metric{name: "float", datatype: sparkplug.Float, fields: float},
+		metric{name: "double", ts: 2000, datatype: sparkplug.Double, fields: double},
+		metric{name: "bool", datatype: sparkplug.Boolean, fields: varint(14, 1)},
+		metric{name: "string", datatype: sparkplug.String, fields: str},
+		metric{name: "bytes", datatype: sparkplug.Bytes, fields: bytes},
+		metric{name: "null", datatype: sparkplug.Int32, fields: varint(7, 1)},
+		metric{name: "data set", datatype: sparkplug.DataSet, fields: dataSet},
+	)
+
+	p, err := sparkplug.Decode(data)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.Equal(t, uint64(1000), p.Timestamp)
+	assert.Equal(t, uint64(7), p.Seq)
+
+	values := []interface{}{int64(-1), int64(-1), uint64(42), float64(1.5), float64(-2.25), true, "running", []byte{1, 2}, nil, nil}
+	require.Equal(t, len(values), len(p.Metrics))
+	for i, v := range values {
+		assert.Equal(t, v, p.Metrics[i].Value, fmt.Sprintf("metric %s: expected %v got %v\n", p.Metrics[i].Name, v, p.Metrics[i].Value))
+	}
+	assert.True(t, p.Metrics[2].HasAlias)
+	assert.Equal(t, uint64(3), p.Metrics[2].Alias)
+	assert.Equal(t, uint64(2000), p.Metrics[4].Timestamp)
+}
+
+func TestDecodeMalformed(t *testing.T) {
+	cases := []struct {
+		desc string
+		data []byte
+	}{
+		{
+			desc: "decode truncated varint",
+			data: []byte{8, 0xff},
+		},
+		{
+			desc: "decode truncated metric",
+			data: []byte{0x12, 10, 1},
+		},
+		{
+			desc: "decode invalid tag",
+			data: []byte{0},
+		},
+	}
+
+	for _, tc := range cases {
+		_, err := sparkplug.Decode(tc.data)
+		assert.Equal(t, sparkplug.ErrMalformedPayload, err, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, sparkplug.ErrMalformedPayload, err))
+	}
+}
+
+func TestSenML(t *testing.T) {
+	value := 21.5
+	p := sparkplug.Payload{
+		Timestamp: 1500,
+		Metrics: []sparkplug.Metric{
+			{Name: "Inputs/Temp (C)", Value: value},
+			{Name: "Node Control/Rebirth", Timestamp: 2500, Value: false},
+			{Name: "", Alias: 4, HasAlias: true, Value: int64(1)},
+			{Name: "null", Value: nil},
+		},
+	}
+
+	data, err := sparkplug.SenML(p)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	var records []map[string]interface{}
+	err = json.Unmarshal(data, &records)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	expected := []map[string]interface{}{
+		{"n": "Inputs/Temp__C_", "t": 1.5, "v": value},
+		{"n": "Node_Control/Rebirth", "t": 2.5, "vb": false},
+	}
+	assert.Equal(t, expected, records)
+
+	_, err = sparkplug.SenML(sparkplug.Payload{Metrics: []sparkplug.Metric{{Name: "null"}}})
+	assert.Equal(t, sparkplug.ErrNoMetrics, err, fmt.Sprintf("expected %s got %s\n", sparkplug.ErrNoMetrics, err))
+}
@@ -0,0 +1,89 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package sparkplug
+
+import (
+	"encoding/base64"
+	"strings"
+
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/senml"
+)
+
+// ErrNoMetrics indicates the payload without the metrics representable in SenML.
+var ErrNoMetrics = errors.New("no sparkplug metrics to convert")
+
+// SenML returns the metrics of the payload as the SenML JSON pack. Metrics
+// without the name, null metrics and metrics of the unsupported types are
+// skipped. Metric names are converted to the valid SenML names by replacing
+// the invalid characters with underscores.
+func SenML(p Payload) ([]byte, error) {
+	var records []senml.Record
+	for _, m := range p.Metrics {
+		r, ok := record(m)
+		if !ok {
+			continue
+		}
+
+		ts := m.Timestamp
+		if ts == 0 {
+			ts = p.Timestamp
+		}
+		r.Time = float64(ts) / 1e3
+
+		records = append(records, r)
+	}
+
+	if len(records) == 0 {
+		return nil, ErrNoMetrics
+	}
+
+	return senml.Encode(senml.Pack{Records: records}, senml.JSON)
+}
+
+func record(m Metric) (senml.Record, bool) {
+	name := senmlName(m.Name)
+	if name == "" {
+		return senml.Record{}, false
+	}
+
+	r := senml.Record{Name: name}
+	switch v := m.Value.(type) {
+	case int64:
+		f := float64(v)
+		r.Value = &f
+	case uint64:
+		f := float64(v)
+		r.Value = &f
+	case float64:
+		r.Value = &v
+	case bool:
+		r.BoolValue = &v
+	case string:
+		r.StringValue = &v
+	case []byte:
+		s := base64.StdEncoding.EncodeToString(v)
+		r.DataValue = &s
+	default:
+		return senml.Record{}, false
+	}
+
+	return r, true
+}
+
+func senmlName(name string) string {
+	valid := func(c rune) bool {
+		return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9') || strings.ContainsRune("-:./_", c)
+	}
+
+	name = strings.Map(func(c rune) rune {
+		if valid(c) {
+			return c
+		}
+		return '_'
+	}, name)
+
+	// SenML names must start with the letter or the digit.
+	return strings.TrimLeft(name, "-:./_")
+}
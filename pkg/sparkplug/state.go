@@ -0,0 +1,196 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package sparkplug
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+const bdSeqMetric = "bdSeq"
+
+// NodeState represents the state of the edge node.
+type NodeState struct {
+	ID      string
+	Online  bool
+	Seq     uint64
+	BirthAt time.Time
+	DeathAt time.Time
+	Devices []DeviceState
+}
+
+// DeviceState represents the state of the device of the edge node.
+type DeviceState struct {
+	ID      string
+	Online  bool
+	BirthAt time.Time
+	DeathAt time.Time
+}
+
+type node struct {
+	state   NodeState
+	bdSeq   *uint64
+	aliases map[uint64]string
+	devices map[string]*device
+}
+
+type device struct {
+	state   DeviceState
+	aliases map[uint64]string
+}
+
+// State keeps the birth and death state of the edge nodes and devices, and
+// resolves the metric aliases announced in their birth certificates.
+type State struct {
+	mu     sync.Mutex
+	groups map[string]map[string]*node
+}
+
+// NewState returns the empty state.
+func NewState() *State {
+	return &State{groups: make(map[string]map[string]*node)}
+}
+
+// Update updates the state of the edge node or device the message of the
+// topic is published by, and sets the names of the payload metrics
+// referenced by their aliases.
+func (s *State) Update(t Topic, p *Payload) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	n := s.node(t.Group, t.Node)
+
+	switch t.Type {
+	case NBIRTH:
+		n.state.Online = true
+		n.state.BirthAt = now
+		n.bdSeq = bdSeq(p)
+		n.aliases = aliases(p)
+		// Devices are born again after the birth of their edge node.
+		for _, d := range n.devices {
+			d.die(now)
+		}
+	case NDEATH:
+		// Death certificate of the previous session of the edge node,
+		// published after its new birth, is stale.
+		if seq := bdSeq(p); seq != nil && n.bdSeq != nil && *seq != *n.bdSeq {
+			return
+		}
+		n.state.Online = false
+		n.state.DeathAt = now
+		for _, d := range n.devices {
+			d.die(now)
+		}
+		return
+	case DBIRTH:
+		d := n.device(t.Device)
+		d.state.Online = true
+		d.state.BirthAt = now
+		d.aliases = aliases(p)
+	case DDEATH:
+		n.device(t.Device).die(now)
+	case NDATA, NCMD:
+		resolve(p, n.aliases)
+	case DDATA, DCMD:
+		resolve(p, n.device(t.Device).aliases)
+	}
+
+	if t.Type != NCMD && t.Type != DCMD {
+		n.state.Seq = p.Seq
+	}
+}
+
+// Nodes returns the edge nodes of the group, sorted by their IDs.
+func (s *State) Nodes(group string) []NodeState {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	nodes := []NodeState{}
+	for _, n := range s.groups[group] {
+		ns := n.state
+		ns.Devices = []DeviceState{}
+		for _, d := range n.devices {
+			ns.Devices = append(ns.Devices, d.state)
+		}
+		sort.Slice(ns.Devices, func(i, j int) bool { return ns.Devices[i].ID < ns.Devices[j].ID })
+		nodes = append(nodes, ns)
+	}
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].ID < nodes[j].ID })
+
+	return nodes
+}
+
+func (s *State) node(group, id string) *node {
+	g, ok := s.groups[group]
+	if !ok {
+		g = make(map[string]*node)
+		s.groups[group] = g
+	}
+
+	n, ok := g[id]
+	if !ok {
+		n = &node{
+			state:   NodeState{ID: id},
+			devices: make(map[string]*device),
+		}
+		g[id] = n
+	}
+
+	return n
+}
+
+func (n *node) device(id string) *device {
+	d, ok := n.devices[id]
+	if !ok {
+		d = &device{state: DeviceState{ID: id}}
+		n.devices[id] = d
+	}
+
+	return d
+}
+
+func (d *device) die(now time.Time) {
+	if d.state.Online {
+		d.state.Online = false
+		d.state.DeathAt = now
+	}
+}
+
+func bdSeq(p *Payload) *uint64 {
+	for _, m := range p.Metrics {
+		if m.Name != bdSeqMetric {
+			continue
+		}
+		switch v := m.Value.(type) {
+		case uint64:
+			return &v
+		case int64:
+			seq := uint64(v)
+			return &seq
+		}
+	}
+
+	return nil
+}
+
+func aliases(p *Payload) map[uint64]string {
+	aliases := make(map[uint64]string)
+	for _, m := range p.Metrics {
+		if m.HasAlias && m.Name != "" {
+			aliases[m.Alias] = m.Name
+		}
+	}
+
+	return aliases
+}
+
+func resolve(p *Payload, aliases map[uint64]string) {
+	for i, m := range p.Metrics {
+		if m.Name == "" && m.HasAlias {
+			p.Metrics[i].Name = aliases[m.Alias]
+		}
+	}
+}
@@ -0,0 +1,115 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package sparkplug_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/MainfluxLabs/mainflux/pkg/sparkplug"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	group  = "plant"
+	nodeID = "node"
+	devID  = "device"
+)
+
+func topic(typ string, device bool) sparkplug.Topic {
+	t := sparkplug.Topic{Group: group, Type: typ, Node: nodeID}
+	if device {
+		t.Device = devID
+	}
+	return t
+}
+
+func birth(bdSeq uint64, name string, alias uint64) *sparkplug.Payload {
+	return &sparkplug.Payload{
+		Metrics: []sparkplug.Metric{
+			{Name: "bdSeq", Datatype: sparkplug.UInt64, Value: bdSeq},
+			{Name: name, Alias: alias, HasAlias: true, Datatype: sparkplug.Double, Value: float64(0)},
+		},
+	}
+}
+
+func TestUpdateBirthDeath(t *testing.T) {
+	state := sparkplug.NewState()
+	assert.Empty(t, state.Nodes(group))
+
+	state.Update(topic(sparkplug.NBIRTH, false), birth(1, "temp", 1))
+	state.Update(topic(sparkplug.DBIRTH, true), birth(0, "pressure", 2))
+
+	nodes := state.Nodes(group)
+	require.Len(t, nodes, 1)
+	assert.True(t, nodes[0].Online, "expected edge node online")
+	require.Len(t, nodes[0].Devices, 1)
+	assert.True(t, nodes[0].Devices[0].Online, "expected device online")
+
+	state.Update(topic(sparkplug.DDEATH, true), &sparkplug.Payload{})
+	nodes = state.Nodes(group)
+	assert.True(t, nodes[0].Online, "expected edge node online")
+	assert.False(t, nodes[0].Devices[0].Online, "expected device offline")
+	assert.False(t, nodes[0].Devices[0].DeathAt.IsZero(), "expected device death time")
+
+	state.Update(topic(sparkplug.DBIRTH, true), birth(0, "pressure", 2))
+
+	// Stale death certificate of the previous session is ignored.
+	state.Update(topic(sparkplug.NDEATH, false), &sparkplug.Payload{Metrics: []sparkplug.Metric{{Name: "bdSeq", Value: uint64(0)}}})
+	nodes = state.Nodes(group)
+	assert.True(t, nodes[0].Online, "expected edge node online after stale death")
+
+	state.Update(topic(sparkplug.NDEATH, false), &sparkplug.Payload{Metrics: []sparkplug.Metric{{Name: "bdSeq", Value: uint64(1)}}})
+	nodes = state.Nodes(group)
+	assert.False(t, nodes[0].Online, "expected edge node offline")
+	assert.False(t, nodes[0].Devices[0].Online, "expected device offline after edge node death")
+}
+
+func TestUpdateAliases(t *testing.T) {
+	state := sparkplug.NewState()
+	state.Update(topic(sparkplug.NBIRTH, false), birth(1, "temp", 1))
+	state.Update(topic(sparkplug.DBIRTH, true), birth(0, "pressure", 1))
+
+	cases := []struct {
+		desc   string
+		topic  sparkplug.Topic
+		alias  uint64
+		name   string
+		seq    uint64
+		device bool
+	}{
+		{
+			desc:  "resolve edge node metric alias",
+			topic: topic(sparkplug.NDATA, false),
+			alias: 1,
+			name:  "temp",
+			seq:   1,
+		},
+		{
+			desc:  "resolve device metric alias",
+			topic: topic(sparkplug.DDATA, true),
+			alias: 1,
+			name:  "pressure",
+			seq:   2,
+		},
+		{
+			desc:  "resolve unknown alias",
+			topic: topic(sparkplug.NDATA, false),
+			alias: 5,
+			name:  "",
+			seq:   3,
+		},
+	}
+
+	for _, tc := range cases {
+		p := &sparkplug.Payload{
+			Seq:     tc.seq,
+			Metrics: []sparkplug.Metric{{Alias: tc.alias, HasAlias: true, Value: float64(1)}},
+		}
+		state.Update(tc.topic, p)
+		assert.Equal(t, tc.name, p.Metrics[0].Name, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.name, p.Metrics[0].Name))
+		assert.Equal(t, tc.seq, state.Nodes(group)[0].Seq, fmt.Sprintf("%s: expected seq %d got %d\n", tc.desc, tc.seq, state.Nodes(group)[0].Seq))
+	}
+}
@@ -0,0 +1,70 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package sparkplug
+
+import (
+	"strings"
+
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+)
+
+// Namespace is the first element of the Sparkplug B topics.
+const Namespace = "spBv1.0"
+
+// Sparkplug B message types.
+const (
+	NBIRTH = "NBIRTH"
+	NDEATH = "NDEATH"
+	DBIRTH = "DBIRTH"
+	DDEATH = "DDEATH"
+	NDATA  = "NDATA"
+	DDATA  = "DDATA"
+	NCMD   = "NCMD"
+	DCMD   = "DCMD"
+)
+
+// ErrMalformedTopic indicates the topic which is not the Sparkplug B topic of
+// the edge node or device.
+var ErrMalformedTopic = errors.New("malformed sparkplug topic")
+
+var nodeTypes = map[string]bool{NBIRTH: true, NDEATH: true, NDATA: true, NCMD: true}
+
+var deviceTypes = map[string]bool{DBIRTH: true, DDEATH: true, DDATA: true, DCMD: true}
+
+// Topic represents the Sparkplug B topic of the form
+// spBv1.0/<group_id>/<message_type>/<edge_node_id>[/<device_id>].
+type Topic struct {
+	Group  string
+	Type   string
+	Node   string
+	Device string
+}
+
+// IsTopic reports whether the topic is in the Sparkplug B namespace.
+func IsTopic(topic string) bool {
+	return strings.HasPrefix(topic, Namespace+"/")
+}
+
+// ParseTopic parses the Sparkplug B topic the edge node or device publishes to.
+func ParseTopic(topic string) (Topic, error) {
+	levels := strings.Split(topic, "/")
+	if levels[0] != Namespace {
+		return Topic{}, ErrMalformedTopic
+	}
+
+	for _, l := range levels[1:] {
+		if l == "" || strings.ContainsAny(l, "+#") {
+			return Topic{}, ErrMalformedTopic
+		}
+	}
+
+	switch {
+	case len(levels) == 4 && nodeTypes[levels[2]]:
+		return Topic{Group: levels[1], Type: levels[2], Node: levels[3]}, nil
+	case len(levels) == 5 && deviceTypes[levels[2]]:
+		return Topic{Group: levels[1], Type: levels[2], Node: levels[3], Device: levels[4]}, nil
+	default:
+		return Topic{}, ErrMalformedTopic
+	}
+}
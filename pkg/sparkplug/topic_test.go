@@ -0,0 +1,73 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package sparkplug_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/MainfluxLabs/mainflux/pkg/sparkplug"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTopic(t *testing.T) {
+	cases := []struct {
+		desc  string
+		topic string
+		res   sparkplug.Topic
+		err   error
+	}{
+		{
+			desc:  "parse edge node topic",
+			topic: "spBv1.0/plant/NDATA/node",
+			res:   sparkplug.Topic{Group: "plant", Type: sparkplug.NDATA, Node: "node"},
+		},
+		{
+			desc:  "parse device topic",
+			topic: "spBv1.0/plant/DBIRTH/node/device",
+			res:   sparkplug.Topic{Group: "plant", Type: sparkplug.DBIRTH, Node: "node", Device: "device"},
+		},
+		{
+			desc:  "parse topic of other namespace",
+			topic: "spAv1.0/plant/NDATA/node",
+			err:   sparkplug.ErrMalformedTopic,
+		},
+		{
+			desc:  "parse edge node topic with device",
+			topic: "spBv1.0/plant/NDATA/node/device",
+			err:   sparkplug.ErrMalformedTopic,
+		},
+		{
+			desc:  "parse device topic without device",
+			topic: "spBv1.0/plant/DDATA/node",
+			err:   sparkplug.ErrMalformedTopic,
+		},
+		{
+			desc:  "parse topic with unknown message type",
+			topic: "spBv1.0/plant/NINFO/node",
+			err:   sparkplug.ErrMalformedTopic,
+		},
+		{
+			desc:  "parse topic with wildcard",
+			topic: "spBv1.0/plant/NDATA/+",
+			err:   sparkplug.ErrMalformedTopic,
+		},
+		{
+			desc:  "parse topic with empty group",
+			topic: "spBv1.0//NDATA/node",
+			err:   sparkplug.ErrMalformedTopic,
+		},
+		{
+			desc:  "parse host application state topic",
+			topic: "spBv1.0/STATE/host",
+			err:   sparkplug.ErrMalformedTopic,
+		},
+	}
+
+	for _, tc := range cases {
+		res, err := sparkplug.ParseTopic(tc.topic)
+		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.res, res, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.res, res))
+	}
+}
@@ -0,0 +1,94 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package transformers
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+const (
+	// SenMLJSON represents SenML in JSON format content type.
+	SenMLJSON = "application/senml+json"
+	// SenMLCBOR represents SenML in CBOR format content type.
+	SenMLCBOR = "application/senml+cbor"
+	// JSON represents JSON content type.
+	JSON = "application/json"
+	// CBOR represents CBOR content type.
+	CBOR = "application/cbor"
+	// Protobuf represents Protocol Buffers content type.
+	Protobuf = "application/x-protobuf"
+)
+
+// SenML labels as defined in RFC 8428, used to tell SenML from plain JSON.
+var senmlLabels = []string{"bn", "bt", "bu", "bv", "bs", "bver", "n", "u", "v", "vs", "vb", "vd", "s", "t", "ut"}
+
+// DetectContentType detects the content type of the message payload.
+// JSON arrays of SenML records and CBOR arrays are recognized as SenML,
+// other JSON and CBOR documents as plain JSON and CBOR respectively.
+// Payloads that are valid protobuf wire format are recognized as protobuf.
+// Empty string is returned if the content type can't be detected.
+func DetectContentType(msg messaging.Message) string {
+	payload := bytes.TrimSpace(msg.Payload)
+	if len(payload) == 0 {
+		return ""
+	}
+
+	switch b := payload[0]; {
+	case b == '{':
+		return JSON
+	case b == '[':
+		if isSenML(payload) {
+			return SenMLJSON
+		}
+		return JSON
+	// CBOR major type 4 (array) and major type 5 (map).
+	case b >= 0x80 && b <= 0x9f:
+		return SenMLCBOR
+	case b >= 0xa0 && b <= 0xbf:
+		return CBOR
+	case isProtobuf(payload):
+		return Protobuf
+	default:
+		return ""
+	}
+}
+
+func isSenML(payload []byte) bool {
+	var records []map[string]json.RawMessage
+	if err := json.Unmarshal(payload, &records); err != nil || len(records) == 0 {
+		return false
+	}
+
+	for _, r := range records {
+		for _, l := range senmlLabels {
+			if _, ok := r[l]; ok {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func isProtobuf(payload []byte) bool {
+	for len(payload) > 0 {
+		num, typ, n := protowire.ConsumeTag(payload)
+		if n < 0 || num < 1 {
+			return false
+		}
+		payload = payload[n:]
+
+		n = protowire.ConsumeFieldValue(num, typ, payload)
+		if n < 0 {
+			return false
+		}
+		payload = payload[n:]
+	}
+
+	return true
+}
@@ -0,0 +1,49 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package json
+
+import (
+	"encoding/json"
+	"reflect"
+
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+	"github.com/MainfluxLabs/mainflux/pkg/transformers"
+	"github.com/fxamacker/cbor/v2"
+)
+
+var errDecodeCBOR = errors.New("failed to decode CBOR payload")
+
+type cborTransformer struct {
+	dm   cbor.DecMode
+	next transformers.Transformer
+}
+
+// NewCBOR returns a new transformer for CBOR encoded JSON documents.
+func NewCBOR(tfs []TimeField) transformers.Transformer {
+	dm, _ := cbor.DecOptions{
+		DefaultMapType: reflect.TypeOf(map[string]interface{}{}),
+	}.DecMode()
+
+	return cborTransformer{
+		dm:   dm,
+		next: New(tfs),
+	}
+}
+
+// Transform decodes CBOR payload and transforms it as JSON message.
+func (ct cborTransformer) Transform(msg messaging.Message) (interface{}, error) {
+	var payload interface{}
+	if err := ct.dm.Unmarshal(msg.Payload, &payload); err != nil {
+		return nil, errors.Wrap(ErrTransform, errors.Wrap(errDecodeCBOR, err))
+	}
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, errors.Wrap(ErrTransform, err)
+	}
+	msg.Payload = data
+
+	return ct.next.Transform(msg)
+}
@@ -8,12 +8,16 @@ type Payload map[string]interface{}
 
 // Message represents a JSON messages.
 type Message struct {
+	// ID identifies the message across the redeliveries of the message it
+	// is transformed from. It is empty if the message ID is not assigned.
+	ID        string  `json:"-" db:"-" bson:"-"`
 	Channel   string  `json:"channel,omitempty" db:"channel" bson:"channel"`
 	Created   int64   `json:"created,omitempty" db:"created" bson:"created"`
 	Subtopic  string  `json:"subtopic,omitempty" db:"subtopic" bson:"subtopic,omitempty"`
 	Publisher string  `json:"publisher,omitempty" db:"publisher" bson:"publisher"`
 	Protocol  string  `json:"protocol,omitempty" db:"protocol" bson:"protocol"`
 	Payload   Payload `json:"payload,omitempty" db:"payload" bson:"payload,omitempty"`
+	Seq       uint64  `json:"seq,omitempty" db:"seq" bson:"seq,omitempty"`
 }
 
 // Messages represents a list of JSON messages.
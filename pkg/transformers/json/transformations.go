@@ -0,0 +1,145 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package json
+
+import (
+	"sort"
+
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/transformers"
+	"github.com/MainfluxLabs/mainflux/pkg/transformers/senml"
+)
+
+// RenameFields returns a transformation that renames the top level fields
+// of JSON messages payloads. Fields are renamed from keys to values of the map.
+func RenameFields(fields map[string]string) transformers.Transformation {
+	return func(msgs interface{}) (interface{}, error) {
+		ms, ok := msgs.(Messages)
+		if !ok {
+			return msgs, nil
+		}
+
+		for _, m := range ms.Data {
+			for from, to := range fields {
+				if v, ok := m.Payload[from]; ok {
+					delete(m.Payload, from)
+					m.Payload[to] = v
+				}
+			}
+		}
+
+		return ms, nil
+	}
+}
+
+// FlattenPayload returns a transformation that flattens nested objects of
+// JSON messages payloads to the top level fields with dotted keys, e.g.
+// {"d": {"tmp": 21}} becomes {"d.tmp": 21}.
+func FlattenPayload() transformers.Transformation {
+	return func(msgs interface{}) (interface{}, error) {
+		ms, ok := msgs.(Messages)
+		if !ok {
+			return msgs, nil
+		}
+
+		for i, m := range ms.Data {
+			flat := make(Payload)
+			flattenDotted("", flat, m.Payload)
+			ms.Data[i].Payload = flat
+		}
+
+		return ms, nil
+	}
+}
+
+func flattenDotted(prefix string, dst, src map[string]interface{}) {
+	for k, v := range src {
+		if nested, ok := v.(map[string]interface{}); ok {
+			flattenDotted(prefix+k+".", dst, nested)
+			continue
+		}
+		dst[prefix+k] = v
+	}
+}
+
+// ExtractTime returns a transformation that sets the creation time of JSON
+// messages to the value of the time field of their payloads. Messages without
+// the time field keep the reception time.
+func ExtractTime(tf TimeField) transformers.Transformation {
+	return func(msgs interface{}) (interface{}, error) {
+		ms, ok := msgs.(Messages)
+		if !ok {
+			return msgs, nil
+		}
+
+		for i, m := range ms.Data {
+			val, ok := m.Payload[tf.FieldName]
+			if !ok {
+				continue
+			}
+
+			t, err := parseTimestamp(tf.FieldFormat, val, tf.Location)
+			if err != nil {
+				return nil, errors.Wrap(ErrInvalidTimeField, err)
+			}
+			ms.Data[i].Created = t.UnixNano()
+		}
+
+		return ms, nil
+	}
+}
+
+// ToSenML returns a transformation that converts JSON messages to SenML
+// messages, so they are stored and queried as SenML records. Payload fields
+// are mapped to SenML record names from keys to values of the map, and the
+// fields that are not mapped are dropped. Numeric, string and boolean fields
+// are mapped to the value, string value and boolean value of the record.
+func ToSenML(names map[string]string) transformers.Transformation {
+	// Fields are sorted so records are always produced in the same order.
+	var fields []string
+	for field := range names {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	return func(msgs interface{}) (interface{}, error) {
+		ms, ok := msgs.(Messages)
+		if !ok {
+			return msgs, nil
+		}
+
+		var res []senml.Message
+		for _, m := range ms.Data {
+			for _, field := range fields {
+				val, ok := m.Payload[field]
+				if !ok {
+					continue
+				}
+
+				rec := senml.Message{
+					Channel:   m.Channel,
+					Subtopic:  m.Subtopic,
+					Publisher: m.Publisher,
+					Protocol:  m.Protocol,
+					Name:      names[field],
+					Time:      float64(m.Created) / float64(1e9),
+					Seq:       m.Seq,
+				}
+				switch v := val.(type) {
+				case float64:
+					rec.Value = &v
+				case string:
+					rec.StringValue = &v
+				case bool:
+					rec.BoolValue = &v
+				default:
+					continue
+				}
+				res = append(res, rec)
+			}
+		}
+
+		return res, nil
+	}
+}
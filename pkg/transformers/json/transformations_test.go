@@ -0,0 +1,110 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package json_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+	"github.com/MainfluxLabs/mainflux/pkg/transformers"
+	"github.com/MainfluxLabs/mainflux/pkg/transformers/json"
+	"github.com/MainfluxLabs/mainflux/pkg/transformers/senml"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const vendorPayload = `{"meta": {"ts": 1638310819000}, "data": {"temp": 21.5, "status": "ok", "door": {"open": true}}}`
+
+func TestVendorTransformations(t *testing.T) {
+	msg := messaging.Message{
+		Channel:   "channel-1",
+		Subtopic:  "subtopic-1",
+		Publisher: "publisher-1",
+		Protocol:  "protocol",
+		Payload:   []byte(vendorPayload),
+		Created:   1,
+	}
+
+	flatten := json.FlattenPayload()
+	extract := json.ExtractTime(json.TimeField{FieldName: "meta.ts", FieldFormat: "unix_ms"})
+	toSenML := json.ToSenML(map[string]string{"data.temp": "temperature", "data.status": "status", "data.door.open": "door"})
+	created := int64(1638310819000) * 1e6
+
+	temp, status, door := 21.5, "ok", true
+
+	cases := []struct {
+		desc            string
+		transformations []transformers.Transformation
+		payload         []byte
+		res             interface{}
+		err             error
+	}{
+		{
+			desc:            "flatten payload",
+			transformations: []transformers.Transformation{flatten},
+			payload:         msg.Payload,
+			res: json.Messages{
+				Data: []json.Message{{
+					Channel:   msg.Channel,
+					Subtopic:  msg.Subtopic,
+					Publisher: msg.Publisher,
+					Protocol:  msg.Protocol,
+					Created:   msg.Created,
+					Payload:   json.Payload{"meta.ts": 1638310819000.0, "data.temp": temp, "data.status": status, "data.door.open": door},
+				}},
+				Format: msg.Subtopic,
+			},
+		},
+		{
+			desc:            "flatten payload and extract time",
+			transformations: []transformers.Transformation{flatten, extract},
+			payload:         msg.Payload,
+			res: json.Messages{
+				Data: []json.Message{{
+					Channel:   msg.Channel,
+					Subtopic:  msg.Subtopic,
+					Publisher: msg.Publisher,
+					Protocol:  msg.Protocol,
+					Created:   created,
+					Payload:   json.Payload{"meta.ts": 1638310819000.0, "data.temp": temp, "data.status": status, "data.door.open": door},
+				}},
+				Format: msg.Subtopic,
+			},
+		},
+		{
+			desc:            "flatten payload, extract time and map fields to SenML",
+			transformations: []transformers.Transformation{flatten, extract, toSenML},
+			payload:         msg.Payload,
+			res: []senml.Message{
+				{Channel: msg.Channel, Subtopic: msg.Subtopic, Publisher: msg.Publisher, Protocol: msg.Protocol, Name: "door", Time: float64(created) / 1e9, BoolValue: &door},
+				{Channel: msg.Channel, Subtopic: msg.Subtopic, Publisher: msg.Publisher, Protocol: msg.Protocol, Name: "status", Time: float64(created) / 1e9, StringValue: &status},
+				{Channel: msg.Channel, Subtopic: msg.Subtopic, Publisher: msg.Publisher, Protocol: msg.Protocol, Name: "temperature", Time: float64(created) / 1e9, Value: &temp},
+			},
+		},
+		{
+			desc:            "extract invalid time",
+			transformations: []transformers.Transformation{extract},
+			payload:         []byte(`{"meta.ts": "abc"}`),
+			res:             nil,
+			err:             json.ErrInvalidTimeField,
+		},
+	}
+
+	for _, tc := range cases {
+		m := msg
+		m.Payload = tc.payload
+		res, err := json.New(nil).Transform(m)
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+
+		for _, tr := range tc.transformations {
+			if res, err = tr(res); err != nil {
+				break
+			}
+		}
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected error %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.res, res, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.res, res))
+	}
+}
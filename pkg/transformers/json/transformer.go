@@ -31,9 +31,9 @@ var (
 
 // TimeField represents the message fields to use as timestamp
 type TimeField struct {
-	FieldName   string `toml:"field_name"`
-	FieldFormat string `toml:"field_format"`
-	Location    string `toml:"location"`
+	FieldName   string `toml:"field_name" json:"field_name"`
+	FieldFormat string `toml:"field_format" json:"field_format"`
+	Location    string `toml:"location" json:"location"`
 }
 
 type transformerService struct {
@@ -50,11 +50,13 @@ func New(tfs []TimeField) transformers.Transformer {
 // Transform transforms Mainflux message to a list of JSON messages.
 func (ts *transformerService) Transform(msg messaging.Message) (interface{}, error) {
 	ret := Message{
+		ID:        transformers.RecordID(msg, 0),
 		Publisher: msg.Publisher,
 		Created:   msg.Created,
 		Protocol:  msg.Protocol,
 		Channel:   msg.Channel,
 		Subtopic:  msg.Subtopic,
+		Seq:       msg.Seq,
 	}
 
 	if ret.Subtopic == "" {
@@ -89,12 +91,13 @@ func (ts *transformerService) Transform(msg messaging.Message) (interface{}, err
 	case []interface{}:
 		res := []Message{}
 		// Make an array of messages from the root array.
-		for _, val := range p {
+		for i, val := range p {
 			v, ok := val.(map[string]interface{})
 			if !ok {
 				return nil, errors.Wrap(ErrTransform, errInvalidNestedJSON)
 			}
 			newMsg := ret
+			newMsg.ID = transformers.RecordID(msg, i)
 
 			// Apply timestamp transformation rules depending on key/unit pairs
 			ts, err := ts.transformTimeField(v)
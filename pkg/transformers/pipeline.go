@@ -0,0 +1,99 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package transformers
+
+import (
+	"sync"
+
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+)
+
+// AllChannels is used to register transformations applied to the messages of every channel.
+const AllChannels = "*"
+
+// ErrUnsupportedContentType indicates that there is no transformer for the message content type.
+var ErrUnsupportedContentType = errors.New("unsupported content type")
+
+// Transformation modifies the messages produced by a transformer, e.g. by
+// converting units or renaming fields. Transformations should return
+// messages of the types they don't handle unchanged.
+type Transformation func(msgs interface{}) (interface{}, error)
+
+// ContentTypeResolver resolves the content type of the message payload.
+type ContentTypeResolver func(msg messaging.Message) string
+
+// Pipeline is a Transformer that transforms each message using the
+// transformer registered for its content type, and then applies the
+// transformations registered for its channel.
+type Pipeline interface {
+	Transformer
+
+	// Register registers the transformer for the content type.
+	Register(contentType string, t Transformer)
+
+	// AddTransformations registers transformations for the channel.
+	AddTransformations(chanID string, ts ...Transformation)
+}
+
+type pipeline struct {
+	mu              sync.RWMutex
+	resolve         ContentTypeResolver
+	transformers    map[string]Transformer
+	transformations map[string][]Transformation
+}
+
+// NewPipeline returns a new transformers pipeline. If resolver is nil,
+// the content type is detected from the message payload.
+func NewPipeline(resolve ContentTypeResolver) Pipeline {
+	if resolve == nil {
+		resolve = DetectContentType
+	}
+
+	return &pipeline{
+		resolve:         resolve,
+		transformers:    make(map[string]Transformer),
+		transformations: make(map[string][]Transformation),
+	}
+}
+
+func (p *pipeline) Register(contentType string, t Transformer) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.transformers[contentType] = t
+}
+
+func (p *pipeline) AddTransformations(chanID string, ts ...Transformation) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.transformations[chanID] = append(p.transformations[chanID], ts...)
+}
+
+func (p *pipeline) Transform(msg messaging.Message) (interface{}, error) {
+	p.mu.RLock()
+	t, ok := p.transformers[p.resolve(msg)]
+	var ts []Transformation
+	ts = append(ts, p.transformations[AllChannels]...)
+	ts = append(ts, p.transformations[msg.Channel]...)
+	p.mu.RUnlock()
+
+	if !ok {
+		return nil, ErrUnsupportedContentType
+	}
+
+	m, err := t.Transform(msg)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, tr := range ts {
+		if m, err = tr(m); err != nil {
+			return nil, err
+		}
+	}
+
+	return m, nil
+}
@@ -0,0 +1,138 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package transformers_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+	"github.com/MainfluxLabs/mainflux/pkg/transformers"
+	"github.com/MainfluxLabs/mainflux/pkg/transformers/json"
+	"github.com/MainfluxLabs/mainflux/pkg/transformers/senml"
+	"github.com/fxamacker/cbor/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+func TestDetectContentType(t *testing.T) {
+	cborObj, err := cbor.Marshal(map[string]interface{}{"temperature": 21.5})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	cborSenML, err := cbor.Marshal([]map[int]interface{}{{0: "temperature", 2: 21.5}})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	pb := protowire.AppendTag(nil, 1, protowire.VarintType)
+	pb = protowire.AppendVarint(pb, 42)
+
+	cases := []struct {
+		desc        string
+		payload     []byte
+		contentType string
+	}{
+		{
+			desc:        "detect SenML JSON",
+			payload:     []byte(`[{"n":"temperature","v":21.5,"u":"Cel"}]`),
+			contentType: transformers.SenMLJSON,
+		},
+		{
+			desc:        "detect JSON object",
+			payload:     []byte(`{"temperature":21.5}`),
+			contentType: transformers.JSON,
+		},
+		{
+			desc:        "detect JSON array",
+			payload:     []byte(` [{"temperature":21.5}]`),
+			contentType: transformers.JSON,
+		},
+		{
+			desc:        "detect SenML CBOR",
+			payload:     cborSenML,
+			contentType: transformers.SenMLCBOR,
+		},
+		{
+			desc:        "detect CBOR",
+			payload:     cborObj,
+			contentType: transformers.CBOR,
+		},
+		{
+			desc:        "detect protobuf",
+			payload:     pb,
+			contentType: transformers.Protobuf,
+		},
+		{
+			desc:        "detect empty payload",
+			payload:     []byte{},
+			contentType: "",
+		},
+	}
+
+	for _, tc := range cases {
+		ct := transformers.DetectContentType(messaging.Message{Payload: tc.payload})
+		assert.Equal(t, tc.contentType, ct, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.contentType, ct))
+	}
+}
+
+func TestPipelineTransform(t *testing.T) {
+	p := transformers.NewPipeline(nil)
+	p.Register(transformers.SenMLJSON, senml.New(senml.JSON))
+	p.Register(transformers.JSON, json.New(nil))
+	p.AddTransformations(transformers.AllChannels, senml.RenameRecords(map[string]string{"temp": "temperature"}))
+	p.AddTransformations("chan", senml.ConvertUnit("Cel", "K", 1, 273.15), json.RenameFields(map[string]string{"hum": "humidity"}))
+
+	v := 21.0
+	k := v + 273.15
+
+	cases := []struct {
+		desc string
+		msg  messaging.Message
+		res  interface{}
+		err  error
+	}{
+		{
+			desc: "transform SenML message with channel transformations",
+			msg: messaging.Message{
+				Channel:   "chan",
+				Publisher: "pub",
+				Payload:   []byte(`[{"n":"temp","u":"Cel","v":21}]`),
+			},
+			res: []senml.Message{{Channel: "chan", Publisher: "pub", Name: "temperature", Unit: "K", Value: &k}},
+		},
+		{
+			desc: "transform SenML message without channel transformations",
+			msg: messaging.Message{
+				Channel:   "other",
+				Publisher: "pub",
+				Payload:   []byte(`[{"n":"temp","u":"Cel","v":21}]`),
+			},
+			res: []senml.Message{{Channel: "other", Publisher: "pub", Name: "temperature", Unit: "Cel", Value: &v}},
+		},
+		{
+			desc: "transform JSON message with channel transformations",
+			msg: messaging.Message{
+				Channel:   "chan",
+				Subtopic:  "sensors",
+				Publisher: "pub",
+				Payload:   []byte(`{"hum":40}`),
+			},
+			res: json.Messages{
+				Data:   []json.Message{{Channel: "chan", Subtopic: "sensors", Publisher: "pub", Payload: json.Payload{"humidity": 40.0}}},
+				Format: "sensors",
+			},
+		},
+		{
+			desc: "transform message of unsupported content type",
+			msg: messaging.Message{
+				Channel: "chan",
+				Payload: []byte("plain text"),
+			},
+			err: transformers.ErrUnsupportedContentType,
+		},
+	}
+
+	for _, tc := range cases {
+		res, err := p.Transform(tc.msg)
+		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected error %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.res, res, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.res, res))
+	}
+}
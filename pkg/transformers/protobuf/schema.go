@@ -0,0 +1,110 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package protobuf
+
+import (
+	"math"
+
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// Field types supported by the schema. Field types determine how the wire
+// format value is interpreted, since the wire format doesn't tell e.g.
+// signed from unsigned integers or strings from bytes.
+const (
+	Int32    = "int32"
+	Int64    = "int64"
+	Uint32   = "uint32"
+	Uint64   = "uint64"
+	Sint32   = "sint32"
+	Sint64   = "sint64"
+	Bool     = "bool"
+	Float    = "float"
+	Fixed32  = "fixed32"
+	Sfixed32 = "sfixed32"
+	Double   = "double"
+	Fixed64  = "fixed64"
+	Sfixed64 = "sfixed64"
+	String   = "string"
+	Bytes    = "bytes"
+)
+
+var (
+	// ErrInvalidSchema indicates the schema with unknown field types.
+	ErrInvalidSchema = errors.New("invalid protobuf schema")
+
+	errTypeMismatch = errors.New("field type doesn't match wire type")
+)
+
+var wireTypes = map[string]protowire.Type{
+	Int32:    protowire.VarintType,
+	Int64:    protowire.VarintType,
+	Uint32:   protowire.VarintType,
+	Uint64:   protowire.VarintType,
+	Sint32:   protowire.VarintType,
+	Sint64:   protowire.VarintType,
+	Bool:     protowire.VarintType,
+	Float:    protowire.Fixed32Type,
+	Fixed32:  protowire.Fixed32Type,
+	Sfixed32: protowire.Fixed32Type,
+	Double:   protowire.Fixed64Type,
+	Fixed64:  protowire.Fixed64Type,
+	Sfixed64: protowire.Fixed64Type,
+	String:   protowire.BytesType,
+	Bytes:    protowire.BytesType,
+}
+
+// Field represents the named and typed field of the protobuf message.
+// Field without the type is decoded the same way as the unknown field.
+type Field struct {
+	Name string `toml:"name" json:"name"`
+	Type string `toml:"type" json:"type"`
+}
+
+// Schema maps field numbers of the protobuf message to its fields.
+type Schema map[int32]Field
+
+// Validate checks whether all the field types of the schema are supported.
+func (s Schema) Validate() error {
+	for _, f := range s {
+		if _, ok := wireTypes[f.Type]; f.Type != "" && !ok {
+			return ErrInvalidSchema
+		}
+	}
+
+	return nil
+}
+
+// typed converts the raw wire value to the value of the field type.
+func (f Field) typed(typ protowire.Type, raw interface{}) (interface{}, error) {
+	if wireTypes[f.Type] != typ {
+		return nil, errTypeMismatch
+	}
+
+	switch f.Type {
+	case Int32:
+		return int32(raw.(uint64)), nil
+	case Int64:
+		return int64(raw.(uint64)), nil
+	case Uint32:
+		return uint32(raw.(uint64)), nil
+	case Sint32, Sint64:
+		return protowire.DecodeZigZag(raw.(uint64)), nil
+	case Bool:
+		return raw.(uint64) != 0, nil
+	case Float:
+		return math.Float32frombits(raw.(uint32)), nil
+	case Sfixed32:
+		return int32(raw.(uint32)), nil
+	case Double:
+		return math.Float64frombits(raw.(uint64)), nil
+	case Sfixed64:
+		return int64(raw.(uint64)), nil
+	case String:
+		return string(raw.([]byte)), nil
+	default:
+		return raw, nil
+	}
+}
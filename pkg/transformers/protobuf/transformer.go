@@ -0,0 +1,162 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package protobuf contains the transformer for Protocol Buffers encoded
+// messages. The payload is decoded from the wire format into a JSON object
+// whose keys are the field names or, for unnamed fields, the field numbers.
+// Field names and types are taken from the schema resolved for the message
+// channel, and field types of messages without the schema are guessed from
+// the wire format.
+package protobuf
+
+import (
+	"encoding/json"
+	"math"
+	"strconv"
+	"unicode/utf8"
+
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+	"github.com/MainfluxLabs/mainflux/pkg/transformers"
+	mfjson "github.com/MainfluxLabs/mainflux/pkg/transformers/json"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+var (
+	// ErrTransform represents an error during parsing message.
+	ErrTransform = errors.New("unable to parse protobuf message")
+
+	errInvalidTag   = errors.New("invalid field tag")
+	errInvalidValue = errors.New("invalid field value")
+	errUnknownType  = errors.New("unsupported wire type")
+)
+
+// SchemaResolver returns the schema of the messages of the channel, or nil
+// if the channel doesn't have the schema.
+type SchemaResolver func(chanID string) Schema
+
+type transformer struct {
+	fields  map[int32]string
+	schemas SchemaResolver
+	next    transformers.Transformer
+}
+
+// New returns a new protobuf transformer. Fields map field numbers to the
+// names used as JSON keys of the transformed messages. Schemas resolved by
+// the channel ID take precedence over the fields. If resolver is nil, the
+// messages are decoded without the schema.
+func New(fields map[int32]string, schemas SchemaResolver, tfs []mfjson.TimeField) transformers.Transformer {
+	return transformer{
+		fields:  fields,
+		schemas: schemas,
+		next:    mfjson.New(tfs),
+	}
+}
+
+// Transform decodes protobuf payload and transforms it as JSON message.
+func (t transformer) Transform(msg messaging.Message) (interface{}, error) {
+	var schema Schema
+	if t.schemas != nil {
+		schema = t.schemas(msg.Channel)
+	}
+
+	payload, err := t.decode(schema, msg.Payload)
+	if err != nil {
+		return nil, errors.Wrap(ErrTransform, err)
+	}
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, errors.Wrap(ErrTransform, err)
+	}
+	msg.Payload = data
+
+	return t.next.Transform(msg)
+}
+
+func (t transformer) decode(schema Schema, b []byte) (map[string]interface{}, error) {
+	payload := make(map[string]interface{})
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return nil, errInvalidTag
+		}
+		b = b[n:]
+
+		var raw interface{}
+		switch typ {
+		case protowire.VarintType:
+			raw, n = protowire.ConsumeVarint(b)
+		case protowire.Fixed32Type:
+			raw, n = protowire.ConsumeFixed32(b)
+		case protowire.Fixed64Type:
+			raw, n = protowire.ConsumeFixed64(b)
+		case protowire.BytesType:
+			raw, n = protowire.ConsumeBytes(b)
+		default:
+			return nil, errUnknownType
+		}
+		if n < 0 {
+			return nil, errInvalidValue
+		}
+		b = b[n:]
+
+		key, val := t.key(int32(num)), guess(typ, raw)
+		if f, ok := schema[int32(num)]; ok {
+			if f.Type != "" {
+				var err error
+				if val, err = f.typed(typ, raw); err != nil {
+					return nil, err
+				}
+			}
+			if f.Name != "" {
+				key = f.Name
+			}
+		}
+
+		// Repeated fields are collected into arrays.
+		switch prev := payload[key].(type) {
+		case nil:
+			payload[key] = val
+		case []interface{}:
+			payload[key] = append(prev, val)
+		default:
+			payload[key] = []interface{}{prev, val}
+		}
+	}
+
+	return payload, nil
+}
+
+func (t transformer) key(num int32) string {
+	if name, ok := t.fields[num]; ok {
+		return name
+	}
+
+	return strconv.Itoa(int(num))
+}
+
+// guess converts the raw wire value of the field without the type,
+// decoding fixed size values as floating point numbers.
+func guess(typ protowire.Type, raw interface{}) interface{} {
+	switch typ {
+	case protowire.Fixed32Type:
+		return math.Float32frombits(raw.(uint32))
+	case protowire.Fixed64Type:
+		return math.Float64frombits(raw.(uint64))
+	case protowire.BytesType:
+		return bytesValue(raw.([]byte))
+	default:
+		return raw
+	}
+}
+
+// bytesValue returns valid UTF-8 bytes as string and other bytes as
+// they are, which are encoded as base64 string in the JSON payload.
+func bytesValue(b []byte) interface{} {
+	if utf8.Valid(b) {
+		return string(b)
+	}
+
+	return b
+}
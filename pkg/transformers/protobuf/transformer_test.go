@@ -0,0 +1,168 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package protobuf_test
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+	"github.com/MainfluxLabs/mainflux/pkg/transformers/json"
+	"github.com/MainfluxLabs/mainflux/pkg/transformers/protobuf"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+func TestTransform(t *testing.T) {
+	tr := protobuf.New(map[int32]string{1: "temperature", 2: "name"}, nil, nil)
+
+	pb := protowire.AppendTag(nil, 1, protowire.Fixed64Type)
+	pb = protowire.AppendFixed64(pb, math.Float64bits(21.5))
+	pb = protowire.AppendTag(pb, 2, protowire.BytesType)
+	pb = protowire.AppendString(pb, "sensor")
+	pb = protowire.AppendTag(pb, 3, protowire.VarintType)
+	pb = protowire.AppendVarint(pb, 7)
+	pb = protowire.AppendTag(pb, 3, protowire.VarintType)
+	pb = protowire.AppendVarint(pb, 8)
+
+	msg := messaging.Message{
+		Channel:   "channel",
+		Subtopic:  "subtopic",
+		Publisher: "publisher",
+		Protocol:  "protocol",
+		Payload:   pb,
+	}
+
+	invalid := msg
+	invalid.Payload = protowire.AppendTag(nil, 1, protowire.BytesType)
+
+	cases := []struct {
+		desc string
+		msg  messaging.Message
+		res  interface{}
+		err  error
+	}{
+		{
+			desc: "transform protobuf message",
+			msg:  msg,
+			res: json.Messages{
+				Data: []json.Message{
+					{
+						Channel:   msg.Channel,
+						Subtopic:  msg.Subtopic,
+						Publisher: msg.Publisher,
+						Protocol:  msg.Protocol,
+						Payload: json.Payload{
+							"temperature": 21.5,
+							"name":        "sensor",
+							"3":           []interface{}{7.0, 8.0},
+						},
+					},
+				},
+				Format: msg.Subtopic,
+			},
+		},
+		{
+			desc: "transform invalid protobuf message",
+			msg:  invalid,
+			err:  protobuf.ErrTransform,
+		},
+	}
+
+	for _, tc := range cases {
+		res, err := tr.Transform(tc.msg)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected error %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.res, res, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.res, res))
+	}
+}
+
+func TestTransformWithSchema(t *testing.T) {
+	schema := protobuf.Schema{
+		1: {Name: "temperature", Type: protobuf.Float},
+		2: {Name: "offset", Type: protobuf.Sint32},
+		3: {Name: "alarm", Type: protobuf.Bool},
+		4: {Name: "serial"},
+	}
+	schemas := map[string]protobuf.Schema{"channel": schema}
+	tr := protobuf.New(map[int32]string{1: "value"}, func(chanID string) protobuf.Schema { return schemas[chanID] }, nil)
+
+	pb := protowire.AppendTag(nil, 1, protowire.Fixed32Type)
+	pb = protowire.AppendFixed32(pb, math.Float32bits(21.5))
+	pb = protowire.AppendTag(pb, 2, protowire.VarintType)
+	pb = protowire.AppendVarint(pb, protowire.EncodeZigZag(-3))
+	pb = protowire.AppendTag(pb, 3, protowire.VarintType)
+	pb = protowire.AppendVarint(pb, 1)
+	pb = protowire.AppendTag(pb, 4, protowire.BytesType)
+	pb = protowire.AppendString(pb, "A1")
+
+	msg := messaging.Message{
+		Channel:   "channel",
+		Subtopic:  "subtopic",
+		Publisher: "publisher",
+		Protocol:  "protocol",
+		Payload:   pb,
+	}
+
+	other := msg
+	other.Channel = "other"
+
+	mismatch := msg
+	mismatch.Payload = protowire.AppendTag(nil, 1, protowire.VarintType)
+	mismatch.Payload = protowire.AppendVarint(mismatch.Payload, 21)
+
+	cases := []struct {
+		desc    string
+		msg     messaging.Message
+		payload json.Payload
+		err     error
+	}{
+		{
+			desc: "transform message using channel schema",
+			msg:  msg,
+			payload: json.Payload{
+				"temperature": 21.5,
+				"offset":      -3.0,
+				"alarm":       true,
+				"serial":      "A1",
+			},
+		},
+		{
+			desc: "transform message of channel without schema",
+			msg:  other,
+			payload: json.Payload{
+				"value": 21.5,
+				"2":     5.0,
+				"3":     1.0,
+				"4":     "A1",
+			},
+		},
+		{
+			desc: "transform message with field type not matching schema",
+			msg:  mismatch,
+			err:  protobuf.ErrTransform,
+		},
+	}
+
+	for _, tc := range cases {
+		res, err := tr.Transform(tc.msg)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected error %s got %s\n", tc.desc, tc.err, err))
+		if tc.err != nil {
+			continue
+		}
+		payload := res.(json.Messages).Data[0].Payload
+		assert.Equal(t, tc.payload, payload, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.payload, payload))
+	}
+}
+
+func TestValidateSchema(t *testing.T) {
+	valid := protobuf.Schema{1: {Name: "temperature", Type: protobuf.Double}, 2: {Name: "name"}}
+	err := valid.Validate()
+	assert.Nil(t, err, fmt.Sprintf("validate valid schema: unexpected error %s\n", err))
+
+	invalid := protobuf.Schema{1: {Name: "temperature", Type: "decimal"}}
+	err = invalid.Validate()
+	assert.Equal(t, protobuf.ErrInvalidSchema, err, fmt.Sprintf("validate invalid schema: expected %s got %s\n", protobuf.ErrInvalidSchema, err))
+}
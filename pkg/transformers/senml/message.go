@@ -2,6 +2,9 @@ package senml
 
 // Message represents a resolved (normalized) SenML record.
 type Message struct {
+	// ID identifies the record across the redeliveries of the message it is
+	// transformed from. It is empty if the message ID is not assigned.
+	ID          string   `json:"-" db:"-" bson:"-"`
 	Channel     string   `json:"channel,omitempty" db:"channel" bson:"channel"`
 	Subtopic    string   `json:"subtopic,omitempty" db:"subtopic" bson:"subtopic,omitempty"`
 	Publisher   string   `json:"publisher,omitempty" db:"publisher" bson:"publisher"`
@@ -15,4 +18,5 @@ type Message struct {
 	DataValue   *string  `json:"data_value,omitempty" db:"data_value" bson:"data_value,omitempty"`
 	BoolValue   *bool    `json:"bool_value,omitempty" db:"bool_value" bson:"bool_value,omitempty"`
 	Sum         *float64 `json:"sum,omitempty" db:"sum" bson:"sum,omitempty"`
+	Seq         uint64   `json:"seq,omitempty" db:"seq" bson:"seq,omitempty"`
 }
@@ -0,0 +1,77 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package senml
+
+import "github.com/MainfluxLabs/mainflux/pkg/transformers"
+
+// ConvertUnit returns a transformation that converts values of the SenML
+// messages in the from unit to the to unit as value * scale + offset.
+func ConvertUnit(from, to string, scale, offset float64) transformers.Transformation {
+	c := UnitConversion{From: from, To: to, Scale: scale, Offset: offset}
+	return func(msgs interface{}) (interface{}, error) {
+		ms, ok := msgs.([]Message)
+		if !ok {
+			return msgs, nil
+		}
+
+		for i := range ms {
+			if ms[i].Unit == from {
+				c.apply(&ms[i])
+			}
+		}
+
+		return ms, nil
+	}
+}
+
+// RenameRecords returns a transformation that renames the SenML messages.
+// Messages are renamed from keys to values of the map.
+func RenameRecords(names map[string]string) transformers.Transformation {
+	return func(msgs interface{}) (interface{}, error) {
+		ms, ok := msgs.([]Message)
+		if !ok {
+			return msgs, nil
+		}
+
+		for i := range ms {
+			if name, ok := names[ms[i].Name]; ok {
+				ms[i].Name = name
+			}
+		}
+
+		return ms, nil
+	}
+}
+
+// FilterRecords returns a transformation that drops the SenML messages by
+// their names. If the include list isn't empty, only the messages with the
+// listed names are kept. Messages with the names from the exclude list are
+// dropped.
+func FilterRecords(include, exclude []string) transformers.Transformation {
+	inc := make(map[string]bool, len(include))
+	for _, name := range include {
+		inc[name] = true
+	}
+	exc := make(map[string]bool, len(exclude))
+	for _, name := range exclude {
+		exc[name] = true
+	}
+
+	return func(msgs interface{}) (interface{}, error) {
+		ms, ok := msgs.([]Message)
+		if !ok {
+			return msgs, nil
+		}
+
+		filtered := ms[:0]
+		for _, m := range ms {
+			if (len(inc) > 0 && !inc[m.Name]) || exc[m.Name] {
+				continue
+			}
+			filtered = append(filtered, m)
+		}
+
+		return filtered, nil
+	}
+}
@@ -64,6 +64,7 @@ func (t transformer) Transform(msg messaging.Message) (interface{}, error) {
 		}
 
 		msgs[i] = Message{
+			ID:          transformers.RecordID(msg, i),
 			Channel:     msg.Channel,
 			Subtopic:    msg.Subtopic,
 			Publisher:   msg.Publisher,
@@ -77,6 +78,7 @@ func (t transformer) Transform(msg messaging.Message) (interface{}, error) {
 			DataValue:   v.DataValue,
 			StringValue: v.StringValue,
 			Sum:         v.Sum,
+			Seq:         msg.Seq,
 		}
 	}
 
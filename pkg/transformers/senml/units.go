@@ -0,0 +1,137 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package senml
+
+import (
+	"sync"
+
+	"github.com/MainfluxLabs/mainflux/pkg/transformers"
+)
+
+// UnitConversion converts values in the From unit to the To unit as
+// value * Scale + Offset. Sums are only scaled.
+type UnitConversion struct {
+	From   string
+	To     string
+	Scale  float64
+	Offset float64
+}
+
+func (c UnitConversion) apply(msg *Message) {
+	if msg.Value != nil {
+		v := *msg.Value*c.Scale + c.Offset
+		msg.Value = &v
+	}
+	if msg.Sum != nil {
+		s := *msg.Sum * c.Scale
+		msg.Sum = &s
+	}
+	msg.Unit = c.To
+}
+
+// DefaultUnitConversions contains conversions between the units commonly
+// reported by heterogeneous devices. Units follow the SenML units registry
+// where possible.
+var DefaultUnitConversions = []UnitConversion{
+	{From: "degF", To: "Cel", Scale: 5.0 / 9, Offset: -160.0 / 9},
+	{From: "K", To: "Cel", Scale: 1, Offset: -273.15},
+	{From: "Cel", To: "K", Scale: 1, Offset: 273.15},
+	{From: "psi", To: "kPa", Scale: 6.894757},
+	{From: "psi", To: "Pa", Scale: 6894.757},
+	{From: "bar", To: "kPa", Scale: 100},
+	{From: "hPa", To: "kPa", Scale: 0.1},
+	{From: "hPa", To: "Pa", Scale: 100},
+	{From: "kPa", To: "Pa", Scale: 1000},
+	{From: "km/h", To: "m/s", Scale: 1 / 3.6},
+	{From: "mph", To: "m/s", Scale: 0.44704},
+	{From: "Wh", To: "J", Scale: 3600},
+	{From: "kWh", To: "J", Scale: 3.6e6},
+	{From: "kWh", To: "Wh", Scale: 1000},
+	{From: "mm", To: "m", Scale: 0.001},
+	{From: "cm", To: "m", Scale: 0.01},
+	{From: "km", To: "m", Scale: 1000},
+	{From: "ms", To: "s", Scale: 0.001},
+	{From: "min", To: "s", Scale: 60},
+	{From: "h", To: "s", Scale: 3600},
+}
+
+// UnitRegistry contains the conversions used to normalize units of the
+// SenML messages.
+type UnitRegistry interface {
+	// Register adds the conversion to the registry, replacing the existing
+	// conversion between the same units.
+	Register(c UnitConversion)
+
+	// Conversion returns the conversion between the units.
+	Conversion(from, to string) (UnitConversion, bool)
+}
+
+var _ UnitRegistry = (*unitRegistry)(nil)
+
+type unitRegistry struct {
+	mu          sync.RWMutex
+	conversions map[string]map[string]UnitConversion
+}
+
+// NewUnitRegistry returns unit registry containing the default conversions
+// and the given ones, which take precedence over the default conversions.
+func NewUnitRegistry(conversions ...UnitConversion) UnitRegistry {
+	r := &unitRegistry{conversions: make(map[string]map[string]UnitConversion)}
+	for _, c := range append(DefaultUnitConversions, conversions...) {
+		r.Register(c)
+	}
+
+	return r
+}
+
+func (r *unitRegistry) Register(c UnitConversion) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.conversions[c.From] == nil {
+		r.conversions[c.From] = make(map[string]UnitConversion)
+	}
+	r.conversions[c.From][c.To] = c
+}
+
+func (r *unitRegistry) Conversion(from, to string) (UnitConversion, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	c, ok := r.conversions[from][to]
+	return c, ok
+}
+
+// NormalizeUnits returns a transformation that converts values of the SenML
+// messages to the first of the given units reachable from the message unit
+// using the registry. Messages already in one of the units, and messages
+// whose unit can't be converted, are left unchanged.
+func NormalizeUnits(r UnitRegistry, units ...string) transformers.Transformation {
+	targets := make(map[string]bool)
+	for _, u := range units {
+		targets[u] = true
+	}
+
+	return func(msgs interface{}) (interface{}, error) {
+		ms, ok := msgs.([]Message)
+		if !ok {
+			return msgs, nil
+		}
+
+		for i := range ms {
+			if ms[i].Unit == "" || targets[ms[i].Unit] {
+				continue
+			}
+
+			for _, u := range units {
+				if c, ok := r.Conversion(ms[i].Unit, u); ok {
+					c.apply(&ms[i])
+					break
+				}
+			}
+		}
+
+		return ms, nil
+	}
+}
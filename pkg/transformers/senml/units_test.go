@@ -0,0 +1,81 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package senml_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/MainfluxLabs/mainflux/pkg/transformers/senml"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeUnits(t *testing.T) {
+	r := senml.NewUnitRegistry(senml.UnitConversion{From: "inHg", To: "kPa", Scale: 3.386389})
+	normalize := senml.NormalizeUnits(r, "Cel", "kPa")
+
+	float := func(v float64) *float64 { return &v }
+
+	cases := []struct {
+		desc  string
+		msg   senml.Message
+		unit  string
+		value float64
+	}{
+		{
+			desc:  "normalize Fahrenheit degrees",
+			msg:   senml.Message{Name: "temperature", Unit: "degF", Value: float(212)},
+			unit:  "Cel",
+			value: 100,
+		},
+		{
+			desc:  "normalize psi",
+			msg:   senml.Message{Name: "pressure", Unit: "psi", Value: float(10)},
+			unit:  "kPa",
+			value: 68.94757,
+		},
+		{
+			desc:  "normalize unit using registered conversion",
+			msg:   senml.Message{Name: "pressure", Unit: "inHg", Value: float(10)},
+			unit:  "kPa",
+			value: 33.86389,
+		},
+		{
+			desc:  "normalize message already in normalized unit",
+			msg:   senml.Message{Name: "temperature", Unit: "Cel", Value: float(21.5)},
+			unit:  "Cel",
+			value: 21.5,
+		},
+		{
+			desc:  "normalize unit without conversion",
+			msg:   senml.Message{Name: "humidity", Unit: "%RH", Value: float(40)},
+			unit:  "%RH",
+			value: 40,
+		},
+	}
+
+	for _, tc := range cases {
+		res, err := normalize([]senml.Message{tc.msg})
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+		msgs := res.([]senml.Message)
+		assert.Equal(t, tc.unit, msgs[0].Unit, fmt.Sprintf("%s: expected unit %s got %s\n", tc.desc, tc.unit, msgs[0].Unit))
+		assert.InDelta(t, tc.value, *msgs[0].Value, 1e-9, fmt.Sprintf("%s: expected value %f got %f\n", tc.desc, tc.value, *msgs[0].Value))
+	}
+}
+
+func TestUnitRegistry(t *testing.T) {
+	r := senml.NewUnitRegistry(senml.UnitConversion{From: "psi", To: "kPa", Scale: 7})
+
+	c, ok := r.Conversion("psi", "kPa")
+	assert.True(t, ok, "expected conversion from psi to kPa to exist")
+	assert.Equal(t, 7.0, c.Scale, fmt.Sprintf("expected custom conversion to override the default one, got scale %f", c.Scale))
+
+	_, ok = r.Conversion("kPa", "psi")
+	assert.False(t, ok, "expected conversion from kPa to psi not to exist")
+
+	r.Register(senml.UnitConversion{From: "kPa", To: "psi", Scale: 0.145038})
+	_, ok = r.Conversion("kPa", "psi")
+	assert.True(t, ok, "expected registered conversion from kPa to psi to exist")
+}
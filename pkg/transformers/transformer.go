@@ -3,10 +3,28 @@
 
 package transformers
 
-import "github.com/MainfluxLabs/mainflux/pkg/messaging"
+import (
+	"strconv"
+
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+	"github.com/gofrs/uuid"
+)
 
 // Transformer specifies API form Message transformer.
 type Transformer interface {
 	// Transform Mainflux message to any other format.
 	Transform(msg messaging.Message) (interface{}, error)
 }
+
+// RecordID returns the ID of the i-th message transformed from the message.
+// The ID is derived from the message ID, so that the message redelivered by
+// the message broker is transformed to the messages with the same IDs. An
+// empty ID is returned if the message ID is not assigned.
+func RecordID(msg messaging.Message, i int) string {
+	ns, err := uuid.FromString(msg.Id)
+	if err != nil {
+		return ""
+	}
+
+	return uuid.NewV5(ns, strconv.Itoa(i)).String()
+}
@@ -0,0 +1,30 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package transformers_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+	"github.com/MainfluxLabs/mainflux/pkg/transformers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordID(t *testing.T) {
+	id, err := messaging.NewID()
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	msg := messaging.Message{Id: id}
+
+	first := transformers.RecordID(msg, 0)
+	assert.NotEmpty(t, first, "expected record ID to be derived from the message ID")
+	assert.Equal(t, first, transformers.RecordID(msg, 0), "expected redelivered message record to keep the ID")
+	assert.NotEqual(t, first, transformers.RecordID(msg, 1), "expected records of the message to have distinct IDs")
+
+	other, err := messaging.NewID()
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.NotEqual(t, first, transformers.RecordID(messaging.Message{Id: other}, 0), "expected records of distinct messages to have distinct IDs")
+	assert.Empty(t, transformers.RecordID(messaging.Message{}, 0), "expected empty record ID of the message without ID")
+}
@@ -5,12 +5,15 @@
 package ulid
 
 import (
+	"io"
 	"time"
 
 	"github.com/MainfluxLabs/mainflux"
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/gofrs/uuid"
 	"github.com/oklog/ulid/v2"
 
+	cryptorand "crypto/rand"
 	mathrand "math/rand"
 )
 
@@ -20,7 +23,8 @@ var ErrGeneratingID = errors.New("generating id failed")
 var _ mainflux.IDProvider = (*ulidProvider)(nil)
 
 type ulidProvider struct {
-	entropy *mathrand.Rand
+	entropy io.Reader
+	uuid    bool
 }
 
 // New instantiates a ULID provider.
@@ -32,11 +36,25 @@ func New() mainflux.IDProvider {
 	}
 }
 
+// NewUUID instantiates a ULID provider which encodes IDs in the canonical
+// UUID form, so that they can be stored wherever UUIDs are expected while
+// preserving their chronological order.
+func NewUUID() mainflux.IDProvider {
+	return &ulidProvider{
+		entropy: cryptorand.Reader,
+		uuid:    true,
+	}
+}
+
 func (up *ulidProvider) ID() (string, error) {
 	id, err := ulid.New(ulid.Timestamp(time.Now()), up.entropy)
 	if err != nil {
 		return "", err
 	}
 
+	if up.uuid {
+		return uuid.UUID(id).String(), nil
+	}
+
 	return id.String(), nil
 }
@@ -0,0 +1,69 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package ulid_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux"
+	"github.com/MainfluxLabs/mainflux/pkg/ulid"
+	"github.com/gofrs/uuid"
+	oklog "github.com/oklog/ulid/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const count = 10
+
+func TestID(t *testing.T) {
+	cases := []struct {
+		desc  string
+		idp   mainflux.IDProvider
+		parse func(id string) (oklog.ULID, error)
+	}{
+		{
+			desc:  "generate ULID",
+			idp:   ulid.New(),
+			parse: oklog.ParseStrict,
+		},
+		{
+			desc: "generate ULID encoded as UUID",
+			idp:  ulid.NewUUID(),
+			parse: func(id string) (oklog.ULID, error) {
+				u, err := uuid.FromString(id)
+				if err != nil {
+					return oklog.ULID{}, err
+				}
+				if id != u.String() {
+					return oklog.ULID{}, fmt.Errorf("non-canonical UUID %s", id)
+				}
+				return oklog.ULID(u), nil
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		var prev string
+		for i := 0; i < count; i++ {
+			before := time.Now().Truncate(time.Millisecond)
+			id, err := tc.idp.ID()
+			require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+
+			u, err := tc.parse(id)
+			require.Nil(t, err, fmt.Sprintf("%s: invalid ID %s: %s", tc.desc, id, err))
+
+			created := oklog.Time(u.Time())
+			assert.False(t, created.Before(before), fmt.Sprintf("%s: expected ID time after %s got %s", tc.desc, before, created))
+			assert.False(t, created.After(time.Now()), fmt.Sprintf("%s: expected ID time before now got %s", tc.desc, created))
+
+			assert.Less(t, prev, id, fmt.Sprintf("%s: expected ID %s to sort after %s", tc.desc, id, prev))
+			prev = id
+
+			// IDs are ordered only across milliseconds.
+			time.Sleep(2 * time.Millisecond)
+		}
+	}
+}
@@ -3,8 +3,9 @@ package api
 import (
 	"context"
 
-	"github.com/go-kit/kit/endpoint"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
 	"github.com/MainfluxLabs/mainflux/provision"
+	"github.com/go-kit/kit/endpoint"
 )
 
 func doProvision(svc provision.Service) endpoint.Endpoint {
@@ -15,9 +16,25 @@ func doProvision(svc provision.Service) endpoint.Endpoint {
 		}
 		token := req.token
 
-		res, err := svc.Provision(token, req.Name, req.ExternalID, req.ExternalKey)
+		if req.dryRun {
+			res, err := svc.DryRun(token, req.Name, req.ExternalID, req.ExternalKey)
+			if err != nil {
+				return nil, err
+			}
+
+			return provisionRes{
+				Things:   res.Things,
+				Channels: res.Channels,
+				dryRun:   true,
+			}, nil
+		}
+
+		res, err := svc.Provision(token, req.idempotencyKey, req.Name, req.ExternalID, req.ExternalKey)
 
 		if err != nil {
+			if errors.Contains(err, provision.ErrIdempotencyKeyInUse) {
+				return nil, err
+			}
 			return provisionRes{Error: err.Error()}, nil
 		}
 
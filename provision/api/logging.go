@@ -25,27 +25,43 @@ func NewLoggingMiddleware(svc provision.Service, logger log.Logger) provision.Se
 	return &loggingMiddleware{logger, svc}
 }
 
-func (lm *loggingMiddleware) Provision(token, name, externalID, externalKey string) (res provision.Result, err error) {
+func (lm *loggingMiddleware) Provision(token, idempotencyKey, name, externalID, externalKey string) (res provision.Result, err error) {
 	defer func(begin time.Time) {
+		l := lm.logger.With("method", "provision", "latency", time.Since(begin).String())
 		message := fmt.Sprintf("Method provision for token: %s and things: %v took %s to complete", token, res.Things, time.Since(begin))
 		if err != nil {
-			lm.logger.Warn(fmt.Sprintf("%s with error: %s", message, err))
+			l.Warn(fmt.Sprintf("%s with error: %s", message, err))
 			return
 		}
-		lm.logger.Info(fmt.Sprintf("%s without errors", message))
+		l.Info(fmt.Sprintf("%s without errors", message))
 	}(time.Now())
 
-	return lm.svc.Provision(token, name, externalID, externalKey)
+	return lm.svc.Provision(token, idempotencyKey, name, externalID, externalKey)
+}
+
+func (lm *loggingMiddleware) DryRun(token, name, externalID, externalKey string) (res provision.Result, err error) {
+	defer func(begin time.Time) {
+		l := lm.logger.With("method", "dry_run", "latency", time.Since(begin).String())
+		message := fmt.Sprintf("Method dry_run for token: %s and things: %v took %s to complete", token, res.Things, time.Since(begin))
+		if err != nil {
+			l.Warn(fmt.Sprintf("%s with error: %s", message, err))
+			return
+		}
+		l.Info(fmt.Sprintf("%s without errors", message))
+	}(time.Now())
+
+	return lm.svc.DryRun(token, name, externalID, externalKey)
 }
 
 func (lm *loggingMiddleware) Cert(token, thingID, duration string, keyBits int) (cert string, key string, err error) {
 	defer func(begin time.Time) {
+		l := lm.logger.With("method", "cert", "latency", time.Since(begin).String())
 		message := fmt.Sprintf("Method cert for token: %s and thing: %v took %s to complete", token, thingID, time.Since(begin))
 		if err != nil {
-			lm.logger.Warn(fmt.Sprintf("%s with error: %s", message, err))
+			l.Warn(fmt.Sprintf("%s with error: %s", message, err))
 			return
 		}
-		lm.logger.Info(fmt.Sprintf("%s without errors", message))
+		l.Info(fmt.Sprintf("%s without errors", message))
 	}(time.Now())
 
 	return lm.svc.Cert(token, thingID, duration, keyBits)
@@ -53,12 +69,13 @@ func (lm *loggingMiddleware) Cert(token, thingID, duration string, keyBits int)
 
 func (lm *loggingMiddleware) Mapping(token string) (res map[string]interface{}, err error) {
 	defer func(begin time.Time) {
+		l := lm.logger.With("method", "mapping", "latency", time.Since(begin).String())
 		message := fmt.Sprintf("Method mapping for token: %s took %s to complete", token, time.Since(begin))
 		if err != nil {
-			lm.logger.Warn(fmt.Sprintf("%s with error: %s", message, err))
+			l.Warn(fmt.Sprintf("%s with error: %s", message, err))
 			return
 		}
-		lm.logger.Info(fmt.Sprintf("%s without errors", message))
+		l.Info(fmt.Sprintf("%s without errors", message))
 	}(time.Now())
 
 	return lm.svc.Mapping(token)
@@ -3,10 +3,12 @@ package api
 import "github.com/MainfluxLabs/mainflux/internal/apiutil"
 
 type provisionReq struct {
-	token       string
-	Name        string `json:"name"`
-	ExternalID  string `json:"external_id"`
-	ExternalKey string `json:"external_key"`
+	token          string
+	idempotencyKey string
+	dryRun         bool
+	Name           string `json:"name"`
+	ExternalID     string `json:"external_id"`
+	ExternalKey    string `json:"external_key"`
 }
 
 func (req provisionReq) validate() error {
@@ -14,9 +14,14 @@ type provisionRes struct {
 	CACert      string            `json:"ca_cert,omitempty"`
 	Whitelisted map[string]bool   `json:"whitelisted,omitempty"`
 	Error       string            `json:"error,omitempty"`
+	dryRun      bool
 }
 
 func (res provisionRes) Code() int {
+	if res.dryRun {
+		return http.StatusOK
+	}
+
 	return http.StatusCreated
 }
 
@@ -16,11 +16,13 @@ import (
 )
 
 const (
-	contentType = "application/json"
+	contentType       = "application/json"
+	idempotencyHeader = "Idempotency-Key"
+	dryRunKey         = "dry_run"
 )
 
 // MakeHandler returns a HTTP handler for API endpoints.
-func MakeHandler(svc provision.Service, logger logger.Logger) http.Handler {
+func MakeHandler(svc provision.Service, logger logger.Logger, checks ...mainflux.HealthCheck) http.Handler {
 
 	opts := []kithttp.ServerOption{
 		kithttp.ServerErrorEncoder(apiutil.LoggingErrorEncoder(logger, encodeError)),
@@ -43,9 +45,10 @@ func MakeHandler(svc provision.Service, logger logger.Logger) http.Handler {
 	))
 
 	r.Handle("/metrics", promhttp.Handler())
-	r.GetFunc("/health", mainflux.Health("provision"))
+	r.Handle("/log-level", mainflux.LogLevel(logger))
+	r.GetFunc("/health", mainflux.Health("provision", checks...))
 
-	return r
+	return apiutil.RequestIDMiddleware(apiutil.LocaleMiddleware(r))
 }
 
 func encodeResponse(_ context.Context, w http.ResponseWriter, response interface{}) error {
@@ -71,7 +74,16 @@ func decodeProvisionRequest(_ context.Context, r *http.Request) (interface{}, er
 		return nil, apiutil.ErrUnsupportedContentType
 	}
 
-	req := provisionReq{token: apiutil.ExtractBearerToken(r)}
+	dryRun, err := apiutil.ReadBoolQuery(r, dryRunKey, false)
+	if err != nil {
+		return nil, err
+	}
+
+	req := provisionReq{
+		token:          apiutil.ExtractBearerToken(r),
+		idempotencyKey: r.Header.Get(idempotencyHeader),
+		dryRun:         dryRun,
+	}
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		return nil, err
 	}
@@ -89,7 +101,7 @@ func decodeMappingRequest(_ context.Context, r *http.Request) (interface{}, erro
 	return req, nil
 }
 
-func encodeError(_ context.Context, err error, w http.ResponseWriter) {
+func encodeError(ctx context.Context, err error, w http.ResponseWriter) {
 	switch {
 	case errors.Contains(err, errors.ErrAuthentication),
 		err == apiutil.ErrBearerToken:
@@ -97,10 +109,12 @@ func encodeError(_ context.Context, err error, w http.ResponseWriter) {
 	case errors.Contains(err, apiutil.ErrUnsupportedContentType):
 		w.WriteHeader(http.StatusUnsupportedMediaType)
 	case errors.Contains(err, apiutil.ErrMalformedEntity),
+		errors.Contains(err, apiutil.ErrInvalidQueryParams),
 		err == apiutil.ErrMissingID,
 		err == apiutil.ErrBearerKey:
 		w.WriteHeader(http.StatusBadRequest)
-	case errors.Contains(err, errors.ErrConflict):
+	case errors.Contains(err, errors.ErrConflict),
+		errors.Contains(err, provision.ErrIdempotencyKeyInUse):
 		w.WriteHeader(http.StatusConflict)
 
 	default:
@@ -109,7 +123,7 @@ func encodeError(_ context.Context, err error, w http.ResponseWriter) {
 
 	if errorVal, ok := err.(errors.Error); ok {
 		w.Header().Set("Content-Type", contentType)
-		if err := json.NewEncoder(w).Encode(apiutil.ErrorRes{Err: errorVal.Msg()}); err != nil {
+		if err := json.NewEncoder(w).Encode(apiutil.NewErrorRes(ctx, errorVal)); err != nil {
 			w.WriteHeader(http.StatusInternalServerError)
 		}
 	}
@@ -0,0 +1,93 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package provision
+
+import (
+	"sync"
+	"time"
+)
+
+var _ ResultCache = (*resultCache)(nil)
+
+// ResultCache stores the results of the provisioning calls by their
+// idempotency keys, so that retried calls don't create duplicate entities.
+type ResultCache interface {
+	// Reserve reserves the key for the call in progress. If the call with the
+	// same key has already completed, its result is returned along with true.
+	// ErrIdempotencyKeyInUse is returned if the call with the same key is
+	// still in progress.
+	Reserve(key string) (Result, bool, error)
+
+	// Save stores the result of the completed call.
+	Save(key string, res Result)
+
+	// Release releases the key of the failed call so the call can be retried.
+	Release(key string)
+}
+
+type cacheEntry struct {
+	res       Result
+	done      bool
+	expiresAt time.Time
+}
+
+type resultCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	entries map[string]cacheEntry
+}
+
+// NewResultCache returns in-memory result cache which keeps the results
+// for the given duration.
+func NewResultCache(ttl time.Duration) ResultCache {
+	return &resultCache{
+		ttl:     ttl,
+		entries: make(map[string]cacheEntry),
+	}
+}
+
+func (rc *resultCache) Reserve(key string) (Result, bool, error) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	now := time.Now()
+	rc.removeExpired(now)
+
+	if e, ok := rc.entries[key]; ok {
+		if !e.done {
+			return Result{}, false, ErrIdempotencyKeyInUse
+		}
+		return e.res, true, nil
+	}
+
+	rc.entries[key] = cacheEntry{expiresAt: now.Add(rc.ttl)}
+
+	return Result{}, false, nil
+}
+
+func (rc *resultCache) Save(key string, res Result) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	rc.entries[key] = cacheEntry{
+		res:       res,
+		done:      true,
+		expiresAt: time.Now().Add(rc.ttl),
+	}
+}
+
+func (rc *resultCache) Release(key string) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	delete(rc.entries, key)
+}
+
+func (rc *resultCache) removeExpired(now time.Time) {
+	for k, e := range rc.entries {
+		if now.After(e.expiresAt) {
+			delete(rc.entries, k)
+		}
+	}
+}
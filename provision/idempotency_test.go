@@ -0,0 +1,50 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package provision_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	SDK "github.com/MainfluxLabs/mainflux/pkg/sdk/go"
+	"github.com/MainfluxLabs/mainflux/provision"
+	"github.com/stretchr/testify/assert"
+)
+
+const idempotencyKey = "key"
+
+func TestResultCache(t *testing.T) {
+	cache := provision.NewResultCache(time.Hour)
+	res := provision.Result{Things: []SDK.Thing{{ID: "1", Name: "thing"}}}
+
+	_, ok, err := cache.Reserve(idempotencyKey)
+	assert.Nil(t, err, fmt.Sprintf("reserving new key: unexpected error %s", err))
+	assert.False(t, ok, "reserving new key: expected no cached result")
+
+	_, _, err = cache.Reserve(idempotencyKey)
+	assert.Equal(t, provision.ErrIdempotencyKeyInUse, err, fmt.Sprintf("reserving key in use: expected %s got %s", provision.ErrIdempotencyKeyInUse, err))
+
+	cache.Release(idempotencyKey)
+	_, ok, err = cache.Reserve(idempotencyKey)
+	assert.Nil(t, err, fmt.Sprintf("reserving released key: unexpected error %s", err))
+	assert.False(t, ok, "reserving released key: expected no cached result")
+
+	cache.Save(idempotencyKey, res)
+	cached, ok, err := cache.Reserve(idempotencyKey)
+	assert.Nil(t, err, fmt.Sprintf("reserving completed key: unexpected error %s", err))
+	assert.True(t, ok, "reserving completed key: expected cached result")
+	assert.Equal(t, res, cached, fmt.Sprintf("reserving completed key: expected %v got %v", res, cached))
+}
+
+func TestResultCacheExpiration(t *testing.T) {
+	cache := provision.NewResultCache(time.Millisecond)
+	cache.Save(idempotencyKey, provision.Result{})
+
+	time.Sleep(5 * time.Millisecond)
+
+	_, ok, err := cache.Reserve(idempotencyKey)
+	assert.Nil(t, err, fmt.Sprintf("reserving expired key: unexpected error %s", err))
+	assert.False(t, ok, "reserving expired key: expected no cached result")
+}
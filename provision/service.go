@@ -35,6 +35,7 @@ var (
 	ErrFailedBootstrap          = errors.New("failed to create bootstrap config")
 	ErrFailedBootstrapValidate  = errors.New("failed to validate bootstrap config creation")
 	ErrGatewayUpdate            = errors.New("failed to updated gateway metadata")
+	ErrIdempotencyKeyInUse      = errors.New("request with the same idempotency key is in progress")
 
 	limit  uint = 10
 	offset uint = 0
@@ -50,7 +51,13 @@ type Service interface {
 	// - create multiple Channels
 	// - create Bootstrap configuration
 	// - whitelist Thing in Bootstrap configuration == connect Thing to Channels
-	Provision(token, name, externalID, externalKey string) (Result, error)
+	// If the idempotency key is provided, the result of the successful call is
+	// stored and returned to the retried calls with the same key and token.
+	Provision(token, idempotencyKey, name, externalID, externalKey string) (Result, error)
+
+	// DryRun validates the provision configuration and returns Things and
+	// Channels that would be created by the Provision method without creating them.
+	DryRun(token, name, externalID, externalKey string) (Result, error)
 
 	// Mapping returns current configuration used for provision
 	// useful for using in ui to create configuration that matches
@@ -66,9 +73,10 @@ type Service interface {
 }
 
 type provisionService struct {
-	logger logger.Logger
-	sdk    SDK.SDK
-	conf   Config
+	logger  logger.Logger
+	sdk     SDK.SDK
+	results ResultCache
+	conf    Config
 }
 
 // Result represent what is created with additional info.
@@ -83,11 +91,12 @@ type Result struct {
 }
 
 // New returns new provision service.
-func New(cfg Config, sdk SDK.SDK, logger logger.Logger) Service {
+func New(cfg Config, sdk SDK.SDK, results ResultCache, logger logger.Logger) Service {
 	return &provisionService{
-		logger: logger,
-		conf:   cfg,
-		sdk:    sdk,
+		logger:  logger,
+		conf:    cfg,
+		sdk:     sdk,
+		results: results,
 	}
 }
 
@@ -108,7 +117,77 @@ func (ps *provisionService) Mapping(token string) (map[string]interface{}, error
 
 // Provision is provision method for creating setup according to
 // provision layout specified in config.toml
-func (ps *provisionService) Provision(token, name, externalID, externalKey string) (res Result, err error) {
+func (ps *provisionService) Provision(token, idempotencyKey, name, externalID, externalKey string) (Result, error) {
+	if idempotencyKey == "" {
+		return ps.provision(token, name, externalID, externalKey)
+	}
+
+	key := fmt.Sprintf("%s:%s", token, idempotencyKey)
+	res, ok, err := ps.results.Reserve(key)
+	if err != nil {
+		return Result{}, err
+	}
+	if ok {
+		return res, nil
+	}
+
+	res, err = ps.provision(token, name, externalID, externalKey)
+	if err != nil {
+		ps.results.Release(key)
+		return res, err
+	}
+	ps.results.Save(key, res)
+
+	return res, nil
+}
+
+// DryRun reports the setup that would be created according to
+// provision layout specified in config.toml
+func (ps *provisionService) DryRun(token, name, externalID, externalKey string) (Result, error) {
+	if _, err := ps.createTokenIfEmpty(token); err != nil {
+		return Result{}, err
+	}
+
+	if len(ps.conf.Things) == 0 {
+		return Result{}, ErrEmptyThingsList
+	}
+	if len(ps.conf.Channels) == 0 {
+		return Result{}, ErrEmptyChannelsList
+	}
+	if _, err := json.Marshal(ps.conf.Bootstrap.Content); err != nil {
+		return Result{}, errors.Wrap(ErrFailedBootstrap, err)
+	}
+
+	var res Result
+	for _, thing := range ps.conf.Things {
+		metadata := map[string]interface{}{}
+		for k, v := range thing.Metadata {
+			metadata[k] = v
+		}
+		if _, ok := metadata[externalIDKey]; ok {
+			metadata[externalIDKey] = externalID
+		}
+
+		if name == "" {
+			name = thing.Name
+		}
+		res.Things = append(res.Things, SDK.Thing{
+			Name:     name,
+			Metadata: metadata,
+		})
+	}
+
+	for _, channel := range ps.conf.Channels {
+		res.Channels = append(res.Channels, SDK.Channel{
+			Name:     channel.Name,
+			Metadata: channel.Metadata,
+		})
+	}
+
+	return res, nil
+}
+
+func (ps *provisionService) provision(token, name, externalID, externalKey string) (res Result, err error) {
 	var channels []SDK.Channel
 	var things []SDK.Thing
 	defer ps.recover(&err, &things, &channels, &token)
@@ -0,0 +1,102 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+
+	"github.com/MainfluxLabs/mainflux/pkg/encryption"
+	mfjson "github.com/MainfluxLabs/mainflux/pkg/transformers/json"
+	"github.com/MainfluxLabs/mainflux/pkg/transformers/senml"
+	"github.com/MainfluxLabs/mainflux/readers"
+)
+
+const (
+	channelField = "channel"
+	payloadField = "payload"
+)
+
+var _ readers.MessageRepository = (*decryptionMiddleware)(nil)
+
+type decryptionMiddleware struct {
+	keyring encryption.Keyring
+	repo    readers.MessageRepository
+}
+
+// DecryptionMiddleware decrypts JSON message payloads encrypted by the
+// writers with the per-channel data keys. Messages of the channels are
+// decrypted only after the read is authorized.
+func DecryptionMiddleware(repo readers.MessageRepository, keyring encryption.Keyring) readers.MessageRepository {
+	return &decryptionMiddleware{
+		keyring: keyring,
+		repo:    repo,
+	}
+}
+
+func (dm *decryptionMiddleware) ListChannelMessages(chanID string, pm readers.PageMetadata) (readers.MessagesPage, error) {
+	page, err := dm.repo.ListChannelMessages(chanID, pm)
+	if err != nil {
+		return readers.MessagesPage{}, err
+	}
+
+	return dm.decrypt(page, chanID)
+}
+
+func (dm *decryptionMiddleware) CountChannelMessages(chanID string, pm readers.PageMetadata) (uint64, error) {
+	return dm.repo.CountChannelMessages(chanID, pm)
+}
+
+func (dm *decryptionMiddleware) ListAllMessages(pm readers.PageMetadata) (readers.MessagesPage, error) {
+	page, err := dm.repo.ListAllMessages(pm)
+	if err != nil {
+		return readers.MessagesPage{}, err
+	}
+
+	return dm.decrypt(page, "")
+}
+
+func (dm *decryptionMiddleware) Restore(ctx context.Context, messages ...senml.Message) error {
+	return dm.repo.Restore(ctx, messages...)
+}
+
+// decrypt decrypts the payloads of the page messages. If the channel is
+// empty, the channel of each message is used. Payloads of the messages
+// without channel can't be decrypted and are returned as they are.
+func (dm *decryptionMiddleware) decrypt(page readers.MessagesPage, chanID string) (readers.MessagesPage, error) {
+	ctx := context.Background()
+	for i, msg := range page.Messages {
+		switch m := msg.(type) {
+		case map[string]interface{}:
+			payload, ok := m[payloadField].(map[string]interface{})
+			if !ok {
+				continue
+			}
+			ch := chanID
+			if ch == "" {
+				ch, _ = m[channelField].(string)
+			}
+			if ch == "" {
+				continue
+			}
+			dec, err := encryption.DecryptPayload(ctx, dm.keyring, ch, payload)
+			if err != nil {
+				return readers.MessagesPage{}, err
+			}
+			m[payloadField] = dec
+		case mfjson.Message:
+			ch := chanID
+			if ch == "" {
+				ch = m.Channel
+			}
+			dec, err := encryption.DecryptPayload(ctx, dm.keyring, ch, m.Payload)
+			if err != nil {
+				return readers.MessagesPage{}, err
+			}
+			m.Payload = dec
+			page.Messages[i] = m
+		}
+	}
+
+	return page, nil
+}
@@ -0,0 +1,72 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/MainfluxLabs/mainflux/pkg/encryption"
+	emocks "github.com/MainfluxLabs/mainflux/pkg/encryption/mocks"
+	"github.com/MainfluxLabs/mainflux/pkg/transformers/senml"
+	"github.com/MainfluxLabs/mainflux/readers"
+	"github.com/MainfluxLabs/mainflux/readers/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type jsonRepository struct {
+	messages []readers.Message
+}
+
+func (repo jsonRepository) ListChannelMessages(chanID string, pm readers.PageMetadata) (readers.MessagesPage, error) {
+	return readers.MessagesPage{Total: uint64(len(repo.messages)), Messages: repo.messages}, nil
+}
+
+func (repo jsonRepository) CountChannelMessages(chanID string, pm readers.PageMetadata) (uint64, error) {
+	return uint64(len(repo.messages)), nil
+}
+
+func (repo jsonRepository) ListAllMessages(pm readers.PageMetadata) (readers.MessagesPage, error) {
+	return readers.MessagesPage{Total: uint64(len(repo.messages)), Messages: repo.messages}, nil
+}
+
+func (repo jsonRepository) Restore(ctx context.Context, messages ...senml.Message) error {
+	return nil
+}
+
+func TestDecryptionMiddleware(t *testing.T) {
+	chanID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	kms, err := encryption.NewLocalKMS([]byte("01234567890123456789012345678901"))
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	kr := encryption.NewKeyring(kms, emocks.NewKeyRepository())
+
+	payload := map[string]interface{}{"temperature": 21.5}
+	enc, err := encryption.EncryptPayload(context.Background(), kr, chanID, payload)
+	require.Nil(t, err, fmt.Sprintf("encrypt payload got unexpected error: %s", err))
+
+	newPage := func() []readers.Message {
+		return []readers.Message{
+			map[string]interface{}{"channel": chanID, "payload": enc},
+			map[string]interface{}{"channel": chanID, "payload": payload},
+		}
+	}
+	expected := []readers.Message{
+		map[string]interface{}{"channel": chanID, "payload": payload},
+		map[string]interface{}{"channel": chanID, "payload": payload},
+	}
+
+	repo := api.DecryptionMiddleware(jsonRepository{messages: newPage()}, kr)
+	page, err := repo.ListChannelMessages(chanID, readers.PageMetadata{})
+	assert.Nil(t, err, fmt.Sprintf("list channel messages: unexpected error: %s", err))
+	assert.Equal(t, expected, page.Messages, fmt.Sprintf("list channel messages: expected %v got %v", expected, page.Messages))
+
+	repo = api.DecryptionMiddleware(jsonRepository{messages: newPage()}, kr)
+	page, err = repo.ListAllMessages(readers.PageMetadata{})
+	assert.Nil(t, err, fmt.Sprintf("list all messages: unexpected error: %s", err))
+	assert.Equal(t, expected, page.Messages, fmt.Sprintf("list all messages: expected %v got %v", expected, page.Messages))
+}
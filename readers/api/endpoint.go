@@ -7,8 +7,10 @@ import (
 	"context"
 
 	auth "github.com/MainfluxLabs/mainflux/auth"
+	"github.com/MainfluxLabs/mainflux/pkg/encryption"
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
 	"github.com/MainfluxLabs/mainflux/readers"
+	"github.com/MainfluxLabs/mainflux/readers/exports"
 	"github.com/go-kit/kit/endpoint"
 )
 
@@ -23,16 +25,27 @@ func ListChannelMessagesEndpoint(svc readers.MessageRepository) endpoint.Endpoin
 			return nil, errors.Wrap(errors.ErrAuthorization, err)
 		}
 
+		if req.export != "" {
+			return exportMessages(req.export, req.pageMeta, func(pm readers.PageMetadata) (readers.MessagesPage, error) {
+				return svc.ListChannelMessages(req.chanID, pm)
+			})
+		}
+
 		page, err := svc.ListChannelMessages(req.chanID, req.pageMeta)
 		if err != nil {
 			return nil, err
 		}
 
-		return listMessagesRes{
+		res := listMessagesRes{
 			PageMetadata: page.PageMetadata,
 			Total:        page.Total,
 			Messages:     page.Messages,
-		}, nil
+		}
+		if req.pageMeta.Publisher != "" {
+			res.Gaps = readers.Gaps(page.Messages)
+		}
+
+		return res, nil
 	}
 }
 
@@ -48,6 +61,10 @@ func listAllMessagesEndpoint(svc readers.MessageRepository) endpoint.Endpoint {
 			return nil, err
 		}
 
+		if req.export != "" {
+			return exportMessages(req.export, req.pageMeta, svc.ListAllMessages)
+		}
+
 		page, err := svc.ListAllMessages(req.pageMeta)
 		if err != nil {
 			return nil, err
@@ -80,3 +97,128 @@ func restoreEndpoint(svc readers.MessageRepository) endpoint.Endpoint {
 		return restoreMessagesRes{}, nil
 	}
 }
+
+func listLastMessagesEndpoint(lvc readers.LastValueCache) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listLastMessagesReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		if err := authorize(ctx, req.token, req.key, req.chanID); err != nil {
+			return nil, errors.Wrap(errors.ErrAuthorization, err)
+		}
+
+		msgs, err := lvc.RetrieveLast(ctx, req.chanID, req.pageMeta)
+		if err != nil {
+			return nil, err
+		}
+
+		return lastMessagesRes{Messages: msgs}, nil
+	}
+}
+
+func countChannelMessagesEndpoint(svc readers.MessageRepository) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(countMessagesReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		if err := authorize(ctx, req.token, req.key, req.chanID); err != nil {
+			return nil, errors.Wrap(errors.ErrAuthorization, err)
+		}
+
+		count, err := svc.CountChannelMessages(req.chanID, req.pageMeta)
+		if err != nil {
+			return nil, err
+		}
+
+		return countMessagesRes{Count: count}, nil
+	}
+}
+
+func rotateKeyEndpoint(kr encryption.Keyring) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(rotateKeyReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		if err := authorizeAdmin(ctx, auth.RootSubject, req.token); err != nil {
+			return nil, err
+		}
+
+		version, err := kr.Rotate(ctx, req.chanID)
+		if err != nil {
+			return nil, err
+		}
+
+		return rotateKeyRes{
+			Channel: req.chanID,
+			Version: version,
+		}, nil
+	}
+}
+
+func createExportJobEndpoint(svc exports.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(createExportJobReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		if err := authorize(ctx, req.token, req.key, req.chanID); err != nil {
+			return nil, errors.Wrap(errors.ErrAuthorization, err)
+		}
+
+		job, err := svc.CreateJob(ctx, req.chanID, req.pageMeta)
+		if err != nil {
+			return nil, err
+		}
+
+		res := buildExportJobRes(job)
+		res.created = true
+
+		return res, nil
+	}
+}
+
+func viewExportJobEndpoint(svc exports.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(viewExportJobReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		if err := authorize(ctx, req.token, req.key, req.chanID); err != nil {
+			return nil, errors.Wrap(errors.ErrAuthorization, err)
+		}
+
+		job, err := svc.ViewJob(ctx, req.chanID, req.id)
+		if err != nil {
+			return nil, err
+		}
+
+		return buildExportJobRes(job), nil
+	}
+}
+
+func buildExportJobRes(job exports.Job) exportJobRes {
+	return exportJobRes{
+		ID:        job.ID,
+		ChannelID: job.ChannelID,
+		Status:    job.Status,
+		Format:    job.Format,
+		Subtopic:  job.Subtopic,
+		Publisher: job.Publisher,
+		From:      job.From,
+		To:        job.To,
+		Total:     job.Total,
+		Exported:  job.Exported,
+		Error:     job.Error,
+		URL:       job.URL,
+		CreatedAt: job.CreatedAt,
+		UpdatedAt: job.UpdatedAt,
+	}
+}
@@ -4,9 +4,11 @@
 package api_test
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -15,12 +17,17 @@ import (
 	"github.com/MainfluxLabs/mainflux"
 	"github.com/MainfluxLabs/mainflux/internal/apiutil"
 	"github.com/MainfluxLabs/mainflux/logger"
+	amocks "github.com/MainfluxLabs/mainflux/pkg/archive/mocks"
+	"github.com/MainfluxLabs/mainflux/pkg/columnar"
+	"github.com/MainfluxLabs/mainflux/pkg/encryption"
+	emocks "github.com/MainfluxLabs/mainflux/pkg/encryption/mocks"
 	"github.com/MainfluxLabs/mainflux/pkg/mocks"
 	thmocks "github.com/MainfluxLabs/mainflux/pkg/mocks"
 	"github.com/MainfluxLabs/mainflux/pkg/transformers/senml"
 	"github.com/MainfluxLabs/mainflux/pkg/uuid"
 	"github.com/MainfluxLabs/mainflux/readers"
 	"github.com/MainfluxLabs/mainflux/readers/api"
+	"github.com/MainfluxLabs/mainflux/readers/exports"
 	rmocks "github.com/MainfluxLabs/mainflux/readers/mocks"
 	"github.com/MainfluxLabs/mainflux/users"
 	"github.com/stretchr/testify/assert"
@@ -57,9 +64,9 @@ var (
 	usersList = []users.User{user, admin}
 )
 
-func newServer(repo readers.MessageRepository, tc mainflux.ThingsServiceClient, ac mainflux.AuthServiceClient) *httptest.Server {
+func newServer(repo readers.MessageRepository, kr encryption.Keyring, lvc readers.LastValueCache, es exports.Service, tc mainflux.ThingsServiceClient, ac mainflux.AuthServiceClient) *httptest.Server {
 	logger := logger.NewMock()
-	mux := api.MakeHandler(repo, tc, ac, svcName, logger)
+	mux := api.MakeHandler(repo, kr, lvc, es, tc, ac, svcName, logger)
 
 	id, _ := idProvider.ID()
 	user.ID = id
@@ -73,6 +80,7 @@ type testRequest struct {
 	url    string
 	token  string
 	key    string
+	accept string
 }
 
 func (tr testRequest) make() (*http.Response, error) {
@@ -86,6 +94,9 @@ func (tr testRequest) make() (*http.Response, error) {
 	if tr.key != "" {
 		req.Header.Set("Authorization", apiutil.ThingPrefix+tr.key)
 	}
+	if tr.accept != "" {
+		req.Header.Set("Accept", tr.accept)
+	}
 
 	return tr.client.Do(req)
 }
@@ -111,6 +122,7 @@ func TestListChannelMessages(t *testing.T) {
 	var boolMsgs []senml.Message
 	var stringMsgs []senml.Message
 	var dataMsgs []senml.Message
+	var projectedMsgs []senml.Message
 
 	for i := 0; i < numOfMessages; i++ {
 		// Mix possible values as well as value sum.
@@ -133,6 +145,7 @@ func TestListChannelMessages(t *testing.T) {
 		case 2:
 			msg.StringValue = &vs
 			stringMsgs = append(stringMsgs, msg)
+			projectedMsgs = append(projectedMsgs, senml.Message{Time: msg.Time, StringValue: &vs})
 		case 3:
 			msg.DataValue = &vd
 			dataMsgs = append(dataMsgs, msg)
@@ -160,7 +173,7 @@ func TestListChannelMessages(t *testing.T) {
 	adminToken := adminTok.GetValue()
 
 	repo := rmocks.NewMessageRepository(chanID, fromSenml(messages))
-	ts := newServer(repo, thSvc, authSvc)
+	ts := newServer(repo, nil, nil, nil, thSvc, authSvc)
 	defer ts.Close()
 
 	cases := []struct {
@@ -450,6 +463,120 @@ func TestListChannelMessages(t *testing.T) {
 				Messages: dataMsgs[0:10],
 			},
 		},
+		{
+			desc:   "read page with value range as thing",
+			url:    fmt.Sprintf("%s/channels/%s/messages?value_gt=%f&value_lt=%f", ts.URL, chanID, v-1, v+1),
+			key:    thingToken,
+			status: http.StatusOK,
+			res: pageRes{
+				Total:    uint64(len(valueMsgs)),
+				Messages: valueMsgs[0:10],
+			},
+		},
+		{
+			desc:   "read page with value out of range as thing",
+			url:    fmt.Sprintf("%s/channels/%s/messages?value_gt=%f", ts.URL, chanID, v),
+			key:    thingToken,
+			status: http.StatusOK,
+			res: pageRes{
+				Total:    0,
+				Messages: []senml.Message{},
+			},
+		},
+		{
+			desc:   "read page with invalid value range as thing",
+			url:    fmt.Sprintf("%s/channels/%s/messages?value_gt=%f&value_lt=%f", ts.URL, chanID, v+1, v-1),
+			key:    thingToken,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "read page with non-float value_gt as thing",
+			url:    fmt.Sprintf("%s/channels/%s/messages?value_gt=ab01", ts.URL, chanID),
+			key:    thingToken,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "read page with invalid aggregation as thing",
+			url:    fmt.Sprintf("%s/channels/%s/messages?aggregation=median&interval=1h", ts.URL, chanID),
+			key:    thingToken,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "read page with interval without aggregation as thing",
+			url:    fmt.Sprintf("%s/channels/%s/messages?interval=1h", ts.URL, chanID),
+			key:    thingToken,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "read page with aggregation and invalid interval as thing",
+			url:    fmt.Sprintf("%s/channels/%s/messages?aggregation=avg&interval=100ms", ts.URL, chanID),
+			key:    thingToken,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "read page with aggregation and invalid time zone as thing",
+			url:    fmt.Sprintf("%s/channels/%s/messages?aggregation=avg&interval=P1D&tz=Mars/Olympus", ts.URL, chanID),
+			key:    thingToken,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "read page with aggregation and invalid ISO 8601 interval as thing",
+			url:    fmt.Sprintf("%s/channels/%s/messages?aggregation=avg&interval=P1M&tz=Europe/Belgrade", ts.URL, chanID),
+			key:    thingToken,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "read page with aggregation and fields as thing",
+			url:    fmt.Sprintf("%s/channels/%s/messages?aggregation=avg&interval=1h&fields=name", ts.URL, chanID),
+			key:    thingToken,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "read page with bool_value as thing",
+			url:    fmt.Sprintf("%s/channels/%s/messages?bool_value=true", ts.URL, chanID),
+			key:    thingToken,
+			status: http.StatusOK,
+			res: pageRes{
+				Total:    uint64(len(boolMsgs)),
+				Messages: boolMsgs[0:10],
+			},
+		},
+		{
+			desc:   "read page with string value pattern as thing",
+			url:    fmt.Sprintf("%s/channels/%s/messages?string_value_like=%s", ts.URL, chanID, vs[1:3]),
+			key:    thingToken,
+			status: http.StatusOK,
+			res: pageRes{
+				Total:    uint64(len(stringMsgs)),
+				Messages: stringMsgs[0:10],
+			},
+		},
+		{
+			desc:   "read page with data_value as thing",
+			url:    fmt.Sprintf("%s/channels/%s/messages?data_value=%s", ts.URL, chanID, vd),
+			key:    thingToken,
+			status: http.StatusOK,
+			res: pageRes{
+				Total:    uint64(len(dataMsgs)),
+				Messages: dataMsgs[0:10],
+			},
+		},
+		{
+			desc:   "read page with selected fields as thing",
+			url:    fmt.Sprintf("%s/channels/%s/messages?vs=%s&fields=time,string_value", ts.URL, chanID, vs),
+			key:    thingToken,
+			status: http.StatusOK,
+			res: pageRes{
+				Total:    uint64(len(stringMsgs)),
+				Messages: projectedMsgs[0:10],
+			},
+		},
+		{
+			desc:   "read page with invalid fields as thing",
+			url:    fmt.Sprintf("%s/channels/%s/messages?fields=time,payload", ts.URL, chanID),
+			key:    thingToken,
+			status: http.StatusBadRequest,
+		},
 		{
 			desc:   "read page with non-float from as thing",
 			url:    fmt.Sprintf("%s/channels/%s/messages?from=ABCD", ts.URL, chanID),
@@ -473,6 +600,16 @@ func TestListChannelMessages(t *testing.T) {
 				Messages: messages[5:15],
 			},
 		},
+		{
+			desc:   "read page with RFC3339 from/to as thing",
+			url:    fmt.Sprintf("%s/channels/%s/messages?from=%s&to=%s", ts.URL, chanID, rfc3339(messages[19].Time), rfc3339(messages[4].Time)),
+			key:    thingToken,
+			status: http.StatusOK,
+			res: pageRes{
+				Total:    uint64(len(messages[5:20])),
+				Messages: messages[5:15],
+			},
+		},
 		{
 			desc:   "read page with valid offset and limit as user",
 			url:    fmt.Sprintf("%s/channels/%s/messages?offset=0&limit=10", ts.URL, chanID),
@@ -855,7 +992,7 @@ func TestListAllMessages(t *testing.T) {
 	adminToken := adminTok.GetValue()
 
 	repo := rmocks.NewMessageRepository("", fromSenml(messages))
-	ts := newServer(repo, thSvc, authSvc)
+	ts := newServer(repo, nil, nil, nil, thSvc, authSvc)
 	defer ts.Close()
 
 	cases := []struct {
@@ -1198,6 +1335,546 @@ func TestListAllMessages(t *testing.T) {
 	}
 }
 
+func TestRotateKey(t *testing.T) {
+	chanID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	kms, err := encryption.NewLocalKMS([]byte("01234567890123456789012345678901"))
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	kr := encryption.NewKeyring(kms, emocks.NewKeyRepository())
+
+	thSvc := thmocks.NewThingsServiceClient(map[string]string{userEmail: ""}, nil)
+	authSvc := newAuthService()
+
+	tok, err := authSvc.Issue(context.Background(), &mainflux.IssueReq{Id: user.ID, Email: user.Email, Type: 0})
+	require.Nil(t, err, fmt.Sprintf("issue token for user got unexpected error: %s", err))
+	adminTok, err := authSvc.Issue(context.Background(), &mainflux.IssueReq{Id: admin.ID, Email: admin.Email})
+	require.Nil(t, err, fmt.Sprintf("issue token for admin got unexpected error: %s", err))
+
+	repo := rmocks.NewMessageRepository("", nil)
+	ts := newServer(repo, kr, nil, nil, thSvc, authSvc)
+	defer ts.Close()
+
+	cases := []struct {
+		desc    string
+		url     string
+		token   string
+		status  int
+		version uint64
+	}{
+		{
+			desc:    "rotate channel key as admin",
+			url:     fmt.Sprintf("%s/channels/%s/keys/rotate", ts.URL, chanID),
+			token:   adminTok.GetValue(),
+			status:  http.StatusOK,
+			version: 1,
+		},
+		{
+			desc:    "rotate rotated channel key as admin",
+			url:     fmt.Sprintf("%s/channels/%s/keys/rotate", ts.URL, chanID),
+			token:   adminTok.GetValue(),
+			status:  http.StatusOK,
+			version: 2,
+		},
+		{
+			desc:   "rotate channel key as user",
+			url:    fmt.Sprintf("%s/channels/%s/keys/rotate", ts.URL, chanID),
+			token:  tok.GetValue(),
+			status: http.StatusForbidden,
+		},
+		{
+			desc:   "rotate channel key with invalid token",
+			url:    fmt.Sprintf("%s/channels/%s/keys/rotate", ts.URL, chanID),
+			token:  invalid,
+			status: http.StatusUnauthorized,
+		},
+		{
+			desc:   "rotate channel key with empty token",
+			url:    fmt.Sprintf("%s/channels/%s/keys/rotate", ts.URL, chanID),
+			token:  "",
+			status: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodPost,
+			url:    tc.url,
+			token:  tc.token,
+		}
+
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+
+		var body struct {
+			Version uint64 `json:"version"`
+		}
+		json.NewDecoder(res.Body).Decode(&body)
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected %d got %d", tc.desc, tc.status, res.StatusCode))
+		assert.Equal(t, tc.version, body.Version, fmt.Sprintf("%s: expected version %d got %d", tc.desc, tc.version, body.Version))
+	}
+}
+
+func TestListLastMessages(t *testing.T) {
+	chanID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	pubID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	pubID2, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	now := float64(time.Now().Unix())
+	messages := []senml.Message{
+		{Channel: chanID, Publisher: pubID, Protocol: mqttProt, Name: msgName, Time: now, Value: &v},
+		{Channel: chanID, Publisher: pubID, Protocol: mqttProt, Name: "humidity", Time: now, Value: &sum},
+		{Channel: chanID, Publisher: pubID2, Protocol: httpProt, Subtopic: subtopic, Name: msgName, Time: now, Value: &v},
+	}
+
+	repo := rmocks.NewMessageRepository("", nil)
+	lvc := rmocks.NewLastValueCache(chanID, messages)
+	// Auth mock identifies users by the list it was created with.
+	thSvc := thmocks.NewThingsServiceClient(map[string]string{usersList[0].ID: chanID}, nil)
+	authSvc := newAuthService()
+	ts := newServer(repo, nil, lvc, nil, thSvc, authSvc)
+	defer ts.Close()
+
+	tok, err := authSvc.Issue(context.Background(), &mainflux.IssueReq{Id: user.ID, Email: user.Email, Type: 0})
+	require.Nil(t, err, fmt.Sprintf("issue token for user got unexpected error: %s", err))
+
+	cases := []struct {
+		desc     string
+		url      string
+		token    string
+		key      string
+		status   int
+		messages []senml.Message
+	}{
+		{
+			desc:     "read last messages as user",
+			url:      fmt.Sprintf("%s/channels/%s/messages/last", ts.URL, chanID),
+			token:    tok.GetValue(),
+			status:   http.StatusOK,
+			messages: messages,
+		},
+		{
+			desc:     "read last messages with thing key",
+			url:      fmt.Sprintf("%s/channels/%s/messages/last", ts.URL, chanID),
+			key:      thingToken,
+			status:   http.StatusOK,
+			messages: messages,
+		},
+		{
+			desc:     "read last messages of publisher",
+			url:      fmt.Sprintf("%s/channels/%s/messages/last?publisher=%s", ts.URL, chanID, pubID),
+			token:    tok.GetValue(),
+			status:   http.StatusOK,
+			messages: messages[0:2],
+		},
+		{
+			desc:     "read last messages by name and subtopic",
+			url:      fmt.Sprintf("%s/channels/%s/messages/last?name=%s&subtopic=%s", ts.URL, chanID, msgName, subtopic),
+			token:    tok.GetValue(),
+			status:   http.StatusOK,
+			messages: messages[2:3],
+		},
+		{
+			desc:     "read last messages of channel without messages",
+			url:      fmt.Sprintf("%s/channels/%s/messages/last?name=%s", ts.URL, chanID, invalid),
+			token:    tok.GetValue(),
+			status:   http.StatusOK,
+			messages: []senml.Message{},
+		},
+		{
+			desc:   "read last messages with invalid token",
+			url:    fmt.Sprintf("%s/channels/%s/messages/last", ts.URL, chanID),
+			token:  invalid,
+			status: http.StatusUnauthorized,
+		},
+		{
+			desc:   "read last messages with empty token",
+			url:    fmt.Sprintf("%s/channels/%s/messages/last", ts.URL, chanID),
+			status: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodGet,
+			url:    tc.url,
+			token:  tc.token,
+			key:    tc.key,
+		}
+
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+
+		var body struct {
+			Messages []senml.Message `json:"messages"`
+		}
+		json.NewDecoder(res.Body).Decode(&body)
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected %d got %d", tc.desc, tc.status, res.StatusCode))
+		assert.ElementsMatch(t, tc.messages, body.Messages, fmt.Sprintf("%s: expected %v got %v", tc.desc, tc.messages, body.Messages))
+	}
+}
+
+func TestCountChannelMessages(t *testing.T) {
+	chanID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	pubID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	pubID2, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	now := time.Now().Unix()
+	var messages []senml.Message
+	for i := 0; i < numOfMessages; i++ {
+		msg := senml.Message{
+			Channel:   chanID,
+			Publisher: pubID,
+			Protocol:  mqttProt,
+			Name:      msgName,
+			Time:      float64(now - int64(i)),
+			Value:     &v,
+		}
+		if i%2 == 0 {
+			msg.Publisher = pubID2
+		}
+		messages = append(messages, msg)
+	}
+
+	repo := rmocks.NewMessageRepository(chanID, fromSenml(messages))
+	thSvc := thmocks.NewThingsServiceClient(map[string]string{usersList[0].ID: chanID}, nil)
+	authSvc := newAuthService()
+	ts := newServer(repo, nil, nil, nil, thSvc, authSvc)
+	defer ts.Close()
+
+	tok, err := authSvc.Issue(context.Background(), &mainflux.IssueReq{Id: user.ID, Email: user.Email, Type: 0})
+	require.Nil(t, err, fmt.Sprintf("issue token for user got unexpected error: %s", err))
+
+	cases := []struct {
+		desc   string
+		url    string
+		token  string
+		key    string
+		status int
+		count  uint64
+	}{
+		{
+			desc:   "count messages as user",
+			url:    fmt.Sprintf("%s/channels/%s/messages/count", ts.URL, chanID),
+			token:  tok.GetValue(),
+			status: http.StatusOK,
+			count:  numOfMessages,
+		},
+		{
+			desc:   "count messages with thing key",
+			url:    fmt.Sprintf("%s/channels/%s/messages/count", ts.URL, chanID),
+			key:    thingToken,
+			status: http.StatusOK,
+			count:  numOfMessages,
+		},
+		{
+			desc:   "count messages of publisher",
+			url:    fmt.Sprintf("%s/channels/%s/messages/count?publisher=%s", ts.URL, chanID, pubID),
+			token:  tok.GetValue(),
+			status: http.StatusOK,
+			count:  numOfMessages / 2,
+		},
+		{
+			desc:   "count messages in time range",
+			url:    fmt.Sprintf("%s/channels/%s/messages/count?from=%d&to=%d", ts.URL, chanID, now-9, now+1),
+			token:  tok.GetValue(),
+			status: http.StatusOK,
+			count:  10,
+		},
+		{
+			desc:   "count messages with from after to",
+			url:    fmt.Sprintf("%s/channels/%s/messages/count?from=%d&to=%d", ts.URL, chanID, now, now-10),
+			token:  tok.GetValue(),
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "count messages with invalid from",
+			url:    fmt.Sprintf("%s/channels/%s/messages/count?from=%s", ts.URL, chanID, invalid),
+			token:  tok.GetValue(),
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "count messages with invalid token",
+			url:    fmt.Sprintf("%s/channels/%s/messages/count", ts.URL, chanID),
+			token:  invalid,
+			status: http.StatusUnauthorized,
+		},
+		{
+			desc:   "count messages with empty token",
+			url:    fmt.Sprintf("%s/channels/%s/messages/count", ts.URL, chanID),
+			status: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodGet,
+			url:    tc.url,
+			token:  tc.token,
+			key:    tc.key,
+		}
+
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+
+		var body struct {
+			Count uint64 `json:"count"`
+		}
+		json.NewDecoder(res.Body).Decode(&body)
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected %d got %d", tc.desc, tc.status, res.StatusCode))
+		assert.Equal(t, tc.count, body.Count, fmt.Sprintf("%s: expected count %d got %d", tc.desc, tc.count, body.Count))
+	}
+}
+
+func TestExportJob(t *testing.T) {
+	chanID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	now := time.Now().Unix()
+	var messages []senml.Message
+	for i := 0; i < numOfMessages; i++ {
+		messages = append(messages, senml.Message{
+			Channel:   chanID,
+			Publisher: chanID,
+			Protocol:  mqttProt,
+			Name:      msgName,
+			Time:      float64(now - int64(i)),
+			Value:     &v,
+		})
+	}
+
+	repo := rmocks.NewMessageRepository(chanID, fromSenml(messages))
+	es := exports.New(repo, amocks.NewMultipartStorage(), uuid.NewMock(), exports.Config{Prefix: "exports/", URLTTL: time.Hour}, logger.NewMock())
+	thSvc := thmocks.NewThingsServiceClient(map[string]string{usersList[0].ID: chanID}, nil)
+	authSvc := newAuthService()
+	ts := newServer(repo, nil, nil, es, thSvc, authSvc)
+	defer ts.Close()
+
+	tok, err := authSvc.Issue(context.Background(), &mainflux.IssueReq{Id: user.ID, Email: user.Email, Type: 0})
+	require.Nil(t, err, fmt.Sprintf("issue token for user got unexpected error: %s", err))
+
+	createCases := []struct {
+		desc   string
+		url    string
+		token  string
+		key    string
+		status int
+	}{
+		{
+			desc:   "create export job as user",
+			url:    fmt.Sprintf("%s/channels/%s/messages/export", ts.URL, chanID),
+			token:  tok.GetValue(),
+			status: http.StatusAccepted,
+		},
+		{
+			desc:   "create export job with thing key",
+			url:    fmt.Sprintf("%s/channels/%s/messages/export?from=%d", ts.URL, chanID, now-9),
+			key:    thingToken,
+			status: http.StatusAccepted,
+		},
+		{
+			desc:   "create export job with from after to",
+			url:    fmt.Sprintf("%s/channels/%s/messages/export?from=%d&to=%d", ts.URL, chanID, now, now-10),
+			token:  tok.GetValue(),
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "create export job with invalid token",
+			url:    fmt.Sprintf("%s/channels/%s/messages/export", ts.URL, chanID),
+			token:  invalid,
+			status: http.StatusUnauthorized,
+		},
+		{
+			desc:   "create export job with empty token",
+			url:    fmt.Sprintf("%s/channels/%s/messages/export", ts.URL, chanID),
+			status: http.StatusUnauthorized,
+		},
+	}
+
+	var jobIDs []string
+	for _, tc := range createCases {
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodPost,
+			url:    tc.url,
+			token:  tc.token,
+			key:    tc.key,
+		}
+		res, err := req.make()
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+
+		var body exportJobRes
+		json.NewDecoder(res.Body).Decode(&body)
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected %d got %d", tc.desc, tc.status, res.StatusCode))
+		if tc.status == http.StatusAccepted {
+			assert.Equal(t, exports.StatusPending, body.Status, fmt.Sprintf("%s: expected status %s got %s", tc.desc, exports.StatusPending, body.Status))
+			jobIDs = append(jobIDs, body.ID)
+		}
+	}
+	require.Len(t, jobIDs, 2, "expected two export jobs to be created")
+
+	viewJob := func(id, token string) (*http.Response, exportJobRes) {
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodGet,
+			url:    fmt.Sprintf("%s/channels/%s/messages/export/%s", ts.URL, chanID, id),
+			token:  token,
+		}
+		res, err := req.make()
+		require.Nil(t, err, fmt.Sprintf("view export job: unexpected error %s", err))
+
+		var body exportJobRes
+		json.NewDecoder(res.Body).Decode(&body)
+		return res, body
+	}
+
+	exported := []uint64{numOfMessages, 10}
+	for i, id := range jobIDs {
+		var body exportJobRes
+		for deadline := time.Now().Add(5 * time.Second); time.Now().Before(deadline); time.Sleep(10 * time.Millisecond) {
+			_, body = viewJob(id, tok.GetValue())
+			if body.Status == exports.StatusCompleted {
+				break
+			}
+		}
+		assert.Equal(t, exports.StatusCompleted, body.Status, fmt.Sprintf("view export job: expected status %s got %s", exports.StatusCompleted, body.Status))
+		assert.Equal(t, exported[i], body.Exported, fmt.Sprintf("view export job: expected %d exported messages got %d", exported[i], body.Exported))
+		assert.NotEmpty(t, body.URL, "view export job: expected download URL")
+	}
+
+	res, _ := viewJob("non-existent", tok.GetValue())
+	assert.Equal(t, http.StatusNotFound, res.StatusCode, fmt.Sprintf("view non-existent export job: expected %d got %d", http.StatusNotFound, res.StatusCode))
+
+	res, _ = viewJob(jobIDs[0], invalid)
+	assert.Equal(t, http.StatusUnauthorized, res.StatusCode, fmt.Sprintf("view export job with invalid token: expected %d got %d", http.StatusUnauthorized, res.StatusCode))
+}
+
+// pagingRepository counts the listed pages of the channel messages.
+type pagingRepository struct {
+	readers.MessageRepository
+	pages int
+}
+
+func (repo *pagingRepository) ListChannelMessages(chanID string, pm readers.PageMetadata) (readers.MessagesPage, error) {
+	repo.pages++
+	return repo.MessageRepository.ListChannelMessages(chanID, pm)
+}
+
+func TestExportChannelMessages(t *testing.T) {
+	chanID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	pubID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	numOfExported := 2500
+	now := time.Now().Unix()
+	var messages []senml.Message
+	for i := 0; i < numOfExported; i++ {
+		msg := senml.Message{
+			Channel:   chanID,
+			Publisher: pubID,
+			Protocol:  mqttProt,
+			Name:      msgName,
+			Time:      float64(now - int64(i)),
+			Value:     &v,
+			Seq:       uint64(i + 1),
+		}
+		messages = append(messages, msg)
+	}
+
+	repo := &pagingRepository{MessageRepository: rmocks.NewMessageRepository(chanID, fromSenml(messages))}
+	thSvc := thmocks.NewThingsServiceClient(map[string]string{user.ID: chanID}, nil)
+	ts := newServer(repo, nil, nil, nil, thSvc, newAuthService())
+	defer ts.Close()
+
+	cases := []struct {
+		desc        string
+		url         string
+		accept      string
+		status      int
+		contentType string
+		pages       int
+		prefix      []byte
+		suffix      []byte
+	}{
+		{
+			desc:        "export messages as parquet",
+			url:         fmt.Sprintf("%s/channels/%s/messages", ts.URL, chanID),
+			accept:      columnar.ParquetContentType,
+			status:      http.StatusOK,
+			contentType: columnar.ParquetContentType,
+			pages:       3,
+			prefix:      []byte("PAR1"),
+			suffix:      []byte("PAR1"),
+		},
+		{
+			desc:        "export messages as arrow stream",
+			url:         fmt.Sprintf("%s/channels/%s/messages", ts.URL, chanID),
+			accept:      columnar.ArrowContentType,
+			status:      http.StatusOK,
+			contentType: columnar.ArrowContentType,
+			pages:       3,
+			prefix:      []byte{0xFF, 0xFF, 0xFF, 0xFF},
+			suffix:      []byte{0xFF, 0xFF, 0xFF, 0xFF, 0, 0, 0, 0},
+		},
+		{
+			desc:        "export limited messages with selected fields",
+			url:         fmt.Sprintf("%s/channels/%s/messages?offset=500&limit=1200&fields=time,value,seq", ts.URL, chanID),
+			accept:      columnar.ParquetContentType,
+			status:      http.StatusOK,
+			contentType: columnar.ParquetContentType,
+			pages:       2,
+			prefix:      []byte("PAR1"),
+			suffix:      []byte("PAR1"),
+		},
+		{
+			desc:   "export messages with limit greater than max export size",
+			url:    fmt.Sprintf("%s/channels/%s/messages?limit=%d", ts.URL, chanID, 100001),
+			accept: columnar.ArrowContentType,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "list messages as JSON with limit greater than max limit size",
+			url:    fmt.Sprintf("%s/channels/%s/messages?limit=%d", ts.URL, chanID, 2000),
+			status: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range cases {
+		repo.pages = 0
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodGet,
+			url:    tc.url,
+			key:    thingToken,
+			accept: tc.accept,
+		}
+
+		res, err := req.make()
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		body, err := io.ReadAll(res.Body)
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected %d got %d", tc.desc, tc.status, res.StatusCode))
+		if tc.status != http.StatusOK {
+			continue
+		}
+		assert.Equal(t, tc.contentType, res.Header.Get("Content-Type"), fmt.Sprintf("%s: unexpected content type", tc.desc))
+		assert.Equal(t, tc.pages, repo.pages, fmt.Sprintf("%s: expected %d pages got %d", tc.desc, tc.pages, repo.pages))
+		assert.True(t, bytes.HasPrefix(body, tc.prefix), fmt.Sprintf("%s: unexpected stream start", tc.desc))
+		assert.True(t, bytes.HasSuffix(body, tc.suffix), fmt.Sprintf("%s: unexpected stream end", tc.desc))
+	}
+}
+
 type pageRes struct {
 	readers.PageMetadata
 	Total    uint64          `json:"total"`
@@ -1211,3 +1888,14 @@ func fromSenml(in []senml.Message) []readers.Message {
 	}
 	return ret
 }
+
+func rfc3339(t float64) string {
+	return time.Unix(int64(t), 0).UTC().Format(time.RFC3339)
+}
+
+type exportJobRes struct {
+	ID       string `json:"id"`
+	Status   string `json:"status"`
+	Exported uint64 `json:"exported"`
+	URL      string `json:"url"`
+}
@@ -0,0 +1,216 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/MainfluxLabs/mainflux/pkg/columnar"
+	"github.com/MainfluxLabs/mainflux/pkg/transformers/senml"
+	"github.com/MainfluxLabs/mainflux/readers"
+)
+
+// exportPageSize is the number of messages retrieved from the repository and
+// encoded as a single record batch or row group of the exported messages.
+const exportPageSize = 1000
+
+var (
+	// senmlTypes are the types of the non-string fields of SenML messages.
+	senmlTypes = map[string]columnar.Type{
+		"time":        columnar.Float64,
+		"update_time": columnar.Float64,
+		"value":       columnar.Float64,
+		"sum":         columnar.Float64,
+		"bool_value":  columnar.Bool,
+		"seq":         columnar.Int64,
+	}
+
+	// jsonTypes are the types of the non-string fields of JSON messages.
+	// The payload is exported as the JSON encoded string.
+	jsonTypes = map[string]columnar.Type{
+		"created": columnar.Int64,
+	}
+)
+
+// listMessagesFunc lists the messages of the page, used to retrieve the
+// exported messages page by page.
+type listMessagesFunc func(pm readers.PageMetadata) (readers.MessagesPage, error)
+
+// exportMessages retrieves the first page of the exported messages. The
+// remaining pages are retrieved while the response is encoded, so that the
+// messages are streamed to the client.
+func exportMessages(contentType string, pm readers.PageMetadata, list listMessagesFunc) (exportMessagesRes, error) {
+	first := pm
+	if first.Limit > exportPageSize {
+		first.Limit = exportPageSize
+	}
+
+	page, err := list(first)
+	if err != nil {
+		return exportMessagesRes{}, err
+	}
+
+	res := exportMessagesRes{
+		contentType: contentType,
+		columns:     exportColumns(pm),
+		pageMeta:    pm,
+		page:        page,
+		list:        list,
+	}
+
+	return res, nil
+}
+
+// exportColumns returns the columns of the exported messages, consisting of
+// the selected fields, or all the fields of the messages format.
+func exportColumns(pm readers.PageMetadata) []columnar.Column {
+	fields, types := readers.SenMLFields, senmlTypes
+	if pm.Format != "" && pm.Format != defFormat {
+		fields, types = readers.JSONFields, jsonTypes
+	}
+	if len(pm.Fields) > 0 {
+		fields = pm.Fields
+	}
+
+	var columns []columnar.Column
+	for _, f := range fields {
+		// The fields without the registered type are strings.
+		columns = append(columns, columnar.Column{Name: f, Type: types[f]})
+	}
+
+	return columns
+}
+
+// exportRows converts the messages to the rows of the column values.
+func exportRows(columns []columnar.Column, msgs []readers.Message) ([][]interface{}, error) {
+	rows := make([][]interface{}, 0, len(msgs))
+	for _, msg := range msgs {
+		m, err := messageFields(msg)
+		if err != nil {
+			return nil, err
+		}
+
+		row := make([]interface{}, len(columns))
+		for i, c := range columns {
+			if row[i], err = exportValue(c.Type, m[c.Name]); err != nil {
+				return nil, err
+			}
+		}
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+// messageFields returns the message fields keyed by their JSON names.
+func messageFields(msg readers.Message) (map[string]interface{}, error) {
+	switch m := msg.(type) {
+	case map[string]interface{}:
+		return m, nil
+	case senml.Message:
+		return senmlFields(m), nil
+	}
+
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return nil, err
+	}
+
+	var m map[string]interface{}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err := dec.Decode(&m); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+func senmlFields(msg senml.Message) map[string]interface{} {
+	m := map[string]interface{}{
+		"channel":     msg.Channel,
+		"subtopic":    msg.Subtopic,
+		"publisher":   msg.Publisher,
+		"protocol":    msg.Protocol,
+		"name":        msg.Name,
+		"unit":        msg.Unit,
+		"time":        msg.Time,
+		"update_time": msg.UpdateTime,
+		"seq":         msg.Seq,
+	}
+	if msg.Value != nil {
+		m["value"] = *msg.Value
+	}
+	if msg.StringValue != nil {
+		m["string_value"] = *msg.StringValue
+	}
+	if msg.DataValue != nil {
+		m["data_value"] = *msg.DataValue
+	}
+	if msg.BoolValue != nil {
+		m["bool_value"] = *msg.BoolValue
+	}
+	if msg.Sum != nil {
+		m["sum"] = *msg.Sum
+	}
+
+	return m
+}
+
+// exportValue converts the message field value to the value of the column
+// type. Values which can't be converted are exported as nulls.
+func exportValue(t columnar.Type, v interface{}) (interface{}, error) {
+	if v == nil {
+		return nil, nil
+	}
+
+	switch t {
+	case columnar.String:
+		if s, ok := v.(string); ok {
+			return s, nil
+		}
+		data, err := json.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+		return string(data), nil
+	case columnar.Float64:
+		switch v := v.(type) {
+		case float64:
+			return v, nil
+		case int64:
+			return float64(v), nil
+		case uint64:
+			return float64(v), nil
+		case json.Number:
+			f, err := v.Float64()
+			if err != nil {
+				return nil, nil
+			}
+			return f, nil
+		}
+	case columnar.Int64:
+		switch v := v.(type) {
+		case int64:
+			return v, nil
+		case uint64:
+			return int64(v), nil
+		case float64:
+			return int64(v), nil
+		case json.Number:
+			i, err := v.Int64()
+			if err != nil {
+				return nil, nil
+			}
+			return i, nil
+		}
+	case columnar.Bool:
+		if b, ok := v.(bool); ok {
+			return b, nil
+		}
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,452 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/internal/apiutil"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/transformers/senml"
+	"github.com/MainfluxLabs/mainflux/readers"
+	"github.com/go-kit/kit/endpoint"
+)
+
+// The Grafana JSON datasource targets are channel IDs, optionally followed by
+// the message name separated by a colon, e.g. <channel_id>:temperature.
+const (
+	grafanaSeparator = ":"
+	grafanaTimeserie = "timeserie"
+	grafanaTable     = "table"
+)
+
+type grafanaRange struct {
+	From time.Time `json:"from"`
+	To   time.Time `json:"to"`
+}
+
+// grafanaPayload contains the additional target parameters, sent as the
+// payload by the JSON datasource and as the data by the SimpleJSON one.
+type grafanaPayload struct {
+	Subtopic    string `json:"subtopic"`
+	Publisher   string `json:"publisher"`
+	Aggregation string `json:"aggregation"`
+}
+
+type grafanaTarget struct {
+	Target  string         `json:"target"`
+	RefID   string         `json:"refId"`
+	Type    string         `json:"type"`
+	Payload grafanaPayload `json:"payload"`
+	Data    grafanaPayload `json:"data"`
+}
+
+func (t grafanaTarget) payload() grafanaPayload {
+	if t.Payload != (grafanaPayload{}) {
+		return t.Payload
+	}
+
+	return t.Data
+}
+
+type grafanaAnnotation struct {
+	Name   string `json:"name"`
+	Enable bool   `json:"enable"`
+	Query  string `json:"query"`
+}
+
+type grafanaSearchReq struct {
+	token  string
+	key    string
+	Target string `json:"target"`
+}
+
+func (req grafanaSearchReq) validate() error {
+	if req.token == "" && req.key == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	return nil
+}
+
+type grafanaQueryReq struct {
+	token         string
+	key           string
+	Range         grafanaRange    `json:"range"`
+	IntervalMs    int64           `json:"intervalMs"`
+	MaxDataPoints uint64          `json:"maxDataPoints"`
+	Targets       []grafanaTarget `json:"targets"`
+}
+
+func (req grafanaQueryReq) validate() error {
+	if req.token == "" && req.key == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	for _, t := range req.Targets {
+		if chanID, _ := parseGrafanaTarget(t.Target); chanID == "" {
+			return apiutil.ErrMissingID
+		}
+
+		switch t.Type {
+		case "", grafanaTimeserie, grafanaTable:
+		default:
+			return apiutil.ErrInvalidQueryParams
+		}
+	}
+
+	return nil
+}
+
+type grafanaAnnotationsReq struct {
+	token      string
+	key        string
+	Range      grafanaRange      `json:"range"`
+	Annotation grafanaAnnotation `json:"annotation"`
+}
+
+func (req grafanaAnnotationsReq) validate() error {
+	if req.token == "" && req.key == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	if chanID, _ := parseGrafanaTarget(req.Annotation.Query); chanID == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return nil
+}
+
+type grafanaSeries struct {
+	Target     string       `json:"target"`
+	Datapoints [][2]float64 `json:"datapoints"`
+}
+
+type grafanaColumn struct {
+	Text string `json:"text"`
+	Type string `json:"type"`
+}
+
+type grafanaTableRes struct {
+	Type    string          `json:"type"`
+	Columns []grafanaColumn `json:"columns"`
+	Rows    [][]interface{} `json:"rows"`
+}
+
+type grafanaAnnotationRes struct {
+	Annotation grafanaAnnotation `json:"annotation"`
+	Time       int64             `json:"time"`
+	Title      string            `json:"title"`
+	Text       string            `json:"text"`
+	Tags       []string          `json:"tags"`
+}
+
+// grafanaRes is the response of the Grafana datasource endpoints, encoded
+// as the JSON array.
+type grafanaRes []interface{}
+
+func (res grafanaRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res grafanaRes) Code() int {
+	return http.StatusOK
+}
+
+func (res grafanaRes) Empty() bool {
+	return false
+}
+
+var grafanaColumns = []grafanaColumn{
+	{Text: "Time", Type: "time"},
+	{Text: "Publisher", Type: "string"},
+	{Text: "Subtopic", Type: "string"},
+	{Text: "Name", Type: "string"},
+	{Text: "Unit", Type: "string"},
+	{Text: "Value", Type: "number"},
+	{Text: "String value", Type: "string"},
+	{Text: "Bool value", Type: "string"},
+}
+
+// grafanaSearchEndpoint lists the names of the recent messages of the
+// channel, as the targets which can be queried.
+func grafanaSearchEndpoint(svc readers.MessageRepository) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(grafanaSearchReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		res := grafanaRes{}
+		chanID, _ := parseGrafanaTarget(req.Target)
+		if chanID == "" {
+			return res, nil
+		}
+
+		if err := authorize(ctx, req.token, req.key, chanID); err != nil {
+			return nil, errors.Wrap(errors.ErrAuthorization, err)
+		}
+
+		page, err := svc.ListChannelMessages(chanID, readers.PageMetadata{Limit: maxLimitSize})
+		if err != nil {
+			return nil, err
+		}
+
+		names := map[string]bool{}
+		for _, m := range page.Messages {
+			if msg, ok := m.(senml.Message); ok && msg.Name != "" {
+				names[msg.Name] = true
+			}
+		}
+
+		var targets []string
+		for n := range names {
+			targets = append(targets, chanID+grafanaSeparator+n)
+		}
+		sort.Strings(targets)
+		for _, t := range targets {
+			res = append(res, t)
+		}
+
+		return res, nil
+	}
+}
+
+func grafanaQueryEndpoint(svc readers.MessageRepository) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(grafanaQueryReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		res := grafanaRes{}
+		for _, t := range req.Targets {
+			chanID, name := parseGrafanaTarget(t.Target)
+			if err := authorize(ctx, req.token, req.key, chanID); err != nil {
+				return nil, errors.Wrap(errors.ErrAuthorization, err)
+			}
+
+			pm := grafanaPageMetadata(req.Range, req.MaxDataPoints)
+			pm.Name = name
+			p := t.payload()
+			pm.Subtopic, pm.Publisher = p.Subtopic, p.Publisher
+			if p.Aggregation != "" {
+				interval := time.Duration(req.IntervalMs) * time.Millisecond
+				if interval < time.Second {
+					interval = time.Second
+				}
+				pm.Aggregation, pm.Interval = p.Aggregation, interval.String()
+				if err := validateAggregation(pm); err != nil {
+					return nil, err
+				}
+			}
+
+			page, err := svc.ListChannelMessages(chanID, pm)
+			if err != nil {
+				return nil, err
+			}
+			msgs := grafanaMessages(page.Messages)
+
+			if t.Type == grafanaTable {
+				res = append(res, grafanaTableOf(msgs))
+				continue
+			}
+			res = append(res, grafanaSeriesOf(t.Target, msgs))
+		}
+
+		return res, nil
+	}
+}
+
+// grafanaAnnotationsEndpoint returns the messages of the annotation query
+// target as the annotations, e.g. the events or the alarms of the devices.
+func grafanaAnnotationsEndpoint(svc readers.MessageRepository) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(grafanaAnnotationsReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		chanID, name := parseGrafanaTarget(req.Annotation.Query)
+		if err := authorize(ctx, req.token, req.key, chanID); err != nil {
+			return nil, errors.Wrap(errors.ErrAuthorization, err)
+		}
+
+		pm := grafanaPageMetadata(req.Range, maxLimitSize)
+		pm.Name = name
+		page, err := svc.ListChannelMessages(chanID, pm)
+		if err != nil {
+			return nil, err
+		}
+
+		res := grafanaRes{}
+		for _, msg := range grafanaMessages(page.Messages) {
+			a := grafanaAnnotationRes{
+				Annotation: req.Annotation,
+				Time:       int64(msg.Time * 1e3),
+				Title:      msg.Name,
+				Text:       grafanaText(msg),
+				Tags:       []string{},
+			}
+			for _, tag := range []string{msg.Publisher, msg.Subtopic} {
+				if tag != "" {
+					a.Tags = append(a.Tags, tag)
+				}
+			}
+			res = append(res, a)
+		}
+
+		return res, nil
+	}
+}
+
+func decodeGrafanaSearch(_ context.Context, r *http.Request) (interface{}, error) {
+	req := grafanaSearchReq{
+		token: apiutil.ExtractBearerToken(r),
+		key:   apiutil.ExtractThingKey(r),
+	}
+
+	if err := decodeGrafanaBody(r, &req); err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+func decodeGrafanaQuery(_ context.Context, r *http.Request) (interface{}, error) {
+	req := grafanaQueryReq{
+		token: apiutil.ExtractBearerToken(r),
+		key:   apiutil.ExtractThingKey(r),
+	}
+
+	if err := decodeGrafanaBody(r, &req); err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+func decodeGrafanaAnnotations(_ context.Context, r *http.Request) (interface{}, error) {
+	req := grafanaAnnotationsReq{
+		token: apiutil.ExtractBearerToken(r),
+		key:   apiutil.ExtractThingKey(r),
+	}
+
+	if err := decodeGrafanaBody(r, &req); err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+func decodeGrafanaBody(r *http.Request, req interface{}) error {
+	if !strings.Contains(r.Header.Get("Content-Type"), contentType) {
+		return apiutil.ErrUnsupportedContentType
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		return errors.Wrap(apiutil.ErrMalformedEntity, err)
+	}
+
+	return nil
+}
+
+// grafanaHealth responds to the datasource connection test.
+func grafanaHealth(w http.ResponseWriter, _ *http.Request) {
+	w.WriteHeader(http.StatusOK)
+}
+
+// parseGrafanaTarget returns the channel ID and the message name of the
+// target. Message names may contain the separator.
+func parseGrafanaTarget(target string) (string, string) {
+	parts := strings.SplitN(strings.TrimSpace(target), grafanaSeparator, 2)
+	if len(parts) == 1 {
+		return parts[0], ""
+	}
+
+	return parts[0], parts[1]
+}
+
+// grafanaPageMetadata returns the page metadata of the messages of the time
+// range, limited by the maximum number of the data points.
+func grafanaPageMetadata(rng grafanaRange, maxDataPoints uint64) readers.PageMetadata {
+	pm := readers.PageMetadata{Limit: maxDataPoints}
+	if pm.Limit == 0 || pm.Limit > maxLimitSize {
+		pm.Limit = maxLimitSize
+	}
+	if !rng.From.IsZero() {
+		pm.From = float64(rng.From.UnixNano()) / float64(time.Second)
+	}
+	if !rng.To.IsZero() {
+		pm.To = float64(rng.To.UnixNano()) / float64(time.Second)
+	}
+
+	return pm
+}
+
+// grafanaMessages returns the SenML messages ordered by their time, as
+// expected by Grafana.
+func grafanaMessages(msgs []readers.Message) []senml.Message {
+	var ret []senml.Message
+	for _, m := range msgs {
+		if msg, ok := m.(senml.Message); ok {
+			ret = append(ret, msg)
+		}
+	}
+	sort.SliceStable(ret, func(i, j int) bool { return ret[i].Time < ret[j].Time })
+
+	return ret
+}
+
+func grafanaSeriesOf(target string, msgs []senml.Message) grafanaSeries {
+	s := grafanaSeries{Target: target, Datapoints: [][2]float64{}}
+	for _, msg := range msgs {
+		if msg.Value == nil {
+			continue
+		}
+		s.Datapoints = append(s.Datapoints, [2]float64{*msg.Value, msg.Time * 1e3})
+	}
+
+	return s
+}
+
+func grafanaTableOf(msgs []senml.Message) grafanaTableRes {
+	t := grafanaTableRes{Type: grafanaTable, Columns: grafanaColumns, Rows: [][]interface{}{}}
+	for _, msg := range msgs {
+		row := []interface{}{int64(msg.Time * 1e3), msg.Publisher, msg.Subtopic, msg.Name, msg.Unit, nil, nil, nil}
+		if msg.Value != nil {
+			row[5] = *msg.Value
+		}
+		if msg.StringValue != nil {
+			row[6] = *msg.StringValue
+		}
+		if msg.BoolValue != nil {
+			row[7] = fmt.Sprint(*msg.BoolValue)
+		}
+		t.Rows = append(t.Rows, row)
+	}
+
+	return t
+}
+
+func grafanaText(msg senml.Message) string {
+	switch {
+	case msg.StringValue != nil:
+		return *msg.StringValue
+	case msg.Value != nil:
+		return strings.TrimSpace(fmt.Sprintf("%g %s", *msg.Value, msg.Unit))
+	case msg.BoolValue != nil:
+		return fmt.Sprint(*msg.BoolValue)
+	case msg.DataValue != nil:
+		return *msg.DataValue
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,196 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/internal/apiutil"
+	thmocks "github.com/MainfluxLabs/mainflux/pkg/mocks"
+	"github.com/MainfluxLabs/mainflux/pkg/transformers/senml"
+	"github.com/MainfluxLabs/mainflux/readers"
+	rmocks "github.com/MainfluxLabs/mainflux/readers/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func grafanaRequest(client *http.Client, url, key, body string) (*http.Response, error) {
+	req, err := http.NewRequest(http.MethodPost, url, strings.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	if key != "" {
+		req.Header.Set("Authorization", apiutil.ThingPrefix+key)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	return client.Do(req)
+}
+
+func TestGrafana(t *testing.T) {
+	chanID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	pubID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	status := "door open"
+	var messages []readers.Message
+	for i := 0; i < 3; i++ {
+		val := float64(i)
+		messages = append(messages, senml.Message{
+			Channel:   chanID,
+			Publisher: pubID,
+			Name:      msgName,
+			Unit:      "C",
+			Time:      float64(start.Add(time.Duration(i) * time.Minute).Unix()),
+			Value:     &val,
+		})
+	}
+	messages = append(messages, senml.Message{
+		Channel:     chanID,
+		Publisher:   pubID,
+		Name:        "event",
+		Time:        float64(start.Unix()),
+		StringValue: &status,
+	})
+
+	thSvc := thmocks.NewThingsServiceClient(map[string]string{thingToken: chanID}, nil)
+	repo := rmocks.NewMessageRepository(chanID, messages)
+	ts := newServer(repo, nil, nil, nil, thSvc, newAuthService())
+	defer ts.Close()
+
+	res, err := ts.Client().Get(fmt.Sprintf("%s/grafana", ts.URL))
+	require.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	assert.Equal(t, http.StatusOK, res.StatusCode, fmt.Sprintf("test datasource connection: expected status %d got %d", http.StatusOK, res.StatusCode))
+
+	rng := fmt.Sprintf(`"range":{"from":"%s","to":"%s"}`, start.Format(time.RFC3339), start.Add(time.Hour).Format(time.RFC3339))
+	target := chanID + ":" + msgName
+
+	cases := []struct {
+		desc   string
+		path   string
+		key    string
+		body   string
+		status int
+		res    string
+	}{
+		{
+			desc:   "search channel targets",
+			path:   "search",
+			key:    thingToken,
+			body:   fmt.Sprintf(`{"target":"%s"}`, chanID),
+			status: http.StatusOK,
+			res:    fmt.Sprintf(`["%s:event","%s"]`, chanID, target),
+		},
+		{
+			desc:   "search without target",
+			path:   "search",
+			key:    thingToken,
+			body:   `{"target":""}`,
+			status: http.StatusOK,
+			res:    `[]`,
+		},
+		{
+			desc:   "search targets with invalid key",
+			path:   "search",
+			key:    invalid,
+			body:   fmt.Sprintf(`{"target":"%s"}`, chanID),
+			status: http.StatusUnauthorized,
+		},
+		{
+			desc:   "search targets without key",
+			path:   "search",
+			key:    "",
+			body:   fmt.Sprintf(`{"target":"%s"}`, chanID),
+			status: http.StatusUnauthorized,
+		},
+		{
+			desc:   "query time series",
+			path:   "query",
+			key:    thingToken,
+			body:   fmt.Sprintf(`{%s,"maxDataPoints":100,"targets":[{"target":"%s","refId":"A","type":"timeserie"}]}`, rng, target),
+			status: http.StatusOK,
+			res:    fmt.Sprintf(`[{"target":"%s","datapoints":[[0,1704067200000],[1,1704067260000],[2,1704067320000]]}]`, target),
+		},
+		{
+			desc:   "query table",
+			path:   "query",
+			key:    thingToken,
+			body:   fmt.Sprintf(`{%s,"targets":[{"target":"%s:event","type":"table"}]}`, rng, chanID),
+			status: http.StatusOK,
+			res: fmt.Sprintf(`[{"type":"table","columns":[{"text":"Time","type":"time"},{"text":"Publisher","type":"string"},{"text":"Subtopic","type":"string"},{"text":"Name","type":"string"},{"text":"Unit","type":"string"},{"text":"Value","type":"number"},{"text":"String value","type":"string"},{"text":"Bool value","type":"string"}],`+
+				`"rows":[[1704067200000,"%s","","event","",null,"door open",null]]}]`, pubID),
+		},
+		{
+			desc:   "query target without channel",
+			path:   "query",
+			key:    thingToken,
+			body:   fmt.Sprintf(`{%s,"targets":[{"target":":%s"}]}`, rng, msgName),
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "query target of invalid type",
+			path:   "query",
+			key:    thingToken,
+			body:   fmt.Sprintf(`{%s,"targets":[{"target":"%s","type":"invalid"}]}`, rng, target),
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "query target with invalid aggregation",
+			path:   "query",
+			key:    thingToken,
+			body:   fmt.Sprintf(`{%s,"targets":[{"target":"%s","payload":{"aggregation":"invalid"}}]}`, rng, target),
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "query target with invalid key",
+			path:   "query",
+			key:    invalid,
+			body:   fmt.Sprintf(`{%s,"targets":[{"target":"%s"}]}`, rng, target),
+			status: http.StatusUnauthorized,
+		},
+		{
+			desc:   "query with malformed body",
+			path:   "query",
+			key:    thingToken,
+			body:   `{`,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "list annotations",
+			path:   "annotations",
+			key:    thingToken,
+			body:   fmt.Sprintf(`{%s,"annotation":{"name":"events","enable":true,"query":"%s:event"}}`, rng, chanID),
+			status: http.StatusOK,
+			res:    fmt.Sprintf(`[{"annotation":{"name":"events","enable":true,"query":"%s:event"},"time":1704067200000,"title":"event","text":"door open","tags":["%s"]}]`, chanID, pubID),
+		},
+		{
+			desc:   "list annotations without channel",
+			path:   "annotations",
+			key:    thingToken,
+			body:   fmt.Sprintf(`{%s,"annotation":{"name":"events","query":""}}`, rng),
+			status: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range cases {
+		res, err := grafanaRequest(ts.Client(), fmt.Sprintf("%s/grafana/%s", ts.URL, tc.path), tc.key, tc.body)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status %d got %d", tc.desc, tc.status, res.StatusCode))
+		if tc.res == "" {
+			continue
+		}
+
+		var body json.RawMessage
+		err = json.NewDecoder(res.Body).Decode(&body)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.JSONEq(t, tc.res, string(body), fmt.Sprintf("%s: unexpected response body", tc.desc))
+	}
+}
@@ -32,25 +32,41 @@ func LoggingMiddleware(svc readers.MessageRepository, logger logger.Logger) read
 
 func (lm *loggingMiddleware) ListChannelMessages(chanID string, rpm readers.PageMetadata) (page readers.MessagesPage, err error) {
 	defer func(begin time.Time) {
+		l := lm.logger.With("method", "list_channel_messages", "latency", time.Since(begin).String())
 		message := fmt.Sprintf("Method list_channel_messages for channel %s with query %v took %s to complete", chanID, rpm, time.Since(begin))
 		if err != nil {
-			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
 			return
 		}
-		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+		l.Info(fmt.Sprintf("%s without errors.", message))
 	}(time.Now())
 
 	return lm.svc.ListChannelMessages(chanID, rpm)
 }
 
+func (lm *loggingMiddleware) CountChannelMessages(chanID string, rpm readers.PageMetadata) (count uint64, err error) {
+	defer func(begin time.Time) {
+		l := lm.logger.With("method", "count_channel_messages", "latency", time.Since(begin).String())
+		message := fmt.Sprintf("Method count_channel_messages for channel %s with query %v took %s to complete", chanID, rpm, time.Since(begin))
+		if err != nil {
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		l.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.CountChannelMessages(chanID, rpm)
+}
+
 func (lm *loggingMiddleware) ListAllMessages(rpm readers.PageMetadata) (page readers.MessagesPage, err error) {
 	defer func(begin time.Time) {
+		l := lm.logger.With("method", "list_all_messages", "latency", time.Since(begin).String())
 		message := fmt.Sprintf("Method list_all_messages took %s to complete", time.Since(begin))
 		if err != nil {
-			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
 			return
 		}
-		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+		l.Info(fmt.Sprintf("%s without errors.", message))
 	}(time.Now())
 
 	return lm.svc.ListAllMessages(rpm)
@@ -58,12 +74,13 @@ func (lm *loggingMiddleware) ListAllMessages(rpm readers.PageMetadata) (page rea
 
 func (lm *loggingMiddleware) Restore(ctx context.Context, messages ...senml.Message) (err error) {
 	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "restore", "latency", time.Since(begin).String())
 		message := fmt.Sprintf("Method restore took %s to complete", time.Since(begin))
 		if err != nil {
-			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
 			return
 		}
-		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+		l.Info(fmt.Sprintf("%s without errors.", message))
 	}(time.Now())
 
 	return lm.svc.Restore(ctx, messages...)
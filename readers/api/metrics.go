@@ -40,6 +40,15 @@ func (mm *metricsMiddleware) ListChannelMessages(chanID string, rpm readers.Page
 	return mm.svc.ListChannelMessages(chanID, rpm)
 }
 
+func (mm *metricsMiddleware) CountChannelMessages(chanID string, rpm readers.PageMetadata) (uint64, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "count_channel_messages").Add(1)
+		mm.latency.With("method", "count_channel_messages").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.CountChannelMessages(chanID, rpm)
+}
+
 func (mm *metricsMiddleware) ListAllMessages(rpm readers.PageMetadata) (readers.MessagesPage, error) {
 	defer func(begin time.Time) {
 		mm.counter.With("method", "list_all_messages").Add(1)
@@ -4,17 +4,25 @@
 package api
 
 import (
+	"time"
+
 	"github.com/MainfluxLabs/mainflux/internal/apiutil"
 	"github.com/MainfluxLabs/mainflux/pkg/transformers/senml"
 	"github.com/MainfluxLabs/mainflux/readers"
 )
 
-const maxLimitSize = 1000
+const (
+	maxLimitSize = 1000
+	// maxExportSize is the maximum number of messages exported in the
+	// columnar format.
+	maxExportSize = 100000
+)
 
 type listChannelMessagesReq struct {
 	chanID   string
 	token    string
 	key      string
+	export   string
 	pageMeta readers.PageMetadata
 }
 
@@ -27,7 +35,7 @@ func (req listChannelMessagesReq) validate() error {
 		return apiutil.ErrMissingID
 	}
 
-	if req.pageMeta.Limit > maxLimitSize {
+	if req.pageMeta.Limit > limitSize(req.export) {
 		return apiutil.ErrLimitSize
 	}
 
@@ -44,12 +52,26 @@ func (req listChannelMessagesReq) validate() error {
 		return apiutil.ErrInvalidComparator
 	}
 
+	if req.pageMeta.ValueGt != nil && req.pageMeta.ValueLt != nil &&
+		*req.pageMeta.ValueGt >= *req.pageMeta.ValueLt {
+		return apiutil.ErrInvalidQueryParams
+	}
+
+	if !validFields(req.pageMeta.Format, req.pageMeta.Fields) {
+		return apiutil.ErrInvalidQueryParams
+	}
+
+	if err := validateAggregation(req.pageMeta); err != nil {
+		return err
+	}
+
 	return nil
 }
 
 type listAllMessagesReq struct {
 	token    string
 	key      string
+	export   string
 	pageMeta readers.PageMetadata
 }
 
@@ -58,7 +80,7 @@ func (req listAllMessagesReq) validate() error {
 		return apiutil.ErrBearerToken
 	}
 
-	if req.pageMeta.Limit > maxLimitSize {
+	if req.pageMeta.Limit > limitSize(req.export) {
 		return apiutil.ErrLimitSize
 	}
 
@@ -75,6 +97,88 @@ func (req listAllMessagesReq) validate() error {
 		return apiutil.ErrInvalidComparator
 	}
 
+	if req.pageMeta.ValueGt != nil && req.pageMeta.ValueLt != nil &&
+		*req.pageMeta.ValueGt >= *req.pageMeta.ValueLt {
+		return apiutil.ErrInvalidQueryParams
+	}
+
+	if !validFields(req.pageMeta.Format, req.pageMeta.Fields) {
+		return apiutil.ErrInvalidQueryParams
+	}
+
+	if err := validateAggregation(req.pageMeta); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// limitSize returns the maximum number of the listed messages, which is
+// greater if the messages are exported in the columnar format.
+func limitSize(export string) uint64 {
+	if export != "" {
+		return maxExportSize
+	}
+
+	return maxLimitSize
+}
+
+// validFields checks whether the fields can be selected from the messages
+// of the given format.
+func validFields(format string, fields []string) bool {
+	supported := readers.JSONFields
+	if format == "" || format == defFormat {
+		supported = readers.SenMLFields
+	}
+
+	for _, f := range fields {
+		found := false
+		for _, s := range supported {
+			if f == s {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
+
+// validateAggregation checks that the aggregation is provided together with
+// the interval of at least one second, and that the bucketing time zone is
+// known. Only SenML messages values can be aggregated and the aggregated
+// messages fields can't be selected.
+func validateAggregation(pm readers.PageMetadata) error {
+	if pm.Aggregation == "" && pm.Interval == "" {
+		return nil
+	}
+
+	switch pm.Aggregation {
+	case readers.AvgAggregation,
+		readers.MinAggregation,
+		readers.MaxAggregation,
+		readers.SumAggregation,
+		readers.CountAggregation:
+	default:
+		return apiutil.ErrInvalidQueryParams
+	}
+
+	interval, err := readers.ParseInterval(pm.Interval)
+	if err != nil || interval < time.Second {
+		return apiutil.ErrInvalidQueryParams
+	}
+
+	if _, err := time.LoadLocation(pm.Timezone); err != nil {
+		return apiutil.ErrInvalidQueryParams
+	}
+
+	if (pm.Format != "" && pm.Format != defFormat) || len(pm.Fields) > 0 {
+		return apiutil.ErrInvalidQueryParams
+	}
+
 	return nil
 }
 
@@ -94,3 +198,104 @@ func (req restoreMessagesReq) validate() error {
 
 	return nil
 }
+
+type listLastMessagesReq struct {
+	chanID   string
+	token    string
+	key      string
+	pageMeta readers.PageMetadata
+}
+
+func (req listLastMessagesReq) validate() error {
+	if req.token == "" && req.key == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	if req.chanID == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return nil
+}
+
+type countMessagesReq struct {
+	chanID   string
+	token    string
+	key      string
+	pageMeta readers.PageMetadata
+}
+
+func (req countMessagesReq) validate() error {
+	if req.token == "" && req.key == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	if req.chanID == "" {
+		return apiutil.ErrMissingID
+	}
+
+	if req.pageMeta.To != 0 && req.pageMeta.From > req.pageMeta.To {
+		return apiutil.ErrInvalidQueryParams
+	}
+
+	return nil
+}
+
+type rotateKeyReq struct {
+	token  string
+	chanID string
+}
+
+func (req rotateKeyReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	if req.chanID == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return nil
+}
+
+type createExportJobReq struct {
+	chanID   string
+	token    string
+	key      string
+	pageMeta readers.PageMetadata
+}
+
+func (req createExportJobReq) validate() error {
+	if req.token == "" && req.key == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	if req.chanID == "" {
+		return apiutil.ErrMissingID
+	}
+
+	if req.pageMeta.To != 0 && req.pageMeta.From > req.pageMeta.To {
+		return apiutil.ErrInvalidQueryParams
+	}
+
+	return nil
+}
+
+type viewExportJobReq struct {
+	chanID string
+	id     string
+	token  string
+	key    string
+}
+
+func (req viewExportJobReq) validate() error {
+	if req.token == "" && req.key == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	if req.chanID == "" || req.id == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return nil
+}
@@ -4,21 +4,31 @@
 package api
 
 import (
+	"io"
 	"net/http"
+	"time"
 
 	"github.com/MainfluxLabs/mainflux"
+	"github.com/MainfluxLabs/mainflux/pkg/columnar"
 	"github.com/MainfluxLabs/mainflux/readers"
 )
 
 var (
 	_ mainflux.Response = (*listMessagesRes)(nil)
+	_ mainflux.Response = (*lastMessagesRes)(nil)
+	_ mainflux.Response = (*countMessagesRes)(nil)
 	_ mainflux.Response = (*restoreMessagesRes)(nil)
+	_ mainflux.Response = (*rotateKeyRes)(nil)
+	_ mainflux.Response = (*exportMessagesRes)(nil)
 )
 
 type listMessagesRes struct {
 	readers.PageMetadata
 	Total    uint64            `json:"total"`
 	Messages []readers.Message `json:"messages,omitempty"`
+	// Gaps are the sequence numbers missing from the messages of the
+	// page, reported if the messages are filtered by the publisher.
+	Gaps []readers.Gap `json:"gaps,omitempty"`
 }
 
 func (res listMessagesRes) Headers() map[string]string {
@@ -33,6 +43,114 @@ func (res listMessagesRes) Empty() bool {
 	return false
 }
 
+// exportMessagesRes streams the listed messages in the columnar format,
+// retrieving them from the repository page by page.
+type exportMessagesRes struct {
+	contentType string
+	columns     []columnar.Column
+	pageMeta    readers.PageMetadata
+	page        readers.MessagesPage
+	list        listMessagesFunc
+}
+
+func (res exportMessagesRes) Headers() map[string]string {
+	filename := "messages.arrows"
+	if res.contentType == columnar.ParquetContentType {
+		filename = "messages.parquet"
+	}
+
+	return map[string]string{
+		"Content-Type":        res.contentType,
+		"Content-Disposition": "attachment; filename=" + filename,
+	}
+}
+
+func (res exportMessagesRes) Code() int {
+	return http.StatusOK
+}
+
+func (res exportMessagesRes) Empty() bool {
+	return false
+}
+
+// encode writes the messages page by page, each page as a single record
+// batch or row group.
+func (res exportMessagesRes) encode(w io.Writer) error {
+	var cw columnar.Writer
+	var err error
+	switch res.contentType {
+	case columnar.ParquetContentType:
+		cw, err = columnar.NewParquetWriter(w, res.columns)
+	default:
+		cw, err = columnar.NewArrowWriter(w, res.columns)
+	}
+	if err != nil {
+		return err
+	}
+
+	page, pm := res.page, res.pageMeta
+	for {
+		rows, err := exportRows(res.columns, page.Messages)
+		if err != nil {
+			return err
+		}
+
+		if err := cw.Write(rows); err != nil {
+			return err
+		}
+
+		n := uint64(len(page.Messages))
+		if n == 0 || n < exportPageSize || n >= pm.Limit {
+			break
+		}
+
+		pm.Offset += n
+		pm.Limit -= n
+		next := pm
+		if next.Limit > exportPageSize {
+			next.Limit = exportPageSize
+		}
+
+		if page, err = res.list(next); err != nil {
+			return err
+		}
+	}
+
+	return cw.Close()
+}
+
+type lastMessagesRes struct {
+	Messages []readers.Message `json:"messages"`
+}
+
+func (res lastMessagesRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res lastMessagesRes) Code() int {
+	return http.StatusOK
+}
+
+func (res lastMessagesRes) Empty() bool {
+	return false
+}
+
+type countMessagesRes struct {
+	Count uint64 `json:"count"`
+}
+
+func (res countMessagesRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res countMessagesRes) Code() int {
+	return http.StatusOK
+}
+
+func (res countMessagesRes) Empty() bool {
+	return false
+}
+
 type restoreMessagesRes struct{}
 
 func (res restoreMessagesRes) Code() int {
@@ -46,3 +164,54 @@ func (res restoreMessagesRes) Headers() map[string]string {
 func (res restoreMessagesRes) Empty() bool {
 	return true
 }
+
+type rotateKeyRes struct {
+	Channel string `json:"channel"`
+	Version uint64 `json:"version"`
+}
+
+func (res rotateKeyRes) Code() int {
+	return http.StatusOK
+}
+
+func (res rotateKeyRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res rotateKeyRes) Empty() bool {
+	return false
+}
+
+type exportJobRes struct {
+	ID        string    `json:"id"`
+	ChannelID string    `json:"channel_id"`
+	Status    string    `json:"status"`
+	Format    string    `json:"format,omitempty"`
+	Subtopic  string    `json:"subtopic,omitempty"`
+	Publisher string    `json:"publisher,omitempty"`
+	From      float64   `json:"from,omitempty"`
+	To        float64   `json:"to"`
+	Total     uint64    `json:"total"`
+	Exported  uint64    `json:"exported"`
+	Error     string    `json:"error,omitempty"`
+	URL       string    `json:"url,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+	created   bool
+}
+
+func (res exportJobRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res exportJobRes) Code() int {
+	if res.created {
+		return http.StatusAccepted
+	}
+
+	return http.StatusOK
+}
+
+func (res exportJobRes) Empty() bool {
+	return false
+}
@@ -7,14 +7,19 @@ import (
 	"context"
 	"encoding/json"
 	"net/http"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/MainfluxLabs/mainflux"
 	auth "github.com/MainfluxLabs/mainflux/auth"
 	"github.com/MainfluxLabs/mainflux/internal/apiutil"
 	"github.com/MainfluxLabs/mainflux/logger"
+	"github.com/MainfluxLabs/mainflux/pkg/columnar"
+	"github.com/MainfluxLabs/mainflux/pkg/encryption"
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
 	"github.com/MainfluxLabs/mainflux/readers"
+	"github.com/MainfluxLabs/mainflux/readers/exports"
 	kithttp "github.com/go-kit/kit/transport/http"
 	"github.com/go-zoo/bone"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
@@ -36,6 +41,15 @@ const (
 	comparatorKey  = "comparator"
 	fromKey        = "from"
 	toKey          = "to"
+	valueGtKey     = "value_gt"
+	valueLtKey     = "value_lt"
+	boolValueAlias = "bool_value"
+	dataValueAlias = "data_value"
+	stringLikeKey  = "string_value_like"
+	fieldsKey      = "fields"
+	aggregationKey = "aggregation"
+	intervalKey    = "interval"
+	timezoneKey    = "tz"
 	defLimit       = 10
 	defOffset      = 0
 	defFormat      = "messages"
@@ -46,8 +60,11 @@ var (
 	authc  mainflux.AuthServiceClient
 )
 
-// MakeHandler returns a HTTP handler for API endpoints.
-func MakeHandler(svc readers.MessageRepository, tc mainflux.ThingsServiceClient, ac mainflux.AuthServiceClient, svcName string, logger logger.Logger) http.Handler {
+// MakeHandler returns a HTTP handler for API endpoints. The data key
+// rotation endpoint is exposed only if the keyring is provided, the
+// last messages endpoint only if the last value cache is provided, and
+// the export job endpoints only if the export service is provided.
+func MakeHandler(svc readers.MessageRepository, kr encryption.Keyring, lvc readers.LastValueCache, es exports.Service, tc mainflux.ThingsServiceClient, ac mainflux.AuthServiceClient, svcName string, logger logger.Logger, checks ...mainflux.HealthCheck) http.Handler {
 	thingc = tc
 	authc = ac
 
@@ -62,6 +79,34 @@ func MakeHandler(svc readers.MessageRepository, tc mainflux.ThingsServiceClient,
 		encodeResponse,
 		opts...,
 	))
+	mux.Get("/channels/:chanID/messages/count", kithttp.NewServer(
+		countChannelMessagesEndpoint(svc),
+		decodeCountChannelMessages,
+		encodeResponse,
+		opts...,
+	))
+	if lvc != nil {
+		mux.Get("/channels/:chanID/messages/last", kithttp.NewServer(
+			listLastMessagesEndpoint(lvc),
+			decodeListLastMessages,
+			encodeResponse,
+			opts...,
+		))
+	}
+	if es != nil {
+		mux.Post("/channels/:chanID/messages/export", kithttp.NewServer(
+			createExportJobEndpoint(es),
+			decodeCreateExportJob,
+			encodeResponse,
+			opts...,
+		))
+		mux.Get("/channels/:chanID/messages/export/:jobID", kithttp.NewServer(
+			viewExportJobEndpoint(es),
+			decodeViewExportJob,
+			encodeResponse,
+			opts...,
+		))
+	}
 	mux.Get("/messages", kithttp.NewServer(
 		listAllMessagesEndpoint(svc),
 		decodeListAllMessages,
@@ -75,10 +120,40 @@ func MakeHandler(svc readers.MessageRepository, tc mainflux.ThingsServiceClient,
 		opts...,
 	))
 
-	mux.GetFunc("/health", mainflux.Health(svcName))
+	if kr != nil {
+		mux.Post("/channels/:chanID/keys/rotate", kithttp.NewServer(
+			rotateKeyEndpoint(kr),
+			decodeRotateKey,
+			encodeResponse,
+			opts...,
+		))
+	}
+
+	mux.GetFunc("/grafana", grafanaHealth)
+	mux.Post("/grafana/search", kithttp.NewServer(
+		grafanaSearchEndpoint(svc),
+		decodeGrafanaSearch,
+		encodeResponse,
+		opts...,
+	))
+	mux.Post("/grafana/query", kithttp.NewServer(
+		grafanaQueryEndpoint(svc),
+		decodeGrafanaQuery,
+		encodeResponse,
+		opts...,
+	))
+	mux.Post("/grafana/annotations", kithttp.NewServer(
+		grafanaAnnotationsEndpoint(svc),
+		decodeGrafanaAnnotations,
+		encodeResponse,
+		opts...,
+	))
+
+	mux.GetFunc("/health", mainflux.Health(svcName, checks...))
 	mux.Handle("/metrics", promhttp.Handler())
+	mux.Handle("/log-level", mainflux.LogLevel(logger))
 
-	return mux
+	return apiutil.RequestIDMiddleware(apiutil.LocaleMiddleware(mux))
 }
 
 func decodeListChannelMessages(ctx context.Context, r *http.Request) (interface{}, error) {
@@ -87,7 +162,8 @@ func decodeListChannelMessages(ctx context.Context, r *http.Request) (interface{
 		return nil, err
 	}
 
-	limit, err := apiutil.ReadLimitQuery(r, limitKey, defLimit)
+	export := readExportType(r)
+	limit, err := readListLimit(r, export)
 	if err != nil {
 		return nil, err
 	}
@@ -137,12 +213,12 @@ func decodeListChannelMessages(ctx context.Context, r *http.Request) (interface{
 		return nil, err
 	}
 
-	from, err := apiutil.ReadFloatQuery(r, fromKey, 0)
+	from, err := readTimeQuery(r, fromKey)
 	if err != nil {
 		return nil, err
 	}
 
-	to, err := apiutil.ReadFloatQuery(r, toKey, 0)
+	to, err := readTimeQuery(r, toKey)
 	if err != nil {
 		return nil, err
 	}
@@ -151,6 +227,7 @@ func decodeListChannelMessages(ctx context.Context, r *http.Request) (interface{
 		chanID: bone.GetValue(r, "chanID"),
 		token:  apiutil.ExtractBearerToken(r),
 		key:    apiutil.ExtractThingKey(r),
+		export: export,
 		pageMeta: readers.PageMetadata{
 			Offset:      offset,
 			Limit:       limit,
@@ -168,12 +245,22 @@ func decodeListChannelMessages(ctx context.Context, r *http.Request) (interface{
 		},
 	}
 
-	vb, err := apiutil.ReadBoolQuery(r, boolValueKey, false)
-	if err != nil && err != apiutil.ErrNotFoundParam {
+	if err := readValueFilters(r, &req.pageMeta); err != nil {
+		return nil, err
+	}
+
+	req.pageMeta.Fields = readFields(r)
+
+	if req.pageMeta.Aggregation, err = apiutil.ReadStringQuery(r, aggregationKey, ""); err != nil {
+		return nil, err
+	}
+
+	if req.pageMeta.Interval, err = apiutil.ReadStringQuery(r, intervalKey, ""); err != nil {
 		return nil, err
 	}
-	if err == nil {
-		req.pageMeta.BoolValue = vb
+
+	if req.pageMeta.Timezone, err = apiutil.ReadStringQuery(r, timezoneKey, ""); err != nil {
+		return nil, err
 	}
 
 	return req, nil
@@ -185,7 +272,8 @@ func decodeListAllMessages(ctx context.Context, r *http.Request) (interface{}, e
 		return nil, err
 	}
 
-	limit, err := apiutil.ReadLimitQuery(r, limitKey, defLimit)
+	export := readExportType(r)
+	limit, err := readListLimit(r, export)
 	if err != nil {
 		return nil, err
 	}
@@ -235,19 +323,20 @@ func decodeListAllMessages(ctx context.Context, r *http.Request) (interface{}, e
 		return nil, err
 	}
 
-	from, err := apiutil.ReadFloatQuery(r, fromKey, 0)
+	from, err := readTimeQuery(r, fromKey)
 	if err != nil {
 		return nil, err
 	}
 
-	to, err := apiutil.ReadFloatQuery(r, toKey, 0)
+	to, err := readTimeQuery(r, toKey)
 	if err != nil {
 		return nil, err
 	}
 
 	req := listAllMessagesReq{
-		token: apiutil.ExtractBearerToken(r),
-		key:   apiutil.ExtractThingKey(r),
+		token:  apiutil.ExtractBearerToken(r),
+		key:    apiutil.ExtractThingKey(r),
+		export: export,
 		pageMeta: readers.PageMetadata{
 			Offset:      offset,
 			Limit:       limit,
@@ -265,12 +354,22 @@ func decodeListAllMessages(ctx context.Context, r *http.Request) (interface{}, e
 		},
 	}
 
-	vb, err := apiutil.ReadBoolQuery(r, boolValueKey, false)
-	if err != nil && err != apiutil.ErrNotFoundParam {
+	if err := readValueFilters(r, &req.pageMeta); err != nil {
 		return nil, err
 	}
-	if err == nil {
-		req.pageMeta.BoolValue = vb
+
+	req.pageMeta.Fields = readFields(r)
+
+	if req.pageMeta.Aggregation, err = apiutil.ReadStringQuery(r, aggregationKey, ""); err != nil {
+		return nil, err
+	}
+
+	if req.pageMeta.Interval, err = apiutil.ReadStringQuery(r, intervalKey, ""); err != nil {
+		return nil, err
+	}
+
+	if req.pageMeta.Timezone, err = apiutil.ReadStringQuery(r, timezoneKey, ""); err != nil {
+		return nil, err
 	}
 
 	return req, nil
@@ -289,6 +388,275 @@ func decodeRestore(ctx context.Context, r *http.Request) (interface{}, error) {
 	return req, nil
 }
 
+func decodeListLastMessages(_ context.Context, r *http.Request) (interface{}, error) {
+	subtopic, err := apiutil.ReadStringQuery(r, subtopicKey, "")
+	if err != nil {
+		return nil, err
+	}
+
+	publisher, err := apiutil.ReadStringQuery(r, publisherKey, "")
+	if err != nil {
+		return nil, err
+	}
+
+	name, err := apiutil.ReadStringQuery(r, nameKey, "")
+	if err != nil {
+		return nil, err
+	}
+
+	req := listLastMessagesReq{
+		chanID: bone.GetValue(r, "chanID"),
+		token:  apiutil.ExtractBearerToken(r),
+		key:    apiutil.ExtractThingKey(r),
+		pageMeta: readers.PageMetadata{
+			Subtopic:  subtopic,
+			Publisher: publisher,
+			Name:      name,
+		},
+	}
+
+	return req, nil
+}
+
+func decodeCountChannelMessages(_ context.Context, r *http.Request) (interface{}, error) {
+	format, err := apiutil.ReadStringQuery(r, formatKey, defFormat)
+	if err != nil {
+		return nil, err
+	}
+
+	subtopic, err := apiutil.ReadStringQuery(r, subtopicKey, "")
+	if err != nil {
+		return nil, err
+	}
+
+	publisher, err := apiutil.ReadStringQuery(r, publisherKey, "")
+	if err != nil {
+		return nil, err
+	}
+
+	protocol, err := apiutil.ReadStringQuery(r, protocolKey, "")
+	if err != nil {
+		return nil, err
+	}
+
+	name, err := apiutil.ReadStringQuery(r, nameKey, "")
+	if err != nil {
+		return nil, err
+	}
+
+	from, err := readTimeQuery(r, fromKey)
+	if err != nil {
+		return nil, err
+	}
+
+	to, err := readTimeQuery(r, toKey)
+	if err != nil {
+		return nil, err
+	}
+
+	req := countMessagesReq{
+		chanID: bone.GetValue(r, "chanID"),
+		token:  apiutil.ExtractBearerToken(r),
+		key:    apiutil.ExtractThingKey(r),
+		pageMeta: readers.PageMetadata{
+			Format:    format,
+			Subtopic:  subtopic,
+			Publisher: publisher,
+			Protocol:  protocol,
+			Name:      name,
+			From:      from,
+			To:        to,
+		},
+	}
+
+	return req, nil
+}
+
+func decodeCreateExportJob(_ context.Context, r *http.Request) (interface{}, error) {
+	format, err := apiutil.ReadStringQuery(r, formatKey, defFormat)
+	if err != nil {
+		return nil, err
+	}
+
+	subtopic, err := apiutil.ReadStringQuery(r, subtopicKey, "")
+	if err != nil {
+		return nil, err
+	}
+
+	publisher, err := apiutil.ReadStringQuery(r, publisherKey, "")
+	if err != nil {
+		return nil, err
+	}
+
+	from, err := readTimeQuery(r, fromKey)
+	if err != nil {
+		return nil, err
+	}
+
+	to, err := readTimeQuery(r, toKey)
+	if err != nil {
+		return nil, err
+	}
+
+	req := createExportJobReq{
+		chanID: bone.GetValue(r, "chanID"),
+		token:  apiutil.ExtractBearerToken(r),
+		key:    apiutil.ExtractThingKey(r),
+		pageMeta: readers.PageMetadata{
+			Format:    format,
+			Subtopic:  subtopic,
+			Publisher: publisher,
+			From:      from,
+			To:        to,
+		},
+	}
+
+	return req, nil
+}
+
+func decodeViewExportJob(_ context.Context, r *http.Request) (interface{}, error) {
+	req := viewExportJobReq{
+		chanID: bone.GetValue(r, "chanID"),
+		id:     bone.GetValue(r, "jobID"),
+		token:  apiutil.ExtractBearerToken(r),
+		key:    apiutil.ExtractThingKey(r),
+	}
+
+	return req, nil
+}
+
+func decodeRotateKey(_ context.Context, r *http.Request) (interface{}, error) {
+	req := rotateKeyReq{
+		token:  apiutil.ExtractBearerToken(r),
+		chanID: bone.GetValue(r, "chanID"),
+	}
+
+	return req, nil
+}
+
+// readValueFilters reads the bool value filter and the value filters which
+// can't be read with defaults, because their zero values are valid filters.
+// Parameters bool_value and data_value are aliases of vb and vd.
+func readValueFilters(r *http.Request, pm *readers.PageMetadata) error {
+	for _, key := range []string{boolValueKey, boolValueAlias} {
+		if len(bone.GetQuery(r, key)) == 0 {
+			continue
+		}
+		vb, err := apiutil.ReadBoolQuery(r, key, false)
+		if err != nil {
+			return err
+		}
+		pm.BoolValue = vb
+	}
+
+	if pm.DataValue == "" {
+		vd, err := apiutil.ReadStringQuery(r, dataValueAlias, "")
+		if err != nil {
+			return err
+		}
+		pm.DataValue = vd
+	}
+
+	like, err := apiutil.ReadStringQuery(r, stringLikeKey, "")
+	if err != nil {
+		return err
+	}
+	pm.StringValueLike = like
+
+	if pm.ValueGt, err = readFloatFilter(r, valueGtKey); err != nil {
+		return err
+	}
+
+	if pm.ValueLt, err = readFloatFilter(r, valueLtKey); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func readFloatFilter(r *http.Request, key string) (*float64, error) {
+	if len(bone.GetQuery(r, key)) == 0 {
+		return nil, nil
+	}
+
+	val, err := apiutil.ReadFloatQuery(r, key, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	return &val, nil
+}
+
+// readTimeQuery reads the time filter given either as the Unix time in
+// seconds or as the RFC3339 timestamp, and returns it as the Unix time in
+// seconds. Zero is returned if the filter is not provided.
+func readTimeQuery(r *http.Request, key string) (float64, error) {
+	vals := bone.GetQuery(r, key)
+	if len(vals) > 1 {
+		return 0, apiutil.ErrInvalidQueryParams
+	}
+
+	if len(vals) == 0 {
+		return 0, nil
+	}
+
+	if val, err := strconv.ParseFloat(vals[0], 64); err == nil {
+		return val, nil
+	}
+
+	t, err := time.Parse(time.RFC3339Nano, vals[0])
+	if err != nil {
+		return 0, apiutil.ErrInvalidQueryParams
+	}
+
+	return float64(t.UnixNano()) / float64(time.Second), nil
+}
+
+// readExportType returns the columnar media type of the exported messages
+// accepted by the client, or an empty string if the messages are listed as
+// JSON.
+func readExportType(r *http.Request) string {
+	accept := r.Header.Get("Accept")
+	switch {
+	case strings.Contains(accept, columnar.ParquetContentType):
+		return columnar.ParquetContentType
+	case strings.Contains(accept, columnar.ArrowContentType):
+		return columnar.ArrowContentType
+	default:
+		return ""
+	}
+}
+
+// readListLimit reads the limit of the listed messages. Unless limited, the
+// exported messages are limited by the maximum export size.
+func readListLimit(r *http.Request, export string) (uint64, error) {
+	if export == "" {
+		return apiutil.ReadLimitQuery(r, limitKey, defLimit)
+	}
+
+	limit, err := apiutil.ReadLimitQuery(r, limitKey, maxExportSize)
+	if err != nil {
+		return 0, err
+	}
+	if limit == 0 {
+		limit = maxExportSize
+	}
+
+	return limit, nil
+}
+
+// readFields reads the comma separated list of the message fields to be retrieved.
+func readFields(r *http.Request) []string {
+	var fields []string
+	for _, f := range bone.GetQuery(r, fieldsKey) {
+		if f = strings.TrimSpace(f); f != "" {
+			fields = append(fields, f)
+		}
+	}
+
+	return fields
+}
+
 func encodeResponse(_ context.Context, w http.ResponseWriter, response interface{}) error {
 	w.Header().Set("Content-Type", contentType)
 
@@ -304,10 +672,14 @@ func encodeResponse(_ context.Context, w http.ResponseWriter, response interface
 		}
 	}
 
+	if res, ok := response.(exportMessagesRes); ok {
+		return res.encode(w)
+	}
+
 	return json.NewEncoder(w).Encode(response)
 }
 
-func encodeError(_ context.Context, err error, w http.ResponseWriter) {
+func encodeError(ctx context.Context, err error, w http.ResponseWriter) {
 	switch {
 	case errors.Contains(err, nil):
 	case errors.Contains(err, apiutil.ErrInvalidQueryParams),
@@ -316,7 +688,8 @@ func encodeError(_ context.Context, err error, w http.ResponseWriter) {
 		err == apiutil.ErrLimitSize,
 		err == apiutil.ErrOffsetSize,
 		err == apiutil.ErrEmptyList,
-		err == apiutil.ErrInvalidComparator:
+		err == apiutil.ErrInvalidComparator,
+		errors.Contains(err, readers.ErrAggregationNotSupported):
 		w.WriteHeader(http.StatusBadRequest)
 	case errors.Contains(err, errors.ErrAuthentication),
 		err == apiutil.ErrBearerToken:
@@ -341,7 +714,7 @@ func encodeError(_ context.Context, err error, w http.ResponseWriter) {
 
 	if errorVal, ok := err.(errors.Error); ok {
 		w.Header().Set("Content-Type", contentType)
-		if err := json.NewEncoder(w).Encode(apiutil.ErrorRes{Err: errorVal.Msg()}); err != nil {
+		if err := json.NewEncoder(w).Encode(apiutil.NewErrorRes(ctx, errorVal)); err != nil {
 			w.WriteHeader(http.StatusInternalServerError)
 		}
 	}
@@ -359,7 +732,12 @@ func authorize(ctx context.Context, token, key, chanID string) (err error) {
 			return nil
 		}
 
-		if _, err = thingc.IsChannelOwner(ctx, &mainflux.ChannelOwnerReq{Owner: user.Id, ChanID: chanID}); err != nil {
+		if _, err = thingc.IsChannelOwner(ctx, &mainflux.ChannelOwnerReq{Owner: user.Id, ChanID: chanID}); err == nil {
+			return nil
+		}
+
+		req := &mainflux.AuthorizeReq{Token: token, Subject: auth.ChannelSubject, Object: chanID, Action: auth.ReadAction}
+		if _, aerr := authc.Authorize(ctx, req); aerr != nil {
 			return err
 		}
 		return nil
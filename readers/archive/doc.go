@@ -0,0 +1,6 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package archive contains repository implementation reading SenML messages
+// archived to the object storage by the archiver.
+package archive
@@ -0,0 +1,68 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package archive
+
+import (
+	"context"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/pkg/transformers/senml"
+	"github.com/MainfluxLabs/mainflux/readers"
+)
+
+var _ readers.MessageRepository = (*fallbackRepository)(nil)
+
+type fallbackRepository struct {
+	hot       readers.MessageRepository
+	archive   readers.MessageRepository
+	retention time.Duration
+}
+
+// NewFallback returns the repository reading messages from the hot repository,
+// unless the requested time range ends before the retention of the hot
+// repository, in which case the messages are read from the archive.
+func NewFallback(hot, archive readers.MessageRepository, retention time.Duration) readers.MessageRepository {
+	return fallbackRepository{
+		hot:       hot,
+		archive:   archive,
+		retention: retention,
+	}
+}
+
+func (fr fallbackRepository) ListChannelMessages(chanID string, rpm readers.PageMetadata) (readers.MessagesPage, error) {
+	if fr.archived(rpm) {
+		return fr.archive.ListChannelMessages(chanID, rpm)
+	}
+
+	return fr.hot.ListChannelMessages(chanID, rpm)
+}
+
+func (fr fallbackRepository) CountChannelMessages(chanID string, rpm readers.PageMetadata) (uint64, error) {
+	if fr.archived(rpm) {
+		return fr.archive.CountChannelMessages(chanID, rpm)
+	}
+
+	return fr.hot.CountChannelMessages(chanID, rpm)
+}
+
+func (fr fallbackRepository) ListAllMessages(rpm readers.PageMetadata) (readers.MessagesPage, error) {
+	if fr.archived(rpm) {
+		return fr.archive.ListAllMessages(rpm)
+	}
+
+	return fr.hot.ListAllMessages(rpm)
+}
+
+func (fr fallbackRepository) Restore(ctx context.Context, messages ...senml.Message) error {
+	return fr.hot.Restore(ctx, messages...)
+}
+
+func (fr fallbackRepository) archived(rpm readers.PageMetadata) bool {
+	if rpm.To == 0 {
+		return false
+	}
+	expiry := time.Now().Add(-fr.retention)
+
+	return rpm.To <= float64(expiry.UnixNano())/float64(time.Second)
+}
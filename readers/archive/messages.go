@@ -0,0 +1,240 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package archive
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/pkg/archive"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/transformers/senml"
+	"github.com/MainfluxLabs/mainflux/readers"
+)
+
+const (
+	defFormat = "messages"
+	noLimit   = 0
+)
+
+var errRestoreNotSupported = errors.New("restoring archived messages is not supported")
+
+var _ readers.MessageRepository = (*archiveRepository)(nil)
+
+type archiveRepository struct {
+	storage archive.Storage
+	prefix  string
+}
+
+// New returns new reader of SenML messages archived to the object storage.
+func New(storage archive.Storage, prefix string) readers.MessageRepository {
+	return archiveRepository{
+		storage: storage,
+		prefix:  prefix,
+	}
+}
+
+func (ar archiveRepository) ListChannelMessages(chanID string, rpm readers.PageMetadata) (readers.MessagesPage, error) {
+	return ar.readAll(chanID, rpm)
+}
+
+func (ar archiveRepository) ListAllMessages(rpm readers.PageMetadata) (readers.MessagesPage, error) {
+	return ar.readAll("", rpm)
+}
+
+// CountChannelMessages counts the archived messages of the channel. The
+// archive has no index, so the matching objects are decoded, but the
+// messages are not retained.
+func (ar archiveRepository) CountChannelMessages(chanID string, rpm readers.PageMetadata) (uint64, error) {
+	if rpm.Format != "" && rpm.Format != defFormat {
+		return 0, nil
+	}
+
+	msgs, err := ar.scan(chanID, rpm)
+	if err != nil {
+		return 0, err
+	}
+
+	return uint64(len(msgs)), nil
+}
+
+func (ar archiveRepository) Restore(ctx context.Context, messages ...senml.Message) error {
+	return errRestoreNotSupported
+}
+
+func (ar archiveRepository) readAll(chanID string, rpm readers.PageMetadata) (readers.MessagesPage, error) {
+	if rpm.Aggregation != "" {
+		return readers.MessagesPage{}, readers.ErrAggregationNotSupported
+	}
+
+	page := readers.MessagesPage{
+		PageMetadata: rpm,
+		Messages:     []readers.Message{},
+	}
+	// Only SenML messages are archived.
+	if rpm.Format != "" && rpm.Format != defFormat {
+		return page, nil
+	}
+
+	msgs, err := ar.scan(chanID, rpm)
+	if err != nil {
+		return readers.MessagesPage{}, err
+	}
+
+	sort.SliceStable(msgs, func(i, j int) bool {
+		return msgs[i].Time > msgs[j].Time
+	})
+
+	page.Total = uint64(len(msgs))
+	if rpm.Offset >= page.Total {
+		return page, nil
+	}
+	end := rpm.Offset + rpm.Limit
+	if rpm.Limit == noLimit || end > page.Total {
+		end = page.Total
+	}
+
+	for _, msg := range msgs[rpm.Offset:end] {
+		page.Messages = append(page.Messages, project(msg, rpm.Fields))
+	}
+
+	return page, nil
+}
+
+// scan returns the archived messages of the channel matching the page metadata.
+func (ar archiveRepository) scan(chanID string, rpm readers.PageMetadata) ([]senml.Message, error) {
+	prefix := ar.prefix
+	if chanID != "" {
+		prefix = ar.prefix + chanID + "/"
+	}
+
+	ctx := context.Background()
+	keys, err := ar.storage.List(ctx, prefix)
+	if err != nil {
+		return nil, errors.Wrap(readers.ErrReadMessages, err)
+	}
+
+	var msgs []senml.Message
+	for _, key := range keys {
+		p, ok := archive.ParseKey(ar.prefix, key)
+		if !ok || (chanID != "" && p.Channel != chanID) || !inRange(p.Day, rpm) {
+			continue
+		}
+
+		data, err := ar.storage.Get(ctx, key)
+		if err != nil {
+			return nil, errors.Wrap(readers.ErrReadMessages, err)
+		}
+		archived, err := archive.Decode(data)
+		if err != nil {
+			return nil, errors.Wrap(readers.ErrReadMessages, err)
+		}
+
+		for _, msg := range archived {
+			if match(msg, rpm) {
+				msgs = append(msgs, msg)
+			}
+		}
+	}
+
+	return msgs, nil
+}
+
+// inRange reports whether the day partition may contain the messages
+// published in the requested time range.
+func inRange(day string, rpm readers.PageMetadata) bool {
+	start, err := time.Parse(archive.DayLayout, day)
+	if err != nil {
+		return false
+	}
+	end := start.AddDate(0, 0, 1)
+
+	if rpm.From != 0 && float64(end.Unix()) <= rpm.From {
+		return false
+	}
+	if rpm.To != 0 && float64(start.Unix()) >= rpm.To {
+		return false
+	}
+
+	return true
+}
+
+func match(msg senml.Message, rpm readers.PageMetadata) bool {
+	switch {
+	case rpm.Subtopic != "" && msg.Subtopic != rpm.Subtopic,
+		rpm.Publisher != "" && msg.Publisher != rpm.Publisher,
+		rpm.Protocol != "" && msg.Protocol != rpm.Protocol,
+		rpm.Name != "" && msg.Name != rpm.Name,
+		rpm.From != 0 && msg.Time < rpm.From,
+		rpm.To != 0 && msg.Time >= rpm.To:
+		return false
+	}
+
+	if rpm.BoolValue && (msg.BoolValue == nil || !*msg.BoolValue) {
+		return false
+	}
+	if rpm.StringValue != "" && (msg.StringValue == nil || *msg.StringValue != rpm.StringValue) {
+		return false
+	}
+	if rpm.StringValueLike != "" && (msg.StringValue == nil || !strings.Contains(*msg.StringValue, rpm.StringValueLike)) {
+		return false
+	}
+	if rpm.DataValue != "" && (msg.DataValue == nil || *msg.DataValue != rpm.DataValue) {
+		return false
+	}
+	if rpm.ValueGt != nil && (msg.Value == nil || *msg.Value <= *rpm.ValueGt) {
+		return false
+	}
+	if rpm.ValueLt != nil && (msg.Value == nil || *msg.Value >= *rpm.ValueLt) {
+		return false
+	}
+	if rpm.Value != 0 {
+		return msg.Value != nil && compare(*msg.Value, rpm.Value, rpm.Comparator)
+	}
+
+	return true
+}
+
+func compare(value, query float64, comparator string) bool {
+	switch comparator {
+	case readers.LowerThanKey:
+		return value < query
+	case readers.LowerThanEqualKey:
+		return value <= query
+	case readers.GreaterThanKey:
+		return value > query
+	case readers.GreaterThanEqualKey:
+		return value >= query
+	default:
+		return value == query
+	}
+}
+
+// project returns the message containing only the given fields.
+func project(msg senml.Message, fields []string) readers.Message {
+	if len(fields) == 0 {
+		return msg
+	}
+
+	var all map[string]interface{}
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return msg
+	}
+	if err := json.Unmarshal(data, &all); err != nil {
+		return msg
+	}
+
+	projected := map[string]interface{}{}
+	for _, f := range fields {
+		if v, ok := all[f]; ok {
+			projected[f] = v
+		}
+	}
+
+	return projected
+}
@@ -0,0 +1,153 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package archive_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/pkg/archive"
+	"github.com/MainfluxLabs/mainflux/pkg/archive/mocks"
+	"github.com/MainfluxLabs/mainflux/pkg/transformers/senml"
+	"github.com/MainfluxLabs/mainflux/readers"
+	rarchive "github.com/MainfluxLabs/mainflux/readers/archive"
+	rmocks "github.com/MainfluxLabs/mainflux/readers/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	prefix  = "messages/"
+	chanID  = "50e6b371-60ff-45cf-bb52-8200e7cde536"
+	chanID2 = "9ad8cd1a-3cba-4a1b-a1f4-2f7c1ab2b8ae"
+	msgName = "temperature"
+	// day is 2022-01-01 UTC.
+	day = 1640995200
+)
+
+func archiveMessages(t *testing.T, storage archive.Storage, msgs []senml.Message) {
+	batches := map[archive.Partition][]senml.Message{}
+	for _, msg := range msgs {
+		p := archive.PartitionOf(msg)
+		batches[p] = append(batches[p], msg)
+	}
+
+	for p, batch := range batches {
+		data, err := archive.Encode(batch)
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+		err = storage.Put(context.Background(), p.Key(prefix, time.Now()), data)
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	}
+}
+
+func TestListChannelMessages(t *testing.T) {
+	storage := mocks.NewStorage()
+	repo := rarchive.New(storage, prefix)
+
+	// Messages of two days, ordered from the newest.
+	var msgs []senml.Message
+	for i := 47; i >= 0; i-- {
+		v := float64(i)
+		msgs = append(msgs, senml.Message{Channel: chanID, Name: msgName, Value: &v, Time: float64(day + i*3600)})
+	}
+	v := 100.0
+	archiveMessages(t, storage, append(msgs, senml.Message{Channel: chanID2, Name: msgName, Value: &v, Time: day}))
+
+	vgt := 40.0
+	cases := map[string]struct {
+		chanID   string
+		pageMeta readers.PageMetadata
+		page     readers.MessagesPage
+		err      error
+	}{
+		"read channel messages": {
+			chanID:   chanID,
+			pageMeta: readers.PageMetadata{Limit: 10},
+			page:     readers.MessagesPage{Total: 48, Messages: toMessages(msgs[0:10])},
+		},
+		"read channel messages with offset": {
+			chanID:   chanID,
+			pageMeta: readers.PageMetadata{Offset: 40, Limit: 10},
+			page:     readers.MessagesPage{Total: 48, Messages: toMessages(msgs[40:48])},
+		},
+		"read channel messages of the first day": {
+			chanID:   chanID,
+			pageMeta: readers.PageMetadata{Limit: 30, From: day, To: day + 86400},
+			page:     readers.MessagesPage{Total: 24, Messages: toMessages(msgs[24:48])},
+		},
+		"read channel messages with value filter": {
+			chanID:   chanID,
+			pageMeta: readers.PageMetadata{Limit: 10, ValueGt: &vgt},
+			page:     readers.MessagesPage{Total: 7, Messages: toMessages(msgs[0:7])},
+		},
+		"read channel messages with wrong name": {
+			chanID:   chanID,
+			pageMeta: readers.PageMetadata{Limit: 10, Name: "humidity"},
+			page:     readers.MessagesPage{Total: 0, Messages: []readers.Message{}},
+		},
+		"read JSON messages": {
+			chanID:   chanID,
+			pageMeta: readers.PageMetadata{Limit: 10, Format: "format"},
+			page:     readers.MessagesPage{Total: 0, Messages: []readers.Message{}},
+		},
+		"read aggregated messages": {
+			chanID:   chanID,
+			pageMeta: readers.PageMetadata{Limit: 10, Aggregation: readers.AvgAggregation, Interval: "1h"},
+			err:      readers.ErrAggregationNotSupported,
+		},
+	}
+
+	for desc, tc := range cases {
+		page, err := repo.ListChannelMessages(tc.chanID, tc.pageMeta)
+		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected error %s got %s\n", desc, tc.err, err))
+		assert.Equal(t, tc.page.Total, page.Total, fmt.Sprintf("%s: expected total %d got %d\n", desc, tc.page.Total, page.Total))
+		assert.Equal(t, tc.page.Messages, page.Messages, fmt.Sprintf("%s: expected %v got %v\n", desc, tc.page.Messages, page.Messages))
+	}
+
+	page, err := repo.ListAllMessages(readers.PageMetadata{Limit: 100})
+	assert.Nil(t, err, fmt.Sprintf("read all messages: expected no error got %s", err))
+	assert.Equal(t, uint64(49), page.Total, fmt.Sprintf("read all messages: expected total 49 got %d", page.Total))
+}
+
+func TestFallback(t *testing.T) {
+	storage := mocks.NewStorage()
+	v := 21.0
+	archived := senml.Message{Channel: chanID, Name: msgName, Value: &v, Time: day}
+	archiveMessages(t, storage, []senml.Message{archived})
+
+	now := float64(time.Now().Unix())
+	hotMsg := senml.Message{Channel: chanID, Name: msgName, Value: &v, Time: now}
+	hot := rmocks.NewMessageRepository(chanID, []readers.Message{hotMsg})
+	repo := rarchive.NewFallback(hot, rarchive.New(storage, prefix), 24*time.Hour)
+
+	cases := map[string]struct {
+		pageMeta readers.PageMetadata
+		messages []readers.Message
+	}{
+		"read recent messages from hot repository": {
+			pageMeta: readers.PageMetadata{Limit: 10},
+			messages: []readers.Message{hotMsg},
+		},
+		"read expired messages from archive": {
+			pageMeta: readers.PageMetadata{Limit: 10, From: day, To: day + 86400},
+			messages: []readers.Message{archived},
+		},
+	}
+
+	for desc, tc := range cases {
+		page, err := repo.ListChannelMessages(chanID, tc.pageMeta)
+		assert.Nil(t, err, fmt.Sprintf("%s: expected no error got %s\n", desc, err))
+		assert.Equal(t, tc.messages, page.Messages, fmt.Sprintf("%s: expected %v got %v\n", desc, tc.messages, page.Messages))
+	}
+}
+
+func toMessages(msgs []senml.Message) []readers.Message {
+	var res []readers.Message
+	for _, m := range msgs {
+		res = append(res, m)
+	}
+	return res
+}
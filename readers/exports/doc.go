@@ -0,0 +1,6 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package exports contains the service exporting the channel messages to
+// the object storage in the background.
+package exports
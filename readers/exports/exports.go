@@ -0,0 +1,91 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package exports
+
+import (
+	"context"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/pkg/archive"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/readers"
+)
+
+const (
+	// StatusPending is the status of the job which hasn't started yet.
+	StatusPending = "pending"
+
+	// StatusRunning is the status of the job exporting the messages.
+	StatusRunning = "running"
+
+	// StatusCompleted is the status of the job whose export is downloadable.
+	StatusCompleted = "completed"
+
+	// StatusFailed is the status of the job which failed to export the messages.
+	StatusFailed = "failed"
+)
+
+var (
+	// ErrExport indicates failure to export the messages.
+	ErrExport = errors.New("failed to export messages")
+
+	// ErrRetrieveJob indicates failure to retrieve the export job.
+	ErrRetrieveJob = errors.New("failed to retrieve export job")
+)
+
+// Job represents the export of the channel messages, published in the
+// time range, to the gzip compressed JSON lines object.
+type Job struct {
+	ID        string    `json:"id"`
+	ChannelID string    `json:"channel_id"`
+	Status    string    `json:"status"`
+	Format    string    `json:"format,omitempty"`
+	Subtopic  string    `json:"subtopic,omitempty"`
+	Publisher string    `json:"publisher,omitempty"`
+	From      float64   `json:"from,omitempty"`
+	To        float64   `json:"to"`
+	Total     uint64    `json:"total"`
+	Exported  uint64    `json:"exported"`
+	Error     string    `json:"error,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	// Key is the key of the exported object, uploaded in parts. The job
+	// is resumed after the last uploaded part.
+	Key      string         `json:"key"`
+	UploadID string         `json:"upload_id,omitempty"`
+	Parts    []archive.Part `json:"parts,omitempty"`
+
+	// URL is the signed download URL of the completed export.
+	URL string `json:"-"`
+}
+
+// Config defines the export jobs.
+type Config struct {
+	// Prefix is the key prefix of the exported objects and job states.
+	Prefix string
+
+	// Rate is the maximum number of messages exported per second by each
+	// job, so that the exports don't overload the database. Unlimited if zero.
+	Rate uint64
+
+	// URLTTL is the validity of the download URL of the completed export.
+	URLTTL time.Duration
+}
+
+// Service specifies an API for exporting the channel messages in the background.
+type Service interface {
+	// CreateJob creates the job exporting the channel messages matching the
+	// page metadata and starts it in the background. The end of the time
+	// range defaults to the job creation time.
+	CreateJob(ctx context.Context, chanID string, pm readers.PageMetadata) (Job, error)
+
+	// ViewJob retrieves the export job of the channel. The signed download
+	// URL is set if the job is completed.
+	ViewJob(ctx context.Context, chanID, id string) (Job, error)
+
+	// Resume restarts the pending and running jobs, e.g. the ones interrupted
+	// by the restart of the service.
+	Resume(ctx context.Context) error
+}
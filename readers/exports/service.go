@@ -0,0 +1,279 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package exports
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux"
+	"github.com/MainfluxLabs/mainflux/logger"
+	"github.com/MainfluxLabs/mainflux/pkg/archive"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/readers"
+)
+
+const (
+	// pageSize is the number of messages retrieved from the repository at once.
+	pageSize = 1000
+
+	// partSize is the minimal size of the uploaded part other than the last
+	// one, required by the S3 multipart uploads.
+	partSize = 5 * 1024 * 1024
+
+	jobsDir   = "jobs/"
+	extension = ".ndjson.gz"
+)
+
+var _ Service = (*exportService)(nil)
+
+type exportService struct {
+	repo       readers.MessageRepository
+	storage    archive.MultipartStorage
+	idProvider mainflux.IDProvider
+	cfg        Config
+	logger     logger.Logger
+}
+
+// New instantiates the service exporting the messages of the repository
+// to the object storage. Job states are kept in the storage as well, so
+// they are available to all the instances of the service.
+func New(repo readers.MessageRepository, storage archive.MultipartStorage, idp mainflux.IDProvider, cfg Config, logger logger.Logger) Service {
+	return &exportService{
+		repo:       repo,
+		storage:    storage,
+		idProvider: idp,
+		cfg:        cfg,
+		logger:     logger,
+	}
+}
+
+func (es *exportService) CreateJob(ctx context.Context, chanID string, pm readers.PageMetadata) (Job, error) {
+	id, err := es.idProvider.ID()
+	if err != nil {
+		return Job{}, err
+	}
+
+	now := time.Now().UTC()
+	to := pm.To
+	if to == 0 {
+		to = float64(now.UnixNano()) / float64(time.Second)
+	}
+
+	job := Job{
+		ID:        id,
+		ChannelID: chanID,
+		Status:    StatusPending,
+		Format:    pm.Format,
+		Subtopic:  pm.Subtopic,
+		Publisher: pm.Publisher,
+		From:      pm.From,
+		To:        to,
+		CreatedAt: now,
+		UpdatedAt: now,
+		Key:       fmt.Sprintf("%s%s/%s%s", es.cfg.Prefix, chanID, id, extension),
+	}
+	if err := es.save(ctx, job); err != nil {
+		return Job{}, err
+	}
+
+	go es.run(job)
+
+	return job, nil
+}
+
+func (es *exportService) ViewJob(ctx context.Context, chanID, id string) (Job, error) {
+	job, err := es.retrieve(ctx, es.jobKey(id))
+	if err != nil {
+		return Job{}, err
+	}
+
+	// Jobs of other channels are concealed.
+	if job.ChannelID != chanID {
+		return Job{}, errors.ErrNotFound
+	}
+
+	if job.Status == StatusCompleted {
+		if job.URL, err = es.storage.SignURL(job.Key, es.cfg.URLTTL); err != nil {
+			return Job{}, errors.Wrap(ErrRetrieveJob, err)
+		}
+	}
+
+	return job, nil
+}
+
+func (es *exportService) Resume(ctx context.Context) error {
+	keys, err := es.storage.List(ctx, es.cfg.Prefix+jobsDir)
+	if err != nil {
+		return errors.Wrap(ErrRetrieveJob, err)
+	}
+
+	for _, key := range keys {
+		job, err := es.retrieve(ctx, key)
+		if err != nil {
+			return err
+		}
+
+		if job.Status == StatusPending || job.Status == StatusRunning {
+			es.logger.Info(fmt.Sprintf("Resuming export job %s after %d exported messages", job.ID, job.Exported))
+			go es.run(job)
+		}
+	}
+
+	return nil
+}
+
+// run runs the job, which outlives the request creating it.
+func (es *exportService) run(job Job) {
+	ctx := context.Background()
+
+	if err := es.export(ctx, &job); err != nil {
+		es.logger.Warn(fmt.Sprintf("Export job %s failed: %s", job.ID, err))
+		job.Status = StatusFailed
+		job.Error = err.Error()
+		job.UpdatedAt = time.Now().UTC()
+	}
+
+	if err := es.save(ctx, job); err != nil {
+		es.logger.Error(fmt.Sprintf("Failed to save export job %s: %s", job.ID, err))
+	}
+}
+
+// export uploads the messages as the parts of the exported object. Each part
+// is a complete gzip member, and the job is saved after each uploaded part,
+// so the interrupted job continues with the next part.
+func (es *exportService) export(ctx context.Context, job *Job) error {
+	if job.UploadID == "" {
+		uploadID, err := es.storage.CreateUpload(ctx, job.Key)
+		if err != nil {
+			return errors.Wrap(ErrExport, err)
+		}
+		job.UploadID = uploadID
+	}
+
+	job.Status = StatusRunning
+	job.UpdatedAt = time.Now().UTC()
+	if err := es.save(ctx, *job); err != nil {
+		return err
+	}
+
+	pm := readers.PageMetadata{
+		Offset:    job.Exported,
+		Limit:     pageSize,
+		Format:    job.Format,
+		Subtopic:  job.Subtopic,
+		Publisher: job.Publisher,
+		From:      job.From,
+		To:        job.To,
+	}
+
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	enc := json.NewEncoder(zw)
+	for {
+		started := time.Now()
+		page, err := es.repo.ListChannelMessages(job.ChannelID, pm)
+		if err != nil {
+			return errors.Wrap(ErrExport, err)
+		}
+
+		for _, msg := range page.Messages {
+			if err := enc.Encode(msg); err != nil {
+				return errors.Wrap(ErrExport, err)
+			}
+		}
+		pm.Offset += uint64(len(page.Messages))
+
+		last := len(page.Messages) < pageSize || pm.Offset >= page.Total
+		if last || buf.Len() >= partSize {
+			if err := zw.Close(); err != nil {
+				return errors.Wrap(ErrExport, err)
+			}
+
+			part, err := es.storage.UploadPart(ctx, job.Key, job.UploadID, len(job.Parts)+1, buf.Bytes())
+			if err != nil {
+				return errors.Wrap(ErrExport, err)
+			}
+
+			job.Parts = append(job.Parts, part)
+			job.Exported = pm.Offset
+			job.Total = page.Total
+			job.UpdatedAt = time.Now().UTC()
+			if err := es.save(ctx, *job); err != nil {
+				return err
+			}
+
+			buf.Reset()
+			zw.Reset(&buf)
+		}
+
+		if last {
+			break
+		}
+
+		es.throttle(started, len(page.Messages))
+	}
+
+	if err := es.storage.CompleteUpload(ctx, job.Key, job.UploadID, job.Parts); err != nil {
+		return errors.Wrap(ErrExport, err)
+	}
+
+	job.Status = StatusCompleted
+	job.UpdatedAt = time.Now().UTC()
+
+	return nil
+}
+
+// throttle waits until the messages read since the start are within the rate.
+func (es *exportService) throttle(started time.Time, n int) {
+	if es.cfg.Rate == 0 {
+		return
+	}
+
+	wait := time.Duration(n)*time.Second/time.Duration(es.cfg.Rate) - time.Since(started)
+	if wait > 0 {
+		time.Sleep(wait)
+	}
+}
+
+func (es *exportService) save(ctx context.Context, job Job) error {
+	data, err := json.Marshal(job)
+	if err != nil {
+		return errors.Wrap(ErrExport, err)
+	}
+
+	if err := es.storage.Put(ctx, es.jobKey(job.ID), data); err != nil {
+		return errors.Wrap(ErrExport, err)
+	}
+
+	return nil
+}
+
+func (es *exportService) retrieve(ctx context.Context, key string) (Job, error) {
+	data, err := es.storage.Get(ctx, key)
+	if err != nil {
+		if err == archive.ErrNotFound {
+			return Job{}, errors.ErrNotFound
+		}
+		return Job{}, errors.Wrap(ErrRetrieveJob, err)
+	}
+
+	var job Job
+	if err := json.Unmarshal(data, &job); err != nil {
+		return Job{}, errors.Wrap(ErrRetrieveJob, err)
+	}
+
+	return job, nil
+}
+
+func (es *exportService) jobKey(id string) string {
+	// Job IDs are generated, but may be passed by the clients as well.
+	id = strings.ReplaceAll(id, "/", "")
+	return fmt.Sprintf("%s%s%s.json", es.cfg.Prefix, jobsDir, id)
+}
@@ -0,0 +1,207 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package exports_test
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/logger"
+	"github.com/MainfluxLabs/mainflux/pkg/archive"
+	amocks "github.com/MainfluxLabs/mainflux/pkg/archive/mocks"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/transformers/senml"
+	"github.com/MainfluxLabs/mainflux/pkg/uuid"
+	"github.com/MainfluxLabs/mainflux/readers"
+	"github.com/MainfluxLabs/mainflux/readers/exports"
+	"github.com/MainfluxLabs/mainflux/readers/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	chanID   = "1"
+	prefix   = "exports/"
+	msgsNum  = 2500
+	waitTime = 5 * time.Second
+)
+
+func newMessages() []readers.Message {
+	var msgs []readers.Message
+	for i := 0; i < msgsNum; i++ {
+		v := float64(i)
+		msgs = append(msgs, senml.Message{
+			Channel:   chanID,
+			Publisher: "publisher",
+			Protocol:  "mqtt",
+			Name:      "temperature",
+			Value:     &v,
+			Time:      float64(i + 1),
+		})
+	}
+
+	return msgs
+}
+
+func newService(storage archive.MultipartStorage) exports.Service {
+	repo := mocks.NewMessageRepository(chanID, newMessages())
+	cfg := exports.Config{
+		Prefix: prefix,
+		URLTTL: time.Hour,
+	}
+
+	return exports.New(repo, storage, uuid.NewMock(), cfg, logger.NewMock())
+}
+
+func waitForJob(t *testing.T, svc exports.Service, id string) exports.Job {
+	deadline := time.Now().Add(waitTime)
+	for time.Now().Before(deadline) {
+		job, err := svc.ViewJob(context.Background(), chanID, id)
+		require.Nil(t, err, fmt.Sprintf("view job: unexpected error: %s", err))
+		if job.Status == exports.StatusCompleted || job.Status == exports.StatusFailed {
+			return job
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	require.FailNow(t, fmt.Sprintf("job %s not finished in %s", id, waitTime))
+
+	return exports.Job{}
+}
+
+func decodeExport(t *testing.T, data []byte) []senml.Message {
+	zr, err := gzip.NewReader(bytes.NewReader(data))
+	require.Nil(t, err, fmt.Sprintf("decode export: unexpected error: %s", err))
+	defer zr.Close()
+
+	var msgs []senml.Message
+	sc := bufio.NewScanner(zr)
+	for sc.Scan() {
+		var msg senml.Message
+		require.Nil(t, json.Unmarshal(sc.Bytes(), &msg), "decode exported message")
+		msgs = append(msgs, msg)
+	}
+	require.Nil(t, sc.Err(), fmt.Sprintf("decode export: unexpected error: %s", sc.Err()))
+
+	return msgs
+}
+
+func encodePart(t *testing.T, msgs []readers.Message) []byte {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	enc := json.NewEncoder(zw)
+	for _, msg := range msgs {
+		require.Nil(t, enc.Encode(msg), "encode exported message")
+	}
+	require.Nil(t, zw.Close(), "close exported part")
+
+	return buf.Bytes()
+}
+
+func TestCreateJob(t *testing.T) {
+	storage := amocks.NewMultipartStorage()
+	svc := newService(storage)
+
+	job, err := svc.CreateJob(context.Background(), chanID, readers.PageMetadata{})
+	require.Nil(t, err, fmt.Sprintf("create job: unexpected error: %s", err))
+	assert.Equal(t, exports.StatusPending, job.Status, fmt.Sprintf("create job: expected status %s got %s", exports.StatusPending, job.Status))
+	assert.NotZero(t, job.To, "create job: expected the end of the time range to be set")
+
+	job = waitForJob(t, svc, job.ID)
+	assert.Equal(t, exports.StatusCompleted, job.Status, fmt.Sprintf("export job: expected status %s got %s: %s", exports.StatusCompleted, job.Status, job.Error))
+	assert.Equal(t, uint64(msgsNum), job.Exported, fmt.Sprintf("export job: expected %d exported messages got %d", msgsNum, job.Exported))
+	assert.Equal(t, uint64(msgsNum), job.Total, fmt.Sprintf("export job: expected %d total messages got %d", msgsNum, job.Total))
+	assert.Equal(t, amocks.URLPrefix+job.Key, job.URL, fmt.Sprintf("export job: expected URL %s got %s", amocks.URLPrefix+job.Key, job.URL))
+
+	data, err := storage.Get(context.Background(), job.Key)
+	require.Nil(t, err, fmt.Sprintf("get export: unexpected error: %s", err))
+	msgs := decodeExport(t, data)
+	assert.Len(t, msgs, msgsNum, fmt.Sprintf("export job: expected %d exported messages got %d", msgsNum, len(msgs)))
+}
+
+func TestViewJob(t *testing.T) {
+	svc := newService(amocks.NewMultipartStorage())
+
+	job, err := svc.CreateJob(context.Background(), chanID, readers.PageMetadata{})
+	require.Nil(t, err, fmt.Sprintf("create job: unexpected error: %s", err))
+	waitForJob(t, svc, job.ID)
+
+	cases := []struct {
+		desc   string
+		chanID string
+		id     string
+		err    error
+	}{
+		{
+			desc:   "view job",
+			chanID: chanID,
+			id:     job.ID,
+			err:    nil,
+		},
+		{
+			desc:   "view job of other channel",
+			chanID: "2",
+			id:     job.ID,
+			err:    errors.ErrNotFound,
+		},
+		{
+			desc:   "view non-existent job",
+			chanID: chanID,
+			id:     "non-existent",
+			err:    errors.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		_, err := svc.ViewJob(context.Background(), tc.chanID, tc.id)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.err, err))
+	}
+}
+
+func TestResume(t *testing.T) {
+	storage := amocks.NewMultipartStorage()
+	svc := newService(storage)
+	msgs := newMessages()
+
+	// The interrupted job has uploaded the first page of the messages.
+	job := exports.Job{
+		ID:        "interrupted",
+		ChannelID: chanID,
+		Status:    exports.StatusRunning,
+		To:        msgsNum + 1,
+		Exported:  1000,
+		Key:       prefix + chanID + "/interrupted.ndjson.gz",
+	}
+	uploadID, err := storage.CreateUpload(context.Background(), job.Key)
+	require.Nil(t, err, fmt.Sprintf("create upload: unexpected error: %s", err))
+	part, err := storage.UploadPart(context.Background(), job.Key, uploadID, 1, encodePart(t, msgs[:job.Exported]))
+	require.Nil(t, err, fmt.Sprintf("upload part: unexpected error: %s", err))
+	job.UploadID = uploadID
+	job.Parts = []archive.Part{part}
+
+	data, err := json.Marshal(job)
+	require.Nil(t, err, fmt.Sprintf("encode job: unexpected error: %s", err))
+	err = storage.Put(context.Background(), prefix+"jobs/interrupted.json", data)
+	require.Nil(t, err, fmt.Sprintf("save job: unexpected error: %s", err))
+
+	err = svc.Resume(context.Background())
+	require.Nil(t, err, fmt.Sprintf("resume jobs: unexpected error: %s", err))
+
+	job = waitForJob(t, svc, job.ID)
+	assert.Equal(t, exports.StatusCompleted, job.Status, fmt.Sprintf("resume job: expected status %s got %s: %s", exports.StatusCompleted, job.Status, job.Error))
+	assert.Len(t, job.Parts, 2, fmt.Sprintf("resume job: expected %d parts got %d", 2, len(job.Parts)))
+
+	data, err = storage.Get(context.Background(), job.Key)
+	require.Nil(t, err, fmt.Sprintf("get export: unexpected error: %s", err))
+	exported := decodeExport(t, data)
+	require.Len(t, exported, msgsNum, fmt.Sprintf("resume job: expected %d exported messages got %d", msgsNum, len(exported)))
+	for i, msg := range exported {
+		assert.Equal(t, msgs[i].(senml.Message).Time, msg.Time, fmt.Sprintf("resume job: expected message %d at %v got %v", i, msgs[i].(senml.Message).Time, msg.Time))
+	}
+}
@@ -0,0 +1,58 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package readers
+
+import (
+	"sort"
+
+	"github.com/MainfluxLabs/mainflux/pkg/transformers/senml"
+)
+
+// Gap represents the range of the sequence numbers missing from the
+// messages of the publisher, i.e. the messages which are lost or not yet
+// received due to reordering.
+type Gap struct {
+	From uint64 `json:"from"`
+	To   uint64 `json:"to"`
+}
+
+// Gaps returns the ranges of the sequence numbers missing between the lowest
+// and the highest sequence number of the messages, which are expected to be
+// of the same publisher. Messages without the sequence number are ignored.
+func Gaps(msgs []Message) []Gap {
+	var seqs []uint64
+	for _, msg := range msgs {
+		if seq := sequence(msg); seq > 0 {
+			seqs = append(seqs, seq)
+		}
+	}
+	sort.Slice(seqs, func(i, j int) bool { return seqs[i] < seqs[j] })
+
+	var gaps []Gap
+	for i := 1; i < len(seqs); i++ {
+		if seqs[i] > seqs[i-1]+1 {
+			gaps = append(gaps, Gap{From: seqs[i-1] + 1, To: seqs[i] - 1})
+		}
+	}
+
+	return gaps
+}
+
+func sequence(msg Message) uint64 {
+	switch m := msg.(type) {
+	case senml.Message:
+		return m.Seq
+	case map[string]interface{}:
+		switch seq := m["seq"].(type) {
+		case uint64:
+			return seq
+		case int64:
+			return uint64(seq)
+		case float64:
+			return uint64(seq)
+		}
+	}
+
+	return 0
+}
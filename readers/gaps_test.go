@@ -0,0 +1,63 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package readers_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/MainfluxLabs/mainflux/pkg/transformers/senml"
+	"github.com/MainfluxLabs/mainflux/readers"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGaps(t *testing.T) {
+	senmlMsgs := func(seqs ...uint64) []readers.Message {
+		var msgs []readers.Message
+		for _, seq := range seqs {
+			msgs = append(msgs, senml.Message{Publisher: "1", Seq: seq})
+		}
+		return msgs
+	}
+
+	cases := []struct {
+		desc string
+		msgs []readers.Message
+		gaps []readers.Gap
+	}{
+		{
+			desc: "consecutive messages",
+			msgs: senmlMsgs(3, 2, 1),
+			gaps: nil,
+		},
+		{
+			desc: "messages with gaps",
+			msgs: senmlMsgs(10, 7, 4, 3, 1),
+			gaps: []readers.Gap{{From: 2, To: 2}, {From: 5, To: 6}, {From: 8, To: 9}},
+		},
+		{
+			desc: "records of the same message",
+			msgs: senmlMsgs(4, 4, 2, 2, 1),
+			gaps: []readers.Gap{{From: 3, To: 3}},
+		},
+		{
+			desc: "messages without sequence numbers",
+			msgs: senmlMsgs(0, 0, 0),
+			gaps: nil,
+		},
+		{
+			desc: "JSON messages with gaps",
+			msgs: []readers.Message{
+				map[string]interface{}{"publisher": "1", "seq": float64(5)},
+				map[string]interface{}{"publisher": "1", "seq": int64(2)},
+			},
+			gaps: []readers.Gap{{From: 3, To: 4}},
+		},
+	}
+
+	for _, tc := range cases {
+		gaps := readers.Gaps(tc.msgs)
+		assert.Equal(t, tc.gaps, gaps, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.gaps, gaps))
+	}
+}
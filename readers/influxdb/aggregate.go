@@ -0,0 +1,119 @@
+package influxdb
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/transformers/senml"
+	"github.com/MainfluxLabs/mainflux/readers"
+)
+
+var errInvalidAggregation = errors.New("invalid aggregation")
+
+// aggregations maps the aggregations to the Flux aggregate functions.
+var aggregations = map[string]string{
+	readers.AvgAggregation:   "mean",
+	readers.MinAggregation:   "min",
+	readers.MaxAggregation:   "max",
+	readers.SumAggregation:   "sum",
+	readers.CountAggregation: "count",
+}
+
+// aggregate aggregates SenML message values over the time windows. Windows
+// of the time zone other than UTC are aligned to the local time, so e.g.
+// daily windows start at local midnight.
+func (repo *influxRepository) aggregate(chanID string, rpm readers.PageMetadata) (readers.MessagesPage, error) {
+	interval, err := readers.ParseInterval(rpm.Interval)
+	if err != nil {
+		return readers.MessagesPage{}, errors.Wrap(errInvalidAggregation, err)
+	}
+
+	loc, err := time.LoadLocation(rpm.Timezone)
+	if err != nil {
+		return readers.MessagesPage{}, errors.Wrap(errInvalidAggregation, err)
+	}
+
+	fn, ok := aggregations[rpm.Aggregation]
+	if !ok {
+		return readers.MessagesPage{}, errInvalidAggregation
+	}
+
+	condition, timeRange := fmtCondition(chanID, rpm)
+
+	var sb strings.Builder
+	sb.WriteString(`import "influxdata/influxdb/v1"`)
+	sb.WriteString(` import "timezone"`)
+	sb.WriteString(fmt.Sprintf(`from(bucket: "%s")`, repo.cfg.Bucket))
+	sb.WriteString(timeRange)
+	sb.WriteString(`|> v1.fieldsAsCols()`)
+	sb.WriteString(fmt.Sprintf(`|> filter(fn: (r) => r._measurement == "%s")`, defMeasurement))
+	sb.WriteString(condition)
+	sb.WriteString(`|> filter(fn: (r) => exists r.value)`)
+	sb.WriteString(`|> group()`)
+	sb.WriteString(`|> keep(columns: ["_time", "value"])`)
+	sb.WriteString(fmt.Sprintf(`|> aggregateWindow(every: %ds, fn: %s, column: "value", timeSrc: "_start", createEmpty: false, location: timezone.location(name: "%s"))`,
+		int64(interval.Seconds()), fn, loc.String()))
+	windows := sb.String()
+
+	sb.WriteString(`|> sort(columns: ["_time"], desc: true)`)
+	if rpm.Limit != noLimit {
+		sb.WriteString(fmt.Sprintf(`|> limit(n:%d,offset:%d)`, rpm.Limit, rpm.Offset))
+	}
+	sb.WriteString(`|> yield(name: "aggregate")`)
+
+	queryAPI := repo.client.QueryAPI(repo.cfg.Org)
+	resp, err := queryAPI.Query(context.Background(), sb.String())
+	if err != nil {
+		return readers.MessagesPage{}, errors.Wrap(readers.ErrReadMessages, err)
+	}
+
+	page := readers.MessagesPage{
+		PageMetadata: rpm,
+		Messages:     []readers.Message{},
+	}
+	for resp.Next() {
+		values := resp.Record().Values()
+		t, ok := values["_time"].(time.Time)
+		if !ok {
+			return readers.MessagesPage{}, errResultTime
+		}
+
+		var value float64
+		switch v := values["value"].(type) {
+		case float64:
+			value = v
+		case int64:
+			value = float64(v)
+		default:
+			continue
+		}
+
+		page.Messages = append(page.Messages, senml.Message{
+			Channel: chanID,
+			Name:    rpm.Name,
+			Time:    float64(t.Unix()),
+			Value:   &value,
+		})
+	}
+	if resp.Err() != nil {
+		return readers.MessagesPage{}, errors.Wrap(readers.ErrReadMessages, resp.Err())
+	}
+
+	cnt, err := queryAPI.Query(context.Background(), windows+`|> count(column: "value") |> yield(name: "count")`)
+	if err != nil {
+		return readers.MessagesPage{}, errors.Wrap(readers.ErrReadMessages, err)
+	}
+	if cnt.Next() {
+		if total, ok := cnt.Record().Values()["value"].(int64); ok {
+			page.Total = uint64(total)
+		}
+	}
+	if cnt.Err() != nil {
+		return readers.MessagesPage{}, errors.Wrap(readers.ErrReadMessages, cnt.Err())
+	}
+
+	return page, nil
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"math"
+	"regexp"
 	"strings"
 	"time"
 	"unicode"
@@ -53,6 +54,21 @@ func (repo *influxRepository) ListChannelMessages(chanID string, rpm readers.Pag
 	return repo.readAll(chanID, rpm)
 }
 
+func (repo *influxRepository) CountChannelMessages(chanID string, rpm readers.PageMetadata) (uint64, error) {
+	format := defMeasurement
+	if rpm.Format != "" {
+		format = rpm.Format
+	}
+
+	condition, timeRange := fmtCondition(chanID, rpm)
+	total, err := repo.count(format, condition, timeRange)
+	if err != nil {
+		return 0, errors.Wrap(readers.ErrReadMessages, err)
+	}
+
+	return total, nil
+}
+
 func (repo *influxRepository) Restore(ctx context.Context, messages ...senml.Message) error {
 	pts, err := repo.senmlPoints(messages)
 	if err != nil {
@@ -81,6 +97,10 @@ func (repo *influxRepository) senmlPoints(messages []senml.Message) ([]*write.Po
 }
 
 func (repo *influxRepository) readAll(chanID string, rpm readers.PageMetadata) (readers.MessagesPage, error) {
+	if rpm.Aggregation != "" {
+		return repo.aggregate(chanID, rpm)
+	}
+
 	format := defMeasurement
 	if rpm.Format != "" {
 		format = rpm.Format
@@ -103,6 +123,7 @@ func (repo *influxRepository) readAll(chanID string, rpm readers.PageMetadata) (
 	if rpm.Limit != noLimit {
 		sb.WriteString(fmt.Sprintf(`|> limit(n:%d,offset:%d)`, rpm.Limit, rpm.Offset))
 	}
+	sb.WriteString(fmtProjection(format, rpm.Fields))
 	sb.WriteString(`|> yield(name: "sort")`)
 	query := sb.String()
 	resp, err := queryAPI.Query(context.Background(), query)
@@ -239,12 +260,72 @@ func fmtCondition(chanID string, rpm readers.PageMetadata) (string, string) {
 		case "vd":
 			sb.WriteString(`|> filter(fn: (r) => exists r.dataValue)`)
 			sb.WriteString(fmt.Sprintf(`|> filter(fn: (r) => r.dataValue == "%s")`, value))
+		case "value_gt":
+			sb.WriteString(`|> filter(fn: (r) => exists r.value)`)
+			sb.WriteString(fmt.Sprintf(`|> filter(fn: (r) => r.value > %v)`, value))
+		case "value_lt":
+			sb.WriteString(`|> filter(fn: (r) => exists r.value)`)
+			sb.WriteString(fmt.Sprintf(`|> filter(fn: (r) => r.value < %v)`, value))
+		case "string_value_like":
+			// Flux regular expression literals are delimited by slashes.
+			pattern := strings.ReplaceAll(regexp.QuoteMeta(rpm.StringValueLike), "/", `\/`)
+			sb.WriteString(`|> filter(fn: (r) => exists r.stringValue)`)
+			sb.WriteString(fmt.Sprintf(`|> filter(fn: (r) => r.stringValue =~ /%s/)`, pattern))
 		}
 	}
 
 	return sb.String(), timeRange
 }
 
+// fmtProjection returns the part of the query retrieving only the given fields.
+// JSON payload is stored as a set of columns, so the unselected columns
+// are dropped if the payload is selected.
+func fmtProjection(measurement string, fields []string) string {
+	if len(fields) == 0 {
+		return ""
+	}
+
+	selected := make(map[string]bool)
+	var cols []string
+	for _, f := range fields {
+		selected[f] = true
+		if f != "payload" {
+			cols = append(cols, fmt.Sprintf(`"%s"`, column(f)))
+		}
+	}
+
+	if measurement == defMeasurement || !selected["payload"] {
+		return fmt.Sprintf(`|> keep(columns: [%s])`, strings.Join(cols, ", "))
+	}
+
+	var dropped []string
+	for _, f := range readers.JSONFields {
+		if f != "payload" && !selected[f] {
+			dropped = append(dropped, fmt.Sprintf(`"%s"`, column(f)))
+		}
+	}
+	if len(dropped) == 0 {
+		return ""
+	}
+
+	return fmt.Sprintf(`|> drop(columns: [%s])`, strings.Join(dropped, ", "))
+}
+
+// column returns the name of the column containing the message field.
+func column(field string) string {
+	switch field {
+	case "time", "created":
+		return "_time"
+	}
+
+	parts := strings.Split(field, "_")
+	for i := 1; i < len(parts); i++ {
+		parts[i] = strings.ToUpper(parts[i][:1]) + parts[i][1:]
+	}
+
+	return strings.Join(parts, "")
+}
+
 func parseMessage(measurement string, valueMap map[string]interface{}) (interface{}, error) {
 	switch measurement {
 	case defMeasurement:
@@ -389,6 +389,76 @@ func TestListChannelMessagesSenML(t *testing.T) {
 	}
 }
 
+func TestListChannelMessagesAggregated(t *testing.T) {
+	err := resetBucket()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	writer := iwriter.New(client, repoCfg)
+
+	chanID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	// Two hourly windows, containing values 1, 2, 3 and 4, 5, 6.
+	var start float64 = 3600 * 1000
+	messages := []senml.Message{}
+	for i := 0; i < 6; i++ {
+		val := float64(i + 1)
+		messages = append(messages, senml.Message{
+			Channel: chanID,
+			Name:    msgName,
+			Value:   &val,
+			Time:    start + float64(i/3)*3600 + float64(i%3)*60,
+		})
+	}
+	err = writer.Consume(messages)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	reader := ireader.New(client, repoCfg)
+
+	aggregated := func(first, second float64) []readers.Message {
+		return []readers.Message{
+			senml.Message{Channel: chanID, Name: msgName, Time: start + 3600, Value: &second},
+			senml.Message{Channel: chanID, Name: msgName, Time: start, Value: &first},
+		}
+	}
+
+	// Both hours belong to the same day starting at 15:00 UTC in Tokyo.
+	avg := 3.5
+	daily := []readers.Message{senml.Message{Channel: chanID, Name: msgName, Time: start - 3600, Value: &avg}}
+
+	cases := map[string]struct {
+		pageMeta readers.PageMetadata
+		page     readers.MessagesPage
+	}{
+		"read average values by hour": {
+			pageMeta: readers.PageMetadata{Limit: limit, Name: msgName, Aggregation: readers.AvgAggregation, Interval: "1h"},
+			page:     readers.MessagesPage{Total: 2, Messages: aggregated(2, 5)},
+		},
+		"read sum of values by hour": {
+			pageMeta: readers.PageMetadata{Limit: limit, Name: msgName, Aggregation: readers.SumAggregation, Interval: "1h"},
+			page:     readers.MessagesPage{Total: 2, Messages: aggregated(6, 15)},
+		},
+		"read count of values by hour": {
+			pageMeta: readers.PageMetadata{Limit: limit, Name: msgName, Aggregation: readers.CountAggregation, Interval: "1h"},
+			page:     readers.MessagesPage{Total: 2, Messages: aggregated(3, 3)},
+		},
+		"read average values by hour with limit": {
+			pageMeta: readers.PageMetadata{Limit: 1, Name: msgName, Aggregation: readers.AvgAggregation, Interval: "1h"},
+			page:     readers.MessagesPage{Total: 2, Messages: aggregated(2, 5)[:1]},
+		},
+		"read average values by day in time zone": {
+			pageMeta: readers.PageMetadata{Limit: limit, Name: msgName, Aggregation: readers.AvgAggregation, Interval: "P1D", Timezone: "Asia/Tokyo"},
+			page:     readers.MessagesPage{Total: 1, Messages: daily},
+		},
+	}
+
+	for desc, tc := range cases {
+		result, err := reader.ListChannelMessages(chanID, tc.pageMeta)
+		assert.Nil(t, err, fmt.Sprintf("%s: expected no error got %s", desc, err))
+		assert.Equal(t, tc.page.Messages, result.Messages, fmt.Sprintf("%s: expected %v got %v", desc, tc.page.Messages, result.Messages))
+		assert.Equal(t, tc.page.Total, result.Total, fmt.Sprintf("%s: expected %d got %d", desc, tc.page.Total, result.Total))
+	}
+}
+
 func TestListChannelMessagesJSON(t *testing.T) {
 	err := resetBucket()
 	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
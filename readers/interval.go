@@ -0,0 +1,52 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package readers
+
+import (
+	"errors"
+	"regexp"
+	"strconv"
+	"time"
+
+	// Time zone database is embedded, since the readers run in the
+	// containers without the system one.
+	_ "time/tzdata"
+)
+
+// ErrInvalidInterval indicates the aggregation interval which is neither
+// Go nor ISO 8601 duration.
+var ErrInvalidInterval = errors.New("invalid aggregation interval")
+
+// isoDuration matches ISO 8601 durations of fixed length, i.e. durations
+// without years and months.
+var isoDuration = regexp.MustCompile(`^P(?:(\d+)W)?(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+(?:\.\d+)?)S)?)?$`)
+
+var isoUnits = []time.Duration{7 * 24 * time.Hour, 24 * time.Hour, time.Hour, time.Minute, time.Second}
+
+// ParseInterval parses the aggregation interval given either as Go duration,
+// e.g. 1h30m, or as ISO 8601 duration, e.g. P1D or PT15M.
+func ParseInterval(interval string) (time.Duration, error) {
+	if d, err := time.ParseDuration(interval); err == nil {
+		return d, nil
+	}
+
+	parts := isoDuration.FindStringSubmatch(interval)
+	if parts == nil || interval == "P" || interval[len(interval)-1] == 'T' {
+		return 0, ErrInvalidInterval
+	}
+
+	var d time.Duration
+	for i, p := range parts[1:] {
+		if p == "" {
+			continue
+		}
+		v, err := strconv.ParseFloat(p, 64)
+		if err != nil {
+			return 0, ErrInvalidInterval
+		}
+		d += time.Duration(v * float64(isoUnits[i]))
+	}
+
+	return d, nil
+}
@@ -0,0 +1,39 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package readers_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/readers"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseInterval(t *testing.T) {
+	cases := []struct {
+		interval string
+		duration time.Duration
+		err      error
+	}{
+		{interval: "1h30m", duration: 90 * time.Minute},
+		{interval: "P1D", duration: 24 * time.Hour},
+		{interval: "P1W", duration: 7 * 24 * time.Hour},
+		{interval: "PT15M", duration: 15 * time.Minute},
+		{interval: "P1DT12H", duration: 36 * time.Hour},
+		{interval: "PT0.5S", duration: 500 * time.Millisecond},
+		{interval: "P1M", err: readers.ErrInvalidInterval},
+		{interval: "P1Y", err: readers.ErrInvalidInterval},
+		{interval: "P", err: readers.ErrInvalidInterval},
+		{interval: "P1DT", err: readers.ErrInvalidInterval},
+		{interval: "daily", err: readers.ErrInvalidInterval},
+	}
+
+	for _, tc := range cases {
+		d, err := readers.ParseInterval(tc.interval)
+		assert.Equal(t, tc.err, err, fmt.Sprintf("parse %s: expected error %s got %s\n", tc.interval, tc.err, err))
+		assert.Equal(t, tc.duration, d, fmt.Sprintf("parse %s: expected %s got %s\n", tc.interval, tc.duration, d))
+	}
+}
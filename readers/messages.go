@@ -21,10 +21,38 @@ const (
 	GreaterThanKey = "gt"
 	// GreaterThanEqualKey represents the greater-than-or-equal comparison operator key.
 	GreaterThanEqualKey = "ge"
+
+	// AvgAggregation aggregates message values to their average.
+	AvgAggregation = "avg"
+	// MinAggregation aggregates message values to their minimum.
+	MinAggregation = "min"
+	// MaxAggregation aggregates message values to their maximum.
+	MaxAggregation = "max"
+	// SumAggregation aggregates message values to their sum.
+	SumAggregation = "sum"
+	// CountAggregation aggregates message values to their count.
+	CountAggregation = "count"
 )
 
-// ErrReadMessages indicates failure occurred while reading messages from database.
-var ErrReadMessages = errors.New("failed to read messages from database")
+var (
+	// SenMLFields are the fields of SenML messages which can be selected
+	// using the page metadata fields.
+	SenMLFields = []string{"channel", "subtopic", "publisher", "protocol", "name", "unit",
+		"time", "update_time", "value", "string_value", "data_value", "bool_value", "sum", "seq"}
+
+	// JSONFields are the fields of JSON messages which can be selected
+	// using the page metadata fields.
+	JSONFields = []string{"channel", "created", "subtopic", "publisher", "protocol", "payload"}
+)
+
+var (
+	// ErrReadMessages indicates failure occurred while reading messages from database.
+	ErrReadMessages = errors.New("failed to read messages from database")
+
+	// ErrAggregationNotSupported indicates that the message repository
+	// doesn't support aggregation of messages.
+	ErrAggregationNotSupported = errors.New("messages aggregation is not supported")
+)
 
 // MessageRepository specifies message reader API.
 type MessageRepository interface {
@@ -32,6 +60,10 @@ type MessageRepository interface {
 	// limited number of messages.
 	ListChannelMessages(chanID string, pm PageMetadata) (MessagesPage, error)
 
+	// CountChannelMessages returns the number of messages of the given channel
+	// matching the page metadata, without retrieving them.
+	CountChannelMessages(chanID string, pm PageMetadata) (uint64, error)
+
 	// ListAllMessages retrieves all messages from database.
 	ListAllMessages(rpm PageMetadata) (MessagesPage, error)
 
@@ -39,6 +71,15 @@ type MessageRepository interface {
 	Restore(ctx context.Context, messages ...senml.Message) error
 }
 
+// LastValueCache specifies the API for retrieving the last received messages
+// without reading the message repository.
+type LastValueCache interface {
+	// RetrieveLast retrieves the last message of every publisher and name of
+	// the channel, optionally filtered by the publisher, the subtopic and
+	// the name of the page metadata.
+	RetrieveLast(ctx context.Context, chanID string, pm PageMetadata) ([]Message, error)
+}
+
 // Message represents any message format.
 type Message interface{}
 
@@ -66,6 +107,20 @@ type PageMetadata struct {
 	From        float64 `json:"from,omitempty"`
 	To          float64 `json:"to,omitempty"`
 	Format      string  `json:"format,omitempty"`
+	// ValueGt and ValueLt are pointers so that zero thresholds can be used.
+	ValueGt         *float64 `json:"value_gt,omitempty"`
+	ValueLt         *float64 `json:"value_lt,omitempty"`
+	StringValueLike string   `json:"string_value_like,omitempty"`
+	// Fields are the message fields to be retrieved. All fields are retrieved if empty.
+	Fields []string `json:"fields,omitempty"`
+	// Aggregation aggregates SenML message values over the time buckets
+	// of the given interval, e.g. 1h or P1D. Each aggregated value is
+	// returned as a message with the time of the start of its bucket.
+	// Buckets are aligned in the given time zone, so e.g. daily buckets
+	// start at the local midnight. UTC is used if the time zone is empty.
+	Aggregation string `json:"aggregation,omitempty"`
+	Interval    string `json:"interval,omitempty"`
+	Timezone    string `json:"tz,omitempty"`
 }
 
 type BackupMessage struct {
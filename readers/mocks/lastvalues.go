@@ -0,0 +1,38 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"context"
+
+	"github.com/MainfluxLabs/mainflux/pkg/transformers/senml"
+	"github.com/MainfluxLabs/mainflux/readers"
+)
+
+var _ readers.LastValueCache = (*lastValueCacheMock)(nil)
+
+type lastValueCacheMock struct {
+	messages map[string][]senml.Message
+}
+
+// NewLastValueCache returns mock implementation of last value cache holding
+// the given last messages of the channel.
+func NewLastValueCache(chanID string, messages []senml.Message) readers.LastValueCache {
+	return &lastValueCacheMock{
+		messages: map[string][]senml.Message{chanID: messages},
+	}
+}
+
+func (lvc *lastValueCacheMock) RetrieveLast(_ context.Context, chanID string, pm readers.PageMetadata) ([]readers.Message, error) {
+	msgs := []readers.Message{}
+	for _, msg := range lvc.messages[chanID] {
+		if (pm.Publisher == "" || msg.Publisher == pm.Publisher) &&
+			(pm.Subtopic == "" || msg.Subtopic == pm.Subtopic) &&
+			(pm.Name == "" || msg.Name == pm.Name) {
+			msgs = append(msgs, msg)
+		}
+	}
+
+	return msgs, nil
+}
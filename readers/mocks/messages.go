@@ -6,6 +6,7 @@ package mocks
 import (
 	"context"
 	"encoding/json"
+	"strings"
 	"sync"
 
 	"github.com/MainfluxLabs/mainflux/pkg/transformers/senml"
@@ -36,6 +37,16 @@ func (repo *messageRepositoryMock) ListChannelMessages(chanID string, rpm reader
 	return repo.readAll(chanID, rpm)
 }
 
+func (repo *messageRepositoryMock) CountChannelMessages(chanID string, rpm readers.PageMetadata) (uint64, error) {
+	rpm.Offset, rpm.Limit = 0, noLimit
+	page, err := repo.readAll(chanID, rpm)
+	if err != nil {
+		return 0, err
+	}
+
+	return page.Total, nil
+}
+
 func (repo *messageRepositoryMock) ListAllMessages(rpm readers.PageMetadata) (readers.MessagesPage, error) {
 	return repo.readAll("", rpm)
 }
@@ -134,6 +145,19 @@ func (repo *messageRepositoryMock) readAll(chanID string, rpm readers.PageMetada
 						*senml.DataValue != rpm.DataValue) {
 					ok = false
 				}
+			case "value_gt":
+				if senml.Value == nil || *senml.Value <= *rpm.ValueGt {
+					ok = false
+				}
+			case "value_lt":
+				if senml.Value == nil || *senml.Value >= *rpm.ValueLt {
+					ok = false
+				}
+			case "string_value_like":
+				if senml.StringValue == nil ||
+					!strings.Contains(*senml.StringValue, rpm.StringValueLike) {
+					ok = false
+				}
 			case "from":
 				if senml.Time < rpm.From {
 					ok = false
@@ -150,7 +174,7 @@ func (repo *messageRepositoryMock) readAll(chanID string, rpm readers.PageMetada
 		}
 
 		if ok {
-			msgs = append(msgs, m)
+			msgs = append(msgs, project(senml, rpm.Fields))
 		}
 	}
 
@@ -174,3 +198,26 @@ func (repo *messageRepositoryMock) readAll(chanID string, rpm readers.PageMetada
 		Messages:     msgs[rpm.Offset:end],
 	}, nil
 }
+
+func project(msg senml.Message, fields []string) senml.Message {
+	if len(fields) == 0 {
+		return msg
+	}
+
+	var all map[string]interface{}
+	data, _ := json.Marshal(msg)
+	json.Unmarshal(data, &all)
+
+	selected := make(map[string]interface{})
+	for _, f := range fields {
+		if v, ok := all[f]; ok {
+			selected[f] = v
+		}
+	}
+
+	var ret senml.Message
+	data, _ = json.Marshal(selected)
+	json.Unmarshal(data, &ret)
+
+	return ret
+}
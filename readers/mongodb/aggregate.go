@@ -0,0 +1,162 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mongodb
+
+import (
+	"context"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/transformers/senml"
+	"github.com/MainfluxLabs/mainflux/readers"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var errInvalidAggregation = errors.New("invalid aggregation")
+
+// Accumulators of the message values grouped by the time buckets.
+var accumulators = map[string]bson.M{
+	readers.AvgAggregation:   {"$avg": "$value"},
+	readers.MinAggregation:   {"$min": "$value"},
+	readers.MaxAggregation:   {"$max": "$value"},
+	readers.SumAggregation:   {"$sum": "$value"},
+	readers.CountAggregation: {"$sum": 1},
+}
+
+// channelTimeIndex is the index of SenML messages created by the MongoDB writer.
+var channelTimeIndex = bson.D{{Key: "channel", Value: 1}, {Key: "time", Value: -1}}
+
+type aggregatedPage struct {
+	Total []struct {
+		Total uint64 `bson:"total"`
+	} `bson:"total"`
+	Messages []struct {
+		Time  float64  `bson:"_id"`
+		Value *float64 `bson:"value"`
+	} `bson:"messages"`
+}
+
+// aggregate aggregates message values over the time buckets using the
+// aggregation pipeline, so that only the aggregated values are returned
+// by the database. Channel messages are aggregated using the channel
+// and time index.
+func (repo mongoRepository) aggregate(chanID string, rpm readers.PageMetadata) (readers.MessagesPage, error) {
+	interval, err := readers.ParseInterval(rpm.Interval)
+	if err != nil {
+		return readers.MessagesPage{}, errors.Wrap(errInvalidAggregation, err)
+	}
+	seconds := interval.Seconds()
+
+	loc, err := time.LoadLocation(rpm.Timezone)
+	if err != nil {
+		return readers.MessagesPage{}, errors.Wrap(errInvalidAggregation, err)
+	}
+
+	acc, ok := accumulators[rpm.Aggregation]
+	if !ok {
+		return readers.MessagesPage{}, errInvalidAggregation
+	}
+
+	page := bson.A{bson.M{"$sort": bson.M{"_id": -1}}}
+	if rpm.Limit != noLimit {
+		page = append(page, bson.M{"$skip": int64(rpm.Offset)}, bson.M{"$limit": int64(rpm.Limit)})
+	}
+
+	var t interface{} = "$time"
+	if loc != time.UTC {
+		t = localSeconds(t, loc.String())
+	}
+
+	pipeline := bson.A{
+		bson.M{"$match": fmtCondition(chanID, rpm)},
+		// Values are matched separately, since the condition may already filter the values.
+		bson.M{"$match": bson.M{"value": bson.M{"$type": "number"}}},
+		bson.M{"$group": bson.M{
+			"_id":   bson.M{"$subtract": bson.A{t, bson.M{"$mod": bson.A{t, seconds}}}},
+			"value": acc,
+		}},
+	}
+	if loc != time.UTC {
+		pipeline = append(pipeline, bson.M{"$project": bson.M{
+			"_id":   utcSeconds("$_id", loc.String()),
+			"value": 1,
+		}})
+	}
+	pipeline = append(pipeline, bson.M{"$facet": bson.M{
+		"total":    bson.A{bson.M{"$count": "total"}},
+		"messages": page,
+	}})
+
+	opts := options.Aggregate().SetAllowDiskUse(true)
+	if chanID != "" {
+		opts.SetHint(channelTimeIndex)
+	}
+
+	cursor, err := repo.db.Collection(defCollection).Aggregate(context.Background(), pipeline, opts)
+	if err != nil {
+		return readers.MessagesPage{}, errors.Wrap(readers.ErrReadMessages, err)
+	}
+	defer cursor.Close(context.Background())
+
+	mp := readers.MessagesPage{
+		PageMetadata: rpm,
+		Messages:     []readers.Message{},
+	}
+	if !cursor.Next(context.Background()) {
+		return mp, nil
+	}
+
+	var res aggregatedPage
+	if err := cursor.Decode(&res); err != nil {
+		return readers.MessagesPage{}, errors.Wrap(readers.ErrReadMessages, err)
+	}
+	if len(res.Total) > 0 {
+		mp.Total = res.Total[0].Total
+	}
+	for _, m := range res.Messages {
+		mp.Messages = append(mp.Messages, senml.Message{
+			Channel: chanID,
+			Name:    rpm.Name,
+			Time:    m.Time,
+			Value:   m.Value,
+		})
+	}
+
+	return mp, nil
+}
+
+// localSeconds returns the expression which converts the Unix time in seconds
+// to the wall clock time of the given time zone, in seconds since the Unix
+// epoch, so that the time buckets are aligned to the local time.
+func localSeconds(t interface{}, tz string) bson.M {
+	return bson.M{"$let": bson.M{
+		"vars": bson.M{"p": bson.M{"$dateToParts": bson.M{"date": toDate(t), "timezone": tz}}},
+		"in":   fromParts(bson.M{}),
+	}}
+}
+
+// utcSeconds returns the expression which converts the wall clock time of
+// the given time zone, in seconds since the Unix epoch, back to the Unix time.
+func utcSeconds(t interface{}, tz string) bson.M {
+	return bson.M{"$let": bson.M{
+		"vars": bson.M{"p": bson.M{"$dateToParts": bson.M{"date": toDate(t)}}},
+		"in":   fromParts(bson.M{"timezone": tz}),
+	}}
+}
+
+func toDate(t interface{}) bson.M {
+	return bson.M{"$toDate": bson.M{"$multiply": bson.A{t, 1000}}}
+}
+
+// fromParts returns the expression which builds the date from the parts
+// bound to the p variable and converts it to seconds since the Unix epoch.
+func fromParts(parts bson.M) bson.M {
+	for _, p := range []string{"year", "month", "day", "hour", "minute", "second", "millisecond"} {
+		parts[p] = "$$p." + p
+	}
+	date := bson.M{"$dateFromParts": parts}
+
+	return bson.M{"$divide": bson.A{bson.M{"$subtract": bson.A{date, time.Unix(0, 0).UTC()}}, 1000}}
+}
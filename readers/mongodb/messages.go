@@ -6,6 +6,7 @@ package mongodb
 import (
 	"context"
 	"encoding/json"
+	"regexp"
 
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
 	"github.com/MainfluxLabs/mainflux/pkg/transformers/senml"
@@ -43,6 +44,20 @@ func (repo mongoRepository) ListChannelMessages(chanID string, rpm readers.PageM
 	return repo.readAll(chanID, rpm)
 }
 
+func (repo mongoRepository) CountChannelMessages(chanID string, rpm readers.PageMetadata) (uint64, error) {
+	format := defCollection
+	if rpm.Format != "" {
+		format = rpm.Format
+	}
+
+	total, err := repo.db.Collection(format).CountDocuments(context.Background(), fmtCondition(chanID, rpm))
+	if err != nil {
+		return 0, errors.Wrap(readers.ErrReadMessages, err)
+	}
+
+	return uint64(total), nil
+}
+
 func (repo mongoRepository) Restore(ctx context.Context, messages ...senml.Message) error {
 	coll := repo.db.Collection(defCollection)
 	var dbMsgs []interface{}
@@ -59,6 +74,10 @@ func (repo mongoRepository) Restore(ctx context.Context, messages ...senml.Messa
 }
 
 func (repo mongoRepository) readAll(chanID string, rpm readers.PageMetadata) (readers.MessagesPage, error) {
+	if rpm.Aggregation != "" {
+		return repo.aggregate(chanID, rpm)
+	}
+
 	format := defCollection
 	order := "time"
 	if rpm.Format != "" && rpm.Format != defCollection {
@@ -75,11 +94,15 @@ func (repo mongoRepository) readAll(chanID string, rpm readers.PageMetadata) (re
 	filter := fmtCondition(chanID, rpm)
 	var cursor *mongo.Cursor
 	var err error
+	opts := options.Find().SetSort(sortMap)
+	if len(rpm.Fields) > 0 {
+		opts.SetProjection(fmtProjection(rpm.Fields))
+	}
 	switch rpm.Limit {
 	case noLimit:
-		cursor, err = col.Find(context.Background(), filter, options.Find().SetSort(sortMap))
+		cursor, err = col.Find(context.Background(), filter, opts)
 	default:
-		cursor, err = col.Find(context.Background(), filter, options.Find().SetSort(sortMap).SetLimit(int64(rpm.Limit)).SetSkip(int64(rpm.Offset)))
+		cursor, err = col.Find(context.Background(), filter, opts.SetLimit(int64(rpm.Limit)).SetSkip(int64(rpm.Offset)))
 	}
 	if err != nil {
 		return readers.MessagesPage{}, errors.Wrap(readers.ErrReadMessages, err)
@@ -123,6 +146,16 @@ func (repo mongoRepository) readAll(chanID string, rpm readers.PageMetadata) (re
 	return mp, nil
 }
 
+// fmtProjection returns the projection retrieving only the given fields.
+func fmtProjection(fields []string) bson.D {
+	projection := bson.D{{Key: "_id", Value: 0}}
+	for _, f := range fields {
+		projection = append(projection, bson.E{Key: f, Value: 1})
+	}
+
+	return projection
+}
+
 func fmtCondition(chanID string, rpm readers.PageMetadata) bson.D {
 	filter := bson.D{}
 
@@ -170,6 +203,8 @@ func fmtCondition(chanID string, rpm readers.PageMetadata) bson.D {
 			filter = append(filter, bson.E{Key: "string_value", Value: value})
 		case "vd":
 			filter = append(filter, bson.E{Key: "data_value", Value: value})
+		case "string_value_like":
+			filter = append(filter, bson.E{Key: "string_value", Value: bson.M{"$regex": regexp.QuoteMeta(rpm.StringValueLike)}})
 		case "from":
 			filter = append(filter, bson.E{Key: "time", Value: bson.M{"$gte": value}})
 		case "to":
@@ -177,5 +212,17 @@ func fmtCondition(chanID string, rpm readers.PageMetadata) bson.D {
 		}
 	}
 
+	// Value range is a single filter, since duplicate keys are not allowed.
+	valueRange := bson.M{}
+	if rpm.ValueGt != nil {
+		valueRange["$gt"] = *rpm.ValueGt
+	}
+	if rpm.ValueLt != nil {
+		valueRange["$lt"] = *rpm.ValueLt
+	}
+	if len(valueRange) > 0 {
+		filter = append(filter, bson.E{Key: "value", Value: valueRange})
+	}
+
 	return filter
 }
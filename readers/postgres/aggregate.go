@@ -0,0 +1,116 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/transformers/senml"
+	"github.com/MainfluxLabs/mainflux/readers"
+)
+
+var errInvalidAggregation = errors.New("invalid aggregation")
+
+// aggregations of the message values.
+var aggregations = map[string]string{
+	readers.AvgAggregation:   "AVG(value)",
+	readers.MinAggregation:   "MIN(value)",
+	readers.MaxAggregation:   "MAX(value)",
+	readers.SumAggregation:   "SUM(value)",
+	readers.CountAggregation: "CAST(COUNT(value) AS FLOAT)",
+}
+
+type aggregatedValue struct {
+	Time  int64    `db:"time"`
+	Value *float64 `db:"value"`
+}
+
+// aggregate aggregates SenML message values over the time buckets.
+func (tr postgresRepository) aggregate(chanID string, rpm readers.PageMetadata) (readers.MessagesPage, error) {
+	interval, err := readers.ParseInterval(rpm.Interval)
+	if err != nil {
+		return readers.MessagesPage{}, errors.Wrap(errInvalidAggregation, err)
+	}
+	seconds := int64(interval.Seconds())
+
+	loc, err := time.LoadLocation(rpm.Timezone)
+	if err != nil {
+		return readers.MessagesPage{}, errors.Wrap(errInvalidAggregation, err)
+	}
+
+	agg, ok := aggregations[rpm.Aggregation]
+	if !ok {
+		return readers.MessagesPage{}, errInvalidAggregation
+	}
+	bucket, condition := fmtBucket(loc), fmtCondition(chanID, rpm)
+
+	olq := "LIMIT :limit OFFSET :offset"
+	if rpm.Limit == 0 {
+		olq = ""
+	}
+
+	q := fmt.Sprintf(`SELECT %s AS time, %s AS value FROM %s %s
+		GROUP BY 1 ORDER BY 1 DESC %s;`, bucket, agg, defTable, condition, olq)
+
+	params := queryParams(chanID, rpm)
+	params["interval"] = seconds
+	params["tz"] = loc.String()
+
+	rows, err := tr.db.NamedQuery(q, params)
+	if err != nil {
+		return readers.MessagesPage{}, errors.Wrap(readers.ErrReadMessages, err)
+	}
+	defer rows.Close()
+
+	page := readers.MessagesPage{
+		PageMetadata: rpm,
+		Messages:     []readers.Message{},
+	}
+	for rows.Next() {
+		var av aggregatedValue
+		if err := rows.StructScan(&av); err != nil {
+			return readers.MessagesPage{}, errors.Wrap(readers.ErrReadMessages, err)
+		}
+
+		page.Messages = append(page.Messages, senml.Message{
+			Channel: chanID,
+			Name:    rpm.Name,
+			Time:    float64(av.Time),
+			Value:   av.Value,
+		})
+	}
+
+	q = fmt.Sprintf(`SELECT COUNT(DISTINCT %s) FROM %s %s;`, bucket, defTable, condition)
+	cnt, err := tr.db.NamedQuery(q, params)
+	if err != nil {
+		return readers.MessagesPage{}, errors.Wrap(readers.ErrReadMessages, err)
+	}
+	defer cnt.Close()
+
+	if cnt.Next() {
+		if err := cnt.Scan(&page.Total); err != nil {
+			return readers.MessagesPage{}, errors.Wrap(readers.ErrReadMessages, err)
+		}
+	}
+
+	return page, nil
+}
+
+// fmtBucket returns the expression of the bucket start, in Unix seconds.
+// Buckets of the time zone other than UTC are aligned to the local time,
+// so e.g. daily buckets start at local midnight: the message time is
+// converted to the local wall clock time, truncated to the interval and
+// converted back to the Unix time.
+func fmtBucket(loc *time.Location) string {
+	if loc == time.UTC {
+		return "CAST(FLOOR(time / :interval) * :interval AS BIGINT)"
+	}
+
+	local := "EXTRACT(EPOCH FROM to_timestamp(time) AT TIME ZONE :tz)"
+
+	return fmt.Sprintf(`CAST(EXTRACT(EPOCH FROM
+		to_timestamp(FLOOR(%[1]s / :interval) * :interval) AT TIME ZONE 'UTC' AT TIME ZONE :tz) AS BIGINT)`, local)
+}
@@ -70,6 +70,34 @@ func migrateDB(db *sqlx.DB) error {
 					"DROP TABLE messages",
 				},
 			},
+			{
+				Id: "messages_2",
+				Up: []string{
+					`ALTER TABLE messages ADD COLUMN IF NOT EXISTS seq BIGINT NOT NULL DEFAULT 0`,
+				},
+				Down: []string{
+					"ALTER TABLE messages DROP COLUMN seq",
+				},
+			},
+			{
+				Id: "messages_3",
+				Up: []string{
+					`CREATE INDEX IF NOT EXISTS messages_channel_idx ON messages (channel, time)`,
+				},
+				Down: []string{
+					"DROP INDEX IF EXISTS messages_channel_idx",
+				},
+			},
+			{
+				// Channel and thing IDs aren't necessarily UUIDs.
+				Id: "messages_4",
+				Up: []string{
+					`ALTER TABLE messages ALTER COLUMN channel TYPE VARCHAR(254), ALTER COLUMN publisher TYPE VARCHAR(254)`,
+				},
+				Down: []string{
+					"ALTER TABLE messages ALTER COLUMN channel TYPE UUID USING channel::uuid, ALTER COLUMN publisher TYPE UUID USING publisher::uuid",
+				},
+			},
 		},
 	}
 
@@ -7,6 +7,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
 
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
 	"github.com/MainfluxLabs/mainflux/pkg/transformers/senml"
@@ -53,6 +54,25 @@ func (tr postgresRepository) ListChannelMessages(chanID string, rpm readers.Page
 	return tr.readAll(chanID, rpm)
 }
 
+func (tr postgresRepository) CountChannelMessages(chanID string, rpm readers.PageMetadata) (uint64, error) {
+	format := defTable
+	if rpm.Format != "" {
+		format = rpm.Format
+	}
+
+	total, err := tr.count(chanID, format, rpm, queryParams(chanID, rpm))
+	if err != nil {
+		if pgErr, ok := err.(*pgconn.PgError); ok {
+			if pgErr.Code == pgerrcode.UndefinedTable {
+				return 0, nil
+			}
+		}
+		return 0, errors.Wrap(readers.ErrReadMessages, err)
+	}
+
+	return total, nil
+}
+
 func (tr postgresRepository) Restore(ctx context.Context, messages ...senml.Message) error {
 	q := `INSERT INTO messages (id, channel, subtopic, publisher, protocol,
           name, unit, value, string_value, bool_value, data_value, sum,
@@ -102,6 +122,10 @@ func (tr postgresRepository) Restore(ctx context.Context, messages ...senml.Mess
 }
 
 func (tr postgresRepository) readAll(chanID string, rpm readers.PageMetadata) (readers.MessagesPage, error) {
+	if rpm.Aggregation != "" {
+		return tr.aggregate(chanID, rpm)
+	}
+
 	order := "time"
 	format := defTable
 
@@ -115,24 +139,15 @@ func (tr postgresRepository) readAll(chanID string, rpm readers.PageMetadata) (r
 		olq = ""
 	}
 
-	q := fmt.Sprintf(`SELECT * FROM %s %s ORDER BY %s DESC %s;`, format, fmtCondition(chanID, rpm), order, olq)
-
-	params := map[string]interface{}{
-		"channel":      chanID,
-		"limit":        rpm.Limit,
-		"offset":       rpm.Offset,
-		"subtopic":     rpm.Subtopic,
-		"publisher":    rpm.Publisher,
-		"name":         rpm.Name,
-		"protocol":     rpm.Protocol,
-		"value":        rpm.Value,
-		"bool_value":   rpm.BoolValue,
-		"string_value": rpm.StringValue,
-		"data_value":   rpm.DataValue,
-		"from":         rpm.From,
-		"to":           rpm.To,
+	cols := "*"
+	if len(rpm.Fields) > 0 {
+		cols = strings.Join(rpm.Fields, ", ")
 	}
 
+	q := fmt.Sprintf(`SELECT %s FROM %s %s ORDER BY %s DESC %s;`, cols, format, fmtCondition(chanID, rpm), order, olq)
+
+	params := queryParams(chanID, rpm)
+
 	rows, err := tr.db.NamedQuery(q, params)
 	if err != nil {
 		if pgErr, ok := err.(*pgconn.PgError); ok {
@@ -164,7 +179,7 @@ func (tr postgresRepository) readAll(chanID string, rpm readers.PageMetadata) (r
 			if err := rows.StructScan(&msg); err != nil {
 				return readers.MessagesPage{}, errors.Wrap(readers.ErrReadMessages, err)
 			}
-			m, err := msg.toMap()
+			m, err := msg.toMap(rpm.Fields)
 			if err != nil {
 				return readers.MessagesPage{}, errors.Wrap(readers.ErrReadMessages, err)
 			}
@@ -173,22 +188,54 @@ func (tr postgresRepository) readAll(chanID string, rpm readers.PageMetadata) (r
 
 	}
 
-	q = fmt.Sprintf(`SELECT COUNT(*) FROM %s %s;`, format, fmtCondition(chanID, rpm))
-	rows, err = tr.db.NamedQuery(q, params)
+	total, err := tr.count(chanID, format, rpm, params)
 	if err != nil {
 		return readers.MessagesPage{}, errors.Wrap(readers.ErrReadMessages, err)
 	}
+	page.Total = total
+
+	return page, nil
+}
+
+// count returns the number of the messages matching the page metadata
+// without retrieving them.
+func (tr postgresRepository) count(chanID, format string, rpm readers.PageMetadata, params map[string]interface{}) (uint64, error) {
+	q := fmt.Sprintf(`SELECT COUNT(*) FROM %s %s;`, format, fmtCondition(chanID, rpm))
+	rows, err := tr.db.NamedQuery(q, params)
+	if err != nil {
+		return 0, err
+	}
 	defer rows.Close()
 
 	total := uint64(0)
 	if rows.Next() {
 		if err := rows.Scan(&total); err != nil {
-			return page, err
+			return 0, err
 		}
 	}
-	page.Total = total
 
-	return page, nil
+	return total, nil
+}
+
+func queryParams(chanID string, rpm readers.PageMetadata) map[string]interface{} {
+	return map[string]interface{}{
+		"channel":           chanID,
+		"limit":             rpm.Limit,
+		"offset":            rpm.Offset,
+		"subtopic":          rpm.Subtopic,
+		"publisher":         rpm.Publisher,
+		"name":              rpm.Name,
+		"protocol":          rpm.Protocol,
+		"value":             rpm.Value,
+		"bool_value":        rpm.BoolValue,
+		"string_value":      rpm.StringValue,
+		"data_value":        rpm.DataValue,
+		"from":              rpm.From,
+		"to":                rpm.To,
+		"value_gt":          rpm.ValueGt,
+		"value_lt":          rpm.ValueLt,
+		"string_value_like": likePattern(rpm.StringValueLike),
+	}
 }
 
 func fmtCondition(chanID string, rpm readers.PageMetadata) string {
@@ -228,6 +275,15 @@ func fmtCondition(chanID string, rpm readers.PageMetadata) string {
 		case "vd":
 			condition = fmt.Sprintf(`%s %s data_value = :data_value`, condition, op)
 			op = "AND"
+		case "value_gt":
+			condition = fmt.Sprintf(`%s %s value > :value_gt`, condition, op)
+			op = "AND"
+		case "value_lt":
+			condition = fmt.Sprintf(`%s %s value < :value_lt`, condition, op)
+			op = "AND"
+		case "string_value_like":
+			condition = fmt.Sprintf(`%s %s string_value LIKE :string_value_like`, condition, op)
+			op = "AND"
 		case "from":
 			condition = fmt.Sprintf(`%s %s time >= :from`, condition, op)
 			op = "AND"
@@ -239,6 +295,13 @@ func fmtCondition(chanID string, rpm readers.PageMetadata) string {
 	return condition
 }
 
+// likePattern returns the LIKE pattern matching the values which contain
+// the given substring. Wildcard characters of the substring are escaped.
+func likePattern(substr string) string {
+	r := strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+	return "%" + r.Replace(substr) + "%"
+}
+
 type senmlMessage struct {
 	ID string `db:"id"`
 	senml.Message
@@ -254,7 +317,9 @@ type jsonMessage struct {
 	Payload   []byte `db:"payload"`
 }
 
-func (msg jsonMessage) toMap() (map[string]interface{}, error) {
+// toMap converts the message to map. If fields are provided,
+// only the selected fields are contained by the map.
+func (msg jsonMessage) toMap(fields []string) (map[string]interface{}, error) {
 	ret := map[string]interface{}{
 		"id":        msg.ID,
 		"channel":   msg.Channel,
@@ -264,12 +329,24 @@ func (msg jsonMessage) toMap() (map[string]interface{}, error) {
 		"protocol":  msg.Protocol,
 		"payload":   map[string]interface{}{},
 	}
-	pld := make(map[string]interface{})
-	if err := json.Unmarshal(msg.Payload, &pld); err != nil {
-		return nil, err
+	if msg.Payload != nil {
+		pld := make(map[string]interface{})
+		if err := json.Unmarshal(msg.Payload, &pld); err != nil {
+			return nil, err
+		}
+		ret["payload"] = pld
+	}
+
+	if len(fields) == 0 {
+		return ret, nil
 	}
-	ret["payload"] = pld
-	return ret, nil
+
+	selected := make(map[string]interface{}, len(fields))
+	for _, f := range fields {
+		selected[f] = ret[f]
+	}
+
+	return selected, nil
 }
 
 type dbMessage struct {
@@ -0,0 +1,68 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/transformers/senml"
+	"github.com/MainfluxLabs/mainflux/readers"
+	"github.com/go-redis/redis/v8"
+)
+
+const keyPrefix = "lastvalue"
+
+var _ readers.LastValueCache = (*cache)(nil)
+
+type cache struct {
+	client *redis.Client
+}
+
+// New returns new last value cache reading the messages stored by the Redis
+// writer.
+func New(client *redis.Client) readers.LastValueCache {
+	return &cache{client: client}
+}
+
+func (c *cache) RetrieveLast(ctx context.Context, chanID string, pm readers.PageMetadata) ([]readers.Message, error) {
+	vals, err := c.client.HGetAll(ctx, fmt.Sprintf("%s:%s", keyPrefix, chanID)).Result()
+	if err != nil {
+		return nil, errors.Wrap(readers.ErrReadMessages, err)
+	}
+
+	var msgs []senml.Message
+	for _, val := range vals {
+		var msg senml.Message
+		if err := json.Unmarshal([]byte(val), &msg); err != nil {
+			return nil, errors.Wrap(readers.ErrReadMessages, err)
+		}
+		if matches(msg, pm) {
+			msgs = append(msgs, msg)
+		}
+	}
+
+	sort.Slice(msgs, func(i, j int) bool {
+		if msgs[i].Publisher != msgs[j].Publisher {
+			return msgs[i].Publisher < msgs[j].Publisher
+		}
+		return msgs[i].Name < msgs[j].Name
+	})
+
+	messages := []readers.Message{}
+	for _, msg := range msgs {
+		messages = append(messages, msg)
+	}
+
+	return messages, nil
+}
+
+func matches(msg senml.Message, pm readers.PageMetadata) bool {
+	return (pm.Publisher == "" || msg.Publisher == pm.Publisher) &&
+		(pm.Subtopic == "" || msg.Subtopic == pm.Subtopic) &&
+		(pm.Name == "" || msg.Name == pm.Name)
+}
@@ -0,0 +1,106 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package redis_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	rwriter "github.com/MainfluxLabs/mainflux/consumers/writers/redis"
+	"github.com/MainfluxLabs/mainflux/pkg/transformers/json"
+	"github.com/MainfluxLabs/mainflux/pkg/transformers/senml"
+	"github.com/MainfluxLabs/mainflux/pkg/uuid"
+	"github.com/MainfluxLabs/mainflux/readers"
+	rreader "github.com/MainfluxLabs/mainflux/readers/redis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	subtopic = "subtopic"
+	mqttProt = "mqtt"
+	httpProt = "http"
+	msgName  = "temperature"
+)
+
+var (
+	v  float64 = 5
+	v2 float64 = 10
+
+	idProvider = uuid.New()
+)
+
+func TestRetrieveLast(t *testing.T) {
+	writer := rwriter.New(redisClient)
+	reader := rreader.New(redisClient)
+
+	chanID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	pubID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	pubID2, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	emptyChanID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	now := float64(time.Now().Unix())
+	old := senml.Message{Channel: chanID, Publisher: pubID, Protocol: mqttProt, Name: msgName, Time: now - 10, Value: &v}
+	last := senml.Message{Channel: chanID, Publisher: pubID, Protocol: mqttProt, Name: msgName, Time: now, Value: &v2}
+	humidity := senml.Message{Channel: chanID, Publisher: pubID, Protocol: mqttProt, Name: "humidity", Time: now, Value: &v}
+	other := senml.Message{Channel: chanID, Publisher: pubID2, Protocol: httpProt, Subtopic: subtopic, Name: msgName, Time: now, Value: &v}
+
+	err = writer.Consume([]senml.Message{old, last, humidity, other})
+	require.Nil(t, err, fmt.Sprintf("expected no error got %s", err))
+
+	// Messages consumed out of order don't overwrite the newer ones.
+	err = writer.Consume([]senml.Message{old})
+	require.Nil(t, err, fmt.Sprintf("expected no error got %s", err))
+
+	err = writer.Consume(json.Messages{Data: []json.Message{{Channel: chanID, Publisher: pubID}}})
+	require.Nil(t, err, fmt.Sprintf("expected no error got %s", err))
+
+	cases := []struct {
+		desc     string
+		chanID   string
+		pageMeta readers.PageMetadata
+		messages []readers.Message
+	}{
+		{
+			desc:     "retrieve last messages of the channel",
+			chanID:   chanID,
+			messages: []readers.Message{humidity, last, other},
+		},
+		{
+			desc:     "retrieve last messages of the publisher",
+			chanID:   chanID,
+			pageMeta: readers.PageMetadata{Publisher: pubID},
+			messages: []readers.Message{humidity, last},
+		},
+		{
+			desc:     "retrieve last messages by name",
+			chanID:   chanID,
+			pageMeta: readers.PageMetadata{Name: msgName},
+			messages: []readers.Message{last, other},
+		},
+		{
+			desc:     "retrieve last messages by subtopic",
+			chanID:   chanID,
+			pageMeta: readers.PageMetadata{Subtopic: subtopic},
+			messages: []readers.Message{other},
+		},
+		{
+			desc:     "retrieve last messages of the channel without messages",
+			chanID:   emptyChanID,
+			messages: []readers.Message{},
+		},
+	}
+
+	for _, tc := range cases {
+		msgs, err := reader.RetrieveLast(context.Background(), tc.chanID, tc.pageMeta)
+		assert.Nil(t, err, fmt.Sprintf("%s: expected no error got %s", tc.desc, err))
+		assert.ElementsMatch(t, tc.messages, msgs, fmt.Sprintf("%s: expected %v got %v", tc.desc, tc.messages, msgs))
+	}
+}
@@ -0,0 +1,204 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package timescale
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/transformers/senml"
+	"github.com/MainfluxLabs/mainflux/readers"
+)
+
+const (
+	// hourlyView is the continuous aggregate of SenML message values
+	// over hourly buckets, maintained by the Timescale writer.
+	hourlyView     = "messages_hourly"
+	hourlyInterval = 3600
+)
+
+var errInvalidAggregation = errors.New("invalid aggregation")
+
+// Aggregations of the message values and of the continuous aggregate columns.
+var (
+	aggregations = map[string]string{
+		readers.AvgAggregation:   "AVG(value)",
+		readers.MinAggregation:   "MIN(value)",
+		readers.MaxAggregation:   "MAX(value)",
+		readers.SumAggregation:   "SUM(value)",
+		readers.CountAggregation: "CAST(COUNT(value) AS FLOAT)",
+	}
+	hourlyAggregations = map[string]string{
+		readers.AvgAggregation:   "SUM(sum_value) / NULLIF(SUM(count_value), 0)",
+		readers.MinAggregation:   "MIN(min_value)",
+		readers.MaxAggregation:   "MAX(max_value)",
+		readers.SumAggregation:   "SUM(sum_value)",
+		readers.CountAggregation: "CAST(SUM(count_value) AS FLOAT)",
+	}
+)
+
+type aggregatedValue struct {
+	Time  int64    `db:"time"`
+	Value *float64 `db:"value"`
+}
+
+// aggregate aggregates message values over the time buckets. The hourly
+// continuous aggregate is used whenever the interval, the time range and
+// the time zone offset consist of whole hours and no other filters than
+// the name are set.
+func (tr timescaleRepository) aggregate(chanID string, rpm readers.PageMetadata) (readers.MessagesPage, error) {
+	interval, err := readers.ParseInterval(rpm.Interval)
+	if err != nil {
+		return readers.MessagesPage{}, errors.Wrap(errInvalidAggregation, err)
+	}
+	seconds := int64(interval.Seconds())
+
+	loc, err := time.LoadLocation(rpm.Timezone)
+	if err != nil {
+		return readers.MessagesPage{}, errors.Wrap(errInvalidAggregation, err)
+	}
+
+	agg, ok := aggregations[rpm.Aggregation]
+	if !ok {
+		return readers.MessagesPage{}, errInvalidAggregation
+	}
+	table, column, condition := "messages", "time", fmtCondition(chanID, rpm)
+	if useHourlyView(seconds, loc, rpm) {
+		table, column, condition = hourlyView, "bucket", fmtHourlyCondition(chanID, rpm)
+		agg = hourlyAggregations[rpm.Aggregation]
+	}
+	bucket := fmtBucket(column, loc)
+
+	olq := "LIMIT :limit OFFSET :offset"
+	if rpm.Limit == 0 {
+		olq = ""
+	}
+
+	q := fmt.Sprintf(`SELECT %s AS time, %s AS value FROM %s %s
+		GROUP BY 1 ORDER BY 1 DESC %s;`, bucket, agg, table, condition, olq)
+
+	params := map[string]interface{}{
+		"channel":           chanID,
+		"limit":             rpm.Limit,
+		"offset":            rpm.Offset,
+		"interval":          seconds,
+		"tz":                loc.String(),
+		"subtopic":          rpm.Subtopic,
+		"publisher":         rpm.Publisher,
+		"name":              rpm.Name,
+		"protocol":          rpm.Protocol,
+		"value":             rpm.Value,
+		"bool_value":        rpm.BoolValue,
+		"string_value":      rpm.StringValue,
+		"data_value":        rpm.DataValue,
+		"from":              rpm.From,
+		"to":                rpm.To,
+		"value_gt":          rpm.ValueGt,
+		"value_lt":          rpm.ValueLt,
+		"string_value_like": likePattern(rpm.StringValueLike),
+	}
+
+	rows, err := tr.db.NamedQuery(q, params)
+	if err != nil {
+		return readers.MessagesPage{}, errors.Wrap(readers.ErrReadMessages, err)
+	}
+	defer rows.Close()
+
+	page := readers.MessagesPage{
+		PageMetadata: rpm,
+		Messages:     []readers.Message{},
+	}
+	for rows.Next() {
+		var av aggregatedValue
+		if err := rows.StructScan(&av); err != nil {
+			return readers.MessagesPage{}, errors.Wrap(readers.ErrReadMessages, err)
+		}
+
+		page.Messages = append(page.Messages, senml.Message{
+			Channel: chanID,
+			Name:    rpm.Name,
+			Time:    float64(av.Time),
+			Value:   av.Value,
+		})
+	}
+
+	q = fmt.Sprintf(`SELECT COUNT(DISTINCT %s) FROM %s %s;`, bucket, table, condition)
+	cnt, err := tr.db.NamedQuery(q, params)
+	if err != nil {
+		return readers.MessagesPage{}, errors.Wrap(readers.ErrReadMessages, err)
+	}
+	defer cnt.Close()
+
+	if cnt.Next() {
+		if err := cnt.Scan(&page.Total); err != nil {
+			return readers.MessagesPage{}, errors.Wrap(readers.ErrReadMessages, err)
+		}
+	}
+
+	return page, nil
+}
+
+// fmtBucket returns the expression of the bucket start, in Unix seconds,
+// of the given time column. Buckets of the time zone other than UTC are
+// aligned to the local time, so e.g. daily buckets start at local midnight.
+func fmtBucket(column string, loc *time.Location) string {
+	if loc == time.UTC {
+		return fmt.Sprintf("time_bucket(:interval, %s)", column)
+	}
+
+	return fmt.Sprintf(`CAST(EXTRACT(EPOCH FROM time_bucket(make_interval(secs => :interval),
+		to_timestamp(%s) AT TIME ZONE :tz) AT TIME ZONE :tz) AS BIGINT)`, column)
+}
+
+func useHourlyView(interval int64, loc *time.Location, rpm readers.PageMetadata) bool {
+	if interval%hourlyInterval != 0 || !wholeHour(rpm.From) || !wholeHour(rpm.To) || !wholeHourOffset(loc) {
+		return false
+	}
+
+	return rpm.Subtopic == "" && rpm.Publisher == "" && rpm.Protocol == "" &&
+		rpm.Value == 0 && rpm.Comparator == "" && !rpm.BoolValue &&
+		rpm.StringValue == "" && rpm.DataValue == "" &&
+		rpm.ValueGt == nil && rpm.ValueLt == nil && rpm.StringValueLike == ""
+}
+
+func wholeHour(t float64) bool {
+	return t == float64(int64(t)) && int64(t)%hourlyInterval == 0
+}
+
+// wholeHourOffset checks that both the standard and the daylight saving
+// time offsets of the time zone consist of whole hours.
+func wholeHourOffset(loc *time.Location) bool {
+	year := time.Now().Year()
+	for _, month := range []time.Month{time.January, time.July} {
+		_, offset := time.Date(year, month, 1, 0, 0, 0, 0, loc).Zone()
+		if offset%hourlyInterval != 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+func fmtHourlyCondition(chanID string, rpm readers.PageMetadata) string {
+	condition := ""
+	op := "WHERE"
+	if chanID != "" {
+		condition = fmt.Sprintf(`%s channel = :channel`, op)
+		op = "AND"
+	}
+	if rpm.Name != "" {
+		condition = fmt.Sprintf(`%s %s name = :name`, condition, op)
+		op = "AND"
+	}
+	if rpm.From != 0 {
+		condition = fmt.Sprintf(`%s %s bucket >= :from`, condition, op)
+		op = "AND"
+	}
+	if rpm.To != 0 {
+		condition = fmt.Sprintf(`%s %s bucket < :to`, condition, op)
+	}
+
+	return condition
+}
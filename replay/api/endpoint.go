@@ -0,0 +1,34 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+
+	"github.com/MainfluxLabs/mainflux/replay"
+	"github.com/go-kit/kit/endpoint"
+)
+
+func replayEndpoint(svc replay.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(replayReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		r := replay.Request{
+			ChannelID: req.channelID,
+			From:      req.From,
+			To:        req.To,
+			Rate:      req.Rate,
+			Key:       req.Key,
+		}
+		res, err := svc.Replay(ctx, req.token, r)
+		if err != nil {
+			return nil, err
+		}
+
+		return replayRes{Key: res.Key, Count: res.Count}, nil
+	}
+}
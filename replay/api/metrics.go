@@ -0,0 +1,40 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !test
+
+package api
+
+import (
+	"context"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/replay"
+	"github.com/go-kit/kit/metrics"
+)
+
+var _ replay.Service = (*metricsMiddleware)(nil)
+
+type metricsMiddleware struct {
+	counter metrics.Counter
+	latency metrics.Histogram
+	svc     replay.Service
+}
+
+// MetricsMiddleware instruments core service by tracking request count and latency.
+func MetricsMiddleware(svc replay.Service, counter metrics.Counter, latency metrics.Histogram) replay.Service {
+	return &metricsMiddleware{
+		counter: counter,
+		latency: latency,
+		svc:     svc,
+	}
+}
+
+func (ms *metricsMiddleware) Replay(ctx context.Context, token string, r replay.Request) (replay.Result, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "replay").Add(1)
+		ms.latency.With("method", "replay").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.Replay(ctx, token, r)
+}
@@ -0,0 +1,51 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/internal/apiutil"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+)
+
+const maxRate = 10000
+
+var (
+	errMissingFrom = errors.New("missing replay start time")
+	errInvalidRate = errors.New("invalid replay rate")
+)
+
+type replayReq struct {
+	token     string
+	channelID string
+	From      time.Time `json:"from"`
+	To        time.Time `json:"to,omitempty"`
+	Rate      uint64    `json:"rate,omitempty"`
+	Key       string    `json:"key,omitempty"`
+}
+
+func (req replayReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	if req.channelID == "" {
+		return apiutil.ErrMissingID
+	}
+
+	if req.From.IsZero() {
+		return errMissingFrom
+	}
+
+	if !req.To.IsZero() && req.To.Before(req.From) {
+		return apiutil.ErrMalformedEntity
+	}
+
+	if req.Rate > maxRate {
+		return errInvalidRate
+	}
+
+	return nil
+}
@@ -0,0 +1,29 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"net/http"
+
+	"github.com/MainfluxLabs/mainflux"
+)
+
+var _ mainflux.Response = (*replayRes)(nil)
+
+type replayRes struct {
+	Key   string `json:"key"`
+	Count uint64 `json:"count"`
+}
+
+func (res replayRes) Code() int {
+	return http.StatusOK
+}
+
+func (res replayRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res replayRes) Empty() bool {
+	return false
+}
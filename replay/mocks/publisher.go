@@ -0,0 +1,35 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"sync"
+
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+	"github.com/MainfluxLabs/mainflux/replay"
+)
+
+var _ replay.Publisher = (*Publisher)(nil)
+
+// Publisher is a replay publisher mock recording published messages
+// by their idempotency keys.
+type Publisher struct {
+	mu   sync.Mutex
+	Keys []string
+	Msgs []messaging.Message
+}
+
+// NewPublisher returns a recording replay publisher mock.
+func NewPublisher() *Publisher {
+	return &Publisher{}
+}
+
+func (p *Publisher) Publish(key string, msg messaging.Message) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.Keys = append(p.Keys, key)
+	p.Msgs = append(p.Msgs, msg)
+	return nil
+}
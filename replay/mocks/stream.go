@@ -0,0 +1,46 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+	"github.com/MainfluxLabs/mainflux/replay"
+)
+
+var _ replay.Stream = (*streamMock)(nil)
+
+type streamMock struct {
+	mu   sync.Mutex
+	msgs []messaging.Message
+}
+
+// NewStream returns stream mock containing the messages. Message sequences
+// are their positions in the stream, starting from one.
+func NewStream(msgs ...messaging.Message) replay.Stream {
+	return &streamMock{
+		msgs: msgs,
+	}
+}
+
+func (sm *streamMock) Read(ctx context.Context, chanID string, from, to time.Time, h replay.MessageHandler) error {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	for i, msg := range sm.msgs {
+		created := time.Unix(0, msg.Created)
+		if msg.Channel != chanID || created.Before(from) || (!to.IsZero() && created.After(to)) {
+			continue
+		}
+
+		if err := h(uint64(i+1), msg); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
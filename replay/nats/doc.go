@@ -0,0 +1,6 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package nats contains NATS JetStream implementation of the message
+// stream and the replayed messages publisher.
+package nats
@@ -0,0 +1,54 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package nats
+
+import (
+	"fmt"
+
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+	"github.com/MainfluxLabs/mainflux/replay"
+	"github.com/gogo/protobuf/proto"
+	broker "github.com/nats-io/nats.go"
+)
+
+// SubjectAllReplays represents subject to subscribe for replayed messages
+// of all the channels.
+const SubjectAllReplays = "replay.channels.>"
+
+const replayPrefix = "replay"
+
+var _ replay.Publisher = (*publisher)(nil)
+
+type publisher struct {
+	conn *broker.Conn
+}
+
+// NewPublisher returns NATS publisher of replayed messages. Messages are
+// published to the channel subject prefixed with "replay", so they are
+// neither persisted to the stream again nor received by the consumers
+// subscribed to the live messages only. The idempotency key is sent in
+// the Nats-Msg-Id header, which allows JetStream to deduplicate messages.
+func NewPublisher(conn *broker.Conn) replay.Publisher {
+	return &publisher{
+		conn: conn,
+	}
+}
+
+func (pub *publisher) Publish(key string, msg messaging.Message) error {
+	data, err := proto.Marshal(&msg)
+	if err != nil {
+		return err
+	}
+
+	subject := fmt.Sprintf("%s.%s.%s", replayPrefix, chansPrefix, msg.Channel)
+	if msg.Subtopic != "" {
+		subject = fmt.Sprintf("%s.%s", subject, msg.Subtopic)
+	}
+
+	m := broker.NewMsg(subject)
+	m.Header.Set(broker.MsgIdHdr, key)
+	m.Data = data
+
+	return pub.conn.PublishMsg(m)
+}
@@ -0,0 +1,87 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package nats
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+	"github.com/MainfluxLabs/mainflux/replay"
+	"github.com/gogo/protobuf/proto"
+	broker "github.com/nats-io/nats.go"
+)
+
+const (
+	chansPrefix = "channels"
+	subjectAll  = "channels.>"
+)
+
+var _ replay.Stream = (*stream)(nil)
+
+type stream struct {
+	js   broker.JetStreamContext
+	name string
+}
+
+// NewStream returns JetStream message stream reading from the named stream.
+func NewStream(js broker.JetStreamContext, name string) replay.Stream {
+	return &stream{
+		js:   js,
+		name: name,
+	}
+}
+
+func (s *stream) Read(ctx context.Context, chanID string, from, to time.Time, h replay.MessageHandler) error {
+	info, err := s.js.StreamInfo(s.name)
+	if err != nil {
+		return err
+	}
+
+	// Messages published after the replay started are not replayed.
+	last := info.State.LastSeq
+	if info.State.Msgs == 0 || info.State.LastTime.Before(from) {
+		return nil
+	}
+
+	sub, err := s.js.SubscribeSync(subjectAll, broker.BindStream(s.name), broker.StartTime(from), broker.AckNone())
+	if err != nil {
+		return err
+	}
+	defer sub.Unsubscribe()
+
+	subject := fmt.Sprintf("%s.%s", chansPrefix, chanID)
+	for {
+		m, err := sub.NextMsgWithContext(ctx)
+		if err != nil {
+			return err
+		}
+
+		meta, err := m.Metadata()
+		if err != nil {
+			return err
+		}
+
+		if !to.IsZero() && meta.Timestamp.After(to) {
+			return nil
+		}
+
+		if m.Subject == subject || strings.HasPrefix(m.Subject, subject+".") {
+			var msg messaging.Message
+			if err := proto.Unmarshal(m.Data, &msg); err != nil {
+				return err
+			}
+
+			if err := h(meta.Sequence.Stream, msg); err != nil {
+				return err
+			}
+		}
+
+		if meta.Sequence.Stream >= last {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,50 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package replay
+
+import (
+	"context"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+)
+
+// Request represents a request to replay the messages of the channel
+// published in the given time range.
+type Request struct {
+	ChannelID string
+	From      time.Time
+	To        time.Time
+	// Rate is the maximum number of replayed messages per second.
+	// Zero value disables rate limiting.
+	Rate uint64
+	// Key is the idempotency key of the replay. Replayed messages are
+	// published with the idempotency key derived from the replay key and
+	// the stream sequence of the message, so replaying the same time range
+	// with the same key produces the same message keys.
+	Key string
+}
+
+// Result represents the outcome of the replay.
+type Result struct {
+	Key   string
+	Count uint64
+}
+
+// MessageHandler handles the messages read from the stream.
+type MessageHandler func(seq uint64, msg messaging.Message) error
+
+// Stream specifies an API for reading messages from the broker's
+// persistent stream.
+type Stream interface {
+	// Read passes the messages of the channel published in the time range
+	// to the handler, in the order they were published.
+	Read(ctx context.Context, chanID string, from, to time.Time, h MessageHandler) error
+}
+
+// Publisher specifies an API for publishing replayed messages.
+type Publisher interface {
+	// Publish publishes the replayed message with the idempotency key.
+	Publish(key string, msg messaging.Message) error
+}
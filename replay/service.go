@@ -0,0 +1,105 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package replay
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+)
+
+const rootSubject = "root"
+
+var (
+	// ErrReadStream indicates failure to read messages from the stream.
+	ErrReadStream = errors.New("failed to read messages from stream")
+
+	// ErrPublish indicates failure to publish the replayed message.
+	ErrPublish = errors.New("failed to publish replayed message")
+)
+
+// Service specifies an API that must be fullfiled by the domain service
+// implementation, and all of its decorators (e.g. logging & metrics).
+type Service interface {
+	// Replay republishes the channel messages published in the requested
+	// time range. Only the root admin is allowed to replay messages.
+	Replay(ctx context.Context, token string, r Request) (Result, error)
+}
+
+var _ Service = (*replayService)(nil)
+
+type replayService struct {
+	auth       mainflux.AuthServiceClient
+	stream     Stream
+	publisher  Publisher
+	idProvider mainflux.IDProvider
+}
+
+// New instantiates the replay service implementation.
+func New(auth mainflux.AuthServiceClient, stream Stream, publisher Publisher, idp mainflux.IDProvider) Service {
+	return &replayService{
+		auth:       auth,
+		stream:     stream,
+		publisher:  publisher,
+		idProvider: idp,
+	}
+}
+
+func (rs *replayService) Replay(ctx context.Context, token string, r Request) (Result, error) {
+	req := &mainflux.AuthorizeReq{
+		Token:   token,
+		Subject: rootSubject,
+	}
+	if _, err := rs.auth.Authorize(ctx, req); err != nil {
+		return Result{}, errors.Wrap(errors.ErrAuthorization, err)
+	}
+
+	if r.Key == "" {
+		key, err := rs.idProvider.ID()
+		if err != nil {
+			return Result{}, err
+		}
+		r.Key = key
+	}
+
+	var tick <-chan time.Time
+	if r.Rate > 0 {
+		ticker := time.NewTicker(time.Second / time.Duration(r.Rate))
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+
+	res := Result{Key: r.Key}
+	var pubErr error
+	h := func(seq uint64, msg messaging.Message) error {
+		if tick != nil {
+			select {
+			case <-tick:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+
+		if err := rs.publisher.Publish(fmt.Sprintf("%s-%d", r.Key, seq), msg); err != nil {
+			pubErr = errors.Wrap(ErrPublish, err)
+			return pubErr
+		}
+		res.Count++
+
+		return nil
+	}
+
+	if err := rs.stream.Read(ctx, r.ChannelID, r.From, r.To, h); err != nil {
+		if pubErr != nil {
+			return res, pubErr
+		}
+		return res, errors.Wrap(ErrReadStream, err)
+	}
+
+	return res, nil
+}
@@ -0,0 +1,112 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package replay_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+	"github.com/MainfluxLabs/mainflux/pkg/mocks"
+	"github.com/MainfluxLabs/mainflux/pkg/uuid"
+	"github.com/MainfluxLabs/mainflux/replay"
+	replaymocks "github.com/MainfluxLabs/mainflux/replay/mocks"
+	"github.com/MainfluxLabs/mainflux/users"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	adminEmail = "admin@example.com"
+	userEmail  = "user@example.com"
+	password   = "password"
+	adminID    = "admin-id"
+	userID     = "user-id"
+	chanID     = "chan-id"
+	otherID    = "other-id"
+	replayKey  = "replay-key"
+)
+
+var usersList = []users.User{
+	{ID: adminID, Email: adminEmail, Password: password},
+	{ID: userID, Email: userEmail, Password: password},
+}
+
+func newService(stream replay.Stream, pub replay.Publisher) replay.Service {
+	auth := mocks.NewAuthService(adminID, usersList)
+	return replay.New(auth, stream, pub, uuid.NewMock())
+}
+
+func TestReplay(t *testing.T) {
+	now := time.Now()
+	var msgs []messaging.Message
+	for i := 0; i < 10; i++ {
+		ch := chanID
+		if i%2 == 1 {
+			ch = otherID
+		}
+		msgs = append(msgs, messaging.Message{
+			Channel: ch,
+			Payload: []byte(fmt.Sprintf(`{"n":%d}`, i)),
+			Created: now.Add(time.Duration(i-10) * time.Minute).UnixNano(),
+		})
+	}
+
+	cases := []struct {
+		desc  string
+		token string
+		req   replay.Request
+		count uint64
+		keys  []string
+		err   error
+	}{
+		{
+			desc:  "replay channel messages as admin",
+			token: adminEmail,
+			req:   replay.Request{ChannelID: chanID, From: now.Add(-time.Hour), Key: replayKey},
+			count: 5,
+			keys:  []string{replayKey + "-1", replayKey + "-3", replayKey + "-5", replayKey + "-7", replayKey + "-9"},
+		},
+		{
+			desc:  "replay channel messages in time range",
+			token: adminEmail,
+			req:   replay.Request{ChannelID: chanID, From: now.Add(-9 * time.Minute), To: now.Add(-5 * time.Minute), Key: replayKey},
+			count: 2,
+			keys:  []string{replayKey + "-3", replayKey + "-5"},
+		},
+		{
+			desc:  "replay channel messages with rate limit",
+			token: adminEmail,
+			req:   replay.Request{ChannelID: chanID, From: now.Add(-3 * time.Minute), Rate: 100, Key: replayKey},
+			count: 1,
+			keys:  []string{replayKey + "-9"},
+		},
+		{
+			desc:  "replay channel messages as non admin",
+			token: userEmail,
+			req:   replay.Request{ChannelID: chanID, From: now.Add(-time.Hour), Key: replayKey},
+			err:   errors.ErrAuthorization,
+		},
+	}
+
+	for _, tc := range cases {
+		pub := replaymocks.NewPublisher()
+		svc := newService(replaymocks.NewStream(msgs...), pub)
+
+		res, err := svc.Replay(context.Background(), tc.token, tc.req)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.count, res.Count, fmt.Sprintf("%s: expected %d messages got %d\n", tc.desc, tc.count, res.Count))
+		assert.Equal(t, tc.keys, pub.Keys, fmt.Sprintf("%s: expected keys %v got %v\n", tc.desc, tc.keys, pub.Keys))
+	}
+}
+
+func TestReplayGeneratesKey(t *testing.T) {
+	svc := newService(replaymocks.NewStream(), replaymocks.NewPublisher())
+
+	res, err := svc.Replay(context.Background(), adminEmail, replay.Request{ChannelID: chanID, From: time.Now()})
+	assert.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.NotEmpty(t, res.Key, "expected replay key to be generated")
+}
@@ -0,0 +1,225 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/MainfluxLabs/mainflux/readers"
+	"github.com/MainfluxLabs/mainflux/reports"
+	"github.com/go-kit/kit/endpoint"
+)
+
+func createReportEndpoint(svc reports.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(reportReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		saved, err := svc.CreateReport(ctx, req.token, toReport(req))
+		if err != nil {
+			return nil, err
+		}
+
+		res := toReportRes(saved)
+		res.created = true
+		return res, nil
+	}
+}
+
+func viewReportEndpoint(svc reports.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(viewReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		r, err := svc.ViewReport(ctx, req.token, req.id)
+		if err != nil {
+			return nil, err
+		}
+
+		return toReportRes(r), nil
+	}
+}
+
+func listReportsEndpoint(svc reports.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		page, err := svc.ListReports(ctx, req.token, reports.PageMetadata{Offset: req.offset, Limit: req.limit})
+		if err != nil {
+			return nil, err
+		}
+
+		res := reportsPageRes{
+			pageRes: pageRes{
+				Total:  page.Total,
+				Offset: page.Offset,
+				Limit:  page.Limit,
+			},
+			Reports: []reportRes{},
+		}
+		for _, r := range page.Reports {
+			res.Reports = append(res.Reports, toReportRes(r))
+		}
+
+		return res, nil
+	}
+}
+
+func updateReportEndpoint(svc reports.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(reportReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		if err := svc.UpdateReport(ctx, req.token, toReport(req)); err != nil {
+			return nil, err
+		}
+
+		return emptyRes{code: http.StatusOK}, nil
+	}
+}
+
+func removeReportEndpoint(svc reports.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(viewReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		if err := svc.RemoveReport(ctx, req.token, req.id); err != nil {
+			return nil, err
+		}
+
+		return emptyRes{code: http.StatusNoContent}, nil
+	}
+}
+
+func generateReportEndpoint(svc reports.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(viewReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		run, err := svc.GenerateReport(ctx, req.token, req.id)
+		if err != nil {
+			return nil, err
+		}
+
+		res := toRunRes(run)
+		res.created = true
+		return res, nil
+	}
+}
+
+func listRunsEndpoint(svc reports.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		page, err := svc.ListRuns(ctx, req.token, req.id, reports.PageMetadata{Offset: req.offset, Limit: req.limit})
+		if err != nil {
+			return nil, err
+		}
+
+		res := runsPageRes{
+			pageRes: pageRes{
+				Total:  page.Total,
+				Offset: page.Offset,
+				Limit:  page.Limit,
+			},
+			Runs: []runRes{},
+		}
+		for _, run := range page.Runs {
+			res.Runs = append(res.Runs, toRunRes(run))
+		}
+
+		return res, nil
+	}
+}
+
+func downloadRunEndpoint(svc reports.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(downloadReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		run, data, err := svc.DownloadRun(ctx, req.token, req.reportID, req.runID)
+		if err != nil {
+			return nil, err
+		}
+
+		res := downloadRes{
+			format: run.Format,
+			size:   run.Size,
+			data:   data,
+		}
+
+		return res, nil
+	}
+}
+
+func toReport(req reportReq) reports.Report {
+	// The period is already validated by the request.
+	period, _ := readers.ParseInterval(req.Period)
+
+	return reports.Report{
+		ID:          req.id,
+		Name:        req.Name,
+		ChannelIDs:  req.ChannelIDs,
+		GroupIDs:    req.GroupIDs,
+		Metrics:     req.Metrics,
+		Aggregation: req.Aggregation,
+		Interval:    req.Interval,
+		Timezone:    req.Timezone,
+		Format:      req.Format,
+		Period:      period,
+		Emails:      req.Emails,
+	}
+}
+
+func toReportRes(r reports.Report) reportRes {
+	return reportRes{
+		ID:          r.ID,
+		Name:        r.Name,
+		ChannelIDs:  r.ChannelIDs,
+		GroupIDs:    r.GroupIDs,
+		Metrics:     r.Metrics,
+		Aggregation: r.Aggregation,
+		Interval:    r.Interval,
+		Timezone:    r.Timezone,
+		Format:      r.Format,
+		Period:      r.Period.String(),
+		Emails:      r.Emails,
+		NextRunAt:   r.NextRunAt,
+		CreatedAt:   r.CreatedAt,
+		UpdatedAt:   r.UpdatedAt,
+	}
+}
+
+func toRunRes(run reports.Run) runRes {
+	return runRes{
+		ID:        run.ID,
+		ReportID:  run.ReportID,
+		Format:    run.Format,
+		Status:    run.Status,
+		Error:     run.Error,
+		Size:      run.Size,
+		From:      run.From,
+		To:        run.To,
+		CreatedAt: run.CreatedAt,
+	}
+}
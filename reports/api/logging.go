@@ -0,0 +1,154 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !test
+
+package api
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	log "github.com/MainfluxLabs/mainflux/logger"
+	"github.com/MainfluxLabs/mainflux/reports"
+)
+
+var _ reports.Service = (*loggingMiddleware)(nil)
+
+type loggingMiddleware struct {
+	logger log.Logger
+	svc    reports.Service
+}
+
+// LoggingMiddleware adds logging facilities to the core service.
+func LoggingMiddleware(svc reports.Service, logger log.Logger) reports.Service {
+	return &loggingMiddleware{logger, svc}
+}
+
+func (lm *loggingMiddleware) CreateReport(ctx context.Context, token string, r reports.Report) (saved reports.Report, err error) {
+	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "create_report", "latency", time.Since(begin).String())
+		message := fmt.Sprintf("Method create_report with the id %s for token %s took %s to complete", saved.ID, token, time.Since(begin))
+		if err != nil {
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		l.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.CreateReport(ctx, token, r)
+}
+
+func (lm *loggingMiddleware) ViewReport(ctx context.Context, token, id string) (r reports.Report, err error) {
+	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "view_report", "latency", time.Since(begin).String())
+		message := fmt.Sprintf("Method view_report with the id %s for token %s took %s to complete", id, token, time.Since(begin))
+		if err != nil {
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		l.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ViewReport(ctx, token, id)
+}
+
+func (lm *loggingMiddleware) ListReports(ctx context.Context, token string, pm reports.PageMetadata) (page reports.ReportsPage, err error) {
+	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "list_reports", "latency", time.Since(begin).String())
+		message := fmt.Sprintf("Method list_reports for token %s took %s to complete", token, time.Since(begin))
+		if err != nil {
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		l.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ListReports(ctx, token, pm)
+}
+
+func (lm *loggingMiddleware) UpdateReport(ctx context.Context, token string, r reports.Report) (err error) {
+	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "update_report", "latency", time.Since(begin).String())
+		message := fmt.Sprintf("Method update_report with the id %s for token %s took %s to complete", r.ID, token, time.Since(begin))
+		if err != nil {
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		l.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.UpdateReport(ctx, token, r)
+}
+
+func (lm *loggingMiddleware) RemoveReport(ctx context.Context, token, id string) (err error) {
+	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "remove_report", "latency", time.Since(begin).String())
+		message := fmt.Sprintf("Method remove_report with the id %s for token %s took %s to complete", id, token, time.Since(begin))
+		if err != nil {
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		l.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.RemoveReport(ctx, token, id)
+}
+
+func (lm *loggingMiddleware) GenerateReport(ctx context.Context, token, id string) (run reports.Run, err error) {
+	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "generate_report", "latency", time.Since(begin).String())
+		message := fmt.Sprintf("Method generate_report with the id %s for token %s took %s to complete", id, token, time.Since(begin))
+		if err != nil {
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		l.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.GenerateReport(ctx, token, id)
+}
+
+func (lm *loggingMiddleware) ListRuns(ctx context.Context, token, id string, pm reports.PageMetadata) (page reports.RunsPage, err error) {
+	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "list_runs", "latency", time.Since(begin).String())
+		message := fmt.Sprintf("Method list_runs with the id %s for token %s took %s to complete", id, token, time.Since(begin))
+		if err != nil {
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		l.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ListRuns(ctx, token, id, pm)
+}
+
+func (lm *loggingMiddleware) DownloadRun(ctx context.Context, token, reportID, runID string) (run reports.Run, data io.ReadCloser, err error) {
+	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "download_run", "latency", time.Since(begin).String())
+		message := fmt.Sprintf("Method download_run with the id %s of report %s for token %s took %s to complete", runID, reportID, token, time.Since(begin))
+		if err != nil {
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		l.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.DownloadRun(ctx, token, reportID, runID)
+}
+
+func (lm *loggingMiddleware) RunScheduled(ctx context.Context) (err error) {
+	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "run_scheduled", "latency", time.Since(begin).String())
+		message := fmt.Sprintf("Method run_scheduled took %s to complete", time.Since(begin))
+		if err != nil {
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		l.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.RunScheduled(ctx)
+}
@@ -0,0 +1,113 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !test
+
+package api
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/reports"
+	"github.com/go-kit/kit/metrics"
+)
+
+var _ reports.Service = (*metricsMiddleware)(nil)
+
+type metricsMiddleware struct {
+	counter metrics.Counter
+	latency metrics.Histogram
+	svc     reports.Service
+}
+
+// MetricsMiddleware instruments core service by tracking request count and latency.
+func MetricsMiddleware(svc reports.Service, counter metrics.Counter, latency metrics.Histogram) reports.Service {
+	return &metricsMiddleware{
+		counter: counter,
+		latency: latency,
+		svc:     svc,
+	}
+}
+
+func (ms *metricsMiddleware) CreateReport(ctx context.Context, token string, r reports.Report) (reports.Report, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "create_report").Add(1)
+		ms.latency.With("method", "create_report").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.CreateReport(ctx, token, r)
+}
+
+func (ms *metricsMiddleware) ViewReport(ctx context.Context, token, id string) (reports.Report, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "view_report").Add(1)
+		ms.latency.With("method", "view_report").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ViewReport(ctx, token, id)
+}
+
+func (ms *metricsMiddleware) ListReports(ctx context.Context, token string, pm reports.PageMetadata) (reports.ReportsPage, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "list_reports").Add(1)
+		ms.latency.With("method", "list_reports").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ListReports(ctx, token, pm)
+}
+
+func (ms *metricsMiddleware) UpdateReport(ctx context.Context, token string, r reports.Report) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "update_report").Add(1)
+		ms.latency.With("method", "update_report").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.UpdateReport(ctx, token, r)
+}
+
+func (ms *metricsMiddleware) RemoveReport(ctx context.Context, token, id string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "remove_report").Add(1)
+		ms.latency.With("method", "remove_report").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.RemoveReport(ctx, token, id)
+}
+
+func (ms *metricsMiddleware) GenerateReport(ctx context.Context, token, id string) (reports.Run, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "generate_report").Add(1)
+		ms.latency.With("method", "generate_report").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.GenerateReport(ctx, token, id)
+}
+
+func (ms *metricsMiddleware) ListRuns(ctx context.Context, token, id string, pm reports.PageMetadata) (reports.RunsPage, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "list_runs").Add(1)
+		ms.latency.With("method", "list_runs").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ListRuns(ctx, token, id, pm)
+}
+
+func (ms *metricsMiddleware) DownloadRun(ctx context.Context, token, reportID, runID string) (reports.Run, io.ReadCloser, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "download_run").Add(1)
+		ms.latency.With("method", "download_run").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.DownloadRun(ctx, token, reportID, runID)
+}
+
+func (ms *metricsMiddleware) RunScheduled(ctx context.Context) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "run_scheduled").Add(1)
+		ms.latency.With("method", "run_scheduled").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.RunScheduled(ctx)
+}
@@ -0,0 +1,145 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"net/mail"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/internal/apiutil"
+	"github.com/MainfluxLabs/mainflux/readers"
+	"github.com/MainfluxLabs/mainflux/reports"
+)
+
+const (
+	maxLimitSize = 100
+	minPeriod    = time.Minute
+)
+
+type reportReq struct {
+	token       string
+	id          string
+	Name        string   `json:"name,omitempty"`
+	ChannelIDs  []string `json:"channel_ids,omitempty"`
+	GroupIDs    []string `json:"group_ids,omitempty"`
+	Metrics     []string `json:"metrics,omitempty"`
+	Aggregation string   `json:"aggregation,omitempty"`
+	Interval    string   `json:"interval,omitempty"`
+	Timezone    string   `json:"timezone,omitempty"`
+	Format      string   `json:"format"`
+	Period      string   `json:"period"`
+	Emails      []string `json:"emails,omitempty"`
+}
+
+func (req reportReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	if len(req.ChannelIDs) == 0 && len(req.GroupIDs) == 0 {
+		return apiutil.ErrMalformedEntity
+	}
+
+	if req.Format != reports.FormatCSV && req.Format != reports.FormatPDF {
+		return reports.ErrInvalidFormat
+	}
+
+	if period, err := readers.ParseInterval(req.Period); err != nil || period < minPeriod {
+		return apiutil.ErrMalformedEntity
+	}
+
+	if _, err := time.LoadLocation(req.Timezone); err != nil {
+		return apiutil.ErrMalformedEntity
+	}
+
+	for _, email := range req.Emails {
+		if _, err := mail.ParseAddress(email); err != nil {
+			return apiutil.ErrMalformedEntity
+		}
+	}
+
+	return req.validateAggregation()
+}
+
+// validateAggregation checks that the aggregation is provided together with
+// the interval of at least one second and the metrics to be aggregated.
+func (req reportReq) validateAggregation() error {
+	if req.Aggregation == "" && req.Interval == "" {
+		return nil
+	}
+
+	switch req.Aggregation {
+	case readers.AvgAggregation,
+		readers.MinAggregation,
+		readers.MaxAggregation,
+		readers.SumAggregation,
+		readers.CountAggregation:
+	default:
+		return apiutil.ErrMalformedEntity
+	}
+
+	if interval, err := readers.ParseInterval(req.Interval); err != nil || interval < time.Second {
+		return apiutil.ErrMalformedEntity
+	}
+
+	if len(req.Metrics) == 0 {
+		return apiutil.ErrMalformedEntity
+	}
+
+	return nil
+}
+
+type viewReq struct {
+	token string
+	id    string
+}
+
+func (req viewReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	if req.id == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return nil
+}
+
+type listReq struct {
+	token  string
+	id     string
+	offset uint64
+	limit  uint64
+}
+
+func (req listReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	if req.limit > maxLimitSize {
+		return apiutil.ErrLimitSize
+	}
+
+	return nil
+}
+
+type downloadReq struct {
+	token    string
+	reportID string
+	runID    string
+}
+
+func (req downloadReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	if req.reportID == "" || req.runID == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return nil
+}
@@ -0,0 +1,158 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux"
+)
+
+var (
+	_ mainflux.Response = (*reportRes)(nil)
+	_ mainflux.Response = (*reportsPageRes)(nil)
+	_ mainflux.Response = (*runRes)(nil)
+	_ mainflux.Response = (*runsPageRes)(nil)
+	_ mainflux.Response = (*emptyRes)(nil)
+)
+
+type pageRes struct {
+	Total  uint64 `json:"total"`
+	Offset uint64 `json:"offset"`
+	Limit  uint64 `json:"limit"`
+}
+
+type reportRes struct {
+	ID          string    `json:"id"`
+	Name        string    `json:"name,omitempty"`
+	ChannelIDs  []string  `json:"channel_ids"`
+	GroupIDs    []string  `json:"group_ids,omitempty"`
+	Metrics     []string  `json:"metrics,omitempty"`
+	Aggregation string    `json:"aggregation,omitempty"`
+	Interval    string    `json:"interval,omitempty"`
+	Timezone    string    `json:"timezone,omitempty"`
+	Format      string    `json:"format"`
+	Period      string    `json:"period"`
+	Emails      []string  `json:"emails,omitempty"`
+	NextRunAt   time.Time `json:"next_run_at"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+	created     bool
+}
+
+func (res reportRes) Code() int {
+	if res.created {
+		return http.StatusCreated
+	}
+
+	return http.StatusOK
+}
+
+func (res reportRes) Headers() map[string]string {
+	if res.created {
+		return map[string]string{
+			"Location": fmt.Sprintf("/reports/%s", res.ID),
+		}
+	}
+
+	return map[string]string{}
+}
+
+func (res reportRes) Empty() bool {
+	return false
+}
+
+type reportsPageRes struct {
+	pageRes
+	Reports []reportRes `json:"reports"`
+}
+
+func (res reportsPageRes) Code() int {
+	return http.StatusOK
+}
+
+func (res reportsPageRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res reportsPageRes) Empty() bool {
+	return false
+}
+
+type runRes struct {
+	ID        string    `json:"id"`
+	ReportID  string    `json:"report_id"`
+	Format    string    `json:"format"`
+	Status    string    `json:"status"`
+	Error     string    `json:"error,omitempty"`
+	Size      int64     `json:"size"`
+	From      time.Time `json:"from"`
+	To        time.Time `json:"to"`
+	CreatedAt time.Time `json:"created_at"`
+	created   bool
+}
+
+func (res runRes) Code() int {
+	if res.created {
+		return http.StatusCreated
+	}
+
+	return http.StatusOK
+}
+
+func (res runRes) Headers() map[string]string {
+	if res.created {
+		return map[string]string{
+			"Location": fmt.Sprintf("/reports/%s/runs/%s/file", res.ReportID, res.ID),
+		}
+	}
+
+	return map[string]string{}
+}
+
+func (res runRes) Empty() bool {
+	return false
+}
+
+type runsPageRes struct {
+	pageRes
+	Runs []runRes `json:"runs"`
+}
+
+func (res runsPageRes) Code() int {
+	return http.StatusOK
+}
+
+func (res runsPageRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res runsPageRes) Empty() bool {
+	return false
+}
+
+type downloadRes struct {
+	format string
+	size   int64
+	data   io.ReadCloser
+}
+
+type emptyRes struct {
+	code int
+}
+
+func (res emptyRes) Code() int {
+	return res.code
+}
+
+func (res emptyRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res emptyRes) Empty() bool {
+	return true
+}
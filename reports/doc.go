@@ -0,0 +1,6 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package reports contains the domain concept definitions needed to support
+// Mainflux scheduled report generation functionality.
+package reports
@@ -0,0 +1,37 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package email contains the email agent backed report mailer.
+package email
+
+import (
+	"github.com/MainfluxLabs/mainflux/internal/email"
+	"github.com/MainfluxLabs/mainflux/reports"
+)
+
+const reportTemplate = "report"
+
+var _ reports.Mailer = (*mailer)(nil)
+
+type mailer struct {
+	agent *email.Agent
+}
+
+// New instantiates the report mailer using the given email agent.
+func New(agent *email.Agent) reports.Mailer {
+	return &mailer{agent: agent}
+}
+
+func (m *mailer) Send(to []string, subject, content, filename string, data []byte) error {
+	msg := email.Message{
+		To:       to,
+		Template: reportTemplate,
+		Data: map[string]interface{}{
+			"Subject": subject,
+			"Content": content,
+		},
+		Attachments: []email.Attachment{{Name: filename, Data: data}},
+	}
+
+	return m.agent.Send(msg)
+}
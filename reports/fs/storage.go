@@ -0,0 +1,48 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package fs contains the filesystem backed generated reports storage.
+package fs
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/MainfluxLabs/mainflux/reports"
+)
+
+var _ reports.Storage = (*storage)(nil)
+
+type storage struct {
+	dir string
+}
+
+// New instantiates a storage keeping report files in the given directory.
+func New(dir string) (reports.Storage, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+
+	return &storage{dir: dir}, nil
+}
+
+func (s *storage) Save(id string, data []byte) error {
+	return os.WriteFile(s.path(id), data, 0644)
+}
+
+func (s *storage) Open(id string) (io.ReadCloser, error) {
+	return os.Open(s.path(id))
+}
+
+func (s *storage) Remove(id string) error {
+	if err := os.Remove(s.path(id)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	return nil
+}
+
+func (s *storage) path(id string) string {
+	return filepath.Join(s.dir, filepath.Base(id))
+}
@@ -0,0 +1,30 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/reports"
+)
+
+var _ reports.Groups = (*groupsMock)(nil)
+
+type groupsMock struct {
+	groups map[string][]string
+}
+
+// NewGroups returns a group channels resolver mock using the given group
+// to channel IDs mapping.
+func NewGroups(groups map[string][]string) reports.Groups {
+	return &groupsMock{groups: groups}
+}
+
+func (gm *groupsMock) GroupChannels(_, groupID string) ([]string, error) {
+	ids, ok := gm.groups[groupID]
+	if !ok {
+		return nil, errors.ErrNotFound
+	}
+
+	return ids, nil
+}
@@ -0,0 +1,45 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"sync"
+
+	"github.com/MainfluxLabs/mainflux/reports"
+)
+
+var _ reports.Mailer = (*Mailer)(nil)
+
+// Email represents the email sent by the mailer mock.
+type Email struct {
+	To       []string
+	Subject  string
+	Filename string
+	Data     []byte
+}
+
+// Mailer is a report mailer mock recording sent emails.
+type Mailer struct {
+	mu     sync.Mutex
+	err    error
+	Emails []Email
+}
+
+// NewMailer returns a recording report mailer mock, which fails with the
+// given error if it is not nil.
+func NewMailer(err error) *Mailer {
+	return &Mailer{err: err}
+}
+
+func (m *Mailer) Send(to []string, subject, _, filename string, data []byte) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.err != nil {
+		return m.err
+	}
+
+	m.Emails = append(m.Emails, Email{To: to, Subject: subject, Filename: filename, Data: data})
+	return nil
+}
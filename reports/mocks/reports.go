@@ -0,0 +1,194 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/reports"
+)
+
+var _ reports.ReportRepository = (*reportRepositoryMock)(nil)
+
+type reportRepositoryMock struct {
+	mu      sync.Mutex
+	reports map[string]reports.Report
+	runs    map[string]map[string]reports.Run
+}
+
+// NewReportRepository returns a new report repository mock.
+func NewReportRepository() reports.ReportRepository {
+	return &reportRepositoryMock{
+		reports: make(map[string]reports.Report),
+		runs:    make(map[string]map[string]reports.Run),
+	}
+}
+
+func (rrm *reportRepositoryMock) Save(_ context.Context, r reports.Report) error {
+	rrm.mu.Lock()
+	defer rrm.mu.Unlock()
+
+	if _, ok := rrm.reports[r.ID]; ok {
+		return errors.ErrConflict
+	}
+
+	rrm.reports[r.ID] = r
+	return nil
+}
+
+func (rrm *reportRepositoryMock) Update(_ context.Context, r reports.Report) error {
+	rrm.mu.Lock()
+	defer rrm.mu.Unlock()
+
+	if _, ok := rrm.reports[r.ID]; !ok {
+		return errors.ErrNotFound
+	}
+
+	rrm.reports[r.ID] = r
+	return nil
+}
+
+func (rrm *reportRepositoryMock) RetrieveByID(_ context.Context, id string) (reports.Report, error) {
+	rrm.mu.Lock()
+	defer rrm.mu.Unlock()
+
+	r, ok := rrm.reports[id]
+	if !ok {
+		return reports.Report{}, errors.ErrNotFound
+	}
+
+	return r, nil
+}
+
+func (rrm *reportRepositoryMock) RetrieveByOwner(_ context.Context, owner string, pm reports.PageMetadata) (reports.ReportsPage, error) {
+	rrm.mu.Lock()
+	defer rrm.mu.Unlock()
+
+	var items []reports.Report
+	for _, r := range rrm.reports {
+		if r.OwnerID == owner {
+			items = append(items, r)
+		}
+	}
+
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].ID < items[j].ID
+	})
+
+	start, end := bounds(pm, len(items))
+	page := reports.ReportsPage{
+		PageMetadata: reports.PageMetadata{
+			Total:  uint64(len(items)),
+			Offset: pm.Offset,
+			Limit:  pm.Limit,
+		},
+		Reports: items[start:end],
+	}
+
+	return page, nil
+}
+
+func (rrm *reportRepositoryMock) Remove(_ context.Context, owner, id string) error {
+	rrm.mu.Lock()
+	defer rrm.mu.Unlock()
+
+	r, ok := rrm.reports[id]
+	if !ok || r.OwnerID != owner {
+		return errors.ErrNotFound
+	}
+
+	delete(rrm.reports, id)
+	delete(rrm.runs, id)
+	return nil
+}
+
+func (rrm *reportRepositoryMock) ClaimDue(_ context.Context, now time.Time) ([]reports.Report, error) {
+	rrm.mu.Lock()
+	defer rrm.mu.Unlock()
+
+	var due []reports.Report
+	for id, r := range rrm.reports {
+		if r.NextRunAt.After(now) {
+			continue
+		}
+		due = append(due, r)
+
+		r.NextRunAt = r.NextRunAt.Add(r.Period)
+		rrm.reports[id] = r
+	}
+
+	return due, nil
+}
+
+func (rrm *reportRepositoryMock) SaveRun(_ context.Context, run reports.Run) error {
+	rrm.mu.Lock()
+	defer rrm.mu.Unlock()
+
+	if _, ok := rrm.reports[run.ReportID]; !ok {
+		return errors.ErrNotFound
+	}
+
+	if _, ok := rrm.runs[run.ReportID]; !ok {
+		rrm.runs[run.ReportID] = make(map[string]reports.Run)
+	}
+	rrm.runs[run.ReportID][run.ID] = run
+
+	return nil
+}
+
+func (rrm *reportRepositoryMock) RetrieveRun(_ context.Context, reportID, id string) (reports.Run, error) {
+	rrm.mu.Lock()
+	defer rrm.mu.Unlock()
+
+	run, ok := rrm.runs[reportID][id]
+	if !ok {
+		return reports.Run{}, errors.ErrNotFound
+	}
+
+	return run, nil
+}
+
+func (rrm *reportRepositoryMock) RetrieveRuns(_ context.Context, reportID string, pm reports.PageMetadata) (reports.RunsPage, error) {
+	rrm.mu.Lock()
+	defer rrm.mu.Unlock()
+
+	var items []reports.Run
+	for _, run := range rrm.runs[reportID] {
+		items = append(items, run)
+	}
+
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].ID > items[j].ID
+	})
+
+	start, end := bounds(pm, len(items))
+	page := reports.RunsPage{
+		PageMetadata: reports.PageMetadata{
+			Total:  uint64(len(items)),
+			Offset: pm.Offset,
+			Limit:  pm.Limit,
+		},
+		Runs: items[start:end],
+	}
+
+	return page, nil
+}
+
+func bounds(pm reports.PageMetadata, total int) (int, int) {
+	start := int(pm.Offset)
+	if start > total {
+		start = total
+	}
+
+	end := start + int(pm.Limit)
+	if pm.Limit == 0 || end > total {
+		end = total
+	}
+
+	return start, end
+}
@@ -0,0 +1,55 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"bytes"
+	"io"
+	"sync"
+
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/reports"
+)
+
+var _ reports.Storage = (*storageMock)(nil)
+
+type storageMock struct {
+	mu    sync.Mutex
+	files map[string][]byte
+}
+
+// NewStorage returns an in-memory report files storage mock.
+func NewStorage() reports.Storage {
+	return &storageMock{
+		files: make(map[string][]byte),
+	}
+}
+
+func (sm *storageMock) Save(id string, data []byte) error {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	sm.files[id] = data
+	return nil
+}
+
+func (sm *storageMock) Open(id string) (io.ReadCloser, error) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	data, ok := sm.files[id]
+	if !ok {
+		return nil, errors.ErrNotFound
+	}
+
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+func (sm *storageMock) Remove(id string) error {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	delete(sm.files, id)
+	return nil
+}
@@ -0,0 +1,121 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package reports
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// Layout of the landscape A4 pages using the built-in Courier font,
+// so that no fonts need to be embedded.
+const (
+	pageWidth    = 842
+	pageHeight   = 595
+	margin       = 24
+	fontSize     = 7
+	lineHeight   = 9
+	linesPerPage = (pageHeight-2*margin)/lineHeight - 3
+)
+
+// Widths of the report table columns, in characters.
+var widths = []int{36, 16, 36, 20, 8, 32, 24}
+
+// encodePDF renders the rows as the plain text table on as many pages as
+// needed. Every page repeats the title and the table header.
+func encodePDF(title string, rows [][]string) []byte {
+	var pages [][]string
+	for start := 0; start == 0 || start < len(rows); start += linesPerPage {
+		end := start + linesPerPage
+		if end > len(rows) {
+			end = len(rows)
+		}
+
+		lines := []string{fmt.Sprintf("%s (page %d of %d)", title, len(pages)+1, pageCount(len(rows))), "", formatRow(header)}
+		for _, row := range rows[start:end] {
+			lines = append(lines, formatRow(row))
+		}
+		pages = append(pages, lines)
+	}
+
+	buf := new(bytes.Buffer)
+	var offsets []int
+	object := func(body string) {
+		offsets = append(offsets, buf.Len())
+		fmt.Fprintf(buf, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	buf.WriteString("%PDF-1.4\n")
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+
+	var kids []string
+	for i := range pages {
+		kids = append(kids, fmt.Sprintf("%d 0 R", 4+2*i))
+	}
+	object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(pages)))
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Courier >>")
+
+	for i, lines := range pages {
+		object(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %d %d] /Resources << /Font << /F1 3 0 R >> >> /Contents %d 0 R >>", pageWidth, pageHeight, 5+2*i))
+
+		content := new(bytes.Buffer)
+		fmt.Fprintf(content, "BT /F1 %d Tf %d TL %d %d Td\n", fontSize, lineHeight, margin, pageHeight-margin)
+		for _, line := range lines {
+			fmt.Fprintf(content, "(%s) Tj T*\n", escape(line))
+		}
+		content.WriteString("ET")
+		object(fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", content.Len(), content.String()))
+	}
+
+	xref := buf.Len()
+	fmt.Fprintf(buf, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, off := range offsets {
+		fmt.Fprintf(buf, "%010d 00000 n \n", off)
+	}
+	fmt.Fprintf(buf, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+
+	return buf.Bytes()
+}
+
+func pageCount(rows int) int {
+	if rows == 0 {
+		return 1
+	}
+
+	return (rows + linesPerPage - 1) / linesPerPage
+}
+
+// formatRow pads or truncates the cells to the column widths.
+func formatRow(row []string) string {
+	cells := make([]string, len(row))
+	for i, cell := range row {
+		w := widths[i]
+		if len(cell) > w {
+			cell = cell[:w-1] + "~"
+		}
+		cells[i] = fmt.Sprintf("%-*s", w, cell)
+	}
+
+	return strings.TrimRight(strings.Join(cells, " "), " ")
+}
+
+// escape escapes the PDF string delimiters and replaces the characters
+// which can't be rendered by the standard font.
+func escape(s string) string {
+	var b strings.Builder
+	for _, c := range s {
+		switch {
+		case c == '(' || c == ')' || c == '\\':
+			b.WriteRune('\\')
+			b.WriteRune(c)
+		case c < ' ' || c > '~':
+			b.WriteRune('?')
+		default:
+			b.WriteRune(c)
+		}
+	}
+
+	return b.String()
+}
@@ -0,0 +1,92 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"fmt"
+
+	_ "github.com/jackc/pgx/v5/stdlib" // required for SQL access
+	"github.com/jmoiron/sqlx"
+	migrate "github.com/rubenv/sql-migrate"
+)
+
+// Config defines the options that are used when connecting to a PostgreSQL instance
+type Config struct {
+	Host        string
+	Port        string
+	User        string
+	Pass        string
+	Name        string
+	SSLMode     string
+	SSLCert     string
+	SSLKey      string
+	SSLRootCert string
+}
+
+// Connect creates a connection to the PostgreSQL instance and applies any
+// unapplied database migrations. A non-nil error is returned to indicate
+// failure.
+func Connect(cfg Config) (*sqlx.DB, error) {
+	url := fmt.Sprintf("host=%s port=%s user=%s dbname=%s password=%s sslmode=%s sslcert=%s sslkey=%s sslrootcert=%s", cfg.Host, cfg.Port, cfg.User, cfg.Name, cfg.Pass, cfg.SSLMode, cfg.SSLCert, cfg.SSLKey, cfg.SSLRootCert)
+
+	db, err := sqlx.Open("pgx", url)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := migrateDB(db); err != nil {
+		return nil, err
+	}
+
+	return db, nil
+}
+
+func migrateDB(db *sqlx.DB) error {
+	migrations := &migrate.MemoryMigrationSource{
+		Migrations: []*migrate.Migration{
+			{
+				Id: "reports_1",
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS reports (
+                        id           VARCHAR(254) PRIMARY KEY,
+                        owner_id     VARCHAR(254) NOT NULL,
+                        name         VARCHAR(254),
+                        channel_ids  JSONB NOT NULL,
+                        group_ids    JSONB NOT NULL,
+                        metrics      JSONB NOT NULL,
+                        aggregation  VARCHAR(16),
+                        agg_interval VARCHAR(32),
+                        timezone     VARCHAR(64),
+                        format       VARCHAR(16) NOT NULL,
+                        period       BIGINT NOT NULL,
+                        emails       JSONB NOT NULL,
+                        next_run_at  TIMESTAMPTZ NOT NULL,
+                        created_at   TIMESTAMPTZ NOT NULL,
+                        updated_at   TIMESTAMPTZ NOT NULL
+                    )`,
+					`CREATE INDEX IF NOT EXISTS reports_next_run_at_idx ON reports (next_run_at)`,
+					`CREATE TABLE IF NOT EXISTS report_runs (
+                        id         VARCHAR(254) PRIMARY KEY,
+                        report_id  VARCHAR(254) NOT NULL REFERENCES reports (id) ON DELETE CASCADE,
+                        format     VARCHAR(16) NOT NULL,
+                        status     VARCHAR(32) NOT NULL,
+                        error      TEXT,
+                        size       BIGINT NOT NULL,
+                        from_time  TIMESTAMPTZ NOT NULL,
+                        to_time    TIMESTAMPTZ NOT NULL,
+                        created_at TIMESTAMPTZ NOT NULL
+                    )`,
+					`CREATE INDEX IF NOT EXISTS report_runs_report_id_idx ON report_runs (report_id, created_at)`,
+				},
+				Down: []string{
+					"DROP TABLE IF EXISTS report_runs",
+					"DROP TABLE IF EXISTS reports",
+				},
+			},
+		},
+	}
+
+	_, err := migrate.Exec(db.DB, "postgres", migrations, migrate.Up)
+	return err
+}
@@ -0,0 +1,394 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/reports"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+const reportColumns = `id, owner_id, name, channel_ids, group_ids, metrics, aggregation, agg_interval,
+	timezone, format, period, emails, next_run_at, created_at, updated_at`
+
+var _ reports.ReportRepository = (*reportRepository)(nil)
+
+type reportRepository struct {
+	db Database
+}
+
+// NewReportRepository instantiates a PostgreSQL implementation of report repository.
+func NewReportRepository(db Database) reports.ReportRepository {
+	return &reportRepository{db: db}
+}
+
+func (rr reportRepository) Save(ctx context.Context, r reports.Report) error {
+	q := `INSERT INTO reports (` + reportColumns + `)
+		VALUES (:id, :owner_id, :name, :channel_ids, :group_ids, :metrics, :aggregation, :agg_interval,
+		:timezone, :format, :period, :emails, :next_run_at, :created_at, :updated_at)`
+
+	dbr, err := toDBReport(r)
+	if err != nil {
+		return errors.Wrap(errors.ErrCreateEntity, err)
+	}
+
+	if _, err := rr.db.NamedExecContext(ctx, q, dbr); err != nil {
+		if pgErr, ok := err.(*pgconn.PgError); ok && pgErr.Code == pgerrcode.UniqueViolation {
+			return errors.Wrap(errors.ErrConflict, err)
+		}
+		return errors.Wrap(errors.ErrCreateEntity, err)
+	}
+
+	return nil
+}
+
+func (rr reportRepository) Update(ctx context.Context, r reports.Report) error {
+	q := `UPDATE reports SET name = :name, channel_ids = :channel_ids, group_ids = :group_ids, metrics = :metrics,
+		aggregation = :aggregation, agg_interval = :agg_interval, timezone = :timezone, format = :format,
+		period = :period, emails = :emails, updated_at = :updated_at
+		WHERE id = :id AND owner_id = :owner_id`
+
+	dbr, err := toDBReport(r)
+	if err != nil {
+		return errors.Wrap(errors.ErrUpdateEntity, err)
+	}
+
+	res, err := rr.db.NamedExecContext(ctx, q, dbr)
+	if err != nil {
+		return errors.Wrap(errors.ErrUpdateEntity, err)
+	}
+
+	if cnt, err := res.RowsAffected(); err == nil && cnt == 0 {
+		return errors.ErrNotFound
+	}
+
+	return nil
+}
+
+func (rr reportRepository) RetrieveByID(ctx context.Context, id string) (reports.Report, error) {
+	q := `SELECT ` + reportColumns + ` FROM reports WHERE id = $1`
+
+	var dbr dbReport
+	if err := rr.db.QueryRowxContext(ctx, q, id).StructScan(&dbr); err != nil {
+		if err == sql.ErrNoRows {
+			return reports.Report{}, errors.Wrap(errors.ErrNotFound, err)
+		}
+		return reports.Report{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+
+	r, err := toReport(dbr)
+	if err != nil {
+		return reports.Report{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+
+	return r, nil
+}
+
+func (rr reportRepository) RetrieveByOwner(ctx context.Context, owner string, pm reports.PageMetadata) (reports.ReportsPage, error) {
+	q := `SELECT ` + reportColumns + ` FROM reports WHERE owner_id = :owner_id
+		ORDER BY created_at DESC LIMIT :limit OFFSET :offset`
+	params := map[string]interface{}{
+		"owner_id": owner,
+		"limit":    pm.Limit,
+		"offset":   pm.Offset,
+	}
+
+	items, err := rr.retrieve(ctx, q, params)
+	if err != nil {
+		return reports.ReportsPage{}, err
+	}
+
+	cq := `SELECT COUNT(*) FROM reports WHERE owner_id = :owner_id`
+	total, err := total(ctx, rr.db, cq, params)
+	if err != nil {
+		return reports.ReportsPage{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+
+	return reports.ReportsPage{
+		PageMetadata: reports.PageMetadata{
+			Total:  total,
+			Offset: pm.Offset,
+			Limit:  pm.Limit,
+		},
+		Reports: items,
+	}, nil
+}
+
+func (rr reportRepository) Remove(ctx context.Context, owner, id string) error {
+	q := `DELETE FROM reports WHERE id = :id AND owner_id = :owner_id`
+	params := map[string]interface{}{
+		"id":       id,
+		"owner_id": owner,
+	}
+
+	res, err := rr.db.NamedExecContext(ctx, q, params)
+	if err != nil {
+		return errors.Wrap(errors.ErrRemoveEntity, err)
+	}
+
+	if cnt, err := res.RowsAffected(); err == nil && cnt == 0 {
+		return errors.ErrNotFound
+	}
+
+	return nil
+}
+
+func (rr reportRepository) ClaimDue(ctx context.Context, now time.Time) ([]reports.Report, error) {
+	// Row locks taken by the update make concurrent claims of the same
+	// report wait and skip it once it is rescheduled.
+	q := `UPDATE reports SET next_run_at = next_run_at + make_interval(secs => period)
+		WHERE next_run_at <= :now
+		RETURNING id, owner_id, name, channel_ids, group_ids, metrics, aggregation, agg_interval,
+		timezone, format, period, emails, next_run_at - make_interval(secs => period) AS next_run_at,
+		created_at, updated_at`
+
+	return rr.retrieve(ctx, q, map[string]interface{}{"now": now})
+}
+
+func (rr reportRepository) SaveRun(ctx context.Context, run reports.Run) error {
+	q := `INSERT INTO report_runs (id, report_id, format, status, error, size, from_time, to_time, created_at)
+		VALUES (:id, :report_id, :format, :status, :error, :size, :from_time, :to_time, :created_at)`
+
+	if _, err := rr.db.NamedExecContext(ctx, q, toDBRun(run)); err != nil {
+		if pgErr, ok := err.(*pgconn.PgError); ok {
+			switch pgErr.Code {
+			case pgerrcode.UniqueViolation:
+				return errors.Wrap(errors.ErrConflict, err)
+			case pgerrcode.ForeignKeyViolation:
+				return errors.Wrap(errors.ErrNotFound, err)
+			}
+		}
+		return errors.Wrap(errors.ErrCreateEntity, err)
+	}
+
+	return nil
+}
+
+func (rr reportRepository) RetrieveRun(ctx context.Context, reportID, id string) (reports.Run, error) {
+	q := `SELECT id, report_id, format, status, error, size, from_time, to_time, created_at
+		FROM report_runs WHERE report_id = $1 AND id = $2`
+
+	var dbr dbRun
+	if err := rr.db.QueryRowxContext(ctx, q, reportID, id).StructScan(&dbr); err != nil {
+		if err == sql.ErrNoRows {
+			return reports.Run{}, errors.Wrap(errors.ErrNotFound, err)
+		}
+		return reports.Run{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+
+	return toRun(dbr), nil
+}
+
+func (rr reportRepository) RetrieveRuns(ctx context.Context, reportID string, pm reports.PageMetadata) (reports.RunsPage, error) {
+	q := `SELECT id, report_id, format, status, error, size, from_time, to_time, created_at
+		FROM report_runs WHERE report_id = :report_id ORDER BY created_at DESC LIMIT :limit OFFSET :offset`
+	params := map[string]interface{}{
+		"report_id": reportID,
+		"limit":     pm.Limit,
+		"offset":    pm.Offset,
+	}
+
+	rows, err := rr.db.NamedQueryContext(ctx, q, params)
+	if err != nil {
+		return reports.RunsPage{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+	defer rows.Close()
+
+	var items []reports.Run
+	for rows.Next() {
+		var dbr dbRun
+		if err := rows.StructScan(&dbr); err != nil {
+			return reports.RunsPage{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+		}
+		items = append(items, toRun(dbr))
+	}
+
+	cq := `SELECT COUNT(*) FROM report_runs WHERE report_id = :report_id`
+	total, err := total(ctx, rr.db, cq, params)
+	if err != nil {
+		return reports.RunsPage{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+
+	return reports.RunsPage{
+		PageMetadata: reports.PageMetadata{
+			Total:  total,
+			Offset: pm.Offset,
+			Limit:  pm.Limit,
+		},
+		Runs: items,
+	}, nil
+}
+
+func (rr reportRepository) retrieve(ctx context.Context, query string, params interface{}) ([]reports.Report, error) {
+	rows, err := rr.db.NamedQueryContext(ctx, query, params)
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+	defer rows.Close()
+
+	var items []reports.Report
+	for rows.Next() {
+		var dbr dbReport
+		if err := rows.StructScan(&dbr); err != nil {
+			return nil, errors.Wrap(errors.ErrRetrieveEntity, err)
+		}
+
+		r, err := toReport(dbr)
+		if err != nil {
+			return nil, errors.Wrap(errors.ErrRetrieveEntity, err)
+		}
+		items = append(items, r)
+	}
+
+	return items, nil
+}
+
+func total(ctx context.Context, db Database, query string, params interface{}) (uint64, error) {
+	rows, err := db.NamedQueryContext(ctx, query, params)
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	var total uint64
+	if rows.Next() {
+		if err := rows.Scan(&total); err != nil {
+			return 0, err
+		}
+	}
+
+	return total, nil
+}
+
+type dbReport struct {
+	ID          string         `db:"id"`
+	OwnerID     string         `db:"owner_id"`
+	Name        sql.NullString `db:"name"`
+	ChannelIDs  []byte         `db:"channel_ids"`
+	GroupIDs    []byte         `db:"group_ids"`
+	Metrics     []byte         `db:"metrics"`
+	Aggregation sql.NullString `db:"aggregation"`
+	Interval    sql.NullString `db:"agg_interval"`
+	Timezone    sql.NullString `db:"timezone"`
+	Format      string         `db:"format"`
+	Period      int64          `db:"period"`
+	Emails      []byte         `db:"emails"`
+	NextRunAt   time.Time      `db:"next_run_at"`
+	CreatedAt   time.Time      `db:"created_at"`
+	UpdatedAt   time.Time      `db:"updated_at"`
+}
+
+func toDBReport(r reports.Report) (dbReport, error) {
+	lists := make([][]byte, 4)
+	for i, l := range [][]string{r.ChannelIDs, r.GroupIDs, r.Metrics, r.Emails} {
+		if l == nil {
+			l = []string{}
+		}
+		data, err := json.Marshal(l)
+		if err != nil {
+			return dbReport{}, err
+		}
+		lists[i] = data
+	}
+
+	return dbReport{
+		ID:          r.ID,
+		OwnerID:     r.OwnerID,
+		Name:        nullString(r.Name),
+		ChannelIDs:  lists[0],
+		GroupIDs:    lists[1],
+		Metrics:     lists[2],
+		Aggregation: nullString(r.Aggregation),
+		Interval:    nullString(r.Interval),
+		Timezone:    nullString(r.Timezone),
+		Format:      r.Format,
+		Period:      int64(r.Period / time.Second),
+		Emails:      lists[3],
+		NextRunAt:   r.NextRunAt,
+		CreatedAt:   r.CreatedAt,
+		UpdatedAt:   r.UpdatedAt,
+	}, nil
+}
+
+func toReport(dbr dbReport) (reports.Report, error) {
+	r := reports.Report{
+		ID:          dbr.ID,
+		OwnerID:     dbr.OwnerID,
+		Name:        dbr.Name.String,
+		Aggregation: dbr.Aggregation.String,
+		Interval:    dbr.Interval.String,
+		Timezone:    dbr.Timezone.String,
+		Format:      dbr.Format,
+		Period:      time.Duration(dbr.Period) * time.Second,
+		NextRunAt:   dbr.NextRunAt.UTC(),
+		CreatedAt:   dbr.CreatedAt.UTC(),
+		UpdatedAt:   dbr.UpdatedAt.UTC(),
+	}
+
+	for _, l := range []struct {
+		data []byte
+		dest *[]string
+	}{
+		{dbr.ChannelIDs, &r.ChannelIDs},
+		{dbr.GroupIDs, &r.GroupIDs},
+		{dbr.Metrics, &r.Metrics},
+		{dbr.Emails, &r.Emails},
+	} {
+		if err := json.Unmarshal(l.data, l.dest); err != nil {
+			return reports.Report{}, err
+		}
+	}
+
+	return r, nil
+}
+
+type dbRun struct {
+	ID        string         `db:"id"`
+	ReportID  string         `db:"report_id"`
+	Format    string         `db:"format"`
+	Status    string         `db:"status"`
+	Error     sql.NullString `db:"error"`
+	Size      int64          `db:"size"`
+	From      time.Time      `db:"from_time"`
+	To        time.Time      `db:"to_time"`
+	CreatedAt time.Time      `db:"created_at"`
+}
+
+func toDBRun(run reports.Run) dbRun {
+	return dbRun{
+		ID:        run.ID,
+		ReportID:  run.ReportID,
+		Format:    run.Format,
+		Status:    run.Status,
+		Error:     nullString(run.Error),
+		Size:      run.Size,
+		From:      run.From,
+		To:        run.To,
+		CreatedAt: run.CreatedAt,
+	}
+}
+
+func toRun(dbr dbRun) reports.Run {
+	return reports.Run{
+		ID:        dbr.ID,
+		ReportID:  dbr.ReportID,
+		Format:    dbr.Format,
+		Status:    dbr.Status,
+		Error:     dbr.Error.String,
+		Size:      dbr.Size,
+		From:      dbr.From.UTC(),
+		To:        dbr.To.UTC(),
+		CreatedAt: dbr.CreatedAt.UTC(),
+	}
+}
+
+func nullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
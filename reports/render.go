@@ -0,0 +1,136 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package reports
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/pkg/transformers/senml"
+	"github.com/MainfluxLabs/mainflux/readers"
+)
+
+const pageLimit = 1000
+
+var header = []string{"channel", "subtopic", "publisher", "name", "unit", "time", "value"}
+
+// render reads the report messages in the given time range and encodes
+// them in the report format. Messages are ordered by channel and metric,
+// and then by time.
+func (rs *reportsService) render(r Report, from, to time.Time) ([]byte, error) {
+	loc, err := time.LoadLocation(r.Timezone)
+	if err != nil {
+		return nil, err
+	}
+
+	names := r.Metrics
+	if len(names) == 0 {
+		names = []string{""}
+	}
+
+	rows := [][]string{}
+	for _, chID := range r.ChannelIDs {
+		for _, name := range names {
+			msgs, err := rs.read(chID, name, r, from, to)
+			if err != nil {
+				return nil, err
+			}
+			for _, m := range msgs {
+				rows = append(rows, toRow(m, loc))
+			}
+		}
+	}
+
+	switch r.Format {
+	case FormatCSV:
+		return encodeCSV(rows)
+	case FormatPDF:
+		title := fmt.Sprintf("Report %s, %s - %s", reportName(r), from.In(loc).Format(time.RFC3339), to.In(loc).Format(time.RFC3339))
+		return encodePDF(title, rows), nil
+	default:
+		return nil, ErrInvalidFormat
+	}
+}
+
+// read reads all SenML messages of the channel metric, page by page.
+func (rs *reportsService) read(chID, name string, r Report, from, to time.Time) ([]senml.Message, error) {
+	var msgs []senml.Message
+	for offset := uint64(0); ; offset += pageLimit {
+		pm := readers.PageMetadata{
+			Offset:      offset,
+			Limit:       pageLimit,
+			Name:        name,
+			From:        toSeconds(from),
+			To:          toSeconds(to),
+			Aggregation: r.Aggregation,
+			Interval:    r.Interval,
+			Timezone:    r.Timezone,
+		}
+		page, err := rs.messages.ListChannelMessages(chID, pm)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, m := range page.Messages {
+			if msg, ok := m.(senml.Message); ok {
+				msgs = append(msgs, msg)
+			}
+		}
+
+		if offset+pageLimit >= page.Total {
+			break
+		}
+	}
+
+	sort.SliceStable(msgs, func(i, j int) bool {
+		return msgs[i].Time < msgs[j].Time
+	})
+
+	return msgs, nil
+}
+
+func toRow(m senml.Message, loc *time.Location) []string {
+	sec, frac := int64(m.Time), m.Time-float64(int64(m.Time))
+	t := time.Unix(sec, int64(frac*float64(time.Second))).In(loc)
+
+	return []string{m.Channel, m.Subtopic, m.Publisher, m.Name, m.Unit, t.Format(time.RFC3339Nano), value(m)}
+}
+
+func value(m senml.Message) string {
+	switch {
+	case m.Value != nil:
+		return strconv.FormatFloat(*m.Value, 'f', -1, 64)
+	case m.StringValue != nil:
+		return *m.StringValue
+	case m.BoolValue != nil:
+		return strconv.FormatBool(*m.BoolValue)
+	case m.DataValue != nil:
+		return *m.DataValue
+	case m.Sum != nil:
+		return strconv.FormatFloat(*m.Sum, 'f', -1, 64)
+	default:
+		return ""
+	}
+}
+
+func encodeCSV(rows [][]string) ([]byte, error) {
+	buf := new(bytes.Buffer)
+	w := csv.NewWriter(buf)
+	if err := w.Write(header); err != nil {
+		return nil, err
+	}
+	if err := w.WriteAll(rows); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func toSeconds(t time.Time) float64 {
+	return float64(t.UnixNano()) / float64(time.Second)
+}
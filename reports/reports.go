@@ -0,0 +1,135 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package reports
+
+import (
+	"context"
+	"io"
+	"time"
+)
+
+// Report formats.
+const (
+	FormatCSV = "csv"
+	FormatPDF = "pdf"
+)
+
+// Run statuses.
+const (
+	RunSucceeded = "succeeded"
+	RunFailed    = "failed"
+)
+
+// Report represents the definition of the report which is periodically
+// generated from the messages of its channels. Every run covers the last
+// period of messages, optionally aggregated over the time buckets of the
+// interval. Generated reports are stored for download and emailed to the
+// recipients, if there are any.
+type Report struct {
+	ID          string
+	OwnerID     string
+	Name        string
+	ChannelIDs  []string
+	GroupIDs    []string
+	Metrics     []string
+	Aggregation string
+	Interval    string
+	Timezone    string
+	Format      string
+	Period      time.Duration
+	Emails      []string
+	NextRunAt   time.Time
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// ReportsPage contains page related metadata as well as list of reports
+// that belong to this page.
+type ReportsPage struct {
+	PageMetadata
+	Reports []Report
+}
+
+// Run represents a single generation of the report.
+type Run struct {
+	ID        string
+	ReportID  string
+	Format    string
+	Status    string
+	Error     string
+	Size      int64
+	From      time.Time
+	To        time.Time
+	CreatedAt time.Time
+}
+
+// RunsPage contains page related metadata as well as list of report runs
+// that belong to this page.
+type RunsPage struct {
+	PageMetadata
+	Runs []Run
+}
+
+// PageMetadata contains page metadata that helps navigation.
+type PageMetadata struct {
+	Total  uint64
+	Offset uint64
+	Limit  uint64
+}
+
+// ReportRepository specifies a report and run persistence API.
+type ReportRepository interface {
+	// Save persists the report.
+	Save(ctx context.Context, r Report) error
+
+	// Update updates the report definition.
+	Update(ctx context.Context, r Report) error
+
+	// RetrieveByID retrieves the report having the provided identifier.
+	RetrieveByID(ctx context.Context, id string) (Report, error)
+
+	// RetrieveByOwner retrieves the subset of reports owned by the specified user.
+	RetrieveByOwner(ctx context.Context, owner string, pm PageMetadata) (ReportsPage, error)
+
+	// Remove removes the report having the provided identifier.
+	Remove(ctx context.Context, owner, id string) error
+
+	// ClaimDue reschedules the reports due at the given time to their next
+	// run and returns them with the next run time set to the claimed run.
+	// Every due run is claimed by a single caller only.
+	ClaimDue(ctx context.Context, now time.Time) ([]Report, error)
+
+	// SaveRun persists the report run.
+	SaveRun(ctx context.Context, run Run) error
+
+	// RetrieveRun retrieves the run of the report.
+	RetrieveRun(ctx context.Context, reportID, id string) (Run, error)
+
+	// RetrieveRuns retrieves the subset of the report runs, newest first.
+	RetrieveRuns(ctx context.Context, reportID string, pm PageMetadata) (RunsPage, error)
+}
+
+// Storage specifies a generated report files storage API.
+type Storage interface {
+	// Save stores the report file.
+	Save(id string, data []byte) error
+
+	// Open opens the stored report file for reading.
+	Open(id string) (io.ReadCloser, error)
+
+	// Remove removes the stored report file.
+	Remove(id string) error
+}
+
+// Mailer specifies an API for delivering the generated reports.
+type Mailer interface {
+	// Send emails the report file as the attachment to the recipients.
+	Send(to []string, subject, content, filename string, data []byte) error
+}
+
+// Groups specifies an API for resolving the channels of the report groups.
+type Groups interface {
+	// GroupChannels retrieves IDs of all channels assigned to the group.
+	GroupChannels(token, groupID string) ([]string, error)
+}
@@ -0,0 +1,30 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package reports
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/logger"
+)
+
+// Start checks for due reports on every tick of the given interval and
+// generates them, until the context is canceled.
+func Start(ctx context.Context, svc Service, interval time.Duration, logger logger.Logger) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := svc.RunScheduled(ctx); err != nil {
+				logger.Warn(fmt.Sprintf("Failed to generate scheduled reports: %s", err))
+			}
+		}
+	}
+}
@@ -0,0 +1,367 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package reports
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/readers"
+)
+
+var (
+	// ErrInvalidFormat indicates an unsupported report format.
+	ErrInvalidFormat = errors.New("invalid report format")
+
+	// ErrGenerateReport indicates failure to generate or deliver the report.
+	ErrGenerateReport = errors.New("failed to generate report")
+)
+
+// Service specifies an API that must be fullfiled by the domain service
+// implementation, and all of its decorators (e.g. logging & metrics).
+type Service interface {
+	// CreateReport creates the report definition and schedules its first
+	// run one period from now.
+	CreateReport(ctx context.Context, token string, r Report) (Report, error)
+
+	// ViewReport retrieves the report definition.
+	ViewReport(ctx context.Context, token, id string) (Report, error)
+
+	// ListReports retrieves the reports created by the user.
+	ListReports(ctx context.Context, token string, pm PageMetadata) (ReportsPage, error)
+
+	// UpdateReport updates the report definition, keeping its schedule.
+	UpdateReport(ctx context.Context, token string, r Report) error
+
+	// RemoveReport removes the report definition and its runs.
+	RemoveReport(ctx context.Context, token, id string) error
+
+	// GenerateReport generates the report for the last period immediately.
+	GenerateReport(ctx context.Context, token, id string) (Run, error)
+
+	// ListRuns retrieves the run history of the report.
+	ListRuns(ctx context.Context, token, id string, pm PageMetadata) (RunsPage, error)
+
+	// DownloadRun returns the file generated by the report run.
+	DownloadRun(ctx context.Context, token, reportID, runID string) (Run, io.ReadCloser, error)
+
+	// RunScheduled generates all reports whose scheduled run is due.
+	RunScheduled(ctx context.Context) error
+}
+
+var _ Service = (*reportsService)(nil)
+
+type reportsService struct {
+	auth       mainflux.AuthServiceClient
+	things     mainflux.ThingsServiceClient
+	groups     Groups
+	reports    ReportRepository
+	messages   readers.MessageRepository
+	storage    Storage
+	mailer     Mailer
+	idProvider mainflux.IDProvider
+}
+
+// New instantiates the reports service implementation.
+func New(auth mainflux.AuthServiceClient, things mainflux.ThingsServiceClient, groups Groups, reports ReportRepository, messages readers.MessageRepository, storage Storage, mailer Mailer, idp mainflux.IDProvider) Service {
+	return &reportsService{
+		auth:       auth,
+		things:     things,
+		groups:     groups,
+		reports:    reports,
+		messages:   messages,
+		storage:    storage,
+		mailer:     mailer,
+		idProvider: idp,
+	}
+}
+
+func (rs *reportsService) CreateReport(ctx context.Context, token string, r Report) (Report, error) {
+	res, err := rs.auth.Identify(ctx, &mainflux.Token{Value: token})
+	if err != nil {
+		return Report{}, errors.Wrap(errors.ErrAuthentication, err)
+	}
+
+	if r.ChannelIDs, err = rs.resolveChannels(ctx, token, res.GetId(), r); err != nil {
+		return Report{}, err
+	}
+
+	id, err := rs.idProvider.ID()
+	if err != nil {
+		return Report{}, err
+	}
+
+	timestamp := getTimestamp()
+	r.ID = id
+	r.OwnerID = res.GetId()
+	r.NextRunAt = timestamp.Add(r.Period)
+	r.CreatedAt = timestamp
+	r.UpdatedAt = timestamp
+
+	if err := rs.reports.Save(ctx, r); err != nil {
+		return Report{}, err
+	}
+
+	return r, nil
+}
+
+func (rs *reportsService) ViewReport(ctx context.Context, token, id string) (Report, error) {
+	res, err := rs.auth.Identify(ctx, &mainflux.Token{Value: token})
+	if err != nil {
+		return Report{}, errors.Wrap(errors.ErrAuthentication, err)
+	}
+
+	return rs.ownedReport(ctx, res.GetId(), id)
+}
+
+func (rs *reportsService) ListReports(ctx context.Context, token string, pm PageMetadata) (ReportsPage, error) {
+	res, err := rs.auth.Identify(ctx, &mainflux.Token{Value: token})
+	if err != nil {
+		return ReportsPage{}, errors.Wrap(errors.ErrAuthentication, err)
+	}
+
+	return rs.reports.RetrieveByOwner(ctx, res.GetId(), pm)
+}
+
+func (rs *reportsService) UpdateReport(ctx context.Context, token string, r Report) error {
+	res, err := rs.auth.Identify(ctx, &mainflux.Token{Value: token})
+	if err != nil {
+		return errors.Wrap(errors.ErrAuthentication, err)
+	}
+
+	saved, err := rs.ownedReport(ctx, res.GetId(), r.ID)
+	if err != nil {
+		return err
+	}
+
+	if r.ChannelIDs, err = rs.resolveChannels(ctx, token, res.GetId(), r); err != nil {
+		return err
+	}
+
+	r.OwnerID = saved.OwnerID
+	r.NextRunAt = saved.NextRunAt
+	r.CreatedAt = saved.CreatedAt
+	r.UpdatedAt = getTimestamp()
+
+	return rs.reports.Update(ctx, r)
+}
+
+func (rs *reportsService) RemoveReport(ctx context.Context, token, id string) error {
+	res, err := rs.auth.Identify(ctx, &mainflux.Token{Value: token})
+	if err != nil {
+		return errors.Wrap(errors.ErrAuthentication, err)
+	}
+
+	if _, err := rs.ownedReport(ctx, res.GetId(), id); err != nil {
+		return err
+	}
+
+	var runIDs []string
+	for offset := uint64(0); ; offset += pageLimit {
+		page, err := rs.reports.RetrieveRuns(ctx, id, PageMetadata{Offset: offset, Limit: pageLimit})
+		if err != nil {
+			return err
+		}
+		for _, run := range page.Runs {
+			runIDs = append(runIDs, run.ID)
+		}
+		if offset+pageLimit >= page.Total {
+			break
+		}
+	}
+
+	if err := rs.reports.Remove(ctx, res.GetId(), id); err != nil {
+		return err
+	}
+
+	for _, runID := range runIDs {
+		rs.storage.Remove(runID)
+	}
+
+	return nil
+}
+
+func (rs *reportsService) GenerateReport(ctx context.Context, token, id string) (Run, error) {
+	res, err := rs.auth.Identify(ctx, &mainflux.Token{Value: token})
+	if err != nil {
+		return Run{}, errors.Wrap(errors.ErrAuthentication, err)
+	}
+
+	r, err := rs.ownedReport(ctx, res.GetId(), id)
+	if err != nil {
+		return Run{}, err
+	}
+
+	to := getTimestamp()
+	return rs.generate(ctx, r, to.Add(-r.Period), to)
+}
+
+func (rs *reportsService) ListRuns(ctx context.Context, token, id string, pm PageMetadata) (RunsPage, error) {
+	res, err := rs.auth.Identify(ctx, &mainflux.Token{Value: token})
+	if err != nil {
+		return RunsPage{}, errors.Wrap(errors.ErrAuthentication, err)
+	}
+
+	if _, err := rs.ownedReport(ctx, res.GetId(), id); err != nil {
+		return RunsPage{}, err
+	}
+
+	return rs.reports.RetrieveRuns(ctx, id, pm)
+}
+
+func (rs *reportsService) DownloadRun(ctx context.Context, token, reportID, runID string) (Run, io.ReadCloser, error) {
+	res, err := rs.auth.Identify(ctx, &mainflux.Token{Value: token})
+	if err != nil {
+		return Run{}, nil, errors.Wrap(errors.ErrAuthentication, err)
+	}
+
+	if _, err := rs.ownedReport(ctx, res.GetId(), reportID); err != nil {
+		return Run{}, nil, err
+	}
+
+	run, err := rs.reports.RetrieveRun(ctx, reportID, runID)
+	if err != nil {
+		return Run{}, nil, err
+	}
+
+	if run.Status != RunSucceeded {
+		return Run{}, nil, errors.ErrNotFound
+	}
+
+	data, err := rs.storage.Open(run.ID)
+	if err != nil {
+		return Run{}, nil, errors.Wrap(errors.ErrNotFound, err)
+	}
+
+	return run, data, nil
+}
+
+func (rs *reportsService) RunScheduled(ctx context.Context) error {
+	due, err := rs.reports.ClaimDue(ctx, getTimestamp())
+	if err != nil {
+		return err
+	}
+
+	// Reports are generated independently, so that the failure of one
+	// of them doesn't prevent the others from being delivered.
+	var errs error
+	for _, r := range due {
+		if _, err := rs.generate(ctx, r, r.NextRunAt.Add(-r.Period), r.NextRunAt); err != nil && errs == nil {
+			errs = err
+		}
+	}
+
+	return errs
+}
+
+// generate renders the report of the messages in the given time range,
+// stores and delivers it, and records the run.
+func (rs *reportsService) generate(ctx context.Context, r Report, from, to time.Time) (Run, error) {
+	id, err := rs.idProvider.ID()
+	if err != nil {
+		return Run{}, err
+	}
+
+	run := Run{
+		ID:        id,
+		ReportID:  r.ID,
+		Format:    r.Format,
+		Status:    RunSucceeded,
+		From:      from,
+		To:        to,
+		CreatedAt: getTimestamp(),
+	}
+
+	data, genErr := rs.render(r, from, to)
+	if genErr == nil {
+		genErr = rs.storage.Save(run.ID, data)
+	}
+	if genErr == nil && len(r.Emails) > 0 {
+		subject := fmt.Sprintf("Report %s", reportName(r))
+		content := fmt.Sprintf("Report %s for the period from %s to %s is attached.", reportName(r), from.Format(time.RFC3339), to.Format(time.RFC3339))
+		genErr = rs.mailer.Send(r.Emails, subject, content, filename(r, run), data)
+	}
+	if genErr != nil {
+		run.Status = RunFailed
+		run.Error = genErr.Error()
+	} else {
+		run.Size = int64(len(data))
+	}
+
+	if err := rs.reports.SaveRun(ctx, run); err != nil {
+		return Run{}, err
+	}
+
+	if genErr != nil {
+		return run, errors.Wrap(ErrGenerateReport, genErr)
+	}
+
+	return run, nil
+}
+
+// resolveChannels checks that the user owns the report channels and adds
+// the channels of the report groups. Group channels are resolved when the
+// report is saved, so channels assigned to the groups later are not reported.
+func (rs *reportsService) resolveChannels(ctx context.Context, token, owner string, r Report) ([]string, error) {
+	seen := make(map[string]bool)
+	var ids []string
+	for _, chID := range r.ChannelIDs {
+		if seen[chID] {
+			continue
+		}
+		if _, err := rs.things.IsChannelOwner(ctx, &mainflux.ChannelOwnerReq{Owner: owner, ChanID: chID}); err != nil {
+			return nil, errors.Wrap(errors.ErrAuthorization, err)
+		}
+		seen[chID] = true
+		ids = append(ids, chID)
+	}
+
+	for _, groupID := range r.GroupIDs {
+		chIDs, err := rs.groups.GroupChannels(token, groupID)
+		if err != nil {
+			return nil, err
+		}
+		for _, chID := range chIDs {
+			if !seen[chID] {
+				seen[chID] = true
+				ids = append(ids, chID)
+			}
+		}
+	}
+
+	return ids, nil
+}
+
+func (rs *reportsService) ownedReport(ctx context.Context, owner, id string) (Report, error) {
+	r, err := rs.reports.RetrieveByID(ctx, id)
+	if err != nil {
+		return Report{}, err
+	}
+
+	if r.OwnerID != owner {
+		return Report{}, errors.ErrNotFound
+	}
+
+	return r, nil
+}
+
+func reportName(r Report) string {
+	if r.Name != "" {
+		return r.Name
+	}
+
+	return r.ID
+}
+
+func filename(r Report, run Run) string {
+	return fmt.Sprintf("%s-%s.%s", reportName(r), run.To.Format("20060102T150405Z"), run.Format)
+}
+
+func getTimestamp() time.Time {
+	return time.Now().UTC().Round(time.Millisecond)
+}
@@ -0,0 +1,315 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package reports_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/mocks"
+	"github.com/MainfluxLabs/mainflux/pkg/transformers/senml"
+	"github.com/MainfluxLabs/mainflux/pkg/uuid"
+	"github.com/MainfluxLabs/mainflux/readers"
+	rmocks "github.com/MainfluxLabs/mainflux/readers/mocks"
+	"github.com/MainfluxLabs/mainflux/reports"
+	repmocks "github.com/MainfluxLabs/mainflux/reports/mocks"
+	"github.com/MainfluxLabs/mainflux/users"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	userEmail      = "user@example.com"
+	otherUserEmail = "otherUser@example.com"
+	password       = "password"
+	channelID      = "channel-1"
+	groupID        = "group-1"
+	msgName        = "temperature"
+	recipient      = "recipient@example.com"
+)
+
+var (
+	user      = users.User{ID: "user-1", Email: userEmail, Password: password}
+	otherUser = users.User{ID: "user-2", Email: otherUserEmail, Password: password}
+	usersList = []users.User{user, otherUser}
+	groups    = map[string][]string{groupID: {channelID, "channel-2"}}
+	report    = reports.Report{
+		Name:       "daily",
+		ChannelIDs: []string{channelID},
+		Metrics:    []string{msgName},
+		Format:     reports.FormatCSV,
+		Period:     24 * time.Hour,
+	}
+)
+
+func newService(repo reports.ReportRepository, mailer reports.Mailer) reports.Service {
+	auth := mocks.NewAuthService("", usersList)
+	things := mocks.NewThingsServiceClient(map[string]string{user.ID: channelID}, nil)
+
+	now := time.Now()
+	var msgs []readers.Message
+	for i := 0; i < 5; i++ {
+		val := float64(i)
+		msgs = append(msgs, senml.Message{
+			Channel: channelID,
+			Name:    msgName,
+			Unit:    "Cel",
+			Time:    float64(now.Add(-time.Duration(i) * time.Hour).Unix()),
+			Value:   &val,
+		})
+	}
+	messages := rmocks.NewMessageRepository(channelID, msgs)
+
+	return reports.New(auth, things, repmocks.NewGroups(groups), repo, messages, repmocks.NewStorage(), mailer, uuid.NewMock())
+}
+
+func TestCreateReport(t *testing.T) {
+	svc := newService(repmocks.NewReportRepository(), repmocks.NewMailer(nil))
+
+	cases := []struct {
+		desc     string
+		token    string
+		report   reports.Report
+		channels []string
+		err      error
+	}{
+		{
+			desc:     "create report",
+			token:    userEmail,
+			report:   report,
+			channels: []string{channelID},
+			err:      nil,
+		},
+		{
+			desc:     "create report of group channels",
+			token:    userEmail,
+			report:   reports.Report{ChannelIDs: []string{channelID}, GroupIDs: []string{groupID}, Format: reports.FormatPDF, Period: time.Hour},
+			channels: []string{channelID, "channel-2"},
+			err:      nil,
+		},
+		{
+			desc:   "create report of non-existing group",
+			token:  userEmail,
+			report: reports.Report{GroupIDs: []string{"non-existing"}, Format: reports.FormatCSV, Period: time.Hour},
+			err:    errors.ErrNotFound,
+		},
+		{
+			desc:   "create report of channel owned by other user",
+			token:  otherUserEmail,
+			report: report,
+			err:    errors.ErrAuthorization,
+		},
+		{
+			desc:   "create report with invalid token",
+			token:  "invalid",
+			report: report,
+			err:    errors.ErrAuthentication,
+		},
+	}
+
+	for _, tc := range cases {
+		r, err := svc.CreateReport(context.Background(), tc.token, tc.report)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected error %s got %s\n", tc.desc, tc.err, err))
+		if tc.err != nil {
+			continue
+		}
+		assert.Equal(t, tc.channels, r.ChannelIDs, fmt.Sprintf("%s: expected channels %v got %v\n", tc.desc, tc.channels, r.ChannelIDs))
+		assert.Equal(t, r.CreatedAt.Add(tc.report.Period), r.NextRunAt, fmt.Sprintf("%s: expected first run one period after creation\n", tc.desc))
+	}
+}
+
+func TestGenerateReport(t *testing.T) {
+	mailer := repmocks.NewMailer(nil)
+	svc := newService(repmocks.NewReportRepository(), mailer)
+
+	emailed := report
+	emailed.Emails = []string{recipient}
+	csvReport, err := svc.CreateReport(context.Background(), userEmail, emailed)
+	require.Nil(t, err, fmt.Sprintf("unexpected error creating report: %s", err))
+
+	pdf := report
+	pdf.Format = reports.FormatPDF
+	pdfReport, err := svc.CreateReport(context.Background(), userEmail, pdf)
+	require.Nil(t, err, fmt.Sprintf("unexpected error creating report: %s", err))
+
+	cases := []struct {
+		desc    string
+		token   string
+		id      string
+		prefix  string
+		content string
+		err     error
+	}{
+		{
+			desc:    "generate CSV report",
+			token:   userEmail,
+			id:      csvReport.ID,
+			prefix:  "channel,subtopic,publisher,name,unit,time,value\n",
+			content: fmt.Sprintf("%s,,,%s,Cel,", channelID, msgName),
+			err:     nil,
+		},
+		{
+			desc:    "generate PDF report",
+			token:   userEmail,
+			id:      pdfReport.ID,
+			prefix:  "%PDF-1.4\n",
+			content: "(Report daily,",
+			err:     nil,
+		},
+		{
+			desc:  "generate report owned by other user",
+			token: otherUserEmail,
+			id:    csvReport.ID,
+			err:   errors.ErrNotFound,
+		},
+		{
+			desc:  "generate report with invalid token",
+			token: "invalid",
+			id:    csvReport.ID,
+			err:   errors.ErrAuthentication,
+		},
+	}
+
+	for _, tc := range cases {
+		run, err := svc.GenerateReport(context.Background(), tc.token, tc.id)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected error %s got %s\n", tc.desc, tc.err, err))
+		if tc.err != nil {
+			continue
+		}
+		assert.Equal(t, reports.RunSucceeded, run.Status, fmt.Sprintf("%s: expected status %s got %s\n", tc.desc, reports.RunSucceeded, run.Status))
+		assert.Equal(t, 24*time.Hour, run.To.Sub(run.From), fmt.Sprintf("%s: expected report of the last period\n", tc.desc))
+
+		_, data, err := svc.DownloadRun(context.Background(), tc.token, tc.id, run.ID)
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error downloading report: %s", tc.desc, err))
+		body, err := io.ReadAll(data)
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error reading report: %s", tc.desc, err))
+		assert.True(t, strings.HasPrefix(string(body), tc.prefix), fmt.Sprintf("%s: expected report starting with %q got %q\n", tc.desc, tc.prefix, body))
+		assert.Contains(t, string(body), tc.content, fmt.Sprintf("%s: expected report containing %q\n", tc.desc, tc.content))
+		assert.Equal(t, int64(len(body)), run.Size, fmt.Sprintf("%s: expected size %d got %d\n", tc.desc, len(body), run.Size))
+	}
+
+	require.Len(t, mailer.Emails, 1, "expected emailed report")
+	assert.Equal(t, []string{recipient}, mailer.Emails[0].To, fmt.Sprintf("expected recipients %v got %v\n", []string{recipient}, mailer.Emails[0].To))
+	assert.True(t, strings.HasSuffix(mailer.Emails[0].Filename, ".csv"), fmt.Sprintf("expected CSV attachment got %s\n", mailer.Emails[0].Filename))
+}
+
+func TestGenerateReportDeliveryFailure(t *testing.T) {
+	svc := newService(repmocks.NewReportRepository(), repmocks.NewMailer(errors.New("smtp unavailable")))
+
+	emailed := report
+	emailed.Emails = []string{recipient}
+	r, err := svc.CreateReport(context.Background(), userEmail, emailed)
+	require.Nil(t, err, fmt.Sprintf("unexpected error creating report: %s", err))
+
+	run, err := svc.GenerateReport(context.Background(), userEmail, r.ID)
+	assert.True(t, errors.Contains(err, reports.ErrGenerateReport), fmt.Sprintf("expected error %s got %s\n", reports.ErrGenerateReport, err))
+	assert.Equal(t, reports.RunFailed, run.Status, fmt.Sprintf("expected status %s got %s\n", reports.RunFailed, run.Status))
+
+	page, err := svc.ListRuns(context.Background(), userEmail, r.ID, reports.PageMetadata{Limit: 10})
+	require.Nil(t, err, fmt.Sprintf("unexpected error listing runs: %s", err))
+	require.Len(t, page.Runs, 1, "expected recorded failed run")
+	assert.Equal(t, "smtp unavailable", page.Runs[0].Error, fmt.Sprintf("expected run error got %s\n", page.Runs[0].Error))
+
+	_, _, err = svc.DownloadRun(context.Background(), userEmail, r.ID, run.ID)
+	assert.True(t, errors.Contains(err, errors.ErrNotFound), fmt.Sprintf("download failed run: expected error %s got %s\n", errors.ErrNotFound, err))
+}
+
+func TestRunScheduled(t *testing.T) {
+	repo := repmocks.NewReportRepository()
+	svc := newService(repo, repmocks.NewMailer(nil))
+
+	due, err := svc.CreateReport(context.Background(), userEmail, report)
+	require.Nil(t, err, fmt.Sprintf("unexpected error creating report: %s", err))
+	scheduled, err := svc.CreateReport(context.Background(), userEmail, report)
+	require.Nil(t, err, fmt.Sprintf("unexpected error creating report: %s", err))
+
+	runAt := due.NextRunAt.Add(-48 * time.Hour)
+	due.NextRunAt = runAt
+	err = repo.Update(context.Background(), due)
+	require.Nil(t, err, fmt.Sprintf("unexpected error updating report: %s", err))
+
+	err = svc.RunScheduled(context.Background())
+	assert.Nil(t, err, fmt.Sprintf("run scheduled reports: unexpected error %s", err))
+
+	cases := []struct {
+		desc string
+		id   string
+		runs int
+		next time.Time
+	}{
+		{
+			desc: "generate due report",
+			id:   due.ID,
+			runs: 1,
+			next: runAt.Add(report.Period),
+		},
+		{
+			desc: "skip report which is not due",
+			id:   scheduled.ID,
+			runs: 0,
+			next: scheduled.NextRunAt,
+		},
+	}
+
+	for _, tc := range cases {
+		page, err := svc.ListRuns(context.Background(), userEmail, tc.id, reports.PageMetadata{Limit: 10})
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error listing runs: %s", tc.desc, err))
+		require.Len(t, page.Runs, tc.runs, fmt.Sprintf("%s: expected %d runs got %d\n", tc.desc, tc.runs, len(page.Runs)))
+		if tc.runs > 0 {
+			assert.Equal(t, runAt, page.Runs[0].To, fmt.Sprintf("%s: expected run ending at the scheduled time\n", tc.desc))
+		}
+
+		r, err := svc.ViewReport(context.Background(), userEmail, tc.id)
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error viewing report: %s", tc.desc, err))
+		assert.Equal(t, tc.next, r.NextRunAt, fmt.Sprintf("%s: expected next run at %s got %s\n", tc.desc, tc.next, r.NextRunAt))
+	}
+}
+
+func TestRemoveReport(t *testing.T) {
+	svc := newService(repmocks.NewReportRepository(), repmocks.NewMailer(nil))
+
+	r, err := svc.CreateReport(context.Background(), userEmail, report)
+	require.Nil(t, err, fmt.Sprintf("unexpected error creating report: %s", err))
+	run, err := svc.GenerateReport(context.Background(), userEmail, r.ID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error generating report: %s", err))
+
+	cases := []struct {
+		desc  string
+		token string
+		id    string
+		err   error
+	}{
+		{
+			desc:  "remove report owned by other user",
+			token: otherUserEmail,
+			id:    r.ID,
+			err:   errors.ErrNotFound,
+		},
+		{
+			desc:  "remove report",
+			token: userEmail,
+			id:    r.ID,
+			err:   nil,
+		},
+		{
+			desc:  "remove removed report",
+			token: userEmail,
+			id:    r.ID,
+			err:   errors.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		err := svc.RemoveReport(context.Background(), tc.token, tc.id)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected error %s got %s\n", tc.desc, tc.err, err))
+	}
+
+	_, _, err = svc.DownloadRun(context.Background(), userEmail, r.ID, run.ID)
+	assert.True(t, errors.Contains(err, errors.ErrNotFound), fmt.Sprintf("download run of removed report: expected error %s got %s\n", errors.ErrNotFound, err))
+}
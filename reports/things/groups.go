@@ -0,0 +1,40 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package things contains the SDK backed implementation of the group
+// channels resolver.
+package things
+
+import (
+	mfsdk "github.com/MainfluxLabs/mainflux/pkg/sdk/go"
+	"github.com/MainfluxLabs/mainflux/reports"
+)
+
+const pageLimit = 100
+
+var _ reports.Groups = (*groups)(nil)
+
+type groups struct {
+	sdk mfsdk.SDK
+}
+
+// New returns a group channels resolver backed by the Mainflux SDK.
+func New(sdk mfsdk.SDK) reports.Groups {
+	return &groups{sdk: sdk}
+}
+
+func (g *groups) GroupChannels(token, groupID string) ([]string, error) {
+	var ids []string
+	for offset := uint64(0); ; offset += pageLimit {
+		page, err := g.sdk.ListGroupChannels(groupID, token, offset, pageLimit)
+		if err != nil {
+			return nil, err
+		}
+
+		ids = append(ids, page.Channels...)
+
+		if offset+pageLimit >= page.Total {
+			return ids, nil
+		}
+	}
+}
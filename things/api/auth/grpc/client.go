@@ -5,6 +5,7 @@ package grpc
 
 import (
 	"context"
+	"encoding/json"
 	"time"
 
 	"github.com/MainfluxLabs/mainflux"
@@ -22,9 +23,15 @@ type grpcClient struct {
 	timeout        time.Duration
 	canAccessByKey endpoint.Endpoint
 	canAccessByID  endpoint.Endpoint
+	canAccessBatch endpoint.Endpoint
 	isChannelOwner endpoint.Endpoint
 	identify       endpoint.Endpoint
 	getGroupsByIDs endpoint.Endpoint
+	getSigningKey  endpoint.Endpoint
+	getTopicACL    endpoint.Endpoint
+	getChanGroup   endpoint.Endpoint
+	getChanProfile endpoint.Endpoint
+	getChannel     endpoint.Endpoint
 }
 
 // NewClient returns new gRPC client instance.
@@ -49,6 +56,14 @@ func NewClient(conn *grpc.ClientConn, tracer opentracing.Tracer, timeout time.Du
 			decodeEmptyResponse,
 			empty.Empty{},
 		).Endpoint()),
+		canAccessBatch: kitot.TraceClient(tracer, "can_access_batch")(kitgrpc.NewClient(
+			conn,
+			svcName,
+			"CanAccessBatch",
+			encodeCanAccessBatchRequest,
+			decodeAccessBatchResponse,
+			mainflux.AccessBatchRes{},
+		).Endpoint()),
 		isChannelOwner: kitot.TraceClient(tracer, "is_channel_owner")(kitgrpc.NewClient(
 			conn,
 			svcName,
@@ -73,6 +88,46 @@ func NewClient(conn *grpc.ClientConn, tracer opentracing.Tracer, timeout time.Du
 			decodeGetGroupsByIDsResponse,
 			mainflux.GroupsRes{},
 		).Endpoint()),
+		getSigningKey: kitot.TraceClient(tracer, "get_signing_key")(kitgrpc.NewClient(
+			conn,
+			svcName,
+			"GetSigningKey",
+			encodeGetSigningKeyRequest,
+			decodeSigningKeyResponse,
+			mainflux.SigningKey{},
+		).Endpoint()),
+		getTopicACL: kitot.TraceClient(tracer, "get_topic_acl")(kitgrpc.NewClient(
+			conn,
+			svcName,
+			"GetTopicACL",
+			encodeGetTopicACLRequest,
+			decodeTopicACLResponse,
+			mainflux.TopicACL{},
+		).Endpoint()),
+		getChanGroup: kitot.TraceClient(tracer, "get_channel_group")(kitgrpc.NewClient(
+			conn,
+			svcName,
+			"GetChannelGroup",
+			encodeGetChannelGroupRequest,
+			decodeChannelGroupResponse,
+			mainflux.Group{},
+		).Endpoint()),
+		getChanProfile: kitot.TraceClient(tracer, "get_channel_profile")(kitgrpc.NewClient(
+			conn,
+			svcName,
+			"GetChannelProfile",
+			encodeGetChannelProfileRequest,
+			decodeChannelProfileResponse,
+			mainflux.ChannelProfile{},
+		).Endpoint()),
+		getChannel: kitot.TraceClient(tracer, "get_channel")(kitgrpc.NewClient(
+			conn,
+			svcName,
+			"GetChannel",
+			encodeGetChannelRequest,
+			decodeChannelResponse,
+			mainflux.Channel{},
+		).Endpoint()),
 	}
 }
 
@@ -104,6 +159,20 @@ func (client grpcClient) CanAccessByID(ctx context.Context, req *mainflux.Access
 	return &empty.Empty{}, er.err
 }
 
+func (client grpcClient) CanAccessBatch(ctx context.Context, req *mainflux.AccessBatchReq, _ ...grpc.CallOption) (*mainflux.AccessBatchRes, error) {
+	ctx, cancel := context.WithTimeout(ctx, client.timeout)
+	defer cancel()
+
+	ar := accessBatchReq{thingID: req.GetThingID(), chanIDs: req.GetChanIDs()}
+	res, err := client.canAccessBatch(ctx, ar)
+	if err != nil {
+		return nil, err
+	}
+
+	br := res.(accessBatchRes)
+	return &mainflux.AccessBatchRes{ChanIDs: br.chanIDs}, nil
+}
+
 func (client grpcClient) IsChannelOwner(ctx context.Context, req *mainflux.ChannelOwnerReq, _ ...grpc.CallOption) (*empty.Empty, error) {
 	ar := channelOwnerReq{owner: req.GetOwner(), chanID: req.GetChanID()}
 	res, err := client.isChannelOwner(ctx, ar)
@@ -141,6 +210,76 @@ func (client grpcClient) GetGroupsByIDs(ctx context.Context, req *mainflux.Group
 	return &mainflux.GroupsRes{Groups: ir.groups}, nil
 }
 
+func (client grpcClient) GetSigningKey(ctx context.Context, req *mainflux.ThingID, _ ...grpc.CallOption) (*mainflux.SigningKey, error) {
+	ctx, cancel := context.WithTimeout(ctx, client.timeout)
+	defer cancel()
+
+	res, err := client.getSigningKey(ctx, signingKeyReq{thingID: req.GetValue()})
+	if err != nil {
+		return nil, err
+	}
+
+	sr := res.(signingKeyRes)
+	return &mainflux.SigningKey{Algorithm: sr.algorithm, Key: sr.key}, nil
+}
+
+func (client grpcClient) GetTopicACL(ctx context.Context, req *mainflux.ChannelID, _ ...grpc.CallOption) (*mainflux.TopicACL, error) {
+	ctx, cancel := context.WithTimeout(ctx, client.timeout)
+	defer cancel()
+
+	res, err := client.getTopicACL(ctx, topicACLReq{chanID: req.GetValue()})
+	if err != nil {
+		return nil, err
+	}
+
+	ar := res.(topicACLRes)
+	return &mainflux.TopicACL{Publish: ar.publish, Subscribe: ar.subscribe}, nil
+}
+
+func (client grpcClient) GetChannelGroup(ctx context.Context, req *mainflux.ChannelID, _ ...grpc.CallOption) (*mainflux.Group, error) {
+	ctx, cancel := context.WithTimeout(ctx, client.timeout)
+	defer cancel()
+
+	res, err := client.getChanGroup(ctx, channelGroupReq{chanID: req.GetValue()})
+	if err != nil {
+		return nil, err
+	}
+
+	gr := res.(channelGroupRes)
+	return gr.group, nil
+}
+
+func (client grpcClient) GetChannelProfile(ctx context.Context, req *mainflux.ChannelID, _ ...grpc.CallOption) (*mainflux.ChannelProfile, error) {
+	ctx, cancel := context.WithTimeout(ctx, client.timeout)
+	defer cancel()
+
+	res, err := client.getChanProfile(ctx, channelProfileReq{chanID: req.GetValue()})
+	if err != nil {
+		return nil, err
+	}
+
+	pr := res.(channelProfileRes)
+	return &mainflux.ChannelProfile{MirrorTo: pr.mirrorTo, Message: pr.message}, nil
+}
+
+func (client grpcClient) GetChannel(ctx context.Context, req *mainflux.ChannelID, _ ...grpc.CallOption) (*mainflux.Channel, error) {
+	ctx, cancel := context.WithTimeout(ctx, client.timeout)
+	defer cancel()
+
+	res, err := client.getChannel(ctx, channelReq{chanID: req.GetValue()})
+	if err != nil {
+		return nil, err
+	}
+
+	cr := res.(channelRes)
+	metadata, err := json.Marshal(cr.metadata)
+	if err != nil {
+		return nil, err
+	}
+
+	return &mainflux.Channel{Id: cr.id, Name: cr.name, Metadata: metadata}, nil
+}
+
 func encodeCanAccessByKeyRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
 	req := grpcReq.(accessByKeyReq)
 	return &mainflux.AccessByKeyReq{Token: req.thingKey, ChanID: req.chanID}, nil
@@ -151,6 +290,11 @@ func encodeCanAccessByIDRequest(_ context.Context, grpcReq interface{}) (interfa
 	return &mainflux.AccessByIDReq{ThingID: req.thingID, ChanID: req.chanID}, nil
 }
 
+func encodeCanAccessBatchRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(accessBatchReq)
+	return &mainflux.AccessBatchReq{ThingID: req.thingID, ChanIDs: req.chanIDs}, nil
+}
+
 func encodeIsChannelOwner(_ context.Context, grpcReq interface{}) (interface{}, error) {
 	req := grpcReq.(channelOwnerReq)
 	return &mainflux.ChannelOwnerReq{Owner: req.owner, ChanID: req.chanID}, nil
@@ -166,11 +310,31 @@ func encodeGetGroupsByIDsRequest(_ context.Context, grpcReq interface{}) (interf
 	return &mainflux.GroupsReq{Ids: req.ids}, nil
 }
 
+func encodeGetSigningKeyRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(signingKeyReq)
+	return &mainflux.ThingID{Value: req.thingID}, nil
+}
+
+func encodeGetTopicACLRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(topicACLReq)
+	return &mainflux.ChannelID{Value: req.chanID}, nil
+}
+
+func encodeGetChannelGroupRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(channelGroupReq)
+	return &mainflux.ChannelID{Value: req.chanID}, nil
+}
+
 func decodeIdentityResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
 	res := grpcRes.(*mainflux.ThingID)
 	return identityRes{id: res.GetValue()}, nil
 }
 
+func decodeAccessBatchResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	res := grpcRes.(*mainflux.AccessBatchRes)
+	return accessBatchRes{chanIDs: res.GetChanIDs()}, nil
+}
+
 func decodeEmptyResponse(_ context.Context, _ interface{}) (interface{}, error) {
 	return emptyRes{}, nil
 }
@@ -179,3 +343,46 @@ func decodeGetGroupsByIDsResponse(_ context.Context, grpcRes interface{}) (inter
 	res := grpcRes.(*mainflux.GroupsRes)
 	return getGroupsByIDsRes{groups: res.GetGroups()}, nil
 }
+
+func decodeSigningKeyResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	res := grpcRes.(*mainflux.SigningKey)
+	return signingKeyRes{algorithm: res.GetAlgorithm(), key: res.GetKey()}, nil
+}
+
+func decodeTopicACLResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	res := grpcRes.(*mainflux.TopicACL)
+	return topicACLRes{publish: res.GetPublish(), subscribe: res.GetSubscribe()}, nil
+}
+
+func encodeGetChannelProfileRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(channelProfileReq)
+	return &mainflux.ChannelID{Value: req.chanID}, nil
+}
+
+func decodeChannelGroupResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	res := grpcRes.(*mainflux.Group)
+	return channelGroupRes{group: res}, nil
+}
+
+func decodeChannelProfileResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	res := grpcRes.(*mainflux.ChannelProfile)
+	return channelProfileRes{mirrorTo: res.GetMirrorTo(), message: res.GetMessage()}, nil
+}
+
+func encodeGetChannelRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(channelReq)
+	return &mainflux.ChannelID{Value: req.chanID}, nil
+}
+
+func decodeChannelResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	res := grpcRes.(*mainflux.Channel)
+
+	var metadata map[string]interface{}
+	if len(res.GetMetadata()) > 0 {
+		if err := json.Unmarshal(res.GetMetadata(), &metadata); err != nil {
+			return nil, err
+		}
+	}
+
+	return channelRes{id: res.GetId(), name: res.GetName(), metadata: metadata}, nil
+}
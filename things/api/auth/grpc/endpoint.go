@@ -7,6 +7,7 @@ import (
 	"context"
 
 	"github.com/MainfluxLabs/mainflux"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
 	"github.com/MainfluxLabs/mainflux/things"
 	"github.com/go-kit/kit/endpoint"
 )
@@ -38,6 +39,30 @@ func canAccessByIDEndpoint(svc things.Service) endpoint.Endpoint {
 	}
 }
 
+func canAccessBatchEndpoint(svc things.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(accessBatchReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		res := accessBatchRes{chanIDs: []string{}}
+		for _, chanID := range req.chanIDs {
+			err := svc.CanAccessByID(ctx, chanID, req.thingID)
+			switch {
+			case err == nil:
+				res.chanIDs = append(res.chanIDs, chanID)
+			case errors.Contains(err, errors.ErrNotFound), errors.Contains(err, errors.ErrAuthorization):
+				continue
+			default:
+				return accessBatchRes{}, err
+			}
+		}
+
+		return res, nil
+	}
+}
+
 func isChannelOwnerEndpoint(svc things.Service) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req := request.(channelOwnerReq)
@@ -93,3 +118,92 @@ func listGroupsByIDsEndpoint(svc things.Service) endpoint.Endpoint {
 		return getGroupsByIDsRes{groups: mgr}, nil
 	}
 }
+
+func getSigningKeyEndpoint(svc things.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(signingKeyReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		sk, err := svc.GetSigningKey(ctx, req.thingID)
+		if err != nil {
+			if errors.Contains(err, errors.ErrNotFound) {
+				return signingKeyRes{}, nil
+			}
+			return signingKeyRes{}, err
+		}
+
+		return signingKeyRes{algorithm: sk.Algorithm, key: sk.Key}, nil
+	}
+}
+
+func getTopicACLEndpoint(svc things.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(topicACLReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		a, err := svc.GetTopicACL(ctx, req.chanID)
+		if err != nil {
+			return topicACLRes{}, err
+		}
+
+		return topicACLRes{publish: a.Publish, subscribe: a.Subscribe}, nil
+	}
+}
+
+func getChannelGroupEndpoint(svc things.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(channelGroupReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		g, err := svc.GetChannelGroup(ctx, req.chanID)
+		if err != nil {
+			return channelGroupRes{}, err
+		}
+
+		group := &mainflux.Group{Id: g.ID, OwnerID: g.OwnerID, Name: g.Name, Description: g.Description}
+		return channelGroupRes{group: group}, nil
+	}
+}
+
+func getChannelProfileEndpoint(svc things.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(channelProfileReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		p, err := svc.GetChannelProfile(ctx, req.chanID)
+		if err != nil {
+			return channelProfileRes{}, err
+		}
+
+		res := channelProfileRes{mirrorTo: p.MirrorTo}
+		if p.Message != nil {
+			res.message = &mainflux.MessageProfile{Forward: p.Message.Forward, Persist: p.Message.Persist}
+		}
+
+		return res, nil
+	}
+}
+
+func getChannelEndpoint(svc things.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(channelReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		ch, err := svc.GetChannel(ctx, req.chanID)
+		if err != nil {
+			return channelRes{}, err
+		}
+
+		return channelRes{id: ch.ID, name: ch.Name, metadata: ch.Metadata}, nil
+	}
+}
@@ -6,10 +6,12 @@ package grpc_test
 import (
 	"context"
 	"fmt"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/MainfluxLabs/mainflux"
+	"github.com/MainfluxLabs/mainflux/pkg/signature"
 	"github.com/MainfluxLabs/mainflux/things"
 	grpcapi "github.com/MainfluxLabs/mainflux/things/api/auth/grpc"
 	"github.com/opentracing/opentracing-go/mocktracer"
@@ -171,6 +173,81 @@ func TestCanAccessByID(t *testing.T) {
 	}
 }
 
+func TestCanAccessBatch(t *testing.T) {
+	ths, err := svc.CreateThings(context.Background(), token, thing, thing)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	th1, th2 := ths[0], ths[1]
+
+	chs, err := svc.CreateChannels(context.Background(), token, channel)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	ch := chs[0]
+
+	grs, err := svc.CreateGroups(context.Background(), token, group)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	gr := grs[0]
+
+	err = svc.AssignThing(context.Background(), token, gr.ID, th2.ID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	err = svc.AssignChannel(context.Background(), token, gr.ID, ch.ID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	err = svc.Connect(context.Background(), token, ch.ID, []string{th2.ID})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	usersAddr := fmt.Sprintf("localhost:%d", port)
+	conn, err := grpc.Dial(usersAddr, grpc.WithInsecure())
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	cli := grpcapi.NewClient(conn, mocktracer.New(), time.Second)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	cases := map[string]struct {
+		chanIDs []string
+		thingID string
+		res     []string
+		code    codes.Code
+	}{
+		"check if connected thing can access channels": {
+			chanIDs: []string{ch.ID, wrong},
+			thingID: th2.ID,
+			res:     []string{ch.ID},
+			code:    codes.OK,
+		},
+		"check if unconnected thing can access channels": {
+			chanIDs: []string{ch.ID},
+			thingID: th1.ID,
+			res:     []string{},
+			code:    codes.OK,
+		},
+		"check if thing with empty ID can access channels": {
+			chanIDs: []string{ch.ID},
+			thingID: "",
+			code:    codes.InvalidArgument,
+		},
+		"check if thing can access channels without IDs": {
+			chanIDs: []string{},
+			thingID: th2.ID,
+			code:    codes.InvalidArgument,
+		},
+		"check if thing can access channel with empty ID": {
+			chanIDs: []string{ch.ID, ""},
+			thingID: th2.ID,
+			code:    codes.InvalidArgument,
+		},
+	}
+
+	for desc, tc := range cases {
+		res, err := cli.CanAccessBatch(ctx, &mainflux.AccessBatchReq{ThingID: tc.thingID, ChanIDs: tc.chanIDs})
+		e, ok := status.FromError(err)
+		assert.True(t, ok, "OK expected to be true")
+		assert.Equal(t, tc.code, e.Code(), fmt.Sprintf("%s: expected %s got %s", desc, tc.code, e.Code()))
+		if tc.code == codes.OK {
+			assert.ElementsMatch(t, tc.res, res.GetChanIDs(), fmt.Sprintf("%s: expected %v got %v", desc, tc.res, res.GetChanIDs()))
+		}
+	}
+}
+
 func TestIdentify(t *testing.T) {
 	ths, err := svc.CreateThings(context.Background(), token, thing)
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
@@ -208,3 +285,280 @@ func TestIdentify(t *testing.T) {
 		assert.Equal(t, tc.code, e.Code(), fmt.Sprintf("%s: expected %s got %s", desc, tc.code, e.Code()))
 	}
 }
+
+func TestGetSigningKey(t *testing.T) {
+	ths, err := svc.CreateThings(context.Background(), token, thing, thing)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	signed, unsigned := ths[0], ths[1]
+
+	sk := things.SigningKey{
+		ThingID:   signed.ID,
+		Algorithm: signature.HMACSHA256,
+		Key:       []byte(strings.Repeat("s", 32)),
+	}
+	err = svc.UpdateSigningKey(context.Background(), token, sk)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	usersAddr := fmt.Sprintf("localhost:%d", port)
+	conn, err := grpc.Dial(usersAddr, grpc.WithInsecure())
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	cli := grpcapi.NewClient(conn, mocktracer.New(), time.Second)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	cases := map[string]struct {
+		id        string
+		algorithm string
+		key       []byte
+		code      codes.Code
+	}{
+		"get signing key of thing with signing key": {
+			id:        signed.ID,
+			algorithm: sk.Algorithm,
+			key:       sk.Key,
+			code:      codes.OK,
+		},
+		"get signing key of thing without signing key": {
+			id:   unsigned.ID,
+			code: codes.OK,
+		},
+		"get signing key with empty thing id": {
+			id:   wrongID,
+			code: codes.InvalidArgument,
+		},
+	}
+
+	for desc, tc := range cases {
+		res, err := cli.GetSigningKey(ctx, &mainflux.ThingID{Value: tc.id})
+		e, ok := status.FromError(err)
+		assert.True(t, ok, "OK expected to be true")
+		assert.Equal(t, tc.algorithm, res.GetAlgorithm(), fmt.Sprintf("%s: expected %s got %s", desc, tc.algorithm, res.GetAlgorithm()))
+		assert.Equal(t, tc.key, res.GetKey(), fmt.Sprintf("%s: expected %v got %v", desc, tc.key, res.GetKey()))
+		assert.Equal(t, tc.code, e.Code(), fmt.Sprintf("%s: expected %s got %s", desc, tc.code, e.Code()))
+	}
+}
+
+func TestGetTopicACL(t *testing.T) {
+	restricted := channel
+	restricted.Metadata = map[string]interface{}{
+		"profile": map[string]interface{}{
+			"acl": map[string]interface{}{
+				"publish":   []interface{}{"devices/{thing_id}/#"},
+				"subscribe": []interface{}{"commands/{thing_id}/#"},
+			},
+		},
+	}
+	chs, err := svc.CreateChannels(context.Background(), token, restricted, channel)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	withACL, withoutACL := chs[0], chs[1]
+
+	usersAddr := fmt.Sprintf("localhost:%d", port)
+	conn, err := grpc.Dial(usersAddr, grpc.WithInsecure())
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	cli := grpcapi.NewClient(conn, mocktracer.New(), time.Second)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	cases := map[string]struct {
+		id        string
+		publish   []string
+		subscribe []string
+		code      codes.Code
+	}{
+		"get topic ACL of channel with topic ACL": {
+			id:        withACL.ID,
+			publish:   []string{"devices/{thing_id}/#"},
+			subscribe: []string{"commands/{thing_id}/#"},
+			code:      codes.OK,
+		},
+		"get topic ACL of channel without topic ACL": {
+			id:   withoutACL.ID,
+			code: codes.OK,
+		},
+		"get topic ACL of non-existing channel": {
+			id:   "non-existing",
+			code: codes.NotFound,
+		},
+		"get topic ACL with empty channel id": {
+			id:   wrongID,
+			code: codes.InvalidArgument,
+		},
+	}
+
+	for desc, tc := range cases {
+		res, err := cli.GetTopicACL(ctx, &mainflux.ChannelID{Value: tc.id})
+		e, ok := status.FromError(err)
+		assert.True(t, ok, "OK expected to be true")
+		assert.Equal(t, tc.publish, res.GetPublish(), fmt.Sprintf("%s: expected %v got %v", desc, tc.publish, res.GetPublish()))
+		assert.Equal(t, tc.subscribe, res.GetSubscribe(), fmt.Sprintf("%s: expected %v got %v", desc, tc.subscribe, res.GetSubscribe()))
+		assert.Equal(t, tc.code, e.Code(), fmt.Sprintf("%s: expected %s got %s", desc, tc.code, e.Code()))
+	}
+}
+
+func TestGetChannelGroup(t *testing.T) {
+	chs, err := svc.CreateChannels(context.Background(), token, channel, channel)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	assigned, unassigned := chs[0], chs[1]
+
+	grs, err := svc.CreateGroups(context.Background(), token, group)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	gr := grs[0]
+
+	err = svc.AssignChannel(context.Background(), token, gr.ID, assigned.ID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	usersAddr := fmt.Sprintf("localhost:%d", port)
+	conn, err := grpc.Dial(usersAddr, grpc.WithInsecure())
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	cli := grpcapi.NewClient(conn, mocktracer.New(), time.Second)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	cases := map[string]struct {
+		id      string
+		groupID string
+		code    codes.Code
+	}{
+		"get group of channel assigned to group": {
+			id:      assigned.ID,
+			groupID: gr.ID,
+			code:    codes.OK,
+		},
+		"get group of channel not assigned to group": {
+			id:   unassigned.ID,
+			code: codes.OK,
+		},
+		"get group of non-existing channel": {
+			id:   "non-existing",
+			code: codes.NotFound,
+		},
+		"get group with empty channel id": {
+			id:   wrongID,
+			code: codes.InvalidArgument,
+		},
+	}
+
+	for desc, tc := range cases {
+		res, err := cli.GetChannelGroup(ctx, &mainflux.ChannelID{Value: tc.id})
+		e, ok := status.FromError(err)
+		assert.True(t, ok, "OK expected to be true")
+		assert.Equal(t, tc.groupID, res.GetId(), fmt.Sprintf("%s: expected %s got %s", desc, tc.groupID, res.GetId()))
+		assert.Equal(t, tc.code, e.Code(), fmt.Sprintf("%s: expected %s got %s", desc, tc.code, e.Code()))
+	}
+}
+
+func TestGetChannelProfile(t *testing.T) {
+	chs, err := svc.CreateChannels(context.Background(), token, channel)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	mirror := chs[0]
+
+	mirrored := channel
+	mirrored.Metadata = map[string]interface{}{
+		"profile": map[string]interface{}{"mirror_to": mirror.ID},
+	}
+	chs, err = svc.CreateChannels(context.Background(), token, mirrored)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	withMirror := chs[0]
+
+	unpersisted := channel
+	unpersisted.Metadata = map[string]interface{}{
+		"profile": map[string]interface{}{"persist": false},
+	}
+	chs, err = svc.CreateChannels(context.Background(), token, unpersisted)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	withMessage := chs[0]
+
+	usersAddr := fmt.Sprintf("localhost:%d", port)
+	conn, err := grpc.Dial(usersAddr, grpc.WithInsecure())
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	cli := grpcapi.NewClient(conn, mocktracer.New(), time.Second)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	cases := map[string]struct {
+		id       string
+		mirrorTo string
+		message  *mainflux.MessageProfile
+		code     codes.Code
+	}{
+		"get profile of mirrored channel": {
+			id:       withMirror.ID,
+			mirrorTo: mirror.ID,
+			code:     codes.OK,
+		},
+		"get profile of channel with message profile": {
+			id:      withMessage.ID,
+			message: &mainflux.MessageProfile{Forward: true, Persist: false},
+			code:    codes.OK,
+		},
+		"get profile of channel without profile": {
+			id:   mirror.ID,
+			code: codes.OK,
+		},
+		"get profile of non-existing channel": {
+			id:   "non-existing",
+			code: codes.NotFound,
+		},
+		"get profile with empty channel id": {
+			id:   wrongID,
+			code: codes.InvalidArgument,
+		},
+	}
+
+	for desc, tc := range cases {
+		res, err := cli.GetChannelProfile(ctx, &mainflux.ChannelID{Value: tc.id})
+		e, ok := status.FromError(err)
+		assert.True(t, ok, "OK expected to be true")
+		assert.Equal(t, tc.mirrorTo, res.GetMirrorTo(), fmt.Sprintf("%s: expected %s got %s", desc, tc.mirrorTo, res.GetMirrorTo()))
+		assert.Equal(t, tc.message.GetForward(), res.GetMessage().GetForward(), fmt.Sprintf("%s: expected forward %t got %t", desc, tc.message.GetForward(), res.GetMessage().GetForward()))
+		assert.Equal(t, tc.message.GetPersist(), res.GetMessage().GetPersist(), fmt.Sprintf("%s: expected persist %t got %t", desc, tc.message.GetPersist(), res.GetMessage().GetPersist()))
+		assert.Equal(t, tc.message == nil, res.GetMessage() == nil, fmt.Sprintf("%s: expected message profile %v got %v", desc, tc.message, res.GetMessage()))
+		assert.Equal(t, tc.code, e.Code(), fmt.Sprintf("%s: expected %s got %s", desc, tc.code, e.Code()))
+	}
+}
+
+func TestGetChannel(t *testing.T) {
+	ch := channel
+	ch.Metadata = map[string]interface{}{"key": "value"}
+	chs, err := svc.CreateChannels(context.Background(), token, ch)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	ch = chs[0]
+
+	usersAddr := fmt.Sprintf("localhost:%d", port)
+	conn, err := grpc.Dial(usersAddr, grpc.WithInsecure())
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	cli := grpcapi.NewClient(conn, mocktracer.New(), time.Second)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	cases := map[string]struct {
+		id       string
+		name     string
+		metadata string
+		code     codes.Code
+	}{
+		"get existing channel": {
+			id:       ch.ID,
+			name:     ch.Name,
+			metadata: `{"key":"value"}`,
+			code:     codes.OK,
+		},
+		"get non-existing channel": {
+			id:   "non-existing",
+			code: codes.NotFound,
+		},
+		"get channel with empty id": {
+			id:   wrongID,
+			code: codes.InvalidArgument,
+		},
+	}
+
+	for desc, tc := range cases {
+		res, err := cli.GetChannel(ctx, &mainflux.ChannelID{Value: tc.id})
+		e, ok := status.FromError(err)
+		assert.True(t, ok, "OK expected to be true")
+		assert.Equal(t, tc.name, res.GetName(), fmt.Sprintf("%s: expected %s got %s", desc, tc.name, res.GetName()))
+		assert.Equal(t, tc.metadata, string(res.GetMetadata()), fmt.Sprintf("%s: expected %s got %s", desc, tc.metadata, res.GetMetadata()))
+		assert.Equal(t, tc.code, e.Code(), fmt.Sprintf("%s: expected %s got %s", desc, tc.code, e.Code()))
+	}
+}
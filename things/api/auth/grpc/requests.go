@@ -35,6 +35,26 @@ func (req accessByIDReq) validate() error {
 	return nil
 }
 
+// accessBatchReq contains channels to which the thing access is checked.
+type accessBatchReq struct {
+	thingID string
+	chanIDs []string
+}
+
+func (req accessBatchReq) validate() error {
+	if req.thingID == "" || len(req.chanIDs) == 0 {
+		return apiutil.ErrMissingID
+	}
+
+	for _, chanID := range req.chanIDs {
+		if chanID == "" {
+			return apiutil.ErrMissingID
+		}
+	}
+
+	return nil
+}
+
 type channelOwnerReq struct {
 	owner  string
 	chanID string
@@ -60,6 +80,66 @@ func (req identifyReq) validate() error {
 	return nil
 }
 
+type signingKeyReq struct {
+	thingID string
+}
+
+func (req signingKeyReq) validate() error {
+	if req.thingID == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return nil
+}
+
+type topicACLReq struct {
+	chanID string
+}
+
+func (req topicACLReq) validate() error {
+	if req.chanID == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return nil
+}
+
+type channelGroupReq struct {
+	chanID string
+}
+
+func (req channelGroupReq) validate() error {
+	if req.chanID == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return nil
+}
+
+type channelReq struct {
+	chanID string
+}
+
+func (req channelReq) validate() error {
+	if req.chanID == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return nil
+}
+
+type channelProfileReq struct {
+	chanID string
+}
+
+func (req channelProfileReq) validate() error {
+	if req.chanID == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return nil
+}
+
 type getGroupsByIDsReq struct {
 	ids []string
 }
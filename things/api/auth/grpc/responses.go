@@ -9,10 +9,50 @@ type identityRes struct {
 	id string
 }
 
+// accessBatchRes contains channels which the thing can access.
+type accessBatchRes struct {
+	chanIDs []string
+}
+
 type emptyRes struct {
 	err error
 }
 
+// signingKeyRes contains the signing key of the thing. Empty algorithm
+// means that the thing doesn't sign its messages.
+type signingKeyRes struct {
+	algorithm string
+	key       []byte
+}
+
+// topicACLRes contains the topic ACL patterns of the channel. Empty
+// patterns mean that the access to subtopics is not restricted.
+type topicACLRes struct {
+	publish   []string
+	subscribe []string
+}
+
+// channelGroupRes contains the group of the channel, which is empty if the
+// channel doesn't belong to any group.
+type channelGroupRes struct {
+	group *mainflux.Group
+}
+
+// channelProfileRes contains the profile settings of the channel applied
+// by the adapters. Nil message profile means that the messages are both
+// forwarded and persisted.
+type channelProfileRes struct {
+	mirrorTo string
+	message  *mainflux.MessageProfile
+}
+
+// channelRes contains the channel data copied by the other services.
+type channelRes struct {
+	id       string
+	name     string
+	metadata map[string]interface{}
+}
+
 type getGroupsByIDsRes struct {
 	groups []*mainflux.Group
 }
@@ -5,6 +5,7 @@ package grpc
 
 import (
 	"context"
+	"encoding/json"
 
 	"github.com/MainfluxLabs/mainflux"
 	"github.com/MainfluxLabs/mainflux/internal/apiutil"
@@ -23,9 +24,15 @@ var _ mainflux.ThingsServiceServer = (*grpcServer)(nil)
 type grpcServer struct {
 	canAccessByKey kitgrpc.Handler
 	canAccessByID  kitgrpc.Handler
+	canAccessBatch kitgrpc.Handler
 	isChannelOwner kitgrpc.Handler
 	identify       kitgrpc.Handler
 	getGroupsByIDs kitgrpc.Handler
+	getSigningKey  kitgrpc.Handler
+	getTopicACL    kitgrpc.Handler
+	getChanGroup   kitgrpc.Handler
+	getChanProfile kitgrpc.Handler
+	getChannel     kitgrpc.Handler
 }
 
 // NewServer returns new ThingsServiceServer instance.
@@ -41,6 +48,11 @@ func NewServer(tracer opentracing.Tracer, svc things.Service) mainflux.ThingsSer
 			decodeCanAccessByIDRequest,
 			encodeEmptyResponse,
 		),
+		canAccessBatch: kitgrpc.NewServer(
+			kitot.TraceServer(tracer, "can_access_batch")(canAccessBatchEndpoint(svc)),
+			decodeCanAccessBatchRequest,
+			encodeAccessBatchResponse,
+		),
 		isChannelOwner: kitgrpc.NewServer(
 			kitot.TraceServer(tracer, "is_channel_owner")(isChannelOwnerEndpoint(svc)),
 			decodeIsChannelOwnerRequest,
@@ -56,6 +68,31 @@ func NewServer(tracer opentracing.Tracer, svc things.Service) mainflux.ThingsSer
 			decodeGetGroupsByIDsRequest,
 			encodeGetGroupsByIDsResponse,
 		),
+		getSigningKey: kitgrpc.NewServer(
+			kitot.TraceServer(tracer, "get_signing_key")(getSigningKeyEndpoint(svc)),
+			decodeGetSigningKeyRequest,
+			encodeSigningKeyResponse,
+		),
+		getTopicACL: kitgrpc.NewServer(
+			kitot.TraceServer(tracer, "get_topic_acl")(getTopicACLEndpoint(svc)),
+			decodeGetTopicACLRequest,
+			encodeTopicACLResponse,
+		),
+		getChanGroup: kitgrpc.NewServer(
+			kitot.TraceServer(tracer, "get_channel_group")(getChannelGroupEndpoint(svc)),
+			decodeGetChannelGroupRequest,
+			encodeChannelGroupResponse,
+		),
+		getChanProfile: kitgrpc.NewServer(
+			kitot.TraceServer(tracer, "get_channel_profile")(getChannelProfileEndpoint(svc)),
+			decodeGetChannelProfileRequest,
+			encodeChannelProfileResponse,
+		),
+		getChannel: kitgrpc.NewServer(
+			kitot.TraceServer(tracer, "get_channel")(getChannelEndpoint(svc)),
+			decodeGetChannelRequest,
+			encodeChannelResponse,
+		),
 	}
 }
 
@@ -77,6 +114,15 @@ func (gs *grpcServer) CanAccessByID(ctx context.Context, req *mainflux.AccessByI
 	return res.(*empty.Empty), nil
 }
 
+func (gs *grpcServer) CanAccessBatch(ctx context.Context, req *mainflux.AccessBatchReq) (*mainflux.AccessBatchRes, error) {
+	_, res, err := gs.canAccessBatch.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+
+	return res.(*mainflux.AccessBatchRes), nil
+}
+
 func (gs *grpcServer) IsChannelOwner(ctx context.Context, req *mainflux.ChannelOwnerReq) (*empty.Empty, error) {
 	_, res, err := gs.isChannelOwner.ServeGRPC(ctx, req)
 	if err != nil {
@@ -104,6 +150,51 @@ func (gs *grpcServer) GetGroupsByIDs(ctx context.Context, req *mainflux.GroupsRe
 	return res.(*mainflux.GroupsRes), nil
 }
 
+func (gs *grpcServer) GetSigningKey(ctx context.Context, req *mainflux.ThingID) (*mainflux.SigningKey, error) {
+	_, res, err := gs.getSigningKey.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+
+	return res.(*mainflux.SigningKey), nil
+}
+
+func (gs *grpcServer) GetTopicACL(ctx context.Context, req *mainflux.ChannelID) (*mainflux.TopicACL, error) {
+	_, res, err := gs.getTopicACL.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+
+	return res.(*mainflux.TopicACL), nil
+}
+
+func (gs *grpcServer) GetChannelGroup(ctx context.Context, req *mainflux.ChannelID) (*mainflux.Group, error) {
+	_, res, err := gs.getChanGroup.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+
+	return res.(*mainflux.Group), nil
+}
+
+func (gs *grpcServer) GetChannelProfile(ctx context.Context, req *mainflux.ChannelID) (*mainflux.ChannelProfile, error) {
+	_, res, err := gs.getChanProfile.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+
+	return res.(*mainflux.ChannelProfile), nil
+}
+
+func (gs *grpcServer) GetChannel(ctx context.Context, req *mainflux.ChannelID) (*mainflux.Channel, error) {
+	_, res, err := gs.getChannel.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+
+	return res.(*mainflux.Channel), nil
+}
+
 func decodeCanAccessByKeyRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
 	req := grpcReq.(*mainflux.AccessByKeyReq)
 	return accessByKeyReq{thingKey: req.GetToken(), chanID: req.GetChanID()}, nil
@@ -114,6 +205,11 @@ func decodeCanAccessByIDRequest(_ context.Context, grpcReq interface{}) (interfa
 	return accessByIDReq{thingID: req.GetThingID(), chanID: req.GetChanID()}, nil
 }
 
+func decodeCanAccessBatchRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*mainflux.AccessBatchReq)
+	return accessBatchReq{thingID: req.GetThingID(), chanIDs: req.GetChanIDs()}, nil
+}
+
 func decodeIsChannelOwnerRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
 	req := grpcReq.(*mainflux.ChannelOwnerReq)
 	return channelOwnerReq{owner: req.GetOwner(), chanID: req.GetChanID()}, nil
@@ -129,11 +225,41 @@ func decodeGetGroupsByIDsRequest(_ context.Context, grpcReq interface{}) (interf
 	return getGroupsByIDsReq{ids: req.GetIds()}, nil
 }
 
+func decodeGetSigningKeyRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*mainflux.ThingID)
+	return signingKeyReq{thingID: req.GetValue()}, nil
+}
+
+func decodeGetTopicACLRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*mainflux.ChannelID)
+	return topicACLReq{chanID: req.GetValue()}, nil
+}
+
+func decodeGetChannelGroupRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*mainflux.ChannelID)
+	return channelGroupReq{chanID: req.GetValue()}, nil
+}
+
+func decodeGetChannelProfileRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*mainflux.ChannelID)
+	return channelProfileReq{chanID: req.GetValue()}, nil
+}
+
+func decodeGetChannelRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*mainflux.ChannelID)
+	return channelReq{chanID: req.GetValue()}, nil
+}
+
 func encodeIdentityResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
 	res := grpcRes.(identityRes)
 	return &mainflux.ThingID{Value: res.id}, nil
 }
 
+func encodeAccessBatchResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	res := grpcRes.(accessBatchRes)
+	return &mainflux.AccessBatchRes{ChanIDs: res.chanIDs}, nil
+}
+
 func encodeEmptyResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
 	res := grpcRes.(emptyRes)
 	return &empty.Empty{}, encodeError(res.err)
@@ -144,6 +270,36 @@ func encodeGetGroupsByIDsResponse(_ context.Context, grpcRes interface{}) (inter
 	return &mainflux.GroupsRes{Groups: res.groups}, nil
 }
 
+func encodeSigningKeyResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	res := grpcRes.(signingKeyRes)
+	return &mainflux.SigningKey{Algorithm: res.algorithm, Key: res.key}, nil
+}
+
+func encodeTopicACLResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	res := grpcRes.(topicACLRes)
+	return &mainflux.TopicACL{Publish: res.publish, Subscribe: res.subscribe}, nil
+}
+
+func encodeChannelGroupResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	res := grpcRes.(channelGroupRes)
+	return res.group, nil
+}
+
+func encodeChannelProfileResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	res := grpcRes.(channelProfileRes)
+	return &mainflux.ChannelProfile{MirrorTo: res.mirrorTo, Message: res.message}, nil
+}
+
+func encodeChannelResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	res := grpcRes.(channelRes)
+	metadata, err := json.Marshal(res.metadata)
+	if err != nil {
+		return nil, err
+	}
+
+	return &mainflux.Channel{Id: res.id, Name: res.name, Metadata: metadata}, nil
+}
+
 func encodeError(err error) error {
 	switch {
 	case err == nil:
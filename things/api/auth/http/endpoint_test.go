@@ -76,11 +76,12 @@ func newService(tokens map[string]string) things.Service {
 	thingsRepo := thmocks.NewThingRepository(conns)
 	channelsRepo := thmocks.NewChannelRepository(thingsRepo, conns)
 	groupsRepo := thmocks.NewGroupRepository()
+	templatesRepo := thmocks.NewGroupTemplateRepository()
 	chanCache := thmocks.NewChannelCache()
 	thingCache := thmocks.NewThingCache()
 	idProvider := uuid.NewMock()
 
-	return things.New(auth, thingsRepo, channelsRepo, groupsRepo, chanCache, thingCache, idProvider)
+	return things.New(auth, thingsRepo, channelsRepo, groupsRepo, templatesRepo, chanCache, thingCache, idProvider, idProvider, idProvider, idProvider, nil, nil)
 }
 
 func newServer(svc things.Service) *httptest.Server {
@@ -11,6 +11,7 @@ import (
 	"time"
 
 	log "github.com/MainfluxLabs/mainflux/logger"
+	"github.com/MainfluxLabs/mainflux/pkg/acl"
 	"github.com/MainfluxLabs/mainflux/things"
 )
 
@@ -28,12 +29,13 @@ func LoggingMiddleware(svc things.Service, logger log.Logger) things.Service {
 
 func (lm *loggingMiddleware) CreateThings(ctx context.Context, token string, ths ...things.Thing) (saved []things.Thing, err error) {
 	defer func(begin time.Time) {
-		message := fmt.Sprintf("Method create_things for token %s and things %s took %s to complete", token, saved, time.Since(begin))
+		l := lm.logger.WithContext(ctx).With("method", "create_things", "latency", time.Since(begin).String())
+		message := fmt.Sprintf("Method create_things for token %s and things %v took %s to complete", token, saved, time.Since(begin))
 		if err != nil {
-			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
 			return
 		}
-		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+		l.Info(fmt.Sprintf("%s without errors.", message))
 	}(time.Now())
 
 	return lm.svc.CreateThings(ctx, token, ths...)
@@ -41,12 +43,13 @@ func (lm *loggingMiddleware) CreateThings(ctx context.Context, token string, ths
 
 func (lm *loggingMiddleware) UpdateThing(ctx context.Context, token string, thing things.Thing) (err error) {
 	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "update_thing", "latency", time.Since(begin).String())
 		message := fmt.Sprintf("Method update_thing for token %s and thing %s took %s to complete", token, thing.ID, time.Since(begin))
 		if err != nil {
-			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
 			return
 		}
-		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+		l.Info(fmt.Sprintf("%s without errors.", message))
 	}(time.Now())
 
 	return lm.svc.UpdateThing(ctx, token, thing)
@@ -54,55 +57,199 @@ func (lm *loggingMiddleware) UpdateThing(ctx context.Context, token string, thin
 
 func (lm *loggingMiddleware) UpdateKey(ctx context.Context, token, id, key string) (err error) {
 	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "update_key", "latency", time.Since(begin).String())
 		message := fmt.Sprintf("Method update_key for thing %s and key %s took %s to complete", id, key, time.Since(begin))
 		if err != nil {
-			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
 			return
 		}
-		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+		l.Info(fmt.Sprintf("%s without errors.", message))
 	}(time.Now())
 
 	return lm.svc.UpdateKey(ctx, token, id, key)
 }
 
+func (lm *loggingMiddleware) UpdateSigningKey(ctx context.Context, token string, sk things.SigningKey) (err error) {
+	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "update_signing_key", "latency", time.Since(begin).String())
+		message := fmt.Sprintf("Method update_signing_key for thing %s and algorithm %s took %s to complete", sk.ThingID, sk.Algorithm, time.Since(begin))
+		if err != nil {
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		l.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.UpdateSigningKey(ctx, token, sk)
+}
+
+func (lm *loggingMiddleware) ViewSigningKey(ctx context.Context, token, thingID string) (sk things.SigningKey, err error) {
+	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "view_signing_key", "latency", time.Since(begin).String())
+		message := fmt.Sprintf("Method view_signing_key for thing %s took %s to complete", thingID, time.Since(begin))
+		if err != nil {
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		l.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ViewSigningKey(ctx, token, thingID)
+}
+
+func (lm *loggingMiddleware) RemoveSigningKey(ctx context.Context, token, thingID string) (err error) {
+	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "remove_signing_key", "latency", time.Since(begin).String())
+		message := fmt.Sprintf("Method remove_signing_key for thing %s took %s to complete", thingID, time.Since(begin))
+		if err != nil {
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		l.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.RemoveSigningKey(ctx, token, thingID)
+}
+
+func (lm *loggingMiddleware) GetSigningKey(ctx context.Context, thingID string) (sk things.SigningKey, err error) {
+	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "get_signing_key", "latency", time.Since(begin).String())
+		message := fmt.Sprintf("Method get_signing_key for thing %s took %s to complete", thingID, time.Since(begin))
+		if err != nil {
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		l.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.GetSigningKey(ctx, thingID)
+}
+
+func (lm *loggingMiddleware) GetTopicACL(ctx context.Context, chanID string) (a acl.ACL, err error) {
+	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "get_topic_acl", "latency", time.Since(begin).String())
+		message := fmt.Sprintf("Method get_topic_acl for channel %s took %s to complete", chanID, time.Since(begin))
+		if err != nil {
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		l.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.GetTopicACL(ctx, chanID)
+}
+
+func (lm *loggingMiddleware) GetChannelGroup(ctx context.Context, chanID string) (g things.Group, err error) {
+	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "get_channel_group", "latency", time.Since(begin).String())
+		message := fmt.Sprintf("Method get_channel_group for channel %s took %s to complete", chanID, time.Since(begin))
+		if err != nil {
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		l.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.GetChannelGroup(ctx, chanID)
+}
+
+func (lm *loggingMiddleware) GetChannelProfile(ctx context.Context, chanID string) (p things.ChannelProfile, err error) {
+	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "get_channel_profile", "latency", time.Since(begin).String())
+		message := fmt.Sprintf("Method get_channel_profile for channel %s took %s to complete", chanID, time.Since(begin))
+		if err != nil {
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		l.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.GetChannelProfile(ctx, chanID)
+}
+
+func (lm *loggingMiddleware) GetChannel(ctx context.Context, chanID string) (ch things.Channel, err error) {
+	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "get_channel", "latency", time.Since(begin).String())
+		message := fmt.Sprintf("Method get_channel for channel %s took %s to complete", chanID, time.Since(begin))
+		if err != nil {
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		l.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.GetChannel(ctx, chanID)
+}
+
 func (lm *loggingMiddleware) ViewThing(ctx context.Context, token, id string) (thing things.Thing, err error) {
 	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "view_thing", "latency", time.Since(begin).String())
 		message := fmt.Sprintf("Method view_thing for token %s and thing %s took %s to complete", token, id, time.Since(begin))
 		if err != nil {
-			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
 			return
 		}
-		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+		l.Info(fmt.Sprintf("%s without errors.", message))
 	}(time.Now())
 
 	return lm.svc.ViewThing(ctx, token, id)
 }
 
+func (lm *loggingMiddleware) ViewThingByExternalID(ctx context.Context, token, externalID string) (_ things.Thing, err error) {
+	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "view_thing_by_external_id", "latency", time.Since(begin).String())
+		message := fmt.Sprintf("Method view_thing_by_external_id for external id %s took %s to complete", externalID, time.Since(begin))
+		if err != nil {
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		l.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ViewThingByExternalID(ctx, token, externalID)
+}
+
 func (lm *loggingMiddleware) ListThings(ctx context.Context, token string, admin bool, pm things.PageMetadata) (_ things.Page, err error) {
 	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "list_things", "latency", time.Since(begin).String())
 		nlog := ""
 		if pm.Name != "" {
 			nlog = fmt.Sprintf("with name %s", pm.Name)
 		}
 		message := fmt.Sprintf("Method list_things %s for token %s took %s to complete", nlog, token, time.Since(begin))
 		if err != nil {
-			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
 			return
 		}
-		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+		l.Info(fmt.Sprintf("%s without errors.", message))
 	}(time.Now())
 
 	return lm.svc.ListThings(ctx, token, admin, pm)
 }
 
+func (lm *loggingMiddleware) SearchThings(ctx context.Context, token string, pm things.PageMetadata) (_ things.Page, err error) {
+	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "search_things_by_admin", "latency", time.Since(begin).String())
+		message := fmt.Sprintf("Method search_things_by_admin for token %s took %s to complete", token, time.Since(begin))
+		if err != nil {
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		l.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.SearchThings(ctx, token, pm)
+}
+
 func (lm *loggingMiddleware) ListThingsByIDs(ctx context.Context, ids []string) (page things.Page, err error) {
 	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "list_things_by_ids", "latency", time.Since(begin).String())
 		message := fmt.Sprintf("Method list_things_by_ids for ids %s took %s to complete", ids, time.Since(begin))
 		if err != nil {
-			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
 			return
 		}
-		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+		l.Info(fmt.Sprintf("%s without errors.", message))
 	}(time.Now())
 
 	return lm.svc.ListThingsByIDs(ctx, ids)
@@ -110,11 +257,12 @@ func (lm *loggingMiddleware) ListThingsByIDs(ctx context.Context, ids []string)
 
 func (lm *loggingMiddleware) ListThingsByChannel(ctx context.Context, token, chID string, pm things.PageMetadata) (_ things.Page, err error) {
 	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "list_things_by_channel", "latency", time.Since(begin).String())
 		message := fmt.Sprintf("Method list_things_by_channel for channel %s took %s to complete", chID, time.Since(begin))
 		if err != nil {
-			lm.logger.Warn(fmt.Sprintf("%s with error: %s", message, err))
+			l.Warn(fmt.Sprintf("%s with error: %s", message, err))
 		}
-		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+		l.Info(fmt.Sprintf("%s without errors.", message))
 	}(time.Now())
 
 	return lm.svc.ListThingsByChannel(ctx, token, chID, pm)
@@ -122,12 +270,13 @@ func (lm *loggingMiddleware) ListThingsByChannel(ctx context.Context, token, chI
 
 func (lm *loggingMiddleware) RemoveThings(ctx context.Context, token string, ids ...string) (err error) {
 	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "remove_things", "latency", time.Since(begin).String())
 		message := fmt.Sprintf("Method remove_things for token %s took %s to complete", token, time.Since(begin))
 		if err != nil {
-			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
 			return
 		}
-		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+		l.Info(fmt.Sprintf("%s without errors.", message))
 	}(time.Now())
 
 	return lm.svc.RemoveThings(ctx, token, ids...)
@@ -135,12 +284,13 @@ func (lm *loggingMiddleware) RemoveThings(ctx context.Context, token string, ids
 
 func (lm *loggingMiddleware) CreateChannels(ctx context.Context, token string, channels ...things.Channel) (saved []things.Channel, err error) {
 	defer func(begin time.Time) {
-		message := fmt.Sprintf("Method create_channels for token %s and channels %s took %s to complete", token, saved, time.Since(begin))
+		l := lm.logger.WithContext(ctx).With("method", "create_channels", "latency", time.Since(begin).String())
+		message := fmt.Sprintf("Method create_channels for token %s and channels %v took %s to complete", token, saved, time.Since(begin))
 		if err != nil {
-			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
 			return
 		}
-		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+		l.Info(fmt.Sprintf("%s without errors.", message))
 	}(time.Now())
 
 	return lm.svc.CreateChannels(ctx, token, channels...)
@@ -148,25 +298,55 @@ func (lm *loggingMiddleware) CreateChannels(ctx context.Context, token string, c
 
 func (lm *loggingMiddleware) UpdateChannel(ctx context.Context, token string, channel things.Channel) (err error) {
 	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "update_channel", "latency", time.Since(begin).String())
 		message := fmt.Sprintf("Method update_channel for token %s and channel %s took %s to complete", token, channel.ID, time.Since(begin))
 		if err != nil {
-			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
 			return
 		}
-		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+		l.Info(fmt.Sprintf("%s without errors.", message))
 	}(time.Now())
 
 	return lm.svc.UpdateChannel(ctx, token, channel)
 }
 
+func (lm *loggingMiddleware) ListChannelVersions(ctx context.Context, token, chID string, pm things.PageMetadata) (_ things.ChannelVersionsPage, err error) {
+	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "list_channel_versions", "latency", time.Since(begin).String())
+		message := fmt.Sprintf("Method list_channel_versions for token %s and channel %s took %s to complete", token, chID, time.Since(begin))
+		if err != nil {
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		l.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ListChannelVersions(ctx, token, chID, pm)
+}
+
+func (lm *loggingMiddleware) RollbackChannel(ctx context.Context, token, chID string, version uint64) (_ things.Channel, err error) {
+	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "rollback_channel", "latency", time.Since(begin).String())
+		message := fmt.Sprintf("Method rollback_channel for token %s, channel %s and version %d took %s to complete", token, chID, version, time.Since(begin))
+		if err != nil {
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		l.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.RollbackChannel(ctx, token, chID, version)
+}
+
 func (lm *loggingMiddleware) ViewChannel(ctx context.Context, token, id string) (channel things.Channel, err error) {
 	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "view_channel", "latency", time.Since(begin).String())
 		message := fmt.Sprintf("Method view_channel for token %s and channel %s took %s to complete", token, id, time.Since(begin))
 		if err != nil {
-			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
 			return
 		}
-		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+		l.Info(fmt.Sprintf("%s without errors.", message))
 	}(time.Now())
 
 	return lm.svc.ViewChannel(ctx, token, id)
@@ -174,28 +354,44 @@ func (lm *loggingMiddleware) ViewChannel(ctx context.Context, token, id string)
 
 func (lm *loggingMiddleware) ListChannels(ctx context.Context, token string, admin bool, pm things.PageMetadata) (_ things.ChannelsPage, err error) {
 	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "list_channels", "latency", time.Since(begin).String())
 		nlog := ""
 		if pm.Name != "" {
 			nlog = fmt.Sprintf("with name %s", pm.Name)
 		}
 		message := fmt.Sprintf("Method list_channels %s for token %s took %s to complete", nlog, token, time.Since(begin))
 		if err != nil {
-			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
 			return
 		}
-		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+		l.Info(fmt.Sprintf("%s without errors.", message))
 	}(time.Now())
 
 	return lm.svc.ListChannels(ctx, token, admin, pm)
 }
 
+func (lm *loggingMiddleware) SearchChannels(ctx context.Context, token string, pm things.PageMetadata) (_ things.ChannelsPage, err error) {
+	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "search_channels_by_admin", "latency", time.Since(begin).String())
+		message := fmt.Sprintf("Method search_channels_by_admin for token %s took %s to complete", token, time.Since(begin))
+		if err != nil {
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		l.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.SearchChannels(ctx, token, pm)
+}
+
 func (lm *loggingMiddleware) ViewChannelByThing(ctx context.Context, token, thID string) (_ things.Channel, err error) {
 	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "view_channel_by_thing", "latency", time.Since(begin).String())
 		message := fmt.Sprintf("Method view_channel_by_thing for thing %s took %s to complete", thID, time.Since(begin))
 		if err != nil {
-			lm.logger.Warn(fmt.Sprintf("%s with error: %s", message, err))
+			l.Warn(fmt.Sprintf("%s with error: %s", message, err))
 		}
-		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+		l.Info(fmt.Sprintf("%s without errors.", message))
 	}(time.Now())
 
 	return lm.svc.ViewChannelByThing(ctx, token, thID)
@@ -203,12 +399,13 @@ func (lm *loggingMiddleware) ViewChannelByThing(ctx context.Context, token, thID
 
 func (lm *loggingMiddleware) RemoveChannels(ctx context.Context, token string, ids ...string) (err error) {
 	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "remove_channels", "latency", time.Since(begin).String())
 		message := fmt.Sprintf("Method remove_channels for token %s took %s to complete", token, time.Since(begin))
 		if err != nil {
-			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
 			return
 		}
-		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+		l.Info(fmt.Sprintf("%s without errors.", message))
 	}(time.Now())
 
 	return lm.svc.RemoveChannels(ctx, token, ids...)
@@ -216,12 +413,13 @@ func (lm *loggingMiddleware) RemoveChannels(ctx context.Context, token string, i
 
 func (lm *loggingMiddleware) Connect(ctx context.Context, token, chID string, thIDs []string) (err error) {
 	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "connect", "latency", time.Since(begin).String())
 		message := fmt.Sprintf("Method connect for token %s, channel %s and things %s took %s to complete", token, chID, thIDs, time.Since(begin))
 		if err != nil {
-			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
 			return
 		}
-		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+		l.Info(fmt.Sprintf("%s without errors.", message))
 	}(time.Now())
 
 	return lm.svc.Connect(ctx, token, chID, thIDs)
@@ -229,25 +427,41 @@ func (lm *loggingMiddleware) Connect(ctx context.Context, token, chID string, th
 
 func (lm *loggingMiddleware) Disconnect(ctx context.Context, token, chID string, thIDs []string) (err error) {
 	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "disconnect", "latency", time.Since(begin).String())
 		message := fmt.Sprintf("Method disconnect for token %s, channel %v and things %v took %s to complete", token, chID, thIDs, time.Since(begin))
 		if err != nil {
-			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
 			return
 		}
-		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+		l.Info(fmt.Sprintf("%s without errors.", message))
 	}(time.Now())
 
 	return lm.svc.Disconnect(ctx, token, chID, thIDs)
 }
 
+func (lm *loggingMiddleware) ListConnections(ctx context.Context, token string, cf things.ConnectionsFilter, pm things.PageMetadata) (_ things.ConnectionsPage, err error) {
+	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "list_connections", "latency", time.Since(begin).String())
+		message := fmt.Sprintf("Method list_connections for token %s took %s to complete", token, time.Since(begin))
+		if err != nil {
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		l.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ListConnections(ctx, token, cf, pm)
+}
+
 func (lm *loggingMiddleware) CanAccessByKey(ctx context.Context, id, key string) (thing string, err error) {
 	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "can_access", "latency", time.Since(begin).String())
 		message := fmt.Sprintf("Method can_access for channel %s and thing %s took %s to complete", id, thing, time.Since(begin))
 		if err != nil {
-			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
 			return
 		}
-		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+		l.Info(fmt.Sprintf("%s without errors.", message))
 	}(time.Now())
 
 	return lm.svc.CanAccessByKey(ctx, id, key)
@@ -255,12 +469,13 @@ func (lm *loggingMiddleware) CanAccessByKey(ctx context.Context, id, key string)
 
 func (lm *loggingMiddleware) CanAccessByID(ctx context.Context, chanID, thingID string) (err error) {
 	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "can_access_by_id", "latency", time.Since(begin).String())
 		message := fmt.Sprintf("Method can_access_by_id for channel %s and thing %s took %s to complete", chanID, thingID, time.Since(begin))
 		if err != nil {
-			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
 			return
 		}
-		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+		l.Info(fmt.Sprintf("%s without errors.", message))
 	}(time.Now())
 
 	return lm.svc.CanAccessByID(ctx, chanID, thingID)
@@ -268,12 +483,13 @@ func (lm *loggingMiddleware) CanAccessByID(ctx context.Context, chanID, thingID
 
 func (lm *loggingMiddleware) IsChannelOwner(ctx context.Context, owner, chanID string) (err error) {
 	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "is_channel_owner", "latency", time.Since(begin).String())
 		message := fmt.Sprintf("Method is_channel_owner for channel %s and user %s took %s to complete", chanID, owner, time.Since(begin))
 		if err != nil {
-			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
 			return
 		}
-		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+		l.Info(fmt.Sprintf("%s without errors.", message))
 	}(time.Now())
 
 	return lm.svc.IsChannelOwner(ctx, owner, chanID)
@@ -281,12 +497,13 @@ func (lm *loggingMiddleware) IsChannelOwner(ctx context.Context, owner, chanID s
 
 func (lm *loggingMiddleware) Identify(ctx context.Context, key string) (id string, err error) {
 	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "identify", "latency", time.Since(begin).String())
 		message := fmt.Sprintf("Method identify for token %s and thing %s took %s to complete", key, id, time.Since(begin))
 		if err != nil {
-			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
 			return
 		}
-		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+		l.Info(fmt.Sprintf("%s without errors.", message))
 	}(time.Now())
 
 	return lm.svc.Identify(ctx, key)
@@ -294,12 +511,13 @@ func (lm *loggingMiddleware) Identify(ctx context.Context, key string) (id strin
 
 func (lm *loggingMiddleware) Backup(ctx context.Context, token string) (bk things.Backup, err error) {
 	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "backup", "latency", time.Since(begin).String())
 		message := fmt.Sprintf("Method backup for token %s took %s to complete", token, time.Since(begin))
 		if err != nil {
-			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
 			return
 		}
-		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+		l.Info(fmt.Sprintf("%s without errors.", message))
 	}(time.Now())
 
 	return lm.svc.Backup(ctx, token)
@@ -307,25 +525,41 @@ func (lm *loggingMiddleware) Backup(ctx context.Context, token string) (bk thing
 
 func (lm *loggingMiddleware) Restore(ctx context.Context, token string, backup things.Backup) (err error) {
 	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "restore", "latency", time.Since(begin).String())
 		message := fmt.Sprintf("Method restore for token %s took %s to complete", token, time.Since(begin))
 		if err != nil {
-			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
 			return
 		}
-		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+		l.Info(fmt.Sprintf("%s without errors.", message))
 	}(time.Now())
 
 	return lm.svc.Restore(ctx, token, backup)
 }
 
+func (lm *loggingMiddleware) AuthorizeAdmin(ctx context.Context, token string) (err error) {
+	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "authorize_admin", "latency", time.Since(begin).String())
+		message := fmt.Sprintf("Method authorize_admin for token %s took %s to complete", token, time.Since(begin))
+		if err != nil {
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		l.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.AuthorizeAdmin(ctx, token)
+}
+
 func (lm *loggingMiddleware) CreateGroups(ctx context.Context, token string, grs ...things.Group) (saved []things.Group, err error) {
 	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "create_groups", "latency", time.Since(begin).String())
 		message := fmt.Sprintf("Method create_groups for token %s took %s to complete", token, time.Since(begin))
 		if err != nil {
-			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
 			return
 		}
-		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+		l.Info(fmt.Sprintf("%s without errors.", message))
 	}(time.Now())
 
 	return lm.svc.CreateGroups(ctx, token, grs...)
@@ -333,12 +567,13 @@ func (lm *loggingMiddleware) CreateGroups(ctx context.Context, token string, grs
 
 func (lm *loggingMiddleware) UpdateGroup(ctx context.Context, token string, gr things.Group) (g things.Group, err error) {
 	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "update_group", "latency", time.Since(begin).String())
 		message := fmt.Sprintf("Method update_group for token %s took %s to complete", token, time.Since(begin))
 		if err != nil {
-			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
 			return
 		}
-		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+		l.Info(fmt.Sprintf("%s without errors.", message))
 	}(time.Now())
 
 	return lm.svc.UpdateGroup(ctx, token, gr)
@@ -346,12 +581,13 @@ func (lm *loggingMiddleware) UpdateGroup(ctx context.Context, token string, gr t
 
 func (lm *loggingMiddleware) ViewGroup(ctx context.Context, token, id string) (g things.Group, err error) {
 	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "view_group", "latency", time.Since(begin).String())
 		message := fmt.Sprintf("Method view_group for token %s took %s to complete", token, time.Since(begin))
 		if err != nil {
-			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
 			return
 		}
-		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+		l.Info(fmt.Sprintf("%s without errors.", message))
 	}(time.Now())
 
 	return lm.svc.ViewGroup(ctx, token, id)
@@ -359,25 +595,41 @@ func (lm *loggingMiddleware) ViewGroup(ctx context.Context, token, id string) (g
 
 func (lm *loggingMiddleware) ListGroups(ctx context.Context, token string, admin bool, pm things.PageMetadata) (g things.GroupPage, err error) {
 	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "list_groups", "latency", time.Since(begin).String())
 		message := fmt.Sprintf("Method list_groups for token %s took %s to complete", token, time.Since(begin))
 		if err != nil {
-			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
 			return
 		}
-		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+		l.Info(fmt.Sprintf("%s without errors.", message))
 	}(time.Now())
 
 	return lm.svc.ListGroups(ctx, token, admin, pm)
 }
 
+func (lm *loggingMiddleware) SearchGroups(ctx context.Context, token string, pm things.PageMetadata) (_ things.GroupPage, err error) {
+	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "search_groups_by_admin", "latency", time.Since(begin).String())
+		message := fmt.Sprintf("Method search_groups_by_admin for token %s took %s to complete", token, time.Since(begin))
+		if err != nil {
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		l.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.SearchGroups(ctx, token, pm)
+}
+
 func (lm *loggingMiddleware) ListGroupsByIDs(ctx context.Context, groupIDs []string) (g []things.Group, err error) {
 	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "list_groups_by_ids", "latency", time.Since(begin).String())
 		message := fmt.Sprintf("Method list_groups_by_ids for group ids %s took %s to complete", groupIDs, time.Since(begin))
 		if err != nil {
-			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
 			return
 		}
-		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+		l.Info(fmt.Sprintf("%s without errors.", message))
 	}(time.Now())
 
 	return lm.svc.ListGroupsByIDs(ctx, groupIDs)
@@ -385,12 +637,13 @@ func (lm *loggingMiddleware) ListGroupsByIDs(ctx context.Context, groupIDs []str
 
 func (lm *loggingMiddleware) ListGroupThings(ctx context.Context, token, groupID string, pm things.PageMetadata) (mp things.GroupThingsPage, err error) {
 	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "list_group_things", "latency", time.Since(begin).String())
 		message := fmt.Sprintf("Method list_group_things for token %s and group id %s took %s to complete", token, groupID, time.Since(begin))
 		if err != nil {
-			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
 			return
 		}
-		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+		l.Info(fmt.Sprintf("%s without errors.", message))
 	}(time.Now())
 
 	return lm.svc.ListGroupThings(ctx, token, groupID, pm)
@@ -398,12 +651,13 @@ func (lm *loggingMiddleware) ListGroupThings(ctx context.Context, token, groupID
 
 func (lm *loggingMiddleware) ListGroupThingsByChannel(ctx context.Context, token, grID, chID string, pm things.PageMetadata) (tp things.GroupThingsPage, err error) {
 	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "list_group_things_by_channel", "latency", time.Since(begin).String())
 		message := fmt.Sprintf("Method list_group_things_by_channel for token %s, group %s and channel %s took %s to complete", token, grID, chID, time.Since(begin))
 		if err != nil {
-			lm.logger.Warn(fmt.Sprintf("%s with error: %s", message, err))
+			l.Warn(fmt.Sprintf("%s with error: %s", message, err))
 			return
 		}
-		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+		l.Info(fmt.Sprintf("%s without errors.", message))
 	}(time.Now())
 
 	return lm.svc.ListGroupThingsByChannel(ctx, token, grID, chID, pm)
@@ -411,12 +665,13 @@ func (lm *loggingMiddleware) ListGroupThingsByChannel(ctx context.Context, token
 
 func (lm *loggingMiddleware) ViewThingMembership(ctx context.Context, token, thingID string) (gr things.Group, err error) {
 	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "view_thing_membership", "latency", time.Since(begin).String())
 		message := fmt.Sprintf("Method view_thing_membership for token %s took %s to complete", token, time.Since(begin))
 		if err != nil {
-			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
 			return
 		}
-		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+		l.Info(fmt.Sprintf("%s without errors.", message))
 	}(time.Now())
 
 	return lm.svc.ViewThingMembership(ctx, token, thingID)
@@ -424,12 +679,13 @@ func (lm *loggingMiddleware) ViewThingMembership(ctx context.Context, token, thi
 
 func (lm *loggingMiddleware) RemoveGroups(ctx context.Context, token string, ids ...string) (err error) {
 	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "remove_groups", "latency", time.Since(begin).String())
 		message := fmt.Sprintf("Method remove_groups for token %s took %s to complete", token, time.Since(begin))
 		if err != nil {
-			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
 			return
 		}
-		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+		l.Info(fmt.Sprintf("%s without errors.", message))
 	}(time.Now())
 
 	return lm.svc.RemoveGroups(ctx, token, ids...)
@@ -437,12 +693,13 @@ func (lm *loggingMiddleware) RemoveGroups(ctx context.Context, token string, ids
 
 func (lm *loggingMiddleware) AssignThing(ctx context.Context, token, groupID string, thingIDs ...string) (err error) {
 	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "assign_thing", "latency", time.Since(begin).String())
 		message := fmt.Sprintf("Method assign_thing for token %s took %s to complete", token, time.Since(begin))
 		if err != nil {
-			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
 			return
 		}
-		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+		l.Info(fmt.Sprintf("%s without errors.", message))
 	}(time.Now())
 
 	return lm.svc.AssignThing(ctx, token, groupID, thingIDs...)
@@ -450,12 +707,13 @@ func (lm *loggingMiddleware) AssignThing(ctx context.Context, token, groupID str
 
 func (lm *loggingMiddleware) UnassignThing(ctx context.Context, token, groupID string, thingIDs ...string) (err error) {
 	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "unassign_thing", "latency", time.Since(begin).String())
 		message := fmt.Sprintf("Method unassign_thing for token %s took %s to complete", token, time.Since(begin))
 		if err != nil {
-			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
 			return
 		}
-		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+		l.Info(fmt.Sprintf("%s without errors.", message))
 	}(time.Now())
 
 	return lm.svc.UnassignThing(ctx, token, groupID, thingIDs...)
@@ -463,12 +721,13 @@ func (lm *loggingMiddleware) UnassignThing(ctx context.Context, token, groupID s
 
 func (lm *loggingMiddleware) AssignChannel(ctx context.Context, token, groupID string, channelIDs ...string) (err error) {
 	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "assign_channel", "latency", time.Since(begin).String())
 		message := fmt.Sprintf("Method assign_channel for token %s took %s to complete", token, time.Since(begin))
 		if err != nil {
-			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
 			return
 		}
-		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+		l.Info(fmt.Sprintf("%s without errors.", message))
 	}(time.Now())
 
 	return lm.svc.AssignChannel(ctx, token, groupID, channelIDs...)
@@ -476,12 +735,13 @@ func (lm *loggingMiddleware) AssignChannel(ctx context.Context, token, groupID s
 
 func (lm *loggingMiddleware) UnassignChannel(ctx context.Context, token, groupID string, channelIDs ...string) (err error) {
 	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "unassign_channel", "latency", time.Since(begin).String())
 		message := fmt.Sprintf("Method unassign_channel for token %s took %s to complete", token, time.Since(begin))
 		if err != nil {
-			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
 			return
 		}
-		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+		l.Info(fmt.Sprintf("%s without errors.", message))
 	}(time.Now())
 
 	return lm.svc.UnassignChannel(ctx, token, groupID, channelIDs...)
@@ -489,26 +749,196 @@ func (lm *loggingMiddleware) UnassignChannel(ctx context.Context, token, groupID
 
 func (lm *loggingMiddleware) ViewChannelMembership(ctx context.Context, token, channelID string) (gr things.Group, err error) {
 	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "view_channel_membership", "latency", time.Since(begin).String())
 		message := fmt.Sprintf("Method view_channel_membership for token %s took %s to complete", token, time.Since(begin))
 		if err != nil {
-			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
 			return
 		}
-		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+		l.Info(fmt.Sprintf("%s without errors.", message))
 	}(time.Now())
 
 	return lm.svc.ViewChannelMembership(ctx, token, channelID)
 }
 
+func (lm *loggingMiddleware) AssignThingsBatch(ctx context.Context, token, groupID string, thingIDs ...string) (res things.BatchResult, err error) {
+	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "assign_things_batch", "latency", time.Since(begin).String())
+		message := fmt.Sprintf("Method assign_things_batch for token %s and group %s took %s to complete", token, groupID, time.Since(begin))
+		if err != nil {
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		l.Info(fmt.Sprintf("%s with %d processed and %d failed members.", message, res.Processed(), len(res.Failed)))
+	}(time.Now())
+
+	return lm.svc.AssignThingsBatch(ctx, token, groupID, thingIDs...)
+}
+
+func (lm *loggingMiddleware) UnassignThingsBatch(ctx context.Context, token, groupID string, thingIDs ...string) (res things.BatchResult, err error) {
+	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "unassign_things_batch", "latency", time.Since(begin).String())
+		message := fmt.Sprintf("Method unassign_things_batch for token %s and group %s took %s to complete", token, groupID, time.Since(begin))
+		if err != nil {
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		l.Info(fmt.Sprintf("%s with %d processed and %d failed members.", message, res.Processed(), len(res.Failed)))
+	}(time.Now())
+
+	return lm.svc.UnassignThingsBatch(ctx, token, groupID, thingIDs...)
+}
+
+func (lm *loggingMiddleware) AssignChannelsBatch(ctx context.Context, token, groupID string, channelIDs ...string) (res things.BatchResult, err error) {
+	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "assign_channels_batch", "latency", time.Since(begin).String())
+		message := fmt.Sprintf("Method assign_channels_batch for token %s and group %s took %s to complete", token, groupID, time.Since(begin))
+		if err != nil {
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		l.Info(fmt.Sprintf("%s with %d processed and %d failed members.", message, res.Processed(), len(res.Failed)))
+	}(time.Now())
+
+	return lm.svc.AssignChannelsBatch(ctx, token, groupID, channelIDs...)
+}
+
+func (lm *loggingMiddleware) UnassignChannelsBatch(ctx context.Context, token, groupID string, channelIDs ...string) (res things.BatchResult, err error) {
+	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "unassign_channels_batch", "latency", time.Since(begin).String())
+		message := fmt.Sprintf("Method unassign_channels_batch for token %s and group %s took %s to complete", token, groupID, time.Since(begin))
+		if err != nil {
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		l.Info(fmt.Sprintf("%s with %d processed and %d failed members.", message, res.Processed(), len(res.Failed)))
+	}(time.Now())
+
+	return lm.svc.UnassignChannelsBatch(ctx, token, groupID, channelIDs...)
+}
+
+func (lm *loggingMiddleware) ViewGroupStats(ctx context.Context, token, groupID string) (_ things.GroupStats, err error) {
+	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "view_group_stats", "latency", time.Since(begin).String())
+		message := fmt.Sprintf("Method view_group_stats for token %s and group %s took %s to complete", token, groupID, time.Since(begin))
+		if err != nil {
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		l.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ViewGroupStats(ctx, token, groupID)
+}
+
 func (lm *loggingMiddleware) ListGroupChannels(ctx context.Context, token, groupID string, pm things.PageMetadata) (gchp things.GroupChannelsPage, err error) {
 	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "list_group_channels", "latency", time.Since(begin).String())
 		message := fmt.Sprintf("Method list_group_channels for token %s took %s to complete", token, time.Since(begin))
 		if err != nil {
-			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
 			return
 		}
-		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+		l.Info(fmt.Sprintf("%s without errors.", message))
 	}(time.Now())
 
 	return lm.svc.ListGroupChannels(ctx, token, groupID, pm)
 }
+
+func (lm *loggingMiddleware) CreateGroupTemplate(ctx context.Context, token string, gt things.GroupTemplate) (saved things.GroupTemplate, err error) {
+	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "create_group_template", "latency", time.Since(begin).String())
+		message := fmt.Sprintf("Method create_group_template for token %s took %s to complete", token, time.Since(begin))
+		if err != nil {
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		l.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.CreateGroupTemplate(ctx, token, gt)
+}
+
+func (lm *loggingMiddleware) ViewGroupTemplate(ctx context.Context, token, id string) (gt things.GroupTemplate, err error) {
+	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "view_group_template", "latency", time.Since(begin).String())
+		message := fmt.Sprintf("Method view_group_template for id %s took %s to complete", id, time.Since(begin))
+		if err != nil {
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		l.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ViewGroupTemplate(ctx, token, id)
+}
+
+func (lm *loggingMiddleware) ListGroupTemplates(ctx context.Context, token string, pm things.PageMetadata) (gtp things.GroupTemplatesPage, err error) {
+	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "list_group_templates", "latency", time.Since(begin).String())
+		message := fmt.Sprintf("Method list_group_templates for token %s took %s to complete", token, time.Since(begin))
+		if err != nil {
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		l.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ListGroupTemplates(ctx, token, pm)
+}
+
+func (lm *loggingMiddleware) RemoveGroupTemplate(ctx context.Context, token, id string) (err error) {
+	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "remove_group_template", "latency", time.Since(begin).String())
+		message := fmt.Sprintf("Method remove_group_template for id %s took %s to complete", id, time.Since(begin))
+		if err != nil {
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		l.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.RemoveGroupTemplate(ctx, token, id)
+}
+
+func (lm *loggingMiddleware) CreateGroupFromTemplate(ctx context.Context, token, templateID string, g things.Group) (gr things.Group, err error) {
+	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "create_group_from_template", "latency", time.Since(begin).String())
+		message := fmt.Sprintf("Method create_group_from_template for template %s took %s to complete", templateID, time.Since(begin))
+		if err != nil {
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		l.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.CreateGroupFromTemplate(ctx, token, templateID, g)
+}
+
+func (lm *loggingMiddleware) ExportGroup(ctx context.Context, token, groupID string) (ge things.GroupExport, err error) {
+	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "export_group", "latency", time.Since(begin).String())
+		message := fmt.Sprintf("Method export_group for group %s took %s to complete", groupID, time.Since(begin))
+		if err != nil {
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		l.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ExportGroup(ctx, token, groupID)
+}
+
+func (lm *loggingMiddleware) ImportGroup(ctx context.Context, token string, ge things.GroupExport, rekey bool) (imported things.GroupExport, err error) {
+	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "import_group", "latency", time.Since(begin).String())
+		message := fmt.Sprintf("Method import_group for token %s took %s to complete", token, time.Since(begin))
+		if err != nil {
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		l.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ImportGroup(ctx, token, ge, rekey)
+}
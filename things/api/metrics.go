@@ -9,6 +9,7 @@ import (
 	"context"
 	"time"
 
+	"github.com/MainfluxLabs/mainflux/pkg/acl"
 	"github.com/MainfluxLabs/mainflux/things"
 	"github.com/go-kit/kit/metrics"
 )
@@ -57,6 +58,78 @@ func (ms *metricsMiddleware) UpdateKey(ctx context.Context, token, id, key strin
 	return ms.svc.UpdateKey(ctx, token, id, key)
 }
 
+func (ms *metricsMiddleware) UpdateSigningKey(ctx context.Context, token string, sk things.SigningKey) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "update_signing_key").Add(1)
+		ms.latency.With("method", "update_signing_key").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.UpdateSigningKey(ctx, token, sk)
+}
+
+func (ms *metricsMiddleware) ViewSigningKey(ctx context.Context, token, thingID string) (things.SigningKey, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "view_signing_key").Add(1)
+		ms.latency.With("method", "view_signing_key").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ViewSigningKey(ctx, token, thingID)
+}
+
+func (ms *metricsMiddleware) RemoveSigningKey(ctx context.Context, token, thingID string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "remove_signing_key").Add(1)
+		ms.latency.With("method", "remove_signing_key").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.RemoveSigningKey(ctx, token, thingID)
+}
+
+func (ms *metricsMiddleware) GetSigningKey(ctx context.Context, thingID string) (things.SigningKey, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "get_signing_key").Add(1)
+		ms.latency.With("method", "get_signing_key").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.GetSigningKey(ctx, thingID)
+}
+
+func (ms *metricsMiddleware) GetTopicACL(ctx context.Context, chanID string) (acl.ACL, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "get_topic_acl").Add(1)
+		ms.latency.With("method", "get_topic_acl").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.GetTopicACL(ctx, chanID)
+}
+
+func (ms *metricsMiddleware) GetChannelGroup(ctx context.Context, chanID string) (things.Group, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "get_channel_group").Add(1)
+		ms.latency.With("method", "get_channel_group").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.GetChannelGroup(ctx, chanID)
+}
+
+func (ms *metricsMiddleware) GetChannelProfile(ctx context.Context, chanID string) (things.ChannelProfile, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "get_channel_profile").Add(1)
+		ms.latency.With("method", "get_channel_profile").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.GetChannelProfile(ctx, chanID)
+}
+
+func (ms *metricsMiddleware) GetChannel(ctx context.Context, chanID string) (things.Channel, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "get_channel").Add(1)
+		ms.latency.With("method", "get_channel").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.GetChannel(ctx, chanID)
+}
+
 func (ms *metricsMiddleware) ViewThing(ctx context.Context, token, id string) (things.Thing, error) {
 	defer func(begin time.Time) {
 		ms.counter.With("method", "view_thing").Add(1)
@@ -66,6 +139,15 @@ func (ms *metricsMiddleware) ViewThing(ctx context.Context, token, id string) (t
 	return ms.svc.ViewThing(ctx, token, id)
 }
 
+func (ms *metricsMiddleware) ViewThingByExternalID(ctx context.Context, token, externalID string) (things.Thing, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "view_thing_by_external_id").Add(1)
+		ms.latency.With("method", "view_thing_by_external_id").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ViewThingByExternalID(ctx, token, externalID)
+}
+
 func (ms *metricsMiddleware) ListThings(ctx context.Context, token string, admin bool, pm things.PageMetadata) (things.Page, error) {
 	defer func(begin time.Time) {
 		ms.counter.With("method", "list_things").Add(1)
@@ -75,6 +157,15 @@ func (ms *metricsMiddleware) ListThings(ctx context.Context, token string, admin
 	return ms.svc.ListThings(ctx, token, admin, pm)
 }
 
+func (ms *metricsMiddleware) SearchThings(ctx context.Context, token string, pm things.PageMetadata) (things.Page, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "search_things_by_admin").Add(1)
+		ms.latency.With("method", "search_things_by_admin").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.SearchThings(ctx, token, pm)
+}
+
 func (ms *metricsMiddleware) ListThingsByIDs(ctx context.Context, ids []string) (things.Page, error) {
 	defer func(begin time.Time) {
 		ms.counter.With("method", "list_things_by_ids").Add(1)
@@ -120,6 +211,24 @@ func (ms *metricsMiddleware) UpdateChannel(ctx context.Context, token string, ch
 	return ms.svc.UpdateChannel(ctx, token, channel)
 }
 
+func (ms *metricsMiddleware) ListChannelVersions(ctx context.Context, token, chID string, pm things.PageMetadata) (things.ChannelVersionsPage, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "list_channel_versions").Add(1)
+		ms.latency.With("method", "list_channel_versions").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ListChannelVersions(ctx, token, chID, pm)
+}
+
+func (ms *metricsMiddleware) RollbackChannel(ctx context.Context, token, chID string, version uint64) (things.Channel, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "rollback_channel").Add(1)
+		ms.latency.With("method", "rollback_channel").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.RollbackChannel(ctx, token, chID, version)
+}
+
 func (ms *metricsMiddleware) ViewChannel(ctx context.Context, token, id string) (things.Channel, error) {
 	defer func(begin time.Time) {
 		ms.counter.With("method", "view_channel").Add(1)
@@ -138,6 +247,15 @@ func (ms *metricsMiddleware) ListChannels(ctx context.Context, token string, adm
 	return ms.svc.ListChannels(ctx, token, admin, pm)
 }
 
+func (ms *metricsMiddleware) SearchChannels(ctx context.Context, token string, pm things.PageMetadata) (things.ChannelsPage, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "search_channels_by_admin").Add(1)
+		ms.latency.With("method", "search_channels_by_admin").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.SearchChannels(ctx, token, pm)
+}
+
 func (ms *metricsMiddleware) ViewChannelByThing(ctx context.Context, token, thID string) (things.Channel, error) {
 	defer func(begin time.Time) {
 		ms.counter.With("method", "view_channel_by_thing").Add(1)
@@ -174,6 +292,15 @@ func (ms *metricsMiddleware) Disconnect(ctx context.Context, token, chID string,
 	return ms.svc.Disconnect(ctx, token, chID, thIDs)
 }
 
+func (ms *metricsMiddleware) ListConnections(ctx context.Context, token string, cf things.ConnectionsFilter, pm things.PageMetadata) (things.ConnectionsPage, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "list_connections").Add(1)
+		ms.latency.With("method", "list_connections").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ListConnections(ctx, token, cf, pm)
+}
+
 func (ms *metricsMiddleware) CanAccessByKey(ctx context.Context, id, key string) (string, error) {
 	defer func(begin time.Time) {
 		ms.counter.With("method", "can_access_by_key").Add(1)
@@ -228,6 +355,15 @@ func (ms *metricsMiddleware) Restore(ctx context.Context, token string, backup t
 	return ms.svc.Restore(ctx, token, backup)
 }
 
+func (ms *metricsMiddleware) AuthorizeAdmin(ctx context.Context, token string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "authorize_admin").Add(1)
+		ms.latency.With("method", "authorize_admin").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.AuthorizeAdmin(ctx, token)
+}
+
 func (ms *metricsMiddleware) CreateGroups(ctx context.Context, token string, grs ...things.Group) ([]things.Group, error) {
 	defer func(begin time.Time) {
 		ms.counter.With("method", "create_groups").Add(1)
@@ -264,6 +400,15 @@ func (ms *metricsMiddleware) ListGroups(ctx context.Context, token string, admin
 	return ms.svc.ListGroups(ctx, token, admin, pm)
 }
 
+func (ms *metricsMiddleware) SearchGroups(ctx context.Context, token string, pm things.PageMetadata) (things.GroupPage, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "search_groups_by_admin").Add(1)
+		ms.latency.With("method", "search_groups_by_admin").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.SearchGroups(ctx, token, pm)
+}
+
 func (ms *metricsMiddleware) ListGroupsByIDs(ctx context.Context, groupIDs []string) ([]things.Group, error) {
 	defer func(begin time.Time) {
 		ms.counter.With("method", "list_groups_by_ids").Add(1)
@@ -345,6 +490,51 @@ func (ms *metricsMiddleware) UnassignChannel(ctx context.Context, token, groupID
 	return ms.svc.UnassignChannel(ctx, token, groupID, channelIDs...)
 }
 
+func (ms *metricsMiddleware) AssignThingsBatch(ctx context.Context, token, groupID string, thingIDs ...string) (things.BatchResult, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "assign_things_batch").Add(1)
+		ms.latency.With("method", "assign_things_batch").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.AssignThingsBatch(ctx, token, groupID, thingIDs...)
+}
+
+func (ms *metricsMiddleware) UnassignThingsBatch(ctx context.Context, token, groupID string, thingIDs ...string) (things.BatchResult, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "unassign_things_batch").Add(1)
+		ms.latency.With("method", "unassign_things_batch").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.UnassignThingsBatch(ctx, token, groupID, thingIDs...)
+}
+
+func (ms *metricsMiddleware) AssignChannelsBatch(ctx context.Context, token, groupID string, channelIDs ...string) (things.BatchResult, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "assign_channels_batch").Add(1)
+		ms.latency.With("method", "assign_channels_batch").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.AssignChannelsBatch(ctx, token, groupID, channelIDs...)
+}
+
+func (ms *metricsMiddleware) UnassignChannelsBatch(ctx context.Context, token, groupID string, channelIDs ...string) (things.BatchResult, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "unassign_channels_batch").Add(1)
+		ms.latency.With("method", "unassign_channels_batch").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.UnassignChannelsBatch(ctx, token, groupID, channelIDs...)
+}
+
+func (ms *metricsMiddleware) ViewGroupStats(ctx context.Context, token, groupID string) (things.GroupStats, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "view_group_stats").Add(1)
+		ms.latency.With("method", "view_group_stats").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ViewGroupStats(ctx, token, groupID)
+}
+
 func (ms *metricsMiddleware) ListGroupChannels(ctx context.Context, token, groupID string, pm things.PageMetadata) (things.GroupChannelsPage, error) {
 	defer func(begin time.Time) {
 		ms.counter.With("method", "list_group_channels").Add(1)
@@ -362,3 +552,66 @@ func (ms *metricsMiddleware) ViewChannelMembership(ctx context.Context, token, c
 
 	return ms.svc.ViewChannelMembership(ctx, token, channelID)
 }
+
+func (ms *metricsMiddleware) CreateGroupTemplate(ctx context.Context, token string, gt things.GroupTemplate) (things.GroupTemplate, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "create_group_template").Add(1)
+		ms.latency.With("method", "create_group_template").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.CreateGroupTemplate(ctx, token, gt)
+}
+
+func (ms *metricsMiddleware) ViewGroupTemplate(ctx context.Context, token, id string) (things.GroupTemplate, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "view_group_template").Add(1)
+		ms.latency.With("method", "view_group_template").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ViewGroupTemplate(ctx, token, id)
+}
+
+func (ms *metricsMiddleware) ListGroupTemplates(ctx context.Context, token string, pm things.PageMetadata) (things.GroupTemplatesPage, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "list_group_templates").Add(1)
+		ms.latency.With("method", "list_group_templates").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ListGroupTemplates(ctx, token, pm)
+}
+
+func (ms *metricsMiddleware) RemoveGroupTemplate(ctx context.Context, token, id string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "remove_group_template").Add(1)
+		ms.latency.With("method", "remove_group_template").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.RemoveGroupTemplate(ctx, token, id)
+}
+
+func (ms *metricsMiddleware) CreateGroupFromTemplate(ctx context.Context, token, templateID string, g things.Group) (things.Group, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "create_group_from_template").Add(1)
+		ms.latency.With("method", "create_group_from_template").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.CreateGroupFromTemplate(ctx, token, templateID, g)
+}
+
+func (ms *metricsMiddleware) ExportGroup(ctx context.Context, token, groupID string) (things.GroupExport, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "export_group").Add(1)
+		ms.latency.With("method", "export_group").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ExportGroup(ctx, token, groupID)
+}
+
+func (ms *metricsMiddleware) ImportGroup(ctx context.Context, token string, ge things.GroupExport, rekey bool) (things.GroupExport, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "import_group").Add(1)
+		ms.latency.With("method", "import_group").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ImportGroup(ctx, token, ge, rekey)
+}
@@ -22,10 +22,12 @@ func createThingsEndpoint(svc things.Service) endpoint.Endpoint {
 		ths := []things.Thing{}
 		for _, tReq := range req.Things {
 			th := things.Thing{
-				Name:     tReq.Name,
-				Key:      tReq.Key,
-				ID:       tReq.ID,
-				Metadata: tReq.Metadata,
+				Name:       tReq.Name,
+				Key:        tReq.Key,
+				ID:         tReq.ID,
+				ExternalID: tReq.ExternalID,
+				Location:   tReq.Location,
+				Metadata:   tReq.Metadata,
 			}
 			ths = append(ths, th)
 		}
@@ -42,10 +44,12 @@ func createThingsEndpoint(svc things.Service) endpoint.Endpoint {
 
 		for _, th := range saved {
 			tRes := thingRes{
-				ID:       th.ID,
-				Name:     th.Name,
-				Key:      th.Key,
-				Metadata: th.Metadata,
+				ID:         th.ID,
+				Name:       th.Name,
+				Key:        th.Key,
+				ExternalID: th.ExternalID,
+				Location:   th.Location,
+				Metadata:   th.Metadata,
 			}
 			res.Things = append(res.Things, tRes)
 		}
@@ -66,6 +70,7 @@ func updateThingEndpoint(svc things.Service) endpoint.Endpoint {
 			ID:       req.id,
 			Name:     req.Name,
 			Metadata: req.Metadata,
+			Revision: req.revision,
 		}
 
 		if err := svc.UpdateThing(ctx, req.token, thing); err != nil {
@@ -94,6 +99,65 @@ func updateKeyEndpoint(svc things.Service) endpoint.Endpoint {
 	}
 }
 
+func updateSigningKeyEndpoint(svc things.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(updateSigningKeyReq)
+
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		sk := things.SigningKey{
+			ThingID:   req.id,
+			Algorithm: req.Algorithm,
+			Key:       req.Key,
+		}
+		if err := svc.UpdateSigningKey(ctx, req.token, sk); err != nil {
+			return nil, err
+		}
+
+		res := thingRes{ID: req.id, created: false}
+		return res, nil
+	}
+}
+
+func viewSigningKeyEndpoint(svc things.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(viewResourceReq)
+
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		sk, err := svc.ViewSigningKey(ctx, req.token, req.id)
+		if err != nil {
+			return nil, err
+		}
+
+		res := signingKeyRes{
+			Algorithm: sk.Algorithm,
+			Key:       sk.Key,
+		}
+		return res, nil
+	}
+}
+
+func removeSigningKeyEndpoint(svc things.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(viewResourceReq)
+
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		if err := svc.RemoveSigningKey(ctx, req.token, req.id); err != nil {
+			return nil, err
+		}
+
+		return removeRes{}, nil
+	}
+}
+
 func viewThingEndpoint(svc things.Service) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req := request.(viewResourceReq)
@@ -108,11 +172,40 @@ func viewThingEndpoint(svc things.Service) endpoint.Endpoint {
 		}
 
 		res := viewThingRes{
-			ID:       thing.ID,
-			Owner:    thing.Owner,
-			Name:     thing.Name,
-			Key:      thing.Key,
-			Metadata: thing.Metadata,
+			ID:         thing.ID,
+			Owner:      thing.Owner,
+			Name:       thing.Name,
+			Key:        thing.Key,
+			ExternalID: thing.ExternalID,
+			Location:   thing.Location,
+			Metadata:   thing.Metadata,
+			revision:   thing.Revision,
+		}
+		return res, nil
+	}
+}
+
+func viewThingByExternalIDEndpoint(svc things.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(viewResourceReq)
+
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		thing, err := svc.ViewThingByExternalID(ctx, req.token, req.id)
+		if err != nil {
+			return nil, err
+		}
+
+		res := viewThingRes{
+			ID:         thing.ID,
+			Owner:      thing.Owner,
+			Name:       thing.Name,
+			Key:        thing.Key,
+			ExternalID: thing.ExternalID,
+			Location:   thing.Location,
+			Metadata:   thing.Metadata,
 		}
 		return res, nil
 	}
@@ -143,11 +236,53 @@ func listThingsEndpoint(svc things.Service) endpoint.Endpoint {
 		}
 		for _, thing := range page.Things {
 			view := viewThingRes{
-				ID:       thing.ID,
-				Owner:    thing.Owner,
-				Name:     thing.Name,
-				Key:      thing.Key,
-				Metadata: thing.Metadata,
+				ID:         thing.ID,
+				Owner:      thing.Owner,
+				Name:       thing.Name,
+				Key:        thing.Key,
+				ExternalID: thing.ExternalID,
+				Location:   thing.Location,
+				Metadata:   thing.Metadata,
+			}
+			res.Things = append(res.Things, view)
+		}
+
+		return res, nil
+	}
+}
+
+func searchThingsEndpoint(svc things.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listResourcesReq)
+
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		page, err := svc.SearchThings(ctx, req.token, req.pageMetadata)
+		if err != nil {
+			return nil, err
+		}
+
+		res := adminThingsPageRes{
+			pageRes: pageRes{
+				Total:  page.Total,
+				Offset: page.Offset,
+				Limit:  page.Limit,
+				Order:  page.Order,
+				Dir:    page.Dir,
+			},
+			Things: []adminThingRes{},
+		}
+		for _, thing := range page.Things {
+			view := adminThingRes{
+				ID:         thing.ID,
+				Owner:      thing.Owner,
+				Name:       thing.Name,
+				Key:        thing.Key,
+				ExternalID: thing.ExternalID,
+				Location:   thing.Location,
+				Metadata:   thing.Metadata,
 			}
 			res.Things = append(res.Things, view)
 		}
@@ -156,6 +291,96 @@ func listThingsEndpoint(svc things.Service) endpoint.Endpoint {
 	}
 }
 
+func listConnectionsEndpoint(svc things.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listConnectionsReq)
+
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		page, err := svc.ListConnections(ctx, req.token, req.filter, req.pageMetadata)
+		if err != nil {
+			return nil, err
+		}
+
+		res := connectionsPageRes{
+			pageRes: pageRes{
+				Total:  page.Total,
+				Offset: page.Offset,
+				Limit:  page.Limit,
+			},
+			Connections: []connectionRes{},
+		}
+		for _, conn := range page.Connections {
+			res.Connections = append(res.Connections, connectionRes{
+				ChannelID: conn.ChannelID,
+				ThingID:   conn.ThingID,
+			})
+		}
+
+		return res, nil
+	}
+}
+
+func listChannelVersionsEndpoint(svc things.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listChannelVersionsReq)
+
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		page, err := svc.ListChannelVersions(ctx, req.token, req.id, req.pageMetadata)
+		if err != nil {
+			return nil, err
+		}
+
+		res := channelVersionsPageRes{
+			pageRes: pageRes{
+				Total:  page.Total,
+				Offset: page.Offset,
+				Limit:  page.Limit,
+			},
+			Versions: []channelVersionRes{},
+		}
+		for _, cv := range page.Versions {
+			view := channelVersionRes{
+				Version:   cv.Version,
+				Name:      cv.Name,
+				Metadata:  cv.Metadata,
+				CreatedAt: cv.CreatedAt,
+			}
+			res.Versions = append(res.Versions, view)
+		}
+
+		return res, nil
+	}
+}
+
+func rollbackChannelEndpoint(svc things.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(rollbackChannelReq)
+
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		ch, err := svc.RollbackChannel(ctx, req.token, req.id, req.version)
+		if err != nil {
+			return nil, err
+		}
+
+		res := viewChannelRes{
+			ID:       ch.ID,
+			Owner:    ch.Owner,
+			Name:     ch.Name,
+			Metadata: ch.Metadata,
+		}
+		return res, nil
+	}
+}
+
 func listThingsByChannelEndpoint(svc things.Service) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req := request.(listByConnectionReq)
@@ -179,11 +404,13 @@ func listThingsByChannelEndpoint(svc things.Service) endpoint.Endpoint {
 		}
 		for _, thing := range page.Things {
 			view := viewThingRes{
-				ID:       thing.ID,
-				Owner:    thing.Owner,
-				Key:      thing.Key,
-				Name:     thing.Name,
-				Metadata: thing.Metadata,
+				ID:         thing.ID,
+				Owner:      thing.Owner,
+				Key:        thing.Key,
+				Name:       thing.Name,
+				ExternalID: thing.ExternalID,
+				Location:   thing.Location,
+				Metadata:   thing.Metadata,
 			}
 			res.Things = append(res.Things, view)
 		}
@@ -283,6 +510,7 @@ func updateChannelEndpoint(svc things.Service) endpoint.Endpoint {
 			ID:       req.id,
 			Name:     req.Name,
 			Metadata: req.Metadata,
+			Revision: req.revision,
 		}
 		if err := svc.UpdateChannel(ctx, req.token, channel); err != nil {
 			return nil, err
@@ -314,6 +542,7 @@ func viewChannelEndpoint(svc things.Service) endpoint.Endpoint {
 			Owner:    channel.Owner,
 			Name:     channel.Name,
 			Metadata: channel.Metadata,
+			revision: channel.Revision,
 		}
 
 		return res, nil
@@ -359,6 +588,43 @@ func listChannelsEndpoint(svc things.Service) endpoint.Endpoint {
 	}
 }
 
+func searchChannelsEndpoint(svc things.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listResourcesReq)
+
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		page, err := svc.SearchChannels(ctx, req.token, req.pageMetadata)
+		if err != nil {
+			return nil, err
+		}
+
+		res := adminChannelsPageRes{
+			pageRes: pageRes{
+				Total:  page.Total,
+				Offset: page.Offset,
+				Limit:  page.Limit,
+				Order:  page.Order,
+				Dir:    page.Dir,
+			},
+			Channels: []adminChannelRes{},
+		}
+		for _, channel := range page.Channels {
+			view := adminChannelRes{
+				ID:       channel.ID,
+				Owner:    channel.Owner,
+				Name:     channel.Name,
+				Metadata: channel.Metadata,
+			}
+			res.Channels = append(res.Channels, view)
+		}
+
+		return res, nil
+	}
+}
+
 func viewChannelByThingEndpoint(svc things.Service) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req := request.(viewResourceReq)
@@ -546,6 +812,7 @@ func viewGroupEndpoint(svc things.Service) endpoint.Endpoint {
 			OwnerID:     group.OwnerID,
 			CreatedAt:   group.CreatedAt,
 			UpdatedAt:   group.UpdatedAt,
+			revision:    group.Revision,
 		}
 
 		return res, nil
@@ -564,6 +831,7 @@ func updateGroupEndpoint(svc things.Service) endpoint.Endpoint {
 			Name:        req.Name,
 			Description: req.Description,
 			Metadata:    req.Metadata,
+			Revision:    req.revision,
 		}
 
 		_, err := svc.UpdateGroup(ctx, req.token, group)
@@ -621,6 +889,95 @@ func listGroupsEndpoint(svc things.Service) endpoint.Endpoint {
 		return buildGroupsResponse(page), nil
 	}
 }
+
+func searchGroupsEndpoint(svc things.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listResourcesReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		page, err := svc.SearchGroups(ctx, req.token, req.pageMetadata)
+		if err != nil {
+			return nil, err
+		}
+
+		return buildGroupsResponse(page), nil
+	}
+}
+
+func updateThingByAdminEndpoint(svc things.Service) endpoint.Endpoint {
+	next := updateThingEndpoint(svc)
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(updateThingReq)
+		if err := svc.AuthorizeAdmin(ctx, req.token); err != nil {
+			return nil, err
+		}
+
+		return next(ctx, req)
+	}
+}
+
+func removeThingByAdminEndpoint(svc things.Service) endpoint.Endpoint {
+	next := removeThingEndpoint(svc)
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(viewResourceReq)
+		if err := svc.AuthorizeAdmin(ctx, req.token); err != nil {
+			return nil, err
+		}
+
+		return next(ctx, req)
+	}
+}
+
+func updateChannelByAdminEndpoint(svc things.Service) endpoint.Endpoint {
+	next := updateChannelEndpoint(svc)
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(updateChannelReq)
+		if err := svc.AuthorizeAdmin(ctx, req.token); err != nil {
+			return nil, err
+		}
+
+		return next(ctx, req)
+	}
+}
+
+func removeChannelByAdminEndpoint(svc things.Service) endpoint.Endpoint {
+	next := removeChannelEndpoint(svc)
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(viewResourceReq)
+		if err := svc.AuthorizeAdmin(ctx, req.token); err != nil {
+			return nil, err
+		}
+
+		return next(ctx, req)
+	}
+}
+
+func updateGroupByAdminEndpoint(svc things.Service) endpoint.Endpoint {
+	next := updateGroupEndpoint(svc)
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(updateGroupReq)
+		if err := svc.AuthorizeAdmin(ctx, req.token); err != nil {
+			return nil, err
+		}
+
+		return next(ctx, req)
+	}
+}
+
+func removeGroupByAdminEndpoint(svc things.Service) endpoint.Endpoint {
+	next := removeGroupEndpoint(svc)
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(groupReq)
+		if err := svc.AuthorizeAdmin(ctx, req.token); err != nil {
+			return nil, err
+		}
+
+		return next(ctx, req)
+	}
+}
+
 func listGroupThingsEndpoint(svc things.Service) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req := request.(listMembersReq)
@@ -700,6 +1057,83 @@ func unassignThingsEndpoint(svc things.Service) endpoint.Endpoint {
 	}
 }
 
+func assignThingsBatchEndpoint(svc things.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(groupThingsBatchReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		res, err := svc.AssignThingsBatch(ctx, req.token, req.groupID, req.Things...)
+		if err != nil {
+			return nil, err
+		}
+
+		return buildBatchResponse(res), nil
+	}
+}
+
+func unassignThingsBatchEndpoint(svc things.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(groupThingsBatchReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		res, err := svc.UnassignThingsBatch(ctx, req.token, req.groupID, req.Things...)
+		if err != nil {
+			return nil, err
+		}
+
+		return buildBatchResponse(res), nil
+	}
+}
+
+func assignChannelsBatchEndpoint(svc things.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(groupChannelsBatchReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		res, err := svc.AssignChannelsBatch(ctx, req.token, req.groupID, req.Channels...)
+		if err != nil {
+			return nil, err
+		}
+
+		return buildBatchResponse(res), nil
+	}
+}
+
+func unassignChannelsBatchEndpoint(svc things.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(groupChannelsBatchReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		res, err := svc.UnassignChannelsBatch(ctx, req.token, req.groupID, req.Channels...)
+		if err != nil {
+			return nil, err
+		}
+
+		return buildBatchResponse(res), nil
+	}
+}
+
+func buildBatchResponse(br things.BatchResult) batchRes {
+	res := batchRes{
+		Processed: br.Processed(),
+		Failed:    []batchFailureRes{},
+	}
+
+	for _, f := range br.Failed {
+		res.Failed = append(res.Failed, batchFailureRes{ID: f.ID, Error: f.Err.Error()})
+	}
+
+	return res
+}
+
 func listGroupChannelsEndpoint(svc things.Service) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req := request.(listMembersReq)
@@ -834,10 +1268,12 @@ func buildGroupThingsResponse(tp things.GroupThingsPage) groupThingsPageRes {
 
 	for _, t := range tp.Things {
 		view := thingRes{
-			ID:       t.ID,
-			Metadata: t.Metadata,
-			Name:     t.Name,
-			Key:      t.Key,
+			ID:         t.ID,
+			Metadata:   t.Metadata,
+			Name:       t.Name,
+			Key:        t.Key,
+			ExternalID: t.ExternalID,
+			Location:   t.Location,
 		}
 		res.Things = append(res.Things, view)
 	}
@@ -870,6 +1306,7 @@ func buildGroupChannelsResponse(cp things.GroupChannelsPage) groupChannelsPageRe
 
 func buildBackupResponse(backup things.Backup) backupRes {
 	res := backupRes{
+		Version:               backupVersion,
 		Things:                []backupThingRes{},
 		Channels:              []backupChannelRes{},
 		Connections:           []backupConnectionRes{},
@@ -880,11 +1317,13 @@ func buildBackupResponse(backup things.Backup) backupRes {
 
 	for _, thing := range backup.Things {
 		view := backupThingRes{
-			ID:       thing.ID,
-			Name:     thing.Name,
-			Owner:    thing.Owner,
-			Key:      thing.Key,
-			Metadata: thing.Metadata,
+			ID:         thing.ID,
+			Name:       thing.Name,
+			Owner:      thing.Owner,
+			Key:        thing.Key,
+			ExternalID: thing.ExternalID,
+			Location:   thing.Location,
+			Metadata:   thing.Metadata,
 		}
 		res.Things = append(res.Things, view)
 	}
@@ -948,11 +1387,13 @@ func buildBackupResponse(backup things.Backup) backupRes {
 func buildBackup(req restoreReq) (backup things.Backup) {
 	for _, thing := range req.Things {
 		th := things.Thing{
-			ID:       thing.ID,
-			Owner:    thing.Owner,
-			Name:     thing.Name,
-			Key:      thing.Key,
-			Metadata: thing.Metadata,
+			ID:         thing.ID,
+			Owner:      thing.Owner,
+			Name:       thing.Name,
+			Key:        thing.Key,
+			ExternalID: thing.ExternalID,
+			Location:   thing.Location,
+			Metadata:   thing.Metadata,
 		}
 		backup.Things = append(backup.Things, th)
 	}
@@ -1012,3 +1453,271 @@ func buildBackup(req restoreReq) (backup things.Backup) {
 
 	return backup
 }
+
+func createGroupTemplateEndpoint(svc things.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(createGroupTemplateReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		gt := things.GroupTemplate{
+			Name:        req.Name,
+			Description: req.Description,
+			Channels:    req.Channels,
+		}
+
+		saved, err := svc.CreateGroupTemplate(ctx, req.token, gt)
+		if err != nil {
+			return nil, err
+		}
+
+		res := toGroupTemplateRes(saved)
+		res.created = true
+
+		return res, nil
+	}
+}
+
+func viewGroupTemplateEndpoint(svc things.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(groupTemplateReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		gt, err := svc.ViewGroupTemplate(ctx, req.token, req.id)
+		if err != nil {
+			return nil, err
+		}
+
+		return toGroupTemplateRes(gt), nil
+	}
+}
+
+func listGroupTemplatesEndpoint(svc things.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listGroupTemplatesReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		page, err := svc.ListGroupTemplates(ctx, req.token, req.pageMetadata)
+		if err != nil {
+			return nil, err
+		}
+
+		res := groupTemplatesPageRes{
+			pageRes: pageRes{
+				Total:  page.Total,
+				Offset: page.Offset,
+				Limit:  page.Limit,
+			},
+			Templates: []groupTemplateRes{},
+		}
+		for _, gt := range page.Templates {
+			res.Templates = append(res.Templates, toGroupTemplateRes(gt))
+		}
+
+		return res, nil
+	}
+}
+
+func removeGroupTemplateEndpoint(svc things.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(groupTemplateReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		if err := svc.RemoveGroupTemplate(ctx, req.token, req.id); err != nil {
+			return nil, err
+		}
+
+		return removeRes{}, nil
+	}
+}
+
+func createGroupFromTemplateEndpoint(svc things.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(createGroupFromTemplateReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		group := things.Group{
+			Name:        req.Name,
+			Description: req.Description,
+			Metadata:    req.Metadata,
+		}
+
+		gr, err := svc.CreateGroupFromTemplate(ctx, req.token, req.templateID, group)
+		if err != nil {
+			return nil, err
+		}
+
+		res := groupsRes{
+			Groups: []groupRes{
+				{
+					ID:          gr.ID,
+					Name:        gr.Name,
+					Description: gr.Description,
+					Metadata:    gr.Metadata,
+				},
+			},
+			created: true,
+		}
+
+		return res, nil
+	}
+}
+
+func toGroupTemplateRes(gt things.GroupTemplate) groupTemplateRes {
+	chs := gt.Channels
+	if chs == nil {
+		chs = []things.ChannelTemplate{}
+	}
+
+	return groupTemplateRes{
+		ID:          gt.ID,
+		Name:        gt.Name,
+		Description: gt.Description,
+		Channels:    chs,
+		CreatedAt:   gt.CreatedAt,
+	}
+}
+
+func viewGroupStatsEndpoint(svc things.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(groupReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		gs, err := svc.ViewGroupStats(ctx, req.token, req.id)
+		if err != nil {
+			return nil, err
+		}
+
+		res := groupStatsRes{
+			Things:             gs.Things,
+			Channels:           gs.Channels,
+			ConnectedThings:    gs.ConnectedThings,
+			DisconnectedThings: gs.DisconnectedThings,
+			Messages:           gs.Messages,
+			StoredMessages:     gs.StoredMessages,
+			ComputedAt:         gs.ComputedAt,
+		}
+
+		return res, nil
+	}
+}
+
+func exportGroupEndpoint(svc things.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(exportGroupReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		ge, err := svc.ExportGroup(ctx, req.token, req.id)
+		if err != nil {
+			return nil, err
+		}
+
+		return buildGroupExportResponse(ge), nil
+	}
+}
+
+func importGroupEndpoint(svc things.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(importGroupReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		ge := things.GroupExport{
+			Group: things.Group{
+				Name:        req.Group.Name,
+				Description: req.Group.Description,
+				Metadata:    req.Group.Metadata,
+			},
+		}
+		for _, thing := range req.Things {
+			ge.Things = append(ge.Things, things.Thing{
+				ID:         thing.ID,
+				Name:       thing.Name,
+				Key:        thing.Key,
+				ExternalID: thing.ExternalID,
+				Location:   thing.Location,
+				Metadata:   thing.Metadata,
+			})
+		}
+		for _, channel := range req.Channels {
+			ge.Channels = append(ge.Channels, things.Channel{
+				ID:       channel.ID,
+				Name:     channel.Name,
+				Metadata: channel.Metadata,
+			})
+		}
+		for _, conn := range req.Connections {
+			ge.Connections = append(ge.Connections, things.Connection{
+				ChannelID: conn.ChannelID,
+				ThingID:   conn.ThingID,
+			})
+		}
+
+		imported, err := svc.ImportGroup(ctx, req.token, ge, req.rekey)
+		if err != nil {
+			return nil, err
+		}
+
+		res := buildGroupExportResponse(imported)
+		res.created = true
+
+		return res, nil
+	}
+}
+
+func buildGroupExportResponse(ge things.GroupExport) groupExportRes {
+	res := groupExportRes{
+		Version: exportVersion,
+		Group: groupRes{
+			ID:          ge.Group.ID,
+			Name:        ge.Group.Name,
+			Description: ge.Group.Description,
+			Metadata:    ge.Group.Metadata,
+		},
+		Things:      []backupThingRes{},
+		Channels:    []backupChannelRes{},
+		Connections: []backupConnectionRes{},
+	}
+
+	for _, thing := range ge.Things {
+		res.Things = append(res.Things, backupThingRes{
+			ID:         thing.ID,
+			Name:       thing.Name,
+			Key:        thing.Key,
+			ExternalID: thing.ExternalID,
+			Location:   thing.Location,
+			Metadata:   thing.Metadata,
+		})
+	}
+
+	for _, channel := range ge.Channels {
+		res.Channels = append(res.Channels, backupChannelRes{
+			ID:       channel.ID,
+			Name:     channel.Name,
+			Metadata: channel.Metadata,
+		})
+	}
+
+	for _, conn := range ge.Connections {
+		res.Connections = append(res.Connections, backupConnectionRes{
+			ChannelID: conn.ChannelID,
+			ThingID:   conn.ThingID,
+		})
+	}
+
+	return res
+}
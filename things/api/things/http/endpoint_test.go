@@ -20,6 +20,7 @@ import (
 	"github.com/MainfluxLabs/mainflux/logger"
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
 	"github.com/MainfluxLabs/mainflux/pkg/mocks"
+	"github.com/MainfluxLabs/mainflux/pkg/signature"
 	"github.com/MainfluxLabs/mainflux/pkg/uuid"
 	"github.com/MainfluxLabs/mainflux/things"
 	httpapi "github.com/MainfluxLabs/mainflux/things/api/things/http"
@@ -31,6 +32,7 @@ import (
 )
 
 const (
+	requestID      = "8c5c3f7e-0a52-4a87-9d5e-3e1b6f9a2c41"
 	contentType    = "application/json"
 	email          = "user@example.com"
 	adminEmail     = "admin@example.com"
@@ -64,9 +66,9 @@ var (
 		Metadata: map[string]interface{}{"test": "data"},
 	}
 	invalidName    = strings.Repeat("m", maxNameSize+1)
-	notFoundRes    = toJSON(apiutil.ErrorRes{Err: errors.ErrNotFound.Error()})
-	unauthRes      = toJSON(apiutil.ErrorRes{Err: errors.ErrAuthentication.Error()})
-	missingTokRes  = toJSON(apiutil.ErrorRes{Err: apiutil.ErrBearerToken.Error()})
+	notFoundRes    = toErrorRes(errors.ErrNotFound)
+	unauthRes      = toErrorRes(errors.ErrAuthentication)
+	missingTokRes  = toErrorRes(apiutil.ErrBearerToken)
 	searchThingReq = things.PageMetadata{
 		Limit:  5,
 		Offset: 0,
@@ -84,6 +86,7 @@ type testRequest struct {
 	url         string
 	contentType string
 	token       string
+	ifMatch     string
 	body        io.Reader
 }
 
@@ -98,6 +101,10 @@ func (tr testRequest) make() (*http.Response, error) {
 	if tr.contentType != "" {
 		req.Header.Set("Content-Type", tr.contentType)
 	}
+	if tr.ifMatch != "" {
+		req.Header.Set("If-Match", tr.ifMatch)
+	}
+	req.Header.Set(apiutil.RequestIDHeader, requestID)
 	return tr.client.Do(req)
 }
 
@@ -107,11 +114,12 @@ func newService() things.Service {
 	thingsRepo := thmocks.NewThingRepository(conns)
 	channelsRepo := thmocks.NewChannelRepository(thingsRepo, conns)
 	groupsRepo := thmocks.NewGroupRepository()
+	templatesRepo := thmocks.NewGroupTemplateRepository()
 	chanCache := thmocks.NewChannelCache()
 	thingCache := thmocks.NewThingCache()
 	idProvider := uuid.NewMock()
 
-	return things.New(auth, thingsRepo, channelsRepo, groupsRepo, chanCache, thingCache, idProvider)
+	return things.New(auth, thingsRepo, channelsRepo, groupsRepo, templatesRepo, chanCache, thingCache, idProvider, idProvider, idProvider, idProvider, nil, nil)
 }
 
 func newServer(svc things.Service) *httptest.Server {
@@ -125,6 +133,10 @@ func toJSON(data interface{}) string {
 	return string(jsonData)
 }
 
+func toErrorRes(err errors.Error, details ...string) string {
+	return toJSON(apiutil.ErrorRes{Err: err.Msg(), Code: apiutil.ErrorCode(err), Message: err.Msg(), Details: details, TraceID: requestID})
+}
+
 func TestCreateThings(t *testing.T) {
 	svc := newService()
 	ts := newServer(svc)
@@ -132,6 +144,7 @@ func TestCreateThings(t *testing.T) {
 
 	data := `[{"name": "1", "key": "1"}, {"name": "2", "key": "2"}]`
 	invalidData := fmt.Sprintf(`[{"name": "%s", "key": "10"}]`, invalidName)
+	invalidLocationData := `[{"name": "3", "key": "3", "location": {"latitude": 91, "longitude": 20}}]`
 
 	cases := []struct {
 		desc        string
@@ -173,6 +186,14 @@ func TestCreateThings(t *testing.T) {
 			status:      http.StatusBadRequest,
 			response:    "",
 		},
+		{
+			desc:        "create thing with invalid location",
+			data:        invalidLocationData,
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusBadRequest,
+			response:    "",
+		},
 		{
 			desc:        "create things with empty JSON array",
 			data:        "[]",
@@ -351,6 +372,75 @@ func TestUpdateThing(t *testing.T) {
 	}
 }
 
+func TestUpdateThingIfMatch(t *testing.T) {
+	svc := newService()
+	ts := newServer(svc)
+	defer ts.Close()
+
+	data := toJSON(thing)
+	ths, err := svc.CreateThings(context.Background(), token, thing)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	th := ths[0]
+
+	viewReq := testRequest{
+		client: ts.Client(),
+		method: http.MethodGet,
+		url:    fmt.Sprintf("%s/things/%s", ts.URL, th.ID),
+		token:  token,
+	}
+	res, err := viewReq.make()
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	etag := res.Header.Get("ETag")
+	assert.Equal(t, `"1"`, etag, fmt.Sprintf("view thing: expected ETag %s got %s", `"1"`, etag))
+
+	cases := []struct {
+		desc    string
+		ifMatch string
+		status  int
+	}{
+		{
+			desc:    "update thing with current revision",
+			ifMatch: etag,
+			status:  http.StatusOK,
+		},
+		{
+			desc:    "update thing with stale revision",
+			ifMatch: etag,
+			status:  http.StatusPreconditionFailed,
+		},
+		{
+			desc:    "update thing with malformed revision",
+			ifMatch: `"invalid"`,
+			status:  http.StatusPreconditionFailed,
+		},
+		{
+			desc:    "update thing with wildcard revision",
+			ifMatch: "*",
+			status:  http.StatusOK,
+		},
+		{
+			desc:    "update thing without revision",
+			ifMatch: "",
+			status:  http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client:      ts.Client(),
+			method:      http.MethodPut,
+			url:         fmt.Sprintf("%s/things/%s", ts.URL, th.ID),
+			contentType: contentType,
+			token:       token,
+			ifMatch:     tc.ifMatch,
+			body:        strings.NewReader(data),
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+	}
+}
+
 func TestUpdateKey(t *testing.T) {
 	svc := newService()
 	ts := newServer(svc)
@@ -473,6 +563,241 @@ func TestUpdateKey(t *testing.T) {
 	}
 }
 
+func TestUpdateSigningKey(t *testing.T) {
+	svc := newService()
+	ts := newServer(svc)
+	defer ts.Close()
+
+	ths, err := svc.CreateThings(context.Background(), token, thing)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	th := ths[0]
+
+	secret := []byte(strings.Repeat("s", 32))
+	data := toJSON(map[string]interface{}{"algorithm": signature.HMACSHA256, "key": secret})
+	shortKeyData := toJSON(map[string]interface{}{"algorithm": signature.HMACSHA256, "key": secret[:16]})
+	invalidAlgData := toJSON(map[string]interface{}{"algorithm": "rsa", "key": secret})
+
+	cases := []struct {
+		desc        string
+		req         string
+		id          string
+		contentType string
+		auth        string
+		status      int
+	}{
+		{
+			desc:        "update signing key of an existing thing",
+			req:         data,
+			id:          th.ID,
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusOK,
+		},
+		{
+			desc:        "update signing key with too short secret",
+			req:         shortKeyData,
+			id:          th.ID,
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "update signing key with unsupported algorithm",
+			req:         invalidAlgData,
+			id:          th.ID,
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "update signing key with empty JSON request",
+			req:         "{}",
+			id:          th.ID,
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "update signing key of non-existent thing",
+			req:         data,
+			id:          strconv.FormatUint(wrongID, 10),
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusNotFound,
+		},
+		{
+			desc:        "update signing key with invalid user token",
+			req:         data,
+			id:          th.ID,
+			contentType: contentType,
+			auth:        wrongValue,
+			status:      http.StatusUnauthorized,
+		},
+		{
+			desc:        "update signing key with invalid data format",
+			req:         "{",
+			id:          th.ID,
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "update signing key without content type",
+			req:         data,
+			id:          th.ID,
+			contentType: "",
+			auth:        token,
+			status:      http.StatusUnsupportedMediaType,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client:      ts.Client(),
+			method:      http.MethodPut,
+			url:         fmt.Sprintf("%s/things/%s/signing-key", ts.URL, tc.id),
+			contentType: tc.contentType,
+			token:       tc.auth,
+			body:        strings.NewReader(tc.req),
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+	}
+}
+
+func TestViewSigningKey(t *testing.T) {
+	svc := newService()
+	ts := newServer(svc)
+	defer ts.Close()
+
+	ths, err := svc.CreateThings(context.Background(), token, thing, thing1)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	th, unsigned := ths[0], ths[1]
+
+	sk := things.SigningKey{
+		ThingID:   th.ID,
+		Algorithm: signature.HMACSHA256,
+		Key:       []byte(strings.Repeat("s", 32)),
+	}
+	err = svc.UpdateSigningKey(context.Background(), token, sk)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	data := toJSON(map[string]interface{}{"algorithm": sk.Algorithm, "key": sk.Key})
+
+	cases := []struct {
+		desc   string
+		id     string
+		auth   string
+		status int
+		res    string
+	}{
+		{
+			desc:   "view signing key of an existing thing",
+			id:     th.ID,
+			auth:   token,
+			status: http.StatusOK,
+			res:    data,
+		},
+		{
+			desc:   "view signing key of thing without signing key",
+			id:     unsigned.ID,
+			auth:   token,
+			status: http.StatusNotFound,
+			res:    notFoundRes,
+		},
+		{
+			desc:   "view signing key of non-existent thing",
+			id:     strconv.FormatUint(wrongID, 10),
+			auth:   token,
+			status: http.StatusNotFound,
+			res:    notFoundRes,
+		},
+		{
+			desc:   "view signing key by passing invalid token",
+			id:     th.ID,
+			auth:   wrongValue,
+			status: http.StatusUnauthorized,
+			res:    unauthRes,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodGet,
+			url:    fmt.Sprintf("%s/things/%s/signing-key", ts.URL, tc.id),
+			token:  tc.auth,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		body, err := ioutil.ReadAll(res.Body)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		data := strings.Trim(string(body), "\n")
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		assert.Equal(t, tc.res, data, fmt.Sprintf("%s: expected body %s got %s", tc.desc, tc.res, data))
+	}
+}
+
+func TestRemoveSigningKey(t *testing.T) {
+	svc := newService()
+	ts := newServer(svc)
+	defer ts.Close()
+
+	ths, err := svc.CreateThings(context.Background(), token, thing)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	th := ths[0]
+
+	sk := things.SigningKey{
+		ThingID:   th.ID,
+		Algorithm: signature.HMACSHA256,
+		Key:       []byte(strings.Repeat("s", 32)),
+	}
+	err = svc.UpdateSigningKey(context.Background(), token, sk)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	cases := []struct {
+		desc   string
+		id     string
+		auth   string
+		status int
+	}{
+		{
+			desc:   "remove signing key with invalid token",
+			id:     th.ID,
+			auth:   wrongValue,
+			status: http.StatusUnauthorized,
+		},
+		{
+			desc:   "remove signing key of an existing thing",
+			id:     th.ID,
+			auth:   token,
+			status: http.StatusNoContent,
+		},
+		{
+			desc:   "remove signing key of non-existent thing",
+			id:     strconv.FormatUint(wrongID, 10),
+			auth:   token,
+			status: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodDelete,
+			url:    fmt.Sprintf("%s/things/%s/signing-key", ts.URL, tc.id),
+			token:  tc.auth,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+	}
+
+	_, err = svc.ViewSigningKey(context.Background(), token, th.ID)
+	assert.True(t, errors.Contains(err, errors.ErrNotFound), fmt.Sprintf("view removed signing key: expected %s got %s\n", errors.ErrNotFound, err))
+}
+
 func TestViewThing(t *testing.T) {
 	svc := newService()
 	ts := newServer(svc)
@@ -550,27 +875,100 @@ func TestViewThing(t *testing.T) {
 	}
 }
 
-func TestListThings(t *testing.T) {
+func TestViewThingByExternalID(t *testing.T) {
 	svc := newService()
 	ts := newServer(svc)
 	defer ts.Close()
 
-	data := []thingRes{}
-	for i := 0; i < n; i++ {
-		id := fmt.Sprintf("%s%012d", prefix, i+1)
-		thing1 := thing
-		thing1.ID = id
-		ths, err := svc.CreateThings(context.Background(), token, thing1)
-		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
-		th := ths[0]
-		data = append(data, thingRes{
-			ID:       th.ID,
-			Name:     th.Name,
-			Key:      th.Key,
-			Metadata: th.Metadata,
-		})
-	}
-
+	extTh := thing
+	extTh.ExternalID = "imei-000000000000001"
+	ths, err := svc.CreateThings(context.Background(), token, extTh)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	th := ths[0]
+
+	data := toJSON(thingRes{
+		ID:         th.ID,
+		Name:       th.Name,
+		Key:        th.Key,
+		ExternalID: th.ExternalID,
+		Metadata:   th.Metadata,
+	})
+
+	cases := []struct {
+		desc       string
+		externalID string
+		auth       string
+		status     int
+		res        string
+	}{
+		{
+			desc:       "view thing by existing external ID",
+			externalID: th.ExternalID,
+			auth:       token,
+			status:     http.StatusOK,
+			res:        data,
+		},
+		{
+			desc:       "view thing by non-existent external ID",
+			externalID: wrongValue,
+			auth:       token,
+			status:     http.StatusNotFound,
+			res:        notFoundRes,
+		},
+		{
+			desc:       "view thing by external ID with invalid token",
+			externalID: th.ExternalID,
+			auth:       wrongValue,
+			status:     http.StatusUnauthorized,
+			res:        unauthRes,
+		},
+		{
+			desc:       "view thing by external ID with empty token",
+			externalID: th.ExternalID,
+			auth:       "",
+			status:     http.StatusUnauthorized,
+			res:        missingTokRes,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodGet,
+			url:    fmt.Sprintf("%s/things/external/%s", ts.URL, tc.externalID),
+			token:  tc.auth,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		body, err := ioutil.ReadAll(res.Body)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		data := strings.Trim(string(body), "\n")
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		assert.Equal(t, tc.res, data, fmt.Sprintf("%s: expected body %s got %s", tc.desc, tc.res, data))
+	}
+}
+
+func TestListThings(t *testing.T) {
+	svc := newService()
+	ts := newServer(svc)
+	defer ts.Close()
+
+	data := []thingRes{}
+	for i := 0; i < n; i++ {
+		id := fmt.Sprintf("%s%012d", prefix, i+1)
+		thing1 := thing
+		thing1.ID = id
+		ths, err := svc.CreateThings(context.Background(), token, thing1)
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+		th := ths[0]
+		data = append(data, thingRes{
+			ID:       th.ID,
+			Name:     th.Name,
+			Key:      th.Key,
+			Metadata: th.Metadata,
+		})
+	}
+
 	thingURL := fmt.Sprintf("%s/things", ts.URL)
 	cases := []struct {
 		desc   string
@@ -747,6 +1145,34 @@ func TestListThings(t *testing.T) {
 			url:    fmt.Sprintf("%s?offset=%d&limit=%d&order=%s&dir=%s", thingURL, 0, 5, nameKey, "wrong"),
 			res:    nil,
 		},
+		{
+			desc:   "get a list of things within area",
+			auth:   token,
+			status: http.StatusOK,
+			url:    fmt.Sprintf("%s?offset=%d&limit=%d&within=%s", thingURL, 0, 5, "44.81,20.46,1000"),
+			res:    []thingRes{},
+		},
+		{
+			desc:   "get a list of things within area with invalid format",
+			auth:   token,
+			status: http.StatusBadRequest,
+			url:    fmt.Sprintf("%s?offset=%d&limit=%d&within=%s", thingURL, 0, 5, "44.81,20.46"),
+			res:    nil,
+		},
+		{
+			desc:   "get a list of things within area with invalid latitude",
+			auth:   token,
+			status: http.StatusBadRequest,
+			url:    fmt.Sprintf("%s?offset=%d&limit=%d&within=%s", thingURL, 0, 5, "95,20.46,1000"),
+			res:    nil,
+		},
+		{
+			desc:   "get a list of things within area with invalid radius",
+			auth:   token,
+			status: http.StatusBadRequest,
+			url:    fmt.Sprintf("%s?offset=%d&limit=%d&within=%s", thingURL, 0, 5, "44.81,20.46,0"),
+			res:    nil,
+		},
 	}
 
 	for _, tc := range cases {
@@ -765,6 +1191,96 @@ func TestListThings(t *testing.T) {
 	}
 }
 
+func TestSearchThingsByText(t *testing.T) {
+	svc := newService()
+	ts := newServer(svc)
+	defer ts.Close()
+
+	data := []thingRes{}
+	for i := 0; i < n; i++ {
+		th := thing
+		th.ID = fmt.Sprintf("%s%012d", prefix, i+1)
+		th.Name = fmt.Sprintf("pump-%d", i)
+		th.ExternalID = fmt.Sprintf("SN-%04d", i)
+		ths, err := svc.CreateThings(context.Background(), token, th)
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+		data = append(data, thingRes{
+			ID:         ths[0].ID,
+			Name:       ths[0].Name,
+			Key:        ths[0].Key,
+			ExternalID: ths[0].ExternalID,
+			Metadata:   ths[0].Metadata,
+		})
+	}
+
+	searchURL := fmt.Sprintf("%s/things/search", ts.URL)
+	cases := []struct {
+		desc   string
+		auth   string
+		status int
+		url    string
+		res    []thingRes
+	}{
+		{
+			desc:   "search things by partial external ID",
+			auth:   token,
+			status: http.StatusOK,
+			url:    fmt.Sprintf("%s?q=%s", searchURL, "SN-0007"),
+			res:    data[7:8],
+		},
+		{
+			desc:   "search things without match",
+			auth:   token,
+			status: http.StatusOK,
+			url:    fmt.Sprintf("%s?q=%s", searchURL, "compressor"),
+			res:    []thingRes{},
+		},
+		{
+			desc:   "search things without search text",
+			auth:   token,
+			status: http.StatusBadRequest,
+			url:    searchURL,
+			res:    nil,
+		},
+		{
+			desc:   "search things with too long search text",
+			auth:   token,
+			status: http.StatusBadRequest,
+			url:    fmt.Sprintf("%s?q=%s", searchURL, invalidName),
+			res:    nil,
+		},
+		{
+			desc:   "search things with invalid limit",
+			auth:   token,
+			status: http.StatusBadRequest,
+			url:    fmt.Sprintf("%s?q=%s&limit=%d", searchURL, "SN", 110),
+			res:    nil,
+		},
+		{
+			desc:   "search things with invalid token",
+			auth:   wrongValue,
+			status: http.StatusUnauthorized,
+			url:    fmt.Sprintf("%s?q=%s", searchURL, "SN"),
+			res:    nil,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodGet,
+			url:    tc.url,
+			token:  tc.auth,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		var page thingsPageRes
+		json.NewDecoder(res.Body).Decode(&page)
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		assert.ElementsMatch(t, tc.res, page.Things, fmt.Sprintf("%s: expected body %v got %v", tc.desc, tc.res, page.Things))
+	}
+}
+
 func TestSearchThings(t *testing.T) {
 	svc := newService()
 	ts := newServer(svc)
@@ -961,114 +1477,378 @@ func TestSearchThings(t *testing.T) {
 	}
 }
 
-func TestListThingsByChannel(t *testing.T) {
+func TestSearchThingsByAdmin(t *testing.T) {
 	svc := newService()
 	ts := newServer(svc)
 	defer ts.Close()
 
-	chs, err := svc.CreateChannels(context.Background(), token, channel)
-	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
-	ch := chs[0]
-
-	data := []thingRes{}
-	thIDs := []string{}
-	for i := 0; i < n; i++ {
-		id := fmt.Sprintf("%s%012d", prefix, i+1)
-		thing1 := thing
-		thing1.ID = id
-		ths, err := svc.CreateThings(context.Background(), token, thing1)
+	data := []adminThingRes{}
+	for i := 0; i < 10; i++ {
+		th := thing
+		th.ID = fmt.Sprintf("%s%012d", prefix, i+1)
+		th.Key = fmt.Sprintf("%s1%011d", prefix, i+1)
+		ths, err := svc.CreateThings(context.Background(), token, th)
 		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
-		th := ths[0]
-
-		data = append(data, thingRes{
-			ID:       th.ID,
-			Name:     th.Name,
-			Key:      th.Key,
-			Metadata: th.Metadata,
+		data = append(data, adminThingRes{
+			ID:       ths[0].ID,
+			Owner:    user.ID,
+			Name:     ths[0].Name,
+			Key:      ths[0].Key,
+			Metadata: ths[0].Metadata,
 		})
-		thIDs = append(thIDs, th.ID)
 	}
 
-	grs, err := svc.CreateGroups(context.Background(), token, group)
-	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
-	gr := grs[0]
-
-	err = svc.AssignThing(context.Background(), token, gr.ID, thIDs...)
-	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
-
-	err = svc.AssignChannel(context.Background(), token, gr.ID, ch.ID)
-	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
-
-	err = svc.Connect(context.Background(), token, ch.ID, thIDs)
-	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
-
-	thingURL := fmt.Sprintf("%s/channels", ts.URL)
-
-	// Wait for things and channels to connect.
-	time.Sleep(time.Second)
-
+	searchURL := fmt.Sprintf("%s/admin/things", ts.URL)
 	cases := []struct {
 		desc   string
 		auth   string
 		status int
 		url    string
-		res    []thingRes
+		res    []adminThingRes
 	}{
 		{
-			desc:   "get a list of things by channel",
-			auth:   token,
+			desc:   "search things by owner",
+			auth:   adminToken,
 			status: http.StatusOK,
-			url:    fmt.Sprintf("%s/%s/things?offset=%d&limit=%d", thingURL, ch.ID, 0, 5),
-			res:    data[0:5],
+			url:    fmt.Sprintf("%s?limit=%d&owner=%s", searchURL, 100, user.ID),
+			res:    data,
 		},
 		{
-			desc:   "get a list of things by channel with no limit",
-			auth:   token,
+			desc:   "search things by key prefix",
+			auth:   adminToken,
 			status: http.StatusOK,
-			url:    fmt.Sprintf("%s/%s/things?limit=%d", thingURL, ch.ID, noLimit),
-			res:    data,
+			url:    fmt.Sprintf("%s?limit=%d&key=%s", searchURL, 100, prefix+"10000000001"),
+			res:    data[9:],
 		},
 		{
-			desc:   "get a list of things by channel with invalid token",
+			desc:   "search things as non-admin user",
+			auth:   token,
+			status: http.StatusForbidden,
+			url:    fmt.Sprintf("%s?owner=%s", searchURL, user.ID),
+			res:    nil,
+		},
+		{
+			desc:   "search things with invalid token",
 			auth:   wrongValue,
 			status: http.StatusUnauthorized,
-			url:    fmt.Sprintf("%s/%s/things?offset=%d&limit=%d", thingURL, ch.ID, 0, 1),
+			url:    searchURL,
 			res:    nil,
 		},
 		{
-			desc:   "get a list of things by channel with empty token",
+			desc:   "search things with empty token",
 			auth:   "",
 			status: http.StatusUnauthorized,
-			url:    fmt.Sprintf("%s/%s/things?offset=%d&limit=%d", thingURL, ch.ID, 0, 1),
+			url:    searchURL,
 			res:    nil,
 		},
 		{
-			desc:   "get a list of things by channel with negative offset",
-			auth:   token,
+			desc:   "search things with invalid limit",
+			auth:   adminToken,
 			status: http.StatusBadRequest,
-			url:    fmt.Sprintf("%s/%s/things?offset=%d&limit=%d", thingURL, ch.ID, -2, 5),
+			url:    fmt.Sprintf("%s?limit=%d", searchURL, 110),
 			res:    nil,
 		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodGet,
+			url:    tc.url,
+			token:  tc.auth,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		var page adminThingsPageRes
+		json.NewDecoder(res.Body).Decode(&page)
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		assert.ElementsMatch(t, tc.res, page.Things, fmt.Sprintf("%s: expected body %v got %v", tc.desc, tc.res, page.Things))
+	}
+}
+
+func TestUpdateByAdmin(t *testing.T) {
+	svc := newService()
+	ts := newServer(svc)
+	defer ts.Close()
+
+	ths, err := svc.CreateThings(context.Background(), token, thing)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	th := ths[0]
+
+	chs, err := svc.CreateChannels(context.Background(), token, channel)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	ch := chs[0]
+
+	grs, err := svc.CreateGroups(context.Background(), token, group)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	gr := grs[0]
+
+	data := `{"name": "updated-by-admin"}`
+	cases := []struct {
+		desc   string
+		url    string
+		auth   string
+		status int
+	}{
 		{
-			desc:   "get a list of things by channel with negative limit",
-			auth:   token,
-			status: http.StatusBadRequest,
-			url:    fmt.Sprintf("%s/%s/things?offset=%d&limit=%d", thingURL, ch.ID, 1, -5),
-			res:    nil,
+			desc:   "update thing as admin",
+			url:    fmt.Sprintf("%s/admin/things/%s", ts.URL, th.ID),
+			auth:   adminToken,
+			status: http.StatusOK,
 		},
 		{
-			desc:   "get a list of things by channel with zero limit",
+			desc:   "update thing as its owner",
+			url:    fmt.Sprintf("%s/admin/things/%s", ts.URL, th.ID),
 			auth:   token,
-			status: http.StatusBadRequest,
-			url:    fmt.Sprintf("%s/%s/things?offset=%d&limit=%d", thingURL, ch.ID, 1, 0),
-			res:    nil,
+			status: http.StatusForbidden,
 		},
 		{
-			desc:   "get a list of things by channel without offset",
-			auth:   token,
-			status: http.StatusOK,
-			url:    fmt.Sprintf("%s/%s/things?limit=%d", thingURL, ch.ID, 5),
-			res:    data[0:5],
+			desc:   "update non-existent thing as admin",
+			url:    fmt.Sprintf("%s/admin/things/%s", ts.URL, strconv.FormatUint(wrongID, 10)),
+			auth:   adminToken,
+			status: http.StatusNotFound,
+		},
+		{
+			desc:   "update channel as admin",
+			url:    fmt.Sprintf("%s/admin/channels/%s", ts.URL, ch.ID),
+			auth:   adminToken,
+			status: http.StatusOK,
+		},
+		{
+			desc:   "update channel as its owner",
+			url:    fmt.Sprintf("%s/admin/channels/%s", ts.URL, ch.ID),
+			auth:   token,
+			status: http.StatusForbidden,
+		},
+		{
+			desc:   "update group as admin",
+			url:    fmt.Sprintf("%s/admin/groups/%s", ts.URL, gr.ID),
+			auth:   adminToken,
+			status: http.StatusOK,
+		},
+		{
+			desc:   "update group as its owner",
+			url:    fmt.Sprintf("%s/admin/groups/%s", ts.URL, gr.ID),
+			auth:   token,
+			status: http.StatusForbidden,
+		},
+		{
+			desc:   "update thing with invalid token",
+			url:    fmt.Sprintf("%s/admin/things/%s", ts.URL, th.ID),
+			auth:   wrongValue,
+			status: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client:      ts.Client(),
+			method:      http.MethodPut,
+			url:         tc.url,
+			contentType: contentType,
+			token:       tc.auth,
+			body:        strings.NewReader(data),
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+	}
+
+	// Admin updates the entities on behalf of their owner.
+	uth, err := svc.ViewThing(context.Background(), token, th.ID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.Equal(t, th.Owner, uth.Owner, fmt.Sprintf("expected owner %s got %s", th.Owner, uth.Owner))
+	assert.Equal(t, "updated-by-admin", uth.Name, fmt.Sprintf("expected name %s got %s", "updated-by-admin", uth.Name))
+}
+
+func TestRemoveByAdmin(t *testing.T) {
+	svc := newService()
+	ts := newServer(svc)
+	defer ts.Close()
+
+	ths, err := svc.CreateThings(context.Background(), token, thing)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	th := ths[0]
+
+	chs, err := svc.CreateChannels(context.Background(), token, channel)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	ch := chs[0]
+
+	grs, err := svc.CreateGroups(context.Background(), token, group)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	gr := grs[0]
+
+	cases := []struct {
+		desc   string
+		url    string
+		auth   string
+		status int
+	}{
+		{
+			desc:   "remove thing as its owner",
+			url:    fmt.Sprintf("%s/admin/things/%s", ts.URL, th.ID),
+			auth:   token,
+			status: http.StatusForbidden,
+		},
+		{
+			desc:   "remove thing as admin",
+			url:    fmt.Sprintf("%s/admin/things/%s", ts.URL, th.ID),
+			auth:   adminToken,
+			status: http.StatusNoContent,
+		},
+		{
+			desc:   "remove channel as its owner",
+			url:    fmt.Sprintf("%s/admin/channels/%s", ts.URL, ch.ID),
+			auth:   token,
+			status: http.StatusForbidden,
+		},
+		{
+			desc:   "remove channel as admin",
+			url:    fmt.Sprintf("%s/admin/channels/%s", ts.URL, ch.ID),
+			auth:   adminToken,
+			status: http.StatusNoContent,
+		},
+		{
+			desc:   "remove group as its owner",
+			url:    fmt.Sprintf("%s/admin/groups/%s", ts.URL, gr.ID),
+			auth:   token,
+			status: http.StatusForbidden,
+		},
+		{
+			desc:   "remove group as admin",
+			url:    fmt.Sprintf("%s/admin/groups/%s", ts.URL, gr.ID),
+			auth:   adminToken,
+			status: http.StatusNoContent,
+		},
+		{
+			desc:   "remove thing with invalid token",
+			url:    fmt.Sprintf("%s/admin/things/%s", ts.URL, th.ID),
+			auth:   wrongValue,
+			status: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodDelete,
+			url:    tc.url,
+			token:  tc.auth,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+	}
+}
+
+func TestListThingsByChannel(t *testing.T) {
+	svc := newService()
+	ts := newServer(svc)
+	defer ts.Close()
+
+	chs, err := svc.CreateChannels(context.Background(), token, channel)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	ch := chs[0]
+
+	data := []thingRes{}
+	thIDs := []string{}
+	for i := 0; i < n; i++ {
+		id := fmt.Sprintf("%s%012d", prefix, i+1)
+		thing1 := thing
+		thing1.ID = id
+		ths, err := svc.CreateThings(context.Background(), token, thing1)
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+		th := ths[0]
+
+		data = append(data, thingRes{
+			ID:       th.ID,
+			Name:     th.Name,
+			Key:      th.Key,
+			Metadata: th.Metadata,
+		})
+		thIDs = append(thIDs, th.ID)
+	}
+
+	grs, err := svc.CreateGroups(context.Background(), token, group)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	gr := grs[0]
+
+	err = svc.AssignThing(context.Background(), token, gr.ID, thIDs...)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	err = svc.AssignChannel(context.Background(), token, gr.ID, ch.ID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	err = svc.Connect(context.Background(), token, ch.ID, thIDs)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	thingURL := fmt.Sprintf("%s/channels", ts.URL)
+
+	// Wait for things and channels to connect.
+	time.Sleep(time.Second)
+
+	cases := []struct {
+		desc   string
+		auth   string
+		status int
+		url    string
+		res    []thingRes
+	}{
+		{
+			desc:   "get a list of things by channel",
+			auth:   token,
+			status: http.StatusOK,
+			url:    fmt.Sprintf("%s/%s/things?offset=%d&limit=%d", thingURL, ch.ID, 0, 5),
+			res:    data[0:5],
+		},
+		{
+			desc:   "get a list of things by channel with no limit",
+			auth:   token,
+			status: http.StatusOK,
+			url:    fmt.Sprintf("%s/%s/things?limit=%d", thingURL, ch.ID, noLimit),
+			res:    data,
+		},
+		{
+			desc:   "get a list of things by channel with invalid token",
+			auth:   wrongValue,
+			status: http.StatusUnauthorized,
+			url:    fmt.Sprintf("%s/%s/things?offset=%d&limit=%d", thingURL, ch.ID, 0, 1),
+			res:    nil,
+		},
+		{
+			desc:   "get a list of things by channel with empty token",
+			auth:   "",
+			status: http.StatusUnauthorized,
+			url:    fmt.Sprintf("%s/%s/things?offset=%d&limit=%d", thingURL, ch.ID, 0, 1),
+			res:    nil,
+		},
+		{
+			desc:   "get a list of things by channel with negative offset",
+			auth:   token,
+			status: http.StatusBadRequest,
+			url:    fmt.Sprintf("%s/%s/things?offset=%d&limit=%d", thingURL, ch.ID, -2, 5),
+			res:    nil,
+		},
+		{
+			desc:   "get a list of things by channel with negative limit",
+			auth:   token,
+			status: http.StatusBadRequest,
+			url:    fmt.Sprintf("%s/%s/things?offset=%d&limit=%d", thingURL, ch.ID, 1, -5),
+			res:    nil,
+		},
+		{
+			desc:   "get a list of things by channel with zero limit",
+			auth:   token,
+			status: http.StatusBadRequest,
+			url:    fmt.Sprintf("%s/%s/things?offset=%d&limit=%d", thingURL, ch.ID, 1, 0),
+			res:    nil,
+		},
+		{
+			desc:   "get a list of things by channel without offset",
+			auth:   token,
+			status: http.StatusOK,
+			url:    fmt.Sprintf("%s/%s/things?limit=%d", thingURL, ch.ID, 5),
+			res:    data[0:5],
 		},
 		{
 			desc:   "get a list of things by channel without limit",
@@ -1415,6 +2195,10 @@ func TestUpdateChannel(t *testing.T) {
 	c.Name = invalidName
 	invalidData := toJSON(c)
 
+	c.Name = "updated_channel"
+	c.Metadata = map[string]interface{}{"profile": map[string]interface{}{"acl": map[string]interface{}{"publish": []string{"devices/#/temperature"}}}}
+	invalidACLData := toJSON(c)
+
 	cases := []struct {
 		desc        string
 		req         string
@@ -1495,6 +2279,14 @@ func TestUpdateChannel(t *testing.T) {
 			auth:        token,
 			status:      http.StatusUnsupportedMediaType,
 		},
+		{
+			desc:        "update channel with malformed topic ACL",
+			req:         invalidACLData,
+			id:          ch.ID,
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusBadRequest,
+		},
 		{
 			desc:        "update channel with invalid name",
 			req:         invalidData,
@@ -1600,68 +2392,254 @@ func TestViewChannel(t *testing.T) {
 	}
 }
 
-func TestListChannels(t *testing.T) {
+func TestListChannelVersions(t *testing.T) {
 	svc := newService()
 	ts := newServer(svc)
 	defer ts.Close()
 
-	channels := []channelRes{}
-	for i := 0; i < n; i++ {
-		name := "name_" + fmt.Sprintf("%03d", i+1)
-		chs, err := svc.CreateChannels(context.Background(), token,
-			things.Channel{
-				Name:     name,
-				Metadata: map[string]interface{}{"test": "data"},
-			})
-		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
-		ch := chs[0]
-		ths, err := svc.CreateThings(context.Background(), token, thing)
-		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
-		th := ths[0]
-		svc.Connect(context.Background(), token, ch.ID, []string{th.ID})
+	chs, err := svc.CreateChannels(context.Background(), token, channel)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	ch := chs[0]
 
-		channels = append(channels, channelRes{
-			ID:       ch.ID,
-			Name:     ch.Name,
-			Metadata: ch.Metadata,
-		})
+	for i := 0; i < 3; i++ {
+		ch.Name = fmt.Sprintf("%s_%d", channel.Name, i)
+		err := svc.UpdateChannel(context.Background(), token, ch)
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
 	}
-	channelURL := fmt.Sprintf("%s/channels", ts.URL)
 
 	cases := []struct {
 		desc   string
 		auth   string
 		status int
 		url    string
-		res    []channelRes
+		total  uint64
+		size   int
 	}{
 		{
-			desc:   "get a list of channels",
+			desc:   "list versions of existing channel",
 			auth:   token,
 			status: http.StatusOK,
-			url:    fmt.Sprintf("%s?offset=%d&limit=%d", channelURL, 0, 6),
-			res:    channels[0:6],
+			url:    fmt.Sprintf("%s/channels/%s/profile/versions", ts.URL, ch.ID),
+			total:  3,
+			size:   3,
 		},
 		{
-			desc:   "get a list of all channels with no limit",
+			desc:   "list versions of existing channel with limit",
 			auth:   token,
 			status: http.StatusOK,
-			url:    fmt.Sprintf("%s?limit=%d", channelURL, noLimit),
-			res:    channels,
+			url:    fmt.Sprintf("%s/channels/%s/profile/versions?limit=%d", ts.URL, ch.ID, 2),
+			total:  3,
+			size:   2,
 		},
 		{
-			desc:   "get a list of channels ordered by id descendent",
+			desc:   "list versions of existing channel with invalid limit",
 			auth:   token,
-			status: http.StatusOK,
-			url:    fmt.Sprintf("%s?offset=%d&limit=%d&order=id&dir=desc", channelURL, 0, 6),
-			res:    channels[len(channels)-6:],
+			status: http.StatusBadRequest,
+			url:    fmt.Sprintf("%s/channels/%s/profile/versions?limit=%d", ts.URL, ch.ID, 110),
 		},
 		{
-			desc:   "get a list of channels ordered by id ascendent",
+			desc:   "list versions of non-existent channel",
 			auth:   token,
-			status: http.StatusOK,
-			url:    fmt.Sprintf("%s?offset=%d&limit=%d&order=id&dir=asc", channelURL, 0, 6),
-			res:    channels[0:6],
+			status: http.StatusNotFound,
+			url:    fmt.Sprintf("%s/channels/%s/profile/versions", ts.URL, strconv.FormatUint(wrongID, 10)),
+		},
+		{
+			desc:   "list versions with invalid token",
+			auth:   wrongValue,
+			status: http.StatusUnauthorized,
+			url:    fmt.Sprintf("%s/channels/%s/profile/versions", ts.URL, ch.ID),
+		},
+		{
+			desc:   "list versions with empty token",
+			auth:   "",
+			status: http.StatusUnauthorized,
+			url:    fmt.Sprintf("%s/channels/%s/profile/versions", ts.URL, ch.ID),
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodGet,
+			url:    tc.url,
+			token:  tc.auth,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		var body channelVersionsPageRes
+		json.NewDecoder(res.Body).Decode(&body)
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		assert.Equal(t, tc.total, body.Total, fmt.Sprintf("%s: expected total %d got %d", tc.desc, tc.total, body.Total))
+		assert.Equal(t, tc.size, len(body.Versions), fmt.Sprintf("%s: expected size %d got %d", tc.desc, tc.size, len(body.Versions)))
+	}
+}
+
+func TestRollbackChannel(t *testing.T) {
+	svc := newService()
+	ts := newServer(svc)
+	defer ts.Close()
+
+	chs, err := svc.CreateChannels(context.Background(), token, channel)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	ch := chs[0]
+
+	updated := ch
+	updated.Name = "updated"
+	updated.Metadata = map[string]interface{}{"test": "updated"}
+	err = svc.UpdateChannel(context.Background(), token, updated)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	data := toJSON(channelRes{
+		ID:       ch.ID,
+		Name:     ch.Name,
+		Metadata: ch.Metadata,
+	})
+
+	cases := []struct {
+		desc    string
+		id      string
+		version string
+		auth    string
+		status  int
+		res     string
+	}{
+		{
+			desc:    "rollback channel with invalid token",
+			id:      ch.ID,
+			version: "1",
+			auth:    wrongValue,
+			status:  http.StatusUnauthorized,
+			res:     unauthRes,
+		},
+		{
+			desc:    "rollback channel with empty token",
+			id:      ch.ID,
+			version: "1",
+			auth:    "",
+			status:  http.StatusUnauthorized,
+			res:     missingTokRes,
+		},
+		{
+			desc:    "rollback channel to invalid version",
+			id:      ch.ID,
+			version: "invalid",
+			auth:    token,
+			status:  http.StatusBadRequest,
+			res:     toErrorRes(apiutil.ErrMalformedEntity, `strconv.ParseUint: parsing "invalid": invalid syntax`),
+		},
+		{
+			desc:    "rollback channel to zero version",
+			id:      ch.ID,
+			version: "0",
+			auth:    token,
+			status:  http.StatusBadRequest,
+			res:     toErrorRes(apiutil.ErrInvalidVersion),
+		},
+		{
+			desc:    "rollback channel to non-existent version",
+			id:      ch.ID,
+			version: "5",
+			auth:    token,
+			status:  http.StatusNotFound,
+			res:     notFoundRes,
+		},
+		{
+			desc:    "rollback non-existent channel",
+			id:      strconv.FormatUint(wrongID, 10),
+			version: "1",
+			auth:    token,
+			status:  http.StatusNotFound,
+			res:     notFoundRes,
+		},
+		{
+			desc:    "rollback channel to existing version",
+			id:      ch.ID,
+			version: "1",
+			auth:    token,
+			status:  http.StatusOK,
+			res:     data,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodPost,
+			url:    fmt.Sprintf("%s/channels/%s/profile/versions/%s/rollback", ts.URL, tc.id, tc.version),
+			token:  tc.auth,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		data, err := ioutil.ReadAll(res.Body)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		body := strings.Trim(string(data), "\n")
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		assert.Equal(t, tc.res, body, fmt.Sprintf("%s: expected body %s got %s", tc.desc, tc.res, body))
+	}
+}
+
+func TestListChannels(t *testing.T) {
+	svc := newService()
+	ts := newServer(svc)
+	defer ts.Close()
+
+	channels := []channelRes{}
+	for i := 0; i < n; i++ {
+		name := "name_" + fmt.Sprintf("%03d", i+1)
+		chs, err := svc.CreateChannels(context.Background(), token,
+			things.Channel{
+				Name:     name,
+				Metadata: map[string]interface{}{"test": "data"},
+			})
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+		ch := chs[0]
+		ths, err := svc.CreateThings(context.Background(), token, thing)
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+		th := ths[0]
+		svc.Connect(context.Background(), token, ch.ID, []string{th.ID})
+
+		channels = append(channels, channelRes{
+			ID:       ch.ID,
+			Name:     ch.Name,
+			Metadata: ch.Metadata,
+		})
+	}
+	channelURL := fmt.Sprintf("%s/channels", ts.URL)
+
+	cases := []struct {
+		desc   string
+		auth   string
+		status int
+		url    string
+		res    []channelRes
+	}{
+		{
+			desc:   "get a list of channels",
+			auth:   token,
+			status: http.StatusOK,
+			url:    fmt.Sprintf("%s?offset=%d&limit=%d", channelURL, 0, 6),
+			res:    channels[0:6],
+		},
+		{
+			desc:   "get a list of all channels with no limit",
+			auth:   token,
+			status: http.StatusOK,
+			url:    fmt.Sprintf("%s?limit=%d", channelURL, noLimit),
+			res:    channels,
+		},
+		{
+			desc:   "get a list of channels ordered by id descendent",
+			auth:   token,
+			status: http.StatusOK,
+			url:    fmt.Sprintf("%s?offset=%d&limit=%d&order=id&dir=desc", channelURL, 0, 6),
+			res:    channels[len(channels)-6:],
+		},
+		{
+			desc:   "get a list of channels ordered by id ascendent",
+			auth:   token,
+			status: http.StatusOK,
+			url:    fmt.Sprintf("%s?offset=%d&limit=%d&order=id&dir=asc", channelURL, 0, 6),
+			res:    channels[0:6],
 		},
 		{
 			desc:   "get a list of channels with invalid order",
@@ -2272,6 +3250,113 @@ func TestConnect(t *testing.T) {
 	}
 }
 
+func TestListConnections(t *testing.T) {
+	svc := newService()
+	ts := newServer(svc)
+	defer ts.Close()
+
+	ths, err := svc.CreateThings(context.Background(), token, thing, thing1)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	th1, th2 := ths[0], ths[1]
+
+	chs, err := svc.CreateChannels(context.Background(), token, channel, channel)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	ch1, ch2 := chs[0], chs[1]
+
+	grs, err := svc.CreateGroups(context.Background(), token, group)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	gr := grs[0]
+
+	err = svc.AssignThing(context.Background(), token, gr.ID, th1.ID, th2.ID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	err = svc.AssignChannel(context.Background(), token, gr.ID, ch1.ID, ch2.ID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	err = svc.Connect(context.Background(), token, ch1.ID, []string{th1.ID})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	err = svc.Connect(context.Background(), token, ch2.ID, []string{th2.ID})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc   string
+		auth   string
+		url    string
+		status int
+		res    []connectionRes
+	}{
+		{
+			desc:   "list all connections",
+			auth:   token,
+			url:    fmt.Sprintf("%s/connections", ts.URL),
+			status: http.StatusOK,
+			res:    []connectionRes{{ChannelID: ch1.ID, ThingID: th1.ID}, {ChannelID: ch2.ID, ThingID: th2.ID}},
+		},
+		{
+			desc:   "list connections with limit",
+			auth:   token,
+			url:    fmt.Sprintf("%s/connections?limit=%d", ts.URL, 1),
+			status: http.StatusOK,
+			res:    []connectionRes{{ChannelID: ch1.ID, ThingID: th1.ID}},
+		},
+		{
+			desc:   "list connections of thing",
+			auth:   token,
+			url:    fmt.Sprintf("%s/connections?thing=%s", ts.URL, th2.ID),
+			status: http.StatusOK,
+			res:    []connectionRes{{ChannelID: ch2.ID, ThingID: th2.ID}},
+		},
+		{
+			desc:   "list connections of channel",
+			auth:   token,
+			url:    fmt.Sprintf("%s/connections?channel=%s", ts.URL, ch1.ID),
+			status: http.StatusOK,
+			res:    []connectionRes{{ChannelID: ch1.ID, ThingID: th1.ID}},
+		},
+		{
+			desc:   "list connections of disconnected thing and channel",
+			auth:   token,
+			url:    fmt.Sprintf("%s/connections?thing=%s&channel=%s", ts.URL, th1.ID, ch2.ID),
+			status: http.StatusOK,
+			res:    []connectionRes{},
+		},
+		{
+			desc:   "list connections with invalid limit",
+			auth:   token,
+			url:    fmt.Sprintf("%s/connections?limit=%d", ts.URL, 110),
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "list connections with invalid token",
+			auth:   wrongValue,
+			url:    fmt.Sprintf("%s/connections", ts.URL),
+			status: http.StatusUnauthorized,
+		},
+		{
+			desc:   "list connections with empty token",
+			auth:   "",
+			url:    fmt.Sprintf("%s/connections", ts.URL),
+			status: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodGet,
+			url:    tc.url,
+			token:  tc.auth,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		var body connectionsPageRes
+		json.NewDecoder(res.Body).Decode(&body)
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		assert.ElementsMatch(t, tc.res, body.Connections, fmt.Sprintf("%s: expected connections %v got %v", tc.desc, tc.res, body.Connections))
+	}
+}
+
 func TestDisconnect(t *testing.T) {
 	svc := newService()
 	ts := newServer(svc)
@@ -2379,185 +3464,710 @@ func TestDisconnect(t *testing.T) {
 			status:      http.StatusUnauthorized,
 		},
 		{
-			desc:        "disconnect existing things from existing channels with empty token",
-			channelID:   ch.ID,
-			thingIDs:    thIDs,
-			auth:        "",
-			contentType: contentType,
-			status:      http.StatusUnauthorized,
+			desc:        "disconnect existing things from existing channels with empty token",
+			channelID:   ch.ID,
+			thingIDs:    thIDs,
+			auth:        "",
+			contentType: contentType,
+			status:      http.StatusUnauthorized,
+		},
+		{
+			desc:        "disconnect things from channels of other user",
+			channelID:   usrCh.ID,
+			thingIDs:    thIDs,
+			auth:        token,
+			contentType: contentType,
+			status:      http.StatusNotFound,
+		},
+		{
+			desc:        "disconnect with invalid content type",
+			channelID:   ch.ID,
+			thingIDs:    thIDs,
+			auth:        token,
+			contentType: "invalid",
+			status:      http.StatusUnsupportedMediaType,
+		},
+		{
+			desc:        "disconnect with invalid JSON",
+			auth:        token,
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+			body:        "{",
+		},
+		{
+			desc:        "disconnect valid thing ids from empty channel ids",
+			channelID:   "",
+			thingIDs:    thIDs,
+			auth:        token,
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "disconnect empty thing ids from valid channel ids",
+			channelID:   ch.ID,
+			thingIDs:    []string{},
+			auth:        token,
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "disconnect empty thing ids from empty channel ids",
+			channelID:   "",
+			thingIDs:    []string{},
+			auth:        token,
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range cases {
+		data := struct {
+			ChannelID string   `json:"channel_id"`
+			ThingIDs  []string `json:"thing_ids"`
+		}{
+			tc.channelID,
+			tc.thingIDs,
+		}
+		body := toJSON(data)
+
+		if tc.body != "" {
+			body = tc.body
+		}
+
+		req := testRequest{
+			client:      ts.Client(),
+			method:      http.MethodPut,
+			url:         fmt.Sprintf("%s/disconnect", ts.URL),
+			contentType: tc.contentType,
+			token:       tc.auth,
+			body:        strings.NewReader(body),
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+	}
+}
+
+func TestRemoveGroups(t *testing.T) {
+	svc := newService()
+	ts := newServer(svc)
+	defer ts.Close()
+
+	var groups []things.Group
+	var groupIDs []string
+	for i := uint64(0); i < 10; i++ {
+		num := strconv.FormatUint(i, 10)
+		group := things.Group{
+			Name:        "test-group-" + num,
+			Description: "test group desc",
+		}
+		grs, err := svc.CreateGroups(context.Background(), token, group)
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+		gr := grs[0]
+
+		groups = append(groups, gr)
+		groupIDs = append(groupIDs, gr.ID)
+	}
+
+	cases := []struct {
+		desc        string
+		data        []string
+		auth        string
+		contentType string
+		status      int
+	}{
+		{
+			desc:        "remove existing groups",
+			data:        groupIDs,
+			auth:        token,
+			contentType: contentType,
+			status:      http.StatusNoContent,
+		},
+		{
+			desc:        "remove non-existent groups",
+			data:        []string{wrongValue},
+			auth:        token,
+			contentType: contentType,
+			status:      http.StatusNotFound,
+		},
+		{
+			desc:        "remove groups with invalid token",
+			data:        groupIDs,
+			auth:        wrongValue,
+			contentType: contentType,
+			status:      http.StatusUnauthorized,
+		},
+		{
+			desc:        "remove groups without group ids",
+			data:        []string{},
+			auth:        wrongValue,
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "remove groups with empty group ids",
+			data:        []string{""},
+			auth:        wrongValue,
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "remove groups with empty token",
+			data:        groupIDs,
+			auth:        "",
+			contentType: contentType,
+			status:      http.StatusUnauthorized,
+		},
+		{
+			desc:        "remove groups with invalid content type",
+			data:        groupIDs,
+			auth:        token,
+			contentType: wrongValue,
+			status:      http.StatusUnsupportedMediaType,
+		},
+	}
+
+	for _, tc := range cases {
+		data := struct {
+			GroupIDs []string `json:"group_ids"`
+		}{
+			tc.data,
+		}
+
+		body := toJSON(data)
+
+		req := testRequest{
+			client:      ts.Client(),
+			method:      http.MethodPatch,
+			url:         fmt.Sprintf("%s/groups", ts.URL),
+			token:       tc.auth,
+			contentType: tc.contentType,
+			body:        strings.NewReader(body),
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+	}
+}
+
+func TestCreateGroupTemplate(t *testing.T) {
+	svc := newService()
+	ts := newServer(svc)
+	defer ts.Close()
+
+	gt := things.GroupTemplate{
+		Name: "sensors",
+		Channels: []things.ChannelTemplate{
+			{Name: "telemetry", Profile: map[string]interface{}{"content_type": "application/senml+json"}, AutoConnect: true},
+		},
+	}
+	data := toJSON(gt)
+	invalidNameData := toJSON(things.GroupTemplate{Name: invalidName, Channels: gt.Channels})
+	noChannels := toJSON(things.GroupTemplate{Name: gt.Name})
+
+	cases := []struct {
+		desc        string
+		req         string
+		contentType string
+		auth        string
+		status      int
+	}{
+		{
+			desc:        "create valid group template",
+			req:         data,
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusCreated,
+		},
+		{
+			desc:        "create group template with invalid name",
+			req:         invalidNameData,
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "create group template without channels",
+			req:         noChannels,
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "create group template with invalid request format",
+			req:         "{",
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "create group template with invalid token",
+			req:         data,
+			contentType: contentType,
+			auth:        wrongValue,
+			status:      http.StatusUnauthorized,
+		},
+		{
+			desc:        "create group template without content type",
+			req:         data,
+			contentType: "",
+			auth:        token,
+			status:      http.StatusUnsupportedMediaType,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client:      ts.Client(),
+			method:      http.MethodPost,
+			url:         fmt.Sprintf("%s/templates", ts.URL),
+			contentType: tc.contentType,
+			token:       tc.auth,
+			body:        strings.NewReader(tc.req),
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+	}
+}
+
+func TestCreateGroupFromTemplate(t *testing.T) {
+	svc := newService()
+	ts := newServer(svc)
+	defer ts.Close()
+
+	gt := things.GroupTemplate{
+		Name: "sensors",
+		Channels: []things.ChannelTemplate{
+			{Name: "telemetry", AutoConnect: true},
+			{Name: "commands"},
+		},
+	}
+	sgt, err := svc.CreateGroupTemplate(context.Background(), token, gt)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	data := toJSON(things.Group{Name: "sensors-1"})
+
+	cases := []struct {
+		desc        string
+		id          string
+		req         string
+		contentType string
+		auth        string
+		status      int
+	}{
+		{
+			desc:        "create group from template",
+			id:          sgt.ID,
+			req:         data,
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusCreated,
+		},
+		{
+			desc:        "create group from non-existent template",
+			id:          wrongValue,
+			req:         data,
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusNotFound,
+		},
+		{
+			desc:        "create group from template with invalid token",
+			id:          sgt.ID,
+			req:         data,
+			contentType: contentType,
+			auth:        wrongValue,
+			status:      http.StatusUnauthorized,
+		},
+		{
+			desc:        "create group from template with invalid request format",
+			id:          sgt.ID,
+			req:         "{",
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "create group from template without content type",
+			id:          sgt.ID,
+			req:         data,
+			contentType: "",
+			auth:        token,
+			status:      http.StatusUnsupportedMediaType,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client:      ts.Client(),
+			method:      http.MethodPost,
+			url:         fmt.Sprintf("%s/templates/%s/groups", ts.URL, tc.id),
+			contentType: tc.contentType,
+			token:       tc.auth,
+			body:        strings.NewReader(tc.req),
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+	}
+}
+
+func TestAssignChannelsBatch(t *testing.T) {
+	svc := newService()
+	ts := newServer(svc)
+	defer ts.Close()
+
+	chs, err := svc.CreateChannels(context.Background(), token, channel, channel)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	grs, err := svc.CreateGroups(context.Background(), token, group)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	gr := grs[0]
+
+	tooManyIDs := make([]string, 10001)
+	for i := range tooManyIDs {
+		tooManyIDs[i] = chs[0].ID
+	}
+
+	cases := []struct {
+		desc      string
+		auth      string
+		req       string
+		status    int
+		processed int
+		failed    []string
+	}{
+		{
+			desc:      "assign channels in batch",
+			auth:      token,
+			req:       toJSON(map[string][]string{"channels": {chs[0].ID, chs[1].ID}}),
+			status:    http.StatusOK,
+			processed: 2,
+		},
+		{
+			desc:      "assign channels in batch with unknown channel",
+			auth:      token,
+			req:       toJSON(map[string][]string{"channels": {wrongValue}}),
+			status:    http.StatusOK,
+			processed: 0,
+			failed:    []string{wrongValue},
+		},
+		{
+			desc:   "assign empty list of channels in batch",
+			auth:   token,
+			req:    toJSON(map[string][]string{"channels": {}}),
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "assign too many channels in batch",
+			auth:   token,
+			req:    toJSON(map[string][]string{"channels": tooManyIDs}),
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "assign channels in batch with invalid request format",
+			auth:   token,
+			req:    "}",
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "assign channels in batch with invalid token",
+			auth:   wrongValue,
+			req:    toJSON(map[string][]string{"channels": {chs[0].ID}}),
+			status: http.StatusUnauthorized,
+		},
+		{
+			desc:   "assign channels in batch to group of other user",
+			auth:   otherToken,
+			req:    toJSON(map[string][]string{"channels": {chs[0].ID}}),
+			status: http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client:      ts.Client(),
+			method:      http.MethodPost,
+			url:         fmt.Sprintf("%s/groups/%s/channels/batch", ts.URL, gr.ID),
+			contentType: contentType,
+			token:       tc.auth,
+			body:        strings.NewReader(tc.req),
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		if res.StatusCode != http.StatusOK {
+			continue
+		}
+
+		var body batchRes
+		err = json.NewDecoder(res.Body).Decode(&body)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.processed, body.Processed, fmt.Sprintf("%s: expected %d processed got %d", tc.desc, tc.processed, body.Processed))
+
+		var failed []string
+		for _, f := range body.Failed {
+			failed = append(failed, f.ID)
+		}
+		assert.Equal(t, tc.failed, failed, fmt.Sprintf("%s: expected failed %v got %v", tc.desc, tc.failed, failed))
+	}
+}
+
+func TestViewGroupStats(t *testing.T) {
+	svc := newService()
+	ts := newServer(svc)
+	defer ts.Close()
+
+	ths, err := svc.CreateThings(context.Background(), token, thing, thing1)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	chs, err := svc.CreateChannels(context.Background(), token, channel)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	grs, err := svc.CreateGroups(context.Background(), token, group)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	gr := grs[0]
+
+	err = svc.AssignThing(context.Background(), token, gr.ID, ths[0].ID, ths[1].ID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	err = svc.AssignChannel(context.Background(), token, gr.ID, chs[0].ID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	err = svc.Connect(context.Background(), token, chs[0].ID, []string{ths[0].ID})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc   string
+		auth   string
+		id     string
+		status int
+		res    groupStatsRes
+	}{
+		{
+			desc:   "view group stats",
+			auth:   token,
+			id:     gr.ID,
+			status: http.StatusOK,
+			res:    groupStatsRes{Things: 2, Channels: 1, ConnectedThings: 1, DisconnectedThings: 1},
 		},
 		{
-			desc:        "disconnect things from channels of other user",
-			channelID:   usrCh.ID,
-			thingIDs:    thIDs,
-			auth:        token,
-			contentType: contentType,
-			status:      http.StatusNotFound,
+			desc:   "view non-existent group stats",
+			auth:   token,
+			id:     wrongValue,
+			status: http.StatusNotFound,
 		},
 		{
-			desc:        "disconnect with invalid content type",
-			channelID:   ch.ID,
-			thingIDs:    thIDs,
-			auth:        token,
-			contentType: "invalid",
-			status:      http.StatusUnsupportedMediaType,
+			desc:   "view group stats with invalid token",
+			auth:   wrongValue,
+			id:     gr.ID,
+			status: http.StatusUnauthorized,
 		},
 		{
-			desc:        "disconnect with invalid JSON",
-			auth:        token,
-			contentType: contentType,
-			status:      http.StatusBadRequest,
-			body:        "{",
+			desc:   "view group stats with empty token",
+			auth:   "",
+			id:     gr.ID,
+			status: http.StatusUnauthorized,
 		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodGet,
+			url:    fmt.Sprintf("%s/groups/%s/stats", ts.URL, tc.id),
+			token:  tc.auth,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		if res.StatusCode != http.StatusOK {
+			continue
+		}
+
+		var body groupStatsRes
+		err = json.NewDecoder(res.Body).Decode(&body)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.res, body, fmt.Sprintf("%s: expected %v got %v", tc.desc, tc.res, body))
+	}
+}
+
+func TestExportGroup(t *testing.T) {
+	svc := newService()
+	ts := newServer(svc)
+	defer ts.Close()
+
+	ths, err := svc.CreateThings(context.Background(), token, thing)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	chs, err := svc.CreateChannels(context.Background(), token, channel)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	grs, err := svc.CreateGroups(context.Background(), token, things.Group{Name: "export-group"})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	gr := grs[0]
+
+	err = svc.AssignThing(context.Background(), token, gr.ID, ths[0].ID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	err = svc.AssignChannel(context.Background(), token, gr.ID, chs[0].ID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	err = svc.Connect(context.Background(), token, chs[0].ID, []string{ths[0].ID})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc   string
+		auth   string
+		url    string
+		status int
+	}{
 		{
-			desc:        "disconnect valid thing ids from empty channel ids",
-			channelID:   "",
-			thingIDs:    thIDs,
-			auth:        token,
-			contentType: contentType,
-			status:      http.StatusBadRequest,
+			desc:   "export group",
+			auth:   token,
+			url:    fmt.Sprintf("%s/groups/%s/export", ts.URL, gr.ID),
+			status: http.StatusOK,
 		},
 		{
-			desc:        "disconnect empty thing ids from valid channel ids",
-			channelID:   ch.ID,
-			thingIDs:    []string{},
-			auth:        token,
-			contentType: contentType,
-			status:      http.StatusBadRequest,
+			desc:   "export non-existent group",
+			auth:   token,
+			url:    fmt.Sprintf("%s/groups/%s/export", ts.URL, wrongValue),
+			status: http.StatusNotFound,
 		},
 		{
-			desc:        "disconnect empty thing ids from empty channel ids",
-			channelID:   "",
-			thingIDs:    []string{},
-			auth:        token,
-			contentType: contentType,
-			status:      http.StatusBadRequest,
+			desc:   "export group with invalid token",
+			auth:   wrongValue,
+			url:    fmt.Sprintf("%s/groups/%s/export", ts.URL, gr.ID),
+			status: http.StatusUnauthorized,
 		},
 	}
 
 	for _, tc := range cases {
-		data := struct {
-			ChannelID string   `json:"channel_id"`
-			ThingIDs  []string `json:"thing_ids"`
-		}{
-			tc.channelID,
-			tc.thingIDs,
-		}
-		body := toJSON(data)
-
-		if tc.body != "" {
-			body = tc.body
-		}
-
 		req := testRequest{
-			client:      ts.Client(),
-			method:      http.MethodPut,
-			url:         fmt.Sprintf("%s/disconnect", ts.URL),
-			contentType: tc.contentType,
-			token:       tc.auth,
-			body:        strings.NewReader(body),
+			client: ts.Client(),
+			method: http.MethodGet,
+			url:    tc.url,
+			token:  tc.auth,
 		}
 		res, err := req.make()
 		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
 		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		if res.StatusCode != http.StatusOK {
+			continue
+		}
+
+		var body groupExportRes
+		err = json.NewDecoder(res.Body).Decode(&body)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		require.Equal(t, 1, len(body.Things), fmt.Sprintf("%s: expected %d things got %d", tc.desc, 1, len(body.Things)))
+		assert.Empty(t, body.Things[0].Key, fmt.Sprintf("%s: expected no key got %s", tc.desc, body.Things[0].Key))
+		assert.Equal(t, 1, len(body.Connections), fmt.Sprintf("%s: expected %d connections got %d", tc.desc, 1, len(body.Connections)))
 	}
 }
 
-func TestRemoveGroups(t *testing.T) {
+func TestImportGroup(t *testing.T) {
 	svc := newService()
 	ts := newServer(svc)
 	defer ts.Close()
 
-	var groups []things.Group
-	var groupIDs []string
-	for i := uint64(0); i < 10; i++ {
-		num := strconv.FormatUint(i, 10)
-		group := things.Group{
-			Name:        "test-group-" + num,
-			Description: "test group desc",
-		}
-		grs, err := svc.CreateGroups(context.Background(), token, group)
-		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
-		gr := grs[0]
-
-		groups = append(groups, gr)
-		groupIDs = append(groupIDs, gr.ID)
+	bundle := groupExportRes{
+		Version:     1,
+		Group:       groupRes{Name: "imported-group"},
+		Things:      []backupThingRes{{ID: "thing-1", Name: "thing"}},
+		Channels:    []backupChannelRes{{ID: "channel-1", Name: "channel"}},
+		Connections: []backupConnectionRes{{ChannelID: "channel-1", ThingID: "thing-1"}},
 	}
+	data := toJSON(bundle)
+
+	invalidConn := bundle
+	invalidConn.Group.Name = "invalid-connection"
+	invalidConn.Connections = []backupConnectionRes{{ChannelID: wrongValue, ThingID: "thing-1"}}
+
+	invalidVersion := bundle
+	invalidVersion.Version = 2
+
+	noName := bundle
+	noName.Group.Name = ""
+
+	withKey := bundle
+	withKey.Group.Name = "rekeyed-group"
+	withKey.Things = []backupThingRes{{ID: "thing-1", Name: "thing", Key: "imported-key"}}
 
 	cases := []struct {
 		desc        string
-		data        []string
-		auth        string
+		req         string
+		query       string
 		contentType string
+		auth        string
 		status      int
 	}{
 		{
-			desc:        "remove existing groups",
-			data:        groupIDs,
+			desc:        "import group",
+			req:         data,
+			contentType: contentType,
 			auth:        token,
+			status:      http.StatusCreated,
+		},
+		{
+			desc:        "import group with rekeying",
+			req:         toJSON(withKey),
+			query:       "?rekey=true",
 			contentType: contentType,
-			status:      http.StatusNoContent,
+			auth:        token,
+			status:      http.StatusCreated,
 		},
 		{
-			desc:        "remove non-existent groups",
-			data:        []string{wrongValue},
+			desc:        "import group with invalid rekey query",
+			req:         data,
+			query:       "?rekey=invalid",
+			contentType: contentType,
 			auth:        token,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "import group with connection outside of the bundle",
+			req:         toJSON(invalidConn),
 			contentType: contentType,
-			status:      http.StatusNotFound,
+			auth:        token,
+			status:      http.StatusBadRequest,
 		},
 		{
-			desc:        "remove groups with invalid token",
-			data:        groupIDs,
-			auth:        wrongValue,
+			desc:        "import group with invalid version",
+			req:         toJSON(invalidVersion),
 			contentType: contentType,
-			status:      http.StatusUnauthorized,
+			auth:        token,
+			status:      http.StatusBadRequest,
 		},
 		{
-			desc:        "remove groups without group ids",
-			data:        []string{},
-			auth:        wrongValue,
+			desc:        "import group without name",
+			req:         toJSON(noName),
 			contentType: contentType,
+			auth:        token,
 			status:      http.StatusBadRequest,
 		},
 		{
-			desc:        "remove groups with empty group ids",
-			data:        []string{""},
-			auth:        wrongValue,
+			desc:        "import group with invalid request format",
+			req:         "{",
 			contentType: contentType,
+			auth:        token,
 			status:      http.StatusBadRequest,
 		},
 		{
-			desc:        "remove groups with empty token",
-			data:        groupIDs,
-			auth:        "",
+			desc:        "import group with invalid token",
+			req:         data,
 			contentType: contentType,
+			auth:        wrongValue,
 			status:      http.StatusUnauthorized,
 		},
 		{
-			desc:        "remove groups with invalid content type",
-			data:        groupIDs,
+			desc:        "import group without content type",
+			req:         data,
+			contentType: "",
 			auth:        token,
-			contentType: wrongValue,
 			status:      http.StatusUnsupportedMediaType,
 		},
 	}
 
 	for _, tc := range cases {
-		data := struct {
-			GroupIDs []string `json:"group_ids"`
-		}{
-			tc.data,
-		}
-
-		body := toJSON(data)
-
 		req := testRequest{
 			client:      ts.Client(),
-			method:      http.MethodPatch,
-			url:         fmt.Sprintf("%s/groups", ts.URL),
-			token:       tc.auth,
+			method:      http.MethodPost,
+			url:         fmt.Sprintf("%s/groups/import%s", ts.URL, tc.query),
 			contentType: tc.contentType,
-			body:        strings.NewReader(body),
+			token:       tc.auth,
+			body:        strings.NewReader(tc.req),
 		}
 		res, err := req.make()
 		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
@@ -2701,6 +4311,7 @@ func TestBackup(t *testing.T) {
 	}
 
 	backup := backupRes{
+		Version:               1,
 		Groups:                groupsRes,
 		Things:                thingsRes,
 		Channels:              channelsRes,
@@ -2758,6 +4369,7 @@ func TestBackup(t *testing.T) {
 		assert.ElementsMatch(t, tc.res.Things, body.Things, fmt.Sprintf("%s: expected body %v got %v", tc.desc, tc.res.Things, body.Things))
 		assert.ElementsMatch(t, tc.res.Groups, body.Groups, fmt.Sprintf("%s: expected body %v got %v", tc.desc, tc.res.Groups, body.Groups))
 		assert.ElementsMatch(t, tc.res.GroupThingRelations, body.GroupThingRelations, fmt.Sprintf("%s: expected body %v got %v", tc.desc, tc.res.GroupThingRelations, body.GroupThingRelations))
+		assert.Equal(t, tc.res.Version, body.Version, fmt.Sprintf("%s: expected version %d got %d", tc.desc, tc.res.Version, body.Version))
 	}
 }
 
@@ -2892,6 +4504,7 @@ func TestRestore(t *testing.T) {
 	})
 
 	resReq := restoreReq{
+		Version:               1,
 		Things:                thr,
 		Channels:              chr,
 		Connections:           cr,
@@ -2902,6 +4515,9 @@ func TestRestore(t *testing.T) {
 
 	data := toJSON(resReq)
 	invalidData := toJSON(restoreReq{})
+	unsupportedReq := resReq
+	unsupportedReq.Version = 2
+	unsupportedData := toJSON(unsupportedReq)
 	restoreURL := fmt.Sprintf("%s/restore", ts.URL)
 
 	cases := []struct {
@@ -2944,6 +4560,14 @@ func TestRestore(t *testing.T) {
 			req:         invalidData,
 			contentType: contentType,
 		},
+		{
+			desc:        "restore with unsupported version",
+			auth:        adminToken,
+			status:      http.StatusBadRequest,
+			url:         restoreURL,
+			req:         unsupportedData,
+			contentType: contentType,
+		},
 	}
 
 	for _, tc := range cases {
@@ -2963,12 +4587,28 @@ func TestRestore(t *testing.T) {
 }
 
 type thingRes struct {
+	ID         string                 `json:"id"`
+	Name       string                 `json:"name,omitempty"`
+	Key        string                 `json:"key"`
+	ExternalID string                 `json:"external_id,omitempty"`
+	Metadata   map[string]interface{} `json:"metadata,omitempty"`
+}
+
+type adminThingRes struct {
 	ID       string                 `json:"id"`
+	Owner    string                 `json:"owner"`
 	Name     string                 `json:"name,omitempty"`
 	Key      string                 `json:"key"`
 	Metadata map[string]interface{} `json:"metadata,omitempty"`
 }
 
+type adminThingsPageRes struct {
+	Things []adminThingRes `json:"things"`
+	Total  uint64          `json:"total"`
+	Offset uint64          `json:"offset"`
+	Limit  uint64          `json:"limit"`
+}
+
 type channelRes struct {
 	ID       string                 `json:"id"`
 	Name     string                 `json:"name,omitempty"`
@@ -2989,6 +4629,31 @@ type channelsPageRes struct {
 	Limit    uint64       `json:"limit"`
 }
 
+type channelVersionRes struct {
+	Version  uint64                 `json:"version"`
+	Name     string                 `json:"name,omitempty"`
+	Metadata map[string]interface{} `json:"metadata,omitempty"`
+}
+
+type channelVersionsPageRes struct {
+	Versions []channelVersionRes `json:"versions"`
+	Total    uint64              `json:"total"`
+	Offset   uint64              `json:"offset"`
+	Limit    uint64              `json:"limit"`
+}
+
+type connectionRes struct {
+	ChannelID string `json:"channel_id"`
+	ThingID   string `json:"thing_id"`
+}
+
+type connectionsPageRes struct {
+	Connections []connectionRes `json:"connections"`
+	Total       uint64          `json:"total"`
+	Offset      uint64          `json:"offset"`
+	Limit       uint64          `json:"limit"`
+}
+
 type backupThingRes struct {
 	ID       string                 `json:"id"`
 	Owner    string                 `json:"owner,omitempty"`
@@ -3034,6 +4699,7 @@ type backupGroupChannelRelationRes struct {
 }
 
 type backupRes struct {
+	Version               int                             `json:"version"`
 	Things                []backupThingRes                `json:"things"`
 	Channels              []backupChannelRes              `json:"channels"`
 	Connections           []backupConnectionRes           `json:"connections"`
@@ -3088,6 +4754,7 @@ type restoreGroupChannelRelationReq struct {
 	UpdatedAt time.Time `json:"updated_at"`
 }
 type restoreReq struct {
+	Version               int                              `json:"version"`
 	Things                []restoreThingReq                `json:"things"`
 	Channels              []restoreChannelReq              `json:"channels"`
 	Connections           []restoreConnectionReq           `json:"connections"`
@@ -3095,3 +4762,35 @@ type restoreReq struct {
 	GroupThingRelations   []restoreGroupThingRelationReq   `json:"group_thing_relations"`
 	GroupChannelRelations []restoreGroupChannelRelationReq `json:"group_channel_relations"`
 }
+
+type groupRes struct {
+	ID          string                 `json:"id"`
+	Name        string                 `json:"name,omitempty"`
+	Description string                 `json:"description,omitempty"`
+	Metadata    map[string]interface{} `json:"metadata,omitempty"`
+}
+
+type groupExportRes struct {
+	Version     int                   `json:"version"`
+	Group       groupRes              `json:"group"`
+	Things      []backupThingRes      `json:"things"`
+	Channels    []backupChannelRes    `json:"channels"`
+	Connections []backupConnectionRes `json:"connections"`
+}
+
+type batchRes struct {
+	Processed int `json:"processed"`
+	Failed    []struct {
+		ID    string `json:"id"`
+		Error string `json:"error"`
+	} `json:"failed"`
+}
+
+type groupStatsRes struct {
+	Things             uint64 `json:"things"`
+	Channels           uint64 `json:"channels"`
+	ConnectedThings    uint64 `json:"connected_things"`
+	DisconnectedThings uint64 `json:"disconnected_things"`
+	Messages           uint64 `json:"messages_24h"`
+	StoredMessages     uint64 `json:"stored_messages"`
+}
@@ -7,13 +7,17 @@ import (
 	"time"
 
 	"github.com/MainfluxLabs/mainflux/internal/apiutil"
+	"github.com/MainfluxLabs/mainflux/pkg/acl"
+	"github.com/MainfluxLabs/mainflux/pkg/signature"
 	"github.com/MainfluxLabs/mainflux/things"
 	"github.com/gofrs/uuid"
 )
 
 const (
 	maxLimitSize = 100
+	maxBatchSize = 10000
 	maxNameSize  = 1024
+	maxExtIDSize = 254
 	nameOrder    = "name"
 	idOrder      = "id"
 	ascDir       = "asc"
@@ -21,10 +25,12 @@ const (
 )
 
 type createThingReq struct {
-	Name     string                 `json:"name,omitempty"`
-	Key      string                 `json:"key,omitempty"`
-	ID       string                 `json:"id,omitempty"`
-	Metadata map[string]interface{} `json:"metadata,omitempty"`
+	Name       string                 `json:"name,omitempty"`
+	Key        string                 `json:"key,omitempty"`
+	ID         string                 `json:"id,omitempty"`
+	ExternalID string                 `json:"external_id,omitempty"`
+	Location   *things.Location       `json:"location,omitempty"`
+	Metadata   map[string]interface{} `json:"metadata,omitempty"`
 }
 
 type createThingsReq struct {
@@ -51,6 +57,14 @@ func (req createThingsReq) validate() error {
 		if len(thing.Name) > maxNameSize {
 			return apiutil.ErrNameSize
 		}
+
+		if len(thing.ExternalID) > maxExtIDSize {
+			return apiutil.ErrExternalIDSize
+		}
+
+		if err := validateLocation(thing.Location); err != nil {
+			return err
+		}
 	}
 
 	return nil
@@ -60,7 +74,9 @@ type updateThingReq struct {
 	token    string
 	id       string
 	Name     string                 `json:"name,omitempty"`
+	Location *things.Location       `json:"location,omitempty"`
 	Metadata map[string]interface{} `json:"metadata,omitempty"`
+	revision uint64
 }
 
 func (req updateThingReq) validate() error {
@@ -76,7 +92,7 @@ func (req updateThingReq) validate() error {
 		return apiutil.ErrNameSize
 	}
 
-	return nil
+	return validateLocation(req.Location)
 }
 
 type updateKeyReq struct {
@@ -101,6 +117,25 @@ func (req updateKeyReq) validate() error {
 	return nil
 }
 
+type updateSigningKeyReq struct {
+	token     string
+	id        string
+	Algorithm string `json:"algorithm"`
+	Key       []byte `json:"key"`
+}
+
+func (req updateSigningKeyReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	if req.id == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return signature.Validate(req.Algorithm, req.Key)
+}
+
 type createChannelReq struct {
 	Name     string                 `json:"name,omitempty"`
 	ID       string                 `json:"id,omitempty"`
@@ -131,6 +166,14 @@ func (req createChannelsReq) validate() error {
 		if len(channel.Name) > maxNameSize {
 			return apiutil.ErrNameSize
 		}
+
+		if _, err := acl.Parse(channel.Metadata); err != nil {
+			return err
+		}
+
+		if _, err := things.ParseProfile(channel.Metadata); err != nil {
+			return err
+		}
 	}
 
 	return nil
@@ -139,6 +182,7 @@ func (req createChannelsReq) validate() error {
 type updateChannelReq struct {
 	token    string
 	id       string
+	revision uint64
 	Name     string                 `json:"name,omitempty"`
 	Metadata map[string]interface{} `json:"metadata,omitempty"`
 }
@@ -156,6 +200,14 @@ func (req updateChannelReq) validate() error {
 		return apiutil.ErrNameSize
 	}
 
+	if _, err := acl.Parse(req.Metadata); err != nil {
+		return err
+	}
+
+	if _, err := things.ParseProfile(req.Metadata); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -237,7 +289,7 @@ func (req *listResourcesReq) validate() error {
 		return apiutil.ErrLimitSize
 	}
 
-	if len(req.pageMetadata.Name) > maxNameSize {
+	if len(req.pageMetadata.Name) > maxNameSize || len(req.pageMetadata.Query) > maxNameSize {
 		return apiutil.ErrNameSize
 	}
 
@@ -251,6 +303,78 @@ func (req *listResourcesReq) validate() error {
 		return apiutil.ErrInvalidDirection
 	}
 
+	if area := req.pageMetadata.Within; area != nil {
+		if err := validateLocation(&area.Location); err != nil {
+			return err
+		}
+
+		if area.Radius <= 0 {
+			return apiutil.ErrInvalidLocation
+		}
+	}
+
+	return nil
+}
+
+type listConnectionsReq struct {
+	token        string
+	filter       things.ConnectionsFilter
+	pageMetadata things.PageMetadata
+}
+
+func (req listConnectionsReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	if req.pageMetadata.Limit > maxLimitSize {
+		return apiutil.ErrLimitSize
+	}
+
+	return nil
+}
+
+type listChannelVersionsReq struct {
+	token        string
+	id           string
+	pageMetadata things.PageMetadata
+}
+
+func (req listChannelVersionsReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	if req.id == "" {
+		return apiutil.ErrMissingID
+	}
+
+	if req.pageMetadata.Limit > maxLimitSize {
+		return apiutil.ErrLimitSize
+	}
+
+	return nil
+}
+
+type rollbackChannelReq struct {
+	token   string
+	id      string
+	version uint64
+}
+
+func (req rollbackChannelReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	if req.id == "" {
+		return apiutil.ErrMissingID
+	}
+
+	if req.version == 0 {
+		return apiutil.ErrInvalidVersion
+	}
+
 	return nil
 }
 
@@ -314,6 +438,10 @@ func (req connectionsReq) validate() error {
 	return nil
 }
 
+// backupVersion is the version of the backup bundle format. Bundles without
+// the version predate the versioning and are restored as the first version.
+const backupVersion = 1
+
 type backupReq struct {
 	token string
 }
@@ -327,11 +455,13 @@ func (req backupReq) validate() error {
 }
 
 type restoreThingReq struct {
-	ID       string                 `json:"id"`
-	Owner    string                 `json:"owner"`
-	Name     string                 `json:"name"`
-	Key      string                 `json:"key"`
-	Metadata map[string]interface{} `json:"metadata"`
+	ID         string                 `json:"id"`
+	Owner      string                 `json:"owner"`
+	Name       string                 `json:"name"`
+	Key        string                 `json:"key"`
+	ExternalID string                 `json:"external_id"`
+	Location   *things.Location       `json:"location"`
+	Metadata   map[string]interface{} `json:"metadata"`
 }
 
 type restoreChannelReq struct {
@@ -374,6 +504,7 @@ type restoreGroupChannelRelationReq struct {
 
 type restoreReq struct {
 	token                 string
+	Version               int                              `json:"version"`
 	Things                []restoreThingReq                `json:"things"`
 	Channels              []restoreChannelReq              `json:"channels"`
 	Connections           []restoreConnectionReq           `json:"connections"`
@@ -387,7 +518,12 @@ func (req restoreReq) validate() error {
 		return apiutil.ErrBearerToken
 	}
 
-	if len(req.Groups) == 0 && len(req.Things) == 0 && len(req.Channels) == 0 && len(req.Connections) == 0 && len(req.GroupThingRelations) == 0 {
+	if req.Version < 0 || req.Version > backupVersion {
+		return apiutil.ErrInvalidVersion
+	}
+
+	if len(req.Groups) == 0 && len(req.Things) == 0 && len(req.Channels) == 0 && len(req.Connections) == 0 &&
+		len(req.GroupThingRelations) == 0 && len(req.GroupChannelRelations) == 0 {
 		return apiutil.ErrEmptyList
 	}
 
@@ -426,6 +562,7 @@ func (req createGroupsReq) validate() error {
 type updateGroupReq struct {
 	token       string
 	id          string
+	revision    uint64
 	Name        string                 `json:"name,omitempty"`
 	Description string                 `json:"description,omitempty"`
 	Metadata    map[string]interface{} `json:"metadata,omitempty"`
@@ -527,6 +664,58 @@ func (req groupChannelsReq) validate() error {
 	return nil
 }
 
+type groupThingsBatchReq struct {
+	token   string
+	groupID string
+	Things  []string `json:"things"`
+}
+
+func (req groupThingsBatchReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	if req.groupID == "" {
+		return apiutil.ErrMissingID
+	}
+
+	if len(req.Things) == 0 {
+		return apiutil.ErrEmptyList
+	}
+
+	if len(req.Things) > maxBatchSize {
+		return apiutil.ErrLimitSize
+	}
+
+	return nil
+}
+
+type groupChannelsBatchReq struct {
+	token    string
+	groupID  string
+	Channels []string `json:"channels"`
+}
+
+func (req groupChannelsBatchReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	if req.groupID == "" {
+		return apiutil.ErrMissingID
+	}
+
+	if len(req.Channels) == 0 {
+		return apiutil.ErrEmptyList
+	}
+
+	if len(req.Channels) > maxBatchSize {
+		return apiutil.ErrLimitSize
+	}
+
+	return nil
+}
+
 type listGroupThingsByChannelReq struct {
 	token        string
 	groupID      string
@@ -546,6 +735,139 @@ func (req listGroupThingsByChannelReq) validate() error {
 	return nil
 }
 
+type createGroupTemplateReq struct {
+	token       string
+	Name        string                   `json:"name,omitempty"`
+	Description string                   `json:"description,omitempty"`
+	Channels    []things.ChannelTemplate `json:"channels,omitempty"`
+}
+
+func (req createGroupTemplateReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	if req.Name == "" || len(req.Name) > maxNameSize {
+		return apiutil.ErrNameSize
+	}
+
+	if len(req.Channels) == 0 {
+		return apiutil.ErrEmptyList
+	}
+
+	for _, ch := range req.Channels {
+		if len(ch.Name) > maxNameSize {
+			return apiutil.ErrNameSize
+		}
+	}
+
+	return nil
+}
+
+type groupTemplateReq struct {
+	token string
+	id    string
+}
+
+func (req groupTemplateReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	if req.id == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return nil
+}
+
+type listGroupTemplatesReq struct {
+	token        string
+	pageMetadata things.PageMetadata
+}
+
+func (req listGroupTemplatesReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	if req.pageMetadata.Limit > maxLimitSize {
+		return apiutil.ErrLimitSize
+	}
+
+	return nil
+}
+
+type createGroupFromTemplateReq struct {
+	token       string
+	templateID  string
+	Name        string                 `json:"name,omitempty"`
+	Description string                 `json:"description,omitempty"`
+	Metadata    map[string]interface{} `json:"metadata,omitempty"`
+}
+
+func (req createGroupFromTemplateReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	if req.templateID == "" {
+		return apiutil.ErrMissingID
+	}
+
+	if len(req.Name) > maxNameSize {
+		return apiutil.ErrNameSize
+	}
+
+	return nil
+}
+
+// exportVersion is the version of the group export bundle format.
+const exportVersion = 1
+
+type exportGroupReq struct {
+	token string
+	id    string
+}
+
+func (req exportGroupReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	if req.id == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return nil
+}
+
+type importGroupReq struct {
+	token       string
+	rekey       bool
+	Version     int                    `json:"version"`
+	Group       createGroupReq         `json:"group"`
+	Things      []restoreThingReq      `json:"things"`
+	Channels    []restoreChannelReq    `json:"channels"`
+	Connections []restoreConnectionReq `json:"connections"`
+}
+
+func (req importGroupReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	if req.Version < 0 || req.Version > exportVersion {
+		return apiutil.ErrInvalidVersion
+	}
+
+	if req.Group.Name == "" || len(req.Group.Name) > maxNameSize {
+		return apiutil.ErrNameSize
+	}
+
+	return nil
+}
+
 type groupReq struct {
 	token string
 	id    string
@@ -572,6 +894,20 @@ func validateUUID(extID string) (err error) {
 	return nil
 }
 
+// validateLocation validates the latitude and longitude of the location, if
+// the location is set.
+func validateLocation(l *things.Location) error {
+	if l == nil {
+		return nil
+	}
+
+	if l.Latitude < -90 || l.Latitude > 90 || l.Longitude < -180 || l.Longitude > 180 {
+		return apiutil.ErrInvalidLocation
+	}
+
+	return nil
+}
+
 type removeGroupsReq struct {
 	token    string
 	GroupIDs []string `json:"group_ids,omitempty"`
@@ -5,22 +5,32 @@ package http
 
 import (
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/MainfluxLabs/mainflux"
+	"github.com/MainfluxLabs/mainflux/things"
 )
 
 var (
 	_ mainflux.Response = (*viewThingRes)(nil)
+	_ mainflux.Response = (*signingKeyRes)(nil)
 	_ mainflux.Response = (*thingsPageRes)(nil)
 	_ mainflux.Response = (*viewChannelRes)(nil)
 	_ mainflux.Response = (*channelsPageRes)(nil)
+	_ mainflux.Response = (*channelVersionsPageRes)(nil)
+	_ mainflux.Response = (*adminThingsPageRes)(nil)
+	_ mainflux.Response = (*adminChannelsPageRes)(nil)
 	_ mainflux.Response = (*connectionsRes)(nil)
+	_ mainflux.Response = (*connectionsPageRes)(nil)
 	_ mainflux.Response = (*shareThingRes)(nil)
 	_ mainflux.Response = (*backupRes)(nil)
 	_ mainflux.Response = (*groupThingsPageRes)(nil)
 	_ mainflux.Response = (*groupChannelsPageRes)(nil)
 	_ mainflux.Response = (*groupsRes)(nil)
+	_ mainflux.Response = (*groupTemplateRes)(nil)
+	_ mainflux.Response = (*groupTemplatesPageRes)(nil)
+	_ mainflux.Response = (*groupExportRes)(nil)
 	_ mainflux.Response = (*removeRes)(nil)
 	_ mainflux.Response = (*assignRes)(nil)
 	_ mainflux.Response = (*unassignRes)(nil)
@@ -55,11 +65,13 @@ func (res shareThingRes) Empty() bool {
 }
 
 type thingRes struct {
-	ID       string                 `json:"id"`
-	Name     string                 `json:"name,omitempty"`
-	Key      string                 `json:"key"`
-	Metadata map[string]interface{} `json:"metadata,omitempty"`
-	created  bool
+	ID         string                 `json:"id"`
+	Name       string                 `json:"name,omitempty"`
+	Key        string                 `json:"key"`
+	ExternalID string                 `json:"external_id,omitempty"`
+	Location   *things.Location       `json:"location,omitempty"`
+	Metadata   map[string]interface{} `json:"metadata,omitempty"`
+	created    bool
 }
 
 type thingsRes struct {
@@ -84,11 +96,14 @@ func (res thingsRes) Empty() bool {
 }
 
 type viewThingRes struct {
-	ID       string                 `json:"id"`
-	Owner    string                 `json:"-"`
-	Name     string                 `json:"name,omitempty"`
-	Key      string                 `json:"key"`
-	Metadata map[string]interface{} `json:"metadata,omitempty"`
+	ID         string                 `json:"id"`
+	Owner      string                 `json:"-"`
+	Name       string                 `json:"name,omitempty"`
+	Key        string                 `json:"key"`
+	ExternalID string                 `json:"external_id,omitempty"`
+	Location   *things.Location       `json:"location,omitempty"`
+	Metadata   map[string]interface{} `json:"metadata,omitempty"`
+	revision   uint64
 }
 
 func (res viewThingRes) Code() int {
@@ -96,13 +111,30 @@ func (res viewThingRes) Code() int {
 }
 
 func (res viewThingRes) Headers() map[string]string {
-	return map[string]string{}
+	return etagHeaders(res.revision)
 }
 
 func (res viewThingRes) Empty() bool {
 	return false
 }
 
+type signingKeyRes struct {
+	Algorithm string `json:"algorithm"`
+	Key       []byte `json:"key"`
+}
+
+func (res signingKeyRes) Code() int {
+	return http.StatusOK
+}
+
+func (res signingKeyRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res signingKeyRes) Empty() bool {
+	return false
+}
+
 type thingsPageRes struct {
 	pageRes
 	Things []viewThingRes `json:"things"`
@@ -154,6 +186,7 @@ type viewChannelRes struct {
 	Name     string                 `json:"name,omitempty"`
 	Things   []viewThingRes         `json:"connected,omitempty"`
 	Metadata map[string]interface{} `json:"metadata,omitempty"`
+	revision uint64
 }
 
 func (res viewChannelRes) Code() int {
@@ -161,7 +194,7 @@ func (res viewChannelRes) Code() int {
 }
 
 func (res viewChannelRes) Headers() map[string]string {
-	return map[string]string{}
+	return etagHeaders(res.revision)
 }
 
 func (res viewChannelRes) Empty() bool {
@@ -185,6 +218,103 @@ func (res channelsPageRes) Empty() bool {
 	return false
 }
 
+type channelVersionRes struct {
+	Version   uint64                 `json:"version"`
+	Name      string                 `json:"name,omitempty"`
+	Metadata  map[string]interface{} `json:"metadata,omitempty"`
+	CreatedAt time.Time              `json:"created_at"`
+}
+
+type channelVersionsPageRes struct {
+	pageRes
+	Versions []channelVersionRes `json:"versions"`
+}
+
+func (res channelVersionsPageRes) Code() int {
+	return http.StatusOK
+}
+
+func (res channelVersionsPageRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res channelVersionsPageRes) Empty() bool {
+	return false
+}
+
+type adminThingRes struct {
+	ID         string                 `json:"id"`
+	Owner      string                 `json:"owner"`
+	Name       string                 `json:"name,omitempty"`
+	Key        string                 `json:"key"`
+	ExternalID string                 `json:"external_id,omitempty"`
+	Location   *things.Location       `json:"location,omitempty"`
+	Metadata   map[string]interface{} `json:"metadata,omitempty"`
+}
+
+type adminThingsPageRes struct {
+	pageRes
+	Things []adminThingRes `json:"things"`
+}
+
+func (res adminThingsPageRes) Code() int {
+	return http.StatusOK
+}
+
+func (res adminThingsPageRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res adminThingsPageRes) Empty() bool {
+	return false
+}
+
+type adminChannelRes struct {
+	ID       string                 `json:"id"`
+	Owner    string                 `json:"owner"`
+	Name     string                 `json:"name,omitempty"`
+	Metadata map[string]interface{} `json:"metadata,omitempty"`
+}
+
+type adminChannelsPageRes struct {
+	pageRes
+	Channels []adminChannelRes `json:"channels"`
+}
+
+func (res adminChannelsPageRes) Code() int {
+	return http.StatusOK
+}
+
+func (res adminChannelsPageRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res adminChannelsPageRes) Empty() bool {
+	return false
+}
+
+type connectionRes struct {
+	ChannelID string `json:"channel_id"`
+	ThingID   string `json:"thing_id"`
+}
+
+type connectionsPageRes struct {
+	pageRes
+	Connections []connectionRes `json:"connections"`
+}
+
+func (res connectionsPageRes) Code() int {
+	return http.StatusOK
+}
+
+func (res connectionsPageRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res connectionsPageRes) Empty() bool {
+	return false
+}
+
 type connectionsRes struct{}
 
 func (res connectionsRes) Code() int {
@@ -200,11 +330,13 @@ func (res connectionsRes) Empty() bool {
 }
 
 type backupThingRes struct {
-	ID       string                 `json:"id"`
-	Owner    string                 `json:"owner,omitempty"`
-	Name     string                 `json:"name,omitempty"`
-	Key      string                 `json:"key"`
-	Metadata map[string]interface{} `json:"metadata,omitempty"`
+	ID         string                 `json:"id"`
+	Owner      string                 `json:"owner,omitempty"`
+	Name       string                 `json:"name,omitempty"`
+	Key        string                 `json:"key"`
+	ExternalID string                 `json:"external_id,omitempty"`
+	Location   *things.Location       `json:"location,omitempty"`
+	Metadata   map[string]interface{} `json:"metadata,omitempty"`
 }
 
 type backupChannelRes struct {
@@ -235,7 +367,33 @@ type backupGroupChannelRelationRes struct {
 	UpdatedAt time.Time `json:"updated_at"`
 }
 
+type groupExportRes struct {
+	Version     int                   `json:"version"`
+	Group       groupRes              `json:"group"`
+	Things      []backupThingRes      `json:"things"`
+	Channels    []backupChannelRes    `json:"channels"`
+	Connections []backupConnectionRes `json:"connections"`
+	created     bool
+}
+
+func (res groupExportRes) Code() int {
+	if res.created {
+		return http.StatusCreated
+	}
+
+	return http.StatusOK
+}
+
+func (res groupExportRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res groupExportRes) Empty() bool {
+	return false
+}
+
 type backupRes struct {
+	Version               int                             `json:"version"`
 	Things                []backupThingRes                `json:"things"`
 	Channels              []backupChannelRes              `json:"channels"`
 	Connections           []backupConnectionRes           `json:"connections"`
@@ -321,6 +479,7 @@ type viewGroupRes struct {
 	Metadata    map[string]interface{} `json:"metadata,omitempty"`
 	CreatedAt   time.Time              `json:"created_at"`
 	UpdatedAt   time.Time              `json:"updated_at"`
+	revision    uint64
 }
 
 func (res viewGroupRes) Code() int {
@@ -328,13 +487,55 @@ func (res viewGroupRes) Code() int {
 }
 
 func (res viewGroupRes) Headers() map[string]string {
-	return map[string]string{}
+	return etagHeaders(res.revision)
 }
 
 func (res viewGroupRes) Empty() bool {
 	return false
 }
 
+type groupTemplateRes struct {
+	ID          string                   `json:"id"`
+	Name        string                   `json:"name"`
+	Description string                   `json:"description,omitempty"`
+	Channels    []things.ChannelTemplate `json:"channels"`
+	CreatedAt   time.Time                `json:"created_at"`
+	created     bool
+}
+
+func (res groupTemplateRes) Code() int {
+	if res.created {
+		return http.StatusCreated
+	}
+
+	return http.StatusOK
+}
+
+func (res groupTemplateRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res groupTemplateRes) Empty() bool {
+	return false
+}
+
+type groupTemplatesPageRes struct {
+	pageRes
+	Templates []groupTemplateRes `json:"templates"`
+}
+
+func (res groupTemplatesPageRes) Code() int {
+	return http.StatusOK
+}
+
+func (res groupTemplatesPageRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res groupTemplatesPageRes) Empty() bool {
+	return false
+}
+
 type groupRes struct {
 	ID          string                 `json:"id"`
 	Name        string                 `json:"name,omitempty"`
@@ -381,6 +582,28 @@ func (res groupPageRes) Empty() bool {
 	return false
 }
 
+type groupStatsRes struct {
+	Things             uint64    `json:"things"`
+	Channels           uint64    `json:"channels"`
+	ConnectedThings    uint64    `json:"connected_things"`
+	DisconnectedThings uint64    `json:"disconnected_things"`
+	Messages           uint64    `json:"messages_24h"`
+	StoredMessages     uint64    `json:"stored_messages"`
+	ComputedAt         time.Time `json:"computed_at"`
+}
+
+func (res groupStatsRes) Code() int {
+	return http.StatusOK
+}
+
+func (res groupStatsRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res groupStatsRes) Empty() bool {
+	return false
+}
+
 type assignRes struct{}
 
 func (res assignRes) Code() int {
@@ -395,6 +618,28 @@ func (res assignRes) Empty() bool {
 	return true
 }
 
+type batchFailureRes struct {
+	ID    string `json:"id"`
+	Error string `json:"error"`
+}
+
+type batchRes struct {
+	Processed int               `json:"processed"`
+	Failed    []batchFailureRes `json:"failed"`
+}
+
+func (res batchRes) Code() int {
+	return http.StatusOK
+}
+
+func (res batchRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res batchRes) Empty() bool {
+	return false
+}
+
 type unassignRes struct{}
 
 func (res unassignRes) Code() int {
@@ -408,3 +653,13 @@ func (res unassignRes) Headers() map[string]string {
 func (res unassignRes) Empty() bool {
 	return true
 }
+
+func etagHeaders(revision uint64) map[string]string {
+	if revision == 0 {
+		return map[string]string{}
+	}
+
+	return map[string]string{
+		"ETag": strconv.Quote(strconv.FormatUint(revision, 10)),
+	}
+}
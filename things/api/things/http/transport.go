@@ -7,12 +7,15 @@ import (
 	"context"
 	"encoding/json"
 	"net/http"
+	"strconv"
 	"strings"
 
 	"github.com/MainfluxLabs/mainflux"
 	"github.com/MainfluxLabs/mainflux/internal/apiutil"
 	log "github.com/MainfluxLabs/mainflux/logger"
+	"github.com/MainfluxLabs/mainflux/pkg/acl"
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/signature"
 	"github.com/MainfluxLabs/mainflux/pkg/uuid"
 	"github.com/MainfluxLabs/mainflux/things"
 	kitot "github.com/go-kit/kit/tracing/opentracing"
@@ -36,12 +39,23 @@ const (
 	channelIDKey  = "channelID"
 	unassignedKey = "unassigned"
 	adminKey      = "admin"
+	ownerKey      = "owner"
+	keyPrefixKey  = "key"
+	withinKey     = "within"
+	queryKey      = "q"
+	versionKey    = "version"
+	thingKey      = "thing"
+	channelKey    = "channel"
+	groupKey      = "group"
+	templateIDKey = "templateID"
+	rekeyKey      = "rekey"
+	ifMatchHeader = "If-Match"
 	defOffset     = 0
 	defLimit      = 10
 )
 
 // MakeHandler returns a HTTP handler for API endpoints.
-func MakeHandler(tracer opentracing.Tracer, svc things.Service, logger log.Logger) http.Handler {
+func MakeHandler(tracer opentracing.Tracer, svc things.Service, logger log.Logger, checks ...mainflux.HealthCheck) http.Handler {
 	opts := []kithttp.ServerOption{
 		kithttp.ServerErrorEncoder(apiutil.LoggingErrorEncoder(logger, encodeError)),
 	}
@@ -69,6 +83,27 @@ func MakeHandler(tracer opentracing.Tracer, svc things.Service, logger log.Logge
 		opts...,
 	))
 
+	r.Put("/things/:id/signing-key", kithttp.NewServer(
+		kitot.TraceServer(tracer, "update_signing_key")(updateSigningKeyEndpoint(svc)),
+		decodeSigningKeyUpdate,
+		encodeResponse,
+		opts...,
+	))
+
+	r.Get("/things/:id/signing-key", kithttp.NewServer(
+		kitot.TraceServer(tracer, "view_signing_key")(viewSigningKeyEndpoint(svc)),
+		decodeView,
+		encodeResponse,
+		opts...,
+	))
+
+	r.Delete("/things/:id/signing-key", kithttp.NewServer(
+		kitot.TraceServer(tracer, "remove_signing_key")(removeSigningKeyEndpoint(svc)),
+		decodeView,
+		encodeResponse,
+		opts...,
+	))
+
 	r.Put("/things/:id", kithttp.NewServer(
 		kitot.TraceServer(tracer, "update_thing")(updateThingEndpoint(svc)),
 		decodeThingUpdate,
@@ -83,6 +118,20 @@ func MakeHandler(tracer opentracing.Tracer, svc things.Service, logger log.Logge
 		opts...,
 	))
 
+	r.Get("/things/external/:id", kithttp.NewServer(
+		kitot.TraceServer(tracer, "view_thing_by_external_id")(viewThingByExternalIDEndpoint(svc)),
+		decodeView,
+		encodeResponse,
+		opts...,
+	))
+
+	r.Get("/things/search", kithttp.NewServer(
+		kitot.TraceServer(tracer, "search_things_by_text")(listThingsEndpoint(svc)),
+		decodeTextSearch,
+		encodeResponse,
+		opts...,
+	))
+
 	r.Get("/things/:id", kithttp.NewServer(
 		kitot.TraceServer(tracer, "view_thing")(viewThingEndpoint(svc)),
 		decodeView,
@@ -146,6 +195,20 @@ func MakeHandler(tracer opentracing.Tracer, svc things.Service, logger log.Logge
 		opts...,
 	))
 
+	r.Get("/channels/:id/profile/versions", kithttp.NewServer(
+		kitot.TraceServer(tracer, "list_channel_versions")(listChannelVersionsEndpoint(svc)),
+		decodeListChannelVersions,
+		encodeResponse,
+		opts...,
+	))
+
+	r.Post("/channels/:id/profile/versions/:version/rollback", kithttp.NewServer(
+		kitot.TraceServer(tracer, "rollback_channel")(rollbackChannelEndpoint(svc)),
+		decodeRollbackChannel,
+		encodeResponse,
+		opts...,
+	))
+
 	r.Get("/channels/:id/things", kithttp.NewServer(
 		kitot.TraceServer(tracer, "list_things_by_channel")(listThingsByChannelEndpoint(svc)),
 		decodeListByConnection,
@@ -174,6 +237,69 @@ func MakeHandler(tracer opentracing.Tracer, svc things.Service, logger log.Logge
 		opts...,
 	))
 
+	r.Get("/connections", kithttp.NewServer(
+		kitot.TraceServer(tracer, "list_connections")(listConnectionsEndpoint(svc)),
+		decodeListConnections,
+		encodeResponse,
+		opts...,
+	))
+
+	r.Post("/templates", kithttp.NewServer(
+		kitot.TraceServer(tracer, "create_group_template")(createGroupTemplateEndpoint(svc)),
+		decodeGroupTemplateCreation,
+		encodeResponse,
+		opts...,
+	))
+
+	r.Get("/templates", kithttp.NewServer(
+		kitot.TraceServer(tracer, "list_group_templates")(listGroupTemplatesEndpoint(svc)),
+		decodeListGroupTemplates,
+		encodeResponse,
+		opts...,
+	))
+
+	r.Get("/templates/:templateID", kithttp.NewServer(
+		kitot.TraceServer(tracer, "view_group_template")(viewGroupTemplateEndpoint(svc)),
+		decodeGroupTemplateRequest,
+		encodeResponse,
+		opts...,
+	))
+
+	r.Delete("/templates/:templateID", kithttp.NewServer(
+		kitot.TraceServer(tracer, "remove_group_template")(removeGroupTemplateEndpoint(svc)),
+		decodeGroupTemplateRequest,
+		encodeResponse,
+		opts...,
+	))
+
+	r.Post("/templates/:templateID/groups", kithttp.NewServer(
+		kitot.TraceServer(tracer, "create_group_from_template")(createGroupFromTemplateEndpoint(svc)),
+		decodeGroupFromTemplateCreation,
+		encodeResponse,
+		opts...,
+	))
+
+	r.Post("/groups/import", kithttp.NewServer(
+		kitot.TraceServer(tracer, "import_group")(importGroupEndpoint(svc)),
+		decodeImportGroup,
+		encodeResponse,
+		opts...,
+	))
+
+	r.Get("/groups/:groupID/stats", kithttp.NewServer(
+		kitot.TraceServer(tracer, "view_group_stats")(viewGroupStatsEndpoint(svc)),
+		decodeGroupRequest,
+		encodeResponse,
+		opts...,
+	))
+
+	r.Get("/groups/:groupID/export", kithttp.NewServer(
+		kitot.TraceServer(tracer, "export_group")(exportGroupEndpoint(svc)),
+		decodeExportGroup,
+		encodeResponse,
+		opts...,
+	))
+
 	r.Post("/groups", kithttp.NewServer(
 		kitot.TraceServer(tracer, "create_groups")(createGroupsEndpoint(svc)),
 		decodeGroupsCreation,
@@ -230,6 +356,20 @@ func MakeHandler(tracer opentracing.Tracer, svc things.Service, logger log.Logge
 		opts...,
 	))
 
+	r.Post("/groups/:groupID/things/batch", kithttp.NewServer(
+		kitot.TraceServer(tracer, "assign_things_batch")(assignThingsBatchEndpoint(svc)),
+		decodeGroupThingsBatchRequest,
+		encodeResponse,
+		opts...,
+	))
+
+	r.Patch("/groups/:groupID/things/batch", kithttp.NewServer(
+		kitot.TraceServer(tracer, "unassign_things_batch")(unassignThingsBatchEndpoint(svc)),
+		decodeGroupThingsBatchRequest,
+		encodeResponse,
+		opts...,
+	))
+
 	r.Get("/groups/:groupID/things", kithttp.NewServer(
 		kitot.TraceServer(tracer, "list_group_things")(listGroupThingsEndpoint(svc)),
 		decodeListMembersRequest,
@@ -258,6 +398,20 @@ func MakeHandler(tracer opentracing.Tracer, svc things.Service, logger log.Logge
 		opts...,
 	))
 
+	r.Post("/groups/:groupID/channels/batch", kithttp.NewServer(
+		kitot.TraceServer(tracer, "assign_channels_batch")(assignChannelsBatchEndpoint(svc)),
+		decodeGroupChannelsBatchRequest,
+		encodeResponse,
+		opts...,
+	))
+
+	r.Patch("/groups/:groupID/channels/batch", kithttp.NewServer(
+		kitot.TraceServer(tracer, "unassign_channels_batch")(unassignChannelsBatchEndpoint(svc)),
+		decodeGroupChannelsBatchRequest,
+		encodeResponse,
+		opts...,
+	))
+
 	r.Get("/groups/:groupID/channels", kithttp.NewServer(
 		kitot.TraceServer(tracer, "list_group_channels")(listGroupChannelsEndpoint(svc)),
 		decodeListMembersRequest,
@@ -279,6 +433,69 @@ func MakeHandler(tracer opentracing.Tracer, svc things.Service, logger log.Logge
 		opts...,
 	))
 
+	r.Get("/admin/things", kithttp.NewServer(
+		kitot.TraceServer(tracer, "search_things_by_admin")(searchThingsEndpoint(svc)),
+		decodeSearch,
+		encodeResponse,
+		opts...,
+	))
+
+	r.Get("/admin/channels", kithttp.NewServer(
+		kitot.TraceServer(tracer, "search_channels_by_admin")(searchChannelsEndpoint(svc)),
+		decodeSearch,
+		encodeResponse,
+		opts...,
+	))
+
+	r.Get("/admin/groups", kithttp.NewServer(
+		kitot.TraceServer(tracer, "search_groups_by_admin")(searchGroupsEndpoint(svc)),
+		decodeSearch,
+		encodeResponse,
+		opts...,
+	))
+
+	r.Put("/admin/things/:id", kithttp.NewServer(
+		kitot.TraceServer(tracer, "update_thing_by_admin")(updateThingByAdminEndpoint(svc)),
+		decodeThingUpdate,
+		encodeResponse,
+		opts...,
+	))
+
+	r.Delete("/admin/things/:id", kithttp.NewServer(
+		kitot.TraceServer(tracer, "remove_thing_by_admin")(removeThingByAdminEndpoint(svc)),
+		decodeView,
+		encodeResponse,
+		opts...,
+	))
+
+	r.Put("/admin/channels/:id", kithttp.NewServer(
+		kitot.TraceServer(tracer, "update_channel_by_admin")(updateChannelByAdminEndpoint(svc)),
+		decodeChannelUpdate,
+		encodeResponse,
+		opts...,
+	))
+
+	r.Delete("/admin/channels/:id", kithttp.NewServer(
+		kitot.TraceServer(tracer, "remove_channel_by_admin")(removeChannelByAdminEndpoint(svc)),
+		decodeView,
+		encodeResponse,
+		opts...,
+	))
+
+	r.Put("/admin/groups/:groupID", kithttp.NewServer(
+		kitot.TraceServer(tracer, "update_group_by_admin")(updateGroupByAdminEndpoint(svc)),
+		decodeGroupUpdate,
+		encodeResponse,
+		opts...,
+	))
+
+	r.Delete("/admin/groups/:groupID", kithttp.NewServer(
+		kitot.TraceServer(tracer, "remove_group_by_admin")(removeGroupByAdminEndpoint(svc)),
+		decodeGroupRequest,
+		encodeResponse,
+		opts...,
+	))
+
 	r.Get("/backup", kithttp.NewServer(
 		kitot.TraceServer(tracer, "backup")(backupEndpoint(svc)),
 		decodeBackup,
@@ -293,10 +510,11 @@ func MakeHandler(tracer opentracing.Tracer, svc things.Service, logger log.Logge
 		opts...,
 	))
 
-	r.GetFunc("/health", mainflux.Health("things"))
+	r.GetFunc("/health", mainflux.Health("things", checks...))
 	r.Handle("/metrics", promhttp.Handler())
+	r.Handle("/log-level", mainflux.LogLevel(logger))
 
-	return r
+	return apiutil.RequestIDMiddleware(apiutil.LocaleMiddleware(r))
 }
 
 func decodeThingsCreation(_ context.Context, r *http.Request) (interface{}, error) {
@@ -317,9 +535,15 @@ func decodeThingUpdate(_ context.Context, r *http.Request) (interface{}, error)
 		return nil, apiutil.ErrUnsupportedContentType
 	}
 
+	revision, err := decodeIfMatch(r)
+	if err != nil {
+		return nil, err
+	}
+
 	req := updateThingReq{
-		token: apiutil.ExtractBearerToken(r),
-		id:    bone.GetValue(r, "id"),
+		token:    apiutil.ExtractBearerToken(r),
+		id:       bone.GetValue(r, "id"),
+		revision: revision,
 	}
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		return nil, errors.Wrap(apiutil.ErrMalformedEntity, err)
@@ -344,6 +568,22 @@ func decodeKeyUpdate(_ context.Context, r *http.Request) (interface{}, error) {
 	return req, nil
 }
 
+func decodeSigningKeyUpdate(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), contentType) {
+		return nil, apiutil.ErrUnsupportedContentType
+	}
+
+	req := updateSigningKeyReq{
+		token: apiutil.ExtractBearerToken(r),
+		id:    bone.GetValue(r, "id"),
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(apiutil.ErrMalformedEntity, err)
+	}
+
+	return req, nil
+}
+
 func decodeChannelsCreation(_ context.Context, r *http.Request) (interface{}, error) {
 	if !strings.Contains(r.Header.Get("Content-Type"), contentType) {
 		return nil, apiutil.ErrUnsupportedContentType
@@ -362,9 +602,15 @@ func decodeChannelUpdate(_ context.Context, r *http.Request) (interface{}, error
 		return nil, apiutil.ErrUnsupportedContentType
 	}
 
+	revision, err := decodeIfMatch(r)
+	if err != nil {
+		return nil, err
+	}
+
 	req := updateChannelReq{
-		token: apiutil.ExtractBearerToken(r),
-		id:    bone.GetValue(r, "id"),
+		token:    apiutil.ExtractBearerToken(r),
+		id:       bone.GetValue(r, "id"),
+		revision: revision,
 	}
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		return nil, errors.Wrap(apiutil.ErrMalformedEntity, err)
@@ -435,6 +681,11 @@ func decodeList(_ context.Context, r *http.Request) (interface{}, error) {
 		return nil, err
 	}
 
+	w, err := readWithinQuery(r, withinKey)
+	if err != nil {
+		return nil, err
+	}
+
 	req := listResourcesReq{
 		token: apiutil.ExtractBearerToken(r),
 		pageMetadata: things.PageMetadata{
@@ -444,6 +695,7 @@ func decodeList(_ context.Context, r *http.Request) (interface{}, error) {
 			Order:    or,
 			Dir:      d,
 			Metadata: m,
+			Within:   w,
 		},
 		admin: a,
 	}
@@ -451,6 +703,77 @@ func decodeList(_ context.Context, r *http.Request) (interface{}, error) {
 	return req, nil
 }
 
+// readWithinQuery reads the area given as the comma separated latitude,
+// longitude and radius in meters (e.g. within=44.81,20.46,1000).
+func readWithinQuery(r *http.Request, key string) (*things.Area, error) {
+	// Query values are already split by commas.
+	parts := bone.GetQuery(r, key)
+	if len(parts) == 0 {
+		return nil, nil
+	}
+
+	if len(parts) != 3 {
+		return nil, apiutil.ErrInvalidQueryParams
+	}
+
+	var vals [3]float64
+	for i, p := range parts {
+		v, err := strconv.ParseFloat(strings.TrimSpace(p), 64)
+		if err != nil {
+			return nil, apiutil.ErrInvalidQueryParams
+		}
+		vals[i] = v
+	}
+
+	area := things.Area{
+		Location: things.Location{Latitude: vals[0], Longitude: vals[1]},
+		Radius:   vals[2],
+	}
+
+	return &area, nil
+}
+
+func decodeSearch(ctx context.Context, r *http.Request) (interface{}, error) {
+	req, err := decodeList(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+
+	o, err := apiutil.ReadStringQuery(r, ownerKey, "")
+	if err != nil {
+		return nil, err
+	}
+
+	k, err := apiutil.ReadStringQuery(r, keyPrefixKey, "")
+	if err != nil {
+		return nil, err
+	}
+
+	sr := req.(listResourcesReq)
+	sr.pageMetadata.Owner = o
+	sr.pageMetadata.KeyPrefix = k
+
+	return sr, nil
+}
+
+func decodeTextSearch(ctx context.Context, r *http.Request) (interface{}, error) {
+	req, err := decodeList(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+
+	// Search text is read as is, since it may contain commas.
+	q := strings.TrimSpace(r.URL.Query().Get(queryKey))
+	if q == "" {
+		return nil, apiutil.ErrInvalidQueryParams
+	}
+
+	lr := req.(listResourcesReq)
+	lr.pageMetadata.Query = q
+
+	return lr, nil
+}
+
 func decodeListByMetadata(_ context.Context, r *http.Request) (interface{}, error) {
 	req := listResourcesReq{token: apiutil.ExtractBearerToken(r)}
 	if err := json.NewDecoder(r.Body).Decode(&req.pageMetadata); err != nil {
@@ -501,6 +824,86 @@ func decodeListByConnection(_ context.Context, r *http.Request) (interface{}, er
 	return req, nil
 }
 
+func decodeListConnections(_ context.Context, r *http.Request) (interface{}, error) {
+	o, err := apiutil.ReadUintQuery(r, offsetKey, defOffset)
+	if err != nil {
+		return nil, err
+	}
+
+	l, err := apiutil.ReadLimitQuery(r, limitKey, defLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	th, err := apiutil.ReadStringQuery(r, thingKey, "")
+	if err != nil {
+		return nil, err
+	}
+
+	ch, err := apiutil.ReadStringQuery(r, channelKey, "")
+	if err != nil {
+		return nil, err
+	}
+
+	gr, err := apiutil.ReadStringQuery(r, groupKey, "")
+	if err != nil {
+		return nil, err
+	}
+
+	req := listConnectionsReq{
+		token: apiutil.ExtractBearerToken(r),
+		filter: things.ConnectionsFilter{
+			ThingID:   th,
+			ChannelID: ch,
+			GroupID:   gr,
+		},
+		pageMetadata: things.PageMetadata{
+			Offset: o,
+			Limit:  l,
+		},
+	}
+
+	return req, nil
+}
+
+func decodeListChannelVersions(_ context.Context, r *http.Request) (interface{}, error) {
+	o, err := apiutil.ReadUintQuery(r, offsetKey, defOffset)
+	if err != nil {
+		return nil, err
+	}
+
+	l, err := apiutil.ReadLimitQuery(r, limitKey, defLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	req := listChannelVersionsReq{
+		token: apiutil.ExtractBearerToken(r),
+		id:    bone.GetValue(r, "id"),
+		pageMetadata: things.PageMetadata{
+			Offset: o,
+			Limit:  l,
+		},
+	}
+
+	return req, nil
+}
+
+func decodeRollbackChannel(_ context.Context, r *http.Request) (interface{}, error) {
+	v, err := strconv.ParseUint(bone.GetValue(r, versionKey), 10, 64)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrMalformedEntity, err)
+	}
+
+	req := rollbackChannelReq{
+		token:   apiutil.ExtractBearerToken(r),
+		id:      bone.GetValue(r, "id"),
+		version: v,
+	}
+
+	return req, nil
+}
+
 func decodeListGroupThingsByChannel(_ context.Context, r *http.Request) (interface{}, error) {
 	o, err := apiutil.ReadUintQuery(r, offsetKey, defOffset)
 	if err != nil {
@@ -628,9 +1031,15 @@ func decodeGroupUpdate(_ context.Context, r *http.Request) (interface{}, error)
 		return nil, apiutil.ErrUnsupportedContentType
 	}
 
+	revision, err := decodeIfMatch(r)
+	if err != nil {
+		return nil, err
+	}
+
 	req := updateGroupReq{
-		id:    bone.GetValue(r, groupIDKey),
-		token: apiutil.ExtractBearerToken(r),
+		id:       bone.GetValue(r, groupIDKey),
+		token:    apiutil.ExtractBearerToken(r),
+		revision: revision,
 	}
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		return nil, errors.Wrap(apiutil.ErrMalformedEntity, err)
@@ -655,6 +1064,96 @@ func decodeRemoveGroupsRequest(_ context.Context, r *http.Request) (interface{},
 	return req, nil
 }
 
+func decodeGroupTemplateCreation(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), contentType) {
+		return nil, apiutil.ErrUnsupportedContentType
+	}
+
+	req := createGroupTemplateReq{token: apiutil.ExtractBearerToken(r)}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(apiutil.ErrMalformedEntity, err)
+	}
+
+	return req, nil
+}
+
+func decodeListGroupTemplates(_ context.Context, r *http.Request) (interface{}, error) {
+	o, err := apiutil.ReadUintQuery(r, offsetKey, defOffset)
+	if err != nil {
+		return nil, err
+	}
+
+	l, err := apiutil.ReadLimitQuery(r, limitKey, defLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	req := listGroupTemplatesReq{
+		token: apiutil.ExtractBearerToken(r),
+		pageMetadata: things.PageMetadata{
+			Offset: o,
+			Limit:  l,
+		},
+	}
+
+	return req, nil
+}
+
+func decodeGroupTemplateRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	req := groupTemplateReq{
+		token: apiutil.ExtractBearerToken(r),
+		id:    bone.GetValue(r, templateIDKey),
+	}
+
+	return req, nil
+}
+
+func decodeGroupFromTemplateCreation(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), contentType) {
+		return nil, apiutil.ErrUnsupportedContentType
+	}
+
+	req := createGroupFromTemplateReq{
+		token:      apiutil.ExtractBearerToken(r),
+		templateID: bone.GetValue(r, templateIDKey),
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(apiutil.ErrMalformedEntity, err)
+	}
+
+	return req, nil
+}
+
+func decodeExportGroup(_ context.Context, r *http.Request) (interface{}, error) {
+	req := exportGroupReq{
+		token: apiutil.ExtractBearerToken(r),
+		id:    bone.GetValue(r, groupIDKey),
+	}
+
+	return req, nil
+}
+
+func decodeImportGroup(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), contentType) {
+		return nil, apiutil.ErrUnsupportedContentType
+	}
+
+	rk, err := apiutil.ReadBoolQuery(r, rekeyKey, false)
+	if err != nil {
+		return nil, err
+	}
+
+	req := importGroupReq{
+		token: apiutil.ExtractBearerToken(r),
+		rekey: rk,
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(apiutil.ErrMalformedEntity, err)
+	}
+
+	return req, nil
+}
+
 func decodeGroupRequest(_ context.Context, r *http.Request) (interface{}, error) {
 	req := groupReq{
 		token: apiutil.ExtractBearerToken(r),
@@ -690,6 +1189,32 @@ func decodeGroupChannelsRequest(_ context.Context, r *http.Request) (interface{}
 	return req, nil
 }
 
+func decodeGroupThingsBatchRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	req := groupThingsBatchReq{
+		token:   apiutil.ExtractBearerToken(r),
+		groupID: bone.GetValue(r, groupIDKey),
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(apiutil.ErrMalformedEntity, err)
+	}
+
+	return req, nil
+}
+
+func decodeGroupChannelsBatchRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	req := groupChannelsBatchReq{
+		token:   apiutil.ExtractBearerToken(r),
+		groupID: bone.GetValue(r, groupIDKey),
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(apiutil.ErrMalformedEntity, err)
+	}
+
+	return req, nil
+}
+
 func decodeViewThingMembershipRequest(_ context.Context, r *http.Request) (interface{}, error) {
 	req := listMembersReq{
 		token: apiutil.ExtractBearerToken(r),
@@ -761,7 +1286,23 @@ func encodeResponse(_ context.Context, w http.ResponseWriter, response interface
 	return json.NewEncoder(w).Encode(response)
 }
 
-func encodeError(_ context.Context, err error, w http.ResponseWriter) {
+// decodeIfMatch returns the revision carried by the If-Match header. A missing
+// header or a wildcard yields zero, which disables the revision check.
+func decodeIfMatch(r *http.Request) (uint64, error) {
+	tag := strings.TrimPrefix(strings.TrimSpace(r.Header.Get(ifMatchHeader)), "W/")
+	if tag == "" || tag == "*" {
+		return 0, nil
+	}
+
+	revision, err := strconv.ParseUint(strings.Trim(tag, `"`), 10, 64)
+	if err != nil || revision == 0 {
+		return 0, things.ErrRevisionMismatch
+	}
+
+	return revision, nil
+}
+
+func encodeError(ctx context.Context, err error, w http.ResponseWriter) {
 	switch {
 	// ErrNotFound can be masked by ErrAuthentication, but it has priority.
 	case errors.Contains(err, errors.ErrNotFound):
@@ -775,7 +1316,10 @@ func encodeError(_ context.Context, err error, w http.ResponseWriter) {
 		w.WriteHeader(http.StatusUnsupportedMediaType)
 	case errors.Contains(err, apiutil.ErrInvalidQueryParams),
 		errors.Contains(err, apiutil.ErrMalformedEntity),
+		errors.Contains(err, errors.ErrMalformedEntity),
 		err == apiutil.ErrNameSize,
+		err == apiutil.ErrExternalIDSize,
+		err == apiutil.ErrInvalidLocation,
 		err == apiutil.ErrEmptyList,
 		err == apiutil.ErrMissingID,
 		err == apiutil.ErrMissingEmail,
@@ -784,10 +1328,17 @@ func encodeError(_ context.Context, err error, w http.ResponseWriter) {
 		err == apiutil.ErrOffsetSize,
 		err == apiutil.ErrInvalidOrder,
 		err == apiutil.ErrInvalidDirection,
-		err == apiutil.ErrInvalidIDFormat:
+		err == apiutil.ErrInvalidVersion,
+		err == apiutil.ErrInvalidIDFormat,
+		errors.Contains(err, acl.ErrMalformedACL),
+		errors.Contains(err, things.ErrMalformedProfile),
+		err == signature.ErrUnsupportedAlgorithm,
+		err == signature.ErrInvalidKey:
 		w.WriteHeader(http.StatusBadRequest)
 	case errors.Contains(err, errors.ErrConflict):
 		w.WriteHeader(http.StatusConflict)
+	case errors.Contains(err, things.ErrRevisionMismatch):
+		w.WriteHeader(http.StatusPreconditionFailed)
 	case errors.Contains(err, errors.ErrScanMetadata):
 		w.WriteHeader(http.StatusUnprocessableEntity)
 
@@ -806,7 +1357,7 @@ func encodeError(_ context.Context, err error, w http.ResponseWriter) {
 
 	if errorVal, ok := err.(errors.Error); ok {
 		w.Header().Set("Content-Type", contentType)
-		if err := json.NewEncoder(w).Encode(apiutil.ErrorRes{Err: errorVal.Msg()}); err != nil {
+		if err := json.NewEncoder(w).Encode(apiutil.NewErrorRes(ctx, errorVal)); err != nil {
 			w.WriteHeader(http.StatusInternalServerError)
 		}
 	}
@@ -5,6 +5,7 @@ package things
 
 import (
 	"context"
+	"time"
 )
 
 // Channel represents a Mainflux "communication group". This group contains the
@@ -14,6 +15,7 @@ type Channel struct {
 	Owner    string
 	Name     string
 	Metadata map[string]interface{}
+	Revision uint64
 }
 
 // ChannelsPage contains page related metadata as well as list of channels that
@@ -23,6 +25,24 @@ type ChannelsPage struct {
 	Channels []Channel
 }
 
+// ChannelVersion represents the configuration of the channel, i.e. its name
+// and metadata, preceding one of the channel updates.
+type ChannelVersion struct {
+	ChannelID string
+	Owner     string
+	Version   uint64
+	Name      string
+	Metadata  map[string]interface{}
+	CreatedAt time.Time
+}
+
+// ChannelVersionsPage contains page related metadata as well as list of
+// channel versions that belong to this page.
+type ChannelVersionsPage struct {
+	PageMetadata
+	Versions []ChannelVersion
+}
+
 // Connection represents a connection between a channel and a thing.
 type Connection struct {
 	ChannelID    string
@@ -31,6 +51,21 @@ type Connection struct {
 	ThingOwner   string
 }
 
+// ConnectionsFilter specifies the thing, channel and group whose connections
+// are retrieved. Empty fields are not used for filtering.
+type ConnectionsFilter struct {
+	ThingID   string
+	ChannelID string
+	GroupID   string
+}
+
+// ConnectionsPage contains page related metadata as well as list of
+// connections that belong to this page.
+type ConnectionsPage struct {
+	PageMetadata
+	Connections []Connection
+}
+
 // ChannelRepository specifies a channel persistence API.
 type ChannelRepository interface {
 	// Save persists multiple channels. Channels are saved using a transaction. If one channel
@@ -80,11 +115,29 @@ type ChannelRepository interface {
 	// RetrieveAll retrieves all channels for all users.
 	RetrieveAll(ctx context.Context) ([]Channel, error)
 
-	// RetrieveByAdmin  retrieves all channels for all users with pagination.
+	// RetrieveByAdmin retrieves channels of all users with pagination,
+	// optionally filtered by the owner.
 	RetrieveByAdmin(ctx context.Context, pm PageMetadata) (ChannelsPage, error)
 
 	// RetrieveAllConnections retrieves all connections between channels and things for all users.
 	RetrieveAllConnections(ctx context.Context) ([]Connection, error)
+
+	// RetrieveConnections retrieves the subset of connections of channels owned
+	// by the specified user, matching the filter. If the owner is empty,
+	// connections of all users are retrieved.
+	RetrieveConnections(ctx context.Context, owner string, cf ConnectionsFilter, pm PageMetadata) (ConnectionsPage, error)
+
+	// UpdateWithVersion performs an update of the channel, and persists the
+	// channel configuration preceding the update, created at the provided
+	// time, as the next version of the channel. Both happen atomically, so
+	// no version is persisted if the update fails.
+	UpdateWithVersion(ctx context.Context, ch Channel, createdAt time.Time) error
+
+	// RetrieveVersion retrieves the version of the channel.
+	RetrieveVersion(ctx context.Context, chID string, version uint64) (ChannelVersion, error)
+
+	// RetrieveVersions retrieves the subset of versions of the channel, starting from the latest one.
+	RetrieveVersions(ctx context.Context, chID string, pm PageMetadata) (ChannelVersionsPage, error)
 }
 
 // ChannelCache contains channel-thing connection caching interface.
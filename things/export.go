@@ -0,0 +1,40 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package things
+
+import (
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+)
+
+// GroupExport represents the portable bundle of the group along with its
+// things, channels and connections, used to migrate the group between
+// deployments. Channel profiles are carried by the channel metadata.
+type GroupExport struct {
+	Group       Group
+	Things      []Thing
+	Channels    []Channel
+	Connections []Connection
+}
+
+// validate checks that the connections of the bundle refer to the things and
+// channels within the bundle.
+func (ge GroupExport) validate() error {
+	ths := map[string]bool{}
+	for _, th := range ge.Things {
+		ths[th.ID] = true
+	}
+
+	chs := map[string]bool{}
+	for _, ch := range ge.Channels {
+		chs[ch.ID] = true
+	}
+
+	for _, conn := range ge.Connections {
+		if !ths[conn.ThingID] || !chs[conn.ChannelID] {
+			return errors.ErrMalformedEntity
+		}
+	}
+
+	return nil
+}
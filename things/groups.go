@@ -57,6 +57,7 @@ type Group struct {
 	Name        string
 	Description string
 	Metadata    GroupMetadata
+	Revision    uint64
 	CreatedAt   time.Time
 	UpdatedAt   time.Time
 }
@@ -96,6 +97,35 @@ type GroupChannelsPage struct {
 	Channels []Channel
 }
 
+// BatchChunkSize is the number of members assigned to or unassigned from
+// the group at once by the batch group assignment.
+const BatchChunkSize = 100
+
+// BatchFailure represents the member which failed to be assigned to or
+// unassigned from the group.
+type BatchFailure struct {
+	ID  string
+	Err error
+}
+
+// BatchResult represents the outcome of the batch group assignment. Chunks
+// contain the IDs of the processed members grouped by the chunks in which
+// they were processed.
+type BatchResult struct {
+	Chunks [][]string
+	Failed []BatchFailure
+}
+
+// Processed returns the number of the processed members.
+func (br BatchResult) Processed() int {
+	n := 0
+	for _, c := range br.Chunks {
+		n += len(c)
+	}
+
+	return n
+}
+
 // GroupRepository specifies a group persistence API.
 type GroupRepository interface {
 	// Save group
@@ -146,7 +176,8 @@ type GroupRepository interface {
 	// RetrieveAll retrieves all groups.
 	RetrieveAll(ctx context.Context) ([]Group, error)
 
-	// RetrieveByAdmin retrieves all groups with pagination.
+	// RetrieveByAdmin retrieves all groups with pagination, optionally
+	// filtered by the owner.
 	RetrieveByAdmin(ctx context.Context, pm PageMetadata) (GroupPage, error)
 
 	// RetrieveAllThingRelations retrieves all thing relations.
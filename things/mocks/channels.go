@@ -6,8 +6,10 @@ package mocks
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
 	"github.com/MainfluxLabs/mainflux/things"
@@ -29,6 +31,7 @@ type channelRepositoryMock struct {
 	channels map[string]things.Channel
 	tconns   chan Connection                      // used for synchronization with thing repo
 	cconns   map[string]map[string]things.Channel // used to track connections
+	versions map[string][]things.ChannelVersion
 	things   things.ThingRepository
 }
 
@@ -38,6 +41,7 @@ func NewChannelRepository(repo things.ThingRepository, tconns chan Connection) t
 		channels: make(map[string]things.Channel),
 		tconns:   tconns,
 		cconns:   make(map[string]map[string]things.Channel),
+		versions: make(map[string][]things.ChannelVersion),
 		things:   repo,
 	}
 }
@@ -51,7 +55,9 @@ func (crm *channelRepositoryMock) Save(_ context.Context, channels ...things.Cha
 		if channels[i].ID == "" {
 			channels[i].ID = fmt.Sprintf("%03d", crm.counter)
 		}
-		crm.channels[key(channels[i].Owner, channels[i].ID)] = channels[i]
+		ch := channels[i]
+		ch.Revision = 1
+		crm.channels[key(ch.Owner, ch.ID)] = ch
 	}
 
 	return channels, nil
@@ -63,10 +69,16 @@ func (crm *channelRepositoryMock) Update(_ context.Context, channel things.Chann
 
 	dbKey := key(channel.Owner, channel.ID)
 
-	if _, ok := crm.channels[dbKey]; !ok {
+	ch, ok := crm.channels[dbKey]
+	if !ok {
 		return errors.ErrNotFound
 	}
 
+	if channel.Revision != 0 && channel.Revision != ch.Revision {
+		return things.ErrRevisionMismatch
+	}
+
+	channel.Revision = ch.Revision + 1
 	crm.channels[dbKey] = channel
 	return nil
 }
@@ -137,6 +149,9 @@ func (crm *channelRepositoryMock) RetrieveByAdmin(ctx context.Context, pm things
 	i := uint64(0)
 	var chs []things.Channel
 	for _, ch := range crm.channels {
+		if pm.Owner != "" && ch.Owner != pm.Owner {
+			continue
+		}
 		if i >= pm.Offset && i < pm.Offset+pm.Limit {
 			chs = append(chs, ch)
 		}
@@ -171,7 +186,22 @@ func (crm *channelRepositoryMock) RetrieveByThing(_ context.Context, owner, thID
 }
 
 func (crm *channelRepositoryMock) RetrieveConns(_ context.Context, thID string, pm things.PageMetadata) (things.ChannelsPage, error) {
-	return things.ChannelsPage{}, nil
+	crm.mu.Lock()
+	defer crm.mu.Unlock()
+
+	chs := []things.Channel{}
+	for _, ch := range crm.cconns[thID] {
+		chs = append(chs, ch)
+	}
+
+	page := things.ChannelsPage{
+		Channels: chs,
+		PageMetadata: things.PageMetadata{
+			Total: uint64(len(chs)),
+		},
+	}
+
+	return page, nil
 }
 
 func (crm *channelRepositoryMock) Remove(_ context.Context, owner string, ids ...string) error {
@@ -184,6 +214,7 @@ func (crm *channelRepositoryMock) Remove(_ context.Context, owner string, ids ..
 		}
 
 		delete(crm.channels, key(owner, id))
+		delete(crm.versions, id)
 
 		for thk := range crm.cconns {
 			delete(crm.cconns[thk], key(owner, id))
@@ -204,7 +235,7 @@ func (crm *channelRepositoryMock) Connect(_ context.Context, owner, chID string,
 	}
 
 	for _, thID := range thIDs {
-		if _, ok := crm.cconns[thID]; ok {
+		if _, ok := crm.cconns[thID][chID]; ok {
 			return errors.ErrConflict
 		}
 		th, err := crm.things.RetrieveByID(context.Background(), thID)
@@ -310,6 +341,121 @@ func (crm *channelRepositoryMock) RetrieveAllConnections(ctx context.Context) ([
 
 }
 
+// RetrieveConnections doesn't support filtering by the group, since the
+// channel repository mock doesn't track group memberships.
+func (crm *channelRepositoryMock) RetrieveConnections(_ context.Context, owner string, cf things.ConnectionsFilter, pm things.PageMetadata) (things.ConnectionsPage, error) {
+	crm.mu.Lock()
+	defer crm.mu.Unlock()
+
+	var conns []things.Connection
+	for thID, chs := range crm.cconns {
+		if cf.ThingID != "" && thID != cf.ThingID {
+			continue
+		}
+		for _, ch := range chs {
+			if (owner != "" && ch.Owner != owner) || (cf.ChannelID != "" && ch.ID != cf.ChannelID) {
+				continue
+			}
+			conns = append(conns, things.Connection{
+				ChannelID:    ch.ID,
+				ChannelOwner: ch.Owner,
+				ThingID:      thID,
+				ThingOwner:   ch.Owner,
+			})
+		}
+	}
+
+	sort.Slice(conns, func(i, j int) bool {
+		if conns[i].ChannelID != conns[j].ChannelID {
+			return conns[i].ChannelID < conns[j].ChannelID
+		}
+		return conns[i].ThingID < conns[j].ThingID
+	})
+
+	total := uint64(len(conns))
+	items := []things.Connection{}
+	for i := pm.Offset; i < total && (pm.Limit == 0 || i < pm.Offset+pm.Limit); i++ {
+		items = append(items, conns[i])
+	}
+
+	page := things.ConnectionsPage{
+		Connections: items,
+		PageMetadata: things.PageMetadata{
+			Total:  total,
+			Offset: pm.Offset,
+			Limit:  pm.Limit,
+		},
+	}
+
+	return page, nil
+}
+
+func (crm *channelRepositoryMock) UpdateWithVersion(_ context.Context, channel things.Channel, createdAt time.Time) error {
+	crm.mu.Lock()
+	defer crm.mu.Unlock()
+
+	dbKey := key(channel.Owner, channel.ID)
+
+	ch, ok := crm.channels[dbKey]
+	if !ok {
+		return errors.ErrNotFound
+	}
+
+	if channel.Revision != 0 && channel.Revision != ch.Revision {
+		return things.ErrRevisionMismatch
+	}
+
+	cv := things.ChannelVersion{
+		ChannelID: ch.ID,
+		Owner:     ch.Owner,
+		Version:   uint64(len(crm.versions[ch.ID]) + 1),
+		Name:      ch.Name,
+		Metadata:  ch.Metadata,
+		CreatedAt: createdAt,
+	}
+	crm.versions[ch.ID] = append(crm.versions[ch.ID], cv)
+
+	channel.Revision = ch.Revision + 1
+	crm.channels[dbKey] = channel
+	return nil
+}
+
+func (crm *channelRepositoryMock) RetrieveVersion(_ context.Context, chID string, version uint64) (things.ChannelVersion, error) {
+	crm.mu.Lock()
+	defer crm.mu.Unlock()
+
+	cvs := crm.versions[chID]
+	if version == 0 || version > uint64(len(cvs)) {
+		return things.ChannelVersion{}, errors.ErrNotFound
+	}
+
+	return cvs[version-1], nil
+}
+
+func (crm *channelRepositoryMock) RetrieveVersions(_ context.Context, chID string, pm things.PageMetadata) (things.ChannelVersionsPage, error) {
+	crm.mu.Lock()
+	defer crm.mu.Unlock()
+
+	cvs := crm.versions[chID]
+	total := uint64(len(cvs))
+
+	items := []things.ChannelVersion{}
+	for i := pm.Offset; i < total && (pm.Limit == 0 || i < pm.Offset+pm.Limit); i++ {
+		items = append(items, cvs[total-i-1])
+	}
+
+	page := things.ChannelVersionsPage{
+		Versions: items,
+		PageMetadata: things.PageMetadata{
+			Total:  total,
+			Offset: pm.Offset,
+			Limit:  pm.Limit,
+		},
+	}
+
+	return page, nil
+}
+
 type channelCacheMock struct {
 	mu       sync.Mutex
 	channels map[string]string
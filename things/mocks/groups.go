@@ -47,7 +47,9 @@ func (grm *groupRepositoryMock) Save(ctx context.Context, group things.Group) (t
 		return things.Group{}, errors.ErrConflict
 	}
 
-	grm.groups[group.ID] = group
+	gr := group
+	gr.Revision = 1
+	grm.groups[group.ID] = gr
 	return group, nil
 }
 
@@ -58,6 +60,10 @@ func (grm *groupRepositoryMock) Update(ctx context.Context, group things.Group)
 	if !ok {
 		return things.Group{}, errors.ErrNotFound
 	}
+	if group.Revision != 0 && group.Revision != up.Revision {
+		return things.Group{}, things.ErrRevisionMismatch
+	}
+	up.Revision++
 	up.Name = group.Name
 	up.Description = group.Description
 	up.Metadata = group.Metadata
@@ -201,7 +207,7 @@ func (grm *groupRepositoryMock) RetrieveGroupThings(ctx context.Context, ownerID
 	first := uint64(pm.Offset)
 	last := first + uint64(pm.Limit)
 
-	if last > uint64(len(ths)) {
+	if pm.Limit == 0 || last > uint64(len(ths)) {
 		last = uint64(len(ths))
 	}
 
@@ -318,7 +324,7 @@ func (grm *groupRepositoryMock) RetrieveGroupChannels(ctx context.Context, owner
 	first := uint64(pm.Offset)
 	last := first + uint64(pm.Limit)
 
-	if last > uint64(len(chs)) {
+	if pm.Limit == 0 || last > uint64(len(chs)) {
 		last = uint64(len(chs))
 	}
 
@@ -339,5 +345,21 @@ func (grm *groupRepositoryMock) RetrieveGroupThingsByChannel(ctx context.Context
 }
 
 func (grm *groupRepositoryMock) RetrieveByAdmin(ctx context.Context, pm things.PageMetadata) (things.GroupPage, error) {
-	panic("not implemented")
+	grm.mu.Lock()
+	defer grm.mu.Unlock()
+
+	var items []things.Group
+	for _, g := range grm.groups {
+		if pm.Owner != "" && g.OwnerID != pm.Owner {
+			continue
+		}
+		items = append(items, g)
+	}
+
+	return things.GroupPage{
+		Groups: items,
+		PageMetadata: things.PageMetadata{
+			Total: uint64(len(items)),
+		},
+	}, nil
 }
@@ -0,0 +1,36 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"context"
+
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/things"
+)
+
+var _ things.ExternalKeyValidator = (*externalKeyValidatorMock)(nil)
+
+type externalKeyValidatorMock struct {
+	owner string
+	keys  map[string]string
+}
+
+// NewExternalKeyValidator creates validator of the external keys issued to
+// the things of the owner. Keys map the valid keys to the external IDs.
+func NewExternalKeyValidator(owner string, keys map[string]string) things.ExternalKeyValidator {
+	return &externalKeyValidatorMock{
+		owner: owner,
+		keys:  keys,
+	}
+}
+
+func (ekv *externalKeyValidatorMock) Validate(_ context.Context, key string) (string, string, error) {
+	extID, ok := ekv.keys[key]
+	if !ok {
+		return "", "", errors.ErrAuthentication
+	}
+
+	return ekv.owner, extID, nil
+}
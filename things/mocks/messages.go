@@ -0,0 +1,40 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/things"
+)
+
+var _ things.MessageCounter = (*messageCounterMock)(nil)
+
+type messageCounterMock struct {
+	mu       sync.Mutex
+	messages map[string][]time.Time
+}
+
+// NewMessageCounter creates counter of the messages stored per channel.
+// Messages map the channel IDs to the publishing times of their messages.
+func NewMessageCounter(messages map[string][]time.Time) things.MessageCounter {
+	return &messageCounterMock{messages: messages}
+}
+
+func (mc *messageCounterMock) CountMessages(_ context.Context, _, chanID string, from, to time.Time) (uint64, error) {
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+
+	var n uint64
+	for _, t := range mc.messages[chanID] {
+		if (!from.IsZero() && t.Before(from)) || (!to.IsZero() && !t.Before(to)) {
+			continue
+		}
+		n++
+	}
+
+	return n, nil
+}
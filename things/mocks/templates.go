@@ -0,0 +1,116 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/things"
+)
+
+var _ things.GroupTemplateRepository = (*groupTemplateRepositoryMock)(nil)
+
+type groupTemplateRepositoryMock struct {
+	mu sync.Mutex
+	// Map of group templates, template id as a key.
+	templates map[string]things.GroupTemplate
+	// Map of auto connect channels where group id is a key and channel ids are values.
+	autoConnects map[string][]string
+}
+
+// NewGroupTemplateRepository creates in-memory group template repository.
+func NewGroupTemplateRepository() things.GroupTemplateRepository {
+	return &groupTemplateRepositoryMock{
+		templates:    make(map[string]things.GroupTemplate),
+		autoConnects: make(map[string][]string),
+	}
+}
+
+func (gtrm *groupTemplateRepositoryMock) Save(_ context.Context, gt things.GroupTemplate) (things.GroupTemplate, error) {
+	gtrm.mu.Lock()
+	defer gtrm.mu.Unlock()
+
+	if _, ok := gtrm.templates[gt.ID]; ok {
+		return things.GroupTemplate{}, errors.ErrConflict
+	}
+
+	gtrm.templates[gt.ID] = gt
+	return gt, nil
+}
+
+func (gtrm *groupTemplateRepositoryMock) RetrieveByID(_ context.Context, id string) (things.GroupTemplate, error) {
+	gtrm.mu.Lock()
+	defer gtrm.mu.Unlock()
+
+	gt, ok := gtrm.templates[id]
+	if !ok {
+		return things.GroupTemplate{}, errors.ErrNotFound
+	}
+
+	return gt, nil
+}
+
+func (gtrm *groupTemplateRepositoryMock) RetrieveByOwner(_ context.Context, ownerID string, pm things.PageMetadata) (things.GroupTemplatesPage, error) {
+	gtrm.mu.Lock()
+	defer gtrm.mu.Unlock()
+
+	items := []things.GroupTemplate{}
+	for _, gt := range gtrm.templates {
+		if gt.OwnerID == ownerID {
+			items = append(items, gt)
+		}
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].ID < items[j].ID
+	})
+
+	total := uint64(len(items))
+	first := pm.Offset
+	if first > total {
+		first = total
+	}
+	last := total
+	if pm.Limit > 0 && first+pm.Limit < total {
+		last = first + pm.Limit
+	}
+
+	return things.GroupTemplatesPage{
+		Templates: items[first:last],
+		PageMetadata: things.PageMetadata{
+			Total:  total,
+			Offset: pm.Offset,
+			Limit:  pm.Limit,
+		},
+	}, nil
+}
+
+func (gtrm *groupTemplateRepositoryMock) Remove(_ context.Context, ownerID, id string) error {
+	gtrm.mu.Lock()
+	defer gtrm.mu.Unlock()
+
+	if gt, ok := gtrm.templates[id]; ok && gt.OwnerID == ownerID {
+		delete(gtrm.templates, id)
+	}
+
+	return nil
+}
+
+func (gtrm *groupTemplateRepositoryMock) SaveAutoConnects(_ context.Context, groupID, _ string, chIDs ...string) error {
+	gtrm.mu.Lock()
+	defer gtrm.mu.Unlock()
+
+	gtrm.autoConnects[groupID] = append(gtrm.autoConnects[groupID], chIDs...)
+	return nil
+}
+
+func (gtrm *groupTemplateRepositoryMock) RetrieveAutoConnects(_ context.Context, groupID string) ([]string, error) {
+	gtrm.mu.Lock()
+	defer gtrm.mu.Unlock()
+
+	return gtrm.autoConnects[groupID], nil
+}
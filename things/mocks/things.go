@@ -21,6 +21,7 @@ type thingRepositoryMock struct {
 	conns   chan Connection
 	tconns  map[string]map[string]things.Thing
 	things  map[string]things.Thing
+	skeys   map[string]things.SigningKey
 }
 
 // NewThingRepository creates in-memory thing repository.
@@ -29,6 +30,7 @@ func NewThingRepository(conns chan Connection) things.ThingRepository {
 		conns:  conns,
 		things: make(map[string]things.Thing),
 		tconns: make(map[string]map[string]things.Thing),
+		skeys:  make(map[string]things.SigningKey),
 	}
 	go func(conns chan Connection, repo *thingRepositoryMock) {
 		for conn := range conns {
@@ -52,13 +54,18 @@ func (trm *thingRepositoryMock) Save(_ context.Context, ths ...things.Thing) ([]
 			if th.Key == ths[i].Key {
 				return []things.Thing{}, errors.ErrConflict
 			}
+			if ths[i].ExternalID != "" && th.Owner == ths[i].Owner && th.ExternalID == ths[i].ExternalID {
+				return []things.Thing{}, errors.ErrConflict
+			}
 		}
 
 		trm.counter++
 		if ths[i].ID == "" {
 			ths[i].ID = fmt.Sprintf("%03d", trm.counter)
 		}
-		trm.things[key(ths[i].Owner, ths[i].ID)] = ths[i]
+		th := ths[i]
+		th.Revision = 1
+		trm.things[key(th.Owner, th.ID)] = th
 	}
 
 	return ths, nil
@@ -70,10 +77,20 @@ func (trm *thingRepositoryMock) Update(_ context.Context, thing things.Thing) er
 
 	dbKey := key(thing.Owner, thing.ID)
 
-	if _, ok := trm.things[dbKey]; !ok {
+	th, ok := trm.things[dbKey]
+	if !ok {
 		return errors.ErrNotFound
 	}
 
+	if thing.Revision != 0 && thing.Revision != th.Revision {
+		return things.ErrRevisionMismatch
+	}
+
+	if thing.Location == nil {
+		thing.Location = th.Location
+	}
+
+	thing.Revision = th.Revision + 1
 	trm.things[dbKey] = thing
 
 	return nil
@@ -115,6 +132,19 @@ func (trm *thingRepositoryMock) RetrieveByID(_ context.Context, id string) (thin
 	return things.Thing{}, errors.ErrNotFound
 }
 
+func (trm *thingRepositoryMock) RetrieveByExternalID(_ context.Context, owner, externalID string) (things.Thing, error) {
+	trm.mu.Lock()
+	defer trm.mu.Unlock()
+
+	for _, th := range trm.things {
+		if th.Owner == owner && th.ExternalID == externalID {
+			return th, nil
+		}
+	}
+
+	return things.Thing{}, errors.ErrNotFound
+}
+
 func (trm *thingRepositoryMock) RetrieveByOwner(_ context.Context, owner string, pm things.PageMetadata) (things.Page, error) {
 	trm.mu.Lock()
 	defer trm.mu.Unlock()
@@ -132,6 +162,10 @@ func (trm *thingRepositoryMock) RetrieveByOwner(_ context.Context, owner string,
 	// itself (see mocks/commons.go).
 	prefix := fmt.Sprintf("%s-", owner)
 	for k, v := range trm.things {
+		if !within(v, pm.Within) || !matches(v, pm.Query) {
+			continue
+		}
+
 		id := parseID(v.ID)
 
 		if strings.HasPrefix(k, prefix) && id >= first && pm.Limit == 0 {
@@ -319,6 +353,9 @@ func (trm *thingRepositoryMock) RetrieveByAdmin(ctx context.Context, pm things.P
 	i := uint64(0)
 	var ths []things.Thing
 	for _, th := range trm.things {
+		if (pm.Owner != "" && th.Owner != pm.Owner) || !strings.HasPrefix(th.Key, pm.KeyPrefix) || !within(th, pm.Within) || !matches(th, pm.Query) {
+			continue
+		}
 		if i >= pm.Offset && i < pm.Offset+pm.Limit {
 			ths = append(ths, th)
 		}
@@ -337,6 +374,52 @@ func (trm *thingRepositoryMock) RetrieveByAdmin(ctx context.Context, pm things.P
 	return page, nil
 }
 
+func (trm *thingRepositoryMock) SaveSigningKey(_ context.Context, sk things.SigningKey) error {
+	trm.mu.Lock()
+	defer trm.mu.Unlock()
+
+	if !trm.exists(sk.ThingID) {
+		return errors.ErrNotFound
+	}
+
+	trm.skeys[sk.ThingID] = sk
+	return nil
+}
+
+func (trm *thingRepositoryMock) RetrieveSigningKey(_ context.Context, thingID string) (things.SigningKey, error) {
+	trm.mu.Lock()
+	defer trm.mu.Unlock()
+
+	sk, ok := trm.skeys[thingID]
+	if !ok {
+		return things.SigningKey{}, errors.ErrNotFound
+	}
+
+	return sk, nil
+}
+
+func (trm *thingRepositoryMock) RemoveSigningKey(_ context.Context, thingID string) error {
+	trm.mu.Lock()
+	defer trm.mu.Unlock()
+
+	if !trm.exists(thingID) {
+		return errors.ErrNotFound
+	}
+
+	delete(trm.skeys, thingID)
+	return nil
+}
+
+func (trm *thingRepositoryMock) exists(id string) bool {
+	for _, th := range trm.things {
+		if th.ID == id {
+			return true
+		}
+	}
+
+	return false
+}
+
 type thingCacheMock struct {
 	mu     sync.Mutex
 	things map[string]string
@@ -382,3 +465,20 @@ func (tcm *thingCacheMock) Remove(_ context.Context, id string) error {
 
 	return nil
 }
+
+func within(th things.Thing, area *things.Area) bool {
+	if area == nil {
+		return true
+	}
+
+	return th.Location != nil && area.Contains(*th.Location)
+}
+
+func matches(th things.Thing, query string) bool {
+	if query == "" {
+		return true
+	}
+
+	text := strings.ToLower(fmt.Sprintf("%s %s %v", th.Name, th.ExternalID, th.Metadata))
+	return strings.Contains(text, strings.ToLower(query))
+}
@@ -0,0 +1,76 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package things
+
+import (
+	"context"
+
+	"github.com/MainfluxLabs/mainflux"
+	"github.com/MainfluxLabs/mainflux/auth"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var errMigrateOwners = errors.New("failed to migrate owners of things and channels")
+
+// MigrateOwners registers the owner policies of the things and the channels
+// created before the policies were introduced, so that their ownership
+// can't be claimed by other users. Objects whose owners are already
+// registered are skipped, so the migration is run on each start.
+func MigrateOwners(ctx context.Context, ac mainflux.AuthServiceClient, things ThingRepository, channels ChannelRepository) error {
+	ths, err := things.RetrieveAll(ctx)
+	if err != nil {
+		return errors.Wrap(errMigrateOwners, err)
+	}
+
+	for _, th := range ths {
+		if err := migrateOwner(ctx, ac, th.Owner, auth.ThingSubject, th.ID); err != nil {
+			return errors.Wrap(errMigrateOwners, err)
+		}
+	}
+
+	chs, err := channels.RetrieveAll(ctx)
+	if err != nil {
+		return errors.Wrap(errMigrateOwners, err)
+	}
+
+	for _, ch := range chs {
+		if err := migrateOwner(ctx, ac, ch.Owner, auth.ChannelSubject, ch.ID); err != nil {
+			return errors.Wrap(errMigrateOwners, err)
+		}
+	}
+
+	return nil
+}
+
+func migrateOwner(ctx context.Context, ac mainflux.AuthServiceClient, owner, subject, object string) error {
+	req := &mainflux.OwnershipReq{
+		Subject: subject,
+		Object:  object,
+		OwnerID: owner,
+	}
+
+	// Object owned by another user is left as it is.
+	if _, err := ac.ClaimOwnership(ctx, req); err != nil && status.Code(err) != codes.PermissionDenied {
+		return err
+	}
+
+	return nil
+}
+
+// claimOwnership registers the owner policy of a newly created thing or channel.
+func claimOwnership(ctx context.Context, ac mainflux.AuthServiceClient, owner, subject, object string) error {
+	req := &mainflux.OwnershipReq{
+		Subject: subject,
+		Object:  object,
+		OwnerID: owner,
+	}
+
+	if _, err := ac.ClaimOwnership(ctx, req); err != nil {
+		return errors.Wrap(errors.ErrCreateEntity, err)
+	}
+
+	return nil
+}
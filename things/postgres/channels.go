@@ -10,6 +10,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/MainfluxLabs/mainflux/internal/dbutil"
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
@@ -17,6 +18,7 @@ import (
 	"github.com/gofrs/uuid"
 	"github.com/jackc/pgerrcode"
 	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jmoiron/sqlx"
 )
 
 const ownerDbId = "owner"
@@ -79,7 +81,10 @@ func (cr channelRepository) Save(ctx context.Context, channels ...things.Channel
 }
 
 func (cr channelRepository) Update(ctx context.Context, channel things.Channel) error {
-	q := `UPDATE channels SET name = :name, metadata = :metadata WHERE owner = :owner AND id = :id;`
+	q := `UPDATE channels SET name = :name, metadata = :metadata, revision = revision + 1 WHERE owner = :owner AND id = :id`
+	if channel.Revision != 0 {
+		q = fmt.Sprintf("%s AND revision = :revision", q)
+	}
 
 	dbch := toDBChannel(channel)
 
@@ -104,14 +109,14 @@ func (cr channelRepository) Update(ctx context.Context, channel things.Channel)
 	}
 
 	if cnt == 0 {
-		return errors.ErrNotFound
+		return revisionError(ctx, cr.db, "channels", channel.ID, channel.Revision)
 	}
 
 	return nil
 }
 
 func (cr channelRepository) RetrieveByID(ctx context.Context, id string) (things.Channel, error) {
-	q := `SELECT name, metadata, owner FROM channels WHERE id = $1;`
+	q := `SELECT name, metadata, owner, revision FROM channels WHERE id = $1;`
 
 	dbch := dbChannel{
 		ID: id,
@@ -147,17 +152,12 @@ func (cr channelRepository) RetrieveAll(ctx context.Context) ([]things.Channel,
 }
 
 func (cr channelRepository) RetrieveByAdmin(ctx context.Context, pm things.PageMetadata) (things.ChannelsPage, error) {
-	return cr.retrieve(ctx, "", false, pm)
+	return cr.retrieve(ctx, pm.Owner, false, pm)
 }
 
 func (cr channelRepository) RetrieveByThing(ctx context.Context, owner, thID string) (things.Channel, error) {
-	// Verify if UUID format is valid to avoid internal Postgres error
-	if _, err := uuid.FromString(thID); err != nil {
-		return things.Channel{}, errors.Wrap(errors.ErrNotFound, err)
-	}
-
 	var q string
-	q = fmt.Sprintf(`SELECT id, name, metadata FROM channels ch
+	q = fmt.Sprintf(`SELECT id, name, metadata, revision FROM channels ch
 		        INNER JOIN connections conn
 		        ON ch.id = conn.channel_id
 		        WHERE ch.owner = :owner AND conn.thing_id = :thing;`)
@@ -190,11 +190,6 @@ func (cr channelRepository) RetrieveConns(ctx context.Context, thID string, pm t
 	oq := getConnOrderQuery(pm.Order, "ch")
 	dq := getDirQuery(pm.Dir)
 
-	// Verify if UUID format is valid to avoid internal Postgres error
-	if _, err := uuid.FromString(thID); err != nil {
-		return things.ChannelsPage{}, errors.Wrap(errors.ErrNotFound, err)
-	}
-
 	olq := "LIMIT :limit OFFSET :offset"
 	if pm.Limit == 0 {
 		olq = ""
@@ -409,6 +404,216 @@ func (cr channelRepository) RetrieveAllConnections(ctx context.Context) ([]thing
 	return connections, nil
 }
 
+func (cr channelRepository) RetrieveConnections(ctx context.Context, owner string, cf things.ConnectionsFilter, pm things.PageMetadata) (things.ConnectionsPage, error) {
+	// Verify if UUID format is valid to avoid internal Postgres error
+	if cf.GroupID != "" {
+		if _, err := uuid.FromString(cf.GroupID); err != nil {
+			return things.ConnectionsPage{}, errors.Wrap(errors.ErrNotFound, err)
+		}
+	}
+
+	var query []string
+	if owner != "" {
+		query = append(query, "channel_owner = :owner")
+	}
+	if cf.ThingID != "" {
+		query = append(query, "thing_id = :thing_id")
+	}
+	if cf.ChannelID != "" {
+		query = append(query, "channel_id = :channel_id")
+	}
+	if cf.GroupID != "" {
+		query = append(query, `(channel_id IN (SELECT channel_id FROM group_channels WHERE group_id = :group_id)
+			OR thing_id IN (SELECT thing_id FROM group_things WHERE group_id = :group_id))`)
+	}
+
+	var whereClause string
+	if len(query) > 0 {
+		whereClause = fmt.Sprintf(" WHERE %s", strings.Join(query, " AND "))
+	}
+
+	olq := "LIMIT :limit OFFSET :offset"
+	if pm.Limit == 0 {
+		olq = ""
+	}
+
+	q := fmt.Sprintf(`SELECT channel_id, channel_owner, thing_id, thing_owner FROM connections %s
+		  ORDER BY channel_id, thing_id %s;`, whereClause, olq)
+
+	params := map[string]interface{}{
+		"owner":      owner,
+		"thing_id":   cf.ThingID,
+		"channel_id": cf.ChannelID,
+		"group_id":   cf.GroupID,
+		"limit":      pm.Limit,
+		"offset":     pm.Offset,
+	}
+	rows, err := cr.db.NamedQueryContext(ctx, q, params)
+	if err != nil {
+		return things.ConnectionsPage{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+	defer rows.Close()
+
+	items := []things.Connection{}
+	for rows.Next() {
+		dbco := dbConn{}
+		if err := rows.StructScan(&dbco); err != nil {
+			return things.ConnectionsPage{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+		}
+		items = append(items, toConnection(dbco))
+	}
+
+	cq := fmt.Sprintf(`SELECT COUNT(*) FROM connections %s;`, whereClause)
+
+	total, err := total(ctx, cr.db, cq, params)
+	if err != nil {
+		return things.ConnectionsPage{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+
+	page := things.ConnectionsPage{
+		Connections: items,
+		PageMetadata: things.PageMetadata{
+			Total:  total,
+			Offset: pm.Offset,
+			Limit:  pm.Limit,
+		},
+	}
+
+	return page, nil
+}
+
+func (cr channelRepository) UpdateWithVersion(ctx context.Context, channel things.Channel, createdAt time.Time) error {
+	tx, err := cr.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(errors.ErrUpdateEntity, err)
+	}
+
+	if err := updateWithVersion(ctx, tx, channel, createdAt); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.Wrap(errors.ErrUpdateEntity, err)
+	}
+
+	return nil
+}
+
+// updateWithVersion locks the channel row, so the concurrent updates record
+// the versions of the channel one after another.
+func updateWithVersion(ctx context.Context, tx *sqlx.Tx, channel things.Channel, createdAt time.Time) error {
+	lq := `SELECT revision FROM channels WHERE owner = $1 AND id = $2 FOR UPDATE;`
+
+	var revision uint64
+	if err := tx.QueryRowxContext(ctx, lq, channel.Owner, channel.ID).Scan(&revision); err != nil {
+		pgErr, ok := err.(*pgconn.PgError)
+		if err == sql.ErrNoRows || ok && pgerrcode.InvalidTextRepresentation == pgErr.Code {
+			return errors.ErrNotFound
+		}
+		return errors.Wrap(errors.ErrUpdateEntity, err)
+	}
+	if channel.Revision != 0 && channel.Revision != revision {
+		return things.ErrRevisionMismatch
+	}
+
+	vq := `INSERT INTO channel_versions (channel_id, channel_owner, version, name, metadata, created_at)
+		  SELECT id, owner, COALESCE((SELECT MAX(version) FROM channel_versions WHERE channel_id = :id), 0) + 1, name, metadata, :created_at
+		  FROM channels WHERE id = :id;`
+
+	params := map[string]interface{}{
+		"id":         channel.ID,
+		"created_at": createdAt,
+	}
+	if _, err := tx.NamedExecContext(ctx, vq, params); err != nil {
+		pgErr, ok := err.(*pgconn.PgError)
+		if ok && pgErr.Code == pgerrcode.UniqueViolation {
+			return errors.Wrap(errors.ErrConflict, err)
+		}
+		return errors.Wrap(errors.ErrCreateEntity, err)
+	}
+
+	uq := `UPDATE channels SET name = :name, metadata = :metadata, revision = revision + 1 WHERE owner = :owner AND id = :id;`
+	if _, err := tx.NamedExecContext(ctx, uq, toDBChannel(channel)); err != nil {
+		pgErr, ok := err.(*pgconn.PgError)
+		if ok {
+			switch pgErr.Code {
+			case pgerrcode.InvalidTextRepresentation:
+				return errors.Wrap(errors.ErrMalformedEntity, err)
+			case pgerrcode.StringDataRightTruncationDataException:
+				return errors.Wrap(errors.ErrMalformedEntity, err)
+			}
+		}
+		return errors.Wrap(errors.ErrUpdateEntity, err)
+	}
+
+	return nil
+}
+
+func (cr channelRepository) RetrieveVersion(ctx context.Context, chID string, version uint64) (things.ChannelVersion, error) {
+	q := `SELECT channel_id, channel_owner, version, name, metadata, created_at FROM channel_versions
+		  WHERE channel_id = $1 AND version = $2;`
+
+	var dbcv dbChannelVersion
+	if err := cr.db.QueryRowxContext(ctx, q, chID, version).StructScan(&dbcv); err != nil {
+		pgErr, ok := err.(*pgconn.PgError)
+		if err == sql.ErrNoRows || ok && pgerrcode.InvalidTextRepresentation == pgErr.Code {
+			return things.ChannelVersion{}, errors.ErrNotFound
+		}
+		return things.ChannelVersion{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+
+	return toChannelVersion(dbcv), nil
+}
+
+func (cr channelRepository) RetrieveVersions(ctx context.Context, chID string, pm things.PageMetadata) (things.ChannelVersionsPage, error) {
+	olq := "LIMIT :limit OFFSET :offset"
+	if pm.Limit == 0 {
+		olq = ""
+	}
+
+	q := fmt.Sprintf(`SELECT channel_id, channel_owner, version, name, metadata, created_at FROM channel_versions
+		  WHERE channel_id = :channel_id ORDER BY version DESC %s;`, olq)
+
+	params := map[string]interface{}{
+		"channel_id": chID,
+		"limit":      pm.Limit,
+		"offset":     pm.Offset,
+	}
+	rows, err := cr.db.NamedQueryContext(ctx, q, params)
+	if err != nil {
+		return things.ChannelVersionsPage{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+	defer rows.Close()
+
+	items := []things.ChannelVersion{}
+	for rows.Next() {
+		var dbcv dbChannelVersion
+		if err := rows.StructScan(&dbcv); err != nil {
+			return things.ChannelVersionsPage{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+		}
+		items = append(items, toChannelVersion(dbcv))
+	}
+
+	cq := `SELECT COUNT(*) FROM channel_versions WHERE channel_id = :channel_id;`
+
+	total, err := total(ctx, cr.db, cq, params)
+	if err != nil {
+		return things.ChannelVersionsPage{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+
+	page := things.ChannelVersionsPage{
+		Versions: items,
+		PageMetadata: things.PageMetadata{
+			Total:  total,
+			Offset: pm.Offset,
+			Limit:  pm.Limit,
+		},
+	}
+
+	return page, nil
+}
+
 func (cr channelRepository) retrieve(ctx context.Context, owner string, includeOwner bool, pm things.PageMetadata) (things.ChannelsPage, error) {
 	ownq := dbutil.GetOwnerQuery(owner, ownerDbId)
 	nq, name := dbutil.GetNameQuery(pm.Name)
@@ -439,7 +644,7 @@ func (cr channelRepository) retrieve(ctx context.Context, owner string, includeO
 		olq = ""
 	}
 
-	q := fmt.Sprintf(`SELECT id, name, metadata FROM channels %s ORDER BY %s %s %s;`, whereClause, oq, dq, olq)
+	q := fmt.Sprintf(`SELECT id, owner, name, metadata FROM channels %s ORDER BY %s %s %s;`, whereClause, oq, dq, olq)
 
 	if includeOwner {
 		q = "SELECT id, name, owner, metadata FROM channels;"
@@ -533,6 +738,7 @@ type dbChannel struct {
 	Owner    string     `db:"owner"`
 	Name     string     `db:"name"`
 	Metadata dbMetadata `db:"metadata"`
+	Revision uint64     `db:"revision"`
 }
 
 func toDBChannel(ch things.Channel) dbChannel {
@@ -541,6 +747,7 @@ func toDBChannel(ch things.Channel) dbChannel {
 		Owner:    ch.Owner,
 		Name:     ch.Name,
 		Metadata: ch.Metadata,
+		Revision: ch.Revision,
 	}
 }
 
@@ -550,6 +757,27 @@ func toChannel(ch dbChannel) things.Channel {
 		Owner:    ch.Owner,
 		Name:     ch.Name,
 		Metadata: ch.Metadata,
+		Revision: ch.Revision,
+	}
+}
+
+type dbChannelVersion struct {
+	ChannelID    string     `db:"channel_id"`
+	ChannelOwner string     `db:"channel_owner"`
+	Version      uint64     `db:"version"`
+	Name         string     `db:"name"`
+	Metadata     dbMetadata `db:"metadata"`
+	CreatedAt    time.Time  `db:"created_at"`
+}
+
+func toChannelVersion(cv dbChannelVersion) things.ChannelVersion {
+	return things.ChannelVersion{
+		ChannelID: cv.ChannelID,
+		Owner:     cv.ChannelOwner,
+		Version:   cv.Version,
+		Name:      cv.Name,
+		Metadata:  cv.Metadata,
+		CreatedAt: cv.CreatedAt,
 	}
 }
 
@@ -596,6 +824,27 @@ func getDirQuery(dir string) string {
 	}
 }
 
+// revisionError returns the cause of the update of the entity identified by
+// id which affected no rows. The conditional update fails due to the stale
+// revision if the entity still exists.
+func revisionError(ctx context.Context, db Database, table, id string, revision uint64) error {
+	if revision == 0 {
+		return errors.ErrNotFound
+	}
+
+	q := fmt.Sprintf(`SELECT COUNT(*) FROM %s WHERE id = :id;`, table)
+	cnt, err := total(ctx, db, q, map[string]interface{}{"id": id})
+	if err != nil {
+		return errors.Wrap(errors.ErrUpdateEntity, err)
+	}
+
+	if cnt == 0 {
+		return errors.ErrNotFound
+	}
+
+	return things.ErrRevisionMismatch
+}
+
 func total(ctx context.Context, db Database, query string, params interface{}) (uint64, error) {
 	rows, err := db.NamedQueryContext(ctx, query, params)
 	if err != nil {
@@ -9,6 +9,8 @@ import (
 	"testing"
 
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/idprefix"
+	"github.com/MainfluxLabs/mainflux/pkg/ksuid"
 	"github.com/MainfluxLabs/mainflux/things"
 	"github.com/MainfluxLabs/mainflux/things/postgres"
 	"github.com/stretchr/testify/assert"
@@ -425,11 +427,11 @@ func TestRetrieveByThing(t *testing.T) {
 			channel: things.Channel{},
 			err:     nil,
 		},
-		"retrieve channel with malformed UUID": {
+		"retrieve channel by thing with non-UUID ID": {
 			owner:   email,
 			thID:    wrongValue,
 			channel: things.Channel{},
-			err:     errors.ErrNotFound,
+			err:     nil,
 		},
 	}
 
@@ -462,7 +464,7 @@ func TestChannelRemoval(t *testing.T) {
 		"remove non-existing channel": {
 			owner: email,
 			chID:  wrongValue,
-			err:   errors.ErrRemoveEntity,
+			err:   nil,
 		},
 		"remove channel": {
 			owner: email,
@@ -943,3 +945,32 @@ func testSortChannels(t *testing.T, pm things.PageMetadata, chs []things.Channel
 		break
 	}
 }
+
+func TestConnectPrefixedIDs(t *testing.T) {
+	email := "prefixed-ids@example.com"
+	dbMiddleware := postgres.NewDatabase(db)
+	thingRepo := postgres.NewThingRepository(dbMiddleware)
+	chanRepo := postgres.NewChannelRepository(dbMiddleware)
+
+	thID, err := idprefix.New(ksuid.New(), "thg_").ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	thKey, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	_, err = thingRepo.Save(context.Background(), things.Thing{ID: thID, Owner: email, Key: thKey})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	chID, err := idprefix.New(ksuid.New(), "chn_").ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	_, err = chanRepo.Save(context.Background(), things.Channel{ID: chID, Owner: email})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	err = chanRepo.Connect(context.Background(), email, chID, []string{thID})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	err = chanRepo.HasThingByID(context.Background(), chID, thID)
+	assert.Nil(t, err, fmt.Sprintf("expected thing %s connected to channel %s got %s", thID, chID, err))
+
+	ch, err := chanRepo.RetrieveByThing(context.Background(), email, thID)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.Equal(t, chID, ch.ID, fmt.Sprintf("expected channel %s got %s", chID, ch.ID))
+}
@@ -71,8 +71,12 @@ func (gr groupRepository) Save(ctx context.Context, g things.Group) (things.Grou
 }
 
 func (gr groupRepository) Update(ctx context.Context, g things.Group) (things.Group, error) {
-	q := `UPDATE groups SET name = :name, description = :description, metadata = :metadata, updated_at = :updated_at WHERE id = :id
-		  RETURNING id, name, owner_id, description, metadata, created_at, updated_at`
+	q := `UPDATE groups SET name = :name, description = :description, metadata = :metadata, updated_at = :updated_at, revision = revision + 1
+		  WHERE id = :id`
+	if g.Revision != 0 {
+		q = fmt.Sprintf("%s AND revision = :revision", q)
+	}
+	q = fmt.Sprintf("%s RETURNING id, name, owner_id, description, metadata, revision, created_at, updated_at", q)
 
 	dbu, err := toDBGroup(g)
 	if err != nil {
@@ -96,7 +100,9 @@ func (gr groupRepository) Update(ctx context.Context, g things.Group) (things.Gr
 	}
 
 	defer row.Close()
-	row.Next()
+	if !row.Next() {
+		return things.Group{}, revisionError(ctx, gr.db, "groups", g.ID, g.Revision)
+	}
 	dbu = dbGroup{}
 	if err := row.StructScan(&dbu); err != nil {
 		return g, errors.Wrap(errors.ErrUpdateEntity, err)
@@ -159,7 +165,7 @@ func (gr groupRepository) RetrieveByID(ctx context.Context, id string) (things.G
 	dbu := dbGroup{
 		ID: id,
 	}
-	q := `SELECT id, name, owner_id, description, metadata, created_at, updated_at FROM groups WHERE id = $1`
+	q := `SELECT id, name, owner_id, description, metadata, revision, created_at, updated_at FROM groups WHERE id = $1`
 	if err := gr.db.QueryRowxContext(ctx, q, id).StructScan(&dbu); err != nil {
 		if err == sql.ErrNoRows {
 			return things.Group{}, errors.Wrap(errors.ErrNotFound, err)
@@ -224,7 +230,7 @@ func (gr groupRepository) RetrieveByOwner(ctx context.Context, ownerID string, p
 }
 
 func (gr groupRepository) RetrieveByAdmin(ctx context.Context, pm things.PageMetadata) (things.GroupPage, error) {
-	return gr.retrieve(ctx, "", pm)
+	return gr.retrieve(ctx, pm.Owner, pm)
 }
 
 func (gr groupRepository) RetrieveGroupThings(ctx context.Context, ownerID, groupID string, pm things.PageMetadata) (things.GroupThingsPage, error) {
@@ -241,7 +247,7 @@ func (gr groupRepository) RetrieveGroupThings(ctx context.Context, ownerID, grou
 	var q, qc string
 	switch pm.Unassigned {
 	case true:
-		q = fmt.Sprintf(`SELECT t.id, t.owner, t.name, t.metadata, t.key
+		q = fmt.Sprintf(`SELECT t.id, t.owner, t.name, t.metadata, t.key, t.external_id, t.latitude, t.longitude
 			FROM  things t
 			WHERE t.owner = :owner_id
 			AND t.id NOT IN (SELECT gr.thing_id FROM group_things gr)
@@ -250,7 +256,7 @@ func (gr groupRepository) RetrieveGroupThings(ctx context.Context, ownerID, grou
 			WHERE t.owner = :owner_id
 			AND t.id NOT IN (SELECT gr.thing_id FROM group_things gr) %s;`, mq)
 	default:
-		q = fmt.Sprintf(`SELECT t.id, t.owner, t.name, t.metadata, t.key
+		q = fmt.Sprintf(`SELECT t.id, t.owner, t.name, t.metadata, t.key, t.external_id, t.latitude, t.longitude
 			FROM group_things gr, things t
 			WHERE gr.group_id = :group_id and gr.thing_id = t.id
 			%s %s;`, mq, olq)
@@ -314,7 +320,7 @@ func (gr groupRepository) RetrieveGroupThingsByChannel(ctx context.Context, grou
 		olq = ""
 	}
 
-	q := fmt.Sprintf(`SELECT t.id, t.owner, t.name, t.metadata, t.key
+	q := fmt.Sprintf(`SELECT t.id, t.owner, t.name, t.metadata, t.key, t.external_id, t.latitude, t.longitude
 		FROM group_things gr, things t, group_channels gc
 		WHERE gr.group_id = :group_id and gr.thing_id = t.id and gc.group_id = gr.group_id and gc.channel_id = :channel_id and t.id
 		NOT IN (SELECT c.thing_id FROM connections c)
@@ -791,6 +797,7 @@ type dbGroup struct {
 	Name        string     `db:"name"`
 	Description string     `db:"description"`
 	Metadata    dbMetadata `db:"metadata"`
+	Revision    uint64     `db:"revision"`
 	CreatedAt   time.Time  `db:"created_at"`
 	UpdatedAt   time.Time  `db:"updated_at"`
 }
@@ -802,6 +809,7 @@ func toDBGroup(g things.Group) (dbGroup, error) {
 		OwnerID:     g.OwnerID,
 		Description: g.Description,
 		Metadata:    dbMetadata(g.Metadata),
+		Revision:    g.Revision,
 		CreatedAt:   g.CreatedAt,
 		UpdatedAt:   g.UpdatedAt,
 	}, nil
@@ -814,6 +822,7 @@ func toGroup(dbu dbGroup) (things.Group, error) {
 		OwnerID:     dbu.OwnerID,
 		Description: dbu.Description,
 		Metadata:    things.GroupMetadata(dbu.Metadata),
+		Revision:    dbu.Revision,
 		UpdatedAt:   dbu.UpdatedAt,
 		CreatedAt:   dbu.CreatedAt,
 	}, nil
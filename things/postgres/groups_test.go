@@ -673,7 +673,7 @@ func TestRetrieveGroupThings(t *testing.T) {
 
 	for desc, tc := range cases {
 		ths, err := groupRepo.RetrieveGroupThings(context.Background(), tc.ownerID, tc.groupID, tc.pagemeta)
-		assert.Equal(t, tc.things, ths.Things, fmt.Sprintf("%s: expected %s got %s\n", desc, tc.things, ths.Things))
+		assert.Equal(t, tc.things, ths.Things, fmt.Sprintf("%s: expected %v got %v\n", desc, tc.things, ths.Things))
 		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", desc, tc.err, err))
 	}
 }
@@ -888,7 +888,7 @@ func TestRetrieveGroupChannels(t *testing.T) {
 
 	for desc, tc := range cases {
 		chs, err := groupRepo.RetrieveGroupChannels(context.Background(), tc.ownerID, tc.groupID, tc.pagemeta)
-		assert.Equal(t, tc.channels, chs.Channels, fmt.Sprintf("%s: expected %s got %s\n", desc, tc.channels, chs.Channels))
+		assert.Equal(t, tc.channels, chs.Channels, fmt.Sprintf("%s: expected %v got %v\n", desc, tc.channels, chs.Channels))
 		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", desc, tc.err, err))
 	}
 }
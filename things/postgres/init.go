@@ -152,6 +152,152 @@ func migrateDB(db *sqlx.DB) error {
 					"DROP TABLE group_things",
 				},
 			},
+			{
+				Id: "things_8",
+				Up: []string{
+					`ALTER TABLE IF EXISTS things ADD COLUMN IF NOT EXISTS external_id VARCHAR(254)`,
+					`ALTER TABLE IF EXISTS things ADD CONSTRAINT things_owner_external_id_key UNIQUE (owner, external_id)`,
+				},
+				Down: []string{
+					"ALTER TABLE IF EXISTS things DROP CONSTRAINT IF EXISTS things_owner_external_id_key",
+					"ALTER TABLE IF EXISTS things DROP COLUMN IF EXISTS external_id",
+				},
+			},
+			{
+				Id: "things_9",
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS channel_versions (
+						channel_id    UUID NOT NULL,
+						channel_owner VARCHAR(254) NOT NULL,
+						version       BIGINT NOT NULL,
+						name          VARCHAR(1024),
+						metadata      JSONB,
+						created_at    TIMESTAMPTZ,
+						FOREIGN KEY (channel_id, channel_owner) REFERENCES channels (id, owner) ON DELETE CASCADE ON UPDATE CASCADE,
+						PRIMARY KEY (channel_id, version)
+					)`,
+				},
+				Down: []string{
+					"DROP TABLE channel_versions",
+				},
+			},
+			{
+				Id: "things_10",
+				Up: []string{
+					`ALTER TABLE IF EXISTS things ADD COLUMN IF NOT EXISTS signing_algorithm VARCHAR(32)`,
+					`ALTER TABLE IF EXISTS things ADD COLUMN IF NOT EXISTS signing_key BYTEA`,
+				},
+				Down: []string{
+					"ALTER TABLE IF EXISTS things DROP COLUMN IF EXISTS signing_key",
+					"ALTER TABLE IF EXISTS things DROP COLUMN IF EXISTS signing_algorithm",
+				},
+			},
+			{
+				Id: "things_11",
+				Up: []string{
+					`CREATE EXTENSION IF NOT EXISTS cube`,
+					`CREATE EXTENSION IF NOT EXISTS earthdistance`,
+					`ALTER TABLE IF EXISTS things ADD COLUMN IF NOT EXISTS latitude DOUBLE PRECISION`,
+					`ALTER TABLE IF EXISTS things ADD COLUMN IF NOT EXISTS longitude DOUBLE PRECISION`,
+					`CREATE INDEX IF NOT EXISTS things_location_idx ON things USING gist (ll_to_earth(latitude, longitude))
+						WHERE latitude IS NOT NULL AND longitude IS NOT NULL`,
+				},
+				Down: []string{
+					"DROP INDEX IF EXISTS things_location_idx",
+					"ALTER TABLE IF EXISTS things DROP COLUMN IF EXISTS longitude",
+					"ALTER TABLE IF EXISTS things DROP COLUMN IF EXISTS latitude",
+				},
+			},
+			{
+				Id: "things_12",
+				Up: []string{
+					`CREATE EXTENSION IF NOT EXISTS pg_trgm`,
+					`CREATE INDEX IF NOT EXISTS things_search_idx ON things USING gin (` + searchExpr + ` gin_trgm_ops)`,
+				},
+				Down: []string{
+					"DROP INDEX IF EXISTS things_search_idx",
+				},
+			},
+			{
+				Id: "things_13",
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS group_templates (
+						id          UUID PRIMARY KEY,
+						owner_id    UUID NOT NULL,
+						name        VARCHAR(254) NOT NULL,
+						description VARCHAR(1024),
+						channels    JSONB,
+						created_at  TIMESTAMPTZ
+					)`,
+					`CREATE TABLE IF NOT EXISTS group_auto_connects (
+						group_id      UUID NOT NULL,
+						channel_id    UUID NOT NULL,
+						channel_owner VARCHAR(254) NOT NULL,
+						FOREIGN KEY (group_id) REFERENCES groups (id) ON DELETE CASCADE ON UPDATE CASCADE,
+						FOREIGN KEY (channel_id, channel_owner) REFERENCES channels (id, owner) ON DELETE CASCADE ON UPDATE CASCADE,
+						PRIMARY KEY (group_id, channel_id)
+					)`,
+				},
+				Down: []string{
+					"DROP TABLE group_auto_connects",
+					"DROP TABLE group_templates",
+				},
+			},
+			{
+				Id: "things_14",
+				Up: []string{
+					`ALTER TABLE IF EXISTS things ADD COLUMN IF NOT EXISTS revision BIGINT NOT NULL DEFAULT 1`,
+					`ALTER TABLE IF EXISTS channels ADD COLUMN IF NOT EXISTS revision BIGINT NOT NULL DEFAULT 1`,
+					`ALTER TABLE IF EXISTS groups ADD COLUMN IF NOT EXISTS revision BIGINT NOT NULL DEFAULT 1`,
+				},
+				Down: []string{
+					"ALTER TABLE IF EXISTS groups DROP COLUMN IF EXISTS revision",
+					"ALTER TABLE IF EXISTS channels DROP COLUMN IF EXISTS revision",
+					"ALTER TABLE IF EXISTS things DROP COLUMN IF EXISTS revision",
+				},
+			},
+			{
+				// Channel and thing IDs aren't necessarily UUIDs.
+				Id: "things_15",
+				Up: []string{
+					`ALTER TABLE IF EXISTS connections DROP CONSTRAINT IF EXISTS connections_channel_id_channel_owner_fkey`,
+					`ALTER TABLE IF EXISTS connections DROP CONSTRAINT IF EXISTS connections_thing_id_thing_owner_fkey`,
+					`ALTER TABLE IF EXISTS channel_versions DROP CONSTRAINT IF EXISTS channel_versions_channel_id_channel_owner_fkey`,
+					`ALTER TABLE IF EXISTS group_auto_connects DROP CONSTRAINT IF EXISTS group_auto_connects_channel_id_channel_owner_fkey`,
+					`ALTER TABLE IF EXISTS things ALTER COLUMN id TYPE VARCHAR(254)`,
+					`ALTER TABLE IF EXISTS channels ALTER COLUMN id TYPE VARCHAR(254)`,
+					`ALTER TABLE IF EXISTS connections ALTER COLUMN channel_id TYPE VARCHAR(254), ALTER COLUMN thing_id TYPE VARCHAR(254)`,
+					`ALTER TABLE IF EXISTS group_things ALTER COLUMN thing_id TYPE VARCHAR(254)`,
+					`ALTER TABLE IF EXISTS group_channels ALTER COLUMN channel_id TYPE VARCHAR(254)`,
+					`ALTER TABLE IF EXISTS channel_versions ALTER COLUMN channel_id TYPE VARCHAR(254)`,
+					`ALTER TABLE IF EXISTS group_auto_connects ALTER COLUMN channel_id TYPE VARCHAR(254)`,
+					`ALTER TABLE IF EXISTS connections ADD CONSTRAINT connections_channel_id_channel_owner_fkey
+						FOREIGN KEY (channel_id, channel_owner) REFERENCES channels (id, owner) ON DELETE CASCADE ON UPDATE CASCADE`,
+					`ALTER TABLE IF EXISTS connections ADD CONSTRAINT connections_thing_id_thing_owner_fkey
+						FOREIGN KEY (thing_id, thing_owner) REFERENCES things (id, owner) ON DELETE CASCADE ON UPDATE CASCADE`,
+					`ALTER TABLE IF EXISTS channel_versions ADD CONSTRAINT channel_versions_channel_id_channel_owner_fkey
+						FOREIGN KEY (channel_id, channel_owner) REFERENCES channels (id, owner) ON DELETE CASCADE ON UPDATE CASCADE`,
+					`ALTER TABLE IF EXISTS group_auto_connects ADD CONSTRAINT group_auto_connects_channel_id_channel_owner_fkey
+						FOREIGN KEY (channel_id, channel_owner) REFERENCES channels (id, owner) ON DELETE CASCADE ON UPDATE CASCADE`,
+				},
+				Down: []string{
+					"ALTER TABLE IF EXISTS connections DROP CONSTRAINT IF EXISTS connections_channel_id_channel_owner_fkey",
+					"ALTER TABLE IF EXISTS connections DROP CONSTRAINT IF EXISTS connections_thing_id_thing_owner_fkey",
+					"ALTER TABLE IF EXISTS channel_versions DROP CONSTRAINT IF EXISTS channel_versions_channel_id_channel_owner_fkey",
+					"ALTER TABLE IF EXISTS group_auto_connects DROP CONSTRAINT IF EXISTS group_auto_connects_channel_id_channel_owner_fkey",
+					"ALTER TABLE IF EXISTS group_auto_connects ALTER COLUMN channel_id TYPE UUID USING channel_id::uuid",
+					"ALTER TABLE IF EXISTS channel_versions ALTER COLUMN channel_id TYPE UUID USING channel_id::uuid",
+					"ALTER TABLE IF EXISTS group_channels ALTER COLUMN channel_id TYPE UUID USING channel_id::uuid",
+					"ALTER TABLE IF EXISTS group_things ALTER COLUMN thing_id TYPE UUID USING thing_id::uuid",
+					"ALTER TABLE IF EXISTS connections ALTER COLUMN channel_id TYPE UUID USING channel_id::uuid, ALTER COLUMN thing_id TYPE UUID USING thing_id::uuid",
+					"ALTER TABLE IF EXISTS channels ALTER COLUMN id TYPE UUID USING id::uuid",
+					"ALTER TABLE IF EXISTS things ALTER COLUMN id TYPE UUID USING id::uuid",
+					"ALTER TABLE IF EXISTS connections ADD CONSTRAINT connections_channel_id_channel_owner_fkey FOREIGN KEY (channel_id, channel_owner) REFERENCES channels (id, owner) ON DELETE CASCADE ON UPDATE CASCADE",
+					"ALTER TABLE IF EXISTS connections ADD CONSTRAINT connections_thing_id_thing_owner_fkey FOREIGN KEY (thing_id, thing_owner) REFERENCES things (id, owner) ON DELETE CASCADE ON UPDATE CASCADE",
+					"ALTER TABLE IF EXISTS channel_versions ADD CONSTRAINT channel_versions_channel_id_channel_owner_fkey FOREIGN KEY (channel_id, channel_owner) REFERENCES channels (id, owner) ON DELETE CASCADE ON UPDATE CASCADE",
+					"ALTER TABLE IF EXISTS group_auto_connects ADD CONSTRAINT group_auto_connects_channel_id_channel_owner_fkey FOREIGN KEY (channel_id, channel_owner) REFERENCES channels (id, owner) ON DELETE CASCADE ON UPDATE CASCADE",
+				},
+			},
 			/*{
 				Id: "things_7",
 				Up: []string{
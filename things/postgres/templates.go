@@ -0,0 +1,246 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/things"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+var _ things.GroupTemplateRepository = (*groupTemplateRepository)(nil)
+
+type groupTemplateRepository struct {
+	db Database
+}
+
+// NewGroupTemplateRepository instantiates a PostgreSQL implementation of group
+// template repository.
+func NewGroupTemplateRepository(db Database) things.GroupTemplateRepository {
+	return &groupTemplateRepository{
+		db: db,
+	}
+}
+
+func (gtr groupTemplateRepository) Save(ctx context.Context, gt things.GroupTemplate) (things.GroupTemplate, error) {
+	q := `INSERT INTO group_templates (id, owner_id, name, description, channels, created_at)
+		  VALUES (:id, :owner_id, :name, :description, :channels, :created_at)`
+
+	if _, err := gtr.db.NamedExecContext(ctx, q, toDBGroupTemplate(gt)); err != nil {
+		pgErr, ok := err.(*pgconn.PgError)
+		if ok {
+			switch pgErr.Code {
+			case pgerrcode.InvalidTextRepresentation, pgerrcode.StringDataRightTruncationDataException:
+				return things.GroupTemplate{}, errors.Wrap(errors.ErrMalformedEntity, err)
+			case pgerrcode.UniqueViolation:
+				return things.GroupTemplate{}, errors.Wrap(errors.ErrConflict, err)
+			}
+		}
+		return things.GroupTemplate{}, errors.Wrap(errors.ErrCreateEntity, err)
+	}
+
+	return gt, nil
+}
+
+func (gtr groupTemplateRepository) RetrieveByID(ctx context.Context, id string) (things.GroupTemplate, error) {
+	q := `SELECT id, owner_id, name, description, channels, created_at FROM group_templates WHERE id = $1`
+
+	var dbgt dbGroupTemplate
+	if err := gtr.db.QueryRowxContext(ctx, q, id).StructScan(&dbgt); err != nil {
+		pgErr, ok := err.(*pgconn.PgError)
+		if err == sql.ErrNoRows || ok && pgerrcode.InvalidTextRepresentation == pgErr.Code {
+			return things.GroupTemplate{}, errors.Wrap(errors.ErrNotFound, err)
+		}
+		return things.GroupTemplate{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+
+	return toGroupTemplate(dbgt), nil
+}
+
+func (gtr groupTemplateRepository) RetrieveByOwner(ctx context.Context, ownerID string, pm things.PageMetadata) (things.GroupTemplatesPage, error) {
+	olq := "LIMIT :limit OFFSET :offset"
+	if pm.Limit == 0 {
+		olq = ""
+	}
+
+	q := fmt.Sprintf(`SELECT id, owner_id, name, description, channels, created_at FROM group_templates
+		  WHERE owner_id = :owner_id ORDER BY created_at, id %s;`, olq)
+
+	params := map[string]interface{}{
+		"owner_id": ownerID,
+		"limit":    pm.Limit,
+		"offset":   pm.Offset,
+	}
+	rows, err := gtr.db.NamedQueryContext(ctx, q, params)
+	if err != nil {
+		return things.GroupTemplatesPage{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+	defer rows.Close()
+
+	items := []things.GroupTemplate{}
+	for rows.Next() {
+		var dbgt dbGroupTemplate
+		if err := rows.StructScan(&dbgt); err != nil {
+			return things.GroupTemplatesPage{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+		}
+		items = append(items, toGroupTemplate(dbgt))
+	}
+
+	cq := `SELECT COUNT(*) FROM group_templates WHERE owner_id = :owner_id;`
+
+	total, err := total(ctx, gtr.db, cq, params)
+	if err != nil {
+		return things.GroupTemplatesPage{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+
+	page := things.GroupTemplatesPage{
+		Templates: items,
+		PageMetadata: things.PageMetadata{
+			Total:  total,
+			Offset: pm.Offset,
+			Limit:  pm.Limit,
+		},
+	}
+
+	return page, nil
+}
+
+func (gtr groupTemplateRepository) Remove(ctx context.Context, ownerID, id string) error {
+	q := `DELETE FROM group_templates WHERE id = :id AND owner_id = :owner_id`
+
+	params := map[string]interface{}{
+		"id":       id,
+		"owner_id": ownerID,
+	}
+	if _, err := gtr.db.NamedExecContext(ctx, q, params); err != nil {
+		return errors.Wrap(errors.ErrRemoveEntity, err)
+	}
+
+	return nil
+}
+
+func (gtr groupTemplateRepository) SaveAutoConnects(ctx context.Context, groupID, owner string, chIDs ...string) error {
+	tx, err := gtr.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(errors.ErrCreateEntity, err)
+	}
+
+	q := `INSERT INTO group_auto_connects (group_id, channel_id, channel_owner)
+		  VALUES (:group_id, :channel_id, :channel_owner)`
+
+	for _, chID := range chIDs {
+		params := map[string]interface{}{
+			"group_id":      groupID,
+			"channel_id":    chID,
+			"channel_owner": owner,
+		}
+		if _, err := tx.NamedExecContext(ctx, q, params); err != nil {
+			tx.Rollback()
+			pgErr, ok := err.(*pgconn.PgError)
+			if ok {
+				switch pgErr.Code {
+				case pgerrcode.InvalidTextRepresentation:
+					return errors.Wrap(errors.ErrMalformedEntity, err)
+				case pgerrcode.ForeignKeyViolation:
+					return errors.Wrap(errors.ErrNotFound, err)
+				case pgerrcode.UniqueViolation:
+					return errors.Wrap(errors.ErrConflict, err)
+				}
+			}
+			return errors.Wrap(errors.ErrCreateEntity, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.Wrap(errors.ErrCreateEntity, err)
+	}
+
+	return nil
+}
+
+func (gtr groupTemplateRepository) RetrieveAutoConnects(ctx context.Context, groupID string) ([]string, error) {
+	q := `SELECT channel_id FROM group_auto_connects WHERE group_id = :group_id`
+
+	rows, err := gtr.db.NamedQueryContext(ctx, q, map[string]interface{}{"group_id": groupID})
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+	defer rows.Close()
+
+	var chIDs []string
+	for rows.Next() {
+		var chID string
+		if err := rows.Scan(&chID); err != nil {
+			return nil, errors.Wrap(errors.ErrRetrieveEntity, err)
+		}
+		chIDs = append(chIDs, chID)
+	}
+
+	return chIDs, nil
+}
+
+// dbChannelTemplates type for handling channel templates properly in database/sql.
+type dbChannelTemplates []things.ChannelTemplate
+
+// Scan implements the database/sql scanner interface.
+func (cts *dbChannelTemplates) Scan(value interface{}) error {
+	if value == nil {
+		return nil
+	}
+
+	b, ok := value.([]byte)
+	if !ok {
+		return errors.ErrScanMetadata
+	}
+
+	return json.Unmarshal(b, cts)
+}
+
+// Value implements database/sql valuer interface.
+func (cts dbChannelTemplates) Value() (driver.Value, error) {
+	if len(cts) == 0 {
+		return nil, nil
+	}
+
+	return json.Marshal(cts)
+}
+
+type dbGroupTemplate struct {
+	ID          string             `db:"id"`
+	OwnerID     string             `db:"owner_id"`
+	Name        string             `db:"name"`
+	Description string             `db:"description"`
+	Channels    dbChannelTemplates `db:"channels"`
+	CreatedAt   time.Time          `db:"created_at"`
+}
+
+func toDBGroupTemplate(gt things.GroupTemplate) dbGroupTemplate {
+	return dbGroupTemplate{
+		ID:          gt.ID,
+		OwnerID:     gt.OwnerID,
+		Name:        gt.Name,
+		Description: gt.Description,
+		Channels:    gt.Channels,
+		CreatedAt:   gt.CreatedAt,
+	}
+}
+
+func toGroupTemplate(dbgt dbGroupTemplate) things.GroupTemplate {
+	return things.GroupTemplate{
+		ID:          dbgt.ID,
+		OwnerID:     dbgt.OwnerID,
+		Name:        dbgt.Name,
+		Description: dbgt.Description,
+		Channels:    dbgt.Channels,
+		CreatedAt:   dbgt.CreatedAt,
+	}
+}
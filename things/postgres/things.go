@@ -10,8 +10,6 @@ import (
 	"fmt"
 	"strings"
 
-	"github.com/gofrs/uuid"
-
 	"github.com/MainfluxLabs/mainflux/internal/dbutil"
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
 	"github.com/MainfluxLabs/mainflux/things"
@@ -21,6 +19,15 @@ import (
 
 var _ things.ThingRepository = (*thingRepository)(nil)
 
+const (
+	// searchExpr is the text searched by the things search, indexed by
+	// the things_search_idx trigram index.
+	searchExpr = `(COALESCE(name, '') || ' ' || COALESCE(external_id, '') || ' ' || CAST(metadata AS TEXT))`
+	// searchRank ranks the things search results, preferring the matches of
+	// the whole words and the matches of the thing name.
+	searchRank = `GREATEST(word_similarity(:query, ` + searchExpr + `), similarity(COALESCE(name, ''), :query))`
+)
+
 type thingRepository struct {
 	db Database
 }
@@ -39,8 +46,8 @@ func (tr thingRepository) Save(ctx context.Context, ths ...things.Thing) ([]thin
 		return []things.Thing{}, errors.Wrap(errors.ErrCreateEntity, err)
 	}
 
-	q := `INSERT INTO things (id, owner, name, key, metadata)
-		  VALUES (:id, :owner, :name, :key, :metadata);`
+	q := `INSERT INTO things (id, owner, name, key, external_id, latitude, longitude, metadata)
+		  VALUES (:id, :owner, :name, :key, :external_id, :latitude, :longitude, :metadata);`
 
 	for _, thing := range ths {
 		dbth, err := toDBThing(thing)
@@ -74,7 +81,12 @@ func (tr thingRepository) Save(ctx context.Context, ths ...things.Thing) ([]thin
 }
 
 func (tr thingRepository) Update(ctx context.Context, t things.Thing) error {
-	q := `UPDATE things SET name = :name, metadata = :metadata WHERE id = :id;`
+	// Location of the thing is kept if it's not provided.
+	q := `UPDATE things SET name = :name, latitude = COALESCE(:latitude, latitude), longitude = COALESCE(:longitude, longitude),
+		metadata = :metadata, revision = revision + 1 WHERE id = :id`
+	if t.Revision != 0 {
+		q = fmt.Sprintf("%s AND revision = :revision", q)
+	}
 
 	dbth, err := toDBThing(t)
 	if err != nil {
@@ -102,7 +114,7 @@ func (tr thingRepository) Update(ctx context.Context, t things.Thing) error {
 	}
 
 	if cnt == 0 {
-		return errors.ErrNotFound
+		return revisionError(ctx, tr.db, "things", t.ID, t.Revision)
 	}
 
 	return nil
@@ -147,7 +159,7 @@ func (tr thingRepository) UpdateKey(ctx context.Context, owner, id, key string)
 }
 
 func (tr thingRepository) RetrieveByID(ctx context.Context, id string) (things.Thing, error) {
-	q := `SELECT name, owner, key, metadata FROM things WHERE id = $1;`
+	q := `SELECT name, owner, key, external_id, latitude, longitude, metadata, revision FROM things WHERE id = $1;`
 
 	dbth := dbThing{ID: id}
 
@@ -177,6 +189,21 @@ func (tr thingRepository) RetrieveByKey(ctx context.Context, key string) (string
 	return id, nil
 }
 
+func (tr thingRepository) RetrieveByExternalID(ctx context.Context, owner, externalID string) (things.Thing, error) {
+	q := `SELECT id, name, key, external_id, latitude, longitude, metadata FROM things WHERE owner = $1 AND external_id = $2;`
+
+	dbth := dbThing{Owner: owner}
+
+	if err := tr.db.QueryRowxContext(ctx, q, owner, externalID).StructScan(&dbth); err != nil {
+		if err == sql.ErrNoRows {
+			return things.Thing{}, errors.Wrap(errors.ErrNotFound, err)
+		}
+		return things.Thing{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+
+	return toThing(dbth)
+}
+
 func (tr thingRepository) RetrieveByIDs(ctx context.Context, thingIDs []string, pm things.PageMetadata) (things.Page, error) {
 	if len(thingIDs) == 0 {
 		return things.Page{}, nil
@@ -192,7 +219,7 @@ func (tr thingRepository) RetrieveByIDs(ctx context.Context, thingIDs []string,
 		return things.Page{}, errors.Wrap(errors.ErrRetrieveEntity, err)
 	}
 
-	q := fmt.Sprintf(`SELECT id, owner, name, key, metadata FROM things
+	q := fmt.Sprintf(`SELECT id, owner, name, key, external_id, latitude, longitude, metadata FROM things
 					   %s%s%s ORDER BY %s %s LIMIT :limit OFFSET :offset;`, idq, mq, nq, oq, dq)
 
 	params := map[string]interface{}{
@@ -262,18 +289,13 @@ func (tr thingRepository) RetrieveAll(ctx context.Context) ([]things.Thing, erro
 }
 
 func (tr thingRepository) RetrieveByAdmin(ctx context.Context, pm things.PageMetadata) (things.Page, error) {
-	return tr.retrieve(ctx, "", false, pm)
+	return tr.retrieve(ctx, pm.Owner, false, pm)
 }
 
 func (tr thingRepository) RetrieveByChannel(ctx context.Context, owner, chID string, pm things.PageMetadata) (things.Page, error) {
 	oq := getConnOrderQuery(pm.Order, "th")
 	dq := getDirQuery(pm.Dir)
 
-	// Verify if UUID format is valid to avoid internal Postgres error
-	if _, err := uuid.FromString(chID); err != nil {
-		return things.Page{}, errors.Wrap(errors.ErrNotFound, err)
-	}
-
 	olq := "LIMIT :limit OFFSET :offset"
 	if pm.Limit == 0 {
 		olq = ""
@@ -282,7 +304,7 @@ func (tr thingRepository) RetrieveByChannel(ctx context.Context, owner, chID str
 	var q, qc string
 	switch pm.Disconnected {
 	case true:
-		q = fmt.Sprintf(`SELECT id, name, key, metadata
+		q = fmt.Sprintf(`SELECT id, name, key, external_id, latitude, longitude, metadata
 		        FROM things th
 		        WHERE th.owner = :owner AND th.id NOT IN
 		        (SELECT id FROM things th
@@ -299,7 +321,7 @@ func (tr thingRepository) RetrieveByChannel(ctx context.Context, owner, chID str
 		          ON th.id = conn.thing_id
 		          WHERE th.owner = $1 AND conn.channel_id = $2);`
 	default:
-		q = fmt.Sprintf(`SELECT id, name, key, metadata
+		q = fmt.Sprintf(`SELECT id, name, key, external_id, latitude, longitude, metadata
 		        FROM things th
 		        INNER JOIN connections conn
 		        ON th.id = conn.thing_id
@@ -375,8 +397,17 @@ func (tr thingRepository) Remove(ctx context.Context, owner string, ids ...strin
 func (tr thingRepository) retrieve(ctx context.Context, owner string, includeOwner bool, pm things.PageMetadata) (things.Page, error) {
 	ownq := dbutil.GetOwnerQuery(owner, ownerDbId)
 	nq, name := dbutil.GetNameQuery(pm.Name)
+	kq, key := getKeyPrefixQuery(pm.KeyPrefix)
+	wq := getWithinQuery(pm.Within)
+	sq, pattern := getSearchQuery(pm.Query)
 	oq := getOrderQuery(pm.Order)
 	dq := getDirQuery(pm.Dir)
+	if pm.Query != "" && pm.Order == "" {
+		// Search results are ranked by their relevance, unless the order is
+		// explicitly requested.
+		oq = searchRank
+		dq = "DESC"
+	}
 	m, mq, err := dbutil.GetMetadataQuery("", pm.Metadata)
 	if err != nil {
 		return things.Page{}, errors.Wrap(errors.ErrRetrieveEntity, err)
@@ -392,6 +423,15 @@ func (tr thingRepository) retrieve(ctx context.Context, owner string, includeOwn
 	if nq != "" {
 		query = append(query, nq)
 	}
+	if kq != "" {
+		query = append(query, kq)
+	}
+	if wq != "" {
+		query = append(query, wq)
+	}
+	if sq != "" {
+		query = append(query, sq)
+	}
 
 	var whereClause string
 	if len(query) > 0 {
@@ -403,10 +443,10 @@ func (tr thingRepository) retrieve(ctx context.Context, owner string, includeOwn
 		olq = ""
 	}
 
-	q := fmt.Sprintf(`SELECT id, name, key, metadata FROM things %s ORDER BY %s %s %s;`, whereClause, oq, dq, olq)
+	q := fmt.Sprintf(`SELECT id, owner, name, key, external_id, latitude, longitude, metadata FROM things %s ORDER BY %s %s %s;`, whereClause, oq, dq, olq)
 
 	if includeOwner {
-		q = "SELECT id, owner, name, key, metadata FROM things;"
+		q = "SELECT id, owner, name, key, external_id, latitude, longitude, metadata FROM things;"
 	}
 
 	params := map[string]interface{}{
@@ -414,7 +454,15 @@ func (tr thingRepository) retrieve(ctx context.Context, owner string, includeOwn
 		"limit":    pm.Limit,
 		"offset":   pm.Offset,
 		"name":     name,
+		"key":      key,
 		"metadata": m,
+		"query":    pm.Query,
+		"pattern":  pattern,
+	}
+	if pm.Within != nil {
+		params["latitude"] = pm.Within.Latitude
+		params["longitude"] = pm.Within.Longitude
+		params["radius"] = pm.Within.Radius
 	}
 
 	rows, err := tr.db.NamedQueryContext(ctx, q, params)
@@ -459,12 +507,111 @@ func (tr thingRepository) retrieve(ctx context.Context, owner string, includeOwn
 	return page, nil
 }
 
+func (tr thingRepository) SaveSigningKey(ctx context.Context, sk things.SigningKey) error {
+	q := `UPDATE things SET signing_algorithm = :signing_algorithm, signing_key = :signing_key WHERE id = :thing_id;`
+
+	return tr.updateSigningKey(ctx, q, toDBSigningKey(sk))
+}
+
+func (tr thingRepository) RetrieveSigningKey(ctx context.Context, thingID string) (things.SigningKey, error) {
+	q := `SELECT id AS thing_id, signing_algorithm, signing_key FROM things WHERE id = $1;`
+
+	var dbsk dbSigningKey
+	if err := tr.db.QueryRowxContext(ctx, q, thingID).StructScan(&dbsk); err != nil {
+		pgErr, ok := err.(*pgconn.PgError)
+		if err == sql.ErrNoRows || ok && pgerrcode.InvalidTextRepresentation == pgErr.Code {
+			return things.SigningKey{}, errors.Wrap(errors.ErrNotFound, err)
+		}
+		return things.SigningKey{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+
+	if !dbsk.Algorithm.Valid {
+		return things.SigningKey{}, errors.ErrNotFound
+	}
+
+	return toSigningKey(dbsk), nil
+}
+
+func (tr thingRepository) RemoveSigningKey(ctx context.Context, thingID string) error {
+	q := `UPDATE things SET signing_algorithm = NULL, signing_key = NULL WHERE id = :thing_id;`
+
+	return tr.updateSigningKey(ctx, q, dbSigningKey{ThingID: thingID})
+}
+
+func (tr thingRepository) updateSigningKey(ctx context.Context, q string, dbsk dbSigningKey) error {
+	res, err := tr.db.NamedExecContext(ctx, q, dbsk)
+	if err != nil {
+		pgErr, ok := err.(*pgconn.PgError)
+		if ok {
+			switch pgErr.Code {
+			case pgerrcode.InvalidTextRepresentation:
+				return errors.Wrap(errors.ErrNotFound, err)
+			case pgerrcode.StringDataRightTruncationDataException:
+				return errors.Wrap(errors.ErrMalformedEntity, err)
+			}
+		}
+
+		return errors.Wrap(errors.ErrUpdateEntity, err)
+	}
+
+	cnt, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(errors.ErrUpdateEntity, err)
+	}
+
+	if cnt == 0 {
+		return errors.ErrNotFound
+	}
+
+	return nil
+}
+
+// getKeyPrefixQuery returns the condition matching things whose key starts
+// with the given prefix, escaping the LIKE wildcards contained in the prefix.
+func getKeyPrefixQuery(prefix string) (string, string) {
+	if prefix == "" {
+		return "", ""
+	}
+
+	prefix = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(prefix)
+
+	return "key LIKE :key", prefix + "%"
+}
+
+// getSearchQuery returns the condition matching things whose name, external
+// ID or metadata contain the given text, served by the trigram search index.
+func getSearchQuery(text string) (string, string) {
+	if text == "" {
+		return "", ""
+	}
+
+	text = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(text)
+
+	return searchExpr + " ILIKE :pattern", "%" + text + "%"
+}
+
+// getWithinQuery returns the condition matching things located within the
+// area. The earth_box condition is served by the location index, while the
+// earth_distance condition filters out the things located in its corners.
+func getWithinQuery(area *things.Area) string {
+	if area == nil {
+		return ""
+	}
+
+	return `earth_box(ll_to_earth(:latitude, :longitude), :radius) @> ll_to_earth(things.latitude, things.longitude)
+		AND earth_distance(ll_to_earth(:latitude, :longitude), ll_to_earth(things.latitude, things.longitude)) <= :radius`
+}
+
 type dbThing struct {
-	ID       string `db:"id"`
-	Owner    string `db:"owner"`
-	Name     string `db:"name"`
-	Key      string `db:"key"`
-	Metadata []byte `db:"metadata"`
+	ID         string          `db:"id"`
+	Owner      string          `db:"owner"`
+	Name       string          `db:"name"`
+	Key        string          `db:"key"`
+	ExternalID sql.NullString  `db:"external_id"`
+	Latitude   sql.NullFloat64 `db:"latitude"`
+	Longitude  sql.NullFloat64 `db:"longitude"`
+	Metadata   []byte          `db:"metadata"`
+	Revision   uint64          `db:"revision"`
 }
 
 func toDBThing(th things.Thing) (dbThing, error) {
@@ -477,13 +624,21 @@ func toDBThing(th things.Thing) (dbThing, error) {
 		data = b
 	}
 
-	return dbThing{
-		ID:       th.ID,
-		Owner:    th.Owner,
-		Name:     th.Name,
-		Key:      th.Key,
-		Metadata: data,
-	}, nil
+	dbth := dbThing{
+		ID:         th.ID,
+		Owner:      th.Owner,
+		Name:       th.Name,
+		Key:        th.Key,
+		ExternalID: sql.NullString{String: th.ExternalID, Valid: th.ExternalID != ""},
+		Metadata:   data,
+		Revision:   th.Revision,
+	}
+	if th.Location != nil {
+		dbth.Latitude = sql.NullFloat64{Float64: th.Location.Latitude, Valid: true}
+		dbth.Longitude = sql.NullFloat64{Float64: th.Location.Longitude, Valid: true}
+	}
+
+	return dbth, nil
 }
 
 func toThing(dbth dbThing) (things.Thing, error) {
@@ -492,11 +647,43 @@ func toThing(dbth dbThing) (things.Thing, error) {
 		return things.Thing{}, errors.Wrap(errors.ErrMalformedEntity, err)
 	}
 
-	return things.Thing{
-		ID:       dbth.ID,
-		Owner:    dbth.Owner,
-		Name:     dbth.Name,
-		Key:      dbth.Key,
-		Metadata: metadata,
-	}, nil
+	th := things.Thing{
+		ID:         dbth.ID,
+		Owner:      dbth.Owner,
+		Name:       dbth.Name,
+		Key:        dbth.Key,
+		ExternalID: dbth.ExternalID.String,
+		Metadata:   metadata,
+		Revision:   dbth.Revision,
+	}
+	if dbth.Latitude.Valid && dbth.Longitude.Valid {
+		th.Location = &things.Location{
+			Latitude:  dbth.Latitude.Float64,
+			Longitude: dbth.Longitude.Float64,
+		}
+	}
+
+	return th, nil
+}
+
+type dbSigningKey struct {
+	ThingID   string         `db:"thing_id"`
+	Algorithm sql.NullString `db:"signing_algorithm"`
+	Key       []byte         `db:"signing_key"`
+}
+
+func toDBSigningKey(sk things.SigningKey) dbSigningKey {
+	return dbSigningKey{
+		ThingID:   sk.ThingID,
+		Algorithm: sql.NullString{String: sk.Algorithm, Valid: sk.Algorithm != ""},
+		Key:       sk.Key,
+	}
+}
+
+func toSigningKey(dbsk dbSigningKey) things.SigningKey {
+	return things.SigningKey{
+		ThingID:   dbsk.ThingID,
+		Algorithm: dbsk.Algorithm.String,
+		Key:       dbsk.Key,
+	}
 }
@@ -180,6 +180,26 @@ func TestThingUpdate(t *testing.T) {
 			},
 			err: errors.ErrMalformedEntity,
 		},
+		{
+			desc: "update thing with stale revision",
+			thing: things.Thing{
+				ID:       thID,
+				Owner:    email,
+				Key:      thkey,
+				Name:     validName,
+				Revision: 1,
+			},
+			err: things.ErrRevisionMismatch,
+		},
+		{
+			desc: "update non-existing thing with revision",
+			thing: things.Thing{
+				ID:       nonexistentThingID,
+				Owner:    email,
+				Revision: 1,
+			},
+			err: errors.ErrNotFound,
+		},
 	}
 
 	for _, tc := range cases {
@@ -188,6 +208,58 @@ func TestThingUpdate(t *testing.T) {
 	}
 }
 
+func TestThingUpdateLocation(t *testing.T) {
+	dbMiddleware := postgres.NewDatabase(db)
+	thingRepo := postgres.NewThingRepository(dbMiddleware)
+
+	thID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	thkey, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	location := &things.Location{Latitude: 44.81, Longitude: 20.46}
+	thing := things.Thing{
+		ID:       thID,
+		Owner:    "thing-update-location@example.com",
+		Key:      thkey,
+		Location: location,
+	}
+
+	_, err = thingRepo.Save(context.Background(), thing)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	moved := &things.Location{Latitude: 45.25, Longitude: 19.84}
+
+	cases := []struct {
+		desc     string
+		location *things.Location
+		res      *things.Location
+	}{
+		{
+			desc:     "update thing without location",
+			location: nil,
+			res:      location,
+		},
+		{
+			desc:     "update thing with location",
+			location: moved,
+			res:      moved,
+		},
+	}
+
+	for _, tc := range cases {
+		th := thing
+		th.Name = "updated"
+		th.Location = tc.location
+		err := thingRepo.Update(context.Background(), th)
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s\n", tc.desc, err))
+
+		res, err := thingRepo.RetrieveByID(context.Background(), thID)
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s\n", tc.desc, err))
+		assert.Equal(t, tc.res, res.Location, fmt.Sprintf("%s: expected location %v got %v\n", tc.desc, tc.res, res.Location))
+	}
+}
+
 func TestUpdateKey(t *testing.T) {
 	email := "thing-update=key@example.com"
 	newKey := "new-key"
@@ -273,6 +345,52 @@ func TestUpdateKey(t *testing.T) {
 	}
 }
 
+func TestSigningKey(t *testing.T) {
+	email := "thing-signing-key@example.com"
+	dbMiddleware := postgres.NewDatabase(db)
+	thingRepo := postgres.NewThingRepository(dbMiddleware)
+
+	id, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	key, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	th := things.Thing{
+		ID:    id,
+		Owner: email,
+		Key:   key,
+	}
+	_, err = thingRepo.Save(context.Background(), th)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	nonexistentThingID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	sk := things.SigningKey{
+		ThingID:   th.ID,
+		Algorithm: "hmac-sha256",
+		Key:       []byte("0123456789abcdef0123456789abcdef"),
+	}
+
+	_, err = thingRepo.RetrieveSigningKey(context.Background(), th.ID)
+	assert.True(t, errors.Contains(err, errors.ErrNotFound), fmt.Sprintf("retrieve signing key of thing without key: expected %s got %s\n", errors.ErrNotFound, err))
+
+	err = thingRepo.SaveSigningKey(context.Background(), sk)
+	assert.Nil(t, err, fmt.Sprintf("save signing key: unexpected error: %s\n", err))
+
+	err = thingRepo.SaveSigningKey(context.Background(), things.SigningKey{ThingID: nonexistentThingID, Algorithm: sk.Algorithm, Key: sk.Key})
+	assert.True(t, errors.Contains(err, errors.ErrNotFound), fmt.Sprintf("save signing key of non-existing thing: expected %s got %s\n", errors.ErrNotFound, err))
+
+	res, err := thingRepo.RetrieveSigningKey(context.Background(), th.ID)
+	assert.Nil(t, err, fmt.Sprintf("retrieve signing key: unexpected error: %s\n", err))
+	assert.Equal(t, sk, res, fmt.Sprintf("retrieve signing key: expected %v got %v\n", sk, res))
+
+	err = thingRepo.RemoveSigningKey(context.Background(), th.ID)
+	assert.Nil(t, err, fmt.Sprintf("remove signing key: unexpected error: %s\n", err))
+
+	_, err = thingRepo.RetrieveSigningKey(context.Background(), th.ID)
+	assert.True(t, errors.Contains(err, errors.ErrNotFound), fmt.Sprintf("retrieve removed signing key: expected %s got %s\n", errors.ErrNotFound, err))
+}
+
 func TestSingleThingRetrieval(t *testing.T) {
 	email := "thing-single-retrieval@example.com"
 	dbMiddleware := postgres.NewDatabase(db)
@@ -547,6 +665,132 @@ func TestMultiThingRetrieval(t *testing.T) {
 	}
 }
 
+func TestThingRetrievalWithinArea(t *testing.T) {
+	dbMiddleware := postgres.NewDatabase(db)
+	err := cleanTestTable(context.Background(), "things", dbMiddleware)
+	assert.Nil(t, err, fmt.Sprintf("cleaning table 'things' expected to success %v", err))
+	thingRepo := postgres.NewThingRepository(dbMiddleware)
+
+	email := "thing-within-area@example.com"
+	locations := []*things.Location{
+		{Latitude: 44.8176, Longitude: 20.4569},
+		{Latitude: 45.2671, Longitude: 19.8335},
+		nil,
+	}
+
+	for _, l := range locations {
+		id, err := idProvider.ID()
+		require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+		key, err := idProvider.ID()
+		require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+		th := things.Thing{Owner: email, ID: id, Key: key, Location: l}
+		_, err = thingRepo.Save(context.Background(), th)
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	}
+
+	center := things.Location{Latitude: 44.7866, Longitude: 20.4489}
+
+	cases := []struct {
+		desc   string
+		within *things.Area
+		size   uint64
+	}{
+		{
+			desc: "retrieve things without area",
+			size: 3,
+		},
+		{
+			desc:   "retrieve things within small area",
+			within: &things.Area{Location: center, Radius: 10000},
+			size:   1,
+		},
+		{
+			desc:   "retrieve things within large area",
+			within: &things.Area{Location: center, Radius: 100000},
+			size:   2,
+		},
+	}
+
+	for _, tc := range cases {
+		page, err := thingRepo.RetrieveByOwner(context.Background(), email, things.PageMetadata{Limit: 10, Within: tc.within})
+		size := uint64(len(page.Things))
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+		assert.Equal(t, tc.size, size, fmt.Sprintf("%s: expected size %d got %d\n", tc.desc, tc.size, size))
+		assert.Equal(t, tc.size, page.Total, fmt.Sprintf("%s: expected total %d got %d\n", tc.desc, tc.size, page.Total))
+	}
+}
+
+func TestThingRetrievalByText(t *testing.T) {
+	dbMiddleware := postgres.NewDatabase(db)
+	err := cleanTestTable(context.Background(), "things", dbMiddleware)
+	assert.Nil(t, err, fmt.Sprintf("cleaning table 'things' expected to success %v", err))
+	thingRepo := postgres.NewThingRepository(dbMiddleware)
+
+	email := "thing-text-search@example.com"
+	ths := []things.Thing{
+		{Name: "pump-01", ExternalID: "SN-4711-A"},
+		{Name: "pump-02", Metadata: things.Metadata{"serial": "SN-4712-B"}},
+		{Name: "valve-01", Metadata: things.Metadata{"site": "north"}},
+	}
+
+	for _, th := range ths {
+		id, err := idProvider.ID()
+		require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+		key, err := idProvider.ID()
+		require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+		th.ID, th.Key, th.Owner = id, key, email
+		_, err = thingRepo.Save(context.Background(), th)
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	}
+
+	cases := []struct {
+		desc  string
+		query string
+		size  uint64
+	}{
+		{
+			desc:  "search things by partial name",
+			query: "pump",
+			size:  2,
+		},
+		{
+			desc:  "search things by partial external ID",
+			query: "4711",
+			size:  1,
+		},
+		{
+			desc:  "search things by partial metadata value",
+			query: "4712",
+			size:  1,
+		},
+		{
+			desc:  "search things case insensitive",
+			query: "VALVE",
+			size:  1,
+		},
+		{
+			desc:  "search things with wildcard characters",
+			query: "%",
+			size:  0,
+		},
+		{
+			desc:  "search things without match",
+			query: "compressor",
+			size:  0,
+		},
+	}
+
+	for _, tc := range cases {
+		page, err := thingRepo.RetrieveByOwner(context.Background(), email, things.PageMetadata{Limit: 10, Query: tc.query})
+		size := uint64(len(page.Things))
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+		assert.Equal(t, tc.size, size, fmt.Sprintf("%s: expected size %d got %d\n", tc.desc, tc.size, size))
+		assert.Equal(t, tc.size, page.Total, fmt.Sprintf("%s: expected total %d got %d\n", tc.desc, tc.size, page.Total))
+	}
+}
+
 func TestBackupThings(t *testing.T) {
 	dbMiddleware := postgres.NewDatabase(db)
 	err := cleanTestTable(context.Background(), "things", dbMiddleware)
@@ -714,7 +958,7 @@ func TestMultiThingRetrievalByChannel(t *testing.T) {
 			},
 			size: 0,
 		},
-		"retrieve things with malformed UUID": {
+		"retrieve things by channel with non-UUID ID": {
 			owner: email,
 			chID:  wrongValue,
 			pageMetadata: things.PageMetadata{
@@ -722,7 +966,7 @@ func TestMultiThingRetrievalByChannel(t *testing.T) {
 				Limit:  n,
 			},
 			size: 0,
-			err:  errors.ErrNotFound,
+			err:  nil,
 		},
 		"retrieve all non connected things by channel with existing owner": {
 			owner: email,
@@ -828,7 +1072,7 @@ func TestThingRemoval(t *testing.T) {
 		"remove non-existing thing": {
 			owner:   email,
 			thingID: wrongValue,
-			err:     errors.ErrRemoveEntity,
+			err:     nil,
 		},
 		"remove thing": {
 			owner:   email,
@@ -0,0 +1,95 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package things
+
+import "github.com/MainfluxLabs/mainflux/pkg/errors"
+
+// Channel profile keys.
+const (
+	// mirrorKey is the key holding the ID of the mirror channel.
+	mirrorKey = "mirror_to"
+
+	// forwardKey is the key holding whether the messages of the channel
+	// are forwarded to the subscribers.
+	forwardKey = "forward"
+
+	// persistKey is the key holding whether the messages of the channel
+	// are persisted by the writers.
+	persistKey = "persist"
+)
+
+// ErrMalformedProfile indicates malformed channel profile.
+var ErrMalformedProfile = errors.New("malformed channel profile")
+
+// ChannelProfile represents the channel profile settings applied by the
+// protocol adapters to the messages published to the channel.
+type ChannelProfile struct {
+	// MirrorTo is the ID of the channel the accepted messages of the
+	// channel are published to as well, or empty if they are not mirrored.
+	MirrorTo string
+
+	// Message is the profile the adapters set to the messages published
+	// to the channel, or nil if the messages are both forwarded and
+	// persisted.
+	Message *MessageProfile
+}
+
+// MessageProfile specifies how the messages published to the channel are
+// handled once they are published.
+type MessageProfile struct {
+	Forward bool
+	Persist bool
+}
+
+// ParseProfile reads the channel profile from the channel metadata.
+func ParseProfile(metadata map[string]interface{}) (ChannelProfile, error) {
+	val, ok := metadata[profileKey]
+	if !ok {
+		return ChannelProfile{}, nil
+	}
+
+	profile, ok := val.(map[string]interface{})
+	if !ok {
+		return ChannelProfile{}, ErrMalformedProfile
+	}
+
+	var cp ChannelProfile
+	if mirror, ok := profile[mirrorKey]; ok {
+		mirrorTo, ok := mirror.(string)
+		if !ok {
+			return ChannelProfile{}, ErrMalformedProfile
+		}
+		cp.MirrorTo = mirrorTo
+	}
+
+	forward, fok, err := readFlag(profile, forwardKey)
+	if err != nil {
+		return ChannelProfile{}, err
+	}
+	persist, pok, err := readFlag(profile, persistKey)
+	if err != nil {
+		return ChannelProfile{}, err
+	}
+	if fok || pok {
+		cp.Message = &MessageProfile{Forward: forward, Persist: persist}
+	}
+
+	return cp, nil
+}
+
+// readFlag reads the flag of the channel profile, which defaults to true
+// if not set. It reports whether the flag is set.
+func readFlag(profile map[string]interface{}, key string) (bool, bool, error) {
+	val, ok := profile[key]
+	if !ok {
+		return true, false, nil
+	}
+
+	flag, ok := val.(bool)
+	if !ok {
+		return false, false, ErrMalformedProfile
+	}
+
+	return flag, true, nil
+}
@@ -0,0 +1,87 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package readers contains the message counter backed by the readers HTTP
+// API.
+package readers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/internal/apiutil"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/things"
+)
+
+// ErrCountMessages indicates failure to count the messages by the readers.
+var ErrCountMessages = errors.New("failed to count messages")
+
+var _ things.MessageCounter = (*counter)(nil)
+
+type countRes struct {
+	Count uint64 `json:"count"`
+}
+
+type counter struct {
+	url    string
+	client *http.Client
+}
+
+// NewMessageCounter returns the message counter sending the requests to the
+// readers service at the given URL.
+func NewMessageCounter(url string, client *http.Client) things.MessageCounter {
+	return &counter{
+		url:    url,
+		client: client,
+	}
+}
+
+func (c *counter) CountMessages(ctx context.Context, token, chanID string, from, to time.Time) (uint64, error) {
+	query := url.Values{}
+	if !from.IsZero() {
+		query.Set("from", formatTime(from))
+	}
+	if !to.IsZero() {
+		query.Set("to", formatTime(to))
+	}
+
+	u := fmt.Sprintf("%s/channels/%s/messages/count", c.url, url.PathEscape(chanID))
+	if len(query) > 0 {
+		u = fmt.Sprintf("%s?%s", u, query.Encode())
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return 0, errors.Wrap(ErrCountMessages, err)
+	}
+	req.Header.Set("Authorization", apiutil.BearerPrefix+token)
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return 0, errors.Wrap(ErrCountMessages, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return 0, errors.Wrap(ErrCountMessages, errors.New(resp.Status))
+	}
+
+	var res countRes
+	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
+		return 0, errors.Wrap(ErrCountMessages, err)
+	}
+
+	return res.Count, nil
+}
+
+// formatTime formats the time as the fractional Unix seconds expected by
+// the readers.
+func formatTime(t time.Time) string {
+	return strconv.FormatFloat(float64(t.UnixNano())/float64(time.Second), 'f', -1, 64)
+}
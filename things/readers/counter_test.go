@@ -0,0 +1,85 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package readers_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/things/readers"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	token  = "token"
+	chanID = "chan-id"
+)
+
+func TestCountMessages(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer "+token {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.URL.Path != fmt.Sprintf("/channels/%s/messages/count", chanID) {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		count := 10
+		if r.URL.Query().Get("from") != "" {
+			count = 3
+		}
+		fmt.Fprintf(w, `{"count":%d}`, count)
+	}))
+	defer ts.Close()
+
+	counter := readers.NewMessageCounter(ts.URL, ts.Client())
+
+	cases := []struct {
+		desc   string
+		token  string
+		chanID string
+		from   time.Time
+		count  uint64
+		err    error
+	}{
+		{
+			desc:   "count all messages",
+			token:  token,
+			chanID: chanID,
+			count:  10,
+		},
+		{
+			desc:   "count messages since time",
+			token:  token,
+			chanID: chanID,
+			from:   time.Now().Add(-time.Hour),
+			count:  3,
+		},
+		{
+			desc:   "count messages with invalid token",
+			token:  "invalid",
+			chanID: chanID,
+			err:    readers.ErrCountMessages,
+		},
+		{
+			desc:   "count messages of unknown channel",
+			token:  token,
+			chanID: "unknown",
+			err:    readers.ErrCountMessages,
+		},
+	}
+
+	for _, tc := range cases {
+		count, err := counter.CountMessages(context.Background(), tc.token, tc.chanID, tc.from, time.Time{})
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.count, count, fmt.Sprintf("%s: expected %d got %d\n", tc.desc, tc.count, count))
+	}
+}
@@ -1,12 +1,18 @@
 package redis
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"strings"
+)
 
 const (
 	thingPrefix     = "thing."
 	thingCreate     = thingPrefix + "create"
 	thingUpdate     = thingPrefix + "update"
 	thingRemove     = thingPrefix + "remove"
+	thingUpdateKey  = thingPrefix + "update_key"
+	thingUpdateSK   = thingPrefix + "update_signing_key"
+	thingRemoveSK   = thingPrefix + "remove_signing_key"
 	thingConnect    = thingPrefix + "connect"
 	thingDisconnect = thingPrefix + "disconnect"
 
@@ -14,6 +20,12 @@ const (
 	channelCreate = channelPrefix + "create"
 	channelUpdate = channelPrefix + "update"
 	channelRemove = channelPrefix + "remove"
+
+	groupPrefix           = "group."
+	groupAssignThings     = groupPrefix + "assign_things"
+	groupUnassignThings   = groupPrefix + "unassign_things"
+	groupAssignChannels   = groupPrefix + "assign_channels"
+	groupUnassignChannels = groupPrefix + "unassign_channels"
 )
 
 type event interface {
@@ -24,18 +36,23 @@ var (
 	_ event = (*createThingEvent)(nil)
 	_ event = (*updateThingEvent)(nil)
 	_ event = (*removeThingEvent)(nil)
+	_ event = (*updateKeyEvent)(nil)
+	_ event = (*updateSigningKeyEvent)(nil)
+	_ event = (*removeSigningKeyEvent)(nil)
 	_ event = (*createChannelEvent)(nil)
 	_ event = (*updateChannelEvent)(nil)
 	_ event = (*removeChannelEvent)(nil)
 	_ event = (*connectThingEvent)(nil)
 	_ event = (*disconnectThingEvent)(nil)
+	_ event = (*groupMembersEvent)(nil)
 )
 
 type createThingEvent struct {
-	id       string
-	owner    string
-	name     string
-	metadata map[string]interface{}
+	id         string
+	owner      string
+	name       string
+	externalID string
+	metadata   map[string]interface{}
 }
 
 func (cte createThingEvent) Encode() map[string]interface{} {
@@ -49,6 +66,10 @@ func (cte createThingEvent) Encode() map[string]interface{} {
 		val["name"] = cte.name
 	}
 
+	if cte.externalID != "" {
+		val["external_id"] = cte.externalID
+	}
+
 	if cte.metadata != nil {
 		metadata, err := json.Marshal(cte.metadata)
 		if err != nil {
@@ -90,16 +111,55 @@ func (ute updateThingEvent) Encode() map[string]interface{} {
 }
 
 type removeThingEvent struct {
-	id string
+	id       string
+	owner    string
+	channels []string
 }
 
 func (rte removeThingEvent) Encode() map[string]interface{} {
 	return map[string]interface{}{
 		"id":        rte.id,
+		"owner":     rte.owner,
+		"channels":  strings.Join(rte.channels, ","),
 		"operation": thingRemove,
 	}
 }
 
+type updateKeyEvent struct {
+	id string
+}
+
+func (uke updateKeyEvent) Encode() map[string]interface{} {
+	return map[string]interface{}{
+		"id":        uke.id,
+		"operation": thingUpdateKey,
+	}
+}
+
+type updateSigningKeyEvent struct {
+	id        string
+	algorithm string
+}
+
+func (use updateSigningKeyEvent) Encode() map[string]interface{} {
+	return map[string]interface{}{
+		"id":        use.id,
+		"algorithm": use.algorithm,
+		"operation": thingUpdateSK,
+	}
+}
+
+type removeSigningKeyEvent struct {
+	id string
+}
+
+func (rse removeSigningKeyEvent) Encode() map[string]interface{} {
+	return map[string]interface{}{
+		"id":        rse.id,
+		"operation": thingRemoveSK,
+	}
+}
+
 type createChannelEvent struct {
 	id       string
 	owner    string
@@ -194,3 +254,17 @@ func (dte disconnectThingEvent) Encode() map[string]interface{} {
 		"operation": thingDisconnect,
 	}
 }
+
+type groupMembersEvent struct {
+	groupID   string
+	memberIDs []string
+	operation string
+}
+
+func (gme groupMembersEvent) Encode() map[string]interface{} {
+	return map[string]interface{}{
+		"group_id":   gme.groupID,
+		"member_ids": strings.Join(gme.memberIDs, ","),
+		"operation":  gme.operation,
+	}
+}
@@ -6,6 +6,7 @@ package redis
 import (
 	"context"
 
+	"github.com/MainfluxLabs/mainflux/pkg/acl"
 	"github.com/MainfluxLabs/mainflux/things"
 	"github.com/go-redis/redis/v8"
 )
@@ -39,10 +40,11 @@ func (es eventStore) CreateThings(ctx context.Context, token string, ths ...thin
 
 	for _, thing := range sths {
 		event := createThingEvent{
-			id:       thing.ID,
-			owner:    thing.Owner,
-			name:     thing.Name,
-			metadata: thing.Metadata,
+			id:         thing.ID,
+			owner:      thing.Owner,
+			name:       thing.Name,
+			externalID: thing.ExternalID,
+			metadata:   thing.Metadata,
 		}
 		record := &redis.XAddArgs{
 			Stream:       streamID,
@@ -75,21 +77,105 @@ func (es eventStore) UpdateThing(ctx context.Context, token string, thing things
 	return nil
 }
 
-// UpdateKey doesn't send event because key shouldn't be sent over stream.
-// Maybe we can start publishing this event at some point, without key value
-// in order to notify adapters to disconnect connected things after key update.
+// UpdateKey sends event without the key value, which is used to invalidate
+// the old key cached by the adapters.
 func (es eventStore) UpdateKey(ctx context.Context, token, id, key string) error {
-	return es.svc.UpdateKey(ctx, token, id, key)
+	if err := es.svc.UpdateKey(ctx, token, id, key); err != nil {
+		return err
+	}
+
+	event := updateKeyEvent{
+		id: id,
+	}
+	record := &redis.XAddArgs{
+		Stream:       streamID,
+		MaxLenApprox: streamLen,
+		Values:       event.Encode(),
+	}
+	es.client.XAdd(ctx, record).Err()
+
+	return nil
+}
+
+// UpdateSigningKey sends event without the key value, which is used to
+// invalidate signing keys cached by the adapters.
+func (es eventStore) UpdateSigningKey(ctx context.Context, token string, sk things.SigningKey) error {
+	if err := es.svc.UpdateSigningKey(ctx, token, sk); err != nil {
+		return err
+	}
+
+	event := updateSigningKeyEvent{
+		id:        sk.ThingID,
+		algorithm: sk.Algorithm,
+	}
+	record := &redis.XAddArgs{
+		Stream:       streamID,
+		MaxLenApprox: streamLen,
+		Values:       event.Encode(),
+	}
+	es.client.XAdd(ctx, record).Err()
+
+	return nil
+}
+
+func (es eventStore) ViewSigningKey(ctx context.Context, token, thingID string) (things.SigningKey, error) {
+	return es.svc.ViewSigningKey(ctx, token, thingID)
+}
+
+func (es eventStore) RemoveSigningKey(ctx context.Context, token, thingID string) error {
+	if err := es.svc.RemoveSigningKey(ctx, token, thingID); err != nil {
+		return err
+	}
+
+	event := removeSigningKeyEvent{
+		id: thingID,
+	}
+	record := &redis.XAddArgs{
+		Stream:       streamID,
+		MaxLenApprox: streamLen,
+		Values:       event.Encode(),
+	}
+	es.client.XAdd(ctx, record).Err()
+
+	return nil
+}
+
+func (es eventStore) GetSigningKey(ctx context.Context, thingID string) (things.SigningKey, error) {
+	return es.svc.GetSigningKey(ctx, thingID)
+}
+
+func (es eventStore) GetTopicACL(ctx context.Context, chanID string) (acl.ACL, error) {
+	return es.svc.GetTopicACL(ctx, chanID)
+}
+
+func (es eventStore) GetChannelGroup(ctx context.Context, chanID string) (things.Group, error) {
+	return es.svc.GetChannelGroup(ctx, chanID)
+}
+
+func (es eventStore) GetChannelProfile(ctx context.Context, chanID string) (things.ChannelProfile, error) {
+	return es.svc.GetChannelProfile(ctx, chanID)
+}
+
+func (es eventStore) GetChannel(ctx context.Context, chanID string) (things.Channel, error) {
+	return es.svc.GetChannel(ctx, chanID)
 }
 
 func (es eventStore) ViewThing(ctx context.Context, token, id string) (things.Thing, error) {
 	return es.svc.ViewThing(ctx, token, id)
 }
 
+func (es eventStore) ViewThingByExternalID(ctx context.Context, token, externalID string) (things.Thing, error) {
+	return es.svc.ViewThingByExternalID(ctx, token, externalID)
+}
+
 func (es eventStore) ListThings(ctx context.Context, token string, admin bool, pm things.PageMetadata) (things.Page, error) {
 	return es.svc.ListThings(ctx, token, admin, pm)
 }
 
+func (es eventStore) SearchThings(ctx context.Context, token string, pm things.PageMetadata) (things.Page, error) {
+	return es.svc.SearchThings(ctx, token, pm)
+}
+
 func (es eventStore) ListThingsByIDs(ctx context.Context, ids []string) (things.Page, error) {
 	return es.svc.ListThingsByIDs(ctx, ids)
 }
@@ -106,14 +192,36 @@ func (es eventStore) Restore(ctx context.Context, token string, backup things.Ba
 	return es.svc.Restore(ctx, token, backup)
 }
 
+func (es eventStore) AuthorizeAdmin(ctx context.Context, token string) error {
+	return es.svc.AuthorizeAdmin(ctx, token)
+}
+
 func (es eventStore) RemoveThings(ctx context.Context, token string, ids ...string) error {
 	for _, id := range ids {
+		th, err := es.svc.ViewThing(ctx, token, id)
+		if err != nil {
+			return err
+		}
+
+		cp, err := es.svc.ListConnections(ctx, token, things.ConnectionsFilter{ThingID: id}, things.PageMetadata{})
+		if err != nil {
+			return err
+		}
+
 		if err := es.svc.RemoveThings(ctx, token, id); err != nil {
 			return err
 		}
 
+		// The single event of the removal covers the disconnects from the
+		// channels as well, so no disconnect events are published.
+		var chIDs []string
+		for _, conn := range cp.Connections {
+			chIDs = append(chIDs, conn.ChannelID)
+		}
 		event := removeThingEvent{
-			id: id,
+			id:       id,
+			owner:    th.Owner,
+			channels: chIDs,
 		}
 		record := &redis.XAddArgs{
 			Stream:       streamID,
@@ -170,6 +278,31 @@ func (es eventStore) UpdateChannel(ctx context.Context, token string, channel th
 	return nil
 }
 
+func (es eventStore) ListChannelVersions(ctx context.Context, token, chID string, pm things.PageMetadata) (things.ChannelVersionsPage, error) {
+	return es.svc.ListChannelVersions(ctx, token, chID, pm)
+}
+
+func (es eventStore) RollbackChannel(ctx context.Context, token, chID string, version uint64) (things.Channel, error) {
+	ch, err := es.svc.RollbackChannel(ctx, token, chID, version)
+	if err != nil {
+		return things.Channel{}, err
+	}
+
+	event := updateChannelEvent{
+		id:       ch.ID,
+		name:     ch.Name,
+		metadata: ch.Metadata,
+	}
+	record := &redis.XAddArgs{
+		Stream:       streamID,
+		MaxLenApprox: streamLen,
+		Values:       event.Encode(),
+	}
+	es.client.XAdd(ctx, record).Err()
+
+	return ch, nil
+}
+
 func (es eventStore) ViewChannel(ctx context.Context, token, id string) (things.Channel, error) {
 	return es.svc.ViewChannel(ctx, token, id)
 }
@@ -178,6 +311,10 @@ func (es eventStore) ListChannels(ctx context.Context, token string, admin bool,
 	return es.svc.ListChannels(ctx, token, admin, pm)
 }
 
+func (es eventStore) SearchChannels(ctx context.Context, token string, pm things.PageMetadata) (things.ChannelsPage, error) {
+	return es.svc.SearchChannels(ctx, token, pm)
+}
+
 func (es eventStore) ViewChannelByThing(ctx context.Context, token, thID string) (things.Channel, error) {
 	return es.svc.ViewChannelByThing(ctx, token, thID)
 }
@@ -245,6 +382,10 @@ func (es eventStore) Disconnect(ctx context.Context, token, chID string, thIDs [
 	return nil
 }
 
+func (es eventStore) ListConnections(ctx context.Context, token string, cf things.ConnectionsFilter, pm things.PageMetadata) (things.ConnectionsPage, error) {
+	return es.svc.ListConnections(ctx, token, cf, pm)
+}
+
 func (es eventStore) CanAccessByKey(ctx context.Context, chanID string, key string) (string, error) {
 	return es.svc.CanAccessByKey(ctx, chanID, key)
 }
@@ -277,6 +418,10 @@ func (es eventStore) ListGroups(ctx context.Context, token string, admin bool, p
 	return es.svc.ListGroups(ctx, token, admin, pm)
 }
 
+func (es eventStore) SearchGroups(ctx context.Context, token string, pm things.PageMetadata) (things.GroupPage, error) {
+	return es.svc.SearchGroups(ctx, token, pm)
+}
+
 func (es eventStore) ListGroupsByIDs(ctx context.Context, groupIDs []string) ([]things.Group, error) {
 	return es.svc.ListGroupsByIDs(ctx, groupIDs)
 }
@@ -313,10 +458,163 @@ func (es eventStore) UnassignChannel(ctx context.Context, token string, groupID
 	return es.svc.UnassignChannel(ctx, token, groupID, channelIDs...)
 }
 
+func (es eventStore) AssignThingsBatch(ctx context.Context, token, groupID string, thingIDs ...string) (things.BatchResult, error) {
+	res, err := es.svc.AssignThingsBatch(ctx, token, groupID, thingIDs...)
+	if err != nil {
+		return res, err
+	}
+	es.addBatchEvents(ctx, groupID, groupAssignThings, res)
+
+	return res, nil
+}
+
+func (es eventStore) UnassignThingsBatch(ctx context.Context, token, groupID string, thingIDs ...string) (things.BatchResult, error) {
+	res, err := es.svc.UnassignThingsBatch(ctx, token, groupID, thingIDs...)
+	if err != nil {
+		return res, err
+	}
+	es.addBatchEvents(ctx, groupID, groupUnassignThings, res)
+
+	return res, nil
+}
+
+func (es eventStore) AssignChannelsBatch(ctx context.Context, token, groupID string, channelIDs ...string) (things.BatchResult, error) {
+	res, err := es.svc.AssignChannelsBatch(ctx, token, groupID, channelIDs...)
+	if err != nil {
+		return res, err
+	}
+	es.addBatchEvents(ctx, groupID, groupAssignChannels, res)
+
+	return res, nil
+}
+
+func (es eventStore) UnassignChannelsBatch(ctx context.Context, token, groupID string, channelIDs ...string) (things.BatchResult, error) {
+	res, err := es.svc.UnassignChannelsBatch(ctx, token, groupID, channelIDs...)
+	if err != nil {
+		return res, err
+	}
+	es.addBatchEvents(ctx, groupID, groupUnassignChannels, res)
+
+	return res, nil
+}
+
+// addBatchEvents emits an event per processed chunk of the batch.
+func (es eventStore) addBatchEvents(ctx context.Context, groupID, operation string, res things.BatchResult) {
+	for _, chunk := range res.Chunks {
+		event := groupMembersEvent{
+			groupID:   groupID,
+			memberIDs: chunk,
+			operation: operation,
+		}
+		record := &redis.XAddArgs{
+			Stream:       streamID,
+			MaxLenApprox: streamLen,
+			Values:       event.Encode(),
+		}
+		es.client.XAdd(ctx, record).Err()
+	}
+}
+
+func (es eventStore) ViewGroupStats(ctx context.Context, token, groupID string) (things.GroupStats, error) {
+	return es.svc.ViewGroupStats(ctx, token, groupID)
+}
+
 func (es eventStore) ListGroupChannels(ctx context.Context, token, groupID string, pm things.PageMetadata) (things.GroupChannelsPage, error) {
 	return es.svc.ListGroupChannels(ctx, token, groupID, pm)
 }
 
+func (es eventStore) CreateGroupTemplate(ctx context.Context, token string, gt things.GroupTemplate) (things.GroupTemplate, error) {
+	return es.svc.CreateGroupTemplate(ctx, token, gt)
+}
+
+func (es eventStore) ViewGroupTemplate(ctx context.Context, token, id string) (things.GroupTemplate, error) {
+	return es.svc.ViewGroupTemplate(ctx, token, id)
+}
+
+func (es eventStore) ListGroupTemplates(ctx context.Context, token string, pm things.PageMetadata) (things.GroupTemplatesPage, error) {
+	return es.svc.ListGroupTemplates(ctx, token, pm)
+}
+
+func (es eventStore) RemoveGroupTemplate(ctx context.Context, token, id string) error {
+	return es.svc.RemoveGroupTemplate(ctx, token, id)
+}
+
+func (es eventStore) CreateGroupFromTemplate(ctx context.Context, token, templateID string, g things.Group) (things.Group, error) {
+	gr, err := es.svc.CreateGroupFromTemplate(ctx, token, templateID, g)
+	if err != nil {
+		return gr, err
+	}
+
+	gcp, err := es.svc.ListGroupChannels(ctx, token, gr.ID, things.PageMetadata{})
+	if err != nil {
+		return gr, nil
+	}
+
+	for _, channel := range gcp.Channels {
+		event := createChannelEvent{
+			id:       channel.ID,
+			owner:    channel.Owner,
+			name:     channel.Name,
+			metadata: channel.Metadata,
+		}
+		record := &redis.XAddArgs{
+			Stream:       streamID,
+			MaxLenApprox: streamLen,
+			Values:       event.Encode(),
+		}
+		es.client.XAdd(ctx, record).Err()
+	}
+
+	return gr, nil
+}
+
+func (es eventStore) ExportGroup(ctx context.Context, token, groupID string) (things.GroupExport, error) {
+	return es.svc.ExportGroup(ctx, token, groupID)
+}
+
+func (es eventStore) ImportGroup(ctx context.Context, token string, ge things.GroupExport, rekey bool) (things.GroupExport, error) {
+	imported, err := es.svc.ImportGroup(ctx, token, ge, rekey)
+	if err != nil {
+		return imported, err
+	}
+
+	var events []event
+	for _, thing := range imported.Things {
+		events = append(events, createThingEvent{
+			id:         thing.ID,
+			owner:      thing.Owner,
+			name:       thing.Name,
+			externalID: thing.ExternalID,
+			metadata:   thing.Metadata,
+		})
+	}
+	for _, channel := range imported.Channels {
+		events = append(events, createChannelEvent{
+			id:       channel.ID,
+			owner:    channel.Owner,
+			name:     channel.Name,
+			metadata: channel.Metadata,
+		})
+	}
+	for _, conn := range imported.Connections {
+		events = append(events, connectThingEvent{
+			chanID:  conn.ChannelID,
+			thingID: conn.ThingID,
+		})
+	}
+
+	for _, event := range events {
+		record := &redis.XAddArgs{
+			Stream:       streamID,
+			MaxLenApprox: streamLen,
+			Values:       event.Encode(),
+		}
+		es.client.XAdd(ctx, record).Err()
+	}
+
+	return imported, nil
+}
+
 func (es eventStore) ViewChannelMembership(ctx context.Context, token string, channelID string) (things.Group, error) {
 	return es.svc.ViewChannelMembership(ctx, token, channelID)
 }
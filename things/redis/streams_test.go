@@ -33,6 +33,7 @@ const (
 	thingCreate     = thingPrefix + "create"
 	thingUpdate     = thingPrefix + "update"
 	thingRemove     = thingPrefix + "remove"
+	thingUpdateKey  = thingPrefix + "update_key"
 	thingConnect    = thingPrefix + "connect"
 	thingDisconnect = thingPrefix + "disconnect"
 
@@ -40,6 +41,9 @@ const (
 	channelCreate = channelPrefix + "create"
 	channelUpdate = channelPrefix + "update"
 	channelRemove = channelPrefix + "remove"
+
+	groupPrefix         = "group."
+	groupAssignChannels = groupPrefix + "assign_channels"
 )
 
 var (
@@ -55,11 +59,12 @@ func newService(tokens map[string]string) things.Service {
 	thingsRepo := thmocks.NewThingRepository(conns)
 	channelsRepo := thmocks.NewChannelRepository(thingsRepo, conns)
 	groupsRepo := thmocks.NewGroupRepository()
+	templatesRepo := thmocks.NewGroupTemplateRepository()
 	chanCache := thmocks.NewChannelCache()
 	thingCache := thmocks.NewThingCache()
 	idProvider := uuid.NewMock()
 
-	return things.New(auth, thingsRepo, channelsRepo, groupsRepo, chanCache, thingCache, idProvider)
+	return things.New(auth, thingsRepo, channelsRepo, groupsRepo, templatesRepo, chanCache, thingCache, idProvider, idProvider, idProvider, idProvider, nil, nil)
 }
 
 func TestCreateThings(t *testing.T) {
@@ -180,6 +185,67 @@ func TestUpdateThing(t *testing.T) {
 	}
 }
 
+func TestUpdateKey(t *testing.T) {
+	_ = redisClient.FlushAll(context.Background()).Err()
+
+	svc := newService(map[string]string{token: email})
+	// Create thing without sending event.
+	sths, err := svc.CreateThings(context.Background(), token, things.Thing{Name: "a"})
+	require.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	sth := sths[0]
+
+	svc = redis.NewEventStoreMiddleware(svc, redisClient)
+
+	cases := []struct {
+		desc  string
+		id    string
+		key   string
+		token string
+		err   error
+		event map[string]interface{}
+	}{
+		{
+			desc:  "update key of existing thing successfully",
+			id:    sth.ID,
+			key:   "new-key",
+			token: token,
+			err:   nil,
+			event: map[string]interface{}{
+				"id":        sth.ID,
+				"operation": thingUpdateKey,
+			},
+		},
+		{
+			desc:  "update key of non-existing thing",
+			id:    strconv.FormatUint(math.MaxUint64, 10),
+			key:   "other-key",
+			token: token,
+			err:   errors.ErrNotFound,
+			event: nil,
+		},
+	}
+
+	lastID := "0"
+	for _, tc := range cases {
+		err := svc.UpdateKey(context.Background(), tc.token, tc.id, tc.key)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		streams := redisClient.XRead(context.Background(), &r.XReadArgs{
+			Streams: []string{streamID, lastID},
+			Count:   1,
+			Block:   time.Second,
+		}).Val()
+
+		var event map[string]interface{}
+		if len(streams) > 0 && len(streams[0].Messages) > 0 {
+			msg := streams[0].Messages[0]
+			event = msg.Values
+			lastID = msg.ID
+		}
+
+		assert.Equal(t, tc.event, event, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.event, event))
+	}
+}
+
 func TestViewThing(t *testing.T) {
 	_ = redisClient.FlushAll(context.Background()).Err()
 
@@ -253,6 +319,23 @@ func TestRemoveThing(t *testing.T) {
 	require.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
 	sth := sths[0]
 
+	schs, err := svc.CreateChannels(context.Background(), token, things.Channel{Name: "a"})
+	require.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	sch := schs[0]
+
+	grs, err := svc.CreateGroups(context.Background(), token, group)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	gr := grs[0]
+
+	err = svc.AssignThing(context.Background(), token, gr.ID, sth.ID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	err = svc.AssignChannel(context.Background(), token, gr.ID, sch.ID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	err = svc.Connect(context.Background(), token, sch.ID, []string{sth.ID})
+	require.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+
 	svc = redis.NewEventStoreMiddleware(svc, redisClient)
 
 	cases := []struct {
@@ -269,6 +352,8 @@ func TestRemoveThing(t *testing.T) {
 			err:  nil,
 			event: map[string]interface{}{
 				"id":        sth.ID,
+				"owner":     sth.Owner,
+				"channels":  sch.ID,
 				"operation": thingRemove,
 			},
 		},
@@ -706,3 +791,65 @@ func TestDisconnectEvent(t *testing.T) {
 		assert.Equal(t, tc.event, event, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.event, event))
 	}
 }
+
+func TestAssignChannelsBatchEvent(t *testing.T) {
+	_ = redisClient.FlushAll(context.Background()).Err()
+
+	svc := newService(map[string]string{token: email})
+	schs, err := svc.CreateChannels(context.Background(), token, things.Channel{Name: "a"}, things.Channel{Name: "b"})
+	require.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+
+	grs, err := svc.CreateGroups(context.Background(), token, group)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	gr := grs[0]
+
+	svc = redis.NewEventStoreMiddleware(svc, redisClient)
+
+	cases := []struct {
+		desc  string
+		chIDs []string
+		key   string
+		err   error
+		event map[string]interface{}
+	}{
+		{
+			desc:  "assign channels in batch",
+			chIDs: []string{schs[0].ID, strconv.FormatUint(math.MaxUint64, 10), schs[1].ID},
+			key:   token,
+			err:   nil,
+			event: map[string]interface{}{
+				"group_id":   gr.ID,
+				"member_ids": fmt.Sprintf("%s,%s", schs[0].ID, schs[1].ID),
+				"operation":  groupAssignChannels,
+			},
+		},
+		{
+			desc:  "assign channels in batch with invalid credentials",
+			chIDs: []string{schs[0].ID},
+			key:   "",
+			err:   errors.ErrAuthentication,
+			event: nil,
+		},
+	}
+
+	lastID := "0"
+	for _, tc := range cases {
+		_, err := svc.AssignChannelsBatch(context.Background(), tc.key, gr.ID, tc.chIDs...)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+
+		streams := redisClient.XRead(context.Background(), &r.XReadArgs{
+			Streams: []string{streamID, lastID},
+			Count:   1,
+			Block:   time.Second,
+		}).Val()
+
+		var event map[string]interface{}
+		if len(streams) > 0 && len(streams[0].Messages) > 0 {
+			msg := streams[0].Messages[0]
+			event = msg.Values
+			lastID = msg.ID
+		}
+
+		assert.Equal(t, tc.event, event, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.event, event))
+	}
+}
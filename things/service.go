@@ -5,10 +5,12 @@ package things
 
 import (
 	"context"
+	"strings"
 	"time"
 
 	"github.com/MainfluxLabs/mainflux"
 	"github.com/MainfluxLabs/mainflux/auth"
+	"github.com/MainfluxLabs/mainflux/pkg/acl"
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
 )
 
@@ -19,21 +21,69 @@ type Service interface {
 	CreateThings(ctx context.Context, token string, things ...Thing) ([]Thing, error)
 
 	// UpdateThing updates the thing identified by the provided ID, that
-	// belongs to the user identified by the provided key.
+	// belongs to the user identified by the provided key. Admin updates
+	// the thing on behalf of its owner.
 	UpdateThing(ctx context.Context, token string, thing Thing) error
 
 	// UpdateKey updates key value of the existing thing. A non-nil error is
-	// returned to indicate operation failure.
+	// returned to indicate operation failure. Admin updates the key on behalf
+	// of the thing owner.
 	UpdateKey(ctx context.Context, token, id, key string) error
 
+	// UpdateSigningKey sets the key used to verify signatures of the messages
+	// published by the thing. Once the signing key is set, adapters reject
+	// unsigned messages and messages with invalid signatures.
+	UpdateSigningKey(ctx context.Context, token string, sk SigningKey) error
+
+	// ViewSigningKey retrieves the signing key of the thing identified by
+	// the provided ID. Only the users who can modify the thing can view it.
+	ViewSigningKey(ctx context.Context, token, thingID string) (SigningKey, error)
+
+	// RemoveSigningKey removes the signing key of the thing identified by
+	// the provided ID, disabling the signature verification.
+	RemoveSigningKey(ctx context.Context, token, thingID string) error
+
+	// GetSigningKey retrieves the signing key of the thing identified by
+	// the provided ID. It's used by the adapters to verify signatures, so it
+	// performs no authorization and must be exposed to trusted services only.
+	GetSigningKey(ctx context.Context, thingID string) (SigningKey, error)
+
+	// GetTopicACL retrieves the topic ACL of the channel identified by the
+	// provided ID. It's used by the adapters to restrict subtopics things
+	// may publish and subscribe to.
+	GetTopicACL(ctx context.Context, chanID string) (acl.ACL, error)
+
+	// GetChannelGroup retrieves the group the channel identified by the
+	// provided ID belongs to, or the empty group if the channel doesn't
+	// belong to any group.
+	GetChannelGroup(ctx context.Context, chanID string) (Group, error)
+
+	// GetChannelProfile retrieves the profile of the channel identified by
+	// the provided ID. It's used by the adapters to mirror the messages
+	// published to the channel.
+	GetChannelProfile(ctx context.Context, chanID string) (ChannelProfile, error)
+
+	// GetChannel retrieves the channel identified by the provided ID. It's
+	// used by the services keeping the copies of the channel data, e.g.
+	// bootstrap, to reconcile them with the things service.
+	GetChannel(ctx context.Context, chanID string) (Channel, error)
+
 	// ViewThing retrieves data about the thing identified with the provided
 	// ID, that belongs to the user identified by the provided key.
 	ViewThing(ctx context.Context, token, id string) (Thing, error)
 
+	// ViewThingByExternalID retrieves data about the thing identified with the
+	// provided external ID, that belongs to the user identified by the provided key.
+	ViewThingByExternalID(ctx context.Context, token, externalID string) (Thing, error)
+
 	// ListThings retrieves data about subset of things that belongs to the
 	// user identified by the provided key.
 	ListThings(ctx context.Context, token string, admin bool, pm PageMetadata) (Page, error)
 
+	// SearchThings retrieves things of all users, filtered by the name, metadata,
+	// owner and key prefix. Only accessible by admin.
+	SearchThings(ctx context.Context, token string, pm PageMetadata) (Page, error)
+
 	// ListThingsByIDs retrieves data about subset of things that are identified
 	ListThingsByIDs(ctx context.Context, ids []string) (Page, error)
 
@@ -43,14 +93,16 @@ type Service interface {
 	ListThingsByChannel(ctx context.Context, token, chID string, pm PageMetadata) (Page, error)
 
 	// RemoveThings removes the things identified with the provided IDs, that
-	// belongs to the user identified by the provided key.
+	// belongs to the user identified by the provided key. Admin removes
+	// things on behalf of their owners.
 	RemoveThings(ctx context.Context, token string, id ...string) error
 
 	// CreateChannels adds channels to the user identified by the provided key.
 	CreateChannels(ctx context.Context, token string, channels ...Channel) ([]Channel, error)
 
 	// UpdateChannel updates the channel identified by the provided ID, that
-	// belongs to the user identified by the provided key.
+	// belongs to the user identified by the provided key. Admin updates
+	// the channel on behalf of its owner.
 	UpdateChannel(ctx context.Context, token string, channel Channel) error
 
 	// ViewChannel retrieves data about the channel identified by the provided
@@ -61,13 +113,26 @@ type Service interface {
 	// user identified by the provided key.
 	ListChannels(ctx context.Context, token string, admin bool, pm PageMetadata) (ChannelsPage, error)
 
+	// SearchChannels retrieves channels of all users, filtered by the name,
+	// metadata and owner. Only accessible by admin.
+	SearchChannels(ctx context.Context, token string, pm PageMetadata) (ChannelsPage, error)
+
 	// ViewChannelByThing retrieves data about channel that have
 	// specified thing connected or not connected to it and belong to the user identified by
 	// the provided key.
 	ViewChannelByThing(ctx context.Context, token, thID string) (Channel, error)
 
-	// RemoveChannels removes the things identified by the provided IDs, that
-	// belongs to the user identified by the provided key.
+	// ListChannelVersions retrieves the previous configurations of the channel
+	// identified by the provided ID, starting from the latest one.
+	ListChannelVersions(ctx context.Context, token, chID string, pm PageMetadata) (ChannelVersionsPage, error)
+
+	// RollbackChannel restores the configuration of the channel identified by
+	// the provided ID to the given version, and returns the restored channel.
+	RollbackChannel(ctx context.Context, token, chID string, version uint64) (Channel, error)
+
+	// RemoveChannels removes the channels identified by the provided IDs, that
+	// belongs to the user identified by the provided key. Admin removes
+	// channels on behalf of their owners.
 	RemoveChannels(ctx context.Context, token string, ids ...string) error
 
 	// Connect connects a list of things to a channel.
@@ -76,6 +141,11 @@ type Service interface {
 	// Disconnect disconnects a list of things from a channel.
 	Disconnect(ctx context.Context, token, chID string, thIDs []string) error
 
+	// ListConnections retrieves the subset of connections between things and
+	// channels of the user, filtered by the thing, channel and group. The root
+	// admin retrieves connections of all users.
+	ListConnections(ctx context.Context, token string, cf ConnectionsFilter, pm PageMetadata) (ConnectionsPage, error)
+
 	// CanAccessByKey determines whether the channel can be accessed using the
 	// provided key and returns thing's id if access is allowed.
 	CanAccessByKey(ctx context.Context, chanID, key string) (string, error)
@@ -97,10 +167,16 @@ type Service interface {
 	// Restore adds things, channels and connections from a backup. Only accessible by admin.
 	Restore(ctx context.Context, token string, backup Backup) error
 
+	// AuthorizeAdmin checks whether the user identified by the provided key
+	// is the root admin. It guards the admin routes which update and remove
+	// things, channels and groups on behalf of their owners.
+	AuthorizeAdmin(ctx context.Context, token string) error
+
 	// CreateGroups adds groups to the user identified by the provided key.
 	CreateGroups(ctx context.Context, token string, groups ...Group) ([]Group, error)
 
-	// UpdateGroup updates the group identified by the provided ID.
+	// UpdateGroup updates the group identified by the provided ID. Admin
+	// updates the group on behalf of its owner.
 	UpdateGroup(ctx context.Context, token string, g Group) (Group, error)
 
 	// ViewGroup retrieves data about the group identified by ID.
@@ -109,6 +185,10 @@ type Service interface {
 	// ListGroups retrieves groups.
 	ListGroups(ctx context.Context, token string, admin bool, pm PageMetadata) (GroupPage, error)
 
+	// SearchGroups retrieves groups of all users, filtered by the name,
+	// metadata and owner. Only accessible by admin.
+	SearchGroups(ctx context.Context, token string, pm PageMetadata) (GroupPage, error)
+
 	// ListGroupsByIDs retrieves groups by their IDs.
 	ListGroupsByIDs(ctx context.Context, ids []string) ([]Group, error)
 
@@ -121,7 +201,8 @@ type Service interface {
 	// ViewThingMembership retrieves group that thing belongs to.
 	ViewThingMembership(ctx context.Context, token, thingID string) (Group, error)
 
-	// RemoveGroups removes the groups identified with the provided IDs.
+	// RemoveGroups removes the groups identified with the provided IDs. Admin
+	// removes groups on behalf of their owners.
 	RemoveGroups(ctx context.Context, token string, ids ...string) error
 
 	// AssignThing adds a thing with thingID into the group identified by groupID.
@@ -141,6 +222,57 @@ type Service interface {
 
 	// UnassignChannel removes channels from the group identified by groupID.
 	UnassignChannel(ctx context.Context, token string, groupID string, channelIDs ...string) error
+
+	// AssignThingsBatch adds things to the group identified by groupID in
+	// chunks. Things which failed to be assigned are reported in the result
+	// instead of failing the whole batch.
+	AssignThingsBatch(ctx context.Context, token, groupID string, thingIDs ...string) (BatchResult, error)
+
+	// UnassignThingsBatch removes things from the group identified by groupID
+	// in chunks, reporting things which failed to be unassigned.
+	UnassignThingsBatch(ctx context.Context, token, groupID string, thingIDs ...string) (BatchResult, error)
+
+	// AssignChannelsBatch adds channels to the group identified by groupID in
+	// chunks, reporting channels which failed to be assigned.
+	AssignChannelsBatch(ctx context.Context, token, groupID string, channelIDs ...string) (BatchResult, error)
+
+	// UnassignChannelsBatch removes channels from the group identified by
+	// groupID in chunks, reporting channels which failed to be unassigned.
+	UnassignChannelsBatch(ctx context.Context, token, groupID string, channelIDs ...string) (BatchResult, error)
+
+	// CreateGroupTemplate adds the group template to the user identified by
+	// the provided key.
+	CreateGroupTemplate(ctx context.Context, token string, gt GroupTemplate) (GroupTemplate, error)
+
+	// ViewGroupTemplate retrieves the group template identified by ID.
+	ViewGroupTemplate(ctx context.Context, token, id string) (GroupTemplate, error)
+
+	// ListGroupTemplates retrieves the group templates of the user.
+	ListGroupTemplates(ctx context.Context, token string, pm PageMetadata) (GroupTemplatesPage, error)
+
+	// RemoveGroupTemplate removes the group template identified by ID.
+	RemoveGroupTemplate(ctx context.Context, token, id string) error
+
+	// CreateGroupFromTemplate creates the group along with the channels defined
+	// by the template identified by templateID. Things assigned to the group
+	// are automatically connected to the channels marked for auto connecting.
+	CreateGroupFromTemplate(ctx context.Context, token, templateID string, g Group) (Group, error)
+
+	// ViewGroupStats retrieves the statistics of the group identified by
+	// groupID. Statistics are computed at most once a minute per group.
+	ViewGroupStats(ctx context.Context, token, groupID string) (GroupStats, error)
+
+	// ExportGroup retrieves the group identified by groupID along with its
+	// things, channels and connections. Thing keys are never exported.
+	ExportGroup(ctx context.Context, token, groupID string) (GroupExport, error)
+
+	// ImportGroup creates the exported group, along with its things, channels
+	// and connections, for the user identified by the provided key. All the
+	// entities are assigned with new IDs, and things without keys are assigned
+	// with new keys. If rekey is true, the keys provided by the bundle are
+	// replaced with new ones as well. The imported bundle with remapped IDs is
+	// returned.
+	ImportGroup(ctx context.Context, token string, ge GroupExport, rekey bool) (GroupExport, error)
 }
 
 // PageMetadata contains page metadata that helps navigation.
@@ -152,6 +284,10 @@ type PageMetadata struct {
 	Order        string                 `json:"order,omitempty"`
 	Dir          string                 `json:"dir,omitempty"`
 	Metadata     map[string]interface{} `json:"metadata,omitempty"`
+	Owner        string                 `json:"owner,omitempty"`
+	KeyPrefix    string                 `json:"key_prefix,omitempty"`
+	Within       *Area                  `json:"within,omitempty"`
+	Query        string                 `json:"q,omitempty"`
 	Disconnected bool                   // Used for connected or disconnected lists
 	Unassigned   bool                   // Used for assigned or unassigned lists
 }
@@ -172,21 +308,41 @@ type thingsService struct {
 	things       ThingRepository
 	channels     ChannelRepository
 	groups       GroupRepository
+	templates    GroupTemplateRepository
 	channelCache ChannelCache
 	thingCache   ThingCache
+	thingIDs     mainflux.IDProvider
+	channelIDs   mainflux.IDProvider
 	idProvider   mainflux.IDProvider
+	keyProvider  mainflux.IDProvider
+	externalKeys ExternalKeyValidator
+	messages     MessageCounter
+	stats        *statsCache
 }
 
-// New instantiates the things service implementation.
-func New(auth mainflux.AuthServiceClient, things ThingRepository, channels ChannelRepository, groups GroupRepository, ccache ChannelCache, tcache ThingCache, idp mainflux.IDProvider) Service {
+// New instantiates the things service implementation. Thing and channel ID
+// providers generate the IDs of the things and channels respectively, ID
+// provider generates the IDs of the groups and templates, while key provider
+// generates the thing keys. Validator of the externally issued thing keys is
+// optional; if it's nil, only the keys issued by the service are accepted.
+// Message counter is optional as well; if it's nil, message volume is omitted
+// from the group statistics.
+func New(auth mainflux.AuthServiceClient, things ThingRepository, channels ChannelRepository, groups GroupRepository, templates GroupTemplateRepository, ccache ChannelCache, tcache ThingCache, thp, chp, idp, kp mainflux.IDProvider, keys ExternalKeyValidator, messages MessageCounter) Service {
 	return &thingsService{
 		auth:         auth,
 		things:       things,
 		channels:     channels,
 		groups:       groups,
+		templates:    templates,
 		channelCache: ccache,
 		thingCache:   tcache,
+		thingIDs:     thp,
+		channelIDs:   chp,
 		idProvider:   idp,
+		keyProvider:  kp,
+		externalKeys: keys,
+		messages:     messages,
+		stats:        newStatsCache(),
 	}
 }
 
@@ -198,7 +354,7 @@ func (ts *thingsService) CreateThings(ctx context.Context, token string, things
 
 	ths := []Thing{}
 	for _, thing := range things {
-		th, err := ts.createThing(ctx, &thing, res)
+		th, err := ts.createThing(ctx, token, &thing, res)
 
 		if err != nil {
 			return []Thing{}, err
@@ -209,11 +365,11 @@ func (ts *thingsService) CreateThings(ctx context.Context, token string, things
 	return ths, nil
 }
 
-func (ts *thingsService) createThing(ctx context.Context, thing *Thing, identity *mainflux.UserIdentity) (Thing, error) {
+func (ts *thingsService) createThing(ctx context.Context, token string, thing *Thing, identity *mainflux.UserIdentity) (Thing, error) {
 	thing.Owner = identity.GetId()
 
 	if thing.ID == "" {
-		id, err := ts.idProvider.ID()
+		id, err := ts.thingIDs.ID()
 		if err != nil {
 			return Thing{}, err
 		}
@@ -221,7 +377,7 @@ func (ts *thingsService) createThing(ctx context.Context, thing *Thing, identity
 	}
 
 	if thing.Key == "" {
-		key, err := ts.idProvider.ID()
+		key, err := ts.keyProvider.ID()
 
 		if err != nil {
 			return Thing{}, err
@@ -236,6 +392,16 @@ func (ts *thingsService) createThing(ctx context.Context, thing *Thing, identity
 	if len(ths) == 0 {
 		return Thing{}, errors.ErrCreateEntity
 	}
+
+	// Thing whose ownership can't be claimed is removed, so that no thing
+	// is left without the policy of its owner.
+	if err := claimOwnership(ctx, ts.auth, thing.Owner, auth.ThingSubject, ths[0].ID); err != nil {
+		if rerr := ts.things.Remove(ctx, thing.Owner, ths[0].ID); rerr != nil {
+			return Thing{}, errors.Wrap(err, rerr)
+		}
+		return Thing{}, err
+	}
+
 	return ths[0], nil
 }
 
@@ -245,11 +411,21 @@ func (ts *thingsService) UpdateThing(ctx context.Context, token string, thing Th
 		return err
 	}
 
-	if err := ts.isThingOwner(ctx, res.GetId(), thing.ID); err != nil {
+	th, err := ts.things.RetrieveByID(ctx, thing.ID)
+	if err != nil {
 		return err
 	}
 
-	thing.Owner = res.GetId()
+	if th.Owner != res.GetId() {
+		if err := ts.authorize(ctx, auth.RootSubject, token); err != nil {
+			if err := ts.canAccessObject(ctx, token, auth.ThingSubject, thing.ID, auth.WriteAction); err != nil {
+				return errors.ErrNotFound
+			}
+		}
+	}
+
+	thing.Owner = th.Owner
+	thing.ExternalID = th.ExternalID
 
 	return ts.things.Update(ctx, thing)
 }
@@ -261,10 +437,111 @@ func (ts *thingsService) UpdateKey(ctx context.Context, token, id, key string) e
 	}
 
 	owner := res.GetId()
+	if err := ts.authorize(ctx, auth.RootSubject, token); err == nil {
+		th, err := ts.things.RetrieveByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		owner = th.Owner
+	}
 
 	return ts.things.UpdateKey(ctx, owner, id, key)
 }
 
+func (ts *thingsService) UpdateSigningKey(ctx context.Context, token string, sk SigningKey) error {
+	if err := ts.canModifyThing(ctx, token, sk.ThingID); err != nil {
+		return err
+	}
+
+	return ts.things.SaveSigningKey(ctx, sk)
+}
+
+func (ts *thingsService) ViewSigningKey(ctx context.Context, token, thingID string) (SigningKey, error) {
+	// The signing key is a secret, so it's not disclosed to the viewers.
+	if err := ts.canModifyThing(ctx, token, thingID); err != nil {
+		return SigningKey{}, err
+	}
+
+	return ts.things.RetrieveSigningKey(ctx, thingID)
+}
+
+func (ts *thingsService) RemoveSigningKey(ctx context.Context, token, thingID string) error {
+	if err := ts.canModifyThing(ctx, token, thingID); err != nil {
+		return err
+	}
+
+	return ts.things.RemoveSigningKey(ctx, thingID)
+}
+
+func (ts *thingsService) GetSigningKey(ctx context.Context, thingID string) (SigningKey, error) {
+	return ts.things.RetrieveSigningKey(ctx, thingID)
+}
+
+func (ts *thingsService) GetTopicACL(ctx context.Context, chanID string) (acl.ACL, error) {
+	ch, err := ts.channels.RetrieveByID(ctx, chanID)
+	if err != nil {
+		return acl.ACL{}, err
+	}
+
+	return acl.Parse(ch.Metadata)
+}
+
+func (ts *thingsService) GetChannelGroup(ctx context.Context, chanID string) (Group, error) {
+	if _, err := ts.channels.RetrieveByID(ctx, chanID); err != nil {
+		return Group{}, err
+	}
+
+	groupID, err := ts.groups.RetrieveChannelMembership(ctx, chanID)
+	if err != nil && !errors.Contains(err, errors.ErrNotFound) {
+		return Group{}, err
+	}
+
+	if groupID == "" {
+		return Group{}, nil
+	}
+
+	return ts.groups.RetrieveByID(ctx, groupID)
+}
+
+func (ts *thingsService) GetChannelProfile(ctx context.Context, chanID string) (ChannelProfile, error) {
+	ch, err := ts.channels.RetrieveByID(ctx, chanID)
+	if err != nil {
+		return ChannelProfile{}, err
+	}
+
+	return ParseProfile(ch.Metadata)
+}
+
+func (ts *thingsService) GetChannel(ctx context.Context, chanID string) (Channel, error) {
+	return ts.channels.RetrieveByID(ctx, chanID)
+}
+
+func (ts *thingsService) canModifyThing(ctx context.Context, token, thingID string) error {
+	res, err := ts.auth.Identify(ctx, &mainflux.Token{Value: token})
+	if err != nil {
+		return errors.Wrap(errors.ErrAuthentication, err)
+	}
+
+	th, err := ts.things.RetrieveByID(ctx, thingID)
+	if err != nil {
+		return err
+	}
+
+	if th.Owner == res.GetId() {
+		return nil
+	}
+
+	if err := ts.authorize(ctx, auth.RootSubject, token); err == nil {
+		return nil
+	}
+
+	if err := ts.canAccessObject(ctx, token, auth.ThingSubject, thingID, auth.WriteAction); err != nil {
+		return errors.ErrAuthorization
+	}
+
+	return nil
+}
+
 func (ts *thingsService) ViewThing(ctx context.Context, token, id string) (Thing, error) {
 	res, err := ts.auth.Identify(ctx, &mainflux.Token{Value: token})
 	if err != nil {
@@ -284,6 +561,10 @@ func (ts *thingsService) ViewThing(ctx context.Context, token, id string) (Thing
 		return thing, nil
 	}
 
+	if err := ts.canAccessObject(ctx, token, auth.ThingSubject, id, auth.ReadAction); err == nil {
+		return thing, nil
+	}
+
 	groupID, err := ts.groups.RetrieveThingMembership(ctx, id)
 	if err != nil {
 		return Thing{}, errors.ErrAuthorization
@@ -296,6 +577,15 @@ func (ts *thingsService) ViewThing(ctx context.Context, token, id string) (Thing
 	return Thing{}, errors.ErrAuthorization
 }
 
+func (ts *thingsService) ViewThingByExternalID(ctx context.Context, token, externalID string) (Thing, error) {
+	res, err := ts.auth.Identify(ctx, &mainflux.Token{Value: token})
+	if err != nil {
+		return Thing{}, errors.Wrap(errors.ErrAuthentication, err)
+	}
+
+	return ts.things.RetrieveByExternalID(ctx, res.GetId(), externalID)
+}
+
 func (ts *thingsService) ListThings(ctx context.Context, token string, admin bool, pm PageMetadata) (Page, error) {
 	res, err := ts.auth.Identify(ctx, &mainflux.Token{Value: token})
 	if err != nil {
@@ -311,6 +601,14 @@ func (ts *thingsService) ListThings(ctx context.Context, token string, admin boo
 	return ts.things.RetrieveByOwner(ctx, res.GetId(), pm)
 }
 
+func (ts *thingsService) SearchThings(ctx context.Context, token string, pm PageMetadata) (Page, error) {
+	if err := ts.authorize(ctx, auth.RootSubject, token); err != nil {
+		return Page{}, err
+	}
+
+	return ts.things.RetrieveByAdmin(ctx, pm)
+}
+
 func (ts *thingsService) ListThingsByIDs(ctx context.Context, ids []string) (Page, error) {
 	things, err := ts.things.RetrieveByIDs(ctx, ids, PageMetadata{})
 	if err != nil {
@@ -334,19 +632,97 @@ func (ts *thingsService) RemoveThings(ctx context.Context, token string, ids ...
 		return errors.Wrap(errors.ErrAuthentication, err)
 	}
 
-	for _, id := range ids {
-		if err := ts.thingCache.Remove(ctx, id); err != nil {
-			return err
+	owners, err := ts.thingOwners(ctx, ids...)
+	if err != nil {
+		return err
+	}
+
+	// Things of other users are concealed from the users other than the root
+	// admin, and no cache entries are purged for them.
+	if err := ts.authorize(ctx, auth.RootSubject, token); err != nil {
+		for owner := range owners {
+			if owner != res.GetId() {
+				return errors.ErrNotFound
+			}
+		}
+	}
+
+	for owner, ownerIDs := range owners {
+		for _, id := range ownerIDs {
+			if err := ts.removeThing(ctx, owner, id); err != nil {
+				return err
+			}
 		}
 	}
 
-	if err := ts.things.Remove(ctx, res.GetId(), ids...); err != nil {
+	return nil
+}
+
+// removeThing removes the thing as the saga of steps: the thing is
+// disconnected from its channels, its cache entries are purged and the thing
+// is removed. If any of the steps fails, the thing is connected to the
+// disconnected channels again. Purged cache entries are not restored, since
+// they are repopulated on the next access.
+func (ts *thingsService) removeThing(ctx context.Context, owner, id string) error {
+	cp, err := ts.channels.RetrieveConns(ctx, id, PageMetadata{})
+	if err != nil {
+		return err
+	}
+
+	var disconnected []string
+	compensate := func(err error) error {
+		for _, chID := range disconnected {
+			if cerr := ts.channels.Connect(ctx, owner, chID, []string{id}); cerr != nil {
+				return errors.Wrap(err, cerr)
+			}
+		}
 		return err
 	}
 
+	for _, ch := range cp.Channels {
+		if err := ts.channels.Disconnect(ctx, owner, ch.ID, []string{id}); err != nil {
+			return compensate(err)
+		}
+		disconnected = append(disconnected, ch.ID)
+	}
+
+	if err := ts.purgeThingCache(ctx, id, disconnected); err != nil {
+		return compensate(err)
+	}
+
+	if err := ts.things.Remove(ctx, owner, id); err != nil {
+		return compensate(err)
+	}
+
 	return nil
 }
 
+// purgeThingCache removes the cached key of the thing along with its cached
+// connections, so the removed thing can't access channels it was connected to.
+func (ts *thingsService) purgeThingCache(ctx context.Context, id string, chIDs []string) error {
+	for _, chID := range chIDs {
+		if err := ts.channelCache.Disconnect(ctx, chID, id); err != nil {
+			return err
+		}
+	}
+
+	return ts.thingCache.Remove(ctx, id)
+}
+
+// thingOwners groups the IDs of things by their owners.
+func (ts *thingsService) thingOwners(ctx context.Context, ids ...string) (map[string][]string, error) {
+	owners := make(map[string][]string)
+	for _, id := range ids {
+		th, err := ts.things.RetrieveByID(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		owners[th.Owner] = append(owners[th.Owner], id)
+	}
+
+	return owners, nil
+}
+
 func (ts *thingsService) CreateChannels(ctx context.Context, token string, channels ...Channel) ([]Channel, error) {
 	res, err := ts.auth.Identify(ctx, &mainflux.Token{Value: token})
 	if err != nil {
@@ -355,7 +731,7 @@ func (ts *thingsService) CreateChannels(ctx context.Context, token string, chann
 
 	chs := []Channel{}
 	for _, channel := range channels {
-		ch, err := ts.createChannel(ctx, &channel, res)
+		ch, err := ts.createChannel(ctx, token, &channel, res)
 		if err != nil {
 			return []Channel{}, err
 		}
@@ -364,9 +740,9 @@ func (ts *thingsService) CreateChannels(ctx context.Context, token string, chann
 	return chs, nil
 }
 
-func (ts *thingsService) createChannel(ctx context.Context, channel *Channel, identity *mainflux.UserIdentity) (Channel, error) {
+func (ts *thingsService) createChannel(ctx context.Context, token string, channel *Channel, identity *mainflux.UserIdentity) (Channel, error) {
 	if channel.ID == "" {
-		chID, err := ts.idProvider.ID()
+		chID, err := ts.channelIDs.ID()
 		if err != nil {
 			return Channel{}, err
 		}
@@ -374,6 +750,10 @@ func (ts *thingsService) createChannel(ctx context.Context, channel *Channel, id
 	}
 	channel.Owner = identity.GetId()
 
+	if err := ts.canMirror(ctx, token, identity.GetId(), *channel); err != nil {
+		return Channel{}, err
+	}
+
 	chs, err := ts.channels.Save(ctx, *channel)
 	if err != nil {
 		return Channel{}, err
@@ -382,6 +762,14 @@ func (ts *thingsService) createChannel(ctx context.Context, channel *Channel, id
 		return Channel{}, errors.ErrCreateEntity
 	}
 
+	// Channel whose ownership can't be claimed is removed, same as the thing.
+	if err := claimOwnership(ctx, ts.auth, channel.Owner, auth.ChannelSubject, chs[0].ID); err != nil {
+		if rerr := ts.channels.Remove(ctx, channel.Owner, chs[0].ID); rerr != nil {
+			return Channel{}, errors.Wrap(err, rerr)
+		}
+		return Channel{}, err
+	}
+
 	return chs[0], nil
 }
 
@@ -391,8 +779,25 @@ func (ts *thingsService) UpdateChannel(ctx context.Context, token string, channe
 		return errors.Wrap(errors.ErrAuthentication, err)
 	}
 
-	channel.Owner = res.GetId()
-	return ts.channels.Update(ctx, channel)
+	ch, err := ts.channels.RetrieveByID(ctx, channel.ID)
+	if err != nil {
+		return err
+	}
+
+	if ch.Owner != res.GetId() {
+		if err := ts.authorize(ctx, auth.RootSubject, token); err != nil {
+			if err := ts.canAccessObject(ctx, token, auth.ChannelSubject, channel.ID, auth.WriteAction); err != nil {
+				return errors.ErrNotFound
+			}
+		}
+	}
+
+	if err := ts.canMirror(ctx, token, res.GetId(), channel); err != nil {
+		return err
+	}
+
+	channel.Owner = ch.Owner
+	return ts.channels.UpdateWithVersion(ctx, channel, time.Now())
 }
 
 func (ts *thingsService) ViewChannel(ctx context.Context, token, id string) (Channel, error) {
@@ -410,8 +815,12 @@ func (ts *thingsService) ViewChannel(ctx context.Context, token, id string) (Cha
 		return channel, nil
 	}
 
-	if channel.Owner != res.GetId() {
-		return Channel{}, errors.ErrAuthorization
+	if channel.Owner == res.GetId() {
+		return channel, nil
+	}
+
+	if err := ts.canAccessObject(ctx, token, auth.ChannelSubject, id, auth.ReadAction); err != nil {
+		return Channel{}, err
 	}
 
 	return channel, nil
@@ -432,6 +841,14 @@ func (ts *thingsService) ListChannels(ctx context.Context, token string, admin b
 	return ts.channels.RetrieveByOwner(ctx, res.GetId(), pm)
 }
 
+func (ts *thingsService) SearchChannels(ctx context.Context, token string, pm PageMetadata) (ChannelsPage, error) {
+	if err := ts.authorize(ctx, auth.RootSubject, token); err != nil {
+		return ChannelsPage{}, err
+	}
+
+	return ts.channels.RetrieveByAdmin(ctx, pm)
+}
+
 func (ts *thingsService) ViewChannelByThing(ctx context.Context, token, thID string) (Channel, error) {
 	res, err := ts.auth.Identify(ctx, &mainflux.Token{Value: token})
 	if err != nil {
@@ -463,19 +880,72 @@ func (ts *thingsService) ViewChannelByThing(ctx context.Context, token, thID str
 	return Channel{}, errors.ErrAuthorization
 }
 
+func (ts *thingsService) ListChannelVersions(ctx context.Context, token, chID string, pm PageMetadata) (ChannelVersionsPage, error) {
+	if _, err := ts.ViewChannel(ctx, token, chID); err != nil {
+		return ChannelVersionsPage{}, err
+	}
+
+	return ts.channels.RetrieveVersions(ctx, chID, pm)
+}
+
+func (ts *thingsService) RollbackChannel(ctx context.Context, token, chID string, version uint64) (Channel, error) {
+	cv, err := ts.channels.RetrieveVersion(ctx, chID, version)
+	if err != nil {
+		return Channel{}, err
+	}
+
+	channel := Channel{
+		ID:       chID,
+		Name:     cv.Name,
+		Metadata: cv.Metadata,
+	}
+	if err := ts.UpdateChannel(ctx, token, channel); err != nil {
+		return Channel{}, err
+	}
+
+	return ts.channels.RetrieveByID(ctx, chID)
+}
+
 func (ts *thingsService) RemoveChannels(ctx context.Context, token string, ids ...string) error {
 	res, err := ts.auth.Identify(ctx, &mainflux.Token{Value: token})
 	if err != nil {
 		return errors.Wrap(errors.ErrAuthentication, err)
 	}
 
+	owners := map[string][]string{res.GetId(): ids}
+	if err := ts.authorize(ctx, auth.RootSubject, token); err == nil {
+		if owners, err = ts.channelOwners(ctx, ids...); err != nil {
+			return err
+		}
+	}
+
 	for _, id := range ids {
 		if err := ts.channelCache.Remove(ctx, id); err != nil {
 			return err
 		}
 	}
 
-	return ts.channels.Remove(ctx, res.GetId(), ids...)
+	for owner, ownerIDs := range owners {
+		if err := ts.channels.Remove(ctx, owner, ownerIDs...); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// channelOwners groups the IDs of channels by their owners.
+func (ts *thingsService) channelOwners(ctx context.Context, ids ...string) (map[string][]string, error) {
+	owners := make(map[string][]string)
+	for _, id := range ids {
+		ch, err := ts.channels.RetrieveByID(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		owners[ch.Owner] = append(owners[ch.Owner], id)
+	}
+
+	return owners, nil
 }
 
 func (ts *thingsService) Connect(ctx context.Context, token, chID string, thIDs []string) error {
@@ -532,7 +1002,31 @@ func (ts *thingsService) Disconnect(ctx context.Context, token, chID string, thI
 	return ts.channels.Disconnect(ctx, res.GetId(), chID, thIDs)
 }
 
+func (ts *thingsService) ListConnections(ctx context.Context, token string, cf ConnectionsFilter, pm PageMetadata) (ConnectionsPage, error) {
+	res, err := ts.auth.Identify(ctx, &mainflux.Token{Value: token})
+	if err != nil {
+		return ConnectionsPage{}, errors.Wrap(errors.ErrAuthentication, err)
+	}
+
+	if err := ts.authorize(ctx, auth.RootSubject, token); err == nil {
+		return ts.channels.RetrieveConnections(ctx, "", cf, pm)
+	}
+
+	return ts.channels.RetrieveConnections(ctx, res.GetId(), cf, pm)
+}
+
 func (ts *thingsService) CanAccessByKey(ctx context.Context, chanID, thingKey string) (string, error) {
+	if ts.isExternalKey(thingKey) {
+		thingID, err := ts.identifyExternal(ctx, thingKey)
+		if err != nil {
+			return "", err
+		}
+		if err := ts.CanAccessByID(ctx, chanID, thingID); err != nil {
+			return "", err
+		}
+		return thingID, nil
+	}
+
 	thingID, err := ts.hasThing(ctx, chanID, thingKey)
 	if err == nil {
 		return thingID, nil
@@ -581,6 +1075,10 @@ func (ts *thingsService) IsChannelOwner(ctx context.Context, owner, chanID strin
 }
 
 func (ts *thingsService) Identify(ctx context.Context, key string) (string, error) {
+	if ts.isExternalKey(key) {
+		return ts.identifyExternal(ctx, key)
+	}
+
 	id, err := ts.thingCache.ID(ctx, key)
 	if err == nil {
 		return id, nil
@@ -597,6 +1095,28 @@ func (ts *thingsService) Identify(ctx context.Context, key string) (string, erro
 	return id, nil
 }
 
+// isExternalKey reports whether the key is the token issued by the external
+// PKI, i.e. a compact serialized JWT.
+func (ts *thingsService) isExternalKey(key string) bool {
+	return ts.externalKeys != nil && strings.Count(key, ".") == 2
+}
+
+// identifyExternal validates the externally issued key and returns the ID of
+// the thing it's issued to. Since the tokens expire, they are not cached.
+func (ts *thingsService) identifyExternal(ctx context.Context, key string) (string, error) {
+	owner, extID, err := ts.externalKeys.Validate(ctx, key)
+	if err != nil {
+		return "", errors.Wrap(errors.ErrAuthentication, err)
+	}
+
+	th, err := ts.things.RetrieveByExternalID(ctx, owner, extID)
+	if err != nil {
+		return "", errors.Wrap(errors.ErrAuthentication, err)
+	}
+
+	return th.ID, nil
+}
+
 func (ts *thingsService) hasThing(ctx context.Context, chanID, thingKey string) (string, error) {
 	thingID, err := ts.thingCache.ID(ctx, thingKey)
 	if err != nil {
@@ -659,30 +1179,33 @@ func (ts *thingsService) Restore(ctx context.Context, token string, backup Backu
 		return err
 	}
 
-	for _, group := range backup.Groups {
-		if _, err := ts.groups.Save(ctx, group); err != nil {
-			return err
-		}
-	}
+	// Entities are restored before the relations referencing them.
+	if _, err := ts.things.Save(ctx, backup.Things...); err != nil {
+		return err
+	}
 
-	for _, gtr := range backup.GroupThingRelations {
-		if err := ts.groups.AssignThing(ctx, gtr.GroupID, gtr.ThingID); err != nil {
-			return err
-		}
+	if _, err := ts.channels.Save(ctx, backup.Channels...); err != nil {
+		return err
 	}
 
-	for _, gcr := range backup.GroupChannelRelations {
-		if err := ts.groups.AssignChannel(ctx, gcr.GroupID, gcr.ChannelID); err != nil {
+	// Restored things and channels are claimed by their owners, the same
+	// as the created ones.
+	for _, th := range backup.Things {
+		if err := claimOwnership(ctx, ts.auth, th.Owner, auth.ThingSubject, th.ID); err != nil {
 			return err
 		}
 	}
 
-	if _, err := ts.things.Save(ctx, backup.Things...); err != nil {
-		return err
+	for _, ch := range backup.Channels {
+		if err := claimOwnership(ctx, ts.auth, ch.Owner, auth.ChannelSubject, ch.ID); err != nil {
+			return err
+		}
 	}
 
-	if _, err := ts.channels.Save(ctx, backup.Channels...); err != nil {
-		return err
+	for _, group := range backup.Groups {
+		if _, err := ts.groups.Save(ctx, group); err != nil {
+			return err
+		}
 	}
 
 	for _, conn := range backup.Connections {
@@ -691,6 +1214,18 @@ func (ts *thingsService) Restore(ctx context.Context, token string, backup Backu
 		}
 	}
 
+	for _, gtr := range backup.GroupThingRelations {
+		if err := ts.groups.AssignThing(ctx, gtr.GroupID, gtr.ThingID); err != nil {
+			return err
+		}
+	}
+
+	for _, gcr := range backup.GroupChannelRelations {
+		if err := ts.groups.AssignChannel(ctx, gcr.GroupID, gcr.ChannelID); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -750,6 +1285,14 @@ func (ts *thingsService) ListGroups(ctx context.Context, token string, admin boo
 	return ts.groups.RetrieveByOwner(ctx, user.GetId(), pm)
 }
 
+func (ts *thingsService) SearchGroups(ctx context.Context, token string, pm PageMetadata) (GroupPage, error) {
+	if err := ts.authorize(ctx, auth.RootSubject, token); err != nil {
+		return GroupPage{}, err
+	}
+
+	return ts.groups.RetrieveByAdmin(ctx, pm)
+}
+
 func (ts *thingsService) ListGroupsByIDs(ctx context.Context, ids []string) ([]Group, error) {
 	page, err := ts.groups.RetrieveByIDs(ctx, ids)
 	if err != nil {
@@ -772,16 +1315,18 @@ func (ts *thingsService) RemoveGroups(ctx context.Context, token string, ids ...
 		}
 
 		if gr.OwnerID != user.GetId() {
-			return errors.ErrAuthorization
+			if err := ts.authorize(ctx, auth.RootSubject, token); err != nil {
+				return errors.ErrAuthorization
+			}
 		}
 
-		cp, err := ts.groups.RetrieveGroupChannels(ctx, user.GetId(), id, PageMetadata{})
+		cp, err := ts.groups.RetrieveGroupChannels(ctx, gr.OwnerID, id, PageMetadata{})
 		if err != nil {
 			return err
 		}
 
 		for _, ch := range cp.Channels {
-			tp, err := ts.things.RetrieveByChannel(ctx, user.GetId(), ch.ID, PageMetadata{})
+			tp, err := ts.things.RetrieveByChannel(ctx, gr.OwnerID, ch.ID, PageMetadata{})
 			if err != nil {
 				return err
 			}
@@ -791,7 +1336,7 @@ func (ts *thingsService) RemoveGroups(ctx context.Context, token string, ids ...
 				thingIDs = append(thingIDs, th.ID)
 			}
 
-			if err := ts.channels.Disconnect(ctx, user.GetId(), ch.ID, thingIDs); err != nil {
+			if err := ts.channels.Disconnect(ctx, gr.OwnerID, ch.ID, thingIDs); err != nil {
 				return err
 			}
 		}
@@ -812,7 +1357,9 @@ func (ts *thingsService) UpdateGroup(ctx context.Context, token string, group Gr
 	}
 
 	if gr.OwnerID != user.GetId() {
-		return Group{}, errors.ErrAuthorization
+		if err := ts.authorize(ctx, auth.RootSubject, token); err != nil {
+			return Group{}, errors.ErrAuthorization
+		}
 	}
 
 	group.UpdatedAt = getTimestmap()
@@ -843,6 +1390,76 @@ func (ts *thingsService) ViewGroup(ctx context.Context, token, id string) (Group
 	return gr, nil
 }
 
+func (ts *thingsService) ViewGroupStats(ctx context.Context, token, groupID string) (GroupStats, error) {
+	gr, err := ts.ViewGroup(ctx, token, groupID)
+	if err != nil {
+		return GroupStats{}, err
+	}
+
+	if gs, ok := ts.stats.get(gr.ID); ok {
+		return gs, nil
+	}
+
+	tp, err := ts.groups.RetrieveGroupThings(ctx, gr.OwnerID, gr.ID, PageMetadata{})
+	if err != nil {
+		return GroupStats{}, err
+	}
+
+	cp, err := ts.groups.RetrieveGroupChannels(ctx, gr.OwnerID, gr.ID, PageMetadata{})
+	if err != nil {
+		return GroupStats{}, err
+	}
+
+	conns, err := ts.channels.RetrieveConnections(ctx, "", ConnectionsFilter{GroupID: gr.ID}, PageMetadata{})
+	if err != nil {
+		return GroupStats{}, err
+	}
+
+	connected := map[string]bool{}
+	for _, th := range tp.Things {
+		connected[th.ID] = false
+	}
+	for _, conn := range conns.Connections {
+		if _, ok := connected[conn.ThingID]; ok {
+			connected[conn.ThingID] = true
+		}
+	}
+
+	gs := GroupStats{
+		Things:     uint64(len(tp.Things)),
+		Channels:   uint64(len(cp.Channels)),
+		ComputedAt: time.Now(),
+	}
+	for _, ok := range connected {
+		if ok {
+			gs.ConnectedThings++
+		}
+	}
+	gs.DisconnectedThings = gs.Things - gs.ConnectedThings
+
+	if ts.messages != nil {
+		from := gs.ComputedAt.Add(-statsWindow)
+		for _, ch := range cp.Channels {
+			recent, err := ts.messages.CountMessages(ctx, token, ch.ID, from, time.Time{})
+			if err != nil {
+				return GroupStats{}, err
+			}
+
+			stored, err := ts.messages.CountMessages(ctx, token, ch.ID, time.Time{}, time.Time{})
+			if err != nil {
+				return GroupStats{}, err
+			}
+
+			gs.Messages += recent
+			gs.StoredMessages += stored
+		}
+	}
+
+	ts.stats.save(gr.ID, gs)
+
+	return gs, nil
+}
+
 func (ts *thingsService) AssignThing(ctx context.Context, token string, groupID string, thingIDs ...string) error {
 	if _, err := ts.auth.Identify(ctx, &mainflux.Token{Value: token}); err != nil {
 		return err
@@ -863,6 +1480,36 @@ func (ts *thingsService) AssignThing(ctx context.Context, token string, groupID
 		return err
 	}
 
+	return ts.autoConnect(ctx, groupID, thingIDs...)
+}
+
+// autoConnect connects the things assigned to the group to the group channels
+// marked for auto connecting by the group template.
+func (ts *thingsService) autoConnect(ctx context.Context, groupID string, thingIDs ...string) error {
+	chIDs, err := ts.templates.RetrieveAutoConnects(ctx, groupID)
+	if err != nil {
+		return err
+	}
+
+	for _, chID := range chIDs {
+		grID, err := ts.groups.RetrieveChannelMembership(ctx, chID)
+		if err != nil {
+			return err
+		}
+		if grID != groupID {
+			continue
+		}
+
+		ch, err := ts.channels.RetrieveByID(ctx, chID)
+		if err != nil {
+			return err
+		}
+
+		if err := ts.channels.Connect(ctx, ch.Owner, chID, thingIDs); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -968,6 +1615,382 @@ func (ts *thingsService) UnassignThing(ctx context.Context, token string, groupI
 	return ts.groups.UnassignThing(ctx, groupID, thingIDs...)
 }
 
+func (ts *thingsService) AssignThingsBatch(ctx context.Context, token, groupID string, thingIDs ...string) (BatchResult, error) {
+	return processBatch(thingIDs, func(ids ...string) error {
+		return ts.AssignThing(ctx, token, groupID, ids...)
+	})
+}
+
+func (ts *thingsService) UnassignThingsBatch(ctx context.Context, token, groupID string, thingIDs ...string) (BatchResult, error) {
+	return processBatch(thingIDs, func(ids ...string) error {
+		return ts.UnassignThing(ctx, token, groupID, ids...)
+	})
+}
+
+func (ts *thingsService) AssignChannelsBatch(ctx context.Context, token, groupID string, channelIDs ...string) (BatchResult, error) {
+	return processBatch(channelIDs, func(ids ...string) error {
+		return ts.AssignChannel(ctx, token, groupID, ids...)
+	})
+}
+
+func (ts *thingsService) UnassignChannelsBatch(ctx context.Context, token, groupID string, channelIDs ...string) (BatchResult, error) {
+	return processBatch(channelIDs, func(ids ...string) error {
+		return ts.UnassignChannel(ctx, token, groupID, ids...)
+	})
+}
+
+// processBatch processes the IDs in chunks of BatchChunkSize. A failed chunk
+// is processed once more member by member in order to isolate the failed
+// members. Authentication and authorization failures abort the whole batch.
+func processBatch(ids []string, process func(ids ...string) error) (BatchResult, error) {
+	var res BatchResult
+	for start := 0; start < len(ids); start += BatchChunkSize {
+		end := start + BatchChunkSize
+		if end > len(ids) {
+			end = len(ids)
+		}
+		chunk := ids[start:end]
+
+		err := process(chunk...)
+		if err == nil {
+			res.Chunks = append(res.Chunks, chunk)
+			continue
+		}
+		if isBatchAborted(err) {
+			return BatchResult{}, err
+		}
+
+		var processed []string
+		for _, id := range chunk {
+			if err := process(id); err != nil {
+				if isBatchAborted(err) {
+					return BatchResult{}, err
+				}
+				res.Failed = append(res.Failed, BatchFailure{ID: id, Err: err})
+				continue
+			}
+			processed = append(processed, id)
+		}
+
+		if len(processed) > 0 {
+			res.Chunks = append(res.Chunks, processed)
+		}
+	}
+
+	return res, nil
+}
+
+func isBatchAborted(err error) bool {
+	return errors.Contains(err, errors.ErrAuthentication) || errors.Contains(err, errors.ErrAuthorization)
+}
+
+func (ts *thingsService) CreateGroupTemplate(ctx context.Context, token string, gt GroupTemplate) (GroupTemplate, error) {
+	user, err := ts.auth.Identify(ctx, &mainflux.Token{Value: token})
+	if err != nil {
+		return GroupTemplate{}, errors.Wrap(errors.ErrAuthentication, err)
+	}
+
+	id, err := ts.idProvider.ID()
+	if err != nil {
+		return GroupTemplate{}, err
+	}
+
+	gt.ID = id
+	gt.OwnerID = user.GetId()
+	gt.CreatedAt = getTimestmap()
+
+	return ts.templates.Save(ctx, gt)
+}
+
+func (ts *thingsService) ViewGroupTemplate(ctx context.Context, token, id string) (GroupTemplate, error) {
+	user, err := ts.auth.Identify(ctx, &mainflux.Token{Value: token})
+	if err != nil {
+		return GroupTemplate{}, errors.Wrap(errors.ErrAuthentication, err)
+	}
+
+	return ts.viewGroupTemplate(ctx, user.GetId(), id)
+}
+
+func (ts *thingsService) viewGroupTemplate(ctx context.Context, owner, id string) (GroupTemplate, error) {
+	gt, err := ts.templates.RetrieveByID(ctx, id)
+	if err != nil {
+		return GroupTemplate{}, err
+	}
+
+	if gt.OwnerID != owner {
+		return GroupTemplate{}, errors.ErrNotFound
+	}
+
+	return gt, nil
+}
+
+func (ts *thingsService) ListGroupTemplates(ctx context.Context, token string, pm PageMetadata) (GroupTemplatesPage, error) {
+	user, err := ts.auth.Identify(ctx, &mainflux.Token{Value: token})
+	if err != nil {
+		return GroupTemplatesPage{}, errors.Wrap(errors.ErrAuthentication, err)
+	}
+
+	return ts.templates.RetrieveByOwner(ctx, user.GetId(), pm)
+}
+
+func (ts *thingsService) RemoveGroupTemplate(ctx context.Context, token, id string) error {
+	user, err := ts.auth.Identify(ctx, &mainflux.Token{Value: token})
+	if err != nil {
+		return errors.Wrap(errors.ErrAuthentication, err)
+	}
+
+	return ts.templates.Remove(ctx, user.GetId(), id)
+}
+
+func (ts *thingsService) CreateGroupFromTemplate(ctx context.Context, token, templateID string, g Group) (Group, error) {
+	user, err := ts.auth.Identify(ctx, &mainflux.Token{Value: token})
+	if err != nil {
+		return Group{}, errors.Wrap(errors.ErrAuthentication, err)
+	}
+
+	gt, err := ts.viewGroupTemplate(ctx, user.GetId(), templateID)
+	if err != nil {
+		return Group{}, err
+	}
+
+	if g.Name == "" {
+		g.Name = gt.Name
+	}
+	if g.Description == "" {
+		g.Description = gt.Description
+	}
+
+	timestamp := getTimestmap()
+	g.OwnerID = user.GetId()
+	g.CreatedAt = timestamp
+	g.UpdatedAt = timestamp
+
+	gr, err := ts.createGroup(ctx, g)
+	if err != nil {
+		return Group{}, err
+	}
+
+	var chIDs, autoIDs []string
+	for _, ct := range gt.Channels {
+		tch := ct.channel()
+		ch, err := ts.createChannel(ctx, token, &tch, user)
+		if err != nil {
+			return Group{}, err
+		}
+
+		chIDs = append(chIDs, ch.ID)
+		if ct.AutoConnect {
+			autoIDs = append(autoIDs, ch.ID)
+		}
+	}
+
+	if len(chIDs) > 0 {
+		if err := ts.groups.AssignChannel(ctx, gr.ID, chIDs...); err != nil {
+			return Group{}, err
+		}
+	}
+
+	if len(autoIDs) > 0 {
+		if err := ts.templates.SaveAutoConnects(ctx, gr.ID, user.GetId(), autoIDs...); err != nil {
+			return Group{}, err
+		}
+	}
+
+	return gr, nil
+}
+
+func (ts *thingsService) ExportGroup(ctx context.Context, token, groupID string) (GroupExport, error) {
+	gr, err := ts.ViewGroup(ctx, token, groupID)
+	if err != nil {
+		return GroupExport{}, err
+	}
+
+	ge := GroupExport{
+		Group:       gr,
+		Things:      []Thing{},
+		Channels:    []Channel{},
+		Connections: []Connection{},
+	}
+
+	gtp, err := ts.groups.RetrieveGroupThings(ctx, gr.OwnerID, gr.ID, PageMetadata{})
+	if err != nil && !errors.Contains(err, errors.ErrNotFound) {
+		return GroupExport{}, err
+	}
+
+	thIDs := map[string]bool{}
+	for _, gth := range gtp.Things {
+		th, err := ts.things.RetrieveByID(ctx, gth.ID)
+		if err != nil {
+			return GroupExport{}, err
+		}
+		th.Key = ""
+		ge.Things = append(ge.Things, th)
+		thIDs[th.ID] = true
+	}
+
+	gcp, err := ts.groups.RetrieveGroupChannels(ctx, gr.OwnerID, gr.ID, PageMetadata{})
+	if err != nil && !errors.Contains(err, errors.ErrNotFound) {
+		return GroupExport{}, err
+	}
+
+	chIDs := map[string]bool{}
+	for _, gch := range gcp.Channels {
+		ch, err := ts.channels.RetrieveByID(ctx, gch.ID)
+		if err != nil {
+			return GroupExport{}, err
+		}
+		ge.Channels = append(ge.Channels, ch)
+		chIDs[ch.ID] = true
+	}
+
+	cp, err := ts.channels.RetrieveConnections(ctx, gr.OwnerID, ConnectionsFilter{GroupID: gr.ID}, PageMetadata{})
+	if err != nil {
+		return GroupExport{}, err
+	}
+
+	// Only the connections within the group are exported, so that the
+	// bundle can be imported on its own.
+	for _, conn := range cp.Connections {
+		if chIDs[conn.ChannelID] && thIDs[conn.ThingID] {
+			ge.Connections = append(ge.Connections, conn)
+		}
+	}
+
+	return ge, nil
+}
+
+func (ts *thingsService) ImportGroup(ctx context.Context, token string, ge GroupExport, rekey bool) (GroupExport, error) {
+	user, err := ts.auth.Identify(ctx, &mainflux.Token{Value: token})
+	if err != nil {
+		return GroupExport{}, errors.Wrap(errors.ErrAuthentication, err)
+	}
+
+	if err := ge.validate(); err != nil {
+		return GroupExport{}, err
+	}
+
+	timestamp := getTimestmap()
+	g := ge.Group
+	g.OwnerID = user.GetId()
+	g.CreatedAt = timestamp
+	g.UpdatedAt = timestamp
+
+	gr, err := ts.createGroup(ctx, g)
+	if err != nil {
+		return GroupExport{}, err
+	}
+
+	res := GroupExport{
+		Group:       gr,
+		Things:      []Thing{},
+		Channels:    []Channel{},
+		Connections: []Connection{},
+	}
+
+	// Entities imported before the failure are removed, so that the retried
+	// import doesn't duplicate them.
+	if err := ts.importEntities(ctx, token, user, ge, rekey, &res); err != nil {
+		if rerr := ts.removeImported(ctx, user.GetId(), res); rerr != nil {
+			return GroupExport{}, errors.Wrap(err, rerr)
+		}
+		return GroupExport{}, err
+	}
+
+	return res, nil
+}
+
+// importEntities imports the things, channels and connections of the export
+// into the imported group, recording the imported entities in the result.
+func (ts *thingsService) importEntities(ctx context.Context, token string, user *mainflux.UserIdentity, ge GroupExport, rekey bool, res *GroupExport) error {
+	// IDs of the exported entities are mapped to the IDs of the imported ones.
+	thIDs := map[string]string{}
+	for _, th := range ge.Things {
+		oldID := th.ID
+		th.ID = ""
+		// Things without keys are assigned with new keys by createThing.
+		if rekey {
+			th.Key = ""
+		}
+		sth, err := ts.createThing(ctx, token, &th, user)
+		if err != nil {
+			return err
+		}
+		thIDs[oldID] = sth.ID
+		res.Things = append(res.Things, sth)
+	}
+
+	chIDs := map[string]string{}
+	for _, ch := range ge.Channels {
+		oldID := ch.ID
+		ch.ID = ""
+		sch, err := ts.createChannel(ctx, token, &ch, user)
+		if err != nil {
+			return err
+		}
+		chIDs[oldID] = sch.ID
+		res.Channels = append(res.Channels, sch)
+	}
+
+	if len(thIDs) > 0 {
+		ids := make([]string, 0, len(res.Things))
+		for _, th := range res.Things {
+			ids = append(ids, th.ID)
+		}
+		if err := ts.groups.AssignThing(ctx, res.Group.ID, ids...); err != nil {
+			return err
+		}
+	}
+
+	if len(chIDs) > 0 {
+		ids := make([]string, 0, len(res.Channels))
+		for _, ch := range res.Channels {
+			ids = append(ids, ch.ID)
+		}
+		if err := ts.groups.AssignChannel(ctx, res.Group.ID, ids...); err != nil {
+			return err
+		}
+	}
+
+	for _, conn := range ge.Connections {
+		chID, thID := chIDs[conn.ChannelID], thIDs[conn.ThingID]
+		if err := ts.channels.Connect(ctx, user.GetId(), chID, []string{thID}); err != nil {
+			return err
+		}
+
+		res.Connections = append(res.Connections, Connection{
+			ChannelID:    chID,
+			ChannelOwner: user.GetId(),
+			ThingID:      thID,
+			ThingOwner:   user.GetId(),
+		})
+	}
+
+	return nil
+}
+
+// removeImported removes the channels, things and the group created by the
+// failed import. Connections and group memberships are removed along with
+// the removed entities.
+func (ts *thingsService) removeImported(ctx context.Context, owner string, res GroupExport) error {
+	for _, ch := range res.Channels {
+		if err := ts.channels.Remove(ctx, owner, ch.ID); err != nil {
+			return err
+		}
+	}
+
+	if len(res.Things) > 0 {
+		ids := make([]string, 0, len(res.Things))
+		for _, th := range res.Things {
+			ids = append(ids, th.ID)
+		}
+		if err := ts.things.Remove(ctx, owner, ids...); err != nil {
+			return err
+		}
+	}
+
+	return ts.groups.Remove(ctx, res.Group.ID)
+}
+
 func getTimestmap() time.Time {
 	return time.Now().UTC().Round(time.Millisecond)
 }
@@ -1080,6 +2103,10 @@ func (ts *thingsService) ViewChannelMembership(ctx context.Context, token string
 	return group, nil
 }
 
+func (ts *thingsService) AuthorizeAdmin(ctx context.Context, token string) error {
+	return ts.authorize(ctx, auth.RootSubject, token)
+}
+
 func (ts *thingsService) authorize(ctx context.Context, subject, token string) error {
 	req := &mainflux.AuthorizeReq{
 		Token:   token,
@@ -1093,6 +2120,55 @@ func (ts *thingsService) authorize(ctx context.Context, subject, token string) e
 	return nil
 }
 
+func (ts *thingsService) canAccessObject(ctx context.Context, token, subject, object, action string) error {
+	req := &mainflux.AuthorizeReq{
+		Token:   token,
+		Subject: subject,
+		Object:  object,
+		Action:  action,
+	}
+
+	if _, err := ts.auth.Authorize(ctx, req); err != nil {
+		return errors.Wrap(errors.ErrAuthorization, err)
+	}
+
+	return nil
+}
+
+// canMirror checks whether the messages of the channel may be mirrored to
+// the mirror channel set in its profile, i.e. whether the user may publish
+// to the mirror channel as well.
+func (ts *thingsService) canMirror(ctx context.Context, token, userID string, channel Channel) error {
+	profile, err := ParseProfile(channel.Metadata)
+	if err != nil {
+		return err
+	}
+
+	switch profile.MirrorTo {
+	case "":
+		return nil
+	case channel.ID:
+		return ErrMalformedProfile
+	}
+
+	mirror, err := ts.channels.RetrieveByID(ctx, profile.MirrorTo)
+	switch {
+	case errors.Contains(err, errors.ErrNotFound):
+		return ErrMalformedProfile
+	case err != nil:
+		return err
+	}
+
+	if mirror.Owner == userID {
+		return nil
+	}
+	if err := ts.authorize(ctx, auth.RootSubject, token); err == nil {
+		return nil
+	}
+
+	return ts.canAccessObject(ctx, token, auth.ChannelSubject, mirror.ID, auth.WriteAction)
+}
+
 func (ts *thingsService) isThingOwner(ctx context.Context, owner string, thingID string) error {
 	thing, err := ts.things.RetrieveByID(ctx, thingID)
 	if err != nil {
@@ -50,7 +50,19 @@ func (repo singleUserRepo) Authorize(ctx context.Context, req *mainflux.Authoriz
 }
 
 func (repo singleUserRepo) AddPolicy(ctx context.Context, in *mainflux.PolicyReq, opts ...grpc.CallOption) (r *empty.Empty, err error) {
-	return &empty.Empty{}, errUnsupported
+	if repo.token != in.GetToken() {
+		return &empty.Empty{}, errors.ErrAuthentication
+	}
+
+	return &empty.Empty{}, nil
+}
+
+func (repo singleUserRepo) ClaimOwnership(ctx context.Context, in *mainflux.OwnershipReq, opts ...grpc.CallOption) (r *empty.Empty, err error) {
+	if repo.email != in.GetOwnerID() {
+		return &empty.Empty{}, errors.ErrAuthorization
+	}
+
+	return &empty.Empty{}, nil
 }
 
 func (repo singleUserRepo) Members(ctx context.Context, req *mainflux.MembersReq, _ ...grpc.CallOption) (r *mainflux.MembersRes, err error) {
@@ -64,3 +76,11 @@ func (repo singleUserRepo) Assign(ctx context.Context, req *mainflux.Assignment,
 func (repo singleUserRepo) AssignRole(ctx context.Context, req *mainflux.AssignRoleReq, _ ...grpc.CallOption) (r *empty.Empty, err error) {
 	return &empty.Empty{}, errUnsupported
 }
+
+func (repo singleUserRepo) RetrieveRole(ctx context.Context, req *mainflux.RetrieveRoleReq, _ ...grpc.CallOption) (r *mainflux.RetrieveRoleRes, err error) {
+	return &mainflux.RetrieveRoleRes{}, errUnsupported
+}
+
+func (repo singleUserRepo) RetrieveMemberships(ctx context.Context, req *mainflux.MembershipsReq, _ ...grpc.CallOption) (r *mainflux.MembershipsRes, err error) {
+	return &mainflux.MembershipsRes{}, errUnsupported
+}
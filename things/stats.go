@@ -0,0 +1,79 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package things
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const (
+	// statsCacheTTL is the duration the computed group statistics are served
+	// from the cache for.
+	statsCacheTTL = time.Minute
+
+	// statsWindow is the window the recent message volume is counted within.
+	statsWindow = 24 * time.Hour
+)
+
+// GroupStats represents the statistics of the group shown by the fleet
+// overview.
+type GroupStats struct {
+	Things             uint64
+	Channels           uint64
+	ConnectedThings    uint64
+	DisconnectedThings uint64
+	// Messages is the number of messages published to the group channels
+	// within the last 24 hours.
+	Messages uint64
+	// StoredMessages is the number of messages of the group channels kept
+	// by the readers storage.
+	StoredMessages uint64
+	ComputedAt     time.Time
+}
+
+// MessageCounter specifies the API of the readers counting the stored
+// messages.
+type MessageCounter interface {
+	// CountMessages returns the number of stored messages of the channel
+	// identified by chanID, published within the given interval. Zero from
+	// and to times leave the interval unbounded.
+	CountMessages(ctx context.Context, token, chanID string, from, to time.Time) (uint64, error)
+}
+
+type cachedStats struct {
+	stats   GroupStats
+	expires time.Time
+}
+
+// statsCache keeps the recently computed group statistics in memory.
+type statsCache struct {
+	mu    sync.Mutex
+	stats map[string]cachedStats
+}
+
+func newStatsCache() *statsCache {
+	return &statsCache{stats: map[string]cachedStats{}}
+}
+
+func (sc *statsCache) get(groupID string) (GroupStats, bool) {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+
+	cs, ok := sc.stats[groupID]
+	if !ok || time.Now().After(cs.expires) {
+		delete(sc.stats, groupID)
+		return GroupStats{}, false
+	}
+
+	return cs.stats, true
+}
+
+func (sc *statsCache) save(groupID string, gs GroupStats) {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+
+	sc.stats[groupID] = cachedStats{stats: gs, expires: gs.ComputedAt.Add(statsCacheTTL)}
+}
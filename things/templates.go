@@ -0,0 +1,80 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package things
+
+import (
+	"context"
+	"time"
+)
+
+// profileKey is the channel metadata key under which the channel profile,
+// e.g. content type and transformer settings, is stored.
+const profileKey = "profile"
+
+// ChannelTemplate describes a channel that is created along with the group
+// created from the template.
+type ChannelTemplate struct {
+	Name     string                 `json:"name"`
+	Profile  map[string]interface{} `json:"profile,omitempty"`
+	Metadata map[string]interface{} `json:"metadata,omitempty"`
+	// AutoConnect specifies whether the things assigned to the group are
+	// automatically connected to the channel.
+	AutoConnect bool `json:"auto_connect,omitempty"`
+}
+
+// GroupTemplate represents a predefined set of channels, with their profiles
+// and default connection rules, that is created along with the group.
+type GroupTemplate struct {
+	ID          string
+	OwnerID     string
+	Name        string
+	Description string
+	Channels    []ChannelTemplate
+	CreatedAt   time.Time
+}
+
+// GroupTemplatesPage contains page related metadata as well as list of group
+// templates that belong to this page.
+type GroupTemplatesPage struct {
+	PageMetadata
+	Templates []GroupTemplate
+}
+
+// GroupTemplateRepository specifies a group template persistence API.
+type GroupTemplateRepository interface {
+	// Save persists the group template.
+	Save(ctx context.Context, gt GroupTemplate) (GroupTemplate, error)
+
+	// RetrieveByID retrieves the group template by its ID.
+	RetrieveByID(ctx context.Context, id string) (GroupTemplate, error)
+
+	// RetrieveByOwner retrieves the subset of group templates of the owner.
+	RetrieveByOwner(ctx context.Context, ownerID string, pm PageMetadata) (GroupTemplatesPage, error)
+
+	// Remove removes the group template of the owner.
+	Remove(ctx context.Context, ownerID, id string) error
+
+	// SaveAutoConnects stores the channels of the group which the things
+	// assigned to the group are automatically connected to.
+	SaveAutoConnects(ctx context.Context, groupID, owner string, chIDs ...string) error
+
+	// RetrieveAutoConnects retrieves the channels of the group which the
+	// things assigned to the group are automatically connected to.
+	RetrieveAutoConnects(ctx context.Context, groupID string) ([]string, error)
+}
+
+func (ct ChannelTemplate) channel() Channel {
+	md := map[string]interface{}{}
+	for k, v := range ct.Metadata {
+		md[k] = v
+	}
+	if len(ct.Profile) > 0 {
+		md[profileKey] = ct.Profile
+	}
+
+	return Channel{
+		Name:     ct.Name,
+		Metadata: md,
+	}
+}
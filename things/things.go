@@ -5,6 +5,7 @@ package things
 
 import (
 	"context"
+	"math"
 
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
 )
@@ -18,20 +19,71 @@ var (
 
 	// ErrEntityConnected indicates error while checking connection in database
 	ErrEntityConnected = errors.New("check thing-channel connection in database error")
+
+	// ErrRevisionMismatch indicates that the entity was modified since the
+	// revision the update is based on.
+	ErrRevisionMismatch = errors.New("entity revision mismatch")
 )
 
+// earthRadius is the radius of the Earth in meters, matching the one used by
+// the Postgres earthdistance module.
+const earthRadius = 6378168
+
 // Metadata to be used for Mainflux thing or channel for customized
 // describing of particular thing or channel.
 type Metadata map[string]interface{}
 
 // Thing represents a Mainflux thing. Each thing is owned by one user, and
 // it is assigned with the unique identifier and (temporary) access key.
+// Optionally, the thing is assigned with the immutable external identifier
+// (e.g. IMEI or serial number), unique among things of the same owner,
+// and with the geographic location the thing is deployed at. Revision is
+// incremented on each update; the update with the non-zero revision is
+// applied only if it matches the current revision of the thing.
 type Thing struct {
-	ID       string
-	Owner    string
-	Name     string
-	Key      string
-	Metadata Metadata
+	ID         string
+	Owner      string
+	Name       string
+	Key        string
+	ExternalID string
+	Location   *Location
+	Metadata   Metadata
+	Revision   uint64
+}
+
+// Location represents the geographic location given by the latitude and
+// longitude in decimal degrees.
+type Location struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+}
+
+// Area represents the circular geographic area given by its center and
+// the radius in meters.
+type Area struct {
+	Location
+	Radius float64 `json:"radius"`
+}
+
+// Contains returns true if the location is within the area, using the
+// great-circle distance between the location and the center of the area.
+func (a Area) Contains(l Location) bool {
+	lat1, lat2 := a.Latitude*math.Pi/180, l.Latitude*math.Pi/180
+	dLat := lat2 - lat1
+	dLng := (l.Longitude - a.Longitude) * math.Pi / 180
+
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLng/2)*math.Sin(dLng/2)
+	return 2*earthRadius*math.Asin(math.Sqrt(h)) <= a.Radius
+}
+
+// SigningKey represents the key used to verify signatures of the messages
+// published by the thing. Key is the secret shared with the thing in case
+// of HMAC-SHA256 signatures, or the public key of the thing in case of
+// Ed25519 signatures.
+type SigningKey struct {
+	ThingID   string
+	Algorithm string
+	Key       []byte
 }
 
 // Page contains page related metadata as well as list of things that
@@ -63,6 +115,10 @@ type ThingRepository interface {
 	// RetrieveByKey returns thing ID for given thing key.
 	RetrieveByKey(ctx context.Context, key string) (string, error)
 
+	// RetrieveByExternalID retrieves the thing having the provided external
+	// identifier, that is owned by the specified user.
+	RetrieveByExternalID(ctx context.Context, owner, externalID string) (Thing, error)
+
 	// RetrieveByOwner retrieves the subset of things owned by the specified user
 	RetrieveByOwner(ctx context.Context, owner string, pm PageMetadata) (Page, error)
 
@@ -80,8 +136,19 @@ type ThingRepository interface {
 	// RetrieveAll retrieves all things for all users.
 	RetrieveAll(ctx context.Context) ([]Thing, error)
 
-	// RetrieveByAdmin retrieves all things for all users with pagination.
+	// RetrieveByAdmin retrieves things of all users with pagination. Things
+	// are optionally filtered by the owner and the key prefix.
 	RetrieveByAdmin(ctx context.Context, pm PageMetadata) (Page, error)
+
+	// SaveSigningKey persists the signing key of the thing, replacing the
+	// existing one.
+	SaveSigningKey(ctx context.Context, sk SigningKey) error
+
+	// RetrieveSigningKey retrieves the signing key of the thing.
+	RetrieveSigningKey(ctx context.Context, thingID string) (SigningKey, error)
+
+	// RemoveSigningKey removes the signing key of the thing.
+	RemoveSigningKey(ctx context.Context, thingID string) error
 }
 
 // ThingCache contains thing caching interface.
@@ -95,3 +162,10 @@ type ThingCache interface {
 	// Removes thing from cache.
 	Remove(context.Context, string) error
 }
+
+// ExternalKeyValidator validates the thing keys issued by the external PKI.
+type ExternalKeyValidator interface {
+	// Validate validates the key and returns the owner and the external ID
+	// of the thing the key is issued to.
+	Validate(ctx context.Context, key string) (string, string, error)
+}
@@ -5,24 +5,29 @@ package tracing
 
 import (
 	"context"
+	"time"
 
 	"github.com/MainfluxLabs/mainflux/things"
 	opentracing "github.com/opentracing/opentracing-go"
 )
 
 const (
-	saveChannelsOp           = "save_channels"
-	updateChannelOp          = "update_channel"
-	retrieveChannelByIDOp    = "retrieve_channel_by_id"
-	retrieveByThingOp        = "retrieve_by_thing"
-	retrieveChannelConnsOp   = "retrieve_channels_conns"
-	removeChannelOp          = "retrieve_channel"
-	connectOp                = "connect"
-	disconnectOp             = "disconnect"
-	hasThingOp               = "has_thing"
-	hasThingByIDOp           = "has_thing_by_id"
-	retrieveAllChannelsOp    = "retrieve_all_channels"
-	retrieveAllConnectionsOp = "retrieve_all_connections"
+	saveChannelsOp             = "save_channels"
+	updateChannelOp            = "update_channel"
+	retrieveChannelByIDOp      = "retrieve_channel_by_id"
+	retrieveByThingOp          = "retrieve_by_thing"
+	retrieveChannelConnsOp     = "retrieve_channels_conns"
+	removeChannelOp            = "retrieve_channel"
+	connectOp                  = "connect"
+	disconnectOp               = "disconnect"
+	hasThingOp                 = "has_thing"
+	hasThingByIDOp             = "has_thing_by_id"
+	retrieveAllChannelsOp      = "retrieve_all_channels"
+	retrieveAllConnectionsOp   = "retrieve_all_connections"
+	retrieveConnectionsOp      = "retrieve_connections"
+	updateChannelWithVersionOp = "update_channel_with_version"
+	retrieveChannelVersionOp   = "retrieve_channel_version"
+	retrieveChannelVersionsOp  = "retrieve_channel_versions"
 )
 
 var (
@@ -156,6 +161,38 @@ func (crm channelRepositoryMiddleware) RetrieveAllConnections(ctx context.Contex
 	return crm.repo.RetrieveAllConnections(ctx)
 }
 
+func (crm channelRepositoryMiddleware) RetrieveConnections(ctx context.Context, owner string, cf things.ConnectionsFilter, pm things.PageMetadata) (things.ConnectionsPage, error) {
+	span := createSpan(ctx, crm.tracer, retrieveConnectionsOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return crm.repo.RetrieveConnections(ctx, owner, cf, pm)
+}
+
+func (crm channelRepositoryMiddleware) UpdateWithVersion(ctx context.Context, ch things.Channel, createdAt time.Time) error {
+	span := createSpan(ctx, crm.tracer, updateChannelWithVersionOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return crm.repo.UpdateWithVersion(ctx, ch, createdAt)
+}
+
+func (crm channelRepositoryMiddleware) RetrieveVersion(ctx context.Context, chID string, version uint64) (things.ChannelVersion, error) {
+	span := createSpan(ctx, crm.tracer, retrieveChannelVersionOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return crm.repo.RetrieveVersion(ctx, chID, version)
+}
+
+func (crm channelRepositoryMiddleware) RetrieveVersions(ctx context.Context, chID string, pm things.PageMetadata) (things.ChannelVersionsPage, error) {
+	span := createSpan(ctx, crm.tracer, retrieveChannelVersionsOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return crm.repo.RetrieveVersions(ctx, chID, pm)
+}
+
 type channelCacheMiddleware struct {
 	tracer opentracing.Tracer
 	cache  things.ChannelCache
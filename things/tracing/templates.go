@@ -0,0 +1,83 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package tracing
+
+import (
+	"context"
+
+	"github.com/MainfluxLabs/mainflux/things"
+	opentracing "github.com/opentracing/opentracing-go"
+)
+
+const (
+	saveGroupTemplateOp         = "save_group_template"
+	retrieveGroupTemplateByIDOp = "retrieve_group_template_by_id"
+	retrieveGroupTemplatesOp    = "retrieve_group_templates_by_owner"
+	removeGroupTemplateOp       = "remove_group_template"
+	saveAutoConnectsOp          = "save_auto_connects"
+	retrieveAutoConnectsOp      = "retrieve_auto_connects"
+)
+
+var _ things.GroupTemplateRepository = (*groupTemplateRepositoryMiddleware)(nil)
+
+type groupTemplateRepositoryMiddleware struct {
+	tracer opentracing.Tracer
+	repo   things.GroupTemplateRepository
+}
+
+// GroupTemplateRepositoryMiddleware tracks request and their latency, and adds spans to context.
+func GroupTemplateRepositoryMiddleware(tracer opentracing.Tracer, repo things.GroupTemplateRepository) things.GroupTemplateRepository {
+	return groupTemplateRepositoryMiddleware{
+		tracer: tracer,
+		repo:   repo,
+	}
+}
+
+func (gtrm groupTemplateRepositoryMiddleware) Save(ctx context.Context, gt things.GroupTemplate) (things.GroupTemplate, error) {
+	span := createSpan(ctx, gtrm.tracer, saveGroupTemplateOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return gtrm.repo.Save(ctx, gt)
+}
+
+func (gtrm groupTemplateRepositoryMiddleware) RetrieveByID(ctx context.Context, id string) (things.GroupTemplate, error) {
+	span := createSpan(ctx, gtrm.tracer, retrieveGroupTemplateByIDOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return gtrm.repo.RetrieveByID(ctx, id)
+}
+
+func (gtrm groupTemplateRepositoryMiddleware) RetrieveByOwner(ctx context.Context, ownerID string, pm things.PageMetadata) (things.GroupTemplatesPage, error) {
+	span := createSpan(ctx, gtrm.tracer, retrieveGroupTemplatesOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return gtrm.repo.RetrieveByOwner(ctx, ownerID, pm)
+}
+
+func (gtrm groupTemplateRepositoryMiddleware) Remove(ctx context.Context, ownerID, id string) error {
+	span := createSpan(ctx, gtrm.tracer, removeGroupTemplateOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return gtrm.repo.Remove(ctx, ownerID, id)
+}
+
+func (gtrm groupTemplateRepositoryMiddleware) SaveAutoConnects(ctx context.Context, groupID, owner string, chIDs ...string) error {
+	span := createSpan(ctx, gtrm.tracer, saveAutoConnectsOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return gtrm.repo.SaveAutoConnects(ctx, groupID, owner, chIDs...)
+}
+
+func (gtrm groupTemplateRepositoryMiddleware) RetrieveAutoConnects(ctx context.Context, groupID string) ([]string, error) {
+	span := createSpan(ctx, gtrm.tracer, retrieveAutoConnectsOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return gtrm.repo.RetrieveAutoConnects(ctx, groupID)
+}
@@ -18,12 +18,16 @@ const (
 	retrieveThingByIDOp       = "retrieve_thing_by_id"
 	retrieveThingsByIDsOp     = "retrieve_things_by_ids"
 	retrieveThingByKeyOp      = "retrieve_thing_by_key"
+	retrieveThingByExtIDOp    = "retrieve_thing_by_external_id"
 	retrieveThingsByOwnerOp   = "retrieve_things_by_owner"
 	retrieveThingsByChannelOp = "retrieve_things_by_chan"
 	removeThingOp             = "remove_thing"
 	retrieveThingIDByKeyOp    = "retrieve_id_by_key"
 	retrieveAllThingsOp       = "retrieve_all_things"
 	restoreThingsOp           = "restore_things"
+	saveSigningKeyOp          = "save_signing_key"
+	retrieveSigningKeyOp      = "retrieve_signing_key"
+	removeSigningKeyOp        = "remove_signing_key"
 )
 
 var (
@@ -77,6 +81,14 @@ func (trm thingRepositoryMiddleware) RetrieveByID(ctx context.Context, id string
 	return trm.repo.RetrieveByID(ctx, id)
 }
 
+func (trm thingRepositoryMiddleware) RetrieveByExternalID(ctx context.Context, owner, externalID string) (things.Thing, error) {
+	span := createSpan(ctx, trm.tracer, retrieveThingByExtIDOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return trm.repo.RetrieveByExternalID(ctx, owner, externalID)
+}
+
 func (trm thingRepositoryMiddleware) RetrieveByKey(ctx context.Context, key string) (string, error) {
 	span := createSpan(ctx, trm.tracer, retrieveThingByKeyOp)
 	defer span.Finish()
@@ -133,6 +145,30 @@ func (trm thingRepositoryMiddleware) RetrieveByAdmin(ctx context.Context, pm thi
 	return trm.repo.RetrieveByAdmin(ctx, pm)
 }
 
+func (trm thingRepositoryMiddleware) SaveSigningKey(ctx context.Context, sk things.SigningKey) error {
+	span := createSpan(ctx, trm.tracer, saveSigningKeyOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return trm.repo.SaveSigningKey(ctx, sk)
+}
+
+func (trm thingRepositoryMiddleware) RetrieveSigningKey(ctx context.Context, thingID string) (things.SigningKey, error) {
+	span := createSpan(ctx, trm.tracer, retrieveSigningKeyOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return trm.repo.RetrieveSigningKey(ctx, thingID)
+}
+
+func (trm thingRepositoryMiddleware) RemoveSigningKey(ctx context.Context, thingID string) error {
+	span := createSpan(ctx, trm.tracer, removeSigningKeyOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return trm.repo.RemoveSigningKey(ctx, thingID)
+}
+
 type thingCacheMiddleware struct {
 	tracer opentracing.Tracer
 	cache  things.ThingCache
@@ -0,0 +1,87 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"log"
+	"os"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/tools/loadtest"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+func main() {
+	confFile := ""
+	cfg := loadtest.Config{}
+
+	// Command
+	var rootCmd = &cobra.Command{
+		Use:   "loadtest",
+		Short: "loadtest is load testing tool for Mainflux",
+		Long: `Tool for simulating devices publishing messages to Mainflux adapters,
+verifying their arrival using readers and reporting latency percentiles.
+Complete documentation is available at https://mainfluxlabs.github.io/docs`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if confFile != "" {
+				viper.SetConfigFile(confFile)
+
+				if err := viper.ReadInConfig(); err != nil {
+					log.Printf("Failed to load config - %s", err.Error())
+				}
+
+				if err := viper.Unmarshal(&cfg); err != nil {
+					log.Printf("Unable to decode into struct, %v", err)
+				}
+			}
+
+			rep, err := loadtest.Run(cfg)
+			if err != nil {
+				log.Fatalf("Load test failed: %s", err)
+			}
+
+			if err := rep.Print(os.Stdout, cfg.Log.Format); err != nil {
+				log.Fatalf("Failed to print results: %s", err)
+			}
+		},
+	}
+
+	// Flags
+	// Test params
+	rootCmd.PersistentFlags().StringVarP(&cfg.Test.Protocol, "protocol", "P", loadtest.ProtocolMQTT, "Publishing protocol: mqtt|http")
+	rootCmd.PersistentFlags().IntVarP(&cfg.Test.Devices, "devices", "d", 10, "Number of simulated devices")
+	rootCmd.PersistentFlags().IntVarP(&cfg.Test.Count, "count", "n", 100, "Number of messages sent per device")
+	rootCmd.PersistentFlags().Float64VarP(&cfg.Test.Rate, "rate", "r", 1, "Messages per second sent per device")
+
+	// MQTT
+	rootCmd.PersistentFlags().StringVarP(&cfg.MQTT.URL, "mqtt", "b", "tcp://localhost:1883", "MQTT adapter address")
+	rootCmd.PersistentFlags().IntVarP(&cfg.MQTT.QoS, "qos", "q", 1, "QoS for published messages, values 0 1 2")
+	rootCmd.PersistentFlags().DurationVarP(&cfg.MQTT.Timeout, "mqtt-timeout", "", 10*time.Second, "MQTT connect and publish timeout")
+
+	// HTTP
+	rootCmd.PersistentFlags().StringVarP(&cfg.HTTP.URL, "http", "", "http://localhost:8185", "HTTP adapter address")
+	rootCmd.PersistentFlags().DurationVarP(&cfg.HTTP.Timeout, "http-timeout", "", 10*time.Second, "HTTP publish timeout")
+
+	// Message
+	rootCmd.PersistentFlags().StringVarP(&cfg.Message.Subtopic, "subtopic", "s", "loadtest", "Subtopic prefix of published messages")
+	rootCmd.PersistentFlags().StringVarP(&cfg.Message.Template, "template", "t", loadtest.DefaultTemplate, "SenML payload template")
+
+	// Reader
+	rootCmd.PersistentFlags().StringVarP(&cfg.Reader.URL, "reader", "", "", "Reader address, arrival is not verified if empty")
+	rootCmd.PersistentFlags().DurationVarP(&cfg.Reader.Interval, "reader-interval", "", time.Second, "Reader poll interval")
+	rootCmd.PersistentFlags().DurationVarP(&cfg.Reader.Timeout, "reader-timeout", "", 30*time.Second, "Time to wait for messages arrival once publishing is done")
+
+	// Log params
+	rootCmd.PersistentFlags().BoolVarP(&cfg.Log.Quiet, "quiet", "", false, "Supress messages")
+	rootCmd.PersistentFlags().StringVarP(&cfg.Log.Format, "format", "f", "text", "Output format: text|json")
+
+	// Config file
+	rootCmd.PersistentFlags().StringVarP(&confFile, "config", "c", "", "config file for loadtest")
+	rootCmd.PersistentFlags().StringVarP(&cfg.Mf.ConnFile, "mainflux", "m", "connections.toml", "config file for Mainflux connections")
+
+	if err := rootCmd.Execute(); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package loadtest
+
+import "time"
+
+const (
+	// ProtocolMQTT publishes the messages to the MQTT adapter.
+	ProtocolMQTT = "mqtt"
+	// ProtocolHTTP publishes the messages to the HTTP adapter.
+	ProtocolHTTP = "http"
+)
+
+// Keep struct names exported, otherwise Viper unmarshalling won't work
+type testConfig struct {
+	Protocol string  `toml:"protocol" mapstructure:"protocol"`
+	Devices  int     `toml:"devices" mapstructure:"devices"`
+	Count    int     `toml:"count" mapstructure:"count"`
+	Rate     float64 `toml:"rate" mapstructure:"rate"`
+}
+
+type mqttConfig struct {
+	URL     string        `toml:"url" mapstructure:"url"`
+	QoS     int           `toml:"qos" mapstructure:"qos"`
+	Timeout time.Duration `toml:"timeout" mapstructure:"timeout"`
+}
+
+type httpConfig struct {
+	URL     string        `toml:"url" mapstructure:"url"`
+	Timeout time.Duration `toml:"timeout" mapstructure:"timeout"`
+}
+
+type messageConfig struct {
+	Subtopic string `toml:"subtopic" mapstructure:"subtopic"`
+	Template string `toml:"template" mapstructure:"template"`
+}
+
+type readerConfig struct {
+	URL      string        `toml:"url" mapstructure:"url"`
+	Interval time.Duration `toml:"interval" mapstructure:"interval"`
+	Timeout  time.Duration `toml:"timeout" mapstructure:"timeout"`
+}
+
+type logConfig struct {
+	Quiet  bool   `toml:"quiet" mapstructure:"quiet"`
+	Format string `toml:"format" mapstructure:"format"`
+}
+
+type mainfluxFile struct {
+	ConnFile string `toml:"connections_file" mapstructure:"connections_file"`
+}
+
+type mfThing struct {
+	ThingID  string `toml:"thing_id" mapstructure:"thing_id"`
+	ThingKey string `toml:"thing_key" mapstructure:"thing_key"`
+}
+
+type mfChannel struct {
+	ChannelID string `toml:"channel_id" mapstructure:"channel_id"`
+}
+
+type mainflux struct {
+	Things   []mfThing   `toml:"things" mapstructure:"things"`
+	Channels []mfChannel `toml:"channels" mapstructure:"channels"`
+}
+
+// Config struct holds load test configuration
+type Config struct {
+	Test    testConfig    `toml:"test" mapstructure:"test"`
+	MQTT    mqttConfig    `toml:"mqtt" mapstructure:"mqtt"`
+	HTTP    httpConfig    `toml:"http" mapstructure:"http"`
+	Message messageConfig `toml:"message" mapstructure:"message"`
+	Reader  readerConfig  `toml:"reader" mapstructure:"reader"`
+	Log     logConfig     `toml:"log" mapstructure:"log"`
+	Mf      mainfluxFile  `toml:"mainflux" mapstructure:"mainflux"`
+}
@@ -0,0 +1,215 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package loadtest
+
+import (
+	"fmt"
+	"io/ioutil"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pelletier/go-toml"
+)
+
+// Run simulates the devices publishing the messages and verifies their
+// arrival using the readers, if the reader URL is set.
+func Run(cfg Config) (Report, error) {
+	if cfg.Test.Devices < 1 || cfg.Test.Count < 1 || cfg.Test.Rate <= 0 {
+		return Report{}, fmt.Errorf("devices, count and rate must be positive")
+	}
+	if cfg.Test.Protocol != ProtocolMQTT && cfg.Test.Protocol != ProtocolHTTP {
+		return Report{}, fmt.Errorf("unsupported protocol %q", cfg.Test.Protocol)
+	}
+
+	tmpl, err := newPayloadTemplate(cfg.Message.Template)
+	if err != nil {
+		return Report{}, fmt.Errorf("invalid payload template: %s", err)
+	}
+
+	data, err := ioutil.ReadFile(cfg.Mf.ConnFile)
+	if err != nil {
+		return Report{}, fmt.Errorf("failed to load connections file: %s", err)
+	}
+	mf := mainflux{}
+	if err := toml.Unmarshal(data, &mf); err != nil {
+		return Report{}, fmt.Errorf("cannot load Mainflux connections config %s, use tools/provision to create file", cfg.Mf.ConnFile)
+	}
+	if len(mf.Things) == 0 || len(mf.Channels) == 0 {
+		return Report{}, fmt.Errorf("connections file %s contains no things or channels", cfg.Mf.ConnFile)
+	}
+
+	// Every run publishes to its own subtopics, so that the messages of the
+	// previous runs are not counted.
+	run := time.Now().UnixNano()
+	devices := make([]*device, cfg.Test.Devices)
+	for i := range devices {
+		subtopic := fmt.Sprintf("%d.%d", run, i)
+		if cfg.Message.Subtopic != "" {
+			subtopic = fmt.Sprintf("%s.%s", cfg.Message.Subtopic, subtopic)
+		}
+		devices[i] = &device{
+			id:       fmt.Sprintf("loadtest-%d-%d", run, i),
+			thing:    mf.Things[i%len(mf.Things)],
+			chanID:   mf.Channels[i%len(mf.Channels)].ChannelID,
+			subtopic: subtopic,
+		}
+	}
+
+	var rep Report
+	var mu sync.Mutex
+	var pubLats, e2eLats []float64
+
+	done := make(chan struct{})
+	verified := make(chan struct{})
+	if cfg.Reader.URL != "" {
+		go func() {
+			lats := verify(cfg.Reader, devices, done, cfg.Log.Quiet)
+			mu.Lock()
+			e2eLats = lats
+			mu.Unlock()
+			close(verified)
+		}()
+	} else {
+		close(verified)
+	}
+
+	start := time.Now()
+	var wg sync.WaitGroup
+	for _, d := range devices {
+		wg.Add(1)
+		go func(d *device) {
+			defer wg.Done()
+			sent, failed, lats := simulate(cfg, tmpl, d)
+
+			mu.Lock()
+			rep.Sent += sent
+			rep.Failed += failed
+			pubLats = append(pubLats, lats...)
+			mu.Unlock()
+		}(d)
+	}
+	wg.Wait()
+	rep.Duration = time.Since(start)
+	close(done)
+	<-verified
+
+	rep.Devices = len(devices)
+	rep.Throughput = float64(rep.Sent) / rep.Duration.Seconds()
+	rep.PublishLatency = newPercentiles(pubLats)
+	if cfg.Reader.URL != "" {
+		for _, d := range devices {
+			rep.Records += len(d.sent)
+			rep.Arrived += d.arrived
+		}
+		if rep.Records > 0 {
+			rep.ArrivalRatio = float64(rep.Arrived) / float64(rep.Records)
+		}
+		e2e := newPercentiles(e2eLats)
+		rep.EndToEndLatency = &e2e
+	}
+
+	return rep, nil
+}
+
+// simulate publishes the messages of the device at the configured rate and
+// returns the number of the published and the failed messages, and the
+// publish latencies.
+func simulate(cfg Config, tmpl payloadTemplate, d *device) (int, int, []float64) {
+	var pub publisher
+	switch cfg.Test.Protocol {
+	case ProtocolMQTT:
+		topic := fmt.Sprintf("channels/%s/messages/%s", d.chanID, strings.ReplaceAll(d.subtopic, ".", "/"))
+		p, err := newMQTTPublisher(cfg.MQTT, d.id, d.thing, topic)
+		if err != nil {
+			log.Printf("Device %s failed to connect: %s\n", d.id, err)
+			return 0, cfg.Test.Count, nil
+		}
+		pub = p
+	default:
+		pub = newHTTPPublisher(cfg.HTTP, d.thing, d.chanID, d.subtopic)
+	}
+	defer pub.close()
+
+	ticker := time.NewTicker(time.Duration(float64(time.Second) / cfg.Test.Rate))
+	defer ticker.Stop()
+
+	var sent, failed int
+	var lats []float64
+	for i := 0; i < cfg.Test.Count; i++ {
+		if i > 0 {
+			<-ticker.C
+		}
+
+		now := time.Now()
+		payload, records, err := tmpl.render(d.thing.ThingID, i, now)
+		if err != nil {
+			log.Printf("Device %s failed to render payload: %s\n", d.id, err)
+			failed++
+			continue
+		}
+
+		if err := pub.publish(payload); err != nil {
+			if !cfg.Log.Quiet {
+				log.Printf("Device %s failed to publish: %s\n", d.id, err)
+			}
+			failed++
+			continue
+		}
+
+		lats = append(lats, millis(time.Since(now)))
+		d.recordSent(now, records)
+		sent++
+	}
+
+	return sent, failed, lats
+}
+
+// verify polls the readers for the number of the stored messages of every
+// device, until all published messages arrive, or until the timeout elapses
+// once the publishing is done. End-to-end latencies are measured with the
+// resolution of the poll interval.
+func verify(cfg readerConfig, devices []*device, done <-chan struct{}, quiet bool) []float64 {
+	v := newVerifier(cfg)
+	ticker := time.NewTicker(cfg.Interval)
+	defer ticker.Stop()
+
+	var lats []float64
+	var deadline <-chan time.Time
+	for {
+		select {
+		case <-done:
+			done = nil
+			timer := time.NewTimer(cfg.Timeout)
+			defer timer.Stop()
+			deadline = timer.C
+		case <-deadline:
+			return lats
+		case <-ticker.C:
+		}
+
+		pending := false
+		for _, d := range devices {
+			count, err := v.count(d)
+			if err != nil {
+				if !quiet {
+					log.Printf("Failed to count messages of device %s: %s\n", d.id, err)
+				}
+				pending = true
+				continue
+			}
+			lats = append(lats, d.recordArrived(count, time.Now())...)
+			pending = pending || d.pending()
+		}
+
+		if done == nil && !pending {
+			return lats
+		}
+	}
+}
+
+func millis(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
@@ -0,0 +1,118 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package loadtest
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	mqtt "github.com/eclipse/paho.mqtt.golang"
+)
+
+const senmlContentType = "application/senml+json"
+
+// publisher publishes the messages of a single device.
+type publisher interface {
+	// publish publishes the payload and returns once the adapter accepts it.
+	publish(payload []byte) error
+
+	// close closes the device connection.
+	close()
+}
+
+type mqttPublisher struct {
+	client  mqtt.Client
+	topic   string
+	qos     byte
+	timeout time.Duration
+}
+
+func newMQTTPublisher(cfg mqttConfig, id string, thing mfThing, topic string) (publisher, error) {
+	opts := mqtt.NewClientOptions().
+		AddBroker(cfg.URL).
+		SetClientID(id).
+		SetUsername(thing.ThingID).
+		SetPassword(thing.ThingKey).
+		SetCleanSession(true).
+		SetAutoReconnect(true)
+
+	client := mqtt.NewClient(opts)
+	token := client.Connect()
+	if !token.WaitTimeout(cfg.Timeout) {
+		return nil, fmt.Errorf("connection timed out")
+	}
+	if err := token.Error(); err != nil {
+		return nil, err
+	}
+
+	return &mqttPublisher{
+		client:  client,
+		topic:   topic,
+		qos:     byte(cfg.QoS),
+		timeout: cfg.Timeout,
+	}, nil
+}
+
+func (p *mqttPublisher) publish(payload []byte) error {
+	token := p.client.Publish(p.topic, p.qos, false, payload)
+	if !token.WaitTimeout(p.timeout) {
+		return fmt.Errorf("publish timed out")
+	}
+
+	return token.Error()
+}
+
+func (p *mqttPublisher) close() {
+	p.client.Disconnect(0)
+}
+
+type httpPublisher struct {
+	client *http.Client
+	url    string
+	key    string
+}
+
+func newHTTPPublisher(cfg httpConfig, thing mfThing, chanID, subtopic string) publisher {
+	url := fmt.Sprintf("%s/channels/%s/messages", strings.TrimSuffix(cfg.URL, "/"), chanID)
+	if subtopic != "" {
+		url = fmt.Sprintf("%s/%s", url, strings.ReplaceAll(subtopic, ".", "/"))
+	}
+
+	return &httpPublisher{
+		client: &http.Client{Timeout: cfg.Timeout},
+		url:    url,
+		key:    thing.ThingKey,
+	}
+}
+
+func (p *httpPublisher) publish(payload []byte) error {
+	req, err := http.NewRequest(http.MethodPost, p.url, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", senmlContentType)
+	req.Header.Set("Authorization", "Thing "+p.key)
+
+	res, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	io.Copy(ioutil.Discard, res.Body)
+
+	if res.StatusCode != http.StatusAccepted && res.StatusCode != http.StatusCreated {
+		return fmt.Errorf("unexpected response status %d", res.StatusCode)
+	}
+
+	return nil
+}
+
+func (p *httpPublisher) close() {
+	p.client.CloseIdleConnections()
+}
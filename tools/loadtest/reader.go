@@ -0,0 +1,108 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package loadtest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// device is a simulated device publishing the messages to its own subtopic,
+// so that its stored messages are counted separately by the readers.
+type device struct {
+	id       string
+	thing    mfThing
+	chanID   string
+	subtopic string
+
+	mu sync.Mutex
+	// sent contains the publish times of the published SenML records.
+	sent    []time.Time
+	arrived int
+}
+
+func (d *device) recordSent(t time.Time, records int) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	for i := 0; i < records; i++ {
+		d.sent = append(d.sent, t)
+	}
+}
+
+// recordArrived marks the records up to the stored count as arrived at the
+// given time and returns their end-to-end latencies. Records are assumed to
+// be stored in the order they are published.
+func (d *device) recordArrived(count int, at time.Time) []float64 {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if count > len(d.sent) {
+		count = len(d.sent)
+	}
+
+	var lats []float64
+	for ; d.arrived < count; d.arrived++ {
+		lats = append(lats, millis(at.Sub(d.sent[d.arrived])))
+	}
+
+	return lats
+}
+
+func (d *device) pending() bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return d.arrived < len(d.sent)
+}
+
+type verifier struct {
+	client *http.Client
+	url    string
+}
+
+func newVerifier(cfg readerConfig) *verifier {
+	return &verifier{
+		client: &http.Client{Timeout: cfg.Interval},
+		url:    strings.TrimSuffix(cfg.URL, "/"),
+	}
+}
+
+// count returns the number of the stored messages of the device.
+func (v *verifier) count(d *device) (int, error) {
+	q := url.Values{}
+	q.Set("subtopic", d.subtopic)
+	q.Set("publisher", d.thing.ThingID)
+	u := fmt.Sprintf("%s/channels/%s/messages/count?%s", v.url, d.chanID, q.Encode())
+
+	req, err := http.NewRequest(http.MethodGet, u, nil)
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Authorization", "Thing "+d.thing.ThingKey)
+
+	res, err := v.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("unexpected response status %d", res.StatusCode)
+	}
+
+	var cr struct {
+		Count int `json:"count"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&cr); err != nil {
+		return 0, err
+	}
+
+	return cr.Count, nil
+}
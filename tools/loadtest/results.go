@@ -0,0 +1,99 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package loadtest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"gonum.org/v1/gonum/stat"
+)
+
+// Percentiles contains the latency percentiles in milliseconds.
+type Percentiles struct {
+	Count int     `json:"count"`
+	Min   float64 `json:"min"`
+	P50   float64 `json:"p50"`
+	P90   float64 `json:"p90"`
+	P95   float64 `json:"p95"`
+	P99   float64 `json:"p99"`
+	Max   float64 `json:"max"`
+	Mean  float64 `json:"mean"`
+}
+
+func newPercentiles(lats []float64) Percentiles {
+	if len(lats) == 0 {
+		return Percentiles{}
+	}
+
+	sorted := append([]float64(nil), lats...)
+	sort.Float64s(sorted)
+
+	return Percentiles{
+		Count: len(sorted),
+		Min:   sorted[0],
+		P50:   stat.Quantile(0.5, stat.Empirical, sorted, nil),
+		P90:   stat.Quantile(0.9, stat.Empirical, sorted, nil),
+		P95:   stat.Quantile(0.95, stat.Empirical, sorted, nil),
+		P99:   stat.Quantile(0.99, stat.Empirical, sorted, nil),
+		Max:   sorted[len(sorted)-1],
+		Mean:  stat.Mean(sorted, nil),
+	}
+}
+
+// Report contains the load test results.
+type Report struct {
+	Devices    int           `json:"devices"`
+	Sent       int           `json:"sent"`
+	Failed     int           `json:"failed"`
+	Duration   time.Duration `json:"duration"`
+	Throughput float64       `json:"throughput"`
+	// PublishLatency is the time until the adapter accepts the message.
+	PublishLatency Percentiles `json:"publish_latency"`
+	// Records is the number of the published SenML records, and Arrived is
+	// the number of the records read from the readers.
+	Records      int     `json:"records,omitempty"`
+	Arrived      int     `json:"arrived,omitempty"`
+	ArrivalRatio float64 `json:"arrival_ratio,omitempty"`
+	// EndToEndLatency is the time until the record is read from the readers,
+	// or nil if the arrival is not verified.
+	EndToEndLatency *Percentiles `json:"end_to_end_latency,omitempty"`
+}
+
+// Print writes the report in the text or JSON format.
+func (r Report) Print(w io.Writer, format string) error {
+	if format == "json" {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "\t")
+		return enc.Encode(r)
+	}
+
+	fmt.Fprintf(w, "========= LOAD TEST (%d devices) =========\n", r.Devices)
+	fmt.Fprintf(w, "Sent:                     %d\n", r.Sent)
+	fmt.Fprintf(w, "Failed:                   %d\n", r.Failed)
+	fmt.Fprintf(w, "Duration (sec):           %.3f\n", r.Duration.Seconds())
+	fmt.Fprintf(w, "Throughput (msg/sec):     %.3f\n\n", r.Throughput)
+	printPercentiles(w, "Publish latency", r.PublishLatency)
+
+	if r.EndToEndLatency != nil {
+		fmt.Fprintf(w, "\nArrived:                  %.3f (%d/%d)\n\n", r.ArrivalRatio, r.Arrived, r.Records)
+		printPercentiles(w, "End-to-end latency", *r.EndToEndLatency)
+	}
+
+	return nil
+}
+
+func printPercentiles(w io.Writer, name string, p Percentiles) {
+	fmt.Fprintf(w, "%s (ms):\n", name)
+	fmt.Fprintf(w, "  min:  %10.3f\n", p.Min)
+	fmt.Fprintf(w, "  p50:  %10.3f\n", p.P50)
+	fmt.Fprintf(w, "  p90:  %10.3f\n", p.P90)
+	fmt.Fprintf(w, "  p95:  %10.3f\n", p.P95)
+	fmt.Fprintf(w, "  p99:  %10.3f\n", p.P99)
+	fmt.Fprintf(w, "  max:  %10.3f\n", p.Max)
+	fmt.Fprintf(w, "  mean: %10.3f\n", p.Mean)
+}
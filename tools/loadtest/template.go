@@ -0,0 +1,68 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package loadtest
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/rand"
+	"text/template"
+	"time"
+)
+
+// DefaultTemplate is the SenML payload template of a single record.
+const DefaultTemplate = `[{"bn":"{{.Device}}:","n":"temperature","u":"Cel","v":{{.Value}},"t":{{.Time}}}]`
+
+// payloadData contains the values available to the payload template.
+type payloadData struct {
+	// Device is the ID of the publishing thing.
+	Device string
+	// Seq is the sequence number of the message of the device, from 0.
+	Seq int
+	// Time is the publish time in seconds since the epoch.
+	Time float64
+	// Value is the random value between 0 and 100.
+	Value float64
+}
+
+type payloadTemplate struct {
+	tmpl *template.Template
+}
+
+func newPayloadTemplate(text string) (payloadTemplate, error) {
+	if text == "" {
+		text = DefaultTemplate
+	}
+
+	tmpl, err := template.New("payload").Parse(text)
+	if err != nil {
+		return payloadTemplate{}, err
+	}
+
+	return payloadTemplate{tmpl: tmpl}, nil
+}
+
+// render returns the payload of the message and the number of SenML
+// records it contains, which are stored as the separate messages.
+func (pt payloadTemplate) render(device string, seq int, now time.Time) ([]byte, int, error) {
+	data := payloadData{
+		Device: device,
+		Seq:    seq,
+		Time:   float64(now.UnixNano()) / 1e9,
+		Value:  rand.Float64() * 100,
+	}
+
+	var buf bytes.Buffer
+	if err := pt.tmpl.Execute(&buf, data); err != nil {
+		return nil, 0, err
+	}
+	payload := buf.Bytes()
+
+	var records []json.RawMessage
+	if err := json.Unmarshal(payload, &records); err != nil {
+		return payload, 1, nil
+	}
+
+	return payload, len(records), nil
+}
@@ -153,6 +153,34 @@ func listUsersEndpoint(svc users.Service) endpoint.Endpoint {
 	}
 }
 
+func searchUsersEndpoint(svc users.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listUsersReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+		pm := users.PageMetadata{
+			Offset:   req.offset,
+			Limit:    req.limit,
+			Email:    req.email,
+			Status:   req.status,
+			Metadata: req.metadata,
+		}
+		up, err := svc.SearchUsers(ctx, req.token, pm)
+		if err != nil {
+			return nil, err
+		}
+
+		// Admin search results include the status of each user.
+		res := buildUsersResponse(up)
+		for i, user := range up.Users {
+			res.Users[i].Status = user.Status
+		}
+
+		return res, nil
+	}
+}
+
 func updateUserEndpoint(svc users.Service) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req := request.(updateUserReq)
@@ -170,6 +198,23 @@ func updateUserEndpoint(svc users.Service) endpoint.Endpoint {
 	}
 }
 
+func updateUserByAdminEndpoint(svc users.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(updateUserByAdminReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+		user := users.User{
+			ID:       req.id,
+			Metadata: req.Metadata,
+		}
+		if err := svc.UpdateUserByAdmin(ctx, req.token, user); err != nil {
+			return nil, err
+		}
+		return updateUserRes{}, nil
+	}
+}
+
 func passwordChangeEndpoint(svc users.Service) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req := request.(passwChangeReq)
@@ -190,12 +235,155 @@ func loginEndpoint(svc users.Service) endpoint.Endpoint {
 		if err := req.validate(); err != nil {
 			return nil, err
 		}
-		token, err := svc.Login(ctx, req.user)
+		if req.otp != "" {
+			token, err := svc.LoginTOTP(ctx, req.user, req.otp, req.client)
+			if err != nil {
+				return nil, err
+			}
+
+			return tokenRes{Token: token.AccessToken, RefreshToken: token.RefreshToken}, nil
+		}
+
+		token, err := svc.Login(ctx, req.user, req.client)
+		if err != nil {
+			return nil, err
+		}
+
+		return tokenRes{Token: token.AccessToken, RefreshToken: token.RefreshToken}, nil
+	}
+}
+
+func enrollTOTPEndpoint(svc users.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(userReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		key, err := svc.EnrollTOTP(ctx, req.user)
 		if err != nil {
 			return nil, err
 		}
 
-		return tokenRes{token}, nil
+		return totpKeyRes{Secret: key.Secret, URI: key.URI}, nil
+	}
+}
+
+func confirmTOTPEndpoint(svc users.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(totpReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		codes, err := svc.ConfirmTOTP(ctx, req.user, req.code)
+		if err != nil {
+			return nil, err
+		}
+
+		return recoveryCodesRes{RecoveryCodes: codes}, nil
+	}
+}
+
+func disableTOTPEndpoint(svc users.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(disableTOTPReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		if err := svc.DisableTOTP(ctx, req.token, req.Code); err != nil {
+			return nil, err
+		}
+
+		return deleteRes{}, nil
+	}
+}
+
+func updateOrgTOTPPolicyEndpoint(svc users.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(orgTOTPPolicyReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		if err := svc.UpdateOrgTOTPPolicy(ctx, req.token, req.orgID, req.Required); err != nil {
+			return nil, err
+		}
+
+		return updateUserRes{}, nil
+	}
+}
+
+func updateOrgBrandingEndpoint(svc users.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(orgBrandingReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		if err := svc.UpdateOrgBranding(ctx, req.token, req.branding()); err != nil {
+			return nil, err
+		}
+
+		return updateUserRes{}, nil
+	}
+}
+
+func viewOrgBrandingEndpoint(svc users.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(orgReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		b, err := svc.ViewOrgBranding(ctx, req.token, req.orgID)
+		if err != nil {
+			return nil, err
+		}
+
+		res := orgBrandingRes{
+			OrgID:        b.OrgID,
+			Name:         b.Name,
+			LogoURL:      b.LogoURL,
+			PrimaryColor: b.PrimaryColor,
+			SupportEmail: b.SupportEmail,
+			Footer:       b.Footer,
+			Locale:       b.Locale,
+			UpdatedAt:    b.UpdatedAt,
+		}
+
+		return res, nil
+	}
+}
+
+func removeOrgBrandingEndpoint(svc users.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(orgReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		if err := svc.RemoveOrgBranding(ctx, req.token, req.orgID); err != nil {
+			return nil, err
+		}
+
+		return removeRes{}, nil
+	}
+}
+
+func sendTestEmailEndpoint(svc users.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(testEmailReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		if err := svc.SendTestEmail(ctx, req.token, req.orgID, req.Email); err != nil {
+			return nil, err
+		}
+
+		return passwResetReqRes{Msg: MailSent}, nil
 	}
 }
 
@@ -259,6 +447,91 @@ func restoreEndpoint(svc users.Service) endpoint.Endpoint {
 	}
 }
 
+func inviteUserEndpoint(svc users.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(inviteUserReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		inv := users.Invitation{
+			Email: req.Email,
+			OrgID: req.OrgID,
+		}
+		id, err := svc.InviteUser(ctx, req.token, req.host, inv)
+		if err != nil {
+			return nil, err
+		}
+
+		return inviteUserRes{ID: id}, nil
+	}
+}
+
+func listInvitationsEndpoint(svc users.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listInvitationsReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		pm := users.PageMetadata{
+			Offset: req.offset,
+			Limit:  req.limit,
+		}
+		page, err := svc.ListInvitations(ctx, req.token, pm)
+		if err != nil {
+			return nil, err
+		}
+
+		return buildInvitationsResponse(page), nil
+	}
+}
+
+func resendInvitationEndpoint(svc users.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(resendInvitationReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		if err := svc.ResendInvitation(ctx, req.token, req.host, req.id); err != nil {
+			return nil, err
+		}
+
+		return resendInvitationRes{}, nil
+	}
+}
+
+func revokeInvitationEndpoint(svc users.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(invitationReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		if err := svc.RevokeInvitation(ctx, req.token, req.id); err != nil {
+			return nil, err
+		}
+
+		return deleteRes{}, nil
+	}
+}
+
+func acceptInvitationEndpoint(svc users.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(acceptInvitationReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		if err := svc.AcceptInvitation(ctx, req.Token, req.Password); err != nil {
+			return nil, err
+		}
+
+		return passwChangeRes{}, nil
+	}
+}
+
 func buildUsersResponse(up users.UserPage) userPageRes {
 	res := userPageRes{
 		pageRes: pageRes{
@@ -281,6 +554,7 @@ func buildUsersResponse(up users.UserPage) userPageRes {
 
 func buildBackupResponse(admin users.User, users []users.User) backupRes {
 	res := backupRes{
+		Version: backupVersion,
 		Admin: backupUserRes{
 			ID:       admin.ID,
 			Email:    admin.Email,
@@ -328,3 +602,26 @@ func buildBackup(req restoreReq) (users.User, []users.User) {
 
 	return admin, u
 }
+
+func buildInvitationsResponse(page users.InvitationPage) invitationsPageRes {
+	res := invitationsPageRes{
+		pageRes: pageRes{
+			Total:  page.Total,
+			Offset: page.Offset,
+			Limit:  page.Limit,
+		},
+		Invitations: []invitationRes{},
+	}
+	for _, inv := range page.Invitations {
+		res.Invitations = append(res.Invitations, invitationRes{
+			ID:        inv.ID,
+			Email:     inv.Email,
+			OrgID:     inv.OrgID,
+			InvitedBy: inv.InvitedBy,
+			CreatedAt: inv.CreatedAt,
+			ExpiresAt: inv.ExpiresAt,
+		})
+	}
+
+	return res
+}
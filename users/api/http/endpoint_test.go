@@ -15,6 +15,7 @@ import (
 	"sort"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/MainfluxLabs/mainflux"
 	"github.com/MainfluxLabs/mainflux/internal/apiutil"
@@ -31,6 +32,7 @@ import (
 )
 
 const (
+	requestID    = "8c5c3f7e-0a52-4a87-9d5e-3e1b6f9a2c41"
 	contentType  = "application/json"
 	validEmail   = "user@example.com"
 	adminEmail   = "admin@example.com"
@@ -40,6 +42,8 @@ const (
 	invalidPass  = "wrong"
 	prefix       = "fe6b4e92-cc98-425e-b0aa-"
 	userNum      = 101
+
+	inviteDuration = 24 * time.Hour
 )
 
 var (
@@ -48,15 +52,15 @@ var (
 	newUser            = users.User{Email: "newuser@example.com", Password: validPass, Status: "enabled"}
 	usersList          = []users.User{admin, user}
 	metadata           = map[string]interface{}{"key": "value"}
-	notFoundRes        = toJSON(apiutil.ErrorRes{Err: errors.ErrNotFound.Error()})
-	unauthRes          = toJSON(apiutil.ErrorRes{Err: errors.ErrAuthentication.Error()})
-	weakPassword       = toJSON(apiutil.ErrorRes{Err: users.ErrPasswordFormat.Error()})
-	malformedRes       = toJSON(apiutil.ErrorRes{Err: apiutil.ErrMalformedEntity.Error()})
-	unsupportedRes     = toJSON(apiutil.ErrorRes{Err: apiutil.ErrUnsupportedContentType.Error()})
-	missingTokRes      = toJSON(apiutil.ErrorRes{Err: apiutil.ErrBearerToken.Error()})
-	missingEmailRes    = toJSON(apiutil.ErrorRes{Err: apiutil.ErrMissingEmail.Error()})
-	missingPassRes     = toJSON(apiutil.ErrorRes{Err: apiutil.ErrMissingPass.Error()})
-	invalidRestPassRes = toJSON(apiutil.ErrorRes{Err: apiutil.ErrInvalidResetPass.Error()})
+	notFoundRes        = toErrorRes(errors.ErrNotFound)
+	unauthRes          = toErrorRes(errors.ErrAuthentication)
+	weakPassword       = toErrorRes(users.ErrPasswordFormat)
+	malformedRes       = toErrorRes(apiutil.ErrMalformedEntity)
+	unsupportedRes     = toErrorRes(apiutil.ErrUnsupportedContentType)
+	missingTokRes      = toErrorRes(apiutil.ErrBearerToken)
+	missingEmailRes    = toErrorRes(apiutil.ErrMissingEmail)
+	missingPassRes     = toErrorRes(apiutil.ErrMissingPass)
+	invalidRestPassRes = toErrorRes(apiutil.ErrInvalidResetPass)
 	idProvider         = uuid.New()
 	passRegex          = regexp.MustCompile("^.{8,}$")
 )
@@ -83,15 +87,19 @@ func (tr testRequest) make() (*http.Response, error) {
 	}
 
 	req.Header.Set("Referer", "http://localhost")
+	req.Header.Set(apiutil.RequestIDHeader, requestID)
 	return tr.client.Do(req)
 }
 
 func newService() users.Service {
 	usersRepo := usmocks.NewUserRepository(usersList)
+	totpRepo := usmocks.NewTOTPRepository()
+	invitationRepo := usmocks.NewInvitationRepository()
+	brandingRepo := usmocks.NewBrandingRepository()
 	hasher := usmocks.NewHasher()
 	auth := mocks.NewAuthService(admin.ID, usersList)
 	email := usmocks.NewEmailer()
-	return users.New(usersRepo, hasher, auth, email, idProvider, passRegex)
+	return users.New(usersRepo, totpRepo, invitationRepo, brandingRepo, hasher, auth, email, idProvider, passRegex, inviteDuration)
 }
 
 func newServer(svc users.Service) *httptest.Server {
@@ -104,6 +112,10 @@ func toJSON(data interface{}) string {
 	return string(jsonData)
 }
 
+func toErrorRes(err errors.Error, details ...string) string {
+	return toJSON(apiutil.ErrorRes{Err: err.Msg(), Code: apiutil.ErrorCode(err), Message: err.Msg(), Details: details, TraceID: requestID})
+}
+
 func TestSelfRegister(t *testing.T) {
 	svc := newService()
 	ts := newServer(svc)
@@ -219,7 +231,10 @@ func TestLogin(t *testing.T) {
 	mfxTok, err := auth.Issue(context.Background(), &mainflux.IssueReq{Id: user.ID, Email: user.Email, Type: 0})
 	require.Nil(t, err, fmt.Sprintf("issue token for user got unexpected error: %s", err))
 	token := mfxTok.GetValue()
-	tokenData := toJSON(map[string]string{"token": token})
+	tokenData := toJSON(struct {
+		Token        string `json:"token"`
+		RefreshToken string `json:"refresh_token"`
+	}{token, token})
 
 	cases := []struct {
 		desc        string
@@ -232,9 +247,9 @@ func TestLogin(t *testing.T) {
 		{"login with invalid credentials", invalidData, contentType, http.StatusUnauthorized, unauthRes},
 		{"login with invalid email address", invalidEmailData, contentType, http.StatusBadRequest, malformedRes},
 		{"login non-existent user", nonexistentData, contentType, http.StatusUnauthorized, unauthRes},
-		{"login with invalid request format", "{", contentType, http.StatusBadRequest, malformedRes},
+		{"login with invalid request format", "{", contentType, http.StatusBadRequest, toErrorRes(apiutil.ErrMalformedEntity, "unexpected EOF")},
 		{"login with empty JSON request", "{}", contentType, http.StatusBadRequest, malformedRes},
-		{"login with empty request", "", contentType, http.StatusBadRequest, malformedRes},
+		{"login with empty request", "", contentType, http.StatusBadRequest, toErrorRes(apiutil.ErrMalformedEntity, "EOF")},
 		{"login with missing content type", data, "", http.StatusUnsupportedMediaType, unsupportedRes},
 	}
 
@@ -257,6 +272,133 @@ func TestLogin(t *testing.T) {
 	}
 }
 
+func TestEnrollTOTP(t *testing.T) {
+	svc := newService()
+	ts := newServer(svc)
+	defer ts.Close()
+	client := ts.Client()
+
+	data := toJSON(user)
+	invalidData := toJSON(users.User{
+		Email:    validEmail,
+		Password: "invalid_password",
+	})
+
+	cases := []struct {
+		desc        string
+		req         string
+		contentType string
+		status      int
+	}{
+		{"enroll second factor with valid credentials", data, contentType, http.StatusCreated},
+		{"enroll second factor with invalid credentials", invalidData, contentType, http.StatusUnauthorized},
+		{"enroll second factor with invalid request format", "{", contentType, http.StatusBadRequest},
+		{"enroll second factor with missing content type", data, "", http.StatusUnsupportedMediaType},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client:      client,
+			method:      http.MethodPost,
+			url:         fmt.Sprintf("%s/totp/enroll", ts.URL),
+			contentType: tc.contentType,
+			body:        strings.NewReader(tc.req),
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+	}
+}
+
+func TestInviteUser(t *testing.T) {
+	svc := newService()
+	ts := newServer(svc)
+	defer ts.Close()
+	client := ts.Client()
+
+	orgID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	data := toJSON(map[string]string{"email": "invited@example.com", "org_id": orgID})
+	invalidData := toJSON(map[string]string{"email": "invited.example.com", "org_id": orgID})
+	missingOrgData := toJSON(map[string]string{"email": "other-invited@example.com"})
+
+	cases := []struct {
+		desc        string
+		req         string
+		contentType string
+		token       string
+		status      int
+	}{
+		{"invite new user", data, contentType, admin.Email, http.StatusCreated},
+		{"invite already invited user", data, contentType, admin.Email, http.StatusConflict},
+		{"invite user with invalid email", invalidData, contentType, admin.Email, http.StatusBadRequest},
+		{"invite user without org", missingOrgData, contentType, admin.Email, http.StatusBadRequest},
+		{"invite user with invalid token", data, contentType, invalidToken, http.StatusUnauthorized},
+		{"invite user without token", data, contentType, "", http.StatusUnauthorized},
+		{"invite user with invalid request format", "{", contentType, admin.Email, http.StatusBadRequest},
+		{"invite user with missing content type", data, "", admin.Email, http.StatusUnsupportedMediaType},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client:      client,
+			method:      http.MethodPost,
+			url:         fmt.Sprintf("%s/users/invite", ts.URL),
+			contentType: tc.contentType,
+			token:       tc.token,
+			body:        strings.NewReader(tc.req),
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+	}
+}
+
+func TestUpdateOrgBranding(t *testing.T) {
+	svc := newService()
+	ts := newServer(svc)
+	defer ts.Close()
+	client := ts.Client()
+
+	orgID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	data := toJSON(map[string]string{"name": "Acme", "primary_color": "#1a2b3c", "locale": "de"})
+	invalidColorData := toJSON(map[string]string{"name": "Acme", "primary_color": "blue"})
+	invalidLogoData := toJSON(map[string]string{"name": "Acme", "logo_url": "ftp://acme.com/logo.png"})
+
+	cases := []struct {
+		desc        string
+		req         string
+		contentType string
+		token       string
+		status      int
+	}{
+		{"update branding", data, contentType, admin.Email, http.StatusOK},
+		{"update branding with invalid color", invalidColorData, contentType, admin.Email, http.StatusBadRequest},
+		{"update branding with invalid logo url", invalidLogoData, contentType, admin.Email, http.StatusBadRequest},
+		{"update branding as user", data, contentType, user.Email, http.StatusForbidden},
+		{"update branding without token", data, contentType, "", http.StatusUnauthorized},
+		{"update branding with invalid request format", "{", contentType, admin.Email, http.StatusBadRequest},
+		{"update branding with missing content type", data, "", admin.Email, http.StatusUnsupportedMediaType},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client:      client,
+			method:      http.MethodPut,
+			url:         fmt.Sprintf("%s/emails/orgs/%s/branding", ts.URL, orgID),
+			contentType: tc.contentType,
+			token:       tc.token,
+			body:        strings.NewReader(tc.req),
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+	}
+}
+
 func TestUser(t *testing.T) {
 	svc := newService()
 	ts := newServer(svc)
@@ -303,8 +445,9 @@ func TestListUsers(t *testing.T) {
 	defer ts.Close()
 	client := ts.Client()
 
-	token, err := svc.Login(context.Background(), admin)
+	tokenRes, err := svc.Login(context.Background(), admin, users.Client{})
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	token := tokenRes.AccessToken
 
 	var data []viewUserRes
 	data = append(data, viewUserRes{admin.ID, admin.Email}, viewUserRes{user.ID, user.Email})
@@ -458,14 +601,198 @@ func TestListUsers(t *testing.T) {
 
 }
 
+func TestSearchUsers(t *testing.T) {
+	svc := newService()
+	ts := newServer(svc)
+	defer ts.Close()
+	client := ts.Client()
+
+	tokenRes, err := svc.Login(context.Background(), admin, users.Client{})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	token := tokenRes.AccessToken
+
+	userTokenRes, err := svc.Login(context.Background(), user, users.Client{})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	userToken := userTokenRes.AccessToken
+
+	id, err := svc.Register(context.Background(), token, newUser)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	err = svc.DisableUser(context.Background(), token, id)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	all := []adminUserRes{
+		{ID: admin.ID, Email: admin.Email, Status: admin.Status},
+		{ID: user.ID, Email: user.Email, Status: user.Status},
+		{ID: id, Email: newUser.Email, Status: users.DisabledStatusKey},
+	}
+
+	searchURL := fmt.Sprintf("%s/admin/users", ts.URL)
+	cases := []struct {
+		desc   string
+		url    string
+		token  string
+		status int
+		res    []adminUserRes
+	}{
+		{
+			desc:   "search users of all statuses",
+			url:    searchURL,
+			token:  token,
+			status: http.StatusOK,
+			res:    all,
+		},
+		{
+			desc:   "search disabled users",
+			url:    fmt.Sprintf("%s?status=%s", searchURL, users.DisabledStatusKey),
+			token:  token,
+			status: http.StatusOK,
+			res:    all[2:],
+		},
+		{
+			desc:   "search users by email",
+			url:    fmt.Sprintf("%s?email=%s", searchURL, user.Email),
+			token:  token,
+			status: http.StatusOK,
+			res:    all[1:2],
+		},
+		{
+			desc:   "search users with invalid status",
+			url:    fmt.Sprintf("%s?status=%s", searchURL, "wrong"),
+			token:  token,
+			status: http.StatusBadRequest,
+			res:    nil,
+		},
+		{
+			desc:   "search users without admin role",
+			url:    searchURL,
+			token:  userToken,
+			status: http.StatusForbidden,
+			res:    nil,
+		},
+		{
+			desc:   "search users with invalid token",
+			url:    searchURL,
+			token:  invalidToken,
+			status: http.StatusUnauthorized,
+			res:    nil,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: client,
+			method: http.MethodGet,
+			url:    tc.url,
+			token:  tc.token,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		var data adminUsersRes
+		err = json.NewDecoder(res.Body).Decode(&data)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		assert.ElementsMatch(t, tc.res, data.Users, fmt.Sprintf("%s: expected body %v got %v", tc.desc, tc.res, data.Users))
+	}
+}
+
+func TestUpdateUserByAdmin(t *testing.T) {
+	svc := newService()
+	ts := newServer(svc)
+	defer ts.Close()
+	client := ts.Client()
+
+	tokenRes, err := svc.Login(context.Background(), admin, users.Client{})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	token := tokenRes.AccessToken
+
+	userTokenRes, err := svc.Login(context.Background(), user, users.Client{})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	userToken := userTokenRes.AccessToken
+
+	data := toJSON(map[string]interface{}{"metadata": metadata})
+
+	cases := []struct {
+		desc        string
+		id          string
+		token       string
+		contentType string
+		body        string
+		status      int
+	}{
+		{
+			desc:        "update user metadata by admin",
+			id:          user.ID,
+			token:       token,
+			contentType: contentType,
+			body:        data,
+			status:      http.StatusOK,
+		},
+		{
+			desc:        "update non-existing user metadata by admin",
+			id:          "non-existing",
+			token:       token,
+			contentType: contentType,
+			body:        data,
+			status:      http.StatusNotFound,
+		},
+		{
+			desc:        "update user metadata without admin role",
+			id:          user.ID,
+			token:       userToken,
+			contentType: contentType,
+			body:        data,
+			status:      http.StatusForbidden,
+		},
+		{
+			desc:        "update user metadata with invalid token",
+			id:          user.ID,
+			token:       invalidToken,
+			contentType: contentType,
+			body:        data,
+			status:      http.StatusUnauthorized,
+		},
+		{
+			desc:        "update user metadata with invalid request format",
+			id:          user.ID,
+			token:       token,
+			contentType: contentType,
+			body:        "{",
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "update user metadata without content type",
+			id:          user.ID,
+			token:       token,
+			contentType: "",
+			body:        data,
+			status:      http.StatusUnsupportedMediaType,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client:      client,
+			method:      http.MethodPut,
+			url:         fmt.Sprintf("%s/admin/users/%s", ts.URL, tc.id),
+			contentType: tc.contentType,
+			token:       tc.token,
+			body:        strings.NewReader(tc.body),
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+	}
+}
+
 func TestUpdateUser(t *testing.T) {
 	svc := newService()
 	ts := newServer(svc)
 	defer ts.Close()
 	client := ts.Client()
 
-	token, err := svc.Login(context.Background(), user)
+	tokenRes, err := svc.Login(context.Background(), user, users.Client{})
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	token := tokenRes.AccessToken
 
 	data := toJSON(metadata)
 	emptyData := toJSON(map[string]interface{}{})
@@ -545,9 +872,9 @@ func TestPasswordResetRequest(t *testing.T) {
 	}{
 		{"password reset request with valid email", data, contentType, http.StatusCreated, expectedExisting},
 		{"password reset request with invalid email", nonexistentData, contentType, http.StatusNotFound, notFoundRes},
-		{"password reset request with invalid request format", "{", contentType, http.StatusBadRequest, malformedRes},
+		{"password reset request with invalid request format", "{", contentType, http.StatusBadRequest, toErrorRes(apiutil.ErrMalformedEntity, "unexpected EOF")},
 		{"password reset request with empty JSON request", "{}", contentType, http.StatusBadRequest, missingEmailRes},
-		{"password reset request with empty request", "", contentType, http.StatusBadRequest, malformedRes},
+		{"password reset request with empty request", "", contentType, http.StatusBadRequest, toErrorRes(apiutil.ErrMalformedEntity, "EOF")},
 		{"password reset request with missing content type", data, "", http.StatusUnsupportedMediaType, unsupportedRes},
 	}
 
@@ -570,6 +897,35 @@ func TestPasswordResetRequest(t *testing.T) {
 	}
 }
 
+func TestPasswordResetRequestLimit(t *testing.T) {
+	svc := httpapi.ResetLimitMiddleware(newService(), time.Hour)
+	ts := newServer(svc)
+	defer ts.Close()
+	client := ts.Client()
+	data := toJSON(user)
+
+	cases := []struct {
+		desc   string
+		status int
+	}{
+		{"first password reset request", http.StatusCreated},
+		{"repeated password reset request within the interval", http.StatusTooManyRequests},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client:      client,
+			method:      http.MethodPost,
+			url:         fmt.Sprintf("%s/password/reset-request", ts.URL),
+			contentType: contentType,
+			body:        strings.NewReader(data),
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+	}
+}
+
 func TestPasswordReset(t *testing.T) {
 	svc := newService()
 	ts := newServer(svc)
@@ -616,9 +972,9 @@ func TestPasswordReset(t *testing.T) {
 		{"password reset with valid token", reqExisting, contentType, http.StatusCreated, "{}", token},
 		{"password reset with invalid token", reqNoExist, contentType, http.StatusUnauthorized, unauthRes, token},
 		{"password reset with confirm password not matching", reqPassNoMatch, contentType, http.StatusBadRequest, invalidRestPassRes, token},
-		{"password reset request with invalid request format", "{", contentType, http.StatusBadRequest, malformedRes, token},
+		{"password reset request with invalid request format", "{", contentType, http.StatusBadRequest, toErrorRes(apiutil.ErrMalformedEntity, "unexpected EOF"), token},
 		{"password reset request with empty JSON request", "{}", contentType, http.StatusBadRequest, missingPassRes, token},
-		{"password reset request with empty request", "", contentType, http.StatusBadRequest, malformedRes, token},
+		{"password reset request with empty request", "", contentType, http.StatusBadRequest, toErrorRes(apiutil.ErrMalformedEntity, "EOF"), token},
 		{"password reset request with missing content type", reqExisting, "", http.StatusUnsupportedMediaType, unsupportedRes, token},
 		{"password reset with weak password", reqPassWeak, contentType, http.StatusBadRequest, weakPassword, token},
 	}
@@ -688,7 +1044,7 @@ func TestPasswordChange(t *testing.T) {
 		{"password change with invalid old password", reqWrongPass, contentType, http.StatusUnauthorized, unauthRes, token},
 		{"password change with invalid new password", reqWeakPass, contentType, http.StatusBadRequest, weakPassword, token},
 		{"password change with empty JSON request", "{}", contentType, http.StatusBadRequest, missingPassRes, token},
-		{"password change empty request", "", contentType, http.StatusBadRequest, malformedRes, token},
+		{"password change empty request", "", contentType, http.StatusBadRequest, toErrorRes(apiutil.ErrMalformedEntity, "EOF"), token},
 		{"password change missing content type", dataResExisting, "", http.StatusUnsupportedMediaType, unsupportedRes, token},
 	}
 
@@ -718,6 +1074,17 @@ type viewUserRes struct {
 	Email string `json:"email"`
 }
 
+type adminUserRes struct {
+	ID     string `json:"id"`
+	Email  string `json:"email"`
+	Status string `json:"status"`
+}
+
+type adminUsersRes struct {
+	pageRes
+	Users []adminUserRes `json:"users"`
+}
+
 type pageRes struct {
 	Total  uint64 `json:"total"`
 	Offset uint64 `json:"offset"`
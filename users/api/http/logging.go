@@ -28,12 +28,13 @@ func LoggingMiddleware(svc users.Service, logger log.Logger) users.Service {
 
 func (lm *loggingMiddleware) SelfRegister(ctx context.Context, user users.User) (uid string, err error) {
 	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "self_register", "latency", time.Since(begin).String())
 		message := fmt.Sprintf("Method self_register for user %s took %s to complete", user.Email, time.Since(begin))
 		if err != nil {
-			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
 			return
 		}
-		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+		l.Info(fmt.Sprintf("%s without errors.", message))
 
 	}(time.Now())
 
@@ -42,12 +43,13 @@ func (lm *loggingMiddleware) SelfRegister(ctx context.Context, user users.User)
 
 func (lm *loggingMiddleware) RegisterAdmin(ctx context.Context, user users.User) (err error) {
 	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "register_admin", "latency", time.Since(begin).String())
 		message := fmt.Sprintf("Method register_admin for user %s took %s to complete", user.Email, time.Since(begin))
 		if err != nil {
-			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
 			return
 		}
-		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+		l.Info(fmt.Sprintf("%s without errors.", message))
 
 	}(time.Now())
 
@@ -56,39 +58,168 @@ func (lm *loggingMiddleware) RegisterAdmin(ctx context.Context, user users.User)
 
 func (lm *loggingMiddleware) Register(ctx context.Context, token string, user users.User) (uid string, err error) {
 	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "register", "latency", time.Since(begin).String())
 		message := fmt.Sprintf("Method register for user %s took %s to complete", user.Email, time.Since(begin))
 		if err != nil {
-			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
 			return
 		}
-		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+		l.Info(fmt.Sprintf("%s without errors.", message))
 
 	}(time.Now())
 
 	return lm.svc.Register(ctx, token, user)
 }
 
-func (lm *loggingMiddleware) Login(ctx context.Context, user users.User) (token string, err error) {
+func (lm *loggingMiddleware) Login(ctx context.Context, user users.User, client users.Client) (token users.Token, err error) {
 	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "login", "latency", time.Since(begin).String())
 		message := fmt.Sprintf("Method login for user %s took %s to complete", user.Email, time.Since(begin))
 		if err != nil {
-			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
 			return
 		}
-		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+		l.Info(fmt.Sprintf("%s without errors.", message))
 	}(time.Now())
 
-	return lm.svc.Login(ctx, user)
+	return lm.svc.Login(ctx, user, client)
+}
+
+func (lm *loggingMiddleware) LoginTOTP(ctx context.Context, user users.User, code string, client users.Client) (token users.Token, err error) {
+	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "login_totp", "latency", time.Since(begin).String())
+		message := fmt.Sprintf("Method login_totp for user %s took %s to complete", user.Email, time.Since(begin))
+		if err != nil {
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		l.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.LoginTOTP(ctx, user, code, client)
+}
+
+func (lm *loggingMiddleware) EnrollTOTP(ctx context.Context, user users.User) (key users.TOTPKey, err error) {
+	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "enroll_totp", "latency", time.Since(begin).String())
+		message := fmt.Sprintf("Method enroll_totp for user %s took %s to complete", user.Email, time.Since(begin))
+		if err != nil {
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		l.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.EnrollTOTP(ctx, user)
+}
+
+func (lm *loggingMiddleware) ConfirmTOTP(ctx context.Context, user users.User, code string) (codes []string, err error) {
+	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "confirm_totp", "latency", time.Since(begin).String())
+		message := fmt.Sprintf("Method confirm_totp for user %s took %s to complete", user.Email, time.Since(begin))
+		if err != nil {
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		l.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ConfirmTOTP(ctx, user, code)
+}
+
+func (lm *loggingMiddleware) DisableTOTP(ctx context.Context, token, code string) (err error) {
+	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "disable_totp", "latency", time.Since(begin).String())
+		message := fmt.Sprintf("Method disable_totp took %s to complete", time.Since(begin))
+		if err != nil {
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		l.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.DisableTOTP(ctx, token, code)
+}
+
+func (lm *loggingMiddleware) UpdateOrgTOTPPolicy(ctx context.Context, token, orgID string, required bool) (err error) {
+	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "update_org_totp_policy", "latency", time.Since(begin).String())
+		message := fmt.Sprintf("Method update_org_totp_policy for org %s took %s to complete", orgID, time.Since(begin))
+		if err != nil {
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		l.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.UpdateOrgTOTPPolicy(ctx, token, orgID, required)
+}
+
+func (lm *loggingMiddleware) UpdateOrgBranding(ctx context.Context, token string, b users.Branding) (err error) {
+	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "update_org_branding", "latency", time.Since(begin).String())
+		message := fmt.Sprintf("Method update_org_branding for org %s took %s to complete", b.OrgID, time.Since(begin))
+		if err != nil {
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		l.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.UpdateOrgBranding(ctx, token, b)
+}
+
+func (lm *loggingMiddleware) ViewOrgBranding(ctx context.Context, token, orgID string) (b users.Branding, err error) {
+	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "view_org_branding", "latency", time.Since(begin).String())
+		message := fmt.Sprintf("Method view_org_branding for org %s took %s to complete", orgID, time.Since(begin))
+		if err != nil {
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		l.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ViewOrgBranding(ctx, token, orgID)
+}
+
+func (lm *loggingMiddleware) RemoveOrgBranding(ctx context.Context, token, orgID string) (err error) {
+	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "remove_org_branding", "latency", time.Since(begin).String())
+		message := fmt.Sprintf("Method remove_org_branding for org %s took %s to complete", orgID, time.Since(begin))
+		if err != nil {
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		l.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.RemoveOrgBranding(ctx, token, orgID)
+}
+
+func (lm *loggingMiddleware) SendTestEmail(ctx context.Context, token, orgID, email string) (err error) {
+	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "send_test_email", "latency", time.Since(begin).String())
+		message := fmt.Sprintf("Method send_test_email for org %s took %s to complete", orgID, time.Since(begin))
+		if err != nil {
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		l.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.SendTestEmail(ctx, token, orgID, email)
 }
 
 func (lm *loggingMiddleware) ViewUser(ctx context.Context, token, id string) (u users.User, err error) {
 	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "view_user", "latency", time.Since(begin).String())
 		message := fmt.Sprintf("Method view_user for user %s took %s to complete", u.Email, time.Since(begin))
 		if err != nil {
-			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
 			return
 		}
-		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+		l.Info(fmt.Sprintf("%s without errors.", message))
 	}(time.Now())
 
 	return lm.svc.ViewUser(ctx, token, id)
@@ -96,12 +227,13 @@ func (lm *loggingMiddleware) ViewUser(ctx context.Context, token, id string) (u
 
 func (lm *loggingMiddleware) ViewProfile(ctx context.Context, token string) (u users.User, err error) {
 	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "view_profile", "latency", time.Since(begin).String())
 		message := fmt.Sprintf("Method view_profile for user %s took %s to complete", u.Email, time.Since(begin))
 		if err != nil {
-			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
 			return
 		}
-		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+		l.Info(fmt.Sprintf("%s without errors.", message))
 	}(time.Now())
 
 	return lm.svc.ViewProfile(ctx, token)
@@ -109,25 +241,41 @@ func (lm *loggingMiddleware) ViewProfile(ctx context.Context, token string) (u u
 
 func (lm *loggingMiddleware) ListUsers(ctx context.Context, token string, pm users.PageMetadata) (e users.UserPage, err error) {
 	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "list_users", "latency", time.Since(begin).String())
 		message := fmt.Sprintf("Method list_users for token %s took %s to complete", token, time.Since(begin))
 		if err != nil {
-			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
 			return
 		}
-		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+		l.Info(fmt.Sprintf("%s without errors.", message))
 	}(time.Now())
 
 	return lm.svc.ListUsers(ctx, token, pm)
 }
 
+func (lm *loggingMiddleware) SearchUsers(ctx context.Context, token string, pm users.PageMetadata) (e users.UserPage, err error) {
+	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "search_users_by_admin", "latency", time.Since(begin).String())
+		message := fmt.Sprintf("Method search_users_by_admin for token %s took %s to complete", token, time.Since(begin))
+		if err != nil {
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		l.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.SearchUsers(ctx, token, pm)
+}
+
 func (lm *loggingMiddleware) ListUsersByIDs(ctx context.Context, ids []string) (u users.UserPage, err error) {
 	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "list_users_by_ids", "latency", time.Since(begin).String())
 		message := fmt.Sprintf("Method list_users_by_ids for ids %s took %s to complete", ids, time.Since(begin))
 		if err != nil {
-			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
 			return
 		}
-		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+		l.Info(fmt.Sprintf("%s without errors.", message))
 	}(time.Now())
 
 	return lm.svc.ListUsersByIDs(ctx, ids)
@@ -135,12 +283,13 @@ func (lm *loggingMiddleware) ListUsersByIDs(ctx context.Context, ids []string) (
 
 func (lm *loggingMiddleware) ListUsersByEmails(ctx context.Context, emails []string) (u []users.User, err error) {
 	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "list_users_by_emails", "latency", time.Since(begin).String())
 		message := fmt.Sprintf("Method list_users_by_emails for emails %s took %s to complete", emails, time.Since(begin))
 		if err != nil {
-			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
 			return
 		}
-		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+		l.Info(fmt.Sprintf("%s without errors.", message))
 	}(time.Now())
 
 	return lm.svc.ListUsersByEmails(ctx, emails)
@@ -148,25 +297,41 @@ func (lm *loggingMiddleware) ListUsersByEmails(ctx context.Context, emails []str
 
 func (lm *loggingMiddleware) UpdateUser(ctx context.Context, token string, u users.User) (err error) {
 	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "update_user", "latency", time.Since(begin).String())
 		message := fmt.Sprintf("Method update_user for user %s took %s to complete", u.Email, time.Since(begin))
 		if err != nil {
-			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
 			return
 		}
-		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+		l.Info(fmt.Sprintf("%s without errors.", message))
 	}(time.Now())
 
 	return lm.svc.UpdateUser(ctx, token, u)
 }
 
+func (lm *loggingMiddleware) UpdateUserByAdmin(ctx context.Context, token string, u users.User) (err error) {
+	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "update_user_by_admin", "latency", time.Since(begin).String())
+		message := fmt.Sprintf("Method update_user_by_admin for user %s took %s to complete", u.ID, time.Since(begin))
+		if err != nil {
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		l.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.UpdateUserByAdmin(ctx, token, u)
+}
+
 func (lm *loggingMiddleware) GenerateResetToken(ctx context.Context, email, host string) (err error) {
 	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "generate_reset_token", "latency", time.Since(begin).String())
 		message := fmt.Sprintf("Method generate_reset_token for user %s took %s to complete", email, time.Since(begin))
 		if err != nil {
-			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
 			return
 		}
-		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+		l.Info(fmt.Sprintf("%s without errors.", message))
 	}(time.Now())
 
 	return lm.svc.GenerateResetToken(ctx, email, host)
@@ -174,12 +339,13 @@ func (lm *loggingMiddleware) GenerateResetToken(ctx context.Context, email, host
 
 func (lm *loggingMiddleware) ChangePassword(ctx context.Context, email, password, oldPassword string) (err error) {
 	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "change_password", "latency", time.Since(begin).String())
 		message := fmt.Sprintf("Method change_password for user %s took %s to complete", email, time.Since(begin))
 		if err != nil {
-			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
 			return
 		}
-		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+		l.Info(fmt.Sprintf("%s without errors.", message))
 	}(time.Now())
 
 	return lm.svc.ChangePassword(ctx, email, password, oldPassword)
@@ -187,12 +353,13 @@ func (lm *loggingMiddleware) ChangePassword(ctx context.Context, email, password
 
 func (lm *loggingMiddleware) ResetPassword(ctx context.Context, email, password string) (err error) {
 	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "reset_password", "latency", time.Since(begin).String())
 		message := fmt.Sprintf("Method reset_password for user %s took %s to complete", email, time.Since(begin))
 		if err != nil {
-			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
 			return
 		}
-		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+		l.Info(fmt.Sprintf("%s without errors.", message))
 	}(time.Now())
 
 	return lm.svc.ResetPassword(ctx, email, password)
@@ -200,12 +367,13 @@ func (lm *loggingMiddleware) ResetPassword(ctx context.Context, email, password
 
 func (lm *loggingMiddleware) SendPasswordReset(ctx context.Context, host, email, token string) (err error) {
 	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "send_password_reset", "latency", time.Since(begin).String())
 		message := fmt.Sprintf("Method send_password_reset for user %s took %s to complete", email, time.Since(begin))
 		if err != nil {
-			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
 			return
 		}
-		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+		l.Info(fmt.Sprintf("%s without errors.", message))
 	}(time.Now())
 
 	return lm.svc.SendPasswordReset(ctx, host, email, token)
@@ -213,12 +381,13 @@ func (lm *loggingMiddleware) SendPasswordReset(ctx context.Context, host, email,
 
 func (lm *loggingMiddleware) EnableUser(ctx context.Context, token string, id string) (err error) {
 	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "enable_user", "latency", time.Since(begin).String())
 		message := fmt.Sprintf("Method enable_user for user %s took %s to complete", id, time.Since(begin))
 		if err != nil {
-			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
 			return
 		}
-		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+		l.Info(fmt.Sprintf("%s without errors.", message))
 	}(time.Now())
 
 	return lm.svc.EnableUser(ctx, token, id)
@@ -226,21 +395,93 @@ func (lm *loggingMiddleware) EnableUser(ctx context.Context, token string, id st
 
 func (lm *loggingMiddleware) DisableUser(ctx context.Context, token string, id string) (err error) {
 	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "disable_user", "latency", time.Since(begin).String())
 		message := fmt.Sprintf("Method disable_user for user %s took %s to complete", id, time.Since(begin))
 		if err != nil {
-			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
 			return
 		}
-		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+		l.Info(fmt.Sprintf("%s without errors.", message))
 	}(time.Now())
 
 	return lm.svc.DisableUser(ctx, token, id)
 }
 
+func (lm *loggingMiddleware) InviteUser(ctx context.Context, token, host string, inv users.Invitation) (id string, err error) {
+	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "invite_user", "latency", time.Since(begin).String())
+		message := fmt.Sprintf("Method invite_user for user %s took %s to complete", inv.Email, time.Since(begin))
+		if err != nil {
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		l.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.InviteUser(ctx, token, host, inv)
+}
+
+func (lm *loggingMiddleware) ListInvitations(ctx context.Context, token string, pm users.PageMetadata) (page users.InvitationPage, err error) {
+	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "list_invitations", "latency", time.Since(begin).String())
+		message := fmt.Sprintf("Method list_invitations took %s to complete", time.Since(begin))
+		if err != nil {
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		l.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ListInvitations(ctx, token, pm)
+}
+
+func (lm *loggingMiddleware) ResendInvitation(ctx context.Context, token, host, id string) (err error) {
+	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "resend_invitation", "latency", time.Since(begin).String())
+		message := fmt.Sprintf("Method resend_invitation for invitation %s took %s to complete", id, time.Since(begin))
+		if err != nil {
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		l.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ResendInvitation(ctx, token, host, id)
+}
+
+func (lm *loggingMiddleware) RevokeInvitation(ctx context.Context, token, id string) (err error) {
+	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "revoke_invitation", "latency", time.Since(begin).String())
+		message := fmt.Sprintf("Method revoke_invitation for invitation %s took %s to complete", id, time.Since(begin))
+		if err != nil {
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		l.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.RevokeInvitation(ctx, token, id)
+}
+
+func (lm *loggingMiddleware) AcceptInvitation(ctx context.Context, invitationToken, password string) (err error) {
+	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "accept_invitation", "latency", time.Since(begin).String())
+		message := fmt.Sprintf("Method accept_invitation took %s to complete", time.Since(begin))
+		if err != nil {
+			l.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		l.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.AcceptInvitation(ctx, invitationToken, password)
+}
+
 func (lm *loggingMiddleware) Backup(ctx context.Context, token string) (users.User, []users.User, error) {
 	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "backup", "latency", time.Since(begin).String())
 		message := fmt.Sprintf("Method backup for token %s took %s to complete", token, time.Since(begin))
-		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+		l.Info(fmt.Sprintf("%s without errors.", message))
 	}(time.Now())
 
 	return lm.svc.Backup(ctx, token)
@@ -248,8 +489,9 @@ func (lm *loggingMiddleware) Backup(ctx context.Context, token string) (users.Us
 
 func (lm *loggingMiddleware) Restore(ctx context.Context, token string, admin users.User, users []users.User) error {
 	defer func(begin time.Time) {
+		l := lm.logger.WithContext(ctx).With("method", "restore", "latency", time.Since(begin).String())
 		message := fmt.Sprintf("Method restore for token %s took %s to complete", token, time.Since(begin))
-		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+		l.Info(fmt.Sprintf("%s without errors.", message))
 	}(time.Now())
 
 	return lm.svc.Restore(ctx, token, admin, users)
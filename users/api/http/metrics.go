@@ -57,13 +57,94 @@ func (ms *metricsMiddleware) Register(ctx context.Context, token string, user us
 	return ms.svc.Register(ctx, token, user)
 }
 
-func (ms *metricsMiddleware) Login(ctx context.Context, user users.User) (string, error) {
+func (ms *metricsMiddleware) Login(ctx context.Context, user users.User, client users.Client) (users.Token, error) {
 	defer func(begin time.Time) {
 		ms.counter.With("method", "login").Add(1)
 		ms.latency.With("method", "login").Observe(time.Since(begin).Seconds())
 	}(time.Now())
 
-	return ms.svc.Login(ctx, user)
+	return ms.svc.Login(ctx, user, client)
+}
+
+func (ms *metricsMiddleware) LoginTOTP(ctx context.Context, user users.User, code string, client users.Client) (users.Token, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "login_totp").Add(1)
+		ms.latency.With("method", "login_totp").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.LoginTOTP(ctx, user, code, client)
+}
+
+func (ms *metricsMiddleware) EnrollTOTP(ctx context.Context, user users.User) (users.TOTPKey, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "enroll_totp").Add(1)
+		ms.latency.With("method", "enroll_totp").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.EnrollTOTP(ctx, user)
+}
+
+func (ms *metricsMiddleware) ConfirmTOTP(ctx context.Context, user users.User, code string) ([]string, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "confirm_totp").Add(1)
+		ms.latency.With("method", "confirm_totp").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ConfirmTOTP(ctx, user, code)
+}
+
+func (ms *metricsMiddleware) DisableTOTP(ctx context.Context, token, code string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "disable_totp").Add(1)
+		ms.latency.With("method", "disable_totp").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.DisableTOTP(ctx, token, code)
+}
+
+func (ms *metricsMiddleware) UpdateOrgTOTPPolicy(ctx context.Context, token, orgID string, required bool) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "update_org_totp_policy").Add(1)
+		ms.latency.With("method", "update_org_totp_policy").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.UpdateOrgTOTPPolicy(ctx, token, orgID, required)
+}
+
+func (ms *metricsMiddleware) UpdateOrgBranding(ctx context.Context, token string, b users.Branding) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "update_org_branding").Add(1)
+		ms.latency.With("method", "update_org_branding").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.UpdateOrgBranding(ctx, token, b)
+}
+
+func (ms *metricsMiddleware) ViewOrgBranding(ctx context.Context, token, orgID string) (users.Branding, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "view_org_branding").Add(1)
+		ms.latency.With("method", "view_org_branding").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ViewOrgBranding(ctx, token, orgID)
+}
+
+func (ms *metricsMiddleware) RemoveOrgBranding(ctx context.Context, token, orgID string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "remove_org_branding").Add(1)
+		ms.latency.With("method", "remove_org_branding").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.RemoveOrgBranding(ctx, token, orgID)
+}
+
+func (ms *metricsMiddleware) SendTestEmail(ctx context.Context, token, orgID, email string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "send_test_email").Add(1)
+		ms.latency.With("method", "send_test_email").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.SendTestEmail(ctx, token, orgID, email)
 }
 
 func (ms *metricsMiddleware) ViewUser(ctx context.Context, token, id string) (users.User, error) {
@@ -93,6 +174,15 @@ func (ms *metricsMiddleware) ListUsers(ctx context.Context, token string, pm use
 	return ms.svc.ListUsers(ctx, token, pm)
 }
 
+func (ms *metricsMiddleware) SearchUsers(ctx context.Context, token string, pm users.PageMetadata) (users.UserPage, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "search_users_by_admin").Add(1)
+		ms.latency.With("method", "search_users_by_admin").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.SearchUsers(ctx, token, pm)
+}
+
 func (ms *metricsMiddleware) ListUsersByIDs(ctx context.Context, ids []string) (users.UserPage, error) {
 	defer func(begin time.Time) {
 		ms.counter.With("method", "list_users_by_ids").Add(1)
@@ -120,6 +210,15 @@ func (ms *metricsMiddleware) UpdateUser(ctx context.Context, token string, u use
 	return ms.svc.UpdateUser(ctx, token, u)
 }
 
+func (ms *metricsMiddleware) UpdateUserByAdmin(ctx context.Context, token string, u users.User) (err error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "update_user_by_admin").Add(1)
+		ms.latency.With("method", "update_user_by_admin").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.UpdateUserByAdmin(ctx, token, u)
+}
+
 func (ms *metricsMiddleware) GenerateResetToken(ctx context.Context, email, host string) error {
 	defer func(begin time.Time) {
 		ms.counter.With("method", "generate_reset_token").Add(1)
@@ -174,6 +273,51 @@ func (ms *metricsMiddleware) DisableUser(ctx context.Context, token string, id s
 	return ms.svc.DisableUser(ctx, token, id)
 }
 
+func (ms *metricsMiddleware) InviteUser(ctx context.Context, token, host string, inv users.Invitation) (string, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "invite_user").Add(1)
+		ms.latency.With("method", "invite_user").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.InviteUser(ctx, token, host, inv)
+}
+
+func (ms *metricsMiddleware) ListInvitations(ctx context.Context, token string, pm users.PageMetadata) (users.InvitationPage, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "list_invitations").Add(1)
+		ms.latency.With("method", "list_invitations").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ListInvitations(ctx, token, pm)
+}
+
+func (ms *metricsMiddleware) ResendInvitation(ctx context.Context, token, host, id string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "resend_invitation").Add(1)
+		ms.latency.With("method", "resend_invitation").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ResendInvitation(ctx, token, host, id)
+}
+
+func (ms *metricsMiddleware) RevokeInvitation(ctx context.Context, token, id string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "revoke_invitation").Add(1)
+		ms.latency.With("method", "revoke_invitation").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.RevokeInvitation(ctx, token, id)
+}
+
+func (ms *metricsMiddleware) AcceptInvitation(ctx context.Context, invitationToken, password string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "accept_invitation").Add(1)
+		ms.latency.With("method", "accept_invitation").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.AcceptInvitation(ctx, invitationToken, password)
+}
+
 func (ms *metricsMiddleware) Backup(ctx context.Context, token string) (users.User, []users.User, error) {
 	defer func(begin time.Time) {
 		ms.counter.With("method", "backup").Add(1)
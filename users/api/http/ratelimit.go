@@ -0,0 +1,57 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/users"
+)
+
+var _ users.Service = (*resetLimitMiddleware)(nil)
+
+type resetLimitMiddleware struct {
+	users.Service
+	interval time.Duration
+	mu       sync.Mutex
+	requests map[string]time.Time
+}
+
+// ResetLimitMiddleware limits password reset requests to a single request
+// per email during the interval, so that reset emails can't be flooded.
+func ResetLimitMiddleware(svc users.Service, interval time.Duration) users.Service {
+	return &resetLimitMiddleware{
+		Service:  svc,
+		interval: interval,
+		requests: make(map[string]time.Time),
+	}
+}
+
+func (rm *resetLimitMiddleware) GenerateResetToken(ctx context.Context, email, host string) error {
+	if !rm.allow(strings.ToLower(email), time.Now()) {
+		return users.ErrResetRateLimit
+	}
+
+	return rm.Service.GenerateResetToken(ctx, email, host)
+}
+
+func (rm *resetLimitMiddleware) allow(email string, now time.Time) bool {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+
+	for e, t := range rm.requests {
+		if now.Sub(t) >= rm.interval {
+			delete(rm.requests, e)
+		}
+	}
+	if _, ok := rm.requests[email]; ok {
+		return false
+	}
+	rm.requests[email] = now
+
+	return true
+}
@@ -15,7 +15,9 @@ const (
 )
 
 type userReq struct {
-	user users.User
+	user   users.User
+	otp    string
+	client users.Client
 }
 
 func (req userReq) validate() error {
@@ -96,6 +98,24 @@ func (req updateUserReq) validate() error {
 	return nil
 }
 
+type updateUserByAdminReq struct {
+	token    string
+	id       string
+	Metadata map[string]interface{} `json:"metadata,omitempty"`
+}
+
+func (req updateUserByAdminReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	if req.id == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return nil
+}
+
 type passwResetReq struct {
 	Email string `json:"email"`
 	Host  string `json:"host"`
@@ -170,6 +190,10 @@ func (req changeUserStatusReq) validate() error {
 	return nil
 }
 
+// backupVersion is the version of the backup bundle format. Bundles without
+// the version predate the versioning and are restored as the first version.
+const backupVersion = 1
+
 type backupReq struct {
 	token string
 }
@@ -186,12 +210,14 @@ type restoreUserReq struct {
 	Email    string                 `json:"email"`
 	Password string                 `json:"password"`
 	Metadata map[string]interface{} `json:"metadata"`
-	Status   string
+	Status   string                 `json:"status"`
 }
+
 type restoreReq struct {
-	token string
-	Users []restoreUserReq `json:"users"`
-	Admin restoreUserReq   `json:"admin"`
+	token   string
+	Version int              `json:"version"`
+	Users   []restoreUserReq `json:"users"`
+	Admin   restoreUserReq   `json:"admin"`
 }
 
 func (req restoreReq) validate() error {
@@ -199,6 +225,10 @@ func (req restoreReq) validate() error {
 		return apiutil.ErrBearerToken
 	}
 
+	if req.Version < 0 || req.Version > backupVersion {
+		return apiutil.ErrInvalidVersion
+	}
+
 	if len(req.Users) == 0 {
 		return apiutil.ErrEmptyList
 	}
@@ -209,3 +239,223 @@ func (req restoreReq) validate() error {
 
 	return nil
 }
+
+type totpReq struct {
+	user users.User
+	code string
+}
+
+func (req totpReq) validate() error {
+	if req.code == "" {
+		return apiutil.ErrMalformedEntity
+	}
+	return req.user.Validate()
+}
+
+type disableTOTPReq struct {
+	token string
+	Code  string `json:"code"`
+}
+
+func (req disableTOTPReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if req.Code == "" {
+		return apiutil.ErrMalformedEntity
+	}
+	return nil
+}
+
+type orgTOTPPolicyReq struct {
+	token    string
+	orgID    string
+	Required bool `json:"required"`
+}
+
+func (req orgTOTPPolicyReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if req.orgID == "" {
+		return apiutil.ErrMissingID
+	}
+	return nil
+}
+
+type orgBrandingReq struct {
+	token        string
+	orgID        string
+	Name         string `json:"name,omitempty"`
+	LogoURL      string `json:"logo_url,omitempty"`
+	PrimaryColor string `json:"primary_color,omitempty"`
+	SupportEmail string `json:"support_email,omitempty"`
+	Footer       string `json:"footer,omitempty"`
+	Locale       string `json:"locale,omitempty"`
+}
+
+func (req orgBrandingReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if req.orgID == "" {
+		return apiutil.ErrMissingID
+	}
+	return req.branding().Validate()
+}
+
+func (req orgBrandingReq) branding() users.Branding {
+	return users.Branding{
+		OrgID:        req.orgID,
+		Name:         req.Name,
+		LogoURL:      req.LogoURL,
+		PrimaryColor: req.PrimaryColor,
+		SupportEmail: req.SupportEmail,
+		Footer:       req.Footer,
+		Locale:       req.Locale,
+	}
+}
+
+type orgReq struct {
+	token string
+	orgID string
+}
+
+func (req orgReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if req.orgID == "" {
+		return apiutil.ErrMissingID
+	}
+	return nil
+}
+
+type testEmailReq struct {
+	token string
+	orgID string
+	Email string `json:"email,omitempty"`
+}
+
+func (req testEmailReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if req.orgID == "" {
+		return apiutil.ErrMissingID
+	}
+	if req.Email != "" {
+		return users.User{Email: req.Email}.Validate()
+	}
+	return nil
+}
+
+type inviteUserReq struct {
+	token string
+	host  string
+	Email string `json:"email"`
+	OrgID string `json:"org_id"`
+}
+
+func (req inviteUserReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	if req.Email == "" {
+		return apiutil.ErrMissingEmail
+	}
+
+	if req.OrgID == "" {
+		return apiutil.ErrMissingID
+	}
+
+	if req.host == "" {
+		return apiutil.ErrMissingHost
+	}
+
+	return nil
+}
+
+type listInvitationsReq struct {
+	token  string
+	offset uint64
+	limit  uint64
+}
+
+func (req listInvitationsReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	if req.limit > maxLimitSize {
+		return apiutil.ErrLimitSize
+	}
+
+	return nil
+}
+
+type invitationReq struct {
+	token string
+	id    string
+}
+
+func (req invitationReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	if req.id == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return nil
+}
+
+type resendInvitationReq struct {
+	token string
+	host  string
+	id    string
+}
+
+func (req resendInvitationReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	if req.id == "" {
+		return apiutil.ErrMissingID
+	}
+
+	if req.host == "" {
+		return apiutil.ErrMissingHost
+	}
+
+	return nil
+}
+
+type acceptInvitationReq struct {
+	Token    string `json:"token"`
+	Password string `json:"password"`
+	ConfPass string `json:"confirm_password"`
+}
+
+func (req acceptInvitationReq) validate() error {
+	if req.Token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	if req.Password == "" {
+		return apiutil.ErrMissingPass
+	}
+
+	if req.ConfPass == "" {
+		return apiutil.ErrMissingConfPass
+	}
+
+	if req.Password != req.ConfPass {
+		return apiutil.ErrInvalidResetPass
+	}
+
+	return nil
+}
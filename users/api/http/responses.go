@@ -6,6 +6,7 @@ package http
 import (
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/MainfluxLabs/mainflux"
 )
@@ -16,6 +17,11 @@ var (
 	_ mainflux.Response = (*passwChangeRes)(nil)
 	_ mainflux.Response = (*createUserRes)(nil)
 	_ mainflux.Response = (*deleteRes)(nil)
+	_ mainflux.Response = (*totpKeyRes)(nil)
+	_ mainflux.Response = (*recoveryCodesRes)(nil)
+	_ mainflux.Response = (*inviteUserRes)(nil)
+	_ mainflux.Response = (*invitationsPageRes)(nil)
+	_ mainflux.Response = (*resendInvitationRes)(nil)
 )
 
 // MailSent message response when link is sent
@@ -55,7 +61,8 @@ func (res createUserRes) Empty() bool {
 }
 
 type tokenRes struct {
-	Token string `json:"token,omitempty"`
+	Token        string `json:"token,omitempty"`
+	RefreshToken string `json:"refresh_token,omitempty"`
 }
 
 func (res tokenRes) Code() int {
@@ -70,6 +77,39 @@ func (res tokenRes) Empty() bool {
 	return res.Token == ""
 }
 
+type totpKeyRes struct {
+	Secret string `json:"secret"`
+	URI    string `json:"uri"`
+}
+
+func (res totpKeyRes) Code() int {
+	return http.StatusCreated
+}
+
+func (res totpKeyRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res totpKeyRes) Empty() bool {
+	return false
+}
+
+type recoveryCodesRes struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+func (res recoveryCodesRes) Code() int {
+	return http.StatusOK
+}
+
+func (res recoveryCodesRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res recoveryCodesRes) Empty() bool {
+	return false
+}
+
 type updateUserRes struct{}
 
 func (res updateUserRes) Code() int {
@@ -89,6 +129,7 @@ type viewUserRes struct {
 	Email    string                 `json:"email"`
 	Metadata map[string]interface{} `json:"metadata,omitempty"`
 	Role     string                 `json:"role,omitempty"`
+	Status   string                 `json:"status,omitempty"`
 }
 
 func (res viewUserRes) Code() int {
@@ -103,6 +144,43 @@ func (res viewUserRes) Empty() bool {
 	return false
 }
 
+type orgBrandingRes struct {
+	OrgID        string    `json:"org_id"`
+	Name         string    `json:"name,omitempty"`
+	LogoURL      string    `json:"logo_url,omitempty"`
+	PrimaryColor string    `json:"primary_color,omitempty"`
+	SupportEmail string    `json:"support_email,omitempty"`
+	Footer       string    `json:"footer,omitempty"`
+	Locale       string    `json:"locale,omitempty"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+func (res orgBrandingRes) Code() int {
+	return http.StatusOK
+}
+
+func (res orgBrandingRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res orgBrandingRes) Empty() bool {
+	return false
+}
+
+type removeRes struct{}
+
+func (res removeRes) Code() int {
+	return http.StatusNoContent
+}
+
+func (res removeRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res removeRes) Empty() bool {
+	return true
+}
+
 type userPageRes struct {
 	pageRes
 	Users []viewUserRes `json:"users"`
@@ -151,6 +229,62 @@ func (res passwChangeRes) Empty() bool {
 	return false
 }
 
+type inviteUserRes struct {
+	ID string `json:"id"`
+}
+
+func (res inviteUserRes) Code() int {
+	return http.StatusCreated
+}
+
+func (res inviteUserRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res inviteUserRes) Empty() bool {
+	return false
+}
+
+type invitationRes struct {
+	ID        string    `json:"id"`
+	Email     string    `json:"email"`
+	OrgID     string    `json:"org_id"`
+	InvitedBy string    `json:"invited_by"`
+	CreatedAt time.Time `json:"created_at"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+type invitationsPageRes struct {
+	pageRes
+	Invitations []invitationRes `json:"invitations"`
+}
+
+func (res invitationsPageRes) Code() int {
+	return http.StatusOK
+}
+
+func (res invitationsPageRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res invitationsPageRes) Empty() bool {
+	return false
+}
+
+type resendInvitationRes struct{}
+
+func (res resendInvitationRes) Code() int {
+	return http.StatusNoContent
+}
+
+func (res resendInvitationRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res resendInvitationRes) Empty() bool {
+	return true
+}
+
 type deleteRes struct{}
 
 func (res deleteRes) Code() int {
@@ -174,8 +308,9 @@ type backupUserRes struct {
 }
 
 type backupRes struct {
-	Users []backupUserRes `json:"users"`
-	Admin backupUserRes   `json:"admin"`
+	Version int             `json:"version"`
+	Users   []backupUserRes `json:"users"`
+	Admin   backupUserRes   `json:"admin"`
 }
 
 func (res backupRes) Code() int {
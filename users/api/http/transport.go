@@ -6,6 +6,7 @@ package http
 import (
 	"context"
 	"encoding/json"
+	"net"
 	"net/http"
 	"strings"
 
@@ -34,7 +35,7 @@ const (
 )
 
 // MakeHandler returns a HTTP handler for API endpoints.
-func MakeHandler(svc users.Service, tracer opentracing.Tracer, logger logger.Logger) http.Handler {
+func MakeHandler(svc users.Service, tracer opentracing.Tracer, logger logger.Logger, checks ...mainflux.HealthCheck) http.Handler {
 	opts := []kithttp.ServerOption{
 		kithttp.ServerErrorEncoder(apiutil.LoggingErrorEncoder(logger, encodeError)),
 	}
@@ -55,6 +56,41 @@ func MakeHandler(svc users.Service, tracer opentracing.Tracer, logger logger.Log
 		opts...,
 	))
 
+	mux.Post("/users/invite", kithttp.NewServer(
+		kitot.TraceServer(tracer, "invite_user")(inviteUserEndpoint(svc)),
+		decodeInviteUser,
+		encodeResponse,
+		opts...,
+	))
+
+	mux.Get("/users/invitations", kithttp.NewServer(
+		kitot.TraceServer(tracer, "list_invitations")(listInvitationsEndpoint(svc)),
+		decodeListInvitations,
+		encodeResponse,
+		opts...,
+	))
+
+	mux.Put("/users/invitations/accept", kithttp.NewServer(
+		kitot.TraceServer(tracer, "accept_invitation")(acceptInvitationEndpoint(svc)),
+		decodeAcceptInvitation,
+		encodeResponse,
+		opts...,
+	))
+
+	mux.Post("/users/invitations/:id/resend", kithttp.NewServer(
+		kitot.TraceServer(tracer, "resend_invitation")(resendInvitationEndpoint(svc)),
+		decodeResendInvitation,
+		encodeResponse,
+		opts...,
+	))
+
+	mux.Delete("/users/invitations/:id", kithttp.NewServer(
+		kitot.TraceServer(tracer, "revoke_invitation")(revokeInvitationEndpoint(svc)),
+		decodeInvitation,
+		encodeResponse,
+		opts...,
+	))
+
 	mux.Get("/users/profile", kithttp.NewServer(
 		kitot.TraceServer(tracer, "view_profile")(viewProfileEndpoint(svc)),
 		decodeViewProfile,
@@ -111,6 +147,62 @@ func MakeHandler(svc users.Service, tracer opentracing.Tracer, logger logger.Log
 		opts...,
 	))
 
+	mux.Post("/totp/enroll", kithttp.NewServer(
+		kitot.TraceServer(tracer, "enroll_totp")(enrollTOTPEndpoint(svc)),
+		decodeCredentials,
+		encodeResponse,
+		opts...,
+	))
+
+	mux.Post("/totp/confirm", kithttp.NewServer(
+		kitot.TraceServer(tracer, "confirm_totp")(confirmTOTPEndpoint(svc)),
+		decodeTOTP,
+		encodeResponse,
+		opts...,
+	))
+
+	mux.Post("/totp/disable", kithttp.NewServer(
+		kitot.TraceServer(tracer, "disable_totp")(disableTOTPEndpoint(svc)),
+		decodeDisableTOTP,
+		encodeResponse,
+		opts...,
+	))
+
+	mux.Put("/totp/orgs/:id", kithttp.NewServer(
+		kitot.TraceServer(tracer, "update_org_totp_policy")(updateOrgTOTPPolicyEndpoint(svc)),
+		decodeOrgTOTPPolicy,
+		encodeResponse,
+		opts...,
+	))
+
+	mux.Put("/emails/orgs/:id/branding", kithttp.NewServer(
+		kitot.TraceServer(tracer, "update_org_branding")(updateOrgBrandingEndpoint(svc)),
+		decodeOrgBranding,
+		encodeResponse,
+		opts...,
+	))
+
+	mux.Get("/emails/orgs/:id/branding", kithttp.NewServer(
+		kitot.TraceServer(tracer, "view_org_branding")(viewOrgBrandingEndpoint(svc)),
+		decodeOrgReq,
+		encodeResponse,
+		opts...,
+	))
+
+	mux.Delete("/emails/orgs/:id/branding", kithttp.NewServer(
+		kitot.TraceServer(tracer, "remove_org_branding")(removeOrgBrandingEndpoint(svc)),
+		decodeOrgReq,
+		encodeResponse,
+		opts...,
+	))
+
+	mux.Post("/emails/orgs/:id/test", kithttp.NewServer(
+		kitot.TraceServer(tracer, "send_test_email")(sendTestEmailEndpoint(svc)),
+		decodeTestEmail,
+		encodeResponse,
+		opts...,
+	))
+
 	mux.Post("/users/:id/enable", kithttp.NewServer(
 		kitot.TraceServer(tracer, "enable_user")(enableUserEndpoint(svc)),
 		decodeChangeUserStatus,
@@ -125,6 +217,20 @@ func MakeHandler(svc users.Service, tracer opentracing.Tracer, logger logger.Log
 		opts...,
 	))
 
+	mux.Get("/admin/users", kithttp.NewServer(
+		kitot.TraceServer(tracer, "search_users_by_admin")(searchUsersEndpoint(svc)),
+		decodeSearchUsers,
+		encodeResponse,
+		opts...,
+	))
+
+	mux.Put("/admin/users/:id", kithttp.NewServer(
+		kitot.TraceServer(tracer, "update_user_by_admin")(updateUserByAdminEndpoint(svc)),
+		decodeUpdateUserByAdmin,
+		encodeResponse,
+		opts...,
+	))
+
 	mux.Get("/backup", kithttp.NewServer(
 		kitot.TraceServer(tracer, "backup")(backupEndpoint(svc)),
 		decodeBackup,
@@ -139,10 +245,11 @@ func MakeHandler(svc users.Service, tracer opentracing.Tracer, logger logger.Log
 		opts...,
 	))
 
-	mux.GetFunc("/health", mainflux.Health("users"))
+	mux.GetFunc("/health", mainflux.Health("users", checks...))
 	mux.Handle("/metrics", promhttp.Handler())
+	mux.Handle("/log-level", mainflux.LogLevel(logger))
 
-	return mux
+	return apiutil.RequestIDMiddleware(apiutil.LocaleMiddleware(mux))
 }
 
 func decodeViewUser(_ context.Context, r *http.Request) (interface{}, error) {
@@ -161,6 +268,16 @@ func decodeViewProfile(_ context.Context, r *http.Request) (interface{}, error)
 }
 
 func decodeListUsers(_ context.Context, r *http.Request) (interface{}, error) {
+	return readListUsers(r, users.EnabledStatusKey)
+}
+
+// decodeSearchUsers decodes the admin search of users, which retrieves users
+// of all statuses unless the status is provided.
+func decodeSearchUsers(_ context.Context, r *http.Request) (interface{}, error) {
+	return readListUsers(r, users.AllStatusKey)
+}
+
+func readListUsers(r *http.Request, defStatus string) (interface{}, error) {
 	o, err := apiutil.ReadUintQuery(r, offsetKey, defOffset)
 	if err != nil {
 		return nil, err
@@ -181,7 +298,7 @@ func decodeListUsers(_ context.Context, r *http.Request) (interface{}, error) {
 		return nil, err
 	}
 
-	s, err := apiutil.ReadStringQuery(r, statusKey, users.EnabledStatusKey)
+	s, err := apiutil.ReadStringQuery(r, statusKey, defStatus)
 	if err != nil {
 		return nil, err
 	}
@@ -205,17 +322,145 @@ func decodeUpdateUser(_ context.Context, r *http.Request) (interface{}, error) {
 	return req, nil
 }
 
+func decodeUpdateUserByAdmin(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), contentType) {
+		return nil, apiutil.ErrUnsupportedContentType
+	}
+
+	req := updateUserByAdminReq{
+		token: apiutil.ExtractBearerToken(r),
+		id:    bone.GetValue(r, "id"),
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(apiutil.ErrMalformedEntity, err)
+	}
+
+	return req, nil
+}
+
 func decodeCredentials(_ context.Context, r *http.Request) (interface{}, error) {
 	if !strings.Contains(r.Header.Get("Content-Type"), contentType) {
 		return nil, apiutil.ErrUnsupportedContentType
 	}
 
-	var user users.User
-	if err := json.NewDecoder(r.Body).Decode(&user); err != nil {
+	var creds struct {
+		users.User
+		OTP string `json:"otp"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&creds); err != nil {
 		return nil, errors.Wrap(apiutil.ErrMalformedEntity, err)
 	}
-	user.Email = strings.TrimSpace(user.Email)
-	return userReq{user}, nil
+	creds.Email = strings.TrimSpace(creds.Email)
+	client := users.Client{
+		IP:        clientIP(r),
+		UserAgent: r.UserAgent(),
+	}
+
+	return userReq{user: creds.User, otp: creds.OTP, client: client}, nil
+}
+
+// clientIP returns the address of the client, preferring the one forwarded
+// by the reverse proxy in front of the service.
+func clientIP(r *http.Request) string {
+	if fwd := r.Header.Get("X-Forwarded-For"); fwd != "" {
+		return strings.TrimSpace(strings.Split(fwd, ",")[0])
+	}
+	if ip := r.Header.Get("X-Real-IP"); ip != "" {
+		return ip
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+
+	return host
+}
+
+func decodeTOTP(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), contentType) {
+		return nil, apiutil.ErrUnsupportedContentType
+	}
+
+	var creds struct {
+		users.User
+		Code string `json:"code"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&creds); err != nil {
+		return nil, errors.Wrap(apiutil.ErrMalformedEntity, err)
+	}
+	creds.Email = strings.TrimSpace(creds.Email)
+	return totpReq{user: creds.User, code: creds.Code}, nil
+}
+
+func decodeDisableTOTP(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), contentType) {
+		return nil, apiutil.ErrUnsupportedContentType
+	}
+
+	req := disableTOTPReq{token: apiutil.ExtractBearerToken(r)}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(apiutil.ErrMalformedEntity, err)
+	}
+
+	return req, nil
+}
+
+func decodeOrgTOTPPolicy(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), contentType) {
+		return nil, apiutil.ErrUnsupportedContentType
+	}
+
+	req := orgTOTPPolicyReq{
+		token: apiutil.ExtractBearerToken(r),
+		orgID: bone.GetValue(r, "id"),
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(apiutil.ErrMalformedEntity, err)
+	}
+
+	return req, nil
+}
+
+func decodeOrgBranding(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), contentType) {
+		return nil, apiutil.ErrUnsupportedContentType
+	}
+
+	req := orgBrandingReq{
+		token: apiutil.ExtractBearerToken(r),
+		orgID: bone.GetValue(r, "id"),
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(apiutil.ErrMalformedEntity, err)
+	}
+
+	return req, nil
+}
+
+func decodeOrgReq(_ context.Context, r *http.Request) (interface{}, error) {
+	req := orgReq{
+		token: apiutil.ExtractBearerToken(r),
+		orgID: bone.GetValue(r, "id"),
+	}
+
+	return req, nil
+}
+
+func decodeTestEmail(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), contentType) {
+		return nil, apiutil.ErrUnsupportedContentType
+	}
+
+	req := testEmailReq{
+		token: apiutil.ExtractBearerToken(r),
+		orgID: bone.GetValue(r, "id"),
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(apiutil.ErrMalformedEntity, err)
+	}
+
+	return req, nil
 }
 
 func decodeCreateUserReq(_ context.Context, r *http.Request) (interface{}, error) {
@@ -300,6 +545,75 @@ func decodeChangeUserStatus(_ context.Context, r *http.Request) (interface{}, er
 	return req, nil
 }
 
+func decodeInviteUser(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), contentType) {
+		return nil, apiutil.ErrUnsupportedContentType
+	}
+
+	req := inviteUserReq{
+		token: apiutil.ExtractBearerToken(r),
+		host:  r.Header.Get("Referer"),
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(apiutil.ErrMalformedEntity, err)
+	}
+	req.Email = strings.TrimSpace(req.Email)
+
+	return req, nil
+}
+
+func decodeListInvitations(_ context.Context, r *http.Request) (interface{}, error) {
+	o, err := apiutil.ReadUintQuery(r, offsetKey, defOffset)
+	if err != nil {
+		return nil, err
+	}
+
+	l, err := apiutil.ReadLimitQuery(r, limitKey, defLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	req := listInvitationsReq{
+		token:  apiutil.ExtractBearerToken(r),
+		offset: o,
+		limit:  l,
+	}
+
+	return req, nil
+}
+
+func decodeAcceptInvitation(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), contentType) {
+		return nil, apiutil.ErrUnsupportedContentType
+	}
+
+	var req acceptInvitationReq
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(apiutil.ErrMalformedEntity, err)
+	}
+
+	return req, nil
+}
+
+func decodeResendInvitation(_ context.Context, r *http.Request) (interface{}, error) {
+	req := resendInvitationReq{
+		token: apiutil.ExtractBearerToken(r),
+		host:  r.Header.Get("Referer"),
+		id:    bone.GetValue(r, "id"),
+	}
+
+	return req, nil
+}
+
+func decodeInvitation(_ context.Context, r *http.Request) (interface{}, error) {
+	req := invitationReq{
+		token: apiutil.ExtractBearerToken(r),
+		id:    bone.GetValue(r, "id"),
+	}
+
+	return req, nil
+}
+
 func decodeBackup(_ context.Context, r *http.Request) (interface{}, error) {
 	req := backupReq{token: apiutil.ExtractBearerToken(r)}
 
@@ -335,7 +649,7 @@ func encodeResponse(_ context.Context, w http.ResponseWriter, response interface
 	return json.NewEncoder(w).Encode(response)
 }
 
-func encodeError(_ context.Context, err error, w http.ResponseWriter) {
+func encodeError(ctx context.Context, err error, w http.ResponseWriter) {
 	switch {
 	case errors.Contains(err, apiutil.ErrInvalidQueryParams),
 		errors.Contains(err, apiutil.ErrMalformedEntity),
@@ -345,10 +659,15 @@ func encodeError(_ context.Context, err error, w http.ResponseWriter) {
 		err == apiutil.ErrMissingPass,
 		err == apiutil.ErrMissingConfPass,
 		err == apiutil.ErrLimitSize,
+		err == apiutil.ErrInvalidStatus,
 		err == apiutil.ErrOffsetSize,
+		err == apiutil.ErrMissingID,
+		err == apiutil.ErrEmptyList,
+		err == apiutil.ErrInvalidVersion,
 		err == apiutil.ErrInvalidResetPass:
 		w.WriteHeader(http.StatusBadRequest)
 	case errors.Contains(err, errors.ErrAuthentication),
+		errors.Contains(err, users.ErrTOTPRequired),
 		err == apiutil.ErrBearerToken:
 		w.WriteHeader(http.StatusUnauthorized)
 	case errors.Contains(err, errors.ErrAuthorization):
@@ -360,6 +679,9 @@ func encodeError(_ context.Context, err error, w http.ResponseWriter) {
 		w.WriteHeader(http.StatusUnsupportedMediaType)
 	case errors.Contains(err, errors.ErrNotFound):
 		w.WriteHeader(http.StatusNotFound)
+	case errors.Contains(err, users.ErrResetRateLimit),
+		errors.Contains(err, users.ErrTOTPLocked):
+		w.WriteHeader(http.StatusTooManyRequests)
 
 	case errors.Contains(err, uuid.ErrGeneratingID),
 		errors.Contains(err, users.ErrRecoveryToken):
@@ -377,7 +699,7 @@ func encodeError(_ context.Context, err error, w http.ResponseWriter) {
 
 	if errorVal, ok := err.(errors.Error); ok {
 		w.Header().Set("Content-Type", contentType)
-		if err := json.NewEncoder(w).Encode(apiutil.ErrorRes{Err: errorVal.Msg()}); err != nil {
+		if err := json.NewEncoder(w).Encode(apiutil.NewErrorRes(ctx, errorVal)); err != nil {
 			w.WriteHeader(http.StatusInternalServerError)
 		}
 	}
@@ -0,0 +1,68 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package users
+
+import (
+	"context"
+	"net/url"
+	"regexp"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+)
+
+const (
+	maxBrandingLen = 1024
+	maxLocaleLen   = 16
+)
+
+var colorRegexp = regexp.MustCompile("^#[0-9a-fA-F]{6}$")
+
+// Branding contains the variables used to brand the e-mails sent on behalf
+// of the org, such as invitations. Empty values fall back to the platform
+// defaults.
+type Branding struct {
+	OrgID        string
+	Name         string
+	LogoURL      string
+	PrimaryColor string
+	SupportEmail string
+	Footer       string
+	// Locale is the locale of the e-mail templates, such as "en" or "de".
+	Locale    string
+	UpdatedAt time.Time
+}
+
+// Validate returns an error if the branding variables are malformed.
+func (b Branding) Validate() error {
+	if len(b.Name) > maxBrandingLen || len(b.Footer) > maxBrandingLen || len(b.Locale) > maxLocaleLen {
+		return errors.ErrMalformedEntity
+	}
+	if b.PrimaryColor != "" && !colorRegexp.MatchString(b.PrimaryColor) {
+		return errors.ErrMalformedEntity
+	}
+	if b.SupportEmail != "" && !isEmail(b.SupportEmail) {
+		return errors.ErrMalformedEntity
+	}
+	if b.LogoURL != "" {
+		u, err := url.ParseRequestURI(b.LogoURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+			return errors.ErrMalformedEntity
+		}
+	}
+
+	return nil
+}
+
+// BrandingRepository specifies an org e-mail branding persistence API.
+type BrandingRepository interface {
+	// Save persists the branding of the org, replacing the existing one.
+	Save(ctx context.Context, b Branding) error
+
+	// RetrieveByOrg retrieves the branding of the org.
+	RetrieveByOrg(ctx context.Context, orgID string) (Branding, error)
+
+	// Remove removes the branding of the org.
+	Remove(ctx context.Context, orgID string) error
+}
@@ -6,4 +6,8 @@ package users
 // Emailer wrapper around the email
 type Emailer interface {
 	SendPasswordReset(To []string, host, token string) error
+	// SendInvitation sends the invitation branded as the inviting org.
+	SendInvitation(To []string, host, token string, b Branding) error
+	// SendTest sends the test e-mail branded as the org.
+	SendTest(To []string, b Branding) error
 }
@@ -9,20 +9,64 @@ import (
 	"github.com/MainfluxLabs/mainflux/users"
 )
 
+const (
+	passwordResetTemplate = "password_reset"
+	invitationTemplate    = "invitation"
+	testTemplate          = "test"
+)
+
 var _ users.Emailer = (*emailer)(nil)
 
 type emailer struct {
-	resetURL string
-	agent    *email.Agent
+	resetURL  string
+	inviteURL string
+	agent     *email.Agent
 }
 
 // New creates new emailer utility
-func New(url string, c *email.Config) (users.Emailer, error) {
+func New(resetURL, inviteURL string, c *email.Config) (users.Emailer, error) {
 	e, err := email.New(c)
-	return &emailer{resetURL: url, agent: e}, err
+	return &emailer{resetURL: resetURL, inviteURL: inviteURL, agent: e}, err
 }
 
 func (e *emailer) SendPasswordReset(To []string, host string, token string) error {
 	url := fmt.Sprintf("%s%s?token=%s", host, e.resetURL, token)
-	return e.agent.Send(To, "", "Password reset", "", url, "")
+	msg := email.Message{
+		To:       To,
+		Template: passwordResetTemplate,
+		Data:     map[string]interface{}{"URL": url},
+	}
+	return e.agent.Send(msg)
+}
+
+func (e *emailer) SendInvitation(To []string, host string, token string, b users.Branding) error {
+	url := fmt.Sprintf("%s%s?token=%s", host, e.inviteURL, token)
+	msg := email.Message{
+		To:       To,
+		Template: invitationTemplate,
+		Locale:   b.Locale,
+		Branding: toBranding(b),
+		Data:     map[string]interface{}{"URL": url},
+	}
+	return e.agent.Send(msg)
+}
+
+func (e *emailer) SendTest(To []string, b users.Branding) error {
+	msg := email.Message{
+		To:       To,
+		Template: testTemplate,
+		Locale:   b.Locale,
+		Branding: toBranding(b),
+	}
+	return e.agent.Send(msg)
+}
+
+func toBranding(b users.Branding) email.Branding {
+	return email.Branding{
+		Name:         b.Name,
+		LogoURL:      b.LogoURL,
+		PrimaryColor: b.PrimaryColor,
+		SupportEmail: b.SupportEmail,
+		Footer:       b.Footer,
+	}
 }
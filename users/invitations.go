@@ -0,0 +1,71 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package users
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+)
+
+const invitationTokenLen = 32
+
+// Invitation represents the invitation of the pending user to the org.
+type Invitation struct {
+	ID        string
+	UserID    string
+	Email     string
+	OrgID     string
+	InvitedBy string
+	// TokenHash is the hash of the token sent to the invitee.
+	TokenHash string
+	CreatedAt time.Time
+	ExpiresAt time.Time
+}
+
+// InvitationPage contains a page of invitations.
+type InvitationPage struct {
+	PageMetadata
+	Invitations []Invitation
+}
+
+// InvitationRepository specifies an invitation persistence API.
+type InvitationRepository interface {
+	// Save persists the invitation.
+	Save(ctx context.Context, inv Invitation) error
+
+	// RetrieveByID retrieves the invitation by its unique identifier.
+	RetrieveByID(ctx context.Context, id string) (Invitation, error)
+
+	// RetrieveByToken retrieves the invitation by the hash of its token.
+	RetrieveByToken(ctx context.Context, tokenHash string) (Invitation, error)
+
+	// RetrieveAll retrieves invitations sent by the user. If the user ID
+	// is empty, invitations sent by all users are retrieved.
+	RetrieveAll(ctx context.Context, invitedBy string, pm PageMetadata) (InvitationPage, error)
+
+	// UpdateToken replaces the token and the expiration time of the invitation.
+	UpdateToken(ctx context.Context, inv Invitation) error
+
+	// Remove removes the invitation.
+	Remove(ctx context.Context, id string) error
+}
+
+// newInvitationToken returns the random invitation token along with its hash.
+func newInvitationToken() (string, string, error) {
+	b := make([]byte, invitationTokenLen)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", err
+	}
+	token := hex.EncodeToString(b)
+
+	return token, hashInvitationToken(token), nil
+}
+
+func hashInvitationToken(token string) string {
+	h := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(h[:])
+}
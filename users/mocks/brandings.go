@@ -0,0 +1,54 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"context"
+	"sync"
+
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/users"
+)
+
+var _ users.BrandingRepository = (*brandingRepositoryMock)(nil)
+
+type brandingRepositoryMock struct {
+	mu        sync.Mutex
+	brandings map[string]users.Branding
+}
+
+// NewBrandingRepository creates in-memory org branding repository.
+func NewBrandingRepository() users.BrandingRepository {
+	return &brandingRepositoryMock{
+		brandings: make(map[string]users.Branding),
+	}
+}
+
+func (brm *brandingRepositoryMock) Save(ctx context.Context, b users.Branding) error {
+	brm.mu.Lock()
+	defer brm.mu.Unlock()
+
+	brm.brandings[b.OrgID] = b
+	return nil
+}
+
+func (brm *brandingRepositoryMock) RetrieveByOrg(ctx context.Context, orgID string) (users.Branding, error) {
+	brm.mu.Lock()
+	defer brm.mu.Unlock()
+
+	b, ok := brm.brandings[orgID]
+	if !ok {
+		return users.Branding{}, errors.ErrNotFound
+	}
+
+	return b, nil
+}
+
+func (brm *brandingRepositoryMock) Remove(ctx context.Context, orgID string) error {
+	brm.mu.Lock()
+	defer brm.mu.Unlock()
+
+	delete(brm.brandings, orgID)
+	return nil
+}
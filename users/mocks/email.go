@@ -18,3 +18,11 @@ func NewEmailer() users.Emailer {
 func (e *emailerMock) SendPasswordReset([]string, string, string) error {
 	return nil
 }
+
+func (e *emailerMock) SendInvitation([]string, string, string, users.Branding) error {
+	return nil
+}
+
+func (e *emailerMock) SendTest([]string, users.Branding) error {
+	return nil
+}
@@ -0,0 +1,121 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/users"
+)
+
+var _ users.InvitationRepository = (*invitationRepositoryMock)(nil)
+
+type invitationRepositoryMock struct {
+	mu          sync.Mutex
+	invitations map[string]users.Invitation
+}
+
+// NewInvitationRepository creates in-memory invitation repository.
+func NewInvitationRepository() users.InvitationRepository {
+	return &invitationRepositoryMock{
+		invitations: make(map[string]users.Invitation),
+	}
+}
+
+func (irm *invitationRepositoryMock) Save(ctx context.Context, inv users.Invitation) error {
+	irm.mu.Lock()
+	defer irm.mu.Unlock()
+
+	if _, ok := irm.invitations[inv.ID]; ok {
+		return errors.ErrConflict
+	}
+
+	irm.invitations[inv.ID] = inv
+	return nil
+}
+
+func (irm *invitationRepositoryMock) RetrieveByID(ctx context.Context, id string) (users.Invitation, error) {
+	irm.mu.Lock()
+	defer irm.mu.Unlock()
+
+	inv, ok := irm.invitations[id]
+	if !ok {
+		return users.Invitation{}, errors.ErrNotFound
+	}
+
+	return inv, nil
+}
+
+func (irm *invitationRepositoryMock) RetrieveByToken(ctx context.Context, tokenHash string) (users.Invitation, error) {
+	irm.mu.Lock()
+	defer irm.mu.Unlock()
+
+	for _, inv := range irm.invitations {
+		if inv.TokenHash == tokenHash {
+			return inv, nil
+		}
+	}
+
+	return users.Invitation{}, errors.ErrNotFound
+}
+
+func (irm *invitationRepositoryMock) RetrieveAll(ctx context.Context, invitedBy string, pm users.PageMetadata) (users.InvitationPage, error) {
+	irm.mu.Lock()
+	defer irm.mu.Unlock()
+
+	var invs []users.Invitation
+	for _, inv := range irm.invitations {
+		if invitedBy == "" || inv.InvitedBy == invitedBy {
+			invs = append(invs, inv)
+		}
+	}
+	sort.SliceStable(invs, func(i, j int) bool {
+		return invs[i].Email < invs[j].Email
+	})
+
+	page := users.InvitationPage{
+		PageMetadata: users.PageMetadata{
+			Total:  uint64(len(invs)),
+			Offset: pm.Offset,
+			Limit:  pm.Limit,
+		},
+		Invitations: []users.Invitation{},
+	}
+	if pm.Offset >= page.Total {
+		return page, nil
+	}
+	end := pm.Offset + pm.Limit
+	if pm.Limit == 0 || end > page.Total {
+		end = page.Total
+	}
+	page.Invitations = invs[pm.Offset:end]
+
+	return page, nil
+}
+
+func (irm *invitationRepositoryMock) UpdateToken(ctx context.Context, inv users.Invitation) error {
+	irm.mu.Lock()
+	defer irm.mu.Unlock()
+
+	saved, ok := irm.invitations[inv.ID]
+	if !ok {
+		return errors.ErrNotFound
+	}
+	saved.TokenHash = inv.TokenHash
+	saved.ExpiresAt = inv.ExpiresAt
+	irm.invitations[inv.ID] = saved
+
+	return nil
+}
+
+func (irm *invitationRepositoryMock) Remove(ctx context.Context, id string) error {
+	irm.mu.Lock()
+	defer irm.mu.Unlock()
+
+	delete(irm.invitations, id)
+	return nil
+}
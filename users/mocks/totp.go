@@ -0,0 +1,161 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/users"
+)
+
+var _ users.TOTPRepository = (*totpRepositoryMock)(nil)
+
+type totpRepositoryMock struct {
+	mu       sync.Mutex
+	totps    map[string]users.TOTP
+	policies map[string]bool
+}
+
+// NewTOTPRepository creates in-memory second factor repository.
+func NewTOTPRepository() users.TOTPRepository {
+	return &totpRepositoryMock{
+		totps:    make(map[string]users.TOTP),
+		policies: make(map[string]bool),
+	}
+}
+
+func (trm *totpRepositoryMock) Save(ctx context.Context, t users.TOTP) error {
+	trm.mu.Lock()
+	defer trm.mu.Unlock()
+
+	trm.totps[t.UserID] = t
+	return nil
+}
+
+func (trm *totpRepositoryMock) RetrieveByUser(ctx context.Context, userID string) (users.TOTP, error) {
+	trm.mu.Lock()
+	defer trm.mu.Unlock()
+
+	t, ok := trm.totps[userID]
+	if !ok {
+		return users.TOTP{}, errors.ErrNotFound
+	}
+
+	codes := make([]string, len(t.RecoveryCodes))
+	copy(codes, t.RecoveryCodes)
+	t.RecoveryCodes = codes
+
+	return t, nil
+}
+
+func (trm *totpRepositoryMock) Remove(ctx context.Context, userID string) error {
+	trm.mu.Lock()
+	defer trm.mu.Unlock()
+
+	delete(trm.totps, userID)
+	return nil
+}
+
+func (trm *totpRepositoryMock) SaveStep(ctx context.Context, userID string, step int64) error {
+	trm.mu.Lock()
+	defer trm.mu.Unlock()
+
+	t, ok := trm.totps[userID]
+	if !ok {
+		return errors.ErrNotFound
+	}
+	if step <= t.LastStep {
+		return errors.ErrConflict
+	}
+
+	t.LastStep = step
+	trm.totps[userID] = t
+	return nil
+}
+
+func (trm *totpRepositoryMock) SaveAttempt(ctx context.Context, userID string, limit int, lockedUntil time.Time) error {
+	trm.mu.Lock()
+	defer trm.mu.Unlock()
+
+	t, ok := trm.totps[userID]
+	if !ok {
+		return errors.ErrNotFound
+	}
+	if t.FailedAttempts >= limit && time.Now().Before(t.LockedUntil) {
+		return errors.ErrConflict
+	}
+
+	t.FailedAttempts++
+	t.LockedUntil = lockedUntil
+	trm.totps[userID] = t
+	return nil
+}
+
+func (trm *totpRepositoryMock) ResetAttempts(ctx context.Context, userID string) error {
+	trm.mu.Lock()
+	defer trm.mu.Unlock()
+
+	t, ok := trm.totps[userID]
+	if !ok {
+		return errors.ErrNotFound
+	}
+
+	t.FailedAttempts = 0
+	t.LockedUntil = time.Time{}
+	trm.totps[userID] = t
+	return nil
+}
+
+func (trm *totpRepositoryMock) RemoveRecoveryCode(ctx context.Context, userID, hash string) error {
+	trm.mu.Lock()
+	defer trm.mu.Unlock()
+
+	t, ok := trm.totps[userID]
+	if !ok {
+		return errors.ErrNotFound
+	}
+
+	for i, h := range t.RecoveryCodes {
+		if h == hash {
+			codes := make([]string, 0, len(t.RecoveryCodes)-1)
+			codes = append(codes, t.RecoveryCodes[:i]...)
+			t.RecoveryCodes = append(codes, t.RecoveryCodes[i+1:]...)
+			trm.totps[userID] = t
+			return nil
+		}
+	}
+
+	return errors.ErrNotFound
+}
+
+func (trm *totpRepositoryMock) SaveOrgPolicy(ctx context.Context, orgID string) error {
+	trm.mu.Lock()
+	defer trm.mu.Unlock()
+
+	trm.policies[orgID] = true
+	return nil
+}
+
+func (trm *totpRepositoryMock) RemoveOrgPolicy(ctx context.Context, orgID string) error {
+	trm.mu.Lock()
+	defer trm.mu.Unlock()
+
+	delete(trm.policies, orgID)
+	return nil
+}
+
+func (trm *totpRepositoryMock) RetrieveOrgPolicies(ctx context.Context) ([]string, error) {
+	trm.mu.Lock()
+	defer trm.mu.Unlock()
+
+	var orgIDs []string
+	for id := range trm.policies {
+		orgIDs = append(orgIDs, id)
+	}
+
+	return orgIDs, nil
+}
@@ -177,6 +177,19 @@ func (urm *userRepositoryMock) ChangeStatus(ctx context.Context, id, status stri
 	return nil
 }
 
+func (urm *userRepositoryMock) Remove(ctx context.Context, id string) error {
+	urm.mu.Lock()
+	defer urm.mu.Unlock()
+
+	u, ok := urm.usersByID[id]
+	if !ok {
+		return errors.ErrNotFound
+	}
+	delete(urm.usersByID, id)
+	delete(urm.usersByEmail, u.Email)
+	return nil
+}
+
 func sortUsers(us map[string]users.User) []users.User {
 	users := []users.User{}
 	ids := make([]string, 0, len(us))
@@ -0,0 +1,119 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/users"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+var _ users.BrandingRepository = (*brandingRepository)(nil)
+
+type brandingRepository struct {
+	db Database
+}
+
+// NewBrandingRepo instantiates a PostgreSQL implementation of org e-mail
+// branding repository.
+func NewBrandingRepo(db Database) users.BrandingRepository {
+	return &brandingRepository{
+		db: db,
+	}
+}
+
+func (br brandingRepository) Save(ctx context.Context, b users.Branding) error {
+	q := `INSERT INTO org_brandings (org_id, name, logo_url, primary_color, support_email, footer, locale, updated_at)
+		VALUES (:org_id, :name, :logo_url, :primary_color, :support_email, :footer, :locale, :updated_at)
+		ON CONFLICT (org_id) DO UPDATE SET name = :name, logo_url = :logo_url, primary_color = :primary_color,
+		support_email = :support_email, footer = :footer, locale = :locale, updated_at = :updated_at`
+
+	if _, err := br.db.NamedExecContext(ctx, q, toDBBranding(b)); err != nil {
+		pgErr, ok := err.(*pgconn.PgError)
+		if ok {
+			switch pgErr.Code {
+			case pgerrcode.InvalidTextRepresentation, pgerrcode.StringDataRightTruncationDataException:
+				return errors.Wrap(errors.ErrMalformedEntity, err)
+			}
+		}
+		return errors.Wrap(errors.ErrCreateEntity, err)
+	}
+
+	return nil
+}
+
+func (br brandingRepository) RetrieveByOrg(ctx context.Context, orgID string) (users.Branding, error) {
+	q := `SELECT org_id, name, logo_url, primary_color, support_email, footer, locale, updated_at
+		FROM org_brandings WHERE org_id = $1`
+
+	var dbb dbBranding
+	if err := br.db.QueryRowxContext(ctx, q, orgID).StructScan(&dbb); err != nil {
+		if err == sql.ErrNoRows {
+			return users.Branding{}, errors.Wrap(errors.ErrNotFound, err)
+		}
+		pgErr, ok := err.(*pgconn.PgError)
+		if ok && pgErr.Code == pgerrcode.InvalidTextRepresentation {
+			return users.Branding{}, errors.Wrap(errors.ErrNotFound, err)
+		}
+		return users.Branding{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+
+	return toBranding(dbb), nil
+}
+
+func (br brandingRepository) Remove(ctx context.Context, orgID string) error {
+	q := `DELETE FROM org_brandings WHERE org_id = :org_id`
+
+	if _, err := br.db.NamedExecContext(ctx, q, dbBranding{OrgID: orgID}); err != nil {
+		pgErr, ok := err.(*pgconn.PgError)
+		if ok && pgErr.Code == pgerrcode.InvalidTextRepresentation {
+			return errors.Wrap(errors.ErrMalformedEntity, err)
+		}
+		return errors.Wrap(errors.ErrRemoveEntity, err)
+	}
+
+	return nil
+}
+
+type dbBranding struct {
+	OrgID        string    `db:"org_id"`
+	Name         string    `db:"name"`
+	LogoURL      string    `db:"logo_url"`
+	PrimaryColor string    `db:"primary_color"`
+	SupportEmail string    `db:"support_email"`
+	Footer       string    `db:"footer"`
+	Locale       string    `db:"locale"`
+	UpdatedAt    time.Time `db:"updated_at"`
+}
+
+func toDBBranding(b users.Branding) dbBranding {
+	return dbBranding{
+		OrgID:        b.OrgID,
+		Name:         b.Name,
+		LogoURL:      b.LogoURL,
+		PrimaryColor: b.PrimaryColor,
+		SupportEmail: b.SupportEmail,
+		Footer:       b.Footer,
+		Locale:       b.Locale,
+		UpdatedAt:    b.UpdatedAt,
+	}
+}
+
+func toBranding(dbb dbBranding) users.Branding {
+	return users.Branding{
+		OrgID:        dbb.OrgID,
+		Name:         dbb.Name,
+		LogoURL:      dbb.LogoURL,
+		PrimaryColor: dbb.PrimaryColor,
+		SupportEmail: dbb.SupportEmail,
+		Footer:       dbb.Footer,
+		Locale:       dbb.Locale,
+		UpdatedAt:    dbb.UpdatedAt,
+	}
+}
@@ -0,0 +1,76 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/users"
+	"github.com/MainfluxLabs/mainflux/users/postgres"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBrandingSave(t *testing.T) {
+	repo := postgres.NewBrandingRepo(postgres.NewDatabase(db))
+
+	b := users.Branding{
+		OrgID:        randomID(t),
+		Name:         "Acme",
+		PrimaryColor: "#ff0000",
+		Locale:       "en",
+		UpdatedAt:    time.Now().UTC().Round(time.Millisecond),
+	}
+	updated := b
+	updated.Name = "Acme Corp"
+
+	cases := []struct {
+		desc     string
+		branding users.Branding
+		err      error
+	}{
+		{
+			desc:     "save new branding",
+			branding: b,
+			err:      nil,
+		},
+		{
+			desc:     "save existing branding",
+			branding: updated,
+			err:      nil,
+		},
+		{
+			desc:     "save branding with invalid org ID",
+			branding: users.Branding{OrgID: "invalid", UpdatedAt: time.Now()},
+			err:      errors.ErrMalformedEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		err := repo.Save(context.Background(), tc.branding)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+
+	saved, err := repo.RetrieveByOrg(context.Background(), b.OrgID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.Equal(t, updated.Name, saved.Name, fmt.Sprintf("expected %s got %s\n", updated.Name, saved.Name))
+}
+
+func TestBrandingRemove(t *testing.T) {
+	repo := postgres.NewBrandingRepo(postgres.NewDatabase(db))
+
+	b := users.Branding{OrgID: randomID(t), Name: "Acme", UpdatedAt: time.Now()}
+	err := repo.Save(context.Background(), b)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	err = repo.Remove(context.Background(), b.OrgID)
+	assert.Nil(t, err, fmt.Sprintf("remove branding: expected to succeed: %s", err))
+
+	_, err = repo.RetrieveByOrg(context.Background(), b.OrgID)
+	assert.True(t, errors.Contains(err, errors.ErrNotFound), fmt.Sprintf("retrieve removed branding: expected %s got %s\n", errors.ErrNotFound, err))
+}
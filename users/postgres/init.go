@@ -91,6 +91,86 @@ func migrateDB(db *sqlx.DB) error {
 					status USER_STATUS NOT NULL DEFAULT 'enabled'`,
 				},
 			},
+			{
+				Id: "users_6",
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS totps (
+					 user_id        UUID    PRIMARY KEY REFERENCES users (id) ON DELETE CASCADE,
+					 secret         VARCHAR(64) NOT NULL,
+					 enabled        BOOLEAN NOT NULL DEFAULT FALSE,
+					 recovery_codes JSONB
+					)`,
+					`CREATE TABLE IF NOT EXISTS totp_org_policies (
+					 org_id UUID PRIMARY KEY
+					)`,
+				},
+				Down: []string{
+					"DROP TABLE totps",
+					"DROP TABLE totp_org_policies",
+				},
+			},
+			{
+				Id: "users_7",
+				Up: []string{
+					`ALTER TYPE user_status ADD VALUE IF NOT EXISTS 'pending'`,
+				},
+				DisableTransactionUp: true,
+			},
+			{
+				Id: "users_8",
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS invitations (
+					 id         UUID         PRIMARY KEY,
+					 user_id    UUID         UNIQUE NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+					 org_id     UUID         NOT NULL,
+					 invited_by UUID         NOT NULL,
+					 token_hash CHAR(64)     UNIQUE NOT NULL,
+					 created_at TIMESTAMPTZ  NOT NULL,
+					 expires_at TIMESTAMPTZ  NOT NULL
+					)`,
+				},
+				Down: []string{
+					"DROP TABLE invitations",
+				},
+			},
+			{
+				Id: "users_9",
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS org_brandings (
+					 org_id        UUID          PRIMARY KEY,
+					 name          VARCHAR(1024),
+					 logo_url      TEXT,
+					 primary_color VARCHAR(7),
+					 support_email VARCHAR(254),
+					 footer        VARCHAR(1024),
+					 locale        VARCHAR(16),
+					 updated_at    TIMESTAMPTZ   NOT NULL
+					)`,
+				},
+				Down: []string{
+					"DROP TABLE org_brandings",
+				},
+			},
+			{
+				Id: "users_10",
+				Up: []string{
+					`ALTER TABLE totps ADD COLUMN IF NOT EXISTS last_step BIGINT NOT NULL DEFAULT 0`,
+				},
+				Down: []string{
+					"ALTER TABLE totps DROP COLUMN last_step",
+				},
+			},
+			{
+				Id: "users_11",
+				Up: []string{
+					`ALTER TABLE totps ADD COLUMN IF NOT EXISTS failed_attempts INTEGER NOT NULL DEFAULT 0`,
+					`ALTER TABLE totps ADD COLUMN IF NOT EXISTS locked_until TIMESTAMPTZ`,
+				},
+				Down: []string{
+					"ALTER TABLE totps DROP COLUMN failed_attempts",
+					"ALTER TABLE totps DROP COLUMN locked_until",
+				},
+			},
 		},
 	}
 
@@ -0,0 +1,199 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/users"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+var _ users.InvitationRepository = (*invitationRepository)(nil)
+
+const invitationColumns = `i.id, i.user_id, u.email, i.org_id, i.invited_by, i.token_hash, i.created_at, i.expires_at`
+
+type invitationRepository struct {
+	db Database
+}
+
+// NewInvitationRepo instantiates a PostgreSQL implementation of invitation
+// repository.
+func NewInvitationRepo(db Database) users.InvitationRepository {
+	return &invitationRepository{
+		db: db,
+	}
+}
+
+func (ir invitationRepository) Save(ctx context.Context, inv users.Invitation) error {
+	q := `INSERT INTO invitations (id, user_id, org_id, invited_by, token_hash, created_at, expires_at)
+		VALUES (:id, :user_id, :org_id, :invited_by, :token_hash, :created_at, :expires_at)`
+
+	if _, err := ir.db.NamedExecContext(ctx, q, toDBInvitation(inv)); err != nil {
+		pgErr, ok := err.(*pgconn.PgError)
+		if ok {
+			switch pgErr.Code {
+			case pgerrcode.InvalidTextRepresentation:
+				return errors.Wrap(errors.ErrMalformedEntity, err)
+			case pgerrcode.UniqueViolation:
+				return errors.Wrap(errors.ErrConflict, err)
+			case pgerrcode.ForeignKeyViolation:
+				return errors.Wrap(errors.ErrNotFound, err)
+			}
+		}
+		return errors.Wrap(errors.ErrCreateEntity, err)
+	}
+
+	return nil
+}
+
+func (ir invitationRepository) RetrieveByID(ctx context.Context, id string) (users.Invitation, error) {
+	q := fmt.Sprintf(`SELECT %s FROM invitations i JOIN users u ON u.id = i.user_id WHERE i.id = $1`, invitationColumns)
+
+	return ir.retrieve(ctx, q, id)
+}
+
+func (ir invitationRepository) RetrieveByToken(ctx context.Context, tokenHash string) (users.Invitation, error) {
+	q := fmt.Sprintf(`SELECT %s FROM invitations i JOIN users u ON u.id = i.user_id WHERE i.token_hash = $1`, invitationColumns)
+
+	return ir.retrieve(ctx, q, tokenHash)
+}
+
+func (ir invitationRepository) retrieve(ctx context.Context, query, arg string) (users.Invitation, error) {
+	var dbi dbInvitation
+	if err := ir.db.QueryRowxContext(ctx, query, arg).StructScan(&dbi); err != nil {
+		pgErr, ok := err.(*pgconn.PgError)
+		switch {
+		case err == sql.ErrNoRows,
+			ok && pgErr.Code == pgerrcode.InvalidTextRepresentation:
+			return users.Invitation{}, errors.Wrap(errors.ErrNotFound, err)
+		}
+		return users.Invitation{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+
+	return toInvitation(dbi), nil
+}
+
+func (ir invitationRepository) RetrieveAll(ctx context.Context, invitedBy string, pm users.PageMetadata) (users.InvitationPage, error) {
+	var wq string
+	if invitedBy != "" {
+		wq = "WHERE i.invited_by = :invited_by"
+	}
+
+	olq := "LIMIT :limit OFFSET :offset"
+	if pm.Limit == 0 {
+		olq = ""
+	}
+
+	q := fmt.Sprintf(`SELECT %s FROM invitations i JOIN users u ON u.id = i.user_id %s ORDER BY i.created_at DESC %s;`, invitationColumns, wq, olq)
+
+	params := map[string]interface{}{
+		"invited_by": invitedBy,
+		"limit":      pm.Limit,
+		"offset":     pm.Offset,
+	}
+
+	rows, err := ir.db.NamedQueryContext(ctx, q, params)
+	if err != nil {
+		return users.InvitationPage{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+	defer rows.Close()
+
+	items := []users.Invitation{}
+	for rows.Next() {
+		var dbi dbInvitation
+		if err := rows.StructScan(&dbi); err != nil {
+			return users.InvitationPage{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+		}
+		items = append(items, toInvitation(dbi))
+	}
+
+	cq := fmt.Sprintf(`SELECT COUNT(*) FROM invitations i %s;`, wq)
+
+	total, err := total(ctx, ir.db, cq, params)
+	if err != nil {
+		return users.InvitationPage{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+
+	page := users.InvitationPage{
+		Invitations: items,
+		PageMetadata: users.PageMetadata{
+			Total:  total,
+			Offset: pm.Offset,
+			Limit:  pm.Limit,
+		},
+	}
+
+	return page, nil
+}
+
+func (ir invitationRepository) UpdateToken(ctx context.Context, inv users.Invitation) error {
+	q := `UPDATE invitations SET token_hash = :token_hash, expires_at = :expires_at WHERE id = :id`
+
+	res, err := ir.db.NamedExecContext(ctx, q, toDBInvitation(inv))
+	if err != nil {
+		return errors.Wrap(errors.ErrUpdateEntity, err)
+	}
+
+	cnt, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(errors.ErrUpdateEntity, err)
+	}
+	if cnt != 1 {
+		return errors.ErrNotFound
+	}
+
+	return nil
+}
+
+func (ir invitationRepository) Remove(ctx context.Context, id string) error {
+	q := `DELETE FROM invitations WHERE id = :id`
+
+	if _, err := ir.db.NamedExecContext(ctx, q, dbInvitation{ID: id}); err != nil {
+		return errors.Wrap(errors.ErrRemoveEntity, err)
+	}
+
+	return nil
+}
+
+type dbInvitation struct {
+	ID        string    `db:"id"`
+	UserID    string    `db:"user_id"`
+	Email     string    `db:"email"`
+	OrgID     string    `db:"org_id"`
+	InvitedBy string    `db:"invited_by"`
+	TokenHash string    `db:"token_hash"`
+	CreatedAt time.Time `db:"created_at"`
+	ExpiresAt time.Time `db:"expires_at"`
+}
+
+func toDBInvitation(inv users.Invitation) dbInvitation {
+	return dbInvitation{
+		ID:        inv.ID,
+		UserID:    inv.UserID,
+		OrgID:     inv.OrgID,
+		InvitedBy: inv.InvitedBy,
+		TokenHash: inv.TokenHash,
+		CreatedAt: inv.CreatedAt,
+		ExpiresAt: inv.ExpiresAt,
+	}
+}
+
+func toInvitation(dbi dbInvitation) users.Invitation {
+	return users.Invitation{
+		ID:        dbi.ID,
+		UserID:    dbi.UserID,
+		Email:     dbi.Email,
+		OrgID:     dbi.OrgID,
+		InvitedBy: dbi.InvitedBy,
+		TokenHash: dbi.TokenHash,
+		CreatedAt: dbi.CreatedAt,
+		ExpiresAt: dbi.ExpiresAt,
+	}
+}
@@ -0,0 +1,142 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/users"
+	"github.com/MainfluxLabs/mainflux/users/postgres"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInvitationSave(t *testing.T) {
+	dbMiddleware := postgres.NewDatabase(db)
+	userRepo := postgres.NewUserRepo(dbMiddleware)
+	repo := postgres.NewInvitationRepo(dbMiddleware)
+
+	uid, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	_, err = userRepo.Save(context.Background(), users.User{ID: uid, Email: "invitation-save@example.com", Status: users.PendingStatusKey})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	inv := newInvitation(t, uid)
+
+	cases := []struct {
+		desc string
+		inv  users.Invitation
+		err  error
+	}{
+		{
+			desc: "save new invitation",
+			inv:  inv,
+			err:  nil,
+		},
+		{
+			desc: "save existing invitation",
+			inv:  inv,
+			err:  errors.ErrConflict,
+		},
+		{
+			desc: "save invitation of non-existing user",
+			inv:  newInvitation(t, randomID(t)),
+			err:  errors.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		err := repo.Save(context.Background(), tc.inv)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestInvitationRetrieveByToken(t *testing.T) {
+	dbMiddleware := postgres.NewDatabase(db)
+	userRepo := postgres.NewUserRepo(dbMiddleware)
+	repo := postgres.NewInvitationRepo(dbMiddleware)
+
+	uid, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	email := "invitation-retrieve@example.com"
+	_, err = userRepo.Save(context.Background(), users.User{ID: uid, Email: email, Status: users.PendingStatusKey})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	inv := newInvitation(t, uid)
+	err = repo.Save(context.Background(), inv)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc      string
+		tokenHash string
+		email     string
+		err       error
+	}{
+		{
+			desc:      "retrieve invitation by token",
+			tokenHash: inv.TokenHash,
+			email:     email,
+			err:       nil,
+		},
+		{
+			desc:      "retrieve invitation by non-existing token",
+			tokenHash: fmt.Sprintf("%064d", 0),
+			email:     "",
+			err:       errors.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		saved, err := repo.RetrieveByToken(context.Background(), tc.tokenHash)
+		assert.Equal(t, tc.email, saved.Email, fmt.Sprintf("%s: expected email %s got %s\n", tc.desc, tc.email, saved.Email))
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestInvitationRemove(t *testing.T) {
+	dbMiddleware := postgres.NewDatabase(db)
+	userRepo := postgres.NewUserRepo(dbMiddleware)
+	repo := postgres.NewInvitationRepo(dbMiddleware)
+
+	uid, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	_, err = userRepo.Save(context.Background(), users.User{ID: uid, Email: "invitation-remove@example.com", Status: users.PendingStatusKey})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	inv := newInvitation(t, uid)
+	err = repo.Save(context.Background(), inv)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	err = userRepo.Remove(context.Background(), uid)
+	assert.Nil(t, err, fmt.Sprintf("remove pending user: unexpected error: %s", err))
+
+	_, err = repo.RetrieveByID(context.Background(), inv.ID)
+	assert.True(t, errors.Contains(err, errors.ErrNotFound), fmt.Sprintf("retrieve invitation of removed user: expected %s got %s\n", errors.ErrNotFound, err))
+}
+
+func newInvitation(t *testing.T, userID string) users.Invitation {
+	id, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	now := time.Now().UTC()
+	return users.Invitation{
+		ID:        id,
+		UserID:    userID,
+		OrgID:     randomID(t),
+		InvitedBy: randomID(t),
+		TokenHash: fmt.Sprintf("%064s", id[:8]),
+		CreatedAt: now,
+		ExpiresAt: now.Add(time.Hour),
+	}
+}
+
+func randomID(t *testing.T) string {
+	id, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	return id
+}
@@ -0,0 +1,270 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/users"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+var _ users.TOTPRepository = (*totpRepository)(nil)
+
+type totpRepository struct {
+	db Database
+}
+
+// NewTOTPRepo instantiates a PostgreSQL implementation of second factor
+// repository.
+func NewTOTPRepo(db Database) users.TOTPRepository {
+	return &totpRepository{
+		db: db,
+	}
+}
+
+func (tr totpRepository) Save(ctx context.Context, t users.TOTP) error {
+	q := `INSERT INTO totps (user_id, secret, enabled, last_step, recovery_codes, failed_attempts, locked_until)
+		VALUES (:user_id, :secret, :enabled, :last_step, :recovery_codes, :failed_attempts, :locked_until)
+		ON CONFLICT (user_id) DO UPDATE SET secret = :secret, enabled = :enabled, last_step = :last_step, recovery_codes = :recovery_codes,
+		failed_attempts = :failed_attempts, locked_until = :locked_until`
+
+	dbt, err := toDBTOTP(t)
+	if err != nil {
+		return errors.Wrap(errors.ErrCreateEntity, err)
+	}
+
+	if _, err := tr.db.NamedExecContext(ctx, q, dbt); err != nil {
+		pgErr, ok := err.(*pgconn.PgError)
+		if ok {
+			switch pgErr.Code {
+			case pgerrcode.InvalidTextRepresentation:
+				return errors.Wrap(errors.ErrMalformedEntity, err)
+			case pgerrcode.ForeignKeyViolation:
+				return errors.Wrap(errors.ErrNotFound, err)
+			}
+		}
+		return errors.Wrap(errors.ErrCreateEntity, err)
+	}
+
+	return nil
+}
+
+func (tr totpRepository) RetrieveByUser(ctx context.Context, userID string) (users.TOTP, error) {
+	q := `SELECT user_id, secret, enabled, last_step, recovery_codes, failed_attempts, locked_until FROM totps WHERE user_id = $1`
+
+	var dbt dbTOTP
+	if err := tr.db.QueryRowxContext(ctx, q, userID).StructScan(&dbt); err != nil {
+		if err == sql.ErrNoRows {
+			return users.TOTP{}, errors.Wrap(errors.ErrNotFound, err)
+		}
+		return users.TOTP{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+
+	return toTOTP(dbt)
+}
+
+func (tr totpRepository) Remove(ctx context.Context, userID string) error {
+	q := `DELETE FROM totps WHERE user_id = :user_id`
+
+	if _, err := tr.db.NamedExecContext(ctx, q, dbTOTP{UserID: userID}); err != nil {
+		return errors.Wrap(errors.ErrRemoveEntity, err)
+	}
+
+	return nil
+}
+
+func (tr totpRepository) SaveStep(ctx context.Context, userID string, step int64) error {
+	q := `UPDATE totps SET last_step = :last_step WHERE user_id = :user_id AND last_step < :last_step`
+
+	res, err := tr.db.NamedExecContext(ctx, q, dbTOTP{UserID: userID, LastStep: step})
+	if err != nil {
+		return errors.Wrap(errors.ErrUpdateEntity, err)
+	}
+
+	cnt, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(errors.ErrUpdateEntity, err)
+	}
+	if cnt != 1 {
+		return errors.ErrConflict
+	}
+
+	return nil
+}
+
+// SaveAttempt counts the attempt only if the limit isn't reached or the lock
+// has expired, so the concurrent attempts can't exceed the limit.
+func (tr totpRepository) SaveAttempt(ctx context.Context, userID string, limit int, lockedUntil time.Time) error {
+	q := `UPDATE totps SET failed_attempts = failed_attempts + 1, locked_until = :locked_until
+		WHERE user_id = :user_id AND (failed_attempts < :limit OR locked_until IS NULL OR locked_until <= NOW())`
+
+	params := map[string]interface{}{
+		"user_id":      userID,
+		"limit":        limit,
+		"locked_until": lockedUntil,
+	}
+	res, err := tr.db.NamedExecContext(ctx, q, params)
+	if err != nil {
+		return errors.Wrap(errors.ErrUpdateEntity, err)
+	}
+
+	cnt, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(errors.ErrUpdateEntity, err)
+	}
+	if cnt != 1 {
+		return errors.ErrConflict
+	}
+
+	return nil
+}
+
+func (tr totpRepository) ResetAttempts(ctx context.Context, userID string) error {
+	q := `UPDATE totps SET failed_attempts = 0, locked_until = NULL WHERE user_id = :user_id`
+
+	if _, err := tr.db.NamedExecContext(ctx, q, dbTOTP{UserID: userID}); err != nil {
+		return errors.Wrap(errors.ErrUpdateEntity, err)
+	}
+
+	return nil
+}
+
+// RemoveRecoveryCode removes the code only if it's still present, so the
+// concurrent uses of the same code can't both succeed.
+func (tr totpRepository) RemoveRecoveryCode(ctx context.Context, userID, hash string) error {
+	q := `UPDATE totps SET recovery_codes = recovery_codes - CAST(:code AS TEXT)
+		WHERE user_id = :user_id AND recovery_codes ? CAST(:code AS TEXT)`
+
+	params := map[string]interface{}{
+		"user_id": userID,
+		"code":    hash,
+	}
+	res, err := tr.db.NamedExecContext(ctx, q, params)
+	if err != nil {
+		return errors.Wrap(errors.ErrRemoveEntity, err)
+	}
+
+	cnt, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(errors.ErrRemoveEntity, err)
+	}
+	if cnt != 1 {
+		return errors.ErrNotFound
+	}
+
+	return nil
+}
+
+func (tr totpRepository) SaveOrgPolicy(ctx context.Context, orgID string) error {
+	q := `INSERT INTO totp_org_policies (org_id) VALUES (:org_id) ON CONFLICT (org_id) DO NOTHING`
+
+	if _, err := tr.db.NamedExecContext(ctx, q, dbOrgPolicy{OrgID: orgID}); err != nil {
+		pgErr, ok := err.(*pgconn.PgError)
+		if ok && pgErr.Code == pgerrcode.InvalidTextRepresentation {
+			return errors.Wrap(errors.ErrMalformedEntity, err)
+		}
+		return errors.Wrap(errors.ErrCreateEntity, err)
+	}
+
+	return nil
+}
+
+func (tr totpRepository) RemoveOrgPolicy(ctx context.Context, orgID string) error {
+	q := `DELETE FROM totp_org_policies WHERE org_id = :org_id`
+
+	if _, err := tr.db.NamedExecContext(ctx, q, dbOrgPolicy{OrgID: orgID}); err != nil {
+		pgErr, ok := err.(*pgconn.PgError)
+		if ok && pgErr.Code == pgerrcode.InvalidTextRepresentation {
+			return errors.Wrap(errors.ErrMalformedEntity, err)
+		}
+		return errors.Wrap(errors.ErrRemoveEntity, err)
+	}
+
+	return nil
+}
+
+func (tr totpRepository) RetrieveOrgPolicies(ctx context.Context) ([]string, error) {
+	q := `SELECT org_id FROM totp_org_policies`
+
+	rows, err := tr.db.NamedQueryContext(ctx, q, map[string]interface{}{})
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+	defer rows.Close()
+
+	var orgIDs []string
+	for rows.Next() {
+		var p dbOrgPolicy
+		if err := rows.StructScan(&p); err != nil {
+			return nil, errors.Wrap(errors.ErrRetrieveEntity, err)
+		}
+		orgIDs = append(orgIDs, p.OrgID)
+	}
+
+	return orgIDs, nil
+}
+
+type dbTOTP struct {
+	UserID         string       `db:"user_id"`
+	Secret         string       `db:"secret"`
+	Enabled        bool         `db:"enabled"`
+	LastStep       int64        `db:"last_step"`
+	RecoveryCodes  []byte       `db:"recovery_codes"`
+	FailedAttempts int          `db:"failed_attempts"`
+	LockedUntil    sql.NullTime `db:"locked_until"`
+}
+
+type dbOrgPolicy struct {
+	OrgID string `db:"org_id"`
+}
+
+func toDBTOTP(t users.TOTP) (dbTOTP, error) {
+	codes := []byte("[]")
+	if len(t.RecoveryCodes) > 0 {
+		b, err := json.Marshal(t.RecoveryCodes)
+		if err != nil {
+			return dbTOTP{}, errors.Wrap(errors.ErrMalformedEntity, err)
+		}
+		codes = b
+	}
+
+	return dbTOTP{
+		UserID:         t.UserID,
+		Secret:         t.Secret,
+		Enabled:        t.Enabled,
+		LastStep:       t.LastStep,
+		RecoveryCodes:  codes,
+		FailedAttempts: t.FailedAttempts,
+		LockedUntil:    sql.NullTime{Time: t.LockedUntil, Valid: !t.LockedUntil.IsZero()},
+	}, nil
+}
+
+func toTOTP(dbt dbTOTP) (users.TOTP, error) {
+	var codes []string
+	if len(dbt.RecoveryCodes) > 0 {
+		if err := json.Unmarshal(dbt.RecoveryCodes, &codes); err != nil {
+			return users.TOTP{}, errors.Wrap(errors.ErrMalformedEntity, err)
+		}
+	}
+
+	t := users.TOTP{
+		UserID:         dbt.UserID,
+		Secret:         dbt.Secret,
+		Enabled:        dbt.Enabled,
+		LastStep:       dbt.LastStep,
+		RecoveryCodes:  codes,
+		FailedAttempts: dbt.FailedAttempts,
+	}
+	if dbt.LockedUntil.Valid {
+		t.LockedUntil = dbt.LockedUntil.Time.UTC()
+	}
+
+	return t, nil
+}
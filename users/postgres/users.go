@@ -120,7 +120,7 @@ func (ur userRepository) RetrieveByEmail(ctx context.Context, email string) (use
 }
 
 func (ur userRepository) RetrieveByID(ctx context.Context, id string) (users.User, error) {
-	q := `SELECT email, password, metadata FROM users WHERE id = $1`
+	q := `SELECT email, password, metadata, status FROM users WHERE id = $1`
 
 	dbu := dbUser{
 		ID: id,
@@ -177,7 +177,7 @@ func (ur userRepository) RetrieveByIDs(ctx context.Context, userIDs []string, pm
 		olq = ""
 	}
 
-	q := fmt.Sprintf(`SELECT id, email, metadata FROM users %s ORDER BY email %s;`, emq, olq)
+	q := fmt.Sprintf(`SELECT id, email, metadata, status FROM users %s ORDER BY email %s;`, emq, olq)
 
 	params := map[string]interface{}{
 		"limit":    pm.Limit,
@@ -282,6 +282,16 @@ func (ur userRepository) ChangeStatus(ctx context.Context, id, status string) er
 	return nil
 }
 
+func (ur userRepository) Remove(ctx context.Context, id string) error {
+	q := `DELETE FROM users WHERE id = :id`
+
+	if _, err := ur.db.NamedExecContext(ctx, q, dbUser{ID: id}); err != nil {
+		return errors.Wrap(errors.ErrRemoveEntity, err)
+	}
+
+	return nil
+}
+
 type dbUser struct {
 	ID       string `db:"id"`
 	Email    string `db:"email"`
@@ -6,6 +6,7 @@ package users
 import (
 	"context"
 	"regexp"
+	"time"
 
 	"github.com/MainfluxLabs/mainflux"
 	"github.com/MainfluxLabs/mainflux/auth"
@@ -16,6 +17,7 @@ import (
 const (
 	EnabledStatusKey  = "enabled"
 	DisabledStatusKey = "disabled"
+	PendingStatusKey  = "pending"
 	AllStatusKey      = "all"
 	rootSubject       = "root"
 )
@@ -28,6 +30,9 @@ var (
 	// ErrRecoveryToken indicates error in generating password recovery token.
 	ErrRecoveryToken = errors.New("failed to generate password recovery token")
 
+	// ErrResetRateLimit indicates too many password reset requests for the email.
+	ErrResetRateLimit = errors.New("too many password reset requests")
+
 	// ErrGetToken indicates error in getting signed token.
 	ErrGetToken = errors.New("failed to fetch signed token")
 
@@ -39,6 +44,25 @@ var (
 
 	// ErrAlreadyDisabledUser indicates the user is already disabled.
 	ErrAlreadyDisabledUser = errors.New("the user is already disabled")
+
+	// ErrTOTPRequired indicates the login requires the second factor.
+	ErrTOTPRequired = errors.New("second factor is required")
+
+	// ErrTOTPEnrollmentRequired indicates the user has to enroll the second
+	// factor, since it is required by the org the user is member of.
+	ErrTOTPEnrollmentRequired = errors.New("second factor enrollment is required")
+
+	// ErrTOTPAlreadyEnabled indicates the second factor is already enabled.
+	ErrTOTPAlreadyEnabled = errors.New("second factor is already enabled")
+
+	// ErrTOTPNotEnabled indicates the second factor is not enabled.
+	ErrTOTPNotEnabled = errors.New("second factor is not enabled")
+
+	// ErrTOTPLocked indicates too many failed second factor attempts.
+	ErrTOTPLocked = errors.New("too many failed second factor attempts")
+
+	// ErrInvitationExpired indicates the invitation has expired.
+	ErrInvitationExpired = errors.New("invitation has expired")
 )
 
 // Service specifies an API that must be fullfiled by the domain service
@@ -60,9 +84,47 @@ type Service interface {
 	RegisterAdmin(ctx context.Context, user User) error
 
 	// Login authenticates the user given its credentials. Successful
-	// authentication generates new access token. Failed invocations are
+	// authentication opens a new session of the user on the given client and
+	// generates new access token and refresh token. Failed invocations are
 	// identified by the non-nil error values in the response.
-	Login(ctx context.Context, user User) (string, error)
+	Login(ctx context.Context, user User, client Client) (Token, error)
+
+	// LoginTOTP authenticates the user given its credentials and the second
+	// factor code, which is either the TOTP code or an unused recovery code.
+	LoginTOTP(ctx context.Context, user User, code string, client Client) (Token, error)
+
+	// EnrollTOTP generates the second factor secret for the user given its
+	// credentials. The second factor is enabled once confirmed.
+	EnrollTOTP(ctx context.Context, user User) (TOTPKey, error)
+
+	// ConfirmTOTP enables the enrolled second factor given the valid code,
+	// and returns the one-time recovery codes.
+	ConfirmTOTP(ctx context.Context, user User, code string) ([]string, error)
+
+	// DisableTOTP disables the second factor of the authenticated user
+	// given the valid code.
+	DisableTOTP(ctx context.Context, token, code string) error
+
+	// UpdateOrgTOTPPolicy specifies whether the second factor is required
+	// for all members of the org. Only accessible by admin.
+	UpdateOrgTOTPPolicy(ctx context.Context, token, orgID string, required bool) error
+
+	// UpdateOrgBranding replaces the branding of the e-mails sent on behalf
+	// of the org. Only accessible by admin.
+	UpdateOrgBranding(ctx context.Context, token string, b Branding) error
+
+	// ViewOrgBranding retrieves the e-mail branding of the org. Only
+	// accessible by admin.
+	ViewOrgBranding(ctx context.Context, token, orgID string) (Branding, error)
+
+	// RemoveOrgBranding removes the e-mail branding of the org, so that its
+	// e-mails use the platform defaults. Only accessible by admin.
+	RemoveOrgBranding(ctx context.Context, token, orgID string) error
+
+	// SendTestEmail sends the test e-mail branded as the org to the provided
+	// address, or to the admin if the address is empty. Only accessible by
+	// admin.
+	SendTestEmail(ctx context.Context, token, orgID, email string) error
 
 	// ViewUser retrieves user info for a given user ID and an authorized token.
 	ViewUser(ctx context.Context, token, id string) (User, error)
@@ -73,6 +135,10 @@ type Service interface {
 	// ListUsers retrieves users list for a valid admin token.
 	ListUsers(ctx context.Context, token string, pm PageMetadata) (UserPage, error)
 
+	// SearchUsers retrieves users of all statuses, unless filtered by the
+	// status, for a valid admin token.
+	SearchUsers(ctx context.Context, token string, pm PageMetadata) (UserPage, error)
+
 	// ListUsersByIDs retrieves users list for the given IDs.
 	ListUsersByIDs(ctx context.Context, ids []string) (UserPage, error)
 
@@ -82,6 +148,10 @@ type Service interface {
 	// UpdateUser updates the user metadata.
 	UpdateUser(ctx context.Context, token string, user User) error
 
+	// UpdateUserByAdmin updates the metadata of the user with the given ID
+	// on behalf of the user, for a valid admin token.
+	UpdateUserByAdmin(ctx context.Context, token string, user User) error
+
 	// GenerateResetToken email where mail will be sent.
 	// host is used for generating reset link.
 	GenerateResetToken(ctx context.Context, email, host string) error
@@ -102,6 +172,24 @@ type Service interface {
 	// DisableUser logically disables the user identified with the provided ID
 	DisableUser(ctx context.Context, token, id string) error
 
+	// InviteUser creates the pending user, assigns it to the org and emails
+	// the invitation link to the user. The invitee sets the password upon
+	// accepting the invitation. It returns the ID of the invitation.
+	InviteUser(ctx context.Context, token, host string, inv Invitation) (string, error)
+
+	// ListInvitations retrieves pending invitations sent by the user. The root
+	// admin retrieves pending invitations sent by all users.
+	ListInvitations(ctx context.Context, token string, pm PageMetadata) (InvitationPage, error)
+
+	// ResendInvitation issues a new invitation token and emails it to the invitee.
+	ResendInvitation(ctx context.Context, token, host, id string) error
+
+	// RevokeInvitation revokes the invitation and removes the pending user.
+	RevokeInvitation(ctx context.Context, token, id string) error
+
+	// AcceptInvitation sets the password of the invited user and enables it.
+	AcceptInvitation(ctx context.Context, invitationToken, password string) error
+
 	// Backup returns admin and all users. Only accessible by admin.
 	Backup(ctx context.Context, token string) (User, []User, error)
 
@@ -109,6 +197,20 @@ type Service interface {
 	Restore(ctx context.Context, token string, admin User, users []User) error
 }
 
+// Token contains the short-lived access token, along with the refresh
+// token used to obtain new access tokens without the user credentials.
+type Token struct {
+	AccessToken  string
+	RefreshToken string
+}
+
+// Client contains details of the client the user logs in from, which are
+// recorded with the opened session.
+type Client struct {
+	IP        string
+	UserAgent string
+}
+
 // PageMetadata contains page metadata that helps navigation.
 type PageMetadata struct {
 	Total    uint64
@@ -128,23 +230,31 @@ type UserPage struct {
 var _ Service = (*usersService)(nil)
 
 type usersService struct {
-	users      UserRepository
-	hasher     Hasher
-	email      Emailer
-	auth       mainflux.AuthServiceClient
-	idProvider mainflux.IDProvider
-	passRegex  *regexp.Regexp
+	users          UserRepository
+	totps          TOTPRepository
+	invitations    InvitationRepository
+	brandings      BrandingRepository
+	hasher         Hasher
+	email          Emailer
+	auth           mainflux.AuthServiceClient
+	idProvider     mainflux.IDProvider
+	passRegex      *regexp.Regexp
+	inviteDuration time.Duration
 }
 
 // New instantiates the users service implementation
-func New(users UserRepository, hasher Hasher, auth mainflux.AuthServiceClient, e Emailer, idp mainflux.IDProvider, passRegex *regexp.Regexp) Service {
+func New(users UserRepository, totps TOTPRepository, invitations InvitationRepository, brandings BrandingRepository, hasher Hasher, auth mainflux.AuthServiceClient, e Emailer, idp mainflux.IDProvider, passRegex *regexp.Regexp, inviteDuration time.Duration) Service {
 	return &usersService{
-		users:      users,
-		hasher:     hasher,
-		auth:       auth,
-		email:      e,
-		idProvider: idp,
-		passRegex:  passRegex,
+		users:          users,
+		totps:          totps,
+		invitations:    invitations,
+		brandings:      brandings,
+		hasher:         hasher,
+		auth:           auth,
+		email:          e,
+		idProvider:     idp,
+		passRegex:      passRegex,
+		inviteDuration: inviteDuration,
 	}
 }
 
@@ -263,15 +373,499 @@ func (svc usersService) Register(ctx context.Context, token string, user User) (
 	return uid, nil
 }
 
-func (svc usersService) Login(ctx context.Context, user User) (string, error) {
+func (svc usersService) Login(ctx context.Context, user User, client Client) (Token, error) {
+	dbUser, err := svc.authenticate(ctx, user)
+	if err != nil {
+		return Token{}, err
+	}
+
+	t, err := svc.totps.RetrieveByUser(ctx, dbUser.ID)
+	switch {
+	case err == nil && t.Enabled:
+		return Token{}, ErrTOTPRequired
+	case err != nil && !errors.Contains(err, errors.ErrNotFound):
+		return Token{}, err
+	}
+	if err := svc.checkTOTPPolicy(ctx, dbUser.ID); err != nil {
+		return Token{}, err
+	}
+
+	sessionID, err := svc.idProvider.ID()
+	if err != nil {
+		return Token{}, err
+	}
+
+	token, err := svc.issueSessionKey(ctx, dbUser, auth.LoginKey, sessionID, client)
+	if err != nil {
+		return Token{}, err
+	}
+
+	refresh, err := svc.issueSessionKey(ctx, dbUser, auth.RefreshKey, sessionID, client)
+	if err != nil {
+		return Token{}, err
+	}
+
+	return Token{AccessToken: token, RefreshToken: refresh}, nil
+}
+
+func (svc usersService) LoginTOTP(ctx context.Context, user User, code string, client Client) (Token, error) {
+	dbUser, err := svc.authenticate(ctx, user)
+	if err != nil {
+		return Token{}, err
+	}
+
+	t, err := svc.enabledTOTP(ctx, dbUser.ID)
+	if err != nil {
+		return Token{}, err
+	}
+	if err := svc.verifyTOTP(ctx, t, code); err != nil {
+		return Token{}, err
+	}
+
+	sessionID, err := svc.idProvider.ID()
+	if err != nil {
+		return Token{}, err
+	}
+
+	token, err := svc.issueSessionKey(ctx, dbUser, auth.LoginKey, sessionID, client)
+	if err != nil {
+		return Token{}, err
+	}
+
+	refresh, err := svc.issueSessionKey(ctx, dbUser, auth.RefreshKey, sessionID, client)
+	if err != nil {
+		return Token{}, err
+	}
+
+	return Token{AccessToken: token, RefreshToken: refresh}, nil
+}
+
+func (svc usersService) EnrollTOTP(ctx context.Context, user User) (TOTPKey, error) {
+	dbUser, err := svc.authenticate(ctx, user)
+	if err != nil {
+		return TOTPKey{}, err
+	}
+
+	t, err := svc.totps.RetrieveByUser(ctx, dbUser.ID)
+	switch {
+	case err == nil && t.Enabled:
+		return TOTPKey{}, errors.Wrap(errors.ErrConflict, ErrTOTPAlreadyEnabled)
+	case err != nil && !errors.Contains(err, errors.ErrNotFound):
+		return TOTPKey{}, err
+	}
+
+	key, err := newTOTPKey(user.Email)
+	if err != nil {
+		return TOTPKey{}, errors.Wrap(errors.ErrCreateEntity, err)
+	}
+
+	t = TOTP{
+		UserID: dbUser.ID,
+		Secret: key.Secret,
+	}
+	if err := svc.totps.Save(ctx, t); err != nil {
+		return TOTPKey{}, err
+	}
+
+	return key, nil
+}
+
+func (svc usersService) ConfirmTOTP(ctx context.Context, user User, code string) ([]string, error) {
+	dbUser, err := svc.authenticate(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	t, err := svc.totps.RetrieveByUser(ctx, dbUser.ID)
+	if err != nil {
+		return nil, err
+	}
+	if t.Enabled {
+		return nil, errors.Wrap(errors.ErrConflict, ErrTOTPAlreadyEnabled)
+	}
+	step, ok := totpStep(t.Secret, code, time.Now())
+	if !ok {
+		return nil, errors.ErrAuthentication
+	}
+
+	codes, err := newRecoveryCodes()
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrCreateEntity, err)
+	}
+
+	t.Enabled = true
+	t.LastStep = step
+	t.RecoveryCodes = []string{}
+	for _, c := range codes {
+		hash, err := svc.hasher.Hash(c)
+		if err != nil {
+			return nil, errors.Wrap(errors.ErrCreateEntity, err)
+		}
+		t.RecoveryCodes = append(t.RecoveryCodes, hash)
+	}
+
+	if err := svc.totps.Save(ctx, t); err != nil {
+		return nil, err
+	}
+
+	return codes, nil
+}
+
+func (svc usersService) DisableTOTP(ctx context.Context, token, code string) error {
+	ir, err := svc.identify(ctx, token)
+	if err != nil {
+		return err
+	}
+
+	t, err := svc.enabledTOTP(ctx, ir.id)
+	if err != nil {
+		return err
+	}
+	if err := svc.verifyTOTP(ctx, t, code); err != nil {
+		return err
+	}
+
+	return svc.totps.Remove(ctx, ir.id)
+}
+
+func (svc usersService) UpdateOrgTOTPPolicy(ctx context.Context, token, orgID string, required bool) error {
+	if err := svc.authorize(ctx, rootSubject, token); err != nil {
+		return err
+	}
+
+	if required {
+		return svc.totps.SaveOrgPolicy(ctx, orgID)
+	}
+
+	return svc.totps.RemoveOrgPolicy(ctx, orgID)
+}
+
+func (svc usersService) UpdateOrgBranding(ctx context.Context, token string, b Branding) error {
+	if err := svc.authorize(ctx, rootSubject, token); err != nil {
+		return err
+	}
+
+	b.UpdatedAt = time.Now().UTC()
+	return svc.brandings.Save(ctx, b)
+}
+
+func (svc usersService) ViewOrgBranding(ctx context.Context, token, orgID string) (Branding, error) {
+	if err := svc.authorize(ctx, rootSubject, token); err != nil {
+		return Branding{}, err
+	}
+
+	return svc.brandings.RetrieveByOrg(ctx, orgID)
+}
+
+func (svc usersService) RemoveOrgBranding(ctx context.Context, token, orgID string) error {
+	if err := svc.authorize(ctx, rootSubject, token); err != nil {
+		return err
+	}
+
+	return svc.brandings.Remove(ctx, orgID)
+}
+
+func (svc usersService) SendTestEmail(ctx context.Context, token, orgID, email string) error {
+	ir, err := svc.identify(ctx, token)
+	if err != nil {
+		return err
+	}
+	if err := svc.authorize(ctx, rootSubject, token); err != nil {
+		return err
+	}
+
+	b, err := svc.orgBranding(ctx, orgID)
+	if err != nil {
+		return err
+	}
+
+	if email == "" {
+		email = ir.email
+	}
+
+	return svc.email.SendTest([]string{email}, b)
+}
+
+// orgBranding returns the e-mail branding of the org, which is empty if the
+// org uses the platform defaults.
+func (svc usersService) orgBranding(ctx context.Context, orgID string) (Branding, error) {
+	b, err := svc.brandings.RetrieveByOrg(ctx, orgID)
+	if errors.Contains(err, errors.ErrNotFound) {
+		return Branding{OrgID: orgID}, nil
+	}
+
+	return b, err
+}
+
+func (svc usersService) authenticate(ctx context.Context, user User) (User, error) {
 	dbUser, err := svc.users.RetrieveByEmail(ctx, user.Email)
 	if err != nil {
-		return "", errors.Wrap(errors.ErrAuthentication, err)
+		return User{}, errors.Wrap(errors.ErrAuthentication, err)
 	}
 	if err := svc.hasher.Compare(user.Password, dbUser.Password); err != nil {
-		return "", errors.Wrap(errors.ErrAuthentication, err)
+		return User{}, errors.Wrap(errors.ErrAuthentication, err)
+	}
+
+	return dbUser, nil
+}
+
+func (svc usersService) enabledTOTP(ctx context.Context, userID string) (TOTP, error) {
+	t, err := svc.totps.RetrieveByUser(ctx, userID)
+	switch {
+	case errors.Contains(err, errors.ErrNotFound):
+		return TOTP{}, errors.Wrap(errors.ErrConflict, ErrTOTPNotEnabled)
+	case err != nil:
+		return TOTP{}, err
+	case !t.Enabled:
+		return TOTP{}, errors.Wrap(errors.ErrConflict, ErrTOTPNotEnabled)
+	}
+
+	return t, nil
+}
+
+// verifyTOTP verifies the TOTP code or the recovery code. Every attempt is
+// counted as failed before the code is checked, so the concurrent attempts
+// can't exceed the limit, and the count is reset once the code is accepted.
+func (svc usersService) verifyTOTP(ctx context.Context, t TOTP, code string) error {
+	err := svc.totps.SaveAttempt(ctx, t.UserID, totpAttemptsLimit, time.Now().Add(totpLockout))
+	switch {
+	case errors.Contains(err, errors.ErrConflict):
+		return ErrTOTPLocked
+	case err != nil:
+		return err
+	}
+
+	if err := svc.checkTOTPCode(ctx, t, code); err != nil {
+		return err
 	}
-	return svc.issue(ctx, dbUser.ID, dbUser.Email, auth.LoginKey)
+
+	return svc.totps.ResetAttempts(ctx, t.UserID)
+}
+
+// checkTOTPCode checks the TOTP code or the recovery code. The time step of
+// the accepted TOTP code and the used recovery code are recorded atomically,
+// so that neither of them can be used again.
+func (svc usersService) checkTOTPCode(ctx context.Context, t TOTP, code string) error {
+	if step, ok := totpStep(t.Secret, code, time.Now()); ok {
+		err := svc.totps.SaveStep(ctx, t.UserID, step)
+		if errors.Contains(err, errors.ErrConflict) {
+			return errors.ErrAuthentication
+		}
+		return err
+	}
+
+	for _, hash := range t.RecoveryCodes {
+		if err := svc.hasher.Compare(code, hash); err != nil {
+			continue
+		}
+		err := svc.totps.RemoveRecoveryCode(ctx, t.UserID, hash)
+		if errors.Contains(err, errors.ErrNotFound) {
+			return errors.ErrAuthentication
+		}
+		return err
+	}
+
+	return errors.ErrAuthentication
+}
+
+// checkTOTPPolicy returns an error if the user is member of an org requiring
+// the second factor. Admins can access all orgs, so the policies don't apply
+// to them. The policies are checked before any key of the user is issued.
+func (svc usersService) checkTOTPPolicy(ctx context.Context, userID string) error {
+	orgIDs, err := svc.totps.RetrieveOrgPolicies(ctx)
+	if err != nil {
+		return err
+	}
+	if len(orgIDs) == 0 {
+		return nil
+	}
+
+	role, err := svc.auth.RetrieveRole(ctx, &mainflux.RetrieveRoleReq{Id: userID})
+	if err != nil {
+		return err
+	}
+	if role.GetRole() == auth.RoleAdmin || role.GetRole() == auth.RoleRootAdmin {
+		return nil
+	}
+
+	res, err := svc.auth.RetrieveMemberships(ctx, &mainflux.MembershipsReq{Id: userID})
+	if err != nil {
+		return err
+	}
+	required := make(map[string]bool)
+	for _, id := range orgIDs {
+		required[id] = true
+	}
+	for _, id := range res.GetOrgIDs() {
+		if required[id] {
+			return errors.Wrap(errors.ErrAuthorization, ErrTOTPEnrollmentRequired)
+		}
+	}
+
+	return nil
+}
+
+func (svc usersService) InviteUser(ctx context.Context, token, host string, inv Invitation) (string, error) {
+	ir, err := svc.identify(ctx, token)
+	if err != nil {
+		return "", err
+	}
+
+	user := User{
+		Email:  inv.Email,
+		Status: PendingStatusKey,
+	}
+	if err := user.Validate(); err != nil {
+		return "", err
+	}
+
+	if user.ID, err = svc.idProvider.ID(); err != nil {
+		return "", err
+	}
+	if _, err := svc.users.Save(ctx, user); err != nil {
+		return "", err
+	}
+
+	req := &mainflux.Assignment{
+		Token:    token,
+		GroupID:  inv.OrgID,
+		MemberID: user.ID,
+	}
+	if _, err := svc.auth.Assign(ctx, req); err != nil {
+		if err := svc.users.Remove(ctx, user.ID); err != nil {
+			return "", err
+		}
+		return "", errors.Wrap(errors.ErrAuthorization, err)
+	}
+
+	t, hash, err := newInvitationToken()
+	if err != nil {
+		return "", err
+	}
+
+	if inv.ID, err = svc.idProvider.ID(); err != nil {
+		return "", err
+	}
+	inv.UserID = user.ID
+	inv.InvitedBy = ir.id
+	inv.TokenHash = hash
+	inv.CreatedAt = time.Now().UTC()
+	inv.ExpiresAt = inv.CreatedAt.Add(svc.inviteDuration)
+
+	if err := svc.invitations.Save(ctx, inv); err != nil {
+		return "", err
+	}
+
+	b, err := svc.orgBranding(ctx, inv.OrgID)
+	if err != nil {
+		return "", err
+	}
+	if err := svc.email.SendInvitation([]string{inv.Email}, host, t, b); err != nil {
+		return "", err
+	}
+
+	return inv.ID, nil
+}
+
+func (svc usersService) ListInvitations(ctx context.Context, token string, pm PageMetadata) (InvitationPage, error) {
+	ir, err := svc.identify(ctx, token)
+	if err != nil {
+		return InvitationPage{}, err
+	}
+
+	invitedBy := ir.id
+	if err := svc.authorize(ctx, rootSubject, token); err == nil {
+		invitedBy = ""
+	}
+
+	return svc.invitations.RetrieveAll(ctx, invitedBy, pm)
+}
+
+func (svc usersService) ResendInvitation(ctx context.Context, token, host, id string) error {
+	inv, err := svc.invitation(ctx, token, id)
+	if err != nil {
+		return err
+	}
+
+	t, hash, err := newInvitationToken()
+	if err != nil {
+		return err
+	}
+	inv.TokenHash = hash
+	inv.ExpiresAt = time.Now().UTC().Add(svc.inviteDuration)
+
+	if err := svc.invitations.UpdateToken(ctx, inv); err != nil {
+		return err
+	}
+
+	b, err := svc.orgBranding(ctx, inv.OrgID)
+	if err != nil {
+		return err
+	}
+
+	return svc.email.SendInvitation([]string{inv.Email}, host, t, b)
+}
+
+func (svc usersService) RevokeInvitation(ctx context.Context, token, id string) error {
+	inv, err := svc.invitation(ctx, token, id)
+	if err != nil {
+		return err
+	}
+
+	if err := svc.invitations.Remove(ctx, inv.ID); err != nil {
+		return err
+	}
+
+	return svc.users.Remove(ctx, inv.UserID)
+}
+
+func (svc usersService) AcceptInvitation(ctx context.Context, invitationToken, password string) error {
+	inv, err := svc.invitations.RetrieveByToken(ctx, hashInvitationToken(invitationToken))
+	if err != nil {
+		return errors.Wrap(errors.ErrAuthentication, err)
+	}
+	if time.Now().After(inv.ExpiresAt) {
+		return errors.Wrap(errors.ErrAuthentication, ErrInvitationExpired)
+	}
+
+	if !svc.passRegex.MatchString(password) {
+		return ErrPasswordFormat
+	}
+	hash, err := svc.hasher.Hash(password)
+	if err != nil {
+		return errors.Wrap(errors.ErrMalformedEntity, err)
+	}
+
+	if err := svc.users.ChangeStatus(ctx, inv.UserID, EnabledStatusKey); err != nil {
+		return err
+	}
+	if err := svc.users.UpdatePassword(ctx, inv.Email, hash); err != nil {
+		return err
+	}
+
+	return svc.invitations.Remove(ctx, inv.ID)
+}
+
+// invitation retrieves the invitation, which can be managed only by
+// the user who sent it and by the root admin.
+func (svc usersService) invitation(ctx context.Context, token, id string) (Invitation, error) {
+	ir, err := svc.identify(ctx, token)
+	if err != nil {
+		return Invitation{}, err
+	}
+
+	inv, err := svc.invitations.RetrieveByID(ctx, id)
+	if err != nil {
+		return Invitation{}, err
+	}
+
+	if inv.InvitedBy != ir.id {
+		if err := svc.authorize(ctx, rootSubject, token); err != nil {
+			return Invitation{}, err
+		}
+	}
+
+	return inv, nil
 }
 
 func (svc usersService) ViewUser(ctx context.Context, token, id string) (User, error) {
@@ -327,6 +921,18 @@ func (svc usersService) ListUsers(ctx context.Context, token string, pm PageMeta
 	return svc.users.RetrieveByIDs(ctx, nil, pm)
 }
 
+func (svc usersService) SearchUsers(ctx context.Context, token string, pm PageMetadata) (UserPage, error) {
+	if err := svc.authorize(ctx, rootSubject, token); err != nil {
+		return UserPage{}, err
+	}
+
+	if pm.Status == "" {
+		pm.Status = AllStatusKey
+	}
+
+	return svc.users.RetrieveByIDs(ctx, nil, pm)
+}
+
 func (svc usersService) ListUsersByIDs(ctx context.Context, ids []string) (UserPage, error) {
 	pm := PageMetadata{Status: EnabledStatusKey}
 	return svc.users.RetrieveByIDs(ctx, ids, pm)
@@ -411,6 +1017,24 @@ func (svc usersService) UpdateUser(ctx context.Context, token string, u User) er
 	return svc.users.UpdateUser(ctx, user)
 }
 
+func (svc usersService) UpdateUserByAdmin(ctx context.Context, token string, u User) error {
+	if err := svc.authorize(ctx, rootSubject, token); err != nil {
+		return err
+	}
+
+	user, err := svc.users.RetrieveByID(ctx, u.ID)
+	if err != nil {
+		return err
+	}
+
+	// User is updated as a whole, so that the rest of its fields are
+	// preserved.
+	user.ID = u.ID
+	user.Metadata = u.Metadata
+
+	return svc.users.UpdateUser(ctx, user)
+}
+
 func (svc usersService) GenerateResetToken(ctx context.Context, email, host string) error {
 	user, err := svc.users.RetrieveByEmail(ctx, email)
 	if err != nil || user.Email == "" {
@@ -458,7 +1082,7 @@ func (svc usersService) ChangePassword(ctx context.Context, authToken, password,
 		ID:       ir.id,
 		Password: oldPassword,
 	}
-	if _, err := svc.Login(ctx, u); err != nil {
+	if _, err := svc.authenticate(ctx, u); err != nil {
 		return errors.ErrAuthentication
 	}
 	u, err = svc.users.RetrieveByID(ctx, ir.id)
@@ -520,6 +1144,24 @@ func (svc usersService) issue(ctx context.Context, id, email string, keyType uin
 	return key.GetValue(), nil
 }
 
+// issueSessionKey issues the login or refresh key of the session opened on
+// the client. Issuing the refresh key records the session in auth service.
+func (svc usersService) issueSessionKey(ctx context.Context, user User, keyType uint32, sessionID string, client Client) (string, error) {
+	req := &mainflux.IssueReq{
+		Id:        user.ID,
+		Email:     user.Email,
+		Type:      keyType,
+		SessionId: sessionID,
+		Ip:        client.IP,
+		UserAgent: client.UserAgent,
+	}
+	key, err := svc.auth.Issue(ctx, req)
+	if err != nil {
+		return "", errors.Wrap(errors.ErrNotFound, err)
+	}
+	return key.GetValue(), nil
+}
+
 type userIdentity struct {
 	id    string
 	email string
@@ -5,9 +5,14 @@ package users_test
 
 import (
 	"context"
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/base32"
+	"encoding/binary"
 	"fmt"
 	"regexp"
 	"testing"
+	"time"
 
 	"github.com/MainfluxLabs/mainflux"
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
@@ -20,8 +25,9 @@ import (
 )
 
 const (
-	wrong   = "wrong-value"
-	userNum = 101
+	wrong          = "wrong-value"
+	userNum        = 101
+	inviteDuration = 24 * time.Hour
 )
 
 var (
@@ -39,12 +45,18 @@ var (
 )
 
 func newService() users.Service {
+	return newServiceWithEmailer(usmocks.NewEmailer())
+}
+
+func newServiceWithEmailer(e users.Emailer) users.Service {
 	hasher := usmocks.NewHasher()
 	userRepo := usmocks.NewUserRepository(usersList)
+	totpRepo := usmocks.NewTOTPRepository()
+	invitationRepo := usmocks.NewInvitationRepository()
+	brandingRepo := usmocks.NewBrandingRepository()
 	authSvc := mocks.NewAuthService(admin.ID, usersList)
-	e := usmocks.NewEmailer()
 
-	return users.New(userRepo, hasher, authSvc, e, idProvider, passRegex)
+	return users.New(userRepo, totpRepo, invitationRepo, brandingRepo, hasher, authSvc, e, idProvider, passRegex, inviteDuration)
 }
 
 func TestSelfRegister(t *testing.T) {
@@ -127,16 +139,385 @@ func TestLogin(t *testing.T) {
 	}
 
 	for desc, tc := range cases {
-		_, err := svc.Login(context.Background(), tc.user)
+		_, err := svc.Login(context.Background(), tc.user, users.Client{})
 		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", desc, tc.err, err))
 	}
 }
 
+func TestTOTP(t *testing.T) {
+	svc := newService()
+	ctx := context.Background()
+
+	_, err := svc.ConfirmTOTP(ctx, registerUser, "000000")
+	assert.True(t, errors.Contains(err, errors.ErrNotFound), fmt.Sprintf("confirm without enrollment: expected %s got %s\n", errors.ErrNotFound, err))
+
+	_, err = svc.EnrollTOTP(ctx, users.User{Email: registerUser.Email, Password: wrong})
+	assert.True(t, errors.Contains(err, errors.ErrAuthentication), fmt.Sprintf("enroll with wrong password: expected %s got %s\n", errors.ErrAuthentication, err))
+
+	key, err := svc.EnrollTOTP(ctx, registerUser)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.Contains(t, key.URI, key.Secret, "otpauth URI should contain the secret")
+
+	_, err = svc.Login(ctx, registerUser, users.Client{})
+	assert.Nil(t, err, fmt.Sprintf("login before confirmation: unexpected error: %s", err))
+
+	_, err = svc.ConfirmTOTP(ctx, registerUser, "000000")
+	assert.True(t, errors.Contains(err, errors.ErrAuthentication), fmt.Sprintf("confirm with wrong code: expected %s got %s\n", errors.ErrAuthentication, err))
+
+	now := time.Now()
+	codes, err := svc.ConfirmTOTP(ctx, registerUser, totpCode(t, key.Secret, now))
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.Len(t, codes, 10, "expected 10 recovery codes")
+
+	_, err = svc.EnrollTOTP(ctx, registerUser)
+	assert.True(t, errors.Contains(err, users.ErrTOTPAlreadyEnabled), fmt.Sprintf("enroll enabled: expected %s got %s\n", users.ErrTOTPAlreadyEnabled, err))
+
+	cases := []struct {
+		desc string
+		code string
+		err  error
+	}{
+		{
+			desc: "login without second factor",
+			code: "",
+			err:  users.ErrTOTPRequired,
+		},
+		{
+			desc: "login with wrong code",
+			code: wrong,
+			err:  errors.ErrAuthentication,
+		},
+		{
+			desc: "login with code used for confirmation",
+			code: totpCode(t, key.Secret, now),
+			err:  errors.ErrAuthentication,
+		},
+		{
+			desc: "login with code of the next period",
+			code: totpCode(t, key.Secret, now.Add(30*time.Second)),
+			err:  nil,
+		},
+		{
+			desc: "login with replayed code",
+			code: totpCode(t, key.Secret, now.Add(30*time.Second)),
+			err:  errors.ErrAuthentication,
+		},
+		{
+			desc: "login with code of the previous period",
+			code: totpCode(t, key.Secret, now.Add(-30*time.Second)),
+			err:  errors.ErrAuthentication,
+		},
+		{
+			desc: "login with expired code",
+			code: totpCode(t, key.Secret, now.Add(-5*time.Minute)),
+			err:  errors.ErrAuthentication,
+		},
+		{
+			desc: "login with recovery code",
+			code: codes[0],
+			err:  nil,
+		},
+		{
+			desc: "login with used recovery code",
+			code: codes[0],
+			err:  errors.ErrAuthentication,
+		},
+	}
+
+	for _, tc := range cases {
+		var err error
+		switch tc.code {
+		case "":
+			_, err = svc.Login(ctx, registerUser, users.Client{})
+		default:
+			_, err = svc.LoginTOTP(ctx, registerUser, tc.code, users.Client{})
+		}
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+
+	err = svc.DisableTOTP(ctx, registerUser.Email, wrong)
+	assert.True(t, errors.Contains(err, errors.ErrAuthentication), fmt.Sprintf("disable with wrong code: expected %s got %s\n", errors.ErrAuthentication, err))
+
+	err = svc.DisableTOTP(ctx, registerUser.Email, codes[1])
+	assert.Nil(t, err, fmt.Sprintf("disable with recovery code: unexpected error: %s", err))
+
+	_, err = svc.Login(ctx, registerUser, users.Client{})
+	assert.Nil(t, err, fmt.Sprintf("login with disabled second factor: unexpected error: %s", err))
+
+	_, err = svc.LoginTOTP(ctx, registerUser, codes[2], users.Client{})
+	assert.True(t, errors.Contains(err, users.ErrTOTPNotEnabled), fmt.Sprintf("login with disabled second factor: expected %s got %s\n", users.ErrTOTPNotEnabled, err))
+}
+
+func TestTOTPLockout(t *testing.T) {
+	svc := newService()
+	ctx := context.Background()
+
+	key, err := svc.EnrollTOTP(ctx, registerUser)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	now := time.Now()
+	codes, err := svc.ConfirmTOTP(ctx, registerUser, totpCode(t, key.Secret, now))
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	for i := 0; i < 4; i++ {
+		_, err := svc.LoginTOTP(ctx, registerUser, wrong, users.Client{})
+		assert.True(t, errors.Contains(err, errors.ErrAuthentication), fmt.Sprintf("login with wrong code: expected %s got %s\n", errors.ErrAuthentication, err))
+	}
+
+	_, err = svc.LoginTOTP(ctx, registerUser, totpCode(t, key.Secret, now.Add(30*time.Second)), users.Client{})
+	assert.Nil(t, err, fmt.Sprintf("login with valid code below the attempts limit: unexpected error: %s", err))
+
+	for i := 0; i < 5; i++ {
+		_, err := svc.LoginTOTP(ctx, registerUser, wrong, users.Client{})
+		assert.True(t, errors.Contains(err, errors.ErrAuthentication), fmt.Sprintf("login with wrong code after reset of failed attempts: expected %s got %s\n", errors.ErrAuthentication, err))
+	}
+
+	cases := []struct {
+		desc string
+		code string
+		err  error
+	}{
+		{
+			desc: "login with wrong code after reaching the attempts limit",
+			code: wrong,
+			err:  users.ErrTOTPLocked,
+		},
+		{
+			desc: "login with valid code after reaching the attempts limit",
+			code: totpCode(t, key.Secret, now.Add(60*time.Second)),
+			err:  users.ErrTOTPLocked,
+		},
+		{
+			desc: "login with recovery code after reaching the attempts limit",
+			code: codes[0],
+			err:  users.ErrTOTPLocked,
+		},
+	}
+
+	for _, tc := range cases {
+		_, err := svc.LoginTOTP(ctx, registerUser, tc.code, users.Client{})
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+
+	err = svc.DisableTOTP(ctx, registerUser.Email, codes[1])
+	assert.True(t, errors.Contains(err, users.ErrTOTPLocked), fmt.Sprintf("disable with recovery code after reaching the attempts limit: expected %s got %s\n", users.ErrTOTPLocked, err))
+}
+
+func TestUpdateOrgTOTPPolicy(t *testing.T) {
+	svc := newService()
+	orgID := "2b6e1c3a-3f0e-4d8e-9a55-3d4c1f0a7b21"
+
+	cases := []struct {
+		desc     string
+		token    string
+		required bool
+		err      error
+	}{
+		{
+			desc:     "require second factor as admin",
+			token:    admin.Email,
+			required: true,
+			err:      nil,
+		},
+		{
+			desc:     "require second factor as user",
+			token:    user.Email,
+			required: true,
+			err:      errors.ErrAuthorization,
+		},
+		{
+			desc:     "require second factor with invalid token",
+			token:    wrong,
+			required: true,
+			err:      errors.ErrAuthorization,
+		},
+		{
+			desc:     "remove second factor requirement as admin",
+			token:    admin.Email,
+			required: false,
+			err:      nil,
+		},
+	}
+
+	for _, tc := range cases {
+		err := svc.UpdateOrgTOTPPolicy(context.Background(), tc.token, orgID, tc.required)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestLoginTOTPPolicy(t *testing.T) {
+	authSvc := mocks.NewAuthService(admin.ID, usersList)
+	svc := users.New(usmocks.NewUserRepository(usersList), usmocks.NewTOTPRepository(), usmocks.NewInvitationRepository(), usmocks.NewBrandingRepository(), usmocks.NewHasher(), authSvc, usmocks.NewEmailer(), idProvider, passRegex, inviteDuration)
+	ctx := context.Background()
+
+	orgID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	otherOrgID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	err = svc.UpdateOrgTOTPPolicy(ctx, admin.Email, orgID, true)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	_, err = authSvc.Assign(ctx, &mainflux.Assignment{Token: admin.Email, GroupID: otherOrgID, MemberID: registerUser.ID})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	_, err = svc.Login(ctx, registerUser, users.Client{})
+	assert.Nil(t, err, fmt.Sprintf("login as member of org without policy: unexpected error: %s", err))
+
+	_, err = authSvc.Assign(ctx, &mainflux.Assignment{Token: admin.Email, GroupID: orgID, MemberID: registerUser.ID})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	token, err := svc.Login(ctx, registerUser, users.Client{})
+	assert.True(t, errors.Contains(err, users.ErrTOTPEnrollmentRequired), fmt.Sprintf("login as member of org requiring second factor: expected %s got %s\n", users.ErrTOTPEnrollmentRequired, err))
+	assert.Equal(t, users.Token{}, token, "login as member of org requiring second factor: expected no token")
+}
+
+// totpCode returns the TOTP code as specified by RFC 6238.
+func totpCode(t *testing.T, secret string, ts time.Time) string {
+	key, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(secret)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	var msg [8]byte
+	binary.BigEndian.PutUint64(msg[:], uint64(ts.Unix()/30))
+	h := hmac.New(sha1.New, key)
+	h.Write(msg[:])
+	sum := h.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+	return fmt.Sprintf("%06d", value%1000000)
+}
+
+func TestUpdateOrgBranding(t *testing.T) {
+	svc := newService()
+	orgID := "2b6e1c3a-3f0e-4d8e-9a55-3d4c1f0a7b21"
+	branding := users.Branding{
+		OrgID:        orgID,
+		Name:         "Acme",
+		PrimaryColor: "#1a2b3c",
+		SupportEmail: "support@acme.com",
+		Locale:       "de",
+	}
+
+	cases := []struct {
+		desc  string
+		token string
+		err   error
+	}{
+		{
+			desc:  "update branding as admin",
+			token: admin.Email,
+			err:   nil,
+		},
+		{
+			desc:  "update branding as user",
+			token: user.Email,
+			err:   errors.ErrAuthorization,
+		},
+		{
+			desc:  "update branding with invalid token",
+			token: wrong,
+			err:   errors.ErrAuthorization,
+		},
+	}
+
+	for _, tc := range cases {
+		err := svc.UpdateOrgBranding(context.Background(), tc.token, branding)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+
+	b, err := svc.ViewOrgBranding(context.Background(), admin.Email, orgID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.Equal(t, branding.Name, b.Name, fmt.Sprintf("view branding: expected name %s got %s\n", branding.Name, b.Name))
+	assert.Equal(t, branding.Locale, b.Locale, fmt.Sprintf("view branding: expected locale %s got %s\n", branding.Locale, b.Locale))
+
+	err = svc.RemoveOrgBranding(context.Background(), admin.Email, orgID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	_, err = svc.ViewOrgBranding(context.Background(), admin.Email, orgID)
+	assert.True(t, errors.Contains(err, errors.ErrNotFound), fmt.Sprintf("view removed branding: expected %s got %s\n", errors.ErrNotFound, err))
+}
+
+type testEmailer struct {
+	users.Emailer
+	sent map[string]users.Branding
+}
+
+func (e testEmailer) SendTest(to []string, b users.Branding) error {
+	for _, email := range to {
+		e.sent[email] = b
+	}
+	return nil
+}
+
+func TestSendTestEmail(t *testing.T) {
+	e := testEmailer{sent: make(map[string]users.Branding)}
+	svc := newServiceWithEmailer(e)
+	orgID := "2b6e1c3a-3f0e-4d8e-9a55-3d4c1f0a7b21"
+
+	err := svc.UpdateOrgBranding(context.Background(), admin.Email, users.Branding{OrgID: orgID, Name: "Acme"})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc      string
+		token     string
+		orgID     string
+		email     string
+		recipient string
+		name      string
+		err       error
+	}{
+		{
+			desc:      "send test email to admin",
+			token:     admin.Email,
+			orgID:     orgID,
+			recipient: admin.Email,
+			name:      "Acme",
+			err:       nil,
+		},
+		{
+			desc:      "send test email to address",
+			token:     admin.Email,
+			orgID:     orgID,
+			email:     "qa@example.com",
+			recipient: "qa@example.com",
+			name:      "Acme",
+			err:       nil,
+		},
+		{
+			desc:      "send test email for org without branding",
+			token:     admin.Email,
+			orgID:     "7c9a3d2e-5b1f-4e6a-8d0c-2f4b6a8c0e13",
+			email:     "default@example.com",
+			recipient: "default@example.com",
+			err:       nil,
+		},
+		{
+			desc:  "send test email as user",
+			token: user.Email,
+			orgID: orgID,
+			email: "user-qa@example.com",
+			err:   errors.ErrAuthorization,
+		},
+	}
+
+	for _, tc := range cases {
+		err := svc.SendTestEmail(context.Background(), tc.token, tc.orgID, tc.email)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if tc.err != nil {
+			continue
+		}
+		b, ok := e.sent[tc.recipient]
+		assert.True(t, ok, fmt.Sprintf("%s: expected email sent to %s\n", tc.desc, tc.recipient))
+		assert.Equal(t, tc.name, b.Name, fmt.Sprintf("%s: expected branding %s got %s\n", tc.desc, tc.name, b.Name))
+	}
+}
+
 func TestViewUser(t *testing.T) {
 	svc := newService()
 
-	token, err := svc.Login(context.Background(), user)
+	tokenRes, err := svc.Login(context.Background(), user, users.Client{})
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	token := tokenRes.AccessToken
 
 	cases := map[string]struct {
 		user   users.User
@@ -174,11 +555,13 @@ func TestViewUser(t *testing.T) {
 func TestViewProfile(t *testing.T) {
 	svc := newService()
 
-	token, err := svc.Login(context.Background(), user)
+	tokenRes, err := svc.Login(context.Background(), user, users.Client{})
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	token := tokenRes.AccessToken
 
-	adminToken, err := svc.Login(context.Background(), admin)
+	adminTokenRes, err := svc.Login(context.Background(), admin, users.Client{})
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	adminToken := adminTokenRes.AccessToken
 
 	cases := map[string]struct {
 		user  users.User
@@ -212,11 +595,13 @@ func TestViewProfile(t *testing.T) {
 func TestListUsers(t *testing.T) {
 	svc := newService()
 
-	token, err := svc.Login(context.Background(), admin)
+	tokenRes, err := svc.Login(context.Background(), admin, users.Client{})
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	token := tokenRes.AccessToken
 
-	unauthUserToken, err := svc.Login(context.Background(), unauthUser)
+	unauthUserTokenRes, err := svc.Login(context.Background(), unauthUser, users.Client{})
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	unauthUserToken := unauthUserTokenRes.AccessToken
 
 	page, err := svc.ListUsers(context.Background(), token, users.PageMetadata{})
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
@@ -308,11 +693,87 @@ func TestListUsers(t *testing.T) {
 	}
 }
 
+func TestSearchUsers(t *testing.T) {
+	svc := newService()
+
+	tokenRes, err := svc.Login(context.Background(), admin, users.Client{})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	token := tokenRes.AccessToken
+
+	unauthUserTokenRes, err := svc.Login(context.Background(), unauthUser, users.Client{})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	unauthUserToken := unauthUserTokenRes.AccessToken
+
+	enabled := users.User{Email: "TestSearchUsers1@example.com", Password: "passpass"}
+	_, err = svc.SelfRegister(context.Background(), enabled)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	disabled := users.User{Email: "TestSearchUsers2@example.com", Password: "passpass"}
+	id, err := svc.SelfRegister(context.Background(), disabled)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	err = svc.DisableUser(context.Background(), token, id)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc   string
+		token  string
+		email  string
+		status string
+		size   int
+		err    error
+	}{
+		{
+			desc:  "search users of all statuses",
+			token: token,
+			size:  len(usersList) + 2,
+			err:   nil,
+		},
+		{
+			desc:   "search disabled users",
+			token:  token,
+			status: users.DisabledStatusKey,
+			size:   1,
+			err:    nil,
+		},
+		{
+			desc:  "search users by email",
+			token: token,
+			email: disabled.Email,
+			size:  1,
+			err:   nil,
+		},
+		{
+			desc:  "search users without permission",
+			token: unauthUserToken,
+			size:  0,
+			err:   errors.ErrAuthorization,
+		},
+		{
+			desc:  "search users with invalid token",
+			token: wrong,
+			size:  0,
+			err:   errors.ErrAuthentication,
+		},
+	}
+
+	for _, tc := range cases {
+		pm := users.PageMetadata{
+			Limit:  100,
+			Email:  tc.email,
+			Status: tc.status,
+		}
+		page, err := svc.SearchUsers(context.Background(), tc.token, pm)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.size, len(page.Users), fmt.Sprintf("%s: expected size %d got %d\n", tc.desc, tc.size, len(page.Users)))
+	}
+}
+
 func TestUpdateUser(t *testing.T) {
 	svc := newService()
 
-	token, err := svc.Login(context.Background(), registerUser)
+	tokenRes, err := svc.Login(context.Background(), registerUser, users.Client{})
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	token := tokenRes.AccessToken
 
 	registerUser.Metadata = map[string]interface{}{"meta": "test"}
 
@@ -339,6 +800,63 @@ func TestUpdateUser(t *testing.T) {
 	}
 }
 
+func TestUpdateUserByAdmin(t *testing.T) {
+	svc := newService()
+
+	tokenRes, err := svc.Login(context.Background(), admin, users.Client{})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	token := tokenRes.AccessToken
+
+	unauthUserTokenRes, err := svc.Login(context.Background(), unauthUser, users.Client{})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	unauthUserToken := unauthUserTokenRes.AccessToken
+
+	metadata := map[string]interface{}{"meta": "admin"}
+
+	cases := []struct {
+		desc  string
+		token string
+		id    string
+		err   error
+	}{
+		{
+			desc:  "update user by admin",
+			token: token,
+			id:    user.ID,
+			err:   nil,
+		},
+		{
+			desc:  "update user without permission",
+			token: unauthUserToken,
+			id:    user.ID,
+			err:   errors.ErrAuthorization,
+		},
+		{
+			desc:  "update user with invalid token",
+			token: wrong,
+			id:    user.ID,
+			err:   errors.ErrAuthentication,
+		},
+		{
+			desc:  "update non-existing user",
+			token: token,
+			id:    wrong,
+			err:   errors.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		err := svc.UpdateUserByAdmin(context.Background(), tc.token, users.User{ID: tc.id, Metadata: metadata})
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+
+	// Metadata is updated on behalf of the user, preserving its email.
+	u, err := svc.ViewUser(context.Background(), token, user.ID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.Equal(t, user.Email, u.Email, fmt.Sprintf("expected email %s got %s\n", user.Email, u.Email))
+	assert.Equal(t, metadata, map[string]interface{}(u.Metadata), fmt.Sprintf("expected metadata %v got %v\n", metadata, u.Metadata))
+}
+
 func TestGenerateResetToken(t *testing.T) {
 	svc := newService()
 
@@ -358,7 +876,8 @@ func TestGenerateResetToken(t *testing.T) {
 
 func TestChangePassword(t *testing.T) {
 	svc := newService()
-	token, _ := svc.Login(context.Background(), registerUser)
+	tokenRes, _ := svc.Login(context.Background(), registerUser, users.Client{})
+	token := tokenRes.AccessToken
 
 	cases := map[string]struct {
 		token       string
@@ -401,7 +920,8 @@ func TestResetPassword(t *testing.T) {
 
 func TestSendPasswordReset(t *testing.T) {
 	svc := newService()
-	token, _ := svc.Login(context.Background(), registerUser)
+	tokenRes, _ := svc.Login(context.Background(), registerUser, users.Client{})
+	token := tokenRes.AccessToken
 
 	cases := map[string]struct {
 		token string
@@ -417,3 +937,253 @@ func TestSendPasswordReset(t *testing.T) {
 
 	}
 }
+
+type invitationEmailer struct {
+	users.Emailer
+	tokens map[string]string
+}
+
+func (e invitationEmailer) SendInvitation(to []string, host, token string, b users.Branding) error {
+	for _, email := range to {
+		e.tokens[email] = token
+	}
+	return nil
+}
+
+func TestInviteUser(t *testing.T) {
+	svc := newService()
+	orgID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc  string
+		token string
+		inv   users.Invitation
+		err   error
+	}{
+		{
+			desc:  "invite new user",
+			token: admin.Email,
+			inv:   users.Invitation{Email: "invited@example.com", OrgID: orgID},
+			err:   nil,
+		},
+		{
+			desc:  "invite already invited user",
+			token: admin.Email,
+			inv:   users.Invitation{Email: "invited@example.com", OrgID: orgID},
+			err:   errors.ErrConflict,
+		},
+		{
+			desc:  "invite existing user",
+			token: admin.Email,
+			inv:   users.Invitation{Email: user.Email, OrgID: orgID},
+			err:   errors.ErrConflict,
+		},
+		{
+			desc:  "invite user with invalid email",
+			token: admin.Email,
+			inv:   users.Invitation{Email: "invited.example.com", OrgID: orgID},
+			err:   errors.ErrMalformedEntity,
+		},
+		{
+			desc:  "invite user with invalid token",
+			token: wrong,
+			inv:   users.Invitation{Email: "other-invited@example.com", OrgID: orgID},
+			err:   errors.ErrAuthentication,
+		},
+	}
+
+	for _, tc := range cases {
+		_, err := svc.InviteUser(context.Background(), tc.token, host, tc.inv)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestListInvitations(t *testing.T) {
+	svc := newService()
+	orgID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	for i := 0; i < 2; i++ {
+		inv := users.Invitation{Email: fmt.Sprintf("invited%d@example.com", i), OrgID: orgID}
+		_, err := svc.InviteUser(context.Background(), admin.Email, host, inv)
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	}
+	inv := users.Invitation{Email: "invited-by-user@example.com", OrgID: orgID}
+	_, err = svc.InviteUser(context.Background(), user.Email, host, inv)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := map[string]struct {
+		token string
+		size  int
+		err   error
+	}{
+		"list invitations as root admin": {
+			token: admin.Email,
+			size:  3,
+			err:   nil,
+		},
+		"list invitations as user": {
+			token: user.Email,
+			size:  1,
+			err:   nil,
+		},
+		"list invitations with invalid token": {
+			token: wrong,
+			size:  0,
+			err:   errors.ErrAuthentication,
+		},
+	}
+
+	for desc, tc := range cases {
+		page, err := svc.ListInvitations(context.Background(), tc.token, users.PageMetadata{Limit: 10})
+		assert.Equal(t, tc.size, len(page.Invitations), fmt.Sprintf("%s: expected %d got %d\n", desc, tc.size, len(page.Invitations)))
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", desc, tc.err, err))
+	}
+}
+
+func TestResendInvitation(t *testing.T) {
+	e := invitationEmailer{tokens: map[string]string{}}
+	svc := newServiceWithEmailer(e)
+	orgID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	inv := users.Invitation{Email: "invited@example.com", OrgID: orgID}
+	id, err := svc.InviteUser(context.Background(), admin.Email, host, inv)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	oldToken := e.tokens[inv.Email]
+
+	cases := map[string]struct {
+		token string
+		id    string
+		err   error
+	}{
+		"resend invitation sent by other user": {
+			token: user.Email,
+			id:    id,
+			err:   errors.ErrAuthorization,
+		},
+		"resend invitation with invalid token": {
+			token: wrong,
+			id:    id,
+			err:   errors.ErrAuthentication,
+		},
+		"resend non-existing invitation": {
+			token: admin.Email,
+			id:    wrong,
+			err:   errors.ErrNotFound,
+		},
+		"resend invitation": {
+			token: admin.Email,
+			id:    id,
+			err:   nil,
+		},
+	}
+
+	for desc, tc := range cases {
+		err := svc.ResendInvitation(context.Background(), tc.token, host, tc.id)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", desc, tc.err, err))
+	}
+
+	err = svc.AcceptInvitation(context.Background(), oldToken, "password")
+	assert.True(t, errors.Contains(err, errors.ErrAuthentication), fmt.Sprintf("accept invitation with replaced token: expected %s got %s\n", errors.ErrAuthentication, err))
+	err = svc.AcceptInvitation(context.Background(), e.tokens[inv.Email], "password")
+	assert.Nil(t, err, fmt.Sprintf("accept invitation with resent token: unexpected error: %s\n", err))
+}
+
+func TestRevokeInvitation(t *testing.T) {
+	svc := newService()
+	orgID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	inv := users.Invitation{Email: "invited@example.com", OrgID: orgID}
+	id, err := svc.InviteUser(context.Background(), admin.Email, host, inv)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc  string
+		token string
+		id    string
+		err   error
+	}{
+		{
+			desc:  "revoke invitation sent by other user",
+			token: user.Email,
+			id:    id,
+			err:   errors.ErrAuthorization,
+		},
+		{
+			desc:  "revoke invitation",
+			token: admin.Email,
+			id:    id,
+			err:   nil,
+		},
+		{
+			desc:  "revoke already revoked invitation",
+			token: admin.Email,
+			id:    id,
+			err:   errors.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		err := svc.RevokeInvitation(context.Background(), tc.token, tc.id)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+
+	_, err = svc.InviteUser(context.Background(), admin.Email, host, inv)
+	assert.Nil(t, err, fmt.Sprintf("invite user with revoked invitation: unexpected error: %s\n", err))
+}
+
+func TestAcceptInvitation(t *testing.T) {
+	e := invitationEmailer{tokens: map[string]string{}}
+	svc := newServiceWithEmailer(e)
+	orgID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	inv := users.Invitation{Email: "invited@example.com", OrgID: orgID}
+	_, err = svc.InviteUser(context.Background(), admin.Email, host, inv)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	token := e.tokens[inv.Email]
+
+	cases := []struct {
+		desc     string
+		token    string
+		password string
+		err      error
+	}{
+		{
+			desc:     "accept invitation with invalid token",
+			token:    wrong,
+			password: "password",
+			err:      errors.ErrAuthentication,
+		},
+		{
+			desc:     "accept invitation with weak password",
+			token:    token,
+			password: "weak",
+			err:      users.ErrPasswordFormat,
+		},
+		{
+			desc:     "accept invitation",
+			token:    token,
+			password: "password",
+			err:      nil,
+		},
+		{
+			desc:     "accept already accepted invitation",
+			token:    token,
+			password: "password",
+			err:      errors.ErrAuthentication,
+		},
+	}
+
+	for _, tc := range cases {
+		err := svc.AcceptInvitation(context.Background(), tc.token, tc.password)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+
+	page, err := svc.ListInvitations(context.Background(), admin.Email, users.PageMetadata{})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.Equal(t, 0, len(page.Invitations), fmt.Sprintf("expected no pending invitations got %d\n", len(page.Invitations)))
+}
@@ -0,0 +1,169 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package users
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	totpIssuer    = "Mainflux"
+	totpDigits    = 6
+	totpPeriod    = 30
+	totpSkew      = 1
+	totpSecretLen = 20
+
+	recoveryCodesNum = 10
+	recoveryCodeLen  = 5
+
+	// After totpAttemptsLimit failed second factor attempts in a row, a
+	// single attempt is allowed per totpLockout.
+	totpAttemptsLimit = 5
+	totpLockout       = 5 * time.Minute
+)
+
+var totpEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// TOTP represents the time-based one-time password second factor of the user.
+type TOTP struct {
+	UserID  string
+	Secret  string
+	Enabled bool
+	// LastStep is the time step of the last accepted TOTP code. Codes of
+	// the steps up to and including it are rejected, so they can't be
+	// replayed.
+	LastStep int64
+	// RecoveryCodes contains hashes of the unused recovery codes.
+	RecoveryCodes []string
+	// FailedAttempts is the number of the failed verification attempts
+	// since the last successful one.
+	FailedAttempts int
+	// LockedUntil is the time until which the verification attempts are
+	// rejected once the failed attempts reach the limit.
+	LockedUntil time.Time
+}
+
+// TOTPKey contains the secret of the enrolled second factor, along with
+// the otpauth URI which authenticator apps accept as a QR code.
+type TOTPKey struct {
+	Secret string
+	URI    string
+}
+
+// TOTPRepository specifies a second factor persistence API.
+type TOTPRepository interface {
+	// Save persists the second factor of the user, replacing the existing one.
+	Save(ctx context.Context, t TOTP) error
+
+	// RetrieveByUser retrieves the second factor of the user.
+	RetrieveByUser(ctx context.Context, userID string) (TOTP, error)
+
+	// Remove removes the second factor of the user.
+	Remove(ctx context.Context, userID string) error
+
+	// SaveStep records the time step of the accepted TOTP code of the user.
+	// ErrConflict is returned if the step isn't later than the last
+	// accepted one.
+	SaveStep(ctx context.Context, userID string, step int64) error
+
+	// SaveAttempt counts the verification attempt of the user as failed
+	// until the attempts are reset, and locks the further attempts until
+	// the given time. ErrConflict is returned if the failed attempts reached
+	// the limit and the previous lock hasn't expired yet.
+	SaveAttempt(ctx context.Context, userID string, limit int, lockedUntil time.Time) error
+
+	// ResetAttempts resets the failed verification attempts of the user.
+	ResetAttempts(ctx context.Context, userID string) error
+
+	// RemoveRecoveryCode removes the recovery code hash of the user.
+	// ErrNotFound is returned if the code is already used.
+	RemoveRecoveryCode(ctx context.Context, userID, hash string) error
+
+	// SaveOrgPolicy requires the second factor for all members of the org.
+	SaveOrgPolicy(ctx context.Context, orgID string) error
+
+	// RemoveOrgPolicy removes the second factor requirement of the org.
+	RemoveOrgPolicy(ctx context.Context, orgID string) error
+
+	// RetrieveOrgPolicies retrieves IDs of orgs requiring the second factor.
+	RetrieveOrgPolicies(ctx context.Context) ([]string, error)
+}
+
+func newTOTPKey(email string) (TOTPKey, error) {
+	b := make([]byte, totpSecretLen)
+	if _, err := rand.Read(b); err != nil {
+		return TOTPKey{}, err
+	}
+	secret := totpEncoding.EncodeToString(b)
+
+	label := url.PathEscape(fmt.Sprintf("%s:%s", totpIssuer, email))
+	q := url.Values{}
+	q.Set("secret", secret)
+	q.Set("issuer", totpIssuer)
+	q.Set("digits", fmt.Sprint(totpDigits))
+	q.Set("period", fmt.Sprint(totpPeriod))
+
+	return TOTPKey{
+		Secret: secret,
+		URI:    fmt.Sprintf("otpauth://totp/%s?%s", label, q.Encode()),
+	}, nil
+}
+
+// totpStep returns the time step for which the code is valid for the secret
+// at the given time, tolerating the clock skew of one period in both
+// directions. It reports whether the code is valid.
+func totpStep(secret, code string, t time.Time) (int64, bool) {
+	key, err := totpEncoding.DecodeString(strings.ToUpper(secret))
+	if err != nil || len(code) != totpDigits {
+		return 0, false
+	}
+
+	counter := t.Unix() / totpPeriod
+	for i := int64(-totpSkew); i <= totpSkew; i++ {
+		if subtle.ConstantTimeCompare([]byte(hotp(key, uint64(counter+i))), []byte(code)) == 1 {
+			return counter + i, true
+		}
+	}
+
+	return 0, false
+}
+
+// hotp returns the HMAC-based one-time password as defined by RFC 4226.
+func hotp(key []byte, counter uint64) string {
+	var msg [8]byte
+	binary.BigEndian.PutUint64(msg[:], counter)
+
+	h := hmac.New(sha1.New, key)
+	h.Write(msg[:])
+	sum := h.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	return fmt.Sprintf("%0*d", totpDigits, value%1000000)
+}
+
+func newRecoveryCodes() ([]string, error) {
+	codes := make([]string, recoveryCodesNum)
+	for i := range codes {
+		b := make([]byte, recoveryCodeLen)
+		if _, err := rand.Read(b); err != nil {
+			return nil, err
+		}
+		codes[i] = hex.EncodeToString(b)
+	}
+
+	return codes, nil
+}
@@ -0,0 +1,57 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package tracing
+
+import (
+	"context"
+
+	"github.com/MainfluxLabs/mainflux/users"
+	opentracing "github.com/opentracing/opentracing-go"
+)
+
+const (
+	saveBrandingOp          = "save_branding"
+	retrieveBrandingByOrgOp = "retrieve_branding_by_org"
+	removeBrandingOp        = "remove_branding"
+)
+
+var _ users.BrandingRepository = (*brandingRepositoryMiddleware)(nil)
+
+type brandingRepositoryMiddleware struct {
+	tracer opentracing.Tracer
+	repo   users.BrandingRepository
+}
+
+// BrandingRepositoryMiddleware tracks request and their latency, and adds
+// spans to context.
+func BrandingRepositoryMiddleware(repo users.BrandingRepository, tracer opentracing.Tracer) users.BrandingRepository {
+	return brandingRepositoryMiddleware{
+		tracer: tracer,
+		repo:   repo,
+	}
+}
+
+func (brm brandingRepositoryMiddleware) Save(ctx context.Context, b users.Branding) error {
+	span := createSpan(ctx, brm.tracer, saveBrandingOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return brm.repo.Save(ctx, b)
+}
+
+func (brm brandingRepositoryMiddleware) RetrieveByOrg(ctx context.Context, orgID string) (users.Branding, error) {
+	span := createSpan(ctx, brm.tracer, retrieveBrandingByOrgOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return brm.repo.RetrieveByOrg(ctx, orgID)
+}
+
+func (brm brandingRepositoryMiddleware) Remove(ctx context.Context, orgID string) error {
+	span := createSpan(ctx, brm.tracer, removeBrandingOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return brm.repo.Remove(ctx, orgID)
+}
@@ -0,0 +1,84 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package tracing
+
+import (
+	"context"
+
+	"github.com/MainfluxLabs/mainflux/users"
+	opentracing "github.com/opentracing/opentracing-go"
+)
+
+const (
+	saveInvitationOp            = "save_invitation"
+	retrieveInvitationByIDOp    = "retrieve_invitation_by_id"
+	retrieveInvitationByTokenOp = "retrieve_invitation_by_token"
+	retrieveAllInvitationsOp    = "retrieve_all_invitations"
+	updateInvitationTokenOp     = "update_invitation_token"
+	removeInvitationOp          = "remove_invitation"
+)
+
+var _ users.InvitationRepository = (*invitationRepositoryMiddleware)(nil)
+
+type invitationRepositoryMiddleware struct {
+	tracer opentracing.Tracer
+	repo   users.InvitationRepository
+}
+
+// InvitationRepositoryMiddleware tracks request and their latency, and adds spans
+// to context.
+func InvitationRepositoryMiddleware(repo users.InvitationRepository, tracer opentracing.Tracer) users.InvitationRepository {
+	return invitationRepositoryMiddleware{
+		tracer: tracer,
+		repo:   repo,
+	}
+}
+
+func (irm invitationRepositoryMiddleware) Save(ctx context.Context, inv users.Invitation) error {
+	span := createSpan(ctx, irm.tracer, saveInvitationOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return irm.repo.Save(ctx, inv)
+}
+
+func (irm invitationRepositoryMiddleware) RetrieveByID(ctx context.Context, id string) (users.Invitation, error) {
+	span := createSpan(ctx, irm.tracer, retrieveInvitationByIDOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return irm.repo.RetrieveByID(ctx, id)
+}
+
+func (irm invitationRepositoryMiddleware) RetrieveByToken(ctx context.Context, tokenHash string) (users.Invitation, error) {
+	span := createSpan(ctx, irm.tracer, retrieveInvitationByTokenOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return irm.repo.RetrieveByToken(ctx, tokenHash)
+}
+
+func (irm invitationRepositoryMiddleware) RetrieveAll(ctx context.Context, invitedBy string, pm users.PageMetadata) (users.InvitationPage, error) {
+	span := createSpan(ctx, irm.tracer, retrieveAllInvitationsOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return irm.repo.RetrieveAll(ctx, invitedBy, pm)
+}
+
+func (irm invitationRepositoryMiddleware) UpdateToken(ctx context.Context, inv users.Invitation) error {
+	span := createSpan(ctx, irm.tracer, updateInvitationTokenOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return irm.repo.UpdateToken(ctx, inv)
+}
+
+func (irm invitationRepositoryMiddleware) Remove(ctx context.Context, id string) error {
+	span := createSpan(ctx, irm.tracer, removeInvitationOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return irm.repo.Remove(ctx, id)
+}
@@ -0,0 +1,121 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package tracing
+
+import (
+	"context"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/users"
+	opentracing "github.com/opentracing/opentracing-go"
+)
+
+const (
+	saveTOTPOp            = "save_totp"
+	retrieveTOTPByUserOp  = "retrieve_totp_by_user"
+	removeTOTPOp          = "remove_totp"
+	saveTOTPStepOp        = "save_totp_step"
+	saveTOTPAttemptOp     = "save_totp_attempt"
+	resetTOTPAttemptsOp   = "reset_totp_attempts"
+	removeRecoveryCodeOp  = "remove_recovery_code"
+	saveOrgPolicyOp       = "save_org_policy"
+	removeOrgPolicyOp     = "remove_org_policy"
+	retrieveOrgPoliciesOp = "retrieve_org_policies"
+)
+
+var _ users.TOTPRepository = (*totpRepositoryMiddleware)(nil)
+
+type totpRepositoryMiddleware struct {
+	tracer opentracing.Tracer
+	repo   users.TOTPRepository
+}
+
+// TOTPRepositoryMiddleware tracks request and their latency, and adds spans
+// to context.
+func TOTPRepositoryMiddleware(repo users.TOTPRepository, tracer opentracing.Tracer) users.TOTPRepository {
+	return totpRepositoryMiddleware{
+		tracer: tracer,
+		repo:   repo,
+	}
+}
+
+func (trm totpRepositoryMiddleware) Save(ctx context.Context, t users.TOTP) error {
+	span := createSpan(ctx, trm.tracer, saveTOTPOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return trm.repo.Save(ctx, t)
+}
+
+func (trm totpRepositoryMiddleware) RetrieveByUser(ctx context.Context, userID string) (users.TOTP, error) {
+	span := createSpan(ctx, trm.tracer, retrieveTOTPByUserOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return trm.repo.RetrieveByUser(ctx, userID)
+}
+
+func (trm totpRepositoryMiddleware) Remove(ctx context.Context, userID string) error {
+	span := createSpan(ctx, trm.tracer, removeTOTPOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return trm.repo.Remove(ctx, userID)
+}
+
+func (trm totpRepositoryMiddleware) SaveStep(ctx context.Context, userID string, step int64) error {
+	span := createSpan(ctx, trm.tracer, saveTOTPStepOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return trm.repo.SaveStep(ctx, userID, step)
+}
+
+func (trm totpRepositoryMiddleware) SaveAttempt(ctx context.Context, userID string, limit int, lockedUntil time.Time) error {
+	span := createSpan(ctx, trm.tracer, saveTOTPAttemptOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return trm.repo.SaveAttempt(ctx, userID, limit, lockedUntil)
+}
+
+func (trm totpRepositoryMiddleware) ResetAttempts(ctx context.Context, userID string) error {
+	span := createSpan(ctx, trm.tracer, resetTOTPAttemptsOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return trm.repo.ResetAttempts(ctx, userID)
+}
+
+func (trm totpRepositoryMiddleware) RemoveRecoveryCode(ctx context.Context, userID, hash string) error {
+	span := createSpan(ctx, trm.tracer, removeRecoveryCodeOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return trm.repo.RemoveRecoveryCode(ctx, userID, hash)
+}
+
+func (trm totpRepositoryMiddleware) SaveOrgPolicy(ctx context.Context, orgID string) error {
+	span := createSpan(ctx, trm.tracer, saveOrgPolicyOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return trm.repo.SaveOrgPolicy(ctx, orgID)
+}
+
+func (trm totpRepositoryMiddleware) RemoveOrgPolicy(ctx context.Context, orgID string) error {
+	span := createSpan(ctx, trm.tracer, removeOrgPolicyOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return trm.repo.RemoveOrgPolicy(ctx, orgID)
+}
+
+func (trm totpRepositoryMiddleware) RetrieveOrgPolicies(ctx context.Context) ([]string, error) {
+	span := createSpan(ctx, trm.tracer, retrieveOrgPoliciesOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return trm.repo.RetrieveOrgPolicies(ctx)
+}
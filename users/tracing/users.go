@@ -21,6 +21,7 @@ const (
 	retrieveAllOp     = "retrieve_all"
 	updatePasswordOp  = "update_password"
 	changeStatusOp    = "change_status"
+	removeOp          = "remove"
 )
 
 var _ users.UserRepository = (*userRepositoryMiddleware)(nil)
@@ -103,6 +104,14 @@ func (urm userRepositoryMiddleware) ChangeStatus(ctx context.Context, id, status
 	return urm.repo.ChangeStatus(ctx, id, status)
 }
 
+func (urm userRepositoryMiddleware) Remove(ctx context.Context, id string) error {
+	span := createSpan(ctx, urm.tracer, removeOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return urm.repo.Remove(ctx, id)
+}
+
 func createSpan(ctx context.Context, tracer opentracing.Tracer, opName string) opentracing.Span {
 	if parentSpan := opentracing.SpanFromContext(ctx); parentSpan != nil {
 		return tracer.StartSpan(
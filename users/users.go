@@ -77,6 +77,9 @@ type UserRepository interface {
 
 	// RetrieveAll retrieves all users.
 	RetrieveAll(ctx context.Context) ([]User, error)
+
+	// Remove removes the user with the given ID.
+	Remove(ctx context.Context, id string) error
 }
 
 func isEmail(email string) bool {
@@ -13,6 +13,7 @@ import (
 	"github.com/MainfluxLabs/mainflux"
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
 	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+	"github.com/MainfluxLabs/mainflux/pkg/signature"
 )
 
 const (
@@ -60,15 +61,17 @@ type Service interface {
 var _ Service = (*adapterService)(nil)
 
 type adapterService struct {
-	things mainflux.ThingsServiceClient
-	pubsub messaging.PubSub
+	things   mainflux.ThingsServiceClient
+	pubsub   messaging.PubSub
+	verifier signature.Verifier
 }
 
 // New instantiates the WS adapter implementation
-func New(things mainflux.ThingsServiceClient, pubsub messaging.PubSub) Service {
+func New(things mainflux.ThingsServiceClient, pubsub messaging.PubSub, verifier signature.Verifier) Service {
 	return &adapterService{
-		things: things,
-		pubsub: pubsub,
+		things:   things,
+		pubsub:   pubsub,
+		verifier: verifier,
 	}
 }
 
@@ -85,6 +88,18 @@ func (svc *adapterService) Publish(ctx context.Context, thingKey string, msg mes
 
 	msg.Publisher = thid.GetValue()
 
+	payload, err := svc.verifier.Verify(ctx, msg)
+	if err != nil {
+		return err
+	}
+	msg.Payload = payload
+
+	id, err := messaging.NewID()
+	if err != nil {
+		return ErrFailedMessagePublish
+	}
+	msg.Id = id
+
 	if err := svc.pubsub.Publish(msg.GetChannel(), msg); err != nil {
 		return ErrFailedMessagePublish
 	}
@@ -103,7 +118,7 @@ func (svc *adapterService) Subscribe(ctx context.Context, thingKey, chanID, subt
 		return ErrUnauthorizedAccess
 	}
 
-	c.id = thid.GetValue()
+	c.setID(thid.GetValue())
 
 	subject := fmt.Sprintf("%s.%s", chansPrefix, chanID)
 	if subtopic != "" {
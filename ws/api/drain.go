@@ -0,0 +1,47 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+
+	"github.com/MainfluxLabs/mainflux/pkg/drain"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+	"github.com/MainfluxLabs/mainflux/ws"
+)
+
+var _ ws.Service = (*drainMiddleware)(nil)
+
+type drainMiddleware struct {
+	drainer drain.Drainer
+	svc     ws.Service
+}
+
+// DrainMiddleware rejects new subscriptions once the drain is started
+// and tracks in-flight publishes.
+func DrainMiddleware(svc ws.Service, drainer drain.Drainer) ws.Service {
+	return &drainMiddleware{
+		drainer: drainer,
+		svc:     svc,
+	}
+}
+
+func (dm *drainMiddleware) Publish(ctx context.Context, thingKey string, msg messaging.Message) error {
+	done := dm.drainer.Track()
+	defer done()
+
+	return dm.svc.Publish(ctx, thingKey, msg)
+}
+
+func (dm *drainMiddleware) Subscribe(ctx context.Context, thingKey, chanID, subtopic string, c *ws.Client) error {
+	if dm.drainer.Draining() {
+		return drain.ErrDraining
+	}
+
+	return dm.svc.Subscribe(ctx, thingKey, chanID, subtopic, c)
+}
+
+func (dm *drainMiddleware) Unsubscribe(ctx context.Context, thingKey, chanID, subtopic string) error {
+	return dm.svc.Unsubscribe(ctx, thingKey, chanID, subtopic)
+}
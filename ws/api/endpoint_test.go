@@ -4,20 +4,25 @@
 package api_test
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"os"
 	"testing"
 
 	"github.com/MainfluxLabs/mainflux"
+	"github.com/MainfluxLabs/mainflux/internal/apiutil"
 	log "github.com/MainfluxLabs/mainflux/logger"
 	thmocks "github.com/MainfluxLabs/mainflux/pkg/mocks"
+	"github.com/MainfluxLabs/mainflux/pkg/signature"
 	"github.com/MainfluxLabs/mainflux/ws"
 	"github.com/MainfluxLabs/mainflux/ws/api"
 	"github.com/MainfluxLabs/mainflux/ws/mocks"
 	"github.com/gorilla/websocket"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 const (
@@ -31,7 +36,7 @@ var msg = []byte(`[{"n":"current","t":-1,"v":1.6}]`)
 
 func newService(tc mainflux.ThingsServiceClient) (ws.Service, mocks.MockPubSub) {
 	pubsub := mocks.NewPubSub()
-	return ws.New(tc, pubsub), pubsub
+	return ws.New(tc, pubsub, signature.NewVerifier(tc, 0)), pubsub
 }
 
 func newHTTPServer(svc ws.Service) *httptest.Server {
@@ -74,11 +79,21 @@ func handshake(tsURL, chanID, subtopic, thingKey string, addHeader bool) (*webso
 	return conn, res, errRet
 }
 
-func TestHandshake(t *testing.T) {
-	thingsClient := thmocks.NewThingsServiceClient(map[string]string{thingKey: chanID},nil)
+// ts is shared by the tests, since the handler keeps the logger
+// in the package variable which is set on its creation.
+var ts *httptest.Server
+
+func TestMain(m *testing.M) {
+	thingsClient := thmocks.NewThingsServiceClient(map[string]string{thingKey: chanID}, nil)
 	svc, _ := newService(thingsClient)
-	ts := newHTTPServer(svc)
-	defer ts.Close()
+	ts = newHTTPServer(svc)
+
+	code := m.Run()
+	ts.Close()
+	os.Exit(code)
+}
+
+func TestHandshake(t *testing.T) {
 
 	cases := []struct {
 		desc     string
@@ -182,6 +197,123 @@ func TestHandshake(t *testing.T) {
 
 			err = conn.WriteMessage(websocket.TextMessage, tc.msg)
 			assert.Nil(t, err, fmt.Sprintf("%s: got unexpected error %s\n", tc.desc, err))
+			continue
 		}
+
+		var errRes apiutil.ErrorRes
+		err = json.NewDecoder(res.Body).Decode(&errRes)
+		assert.Nil(t, err, fmt.Sprintf("%s: got unexpected error decoding error response %s\n", tc.desc, err))
+		assert.NotEmpty(t, errRes.Code, fmt.Sprintf("%s: expected error code in response\n", tc.desc))
+		assert.NotEmpty(t, errRes.Message, fmt.Sprintf("%s: expected error message in response\n", tc.desc))
+		traceID := res.Header.Get(apiutil.RequestIDHeader)
+		assert.Equal(t, traceID, errRes.TraceID, fmt.Sprintf("%s: expected trace ID '%s' got '%s'\n", tc.desc, traceID, errRes.TraceID))
+	}
+}
+
+func TestMuxHandshake(t *testing.T) {
+	u, _ := url.Parse(ts.URL)
+	u.Scheme = protocol
+
+	_, res, err := websocket.DefaultDialer.Dial(fmt.Sprintf("%s/messages", u), http.Header{})
+	assert.NotNil(t, err, "connect without thing key: expected error")
+	assert.Equal(t, http.StatusForbidden, res.StatusCode, fmt.Sprintf("connect without thing key: expected status code '%d' got '%d'\n", http.StatusForbidden, res.StatusCode))
+
+	conn, res, err := websocket.DefaultDialer.Dial(fmt.Sprintf("%s/messages?authorization=%s", u, thingKey), http.Header{})
+	require.Nil(t, err, fmt.Sprintf("connect with thing key: got unexpected error %s\n", err))
+	assert.Equal(t, http.StatusSwitchingProtocols, res.StatusCode, fmt.Sprintf("connect with thing key: expected status code '%d' got '%d'\n", http.StatusSwitchingProtocols, res.StatusCode))
+	defer conn.Close()
+
+	unauthConn, _, err := websocket.DefaultDialer.Dial(fmt.Sprintf("%s/messages?authorization=%s", u, "token"), http.Header{})
+	require.Nil(t, err, fmt.Sprintf("connect with unauthorized thing key: got unexpected error %s\n", err))
+	defer unauthConn.Close()
+
+	cases := []struct {
+		desc  string
+		conn  *websocket.Conn
+		frame interface{}
+		res   ws.Frame
+	}{
+		{
+			desc:  "subscribe to channel",
+			conn:  conn,
+			frame: ws.Frame{Type: ws.SubscribeFrame, ID: "1", Channel: chanID},
+			res:   ws.Frame{Type: ws.AckFrame, ID: "1"},
+		},
+		{
+			desc:  "subscribe to already subscribed channel",
+			conn:  conn,
+			frame: ws.Frame{Type: ws.SubscribeFrame, ID: "2", Channel: chanID},
+			res:   ws.Frame{Type: ws.AckFrame, ID: "2"},
+		},
+		{
+			desc:  "subscribe to channel subtopic",
+			conn:  conn,
+			frame: ws.Frame{Type: ws.SubscribeFrame, ID: "3", Channel: chanID, Subtopic: "subtopic/nested"},
+			res:   ws.Frame{Type: ws.AckFrame, ID: "3"},
+		},
+		{
+			desc:  "subscribe to another channel",
+			conn:  conn,
+			frame: ws.Frame{Type: ws.SubscribeFrame, ID: "4", Channel: id},
+			res:   ws.Frame{Type: ws.AckFrame, ID: "4"},
+		},
+		{
+			desc:  "subscribe to channel without authorization",
+			conn:  unauthConn,
+			frame: ws.Frame{Type: ws.SubscribeFrame, ID: "5", Channel: chanID},
+			res:   ws.Frame{Type: ws.ErrorFrame, ID: "5", Error: ws.ErrUnauthorizedAccess.Error()},
+		},
+		{
+			desc:  "subscribe to empty channel",
+			conn:  conn,
+			frame: ws.Frame{Type: ws.SubscribeFrame, ID: "6"},
+			res:   ws.Frame{Type: ws.ErrorFrame, ID: "6", Error: ws.ErrEmptyID.Error()},
+		},
+		{
+			desc:  "subscribe to subtopic with invalid name",
+			conn:  conn,
+			frame: ws.Frame{Type: ws.SubscribeFrame, ID: "7", Channel: chanID, Subtopic: "sub/a*b/topic"},
+			res:   ws.Frame{Type: ws.ErrorFrame, ID: "7", Error: "malformed subtopic"},
+		},
+		{
+			desc:  "publish to channel",
+			conn:  conn,
+			frame: ws.Frame{Type: ws.PublishFrame, ID: "8", Channel: chanID, Payload: string(msg)},
+			res:   ws.Frame{Type: ws.AckFrame, ID: "8"},
+		},
+		{
+			desc:  "unsubscribe from channel",
+			conn:  conn,
+			frame: ws.Frame{Type: ws.UnsubscribeFrame, ID: "9", Channel: chanID},
+			res:   ws.Frame{Type: ws.AckFrame, ID: "9"},
+		},
+		{
+			desc:  "unsubscribe from not subscribed channel",
+			conn:  conn,
+			frame: ws.Frame{Type: ws.UnsubscribeFrame, ID: "10", Channel: chanID},
+			res:   ws.Frame{Type: ws.ErrorFrame, ID: "10", Error: "not subscribed to the channel"},
+		},
+		{
+			desc:  "send frame of unknown type",
+			conn:  conn,
+			frame: ws.Frame{Type: "unknown", ID: "11", Channel: chanID},
+			res:   ws.Frame{Type: ws.ErrorFrame, ID: "11", Error: "unknown frame type"},
+		},
+		{
+			desc:  "send malformed frame",
+			conn:  conn,
+			frame: "malformed",
+			res:   ws.Frame{Type: ws.ErrorFrame, Error: "malformed frame"},
+		},
+	}
+
+	for _, tc := range cases {
+		err := tc.conn.WriteJSON(tc.frame)
+		require.Nil(t, err, fmt.Sprintf("%s: got unexpected error %s\n", tc.desc, err))
+
+		var res ws.Frame
+		err = tc.conn.ReadJSON(&res)
+		assert.Nil(t, err, fmt.Sprintf("%s: got unexpected error %s\n", tc.desc, err))
+		assert.Equal(t, tc.res, res, fmt.Sprintf("%s: expected frame %+v got %+v\n", tc.desc, tc.res, res))
 	}
 }
@@ -5,6 +5,7 @@ package api
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -13,6 +14,7 @@ import (
 	"time"
 
 	"github.com/MainfluxLabs/mainflux/internal/apiutil"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
 	"github.com/MainfluxLabs/mainflux/pkg/messaging"
 	"github.com/MainfluxLabs/mainflux/ws"
 	"github.com/go-zoo/bone"
@@ -25,7 +27,7 @@ func handshake(svc ws.Service) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		req, err := decodeRequest(r)
 		if err != nil {
-			encodeError(w, err)
+			encodeError(r.Context(), w, err)
 			return
 		}
 		conn, err := upgrader.Upgrade(w, r, nil)
@@ -51,14 +53,9 @@ func handshake(svc ws.Service) http.HandlerFunc {
 }
 
 func decodeRequest(r *http.Request) (connReq, error) {
-	authKey := r.Header.Get("Authorization")
-	if authKey == "" {
-		authKeys := bone.GetQuery(r, "authorization")
-		if len(authKeys) == 0 {
-			logger.Debug("Missing authorization key.")
-			return connReq{}, errUnauthorizedAccess
-		}
-		authKey = authKeys[0]
+	authKey, err := readAuthKey(r)
+	if err != nil {
+		return connReq{}, err
 	}
 
 	chanID := bone.GetValue(r, "id")
@@ -84,6 +81,22 @@ func decodeRequest(r *http.Request) (connReq, error) {
 	return req, nil
 }
 
+// readAuthKey reads the thing key from the Authorization header,
+// or from the authorization query parameter if the header is not set.
+func readAuthKey(r *http.Request) (string, error) {
+	authKey := r.Header.Get("Authorization")
+	if authKey == "" {
+		authKeys := bone.GetQuery(r, "authorization")
+		if len(authKeys) == 0 {
+			logger.Debug("Missing authorization key.")
+			return "", errUnauthorizedAccess
+		}
+		authKey = authKeys[0]
+	}
+
+	return authKey, nil
+}
+
 func parseSubTopic(subtopic string) (string, error) {
 	if subtopic == "" {
 		return subtopic, nil
@@ -152,19 +165,25 @@ func process(svc ws.Service, req connReq, msgs <-chan []byte) {
 	}
 }
 
-func encodeError(w http.ResponseWriter, err error) {
-	statusCode := http.StatusUnauthorized
+func encodeError(ctx context.Context, w http.ResponseWriter, err error) {
+	logger.Warn(fmt.Sprintf("Failed to authorize: %s", err.Error()))
 
-	switch err {
-	case ws.ErrEmptyID, ws.ErrEmptyTopic:
-		statusCode = http.StatusBadRequest
-	case errUnauthorizedAccess:
-		statusCode = http.StatusForbidden
-	case errMalformedSubtopic, apiutil.ErrMalformedEntity:
-		statusCode = http.StatusBadRequest
+	w.Header().Set("Content-Type", contentType)
+	switch {
+	case errors.Contains(err, ws.ErrEmptyID),
+		errors.Contains(err, ws.ErrEmptyTopic),
+		errors.Contains(err, errMalformedSubtopic),
+		errors.Contains(err, apiutil.ErrMalformedEntity):
+		w.WriteHeader(http.StatusBadRequest)
+	case errors.Contains(err, errUnauthorizedAccess):
+		w.WriteHeader(http.StatusForbidden)
 	default:
-		statusCode = http.StatusNotFound
+		w.WriteHeader(http.StatusNotFound)
+	}
+
+	if errorVal, ok := err.(errors.Error); ok {
+		if err := json.NewEncoder(w).Encode(apiutil.NewErrorRes(ctx, errorVal)); err != nil {
+			logger.Warn(fmt.Sprintf("Failed to encode error response: %s", err.Error()))
+		}
 	}
-	logger.Warn(fmt.Sprintf("Failed to authorize: %s", err.Error()))
-	w.WriteHeader(statusCode)
 }
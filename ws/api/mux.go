@@ -0,0 +1,164 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+	"github.com/MainfluxLabs/mainflux/ws"
+	"github.com/gorilla/websocket"
+)
+
+// maxSubscriptions is the maximum number of subscriptions
+// of the single multiplexed connection.
+const maxSubscriptions = 100
+
+type subscription struct {
+	chanID   string
+	subtopic string
+}
+
+// muxConn is the multiplexed connection which manages many channel
+// subscriptions using the control frames. Each subscription is authorized
+// separately, using the thing key provided during the handshake.
+type muxConn struct {
+	svc      ws.Service
+	thingKey string
+	conn     *websocket.Conn
+	client   *ws.Client
+	subs     map[subscription]struct{}
+}
+
+func muxHandshake(svc ws.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		thingKey, err := readAuthKey(r)
+		if err != nil {
+			encodeError(r.Context(), w, err)
+			return
+		}
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			logger.Warn(fmt.Sprintf("Failed to upgrade connection to websocket: %s", err.Error()))
+			return
+		}
+
+		mc := &muxConn{
+			svc:      svc,
+			thingKey: thingKey,
+			conn:     conn,
+			client:   ws.NewMuxClient(conn),
+			subs:     make(map[subscription]struct{}),
+		}
+
+		logger.Debug("Successfully upgraded communication to multiplexed WS")
+		go mc.serve()
+	}
+}
+
+// serve processes the control frames until the connection is closed,
+// and then removes all its subscriptions.
+func (mc *muxConn) serve() {
+	defer mc.close()
+
+	for {
+		_, data, err := mc.conn.ReadMessage()
+		if err != nil {
+			if websocket.IsUnexpectedCloseError(err) {
+				logger.Debug(fmt.Sprintf("Closing WS connection: %s", err.Error()))
+				return
+			}
+			logger.Warn(fmt.Sprintf("Failed to read message: %s", err.Error()))
+			return
+		}
+
+		var f ws.Frame
+		err = errMalformedFrame
+		if json.Unmarshal(data, &f) == nil {
+			err = mc.process(f)
+		}
+
+		res := ws.Frame{Type: ws.AckFrame, ID: f.ID}
+		if err != nil {
+			res = ws.Frame{Type: ws.ErrorFrame, ID: f.ID, Error: err.Error()}
+		}
+		if err := mc.client.WriteFrame(res); err != nil {
+			logger.Warn(fmt.Sprintf("Failed to write frame: %s", err.Error()))
+			return
+		}
+	}
+}
+
+func (mc *muxConn) process(f ws.Frame) error {
+	if f.Channel == "" {
+		return ws.ErrEmptyID
+	}
+
+	subtopic, err := parseSubTopic(f.Subtopic)
+	if err != nil {
+		return err
+	}
+	sub := subscription{chanID: f.Channel, subtopic: subtopic}
+
+	switch f.Type {
+	case ws.SubscribeFrame:
+		return mc.subscribe(sub)
+	case ws.UnsubscribeFrame:
+		return mc.unsubscribe(sub)
+	case ws.PublishFrame:
+		m := messaging.Message{
+			Channel:  sub.chanID,
+			Subtopic: sub.subtopic,
+			Protocol: "websocket",
+			Payload:  []byte(f.Payload),
+			Created:  time.Now().UnixNano(),
+		}
+		return mc.svc.Publish(context.Background(), mc.thingKey, m)
+	default:
+		return errUnknownFrame
+	}
+}
+
+func (mc *muxConn) subscribe(sub subscription) error {
+	if _, ok := mc.subs[sub]; ok {
+		return nil
+	}
+
+	if len(mc.subs) >= maxSubscriptions {
+		return errSubscriptionsLimit
+	}
+
+	if err := mc.svc.Subscribe(context.Background(), mc.thingKey, sub.chanID, sub.subtopic, mc.client); err != nil {
+		return err
+	}
+	mc.subs[sub] = struct{}{}
+
+	return nil
+}
+
+func (mc *muxConn) unsubscribe(sub subscription) error {
+	if _, ok := mc.subs[sub]; !ok {
+		return errNotSubscribed
+	}
+
+	if err := mc.svc.Unsubscribe(context.Background(), mc.thingKey, sub.chanID, sub.subtopic); err != nil {
+		return err
+	}
+	delete(mc.subs, sub)
+
+	return nil
+}
+
+func (mc *muxConn) close() {
+	for sub := range mc.subs {
+		if err := mc.svc.Unsubscribe(context.Background(), mc.thingKey, sub.chanID, sub.subtopic); err != nil {
+			logger.Warn(fmt.Sprintf("Failed to unsubscribe from channel %s: %s", sub.chanID, err.Error()))
+		}
+	}
+	mc.conn.Close()
+}
@@ -4,25 +4,31 @@
 package api
 
 import (
-	"errors"
 	"net/http"
 
-	"github.com/go-zoo/bone"
-	"github.com/gorilla/websocket"
 	"github.com/MainfluxLabs/mainflux"
+	"github.com/MainfluxLabs/mainflux/internal/apiutil"
 	log "github.com/MainfluxLabs/mainflux/logger"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
 	"github.com/MainfluxLabs/mainflux/ws"
+	"github.com/go-zoo/bone"
+	"github.com/gorilla/websocket"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
 const (
 	protocol            = "ws"
 	readwriteBufferSize = 1024
+	contentType         = "application/json"
 )
 
 var (
 	errUnauthorizedAccess = errors.New("missing or invalid credentials provided")
 	errMalformedSubtopic  = errors.New("malformed subtopic")
+	errMalformedFrame     = errors.New("malformed frame")
+	errUnknownFrame       = errors.New("unknown frame type")
+	errNotSubscribed      = errors.New("not subscribed to the channel")
+	errSubscriptionsLimit = errors.New("subscriptions limit reached")
 )
 
 var (
@@ -35,14 +41,17 @@ var (
 )
 
 // MakeHandler returns http handler with handshake endpoint.
-func MakeHandler(svc ws.Service, l log.Logger) http.Handler {
+func MakeHandler(svc ws.Service, l log.Logger, checks ...mainflux.HealthCheck) http.Handler {
 	logger = l
 
 	mux := bone.New()
 	mux.GetFunc("/channels/:id/messages", handshake(svc))
 	mux.GetFunc("/channels/:id/messages/*", handshake(svc))
+	mux.GetFunc("/messages", muxHandshake(svc))
 	mux.GetFunc("/version", mainflux.Health(protocol))
+	mux.GetFunc("/health", mainflux.Health(protocol, checks...))
 	mux.Handle("/metrics", promhttp.Handler())
+	mux.Handle("/log-level", mainflux.LogLevel(l))
 
-	return mux
+	return apiutil.RequestIDMiddleware(apiutil.LocaleMiddleware(mux))
 }
@@ -4,14 +4,18 @@
 package ws
 
 import (
-	"github.com/gorilla/websocket"
+	"sync"
+
 	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+	"github.com/gorilla/websocket"
 )
 
 // Client handles messaging and websocket connection
 type Client struct {
 	conn *websocket.Conn
 	id   string
+	mux  bool
+	mu   sync.Mutex
 }
 
 // NewClient returns a new Client object
@@ -22,6 +26,17 @@ func NewClient(c *websocket.Conn) *Client {
 	}
 }
 
+// NewMuxClient returns a new Client object which receives messages of
+// many subscriptions over the single connection. Messages are wrapped
+// in the message frames carrying their channel and subtopic.
+func NewMuxClient(c *websocket.Conn) *Client {
+	return &Client{
+		conn: c,
+		id:   "",
+		mux:  true,
+	}
+}
+
 // Cancel handles the websocket connection after unsubscribing
 func (c *Client) Cancel() error {
 	if c.conn == nil {
@@ -32,9 +47,39 @@ func (c *Client) Cancel() error {
 
 // Handle handles the sending and receiving of messages via the broker
 func (c *Client) Handle(msg messaging.Message) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
 	// To prevent publisher from receiving its own published message
 	if msg.GetPublisher() == c.id {
 		return nil
 	}
+
+	if c.mux {
+		f := Frame{
+			Type:     MessageFrame,
+			Channel:  msg.GetChannel(),
+			Subtopic: msg.GetSubtopic(),
+			Payload:  string(msg.GetPayload()),
+		}
+		return c.conn.WriteJSON(f)
+	}
+
 	return c.conn.WriteMessage(websocket.TextMessage, msg.Payload)
 }
+
+// WriteFrame writes the frame to the connection. Writes are serialized
+// with the delivery of the subscribed messages.
+func (c *Client) WriteFrame(f Frame) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.conn.WriteJSON(f)
+}
+
+func (c *Client) setID(id string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.id = id
+}
@@ -4,6 +4,7 @@
 package ws_test
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -99,3 +100,43 @@ func TestHandle(t *testing.T) {
 	c := atomic.LoadUint64(&count)
 	assert.Equal(t, expectedCount, c, fmt.Sprintf("expected message count %d, got %d", expectedCount, c))
 }
+
+func TestHandleMux(t *testing.T) {
+	frames := make(chan []byte)
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		for {
+			_, message, err := conn.ReadMessage()
+			if err != nil {
+				break
+			}
+			frames <- message
+		}
+	}))
+	defer s.Close()
+
+	u := strings.Replace(s.URL, "http", "ws", 1)
+	wsConn, _, err := websocket.DefaultDialer.Dial(u, nil)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	defer wsConn.Close()
+
+	c := ws.NewMuxClient(wsConn)
+	m := msg
+	m.Publisher = "publisher"
+	m.Subtopic = subTopic
+
+	err = c.Handle(m)
+	assert.Nil(t, err, fmt.Sprintf("expected nil error from handle, got: %s", err))
+
+	var f ws.Frame
+	err = json.Unmarshal(<-frames, &f)
+	assert.Nil(t, err, fmt.Sprintf("expected message frame, got: %s", err))
+	expected := ws.Frame{Type: ws.MessageFrame, Channel: m.Channel, Subtopic: m.Subtopic, Payload: string(m.Payload)}
+	assert.Equal(t, expected, f, fmt.Sprintf("expected %+v, got %+v", expected, f))
+}
@@ -0,0 +1,41 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package ws
+
+const (
+	// SubscribeFrame is the type of the frame subscribing the multiplexed
+	// client to the channel.
+	SubscribeFrame = "subscribe"
+
+	// UnsubscribeFrame is the type of the frame unsubscribing the multiplexed
+	// client from the channel.
+	UnsubscribeFrame = "unsubscribe"
+
+	// PublishFrame is the type of the frame publishing the message to the channel.
+	PublishFrame = "publish"
+
+	// MessageFrame is the type of the frame delivering the message received
+	// on the subscribed channel.
+	MessageFrame = "message"
+
+	// AckFrame is the type of the frame acknowledging the successfully
+	// processed control frame.
+	AckFrame = "ack"
+
+	// ErrorFrame is the type of the frame reporting the failure of the
+	// control frame processing.
+	ErrorFrame = "error"
+)
+
+// Frame represents the frame exchanged over the multiplexed connection.
+// Control frames may carry the ID, which is sent back in the ack or the
+// error frame.
+type Frame struct {
+	Type     string `json:"type"`
+	ID       string `json:"id,omitempty"`
+	Channel  string `json:"channel,omitempty"`
+	Subtopic string `json:"subtopic,omitempty"`
+	Payload  string `json:"payload,omitempty"`
+	Error    string `json:"error,omitempty"`
+}